@@ -0,0 +1,221 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// AssetStatus is the status of an asset.
+type AssetStatus uint8
+
+const (
+	// AssetLive assets can be used normally.
+	AssetLive AssetStatus = iota
+	// AssetFrozen assets can not be transferred.
+	AssetFrozen
+	// AssetDestroying assets are being destroyed.
+	AssetDestroying
+)
+
+var assetStatusNames = []string{"Live", "Frozen", "Destroying"}
+
+func (s AssetStatus) String() string {
+	if int(s) < len(assetStatusNames) {
+		return assetStatusNames[s]
+	}
+
+	return fmt.Sprintf("AssetStatus(%d)", uint8(s))
+}
+
+func (s *AssetStatus) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	if int(b) >= len(assetStatusNames) {
+		return fmt.Errorf("invalid asset status %d", b)
+	}
+
+	*s = AssetStatus(b)
+
+	return nil
+}
+
+func (s AssetStatus) Encode(encoder scale.Encoder) error {
+	return encoder.PushByte(byte(s))
+}
+
+// AccountStatus is the status of the balance of an account.
+type AccountStatus uint8
+
+const (
+	// AccountLiquid balances can be transferred.
+	AccountLiquid AccountStatus = iota
+	// AccountFrozen balances can not be transferred, but the account can receive funds.
+	AccountFrozen
+	// AccountBlocked balances can neither be transferred nor receive funds.
+	AccountBlocked
+)
+
+var accountStatusNames = []string{"Liquid", "Frozen", "Blocked"}
+
+func (s AccountStatus) String() string {
+	if int(s) < len(accountStatusNames) {
+		return accountStatusNames[s]
+	}
+
+	return fmt.Sprintf("AccountStatus(%d)", uint8(s))
+}
+
+func (s *AccountStatus) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	if int(b) >= len(accountStatusNames) {
+		return fmt.Errorf("invalid account status %d", b)
+	}
+
+	*s = AccountStatus(b)
+
+	return nil
+}
+
+func (s AccountStatus) Encode(encoder scale.Encoder) error {
+	return encoder.PushByte(byte(s))
+}
+
+// DepositFrom is the deposit that was paid by another account for the existence of an account.
+type DepositFrom struct {
+	Depositor types.AccountID
+	Deposit   types.U128
+}
+
+// ExistenceReason is the reason an account holding an asset exists.
+type ExistenceReason struct {
+	IsConsumer        bool
+	IsSufficient      bool
+	IsDepositHeld     bool
+	AsDepositHeld     types.U128
+	IsDepositRefunded bool
+	IsDepositFrom     bool
+	AsDepositFrom     DepositFrom
+}
+
+func (r *ExistenceReason) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		r.IsConsumer = true
+	case 1:
+		r.IsSufficient = true
+	case 2:
+		r.IsDepositHeld = true
+
+		return decoder.Decode(&r.AsDepositHeld)
+	case 3:
+		r.IsDepositRefunded = true
+	case 4:
+		r.IsDepositFrom = true
+
+		return decoder.Decode(&r.AsDepositFrom)
+	default:
+		return fmt.Errorf("invalid existence reason variant %d", b)
+	}
+
+	return nil
+}
+
+func (r ExistenceReason) Encode(encoder scale.Encoder) error {
+	switch {
+	case r.IsConsumer:
+		return encoder.PushByte(0)
+	case r.IsSufficient:
+		return encoder.PushByte(1)
+	case r.IsDepositHeld:
+		if err := encoder.PushByte(2); err != nil {
+			return err
+		}
+
+		return encoder.Encode(r.AsDepositHeld)
+	case r.IsDepositRefunded:
+		return encoder.PushByte(3)
+	case r.IsDepositFrom:
+		if err := encoder.PushByte(4); err != nil {
+			return err
+		}
+
+		return encoder.Encode(r.AsDepositFrom)
+	}
+
+	return nil
+}
+
+// AssetDetails are the details of an asset as stored in Asset. Older runtimes only store whether the asset is
+// frozen, which is mapped to AssetLive and AssetFrozen.
+type AssetDetails struct {
+	Owner        types.AccountID
+	Issuer       types.AccountID
+	Admin        types.AccountID
+	Freezer      types.AccountID
+	Supply       types.U128
+	Deposit      types.U128
+	MinBalance   types.U128
+	IsSufficient bool
+	Accounts     uint32
+	Sufficients  uint32
+	Approvals    uint32
+	Status       AssetStatus
+}
+
+// AssetAccount is the balance of an asset of an account as stored in Account. Older runtimes only store whether the
+// balance is frozen, which is mapped to AccountLiquid and AccountFrozen.
+type AssetAccount struct {
+	Balance types.U128
+	Status  AccountStatus
+	Reason  ExistenceReason
+}
+
+// AssetMetadata is the metadata of an asset as stored in Metadata.
+type AssetMetadata struct {
+	Deposit  types.U128
+	Name     string
+	Symbol   string
+	Decimals uint8
+	IsFrozen bool
+}
+
+// FormatAmount formats the amount of the asset with its decimals and symbol, e.g. "1.5 USDT".
+func (m AssetMetadata) FormatAmount(amount types.U128) string {
+	formatted := FormatAmount(amount, m.Decimals)
+
+	if m.Symbol == "" {
+		return formatted
+	}
+
+	return formatted + " " + m.Symbol
+}
@@ -0,0 +1,221 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	assetsPallet        = "Assets"
+	foreignAssetsPallet = "ForeignAssets"
+)
+
+// AssetID identifies an asset. Assets of the Assets pallet are identified by an integer, foreign assets by the
+// location of the asset as seen from the chain. The IDs are encoded with the asset ID types declared by the metadata,
+// e.g. as u32, compact u32 or u128 integers.
+type AssetID struct {
+	// Index is the ID of assets that are identified by an integer, it is nil for foreign assets.
+	Index *big.Int
+	// Location is the ID of foreign assets, it is nil for assets that are identified by an integer.
+	Location *types.LocationV4
+}
+
+// NewAssetID returns the ID of the asset of the Assets pallet with the given index.
+func NewAssetID(index uint64) AssetID {
+	return AssetID{Index: new(big.Int).SetUint64(index)}
+}
+
+// NewForeignAssetID returns the ID of the foreign asset with the given location, e.g. xcm.RelayChain() for the native
+// asset of the relay chain.
+func NewForeignAssetID(location types.LocationV4) AssetID {
+	return AssetID{Location: &location}
+}
+
+// IsForeign returns true if the asset is a foreign asset, which is identified by its location.
+func (id AssetID) IsForeign() bool {
+	return id.Location != nil
+}
+
+// Pallet returns the name of the pallet that holds the asset, ForeignAssets for foreign assets and Assets otherwise.
+func (id AssetID) Pallet() string {
+	if id.IsForeign() {
+		return foreignAssetsPallet
+	}
+
+	return assetsPallet
+}
+
+func (id AssetID) String() string {
+	if id.IsForeign() {
+		return fmt.Sprintf("%+v", *id.Location)
+	}
+
+	if id.Index == nil {
+		return "0"
+	}
+
+	return id.Index.String()
+}
+
+// encodeAssetID encodes the asset ID with the type of the given ID, which is an integer, a compact integer or a
+// location.
+func encodeAssetID(lookup map[int64]*types.Si1Type, typeID types.Si1LookupTypeID, id AssetID) ([]byte, error) {
+	t, err := types.ResolveSi1Type(lookup, typeID)
+	if err != nil {
+		return nil, ErrUnsupportedLayout.Wrap(err)
+	}
+
+	if isLocation(t) {
+		if !id.IsForeign() {
+			return nil, ErrAssetIDEncoding.WithMsg("asset %s is no location", id)
+		}
+
+		return codec.Encode(*id.Location)
+	}
+
+	if id.IsForeign() {
+		return nil, ErrAssetIDEncoding.WithMsg("asset ID is no location")
+	}
+
+	index := id.Index
+	if index == nil {
+		index = new(big.Int)
+	}
+
+	if t.Def.IsCompact {
+		if _, err := integerLength(lookup, t.Def.Compact.Type); err != nil {
+			return nil, err
+		}
+
+		return codec.Encode(types.NewUCompact(index))
+	}
+
+	length, err := integerLength(lookup, typeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if index.Sign() < 0 || index.BitLen() > length*8 {
+		return nil, ErrAssetIDEncoding.WithMsg("asset %s does not fit into %d bytes", id, length)
+	}
+
+	encoded := make([]byte, length)
+
+	// The integers are encoded in little endian.
+	for i, b := range index.Bytes() {
+		encoded[len(index.Bytes())-1-i] = b
+	}
+
+	return encoded, nil
+}
+
+// decodeAssetID decodes an asset ID of the type with the given ID, see encodeAssetID.
+func decodeAssetID(
+	lookup map[int64]*types.Si1Type,
+	typeID types.Si1LookupTypeID,
+	decoder *scale.Decoder,
+) (AssetID, error) {
+	t, err := types.ResolveSi1Type(lookup, typeID)
+	if err != nil {
+		return AssetID{}, ErrUnsupportedLayout.Wrap(err)
+	}
+
+	if isLocation(t) {
+		var location types.LocationV4
+
+		if err := decoder.Decode(&location); err != nil {
+			return AssetID{}, err
+		}
+
+		return NewForeignAssetID(location), nil
+	}
+
+	if t.Def.IsCompact {
+		index, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return AssetID{}, err
+		}
+
+		return AssetID{Index: index}, nil
+	}
+
+	length, err := integerLength(lookup, typeID)
+	if err != nil {
+		return AssetID{}, err
+	}
+
+	encoded := make([]byte, length)
+
+	if err := decoder.Read(encoded); err != nil {
+		return AssetID{}, err
+	}
+
+	// The integers are encoded in little endian.
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+
+	return AssetID{Index: new(big.Int).SetBytes(encoded)}, nil
+}
+
+// integerLength returns the length of the encoded unsigned integer type.
+func integerLength(lookup map[int64]*types.Si1Type, typeID types.Si1LookupTypeID) (int, error) {
+	t, err := types.ResolveSi1Type(lookup, typeID)
+	if err != nil {
+		return 0, ErrUnsupportedLayout.Wrap(err)
+	}
+
+	if t.Def.IsPrimitive {
+		switch t.Def.Primitive.Si0TypeDefPrimitive {
+		case types.IsU8:
+			return 1, nil
+		case types.IsU16:
+			return 2, nil
+		case types.IsU32:
+			return 4, nil
+		case types.IsU64:
+			return 8, nil
+		case types.IsU128:
+			return 16, nil
+		}
+	}
+
+	return 0, ErrUnsupportedAssetID.WithMsg("type %d", typeID.Int64())
+}
+
+// isLocation returns true if the type is an XCM v3 MultiLocation or an XCM v4 Location, which have the same encoding.
+func isLocation(t *types.Si1Type) bool {
+	if !t.Def.IsComposite || len(t.Path) < 2 {
+		return false
+	}
+
+	name := t.Path[len(t.Path)-1]
+
+	for _, segment := range t.Path {
+		if segment == "v3" && name == "MultiLocation" || segment == "v4" && name == "Location" {
+			return true
+		}
+	}
+
+	return false
+}
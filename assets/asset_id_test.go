@@ -0,0 +1,89 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func primitive(p types.Si0TypeDefPrimitive) *types.Si1Type {
+	return &types.Si1Type{Def: types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{
+		Si0TypeDefPrimitive: p,
+	}}}
+}
+
+func TestAssetID_EncodeDecode(t *testing.T) {
+	lookup := map[int64]*types.Si1Type{
+		1: primitive(types.IsU32),
+		2: primitive(types.IsU128),
+		3: {Def: types.Si1TypeDef{
+			IsCompact: true,
+			Compact:   types.Si1TypeDefCompact{Type: types.NewSi1LookupTypeIDFromUInt(1)},
+		}},
+		4: {
+			Path: types.Si1Path{"staging_xcm", "v3", "multilocation", "MultiLocation"},
+			Def:  types.Si1TypeDef{IsComposite: true, Composite: types.Si1TypeDefComposite{Fields: make([]types.Si1Field, 2)}},
+		},
+		5: primitive(types.IsStr),
+	}
+
+	largeIndex := new(big.Int).Lsh(big.NewInt(0x1f), 120)
+
+	tests := []struct {
+		typeID   uint64
+		id       AssetID
+		expected []byte
+	}{
+		{1, NewAssetID(1200), []byte{0xb0, 0x04, 0, 0}},
+		{3, NewAssetID(1200), []byte{0xc1, 0x12}},
+		{2, AssetID{Index: largeIndex}, append(make([]byte, 15), 0x1f)},
+		{4, NewForeignAssetID(testLocation), statetest.Encode(t, testLocation)},
+	}
+
+	for _, test := range tests {
+		typeID := types.NewSi1LookupTypeIDFromUInt(test.typeID)
+
+		encoded, err := encodeAssetID(lookup, typeID, test.id)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, encoded)
+
+		decoded, err := decodeAssetID(lookup, typeID, scale.NewDecoder(bytes.NewReader(encoded)))
+		require.NoError(t, err)
+		assert.Equal(t, test.id.String(), decoded.String())
+		assert.Equal(t, test.id.IsForeign(), decoded.IsForeign())
+	}
+
+	_, err := encodeAssetID(lookup, types.NewSi1LookupTypeIDFromUInt(1), NewForeignAssetID(testLocation))
+	assert.ErrorIs(t, err, ErrAssetIDEncoding)
+
+	_, err = encodeAssetID(lookup, types.NewSi1LookupTypeIDFromUInt(4), NewAssetID(1))
+	assert.ErrorIs(t, err, ErrAssetIDEncoding)
+
+	_, err = encodeAssetID(lookup, types.NewSi1LookupTypeIDFromUInt(5), NewAssetID(1))
+	assert.ErrorIs(t, err, ErrUnsupportedAssetID)
+
+	assert.Equal(t, assetsPallet, NewAssetID(1).Pallet())
+	assert.Equal(t, foreignAssetsPallet, NewForeignAssetID(testLocation).Pallet())
+}
@@ -0,0 +1,102 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Transfer creates the transfer call of the pallet of the asset, which transfers the amount of the asset to the
+// target and may reap the account of the sender.
+func Transfer(meta *types.Metadata, id AssetID, target types.MultiAddress, amount *big.Int) (types.Call, error) {
+	return newCall(meta, id, "transfer", target, amount)
+}
+
+// TransferKeepAlive creates the transfer_keep_alive call of the pallet of the asset, see Transfer, which fails if the
+// account of the sender would be reaped.
+func TransferKeepAlive(
+	meta *types.Metadata,
+	id AssetID,
+	target types.MultiAddress,
+	amount *big.Int,
+) (types.Call, error) {
+	return newCall(meta, id, "transfer_keep_alive", target, amount)
+}
+
+// ApproveTransfer creates the approve_transfer call of the pallet of the asset, which allows the delegate to transfer
+// the amount of the asset from the account of the sender.
+func ApproveTransfer(
+	meta *types.Metadata,
+	id AssetID,
+	delegate types.MultiAddress,
+	amount *big.Int,
+) (types.Call, error) {
+	return newCall(meta, id, "approve_transfer", delegate, amount)
+}
+
+// newCall creates the call of the pallet of the asset with the asset ID, the account and the compact amount as
+// arguments. The asset ID is encoded with the type of the first argument of the call declared by the metadata.
+func newCall(
+	meta *types.Metadata,
+	id AssetID,
+	name string,
+	account types.MultiAddress,
+	amount *big.Int,
+) (types.Call, error) {
+	callName := id.Pallet() + "." + name
+
+	idType, err := callAssetIDType(meta, id.Pallet(), name)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	encodedID, err := encodeAssetID(meta.AsMetadataV14.EfficientLookup, idType, id)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	call, err := types.NewCall(meta, callName, types.NewData(encodedID), account, types.NewUCompact(amount))
+	if err != nil {
+		return types.Call{}, ErrCallCreation.WithMsg(callName).Wrap(err)
+	}
+
+	return call, nil
+}
+
+// callAssetIDType returns the type of the asset ID, the first argument of the call of the pallet.
+func callAssetIDType(meta *types.Metadata, pallet, name string) (types.Si1LookupTypeID, error) {
+	for _, p := range meta.AsMetadataV14.Pallets {
+		if string(p.Name) != pallet || !p.HasCalls {
+			continue
+		}
+
+		calls, ok := meta.AsMetadataV14.EfficientLookup[p.Calls.Type.Int64()]
+		if !ok {
+			break
+		}
+
+		for _, call := range calls.Def.Variant.Variants {
+			if string(call.Name) == name && len(call.Fields) > 0 {
+				return call.Fields[0].Type, nil
+			}
+		}
+	}
+
+	return types.Si1LookupTypeID{}, ErrCallNotFound.WithMsg("%s.%s", pallet, name)
+}
@@ -0,0 +1,69 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalls(t *testing.T) {
+	meta := withForeignAssets(t, statetest.DecodeMetadata(t, test.StatemintMetaHex))
+
+	target, err := types.NewMultiAddressFromAccountID(testAccount[:])
+	require.NoError(t, err)
+
+	amount := big.NewInt(1_000_000)
+	encodedAccount := statetest.Encode(t, target)
+	encodedAmount := statetest.Encode(t, types.NewUCompact(amount))
+
+	tests := []struct {
+		create   func(*types.Metadata, AssetID, types.MultiAddress, *big.Int) (types.Call, error)
+		id       AssetID
+		index    types.CallIndex
+		encodeID []byte
+	}{
+		// The asset IDs of the Assets pallet are compact encoded in calls.
+		{Transfer, NewAssetID(1200), types.CallIndex{SectionIndex: 50, MethodIndex: 8}, []byte{0xc1, 0x12}},
+		{TransferKeepAlive, NewAssetID(1200), types.CallIndex{SectionIndex: 50, MethodIndex: 9}, []byte{0xc1, 0x12}},
+		{ApproveTransfer, NewAssetID(1200), types.CallIndex{SectionIndex: 50, MethodIndex: 22}, []byte{0xc1, 0x12}},
+		{
+			Transfer,
+			NewForeignAssetID(testLocation),
+			types.CallIndex{SectionIndex: 53, MethodIndex: 8},
+			statetest.Encode(t, testLocation),
+		},
+	}
+
+	for _, test := range tests {
+		call, err := test.create(meta, test.id, target, amount)
+		require.NoError(t, err)
+
+		args := append(append(append([]byte{}, test.encodeID...), encodedAccount...), encodedAmount...)
+
+		assert.Equal(t, types.Call{CallIndex: test.index, Args: args}, call)
+	}
+
+	_, err = Transfer(statetest.DecodeMetadata(t, test.PolkadotMetadataHex), NewAssetID(1), target, amount)
+	assert.ErrorIs(t, err, ErrCallNotFound)
+}
@@ -0,0 +1,132 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Client reads assets and their balances from the Assets pallet and, for foreign assets, from the ForeignAssets
+// pallet, see AssetID.Pallet.
+type Client struct {
+	stateRPC state.State
+}
+
+// NewClient creates a Client that reads the storage of the asset pallets via the state RPC.
+func NewClient(stateRPC state.State) *Client {
+	return &Client{
+		stateRPC: stateRPC,
+	}
+}
+
+// GetAsset returns the details of the asset, or nil if there is no such asset.
+func (c *Client) GetAsset(ctx context.Context, meta *types.Metadata, id AssetID) (*AssetDetails, error) {
+	var details AssetDetails
+
+	lookup := meta.AsMetadataV14.EfficientLookup
+
+	ok, err := c.getStorage(ctx, meta, id, assetEntry, assetDetailsFields(lookup, &details))
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &details, nil
+}
+
+// GetAccount returns the balance of the asset of the account, or nil if the account holds none of the asset.
+func (c *Client) GetAccount(
+	ctx context.Context,
+	meta *types.Metadata,
+	id AssetID,
+	account types.AccountID,
+) (*AssetAccount, error) {
+	var assetAccount AssetAccount
+
+	lookup := meta.AsMetadataV14.EfficientLookup
+
+	ok, err := c.getStorage(ctx, meta, id, accountEntry, assetAccountFields(lookup, &assetAccount), account[:])
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &assetAccount, nil
+}
+
+// GetMetadata returns the metadata of the asset, e.g. its symbol and decimals, or nil if the asset has no metadata.
+func (c *Client) GetMetadata(ctx context.Context, meta *types.Metadata, id AssetID) (*AssetMetadata, error) {
+	var metadata AssetMetadata
+
+	lookup := meta.AsMetadataV14.EfficientLookup
+
+	ok, err := c.getStorage(ctx, meta, id, metadataEntry, assetMetadataFields(lookup, &metadata))
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &metadata, nil
+}
+
+// getStorage reads the storage entry of the pallet of the asset, whose first key is the asset ID, and decodes it with
+// the field decoders, see state.GetStorageEntryRawLatest. False is returned if there is no value stored.
+func (c *Client) getStorage(
+	ctx context.Context,
+	meta *types.Metadata,
+	id AssetID,
+	entry string,
+	fields map[string]fieldDecoder,
+	args ...[]byte,
+) (bool, error) {
+	pallet := id.Pallet()
+	lookup := meta.AsMetadataV14.EfficientLookup
+
+	entryMetadata, err := storageEntry(meta, pallet, entry)
+	if err != nil {
+		return false, err
+	}
+
+	keyType := entryMetadata.Type.AsMap.Key
+
+	if len(args) > 0 {
+		keys, ok := lookup[keyType.Int64()]
+		if !ok || !keys.Def.IsTuple || len(keys.Def.Tuple) != len(args)+1 {
+			return false, ErrUnsupportedLayout.WithMsg("keys of %s.%s", pallet, entry)
+		}
+
+		keyType = keys.Def.Tuple[0]
+	}
+
+	encodedID, err := encodeAssetID(lookup, keyType, id)
+	if err != nil {
+		return false, err
+	}
+
+	encodedKeys := append([][]byte{encodedID}, args...)
+
+	raw, err := state.GetStorageEntryRawLatest(ctx, c.stateRPC, meta, pallet, entry, encodedKeys...)
+	if err != nil || raw == nil {
+		return false, err
+	}
+
+	if err := decodeStorageValue(lookup, entryMetadata, raw, fields); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
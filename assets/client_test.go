@@ -0,0 +1,269 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/xcm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccount  = types.AccountID{1}
+	testOwner    = types.AccountID{2}
+	testLocation = xcm.SiblingParachain(2004)
+)
+
+// withForeignAssets returns a copy of the metadata with a ForeignAssets pallet, which is a copy of the Assets pallet
+// whose asset IDs are XCM v4 locations.
+func withForeignAssets(t *testing.T, meta *types.Metadata) *types.Metadata {
+	foreign := *meta
+	foreign.AsMetadataV14.EfficientLookup = make(map[int64]*types.Si1Type)
+
+	for id, ty := range meta.AsMetadataV14.EfficientLookup {
+		foreign.AsMetadataV14.EfficientLookup[id] = ty
+	}
+
+	lookup := foreign.AsMetadataV14.EfficientLookup
+	nextID := int64(1_000_000)
+	addType := func(ty types.Si1Type) types.Si1LookupTypeID {
+		nextID++
+		lookup[nextID] = &ty
+
+		return types.NewSi1LookupTypeIDFromUInt(uint64(nextID))
+	}
+
+	// Only the path of the location is inspected, the fields are encoded as types.LocationV4.
+	location := addType(types.Si1Type{
+		Path: types.Si1Path{"staging_xcm", "v4", "location", "Location"},
+		Def: types.Si1TypeDef{IsComposite: true, Composite: types.Si1TypeDefComposite{Fields: []types.Si1Field{
+			{HasName: true, Name: "parents"},
+			{HasName: true, Name: "interior"},
+		}}},
+	})
+
+	// withLocation returns a copy of the variant type whose first fields of the variants are locations.
+	withLocation := func(id types.Si1LookupTypeID) types.Si1LookupTypeID {
+		ty := *lookup[id.Int64()]
+		variants := make([]types.Si1Variant, 0, len(ty.Def.Variant.Variants))
+
+		for _, variant := range ty.Def.Variant.Variants {
+			if len(variant.Fields) > 0 {
+				variant.Fields = append([]types.Si1Field{variant.Fields[0]}, variant.Fields[1:]...)
+				variant.Fields[0].Type = location
+			}
+
+			variants = append(variants, variant)
+		}
+
+		ty.Def.Variant.Variants = variants
+
+		return addType(ty)
+	}
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.Name != assetsPallet {
+			continue
+		}
+
+		pallet.Name = foreignAssetsPallet
+		pallet.Index = 53
+		pallet.Storage.Prefix = foreignAssetsPallet
+		pallet.Storage.Items = append([]types.StorageEntryMetadataV14{}, pallet.Storage.Items...)
+		pallet.Calls.Type = withLocation(pallet.Calls.Type)
+		pallet.Events.Type = withLocation(pallet.Events.Type)
+
+		for i, item := range pallet.Storage.Items {
+			keys := lookup[item.Type.AsMap.Key.Int64()]
+
+			if !keys.Def.IsTuple {
+				pallet.Storage.Items[i].Type.AsMap.Key = location
+				continue
+			}
+
+			tuple := append(types.Si1TypeDefTuple{location}, keys.Def.Tuple[1:]...)
+			pallet.Storage.Items[i].Type.AsMap.Key = addType(types.Si1Type{
+				Def: types.Si1TypeDef{IsTuple: true, Tuple: tuple},
+			})
+		}
+
+		foreign.AsMetadataV14.Pallets = append(append([]types.PalletMetadataV14{}, meta.AsMetadataV14.Pallets...), pallet)
+
+		return &foreign
+	}
+
+	t.Fatal("assets pallet not found")
+
+	return nil
+}
+
+// assetDetails is pallet_assets::AssetDetails as stored by runtimes with asset statuses.
+type assetDetails struct {
+	Owner, Issuer, Admin, Freezer    types.AccountID
+	Supply, Deposit, MinBalance      types.U128
+	IsSufficient                     bool
+	Accounts, Sufficients, Approvals types.U32
+	Status                           AssetStatus
+}
+
+func TestClient_GetAsset(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.DecodeMetadata(t, test.StatemintMetaHex)
+	stored := assetDetails{
+		Owner:      testOwner,
+		Issuer:     testOwner,
+		Admin:      testOwner,
+		Freezer:    testOwner,
+		Supply:     u128(1_000_000),
+		Deposit:    u128(10),
+		MinBalance: u128(1),
+		Accounts:   3,
+		Approvals:  1,
+		Status:     AssetFrozen,
+	}
+	encoded := statetest.Encode(t, stored)
+	expected := &AssetDetails{
+		Owner:      testOwner,
+		Issuer:     testOwner,
+		Admin:      testOwner,
+		Freezer:    testOwner,
+		Supply:     u128(1_000_000),
+		Deposit:    u128(10),
+		MinBalance: u128(1),
+		Accounts:   3,
+		Approvals:  1,
+		Status:     AssetFrozen,
+	}
+
+	t.Run("assets", func(t *testing.T) {
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorageRaw(t, meta, assetsPallet, assetEntry, encoded, []byte{0xb0, 0x04, 0, 0})
+
+		details, err := c.GetAsset(ctx, meta, NewAssetID(1200))
+		require.NoError(t, err)
+		assert.Equal(t, expected, details)
+
+		stateRPC.ExpectStorageRaw(t, meta, assetsPallet, assetEntry, nil, []byte{1, 0, 0, 0})
+
+		details, err = c.GetAsset(ctx, meta, NewAssetID(1))
+		require.NoError(t, err)
+		assert.Nil(t, details)
+	})
+
+	t.Run("foreign assets", func(t *testing.T) {
+		meta := withForeignAssets(t, meta)
+
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorageRaw(t, meta, foreignAssetsPallet, assetEntry, encoded, statetest.Encode(t, testLocation))
+
+		details, err := c.GetAsset(ctx, meta, NewForeignAssetID(testLocation))
+		require.NoError(t, err)
+		assert.Equal(t, expected, details)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		c, stateRPC := statetest.NewClient(t, NewClient)
+
+		_, err := c.GetAsset(ctx, meta, NewAssetID(1<<32))
+		assert.ErrorIs(t, err, ErrAssetIDEncoding)
+
+		_, err = c.GetAsset(ctx, meta, NewForeignAssetID(testLocation))
+		assert.ErrorIs(t, err, ErrStorageEntryNotFound)
+
+		// Moonbeam has 20 byte accounts.
+		moonbeam := statetest.DecodeMetadata(t, test.MoonbeamMetaHex)
+		stateRPC.ExpectStorageRaw(
+			t, moonbeam, assetsPallet, assetEntry, statetest.Encode(t, []byte{0}), make([]byte, 16),
+		)
+
+		_, err = c.GetAsset(ctx, moonbeam, NewAssetID(0))
+		assert.ErrorIs(t, err, ErrUnsupportedLayout)
+
+		stateRPC.On("GetStorageRawLatestContext", mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+		_, err = c.GetAsset(ctx, meta, NewAssetID(1))
+		assert.ErrorIs(t, err, ErrStorageRetrieval)
+	})
+}
+
+func TestClient_GetAccount(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.DecodeMetadata(t, test.StatemintMetaHex)
+
+	// The Statemint runtime stores whether the balance is frozen.
+	stored := struct {
+		Balance  types.U128
+		IsFrozen bool
+		Reason   ExistenceReason
+	}{u128(500), true, ExistenceReason{IsDepositHeld: true, AsDepositHeld: u128(10)}}
+	encoded := statetest.Encode(t, stored)
+	expected := &AssetAccount{
+		Balance: u128(500),
+		Status:  AccountFrozen,
+		Reason:  ExistenceReason{IsDepositHeld: true, AsDepositHeld: u128(10)},
+	}
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorageRaw(t, meta, assetsPallet, accountEntry, encoded, []byte{1, 0, 0, 0}, testAccount[:])
+
+	account, err := c.GetAccount(ctx, meta, NewAssetID(1), testAccount)
+	require.NoError(t, err)
+	assert.Equal(t, expected, account)
+
+	meta = withForeignAssets(t, meta)
+
+	stateRPC.ExpectStorageRaw(
+		t, meta, foreignAssetsPallet, accountEntry, encoded, statetest.Encode(t, testLocation), testAccount[:],
+	)
+
+	account, err = c.GetAccount(ctx, meta, NewForeignAssetID(testLocation), testAccount)
+	require.NoError(t, err)
+	assert.Equal(t, expected, account)
+
+	stateRPC.ExpectStorageRaw(t, meta, assetsPallet, accountEntry, nil, []byte{1, 0, 0, 0}, testAccount[:])
+
+	account, err = c.GetAccount(ctx, meta, NewAssetID(1), testAccount)
+	require.NoError(t, err)
+	assert.Nil(t, account)
+}
+
+func TestClient_GetMetadata(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.DecodeMetadata(t, test.StatemintMetaHex)
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorageRaw(t, meta, assetsPallet, metadataEntry, statetest.Encode(t, struct {
+		Deposit  types.U128
+		Name     types.Bytes
+		Symbol   types.Bytes
+		Decimals types.U8
+		IsFrozen bool
+	}{u128(10), types.Bytes("Tether USD"), types.Bytes("USDt"), 6, false}), []byte{0xb0, 0x04, 0, 0})
+
+	metadata, err := c.GetMetadata(ctx, meta, NewAssetID(1200))
+	require.NoError(t, err)
+	assert.Equal(t, &AssetMetadata{Deposit: u128(10), Name: "Tether USD", Symbol: "USDt", Decimals: 6}, metadata)
+	assert.Equal(t, "12.5 USDt", metadata.FormatAmount(u128(12_500_000)))
+}
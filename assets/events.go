@@ -0,0 +1,176 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// IssuedEvent holds the fields of the Issued event, emitted when an amount of the asset was minted to the owner.
+type IssuedEvent struct {
+	AssetID AssetID
+	Owner   types.AccountID
+	Amount  types.U128
+}
+
+// TransferredEvent holds the fields of the Transferred event, emitted when an amount of the asset was transferred.
+type TransferredEvent struct {
+	AssetID AssetID
+	From    types.AccountID
+	To      types.AccountID
+	Amount  types.U128
+}
+
+// BurnedEvent holds the fields of the Burned event, emitted when an amount of the asset of the owner was burned.
+type BurnedEvent struct {
+	AssetID AssetID
+	Owner   types.AccountID
+	Balance types.U128
+}
+
+// Events are the events of the asset pallets emitted by an extrinsic.
+type Events struct {
+	Issued      []IssuedEvent
+	Transferred []TransferredEvent
+	Burned      []BurnedEvent
+}
+
+// DecodeEvents decodes the Issued, Transferred and Burned events of the Assets and ForeignAssets pallets, e.g. the
+// events of submit.ExtrinsicResult. The fields are decoded with the types declared by the metadata, e.g. the asset IDs
+// of the pallet. Other events are ignored.
+func DecodeEvents(meta *types.Metadata, events []*parser.Event) (*Events, error) {
+	var res Events
+
+	lookup := meta.AsMetadataV14.EfficientLookup
+
+	for _, event := range events {
+		pallet, name, ok := strings.Cut(event.Name, ".")
+		if !ok || pallet != assetsPallet && pallet != foreignAssetsPallet {
+			continue
+		}
+
+		var err error
+
+		switch name {
+		case "Issued":
+			var issued IssuedEvent
+
+			err = decodeEvent(meta, event,
+				assetIDOf(lookup, &issued.AssetID),
+				accountOf(lookup, &issued.Owner),
+				balanceOf(lookup, &issued.Amount),
+			)
+
+			res.Issued = append(res.Issued, issued)
+		case "Transferred":
+			var transferred TransferredEvent
+
+			err = decodeEvent(meta, event,
+				assetIDOf(lookup, &transferred.AssetID),
+				accountOf(lookup, &transferred.From),
+				accountOf(lookup, &transferred.To),
+				balanceOf(lookup, &transferred.Amount),
+			)
+
+			res.Transferred = append(res.Transferred, transferred)
+		case "Burned":
+			var burned BurnedEvent
+
+			err = decodeEvent(meta, event,
+				assetIDOf(lookup, &burned.AssetID),
+				accountOf(lookup, &burned.Owner),
+				balanceOf(lookup, &burned.Balance),
+			)
+
+			res.Burned = append(res.Burned, burned)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &res, nil
+}
+
+// decodeEvent decodes the fields of the event with the field decoders, in the order declared by the metadata.
+func decodeEvent(meta *types.Metadata, event *parser.Event, fields ...fieldDecoder) error {
+	variant, err := eventVariant(meta, event.EventID)
+	if err != nil {
+		return err
+	}
+
+	if len(variant.Fields) != len(fields) {
+		return ErrEventDecoding.WithMsg("%s has %d fields", event.Name, len(variant.Fields))
+	}
+
+	reader := bytes.NewReader(event.Data)
+	decoder := scale.NewDecoder(reader)
+
+	for i, field := range variant.Fields {
+		if err := fields[i](decoder, field.Type); err != nil {
+			return ErrEventDecoding.WithMsg("%s field %s", event.Name, field.Name).Wrap(err)
+		}
+	}
+
+	if reader.Len() > 0 {
+		return ErrEventDecoding.WithMsg("%s has %d trailing bytes", event.Name, reader.Len())
+	}
+
+	return nil
+}
+
+// eventVariant returns the variant of the event with the given ID.
+func eventVariant(meta *types.Metadata, eventID types.EventID) (*types.Si1Variant, error) {
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if !pallet.HasEvents || uint8(pallet.Index) != eventID[0] {
+			continue
+		}
+
+		events, ok := meta.AsMetadataV14.EfficientLookup[pallet.Events.Type.Int64()]
+		if !ok {
+			break
+		}
+
+		for i, variant := range events.Def.Variant.Variants {
+			if uint8(variant.Index) == eventID[1] {
+				return &events.Def.Variant.Variants[i], nil
+			}
+		}
+	}
+
+	return nil, ErrEventTypeNotFound.WithMsg("event %v", eventID)
+}
+
+// assetIDOf returns a fieldDecoder for asset IDs, see decodeAssetID.
+func assetIDOf(lookup map[int64]*types.Si1Type, target *AssetID) fieldDecoder {
+	return func(decoder *scale.Decoder, id types.Si1LookupTypeID) error {
+		assetID, err := decodeAssetID(lookup, id, decoder)
+		if err != nil {
+			return err
+		}
+
+		*target = assetID
+
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	meta := withForeignAssets(t, statetest.DecodeMetadata(t, test.StatemintMetaHex))
+
+	assetID := types.NewU32(1200)
+
+	res, err := DecodeEvents(meta, []*parser.Event{
+		{Name: "System.ExtrinsicSuccess", Data: []byte{1}},
+		{
+			Name:    "Assets.Issued",
+			EventID: types.EventID{50, 1},
+			Data:    append(append(statetest.Encode(t, assetID), testOwner[:]...), statetest.Encode(t, u128(100))...),
+		},
+		{
+			Name:    "Assets.Transferred",
+			EventID: types.EventID{50, 2},
+			Data: append(append(append(statetest.Encode(t, assetID), testOwner[:]...), testAccount[:]...),
+				statetest.Encode(t, u128(40))...),
+		},
+		{
+			Name:    "ForeignAssets.Burned",
+			EventID: types.EventID{53, 3},
+			Data: append(append(statetest.Encode(t, testLocation), testAccount[:]...),
+				statetest.Encode(t, u128(10))...),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &Events{
+		Issued: []IssuedEvent{{AssetID: NewAssetID(1200), Owner: testOwner, Amount: u128(100)}},
+		Transferred: []TransferredEvent{
+			{AssetID: NewAssetID(1200), From: testOwner, To: testAccount, Amount: u128(40)},
+		},
+		Burned: []BurnedEvent{{AssetID: NewForeignAssetID(testLocation), Owner: testAccount, Balance: u128(10)}},
+	}, res)
+
+	_, err = DecodeEvents(meta, []*parser.Event{
+		{Name: "Assets.Issued", EventID: types.EventID{50, 1}, Data: statetest.Encode(t, assetID)},
+	})
+	assert.ErrorIs(t, err, ErrEventDecoding)
+
+	_, err = DecodeEvents(meta, []*parser.Event{
+		{Name: "Assets.Issued", EventID: types.EventID{50, 99}},
+	})
+	assert.ErrorIs(t, err, ErrEventTypeNotFound)
+}
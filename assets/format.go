@@ -0,0 +1,77 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// FormatAmount formats the amount in the smallest unit of an asset with the given decimals, without trailing zeros,
+// e.g. "1.5" for 1500000 with 6 decimals.
+func FormatAmount(amount types.U128, decimals uint8) string {
+	digits := "0"
+	if amount.Int != nil {
+		digits = amount.String()
+	}
+
+	if decimals == 0 {
+		return digits
+	}
+
+	d := int(decimals)
+
+	if len(digits) <= d {
+		digits = strings.Repeat("0", d-len(digits)+1) + digits
+	}
+
+	integer, fraction := digits[:len(digits)-d], strings.TrimRight(digits[len(digits)-d:], "0")
+
+	if fraction == "" {
+		return integer
+	}
+
+	return integer + "." + fraction
+}
+
+// ParseAmount parses the decimal amount of an asset with the given decimals into the amount in its smallest unit,
+// e.g. 1500000 for "1.5" with 6 decimals. ErrInvalidAmount is returned if the amount has more fractional digits than
+// the asset has decimals or does not fit into a u128.
+func ParseAmount(s string, decimals uint8) (types.U128, error) {
+	integer, fraction, _ := strings.Cut(s, ".")
+
+	if integer == "" && fraction == "" || len(fraction) > int(decimals) {
+		return types.U128{}, ErrInvalidAmount.WithMsg("%q with %d decimals", s, decimals)
+	}
+
+	digits := integer + fraction + strings.Repeat("0", int(decimals)-len(fraction))
+
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return types.U128{}, ErrInvalidAmount.WithMsg("%q", s)
+		}
+	}
+
+	amount, ok := new(big.Int).SetString(digits, 10)
+	if !ok || amount.BitLen() > 128 {
+		return types.U128{}, ErrInvalidAmount.WithMsg("%q", s)
+	}
+
+	return types.NewU128(*amount), nil
+}
@@ -0,0 +1,78 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func u128(i int64) types.U128 {
+	return types.NewU128(*big.NewInt(i))
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount   types.U128
+		decimals uint8
+		expected string
+	}{
+		{u128(1_500_000), 6, "1.5"},
+		{u128(1_000_000), 6, "1"},
+		{u128(1), 6, "0.000001"},
+		{u128(0), 6, "0"},
+		{u128(42), 0, "42"},
+		{types.U128{}, 10, "0"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, FormatAmount(test.amount, test.decimals))
+	}
+
+	metadata := AssetMetadata{Symbol: "USDT", Decimals: 6}
+	assert.Equal(t, "1.5 USDT", metadata.FormatAmount(u128(1_500_000)))
+	assert.Equal(t, "1.5", AssetMetadata{Decimals: 6}.FormatAmount(u128(1_500_000)))
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		s        string
+		decimals uint8
+		expected types.U128
+	}{
+		{"1.5", 6, u128(1_500_000)},
+		{"1", 6, u128(1_000_000)},
+		{".000001", 6, u128(1)},
+		{"0.000001", 6, u128(1)},
+		{"42", 0, u128(42)},
+	}
+
+	for _, test := range tests {
+		amount, err := ParseAmount(test.s, test.decimals)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, amount)
+	}
+
+	for _, s := range []string{"", ".", "1.0000001", "-1", "1e6", "1.5.0", "340282366920938463463374607431768211456"} {
+		_, err := ParseAmount(s, 6)
+		assert.ErrorIs(t, err, ErrInvalidAmount, s)
+	}
+}
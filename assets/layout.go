@@ -0,0 +1,253 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	assetEntry    = "Asset"
+	accountEntry  = "Account"
+	metadataEntry = "Metadata"
+)
+
+// fieldDecoder decodes a field of the type with the given ID.
+type fieldDecoder func(decoder *scale.Decoder, id types.Si1LookupTypeID) error
+
+// The fields of the storage values changed between runtime versions, e.g. the is_frozen flags were replaced by
+// statuses, so the values are decoded by the names of the fields declared by the metadata.
+
+func assetDetailsFields(lookup map[int64]*types.Si1Type, details *AssetDetails) map[string]fieldDecoder {
+	return map[string]fieldDecoder{
+		"owner":         accountOf(lookup, &details.Owner),
+		"issuer":        accountOf(lookup, &details.Issuer),
+		"admin":         accountOf(lookup, &details.Admin),
+		"freezer":       accountOf(lookup, &details.Freezer),
+		"supply":        balanceOf(lookup, &details.Supply),
+		"deposit":       balanceOf(lookup, &details.Deposit),
+		"min_balance":   balanceOf(lookup, &details.MinBalance),
+		"is_sufficient": valueOf(&details.IsSufficient),
+		"accounts":      u32Of(&details.Accounts),
+		"sufficients":   u32Of(&details.Sufficients),
+		"approvals":     u32Of(&details.Approvals),
+		"status":        valueOf(&details.Status),
+		"is_frozen": func(decoder *scale.Decoder, _ types.Si1LookupTypeID) error {
+			var frozen bool
+
+			if err := decoder.Decode(&frozen); err != nil {
+				return err
+			}
+
+			if frozen {
+				details.Status = AssetFrozen
+			}
+
+			return nil
+		},
+	}
+}
+
+func assetAccountFields(lookup map[int64]*types.Si1Type, account *AssetAccount) map[string]fieldDecoder {
+	return map[string]fieldDecoder{
+		"balance": balanceOf(lookup, &account.Balance),
+		"status":  valueOf(&account.Status),
+		"is_frozen": func(decoder *scale.Decoder, _ types.Si1LookupTypeID) error {
+			var frozen bool
+
+			if err := decoder.Decode(&frozen); err != nil {
+				return err
+			}
+
+			if frozen {
+				account.Status = AccountFrozen
+			}
+
+			return nil
+		},
+		"reason": valueOf(&account.Reason),
+		"extra":  emptyTupleOf(lookup),
+	}
+}
+
+func assetMetadataFields(lookup map[int64]*types.Si1Type, metadata *AssetMetadata) map[string]fieldDecoder {
+	return map[string]fieldDecoder{
+		"deposit":   balanceOf(lookup, &metadata.Deposit),
+		"name":      stringOf(&metadata.Name),
+		"symbol":    stringOf(&metadata.Symbol),
+		"decimals":  valueOf(&metadata.Decimals),
+		"is_frozen": valueOf(&metadata.IsFrozen),
+	}
+}
+
+// storageEntry returns the metadata of the map storage entry of the pallet.
+func storageEntry(meta *types.Metadata, pallet, entry string) (types.StorageEntryMetadataV14, error) {
+	entryMetadata, err := meta.FindStorageEntryMetadata(pallet, entry)
+	if err != nil {
+		return types.StorageEntryMetadataV14{}, ErrStorageEntryNotFound.WithMsg("%s.%s", pallet, entry).Wrap(err)
+	}
+
+	entryV14, ok := entryMetadata.(types.StorageEntryMetadataV14)
+	if !ok || !entryV14.Type.IsMap {
+		return types.StorageEntryMetadataV14{}, ErrUnsupportedLayout.WithMsg("%s.%s is not a map", pallet, entry)
+	}
+
+	return entryV14, nil
+}
+
+// decodeStorageValue decodes the value of the storage entry with the field decoders.
+func decodeStorageValue(
+	lookup map[int64]*types.Si1Type,
+	entry types.StorageEntryMetadataV14,
+	data []byte,
+	fields map[string]fieldDecoder,
+) error {
+	reader := bytes.NewReader(data)
+	decoder := scale.NewDecoder(reader)
+
+	if err := decodeComposite(lookup, entry.Type.AsMap.Value, decoder, fields); err != nil {
+		return err
+	}
+
+	if reader.Len() > 0 {
+		return ErrStorageDecoding.WithMsg("%s has %d trailing bytes", entry.Name, reader.Len())
+	}
+
+	return nil
+}
+
+// decodeComposite decodes the fields of the composite type in the order declared by the metadata, using the decoder
+// for the name of each field.
+func decodeComposite(
+	lookup map[int64]*types.Si1Type,
+	id types.Si1LookupTypeID,
+	decoder *scale.Decoder,
+	fields map[string]fieldDecoder,
+) error {
+	t, ok := lookup[id.Int64()]
+	if !ok || !t.Def.IsComposite {
+		return ErrUnsupportedLayout.WithMsg("type %d is not a composite", id.Int64())
+	}
+
+	for _, field := range t.Def.Composite.Fields {
+		name := string(field.Name)
+
+		decode, ok := fields[name]
+		if !ok {
+			return ErrUnsupportedLayout.WithMsg("field %s of %v", name, t.Path)
+		}
+
+		if err := decode(decoder, field.Type); err != nil {
+			return ErrStorageDecoding.WithMsg("field %s of %v", name, t.Path).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// valueOf returns a fieldDecoder that decodes the field into the target.
+func valueOf(target any) fieldDecoder {
+	return func(decoder *scale.Decoder, _ types.Si1LookupTypeID) error {
+		return decoder.Decode(target)
+	}
+}
+
+func u32Of(target *uint32) fieldDecoder {
+	return func(decoder *scale.Decoder, _ types.Si1LookupTypeID) error {
+		var v types.U32
+
+		if err := decoder.Decode(&v); err != nil {
+			return err
+		}
+
+		*target = uint32(v)
+
+		return nil
+	}
+}
+
+func stringOf(target *string) fieldDecoder {
+	return func(decoder *scale.Decoder, _ types.Si1LookupTypeID) error {
+		var b types.Bytes
+
+		if err := decoder.Decode(&b); err != nil {
+			return err
+		}
+
+		*target = string(b)
+
+		return nil
+	}
+}
+
+// accountOf returns a fieldDecoder for 32 byte accounts, accounts of other lengths are not supported.
+func accountOf(lookup map[int64]*types.Si1Type, target *types.AccountID) fieldDecoder {
+	return func(decoder *scale.Decoder, id types.Si1LookupTypeID) error {
+		t, err := types.ResolveSi1Type(lookup, id)
+		if err != nil {
+			return ErrUnsupportedLayout.Wrap(err)
+		}
+
+		if !t.Def.IsArray || int(t.Def.Array.Len) != len(target) {
+			return ErrUnsupportedLayout.WithMsg("account type %d", id.Int64())
+		}
+
+		return decoder.Decode(target)
+	}
+}
+
+// balanceOf returns a fieldDecoder for u64 and u128 balances.
+func balanceOf(lookup map[int64]*types.Si1Type, target *types.U128) fieldDecoder {
+	return func(decoder *scale.Decoder, id types.Si1LookupTypeID) error {
+		length, err := integerLength(lookup, id)
+		if err != nil {
+			return err
+		}
+
+		switch length {
+		case 8:
+			var balance types.U64
+
+			if err := decoder.Decode(&balance); err != nil {
+				return err
+			}
+
+			*target = types.NewU128(*new(big.Int).SetUint64(uint64(balance)))
+
+			return nil
+		case 16:
+			return decoder.Decode(target)
+		}
+
+		return ErrUnsupportedLayout.WithMsg("balance type %d", id.Int64())
+	}
+}
+
+// emptyTupleOf returns a fieldDecoder for fields of the empty tuple, which are not encoded.
+func emptyTupleOf(lookup map[int64]*types.Si1Type) fieldDecoder {
+	return func(_ *scale.Decoder, id types.Si1LookupTypeID) error {
+		if t, ok := lookup[id.Int64()]; !ok || !t.Def.IsTuple || len(t.Def.Tuple) != 0 {
+			return ErrUnsupportedLayout.WithMsg("extra type %d", id.Int64())
+		}
+
+		return nil
+	}
+}
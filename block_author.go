@@ -0,0 +1,97 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsrpc
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// GetBlockAuthor returns the author of the block with the given hash, see types.ExtractAuthor. The authority index or
+// slot of the pre-runtime digest is resolved against the Session.Validators storage at the parent block, as that is
+// the validator set the block was authored with. For Nimbus chains the Nimbus key of the author is returned. Blocks
+// of other consensus engines return an error that wraps types.ErrUnsupportedConsensus.
+func (api *SubstrateAPI) GetBlockAuthor(ctx context.Context, blockHash types.Hash) (*types.AccountID, error) {
+	header, err := api.RPC.Chain.GetHeaderContext(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nimbus identifies the author directly, chains using it do not necessarily have a Session pallet.
+	for _, item := range header.Digest {
+		if item.IsPreRuntime && item.AsPreRuntime.ConsensusEngineID == types.NimbusEngineID {
+			return types.ExtractAuthor(*header, nil)
+		}
+	}
+
+	validators, err := api.GetValidatorSet(ctx, header.ParentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.ExtractAuthor(*header, validators)
+}
+
+// GetValidatorSet returns the validators of the session at the given block, as stored in Session.Validators.
+func (api *SubstrateAPI) GetValidatorSet(ctx context.Context, blockHash types.Hash) ([]types.AccountID, error) {
+	meta, err := api.getMetadata(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := types.CreateStorageKey(meta, "Session", "Validators")
+	if err != nil {
+		return nil, err
+	}
+
+	var validators []types.AccountID
+	if _, err := api.RPC.State.GetStorageContext(ctx, key, &validators, blockHash); err != nil {
+		return nil, err
+	}
+
+	return validators, nil
+}
+
+// GetQueuedKeys returns the validators of the next session with their session keys at the given block, as stored in
+// Session.QueuedKeys, see registry.DecodeQueuedKeys.
+func (api *SubstrateAPI) GetQueuedKeys(
+	ctx context.Context,
+	blockHash types.Hash,
+) ([]registry.QueuedSessionKeys, error) {
+	meta, err := api.getMetadata(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := types.CreateStorageKey(meta, "Session", "QueuedKeys")
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := api.RPC.State.GetStorageRawContext(ctx, key, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*raw) == 0 {
+		return nil, nil
+	}
+
+	return registry.DecodeQueuedKeys(meta, *raw)
+}
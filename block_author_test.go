@@ -0,0 +1,141 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsrpc_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sessionValidatorsKey = "0xcec5070d609dd3497f72bde07fc96ba088dcde934c658227ee1dfafcd6e16903"
+
+var testParentHash = types.NewHash(codec.MustHexDecodeString(
+	"0x0101010101010101010101010101010101010101010101010101010101010101",
+))
+
+func newTestHeader(t *testing.T, preRuntime types.PreRuntime) map[string]interface{} {
+	item, err := codec.EncodeToHex(types.DigestItem{IsPreRuntime: true, AsPreRuntime: preRuntime})
+	require.NoError(t, err)
+
+	return map[string]interface{}{
+		"parentHash":     testParentHash.Hex(),
+		"number":         "0x2a",
+		"stateRoot":      testParentHash.Hex(),
+		"extrinsicsRoot": testParentHash.Hex(),
+		"digest":         map[string]interface{}{"logs": []string{item}},
+	}
+}
+
+func TestSubstrateAPI_GetBlockAuthor(t *testing.T) {
+	validators, err := codec.EncodeToHex([]types.AccountID{{1}, testAccountID})
+	require.NoError(t, err)
+
+	cl := rpcmocksrv.NewMockClient().
+		Respond("chain_getHeader", newTestHeader(t, types.PreRuntime{
+			ConsensusEngineID: types.BabeEngineID,
+			Bytes:             codec.MustHexDecodeString("0x02010000000200000000000000"),
+		}), testBlockHash.Hex()).
+		Respond("state_getMetadata", test.PolkadotMetadataHex, testParentHash.Hex()).
+		Respond("state_getStorage", validators, sessionValidatorsKey, testParentHash.Hex())
+
+	api := newTestSubstrateAPI(t, cl)
+
+	author, err := api.GetBlockAuthor(context.Background(), testBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, testAccountID, *author)
+}
+
+func TestSubstrateAPI_GetBlockAuthor_Nimbus(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Respond("chain_getHeader", newTestHeader(t, types.PreRuntime{
+			ConsensusEngineID: types.NimbusEngineID,
+			Bytes:             testAccountID.ToBytes(),
+		}), testBlockHash.Hex())
+
+	api := newTestSubstrateAPI(t, cl)
+
+	author, err := api.GetBlockAuthor(context.Background(), testBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, testAccountID, *author)
+
+	cl.AssertNotCalled(t, "state_getStorage")
+}
+
+func TestSubstrateAPI_GetBlockAuthor_UnsupportedConsensus(t *testing.T) {
+	validators, err := codec.EncodeToHex([]types.AccountID{testAccountID})
+	require.NoError(t, err)
+
+	cl := rpcmocksrv.NewMockClient().
+		Respond("chain_getHeader", newTestHeader(t, types.PreRuntime{
+			ConsensusEngineID: types.ConsensusEngineID(0x5f776f70), // pow_
+			Bytes:             codec.MustHexDecodeString("0x01"),
+		}), testBlockHash.Hex()).
+		Respond("state_getMetadata", test.PolkadotMetadataHex, testParentHash.Hex()).
+		Respond("state_getStorage", validators, sessionValidatorsKey, testParentHash.Hex())
+
+	_, err = newTestSubstrateAPI(t, cl).GetBlockAuthor(context.Background(), testBlockHash)
+	assert.ErrorIs(t, err, types.ErrUnsupportedConsensus)
+}
+
+func TestSubstrateAPI_GetValidatorSet(t *testing.T) {
+	validators, err := codec.EncodeToHex([]types.AccountID{{1}, testAccountID})
+	require.NoError(t, err)
+
+	cl := rpcmocksrv.NewMockClient().
+		Respond("state_getMetadata", test.PolkadotMetadataHex, testParentHash.Hex()).
+		Respond("state_getStorage", validators, sessionValidatorsKey, testParentHash.Hex())
+
+	res, err := newTestSubstrateAPI(t, cl).GetValidatorSet(context.Background(), testParentHash)
+	require.NoError(t, err)
+	assert.Equal(t, []types.AccountID{{1}, testAccountID}, res)
+}
+
+func TestSubstrateAPI_GetQueuedKeys(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	key, err := types.CreateStorageKey(&meta, "Session", "QueuedKeys")
+	require.NoError(t, err)
+
+	// One validator with its six session keys.
+	queuedKeys := append([]byte{1 << 2}, testAccountID.ToBytes()...)
+	for i := byte(1); i <= 6; i++ {
+		queuedKeys = append(queuedKeys, bytes.Repeat([]byte{i}, 32)...)
+	}
+
+	cl := rpcmocksrv.NewMockClient().
+		Respond("state_getMetadata", test.PolkadotMetadataHex, testParentHash.Hex()).
+		Respond("state_getStorage", codec.HexEncodeToString(queuedKeys), key.Hex(), testParentHash.Hex())
+
+	res, err := newTestSubstrateAPI(t, cl).GetQueuedKeys(context.Background(), testParentHash)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, types.Bytes(testAccountID.ToBytes()), res[0].ValidatorID)
+	require.Len(t, res[0].Keys, 6)
+	assert.Equal(t, "grandpa", res[0].Keys[0].Name)
+	assert.Equal(t, types.Bytes(bytes.Repeat([]byte{6}, 32)), res[0].Keys[5].PublicKey)
+}
@@ -0,0 +1,191 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// WeightUtilization is the weight consumed in a block relative to the weight limit
+type WeightUtilization struct {
+	Consumed types.Weight
+	Limit    types.Weight
+
+	RefTimePercent   float64
+	ProofSizePercent float64
+}
+
+// BlockUtilization describes how full a block is, see GetBlockUtilization
+type BlockUtilization struct {
+	// Length is the sum of the encoded lengths of the extrinsics of the block, in bytes
+	Length uint64
+	// MaxLength is the maximum length of all extrinsics of a block, in bytes
+	MaxLength     uint64
+	LengthPercent float64
+
+	// Weight holds the utilization of every dispatch class, relative to the maximum total weight of the class, or to
+	// the maximum block weight if the class is not limited.
+	Weight types.PerDispatchClass[WeightUtilization]
+	// Total is the utilization of all dispatch classes, relative to the maximum block weight
+	Total WeightUtilization
+}
+
+// GetBlockUtilization computes how full the block with the given hash is. The length of the block is the sum of the
+// encoded lengths of its extrinsics, the consumed weight is read from the System.BlockWeight storage, and both are
+// compared to the limits of the System.BlockLength and System.BlockWeights constants. If the metadata cache is enabled
+// and holds the runtime of the block, the cached metadata is used, see EnableMetadataCache.
+//
+// Only runtimes with two-dimensional weights are supported.
+func (api *SubstrateAPI) GetBlockUtilization(ctx context.Context, blockHash types.Hash) (*BlockUtilization, error) {
+	meta, err := api.getMetadata(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var blockWeights types.BlockWeights
+	if err := decodeConstant(meta, "BlockWeights", &blockWeights); err != nil {
+		return nil, err
+	}
+
+	var blockLength types.BlockLength
+	if err := decodeConstant(meta, "BlockLength", &blockLength); err != nil {
+		return nil, err
+	}
+
+	length, err := api.getExtrinsicsLength(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := types.CreateStorageKey(meta, "System", "BlockWeight")
+	if err != nil {
+		return nil, err
+	}
+
+	var consumed types.PerDispatchClass[types.Weight]
+	if _, err := api.RPC.State.GetStorageContext(ctx, key, &consumed, blockHash); err != nil {
+		return nil, err
+	}
+
+	maxLength := uint64(blockLength.Max.Normal)
+	for _, classMax := range []types.U32{blockLength.Max.Operational, blockLength.Max.Mandatory} {
+		if uint64(classMax) > maxLength {
+			maxLength = uint64(classMax)
+		}
+	}
+
+	classLimit := func(class types.WeightsPerClass) types.Weight {
+		if ok, maxTotal := class.MaxTotal.Unwrap(); ok {
+			return maxTotal
+		}
+
+		return blockWeights.MaxBlock
+	}
+
+	total := types.NewWeight(
+		addUCompact(consumed.Normal.RefTime, consumed.Operational.RefTime, consumed.Mandatory.RefTime),
+		addUCompact(consumed.Normal.ProofSize, consumed.Operational.ProofSize, consumed.Mandatory.ProofSize),
+	)
+
+	return &BlockUtilization{
+		Length:        length,
+		MaxLength:     maxLength,
+		LengthPercent: percent(new(big.Int).SetUint64(length), new(big.Int).SetUint64(maxLength)),
+		Weight: types.PerDispatchClass[WeightUtilization]{
+			Normal:      newWeightUtilization(consumed.Normal, classLimit(blockWeights.PerClass.Normal)),
+			Operational: newWeightUtilization(consumed.Operational, classLimit(blockWeights.PerClass.Operational)),
+			Mandatory:   newWeightUtilization(consumed.Mandatory, classLimit(blockWeights.PerClass.Mandatory)),
+		},
+		Total: newWeightUtilization(total, blockWeights.MaxBlock),
+	}, nil
+}
+
+// getExtrinsicsLength returns the sum of the encoded lengths of the extrinsics of the block. The extrinsics are not
+// decoded, so that blocks of chains with custom signed extensions are supported as well.
+func (api *SubstrateAPI) getExtrinsicsLength(ctx context.Context, blockHash types.Hash) (uint64, error) {
+	var block struct {
+		Block struct {
+			Extrinsics []string `json:"extrinsics"`
+		} `json:"block"`
+	}
+
+	if err := client.CallWithBlockHashContext(ctx, api.Client, &block, "chain_getBlock", &blockHash); err != nil {
+		return 0, err
+	}
+
+	var length uint64
+
+	for _, xt := range block.Block.Extrinsics {
+		b, err := codec.HexDecodeString(xt)
+		if err != nil {
+			return 0, err
+		}
+
+		length += uint64(len(b))
+	}
+
+	return length, nil
+}
+
+func decodeConstant(meta *types.Metadata, name string, target interface{}) error {
+	b, err := meta.FindConstantValue("System", name)
+	if err != nil {
+		return err
+	}
+
+	if err := codec.Decode(b, target); err != nil {
+		return fmt.Errorf("decoding System.%s: %w", name, err)
+	}
+
+	return nil
+}
+
+func newWeightUtilization(consumed, limit types.Weight) WeightUtilization {
+	return WeightUtilization{
+		Consumed:         consumed,
+		Limit:            limit,
+		RefTimePercent:   percent((*big.Int)(&consumed.RefTime), (*big.Int)(&limit.RefTime)),
+		ProofSizePercent: percent((*big.Int)(&consumed.ProofSize), (*big.Int)(&limit.ProofSize)),
+	}
+}
+
+func addUCompact(values ...types.UCompact) types.UCompact {
+	sum := new(big.Int)
+
+	for _, v := range values {
+		sum.Add(sum, (*big.Int)(&v))
+	}
+
+	return types.NewUCompact(sum)
+}
+
+// percent returns value relative to limit in percent, or 0 if there is no limit.
+func percent(value, limit *big.Int) float64 {
+	if limit.Sign() == 0 {
+		return 0
+	}
+
+	res, _ := new(big.Float).Quo(new(big.Float).SetInt(value), new(big.Float).SetInt(limit)).Float64()
+
+	return res * 100
+}
@@ -0,0 +1,92 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsrpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const systemBlockWeightKey = "0x26aa394eea5630e07c48ae0c9558cef734abf5cb34d6244378cddbf18e849d96"
+
+func TestSubstrateAPI_GetBlockUtilization(t *testing.T) {
+	blockWeight, err := codec.EncodeToHex(types.PerDispatchClass[types.Weight]{
+		Normal:      types.NewWeight(types.NewUCompactFromUInt(750_000_000_000), types.NewUCompactFromUInt(393_216)),
+		Operational: types.NewWeight(types.NewUCompactFromUInt(0), types.NewUCompactFromUInt(0)),
+		Mandatory:   types.NewWeight(types.NewUCompactFromUInt(200_000_000_000), types.NewUCompactFromUInt(524_288)),
+	})
+	require.NoError(t, err)
+
+	cl := rpcmocksrv.NewMockClient().
+		Respond("state_getMetadata", test.PolkadotMetadataHex, testBlockHash.Hex()).
+		Respond("chain_getBlock", map[string]interface{}{
+			"block": map[string]interface{}{
+				"extrinsics": []string{"0x0c0102", "0x1001020304"},
+			},
+		}, testBlockHash.Hex()).
+		Respond("state_getStorage", blockWeight, systemBlockWeightKey, testBlockHash.Hex())
+
+	api := newTestSubstrateAPI(t, cl)
+
+	res, err := api.GetBlockUtilization(context.Background(), testBlockHash)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(8), res.Length)
+	assert.Equal(t, uint64(5_242_880), res.MaxLength)
+	assert.InDelta(t, 0.000152, res.LengthPercent, 0.000001)
+
+	// The normal class is limited by its maximum total weight.
+	assert.Equal(
+		t,
+		types.NewWeight(types.NewUCompactFromUInt(1_500_000_000_000), types.NewUCompactFromUInt(3_932_160)),
+		res.Weight.Normal.Limit,
+	)
+	assert.InDelta(t, 50, res.Weight.Normal.RefTimePercent, 0.001)
+	assert.InDelta(t, 10, res.Weight.Normal.ProofSizePercent, 0.001)
+
+	assert.Zero(t, res.Weight.Operational.RefTimePercent)
+	assert.Zero(t, res.Weight.Operational.ProofSizePercent)
+
+	// The mandatory class is only limited by the maximum block weight.
+	assert.Equal(
+		t,
+		types.NewWeight(types.NewUCompactFromUInt(2_000_000_000_000), types.NewUCompactFromUInt(5_242_880)),
+		res.Weight.Mandatory.Limit,
+	)
+	assert.InDelta(t, 10, res.Weight.Mandatory.RefTimePercent, 0.001)
+	assert.InDelta(t, 10, res.Weight.Mandatory.ProofSizePercent, 0.001)
+
+	assert.InDelta(t, 47.5, res.Total.RefTimePercent, 0.001)
+	assert.InDelta(t, 17.5, res.Total.ProofSizePercent, 0.001)
+}
+
+func TestSubstrateAPI_GetBlockUtilization_LegacyWeights(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Respond("state_getMetadata", types.MetadataV14Data, testBlockHash.Hex())
+
+	api := newTestSubstrateAPI(t, cl)
+
+	_, err := api.GetBlockUtilization(context.Background(), testBlockHash)
+	assert.ErrorContains(t, err, "decoding System.BlockWeights")
+}
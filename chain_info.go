@@ -0,0 +1,253 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	ErrChainInfoRetrieval      = libErr.Error("chain info retrieval")
+	ErrChainPropertiesDecoding = libErr.Error("chain properties decoding")
+	ErrChainConstantDecoding   = libErr.Error("chain constant decoding")
+)
+
+// Token is a token of the chain as announced by its system properties.
+type Token struct {
+	Symbol   string
+	Decimals uint32
+}
+
+// ChainInfo holds the properties of the chain and its runtime that most applications need, e.g. to format balances
+// and encode addresses.
+type ChainInfo struct {
+	Chain              string
+	SpecName           string
+	SpecVersion        uint32
+	TransactionVersion uint32
+
+	// Tokens are the tokens announced by the system properties, the native token first. Most chains have a single
+	// token, multi-token chains, e.g. Acala, announce several.
+	Tokens []Token
+
+	// SS58Prefix is the SS58 format of the system properties, or the System.SS58Prefix constant if the properties
+	// do not announce one.
+	SS58Prefix uint16
+
+	// ExistentialDeposit is the Balances.ExistentialDeposit constant, nil if the runtime has no Balances pallet.
+	ExistentialDeposit *types.Balance
+
+	ss58Format *uint16 // of the system properties
+}
+
+// NativeToken returns the first token of the chain, or the zero Token if the chain announces none.
+func (i *ChainInfo) NativeToken() Token {
+	if len(i.Tokens) == 0 {
+		return Token{}
+	}
+
+	return i.Tokens[0]
+}
+
+// FormatBalance formats the balance in the native token, e.g. "12.3456 DOT", see types.Balance.Format.
+func (i *ChainInfo) FormatBalance(balance types.Balance) string {
+	token := i.NativeToken()
+
+	return balance.Format(token.Decimals, token.Symbol)
+}
+
+// chainProperties are the system properties of a chain, whose token fields are either single values or arrays.
+type chainProperties struct {
+	SS58Format    *uint16         `json:"ss58Format"`
+	TokenDecimals json.RawMessage `json:"tokenDecimals"`
+	TokenSymbol   json.RawMessage `json:"tokenSymbol"`
+}
+
+// ChainInfo returns the ChainInfo of the latest runtime. It is cached and only retrieved again after a runtime
+// upgrade, which is detected via the metadata cache if it is enabled, see EnableMetadataCache, or by retrieving the
+// latest runtime version otherwise. The returned ChainInfo is shared and must not be modified.
+func (api *SubstrateAPI) ChainInfo(ctx context.Context) (*ChainInfo, error) {
+	version, meta, err := api.GetRuntimeCached()
+	if err != nil {
+		latest, err := api.RPC.State.GetRuntimeVersionLatestContext(ctx)
+		if err != nil {
+			return nil, ErrChainInfoRetrieval.Wrap(err)
+		}
+
+		version, meta = *latest, nil
+	}
+
+	api.chainInfoMu.Lock()
+	defer api.chainInfoMu.Unlock()
+
+	cached := api.chainInfo
+	if cached != nil &&
+		cached.SpecVersion == uint32(version.SpecVersion) &&
+		cached.TransactionVersion == uint32(version.TransactionVersion) {
+		return cached, nil
+	}
+
+	if meta == nil {
+		// The runtime version and the metadata are retrieved at the same block, so that they belong together.
+		blockHash, err := api.RPC.Chain.GetBlockHashLatestContext(ctx)
+		if err != nil {
+			return nil, ErrChainInfoRetrieval.Wrap(err)
+		}
+
+		atBlock, err := api.RPC.State.GetRuntimeVersionContext(ctx, blockHash)
+		if err != nil {
+			return nil, ErrChainInfoRetrieval.Wrap(err)
+		}
+
+		if meta, err = api.RPC.State.GetMetadataContext(ctx, blockHash); err != nil {
+			return nil, ErrChainInfoRetrieval.Wrap(err)
+		}
+
+		version = *atBlock
+	}
+
+	info, err := api.getChainInfo(ctx, cached, version, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	api.chainInfo = info
+
+	return info, nil
+}
+
+// getChainInfo returns the ChainInfo of the runtime. The chain name and the properties do not change with runtime
+// upgrades and are taken from the previous ChainInfo if there is one.
+func (api *SubstrateAPI) getChainInfo(
+	ctx context.Context,
+	previous *ChainInfo,
+	version types.RuntimeVersion,
+	meta *types.Metadata,
+) (*ChainInfo, error) {
+	info := &ChainInfo{
+		SpecName:           string(version.SpecName),
+		SpecVersion:        uint32(version.SpecVersion),
+		TransactionVersion: uint32(version.TransactionVersion),
+	}
+
+	if previous != nil {
+		info.Chain, info.Tokens, info.ss58Format = previous.Chain, previous.Tokens, previous.ss58Format
+	} else {
+		chain, err := api.RPC.System.ChainContext(ctx)
+		if err != nil {
+			return nil, ErrChainInfoRetrieval.Wrap(err)
+		}
+
+		var props chainProperties
+
+		if err := api.Client.CallContext(ctx, &props, "system_properties"); err != nil {
+			return nil, ErrChainInfoRetrieval.Wrap(err)
+		}
+
+		tokens, err := decodeTokens(props)
+		if err != nil {
+			return nil, ErrChainPropertiesDecoding.Wrap(err)
+		}
+
+		info.Chain, info.Tokens, info.ss58Format = string(chain), tokens, props.SS58Format
+	}
+
+	if info.ss58Format != nil {
+		info.SS58Prefix = *info.ss58Format
+	} else if value, err := meta.FindConstantValue("System", "SS58Prefix"); err == nil {
+		if err := codec.Decode(value, &info.SS58Prefix); err != nil {
+			return nil, ErrChainConstantDecoding.WithMsg("System.SS58Prefix").Wrap(err)
+		}
+	}
+
+	if value, err := meta.FindConstantValue("Balances", "ExistentialDeposit"); err == nil {
+		var existentialDeposit types.Balance
+
+		if err := codec.Decode(value, &existentialDeposit); err != nil {
+			return nil, ErrChainConstantDecoding.WithMsg("Balances.ExistentialDeposit").Wrap(err)
+		}
+
+		info.ExistentialDeposit = &existentialDeposit
+	}
+
+	return info, nil
+}
+
+// decodeTokens pairs the token symbols with the token decimals of the properties, both are either single values or
+// arrays of the same length.
+func decodeTokens(props chainProperties) ([]Token, error) {
+	var (
+		symbols  []string
+		decimals []uint32
+	)
+
+	if err := decodeOneOrMany(props.TokenSymbol, &symbols); err != nil {
+		return nil, err
+	}
+
+	if err := decodeOneOrMany(props.TokenDecimals, &decimals); err != nil {
+		return nil, err
+	}
+
+	count := len(symbols)
+	if len(decimals) > count {
+		count = len(decimals)
+	}
+
+	tokens := make([]Token, count)
+
+	for i := range tokens {
+		if i < len(symbols) {
+			tokens[i].Symbol = symbols[i]
+		}
+
+		if i < len(decimals) {
+			tokens[i].Decimals = decimals[i]
+		}
+	}
+
+	return tokens, nil
+}
+
+// decodeOneOrMany decodes a JSON value that is either a single value or an array of values into the slice.
+func decodeOneOrMany[T any](data json.RawMessage, target *[]T) error {
+	data = bytes.TrimSpace(data)
+
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if data[0] == '[' {
+		return json.Unmarshal(data, target)
+	}
+
+	var value T
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*target = []T{value}
+
+	return nil
+}
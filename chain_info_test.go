@@ -0,0 +1,129 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsrpc_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countCalls(cl *rpcmocksrv.MockClient, method string) int {
+	var count int
+
+	for _, call := range cl.Calls() {
+		if call.Method == method {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestSubstrateAPI_ChainInfo(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Respond("system_chain", "Polkadot").
+		Respond("system_properties", map[string]interface{}{
+			"ss58Format":    0,
+			"tokenDecimals": 10,
+			"tokenSymbol":   "DOT",
+		}).
+		Respond("chain_getBlockHash", testBlockHash.Hex()).
+		// The latest runtime version is retrieved by every call, and at the latest block once the runtime changed.
+		Respond("state_getRuntimeVersion", testRuntimeVersion).
+		Respond("state_getRuntimeVersion", testRuntimeVersion, testBlockHash.Hex()).
+		Respond("state_getRuntimeVersion", testRuntimeVersion).
+		Respond("state_getRuntimeVersion", testUpgradedVersion)
+
+	api := newTestSubstrateAPI(t, cl)
+
+	info, err := api.ChainInfo(context.Background())
+	require.NoError(t, err)
+
+	existentialDeposit := types.NewBalance(*big.NewInt(100_000_000_000_000))
+
+	assert.Equal(t, "Polkadot", info.Chain)
+	assert.Equal(t, "test", info.SpecName)
+	assert.Equal(t, uint32(1), info.SpecVersion)
+	assert.Equal(t, []gsrpc.Token{{Symbol: "DOT", Decimals: 10}}, info.Tokens)
+	assert.Equal(t, uint16(0), info.SS58Prefix)
+	assert.Equal(t, &existentialDeposit, info.ExistentialDeposit)
+	assert.Equal(t, "10000 DOT", info.FormatBalance(existentialDeposit))
+
+	cached, err := api.ChainInfo(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, info, cached)
+
+	// Only the runtime dependent fields are retrieved again after a runtime upgrade.
+	upgraded, err := api.ChainInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), upgraded.SpecVersion)
+	assert.Equal(t, "Polkadot", upgraded.Chain)
+	assert.Equal(t, info.Tokens, upgraded.Tokens)
+	assert.Equal(t, uint16(0), upgraded.SS58Prefix)
+
+	assert.Equal(t, 1, countCalls(cl, "system_chain"))
+	assert.Equal(t, 1, countCalls(cl, "system_properties"))
+	// The metadata is retrieved by NewRPC as well.
+	assert.Equal(t, 3, countCalls(cl, "state_getMetadata"))
+}
+
+func TestSubstrateAPI_ChainInfo_MultiToken(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Respond("system_chain", "Acala").
+		Respond("system_properties", map[string]interface{}{
+			"tokenDecimals": []uint32{12, 12, 10},
+			"tokenSymbol":   []string{"ACA", "AUSD", "DOT"},
+		}).
+		Respond("chain_getBlockHash", testBlockHash.Hex()).
+		Respond("state_getRuntimeVersion", testRuntimeVersion)
+
+	info, err := newTestSubstrateAPI(t, cl).ChainInfo(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []gsrpc.Token{
+		{Symbol: "ACA", Decimals: 12},
+		{Symbol: "AUSD", Decimals: 12},
+		{Symbol: "DOT", Decimals: 10},
+	}, info.Tokens)
+	assert.Equal(t, gsrpc.Token{Symbol: "ACA", Decimals: 12}, info.NativeToken())
+
+	// Without an SS58 format in the properties, the prefix is taken from the metadata.
+	assert.Equal(t, uint16(42), info.SS58Prefix)
+}
+
+func TestSubstrateAPI_ChainInfo_Error(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Respond("system_chain", "Polkadot").
+		Respond("system_properties", map[string]interface{}{"tokenSymbol": 1}).
+		Respond("chain_getBlockHash", testBlockHash.Hex()).
+		Respond("state_getRuntimeVersion", testRuntimeVersion)
+
+	_, err := newTestSubstrateAPI(t, cl).ChainInfo(context.Background())
+	assert.ErrorIs(t, err, gsrpc.ErrChainPropertiesDecoding)
+
+	cl = rpcmocksrv.NewMockClient().RespondError("state_getRuntimeVersion", testMetadataCacheErr)
+
+	_, err = newTestSubstrateAPI(t, cl).ChainInfo(context.Background())
+	assert.ErrorIs(t, err, gsrpc.ErrChainInfoRetrieval)
+}
@@ -0,0 +1,103 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// BatchResult holds the outcome of a single call that was sent as part of a batch.
+type BatchResult[T any] struct {
+	Value T
+	Err   error
+}
+
+// Batch queues calls that are sent to the node as a single JSON-RPC batch request.
+//
+// The calls are reported in the order in which they were added, and the failure of one call does not
+// affect the others.
+type Batch struct {
+	client Client
+	elems  []gethrpc.BatchElem
+}
+
+// NewBatch creates a new, empty batch that is sent via the provided client
+func NewBatch(c Client) *Batch {
+	return &Batch{client: c}
+}
+
+// Add queues a call to the RPC method with the provided args, returning the index of the call in the batch.
+// Once the batch is sent, the response is unmarshalled into result, which must be a non-nil pointer.
+func (b *Batch) Add(result interface{}, method string, args ...interface{}) int {
+	b.elems = append(b.elems, gethrpc.BatchElem{
+		Method: method,
+		Args:   args,
+		Result: result,
+	})
+
+	return len(b.elems) - 1
+}
+
+// AddWithBlockHash is the batch equivalent of CallWithBlockHash, the block hash is appended to the args if provided.
+func (b *Batch) AddWithBlockHash(
+	result interface{},
+	method string,
+	blockHash *types.Hash,
+	args ...interface{},
+) int {
+	if blockHash != nil {
+		args = append(args, blockHash.Hex())
+	}
+
+	return b.Add(result, method, args...)
+}
+
+// Len returns the number of calls queued in the batch
+func (b *Batch) Len() int {
+	return len(b.elems)
+}
+
+// Send executes all the queued calls in a single request.
+//
+// The returned error is only set if the request could not be completed, the errors of the individual
+// calls are available via Err and Errors.
+func (b *Batch) Send(ctx context.Context) error {
+	if len(b.elems) == 0 {
+		return nil
+	}
+
+	return b.client.BatchCallContext(ctx, b.elems)
+}
+
+// Err returns the error of the call at index i, after the batch was sent
+func (b *Batch) Err(i int) error {
+	return b.elems[i].Error
+}
+
+// Errors returns the errors of all the calls, in the order in which they were added
+func (b *Batch) Errors() []error {
+	errs := make([]error, len(b.elems))
+
+	for i, elem := range b.elems {
+		errs[i] = elem.Error
+	}
+
+	return errs
+}
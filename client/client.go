@@ -18,6 +18,7 @@ package client
 
 import (
 	"context"
+	"time"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
@@ -47,6 +48,12 @@ type Client interface {
 		args ...interface{},
 	) (*gethrpc.ClientSubscription, error)
 
+	// BatchCallContext sends all the provided calls in a single batch request, see gethrpc.Client.BatchCallContext
+	BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error
+
+	// Batch returns a new, empty batch that is sent via this client
+	Batch() *Batch
+
 	URL() string
 
 	Close()
@@ -56,27 +63,69 @@ type client struct {
 	gethrpc.Client
 
 	url string
+
+	// headPollInterval enables the emulation of the heads subscriptions over HTTP, see ConnectHTTP.
+	headPollInterval time.Duration
+
+	// readLimit is the size limit of websocket messages, see ConnectionOptions.ReadLimit.
+	readLimit int64
 }
 
 // URL returns the URL the client connects to
-func (c client) URL() string {
+func (c *client) URL() string {
 	return c.url
 }
 
-func (c client) Close() {
+func (c *client) Close() {
 	c.Client.Close()
 }
 
-// Connect connects to the provided url
+func (c *client) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+// CallContext performs the call via gethrpc.Client.CallContext, errors returned by the node are converted into
+// types.RPCError
+func (c *client) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	err := c.Client.CallContext(ctx, result, method, args...)
+
+	return toRPCError(readLimitError(method, c.readLimit, err))
+}
+
+// BatchCallContext sends the batch via gethrpc.Client.BatchCallContext, errors returned by the node are converted
+// into types.RPCError
+func (c *client) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	err := c.Client.BatchCallContext(ctx, b)
+
+	toRPCErrors(b)
+
+	return toRPCError(readLimitError(BatchMethod, c.readLimit, err))
+}
+
+// Batch returns a new, empty batch that is sent via this client
+func (c *client) Batch() *Batch {
+	return NewBatch(c)
+}
+
+// Connect connects to the provided url. Besides websocket urls, http(s) urls are supported as well, but subscriptions
+// are not available over HTTP, see ConnectHTTP.
 func Connect(url string) (Client, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().DialTimeout)
+	c, err := connect(url, config.Default().DialTimeout, ConnectionOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func connect(url string, dialTimeout time.Duration, opts ConnectionOptions) (*client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
 	defer cancel()
 
-	c, err := gethrpc.DialContext(ctx, url)
+	c, err := opts.dial(ctx, url)
 	if err != nil {
 		return nil, err
 	}
-	cc := client{*c, url}
+	cc := client{Client: *c, url: url, readLimit: opts.readLimit()}
 	return &cc, nil
 }
 
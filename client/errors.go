@@ -0,0 +1,55 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// toRPCError converts an error that was returned by the node into a types.RPCError, all other errors are returned
+// unchanged.
+func toRPCError(err error) error {
+	rpcErr, ok := err.(gethrpc.Error)
+	if !ok {
+		return err
+	}
+
+	if _, ok := err.(types.RPCError); ok {
+		return err
+	}
+
+	res := types.RPCError{
+		Code:    rpcErr.ErrorCode(),
+		Message: rpcErr.Error(),
+	}
+
+	if dataErr, ok := err.(gethrpc.DataError); ok {
+		res.Data = dataErr.ErrorData()
+	}
+
+	return res
+}
+
+// toRPCErrors converts the errors of the batch elements, see toRPCError.
+func toRPCErrors(b []gethrpc.BatchElem) {
+	for i := range b {
+		if b[i].Error != nil {
+			b[i].Error = toRPCError(b[i].Error)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RPCError(t *testing.T) {
+	node := newTestNode(t)
+
+	c, err := Connect(node.url())
+	require.NoError(t, err)
+	defer c.Close()
+
+	var res string
+	err = c.Call(&res, "author_submitExtrinsic", "0x00")
+
+	var rpcErr types.RPCError
+	require.True(t, errors.As(err, &rpcErr))
+
+	assert.Equal(t, types.RPCError{
+		Code:    1010,
+		Message: "Invalid Transaction",
+		Data:    "Inability to pay some fees (e.g. account balance too low)",
+	}, rpcErr)
+	assert.True(t, types.IsInvalidTransaction(err))
+
+	err = c.Call(&res, "test_unknown")
+	assert.True(t, types.IsMethodNotFound(err))
+
+	batch := c.Batch()
+	batch.Add(&res, "test_echo", "value")
+	require.NoError(t, batch.Send(context.Background()))
+	assert.NoError(t, batch.Err(0))
+}
+
+func TestReconnectingClient_RPCError(t *testing.T) {
+	node := newTestNode(t)
+
+	c, _ := connectTestNode(t, node, nil)
+	defer c.Close()
+
+	var res string
+	err := c.Call(&res, "author_submitExtrinsic", "0x00")
+	assert.True(t, types.IsInvalidTransaction(err))
+}
+
+func TestToRPCError(t *testing.T) {
+	testErr := errors.New("test error")
+	assert.Equal(t, testErr, toRPCError(testErr))
+	assert.Nil(t, toRPCError(nil))
+
+	rpcErr := types.RPCError{Code: 1, Message: "test"}
+	assert.Equal(t, rpcErr, toRPCError(rpcErr))
+}
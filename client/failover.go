@@ -0,0 +1,139 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	defaultHealthCheckInterval = 15 * time.Second
+	healthCheckTimeout         = 5 * time.Second
+)
+
+// EndpointPolicy determines which endpoint a client created via ConnectWithFailover connects to.
+type EndpointPolicy uint8
+
+const (
+	// EndpointPolicyPrimaryFallback prefers the endpoints in the order in which they are provided. The client fails
+	// over to the next endpoint if the active one fails and fails back once the first endpoint is healthy again.
+	EndpointPolicyPrimaryFallback EndpointPolicy = iota
+	// EndpointPolicyRoundRobin moves on to the next endpoint, in the order in which they are provided, whenever the
+	// active one fails.
+	EndpointPolicyRoundRobin
+)
+
+// FailoverOptions configure a client created via ConnectWithFailover. Zero values are replaced by the defaults.
+type FailoverOptions struct {
+	ReconnectOptions
+
+	// Policy selects the endpoint to connect to.
+	Policy EndpointPolicy
+
+	// HealthCheckInterval is the interval in which the health of the active endpoint is checked via system_health.
+	HealthCheckInterval time.Duration
+}
+
+// EndpointError is returned by clients created via ConnectWithFailover, it holds the endpoint the error occurred on.
+type EndpointError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *EndpointError) Error() string {
+	return fmt.Sprintf("endpoint %s: %s", e.Endpoint, e.Err)
+}
+
+func (e *EndpointError) Unwrap() error {
+	return e.Err
+}
+
+// ConnectWithFailover connects to the first available of the provided websocket endpoints and fails over to another
+// one once the connection is lost or the active endpoint is unhealthy, i.e. it is syncing or has no peers although
+// it should have some. The endpoints are chosen according to the policy.
+//
+// The client behaves like the one returned by ConnectWithReconnect, subscriptions are migrated to the new endpoint
+// and signal a gap. Errors of calls are returned as EndpointError.
+func ConnectWithFailover(endpoints []string, opts FailoverOptions) (Client, error) {
+	return connectWithFailover(endpoints, opts, opts.Connection.dial)
+}
+
+// dialOrder returns the indexes of the endpoints in the order in which they are tried when replacing the endpoint
+// at index from, which is always tried last.
+func (c *reconnectingClient) dialOrder(from int) []int {
+	n := len(c.endpoints)
+	order := make([]int, 0, n)
+
+	switch c.opts.Policy {
+	case EndpointPolicyRoundRobin:
+		for i := 1; i <= n; i++ {
+			order = append(order, (from+i)%n)
+		}
+	default:
+		for i := 0; i < n; i++ {
+			if i != from {
+				order = append(order, i)
+			}
+		}
+
+		order = append(order, from)
+	}
+
+	return order
+}
+
+// checkHealth returns a connection to a healthy endpoint that should replace conn, or nil if conn should be kept.
+func (c *reconnectingClient) checkHealth(conn *gethrpc.Client) (*gethrpc.Client, int) {
+	active := c.activeIndex()
+
+	var order []int
+
+	switch {
+	case !c.isHealthy(conn):
+		order = c.dialOrder(active)
+		order = order[:len(order)-1]
+	case c.opts.Policy == EndpointPolicyPrimaryFallback && active != 0:
+		order = []int{0}
+	default:
+		return nil, 0
+	}
+
+	next, i, err := c.dialOnce(order, true)
+	if err != nil {
+		return nil, 0
+	}
+
+	return next, i
+}
+
+func (c *reconnectingClient) isHealthy(conn *gethrpc.Client) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	var health types.Health
+
+	if err := conn.CallContext(ctx, &health, "system_health"); err != nil {
+		return false
+	}
+
+	return !health.IsSyncing && (!health.ShouldHavePeers || health.Peers > 0)
+}
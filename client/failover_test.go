@@ -0,0 +1,165 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectWithFailover_NoEndpoints(t *testing.T) {
+	c, err := ConnectWithFailover(nil, FailoverOptions{})
+	assert.Equal(t, ErrNoEndpoints, err)
+	assert.Nil(t, c)
+}
+
+func TestConnectWithFailover_SkipsUnavailableEndpoints(t *testing.T) {
+	primary := newTestNode(t)
+	fallback := newTestNode(t)
+
+	primary.setDown(true)
+
+	c, _ := connectTestNodes(t, FailoverOptions{}, nil, primary, fallback)
+	defer c.Close()
+
+	assert.Equal(t, fallback.url(), c.URL())
+}
+
+func TestReconnectingClient_FailoverMidStream(t *testing.T) {
+	primary := newTestNode(t)
+	fallback := newTestNode(t)
+
+	c, states := connectTestNodes(t, FailoverOptions{}, nil, primary, fallback)
+	defer c.Close()
+
+	assert.Equal(t, primary.url(), c.URL())
+
+	ch := make(chan string)
+
+	sub, err := c.Subscribe(context.Background(), "test", "subscribe", "unsubscribe", "notification", ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	assert.Equal(t, "sub-1", receive(t, ch))
+
+	primary.setDown(true)
+	primary.dropConnections()
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+	assert.Equal(t, ConnectionStateConnected, waitState(t, states))
+
+	select {
+	case <-sub.Gap():
+	case <-time.After(5 * time.Second):
+		t.Fatal("gap was not signaled")
+	}
+
+	assert.Equal(t, "sub-1", receive(t, ch))
+	assert.Equal(t, fallback.url(), c.URL())
+	assert.Equal(t, 1, fallback.subscriptionCount())
+
+	var res string
+	require.NoError(t, c.Call(&res, "test_echo", "after"))
+	assert.Equal(t, "after", res)
+}
+
+func TestReconnectingClient_EndpointError(t *testing.T) {
+	primary := newTestNode(t)
+	fallback := newTestNode(t)
+
+	c, _ := connectTestNodes(t, FailoverOptions{}, nil, primary, fallback)
+	defer c.Close()
+
+	var res string
+	err := c.Call(&res, "test_unknown")
+
+	var endpointErr *EndpointError
+	require.True(t, errors.As(err, &endpointErr))
+	assert.Equal(t, primary.url(), endpointErr.Endpoint)
+
+	var rpcErr gethrpc.Error
+	require.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, -32601, rpcErr.ErrorCode())
+}
+
+func TestReconnectingClient_FailoverUnhealthy(t *testing.T) {
+	primary := newTestNode(t)
+	fallback := newTestNode(t)
+
+	opts := FailoverOptions{
+		HealthCheckInterval: 20 * time.Millisecond,
+	}
+
+	c, states := connectTestNodes(t, opts, nil, primary, fallback)
+	defer c.Close()
+
+	primary.setSyncing(true)
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+	assert.Equal(t, ConnectionStateConnected, waitState(t, states))
+	assert.Equal(t, fallback.url(), c.URL())
+
+	// The client fails back to the primary endpoint once it is healthy again.
+	primary.setSyncing(false)
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+	assert.Equal(t, ConnectionStateConnected, waitState(t, states))
+	assert.Equal(t, primary.url(), c.URL())
+}
+
+func TestReconnectingClient_RoundRobin(t *testing.T) {
+	nodes := []*testNode{newTestNode(t), newTestNode(t), newTestNode(t)}
+
+	opts := FailoverOptions{
+		Policy: EndpointPolicyRoundRobin,
+	}
+
+	c, states := connectTestNodes(t, opts, nil, nodes...)
+	defer c.Close()
+
+	assert.Equal(t, nodes[0].url(), c.URL())
+
+	nodes[0].dropConnections()
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+	assert.Equal(t, ConnectionStateConnected, waitState(t, states))
+	assert.Equal(t, nodes[1].url(), c.URL())
+
+	nodes[1].dropConnections()
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+	assert.Equal(t, ConnectionStateConnected, waitState(t, states))
+	assert.Equal(t, nodes[2].url(), c.URL())
+}
+
+func TestReconnectingClient_DialOrder(t *testing.T) {
+	c := &reconnectingClient{endpoints: []string{"a", "b", "c"}}
+
+	assert.Equal(t, []int{1, 2, 0}, c.dialOrder(0))
+	assert.Equal(t, []int{0, 2, 1}, c.dialOrder(1))
+
+	c.opts.Policy = EndpointPolicyRoundRobin
+
+	assert.Equal(t, []int{1, 2, 0}, c.dialOrder(0))
+	assert.Equal(t, []int{2, 0, 1}, c.dialOrder(1))
+}
@@ -0,0 +1,203 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	ErrSubscriptionsUnsupported = libErr.Error("subscriptions are not supported by the transport")
+	ErrHeadPollChannel          = libErr.Error("head poll channel must be of type chan types.Header")
+)
+
+// HTTPOptions configure a client created via ConnectHTTP.
+type HTTPOptions struct {
+	// HeadPollInterval enables the emulation of the new and finalized heads subscriptions if set, the node is polled
+	// for new heads in this interval.
+	HeadPollInterval time.Duration
+
+	// Connection configures how the requests are sent.
+	Connection ConnectionOptions
+}
+
+// ConnectHTTP connects to the provided http(s) url.
+//
+// All calls work as usual, but since HTTP does not support subscriptions, Subscribe returns
+// ErrSubscriptionsUnsupported. The only exception are the chain_subscribeNewHead and chain_subscribeFinalizedHeads
+// subscriptions if HeadPollInterval is set, they are emulated by polling chain_getHeader. Heads that are produced
+// between two polls are skipped, which is signaled as gap on the subscription, see gethrpc.ClientSubscription.Gap.
+func ConnectHTTP(url string, opts HTTPOptions) (Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.Default().DialTimeout)
+	defer cancel()
+
+	c, err := opts.Connection.dial(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	cc := client{Client: *c, url: url, headPollInterval: opts.HeadPollInterval}
+	return &cc, nil
+}
+
+// Subscribe subscribes to the notifications of the node, see gethrpc.Client.Subscribe. For HTTP clients it returns
+// ErrSubscriptionsUnsupported, unless the subscription is emulated, see ConnectHTTP.
+func (c *client) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	sub, err := c.Client.Subscribe(
+		ctx,
+		namespace,
+		subscribeMethodSuffix,
+		unsubscribeMethodSuffix,
+		notificationMethodSuffix,
+		channel,
+		args...,
+	)
+	if !errors.Is(err, gethrpc.ErrNotificationsUnsupported) {
+		return sub, toRPCError(err)
+	}
+
+	if c.headPollInterval <= 0 {
+		return nil, ErrSubscriptionsUnsupported
+	}
+
+	switch namespace + "_" + subscribeMethodSuffix {
+	case "chain_subscribeNewHead":
+		return c.pollHeads(channel, false)
+	case "chain_subscribeFinalizedHeads":
+		return c.pollHeads(channel, true)
+	default:
+		return nil, ErrSubscriptionsUnsupported
+	}
+}
+
+// pollHeads emulates a heads subscription by polling the best or finalized head.
+func (c *client) pollHeads(channel interface{}, finalized bool) (*gethrpc.ClientSubscription, error) {
+	ch, ok := channel.(chan types.Header)
+	if !ok {
+		return nil, ErrHeadPollChannel
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	// Unsubscribe waits for the poll loop to exit, so that the channel can be closed afterwards.
+	sub := gethrpc.NewDetachedClientSubscription(nil, func() {
+		cancel()
+		<-done
+	})
+
+	go func() {
+		defer cancel()
+
+		err := c.pollHeadsLoop(ctx, sub, ch, finalized)
+
+		close(done)
+
+		if err != nil {
+			sub.Fail(err)
+		}
+	}()
+
+	return sub, nil
+}
+
+// pollHeadsLoop sends every new head to ch until ctx is done or polling fails.
+func (c *client) pollHeadsLoop(
+	ctx context.Context,
+	sub *gethrpc.ClientSubscription,
+	ch chan<- types.Header,
+	finalized bool,
+) error {
+	ticker := time.NewTicker(c.headPollInterval)
+	defer ticker.Stop()
+
+	var (
+		last       string
+		lastNumber types.BlockNumber
+	)
+
+	for {
+		header, err := c.head(ctx, finalized)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+
+		encoded, err := codec.EncodeToHex(header)
+		if err != nil {
+			return err
+		}
+
+		if encoded != last {
+			if last != "" && header.Number > lastNumber+1 {
+				sub.SignalGap()
+			}
+
+			select {
+			case ch <- header:
+			case <-ctx.Done():
+				return nil
+			}
+
+			last = encoded
+			lastNumber = header.Number
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// head returns the header of the best or finalized head.
+func (c *client) head(ctx context.Context, finalized bool) (types.Header, error) {
+	var header types.Header
+
+	if !finalized {
+		err := c.CallContext(ctx, &header, "chain_getHeader")
+		return header, err
+	}
+
+	var hash types.Hash
+
+	if err := c.CallContext(ctx, &hash, "chain_getFinalizedHead"); err != nil {
+		return header, err
+	}
+
+	err := CallWithBlockHashContext(ctx, c, &header, "chain_getHeader", &hash)
+
+	return header, err
+}
@@ -0,0 +1,244 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnect_HTTP(t *testing.T) {
+	node := newTestHTTPNode(t)
+
+	c, err := Connect(node.srv.URL)
+	require.NoError(t, err)
+	defer c.Close()
+
+	var res string
+	require.NoError(t, c.Call(&res, "system_chain"))
+	assert.Equal(t, "Test", res)
+
+	_, err = c.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead",
+		make(chan types.Header))
+	assert.Equal(t, ErrSubscriptionsUnsupported, err)
+}
+
+func TestConnectHTTP_UnsupportedSubscription(t *testing.T) {
+	node := newTestHTTPNode(t)
+
+	c, err := ConnectHTTP(node.srv.URL, HTTPOptions{HeadPollInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.Subscribe(context.Background(), "state", "subscribeRuntimeVersion", "unsubscribeRuntimeVersion",
+		"runtimeVersion", make(chan types.RuntimeVersion))
+	assert.Equal(t, ErrSubscriptionsUnsupported, err)
+
+	_, err = c.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead",
+		make(chan string))
+	assert.Equal(t, ErrHeadPollChannel, err)
+}
+
+func TestConnectHTTP_PollNewHeads(t *testing.T) {
+	node := newTestHTTPNode(t)
+
+	c, err := ConnectHTTP(node.srv.URL, HTTPOptions{HeadPollInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	defer c.Close()
+
+	ch := make(chan types.Header)
+
+	sub, err := c.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	require.NoError(t, err)
+
+	assert.Equal(t, types.BlockNumber(1), receiveHeader(t, ch).Number)
+
+	node.setHeads(2, 1)
+	assert.Equal(t, types.BlockNumber(2), receiveHeader(t, ch).Number)
+
+	select {
+	case <-sub.Gap():
+		t.Fatal("unexpected gap")
+	default:
+	}
+
+	node.setHeads(5, 1)
+	assert.Equal(t, types.BlockNumber(5), receiveHeader(t, ch).Number)
+
+	select {
+	case <-sub.Gap():
+	case <-time.After(5 * time.Second):
+		t.Fatal("gap was not signaled")
+	}
+
+	sub.Unsubscribe()
+
+	_, ok := <-sub.Err()
+	assert.False(t, ok)
+}
+
+func TestConnectHTTP_PollFinalizedHeads(t *testing.T) {
+	node := newTestHTTPNode(t)
+
+	c, err := ConnectHTTP(node.srv.URL, HTTPOptions{HeadPollInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	defer c.Close()
+
+	node.setHeads(5, 3)
+
+	ch := make(chan types.Header)
+
+	sub, err := c.Subscribe(context.Background(), "chain", "subscribeFinalizedHeads", "unsubscribeFinalizedHeads",
+		"finalizedHead", ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	assert.Equal(t, types.BlockNumber(3), receiveHeader(t, ch).Number)
+
+	node.setHeads(6, 4)
+	assert.Equal(t, types.BlockNumber(4), receiveHeader(t, ch).Number)
+}
+
+func TestConnectHTTP_PollError(t *testing.T) {
+	node := newTestHTTPNode(t)
+
+	c, err := ConnectHTTP(node.srv.URL, HTTPOptions{HeadPollInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	defer c.Close()
+
+	ch := make(chan types.Header)
+
+	sub, err := c.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	assert.Equal(t, types.BlockNumber(1), receiveHeader(t, ch).Number)
+
+	node.srv.Close()
+
+	select {
+	case err := <-sub.Err():
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription did not fail")
+	}
+}
+
+func receiveHeader(t *testing.T, ch <-chan types.Header) types.Header {
+	select {
+	case header := <-ch:
+		return header
+	case <-time.After(5 * time.Second):
+		t.Fatal("no header received")
+		return types.Header{}
+	}
+}
+
+// testHTTPNode is a minimal HTTP JSON-RPC server that serves the best and finalized headers. The number of a block is
+// encoded in the last byte of its hash.
+type testHTTPNode struct {
+	srv *httptest.Server
+
+	mu        sync.Mutex
+	best      uint8
+	finalized uint8
+}
+
+func newTestHTTPNode(t *testing.T) *testHTTPNode {
+	node := &testHTTPNode{best: 1, finalized: 1}
+	node.srv = httptest.NewServer(http.HandlerFunc(node.serve))
+
+	t.Cleanup(node.srv.Close)
+
+	return node
+}
+
+func (n *testHTTPNode) setHeads(best, finalized uint8) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.best = best
+	n.finalized = finalized
+}
+
+func (n *testHTTPNode) serve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params []string        `json:"params"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	n.mu.Lock()
+	best, finalized := n.best, n.finalized
+	n.mu.Unlock()
+
+	var result interface{}
+
+	switch req.Method {
+	case "system_chain":
+		result = "Test"
+	case "chain_getFinalizedHead":
+		result = testBlockHash(finalized)
+	case "chain_getHeader":
+		number := best
+
+		if len(req.Params) > 0 {
+			hash, err := types.NewHashFromHexString(req.Params[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			number = hash[len(hash)-1]
+		}
+
+		result = json.RawMessage(fmt.Sprintf(
+			`{"parentHash":"%s","number":"0x%x","stateRoot":"%s","extrinsicsRoot":"%s","digest":{"logs":[]}}`,
+			testBlockHash(number-1), number, testBlockHash(0), testBlockHash(0),
+		))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      req.ID,
+		"result":  result,
+	})
+}
+
+func testBlockHash(number uint8) string {
+	var hash types.Hash
+	hash[len(hash)-1] = number
+
+	return hash.Hex()
+}
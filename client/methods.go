@@ -0,0 +1,187 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"sync"
+
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	ErrUnsupportedMethod = libErr.Error("method is not supported by the node")
+)
+
+// MethodChecker is implemented by clients that know which methods the node exposes, see WithMethodDiscovery.
+type MethodChecker interface {
+	// Methods returns the methods the node exposes, or nil if they are unknown because the node does not support
+	// rpc_methods.
+	Methods(ctx context.Context) (*types.RPCMethods, error)
+}
+
+// WithMethodDiscovery returns a client that queries the methods the node exposes via rpc_methods on first use, and
+// fails calls and subscriptions of methods the node does not expose with ErrUnsupportedMethod instead of sending
+// them. If the node does not support rpc_methods, all methods are assumed to be supported.
+//
+// The methods are queried once, so they are not updated if a client created via ConnectWithFailover switches to an
+// endpoint that exposes different methods.
+func WithMethodDiscovery(c Client) Client {
+	return &methodsClient{Client: c}
+}
+
+// CheckMethod returns ErrUnsupportedMethod if the client knows that the node does not expose the method, see
+// WithMethodDiscovery. Clients that do not implement MethodChecker are assumed to support all methods.
+func CheckMethod(ctx context.Context, c Client, method string) error {
+	checker, ok := c.(MethodChecker)
+	if !ok {
+		return nil
+	}
+
+	methods, err := checker.Methods(ctx)
+	if err != nil {
+		// A failed discovery must not fail the actual request.
+		return nil //nolint:nilerr
+	}
+
+	if methods != nil && !methods.Has(method) {
+		return ErrUnsupportedMethod.WithMsg(method)
+	}
+
+	return nil
+}
+
+// AdvertisesMethod returns true if the node is known to expose the method, i.e. the client implements MethodChecker
+// and the method is listed by rpc_methods. Unlike CheckMethod, methods are assumed to be unsupported if unknown, so
+// it can be used to prefer optional methods over others that are always available.
+func AdvertisesMethod(ctx context.Context, c Client, method string) bool {
+	checker, ok := c.(MethodChecker)
+	if !ok {
+		return false
+	}
+
+	methods, err := checker.Methods(ctx)
+	if err != nil || methods == nil {
+		return false
+	}
+
+	return methods.Has(method)
+}
+
+type methodsClient struct {
+	Client
+
+	mu         sync.Mutex
+	discovered bool
+	methods    *types.RPCMethods
+}
+
+// Methods returns the methods the node exposes, they are queried via rpc_methods on first use.
+func (c *methodsClient) Methods(ctx context.Context) (*types.RPCMethods, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.discovered {
+		return c.methods, nil
+	}
+
+	var methods types.RPCMethods
+
+	err := c.Client.CallContext(ctx, &methods, "rpc_methods")
+	switch {
+	case types.IsMethodNotFound(err):
+		c.discovered = true
+	case err != nil:
+		// Transient errors are not cached, the discovery is retried on the next use.
+		return nil, err
+	default:
+		c.discovered = true
+		c.methods = &methods
+	}
+
+	return c.methods, nil
+}
+
+func (c *methodsClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *methodsClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if err := CheckMethod(ctx, c, method); err != nil {
+		return err
+	}
+
+	return c.Client.CallContext(ctx, result, method, args...)
+}
+
+// BatchCallContext sends the calls of supported methods, the elements of unsupported methods fail with
+// ErrUnsupportedMethod.
+func (c *methodsClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	supported := make([]gethrpc.BatchElem, 0, len(b))
+	indexes := make([]int, 0, len(b))
+
+	for i := range b {
+		if err := CheckMethod(ctx, c, b[i].Method); err != nil {
+			b[i].Error = err
+			continue
+		}
+
+		supported = append(supported, b[i])
+		indexes = append(indexes, i)
+	}
+
+	if len(supported) == 0 {
+		return nil
+	}
+
+	err := c.Client.BatchCallContext(ctx, supported)
+
+	for i, elem := range supported {
+		b[indexes[i]] = elem
+	}
+
+	return err
+}
+
+// Batch returns a new, empty batch that is sent via this client
+func (c *methodsClient) Batch() *Batch {
+	return NewBatch(c)
+}
+
+func (c *methodsClient) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	if err := CheckMethod(ctx, c, namespace+"_"+subscribeMethodSuffix); err != nil {
+		return nil, err
+	}
+
+	return c.Client.Subscribe(
+		ctx,
+		namespace,
+		subscribeMethodSuffix,
+		unsubscribeMethodSuffix,
+		notificationMethodSuffix,
+		channel,
+		args...,
+	)
+}
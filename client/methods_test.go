@@ -0,0 +1,96 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMethodDiscovery(t *testing.T) {
+	node := newTestNode(t)
+	node.setMethods("rpc_methods", "test_echo", "test_subscribe")
+
+	conn, err := Connect(node.url())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	c := WithMethodDiscovery(conn)
+
+	methods, err := c.(MethodChecker).Methods(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rpc_methods", "test_echo", "test_subscribe"}, methods.Methods)
+
+	var res string
+	require.NoError(t, c.Call(&res, "test_echo", "value"))
+	assert.Equal(t, "value", res)
+
+	err = c.Call(&res, "test_hang")
+	assert.True(t, errors.Is(err, ErrUnsupportedMethod))
+	assert.True(t, errors.Is(CheckMethod(context.Background(), c, "test_hang"), ErrUnsupportedMethod))
+	assert.NoError(t, CheckMethod(context.Background(), conn, "test_hang"))
+	assert.True(t, AdvertisesMethod(context.Background(), c, "test_echo"))
+	assert.False(t, AdvertisesMethod(context.Background(), c, "test_hang"))
+	assert.False(t, AdvertisesMethod(context.Background(), conn, "test_echo"))
+
+	ch := make(chan string)
+	sub, err := c.Subscribe(context.Background(), "test", "subscribe", "unsubscribe", "notification", ch)
+	require.NoError(t, err)
+	sub.Unsubscribe()
+
+	_, err = c.Subscribe(context.Background(), "author", "submitAndWatchExtrinsic", "unwatchExtrinsic",
+		"extrinsicUpdate", ch, "0x00")
+	assert.True(t, errors.Is(err, ErrUnsupportedMethod))
+	assert.Equal(t, 1, node.subscriptionCount())
+
+	var echo string
+	batch := c.Batch()
+	batch.Add(&echo, "test_echo", "batched")
+	batch.Add(&res, "test_hang")
+	require.NoError(t, batch.Send(context.Background()))
+	assert.NoError(t, batch.Err(0))
+	assert.Equal(t, "batched", echo)
+	assert.True(t, errors.Is(batch.Err(1), ErrUnsupportedMethod))
+}
+
+func TestWithMethodDiscovery_Unsupported(t *testing.T) {
+	node := newTestNode(t)
+
+	conn, err := Connect(node.url())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	c := WithMethodDiscovery(conn)
+
+	methods, err := c.(MethodChecker).Methods(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, methods)
+	assert.False(t, AdvertisesMethod(context.Background(), c, "test_echo"))
+
+	// Methods are assumed to be supported if the node does not support rpc_methods, so the node is asked.
+	node.setMethods("test_echo")
+
+	var res string
+	require.NoError(t, c.Call(&res, "test_echo", "value"))
+	err = c.Call(&res, "test_unknown")
+	assert.True(t, types.IsMethodNotFound(err))
+}
@@ -0,0 +1,412 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/metrics"
+)
+
+// BatchMethod is the method that interceptors see for batch requests, the only param is the []gethrpc.BatchElem.
+const BatchMethod = "batch"
+
+// Invoker performs an RPC call or subscription request. For calls, the returned result is the value the response was
+// unmarshalled into, for subscription requests it is the *gethrpc.ClientSubscription.
+type Invoker func(ctx context.Context, method string, params []interface{}) (interface{}, error)
+
+// Interceptor wraps every outgoing call and subscription request of a client. It must call next to proceed with the
+// request, and can inspect or modify the method, params, result and error along the way.
+//
+// The method of subscription requests is the subscribe method, e.g. chain_subscribeNewHead.
+type Interceptor func(ctx context.Context, method string, params []interface{}, next Invoker) (interface{}, error)
+
+// Notification is a notification that was received for a subscription.
+type Notification struct {
+	// Method is the subscribe method of the subscription, e.g. chain_subscribeNewHead
+	Method string
+
+	SubscriptionID string
+
+	// Result is the decoded notification, as it is delivered to the subscriber
+	Result interface{}
+
+	ReceivedAt time.Time
+}
+
+// NotificationHook is called for every notification that is received for a subscription, before the notification is
+// delivered to the subscriber. It must not block.
+type NotificationHook func(n Notification)
+
+// Middleware holds the interceptors and notification hooks that are applied to a client.
+type Middleware struct {
+	interceptors      []Interceptor
+	notificationHooks []NotificationHook
+	recorder          metrics.Recorder
+}
+
+// NewMiddleware creates a new, empty middleware
+func NewMiddleware() *Middleware {
+	return &Middleware{}
+}
+
+// Use registers an interceptor. Interceptors are applied in the order of registration, the first one being the
+// outermost.
+func (m *Middleware) Use(interceptor Interceptor) *Middleware {
+	m.interceptors = append(m.interceptors, interceptor)
+
+	return m
+}
+
+// OnNotification registers a notification hook. Hooks are called in the order of registration.
+func (m *Middleware) OnNotification(hook NotificationHook) *Middleware {
+	m.notificationHooks = append(m.notificationHooks, hook)
+
+	return m
+}
+
+// RecordMetrics sets the recorder that the calls, the active subscriptions and their notifications are reported to.
+func (m *Middleware) RecordMetrics(recorder metrics.Recorder) *Middleware {
+	m.recorder = recorder
+
+	return m
+}
+
+// Connect connects to the provided url via Connect and applies the middleware to the client
+func (m *Middleware) Connect(url string) (Client, error) {
+	c, err := Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Wrap(c), nil
+}
+
+// Wrap applies the middleware to the provided client. Later registrations do not affect the returned client.
+func (m *Middleware) Wrap(c Client) Client {
+	return &middlewareClient{
+		Client:            c,
+		interceptors:      append([]Interceptor(nil), m.interceptors...),
+		notificationHooks: append([]NotificationHook(nil), m.notificationHooks...),
+		recorder:          m.recorder,
+	}
+}
+
+type middlewareClient struct {
+	Client
+
+	interceptors      []Interceptor
+	notificationHooks []NotificationHook
+	recorder          metrics.Recorder
+}
+
+func (c *middlewareClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *middlewareClient) CallContext(
+	ctx context.Context,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	_, err := c.invoke(ctx, method, args, func(ctx context.Context, method string, params []interface{}) (
+		interface{},
+		error,
+	) {
+		start := time.Now()
+
+		err := c.Client.CallContext(ctx, result, method, params...)
+
+		c.recordCall(method, params, start, result, err)
+
+		return result, err
+	})
+
+	return err
+}
+
+func (c *middlewareClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	_, err := c.invoke(ctx, BatchMethod, []interface{}{b}, func(ctx context.Context, _ string, _ []interface{}) (
+		interface{},
+		error,
+	) {
+		start := time.Now()
+
+		err := c.Client.BatchCallContext(ctx, b)
+
+		// The results of the batch elements are not part of the response size, their errors are not returned.
+		c.recordCall(BatchMethod, []interface{}{b}, start, nil, err)
+
+		return b, err
+	})
+
+	return err
+}
+
+// Batch returns a new, empty batch that is sent via this client
+func (c *middlewareClient) Batch() *Batch {
+	return NewBatch(c)
+}
+
+func (c *middlewareClient) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	res, err := c.invoke(
+		ctx,
+		namespace+"_"+subscribeMethodSuffix,
+		args,
+		func(ctx context.Context, method string, params []interface{}) (interface{}, error) {
+			if len(c.notificationHooks) == 0 && c.recorder == nil {
+				return c.Client.Subscribe(
+					ctx,
+					namespace,
+					subscribeMethodSuffix,
+					unsubscribeMethodSuffix,
+					notificationMethodSuffix,
+					channel,
+					params...,
+				)
+			}
+
+			in := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, reflect.TypeOf(channel).Elem()), 0)
+
+			start := time.Now()
+
+			inner, err := c.Client.Subscribe(
+				ctx,
+				namespace,
+				subscribeMethodSuffix,
+				unsubscribeMethodSuffix,
+				notificationMethodSuffix,
+				in.Interface(),
+				params...,
+			)
+
+			c.recordCall(method, params, start, nil, err)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return c.forwardNotifications(method, inner, in, reflect.ValueOf(channel)), nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*gethrpc.ClientSubscription), nil
+}
+
+// invoke runs the interceptors around the base invoker.
+func (c *middlewareClient) invoke(
+	ctx context.Context,
+	method string,
+	params []interface{},
+	base Invoker,
+) (interface{}, error) {
+	next := base
+
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor, invoker := c.interceptors[i], next
+
+		next = func(ctx context.Context, method string, params []interface{}) (interface{}, error) {
+			return interceptor(ctx, method, params, invoker)
+		}
+	}
+
+	return next(ctx, method, params)
+}
+
+// recordCall reports a call to the recorder, the response size is only determined for successful calls with a result.
+func (c *middlewareClient) recordCall(
+	method string,
+	params []interface{},
+	start time.Time,
+	res interface{},
+	err error,
+) {
+	if c.recorder == nil {
+		return
+	}
+
+	call := metrics.Call{
+		Method:       method,
+		Code:         ErrorCode(err),
+		Duration:     time.Since(start),
+		RequestSize:  payloadSize(params),
+		ResponseSize: -1,
+	}
+
+	if err == nil && res != nil {
+		call.ResponseSize = payloadSize(res)
+	}
+
+	c.recorder.CallCompleted(call)
+}
+
+// forwardNotifications returns a subscription that delivers the notifications of inner, which are received from in,
+// to out after the notification hooks were called.
+func (c *middlewareClient) forwardNotifications(
+	method string,
+	inner *gethrpc.ClientSubscription,
+	in, out reflect.Value,
+) *gethrpc.ClientSubscription {
+	quit := make(chan struct{})
+	done := make(chan struct{})
+
+	var quitOnce sync.Once
+
+	// Unsubscribe waits for the forwarding to stop, so that the subscriber channel can be closed afterwards.
+	outer := gethrpc.NewDetachedClientSubscription(inner.ID, func() {
+		inner.Unsubscribe()
+		quitOnce.Do(func() { close(quit) })
+		<-done
+	})
+
+	if c.recorder != nil {
+		c.recorder.SubscriptionStarted(method)
+	}
+
+	go func() {
+		failed, err := c.forward(method, inner, outer, in, out, quit)
+
+		if c.recorder != nil {
+			c.recorder.SubscriptionEnded(method)
+		}
+
+		close(done)
+
+		if failed {
+			if err == nil {
+				// Adhere to the subscription semantics, the error channel receives nil for closed clients.
+				err = gethrpc.ErrClientQuit
+			}
+
+			outer.Fail(err)
+		}
+	}()
+
+	return outer
+}
+
+// forward runs until quit is closed or inner fails, in which case it returns true and the error of inner.
+func (c *middlewareClient) forward(
+	method string,
+	inner, outer *gethrpc.ClientSubscription,
+	in, out reflect.Value,
+	quit <-chan struct{},
+) (bool, error) {
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: in},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(quit)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(inner.Err())},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(inner.Gap())},
+	}
+
+	sendCases := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: out},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(quit)},
+	}
+
+	for {
+		chosen, recv, ok := reflect.Select(cases)
+
+		switch chosen {
+		case 0: // <-in
+			n := Notification{
+				Method:         method,
+				SubscriptionID: inner.ID(),
+				Result:         recv.Interface(),
+				ReceivedAt:     time.Now(),
+			}
+
+			if c.recorder != nil {
+				c.recorder.NotificationReceived(method, payloadSize(n.Result))
+			}
+
+			for _, hook := range c.notificationHooks {
+				hook(n)
+			}
+
+			sendCases[0].Send = recv
+
+			if chosen, _, _ := reflect.Select(sendCases); chosen == 1 {
+				return false, nil
+			}
+
+			sendCases[0].Send = reflect.Value{}
+		case 1: // <-quit
+			return false, nil
+		case 2: // <-inner.Err()
+			if !ok {
+				// The error channel is only closed on Unsubscribe.
+				return false, nil
+			}
+
+			err, _ := recv.Interface().(error)
+
+			return true, err
+		case 3: // <-inner.Gap()
+			outer.SignalGap()
+		}
+	}
+}
+
+// ErrorCode returns the label for the outcome of a call: "ok" on success, the JSON-RPC error code for errors returned
+// by the node, and a short description for all other errors.
+func ErrorCode(err error) string {
+	if err == nil {
+		return "ok"
+	}
+
+	var rpcErr gethrpc.Error
+	if errors.As(err, &rpcErr) {
+		return strconv.Itoa(rpcErr.ErrorCode())
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	default:
+		return "error"
+	}
+}
+
+// payloadSize returns the size of the JSON encoding of v, or -1 if it cannot be encoded.
+func payloadSize(v interface{}) int {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return -1
+	}
+
+	return len(b)
+}
@@ -0,0 +1,77 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+)
+
+// Logger logs calls, subscription requests and notifications with a structured logger.
+//
+// Successful calls and notifications are logged at debug level, failed calls at warn level.
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new Logger that logs to the provided logger
+func NewLogger(logger *slog.Logger) *Logger {
+	return &Logger{logger: logger}
+}
+
+// Intercept implements client.Interceptor
+func (l *Logger) Intercept(
+	ctx context.Context,
+	method string,
+	params []interface{},
+	next client.Invoker,
+) (interface{}, error) {
+	start := time.Now()
+
+	res, err := next(ctx, method, params)
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.Duration("duration", time.Since(start)),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("code", client.ErrorCode(err)), slog.String("error", err.Error()))
+
+		l.logger.LogAttrs(ctx, slog.LevelWarn, "RPC call failed", attrs...)
+
+		return res, err
+	}
+
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "RPC call", attrs...)
+
+	return res, nil
+}
+
+// OnNotification implements client.NotificationHook
+func (l *Logger) OnNotification(n client.Notification) {
+	l.logger.LogAttrs(
+		context.Background(),
+		slog.LevelDebug,
+		"RPC notification",
+		slog.String("method", n.Method),
+		slog.String("subscription", n.SubscriptionID),
+	)
+}
@@ -0,0 +1,99 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	next := func(ctx context.Context, method string, params []interface{}) (interface{}, error) {
+		return "result", nil
+	}
+
+	res, err := logger.Intercept(context.Background(), "system_chain", nil, next)
+	assert.NoError(t, err)
+	assert.Equal(t, "result", res)
+
+	entry := decodeLogEntry(t, &buf)
+	assert.Equal(t, "DEBUG", entry["level"])
+	assert.Equal(t, "RPC call", entry["msg"])
+	assert.Equal(t, "system_chain", entry["method"])
+	assert.Contains(t, entry, "duration")
+
+	failing := func(ctx context.Context, method string, params []interface{}) (interface{}, error) {
+		return nil, testRPCError{code: -32601}
+	}
+
+	_, err = logger.Intercept(context.Background(), "system_unknown", nil, failing)
+	assert.Equal(t, testRPCError{code: -32601}, err)
+
+	entry = decodeLogEntry(t, &buf)
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "RPC call failed", entry["msg"])
+	assert.Equal(t, "system_unknown", entry["method"])
+	assert.Equal(t, "-32601", entry["code"])
+	assert.Equal(t, "rpc error -32601", entry["error"])
+
+	logger.OnNotification(client.Notification{
+		Method:         "chain_subscribeNewHead",
+		SubscriptionID: "sub",
+		ReceivedAt:     time.Now(),
+	})
+
+	entry = decodeLogEntry(t, &buf)
+	assert.Equal(t, "DEBUG", entry["level"])
+	assert.Equal(t, "RPC notification", entry["msg"])
+	assert.Equal(t, "chain_subscribeNewHead", entry["method"])
+	assert.Equal(t, "sub", entry["subscription"])
+}
+
+func decodeLogEntry(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	var entry map[string]interface{}
+	require.NoError(t, json.NewDecoder(buf).Decode(&entry))
+
+	return entry
+}
+
+type testRPCError struct {
+	code int
+}
+
+func (e testRPCError) Error() string {
+	return fmt.Sprintf("rpc error %d", e.code)
+}
+
+func (e testRPCError) ErrorCode() int {
+	return e.code
+}
+
+var _ gethrpc.Error = testRPCError{}
@@ -0,0 +1,334 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware_Call(t *testing.T) {
+	node := newTestNode(t)
+
+	var calls []string
+
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, method string, params []interface{}, next Invoker) (interface{}, error) {
+			calls = append(calls, name+" "+method)
+
+			return next(ctx, method, params)
+		}
+	}
+
+	rewrite := func(ctx context.Context, method string, params []interface{}, next Invoker) (interface{}, error) {
+		if method != "test_echo" {
+			return next(ctx, method, params)
+		}
+
+		res, err := next(ctx, method, []interface{}{"rewritten"})
+
+		assert.Equal(t, "rewritten", *res.(*string))
+
+		return res, err
+	}
+
+	c, err := NewMiddleware().Use(record("first")).Use(record("second")).Use(rewrite).Connect(node.url())
+	require.NoError(t, err)
+	defer c.Close()
+
+	var res string
+	require.NoError(t, c.Call(&res, "test_echo", "original"))
+
+	assert.Equal(t, "rewritten", res)
+	assert.Equal(t, []string{"first test_echo", "second test_echo"}, calls)
+
+	err = c.Call(&res, "test_unknown")
+
+	var rpcErr gethrpc.Error
+	require.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, -32601, rpcErr.ErrorCode())
+
+	calls = nil
+
+	batch := c.Batch()
+	batch.Add(&res, "test_echo", "batched")
+	require.NoError(t, batch.Send(context.Background()))
+
+	assert.Equal(t, "batched", res)
+	assert.Equal(t, []string{"first batch", "second batch"}, calls)
+}
+
+func TestMiddleware_InterceptorError(t *testing.T) {
+	node := newTestNode(t)
+
+	testErr := errors.New("test error")
+
+	reject := func(ctx context.Context, method string, params []interface{}, next Invoker) (interface{}, error) {
+		return nil, testErr
+	}
+
+	c, err := NewMiddleware().Use(reject).Connect(node.url())
+	require.NoError(t, err)
+	defer c.Close()
+
+	var res string
+	assert.Equal(t, testErr, c.Call(&res, "test_echo", "value"))
+
+	sub, err := c.Subscribe(context.Background(), "test", "subscribe", "unsubscribe", "notification",
+		make(chan string))
+	assert.Equal(t, testErr, err)
+	assert.Nil(t, sub)
+	assert.Equal(t, 0, node.subscriptionCount())
+}
+
+func TestMiddleware_Subscribe(t *testing.T) {
+	node := newTestNode(t)
+
+	var (
+		mu            sync.Mutex
+		methods       []string
+		notifications []Notification
+	)
+
+	record := func(ctx context.Context, method string, params []interface{}, next Invoker) (interface{}, error) {
+		mu.Lock()
+		methods = append(methods, method)
+		mu.Unlock()
+
+		return next(ctx, method, params)
+	}
+
+	hook := func(n Notification) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		notifications = append(notifications, n)
+	}
+
+	c, err := NewMiddleware().Use(record).OnNotification(hook).Connect(node.url())
+	require.NoError(t, err)
+
+	ch := make(chan string)
+
+	sub, err := c.Subscribe(context.Background(), "test", "subscribe", "unsubscribe", "notification", ch)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sub-1", receive(t, ch))
+	assert.Equal(t, "sub-1", sub.ID())
+
+	mu.Lock()
+	assert.Equal(t, []string{"test_subscribe"}, methods)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "test_subscribe", notifications[0].Method)
+	assert.Equal(t, "sub-1", notifications[0].SubscriptionID)
+	assert.Equal(t, "sub-1", notifications[0].Result)
+	assert.False(t, notifications[0].ReceivedAt.IsZero())
+	mu.Unlock()
+
+	// The subscription ends with the error of the underlying subscription.
+	node.dropConnections()
+
+	select {
+	case err := <-sub.Err():
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription did not end")
+	}
+
+	sub.Unsubscribe()
+	c.Close()
+}
+
+func TestMiddleware_Unsubscribe(t *testing.T) {
+	node := newTestNode(t)
+
+	c, err := NewMiddleware().OnNotification(func(Notification) {}).Connect(node.url())
+	require.NoError(t, err)
+	defer c.Close()
+
+	ch := make(chan string)
+
+	sub, err := c.Subscribe(context.Background(), "test", "subscribe", "unsubscribe", "notification", ch)
+	require.NoError(t, err)
+
+	// The notification is not received before unsubscribing, the forwarding must stop regardless.
+	sub.Unsubscribe()
+	close(ch)
+
+	_, ok := <-sub.Err()
+	assert.False(t, ok)
+}
+
+func TestMiddleware_RecordMetrics(t *testing.T) {
+	node := newTestNode(t)
+
+	recorder := &testRecorder{}
+
+	c, err := NewMiddleware().RecordMetrics(recorder).Connect(node.url())
+	require.NoError(t, err)
+	defer c.Close()
+
+	var res string
+	require.NoError(t, c.Call(&res, "test_echo", "value"))
+	require.Error(t, c.Call(&res, "test_unknown"))
+
+	batch := c.Batch()
+	batch.Add(&res, "test_echo", "batched")
+	require.NoError(t, batch.Send(context.Background()))
+
+	ch := make(chan string)
+
+	sub, err := c.Subscribe(context.Background(), "test", "subscribe", "unsubscribe", "notification", ch)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sub-1", receive(t, ch))
+
+	assert.Equal(t, 1, recorder.count("CallCompleted", "test_echo ok"))
+	assert.Equal(t, 1, recorder.count("CallCompleted", "test_unknown -32601"))
+	assert.Equal(t, 1, recorder.count("CallCompleted", "batch ok"))
+	assert.Equal(t, 1, recorder.count("CallCompleted", "test_subscribe ok"))
+
+	calls := recorder.recordedCalls()
+	require.Len(t, calls, 4)
+
+	// ["value"] and "value"
+	assert.Equal(t, 9, calls[0].RequestSize)
+	assert.Equal(t, 7, calls[0].ResponseSize)
+	assert.Positive(t, calls[0].Duration)
+	assert.Equal(t, -1, calls[1].ResponseSize)
+	assert.Equal(t, -1, calls[2].ResponseSize)
+	assert.Equal(t, -1, calls[3].ResponseSize)
+
+	assert.Equal(t, 1, recorder.count("SubscriptionStarted", "test_subscribe"))
+	assert.Equal(t, 1, recorder.count("NotificationReceived", "test_subscribe"))
+	// "sub-1"
+	assert.Equal(t, []int{7}, recorder.recordedNotificationSizes())
+	assert.Zero(t, recorder.count("SubscriptionEnded", "test_subscribe"))
+
+	sub.Unsubscribe()
+
+	assert.Equal(t, 1, recorder.count("SubscriptionEnded", "test_subscribe"))
+}
+
+// testRecorder counts the recorded metrics by the name of the recorder method and their label, it is safe for
+// concurrent use.
+type testRecorder struct {
+	mu                sync.Mutex
+	counts            map[[2]string]int
+	calls             []metrics.Call
+	notificationSizes []int
+}
+
+func (r *testRecorder) record(name, label string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.counts == nil {
+		r.counts = make(map[[2]string]int)
+	}
+
+	r.counts[[2]string{name, label}]++
+}
+
+func (r *testRecorder) count(name, label string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.counts[[2]string{name, label}]
+}
+
+func (r *testRecorder) recordedCalls() []metrics.Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]metrics.Call(nil), r.calls...)
+}
+
+func (r *testRecorder) recordedNotificationSizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]int(nil), r.notificationSizes...)
+}
+
+func (r *testRecorder) CallCompleted(call metrics.Call) {
+	r.record("CallCompleted", call.Method+" "+call.Code)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, call)
+}
+
+func (r *testRecorder) SubscriptionStarted(method string) { r.record("SubscriptionStarted", method) }
+
+func (r *testRecorder) SubscriptionEnded(method string) { r.record("SubscriptionEnded", method) }
+
+func (r *testRecorder) NotificationReceived(method string, size int) {
+	r.record("NotificationReceived", method)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.notificationSizes = append(r.notificationSizes, size)
+}
+
+func (r *testRecorder) NotificationDropped(method string) { r.record("NotificationDropped", method) }
+
+func (r *testRecorder) Reconnected(endpoint string) { r.record("Reconnected", endpoint) }
+
+func (r *testRecorder) EventsDecoded(int, time.Duration) { r.record("EventsDecoded", "") }
+
+func (r *testRecorder) RegistryLookup(component string, _ bool) {
+	r.record("RegistryLookup", component)
+}
+
+func (r *testRecorder) ExtrinsicOutcome(outcome metrics.Outcome) {
+	r.record("ExtrinsicOutcome", string(outcome))
+}
+
+type testRPCError struct {
+	code int
+}
+
+func (e testRPCError) Error() string {
+	return fmt.Sprintf("rpc error %d", e.code)
+}
+
+func (e testRPCError) ErrorCode() int {
+	return e.code
+}
+
+var _ gethrpc.Error = testRPCError{}
+
+func TestErrorCode(t *testing.T) {
+	assert.Equal(t, "ok", ErrorCode(nil))
+	assert.Equal(t, "-32601", ErrorCode(testRPCError{code: -32601}))
+	assert.Equal(t, "-32601", ErrorCode(fmt.Errorf("wrapped: %w", testRPCError{code: -32601})))
+	assert.Equal(t, "canceled", ErrorCode(context.Canceled))
+	assert.Equal(t, "deadline_exceeded", ErrorCode(context.DeadlineExceeded))
+	assert.Equal(t, "error", ErrorCode(errors.New("test error")))
+}
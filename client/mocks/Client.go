@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	client "github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	rpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -14,6 +15,36 @@ type Client struct {
 	mock.Mock
 }
 
+// Batch provides a mock function with given fields:
+func (_m *Client) Batch() *client.Batch {
+	ret := _m.Called()
+
+	var r0 *client.Batch
+	if rf, ok := ret.Get(0).(func() *client.Batch); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Batch)
+		}
+	}
+
+	return r0
+}
+
+// BatchCallContext provides a mock function with given fields: ctx, b
+func (_m *Client) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	ret := _m.Called(ctx, b)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []rpc.BatchElem) error); ok {
+		r0 = rf(ctx, b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Call provides a mock function with given fields: result, method, args
 func (_m *Client) Call(result interface{}, method string, args ...interface{}) error {
 	var _ca []interface{}
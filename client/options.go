@@ -0,0 +1,142 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/gorilla/websocket"
+)
+
+// ConnectionOptions configure how a client connects to the node, e.g. to authenticate against managed RPC providers.
+// They apply to the initial connection as well as to all reconnection attempts. The zero value connects just like
+// Connect.
+type ConnectionOptions struct {
+	// Header is sent with the websocket handshake and with every HTTP request, e.g. to pass an API key.
+	Header http.Header
+
+	// BearerToken, if set, is sent as bearer token in the Authorization header.
+	BearerToken string
+
+	// TLSConfig is used for wss and https connections, e.g. to trust a custom CA or to present a client certificate.
+	TLSConfig *tls.Config
+
+	// ProxyURL, if set, is the proxy that all connections are made through.
+	ProxyURL *url.URL
+
+	// ReadLimit caps the size of websocket messages, it defaults to DefaultReadLimit. Calls whose response exceeds
+	// the limit fail with ErrReadLimitExceeded, and the connection is closed.
+	ReadLimit int64
+
+	// Compression enables the negotiation of permessage-deflate compression for websocket connections.
+	Compression bool
+
+	// PingInterval, if set, pings the node in this interval to keep idle websocket connections through proxies and
+	// load balancers alive.
+	PingInterval time.Duration
+
+	// PongTimeout is the time the node has to answer a ping before the connection is closed, it defaults to the
+	// PingInterval.
+	PongTimeout time.Duration
+}
+
+const (
+	// DefaultReadLimit is the default size limit of websocket messages, large enough for the metadata of all
+	// known chains.
+	DefaultReadLimit = 32 * 1024 * 1024
+
+	ErrReadLimitExceeded = libErr.Error("websocket read limit exceeded")
+)
+
+// ConnectWithOptions connects to the provided url, just like Connect, using the provided connection options.
+func ConnectWithOptions(url string, opts ConnectionOptions) (Client, error) {
+	c, err := connect(url, config.Default().DialTimeout, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// dial connects to the provided url using the connection options.
+func (o ConnectionOptions) dial(ctx context.Context, url string) (*gethrpc.Client, error) {
+	return gethrpc.DialOptions(ctx, url, o.clientOptions()...)
+}
+
+func (o ConnectionOptions) clientOptions() []gethrpc.ClientOption {
+	opts := []gethrpc.ClientOption{gethrpc.WithWebsocketMessageSizeLimit(o.readLimit())}
+
+	if o.Compression {
+		opts = append(opts, gethrpc.WithWebsocketCompression())
+	}
+
+	if o.PingInterval > 0 {
+		opts = append(opts, gethrpc.WithWebsocketPing(o.PingInterval, o.PongTimeout))
+	}
+
+	if len(o.Header) > 0 {
+		opts = append(opts, gethrpc.WithHeaders(o.Header.Clone()))
+	}
+
+	if o.BearerToken != "" {
+		opts = append(opts, gethrpc.WithHeader("Authorization", "Bearer "+o.BearerToken))
+	}
+
+	if o.TLSConfig == nil && o.ProxyURL == nil {
+		return opts
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = o.TLSConfig
+
+	dialer := websocket.Dialer{TLSClientConfig: o.TLSConfig}
+
+	if o.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(o.ProxyURL)
+		dialer.Proxy = http.ProxyURL(o.ProxyURL)
+	}
+
+	return append(
+		opts,
+		gethrpc.WithHTTPClient(&http.Client{Transport: transport}),
+		gethrpc.WithWebsocketDialer(dialer),
+	)
+}
+
+func (o ConnectionOptions) readLimit() int64 {
+	if o.ReadLimit <= 0 {
+		return DefaultReadLimit
+	}
+
+	return o.ReadLimit
+}
+
+// readLimitError names the method and the read limit if err was caused by a response that exceeded the read limit.
+func readLimitError(method string, readLimit int64, err error) error {
+	if !errors.Is(err, websocket.ErrReadLimit) {
+		return err
+	}
+
+	return ErrReadLimitExceeded.WithMsg("response to %s exceeds the limit of %d bytes", method, readLimit)
+}
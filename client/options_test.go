@@ -0,0 +1,258 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectWithOptions_TLSAndHeaders(t *testing.T) {
+	node := newTestNode(t)
+	srv, tlsConfig := newAuthTLSServer(t, node.serve)
+
+	wsURL := "wss" + strings.TrimPrefix(srv.URL, "https")
+
+	_, err := Connect(wsURL)
+	assert.Error(t, err, "the self-signed certificate must not be trusted by default")
+
+	_, err = ConnectWithOptions(wsURL, ConnectionOptions{TLSConfig: tlsConfig})
+	assert.Error(t, err, "the headers are required")
+
+	c, err := ConnectWithOptions(wsURL, testAuthOptions(tlsConfig))
+	require.NoError(t, err)
+	defer c.Close()
+
+	var res string
+	require.NoError(t, c.Call(&res, "test_echo", "value"))
+	assert.Equal(t, "value", res)
+}
+
+func TestConnectHTTP_TLSAndHeaders(t *testing.T) {
+	node := newTestHTTPNode(t)
+	srv, tlsConfig := newAuthTLSServer(t, node.serve)
+
+	c, err := ConnectHTTP(srv.URL, HTTPOptions{Connection: ConnectionOptions{TLSConfig: tlsConfig}})
+	require.NoError(t, err)
+
+	var res string
+	assert.Error(t, c.Call(&res, "system_chain"), "the headers are required")
+
+	c, err = ConnectHTTP(srv.URL, HTTPOptions{Connection: testAuthOptions(tlsConfig)})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.Call(&res, "system_chain"))
+	assert.Equal(t, "Test", res)
+}
+
+func TestConnectWithReconnect_TLSAndHeaders(t *testing.T) {
+	node := newTestNode(t)
+	srv, tlsConfig := newAuthTLSServer(t, node.serve)
+
+	states := make(chan ConnectionState, 10)
+
+	c, err := ConnectWithReconnect("wss"+strings.TrimPrefix(srv.URL, "https"), ReconnectOptions{
+		Connection: testAuthOptions(tlsConfig),
+		OnStateChange: func(state ConnectionState) {
+			states <- state
+		},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	node.dropConnections()
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+	assert.Equal(t, ConnectionStateConnected, waitState(t, states))
+
+	var res string
+	require.NoError(t, c.Call(&res, "test_echo", "value"))
+	assert.Equal(t, "value", res)
+}
+
+func TestConnectHTTP_Proxy(t *testing.T) {
+	node := newTestHTTPNode(t)
+
+	var proxied atomic.Int32
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Add(1)
+		node.serve(w, r)
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+
+	c, err := ConnectHTTP(node.srv.URL, HTTPOptions{Connection: ConnectionOptions{ProxyURL: proxyURL}})
+	require.NoError(t, err)
+	defer c.Close()
+
+	var res string
+	require.NoError(t, c.Call(&res, "system_chain"))
+	assert.Equal(t, "Test", res)
+	assert.Equal(t, int32(1), proxied.Load())
+}
+
+func TestConnectWithOptions_ReadLimit(t *testing.T) {
+	node := newTestNode(t)
+
+	c, err := ConnectWithOptions(node.url(), ConnectionOptions{ReadLimit: 1024})
+	require.NoError(t, err)
+	defer c.Close()
+
+	var res string
+	err = c.Call(&res, "test_echo", strings.Repeat("a", 2048))
+	assert.True(t, errors.Is(err, ErrReadLimitExceeded))
+	assert.Contains(t, err.Error(), "test_echo")
+	assert.Contains(t, err.Error(), "1024 bytes")
+}
+
+func TestConnectWithReconnect_ReadLimit(t *testing.T) {
+	node := newTestNode(t)
+
+	c, err := ConnectWithReconnect(node.url(), ReconnectOptions{Connection: ConnectionOptions{ReadLimit: 1024}})
+	require.NoError(t, err)
+	defer c.Close()
+
+	var res string
+	err = c.Call(&res, "test_echo", strings.Repeat("a", 2048))
+	assert.True(t, errors.Is(err, ErrReadLimitExceeded))
+	assert.Contains(t, err.Error(), "test_echo")
+}
+
+func TestConnectWithOptions_Compression(t *testing.T) {
+	node := newTestNode(t)
+	node.upgrader.EnableCompression = true
+
+	var extensions atomic.Value
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		extensions.Store(r.Header.Get("Sec-WebSocket-Extensions"))
+		node.serve(w, r)
+	}))
+	defer srv.Close()
+
+	c, err := ConnectWithOptions("ws"+strings.TrimPrefix(srv.URL, "http"), ConnectionOptions{Compression: true})
+	require.NoError(t, err)
+	defer c.Close()
+
+	var res string
+	require.NoError(t, c.Call(&res, "test_echo", strings.Repeat("a", 2048)))
+	assert.Equal(t, strings.Repeat("a", 2048), res)
+	assert.Contains(t, extensions.Load(), "permessage-deflate")
+}
+
+func TestConnectWithReconnect_Ping(t *testing.T) {
+	node := newTestNode(t)
+
+	states := make(chan ConnectionState, 10)
+
+	c, err := ConnectWithReconnect(node.url(), ReconnectOptions{
+		Connection: ConnectionOptions{PingInterval: 20 * time.Millisecond, PongTimeout: 50 * time.Millisecond},
+		OnStateChange: func(state ConnectionState) {
+			states <- state
+		},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	// The node answers the pings, so the connection stays up.
+	time.Sleep(200 * time.Millisecond)
+
+	select {
+	case state := <-states:
+		t.Fatalf("unexpected state change to %s", state)
+	default:
+	}
+
+	var res string
+	require.NoError(t, c.Call(&res, "test_echo", "value"))
+}
+
+func TestConnectWithReconnect_PongTimeout(t *testing.T) {
+	// The silent node never reads from the connection and thereby never answers a ping.
+	silent := make(chan struct{})
+	defer close(silent)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var upgrader websocket.Upgrader
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		<-silent
+	}))
+	defer srv.Close()
+
+	states := make(chan ConnectionState, 10)
+
+	c, err := ConnectWithReconnect("ws"+strings.TrimPrefix(srv.URL, "http"), ReconnectOptions{
+		Connection: ConnectionOptions{PingInterval: 20 * time.Millisecond, PongTimeout: 50 * time.Millisecond},
+		OnStateChange: func(state ConnectionState) {
+			states <- state
+		},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+}
+
+func testAuthOptions(tlsConfig *tls.Config) ConnectionOptions {
+	return ConnectionOptions{
+		Header:      http.Header{"X-Api-Key": []string{"secret"}},
+		BearerToken: "token",
+		TLSConfig:   tlsConfig,
+	}
+}
+
+// newAuthTLSServer starts a TLS server with a self-signed certificate that only passes requests with the headers of
+// testAuthOptions on to the handler. The returned TLS config trusts the certificate.
+func newAuthTLSServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *tls.Config) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		handler(w, r)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	certs := x509.NewCertPool()
+	certs.AddCert(srv.Certificate())
+
+	return srv, &tls.Config{RootCAs: certs, MinVersion: tls.VersionTLS12}
+}
@@ -0,0 +1,697 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/metrics"
+)
+
+const (
+	ErrDisconnected      = libErr.Error("client disconnected")
+	ErrResubscription    = libErr.Error("subscription could not be re-established")
+	ErrNoEndpoints       = libErr.Error("no endpoints provided")
+	ErrUnhealthyEndpoint = libErr.Error("endpoint is unhealthy")
+)
+
+const (
+	defaultReconnectInitialBackoff = 500 * time.Millisecond
+	defaultReconnectMaxBackoff     = 30 * time.Second
+)
+
+// ConnectionState is the state of the connection of a reconnecting client.
+type ConnectionState uint8
+
+const (
+	// ConnectionStateConnected is the state while the client is connected to the node.
+	ConnectionStateConnected ConnectionState = iota
+	// ConnectionStateDisconnected is the state after the connection was lost, while the client is reconnecting.
+	ConnectionStateDisconnected
+	// ConnectionStateClosed is the final state once the client is closed.
+	ConnectionStateClosed
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case ConnectionStateConnected:
+		return "connected"
+	case ConnectionStateDisconnected:
+		return "disconnected"
+	case ConnectionStateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// ReconnectOptions configure a client created via ConnectWithReconnect. Zero values are replaced by the defaults.
+type ReconnectOptions struct {
+	// InitialBackoff is the delay before the first reconnect attempt, it is doubled after every failed attempt.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between two reconnect attempts.
+	MaxBackoff time.Duration
+
+	// DialTimeout bounds every dial attempt, it defaults to config.Default().DialTimeout.
+	DialTimeout time.Duration
+
+	// Connection configures how the initial connection and all reconnection attempts are established.
+	Connection ConnectionOptions
+
+	// OnStateChange, if set, is called with the new state whenever the connection state changes after the
+	// initial connection was established, e.g. to record metrics. It must not block.
+	OnStateChange func(state ConnectionState)
+
+	// Logger, if set, logs lost connections, reconnect attempts, failovers and re-established subscriptions with the
+	// keys endpoint, attempt and method. Nothing is logged by default.
+	Logger *slog.Logger
+
+	// Recorder, if set, records the reconnects and failovers by the endpoint that was connected to.
+	Recorder metrics.Recorder
+}
+
+// nonResubscribableMethods holds the subscriptions that are not re-established after a reconnect, either because
+// subscribing has side effects or because the subscription state is bound to the lost connection.
+var nonResubscribableMethods = map[string]struct{}{
+	"author_submitAndWatchExtrinsic":     {},
+	"transactionWatch_v1_submitAndWatch": {},
+	"chainHead_v1_follow":                {},
+}
+
+type dialFunc func(ctx context.Context, url string) (*gethrpc.Client, error)
+
+// reconnectingClient is the client behind ConnectWithReconnect and ConnectWithFailover.
+type reconnectingClient struct {
+	endpoints []string
+	opts      FailoverOptions
+	dial      dialFunc
+
+	mu     sync.Mutex
+	conn   *gethrpc.Client // nil unless connected
+	active int             // index of the endpoint conn is connected to
+	state  ConnectionState
+	subs   map[*reconnectingSubscription]struct{}
+
+	closing chan struct{}
+}
+
+// ConnectWithReconnect connects to the provided websocket url, just like Connect, but the returned client
+// reconnects automatically with exponential backoff once the connection is lost.
+//
+// While the client is disconnected, calls and in-flight calls fail with ErrDisconnected. Once reconnected, the active
+// subscriptions are re-established and signal a gap, see gethrpc.ClientSubscription.Gap, so that subscribers can
+// reconcile the notifications they missed. Subscriptions that cannot be re-established, like extrinsic watches, end
+// with ErrDisconnected instead.
+func ConnectWithReconnect(url string, opts ReconnectOptions) (Client, error) {
+	return connectWithFailover([]string{url}, FailoverOptions{ReconnectOptions: opts}, opts.Connection.dial)
+}
+
+func connectWithFailover(endpoints []string, opts FailoverOptions, dial dialFunc) (*reconnectingClient, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = defaultReconnectInitialBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultReconnectMaxBackoff
+	}
+	if opts.DialTimeout <= 0 {
+		opts.DialTimeout = config.Default().DialTimeout
+	}
+	if opts.HealthCheckInterval <= 0 {
+		opts.HealthCheckInterval = defaultHealthCheckInterval
+	}
+
+	c := &reconnectingClient{
+		endpoints: endpoints,
+		opts:      opts,
+		dial:      dial,
+		state:     ConnectionStateConnected,
+		subs:      make(map[*reconnectingSubscription]struct{}),
+		closing:   make(chan struct{}),
+	}
+
+	// Replacing the last endpoint yields the initial order for all policies.
+	conn, active, err := c.dialOnce(c.dialOrder(len(endpoints)-1), false)
+	if err != nil {
+		return nil, err
+	}
+
+	c.conn = conn
+	c.active = active
+
+	go c.run(conn)
+
+	return c, nil
+}
+
+// URL returns the URL of the endpoint the client is connected to
+func (c *reconnectingClient) URL() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.endpoints[c.active]
+}
+
+func (c *reconnectingClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *reconnectingClient) CallContext(
+	ctx context.Context,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	conn, endpoint, err := c.current()
+	if err != nil {
+		return err
+	}
+
+	return c.callError(conn, endpoint, method, conn.CallContext(ctx, result, method, args...))
+}
+
+func (c *reconnectingClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	conn, endpoint, err := c.current()
+	if err != nil {
+		return err
+	}
+
+	err = conn.BatchCallContext(ctx, b)
+
+	toRPCErrors(b)
+
+	return c.callError(conn, endpoint, BatchMethod, err)
+}
+
+// Batch returns a new, empty batch that is sent via this client
+func (c *reconnectingClient) Batch() *Batch {
+	return NewBatch(c)
+}
+
+func (c *reconnectingClient) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	conn, endpoint, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+
+	inner, err := conn.Subscribe(
+		ctx,
+		namespace,
+		subscribeMethodSuffix,
+		unsubscribeMethodSuffix,
+		notificationMethodSuffix,
+		channel,
+		args...,
+	)
+	if err != nil {
+		return nil, c.callError(conn, endpoint, namespace+"_"+subscribeMethodSuffix, err)
+	}
+
+	_, nonResubscribable := nonResubscribableMethods[namespace+"_"+subscribeMethodSuffix]
+
+	s := &reconnectingSubscription{
+		client:                   c,
+		namespace:                namespace,
+		subscribeMethodSuffix:    subscribeMethodSuffix,
+		unsubscribeMethodSuffix:  unsubscribeMethodSuffix,
+		notificationMethodSuffix: notificationMethodSuffix,
+		channel:                  channel,
+		args:                     args,
+		resubscribable:           !nonResubscribable,
+		inner:                    inner,
+	}
+	s.outer = gethrpc.NewDetachedClientSubscription(s.id, s.unsubscribe)
+
+	c.mu.Lock()
+
+	if c.conn != conn {
+		// The connection was replaced in the meantime, the subscription would not be re-established.
+		state := c.state
+		c.mu.Unlock()
+
+		inner.Unsubscribe()
+
+		if state == ConnectionStateClosed {
+			return nil, gethrpc.ErrClientQuit
+		}
+
+		return nil, ErrDisconnected
+	}
+
+	c.subs[s] = struct{}{}
+
+	c.mu.Unlock()
+
+	go s.watch(conn, inner)
+
+	return s.outer, nil
+}
+
+// Close closes the client and ends all subscriptions, no reconnect is attempted afterwards.
+func (c *reconnectingClient) Close() {
+	c.mu.Lock()
+
+	if c.state == ConnectionStateClosed {
+		c.mu.Unlock()
+		return
+	}
+
+	conn := c.conn
+	subs := c.takeSubs()
+
+	c.conn = nil
+	c.state = ConnectionStateClosed
+	close(c.closing)
+
+	c.mu.Unlock()
+
+	c.notify(ConnectionStateClosed)
+
+	if conn != nil {
+		conn.Close()
+	}
+
+	for _, s := range subs {
+		// Adhere to the subscription semantics, the error channel receives nil for closed clients.
+		s.outer.Fail(gethrpc.ErrClientQuit)
+	}
+}
+
+// current returns the current connection and its endpoint, or an error if the client is not connected.
+func (c *reconnectingClient) current() (*gethrpc.Client, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case ConnectionStateClosed:
+		return nil, "", gethrpc.ErrClientQuit
+	case ConnectionStateDisconnected:
+		return nil, "", ErrDisconnected
+	default:
+		return c.conn, c.endpoints[c.active], nil
+	}
+}
+
+// run supervises the connection until the client is closed. It replaces the connection once it is lost and, if
+// there are several endpoints, once the health check finds a better endpoint.
+//
+// Since run is the only place where connections are replaced, there is never more than one replacement at a time.
+func (c *reconnectingClient) run(conn *gethrpc.Client) {
+	var healthCheck <-chan time.Time
+
+	if len(c.endpoints) > 1 {
+		ticker := time.NewTicker(c.opts.HealthCheckInterval)
+		defer ticker.Stop()
+
+		healthCheck = ticker.C
+	}
+
+	for {
+		var (
+			next   *gethrpc.Client
+			active int
+		)
+
+		select {
+		case <-c.closing:
+			return
+		case <-conn.ConnectionLost():
+			c.log(slog.LevelWarn, "Connection lost", slog.String("endpoint", c.endpoints[c.activeIndex()]))
+
+			c.disconnect(conn)
+
+			next, active = c.redial()
+		case <-healthCheck:
+			next, active = c.checkHealth(conn)
+			if next == nil {
+				continue
+			}
+
+			c.log(
+				slog.LevelWarn,
+				"Failing over",
+				slog.String("endpoint", c.endpoints[active]),
+				slog.String("from_endpoint", c.endpoints[c.activeIndex()]),
+			)
+
+			c.disconnect(conn)
+		}
+
+		if next == nil {
+			return
+		}
+
+		conn = c.establish(next, active)
+		if conn == nil {
+			return
+		}
+
+		if c.opts.Recorder != nil {
+			c.opts.Recorder.Reconnected(c.endpoints[active])
+		}
+	}
+}
+
+// disconnect closes the connection to be replaced. Subscriptions that cannot be re-established end at this point.
+func (c *reconnectingClient) disconnect(conn *gethrpc.Client) {
+	c.mu.Lock()
+
+	if c.state == ConnectionStateClosed {
+		c.mu.Unlock()
+		return
+	}
+
+	c.conn = nil
+	c.state = ConnectionStateDisconnected
+
+	var ended []*reconnectingSubscription
+
+	for s := range c.subs {
+		if !s.resubscribable {
+			delete(c.subs, s)
+			ended = append(ended, s)
+		}
+	}
+
+	c.mu.Unlock()
+
+	c.notify(ConnectionStateDisconnected)
+
+	conn.Close()
+
+	for _, s := range ended {
+		s.outer.Fail(ErrDisconnected)
+	}
+}
+
+// establish re-establishes the active subscriptions on conn and makes it the current connection. It returns nil if
+// the client was closed in the meantime.
+func (c *reconnectingClient) establish(conn *gethrpc.Client, active int) *gethrpc.Client {
+	// Subscriptions can only be added or removed while connected, so the set is stable until the state changes.
+	c.mu.Lock()
+	subs := make([]*reconnectingSubscription, 0, len(c.subs))
+	for s := range c.subs {
+		subs = append(subs, s)
+	}
+	c.mu.Unlock()
+
+	for _, s := range subs {
+		s.resubscribe(conn)
+	}
+
+	c.mu.Lock()
+
+	if c.state == ConnectionStateClosed {
+		c.mu.Unlock()
+		conn.Close()
+		return nil
+	}
+
+	c.conn = conn
+	c.active = active
+	c.state = ConnectionStateConnected
+
+	c.mu.Unlock()
+
+	c.notify(ConnectionStateConnected)
+
+	return conn
+}
+
+// redial dials until a new connection is established, waiting with exponential backoff between the rounds over all
+// endpoints. It returns nil if the client is closed in the meantime.
+func (c *reconnectingClient) redial() (*gethrpc.Client, int) {
+	backoff := c.opts.InitialBackoff
+	order := c.dialOrder(c.activeIndex())
+
+	for attempt := 1; ; attempt++ {
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-timer.C:
+		case <-c.closing:
+			timer.Stop()
+			return nil, 0
+		}
+
+		conn, active, err := c.dialOnce(order, false)
+		if err == nil {
+			c.log(
+				slog.LevelInfo,
+				"Reconnected",
+				slog.String("endpoint", c.endpoints[active]),
+				slog.Int("attempt", attempt),
+			)
+
+			return conn, active
+		}
+
+		backoff *= 2
+		if backoff > c.opts.MaxBackoff {
+			backoff = c.opts.MaxBackoff
+		}
+
+		c.log(
+			slog.LevelWarn,
+			"Reconnect attempt failed",
+			slog.Int("attempt", attempt),
+			slog.Duration("backoff", backoff),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// dialOnce dials the endpoints in the given order and returns the first connection that is established and, if
+// requested, healthy.
+func (c *reconnectingClient) dialOnce(order []int, healthy bool) (*gethrpc.Client, int, error) {
+	var err error
+
+	for _, i := range order {
+		ctx, cancel := context.WithTimeout(context.Background(), c.opts.DialTimeout)
+		conn, dialErr := c.dial(ctx, c.endpoints[i])
+		cancel()
+
+		if dialErr != nil {
+			err = c.endpointError(c.endpoints[i], dialErr)
+			continue
+		}
+
+		if healthy && !c.isHealthy(conn) {
+			conn.Close()
+			err = c.endpointError(c.endpoints[i], ErrUnhealthyEndpoint)
+			continue
+		}
+
+		return conn, i, nil
+	}
+
+	return nil, 0, err
+}
+
+func (c *reconnectingClient) activeIndex() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.active
+}
+
+// log logs the record via ReconnectOptions.Logger, if it is set.
+func (c *reconnectingClient) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	if c.opts.Logger != nil {
+		c.opts.Logger.LogAttrs(context.Background(), level, msg, attrs...)
+	}
+}
+
+func (c *reconnectingClient) notify(state ConnectionState) {
+	if c.opts.OnStateChange != nil {
+		c.opts.OnStateChange(state)
+	}
+}
+
+// takeSubs removes and returns all subscriptions, the caller must hold the lock.
+func (c *reconnectingClient) takeSubs() []*reconnectingSubscription {
+	subs := make([]*reconnectingSubscription, 0, len(c.subs))
+	for s := range c.subs {
+		subs = append(subs, s)
+	}
+
+	c.subs = make(map[*reconnectingSubscription]struct{})
+
+	return subs
+}
+
+func (c *reconnectingClient) removeSub(s *reconnectingSubscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.subs, s)
+}
+
+// callError wraps err with ErrDisconnected if it occurred because conn was lost, or converts it into a
+// types.RPCError if it was returned by the node. Exceeding the read limit is reported for the method. If the client
+// has several endpoints, the endpoint it occurred on is added as well.
+func (c *reconnectingClient) callError(conn *gethrpc.Client, endpoint, method string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	err = readLimitError(method, c.opts.Connection.readLimit(), err)
+
+	if isLost(conn) {
+		err = ErrDisconnected.Wrap(err)
+	} else {
+		err = toRPCError(err)
+	}
+
+	return c.endpointError(endpoint, err)
+}
+
+func (c *reconnectingClient) endpointError(endpoint string, err error) error {
+	if len(c.endpoints) == 1 {
+		return err
+	}
+
+	return &EndpointError{Endpoint: endpoint, Err: err}
+}
+
+func isLost(conn *gethrpc.Client) bool {
+	select {
+	case <-conn.ConnectionLost():
+		return true
+	default:
+		return false
+	}
+}
+
+// reconnectingSubscription ties the subscription handed out to the subscriber to the server side subscriptions
+// that are established on the changing connections of a reconnecting client.
+type reconnectingSubscription struct {
+	client *reconnectingClient
+	outer  *gethrpc.ClientSubscription
+
+	namespace                string
+	subscribeMethodSuffix    string
+	unsubscribeMethodSuffix  string
+	notificationMethodSuffix string
+	channel                  interface{}
+	args                     []interface{}
+	resubscribable           bool
+
+	mu     sync.Mutex
+	inner  *gethrpc.ClientSubscription
+	closed bool
+}
+
+func (s *reconnectingSubscription) id() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.inner.ID()
+}
+
+func (s *reconnectingSubscription) unsubscribe() {
+	s.mu.Lock()
+	s.closed = true
+	inner := s.inner
+	s.mu.Unlock()
+
+	inner.Unsubscribe()
+
+	s.client.removeSub(s)
+}
+
+// watch ends the subscription if inner fails for any other reason than the loss of conn, which is handled by the
+// reconnect.
+func (s *reconnectingSubscription) watch(conn *gethrpc.Client, inner *gethrpc.ClientSubscription) {
+	select {
+	case err := <-inner.Err():
+		if err == nil || isLost(conn) {
+			return
+		}
+
+		s.client.removeSub(s)
+		s.outer.Fail(err)
+	case <-s.outer.Done():
+	}
+}
+
+// resubscribe establishes the subscription on conn and signals the gap to the subscriber. If conn is lost as well,
+// the subscription is kept for the next reconnect.
+func (s *reconnectingSubscription) resubscribe(conn *gethrpc.Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	s.outer.SignalGap()
+
+	method := s.namespace + "_" + s.subscribeMethodSuffix
+
+	s.client.log(slog.LevelDebug, "Re-establishing subscription", slog.String("method", method))
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	defer cancel()
+
+	inner, err := conn.Subscribe(
+		ctx,
+		s.namespace,
+		s.subscribeMethodSuffix,
+		s.unsubscribeMethodSuffix,
+		s.notificationMethodSuffix,
+		s.channel,
+		s.args...,
+	)
+	if err != nil {
+		if isLost(conn) {
+			return
+		}
+
+		s.client.log(
+			slog.LevelWarn,
+			"Subscription could not be re-established",
+			slog.String("method", method),
+			slog.String("error", err.Error()),
+		)
+
+		s.closed = true
+		s.client.removeSub(s)
+		s.outer.Fail(ErrResubscription.Wrap(err))
+
+		return
+	}
+
+	s.inner = inner
+
+	go s.watch(conn, inner)
+}
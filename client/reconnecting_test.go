@@ -0,0 +1,620 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconnectingClient_Reconnect(t *testing.T) {
+	node := newTestNode(t)
+
+	c, states := connectTestNode(t, node, nil)
+	defer c.Close()
+
+	var res string
+	require.NoError(t, c.Call(&res, "test_echo", "before"))
+	assert.Equal(t, "before", res)
+
+	node.setDown(true)
+	node.dropConnections()
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+
+	err := c.Call(&res, "test_echo", "during")
+	assert.True(t, errors.Is(err, ErrDisconnected))
+
+	node.setDown(false)
+
+	assert.Equal(t, ConnectionStateConnected, waitState(t, states))
+
+	require.NoError(t, c.Call(&res, "test_echo", "after"))
+	assert.Equal(t, "after", res)
+}
+
+func TestReconnectingClient_InFlightCall(t *testing.T) {
+	node := newTestNode(t)
+
+	c, states := connectTestNode(t, node, nil)
+	defer c.Close()
+
+	errs := make(chan error)
+
+	go func() {
+		var res string
+		errs <- c.Call(&res, "test_hang")
+	}()
+
+	<-node.hanging
+	node.dropConnections()
+
+	select {
+	case err := <-errs:
+		assert.True(t, errors.Is(err, ErrDisconnected))
+	case <-time.After(5 * time.Second):
+		t.Fatal("in-flight call did not fail")
+	}
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+	assert.Equal(t, ConnectionStateConnected, waitState(t, states))
+}
+
+func TestReconnectingClient_Backoff(t *testing.T) {
+	node := newTestNode(t)
+
+	var (
+		mu       sync.Mutex
+		attempts []time.Time
+	)
+
+	dial := func(ctx context.Context, url string) (*gethrpc.Client, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts = append(attempts, time.Now())
+
+		// The initial connection succeeds, the first two reconnect attempts fail.
+		if len(attempts) > 1 && len(attempts) < 4 {
+			return nil, errors.New("dial error")
+		}
+
+		return gethrpc.DialContext(ctx, url)
+	}
+
+	c, states := connectTestNode(t, node, dial)
+	defer c.Close()
+
+	node.dropConnections()
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+	assert.Equal(t, ConnectionStateConnected, waitState(t, states))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Len(t, attempts, 4)
+	assert.GreaterOrEqual(t, attempts[2].Sub(attempts[1]), 20*time.Millisecond)
+	assert.GreaterOrEqual(t, attempts[3].Sub(attempts[2]), 40*time.Millisecond)
+}
+
+func TestReconnectingClient_Resubscribe(t *testing.T) {
+	node := newTestNode(t)
+
+	c, states := connectTestNode(t, node, nil)
+	defer c.Close()
+
+	ch := make(chan string)
+
+	sub, err := c.Subscribe(context.Background(), "test", "subscribe", "unsubscribe", "notification", ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	assert.Equal(t, "sub-1", sub.ID())
+	assert.Equal(t, "sub-1", receive(t, ch))
+
+	node.dropConnections()
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+	assert.Equal(t, ConnectionStateConnected, waitState(t, states))
+
+	select {
+	case <-sub.Gap():
+	case <-time.After(5 * time.Second):
+		t.Fatal("gap was not signaled")
+	}
+
+	assert.Equal(t, "sub-2", receive(t, ch))
+	assert.Equal(t, "sub-2", sub.ID())
+
+	select {
+	case err := <-sub.Err():
+		t.Fatalf("unexpected subscription error: %v", err)
+	default:
+	}
+}
+
+func TestReconnectingClient_Logger(t *testing.T) {
+	node := newTestNode(t)
+	logs := &testLogHandler{}
+
+	dials := 0
+
+	dial := func(ctx context.Context, url string) (*gethrpc.Client, error) {
+		dials++
+
+		// The first reconnect attempt fails.
+		if dials == 2 {
+			return nil, errors.New("dial error")
+		}
+
+		return gethrpc.DialContext(ctx, url)
+	}
+
+	recorder := &testRecorder{}
+
+	opts := FailoverOptions{ReconnectOptions: ReconnectOptions{Logger: slog.New(logs), Recorder: recorder}}
+
+	c, states := connectTestNodes(t, opts, dial, node)
+	defer c.Close()
+
+	ch := make(chan string)
+
+	sub, err := c.Subscribe(context.Background(), "test", "subscribe", "unsubscribe", "notification", ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	assert.Equal(t, "sub-1", receive(t, ch))
+
+	node.dropConnections()
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+	assert.Equal(t, ConnectionStateConnected, waitState(t, states))
+
+	assert.Equal(t, []string{
+		"Connection lost",
+		"Reconnect attempt failed",
+		"Reconnected",
+		"Re-establishing subscription",
+	}, logs.messages())
+
+	assert.Equal(t, node.url(), logs.attrs("Connection lost")["endpoint"].String())
+	assert.Equal(t, int64(1), logs.attrs("Reconnect attempt failed")["attempt"].Int64())
+	assert.Equal(t, int64(2), logs.attrs("Reconnected")["attempt"].Int64())
+	assert.Equal(t, "test_subscribe", logs.attrs("Re-establishing subscription")["method"].String())
+
+	require.Eventually(t, func() bool {
+		return recorder.count("Reconnected", node.url()) == 1
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestReconnectingClient_NonResubscribable(t *testing.T) {
+	node := newTestNode(t)
+
+	c, states := connectTestNode(t, node, nil)
+	defer c.Close()
+
+	ch := make(chan string)
+
+	sub, err := c.Subscribe(
+		context.Background(),
+		"author",
+		"submitAndWatchExtrinsic",
+		"unwatchExtrinsic",
+		"extrinsicUpdate",
+		ch,
+		"0x00",
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sub-1", receive(t, ch))
+
+	node.dropConnections()
+
+	select {
+	case err := <-sub.Err():
+		assert.True(t, errors.Is(err, ErrDisconnected))
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription did not end")
+	}
+
+	assert.Equal(t, ConnectionStateDisconnected, waitState(t, states))
+	assert.Equal(t, ConnectionStateConnected, waitState(t, states))
+
+	assert.Equal(t, 1, node.subscriptionCount())
+}
+
+func TestReconnectingClient_Close(t *testing.T) {
+	node := newTestNode(t)
+
+	c, states := connectTestNode(t, node, nil)
+
+	ch := make(chan string)
+
+	sub, err := c.Subscribe(context.Background(), "test", "subscribe", "unsubscribe", "notification", ch)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sub-1", receive(t, ch))
+
+	c.Close()
+
+	assert.Equal(t, ConnectionStateClosed, waitState(t, states))
+
+	select {
+	case err := <-sub.Err():
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription did not end")
+	}
+
+	var res string
+	assert.Equal(t, gethrpc.ErrClientQuit, c.Call(&res, "test_echo", "closed"))
+
+	// Closing the client again is a no-op.
+	c.Close()
+
+	select {
+	case state := <-states:
+		t.Fatalf("unexpected state change to %s", state)
+	default:
+	}
+}
+
+func connectTestNode(t *testing.T, node *testNode, dial dialFunc) (*reconnectingClient, <-chan ConnectionState) {
+	return connectTestNodes(t, FailoverOptions{}, dial, node)
+}
+
+func connectTestNodes(
+	t *testing.T,
+	opts FailoverOptions,
+	dial dialFunc,
+	nodes ...*testNode,
+) (*reconnectingClient, <-chan ConnectionState) {
+	if dial == nil {
+		dial = gethrpc.DialContext
+	}
+
+	states := make(chan ConnectionState, 10)
+
+	opts.InitialBackoff = 20 * time.Millisecond
+	opts.MaxBackoff = 100 * time.Millisecond
+	opts.OnStateChange = func(state ConnectionState) {
+		states <- state
+	}
+
+	endpoints := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		endpoints = append(endpoints, node.url())
+	}
+
+	c, err := connectWithFailover(endpoints, opts, dial)
+	require.NoError(t, err)
+
+	return c, states
+}
+
+// testLogHandler records the logged records, it is safe for concurrent use.
+type testLogHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *testLogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *testLogHandler) Handle(_ context.Context, record slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.records = append(h.records, record.Clone())
+
+	return nil
+}
+
+func (h *testLogHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *testLogHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+func (h *testLogHandler) messages() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	messages := make([]string, 0, len(h.records))
+	for _, record := range h.records {
+		messages = append(messages, record.Message)
+	}
+
+	return messages
+}
+
+// attrs returns the attributes of the first record with the message.
+func (h *testLogHandler) attrs(msg string) map[string]slog.Value {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	attrs := make(map[string]slog.Value)
+
+	for _, record := range h.records {
+		if record.Message != msg {
+			continue
+		}
+
+		record.Attrs(func(attr slog.Attr) bool {
+			attrs[attr.Key] = attr.Value
+			return true
+		})
+
+		break
+	}
+
+	return attrs
+}
+
+func waitState(t *testing.T, states <-chan ConnectionState) ConnectionState {
+	select {
+	case state := <-states:
+		return state
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection state did not change")
+		return 0
+	}
+}
+
+func receive(t *testing.T, ch <-chan string) string {
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification received")
+		return ""
+	}
+}
+
+// testNode is a minimal websocket JSON-RPC server whose connections can be dropped.
+//
+// It answers test_echo with its first param, also in batches, never answers test_hang, rejects author_submitExtrinsic
+// as invalid transaction, answers rpc_methods only once methods are set and fails all unknown methods. Every subscription receives its own ID as the only
+// notification.
+type testNode struct {
+	srv      *httptest.Server
+	upgrader websocket.Upgrader
+	hanging  chan struct{}
+
+	mu      sync.Mutex
+	down    bool
+	syncing bool
+	methods []string
+	conns   []*websocket.Conn
+	subs    int
+}
+
+type testNodeRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+func newTestNode(t *testing.T) *testNode {
+	node := &testNode{hanging: make(chan struct{}, 1)}
+	node.srv = httptest.NewServer(http.HandlerFunc(node.serve))
+
+	t.Cleanup(func() {
+		node.dropConnections()
+		node.srv.Close()
+	})
+
+	return node
+}
+
+func (n *testNode) url() string {
+	return "ws" + strings.TrimPrefix(n.srv.URL, "http")
+}
+
+func (n *testNode) setDown(down bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.down = down
+}
+
+func (n *testNode) setSyncing(syncing bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.syncing = syncing
+}
+
+func (n *testNode) setMethods(methods ...string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.methods = methods
+}
+
+func (n *testNode) subscriptionCount() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.subs
+}
+
+func (n *testNode) ping() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, conn := range n.conns {
+		_ = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second))
+	}
+}
+
+func (n *testNode) dropConnections() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, conn := range n.conns {
+		conn.Close()
+	}
+
+	n.conns = nil
+}
+
+func (n *testNode) serve(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	down := n.down
+	n.mu.Unlock()
+
+	if down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := n.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	n.mu.Lock()
+	n.conns = append(n.conns, conn)
+	n.mu.Unlock()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		if msg[0] == '[' {
+			n.serveBatch(conn, msg)
+			continue
+		}
+
+		var req testNodeRequest
+		if err := json.Unmarshal(msg, &req); err != nil {
+			return
+		}
+
+		n.mu.Lock()
+		methods := n.methods
+		n.mu.Unlock()
+
+		if req.Method == "rpc_methods" && methods != nil {
+			writeResult(conn, req.ID, map[string]interface{}{"version": 1, "methods": methods})
+			continue
+		}
+
+		switch req.Method {
+		case "test_echo":
+			writeResult(conn, req.ID, req.Params[0])
+		case "test_hang":
+			n.hanging <- struct{}{}
+		case "system_health":
+			n.mu.Lock()
+			syncing := n.syncing
+			n.mu.Unlock()
+
+			writeResult(conn, req.ID, map[string]interface{}{
+				"peers":           1,
+				"isSyncing":       syncing,
+				"shouldHavePeers": true,
+			})
+		case "author_submitExtrinsic":
+			_ = conn.WriteJSON(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"error": map[string]interface{}{
+					"code":    1010,
+					"message": "Invalid Transaction",
+					"data":    "Inability to pay some fees (e.g. account balance too low)",
+				},
+			})
+		case "test_unsubscribe", "author_unwatchExtrinsic":
+			writeResult(conn, req.ID, true)
+		case "test_subscribe", "author_submitAndWatchExtrinsic":
+			n.mu.Lock()
+			n.subs++
+			id := fmt.Sprintf("sub-%d", n.subs)
+			n.mu.Unlock()
+
+			writeResult(conn, req.ID, id)
+
+			_ = conn.WriteJSON(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"method":  "test_notification",
+				"params": map[string]interface{}{
+					"subscription": id,
+					"result":       id,
+				},
+			})
+		default:
+			_ = conn.WriteJSON(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"error": map[string]interface{}{
+					"code":    -32601,
+					"message": "Method not found",
+				},
+			})
+		}
+	}
+}
+
+// serveBatch answers batches of test_echo calls.
+func (n *testNode) serveBatch(conn *websocket.Conn, msg []byte) {
+	var reqs []testNodeRequest
+	if err := json.Unmarshal(msg, &reqs); err != nil {
+		return
+	}
+
+	res := make([]map[string]interface{}, 0, len(reqs))
+
+	for _, req := range reqs {
+		res = append(res, map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  req.Params[0],
+		})
+	}
+
+	_ = conn.WriteJSON(res)
+}
+
+func writeResult(conn *websocket.Conn, id json.RawMessage, result interface{}) {
+	_ = conn.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"result":  result,
+	})
+}
@@ -0,0 +1,496 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	ErrStreamClosed          = libErr.Error("stream transport is closed")
+	ErrStreamRequest         = libErr.Error("stream request")
+	ErrInvalidSubscriptionID = libErr.Error("invalid subscription ID")
+)
+
+// maxQueuedNotifications is the number of notifications that are queued for a subscriber of a stream transport that
+// does not keep up, after which the subscription ends with gethrpc.ErrSubscriptionQueueOverflow. It matches the
+// limit of gethrpc.
+const maxQueuedNotifications = 20000
+
+// ConnectSmoldot connects to the JSON-RPC server of a light client, e.g. smoldot, that listens on a local socket,
+// e.g. a unix socket ("unix", "/run/smoldot.sock") or a TCP port ("tcp", "127.0.0.1:9945"). The JSON-RPC messages
+// are exchanged as newline delimited JSON, see NewStreamTransport.
+//
+// Light clients primarily expose the chainHead_v1 and transaction_v1 groups of methods, the lightclient package
+// serves the legacy methods that the modules of the RPC use on top of them.
+func ConnectSmoldot(network, address string) (Client, error) {
+	conn, err := net.DialTimeout(network, address, config.Default().DialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTransportClient(network+"://"+address, NewStreamTransport(conn)), nil
+}
+
+// streamMessage is a JSON-RPC request, response or notification.
+type streamMessage struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *streamError    `json:"error,omitempty"`
+}
+
+type streamError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+type streamNotificationParams struct {
+	Subscription string          `json:"subscription"`
+	Result       json.RawMessage `json:"result"`
+}
+
+// pendingRequest is a request that awaits its response.
+type pendingRequest struct {
+	response chan streamMessage
+
+	// subscription is set for subscribe requests, it is registered with the ID of the response before any
+	// notification is read.
+	subscription *streamSubscription
+}
+
+// streamTransport is a Transport that exchanges JSON-RPC messages over a stream.
+type streamTransport struct {
+	conn io.ReadWriteCloser
+
+	nextID  atomic.Uint64
+	writeMu sync.Mutex
+
+	mu            sync.Mutex
+	pending       map[string]*pendingRequest
+	subscriptions map[string]*streamSubscription
+	err           error
+
+	done chan struct{}
+}
+
+// NewStreamTransport creates a transport that exchanges JSON-RPC messages over the stream, e.g. a socket or the
+// stdin and stdout of a light client process. Requests are written as newline delimited JSON, while responses and
+// notifications are read as a sequence of JSON values, delimited or not.
+//
+// Notifications are queued for subscribers that do not keep up, up to 20000 per subscription, after which the
+// subscription ends with gethrpc.ErrSubscriptionQueueOverflow. The stream is closed once the transport is closed.
+func NewStreamTransport(conn io.ReadWriteCloser) Transport {
+	t := &streamTransport{
+		conn:          conn,
+		pending:       make(map[string]*pendingRequest),
+		subscriptions: make(map[string]*streamSubscription),
+		done:          make(chan struct{}),
+	}
+
+	go t.read()
+
+	return t
+}
+
+func (t *streamTransport) SendRequest(
+	ctx context.Context,
+	method string,
+	params []interface{},
+) (json.RawMessage, error) {
+	return t.request(ctx, method, params, nil)
+}
+
+func (t *streamTransport) Subscribe(
+	ctx context.Context,
+	method, unsubscribeMethod string,
+	params []interface{},
+) (TransportSubscription, error) {
+	s := newStreamSubscription(t, unsubscribeMethod)
+
+	if _, err := t.request(ctx, method, params, s); err != nil {
+		s.stop()
+
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Close closes the stream, pending requests fail with ErrStreamClosed and subscriptions end with a nil error.
+func (t *streamTransport) Close() error {
+	return t.close(nil)
+}
+
+// request sends the request and waits for its response. Subscriptions of subscribe requests are registered once the
+// response arrives, and unsubscribed if ctx is done before that.
+func (t *streamTransport) request(
+	ctx context.Context,
+	method string,
+	params []interface{},
+	subscription *streamSubscription,
+) (json.RawMessage, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, ErrStreamRequest.Wrap(err)
+	}
+
+	id := strconv.FormatUint(t.nextID.Add(1), 10)
+	p := &pendingRequest{response: make(chan streamMessage, 1), subscription: subscription}
+
+	t.mu.Lock()
+	if isClosed(t.done) {
+		t.mu.Unlock()
+
+		return nil, t.closeError()
+	}
+	t.pending[id] = p
+	t.mu.Unlock()
+
+	msg := streamMessage{Version: "2.0", ID: json.RawMessage(id), Method: method, Params: encodedParams}
+
+	if err := t.write(msg); err != nil {
+		t.forget(id)
+
+		return nil, ErrStreamRequest.Wrap(err)
+	}
+
+	select {
+	case msg := <-p.response:
+		return msg.result()
+	case <-t.done:
+		return nil, t.closeError()
+	case <-ctx.Done():
+		if !t.forget(id) {
+			// The response is being delivered, a subscription it registered is not handed out and must be ended.
+			if msg := <-p.response; msg.Error == nil && subscription != nil {
+				subscription.Unsubscribe()
+			}
+		}
+
+		return nil, ctx.Err()
+	}
+}
+
+// forget removes the pending request and returns whether it was still pending.
+func (t *streamTransport) forget(id string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, ok := t.pending[id]
+	delete(t.pending, id)
+
+	return ok
+}
+
+func (t *streamTransport) write(msg streamMessage) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+
+	_, err = t.conn.Write(append(b, '\n'))
+
+	return err
+}
+
+// read reads the responses and notifications from the stream until it is closed.
+func (t *streamTransport) read() {
+	dec := json.NewDecoder(t.conn)
+
+	for {
+		var msg streamMessage
+
+		if err := dec.Decode(&msg); err != nil {
+			_ = t.close(err)
+
+			return
+		}
+
+		if len(msg.ID) > 0 {
+			t.handleResponse(msg)
+		} else if msg.Method != "" {
+			t.handleNotification(msg)
+		}
+	}
+}
+
+func (t *streamTransport) handleResponse(msg streamMessage) {
+	t.mu.Lock()
+
+	p, ok := t.pending[string(msg.ID)]
+	delete(t.pending, string(msg.ID))
+
+	if ok && p.subscription != nil && msg.Error == nil {
+		var id string
+
+		if err := json.Unmarshal(msg.Result, &id); err != nil {
+			msg.Error = &streamError{Message: ErrInvalidSubscriptionID.Wrap(err).Error()}
+		} else {
+			p.subscription.id = id
+			t.subscriptions[id] = p.subscription
+		}
+	}
+
+	t.mu.Unlock()
+
+	if ok {
+		p.response <- msg
+	}
+}
+
+func (t *streamTransport) handleNotification(msg streamMessage) {
+	var params streamNotificationParams
+
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		// Notifications that do not belong to a subscription are not expected, they are ignored.
+		return
+	}
+
+	t.mu.Lock()
+	s, ok := t.subscriptions[params.Subscription]
+	t.mu.Unlock()
+
+	if ok {
+		s.push(params.Result)
+	}
+}
+
+// close closes the stream and ends all subscriptions with err, it returns the error of closing the stream.
+func (t *streamTransport) close(err error) error {
+	t.mu.Lock()
+
+	if isClosed(t.done) {
+		t.mu.Unlock()
+
+		return nil
+	}
+
+	t.err = err
+	close(t.done)
+
+	subscriptions := t.subscriptions
+	t.subscriptions = make(map[string]*streamSubscription)
+
+	t.mu.Unlock()
+
+	for _, s := range subscriptions {
+		s.fail(err)
+	}
+
+	return t.conn.Close()
+}
+
+func (t *streamTransport) closeError() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.err != nil {
+		return ErrStreamClosed.Wrap(t.err)
+	}
+
+	return ErrStreamClosed
+}
+
+func (t *streamTransport) removeSubscription(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.subscriptions, id)
+}
+
+func (m streamMessage) result() (json.RawMessage, error) {
+	if m.Error != nil {
+		return nil, types.RPCError{Code: m.Error.Code, Message: m.Error.Message, Data: m.Error.Data}
+	}
+
+	return m.Result, nil
+}
+
+// streamSubscription is a subscription established through a stream transport. Its notifications are queued, so
+// that the transport never blocks on subscribers that do not keep up.
+type streamSubscription struct {
+	transport         *streamTransport
+	unsubscribeMethod string
+
+	// id is set by the transport when the response of the subscribe request is read.
+	id string
+
+	channel chan json.RawMessage
+	err     chan error
+
+	mu    sync.Mutex
+	queue []json.RawMessage
+
+	wake     chan struct{}
+	quit     chan struct{}
+	quitOnce sync.Once
+}
+
+func newStreamSubscription(t *streamTransport, unsubscribeMethod string) *streamSubscription {
+	s := &streamSubscription{
+		transport:         t,
+		unsubscribeMethod: unsubscribeMethod,
+		channel:           make(chan json.RawMessage),
+		err:               make(chan error, 1),
+		wake:              make(chan struct{}, 1),
+		quit:              make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s
+}
+
+func (s *streamSubscription) ID() string {
+	return s.id
+}
+
+func (s *streamSubscription) Notifications() <-chan json.RawMessage {
+	return s.channel
+}
+
+func (s *streamSubscription) Err() <-chan error {
+	return s.err
+}
+
+// Unsubscribe ends the subscription and calls the unsubscribe method. It can safely be called more than once.
+func (s *streamSubscription) Unsubscribe() {
+	if !s.stop() {
+		return
+	}
+
+	s.unsubscribe()
+}
+
+func (s *streamSubscription) unsubscribe() {
+	s.transport.removeSubscription(s.id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	defer cancel()
+
+	// The subscription ended either way, a failed unsubscribe only means that the node keeps sending notifications
+	// that are ignored.
+	_, _ = s.transport.request(ctx, s.unsubscribeMethod, []interface{}{s.id}, nil)
+}
+
+// stop ends the subscription and returns whether it was still running.
+func (s *streamSubscription) stop() bool {
+	stopped := false
+
+	s.quitOnce.Do(func() {
+		close(s.quit)
+
+		stopped = true
+	})
+
+	return stopped
+}
+
+// fail ends the subscription with err, which is sent to the error channel, and returns whether it was still running.
+func (s *streamSubscription) fail(err error) bool {
+	if !s.stop() {
+		return false
+	}
+
+	s.err <- err
+
+	return true
+}
+
+// push queues the notification, the subscription ends with gethrpc.ErrSubscriptionQueueOverflow if the queue is full.
+func (s *streamSubscription) push(notification json.RawMessage) {
+	s.mu.Lock()
+
+	if len(s.queue) >= maxQueuedNotifications {
+		s.mu.Unlock()
+
+		if s.fail(gethrpc.ErrSubscriptionQueueOverflow) {
+			// The response of the unsubscribe call is read by the caller of push, so it must not be awaited here.
+			go s.unsubscribe()
+		}
+
+		return
+	}
+
+	s.queue = append(s.queue, notification)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run sends the queued notifications to the channel in order.
+func (s *streamSubscription) run() {
+	for {
+		s.mu.Lock()
+
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+
+			select {
+			case <-s.wake:
+				continue
+			case <-s.quit:
+				return
+			}
+		}
+
+		notification := s.queue[0]
+		s.queue = s.queue[1:]
+
+		s.mu.Unlock()
+
+		select {
+		case s.channel <- notification:
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
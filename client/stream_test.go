@@ -0,0 +1,266 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStreamNode is the node side of a stream transport, requests are answered by the handler.
+type testStreamNode struct {
+	conn    net.Conn
+	handler func(n *testStreamNode, req streamMessage)
+
+	mu       sync.Mutex
+	requests []streamMessage
+}
+
+func newTestStreamNode(t *testing.T, handler func(n *testStreamNode, req streamMessage)) (*testStreamNode, Client) {
+	server, conn := net.Pipe()
+
+	n := &testStreamNode{conn: server, handler: handler}
+
+	go n.serve()
+
+	c := NewTransportClient("pipe://", NewStreamTransport(conn))
+	t.Cleanup(c.Close)
+
+	return n, c
+}
+
+func (n *testStreamNode) serve() {
+	dec := json.NewDecoder(n.conn)
+
+	for {
+		var req streamMessage
+		if err := dec.Decode(&req); err != nil {
+			return
+		}
+
+		n.mu.Lock()
+		n.requests = append(n.requests, req)
+		n.mu.Unlock()
+
+		n.handler(n, req)
+	}
+}
+
+func (n *testStreamNode) respond(req streamMessage, result interface{}) {
+	n.send(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+}
+
+func (n *testStreamNode) notify(method, subscription string, result interface{}) {
+	n.send(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  map[string]interface{}{"subscription": subscription, "result": result},
+	})
+}
+
+func (n *testStreamNode) send(msg interface{}) {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	_, _ = n.conn.Write(append(b, '\n'))
+}
+
+func (n *testStreamNode) called(method string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, req := range n.requests {
+		if req.Method == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestStreamTransport_Call(t *testing.T) {
+	_, c := newTestStreamNode(t, func(n *testStreamNode, req streamMessage) {
+		switch req.Method {
+		case "system_chain":
+			n.respond(req, "Test")
+		default:
+			n.send(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"error":   map[string]interface{}{"code": -32601, "message": "Method not found"},
+			})
+		}
+	})
+
+	var res string
+	require.NoError(t, c.Call(&res, "system_chain"))
+	assert.Equal(t, "Test", res)
+
+	err := c.Call(&res, "state_getMetadata")
+	assert.Equal(t, types.RPCError{Code: -32601, Message: "Method not found"}, err)
+	assert.True(t, types.IsMethodNotFound(err))
+
+	b := c.Batch()
+	b.Add(&res, "system_chain")
+	require.NoError(t, b.Send(context.Background()))
+	assert.Equal(t, "Test", res)
+}
+
+func TestStreamTransport_Subscribe(t *testing.T) {
+	node, c := newTestStreamNode(t, func(n *testStreamNode, req streamMessage) {
+		switch req.Method {
+		case "chain_subscribeNewHead":
+			n.respond(req, "sub-1")
+			n.notify("chain_newHead", "other", types.Header{Number: 100})
+			n.notify("chain_newHead", "sub-1", types.Header{Number: 1})
+			n.notify("chain_newHead", "sub-1", types.Header{Number: 2})
+		case "chain_unsubscribeNewHead":
+			n.respond(req, true)
+		}
+	})
+
+	ch := make(chan types.Header)
+
+	sub, err := c.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	require.NoError(t, err)
+	assert.Equal(t, "sub-1", sub.ID())
+
+	assert.Equal(t, types.BlockNumber(1), (<-ch).Number)
+	assert.Equal(t, types.BlockNumber(2), (<-ch).Number)
+
+	sub.Unsubscribe()
+
+	assert.True(t, node.called("chain_unsubscribeNewHead"))
+
+	_, ok := <-sub.Err()
+	assert.False(t, ok)
+}
+
+func TestStreamTransport_NotificationDecoding(t *testing.T) {
+	_, c := newTestStreamNode(t, func(n *testStreamNode, req streamMessage) {
+		switch req.Method {
+		case "chain_subscribeNewHead":
+			n.respond(req, "sub-1")
+			n.notify("chain_newHead", "sub-1", "not a header")
+		case "chain_unsubscribeNewHead":
+			n.respond(req, true)
+		}
+	})
+
+	sub, err := c.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead",
+		make(chan types.Header))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, <-sub.Err(), ErrNotificationDecoding)
+
+	_, err = c.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead",
+		make(<-chan types.Header))
+	assert.Equal(t, ErrTransportChannel, err)
+}
+
+func TestStreamTransport_Close(t *testing.T) {
+	node, c := newTestStreamNode(t, func(n *testStreamNode, req streamMessage) {
+		if req.Method == "chain_subscribeNewHead" {
+			n.respond(req, "sub-1")
+		}
+	})
+
+	sub, err := c.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead",
+		make(chan types.Header))
+	require.NoError(t, err)
+
+	require.NoError(t, node.conn.Close())
+
+	assert.Error(t, <-sub.Err())
+
+	var res string
+	assert.ErrorIs(t, c.Call(&res, "system_chain"), ErrStreamClosed)
+}
+
+func TestStreamTransport_CloseClient(t *testing.T) {
+	_, c := newTestStreamNode(t, func(n *testStreamNode, req streamMessage) {
+		if req.Method == "chain_subscribeNewHead" {
+			n.respond(req, "sub-1")
+		}
+	})
+
+	sub, err := c.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead",
+		make(chan types.Header))
+	require.NoError(t, err)
+
+	c.Close()
+
+	select {
+	case err := <-sub.Err():
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("subscription did not end")
+	}
+}
+
+func TestStreamTransport_CallContextCanceled(t *testing.T) {
+	_, c := newTestStreamNode(t, func(*testStreamNode, streamMessage) {})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	var res string
+	assert.True(t, errors.Is(c.CallContext(ctx, &res, "system_chain"), context.DeadlineExceeded))
+}
+
+func TestConnectSmoldot(t *testing.T) {
+	address := filepath.Join(t.TempDir(), "smoldot.sock")
+
+	l, err := net.Listen("unix", address)
+	require.NoError(t, err)
+	defer l.Close()
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+
+		n := &testStreamNode{conn: conn, handler: func(n *testStreamNode, req streamMessage) {
+			n.respond(req, "Polkadot")
+		}}
+
+		n.serve()
+	}()
+
+	c, err := ConnectSmoldot("unix", address)
+	require.NoError(t, err)
+	defer c.Close()
+
+	assert.Equal(t, "unix://"+address, c.URL())
+
+	var res string
+	require.NoError(t, c.Call(&res, "system_chain"))
+	assert.Equal(t, "Polkadot", res)
+}
@@ -0,0 +1,194 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/metrics"
+)
+
+// UnsubscribeOnCancel calls unsubscribe once ctx is done, unless the subscription exits before that, as signaled
+// by done. Nothing is started for contexts that can never be done.
+func UnsubscribeOnCancel(ctx context.Context, done <-chan struct{}, unsubscribe func()) {
+	if ctx.Done() == nil {
+		return
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			unsubscribe()
+		case <-done:
+		}
+	}()
+}
+
+// ErrSubscriptionBufferOverflow ends subscriptions with the OverflowError policy whose consumer does not keep up.
+const ErrSubscriptionBufferOverflow = libErr.Error("subscription buffer overflow")
+
+// OverflowPolicy decides what happens to the notifications of a subscription whose buffer is full, because its
+// consumer does not keep up.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock keeps all notifications. Once the buffer is full, further notifications queue in the client, up
+	// to 20000, after which the subscription ends with gethrpc.ErrSubscriptionQueueOverflow. It is the default.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDropOldest drops the oldest buffered notification in favour of the new one. Dropped notifications are
+	// counted and signaled on the Gap channel of the subscription, see SubscriptionOptions.OnDrop.
+	OverflowDropOldest
+
+	// OverflowError ends the subscription with ErrSubscriptionBufferOverflow as soon as a notification does not fit
+	// into the buffer.
+	OverflowError
+)
+
+// SubscriptionOptions configure the buffer between a subscription and its consumer.
+type SubscriptionOptions struct {
+	// BufferSize is the number of notifications the subscription channel buffers. OverflowDropOldest and
+	// OverflowError buffer at least one notification.
+	BufferSize int
+
+	// Policy decides what happens once the buffer is full, OverflowBlock by default.
+	Policy OverflowPolicy
+
+	// OnDrop, if set, is called with the total number of dropped notifications whenever OverflowDropOldest drops one,
+	// e.g. to trigger a reconciliation. It must not block.
+	OnDrop func(dropped uint64)
+
+	// Recorder, if set, records the notifications that OverflowDropOldest drops, see metrics.Recorder.
+	Recorder metrics.Recorder
+}
+
+// SubscriptionBuffer passes the notifications of a subscription to its consumer according to the subscription
+// options. The channel of In is the channel to subscribe with, the consumer receives from Out.
+type SubscriptionBuffer[T any] struct {
+	method  string
+	opts    SubscriptionOptions
+	in      chan T
+	out     chan T
+	dropped atomic.Uint64
+
+	forwarding chan struct{} // closed once forwarding stopped
+	closeOnce  sync.Once
+}
+
+// NewSubscriptionBuffer creates the buffer of a subscription with the subscribe method, e.g. chain_subscribeNewHead.
+// With OverflowBlock the subscription sends to the consumer channel directly, other policies are applied by a
+// goroutine that is started by Forward.
+func NewSubscriptionBuffer[T any](method string, opts SubscriptionOptions) *SubscriptionBuffer[T] {
+	b := &SubscriptionBuffer[T]{method: method, opts: opts, forwarding: make(chan struct{})}
+
+	if opts.Policy == OverflowBlock {
+		b.out = make(chan T, max(opts.BufferSize, 0))
+		b.in = b.out
+
+		close(b.forwarding)
+
+		return b
+	}
+
+	b.in = make(chan T)
+	b.out = make(chan T, max(opts.BufferSize, 1))
+
+	return b
+}
+
+// In returns the channel that the subscription sends its notifications to.
+func (b *SubscriptionBuffer[T]) In() chan T {
+	return b.in
+}
+
+// Out returns the channel that the consumer receives the notifications from.
+func (b *SubscriptionBuffer[T]) Out() chan T {
+	return b.out
+}
+
+// Dropped returns the number of notifications that were dropped so far.
+func (b *SubscriptionBuffer[T]) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Forward starts forwarding the notifications of the subscription until it is done.
+func (b *SubscriptionBuffer[T]) Forward(sub *gethrpc.ClientSubscription) {
+	if b.opts.Policy == OverflowBlock {
+		return
+	}
+
+	go func() {
+		defer close(b.forwarding)
+
+		for {
+			select {
+			case <-sub.Done():
+				return
+			case notification := <-b.in:
+				b.push(sub, notification)
+			}
+		}
+	}()
+}
+
+func (b *SubscriptionBuffer[T]) push(sub *gethrpc.ClientSubscription, notification T) {
+	select {
+	case b.out <- notification:
+		return
+	default:
+	}
+
+	if b.opts.Policy == OverflowError {
+		sub.Fail(ErrSubscriptionBufferOverflow.WithMsg("%d notifications are buffered", cap(b.out)))
+
+		return
+	}
+
+	// Only this goroutine sends to out, after receiving the oldest notification there is room for the new one,
+	// unless the consumer received it in the meantime, in which case there is room anyway.
+	select {
+	case <-b.out:
+		dropped := b.dropped.Add(1)
+
+		sub.SignalGap()
+
+		if b.opts.OnDrop != nil {
+			b.opts.OnDrop(dropped)
+		}
+
+		if b.opts.Recorder != nil {
+			b.opts.Recorder.NotificationDropped(b.method)
+		}
+	default:
+	}
+
+	b.out <- notification
+}
+
+// Close waits until forwarding stopped and closes the consumer channel. The subscription must be unsubscribed
+// before. It can safely be called more than once.
+func (b *SubscriptionBuffer[T]) Close() {
+	<-b.forwarding
+
+	b.closeOnce.Do(func() {
+		close(b.out)
+	})
+}
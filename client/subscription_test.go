@@ -0,0 +1,66 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsubscribeOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	unsubscribed := make(chan struct{})
+
+	UnsubscribeOnCancel(ctx, make(chan struct{}), func() {
+		close(unsubscribed)
+	})
+
+	cancel()
+
+	select {
+	case <-unsubscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription was not unsubscribed")
+	}
+}
+
+func TestUnsubscribeOnCancel_SubscriptionDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+
+	var unsubscribed atomic.Bool
+
+	UnsubscribeOnCancel(ctx, done, func() {
+		unsubscribed.Store(true)
+	})
+
+	close(done)
+
+	// Give the goroutine the chance to observe done before the context is cancelled.
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+
+	assert.False(t, unsubscribed.Load())
+}
@@ -0,0 +1,295 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+const (
+	ErrSubscriptionStale = libErr.Error("no subscription notification received within the staleness window")
+)
+
+// TimeoutOptions configure the timeouts of a client created via ConnectWithTimeouts or WithTimeouts. Zero values
+// disable the respective timeout, just like for a client created via Connect, except for DialTimeout which defaults
+// to config.Default().DialTimeout.
+type TimeoutOptions struct {
+	// DialTimeout bounds establishing the connection.
+	DialTimeout time.Duration
+
+	// Connection configures how the connection is established.
+	Connection ConnectionOptions
+
+	// CallTimeout bounds every call, batch and subscription request whose context has no deadline yet.
+	CallTimeout time.Duration
+
+	// SubscriptionStaleness ends a subscription with ErrSubscriptionStale if neither a notification of the
+	// subscription nor a websocket ping of the node is received within this window. A silent subscription usually
+	// means that the connection is dead, even though the socket is still open.
+	SubscriptionStaleness time.Duration
+}
+
+// ConnectWithTimeouts connects to the provided url, just like Connect, and applies the timeouts to the client.
+func ConnectWithTimeouts(url string, opts TimeoutOptions) (Client, error) {
+	if opts.DialTimeout <= 0 {
+		opts.DialTimeout = config.Default().DialTimeout
+	}
+
+	c, err := connect(url, opts.DialTimeout, opts.Connection)
+	if err != nil {
+		return nil, err
+	}
+
+	return WithTimeouts(c, opts), nil
+}
+
+// WithTimeouts applies the call and subscription timeouts to the provided client, e.g. to a client created via
+// ConnectWithReconnect. The DialTimeout and Connection are not used.
+func WithTimeouts(c Client, opts TimeoutOptions) Client {
+	return &timeoutClient{Client: c, opts: opts}
+}
+
+type timeoutClient struct {
+	Client
+
+	opts TimeoutOptions
+}
+
+func (c *timeoutClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *timeoutClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	return c.Client.CallContext(ctx, result, method, args...)
+}
+
+func (c *timeoutClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	return c.Client.BatchCallContext(ctx, b)
+}
+
+// Batch returns a new, empty batch that is sent via this client
+func (c *timeoutClient) Batch() *Batch {
+	return NewBatch(c)
+}
+
+func (c *timeoutClient) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	if c.opts.SubscriptionStaleness <= 0 {
+		return c.Client.Subscribe(
+			ctx,
+			namespace,
+			subscribeMethodSuffix,
+			unsubscribeMethodSuffix,
+			notificationMethodSuffix,
+			channel,
+			args...,
+		)
+	}
+
+	in := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, reflect.TypeOf(channel).Elem()), 0)
+
+	inner, err := c.Client.Subscribe(
+		ctx,
+		namespace,
+		subscribeMethodSuffix,
+		unsubscribeMethodSuffix,
+		notificationMethodSuffix,
+		in.Interface(),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.watchStaleness(inner, in, reflect.ValueOf(channel)), nil
+}
+
+// lastPing returns the time the node last sent a websocket ping to the wrapped client.
+func (c *timeoutClient) lastPing() time.Time {
+	return lastPing(c.Client)
+}
+
+// withCallTimeout applies the CallTimeout to ctx, unless ctx already has a deadline.
+func (c *timeoutClient) withCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || c.opts.CallTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, c.opts.CallTimeout)
+}
+
+// watchStaleness returns a subscription that delivers the notifications of inner, which are received from in, to out
+// and fails with ErrSubscriptionStale once the subscription is silent for longer than the staleness window.
+func (c *timeoutClient) watchStaleness(
+	inner *gethrpc.ClientSubscription,
+	in, out reflect.Value,
+) *gethrpc.ClientSubscription {
+	quit := make(chan struct{})
+	done := make(chan struct{})
+
+	var quitOnce sync.Once
+
+	// Unsubscribe waits for the forwarding to stop, so that the subscriber channel can be closed afterwards.
+	outer := gethrpc.NewDetachedClientSubscription(inner.ID, func() {
+		inner.Unsubscribe()
+		quitOnce.Do(func() { close(quit) })
+		<-done
+	})
+
+	go func() {
+		failed, err := c.watch(inner, outer, in, out, quit)
+
+		close(done)
+
+		if !failed {
+			return
+		}
+
+		if err == ErrSubscriptionStale {
+			// The unsubscribe request might never be answered by a dead node.
+			go inner.Unsubscribe()
+		} else if err == nil {
+			// Adhere to the subscription semantics, the error channel receives nil for closed clients.
+			err = gethrpc.ErrClientQuit
+		}
+
+		outer.Fail(err)
+	}()
+
+	return outer
+}
+
+// watch runs until quit is closed, inner fails or the subscription gets stale, in which case it returns true and the
+// error of inner or ErrSubscriptionStale.
+func (c *timeoutClient) watch(
+	inner, outer *gethrpc.ClientSubscription,
+	in, out reflect.Value,
+	quit <-chan struct{},
+) (bool, error) {
+	window := c.opts.SubscriptionStaleness
+	lastNotification := time.Now()
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: in},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(quit)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(inner.Err())},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(inner.Gap())},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)},
+	}
+
+	sendCases := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: out},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(quit)},
+	}
+
+	for {
+		chosen, recv, ok := reflect.Select(cases)
+
+		switch chosen {
+		case 0: // <-in
+			lastNotification = time.Now()
+
+			sendCases[0].Send = recv
+
+			if chosen, _, _ := reflect.Select(sendCases); chosen == 1 {
+				return false, nil
+			}
+
+			sendCases[0].Send = reflect.Value{}
+		case 1: // <-quit
+			return false, nil
+		case 2: // <-inner.Err()
+			if !ok {
+				// The error channel is only closed on Unsubscribe.
+				return false, nil
+			}
+
+			err, _ := recv.Interface().(error)
+
+			return true, err
+		case 3: // <-inner.Gap()
+			outer.SignalGap()
+		case 4: // <-timer.C
+			last := lastNotification
+			if ping := c.lastPing(); ping.After(last) {
+				last = ping
+			}
+
+			silence := time.Since(last)
+			if silence >= window {
+				return true, ErrSubscriptionStale
+			}
+
+			timer.Reset(window - silence)
+		}
+	}
+}
+
+// pinger is implemented by the clients that know when the node last sent a websocket ping.
+type pinger interface {
+	lastPing() time.Time
+}
+
+// lastPing returns the time the node last sent a websocket ping to c, or the zero time if that is unknown.
+func lastPing(c Client) time.Time {
+	if p, ok := c.(pinger); ok {
+		return p.lastPing()
+	}
+
+	return time.Time{}
+}
+
+func (c *client) lastPing() time.Time {
+	return c.Client.LastPing()
+}
+
+func (c *middlewareClient) lastPing() time.Time {
+	return lastPing(c.Client)
+}
+
+func (c *reconnectingClient) lastPing() time.Time {
+	conn, _, err := c.current()
+	if err != nil {
+		return time.Time{}
+	}
+
+	return conn.LastPing()
+}
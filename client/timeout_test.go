@@ -0,0 +1,131 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectWithTimeouts_CallTimeout(t *testing.T) {
+	node := newTestNode(t)
+
+	c, err := ConnectWithTimeouts(node.url(), TimeoutOptions{CallTimeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+	defer c.Close()
+
+	var res string
+	err = c.Call(&res, "test_hang")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	err = c.Call(&res, "test_echo", "value")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", res)
+}
+
+func TestConnectWithTimeouts_DialTimeout(t *testing.T) {
+	_, err := ConnectWithTimeouts("ws://10.255.255.1:9944", TimeoutOptions{DialTimeout: 50 * time.Millisecond})
+	assert.Error(t, err)
+}
+
+func TestConnectWithTimeouts_NoTimeouts(t *testing.T) {
+	node := newTestNode(t)
+
+	c, err := ConnectWithTimeouts(node.url(), TimeoutOptions{})
+	require.NoError(t, err)
+	defer c.Close()
+
+	ch := make(chan string)
+
+	sub, err := c.Subscribe(context.Background(), "test", "subscribe", "unsubscribe", "notification", ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	assert.Equal(t, "sub-1", receive(t, ch))
+
+	select {
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestConnectWithTimeouts_SubscriptionStaleness(t *testing.T) {
+	node := newTestNode(t)
+
+	c, err := ConnectWithTimeouts(node.url(), TimeoutOptions{SubscriptionStaleness: 100 * time.Millisecond})
+	require.NoError(t, err)
+	defer c.Close()
+
+	ch := make(chan string)
+
+	sub, err := c.Subscribe(context.Background(), "test", "subscribe", "unsubscribe", "notification", ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	assert.Equal(t, "sub-1", receive(t, ch))
+
+	// Pings of the node keep the subscription alive.
+	for i := 0; i < 6; i++ {
+		time.Sleep(40 * time.Millisecond)
+		node.ping()
+	}
+
+	select {
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	default:
+	}
+
+	select {
+	case err := <-sub.Err():
+		assert.ErrorIs(t, err, ErrSubscriptionStale)
+	case <-time.After(time.Second):
+		t.Fatal("subscription did not get stale")
+	}
+}
+
+func TestWithTimeouts_ReconnectingClient(t *testing.T) {
+	node := newTestNode(t)
+
+	rc, _ := connectTestNode(t, node, nil)
+
+	c := WithTimeouts(rc, TimeoutOptions{SubscriptionStaleness: 100 * time.Millisecond})
+	defer c.Close()
+
+	ch := make(chan string)
+
+	sub, err := c.Subscribe(context.Background(), "test", "subscribe", "unsubscribe", "notification", ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	assert.Equal(t, "sub-1", receive(t, ch))
+
+	node.ping()
+
+	select {
+	case err := <-sub.Err():
+		assert.ErrorIs(t, err, ErrSubscriptionStale)
+	case <-time.After(time.Second):
+		t.Fatal("subscription did not get stale")
+	}
+}
@@ -0,0 +1,229 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+const (
+	ErrTransportChannel     = libErr.Error("subscription channel must be a writable channel")
+	ErrNotificationDecoding = libErr.Error("notification decoding")
+)
+
+// Transport sends JSON-RPC requests to a node and receives the notifications of subscriptions. It abstracts the
+// connection of a client, so that other backends than the websocket and HTTP connections, e.g. an embedded light
+// client, can be plugged in via NewTransportClient.
+type Transport interface {
+	// SendRequest sends the request and returns the raw result. Errors returned by the node should be of type
+	// types.RPCError.
+	SendRequest(ctx context.Context, method string, params []interface{}) (json.RawMessage, error)
+
+	// Subscribe sends the subscribe request and returns the subscription, whose unsubscribeMethod is called with the
+	// subscription ID once it is unsubscribed.
+	Subscribe(
+		ctx context.Context,
+		method, unsubscribeMethod string,
+		params []interface{},
+	) (TransportSubscription, error)
+
+	// Close closes the transport, which ends all subscriptions.
+	Close() error
+}
+
+// TransportSubscription is a subscription established through Transport.Subscribe.
+type TransportSubscription interface {
+	// ID returns the subscription ID assigned by the node.
+	ID() string
+
+	// Notifications returns the channel that receives the raw notifications of the subscription. Transports must not
+	// block on subscribers that do not keep up.
+	Notifications() <-chan json.RawMessage
+
+	// Err returns the channel that receives a value once the subscription ends for another reason than Unsubscribe,
+	// e.g. because the transport was closed.
+	Err() <-chan error
+
+	// Unsubscribe ends the subscription. It can safely be called more than once.
+	Unsubscribe()
+}
+
+// transportClient is a Client that sends its requests via a Transport.
+type transportClient struct {
+	transport Transport
+	url       string
+}
+
+// NewTransportClient creates a client that sends its requests via the transport. The url is only used to answer
+// URL, e.g. to identify the client in logs and metrics.
+func NewTransportClient(url string, transport Transport) Client {
+	return &transportClient{transport: transport, url: url}
+}
+
+// URL returns the URL the client was created with
+func (c *transportClient) URL() string {
+	return c.url
+}
+
+func (c *transportClient) Close() {
+	_ = c.transport.Close()
+}
+
+func (c *transportClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+// CallContext sends the request via the transport and unmarshals the result into result, unless it is nil
+func (c *transportClient) CallContext(
+	ctx context.Context,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	if args == nil {
+		args = []interface{}{}
+	}
+
+	res, err := c.transport.SendRequest(ctx, method, args)
+	if err != nil {
+		return toRPCError(err)
+	}
+
+	if result == nil || len(res) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(res, result)
+}
+
+// BatchCallContext sends the calls of the batch one after another, since transports do not support batch requests.
+func (c *transportClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	for i := range b {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		b[i].Error = c.CallContext(ctx, b[i].Result, b[i].Method, b[i].Args...)
+	}
+
+	return nil
+}
+
+// Batch returns a new, empty batch that is sent via this client
+func (c *transportClient) Batch() *Batch {
+	return NewBatch(c)
+}
+
+// Subscribe subscribes via the transport and sends the notifications, unmarshalled into the element type of channel,
+// to channel.
+func (c *transportClient) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, _ string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	ch := reflect.ValueOf(channel)
+	if ch.Kind() != reflect.Chan || ch.Type().ChanDir()&reflect.SendDir == 0 {
+		return nil, ErrTransportChannel
+	}
+
+	if args == nil {
+		args = []interface{}{}
+	}
+
+	inner, err := c.transport.Subscribe(
+		ctx,
+		namespace+"_"+subscribeMethodSuffix,
+		namespace+"_"+unsubscribeMethodSuffix,
+		args,
+	)
+	if err != nil {
+		return nil, toRPCError(err)
+	}
+
+	quit := make(chan struct{})
+	done := make(chan struct{})
+
+	var quitOnce sync.Once
+
+	// Unsubscribe waits for the forwarding to exit, so that the channel can be closed afterwards.
+	sub := gethrpc.NewDetachedClientSubscription(inner.ID, func() {
+		quitOnce.Do(func() {
+			close(quit)
+			inner.Unsubscribe()
+		})
+
+		<-done
+	})
+
+	go func() {
+		err := forwardNotifications(inner, ch, quit)
+
+		close(done)
+
+		if err != nil {
+			sub.Fail(err)
+		}
+	}()
+
+	return sub, nil
+}
+
+// forwardNotifications sends the notifications of the transport subscription to ch until quit is closed or the
+// subscription ends.
+func forwardNotifications(inner TransportSubscription, ch reflect.Value, quit <-chan struct{}) error {
+	elem := ch.Type().Elem()
+
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: ch},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(quit)},
+	}
+
+	for {
+		select {
+		case <-quit:
+			return nil
+		case err := <-inner.Err():
+			if err == nil {
+				// Adhere to subscription semantics, a closed transport ends the subscription without error.
+				return gethrpc.ErrClientQuit
+			}
+
+			return err
+		case raw := <-inner.Notifications():
+			v := reflect.New(elem)
+
+			if err := json.Unmarshal(raw, v.Interface()); err != nil {
+				inner.Unsubscribe()
+
+				return ErrNotificationDecoding.Wrap(err)
+			}
+
+			cases[0].Send = v.Elem()
+
+			if chosen, _, _ := reflect.Select(cases); chosen == 1 {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// DefaultPackageName is the name of the generated package if Options.PackageName is empty.
+const DefaultPackageName = "runtime"
+
+// Options configure the generated code.
+type Options struct {
+	// PackageName is the name of the generated package, DefaultPackageName if it is empty.
+	PackageName string
+
+	// Pallets limits the generated calls, events and errors to the pallets with the given names, e.g. Balances. All
+	// pallets are generated if it is empty.
+	Pallets []string
+}
+
+// Generate generates the Go source of a package with the calls, events and errors of the pallets of the metadata.
+//
+// Each call, event and error is a struct with the typed args or fields of the variant, together with constants for
+// the indices of the pallet and the variant. Calls create the types.Call via their Call method, errors implement the
+// error interface. The types of the fields are generated as well: composites as structs, enums as a struct that
+// holds one of the variant structs in its Value, enums without fields as uint8 constants, options as types.Option and
+// accounts, integers and byte sequences as the types of the types package. All generated structs implement the
+// scale.Encodeable and scale.Decodeable interfaces.
+//
+// The generated source only depends on the metadata and the options, names that clash are made unique in the order
+// of the metadata.
+func Generate(meta *types.Metadata, opts Options) ([]byte, error) {
+	packageName := opts.PackageName
+	if packageName == "" {
+		packageName = DefaultPackageName
+	}
+
+	if !token.IsIdentifier(packageName) {
+		return nil, ErrInvalidPackageName.WithMsg(packageName)
+	}
+
+	pallets, err := selectPallets(meta, opts.Pallets)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		lookup:  meta.AsMetadataV14.EfficientLookup,
+		names:   names{},
+		goTypes: map[int64]string{},
+	}
+
+	for _, pallet := range pallets {
+		if err := g.generatePallet(pallet); err != nil {
+			return nil, ErrPalletGeneration.WithMsg(string(pallet.Name)).Wrap(err)
+		}
+	}
+
+	return g.source(packageName)
+}
+
+// selectPallets returns the pallets with the given names in the order of the metadata, or all pallets if no names
+// are given.
+func selectPallets(meta *types.Metadata, names []string) ([]types.PalletMetadataV14, error) {
+	if len(names) == 0 {
+		return meta.AsMetadataV14.Pallets, nil
+	}
+
+	selected := map[string]bool{}
+
+	for _, name := range names {
+		selected[name] = true
+	}
+
+	var pallets []types.PalletMetadataV14
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if selected[string(pallet.Name)] {
+			pallets = append(pallets, pallet)
+			delete(selected, string(pallet.Name))
+		}
+	}
+
+	for _, name := range names {
+		if selected[name] {
+			return nil, ErrPalletNotFound.WithMsg(name)
+		}
+	}
+
+	return pallets, nil
+}
+
+// field is a field of a generated struct.
+type field struct {
+	name   string
+	goType string
+}
+
+type generator struct {
+	lookup map[int64]*types.Si1Type
+
+	// names holds the declarations of the package.
+	names names
+
+	// goTypes holds the Go types of the resolved metadata types.
+	goTypes map[int64]string
+
+	// pallets holds the declarations of the pallets, types the declarations of the types of their fields.
+	pallets bytes.Buffer
+	types   bytes.Buffer
+}
+
+func (g *generator) source(packageName string) ([]byte, error) {
+	body := g.pallets.String() + g.types.String()
+
+	var src bytes.Buffer
+
+	src.WriteString("// Code generated by go-substrate-rpc-client codegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", packageName)
+
+	src.WriteString("import (\n")
+
+	if strings.Contains(body, "fmt.") {
+		src.WriteString("\t\"fmt\"\n\n")
+	}
+
+	for _, pkg := range []struct{ name, path string }{
+		{"scale", "github.com/centrifuge/go-substrate-rpc-client/v4/scale"},
+		{"types", "github.com/centrifuge/go-substrate-rpc-client/v4/types"},
+		{"codec", "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"},
+	} {
+		if strings.Contains(body, pkg.name+".") {
+			fmt.Fprintf(&src, "\t%q\n", pkg.path)
+		}
+	}
+
+	src.WriteString(")\n\n")
+	src.WriteString(body)
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, ErrSourceFormatting.Wrap(err)
+	}
+
+	return formatted, nil
+}
+
+func (g *generator) generatePallet(pallet types.PalletMetadataV14) error {
+	palletName := exported(string(pallet.Name))
+	indexName := g.names.allocate(palletName + "PalletIndex")
+
+	fmt.Fprintf(&g.pallets, "// %s is the index of the %s pallet.\n", indexName, pallet.Name)
+	fmt.Fprintf(&g.pallets, "const %s uint8 = %d\n\n", indexName, pallet.Index)
+
+	for _, kind := range []struct {
+		has    bool
+		typeID types.Si1LookupTypeID
+		suffix string
+	}{
+		{pallet.HasCalls, pallet.Calls.Type, "Call"},
+		{pallet.HasEvents, pallet.Events.Type, "Event"},
+		{pallet.HasErrors, pallet.Errors.Type, "Error"},
+	} {
+		if !kind.has {
+			continue
+		}
+
+		t, ok := g.lookup[kind.typeID.Int64()]
+		if !ok || !t.Def.IsVariant {
+			return ErrTypeNotFound.WithMsg("%s type %d", strings.ToLower(kind.suffix), kind.typeID.Int64())
+		}
+
+		for _, variant := range t.Def.Variant.Variants {
+			if err := g.generateVariant(pallet, indexName, variant, kind.suffix); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// generateVariant generates the struct of a call, event or error of the pallet.
+func (g *generator) generateVariant(
+	pallet types.PalletMetadataV14,
+	palletIndexName string,
+	variant types.Si1Variant,
+	suffix string,
+) error {
+	name := g.names.allocate(exported(string(pallet.Name)) + exported(string(variant.Name)) + suffix)
+	indexName := g.names.allocate(name + "Index")
+	fullName := fmt.Sprintf("%s.%s", pallet.Name, variant.Name)
+
+	fields, err := g.fields(variant.Fields, suffix)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", strings.ToLower(suffix), variant.Name, err)
+	}
+
+	b := &g.pallets
+
+	fmt.Fprintf(b, "// %s is the index of the %s %s.\n", indexName, strings.ToLower(suffix), fullName)
+	fmt.Fprintf(b, "const %s uint8 = %d\n\n", indexName, variant.Index)
+
+	writeStruct(b, fmt.Sprintf("%s is the %s %s.", name, strings.ToLower(suffix), fullName), name, fields)
+
+	switch suffix {
+	case "Call":
+		fmt.Fprintf(b, "// CallIndex returns the index of the call.\n")
+		fmt.Fprintf(b, "func (%s) CallIndex() types.CallIndex {\n", name)
+		fmt.Fprintf(b, "\treturn types.CallIndex{SectionIndex: %s, MethodIndex: %s}\n}\n\n", palletIndexName, indexName)
+
+		fmt.Fprintf(b, "// Call returns the encoded call, e.g. to sign it.\n")
+		fmt.Fprintf(b, "func (v %s) Call() (types.Call, error) {\n", name)
+		fmt.Fprintf(b, "\targs, err := codec.Encode(v)\n\tif err != nil {\n\t\treturn types.Call{}, err\n\t}\n\n")
+		fmt.Fprintf(b, "\treturn types.Call{CallIndex: v.CallIndex(), Args: args}, nil\n}\n\n")
+	case "Event":
+		fmt.Fprintf(b, "// EventID returns the ID of the event.\n")
+		fmt.Fprintf(b, "func (%s) EventID() types.EventID {\n", name)
+		fmt.Fprintf(b, "\treturn types.EventID{%s, %s}\n}\n\n", palletIndexName, indexName)
+	case "Error":
+		fmt.Fprintf(b, "// Error returns the name of the error.\n")
+		fmt.Fprintf(b, "func (%s) Error() string {\n\treturn %q\n}\n\n", name, fullName)
+	}
+
+	return nil
+}
+
+// reservedFields are the names of the methods of the generated structs, which fields must not use.
+var reservedFields = map[string][]string{
+	"":      {"Encode", "Decode"},
+	"Call":  {"Encode", "Decode", "CallIndex", "Call"},
+	"Event": {"Encode", "Decode", "EventID"},
+	"Error": {"Encode", "Decode", "Error"},
+}
+
+// fields resolves the fields of a struct, unnamed fields are named by their position.
+func (g *generator) fields(si1Fields []types.Si1Field, kind string) ([]field, error) {
+	fieldNames := names{}
+
+	for _, reserved := range reservedFields[kind] {
+		fieldNames[reserved] = true
+	}
+
+	fields := make([]field, 0, len(si1Fields))
+
+	for i, si1Field := range si1Fields {
+		goType, err := g.goType(si1Field.Type.Int64())
+		if err != nil {
+			return nil, err
+		}
+
+		name := fmt.Sprintf("Field%d", i)
+		if si1Field.HasName {
+			name = exported(string(si1Field.Name))
+		}
+
+		fields = append(fields, field{name: fieldNames.allocate(name), goType: goType})
+	}
+
+	return fields, nil
+}
+
+// writeStruct writes the struct with its Encode and Decode methods.
+func writeStruct(b *bytes.Buffer, doc string, name string, fields []field) {
+	if len(fields) == 0 {
+		fmt.Fprintf(b, "// %s\ntype %s struct{}\n\n", doc, name)
+		fmt.Fprintf(b, "func (%s) Encode(_ scale.Encoder) error {\n\treturn nil\n}\n\n", name)
+		fmt.Fprintf(b, "func (*%s) Decode(_ scale.Decoder) error {\n\treturn nil\n}\n\n", name)
+
+		return
+	}
+
+	fmt.Fprintf(b, "// %s\ntype %s struct {\n", doc, name)
+
+	for _, f := range fields {
+		fmt.Fprintf(b, "\t%s %s\n", f.name, f.goType)
+	}
+
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(b, "func (v %s) Encode(encoder scale.Encoder) error {\n", name)
+
+	for _, f := range fields {
+		fmt.Fprintf(b, "\tif err := encoder.Encode(v.%s); err != nil {\n\t\treturn err\n\t}\n\n", f.name)
+	}
+
+	b.WriteString("\treturn nil\n}\n\n")
+
+	fmt.Fprintf(b, "func (v *%s) Decode(decoder scale.Decoder) error {\n", name)
+
+	for _, f := range fields {
+		fmt.Fprintf(b, "\tif err := decoder.Decode(&v.%s); err != nil {\n\t\treturn err\n\t}\n\n", f.name)
+	}
+
+	b.WriteString("\treturn nil\n}\n\n")
+}
@@ -0,0 +1,98 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the generated polkadot package")
+
+const polkadotPackagePath = "internal/polkadot/polkadot.go"
+
+func polkadotMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	require.NoError(t, codec.DecodeFromHex(test.PolkadotMetadataHex, &meta))
+
+	return &meta
+}
+
+func TestGenerate(t *testing.T) {
+	meta := polkadotMetadata(t)
+
+	src, err := Generate(meta, Options{PackageName: "polkadot", Pallets: []string{"Balances", "System"}})
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.WriteFile(polkadotPackagePath, src, 0600))
+	}
+
+	expected, err := os.ReadFile(polkadotPackagePath)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(src), "run the tests with -update to update the generated package")
+
+	// The output does not depend on the order of the pallet filter.
+	again, err := Generate(meta, Options{PackageName: "polkadot", Pallets: []string{"System", "Balances"}})
+	require.NoError(t, err)
+	assert.Equal(t, src, again)
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	meta := polkadotMetadata(t)
+
+	_, err := Generate(meta, Options{PackageName: "func"})
+	assert.ErrorIs(t, err, ErrInvalidPackageName)
+
+	_, err = Generate(meta, Options{Pallets: []string{"Balances", "Unknown"}})
+	assert.ErrorIs(t, err, ErrPalletNotFound)
+
+	// Bit sequences are not supported, e.g. in the candidates of ParaInherent.enter.
+	_, err = Generate(meta, Options{Pallets: []string{"ParaInherent"}})
+	assert.ErrorIs(t, err, ErrPalletGeneration)
+	assert.ErrorIs(t, err, ErrTypeNotSupported)
+}
+
+func TestNames_Allocate(t *testing.T) {
+	n := names{}
+
+	assert.Equal(t, "Transfer", n.allocate("Transfer"))
+	assert.Equal(t, "BalancesTransfer", n.allocate("Transfer", "BalancesTransfer"))
+	assert.Equal(t, "BalancesTransfer2", n.allocate("Transfer", "BalancesTransfer"))
+	assert.Equal(t, "BalancesTransfer3", n.allocate("BalancesTransfer"))
+}
+
+func TestExported(t *testing.T) {
+	for name, expected := range map[string]string{
+		"transfer_keep_alive": "TransferKeepAlive",
+		"type":                "Type",
+		"AccountId32":         "AccountId32",
+		"sp_core::crypto":     "SpCoreCrypto",
+		"0x":                  "X0x",
+		"":                    "X",
+	} {
+		assert.Equal(t, expected, exported(name), name)
+	}
+}
@@ -0,0 +1,2208 @@
+// Code generated by go-substrate-rpc-client codegen. DO NOT EDIT.
+
+package polkadot
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// SystemPalletIndex is the index of the System pallet.
+const SystemPalletIndex uint8 = 0
+
+// SystemRemarkCallIndex is the index of the call System.remark.
+const SystemRemarkCallIndex uint8 = 0
+
+// SystemRemarkCall is the call System.remark.
+type SystemRemarkCall struct {
+	Remark types.Bytes
+}
+
+func (v SystemRemarkCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Remark); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemRemarkCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Remark); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (SystemRemarkCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: SystemPalletIndex, MethodIndex: SystemRemarkCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v SystemRemarkCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// SystemSetHeapPagesCallIndex is the index of the call System.set_heap_pages.
+const SystemSetHeapPagesCallIndex uint8 = 1
+
+// SystemSetHeapPagesCall is the call System.set_heap_pages.
+type SystemSetHeapPagesCall struct {
+	Pages types.U64
+}
+
+func (v SystemSetHeapPagesCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Pages); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemSetHeapPagesCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Pages); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (SystemSetHeapPagesCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: SystemPalletIndex, MethodIndex: SystemSetHeapPagesCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v SystemSetHeapPagesCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// SystemSetCodeCallIndex is the index of the call System.set_code.
+const SystemSetCodeCallIndex uint8 = 2
+
+// SystemSetCodeCall is the call System.set_code.
+type SystemSetCodeCall struct {
+	Code types.Bytes
+}
+
+func (v SystemSetCodeCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Code); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemSetCodeCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Code); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (SystemSetCodeCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: SystemPalletIndex, MethodIndex: SystemSetCodeCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v SystemSetCodeCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// SystemSetCodeWithoutChecksCallIndex is the index of the call System.set_code_without_checks.
+const SystemSetCodeWithoutChecksCallIndex uint8 = 3
+
+// SystemSetCodeWithoutChecksCall is the call System.set_code_without_checks.
+type SystemSetCodeWithoutChecksCall struct {
+	Code types.Bytes
+}
+
+func (v SystemSetCodeWithoutChecksCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Code); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemSetCodeWithoutChecksCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Code); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (SystemSetCodeWithoutChecksCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: SystemPalletIndex, MethodIndex: SystemSetCodeWithoutChecksCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v SystemSetCodeWithoutChecksCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// SystemSetStorageCallIndex is the index of the call System.set_storage.
+const SystemSetStorageCallIndex uint8 = 4
+
+// SystemSetStorageCall is the call System.set_storage.
+type SystemSetStorageCall struct {
+	Items []TupleBytesBytes
+}
+
+func (v SystemSetStorageCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Items); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemSetStorageCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Items); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (SystemSetStorageCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: SystemPalletIndex, MethodIndex: SystemSetStorageCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v SystemSetStorageCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// SystemKillStorageCallIndex is the index of the call System.kill_storage.
+const SystemKillStorageCallIndex uint8 = 5
+
+// SystemKillStorageCall is the call System.kill_storage.
+type SystemKillStorageCall struct {
+	Keys []types.Bytes
+}
+
+func (v SystemKillStorageCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Keys); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemKillStorageCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Keys); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (SystemKillStorageCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: SystemPalletIndex, MethodIndex: SystemKillStorageCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v SystemKillStorageCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// SystemKillPrefixCallIndex is the index of the call System.kill_prefix.
+const SystemKillPrefixCallIndex uint8 = 6
+
+// SystemKillPrefixCall is the call System.kill_prefix.
+type SystemKillPrefixCall struct {
+	Prefix  types.Bytes
+	Subkeys types.U32
+}
+
+func (v SystemKillPrefixCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Prefix); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Subkeys); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemKillPrefixCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Prefix); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Subkeys); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (SystemKillPrefixCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: SystemPalletIndex, MethodIndex: SystemKillPrefixCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v SystemKillPrefixCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// SystemRemarkWithEventCallIndex is the index of the call System.remark_with_event.
+const SystemRemarkWithEventCallIndex uint8 = 7
+
+// SystemRemarkWithEventCall is the call System.remark_with_event.
+type SystemRemarkWithEventCall struct {
+	Remark types.Bytes
+}
+
+func (v SystemRemarkWithEventCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Remark); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemRemarkWithEventCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Remark); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (SystemRemarkWithEventCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: SystemPalletIndex, MethodIndex: SystemRemarkWithEventCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v SystemRemarkWithEventCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// SystemExtrinsicSuccessEventIndex is the index of the event System.ExtrinsicSuccess.
+const SystemExtrinsicSuccessEventIndex uint8 = 0
+
+// SystemExtrinsicSuccessEvent is the event System.ExtrinsicSuccess.
+type SystemExtrinsicSuccessEvent struct {
+	DispatchInfo DispatchInfo
+}
+
+func (v SystemExtrinsicSuccessEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.DispatchInfo); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemExtrinsicSuccessEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.DispatchInfo); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (SystemExtrinsicSuccessEvent) EventID() types.EventID {
+	return types.EventID{SystemPalletIndex, SystemExtrinsicSuccessEventIndex}
+}
+
+// SystemExtrinsicFailedEventIndex is the index of the event System.ExtrinsicFailed.
+const SystemExtrinsicFailedEventIndex uint8 = 1
+
+// SystemExtrinsicFailedEvent is the event System.ExtrinsicFailed.
+type SystemExtrinsicFailedEvent struct {
+	DispatchError DispatchError
+	DispatchInfo  DispatchInfo
+}
+
+func (v SystemExtrinsicFailedEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.DispatchError); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.DispatchInfo); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemExtrinsicFailedEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.DispatchError); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.DispatchInfo); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (SystemExtrinsicFailedEvent) EventID() types.EventID {
+	return types.EventID{SystemPalletIndex, SystemExtrinsicFailedEventIndex}
+}
+
+// SystemCodeUpdatedEventIndex is the index of the event System.CodeUpdated.
+const SystemCodeUpdatedEventIndex uint8 = 2
+
+// SystemCodeUpdatedEvent is the event System.CodeUpdated.
+type SystemCodeUpdatedEvent struct{}
+
+func (SystemCodeUpdatedEvent) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*SystemCodeUpdatedEvent) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (SystemCodeUpdatedEvent) EventID() types.EventID {
+	return types.EventID{SystemPalletIndex, SystemCodeUpdatedEventIndex}
+}
+
+// SystemNewAccountEventIndex is the index of the event System.NewAccount.
+const SystemNewAccountEventIndex uint8 = 3
+
+// SystemNewAccountEvent is the event System.NewAccount.
+type SystemNewAccountEvent struct {
+	Account types.AccountID
+}
+
+func (v SystemNewAccountEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Account); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemNewAccountEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Account); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (SystemNewAccountEvent) EventID() types.EventID {
+	return types.EventID{SystemPalletIndex, SystemNewAccountEventIndex}
+}
+
+// SystemKilledAccountEventIndex is the index of the event System.KilledAccount.
+const SystemKilledAccountEventIndex uint8 = 4
+
+// SystemKilledAccountEvent is the event System.KilledAccount.
+type SystemKilledAccountEvent struct {
+	Account types.AccountID
+}
+
+func (v SystemKilledAccountEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Account); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemKilledAccountEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Account); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (SystemKilledAccountEvent) EventID() types.EventID {
+	return types.EventID{SystemPalletIndex, SystemKilledAccountEventIndex}
+}
+
+// SystemRemarkedEventIndex is the index of the event System.Remarked.
+const SystemRemarkedEventIndex uint8 = 5
+
+// SystemRemarkedEvent is the event System.Remarked.
+type SystemRemarkedEvent struct {
+	Sender types.AccountID
+	Hash   [32]byte
+}
+
+func (v SystemRemarkedEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Sender); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Hash); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *SystemRemarkedEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Sender); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Hash); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (SystemRemarkedEvent) EventID() types.EventID {
+	return types.EventID{SystemPalletIndex, SystemRemarkedEventIndex}
+}
+
+// SystemInvalidSpecNameErrorIndex is the index of the error System.InvalidSpecName.
+const SystemInvalidSpecNameErrorIndex uint8 = 0
+
+// SystemInvalidSpecNameError is the error System.InvalidSpecName.
+type SystemInvalidSpecNameError struct{}
+
+func (SystemInvalidSpecNameError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*SystemInvalidSpecNameError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (SystemInvalidSpecNameError) Error() string {
+	return "System.InvalidSpecName"
+}
+
+// SystemSpecVersionNeedsToIncreaseErrorIndex is the index of the error System.SpecVersionNeedsToIncrease.
+const SystemSpecVersionNeedsToIncreaseErrorIndex uint8 = 1
+
+// SystemSpecVersionNeedsToIncreaseError is the error System.SpecVersionNeedsToIncrease.
+type SystemSpecVersionNeedsToIncreaseError struct{}
+
+func (SystemSpecVersionNeedsToIncreaseError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*SystemSpecVersionNeedsToIncreaseError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (SystemSpecVersionNeedsToIncreaseError) Error() string {
+	return "System.SpecVersionNeedsToIncrease"
+}
+
+// SystemFailedToExtractRuntimeVersionErrorIndex is the index of the error System.FailedToExtractRuntimeVersion.
+const SystemFailedToExtractRuntimeVersionErrorIndex uint8 = 2
+
+// SystemFailedToExtractRuntimeVersionError is the error System.FailedToExtractRuntimeVersion.
+type SystemFailedToExtractRuntimeVersionError struct{}
+
+func (SystemFailedToExtractRuntimeVersionError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*SystemFailedToExtractRuntimeVersionError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (SystemFailedToExtractRuntimeVersionError) Error() string {
+	return "System.FailedToExtractRuntimeVersion"
+}
+
+// SystemNonDefaultCompositeErrorIndex is the index of the error System.NonDefaultComposite.
+const SystemNonDefaultCompositeErrorIndex uint8 = 3
+
+// SystemNonDefaultCompositeError is the error System.NonDefaultComposite.
+type SystemNonDefaultCompositeError struct{}
+
+func (SystemNonDefaultCompositeError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*SystemNonDefaultCompositeError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (SystemNonDefaultCompositeError) Error() string {
+	return "System.NonDefaultComposite"
+}
+
+// SystemNonZeroRefCountErrorIndex is the index of the error System.NonZeroRefCount.
+const SystemNonZeroRefCountErrorIndex uint8 = 4
+
+// SystemNonZeroRefCountError is the error System.NonZeroRefCount.
+type SystemNonZeroRefCountError struct{}
+
+func (SystemNonZeroRefCountError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*SystemNonZeroRefCountError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (SystemNonZeroRefCountError) Error() string {
+	return "System.NonZeroRefCount"
+}
+
+// SystemCallFilteredErrorIndex is the index of the error System.CallFiltered.
+const SystemCallFilteredErrorIndex uint8 = 5
+
+// SystemCallFilteredError is the error System.CallFiltered.
+type SystemCallFilteredError struct{}
+
+func (SystemCallFilteredError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*SystemCallFilteredError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (SystemCallFilteredError) Error() string {
+	return "System.CallFiltered"
+}
+
+// BalancesPalletIndex is the index of the Balances pallet.
+const BalancesPalletIndex uint8 = 5
+
+// BalancesTransferCallIndex is the index of the call Balances.transfer.
+const BalancesTransferCallIndex uint8 = 0
+
+// BalancesTransferCall is the call Balances.transfer.
+type BalancesTransferCall struct {
+	Dest  MultiAddress
+	Value types.UCompact
+}
+
+func (v BalancesTransferCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Dest); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesTransferCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Dest); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (BalancesTransferCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: BalancesPalletIndex, MethodIndex: BalancesTransferCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v BalancesTransferCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// BalancesSetBalanceCallIndex is the index of the call Balances.set_balance.
+const BalancesSetBalanceCallIndex uint8 = 1
+
+// BalancesSetBalanceCall is the call Balances.set_balance.
+type BalancesSetBalanceCall struct {
+	Who         MultiAddress
+	NewFree     types.UCompact
+	NewReserved types.UCompact
+}
+
+func (v BalancesSetBalanceCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Who); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.NewFree); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.NewReserved); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesSetBalanceCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Who); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.NewFree); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.NewReserved); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (BalancesSetBalanceCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: BalancesPalletIndex, MethodIndex: BalancesSetBalanceCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v BalancesSetBalanceCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// BalancesForceTransferCallIndex is the index of the call Balances.force_transfer.
+const BalancesForceTransferCallIndex uint8 = 2
+
+// BalancesForceTransferCall is the call Balances.force_transfer.
+type BalancesForceTransferCall struct {
+	Source MultiAddress
+	Dest   MultiAddress
+	Value  types.UCompact
+}
+
+func (v BalancesForceTransferCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Source); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Dest); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesForceTransferCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Source); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Dest); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (BalancesForceTransferCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: BalancesPalletIndex, MethodIndex: BalancesForceTransferCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v BalancesForceTransferCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// BalancesTransferKeepAliveCallIndex is the index of the call Balances.transfer_keep_alive.
+const BalancesTransferKeepAliveCallIndex uint8 = 3
+
+// BalancesTransferKeepAliveCall is the call Balances.transfer_keep_alive.
+type BalancesTransferKeepAliveCall struct {
+	Dest  MultiAddress
+	Value types.UCompact
+}
+
+func (v BalancesTransferKeepAliveCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Dest); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesTransferKeepAliveCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Dest); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (BalancesTransferKeepAliveCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: BalancesPalletIndex, MethodIndex: BalancesTransferKeepAliveCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v BalancesTransferKeepAliveCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// BalancesTransferAllCallIndex is the index of the call Balances.transfer_all.
+const BalancesTransferAllCallIndex uint8 = 4
+
+// BalancesTransferAllCall is the call Balances.transfer_all.
+type BalancesTransferAllCall struct {
+	Dest      MultiAddress
+	KeepAlive bool
+}
+
+func (v BalancesTransferAllCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Dest); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.KeepAlive); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesTransferAllCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Dest); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.KeepAlive); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (BalancesTransferAllCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: BalancesPalletIndex, MethodIndex: BalancesTransferAllCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v BalancesTransferAllCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// BalancesForceUnreserveCallIndex is the index of the call Balances.force_unreserve.
+const BalancesForceUnreserveCallIndex uint8 = 5
+
+// BalancesForceUnreserveCall is the call Balances.force_unreserve.
+type BalancesForceUnreserveCall struct {
+	Who    MultiAddress
+	Amount types.U128
+}
+
+func (v BalancesForceUnreserveCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Who); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesForceUnreserveCall) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Who); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CallIndex returns the index of the call.
+func (BalancesForceUnreserveCall) CallIndex() types.CallIndex {
+	return types.CallIndex{SectionIndex: BalancesPalletIndex, MethodIndex: BalancesForceUnreserveCallIndex}
+}
+
+// Call returns the encoded call, e.g. to sign it.
+func (v BalancesForceUnreserveCall) Call() (types.Call, error) {
+	args, err := codec.Encode(v)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return types.Call{CallIndex: v.CallIndex(), Args: args}, nil
+}
+
+// BalancesEndowedEventIndex is the index of the event Balances.Endowed.
+const BalancesEndowedEventIndex uint8 = 0
+
+// BalancesEndowedEvent is the event Balances.Endowed.
+type BalancesEndowedEvent struct {
+	Account     types.AccountID
+	FreeBalance types.U128
+}
+
+func (v BalancesEndowedEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Account); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.FreeBalance); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesEndowedEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Account); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.FreeBalance); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (BalancesEndowedEvent) EventID() types.EventID {
+	return types.EventID{BalancesPalletIndex, BalancesEndowedEventIndex}
+}
+
+// BalancesDustLostEventIndex is the index of the event Balances.DustLost.
+const BalancesDustLostEventIndex uint8 = 1
+
+// BalancesDustLostEvent is the event Balances.DustLost.
+type BalancesDustLostEvent struct {
+	Account types.AccountID
+	Amount  types.U128
+}
+
+func (v BalancesDustLostEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Account); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesDustLostEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Account); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (BalancesDustLostEvent) EventID() types.EventID {
+	return types.EventID{BalancesPalletIndex, BalancesDustLostEventIndex}
+}
+
+// BalancesTransferEventIndex is the index of the event Balances.Transfer.
+const BalancesTransferEventIndex uint8 = 2
+
+// BalancesTransferEvent is the event Balances.Transfer.
+type BalancesTransferEvent struct {
+	From   types.AccountID
+	To     types.AccountID
+	Amount types.U128
+}
+
+func (v BalancesTransferEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.From); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.To); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesTransferEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.From); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.To); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (BalancesTransferEvent) EventID() types.EventID {
+	return types.EventID{BalancesPalletIndex, BalancesTransferEventIndex}
+}
+
+// BalancesBalanceSetEventIndex is the index of the event Balances.BalanceSet.
+const BalancesBalanceSetEventIndex uint8 = 3
+
+// BalancesBalanceSetEvent is the event Balances.BalanceSet.
+type BalancesBalanceSetEvent struct {
+	Who      types.AccountID
+	Free     types.U128
+	Reserved types.U128
+}
+
+func (v BalancesBalanceSetEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Who); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Free); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Reserved); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesBalanceSetEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Who); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Free); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Reserved); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (BalancesBalanceSetEvent) EventID() types.EventID {
+	return types.EventID{BalancesPalletIndex, BalancesBalanceSetEventIndex}
+}
+
+// BalancesReservedEventIndex is the index of the event Balances.Reserved.
+const BalancesReservedEventIndex uint8 = 4
+
+// BalancesReservedEvent is the event Balances.Reserved.
+type BalancesReservedEvent struct {
+	Who    types.AccountID
+	Amount types.U128
+}
+
+func (v BalancesReservedEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Who); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesReservedEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Who); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (BalancesReservedEvent) EventID() types.EventID {
+	return types.EventID{BalancesPalletIndex, BalancesReservedEventIndex}
+}
+
+// BalancesUnreservedEventIndex is the index of the event Balances.Unreserved.
+const BalancesUnreservedEventIndex uint8 = 5
+
+// BalancesUnreservedEvent is the event Balances.Unreserved.
+type BalancesUnreservedEvent struct {
+	Who    types.AccountID
+	Amount types.U128
+}
+
+func (v BalancesUnreservedEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Who); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesUnreservedEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Who); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (BalancesUnreservedEvent) EventID() types.EventID {
+	return types.EventID{BalancesPalletIndex, BalancesUnreservedEventIndex}
+}
+
+// BalancesReserveRepatriatedEventIndex is the index of the event Balances.ReserveRepatriated.
+const BalancesReserveRepatriatedEventIndex uint8 = 6
+
+// BalancesReserveRepatriatedEvent is the event Balances.ReserveRepatriated.
+type BalancesReserveRepatriatedEvent struct {
+	From              types.AccountID
+	To                types.AccountID
+	Amount            types.U128
+	DestinationStatus BalanceStatus
+}
+
+func (v BalancesReserveRepatriatedEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.From); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.To); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Amount); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.DestinationStatus); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesReserveRepatriatedEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.From); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.To); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Amount); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.DestinationStatus); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (BalancesReserveRepatriatedEvent) EventID() types.EventID {
+	return types.EventID{BalancesPalletIndex, BalancesReserveRepatriatedEventIndex}
+}
+
+// BalancesDepositEventIndex is the index of the event Balances.Deposit.
+const BalancesDepositEventIndex uint8 = 7
+
+// BalancesDepositEvent is the event Balances.Deposit.
+type BalancesDepositEvent struct {
+	Who    types.AccountID
+	Amount types.U128
+}
+
+func (v BalancesDepositEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Who); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesDepositEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Who); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (BalancesDepositEvent) EventID() types.EventID {
+	return types.EventID{BalancesPalletIndex, BalancesDepositEventIndex}
+}
+
+// BalancesWithdrawEventIndex is the index of the event Balances.Withdraw.
+const BalancesWithdrawEventIndex uint8 = 8
+
+// BalancesWithdrawEvent is the event Balances.Withdraw.
+type BalancesWithdrawEvent struct {
+	Who    types.AccountID
+	Amount types.U128
+}
+
+func (v BalancesWithdrawEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Who); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesWithdrawEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Who); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (BalancesWithdrawEvent) EventID() types.EventID {
+	return types.EventID{BalancesPalletIndex, BalancesWithdrawEventIndex}
+}
+
+// BalancesSlashedEventIndex is the index of the event Balances.Slashed.
+const BalancesSlashedEventIndex uint8 = 9
+
+// BalancesSlashedEvent is the event Balances.Slashed.
+type BalancesSlashedEvent struct {
+	Who    types.AccountID
+	Amount types.U128
+}
+
+func (v BalancesSlashedEvent) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Who); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *BalancesSlashedEvent) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Who); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EventID returns the ID of the event.
+func (BalancesSlashedEvent) EventID() types.EventID {
+	return types.EventID{BalancesPalletIndex, BalancesSlashedEventIndex}
+}
+
+// BalancesVestingBalanceErrorIndex is the index of the error Balances.VestingBalance.
+const BalancesVestingBalanceErrorIndex uint8 = 0
+
+// BalancesVestingBalanceError is the error Balances.VestingBalance.
+type BalancesVestingBalanceError struct{}
+
+func (BalancesVestingBalanceError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*BalancesVestingBalanceError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (BalancesVestingBalanceError) Error() string {
+	return "Balances.VestingBalance"
+}
+
+// BalancesLiquidityRestrictionsErrorIndex is the index of the error Balances.LiquidityRestrictions.
+const BalancesLiquidityRestrictionsErrorIndex uint8 = 1
+
+// BalancesLiquidityRestrictionsError is the error Balances.LiquidityRestrictions.
+type BalancesLiquidityRestrictionsError struct{}
+
+func (BalancesLiquidityRestrictionsError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*BalancesLiquidityRestrictionsError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (BalancesLiquidityRestrictionsError) Error() string {
+	return "Balances.LiquidityRestrictions"
+}
+
+// BalancesInsufficientBalanceErrorIndex is the index of the error Balances.InsufficientBalance.
+const BalancesInsufficientBalanceErrorIndex uint8 = 2
+
+// BalancesInsufficientBalanceError is the error Balances.InsufficientBalance.
+type BalancesInsufficientBalanceError struct{}
+
+func (BalancesInsufficientBalanceError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*BalancesInsufficientBalanceError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (BalancesInsufficientBalanceError) Error() string {
+	return "Balances.InsufficientBalance"
+}
+
+// BalancesExistentialDepositErrorIndex is the index of the error Balances.ExistentialDeposit.
+const BalancesExistentialDepositErrorIndex uint8 = 3
+
+// BalancesExistentialDepositError is the error Balances.ExistentialDeposit.
+type BalancesExistentialDepositError struct{}
+
+func (BalancesExistentialDepositError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*BalancesExistentialDepositError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (BalancesExistentialDepositError) Error() string {
+	return "Balances.ExistentialDeposit"
+}
+
+// BalancesKeepAliveErrorIndex is the index of the error Balances.KeepAlive.
+const BalancesKeepAliveErrorIndex uint8 = 4
+
+// BalancesKeepAliveError is the error Balances.KeepAlive.
+type BalancesKeepAliveError struct{}
+
+func (BalancesKeepAliveError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*BalancesKeepAliveError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (BalancesKeepAliveError) Error() string {
+	return "Balances.KeepAlive"
+}
+
+// BalancesExistingVestingScheduleErrorIndex is the index of the error Balances.ExistingVestingSchedule.
+const BalancesExistingVestingScheduleErrorIndex uint8 = 5
+
+// BalancesExistingVestingScheduleError is the error Balances.ExistingVestingSchedule.
+type BalancesExistingVestingScheduleError struct{}
+
+func (BalancesExistingVestingScheduleError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*BalancesExistingVestingScheduleError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (BalancesExistingVestingScheduleError) Error() string {
+	return "Balances.ExistingVestingSchedule"
+}
+
+// BalancesDeadAccountErrorIndex is the index of the error Balances.DeadAccount.
+const BalancesDeadAccountErrorIndex uint8 = 6
+
+// BalancesDeadAccountError is the error Balances.DeadAccount.
+type BalancesDeadAccountError struct{}
+
+func (BalancesDeadAccountError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*BalancesDeadAccountError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (BalancesDeadAccountError) Error() string {
+	return "Balances.DeadAccount"
+}
+
+// BalancesTooManyReservesErrorIndex is the index of the error Balances.TooManyReserves.
+const BalancesTooManyReservesErrorIndex uint8 = 7
+
+// BalancesTooManyReservesError is the error Balances.TooManyReserves.
+type BalancesTooManyReservesError struct{}
+
+func (BalancesTooManyReservesError) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*BalancesTooManyReservesError) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+// Error returns the name of the error.
+func (BalancesTooManyReservesError) Error() string {
+	return "Balances.TooManyReserves"
+}
+
+// TupleBytesBytes is a tuple of 2 items.
+type TupleBytesBytes struct {
+	Field0 types.Bytes
+	Field1 types.Bytes
+}
+
+func (v TupleBytesBytes) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Field0); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Field1); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *TupleBytesBytes) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Field0); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Field1); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Weight is the type sp_weights::weight_v2::Weight.
+type Weight struct {
+	RefTime   types.UCompact
+	ProofSize types.UCompact
+}
+
+func (v Weight) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.RefTime); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.ProofSize); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *Weight) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.RefTime); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.ProofSize); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DispatchClass is the enum frame_support::dispatch::DispatchClass.
+type DispatchClass uint8
+
+// Variants of DispatchClass.
+const (
+	DispatchClassNormal      DispatchClass = 0
+	DispatchClassOperational DispatchClass = 1
+	DispatchClassMandatory   DispatchClass = 2
+)
+
+// Pays is the enum frame_support::dispatch::Pays.
+type Pays uint8
+
+// Variants of Pays.
+const (
+	PaysYes Pays = 0
+	PaysNo  Pays = 1
+)
+
+// DispatchInfo is the type frame_support::dispatch::DispatchInfo.
+type DispatchInfo struct {
+	Weight  Weight
+	Class   DispatchClass
+	PaysFee Pays
+}
+
+func (v DispatchInfo) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Weight); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Class); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.PaysFee); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *DispatchInfo) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Weight); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Class); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.PaysFee); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ModuleError is the type sp_runtime::ModuleError.
+type ModuleError struct {
+	Index types.U8
+	Error [4]byte
+}
+
+func (v ModuleError) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Index); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(v.Error); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *ModuleError) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Index); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&v.Error); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// TokenError is the enum sp_runtime::TokenError.
+type TokenError uint8
+
+// Variants of TokenError.
+const (
+	TokenErrorNoFunds      TokenError = 0
+	TokenErrorWouldDie     TokenError = 1
+	TokenErrorBelowMinimum TokenError = 2
+	TokenErrorCannotCreate TokenError = 3
+	TokenErrorUnknownAsset TokenError = 4
+	TokenErrorFrozen       TokenError = 5
+	TokenErrorUnsupported  TokenError = 6
+)
+
+// ArithmeticError is the enum sp_runtime::ArithmeticError.
+type ArithmeticError uint8
+
+// Variants of ArithmeticError.
+const (
+	ArithmeticErrorUnderflow      ArithmeticError = 0
+	ArithmeticErrorOverflow       ArithmeticError = 1
+	ArithmeticErrorDivisionByZero ArithmeticError = 2
+)
+
+// TransactionalError is the enum sp_runtime::TransactionalError.
+type TransactionalError uint8
+
+// Variants of TransactionalError.
+const (
+	TransactionalErrorLimitReached TransactionalError = 0
+	TransactionalErrorNoLayer      TransactionalError = 1
+)
+
+// DispatchError is the enum sp_runtime::DispatchError, its Value holds one of its variants.
+type DispatchError struct {
+	Value DispatchErrorValue
+}
+
+// DispatchErrorValue is a variant of DispatchError.
+type DispatchErrorValue interface {
+	isDispatchError()
+}
+
+func (v DispatchError) Encode(encoder scale.Encoder) error {
+	var index byte
+
+	switch v.Value.(type) {
+	case DispatchErrorOther:
+		index = 0
+	case DispatchErrorCannotLookup:
+		index = 1
+	case DispatchErrorBadOrigin:
+		index = 2
+	case DispatchErrorModule:
+		index = 3
+	case DispatchErrorConsumerRemaining:
+		index = 4
+	case DispatchErrorNoProviders:
+		index = 5
+	case DispatchErrorTooManyConsumers:
+		index = 6
+	case DispatchErrorToken:
+		index = 7
+	case DispatchErrorArithmetic:
+		index = 8
+	case DispatchErrorTransactional:
+		index = 9
+	case DispatchErrorExhausted:
+		index = 10
+	case DispatchErrorCorruption:
+		index = 11
+	case DispatchErrorUnavailable:
+		index = 12
+	default:
+		return fmt.Errorf("unknown variant %T of DispatchError", v.Value)
+	}
+
+	if err := encoder.PushByte(index); err != nil {
+		return err
+	}
+
+	return encoder.Encode(v.Value)
+}
+
+func (v *DispatchError) Decode(decoder scale.Decoder) error {
+	index, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch index {
+	case 0:
+		var value DispatchErrorOther
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 1:
+		var value DispatchErrorCannotLookup
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 2:
+		var value DispatchErrorBadOrigin
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 3:
+		var value DispatchErrorModule
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 4:
+		var value DispatchErrorConsumerRemaining
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 5:
+		var value DispatchErrorNoProviders
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 6:
+		var value DispatchErrorTooManyConsumers
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 7:
+		var value DispatchErrorToken
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 8:
+		var value DispatchErrorArithmetic
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 9:
+		var value DispatchErrorTransactional
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 10:
+		var value DispatchErrorExhausted
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 11:
+		var value DispatchErrorCorruption
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 12:
+		var value DispatchErrorUnavailable
+		err = decoder.Decode(&value)
+		v.Value = value
+	default:
+		return fmt.Errorf("unknown variant %d of DispatchError", index)
+	}
+
+	return err
+}
+
+// DispatchErrorOther is a variant of DispatchError.
+type DispatchErrorOther struct{}
+
+func (DispatchErrorOther) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*DispatchErrorOther) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+func (DispatchErrorOther) isDispatchError() {}
+
+// DispatchErrorCannotLookup is a variant of DispatchError.
+type DispatchErrorCannotLookup struct{}
+
+func (DispatchErrorCannotLookup) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*DispatchErrorCannotLookup) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+func (DispatchErrorCannotLookup) isDispatchError() {}
+
+// DispatchErrorBadOrigin is a variant of DispatchError.
+type DispatchErrorBadOrigin struct{}
+
+func (DispatchErrorBadOrigin) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*DispatchErrorBadOrigin) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+func (DispatchErrorBadOrigin) isDispatchError() {}
+
+// DispatchErrorModule is a variant of DispatchError.
+type DispatchErrorModule struct {
+	Field0 ModuleError
+}
+
+func (v DispatchErrorModule) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *DispatchErrorModule) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (DispatchErrorModule) isDispatchError() {}
+
+// DispatchErrorConsumerRemaining is a variant of DispatchError.
+type DispatchErrorConsumerRemaining struct{}
+
+func (DispatchErrorConsumerRemaining) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*DispatchErrorConsumerRemaining) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+func (DispatchErrorConsumerRemaining) isDispatchError() {}
+
+// DispatchErrorNoProviders is a variant of DispatchError.
+type DispatchErrorNoProviders struct{}
+
+func (DispatchErrorNoProviders) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*DispatchErrorNoProviders) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+func (DispatchErrorNoProviders) isDispatchError() {}
+
+// DispatchErrorTooManyConsumers is a variant of DispatchError.
+type DispatchErrorTooManyConsumers struct{}
+
+func (DispatchErrorTooManyConsumers) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*DispatchErrorTooManyConsumers) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+func (DispatchErrorTooManyConsumers) isDispatchError() {}
+
+// DispatchErrorToken is a variant of DispatchError.
+type DispatchErrorToken struct {
+	Field0 TokenError
+}
+
+func (v DispatchErrorToken) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *DispatchErrorToken) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (DispatchErrorToken) isDispatchError() {}
+
+// DispatchErrorArithmetic is a variant of DispatchError.
+type DispatchErrorArithmetic struct {
+	Field0 ArithmeticError
+}
+
+func (v DispatchErrorArithmetic) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *DispatchErrorArithmetic) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (DispatchErrorArithmetic) isDispatchError() {}
+
+// DispatchErrorTransactional is a variant of DispatchError.
+type DispatchErrorTransactional struct {
+	Field0 TransactionalError
+}
+
+func (v DispatchErrorTransactional) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *DispatchErrorTransactional) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (DispatchErrorTransactional) isDispatchError() {}
+
+// DispatchErrorExhausted is a variant of DispatchError.
+type DispatchErrorExhausted struct{}
+
+func (DispatchErrorExhausted) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*DispatchErrorExhausted) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+func (DispatchErrorExhausted) isDispatchError() {}
+
+// DispatchErrorCorruption is a variant of DispatchError.
+type DispatchErrorCorruption struct{}
+
+func (DispatchErrorCorruption) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*DispatchErrorCorruption) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+func (DispatchErrorCorruption) isDispatchError() {}
+
+// DispatchErrorUnavailable is a variant of DispatchError.
+type DispatchErrorUnavailable struct{}
+
+func (DispatchErrorUnavailable) Encode(_ scale.Encoder) error {
+	return nil
+}
+
+func (*DispatchErrorUnavailable) Decode(_ scale.Decoder) error {
+	return nil
+}
+
+func (DispatchErrorUnavailable) isDispatchError() {}
+
+// MultiAddress is the enum sp_runtime::multiaddress::MultiAddress, its Value holds one of its variants.
+type MultiAddress struct {
+	Value MultiAddressValue
+}
+
+// MultiAddressValue is a variant of MultiAddress.
+type MultiAddressValue interface {
+	isMultiAddress()
+}
+
+func (v MultiAddress) Encode(encoder scale.Encoder) error {
+	var index byte
+
+	switch v.Value.(type) {
+	case MultiAddressId:
+		index = 0
+	case MultiAddressIndex:
+		index = 1
+	case MultiAddressRaw:
+		index = 2
+	case MultiAddressAddress32:
+		index = 3
+	case MultiAddressAddress20:
+		index = 4
+	default:
+		return fmt.Errorf("unknown variant %T of MultiAddress", v.Value)
+	}
+
+	if err := encoder.PushByte(index); err != nil {
+		return err
+	}
+
+	return encoder.Encode(v.Value)
+}
+
+func (v *MultiAddress) Decode(decoder scale.Decoder) error {
+	index, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch index {
+	case 0:
+		var value MultiAddressId
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 1:
+		var value MultiAddressIndex
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 2:
+		var value MultiAddressRaw
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 3:
+		var value MultiAddressAddress32
+		err = decoder.Decode(&value)
+		v.Value = value
+	case 4:
+		var value MultiAddressAddress20
+		err = decoder.Decode(&value)
+		v.Value = value
+	default:
+		return fmt.Errorf("unknown variant %d of MultiAddress", index)
+	}
+
+	return err
+}
+
+// MultiAddressId is a variant of MultiAddress.
+type MultiAddressId struct {
+	Field0 types.AccountID
+}
+
+func (v MultiAddressId) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *MultiAddressId) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (MultiAddressId) isMultiAddress() {}
+
+// MultiAddressIndex is a variant of MultiAddress.
+type MultiAddressIndex struct {
+	Field0 types.UCompact
+}
+
+func (v MultiAddressIndex) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *MultiAddressIndex) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (MultiAddressIndex) isMultiAddress() {}
+
+// MultiAddressRaw is a variant of MultiAddress.
+type MultiAddressRaw struct {
+	Field0 types.Bytes
+}
+
+func (v MultiAddressRaw) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *MultiAddressRaw) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (MultiAddressRaw) isMultiAddress() {}
+
+// MultiAddressAddress32 is a variant of MultiAddress.
+type MultiAddressAddress32 struct {
+	Field0 [32]byte
+}
+
+func (v MultiAddressAddress32) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *MultiAddressAddress32) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (MultiAddressAddress32) isMultiAddress() {}
+
+// MultiAddressAddress20 is a variant of MultiAddress.
+type MultiAddressAddress20 struct {
+	Field0 [20]byte
+}
+
+func (v MultiAddressAddress20) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *MultiAddressAddress20) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&v.Field0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (MultiAddressAddress20) isMultiAddress() {}
+
+// BalanceStatus is the enum frame_support::traits::tokens::misc::BalanceStatus.
+type BalanceStatus uint8
+
+// Variants of BalanceStatus.
+const (
+	BalanceStatusFree     BalanceStatus = 0
+	BalanceStatusReserved BalanceStatus = 1
+)
@@ -0,0 +1,89 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package polkadot
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalancesTransferKeepAliveCall(t *testing.T) {
+	var meta types.Metadata
+
+	require.NoError(t, codec.DecodeFromHex(test.PolkadotMetadataHex, &meta))
+
+	accountID, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	dest, err := types.NewMultiAddressFromAccountID(accountID[:])
+	require.NoError(t, err)
+
+	expected, err := types.NewCall(&meta, "Balances.transfer_keep_alive", dest, types.NewUCompactFromUInt(12345))
+	require.NoError(t, err)
+
+	call, err := BalancesTransferKeepAliveCall{
+		Dest:  MultiAddress{Value: MultiAddressId{Field0: *accountID}},
+		Value: types.NewUCompactFromUInt(12345),
+	}.Call()
+	require.NoError(t, err)
+	assert.Equal(t, expected, call)
+
+	var decoded BalancesTransferKeepAliveCall
+
+	require.NoError(t, codec.Decode(call.Args, &decoded))
+	assert.Equal(t, MultiAddressId{Field0: *accountID}, decoded.Dest.Value)
+	assert.Equal(t, types.NewUCompactFromUInt(12345), decoded.Value)
+}
+
+func TestSystemExtrinsicFailedEvent(t *testing.T) {
+	event := SystemExtrinsicFailedEvent{
+		DispatchError: DispatchError{Value: DispatchErrorModule{Field0: ModuleError{Index: 5, Error: [4]byte{2}}}},
+		DispatchInfo: DispatchInfo{
+			Weight:  Weight{RefTime: types.NewUCompactFromUInt(100), ProofSize: types.NewUCompactFromUInt(0)},
+			Class:   DispatchClassOperational,
+			PaysFee: PaysNo,
+		},
+	}
+
+	encoded, err := codec.Encode(event)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x03, 0x05, 0x02, 0x00, 0x00, 0x00, 0x91, 0x01, 0x00, 0x01, 0x01}, encoded)
+
+	var decoded SystemExtrinsicFailedEvent
+
+	require.NoError(t, codec.Decode(encoded, &decoded))
+	assert.Equal(t, event, decoded)
+	assert.Equal(t, types.EventID{SystemPalletIndex, 1}, decoded.EventID())
+
+	assert.Error(t, codec.Decode([]byte{0xff}, &decoded.DispatchError))
+
+	_, err = codec.Encode(DispatchError{})
+	assert.Error(t, err)
+}
+
+func TestBalancesInsufficientBalanceError(t *testing.T) {
+	var err error = BalancesInsufficientBalanceError{}
+
+	assert.EqualError(t, err, "Balances.InsufficientBalance")
+	assert.Equal(t, uint8(2), BalancesInsufficientBalanceErrorIndex)
+}
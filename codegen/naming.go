@@ -0,0 +1,81 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// names allocates unique Go identifiers within a scope, e.g. the declarations of a package or the fields of a struct.
+type names map[string]bool
+
+// allocate returns the first candidate that is not taken yet, or the last candidate with the lowest numeric suffix
+// that makes it unique.
+func (n names) allocate(candidates ...string) string {
+	for _, candidate := range candidates {
+		if !n[candidate] {
+			n[candidate] = true
+			return candidate
+		}
+	}
+
+	last := candidates[len(candidates)-1]
+
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s%d", last, i)
+
+		if !n[candidate] {
+			n[candidate] = true
+			return candidate
+		}
+	}
+}
+
+// exported converts a Rust name, e.g. transfer_keep_alive or AccountId32, into an exported Go identifier, e.g.
+// TransferKeepAlive or AccountId32. Exported identifiers never clash with Go keywords, which are lower case.
+func exported(name string) string {
+	var b strings.Builder
+
+	upper := true
+
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		b.WriteRune(r)
+	}
+
+	identifier := b.String()
+
+	switch {
+	case identifier == "":
+		return "X"
+	case unicode.IsDigit(rune(identifier[0])):
+		return "X" + identifier
+	default:
+		return identifier
+	}
+}
@@ -0,0 +1,376 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var primitiveTypes = map[types.Si0TypeDefPrimitive]string{
+	types.IsBool: "bool",
+	types.IsStr:  "string",
+	types.IsU8:   "types.U8",
+	types.IsU16:  "types.U16",
+	types.IsU32:  "types.U32",
+	types.IsU64:  "types.U64",
+	types.IsU128: "types.U128",
+	types.IsU256: "types.U256",
+	types.IsI8:   "types.I8",
+	types.IsI16:  "types.I16",
+	types.IsI32:  "types.I32",
+	types.IsI64:  "types.I64",
+	types.IsI128: "types.I128",
+	types.IsI256: "types.I256",
+}
+
+// goType returns the Go type of the metadata type, named types are generated the first time they are used.
+func (g *generator) goType(typeID int64) (string, error) {
+	if goType, ok := g.goTypes[typeID]; ok {
+		return goType, nil
+	}
+
+	t, ok := g.lookup[typeID]
+	if !ok {
+		return "", ErrTypeNotFound.WithMsg("type %d", typeID)
+	}
+
+	goType, err := g.resolve(typeID, t)
+	if err != nil {
+		return "", err
+	}
+
+	g.goTypes[typeID] = goType
+
+	return goType, nil
+}
+
+// nolint:funlen
+func (g *generator) resolve(typeID int64, t *types.Si1Type) (string, error) {
+	def := t.Def
+
+	switch {
+	case def.IsPrimitive:
+		goType, ok := primitiveTypes[def.Primitive.Si0TypeDefPrimitive]
+		if !ok {
+			return "", ErrTypeNotSupported.WithMsg("primitive type %d", def.Primitive.Si0TypeDefPrimitive)
+		}
+
+		return goType, nil
+	case def.IsCompact:
+		return "types.UCompact", nil
+	case def.IsSequence:
+		if g.isU8(def.Sequence.Type.Int64()) {
+			return "types.Bytes", nil
+		}
+
+		item, err := g.goType(def.Sequence.Type.Int64())
+		if err != nil {
+			return "", err
+		}
+
+		return "[]" + item, nil
+	case def.IsArray:
+		if g.isU8(def.Array.Type.Int64()) {
+			return fmt.Sprintf("[%d]byte", def.Array.Len), nil
+		}
+
+		item, err := g.goType(def.Array.Type.Int64())
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("[%d]%s", def.Array.Len, item), nil
+	case def.IsTuple:
+		if len(def.Tuple) == 0 {
+			return "struct{}", nil
+		}
+
+		return g.tuple(typeID, def.Tuple)
+	case def.IsComposite:
+		fields := def.Composite.Fields
+
+		switch {
+		case isAccountID(t):
+			return "types.AccountID", nil
+		case len(fields) == 0:
+			return "struct{}", nil
+		case len(fields) == 1 && !g.contains(fields[0].Type.Int64(), typeID, map[int64]bool{}):
+			return g.goType(fields[0].Type.Int64())
+		default:
+			return g.composite(typeID, t)
+		}
+	case def.IsVariant:
+		variants := def.Variant.Variants
+
+		switch {
+		case isOption(t):
+			value, err := g.goType(variants[1].Fields[0].Type.Int64())
+			if err != nil {
+				return "", err
+			}
+
+			return fmt.Sprintf("types.Option[%s]", value), nil
+		case len(variants) == 0:
+			return "struct{}", nil
+		case !hasFields(variants):
+			return g.fieldlessEnum(typeID, t), nil
+		default:
+			return g.enum(typeID, t)
+		}
+	case def.IsBitSequence:
+		return "", ErrTypeNotSupported.WithMsg("bit sequence type %d", typeID)
+	default:
+		return "", ErrTypeNotSupported.WithMsg("type %d", typeID)
+	}
+}
+
+// contains returns true if the type contains the target type. Newtypes, i.e. composites with a single field like
+// BoundedVec<u8> or Perbill, are generated as the type of their field, unless they contain themselves.
+func (g *generator) contains(typeID int64, target int64, visited map[int64]bool) bool {
+	if typeID == target {
+		return true
+	}
+
+	t, ok := g.lookup[typeID]
+	if !ok || visited[typeID] {
+		return false
+	}
+
+	visited[typeID] = true
+
+	var children []int64
+
+	switch def := t.Def; {
+	case def.IsComposite:
+		for _, f := range def.Composite.Fields {
+			children = append(children, f.Type.Int64())
+		}
+	case def.IsVariant:
+		for _, variant := range def.Variant.Variants {
+			for _, f := range variant.Fields {
+				children = append(children, f.Type.Int64())
+			}
+		}
+	case def.IsSequence:
+		children = append(children, def.Sequence.Type.Int64())
+	case def.IsArray:
+		children = append(children, def.Array.Type.Int64())
+	case def.IsTuple:
+		for _, item := range def.Tuple {
+			children = append(children, item.Int64())
+		}
+	}
+
+	for _, child := range children {
+		if g.contains(child, target, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *generator) tuple(typeID int64, items types.Si1TypeDefTuple) (string, error) {
+	fields := make([]field, 0, len(items))
+	itemNames := make([]string, 0, len(items))
+
+	for i, item := range items {
+		goType, err := g.goType(item.Int64())
+		if err != nil {
+			return "", err
+		}
+
+		fields = append(fields, field{name: fmt.Sprintf("Field%d", i), goType: goType})
+		itemNames = append(itemNames, exported(strings.TrimPrefix(goType, "types.")))
+	}
+
+	name := g.names.allocate("Tuple" + strings.Join(itemNames, ""))
+	g.goTypes[typeID] = name
+
+	writeStruct(&g.types, fmt.Sprintf("%s is a tuple of %d items.", name, len(items)), name, fields)
+
+	return name, nil
+}
+
+func (g *generator) composite(typeID int64, t *types.Si1Type) (string, error) {
+	name := g.names.allocate(typeNameCandidates(t)...)
+
+	// The name is known before the fields are resolved, so that fields can refer to the type.
+	g.goTypes[typeID] = name
+
+	fields, err := g.fields(t.Def.Composite.Fields, "")
+	if err != nil {
+		return "", err
+	}
+
+	writeStruct(&g.types, fmt.Sprintf("%s is the type %s.", name, rustPath(t)), name, fields)
+
+	return name, nil
+}
+
+// fieldlessEnum generates an enum whose variants have no fields as uint8 with a constant per variant.
+func (g *generator) fieldlessEnum(typeID int64, t *types.Si1Type) string {
+	name := g.names.allocate(typeNameCandidates(t)...)
+	g.goTypes[typeID] = name
+
+	b := &g.types
+
+	fmt.Fprintf(b, "// %s is the enum %s.\ntype %s uint8\n\n", name, rustPath(t), name)
+	fmt.Fprintf(b, "// Variants of %s.\nconst (\n", name)
+
+	for _, variant := range t.Def.Variant.Variants {
+		variantName := g.names.allocate(name + exported(string(variant.Name)))
+		fmt.Fprintf(b, "\t%s %s = %d\n", variantName, name, variant.Index)
+	}
+
+	b.WriteString(")\n\n")
+
+	return name
+}
+
+// enum generates an enum as a struct that holds one of the variant structs.
+// nolint:funlen
+func (g *generator) enum(typeID int64, t *types.Si1Type) (string, error) {
+	name := g.names.allocate(typeNameCandidates(t)...)
+	g.goTypes[typeID] = name
+
+	valueName := g.names.allocate(name + "Value")
+	marker := "is" + name
+
+	type variant struct {
+		name   string
+		index  types.U8
+		fields []field
+	}
+
+	variants := make([]variant, 0, len(t.Def.Variant.Variants))
+
+	for _, v := range t.Def.Variant.Variants {
+		variantName := g.names.allocate(name + exported(string(v.Name)))
+
+		fields, err := g.fields(v.Fields, "")
+		if err != nil {
+			return "", err
+		}
+
+		variants = append(variants, variant{name: variantName, index: v.Index, fields: fields})
+	}
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// %s is the enum %s, its Value holds one of its variants.\n", name, rustPath(t))
+	fmt.Fprintf(&b, "type %s struct {\n\tValue %s\n}\n\n", name, valueName)
+
+	fmt.Fprintf(&b, "// %s is a variant of %s.\n", valueName, name)
+	fmt.Fprintf(&b, "type %s interface {\n\t%s()\n}\n\n", valueName, marker)
+
+	fmt.Fprintf(&b, "func (v %s) Encode(encoder scale.Encoder) error {\n\tvar index byte\n\n", name)
+	b.WriteString("\tswitch v.Value.(type) {\n")
+
+	for _, v := range variants {
+		fmt.Fprintf(&b, "\tcase %s:\n\t\tindex = %d\n", v.name, v.index)
+	}
+
+	fmt.Fprintf(&b, "\tdefault:\n\t\treturn fmt.Errorf(\"unknown variant %%T of %s\", v.Value)\n\t}\n\n", name)
+	b.WriteString("\tif err := encoder.PushByte(index); err != nil {\n\t\treturn err\n\t}\n\n")
+	b.WriteString("\treturn encoder.Encode(v.Value)\n}\n\n")
+
+	fmt.Fprintf(&b, "func (v *%s) Decode(decoder scale.Decoder) error {\n", name)
+	b.WriteString("\tindex, err := decoder.ReadOneByte()\n\tif err != nil {\n\t\treturn err\n\t}\n\n")
+	b.WriteString("\tswitch index {\n")
+
+	for _, v := range variants {
+		fmt.Fprintf(&b, "\tcase %d:\n\t\tvar value %s\n\t\terr = decoder.Decode(&value)\n\t\tv.Value = value\n",
+			v.index, v.name)
+	}
+
+	fmt.Fprintf(&b, "\tdefault:\n\t\treturn fmt.Errorf(\"unknown variant %%d of %s\", index)\n\t}\n\n", name)
+	b.WriteString("\treturn err\n}\n\n")
+
+	for _, v := range variants {
+		writeStruct(&b, fmt.Sprintf("%s is a variant of %s.", v.name, name), v.name, v.fields)
+		fmt.Fprintf(&b, "func (%s) %s() {}\n\n", v.name, marker)
+	}
+
+	g.types.Write(b.Bytes())
+
+	return name, nil
+}
+
+func (g *generator) isU8(typeID int64) bool {
+	t, ok := g.lookup[typeID]
+
+	return ok && t.Def.IsPrimitive && t.Def.Primitive.Si0TypeDefPrimitive == types.IsU8
+}
+
+// typeNameCandidates returns the names of a type by preference: the last segment of its path, prefixed with the
+// crate and the full path in case of clashes.
+func typeNameCandidates(t *types.Si1Type) []string {
+	if len(t.Path) == 0 {
+		return []string{"Type"}
+	}
+
+	segments := make([]string, 0, len(t.Path))
+
+	for _, segment := range t.Path {
+		segments = append(segments, exported(string(segment)))
+	}
+
+	last := segments[len(segments)-1]
+
+	if len(segments) == 1 {
+		return []string{last}
+	}
+
+	return []string{last, segments[0] + last, strings.Join(segments, "")}
+}
+
+func rustPath(t *types.Si1Type) string {
+	segments := make([]string, 0, len(t.Path))
+
+	for _, segment := range t.Path {
+		segments = append(segments, string(segment))
+	}
+
+	return strings.Join(segments, "::")
+}
+
+func hasFields(variants []types.Si1Variant) bool {
+	for _, variant := range variants {
+		if len(variant.Fields) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAccountID(t *types.Si1Type) bool {
+	return len(t.Path) > 0 && t.Path[len(t.Path)-1] == "AccountId32"
+}
+
+// isOption returns true for Option<T>, whose variants are None and Some(T).
+func isOption(t *types.Si1Type) bool {
+	variants := t.Def.Variant.Variants
+
+	return len(t.Path) == 1 && t.Path[0] == "Option" && len(variants) == 2 &&
+		variants[0].Name == "None" && len(variants[1].Fields) == 1
+}
@@ -0,0 +1,38 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fakes provides in-memory implementations of the module interfaces that the higher-level helpers of the
+// library depend on, e.g. state.Provider and chain.Provider, to test code that uses these helpers without a node.
+package fakes
+
+// API bundles the fakes of the modules in the layout of the RPC of gsrpc.SubstrateAPI, so that code that is written
+// against the modules of the RPC can be pointed at the fakes.
+type API struct {
+	Author *Author
+	Chain  *Chain
+	State  *State
+	System *System
+}
+
+// NewAPI creates the fakes of all modules.
+func NewAPI() *API {
+	return &API{
+		Author: NewAuthor(),
+		Chain:  NewChain(),
+		State:  NewState(),
+		System: NewSystem(),
+	}
+}
@@ -0,0 +1,157 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+const submitAndWatchMethod = "author_submitAndWatchExtrinsic"
+
+// AcceptanceRule decides whether an extrinsic that is submitted to the Author fake is accepted. Extrinsics are
+// rejected with the returned error.
+type AcceptanceRule func(xt types.Extrinsic) error
+
+// Author is an in-memory author.Provider that records the accepted extrinsics. Watchers of submitted extrinsics
+// receive the statuses set via SetStatuses.
+type Author struct {
+	notifier *notifier
+	rpc      author.Author
+
+	mu        sync.RWMutex
+	rule      AcceptanceRule
+	statuses  []types.ExtrinsicStatus
+	submitted []types.Extrinsic
+}
+
+var _ author.Provider = (*Author)(nil)
+
+// NewAuthor creates an author that accepts all extrinsics and sends no statuses.
+func NewAuthor() *Author {
+	a := &Author{}
+
+	a.notifier = newNotifier(a.initialNotifications)
+	a.rpc = author.NewAuthor(a.notifier)
+
+	return a
+}
+
+// SetAcceptanceRule sets the rule for submitted extrinsics, nil accepts all extrinsics.
+func (a *Author) SetAcceptanceRule(rule AcceptanceRule) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.rule = rule
+}
+
+// SetStatuses sets the statuses that are sent in order to the watchers of extrinsics that are submitted afterwards.
+func (a *Author) SetStatuses(statuses ...types.ExtrinsicStatus) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.statuses = statuses
+}
+
+// Submitted returns the accepted extrinsics in the order they were submitted.
+func (a *Author) Submitted() []types.Extrinsic {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return append([]types.Extrinsic(nil), a.submitted...)
+}
+
+func (a *Author) SubmitExtrinsic(xt types.Extrinsic) (types.Hash, error) {
+	return a.SubmitExtrinsicContext(context.Background(), xt)
+}
+
+func (a *Author) SubmitExtrinsicContext(_ context.Context, xt types.Extrinsic) (types.Hash, error) {
+	if err := a.submit(xt); err != nil {
+		return types.Hash{}, err
+	}
+
+	enc, err := codec.Encode(xt)
+	if err != nil {
+		return types.Hash{}, ErrExtrinsicEncoding.Wrap(err)
+	}
+
+	return blake2b.Sum256(enc), nil
+}
+
+func (a *Author) SubmitAndWatchExtrinsic(xt types.Extrinsic) (*author.ExtrinsicStatusSubscription, error) {
+	return a.SubmitAndWatchExtrinsicContext(context.Background(), xt)
+}
+
+func (a *Author) SubmitAndWatchExtrinsicContext(
+	ctx context.Context,
+	xt types.Extrinsic,
+) (*author.ExtrinsicStatusSubscription, error) {
+	return a.rpc.SubmitAndWatchExtrinsicContext(ctx, xt)
+}
+
+// initialNotifications submits the extrinsic that is watched and returns the statuses for the watcher.
+func (a *Author) initialNotifications(method string, args []interface{}) ([]interface{}, error) {
+	if method != submitAndWatchMethod || len(args) != 1 {
+		return nil, ErrUnsupported.WithMsg("subscription %s", method)
+	}
+
+	enc, ok := args[0].(string)
+	if !ok {
+		return nil, ErrExtrinsicEncoding.WithMsg("unexpected arg %v", args[0])
+	}
+
+	var xt types.Extrinsic
+
+	if err := codec.DecodeFromHex(enc, &xt); err != nil {
+		return nil, ErrExtrinsicEncoding.Wrap(err)
+	}
+
+	if err := a.submit(xt); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	notifications := make([]interface{}, 0, len(a.statuses))
+
+	for _, status := range a.statuses {
+		notifications = append(notifications, status)
+	}
+
+	return notifications, nil
+}
+
+func (a *Author) submit(xt types.Extrinsic) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.rule != nil {
+		if err := a.rule(xt); err != nil {
+			return err
+		}
+	}
+
+	a.submitted = append(a.submitted, xt)
+
+	return nil
+}
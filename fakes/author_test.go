@@ -0,0 +1,104 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func newTestExtrinsic(methodIndex uint8) types.Extrinsic {
+	return types.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: methodIndex}})
+}
+
+func TestAuthor_SubmitExtrinsic(t *testing.T) {
+	a := NewAuthor()
+
+	errRejected := errors.New("rejected")
+
+	a.SetAcceptanceRule(func(xt types.Extrinsic) error {
+		if xt.Method.CallIndex.MethodIndex != 0 {
+			return errRejected
+		}
+
+		return nil
+	})
+
+	xt := newTestExtrinsic(0)
+
+	hash, err := a.SubmitExtrinsic(xt)
+	require.NoError(t, err)
+
+	enc, err := codec.Encode(xt)
+	require.NoError(t, err)
+	assert.Equal(t, types.Hash(blake2b.Sum256(enc)), hash)
+
+	_, err = a.SubmitExtrinsic(newTestExtrinsic(1))
+	assert.ErrorIs(t, err, errRejected)
+
+	assert.Equal(t, []types.Extrinsic{xt}, a.Submitted())
+}
+
+func TestAuthor_SubmitAndWatchExtrinsic(t *testing.T) {
+	a := NewAuthor()
+
+	errRejected := errors.New("rejected")
+
+	a.SetAcceptanceRule(func(xt types.Extrinsic) error {
+		if xt.Method.CallIndex.MethodIndex != 0 {
+			return errRejected
+		}
+
+		return nil
+	})
+
+	statuses := []types.ExtrinsicStatus{
+		{IsReady: true},
+		{IsInBlock: true, AsInBlock: types.Hash{1}},
+		{IsFinalized: true, AsFinalized: types.Hash{1}},
+	}
+	a.SetStatuses(statuses...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := a.SubmitAndWatchExtrinsicContext(ctx, newTestExtrinsic(0))
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for _, expected := range statuses {
+		select {
+		case status := <-sub.Chan():
+			assert.Equal(t, expected, status)
+		case <-time.After(time.Second):
+			t.Fatal("status not received")
+		}
+	}
+
+	_, err = a.SubmitAndWatchExtrinsicContext(ctx, newTestExtrinsic(1))
+	assert.ErrorIs(t, err, errRejected)
+
+	assert.Len(t, a.Submitted(), 1)
+}
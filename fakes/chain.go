@@ -0,0 +1,206 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	newHeadsMethod       = "chain_subscribeNewHead"
+	finalizedHeadsMethod = "chain_subscribeFinalizedHeads"
+)
+
+// Chain is an in-memory chain.Provider. Blocks are added on top of the best block via AddBlock, which notifies the
+// new heads subscribers, and are finalized via Finalize, which notifies the finalized heads subscribers.
+type Chain struct {
+	notifier *notifier
+	rpc      chain.Chain
+
+	mu        sync.RWMutex
+	blocks    map[types.Hash]types.SignedBlock
+	hashes    []types.Hash // by block number
+	finalized uint64
+}
+
+var _ chain.Provider = (*Chain)(nil)
+
+// NewChain creates a chain that only contains the finalized genesis block.
+func NewChain() *Chain {
+	n := newNotifier(nil)
+
+	c := &Chain{
+		notifier: n,
+		rpc:      chain.NewChain(n),
+		blocks:   make(map[types.Hash]types.SignedBlock),
+	}
+
+	// The genesis header is empty, so it can always be encoded.
+	_, _ = c.AddBlock()
+
+	return c
+}
+
+// AddBlock adds a block with the extrinsics on top of the best block and returns its hash.
+func (c *Chain) AddBlock(extrinsics ...types.Extrinsic) (types.Hash, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header := types.Header{Number: types.BlockNumber(len(c.hashes))}
+
+	if len(c.hashes) > 0 {
+		header.ParentHash = c.hashes[len(c.hashes)-1]
+	}
+
+	enc, err := codec.Encode(header)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	hash := types.Hash(blake2b.Sum256(enc))
+
+	c.blocks[hash] = types.SignedBlock{Block: types.Block{Header: header, Extrinsics: extrinsics}}
+	c.hashes = append(c.hashes, hash)
+
+	c.notifier.publish(newHeadsMethod, func([]interface{}) interface{} { return header })
+
+	return hash, nil
+}
+
+// Finalize finalizes the block and its ancestors. Blocks that are finalized already are ignored.
+func (c *Chain) Finalize(blockHash types.Hash) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	block, ok := c.blocks[blockHash]
+	if !ok {
+		return ErrUnknownBlock.WithMsg("block hash %s", blockHash.Hex())
+	}
+
+	header := block.Block.Header
+
+	if uint64(header.Number) <= c.finalized {
+		return nil
+	}
+
+	c.finalized = uint64(header.Number)
+
+	c.notifier.publish(finalizedHeadsMethod, func([]interface{}) interface{} { return header })
+
+	return nil
+}
+
+func (c *Chain) SubscribeNewHeads() (*chain.NewHeadsSubscription, error) {
+	return c.SubscribeNewHeadsContext(context.Background())
+}
+
+func (c *Chain) SubscribeNewHeadsContext(ctx context.Context) (*chain.NewHeadsSubscription, error) {
+	return c.rpc.SubscribeNewHeadsContext(ctx)
+}
+
+func (c *Chain) SubscribeFinalizedHeads() (*chain.FinalizedHeadsSubscription, error) {
+	return c.SubscribeFinalizedHeadsContext(context.Background())
+}
+
+func (c *Chain) SubscribeFinalizedHeadsContext(ctx context.Context) (*chain.FinalizedHeadsSubscription, error) {
+	return c.rpc.SubscribeFinalizedHeadsContext(ctx)
+}
+
+func (c *Chain) GetBlockHash(blockNumber uint64) (types.Hash, error) {
+	return c.GetBlockHashContext(context.Background(), blockNumber)
+}
+
+func (c *Chain) GetBlockHashContext(_ context.Context, blockNumber uint64) (types.Hash, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if blockNumber >= uint64(len(c.hashes)) {
+		return types.Hash{}, ErrBlockNumberNotFound.WithMsg("block number %d", blockNumber)
+	}
+
+	return c.hashes[blockNumber], nil
+}
+
+func (c *Chain) GetBlockHashLatest() (types.Hash, error) {
+	return c.GetBlockHashLatestContext(context.Background())
+}
+
+func (c *Chain) GetBlockHashLatestContext(_ context.Context) (types.Hash, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.hashes[len(c.hashes)-1], nil
+}
+
+func (c *Chain) GetFinalizedHead() (types.Hash, error) {
+	return c.GetFinalizedHeadContext(context.Background())
+}
+
+func (c *Chain) GetFinalizedHeadContext(_ context.Context) (types.Hash, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.hashes[c.finalized], nil
+}
+
+func (c *Chain) GetBlock(blockHash types.Hash) (*types.SignedBlock, error) {
+	return c.GetBlockContext(context.Background(), blockHash)
+}
+
+func (c *Chain) GetBlockContext(_ context.Context, blockHash types.Hash) (*types.SignedBlock, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	block, ok := c.blocks[blockHash]
+	if !ok {
+		return nil, ErrUnknownBlock.WithMsg("block hash %s", blockHash.Hex())
+	}
+
+	return &block, nil
+}
+
+func (c *Chain) GetHeader(blockHash types.Hash) (*types.Header, error) {
+	return c.GetHeaderContext(context.Background(), blockHash)
+}
+
+func (c *Chain) GetHeaderContext(ctx context.Context, blockHash types.Hash) (*types.Header, error) {
+	block, err := c.GetBlockContext(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block.Block.Header, nil
+}
+
+func (c *Chain) GetHeaderLatest() (*types.Header, error) {
+	return c.GetHeaderLatestContext(context.Background())
+}
+
+func (c *Chain) GetHeaderLatestContext(ctx context.Context) (*types.Header, error) {
+	blockHash, err := c.GetBlockHashLatestContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetHeaderContext(ctx, blockHash)
+}
@@ -0,0 +1,113 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_AddBlock(t *testing.T) {
+	c := NewChain()
+
+	genesisHash, err := c.GetBlockHash(0)
+	require.NoError(t, err)
+
+	xt := types.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 5}})
+
+	blockHash, err := c.AddBlock(xt)
+	require.NoError(t, err)
+	assert.NotEqual(t, genesisHash, blockHash)
+
+	latest, err := c.GetBlockHashLatest()
+	require.NoError(t, err)
+	assert.Equal(t, blockHash, latest)
+
+	header, err := c.GetHeaderLatest()
+	require.NoError(t, err)
+	assert.Equal(t, types.BlockNumber(1), header.Number)
+	assert.Equal(t, genesisHash, header.ParentHash)
+
+	block, err := c.GetBlock(blockHash)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Extrinsic{xt}, block.Block.Extrinsics)
+
+	_, err = c.GetBlockHash(2)
+	assert.ErrorIs(t, err, ErrBlockNumberNotFound)
+
+	_, err = c.GetHeader(types.Hash{1})
+	assert.ErrorIs(t, err, ErrUnknownBlock)
+}
+
+func TestChain_Subscriptions(t *testing.T) {
+	c := NewChain()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	newHeads, err := c.SubscribeNewHeadsContext(ctx)
+	require.NoError(t, err)
+
+	finalizedHeads, err := c.SubscribeFinalizedHeadsContext(ctx)
+	require.NoError(t, err)
+
+	var hashes []types.Hash
+
+	for i := 0; i < 3; i++ {
+		blockHash, err := c.AddBlock()
+		require.NoError(t, err)
+
+		hashes = append(hashes, blockHash)
+	}
+
+	for i := 1; i <= 3; i++ {
+		select {
+		case header := <-newHeads.Chan():
+			assert.Equal(t, types.BlockNumber(i), header.Number)
+		case <-time.After(time.Second):
+			t.Fatal("new head not received")
+		}
+	}
+
+	require.NoError(t, c.Finalize(hashes[1]))
+	// Finalizing an ancestor of the finalized block is ignored.
+	require.NoError(t, c.Finalize(hashes[0]))
+	assert.ErrorIs(t, c.Finalize(types.Hash{1}), ErrUnknownBlock)
+
+	select {
+	case header := <-finalizedHeads.Chan():
+		assert.Equal(t, types.BlockNumber(2), header.Number)
+	case <-time.After(time.Second):
+		t.Fatal("finalized head not received")
+	}
+
+	finalizedHead, err := c.GetFinalizedHead()
+	require.NoError(t, err)
+	assert.Equal(t, hashes[1], finalizedHead)
+
+	newHeads.Unsubscribe()
+	finalizedHeads.Unsubscribe()
+
+	// Blocks can still be added without subscribers.
+	_, err = c.AddBlock()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,198 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// initialNotifications returns the notifications that are sent to a new subscriber of the method before the
+// published ones, e.g. the current runtime version. An error fails the subscription.
+type initialNotifications func(method string, args []interface{}) ([]interface{}, error)
+
+// notifier is a client.Client that only supports subscriptions, whose notifications are published by the fakes
+// instead of a node. The fakes subscribe via the modules of the rpc packages on top of it, so that they return the
+// same subscription types as the RPCs.
+type notifier struct {
+	initial initialNotifications
+	subID   atomic.Uint64
+
+	mu          sync.Mutex
+	subscribers map[string]map[string]*subscriber // by subscribe method and subscription ID
+}
+
+func newNotifier(initial initialNotifications) *notifier {
+	return &notifier{
+		initial:     initial,
+		subscribers: make(map[string]map[string]*subscriber),
+	}
+}
+
+func (n *notifier) Call(_ interface{}, method string, _ ...interface{}) error {
+	return ErrUnsupported.WithMsg("call of %s", method)
+}
+
+func (n *notifier) CallContext(_ context.Context, _ interface{}, method string, _ ...interface{}) error {
+	return ErrUnsupported.WithMsg("call of %s", method)
+}
+
+func (n *notifier) BatchCallContext(_ context.Context, _ []gethrpc.BatchElem) error {
+	return ErrUnsupported.WithMsg("batch call")
+}
+
+func (n *notifier) Batch() *client.Batch {
+	return client.NewBatch(n)
+}
+
+func (n *notifier) Subscribe(
+	_ context.Context,
+	namespace, subscribeMethodSuffix, _, _ string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	method := namespace + "_" + subscribeMethodSuffix
+
+	s := &subscriber{
+		channel: reflect.ValueOf(channel),
+		args:    args,
+		wake:    make(chan struct{}, 1),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	if n.initial != nil {
+		notifications, err := n.initial(method, args)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, notification := range notifications {
+			s.push(notification)
+		}
+	}
+
+	id := fmt.Sprintf("0x%x", n.subID.Add(1))
+
+	n.mu.Lock()
+	if n.subscribers[method] == nil {
+		n.subscribers[method] = make(map[string]*subscriber)
+	}
+	n.subscribers[method][id] = s
+	n.mu.Unlock()
+
+	var quitOnce sync.Once
+
+	sub := gethrpc.NewDetachedClientSubscription(func() string { return id }, func() {
+		quitOnce.Do(func() {
+			n.mu.Lock()
+			delete(n.subscribers[method], id)
+			n.mu.Unlock()
+
+			close(s.quit)
+		})
+
+		<-s.done
+	})
+
+	go s.run()
+
+	return sub, nil
+}
+
+func (n *notifier) URL() string {
+	return "fake://"
+}
+
+func (n *notifier) Close() {}
+
+// publish queues the notification returned by notification for every subscriber of the method. Subscribers are
+// skipped if it returns nil, e.g. because the notification does not concern the args of the subscription.
+func (n *notifier) publish(method string, notification func(args []interface{}) interface{}) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, s := range n.subscribers[method] {
+		if v := notification(s.args); v != nil {
+			s.push(v)
+		}
+	}
+}
+
+// subscriber sends the queued notifications to the channel of a subscription in order, so that publishing never
+// blocks on slow subscribers.
+type subscriber struct {
+	channel reflect.Value
+	args    []interface{}
+
+	mu    sync.Mutex
+	queue []reflect.Value
+
+	wake chan struct{}
+	quit chan struct{}
+	done chan struct{}
+}
+
+func (s *subscriber) push(notification interface{}) {
+	s.mu.Lock()
+	s.queue = append(s.queue, reflect.ValueOf(notification))
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscriber) run() {
+	defer close(s.done)
+
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: s.channel},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(s.quit)},
+	}
+
+	for {
+		s.mu.Lock()
+
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+
+			select {
+			case <-s.wake:
+				continue
+			case <-s.quit:
+				return
+			}
+		}
+
+		cases[0].Send = s.queue[0]
+		s.queue = s.queue[1:]
+
+		s.mu.Unlock()
+
+		if chosen, _, _ := reflect.Select(cases); chosen == 1 {
+			return
+		}
+	}
+}
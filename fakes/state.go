@@ -0,0 +1,365 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const runtimeVersionMethod = "state_subscribeRuntimeVersion"
+
+// CallHandler answers runtime API calls of the State fake with the SCALE encoded result.
+type CallHandler func(data []byte, blockHash types.Hash) ([]byte, error)
+
+// State is an in-memory state.Provider with canned metadata, runtime versions, storage and runtime API results.
+//
+// Values that are set for a block take precedence over the ones that are set for all blocks.
+type State struct {
+	notifier *notifier
+	rpc      state.State
+
+	mu            sync.RWMutex
+	meta          *types.Metadata
+	metaAt        map[types.Hash]*types.Metadata
+	version       *types.RuntimeVersion
+	versionAt     map[types.Hash]*types.RuntimeVersion
+	storage       map[string]types.StorageDataRaw
+	storageAt     map[types.Hash]map[string]types.StorageDataRaw
+	storageBlocks []types.Hash // blocks with storage, in the order they were set
+	handlers      map[string]CallHandler
+}
+
+var _ state.Provider = (*State)(nil)
+
+// NewState creates a state without any values.
+func NewState() *State {
+	s := &State{
+		metaAt:    make(map[types.Hash]*types.Metadata),
+		versionAt: make(map[types.Hash]*types.RuntimeVersion),
+		storage:   make(map[string]types.StorageDataRaw),
+		storageAt: make(map[types.Hash]map[string]types.StorageDataRaw),
+		handlers:  make(map[string]CallHandler),
+	}
+
+	s.notifier = newNotifier(s.initialNotifications)
+	s.rpc = state.NewState(s.notifier)
+
+	return s
+}
+
+// SetMetadata sets the metadata of all blocks.
+func (s *State) SetMetadata(meta *types.Metadata) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.meta = meta
+}
+
+// SetMetadataAt sets the metadata of the block.
+func (s *State) SetMetadataAt(blockHash types.Hash, meta *types.Metadata) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.metaAt[blockHash] = meta
+}
+
+// SetRuntimeVersion sets the runtime version of all blocks and notifies the runtime version subscribers, as after a
+// runtime upgrade.
+func (s *State) SetRuntimeVersion(version types.RuntimeVersion) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.version = &version
+
+	s.notifier.publish(runtimeVersionMethod, func([]interface{}) interface{} { return version })
+}
+
+// SetRuntimeVersionAt sets the runtime version of the block.
+func (s *State) SetRuntimeVersionAt(blockHash types.Hash, version types.RuntimeVersion) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.versionAt[blockHash] = &version
+}
+
+// SetStorage sets the SCALE encoded value of the storage key for all blocks.
+func (s *State) SetStorage(key types.StorageKey, value interface{}) error {
+	enc, err := codec.Encode(value)
+	if err != nil {
+		return err
+	}
+
+	s.SetStorageRaw(key, enc)
+
+	return nil
+}
+
+// SetStorageRaw sets the raw value of the storage key for all blocks.
+func (s *State) SetStorageRaw(key types.StorageKey, value types.StorageDataRaw) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.storage[key.Hex()] = value
+}
+
+// SetStorageAt sets the SCALE encoded value of the storage key at the block.
+func (s *State) SetStorageAt(blockHash types.Hash, key types.StorageKey, value interface{}) error {
+	enc, err := codec.Encode(value)
+	if err != nil {
+		return err
+	}
+
+	s.SetStorageRawAt(blockHash, key, enc)
+
+	return nil
+}
+
+// SetStorageRawAt sets the raw value of the storage key at the block. The blocks are returned by QueryStorage in the
+// order their first value was set.
+func (s *State) SetStorageRawAt(blockHash types.Hash, key types.StorageKey, value types.StorageDataRaw) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.storageAt[blockHash] == nil {
+		s.storageAt[blockHash] = make(map[string]types.StorageDataRaw)
+		s.storageBlocks = append(s.storageBlocks, blockHash)
+	}
+
+	s.storageAt[blockHash][key.Hex()] = value
+}
+
+// HandleCall registers the handler of the runtime API method, e.g. "Core_version".
+func (s *State) HandleCall(method string, handler CallHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[method] = handler
+}
+
+func (s *State) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) (ok bool, err error) {
+	return s.GetStorageContext(context.Background(), key, target, blockHash)
+}
+
+func (s *State) GetStorageContext(
+	ctx context.Context,
+	key types.StorageKey,
+	target interface{},
+	blockHash types.Hash,
+) (ok bool, err error) {
+	raw, err := s.GetStorageRawContext(ctx, key, blockHash)
+	if err != nil {
+		return false, err
+	}
+
+	if len(*raw) == 0 {
+		return false, nil
+	}
+
+	return true, codec.Decode(*raw, target)
+}
+
+func (s *State) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	return s.GetStorageRawContext(context.Background(), key, blockHash)
+}
+
+func (s *State) GetStorageRawContext(
+	_ context.Context,
+	key types.StorageKey,
+	blockHash types.Hash,
+) (*types.StorageDataRaw, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.storageAt[blockHash][key.Hex()]
+	if !ok {
+		value = s.storage[key.Hex()]
+	}
+
+	raw := types.NewStorageDataRaw(value)
+
+	return &raw, nil
+}
+
+func (s *State) GetMetadata(blockHash types.Hash) (*types.Metadata, error) {
+	return s.GetMetadataContext(context.Background(), blockHash)
+}
+
+func (s *State) GetMetadataContext(_ context.Context, blockHash types.Hash) (*types.Metadata, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if meta, ok := s.metaAt[blockHash]; ok {
+		return meta, nil
+	}
+
+	if s.meta == nil {
+		return nil, ErrMetadataNotSet
+	}
+
+	return s.meta, nil
+}
+
+func (s *State) GetMetadataLatest() (*types.Metadata, error) {
+	return s.GetMetadataLatestContext(context.Background())
+}
+
+func (s *State) GetMetadataLatestContext(_ context.Context) (*types.Metadata, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.meta == nil {
+		return nil, ErrMetadataNotSet
+	}
+
+	return s.meta, nil
+}
+
+func (s *State) GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion, error) {
+	return s.GetRuntimeVersionContext(context.Background(), blockHash)
+}
+
+func (s *State) GetRuntimeVersionContext(_ context.Context, blockHash types.Hash) (*types.RuntimeVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if version, ok := s.versionAt[blockHash]; ok {
+		return version, nil
+	}
+
+	if s.version == nil {
+		return nil, ErrRuntimeVersionNotSet
+	}
+
+	return s.version, nil
+}
+
+func (s *State) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
+	return s.GetRuntimeVersionLatestContext(context.Background())
+}
+
+func (s *State) GetRuntimeVersionLatestContext(_ context.Context) (*types.RuntimeVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.version == nil {
+		return nil, ErrRuntimeVersionNotSet
+	}
+
+	return s.version, nil
+}
+
+// SubscribeRuntimeVersion subscribes the runtime versions set via SetRuntimeVersion. As with a node, the current
+// runtime version is sent first, if any.
+func (s *State) SubscribeRuntimeVersion() (*state.RuntimeVersionSubscription, error) {
+	return s.SubscribeRuntimeVersionContext(context.Background())
+}
+
+func (s *State) SubscribeRuntimeVersionContext(ctx context.Context) (*state.RuntimeVersionSubscription, error) {
+	return s.rpc.SubscribeRuntimeVersionContext(ctx)
+}
+
+func (s *State) initialNotifications(method string, _ []interface{}) ([]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if method != runtimeVersionMethod || s.version == nil {
+		return nil, nil
+	}
+
+	return []interface{}{*s.version}, nil
+}
+
+// QueryStorage returns the values of the keys at the blocks whose storage was set via SetStorageAt or
+// SetStorageRawAt, from the start block to the given block in the order they were set.
+func (s *State) QueryStorage(
+	keys []types.StorageKey,
+	startBlock types.Hash,
+	block types.Hash,
+) ([]types.StorageChangeSet, error) {
+	return s.QueryStorageContext(context.Background(), keys, startBlock, block)
+}
+
+func (s *State) QueryStorageContext(
+	_ context.Context,
+	keys []types.StorageKey,
+	startBlock types.Hash,
+	block types.Hash,
+) ([]types.StorageChangeSet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var changeSets []types.StorageChangeSet
+
+	started := false
+
+	for _, blockHash := range s.storageBlocks {
+		started = started || blockHash == startBlock
+		if !started {
+			continue
+		}
+
+		changeSet := types.StorageChangeSet{Block: blockHash}
+
+		for _, key := range keys {
+			value, ok := s.storageAt[blockHash][key.Hex()]
+
+			changeSet.Changes = append(changeSet.Changes, types.KeyValueOption{
+				StorageKey:     key,
+				HasStorageData: ok,
+				StorageData:    value,
+			})
+		}
+
+		changeSets = append(changeSets, changeSet)
+
+		if blockHash == block {
+			return changeSets, nil
+		}
+	}
+
+	if !started {
+		return nil, ErrUnknownBlock.WithMsg("start block hash %s", startBlock.Hex())
+	}
+
+	return nil, ErrUnknownBlock.WithMsg("block hash %s", block.Hex())
+}
+
+func (s *State) Call(method string, data []byte, blockHash types.Hash) (types.Bytes, error) {
+	return s.CallContext(context.Background(), method, data, blockHash)
+}
+
+func (s *State) CallContext(
+	_ context.Context,
+	method string,
+	data []byte,
+	blockHash types.Hash,
+) (types.Bytes, error) {
+	s.mu.RLock()
+	handler, ok := s.handlers[method]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrNoCallHandler.WithMsg("runtime API method %s", method)
+	}
+
+	return handler(data, blockHash)
+}
@@ -0,0 +1,161 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_Storage(t *testing.T) {
+	s := NewState()
+
+	key := types.NewStorageKey([]byte{1, 2})
+	blockHash := types.Hash{1}
+
+	require.NoError(t, s.SetStorage(key, types.U32(7)))
+	require.NoError(t, s.SetStorageAt(blockHash, key, types.U32(8)))
+
+	var value types.U32
+
+	ok, err := s.GetStorage(key, &value, types.Hash{2})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.U32(7), value)
+
+	ok, err = s.GetStorage(key, &value, blockHash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.U32(8), value)
+
+	ok, err = s.GetStorage(types.NewStorageKey([]byte{3}), &value, blockHash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestState_QueryStorage(t *testing.T) {
+	s := NewState()
+
+	key := types.NewStorageKey([]byte{1, 2})
+
+	s.SetStorageRawAt(types.Hash{1}, key, types.StorageDataRaw{1})
+	s.SetStorageRawAt(types.Hash{2}, types.NewStorageKey([]byte{3}), types.StorageDataRaw{2})
+	s.SetStorageRawAt(types.Hash{3}, key, types.StorageDataRaw{3})
+
+	changeSets, err := s.QueryStorage([]types.StorageKey{key}, types.Hash{2}, types.Hash{3})
+	require.NoError(t, err)
+	assert.Equal(t, []types.StorageChangeSet{
+		{Block: types.Hash{2}, Changes: []types.KeyValueOption{{StorageKey: key}}},
+		{
+			Block:   types.Hash{3},
+			Changes: []types.KeyValueOption{{StorageKey: key, HasStorageData: true, StorageData: types.StorageDataRaw{3}}},
+		},
+	}, changeSets)
+
+	_, err = s.QueryStorage([]types.StorageKey{key}, types.Hash{4}, types.Hash{3})
+	assert.ErrorIs(t, err, ErrUnknownBlock)
+
+	_, err = s.QueryStorage([]types.StorageKey{key}, types.Hash{1}, types.Hash{4})
+	assert.ErrorIs(t, err, ErrUnknownBlock)
+}
+
+func TestState_Metadata(t *testing.T) {
+	s := NewState()
+
+	_, err := s.GetMetadataLatest()
+	assert.ErrorIs(t, err, ErrMetadataNotSet)
+
+	meta := &types.Metadata{Version: 14}
+	metaAt := &types.Metadata{Version: 15}
+
+	s.SetMetadata(meta)
+	s.SetMetadataAt(types.Hash{1}, metaAt)
+
+	res, err := s.GetMetadata(types.Hash{2})
+	require.NoError(t, err)
+	assert.Equal(t, meta, res)
+
+	res, err = s.GetMetadata(types.Hash{1})
+	require.NoError(t, err)
+	assert.Equal(t, metaAt, res)
+}
+
+func TestState_RuntimeVersion(t *testing.T) {
+	s := NewState()
+
+	_, err := s.GetRuntimeVersionLatest()
+	assert.ErrorIs(t, err, ErrRuntimeVersionNotSet)
+
+	s.SetRuntimeVersion(types.RuntimeVersion{SpecVersion: 1})
+	s.SetRuntimeVersionAt(types.Hash{1}, types.RuntimeVersion{SpecVersion: 0})
+
+	version, err := s.GetRuntimeVersion(types.Hash{1})
+	require.NoError(t, err)
+	assert.Equal(t, types.U32(0), version.SpecVersion)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := s.SubscribeRuntimeVersionContext(ctx)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	s.SetRuntimeVersion(types.RuntimeVersion{SpecVersion: 2})
+
+	for _, specVersion := range []types.U32{1, 2} {
+		select {
+		case version := <-sub.Chan():
+			assert.Equal(t, specVersion, version.SpecVersion)
+		case <-time.After(time.Second):
+			t.Fatal("runtime version not received")
+		}
+	}
+
+	version, err = s.GetRuntimeVersionLatest()
+	require.NoError(t, err)
+	assert.Equal(t, types.U32(2), version.SpecVersion)
+}
+
+func TestState_Call(t *testing.T) {
+	s := NewState()
+
+	_, err := s.Call("Core_version", nil, types.Hash{1})
+	assert.ErrorIs(t, err, ErrNoCallHandler)
+
+	testErr := errors.New("error")
+
+	s.HandleCall("Core_version", func(data []byte, blockHash types.Hash) ([]byte, error) {
+		if blockHash != (types.Hash{1}) {
+			return nil, testErr
+		}
+
+		return append(data, 1), nil
+	})
+
+	res, err := s.Call("Core_version", []byte{0}, types.Hash{1})
+	require.NoError(t, err)
+	assert.Equal(t, types.Bytes{0, 1}, res)
+
+	_, err = s.Call("Core_version", []byte{0}, types.Hash{2})
+	assert.ErrorIs(t, err, testErr)
+}
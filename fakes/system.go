@@ -0,0 +1,89 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// DryRunRule returns the result of dry-running the extrinsic via the System fake.
+type DryRunRule func(xt types.Extrinsic, blockHash types.Hash) (types.ApplyExtrinsicResult, error)
+
+// System is an in-memory system.Provider with canned account nonces and dry run results.
+type System struct {
+	mu     sync.RWMutex
+	nonces map[types.AccountID]uint64
+	dryRun DryRunRule
+}
+
+var _ system.Provider = (*System)(nil)
+
+// NewSystem creates a system whose accounts have nonce 0 and whose dry runs succeed.
+func NewSystem() *System {
+	return &System{nonces: make(map[types.AccountID]uint64)}
+}
+
+// SetNonce sets the next nonce of the account.
+func (s *System) SetNonce(accountID types.AccountID, nonce uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nonces[accountID] = nonce
+}
+
+// SetDryRunRule sets the rule for dry runs, nil lets all dry runs succeed.
+func (s *System) SetDryRunRule(rule DryRunRule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dryRun = rule
+}
+
+func (s *System) AccountNextIndex(accountID types.AccountID) (types.U64, error) {
+	return s.AccountNextIndexContext(context.Background(), accountID)
+}
+
+func (s *System) AccountNextIndexContext(_ context.Context, accountID types.AccountID) (types.U64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return types.U64(s.nonces[accountID]), nil
+}
+
+func (s *System) DryRun(xt types.Extrinsic, blockHash types.Hash) (types.ApplyExtrinsicResult, error) {
+	return s.DryRunContext(context.Background(), xt, blockHash)
+}
+
+func (s *System) DryRunContext(
+	_ context.Context,
+	xt types.Extrinsic,
+	blockHash types.Hash,
+) (types.ApplyExtrinsicResult, error) {
+	s.mu.RLock()
+	rule := s.dryRun
+	s.mu.RUnlock()
+
+	if rule == nil {
+		return types.ApplyExtrinsicResult{IsOk: true, Ok: types.DispatchOutcome{IsOk: true}}, nil
+	}
+
+	return rule(xt, blockHash)
+}
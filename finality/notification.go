@@ -0,0 +1,71 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Finalized is sent when a block of the canonical chain was finalized.
+type Finalized struct {
+	Number uint64
+	Hash   types.Hash
+}
+
+// Retracted is sent when blocks were removed from the canonical chain by a reorg, ordered by block number.
+type Retracted struct {
+	Hashes []types.Hash
+}
+
+// Notification is a change of the canonical chain observed by a Tracker.
+type Notification struct {
+	IsFinalized bool
+	AsFinalized Finalized
+	IsRetracted bool
+	AsRetracted Retracted
+}
+
+// NotificationSubscription is a subscription established through Tracker.Subscribe.
+type NotificationSubscription struct {
+	tracker *Tracker
+	channel chan Notification
+	err     chan error
+}
+
+// Chan returns the subscription channel.
+//
+// The channel is closed when Unsubscribe is called or when the subscriber did not keep up with the notifications.
+func (s *NotificationSubscription) Chan() <-chan Notification {
+	return s.channel
+}
+
+// Err returns the subscription error channel.
+//
+// The error channel receives ErrNotificationOverflow if notifications were dropped because the buffer of the
+// subscription was full, the state of blocks can be checked with Tracker.IsFinalized afterwards. The error channel is
+// closed when the subscription ends.
+func (s *NotificationSubscription) Err() <-chan error {
+	return s.err
+}
+
+// Unsubscribe ends the subscription. It can safely be called more than once.
+func (s *NotificationSubscription) Unsubscribe() {
+	s.tracker.mu.Lock()
+	defer s.tracker.mu.Unlock()
+
+	s.tracker.removeSubscription(s, nil)
+}
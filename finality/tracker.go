@@ -0,0 +1,516 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	defaultMaxDepth           = 1024
+	defaultNotificationBuffer = 64
+)
+
+// HeadsSubscription is a subscription of headers, e.g. a chain.NewHeadsSubscription or a
+// chain.FinalizedHeadsSubscription.
+type HeadsSubscription interface {
+	Chan() <-chan types.Header
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// TrackerOptions configure a Tracker. Zero values are replaced by the defaults.
+type TrackerOptions struct {
+	// MaxDepth is the number of blocks of the canonical chain that are kept below the best block, and the maximum
+	// number of headers that are retrieved to fill a gap between two heads, it defaults to 1024.
+	MaxDepth uint64
+
+	// NotificationBuffer is the capacity of the channel of each notification subscription, it defaults to 64.
+	NotificationBuffer int
+
+	// OnError, if set, is called when a head could not be processed. It must not block.
+	OnError func(err error)
+}
+
+// block is a block of the canonical chain.
+type block struct {
+	number     uint64
+	hash       types.Hash
+	parentHash types.Hash
+}
+
+// Tracker follows the best and the finalized heads and keeps the recent part of the canonical chain in memory. It
+// answers whether blocks are finalized, lets callers wait for the finalization of a block and notifies subscribers
+// about finalized and retracted blocks, so that components do not need to maintain their own head subscriptions.
+//
+// Heads that do not extend the known chain, e.g. after missed notifications or a reorg, are connected to it by
+// retrieving the headers of their ancestors, up to TrackerOptions.MaxDepth headers.
+type Tracker struct {
+	chainRPC chain.Chain
+	opts     TrackerOptions
+
+	mu sync.Mutex
+
+	// canonical holds the hashes of the canonical chain from lowest to best, numbers is its reverse index.
+	canonical map[uint64]types.Hash
+	numbers   map[types.Hash]uint64
+	lowest    uint64
+	best      uint64
+
+	hasFinalized    bool
+	finalizedNumber uint64
+
+	// finalizedSignal is closed and replaced whenever blocks were finalized.
+	finalizedSignal chan struct{}
+
+	subscriptions map[*NotificationSubscription]struct{}
+}
+
+// NewTracker creates a Tracker that retrieves missing headers via the chain RPC. The tracker starts following the
+// heads once Run or Watch is called.
+func NewTracker(chainRPC chain.Chain, opts TrackerOptions) *Tracker {
+	if opts.MaxDepth == 0 {
+		opts.MaxDepth = defaultMaxDepth
+	}
+
+	if opts.NotificationBuffer <= 0 {
+		opts.NotificationBuffer = defaultNotificationBuffer
+	}
+
+	return &Tracker{
+		chainRPC:        chainRPC,
+		opts:            opts,
+		canonical:       make(map[uint64]types.Hash),
+		numbers:         make(map[types.Hash]uint64),
+		finalizedSignal: make(chan struct{}),
+		subscriptions:   make(map[*NotificationSubscription]struct{}),
+	}
+}
+
+// Subscribe returns a subscription that receives the notifications of the tracker, see NotificationSubscription.
+func (t *Tracker) Subscribe() *NotificationSubscription {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sub := &NotificationSubscription{
+		tracker: t,
+		channel: make(chan Notification, t.opts.NotificationBuffer),
+		err:     make(chan error, 1),
+	}
+
+	t.subscriptions[sub] = struct{}{}
+
+	return sub
+}
+
+// IsFinalized returns whether the block with the given hash is finalized.
+func (t *Tracker) IsFinalized(blockHash types.Hash) (bool, error) {
+	return t.IsFinalizedContext(context.Background(), blockHash)
+}
+
+// IsFinalizedContext is like IsFinalized but uses the provided context for the RPC calls. Blocks that are not kept
+// in memory are looked up via the chain RPC.
+func (t *Tracker) IsFinalizedContext(ctx context.Context, blockHash types.Hash) (bool, error) {
+	t.mu.Lock()
+	hasFinalized, finalizedNumber := t.hasFinalized, t.finalizedNumber
+	number, known := t.numbers[blockHash]
+	t.mu.Unlock()
+
+	if !hasFinalized {
+		return t.chainRPC.IsFinalizedContext(ctx, blockHash)
+	}
+
+	if known {
+		return number <= finalizedNumber, nil
+	}
+
+	number, err := t.blockNumber(ctx, blockHash)
+	if err != nil {
+		return false, err
+	}
+
+	if number > finalizedNumber {
+		return false, nil
+	}
+
+	finalizedHash, err := t.finalizedHash(ctx, number)
+	if err != nil {
+		return false, err
+	}
+
+	return finalizedHash == blockHash, nil
+}
+
+// WaitForFinalization waits until the block with the given hash or another block at its height was finalized. It
+// returns ErrBlockRetracted in the latter case.
+func (t *Tracker) WaitForFinalization(ctx context.Context, blockHash types.Hash) error {
+	number, err := t.blockNumber(ctx, blockHash)
+	if err != nil {
+		return err
+	}
+
+	for {
+		t.mu.Lock()
+		finalized := t.hasFinalized && number <= t.finalizedNumber
+		signal := t.finalizedSignal
+		t.mu.Unlock()
+
+		if finalized {
+			break
+		}
+
+		select {
+		case <-signal:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	finalizedHash, err := t.finalizedHash(ctx, number)
+	if err != nil {
+		return err
+	}
+
+	if finalizedHash != blockHash {
+		return ErrBlockRetracted.WithMsg("block %s", blockHash.Hex())
+	}
+
+	return nil
+}
+
+// Run subscribes to the new and the finalized heads and follows them until ctx is done or a subscription ended, see
+// Watch.
+func (t *Tracker) Run(ctx context.Context) error {
+	newHeads, err := t.chainRPC.SubscribeNewHeadsContext(ctx)
+	if err != nil {
+		return ErrTrackerSubscribe.Wrap(err)
+	}
+
+	finalizedHeads, err := t.chainRPC.SubscribeFinalizedHeadsContext(ctx)
+	if err != nil {
+		newHeads.Unsubscribe()
+
+		return ErrTrackerSubscribe.Wrap(err)
+	}
+
+	return t.Watch(ctx, newHeads, finalizedHeads)
+}
+
+// Watch follows the heads of the subscriptions until ctx is done or one of the subscriptions ended, and unsubscribes
+// both afterwards. Watch can be called again with new subscriptions, e.g. after reconnecting, blocks that were
+// missed in between are filled in from the ancestors of the next heads.
+func (t *Tracker) Watch(ctx context.Context, newHeads, finalizedHeads HeadsSubscription) error {
+	defer newHeads.Unsubscribe()
+	defer finalizedHeads.Unsubscribe()
+
+	for {
+		var err error
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case header, ok := <-newHeads.Chan():
+			if !ok {
+				return nil
+			}
+
+			err = t.handleNewHead(ctx, header)
+		case header, ok := <-finalizedHeads.Chan():
+			if !ok {
+				return nil
+			}
+
+			err = t.handleFinalizedHead(ctx, header)
+		case err, ok := <-newHeads.Err():
+			if ok && err != nil {
+				return ErrTrackerSubscribe.Wrap(err)
+			}
+
+			return nil
+		case err, ok := <-finalizedHeads.Err():
+			if ok && err != nil {
+				return ErrTrackerSubscribe.Wrap(err)
+			}
+
+			return nil
+		}
+
+		if err != nil && t.opts.OnError != nil {
+			t.opts.OnError(ErrHeadProcessing.Wrap(err))
+		}
+	}
+}
+
+func (t *Tracker) handleNewHead(ctx context.Context, header types.Header) error {
+	path, err := t.walk(ctx, header)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.notify(t.adopt(path))
+	t.prune()
+
+	return nil
+}
+
+func (t *Tracker) handleFinalizedHead(ctx context.Context, header types.Header) error {
+	number := uint64(header.Number)
+
+	t.mu.Lock()
+	done := t.hasFinalized && number <= t.finalizedNumber
+	t.mu.Unlock()
+
+	if done {
+		return nil
+	}
+
+	path, err := t.walk(ctx, header)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// The finalized block is usually part of the canonical chain already, adopting it retracts the blocks of a fork
+	// that was the best chain otherwise.
+	if t.canonical[number] != path[0].hash {
+		t.notify(t.adopt(path))
+	}
+
+	// Only the finalized head is reported when the tracker starts, and the blocks that are not kept in memory are
+	// skipped.
+	start := number
+
+	if t.hasFinalized {
+		start = t.finalizedNumber + 1
+	}
+
+	if start < t.lowest {
+		start = t.lowest
+	}
+
+	for n := start; n <= number; n++ {
+		t.notify(Notification{
+			IsFinalized: true,
+			AsFinalized: Finalized{Number: n, Hash: t.canonical[n]},
+		})
+	}
+
+	t.hasFinalized, t.finalizedNumber = true, number
+
+	close(t.finalizedSignal)
+	t.finalizedSignal = make(chan struct{})
+
+	t.prune()
+
+	return nil
+}
+
+// walk returns the block of the header followed by its ancestors down to the first one whose parent is part of the
+// known canonical chain, or is below it.
+func (t *Tracker) walk(ctx context.Context, header types.Header) ([]block, error) {
+	var path []block
+
+	for {
+		hash, err := headerHash(header)
+		if err != nil {
+			return nil, err
+		}
+
+		b := block{number: uint64(header.Number), hash: hash, parentHash: header.ParentHash}
+		path = append(path, b)
+
+		if b.number == 0 || uint64(len(path)) >= t.opts.MaxDepth || t.isConnected(b) {
+			return path, nil
+		}
+
+		parent, err := t.chainRPC.GetHeaderContext(ctx, b.parentHash)
+		if err != nil {
+			return nil, ErrHeaderRetrieval.Wrap(err)
+		}
+
+		header = *parent
+	}
+}
+
+// isConnected returns true if the parent of the block is part of the known canonical chain or below it, so that
+// nothing is known about the ancestors of the block.
+func (t *Tracker) isConnected(b block) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.canonical) == 0 || b.number <= t.lowest {
+		return true
+	}
+
+	return t.canonical[b.number-1] == b.parentHash
+}
+
+// adopt makes the path the head of the canonical chain and returns the notification about the retracted blocks.
+func (t *Tracker) adopt(path []block) Notification {
+	head, bottom := path[0], path[len(path)-1]
+
+	var retracted []types.Hash
+
+	if len(t.canonical) > 0 {
+		for n := bottom.number; n <= t.best; n++ {
+			hash, ok := t.canonical[n]
+			if !ok || (n <= head.number && hash == path[head.number-n].hash) {
+				continue
+			}
+
+			retracted = append(retracted, hash)
+			t.remove(n)
+		}
+
+		// The path could not be connected within the max depth, the older blocks are not known to be its ancestors.
+		if bottom.number > t.lowest && t.canonical[bottom.number-1] != bottom.parentHash {
+			for n := t.lowest; n < bottom.number; n++ {
+				t.remove(n)
+			}
+		}
+	}
+
+	for _, b := range path {
+		t.canonical[b.number] = b.hash
+		t.numbers[b.hash] = b.number
+	}
+
+	if len(t.canonical) == len(path) || bottom.number < t.lowest {
+		t.lowest = bottom.number
+	}
+
+	t.best = head.number
+
+	if len(retracted) == 0 {
+		return Notification{}
+	}
+
+	return Notification{IsRetracted: true, AsRetracted: Retracted{Hashes: retracted}}
+}
+
+// prune removes the blocks that are more than the max depth below the best block.
+func (t *Tracker) prune() {
+	if t.best < t.opts.MaxDepth {
+		return
+	}
+
+	lowest := t.best - t.opts.MaxDepth + 1
+
+	for n := t.lowest; n < lowest; n++ {
+		t.remove(n)
+	}
+
+	if t.lowest < lowest {
+		t.lowest = lowest
+	}
+}
+
+func (t *Tracker) remove(number uint64) {
+	if hash, ok := t.canonical[number]; ok {
+		delete(t.numbers, hash)
+		delete(t.canonical, number)
+	}
+}
+
+// notify sends the notification to all subscriptions, subscriptions whose buffer is full are ended.
+func (t *Tracker) notify(notification Notification) {
+	if !notification.IsFinalized && !notification.IsRetracted {
+		return
+	}
+
+	for sub := range t.subscriptions {
+		select {
+		case sub.channel <- notification:
+		default:
+			t.removeSubscription(sub, ErrNotificationOverflow)
+		}
+	}
+}
+
+// removeSubscription ends the subscription with the error, if any. The lock must be held.
+func (t *Tracker) removeSubscription(sub *NotificationSubscription, err error) {
+	if _, ok := t.subscriptions[sub]; !ok {
+		return
+	}
+
+	delete(t.subscriptions, sub)
+
+	if err != nil {
+		sub.err <- err
+	}
+
+	close(sub.channel)
+	close(sub.err)
+}
+
+// blockNumber returns the number of the block, retrieving its header if the block is not kept in memory.
+func (t *Tracker) blockNumber(ctx context.Context, blockHash types.Hash) (uint64, error) {
+	t.mu.Lock()
+	number, ok := t.numbers[blockHash]
+	t.mu.Unlock()
+
+	if ok {
+		return number, nil
+	}
+
+	header, err := t.chainRPC.GetHeaderContext(ctx, blockHash)
+	if err != nil {
+		return 0, ErrHeaderRetrieval.Wrap(err)
+	}
+
+	return uint64(header.Number), nil
+}
+
+// finalizedHash returns the hash of the finalized block with the given number, retrieving it if the block is not
+// kept in memory.
+func (t *Tracker) finalizedHash(ctx context.Context, number uint64) (types.Hash, error) {
+	t.mu.Lock()
+	hash, ok := t.canonical[number]
+	t.mu.Unlock()
+
+	if ok {
+		return hash, nil
+	}
+
+	hash, err := t.chainRPC.GetBlockHashContext(ctx, number)
+	if err != nil {
+		return types.Hash{}, ErrBlockHashRetrieval.Wrap(err)
+	}
+
+	return hash, nil
+}
+
+// headerHash returns the hash of the block with the header, the blake2-256 hash of the SCALE encoded header.
+func headerHash(header types.Header) (types.Hash, error) {
+	encoded, err := codec.Encode(header)
+	if err != nil {
+		return types.Hash{}, ErrHeaderEncoding.Wrap(err)
+	}
+
+	return blake2b.Sum256(encoded), nil
+}
@@ -0,0 +1,298 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testHeadsSubscription struct {
+	channel chan types.Header
+	err     chan error
+}
+
+func newTestHeadsSubscription() *testHeadsSubscription {
+	return &testHeadsSubscription{
+		channel: make(chan types.Header),
+		err:     make(chan error, 1),
+	}
+}
+
+func (s *testHeadsSubscription) Chan() <-chan types.Header { return s.channel }
+func (s *testHeadsSubscription) Err() <-chan error         { return s.err }
+func (s *testHeadsSubscription) Unsubscribe()              {}
+
+// testChain is a chain of headers on top of the genesis header, forks are told apart by their state root.
+type testChain struct {
+	headers []types.Header
+	hashes  []types.Hash
+}
+
+func newTestChain(t *testing.T, length int) *testChain {
+	c := &testChain{}
+	c.append(t, types.Header{}, length+1, 0)
+
+	return c
+}
+
+// fork returns a chain that shares the blocks up to the given number with c.
+func (c *testChain) fork(t *testing.T, number int, length int, id byte) *testChain {
+	f := &testChain{
+		headers: append([]types.Header{}, c.headers[:number+1]...),
+		hashes:  append([]types.Hash{}, c.hashes[:number+1]...),
+	}
+	f.append(t, f.headers[number], length, id)
+
+	return f
+}
+
+func (c *testChain) append(t *testing.T, parent types.Header, length int, id byte) {
+	for i := 0; i < length; i++ {
+		header := types.Header{StateRoot: types.Hash{id}}
+
+		if len(c.headers) > 0 {
+			header.Number = parent.Number + 1
+			header.ParentHash = c.hashes[len(c.hashes)-1]
+		}
+
+		hash, err := headerHash(header)
+		require.NoError(t, err)
+
+		c.headers = append(c.headers, header)
+		c.hashes = append(c.hashes, hash)
+		parent = header
+	}
+}
+
+// expectHeaders expects the headers of the blocks to be retrieved.
+func (c *testChain) expectHeaders(chainMock *chainMocks.Chain, numbers ...int) {
+	for _, n := range numbers {
+		header := c.headers[n]
+		chainMock.On("GetHeaderContext", mock.Anything, c.hashes[n]).Return(&header, nil).Once()
+	}
+}
+
+func finalized(c *testChain, numbers ...int) []Notification {
+	var notifications []Notification
+
+	for _, n := range numbers {
+		notifications = append(notifications, Notification{
+			IsFinalized: true,
+			AsFinalized: Finalized{Number: uint64(n), Hash: c.hashes[n]},
+		})
+	}
+
+	return notifications
+}
+
+func receive(t *testing.T, sub *NotificationSubscription, count int) []Notification {
+	var notifications []Notification
+
+	for i := 0; i < count; i++ {
+		select {
+		case notification := <-sub.Chan():
+			notifications = append(notifications, notification)
+		case <-time.After(5 * time.Second):
+			t.Fatal("notification not received")
+		}
+	}
+
+	return notifications
+}
+
+func TestTracker_Finalization(t *testing.T) {
+	chainMock := chainMocks.NewChain(t)
+	tracker := NewTracker(chainMock, TrackerOptions{})
+	sub := tracker.Subscribe()
+
+	c := newTestChain(t, 5)
+
+	for n := 1; n <= 3; n++ {
+		require.NoError(t, tracker.handleNewHead(context.Background(), c.headers[n]))
+	}
+
+	// Only the first finalized head is reported when the tracker starts.
+	require.NoError(t, tracker.handleFinalizedHead(context.Background(), c.headers[1]))
+	assert.Equal(t, finalized(c, 1), receive(t, sub, 1))
+
+	// Block 4 was missed, it is retrieved as the parent of block 5.
+	c.expectHeaders(chainMock, 4)
+	require.NoError(t, tracker.handleNewHead(context.Background(), c.headers[5]))
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- tracker.WaitForFinalization(context.Background(), c.hashes[4])
+	}()
+
+	require.NoError(t, tracker.handleFinalizedHead(context.Background(), c.headers[3]))
+	assert.Equal(t, finalized(c, 2, 3), receive(t, sub, 2))
+
+	for n, expected := range map[int]bool{1: true, 2: true, 3: true, 4: false, 5: false} {
+		isFinalized, err := tracker.IsFinalized(c.hashes[n])
+		assert.NoError(t, err)
+		assert.Equal(t, expected, isFinalized, "block %d", n)
+	}
+
+	select {
+	case <-done:
+		t.Fatal("block 4 is not finalized yet")
+	default:
+	}
+
+	// Finalized heads that were seen before are ignored.
+	require.NoError(t, tracker.handleFinalizedHead(context.Background(), c.headers[2]))
+	require.NoError(t, tracker.handleFinalizedHead(context.Background(), c.headers[5]))
+	assert.Equal(t, finalized(c, 4, 5), receive(t, sub, 2))
+	assert.NoError(t, <-done)
+}
+
+func TestTracker_Reorg(t *testing.T) {
+	chainMock := chainMocks.NewChain(t)
+	tracker := NewTracker(chainMock, TrackerOptions{})
+	sub := tracker.Subscribe()
+
+	c := newTestChain(t, 3)
+	f := c.fork(t, 1, 3, 1)
+
+	for n := 1; n <= 3; n++ {
+		require.NoError(t, tracker.handleNewHead(context.Background(), c.headers[n]))
+	}
+
+	// The fork becomes the best chain with block 4, blocks 2 and 3 of the fork are retrieved.
+	f.expectHeaders(chainMock, 3, 2)
+	require.NoError(t, tracker.handleNewHead(context.Background(), f.headers[4]))
+	assert.Equal(t, []Notification{{
+		IsRetracted: true,
+		AsRetracted: Retracted{Hashes: []types.Hash{c.hashes[2], c.hashes[3]}},
+	}}, receive(t, sub, 1))
+
+	require.NoError(t, tracker.handleFinalizedHead(context.Background(), f.headers[3]))
+	assert.Equal(t, finalized(f, 3), receive(t, sub, 1))
+
+	isFinalized, err := tracker.IsFinalized(f.hashes[2])
+	assert.NoError(t, err)
+	assert.True(t, isFinalized)
+
+	// The retracted blocks are not kept in memory anymore.
+	c.expectHeaders(chainMock, 2, 3)
+
+	isFinalized, err = tracker.IsFinalized(c.hashes[2])
+	assert.NoError(t, err)
+	assert.False(t, isFinalized)
+
+	assert.ErrorIs(t, tracker.WaitForFinalization(context.Background(), c.hashes[3]), ErrBlockRetracted)
+}
+
+func TestTracker_FinalizedFork(t *testing.T) {
+	chainMock := chainMocks.NewChain(t)
+	tracker := NewTracker(chainMock, TrackerOptions{})
+	sub := tracker.Subscribe()
+
+	c := newTestChain(t, 3)
+	f := c.fork(t, 2, 1, 1)
+
+	for n := 1; n <= 3; n++ {
+		require.NoError(t, tracker.handleNewHead(context.Background(), c.headers[n]))
+	}
+
+	// A block of a fork is finalized before it became the best block.
+	require.NoError(t, tracker.handleFinalizedHead(context.Background(), f.headers[3]))
+	assert.Equal(t, []Notification{
+		{IsRetracted: true, AsRetracted: Retracted{Hashes: []types.Hash{c.hashes[3]}}},
+		finalized(f, 3)[0],
+	}, receive(t, sub, 2))
+}
+
+func TestTracker_MaxDepth(t *testing.T) {
+	chainMock := chainMocks.NewChain(t)
+	tracker := NewTracker(chainMock, TrackerOptions{MaxDepth: 3})
+
+	c := newTestChain(t, 10)
+
+	require.NoError(t, tracker.handleNewHead(context.Background(), c.headers[1]))
+
+	// The gap exceeds the max depth, only blocks 8 to 10 are kept.
+	c.expectHeaders(chainMock, 9, 8)
+	require.NoError(t, tracker.handleNewHead(context.Background(), c.headers[10]))
+	assert.Len(t, tracker.canonical, 3)
+	assert.Len(t, tracker.numbers, 3)
+	assert.Equal(t, uint64(8), tracker.lowest)
+
+	require.NoError(t, tracker.handleFinalizedHead(context.Background(), c.headers[9]))
+
+	// Blocks that are not kept in memory are looked up.
+	c.expectHeaders(chainMock, 5)
+	chainMock.On("GetBlockHashContext", mock.Anything, uint64(5)).Return(c.hashes[5], nil).Once()
+
+	isFinalized, err := tracker.IsFinalized(c.hashes[5])
+	assert.NoError(t, err)
+	assert.True(t, isFinalized)
+}
+
+func TestTracker_Subscribe_Overflow(t *testing.T) {
+	tracker := NewTracker(chainMocks.NewChain(t), TrackerOptions{NotificationBuffer: 1})
+	sub := tracker.Subscribe()
+
+	c := newTestChain(t, 2)
+
+	for n := 1; n <= 2; n++ {
+		require.NoError(t, tracker.handleNewHead(context.Background(), c.headers[n]))
+		require.NoError(t, tracker.handleFinalizedHead(context.Background(), c.headers[n]))
+	}
+
+	assert.Equal(t, finalized(c, 1), receive(t, sub, 1))
+	assert.ErrorIs(t, <-sub.Err(), ErrNotificationOverflow)
+
+	_, ok := <-sub.Chan()
+	assert.False(t, ok)
+
+	sub.Unsubscribe()
+}
+
+func TestTracker_Watch(t *testing.T) {
+	tracker := NewTracker(chainMocks.NewChain(t), TrackerOptions{})
+	sub := tracker.Subscribe()
+
+	c := newTestChain(t, 2)
+	newHeads, finalizedHeads := newTestHeadsSubscription(), newTestHeadsSubscription()
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- tracker.Watch(context.Background(), newHeads, finalizedHeads)
+	}()
+
+	newHeads.channel <- c.headers[1]
+	newHeads.channel <- c.headers[2]
+	finalizedHeads.channel <- c.headers[2]
+
+	assert.Equal(t, finalized(c, 2), receive(t, sub, 1))
+
+	assert.NoError(t, tracker.WaitForFinalization(context.Background(), c.hashes[1]))
+
+	finalizedHeads.err <- ErrHeaderRetrieval
+	assert.ErrorIs(t, <-done, ErrTrackerSubscribe)
+}
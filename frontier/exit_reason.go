@@ -0,0 +1,255 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontier
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ExitSucceed is the way an EVM execution succeeded.
+type ExitSucceed uint8
+
+const (
+	// ExitStopped is the execution of the STOP opcode or the end of the code.
+	ExitStopped ExitSucceed = iota
+	// ExitReturned is the execution of the RETURN opcode.
+	ExitReturned
+	// ExitSuicided is the execution of the SELFDESTRUCT opcode.
+	ExitSuicided
+)
+
+// Indices of the variants of evm_core::ExitError that hold data.
+const (
+	exitErrorOther       = 13
+	exitErrorInvalidCode = 15
+)
+
+// exitErrorNames are the names of the variants of evm_core::ExitError by their index.
+var exitErrorNames = map[uint8]string{
+	0:                    "StackUnderflow",
+	1:                    "StackOverflow",
+	2:                    "InvalidJump",
+	3:                    "InvalidRange",
+	4:                    "DesignatedInvalid",
+	5:                    "CallTooDeep",
+	6:                    "CreateCollision",
+	7:                    "CreateContractLimit",
+	8:                    "OutOfOffset",
+	9:                    "OutOfGas",
+	10:                   "OutOfFund",
+	11:                   "PCUnderflow",
+	12:                   "CreateEmpty",
+	exitErrorOther:       "Other",
+	14:                   "MaxNonce",
+	exitErrorInvalidCode: "InvalidCode",
+}
+
+// ExitError is the error an EVM execution failed with, e.g. OutOfGas.
+type ExitError struct {
+	// Index is the index of the variant of evm_core::ExitError.
+	Index uint8
+	// Opcode is the invalid opcode of an InvalidCode error.
+	Opcode types.U8
+	// Message is the message of an Other error.
+	Message string
+}
+
+func (e *ExitError) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := exitErrorNames[b]; !ok {
+		return fmt.Errorf("invalid exit error %d", b)
+	}
+
+	e.Index = b
+
+	switch b {
+	case exitErrorInvalidCode:
+		return decoder.Decode(&e.Opcode)
+	case exitErrorOther:
+		return decoder.Decode(&e.Message)
+	}
+
+	return nil
+}
+
+func (e ExitError) Encode(encoder scale.Encoder) error {
+	if err := encoder.PushByte(e.Index); err != nil {
+		return err
+	}
+
+	switch e.Index {
+	case exitErrorInvalidCode:
+		return encoder.Encode(e.Opcode)
+	case exitErrorOther:
+		return encoder.Encode(e.Message)
+	}
+
+	return nil
+}
+
+func (e ExitError) String() string {
+	switch e.Index {
+	case exitErrorInvalidCode:
+		return fmt.Sprintf("InvalidCode(%#x)", uint8(e.Opcode))
+	case exitErrorOther:
+		return fmt.Sprintf("Other(%s)", e.Message)
+	}
+
+	return exitErrorNames[e.Index]
+}
+
+// ExitFatal is the fatal error an EVM execution ended with.
+type ExitFatal struct {
+	IsNotSupported bool
+
+	IsUnhandledInterrupt bool
+
+	IsCallErrorAsFatal bool
+	AsCallErrorAsFatal ExitError
+
+	IsOther bool
+	AsOther string
+}
+
+func (f *ExitFatal) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		f.IsNotSupported = true
+	case 1:
+		f.IsUnhandledInterrupt = true
+	case 2:
+		f.IsCallErrorAsFatal = true
+
+		return decoder.Decode(&f.AsCallErrorAsFatal)
+	case 3:
+		f.IsOther = true
+
+		return decoder.Decode(&f.AsOther)
+	default:
+		return fmt.Errorf("invalid exit fatal %d", b)
+	}
+
+	return nil
+}
+
+func (f ExitFatal) Encode(encoder scale.Encoder) error {
+	switch {
+	case f.IsNotSupported:
+		return encoder.PushByte(0)
+	case f.IsUnhandledInterrupt:
+		return encoder.PushByte(1)
+	case f.IsCallErrorAsFatal:
+		if err := encoder.PushByte(2); err != nil {
+			return err
+		}
+
+		return encoder.Encode(f.AsCallErrorAsFatal)
+	case f.IsOther:
+		if err := encoder.PushByte(3); err != nil {
+			return err
+		}
+
+		return encoder.Encode(f.AsOther)
+	}
+
+	return nil
+}
+
+// ExitReason is the outcome of an EVM execution, see evm_core::ExitReason.
+type ExitReason struct {
+	IsSucceed bool
+	AsSucceed ExitSucceed
+
+	IsError bool
+	AsError ExitError
+
+	// IsRevert is set if the execution was reverted with the REVERT opcode.
+	IsRevert bool
+
+	IsFatal bool
+	AsFatal ExitFatal
+}
+
+func (r *ExitReason) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		r.IsSucceed = true
+
+		return decoder.Decode(&r.AsSucceed)
+	case 1:
+		r.IsError = true
+
+		return decoder.Decode(&r.AsError)
+	case 2:
+		r.IsRevert = true
+
+		// The only variant of ExitRevert is Reverted.
+		_, err := decoder.ReadOneByte()
+
+		return err
+	case 3:
+		r.IsFatal = true
+
+		return decoder.Decode(&r.AsFatal)
+	}
+
+	return fmt.Errorf("invalid exit reason %d", b)
+}
+
+func (r ExitReason) Encode(encoder scale.Encoder) error {
+	switch {
+	case r.IsSucceed:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(r.AsSucceed)
+	case r.IsError:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(r.AsError)
+	case r.IsRevert:
+		return encoder.Write([]byte{2, 0})
+	case r.IsFatal:
+		if err := encoder.PushByte(3); err != nil {
+			return err
+		}
+
+		return encoder.Encode(r.AsFatal)
+	}
+
+	return nil
+}
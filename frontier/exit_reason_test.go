@@ -0,0 +1,55 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontier
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitReason_EncodeDecode(t *testing.T) {
+	for _, reason := range []ExitReason{
+		{IsSucceed: true, AsSucceed: ExitReturned},
+		{IsError: true, AsError: ExitError{Index: 9}},
+		{IsError: true, AsError: ExitError{Index: exitErrorInvalidCode, Opcode: 0xfe}},
+		{IsError: true, AsError: ExitError{Index: exitErrorOther, Message: "boom"}},
+		{IsRevert: true},
+		{IsFatal: true, AsFatal: ExitFatal{IsNotSupported: true}},
+		{IsFatal: true, AsFatal: ExitFatal{IsCallErrorAsFatal: true, AsCallErrorAsFatal: ExitError{Index: 5}}},
+		{IsFatal: true, AsFatal: ExitFatal{IsOther: true, AsOther: "boom"}},
+	} {
+		assertRoundTrip(t, reason)
+	}
+
+	// Reverted with the only variant of ExitRevert.
+	var reason ExitReason
+	require.NoError(t, codec.Decode([]byte{2, 0}, &reason))
+	assert.Equal(t, ExitReason{IsRevert: true}, reason)
+
+	assert.Error(t, codec.Decode([]byte{4}, &reason))
+	assert.Error(t, codec.Decode([]byte{1, 16}, &reason))
+	assert.Error(t, codec.Decode([]byte{3, 4}, &reason))
+}
+
+func TestExitError_String(t *testing.T) {
+	assert.Equal(t, "OutOfGas", ExitError{Index: 9}.String())
+	assert.Equal(t, "InvalidCode(0xfe)", ExitError{Index: exitErrorInvalidCode, Opcode: 0xfe}.String())
+	assert.Equal(t, "Other(boom)", ExitError{Index: exitErrorOther, Message: "boom"}.String())
+}
@@ -0,0 +1,221 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontier
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	transactCall  = "Ethereum.transact"
+	executedEvent = "Ethereum.Executed"
+	logEvent      = "EVM.Log"
+)
+
+// Log is an EVM log, as emitted by the EVM.Log event.
+type Log struct {
+	Address types.H160
+	Topics  []types.Hash
+	Data    types.Bytes
+}
+
+// ExecutedEvent holds the fields of the Ethereum.Executed event, emitted when an Ethereum transaction was executed.
+type ExecutedEvent struct {
+	From types.H160
+	// To is the called address, or the address of the created contract.
+	To              types.H160
+	TransactionHash types.Hash
+	ExitReason      ExitReason
+	// ExtraData is the revert reason of reverted transactions, it is only emitted by newer runtimes.
+	ExtraData types.Bytes
+}
+
+// Receipt is the receipt of an Ethereum transaction, derived from the events of its extrinsic.
+type Receipt struct {
+	// ExtrinsicIndex is the index of the Ethereum.transact extrinsic in the block.
+	ExtrinsicIndex uint32
+	// TransactionIndex is the index of the transaction among the Ethereum transactions of the block.
+	TransactionIndex uint32
+	ExecutedEvent
+	// Logs are the logs emitted by the transaction, in the order they were emitted.
+	Logs []Log
+}
+
+// IsSuccess returns true if the transaction succeeded.
+func (r Receipt) IsSuccess() bool {
+	return r.ExitReason.IsSucceed
+}
+
+// DecodeLog decodes the EVM.Log event.
+func DecodeLog(event *parser.Event) (*Log, error) {
+	var log Log
+
+	if err := codec.Decode(event.Data, &log); err != nil {
+		return nil, ErrEventDecoding.WithMsg(event.Name).Wrap(err)
+	}
+
+	return &log, nil
+}
+
+// DecodeReceipts returns the receipts of the Ethereum transactions of a block from its events, e.g. the events
+// retrieved with the event retriever of the registry. The receipts are derived from the Ethereum.Executed events and
+// hold the EVM.Log events emitted by the same extrinsic. Logs of extrinsics without an Ethereum transaction, e.g. of
+// EVM.call, are not part of any receipt.
+func DecodeReceipts(events []*parser.Event) ([]Receipt, error) {
+	var (
+		receipts []Receipt
+		logs     = map[uint32][]Log{}
+	)
+
+	for _, event := range events {
+		if event.Phase == nil || !event.Phase.IsApplyExtrinsic {
+			continue
+		}
+
+		extrinsicIndex := event.Phase.AsApplyExtrinsic
+
+		switch event.Name {
+		case logEvent:
+			log, err := DecodeLog(event)
+			if err != nil {
+				return nil, err
+			}
+
+			logs[extrinsicIndex] = append(logs[extrinsicIndex], *log)
+		case executedEvent:
+			executed, err := decodeExecuted(event)
+			if err != nil {
+				return nil, err
+			}
+
+			receipts = append(receipts, Receipt{
+				ExtrinsicIndex:   extrinsicIndex,
+				TransactionIndex: uint32(len(receipts)),
+				ExecutedEvent:    *executed,
+			})
+		}
+	}
+
+	for i := range receipts {
+		receipts[i].Logs = logs[receipts[i].ExtrinsicIndex]
+	}
+
+	return receipts, nil
+}
+
+// decodeExecuted decodes the Ethereum.Executed event, with the extra data of newer runtimes if present.
+func decodeExecuted(event *parser.Event) (*ExecutedEvent, error) {
+	reader := bytes.NewReader(event.Data)
+	decoder := scale.NewDecoder(reader)
+
+	var executed ExecutedEvent
+
+	for _, field := range []any{&executed.From, &executed.To, &executed.TransactionHash, &executed.ExitReason} {
+		if err := decoder.Decode(field); err != nil {
+			return nil, ErrEventDecoding.WithMsg(event.Name).Wrap(err)
+		}
+	}
+
+	if reader.Len() > 0 {
+		if err := decoder.Decode(&executed.ExtraData); err != nil {
+			return nil, ErrEventDecoding.WithMsg(event.Name).Wrap(err)
+		}
+	}
+
+	if reader.Len() > 0 {
+		return nil, ErrEventDecoding.WithMsg("%s has %d trailing bytes", event.Name, reader.Len())
+	}
+
+	return &executed, nil
+}
+
+// DecodeTransact decodes the Ethereum transaction of the Ethereum.transact call, e.g. the call of an extrinsic of a
+// block. ErrNotTransact is returned for other calls.
+func DecodeTransact(meta *types.Metadata, call types.Call) (*TransactionV2, error) {
+	callIndex, err := meta.FindCallIndex(transactCall)
+	if err != nil {
+		return nil, ErrTransactCallNotFound.Wrap(err)
+	}
+
+	if call.CallIndex != callIndex {
+		return nil, ErrNotTransact
+	}
+
+	var tx TransactionV2
+
+	if err := codec.Decode(call.Args, &tx); err != nil {
+		return nil, ErrTransactionDecoding.Wrap(err)
+	}
+
+	return &tx, nil
+}
+
+// BlockTransaction is an Ethereum transaction of a block.
+type BlockTransaction struct {
+	// ExtrinsicIndex is the index of the Ethereum.transact extrinsic in the block.
+	ExtrinsicIndex uint32
+	Hash           types.Hash
+	Transaction    TransactionV2
+	// Receipt is the receipt of the transaction, nil if the block has no Ethereum.Executed event for the extrinsic.
+	Receipt *Receipt
+}
+
+// DecodeBlockTransactions returns the Ethereum transactions of a block with their receipts. The calls are the calls of
+// the extrinsics of the block in their order, the events are the events of the block, see DecodeReceipts.
+func DecodeBlockTransactions(
+	meta *types.Metadata,
+	calls []types.Call,
+	events []*parser.Event,
+) ([]BlockTransaction, error) {
+	receipts, err := DecodeReceipts(events)
+	if err != nil {
+		return nil, err
+	}
+
+	receiptsByExtrinsic := make(map[uint32]*Receipt, len(receipts))
+	for i := range receipts {
+		receiptsByExtrinsic[receipts[i].ExtrinsicIndex] = &receipts[i]
+	}
+
+	var transactions []BlockTransaction
+
+	for i, call := range calls {
+		tx, err := DecodeTransact(meta, call)
+		if errors.Is(err, ErrNotTransact) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		transactions = append(transactions, BlockTransaction{
+			ExtrinsicIndex: uint32(i),
+			Hash:           tx.Hash(),
+			Transaction:    *tx,
+			Receipt:        receiptsByExtrinsic[uint32(i)],
+		})
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,154 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontier
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func applyExtrinsic(i uint32) *types.Phase {
+	return &types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: i}
+}
+
+// executedEventV1 is the Ethereum.Executed event of runtimes without the extra data.
+type executedEventV1 struct {
+	From            types.H160
+	To              types.H160
+	TransactionHash types.Hash
+	ExitReason      ExitReason
+}
+
+func TestDecodeReceipts(t *testing.T) {
+	log1 := Log{Address: types.H160{1}, Topics: []types.Hash{{1}, {2}}, Data: types.Bytes{1, 2, 3}}
+	log2 := Log{Address: types.H160{2}, Topics: []types.Hash{{3}}}
+	evmCallLog := Log{Address: types.H160{3}}
+
+	succeeded := executedEventV1{
+		From:            types.H160{9},
+		To:              types.H160{1},
+		TransactionHash: types.Hash{1},
+		ExitReason:      ExitReason{IsSucceed: true, AsSucceed: ExitStopped},
+	}
+	reverted := ExecutedEvent{
+		From:            types.H160{9},
+		To:              types.H160{2},
+		TransactionHash: types.Hash{2},
+		ExitReason:      ExitReason{IsRevert: true},
+		ExtraData:       types.Bytes{0x08, 0xc3, 0x79, 0xa0},
+	}
+
+	receipts, err := DecodeReceipts([]*parser.Event{
+		{Name: "ParachainSystem.ValidationFunctionStored", Phase: &types.Phase{IsInitialization: true}},
+		{Name: logEvent, Phase: applyExtrinsic(1), Data: statetest.Encode(t, log1)},
+		{Name: logEvent, Phase: applyExtrinsic(1), Data: statetest.Encode(t, log2)},
+		{Name: executedEvent, Phase: applyExtrinsic(1), Data: statetest.Encode(t, succeeded)},
+		{Name: logEvent, Phase: applyExtrinsic(2), Data: statetest.Encode(t, evmCallLog)},
+		{Name: executedEvent, Phase: applyExtrinsic(3), Data: statetest.Encode(t, reverted)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Receipt{
+		{
+			ExtrinsicIndex: 1,
+			ExecutedEvent: ExecutedEvent{
+				From:            succeeded.From,
+				To:              succeeded.To,
+				TransactionHash: succeeded.TransactionHash,
+				ExitReason:      succeeded.ExitReason,
+			},
+			Logs: []Log{log1, log2},
+		},
+		{ExtrinsicIndex: 3, TransactionIndex: 1, ExecutedEvent: reverted},
+	}, receipts)
+	assert.True(t, receipts[0].IsSuccess())
+	assert.False(t, receipts[1].IsSuccess())
+
+	_, err = DecodeReceipts([]*parser.Event{{Name: logEvent, Phase: applyExtrinsic(1), Data: []byte{1}}})
+	assert.ErrorIs(t, err, ErrEventDecoding)
+
+	_, err = DecodeReceipts([]*parser.Event{
+		{Name: executedEvent, Phase: applyExtrinsic(1), Data: append(statetest.Encode(t, reverted), 0)},
+	})
+	assert.ErrorIs(t, err, ErrEventDecoding)
+}
+
+func TestDecodeBlockTransactions(t *testing.T) {
+	meta := statetest.DecodeMetadata(t, test.MoonbeamMetaHex)
+	legacy := newTestLegacyTransaction(t)
+	eip1559 := newTestEIP1559Transaction(t)
+
+	var calls []types.Call
+
+	for _, call := range []struct {
+		name string
+		args []any
+	}{
+		{"Timestamp.set", []any{types.NewUCompactFromUInt(1000)}},
+		{transactCall, []any{legacy}},
+		{transactCall, []any{eip1559}},
+	} {
+		c, err := types.NewCall(meta, call.name, call.args...)
+		require.NoError(t, err)
+
+		calls = append(calls, c)
+	}
+
+	executed := ExecutedEvent{
+		From:            types.H160{9},
+		To:              legacy.Action().AsCall,
+		TransactionHash: legacy.Hash(),
+		ExitReason:      ExitReason{IsSucceed: true, AsSucceed: ExitStopped},
+	}
+
+	transactions, err := DecodeBlockTransactions(meta, calls, []*parser.Event{
+		{Name: executedEvent, Phase: applyExtrinsic(1), Data: statetest.Encode(t, executed)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []BlockTransaction{
+		{
+			ExtrinsicIndex: 1,
+			Hash:           legacy.Hash(),
+			Transaction:    legacy,
+			Receipt:        &Receipt{ExtrinsicIndex: 1, ExecutedEvent: executed},
+		},
+		{ExtrinsicIndex: 2, Hash: eip1559.Hash(), Transaction: eip1559},
+	}, transactions)
+
+	_, err = DecodeTransact(meta, calls[0])
+	assert.ErrorIs(t, err, ErrNotTransact)
+
+	_, err = DecodeTransact(meta, types.Call{CallIndex: calls[1].CallIndex, Args: types.Args{3}})
+	assert.ErrorIs(t, err, ErrTransactionDecoding)
+
+	_, err = DecodeTransact(getTestPolkadotMetadata(t), calls[1])
+	assert.ErrorIs(t, err, ErrTransactCallNotFound)
+}
+
+func getTestPolkadotMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	require.NoError(t, codec.DecodeFromHex(test.PolkadotMetadataHex, &meta))
+
+	return &meta
+}
@@ -0,0 +1,178 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontier
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"golang.org/x/crypto/sha3"
+)
+
+// Hash returns the Ethereum hash of the transaction, the keccak-256 hash of its Ethereum encoding. It is the hash
+// of the transaction in the Ethereum.Executed event and in the eth_* RPC of the chain.
+func (t TransactionV2) Hash() types.Hash {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(t.MarshalBinary())
+
+	var hash types.Hash
+
+	copy(hash[:], hasher.Sum(nil))
+
+	return hash
+}
+
+// MarshalBinary returns the Ethereum encoding of the transaction: the RLP encoded fields for legacy transactions, the
+// type followed by the RLP encoded fields for typed transactions, see EIP-2718.
+func (t TransactionV2) MarshalBinary() []byte {
+	switch {
+	case t.IsLegacy:
+		tx := t.AsLegacy
+
+		return rlpList(
+			rlpU256(tx.Nonce),
+			rlpU256(tx.GasPrice),
+			rlpU256(tx.GasLimit),
+			rlpAction(tx.Action),
+			rlpU256(tx.Value),
+			rlpBytes(tx.Input),
+			rlpUint(uint64(tx.Signature.V)),
+			rlpHash(tx.Signature.R),
+			rlpHash(tx.Signature.S),
+		)
+	case t.IsEIP2930:
+		tx := t.AsEIP2930
+
+		return append([]byte{1}, rlpList(
+			rlpUint(uint64(tx.ChainID)),
+			rlpU256(tx.Nonce),
+			rlpU256(tx.GasPrice),
+			rlpU256(tx.GasLimit),
+			rlpAction(tx.Action),
+			rlpU256(tx.Value),
+			rlpBytes(tx.Input),
+			rlpAccessList(tx.AccessList),
+			rlpBool(tx.OddYParity),
+			rlpHash(tx.R),
+			rlpHash(tx.S),
+		)...)
+	case t.IsEIP1559:
+		tx := t.AsEIP1559
+
+		return append([]byte{2}, rlpList(
+			rlpUint(uint64(tx.ChainID)),
+			rlpU256(tx.Nonce),
+			rlpU256(tx.MaxPriorityFeePerGas),
+			rlpU256(tx.MaxFeePerGas),
+			rlpU256(tx.GasLimit),
+			rlpAction(tx.Action),
+			rlpU256(tx.Value),
+			rlpBytes(tx.Input),
+			rlpAccessList(tx.AccessList),
+			rlpBool(tx.OddYParity),
+			rlpHash(tx.R),
+			rlpHash(tx.S),
+		)...)
+	}
+
+	return nil
+}
+
+// rlpBytes encodes the byte string, single bytes below 0x80 are their own encoding.
+func rlpBytes(b []byte) []byte {
+	if len(b) == 1 && b[0] < 0x80 {
+		return []byte{b[0]}
+	}
+
+	return append(rlpLength(len(b), 0x80), b...)
+}
+
+// rlpList encodes the list of the encoded items.
+func rlpList(items ...[]byte) []byte {
+	var payload []byte
+
+	for _, item := range items {
+		payload = append(payload, item...)
+	}
+
+	return append(rlpLength(len(payload), 0xc0), payload...)
+}
+
+// rlpLength encodes the length of a string or list with the given offset, 0x80 for strings and 0xc0 for lists.
+func rlpLength(n int, offset byte) []byte {
+	if n <= 55 {
+		return []byte{offset + byte(n)}
+	}
+
+	length := new(big.Int).SetUint64(uint64(n)).Bytes()
+
+	return append([]byte{offset + 55 + byte(len(length))}, length...)
+}
+
+// rlpBigInt encodes the integer as big endian bytes without leading zeros, zero is the empty string.
+func rlpBigInt(i *big.Int) []byte {
+	if i == nil {
+		return rlpBytes(nil)
+	}
+
+	return rlpBytes(i.Bytes())
+}
+
+func rlpUint(i uint64) []byte {
+	return rlpBigInt(new(big.Int).SetUint64(i))
+}
+
+func rlpU256(i types.U256) []byte {
+	return rlpBigInt(i.Int)
+}
+
+func rlpBool(b bool) []byte {
+	if b {
+		return rlpUint(1)
+	}
+
+	return rlpUint(0)
+}
+
+// rlpHash encodes the signature values R and S, which are integers.
+func rlpHash(h types.Hash) []byte {
+	return rlpBigInt(new(big.Int).SetBytes(h[:]))
+}
+
+// rlpAction encodes the recipient, the empty string for contract creations.
+func rlpAction(a TransactionAction) []byte {
+	if a.IsCall {
+		return rlpBytes(a.AsCall[:])
+	}
+
+	return rlpBytes(nil)
+}
+
+func rlpAccessList(accessList []AccessListItem) []byte {
+	items := make([][]byte, 0, len(accessList))
+
+	for _, item := range accessList {
+		keys := make([][]byte, 0, len(item.StorageKeys))
+		for _, key := range item.StorageKeys {
+			keys = append(keys, rlpBytes(key[:]))
+		}
+
+		items = append(items, rlpList(rlpBytes(item.Address[:]), rlpList(keys...)))
+	}
+
+	return rlpList(items...)
+}
@@ -0,0 +1,127 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontier
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func u256(i int64) types.U256 {
+	return types.NewU256(*big.NewInt(i))
+}
+
+func hexBytes(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+
+	return b
+}
+
+func hashOf(t *testing.T, s string) types.Hash {
+	return types.NewHash(hexBytes(t, s))
+}
+
+// newTestLegacyTransaction returns the example transaction of EIP-155.
+func newTestLegacyTransaction(t *testing.T) TransactionV2 {
+	value, _ := new(big.Int).SetString("1000000000000000000", 10)
+
+	return TransactionV2{
+		IsLegacy: true,
+		AsLegacy: LegacyTransaction{
+			Nonce:    u256(9),
+			GasPrice: u256(20_000_000_000),
+			GasLimit: u256(21000),
+			Action: TransactionAction{
+				IsCall: true,
+				AsCall: types.NewH160(hexBytes(t, "3535353535353535353535353535353535353535")),
+			},
+			Value: types.NewU256(*value),
+			Signature: TransactionSignature{
+				V: 37,
+				R: hashOf(t, "28ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276"),
+				S: hashOf(t, "67cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"),
+			},
+		},
+	}
+}
+
+func TestTransactionV2_MarshalBinary(t *testing.T) {
+	tx := newTestLegacyTransaction(t)
+
+	assert.Equal(t, hexBytes(t, "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a7640000"+
+		"8025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3"+
+		"dc64214b297fb1966a3b6d83"), tx.MarshalBinary())
+	assert.Equal(t, "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788", tx.Hash().Hex())
+}
+
+func newTestEIP1559Transaction(t *testing.T) TransactionV2 {
+	return TransactionV2{
+		IsEIP1559: true,
+		AsEIP1559: EIP1559Transaction{
+			ChainID:              1,
+			Nonce:                u256(0),
+			MaxPriorityFeePerGas: u256(1),
+			MaxFeePerGas:         u256(2),
+			GasLimit:             u256(21000),
+			Action:               TransactionAction{IsCall: true, AsCall: types.NewH160(hexBytes(t, strings.Repeat("35", 20)))},
+			Value:                u256(0),
+			R:                    types.Hash{31: 1},
+			S:                    types.Hash{31: 2},
+		},
+	}
+}
+
+func TestTransactionV2_MarshalBinary_Typed(t *testing.T) {
+	// The type is followed by the list of the chain ID, the nonce, the fees, the gas limit, the recipient, the value,
+	// the input, the access list, the y parity and the signature values.
+	tx := newTestEIP1559Transaction(t)
+	expected := hexBytes(t, "02e2018001028252089435353535353535353535353535353535353535358080c0800102")
+	assert.Equal(t, expected, tx.MarshalBinary())
+
+	accessList := []AccessListItem{{Address: tx.AsEIP1559.Action.AsCall, StorageKeys: []types.Hash{{1}}}}
+
+	tx = TransactionV2{
+		IsEIP2930: true,
+		AsEIP2930: EIP2930Transaction{
+			ChainID:    1,
+			Nonce:      u256(1),
+			GasPrice:   u256(1),
+			GasLimit:   u256(1),
+			Action:     TransactionAction{IsCreate: true},
+			Value:      u256(0),
+			Input:      types.Bytes{0x60, 0x80},
+			AccessList: accessList,
+			OddYParity: true,
+		},
+	}
+
+	// The access list exceeds 55 bytes, so its length is prefixed with the length of the length.
+	encodedAccessList := rlpAccessList(accessList)
+	assert.Equal(t, []byte{0xf8, 56, 0xf7, 0x94}, encodedAccessList[:4])
+	assert.Len(t, encodedAccessList, 58)
+
+	encoded := tx.MarshalBinary()
+	assert.Equal(t, []byte{1, 0xf8, 70, 1, 1, 1, 1, 0x80, 0x80, 0x82, 0x60, 0x80}, encoded[:12])
+	assert.Equal(t, []byte{1, 0x80, 0x80}, encoded[len(encoded)-3:])
+}
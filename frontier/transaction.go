@@ -0,0 +1,208 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontier
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// TransactionAction is the recipient of a transaction, either the called address or the creation of a contract.
+type TransactionAction struct {
+	IsCall bool
+	AsCall types.H160
+
+	IsCreate bool
+}
+
+func (a *TransactionAction) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		a.IsCall = true
+
+		return decoder.Decode(&a.AsCall)
+	case 1:
+		a.IsCreate = true
+	default:
+		return fmt.Errorf("invalid transaction action %d", b)
+	}
+
+	return nil
+}
+
+func (a TransactionAction) Encode(encoder scale.Encoder) error {
+	switch {
+	case a.IsCall:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.AsCall)
+	case a.IsCreate:
+		return encoder.PushByte(1)
+	}
+
+	return nil
+}
+
+// AccessListItem is an address with the storage keys a transaction accesses, see EIP-2930.
+type AccessListItem struct {
+	Address     types.H160
+	StorageKeys []types.Hash
+}
+
+// TransactionSignature is the signature of a legacy transaction. V holds the recovery ID and, for EIP-155
+// transactions, the chain ID.
+type TransactionSignature struct {
+	V types.U64
+	R types.Hash
+	S types.Hash
+}
+
+// LegacyTransaction is an Ethereum transaction from before EIP-2718 typed transactions.
+type LegacyTransaction struct {
+	Nonce     types.U256
+	GasPrice  types.U256
+	GasLimit  types.U256
+	Action    TransactionAction
+	Value     types.U256
+	Input     types.Bytes
+	Signature TransactionSignature
+}
+
+// EIP2930Transaction is an Ethereum transaction with an access list, see EIP-2930.
+type EIP2930Transaction struct {
+	ChainID    types.U64
+	Nonce      types.U256
+	GasPrice   types.U256
+	GasLimit   types.U256
+	Action     TransactionAction
+	Value      types.U256
+	Input      types.Bytes
+	AccessList []AccessListItem
+	OddYParity bool
+	R          types.Hash
+	S          types.Hash
+}
+
+// EIP1559Transaction is an Ethereum transaction with a priority fee, see EIP-1559.
+type EIP1559Transaction struct {
+	ChainID              types.U64
+	Nonce                types.U256
+	MaxPriorityFeePerGas types.U256
+	MaxFeePerGas         types.U256
+	GasLimit             types.U256
+	Action               TransactionAction
+	Value                types.U256
+	Input                types.Bytes
+	AccessList           []AccessListItem
+	OddYParity           bool
+	R                    types.Hash
+	S                    types.Hash
+}
+
+// TransactionV2 is the Ethereum transaction of the Ethereum.transact call of Frontier based chains.
+type TransactionV2 struct {
+	IsLegacy bool
+	AsLegacy LegacyTransaction
+
+	IsEIP2930 bool
+	AsEIP2930 EIP2930Transaction
+
+	IsEIP1559 bool
+	AsEIP1559 EIP1559Transaction
+}
+
+func (t *TransactionV2) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		t.IsLegacy = true
+
+		return decoder.Decode(&t.AsLegacy)
+	case 1:
+		t.IsEIP2930 = true
+
+		return decoder.Decode(&t.AsEIP2930)
+	case 2:
+		t.IsEIP1559 = true
+
+		return decoder.Decode(&t.AsEIP1559)
+	}
+
+	return fmt.Errorf("invalid transaction type %d", b)
+}
+
+func (t TransactionV2) Encode(encoder scale.Encoder) error {
+	switch {
+	case t.IsLegacy:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(t.AsLegacy)
+	case t.IsEIP2930:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(t.AsEIP2930)
+	case t.IsEIP1559:
+		if err := encoder.PushByte(2); err != nil {
+			return err
+		}
+
+		return encoder.Encode(t.AsEIP1559)
+	}
+
+	return nil
+}
+
+// Action returns the recipient of the transaction.
+func (t TransactionV2) Action() TransactionAction {
+	switch {
+	case t.IsLegacy:
+		return t.AsLegacy.Action
+	case t.IsEIP2930:
+		return t.AsEIP2930.Action
+	}
+
+	return t.AsEIP1559.Action
+}
+
+// Input returns the call data of the transaction, or the init code of a contract creation.
+func (t TransactionV2) Input() []byte {
+	switch {
+	case t.IsLegacy:
+		return t.AsLegacy.Input
+	case t.IsEIP2930:
+		return t.AsEIP2930.Input
+	}
+
+	return t.AsEIP1559.Input
+}
@@ -0,0 +1,71 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontier
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertRoundTrip[T any](t *testing.T, value T) {
+	var decoded T
+	require.NoError(t, codec.Decode(statetest.Encode(t, value), &decoded))
+	assert.Equal(t, value, decoded)
+}
+
+func TestTransactionV2_EncodeDecode(t *testing.T) {
+	eip2930 := TransactionV2{
+		IsEIP2930: true,
+		AsEIP2930: EIP2930Transaction{
+			ChainID:  1284,
+			Nonce:    u256(1),
+			GasPrice: u256(100),
+			GasLimit: u256(50000),
+			Action:   TransactionAction{IsCreate: true},
+			Value:    u256(0),
+			Input:    types.Bytes{0x60, 0x80},
+			AccessList: []AccessListItem{
+				{Address: types.H160{1}, StorageKeys: []types.Hash{{1}, {2}}},
+			},
+			OddYParity: true,
+			R:          types.Hash{3},
+			S:          types.Hash{4},
+		},
+	}
+
+	for _, tx := range []TransactionV2{newTestLegacyTransaction(t), eip2930, newTestEIP1559Transaction(t)} {
+		assertRoundTrip(t, tx)
+	}
+
+	// The nonce of the legacy transaction is encoded as a little endian U256.
+	encoded := statetest.Encode(t, newTestLegacyTransaction(t))
+	assert.Equal(t, append([]byte{0, 9}, make([]byte, 31)...), encoded[:33])
+
+	assert.Equal(t, TransactionAction{IsCreate: true}, eip2930.Action())
+	assert.Equal(t, []byte{0x60, 0x80}, eip2930.Input())
+
+	var tx TransactionV2
+	assert.Error(t, codec.Decode([]byte{3}, &tx))
+
+	var action TransactionAction
+	assert.Error(t, codec.Decode([]byte{2}, &action))
+}
@@ -80,6 +80,9 @@ type Client struct {
 
 	idCounter uint32
 
+	// lastPing holds the unix nano time of the last websocket ping of the server.
+	lastPing *atomic.Int64
+
 	// This function, if non-nil, is called when the connection is lost.
 	reconnectFunc reconnectFunc
 
@@ -93,6 +96,7 @@ type Client struct {
 	closing     chan struct{}    // closed when client is quitting
 	didClose    chan struct{}    // closed when client quits
 	reconnected chan ServerCodec // where write/reconnect sends the new connection
+	connLost    chan struct{}    // closed when the read loop fails for the first time
 	readOp      chan readOp      // read messages
 	readErr     chan error       // errors from read
 	reqInit     chan *requestOp  // register response IDs, takes write lock
@@ -167,15 +171,29 @@ func Dial(rawurl string) (*Client, error) {
 // The context is used to cancel or time out the initial connection establishment. It does
 // not affect subsequent interactions with the client.
 func DialContext(ctx context.Context, rawurl string) (*Client, error) {
+	return DialOptions(ctx, rawurl)
+}
+
+// DialOptions creates a new RPC client for the given URL. You can supply any of the
+// pre-defined client options to configure the underlying transport. The options are
+// ignored for IPC and stdio connections.
+//
+// The context is used to cancel or time out the initial connection establishment. It does
+// not affect subsequent interactions with the client.
+func DialOptions(ctx context.Context, rawurl string, options ...ClientOption) (*Client, error) {
 	u, err := url.Parse(rawurl)
 	if err != nil {
 		return nil, err
 	}
+	cfg := new(clientConfig)
+	for _, opt := range options {
+		opt.applyOption(cfg)
+	}
 	switch u.Scheme {
 	case "http", "https":
-		return DialHTTP(rawurl)
+		return dialHTTP(rawurl, cfg)
 	case "ws", "wss":
-		return DialWebsocket(ctx, rawurl, "")
+		return dialWebsocket(ctx, rawurl, "", cfg)
 	case "stdio":
 		return DialStdIO(ctx)
 	case "":
@@ -208,11 +226,13 @@ func initClient(conn ServerCodec, idgen func() ID, services *serviceRegistry) *C
 		idgen:       idgen,
 		isHTTP:      isHTTP,
 		services:    services,
+		lastPing:    new(atomic.Int64),
 		writeConn:   conn,
 		close:       make(chan struct{}),
 		closing:     make(chan struct{}),
 		didClose:    make(chan struct{}),
 		reconnected: make(chan ServerCodec),
+		connLost:    make(chan struct{}),
 		readOp:      make(chan readOp),
 		readErr:     make(chan error),
 		reqInit:     make(chan *requestOp),
@@ -248,6 +268,21 @@ func (c *Client) SupportedModules() (map[string]string, error) {
 	return result, err
 }
 
+// ConnectionLost returns a channel that is closed once the connection of the client is lost for the first time,
+// regardless of whether the client reconnects afterwards. It is never closed for HTTP clients or by Close.
+func (c *Client) ConnectionLost() <-chan struct{} {
+	return c.connLost
+}
+
+// LastPing returns the time the server last sent a websocket ping, or the zero time if it never did.
+func (c *Client) LastPing() time.Time {
+	ns := c.lastPing.Load()
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
 // Close closes the client, aborting any in-flight requests.
 func (c *Client) Close() {
 	if c.isHTTP {
@@ -571,6 +606,11 @@ func (c *Client) dispatch(codec ServerCodec) {
 
 		case err := <-c.readErr:
 			conn.handler.log.Debug("RPC connection read error", "err", err)
+			select {
+			case <-c.connLost:
+			default:
+				close(c.connLost)
+			}
 			conn.close(err, lastOp)
 			reading = false
 
@@ -629,6 +669,9 @@ func (c *Client) drainRead() {
 
 // read decodes RPC messages from a codec, feeding them into dispatch.
 func (c *Client) read(codec ServerCodec) {
+	if wc, ok := codec.(*websocketCodec); ok {
+		wc.onPing(func() { c.lastPing.Store(time.Now().UnixNano()) })
+	}
 	for {
 		msgs, batch, err := codec.Read()
 		if _, ok := err.(*json.SyntaxError); ok {
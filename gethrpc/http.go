@@ -107,24 +107,36 @@ var DefaultHTTPTimeouts = HTTPTimeouts{
 // DialHTTPWithClient creates a new RPC client that connects to an RPC server over HTTP
 // using the provided HTTP Client.
 func DialHTTPWithClient(endpoint string, client *http.Client) (*Client, error) {
+	return dialHTTP(endpoint, &clientConfig{httpClient: client})
+}
+
+// DialHTTP creates a new RPC client that connects to an RPC server over HTTP.
+func DialHTTP(endpoint string) (*Client, error) {
+	return dialHTTP(endpoint, new(clientConfig))
+}
+
+func dialHTTP(endpoint string, cfg *clientConfig) (*Client, error) {
 	req, err := http.NewRequest(http.MethodPost, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
+	for k, vs := range cfg.httpHeaders {
+		req.Header[k] = vs
+	}
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", contentType)
 
+	client := cfg.httpClient
+	if client == nil {
+		client = new(http.Client)
+	}
+
 	initctx := context.Background()
 	return newClient(initctx, func(context.Context) (ServerCodec, error) {
 		return &httpConn{client: client, req: req, closed: make(chan interface{})}, nil
 	})
 }
 
-// DialHTTP creates a new RPC client that connects to an RPC server over HTTP.
-func DialHTTP(endpoint string) (*Client, error) {
-	return DialHTTPWithClient(endpoint, new(http.Client))
-}
-
 func (c *Client) sendHTTP(ctx context.Context, op *requestOp, msg interface{}) error {
 	hc := c.writeConn.(*httpConn)
 	respBody, err := hc.doRequest(ctx, msg)
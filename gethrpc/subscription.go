@@ -210,6 +210,11 @@ type ClientSubscription struct {
 	notificationMethodSuffix string
 	subid                    string
 	in                       chan json.RawMessage
+	gap                      chan struct{}
+
+	// idFunc and unsubscribeFunc are only set for detached subscriptions, see NewDetachedClientSubscription.
+	idFunc          func() string
+	unsubscribeFunc func()
 
 	quitOnce sync.Once     // ensures quit is closed once
 	quit     chan struct{} // quit is closed when the subscription exits
@@ -230,10 +235,25 @@ func newClientSubscription(c *Client, namespace, subscribeMethodSuffix, unsubscr
 		quit:                     make(chan struct{}),
 		err:                      make(chan error, 1),
 		in:                       make(chan json.RawMessage),
+		gap:                      make(chan struct{}, 1),
 	}
 	return sub
 }
 
+// NewDetachedClientSubscription creates a subscription that is not bound to a single server side subscription.
+// It is meant for clients that feed the notifications of changing server side subscriptions, e.g. across
+// reconnects, into one subscriber channel. id is used to answer ID and unsubscribe is called once when the
+// subscription is unsubscribed.
+func NewDetachedClientSubscription(id func() string, unsubscribe func()) *ClientSubscription {
+	return &ClientSubscription{
+		quit:            make(chan struct{}),
+		err:             make(chan error, 1),
+		gap:             make(chan struct{}, 1),
+		idFunc:          id,
+		unsubscribeFunc: unsubscribe,
+	}
+}
+
 // Err returns the subscription error channel. The intended use of Err is to schedule
 // resubscription when the client connection is closed unexpectedly.
 //
@@ -246,6 +266,41 @@ func (sub *ClientSubscription) Err() <-chan error {
 	return sub.err
 }
 
+// ID returns the subscription ID assigned by the server.
+func (sub *ClientSubscription) ID() string {
+	if sub.idFunc != nil {
+		return sub.idFunc()
+	}
+	return sub.subid
+}
+
+// Gap returns a channel that receives a value when notifications might have been missed, e.g. because the
+// subscription had to be re-established after the connection was lost. Subscribers should reconcile the data they
+// missed once they receive from it. Pending gaps are coalesced, gaps are signaled by subscriptions created through
+// NewDetachedClientSubscription and via SignalGap, e.g. when notifications were dropped.
+func (sub *ClientSubscription) Gap() <-chan struct{} {
+	return sub.gap
+}
+
+// SignalGap marks that notifications might have been missed, see Gap. It never blocks.
+func (sub *ClientSubscription) SignalGap() {
+	select {
+	case sub.gap <- struct{}{}:
+	default:
+	}
+}
+
+// Fail ends the subscription with the given error, which is then received from the error channel.
+func (sub *ClientSubscription) Fail(err error) {
+	sub.quitWithError(err, false)
+}
+
+// Done returns a channel that is closed when the subscription exits, either because
+// Unsubscribe was called or because of an error.
+func (sub *ClientSubscription) Done() <-chan struct{} {
+	return sub.quit
+}
+
 // Unsubscribe unsubscribes the notification and closes the error channel.
 // It can safely be called more than once.
 func (sub *ClientSubscription) Unsubscribe() {
@@ -330,6 +385,10 @@ func (sub *ClientSubscription) unmarshal(result json.RawMessage) (interface{}, e
 }
 
 func (sub *ClientSubscription) requestUnsubscribe() error {
+	if sub.unsubscribeFunc != nil {
+		sub.unsubscribeFunc()
+		return nil
+	}
 	var result interface{}
 	return sub.client.Call(&result, sub.namespace+"_"+sub.unsubscribeMethodSuffix, sub.subid)
 }
@@ -20,11 +20,14 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/ethereum/go-ethereum/log"
@@ -32,8 +35,10 @@ import (
 )
 
 const (
-	wsReadBuffer  = 1024
-	wsWriteBuffer = 1024
+	wsReadBuffer          = 1024
+	wsWriteBuffer         = 1024
+	wsControlWriteTimeout = time.Second
+	wsDefaultReadLimit    = 32 * 1024 * 1024
 )
 
 var wsBufferPool = new(sync.Pool)
@@ -62,7 +67,7 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			log.Debug("WebSocket upgrade failed", "err", err)
 			return
 		}
-		codec := newWebsocketCodec(conn)
+		codec := newWebsocketCodec(conn, maxRequestContentLength, 0, 0)
 		s.ServeCodec(codec, OptionMethodInvocation|OptionSubscriptions)
 	})
 }
@@ -130,15 +135,38 @@ func (e wsHandshakeError) Error() string {
 // The context is used for the initial connection establishment. It does not
 // affect subsequent interactions with the client.
 func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error) {
+	return dialWebsocket(ctx, endpoint, origin, new(clientConfig))
+}
+
+// DialWebsocketWithDialer creates a new RPC client that communicates with a JSON-RPC server
+// that is listening on the given endpoint using the provided dialer.
+func DialWebsocketWithDialer(ctx context.Context, endpoint, origin string, dialer websocket.Dialer) (*Client, error) {
+	return dialWebsocket(ctx, endpoint, origin, &clientConfig{wsDialer: &dialer})
+}
+
+func dialWebsocket(ctx context.Context, endpoint, origin string, cfg *clientConfig) (*Client, error) {
 	endpoint, header, err := wsClientHeaders(endpoint, origin)
 	if err != nil {
 		return nil, err
 	}
+	for k, vs := range cfg.httpHeaders {
+		header[k] = vs
+	}
 	dialer := websocket.Dialer{
 		ReadBufferSize:  wsReadBuffer,
 		WriteBufferSize: wsWriteBuffer,
 		WriteBufferPool: wsBufferPool,
 	}
+	if cfg.wsDialer != nil {
+		dialer = *cfg.wsDialer
+	}
+	if cfg.wsCompression {
+		dialer.EnableCompression = true
+	}
+	readLimit := cfg.wsReadLimit
+	if readLimit <= 0 {
+		readLimit = wsDefaultReadLimit
+	}
 	return newClient(ctx, func(ctx context.Context) (ServerCodec, error) {
 		conn, resp, err := dialer.DialContext(ctx, endpoint, header)
 		if err != nil {
@@ -148,7 +176,7 @@ func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error
 			}
 			return nil, hErr
 		}
-		return newWebsocketCodec(conn), nil
+		return newWebsocketCodec(conn, readLimit, cfg.wsPingInterval, cfg.wsPongTimeout), nil
 	})
 }
 
@@ -169,7 +197,73 @@ func wsClientHeaders(endpoint, origin string) (string, http.Header, error) {
 	return endpointURL.String(), header, nil
 }
 
-func newWebsocketCodec(conn *websocket.Conn) ServerCodec {
-	conn.SetReadLimit(maxRequestContentLength)
-	return newCodec(conn, conn.WriteJSON, conn.ReadJSON)
+// websocketCodec is the codec of websocket connections, it additionally reports the pings of the peer
+// and, if enabled, pings the peer to keep the connection alive.
+type websocketCodec struct {
+	ServerCodec
+	conn        *websocket.Conn
+	pongPending atomic.Bool // set while a ping is not answered yet
+}
+
+// newWebsocketCodec creates a codec that limits incoming messages to readLimit bytes. If pingInterval
+// is positive, the peer is pinged in this interval and the connection is closed once it does not
+// answer within pongTimeout.
+func newWebsocketCodec(conn *websocket.Conn, readLimit int64, pingInterval, pongTimeout time.Duration) ServerCodec {
+	conn.SetReadLimit(readLimit)
+	wc := &websocketCodec{
+		ServerCodec: newCodec(conn, conn.WriteJSON, conn.ReadJSON),
+		conn:        conn,
+	}
+	if pingInterval > 0 {
+		if pongTimeout <= 0 {
+			pongTimeout = pingInterval
+		}
+		conn.SetPongHandler(func(string) error {
+			wc.pongPending.Store(false)
+			return conn.SetReadDeadline(time.Time{})
+		})
+		go wc.pingLoop(pingInterval, pongTimeout)
+	}
+	return wc
+}
+
+// pingLoop pings the peer until the codec is closed. A missing pong lets the read deadline expire,
+// which fails the read loop of the client.
+func (wc *websocketCodec) pingLoop(interval, pongTimeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-wc.Closed():
+			return
+		case <-ticker.C:
+			err := wc.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsControlWriteTimeout))
+			if err != nil {
+				log.Debug("Failed to send websocket ping", "err", err)
+				wc.Close()
+				return
+			}
+			// Only the oldest unanswered ping determines the deadline.
+			if !wc.pongPending.Swap(true) {
+				wc.conn.SetReadDeadline(time.Now().Add(pongTimeout))
+			}
+		}
+	}
+}
+
+// onPing sets a function that is called for every ping of the peer. It must be set before the
+// codec is read from.
+func (wc *websocketCodec) onPing(fn func()) {
+	wc.conn.SetPingHandler(func(appData string) error {
+		fn()
+		// Reply just like the default ping handler does.
+		err := wc.conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(wsControlWriteTimeout))
+		if err == websocket.ErrCloseSent {
+			return nil
+		}
+		if e, ok := err.(net.Error); ok && e.Timeout() {
+			return nil
+		}
+		return err
+	})
 }
@@ -0,0 +1,138 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package governance
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	referendaPallet        = "Referenda"
+	referendumInfoForEntry = "ReferendumInfoFor"
+	convictionVotingPallet = "ConvictionVoting"
+	votingForEntry         = "VotingFor"
+	democracyPallet        = "Democracy"
+	referendumInfoOfEntry  = "ReferendumInfoOf"
+	votingOfEntry          = "VotingOf"
+)
+
+// Referendum is a referendum of the referenda pallet or, on runtimes without it, of the legacy democracy pallet.
+// Exactly one of Info and DemocracyInfo is set.
+type Referendum struct {
+	Index         uint32
+	Info          *ReferendumInfo
+	DemocracyInfo *DemocracyReferendumInfo
+}
+
+// Client reads referenda and votes of the governance pallets. The referenda and conviction voting pallets of OpenGov
+// are used if the runtime has them, the democracy pallet otherwise.
+type Client struct {
+	stateRPC state.State
+}
+
+// NewClient creates a Client that reads the storage of the governance pallets via the state RPC.
+func NewClient(stateRPC state.State) *Client {
+	return &Client{
+		stateRPC: stateRPC,
+	}
+}
+
+// GetReferendum returns the referendum with the given index, or nil if there is none.
+func (c *Client) GetReferendum(ctx context.Context, meta *types.Metadata, index uint32) (*Referendum, error) {
+	encodedIndex, err := codec.Encode(types.NewU32(index))
+	if err != nil {
+		return nil, err
+	}
+
+	referendum := &Referendum{Index: index}
+
+	var ok bool
+
+	switch {
+	case hasStorageEntry(meta, referendaPallet, referendumInfoForEntry):
+		referendum.Info = &ReferendumInfo{}
+
+		ok, err = state.GetStorageEntryLatest(
+			ctx, c.stateRPC, meta, referendaPallet, referendumInfoForEntry, referendum.Info, encodedIndex,
+		)
+	case hasStorageEntry(meta, democracyPallet, referendumInfoOfEntry):
+		referendum.DemocracyInfo = &DemocracyReferendumInfo{}
+
+		ok, err = state.GetStorageEntryLatest(
+			ctx, c.stateRPC, meta, democracyPallet, referendumInfoOfEntry, referendum.DemocracyInfo, encodedIndex,
+		)
+	default:
+		return nil, ErrGovernancePalletNotFound
+	}
+
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return referendum, nil
+}
+
+// GetAccountVotes returns the voting state of the account for the class, or nil if the account neither voted nor
+// delegated. The class is the track of the referenda, it is ignored by the democracy pallet which has no classes.
+func (c *Client) GetAccountVotes(
+	ctx context.Context,
+	meta *types.Metadata,
+	account types.AccountID,
+	class uint16,
+) (*Voting, error) {
+	var (
+		voting Voting
+		ok     bool
+		err    error
+	)
+
+	switch {
+	case hasStorageEntry(meta, convictionVotingPallet, votingForEntry):
+		var encodedClass []byte
+
+		if encodedClass, err = codec.Encode(types.NewU16(class)); err != nil {
+			return nil, err
+		}
+
+		ok, err = state.GetStorageEntryLatest(
+			ctx, c.stateRPC, meta, convictionVotingPallet, votingForEntry, &voting, account[:], encodedClass,
+		)
+	case hasStorageEntry(meta, democracyPallet, votingOfEntry):
+		ok, err = state.GetStorageEntryLatest(
+			ctx, c.stateRPC, meta, democracyPallet, votingOfEntry, &voting, account[:],
+		)
+	default:
+		return nil, ErrGovernancePalletNotFound
+	}
+
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &voting, nil
+}
+
+// hasStorageEntry returns true if the runtime has the storage entry of the pallet.
+func hasStorageEntry(meta *types.Metadata, pallet, entry string) bool {
+	_, err := meta.FindStorageEntryMetadata(pallet, entry)
+
+	return err == nil
+}
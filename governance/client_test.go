@@ -0,0 +1,165 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package governance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// withOpenGov returns a copy of the metadata with the storage entries of the referenda and conviction voting pallets,
+// which are derived from the ones of the democracy pallet.
+func withOpenGov(t *testing.T, meta *types.Metadata) *types.Metadata {
+	openGov := *meta
+	openGov.AsMetadataV14.Pallets = append([]types.PalletMetadataV14{}, meta.AsMetadataV14.Pallets...)
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.Name != democracyPallet {
+			continue
+		}
+
+		for _, item := range pallet.Storage.Items {
+			switch item.Name {
+			case referendumInfoOfEntry:
+				item.Name = referendumInfoForEntry
+				openGov.AsMetadataV14.Pallets = append(
+					openGov.AsMetadataV14.Pallets,
+					statetest.NewStoragePallet(referendaPallet, item),
+				)
+			case votingOfEntry:
+				item.Name = votingForEntry
+				item.Type.AsMap.Hashers = append(
+					append([]types.StorageHasherV10{}, item.Type.AsMap.Hashers...),
+					types.StorageHasherV10{IsTwox64Concat: true},
+				)
+				openGov.AsMetadataV14.Pallets = append(
+					openGov.AsMetadataV14.Pallets,
+					statetest.NewStoragePallet(convictionVotingPallet, item),
+				)
+			}
+		}
+
+		return &openGov
+	}
+
+	t.Fatal("democracy pallet not found")
+
+	return nil
+}
+
+func TestClient_GetReferendum(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("referenda", func(t *testing.T) {
+		meta := withOpenGov(t, statetest.PolkadotMetadata(t))
+		info := newTestOngoingReferendum(ProposalOrigin{Caller: 22, Variant: 3})
+
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorage(t, meta, referendaPallet, referendumInfoForEntry, info, []byte{7, 0, 0, 0})
+
+		referendum, err := c.GetReferendum(ctx, meta, 7)
+		require.NoError(t, err)
+		assert.Equal(t, &Referendum{Index: 7, Info: &info}, referendum)
+
+		stateRPC.ExpectStorage(t, meta, referendaPallet, referendumInfoForEntry, nil, []byte{8, 0, 0, 0})
+
+		referendum, err = c.GetReferendum(ctx, meta, 8)
+		require.NoError(t, err)
+		assert.Nil(t, referendum)
+	})
+
+	t.Run("democracy", func(t *testing.T) {
+		meta := statetest.PolkadotMetadata(t)
+		info := DemocracyReferendumInfo{
+			IsFinished: true,
+			AsFinished: DemocracyFinishedReferendum{Approved: true, End: 1000},
+		}
+
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorage(t, meta, democracyPallet, referendumInfoOfEntry, info, []byte{7, 0, 0, 0})
+
+		referendum, err := c.GetReferendum(ctx, meta, 7)
+		require.NoError(t, err)
+		assert.Equal(t, &Referendum{Index: 7, DemocracyInfo: &info}, referendum)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		meta := statetest.PolkadotMetadata(t)
+
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.On("GetStorageLatestContext", mock.Anything, mock.Anything, mock.Anything).
+			Return(false, errors.New("boom"))
+
+		_, err := c.GetReferendum(ctx, meta, 7)
+		assert.ErrorIs(t, err, ErrStorageRetrieval)
+
+		withoutGovernance := *meta
+		withoutGovernance.AsMetadataV14.Pallets = nil
+
+		_, err = c.GetReferendum(ctx, &withoutGovernance, 7)
+		assert.ErrorIs(t, err, ErrGovernancePalletNotFound)
+	})
+}
+
+func TestClient_GetAccountVotes(t *testing.T) {
+	ctx := context.Background()
+	voting := Voting{
+		IsDelegating: true,
+		AsDelegating: Delegating{
+			Balance:     u128(100),
+			Target:      testDelegate,
+			Conviction:  types.Locked1x,
+			Delegations: Delegations{Votes: u128(0), Capital: u128(0)},
+			Prior:       PriorLock{Until: 0, Balance: u128(0)},
+		},
+	}
+
+	t.Run("conviction voting", func(t *testing.T) {
+		meta := withOpenGov(t, statetest.PolkadotMetadata(t))
+
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorage(t, meta, convictionVotingPallet, votingForEntry, voting, testAccount[:], []byte{33, 0})
+
+		res, err := c.GetAccountVotes(ctx, meta, testAccount, 33)
+		require.NoError(t, err)
+		assert.Equal(t, &voting, res)
+
+		stateRPC.ExpectStorage(t, meta, convictionVotingPallet, votingForEntry, nil, testAccount[:], []byte{34, 0})
+
+		res, err = c.GetAccountVotes(ctx, meta, testAccount, 34)
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("democracy", func(t *testing.T) {
+		meta := statetest.PolkadotMetadata(t)
+
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorage(t, meta, democracyPallet, votingOfEntry, voting, testAccount[:])
+
+		res, err := c.GetAccountVotes(ctx, meta, testAccount, 33)
+		require.NoError(t, err)
+		assert.Equal(t, &voting, res)
+	})
+}
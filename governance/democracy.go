@@ -0,0 +1,99 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package governance
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// DemocracyTally is the tally of a referendum of the democracy pallet, like pallet_democracy::Tally.
+type DemocracyTally struct {
+	// Ayes are the votes in favour, including conviction.
+	Ayes types.U128
+	// Nays are the votes against, including conviction.
+	Nays types.U128
+	// Turnout is the balance that voted, without conviction.
+	Turnout types.U128
+}
+
+// DemocracyReferendumStatus is the status of an ongoing referendum of the democracy pallet.
+type DemocracyReferendumStatus struct {
+	// End is the block the referendum ends.
+	End       types.U32
+	Proposal  Proposal
+	Threshold types.VoteThreshold
+	// Delay is the number of blocks after the end of the referendum the proposal is enacted.
+	Delay types.U32
+	Tally DemocracyTally
+}
+
+// DemocracyFinishedReferendum is a referendum of the democracy pallet that ended.
+type DemocracyFinishedReferendum struct {
+	Approved bool
+	// End is the block the referendum ended.
+	End types.U32
+}
+
+// DemocracyReferendumInfo is a referendum of the democracy pallet, as stored in Democracy.ReferendumInfoOf.
+type DemocracyReferendumInfo struct {
+	IsOngoing  bool
+	AsOngoing  DemocracyReferendumStatus
+	IsFinished bool
+	AsFinished DemocracyFinishedReferendum
+}
+
+func (r *DemocracyReferendumInfo) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		r.IsOngoing = true
+
+		return decoder.Decode(&r.AsOngoing)
+	case 1:
+		r.IsFinished = true
+
+		return decoder.Decode(&r.AsFinished)
+	}
+
+	return fmt.Errorf("invalid democracy referendum info variant %d", b)
+}
+
+func (r DemocracyReferendumInfo) Encode(encoder scale.Encoder) error {
+	switch {
+	case r.IsOngoing:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(r.AsOngoing)
+	case r.IsFinished:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(r.AsFinished)
+	}
+
+	return nil
+}
@@ -0,0 +1,356 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package governance
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Deposit is a deposit placed for a referendum, like pallet_referenda::Deposit.
+type Deposit struct {
+	Who    types.AccountID
+	Amount types.U128
+}
+
+// Tally is the tally of a referendum of the referenda pallet, like pallet_conviction_voting::Tally.
+type Tally struct {
+	// Ayes are the votes in favour, including conviction.
+	Ayes types.U128
+	// Nays are the votes against, including conviction.
+	Nays types.U128
+	// Support is the balance that voted aye or abstained, without conviction.
+	Support types.U128
+}
+
+// DecidingStatus is the status of a referendum in its decision period.
+type DecidingStatus struct {
+	// Since is the block the decision period started.
+	Since types.U32
+	// Confirming is the block the confirmation period ends, if the referendum is being confirmed.
+	Confirming types.Option[types.U32]
+}
+
+// ScheduleAddress is the address of a task of the scheduler, i.e. its block and its index within the block.
+type ScheduleAddress struct {
+	When  types.U32
+	Index types.U32
+}
+
+// Alarm is the block the referendum is serviced next, with the scheduled task that services it.
+type Alarm struct {
+	When    types.U32
+	Address ScheduleAddress
+}
+
+// DispatchTime is when the proposal of an approved referendum is enacted, either at the given block or after the
+// given number of blocks.
+type DispatchTime struct {
+	IsAt    bool
+	AsAt    types.U32
+	IsAfter bool
+	AsAfter types.U32
+}
+
+func (d *DispatchTime) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		d.IsAt = true
+
+		return decoder.Decode(&d.AsAt)
+	case 1:
+		d.IsAfter = true
+
+		return decoder.Decode(&d.AsAfter)
+	}
+
+	return fmt.Errorf("invalid dispatch time variant %d", b)
+}
+
+func (d DispatchTime) Encode(encoder scale.Encoder) error {
+	switch {
+	case d.IsAt:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(d.AsAt)
+	case d.IsAfter:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(d.AsAfter)
+	}
+
+	return nil
+}
+
+// ProposalLookup references a call that is stored as preimage, with the length of the call.
+type ProposalLookup struct {
+	Hash types.Hash
+	Len  types.U32
+}
+
+// Proposal is the call of a referendum, like frame_support::traits::Bounded. Short calls are stored inline, all other
+// ones are stored as preimage and referenced by their hash.
+type Proposal struct {
+	// IsLegacy is true for calls that were noted as preimage before the length of preimages was stored.
+	IsLegacy bool
+	AsLegacy types.Hash
+	IsInline bool
+	AsInline types.Bytes
+	IsLookup bool
+	AsLookup ProposalLookup
+}
+
+// Hash returns the hash of the call, which is the blake2-256 hash of inline calls.
+func (p Proposal) Hash() types.Hash {
+	switch {
+	case p.IsLegacy:
+		return p.AsLegacy
+	case p.IsInline:
+		return blake2b.Sum256(p.AsInline)
+	default:
+		return p.AsLookup.Hash
+	}
+}
+
+func (p *Proposal) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		p.IsLegacy = true
+
+		return decoder.Decode(&p.AsLegacy)
+	case 1:
+		p.IsInline = true
+
+		return decoder.Decode(&p.AsInline)
+	case 2:
+		p.IsLookup = true
+
+		return decoder.Decode(&p.AsLookup)
+	}
+
+	return fmt.Errorf("invalid proposal variant %d", b)
+}
+
+func (p Proposal) Encode(encoder scale.Encoder) error {
+	switch {
+	case p.IsLegacy:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(p.AsLegacy)
+	case p.IsInline:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(p.AsInline)
+	case p.IsLookup:
+		if err := encoder.PushByte(2); err != nil {
+			return err
+		}
+
+		return encoder.Encode(p.AsLookup)
+	}
+
+	return nil
+}
+
+const (
+	systemOriginCaller = 0
+	systemOriginRoot   = 0
+	systemOriginSigned = 1
+)
+
+// ProposalOrigin is the origin the proposal of a referendum is dispatched with, an OriginCaller of the runtime. The
+// origins of the system pallet and origins without fields are supported, e.g. the custom origins of the tracks of the
+// referenda pallet. The system pallet is expected to have the index 0.
+type ProposalOrigin struct {
+	// Caller is the index of the pallet of the origin, i.e. of the variant of the OriginCaller.
+	Caller types.U8
+	// Variant is the index of the origin within the origins of the pallet.
+	Variant types.U8
+	// Signer is the account of signed origins of the system pallet.
+	Signer types.AccountID
+}
+
+// IsRoot returns true for the root origin of the system pallet.
+func (o ProposalOrigin) IsRoot() bool {
+	return o.Caller == systemOriginCaller && o.Variant == systemOriginRoot
+}
+
+// IsSigned returns true for signed origins of the system pallet.
+func (o ProposalOrigin) IsSigned() bool {
+	return o.Caller == systemOriginCaller && o.Variant == systemOriginSigned
+}
+
+func (o *ProposalOrigin) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&o.Caller); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&o.Variant); err != nil {
+		return err
+	}
+
+	if o.IsSigned() {
+		return decoder.Decode(&o.Signer)
+	}
+
+	return nil
+}
+
+func (o ProposalOrigin) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(o.Caller); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(o.Variant); err != nil {
+		return err
+	}
+
+	if o.IsSigned() {
+		return encoder.Encode(o.Signer)
+	}
+
+	return nil
+}
+
+// ReferendumStatus is the status of an ongoing referendum of the referenda pallet.
+type ReferendumStatus struct {
+	// Track is the track of the referendum, which is the class of the votes of the conviction voting pallet.
+	Track             types.U16
+	Origin            ProposalOrigin
+	Proposal          Proposal
+	Enactment         DispatchTime
+	Submitted         types.U32
+	SubmissionDeposit Deposit
+	DecisionDeposit   types.Option[Deposit]
+	Deciding          types.Option[DecidingStatus]
+	Tally             Tally
+	InQueue           bool
+	Alarm             types.Option[Alarm]
+}
+
+// CompletedReferendum is a referendum of the referenda pallet that is no longer ongoing, with the deposits that were
+// not refunded yet.
+type CompletedReferendum struct {
+	// End is the block the referendum ended.
+	End               types.U32
+	SubmissionDeposit types.Option[Deposit]
+	DecisionDeposit   types.Option[Deposit]
+}
+
+// ReferendumInfo is a referendum of the referenda pallet, as stored in Referenda.ReferendumInfoFor.
+type ReferendumInfo struct {
+	IsOngoing   bool
+	AsOngoing   ReferendumStatus
+	IsApproved  bool
+	AsApproved  CompletedReferendum
+	IsRejected  bool
+	AsRejected  CompletedReferendum
+	IsCancelled bool
+	AsCancelled CompletedReferendum
+	IsTimedOut  bool
+	AsTimedOut  CompletedReferendum
+	IsKilled    bool
+	// AsKilled is the block the referendum was killed.
+	AsKilled types.U32
+}
+
+func (r *ReferendumInfo) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		r.IsOngoing = true
+
+		return decoder.Decode(&r.AsOngoing)
+	case 1:
+		r.IsApproved = true
+
+		return decoder.Decode(&r.AsApproved)
+	case 2:
+		r.IsRejected = true
+
+		return decoder.Decode(&r.AsRejected)
+	case 3:
+		r.IsCancelled = true
+
+		return decoder.Decode(&r.AsCancelled)
+	case 4:
+		r.IsTimedOut = true
+
+		return decoder.Decode(&r.AsTimedOut)
+	case 5:
+		r.IsKilled = true
+
+		return decoder.Decode(&r.AsKilled)
+	}
+
+	return fmt.Errorf("invalid referendum info variant %d", b)
+}
+
+func (r ReferendumInfo) Encode(encoder scale.Encoder) error {
+	var (
+		index byte
+		value any
+	)
+
+	switch {
+	case r.IsOngoing:
+		index, value = 0, r.AsOngoing
+	case r.IsApproved:
+		index, value = 1, r.AsApproved
+	case r.IsRejected:
+		index, value = 2, r.AsRejected
+	case r.IsCancelled:
+		index, value = 3, r.AsCancelled
+	case r.IsTimedOut:
+		index, value = 4, r.AsTimedOut
+	case r.IsKilled:
+		index, value = 5, r.AsKilled
+	default:
+		return nil
+	}
+
+	if err := encoder.PushByte(index); err != nil {
+		return err
+	}
+
+	return encoder.Encode(value)
+}
@@ -0,0 +1,142 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	testAccount  = types.AccountID{1}
+	testDelegate = types.AccountID{2}
+)
+
+func u128(i int64) types.U128 {
+	return types.NewU128(*big.NewInt(i))
+}
+
+func assertRoundTrip[T any](t *testing.T, value T) {
+	encoded, err := codec.Encode(value)
+	require.NoError(t, err)
+
+	var decoded T
+	require.NoError(t, codec.Decode(encoded, &decoded))
+	assert.Equal(t, value, decoded)
+}
+
+func newTestOngoingReferendum(origin ProposalOrigin) ReferendumInfo {
+	deposit := Deposit{Who: testAccount, Amount: u128(1000)}
+
+	return ReferendumInfo{
+		IsOngoing: true,
+		AsOngoing: ReferendumStatus{
+			Track:             33,
+			Origin:            origin,
+			Proposal:          Proposal{IsLookup: true, AsLookup: ProposalLookup{Hash: types.Hash{9}, Len: 42}},
+			Enactment:         DispatchTime{IsAfter: true, AsAfter: 100},
+			Submitted:         1000,
+			SubmissionDeposit: deposit,
+			DecisionDeposit:   types.NewOption(deposit),
+			Deciding:          types.NewOption(DecidingStatus{Since: 1100, Confirming: types.NewOption[types.U32](1200)}),
+			Tally:             Tally{Ayes: u128(30), Nays: u128(10), Support: u128(5)},
+			Alarm:             types.NewOption(Alarm{When: 1300, Address: ScheduleAddress{When: 1300, Index: 1}}),
+		},
+	}
+}
+
+func TestReferendumInfo_EncodeDecode(t *testing.T) {
+	completed := CompletedReferendum{
+		End:               2000,
+		SubmissionDeposit: types.NewOption(Deposit{Who: testAccount, Amount: u128(1000)}),
+		DecisionDeposit:   types.NewEmptyOption[Deposit](),
+	}
+
+	for _, info := range []ReferendumInfo{
+		newTestOngoingReferendum(ProposalOrigin{Caller: 0, Variant: 0}),
+		newTestOngoingReferendum(ProposalOrigin{Caller: 0, Variant: 1, Signer: testAccount}),
+		newTestOngoingReferendum(ProposalOrigin{Caller: 22, Variant: 3}),
+		{IsApproved: true, AsApproved: completed},
+		{IsRejected: true, AsRejected: completed},
+		{IsCancelled: true, AsCancelled: completed},
+		{IsTimedOut: true, AsTimedOut: completed},
+		{IsKilled: true, AsKilled: 2000},
+	} {
+		assertRoundTrip(t, info)
+	}
+
+	var info ReferendumInfo
+	assert.Error(t, codec.Decode([]byte{6}, &info))
+}
+
+func TestReferendumInfo_Decode(t *testing.T) {
+	// Killed at block 5.
+	var info ReferendumInfo
+	require.NoError(t, codec.Decode([]byte{5, 5, 0, 0, 0}, &info))
+	assert.Equal(t, ReferendumInfo{IsKilled: true, AsKilled: 5}, info)
+
+	// The origin of the tracks of the referenda pallet has no fields, signed origins of the system pallet have the
+	// account of the signer.
+	var origin ProposalOrigin
+	require.NoError(t, codec.Decode([]byte{22, 3}, &origin))
+	assert.Equal(t, ProposalOrigin{Caller: 22, Variant: 3}, origin)
+	assert.False(t, origin.IsRoot())
+
+	require.NoError(t, codec.Decode(append([]byte{0, 1}, testAccount[:]...), &origin))
+	assert.True(t, origin.IsSigned())
+	assert.Equal(t, testAccount, origin.Signer)
+}
+
+func TestProposal_Hash(t *testing.T) {
+	call := types.Bytes{0, 1, 2}
+
+	assert.Equal(t, types.Hash(blake2b.Sum256(call)), Proposal{IsInline: true, AsInline: call}.Hash())
+	assert.Equal(t, types.Hash{1}, Proposal{IsLegacy: true, AsLegacy: types.Hash{1}}.Hash())
+	assert.Equal(t, types.Hash{2}, Proposal{IsLookup: true, AsLookup: ProposalLookup{Hash: types.Hash{2}}}.Hash())
+
+	for _, proposal := range []Proposal{
+		{IsLegacy: true, AsLegacy: types.Hash{1}},
+		{IsInline: true, AsInline: call},
+		{IsLookup: true, AsLookup: ProposalLookup{Hash: types.Hash{2}, Len: 3}},
+	} {
+		assertRoundTrip(t, proposal)
+	}
+}
+
+func TestDemocracyReferendumInfo_EncodeDecode(t *testing.T) {
+	for _, info := range []DemocracyReferendumInfo{
+		{
+			IsOngoing: true,
+			AsOngoing: DemocracyReferendumStatus{
+				End:       1000,
+				Proposal:  Proposal{IsInline: true, AsInline: types.Bytes{0, 1}},
+				Threshold: types.SimpleMajority,
+				Delay:     10,
+				Tally:     DemocracyTally{Ayes: u128(30), Nays: u128(10), Turnout: u128(40)},
+			},
+		},
+		{IsFinished: true, AsFinished: DemocracyFinishedReferendum{Approved: true, End: 1000}},
+	} {
+		assertRoundTrip(t, info)
+	}
+}
@@ -0,0 +1,147 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package governance
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Delegations are the votes and the capital delegated to an account.
+type Delegations struct {
+	// Votes are the delegated votes, including conviction.
+	Votes types.U128
+	// Capital is the delegated balance, without conviction.
+	Capital types.U128
+}
+
+// PriorLock is the lock of the balance of earlier votes, which are locked until the given block.
+type PriorLock struct {
+	Until   types.U32
+	Balance types.U128
+}
+
+// PollVote is the vote of an account on a referendum.
+type PollVote struct {
+	// Poll is the index of the referendum.
+	Poll types.U32
+	Vote types.VoteAccountVote
+}
+
+// Casting are the votes an account casts itself.
+type Casting struct {
+	Votes       []PollVote
+	Delegations Delegations
+	Prior       PriorLock
+}
+
+// Delegating is the delegation of the votes of an account to the target.
+type Delegating struct {
+	Balance     types.U128
+	Target      types.AccountID
+	Conviction  types.DemocracyConviction
+	Delegations Delegations
+	Prior       PriorLock
+}
+
+// Voting is the voting state of an account, as stored in ConvictionVoting.VotingFor and Democracy.VotingOf. The
+// accounts either cast their votes or delegate them.
+type Voting struct {
+	IsCasting    bool
+	AsCasting    Casting
+	IsDelegating bool
+	AsDelegating Delegating
+}
+
+func (v *Voting) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		v.IsCasting = true
+
+		return decoder.Decode(&v.AsCasting)
+	case 1:
+		v.IsDelegating = true
+
+		return decoder.Decode(&v.AsDelegating)
+	}
+
+	return fmt.Errorf("invalid voting variant %d", b)
+}
+
+func (v Voting) Encode(encoder scale.Encoder) error {
+	switch {
+	case v.IsCasting:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(v.AsCasting)
+	case v.IsDelegating:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(v.AsDelegating)
+	}
+
+	return nil
+}
+
+// VotingPower returns the votes of the balance with the conviction, like Conviction::votes: the balance is
+// multiplied by 1 to 6 for locked convictions, without conviction it only counts a tenth.
+func VotingPower(balance types.U128, conviction types.DemocracyConviction) types.U128 {
+	if balance.Int == nil {
+		return types.NewU128(*big.NewInt(0))
+	}
+
+	if conviction == types.None {
+		return types.NewU128(*new(big.Int).Div(balance.Int, big.NewInt(10)))
+	}
+
+	return types.NewU128(*new(big.Int).Mul(balance.Int, big.NewInt(int64(conviction))))
+}
+
+// AccountVotePower returns the aye and nay votes the vote adds to the tally of a referendum. Split votes have no
+// conviction, abstaining balances only count for the support.
+func AccountVotePower(vote types.VoteAccountVote) (ayes, nays types.U128) {
+	zero := types.NewU128(*big.NewInt(0))
+
+	switch {
+	case vote.IsStandard:
+		votes := VotingPower(vote.AsStandard.Balance, vote.AsStandard.Vote.Conviction)
+
+		if vote.AsStandard.Vote.Aye {
+			return votes, zero
+		}
+
+		return zero, votes
+	case vote.IsSplit:
+		return VotingPower(vote.AsSplit.Aye, types.None), VotingPower(vote.AsSplit.Nay, types.None)
+	case vote.IsSplitAbstain:
+		return VotingPower(vote.AsSplitAbstain.Aye, types.None), VotingPower(vote.AsSplitAbstain.Nay, types.None)
+	}
+
+	return zero, zero
+}
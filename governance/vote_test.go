@@ -0,0 +1,116 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package governance
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoting_EncodeDecode(t *testing.T) {
+	casting := Voting{
+		IsCasting: true,
+		AsCasting: Casting{
+			Votes: []PollVote{
+				{Poll: 1, Vote: types.VoteAccountVote{
+					IsStandard: true,
+					AsStandard: types.VoteAccountVoteAsStandard{
+						Vote:    types.DemocracyVote{Aye: true, Conviction: types.Locked2x},
+						Balance: u128(100),
+					},
+				}},
+				{Poll: 2, Vote: types.VoteAccountVote{
+					IsSplitAbstain: true,
+					AsSplitAbstain: types.VoteAccountVoteAsSplitAbstain{Aye: u128(1), Nay: u128(2), Abstain: u128(3)},
+				}},
+			},
+			Delegations: Delegations{Votes: u128(10), Capital: u128(5)},
+			Prior:       PriorLock{Until: 100, Balance: u128(50)},
+		},
+	}
+
+	delegating := Voting{
+		IsDelegating: true,
+		AsDelegating: Delegating{
+			Balance:     u128(100),
+			Target:      testDelegate,
+			Conviction:  types.Locked6x,
+			Delegations: Delegations{Votes: u128(0), Capital: u128(0)},
+			Prior:       PriorLock{Until: 0, Balance: u128(0)},
+		},
+	}
+
+	for _, voting := range []Voting{casting, delegating} {
+		assertRoundTrip(t, voting)
+	}
+
+	// The vote byte holds the aye flag and the conviction.
+	encoded, err := codec.Encode(casting)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 2 << 2, 1, 0, 0, 0, 0, 0x82}, encoded[:8])
+
+	var voting Voting
+	assert.Error(t, codec.Decode([]byte{2}, &voting))
+}
+
+func TestVotingPower(t *testing.T) {
+	tests := []struct {
+		conviction types.DemocracyConviction
+		expected   types.U128
+	}{
+		{types.None, u128(10)},
+		{types.Locked1x, u128(105)},
+		{types.Locked3x, u128(315)},
+		{types.Locked6x, u128(630)},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, VotingPower(u128(105), test.conviction))
+	}
+
+	assert.Equal(t, u128(0), VotingPower(types.U128{}, types.Locked1x))
+}
+
+func TestAccountVotePower(t *testing.T) {
+	ayes, nays := AccountVotePower(types.VoteAccountVote{
+		IsStandard: true,
+		AsStandard: types.VoteAccountVoteAsStandard{
+			Vote:    types.DemocracyVote{Aye: false, Conviction: types.Locked2x},
+			Balance: u128(100),
+		},
+	})
+	assert.Equal(t, u128(0), ayes)
+	assert.Equal(t, u128(200), nays)
+
+	ayes, nays = AccountVotePower(types.VoteAccountVote{
+		IsSplit: true,
+		AsSplit: types.VoteAccountVoteAsSplit{Aye: u128(100), Nay: u128(50)},
+	})
+	assert.Equal(t, u128(10), ayes)
+	assert.Equal(t, u128(5), nays)
+
+	ayes, nays = AccountVotePower(types.VoteAccountVote{
+		IsSplitAbstain: true,
+		AsSplitAbstain: types.VoteAccountVoteAsSplitAbstain{Aye: u128(100), Nay: u128(50), Abstain: u128(1000)},
+	})
+	assert.Equal(t, u128(10), ayes)
+	assert.Equal(t, u128(5), nays)
+}
@@ -0,0 +1,171 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+type superOf struct {
+	Super types.AccountID
+	Name  Data
+}
+
+type subsOf struct {
+	Deposit  types.U128
+	Accounts []types.AccountID
+}
+
+// Client reads the identities of accounts from the identity pallet, of relay chains as well as of people chains.
+type Client struct {
+	stateRPC state.State
+}
+
+// NewClient creates a Client that reads the storage of the identity pallet via the state RPC.
+func NewClient(stateRPC state.State) *Client {
+	return &Client{
+		stateRPC: stateRPC,
+	}
+}
+
+// GetIdentity returns the identity of the account, or nil if the account has no identity. Sub accounts have no
+// identity of their own, see GetSuper.
+func (c *Client) GetIdentity(ctx context.Context, meta *types.Metadata, account types.AccountID) (*Identity, error) {
+	l, err := newLayout(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := state.GetStorageEntryRawLatest(ctx, c.stateRPC, meta, identityPallet, identityOfEntry, account[:])
+	if err != nil || raw == nil {
+		return nil, err
+	}
+
+	identity, err := l.decodeIdentity(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.withUsername || !hasStorageEntry(meta, usernameOfEntry) {
+		return identity, nil
+	}
+
+	var username types.Bytes
+
+	_, err = state.GetStorageEntryLatest(ctx, c.stateRPC, meta, identityPallet, usernameOfEntry, &username, account[:])
+	if err != nil {
+		return nil, err
+	}
+
+	identity.Username = string(username)
+
+	return identity, nil
+}
+
+// GetSuper returns the super account and the name of the sub account, or nil if the account is no sub account.
+func (c *Client) GetSuper(
+	ctx context.Context,
+	meta *types.Metadata,
+	account types.AccountID,
+) (*SuperIdentity, error) {
+	var s superOf
+
+	ok, err := state.GetStorageEntryLatest(ctx, c.stateRPC, meta, identityPallet, superOfEntry, &s, account[:])
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &SuperIdentity{
+		Super: s.Super,
+		Name:  s.Name,
+	}, nil
+}
+
+// GetSubs returns the sub accounts of the account with their names.
+func (c *Client) GetSubs(ctx context.Context, meta *types.Metadata, account types.AccountID) ([]SubIdentity, error) {
+	var s subsOf
+
+	ok, err := state.GetStorageEntryLatest(ctx, c.stateRPC, meta, identityPallet, subsOfEntry, &s, account[:])
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	subs := make([]SubIdentity, 0, len(s.Accounts))
+
+	for _, sub := range s.Accounts {
+		sup, err := c.GetSuper(ctx, meta, sub)
+		if err != nil {
+			return nil, err
+		}
+
+		subIdentity := SubIdentity{Account: sub}
+
+		if sup != nil {
+			subIdentity.Name = sup.Name
+		}
+
+		subs = append(subs, subIdentity)
+	}
+
+	return subs, nil
+}
+
+// GetDisplayName returns the name explorers display for the account: the display name of its identity, the display
+// name of its super account and its own name separated by a slash for sub accounts, e.g. parent/sub, or its username.
+// An empty name is returned if the account has none of them.
+func (c *Client) GetDisplayName(ctx context.Context, meta *types.Metadata, account types.AccountID) (string, error) {
+	identity, err := c.GetIdentity(ctx, meta, account)
+	if err != nil {
+		return "", err
+	}
+
+	if identity != nil && !identity.Info.Display.IsNone() {
+		return identity.Info.Display.String(), nil
+	}
+
+	sup, err := c.GetSuper(ctx, meta, account)
+	if err != nil {
+		return "", err
+	}
+
+	if sup != nil {
+		superIdentity, err := c.GetIdentity(ctx, meta, sup.Super)
+		if err != nil {
+			return "", err
+		}
+
+		if superIdentity != nil && !superIdentity.Info.Display.IsNone() {
+			return superIdentity.Info.Display.String() + "/" + sup.Name.String(), nil
+		}
+	}
+
+	if identity != nil {
+		return identity.Username, nil
+	}
+
+	return "", nil
+}
+
+// hasStorageEntry returns true if the runtime has the storage entry of the identity pallet.
+func hasStorageEntry(meta *types.Metadata, entry string) bool {
+	_, err := meta.FindStorageEntryMetadata(identityPallet, entry)
+
+	return err == nil
+}
@@ -0,0 +1,316 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccount = types.AccountID{1}
+	testSub     = types.AccountID{2}
+	testOther   = types.AccountID{3}
+)
+
+// encodeJudgements encodes the judgements, which are of the registrars with the index of their position.
+func encodeJudgements(t *testing.T, levels ...JudgementLevel) []byte {
+	b := statetest.Encode(t, types.NewUCompactFromUInt(uint64(len(levels))))
+
+	for i, level := range levels {
+		b = append(b, statetest.Encode(t, types.NewU32(uint32(i)), uint8(level))...)
+
+		if level == JudgementFeePaid {
+			b = append(b, statetest.Encode(t, types.NewU128(*big.NewInt(10)))...)
+		}
+	}
+
+	return b
+}
+
+// withPeopleLayout returns a copy of the metadata with the Identity.IdentityOf layout of people chains, which store
+// the registration with the username and an identity info without additional fields.
+func withPeopleLayout(t *testing.T, meta *types.Metadata) *types.Metadata {
+	people := *meta
+	people.AsMetadataV14.EfficientLookup = make(map[int64]*types.Si1Type)
+
+	for id, ty := range meta.AsMetadataV14.EfficientLookup {
+		people.AsMetadataV14.EfficientLookup[id] = ty
+	}
+
+	entry, err := meta.FindStorageEntryMetadata(identityPallet, identityOfEntry)
+	require.NoError(t, err)
+
+	lookup := meta.AsMetadataV14.EfficientLookup
+	relayValue := entry.(types.StorageEntryMetadataV14).Type.AsMap.Value
+	relayRegistration := lookup[relayValue.Int64()]
+	relayFields := map[types.Text]types.Si1LookupTypeID{}
+
+	for _, field := range relayRegistration.Def.Composite.Fields {
+		relayFields[field.Name] = field.Type
+	}
+
+	relayInfo := relayFields["info"]
+
+	for _, field := range lookup[relayInfo.Int64()].Def.Composite.Fields {
+		relayFields[field.Name] = field.Type
+	}
+
+	nextID := int64(1_000_000)
+	addType := func(def types.Si1TypeDef, path ...types.Text) types.Si1LookupTypeID {
+		nextID++
+		people.AsMetadataV14.EfficientLookup[nextID] = &types.Si1Type{Path: path, Def: def}
+
+		return types.NewSi1LookupTypeIDFromUInt(uint64(nextID))
+	}
+	composite := func(names ...types.Text) types.Si1TypeDef {
+		def := types.Si1TypeDef{IsComposite: true}
+
+		for _, name := range names {
+			def.Composite.Fields = append(def.Composite.Fields, types.Si1Field{
+				HasName: true,
+				Name:    name,
+				Type:    relayFields[name],
+			})
+		}
+
+		return def
+	}
+
+	relayFields["matrix"] = relayFields["display"]
+	relayFields["github"] = relayFields["display"]
+	relayFields["discord"] = relayFields["display"]
+	relayFields["info"] = addType(composite(
+		"display", "legal", "web", "matrix", "email", "pgp_fingerprint", "image", "twitter", "github", "discord",
+	), "pallet_identity", "legacy", "IdentityInfo")
+
+	registration := addType(composite("judgements", "deposit", "info"), "pallet_identity", "types", "Registration")
+	username := addType(types.Si1TypeDef{
+		IsSequence: true,
+		Sequence:   types.Si1TypeDefSequence{Type: types.NewSi1LookupTypeIDFromUInt(2)},
+	})
+	optionalUsername := addType(types.Si1TypeDef{
+		IsVariant: true,
+		Variant: types.Si1TypeDefVariant{Variants: []types.Si1Variant{
+			{Name: "None", Index: 0},
+			{Name: "Some", Index: 1, Fields: []types.Si1Field{{Type: username}}},
+		}},
+	}, "Option")
+	value := addType(types.Si1TypeDef{IsTuple: true, Tuple: types.Si1TypeDefTuple{registration, optionalUsername}})
+
+	people.AsMetadataV14.Pallets = append([]types.PalletMetadataV14{}, meta.AsMetadataV14.Pallets...)
+
+	for i, pallet := range people.AsMetadataV14.Pallets {
+		if pallet.Name != identityPallet {
+			continue
+		}
+
+		pallet.Storage.Items = append([]types.StorageEntryMetadataV14{}, pallet.Storage.Items...)
+
+		for j, item := range pallet.Storage.Items {
+			if item.Name == identityOfEntry {
+				pallet.Storage.Items[j].Type.AsMap.Value = value
+			}
+		}
+
+		people.AsMetadataV14.Pallets[i] = pallet
+	}
+
+	return &people
+}
+
+func expectIdentity(
+	t *testing.T, stateRPC *statetest.State, meta *types.Metadata, account types.AccountID, value []byte,
+) {
+	stateRPC.ExpectStorageRaw(t, meta, identityPallet, identityOfEntry, value, account[:])
+}
+
+func TestClient_GetIdentity_Relay(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+	fingerprint := [20]byte{1, 2, 3}
+	imageHash := Data{Kind: DataBlakeTwo256, Bytes: make([]byte, 32)}
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	expectIdentity(t, stateRPC, meta, testAccount, append(
+		encodeJudgements(t, JudgementFeePaid, JudgementKnownGood),
+		statetest.Encode(t,
+			types.NewU128(*big.NewInt(1000)),
+			[]AdditionalField{{Key: NewRawData("key"), Value: NewRawData("value")}},
+			NewRawData("Alice"),
+			Data{},
+			NewRawData("https://alice.example"),
+			NewRawData("@alice:matrix.org"),
+			NewRawData("alice@example.com"),
+			types.NewOption(fingerprint),
+			imageHash,
+			NewRawData("@alice"),
+		)...,
+	))
+
+	identity, err := c.GetIdentity(ctx, meta, testAccount)
+	require.NoError(t, err)
+	assert.Equal(t, &Identity{
+		Judgements: []Judgement{
+			{RegistrarIndex: 0, Level: JudgementFeePaid, Fee: types.NewU128(*big.NewInt(10))},
+			{RegistrarIndex: 1, Level: JudgementKnownGood},
+		},
+		Deposit: types.NewU128(*big.NewInt(1000)),
+		Info: Info{
+			Display:        NewRawData("Alice"),
+			Web:            NewRawData("https://alice.example"),
+			Matrix:         NewRawData("@alice:matrix.org"),
+			Email:          NewRawData("alice@example.com"),
+			Image:          imageHash,
+			Twitter:        NewRawData("@alice"),
+			PGPFingerprint: &fingerprint,
+			Additional:     []AdditionalField{{Key: NewRawData("key"), Value: NewRawData("value")}},
+		},
+	}, identity)
+	assert.True(t, identity.HasGoodJudgement())
+	assert.Equal(t, "KnownGood", identity.Judgements[1].Level.String())
+
+	expectIdentity(t, stateRPC, meta, testOther, nil)
+
+	identity, err = c.GetIdentity(ctx, meta, testOther)
+	require.NoError(t, err)
+	assert.Nil(t, identity)
+
+	expectIdentity(t, stateRPC, meta, testOther, encodeJudgements(t, JudgementErroneous+1))
+
+	_, err = c.GetIdentity(ctx, meta, testOther)
+	assert.ErrorIs(t, err, ErrIdentityDecoding)
+}
+
+func TestClient_GetIdentity_People(t *testing.T) {
+	ctx := context.Background()
+	meta := withPeopleLayout(t, statetest.PolkadotMetadata(t))
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	expectIdentity(t, stateRPC, meta, testAccount, append(
+		encodeJudgements(t, JudgementReasonable),
+		statetest.Encode(t,
+			types.NewU128(*big.NewInt(1000)),
+			NewRawData("Alice"),
+			Data{},
+			Data{},
+			NewRawData("@alice:matrix.org"),
+			Data{},
+			types.NewEmptyOption[[20]byte](),
+			Data{},
+			Data{},
+			NewRawData("alice"),
+			NewRawData("alice#1234"),
+			types.NewOption(types.NewBytes([]byte("alice.dot"))),
+		)...,
+	))
+
+	identity, err := c.GetIdentity(ctx, meta, testAccount)
+	require.NoError(t, err)
+	assert.Equal(t, &Identity{
+		Judgements: []Judgement{{RegistrarIndex: 0, Level: JudgementReasonable}},
+		Deposit:    types.NewU128(*big.NewInt(1000)),
+		Info: Info{
+			Display: NewRawData("Alice"),
+			Matrix:  NewRawData("@alice:matrix.org"),
+			GitHub:  NewRawData("alice"),
+			Discord: NewRawData("alice#1234"),
+		},
+		Username: "alice.dot",
+	}, identity)
+}
+
+func TestClient_GetIdentity_UnsupportedLayout(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+
+	withoutIdentity := *meta
+	withoutIdentity.AsMetadataV14.Pallets = nil
+
+	c, _ := statetest.NewClient(t, NewClient)
+
+	_, err := c.GetIdentity(context.Background(), &withoutIdentity, testAccount)
+	assert.ErrorIs(t, err, ErrStorageEntryNotFound)
+}
+
+func TestClient_GetSubs(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorage(t, meta, identityPallet, subsOfEntry, subsOf{
+		Deposit:  types.NewU128(*big.NewInt(100)),
+		Accounts: []types.AccountID{testSub, testOther},
+	}, testAccount[:])
+	stateRPC.ExpectStorage(
+		t, meta, identityPallet, superOfEntry, superOf{Super: testAccount, Name: NewRawData("validator")}, testSub[:],
+	)
+	stateRPC.ExpectStorage(t, meta, identityPallet, superOfEntry, nil, testOther[:])
+
+	subs, err := c.GetSubs(ctx, meta, testAccount)
+	require.NoError(t, err)
+	assert.Equal(t, []SubIdentity{
+		{Account: testSub, Name: NewRawData("validator")},
+		{Account: testOther},
+	}, subs)
+}
+
+func TestClient_GetDisplayName(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+
+	identity := append(
+		encodeJudgements(t),
+		statetest.Encode(t,
+			types.NewU128(*big.NewInt(1000)),
+			[]AdditionalField{},
+			NewRawData("Alice"),
+			Data{}, Data{}, Data{}, Data{},
+			types.NewEmptyOption[[20]byte](),
+			Data{}, Data{},
+		)...,
+	)
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	expectIdentity(t, stateRPC, meta, testAccount, identity)
+
+	name, err := c.GetDisplayName(ctx, meta, testAccount)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", name)
+
+	expectIdentity(t, stateRPC, meta, testSub, nil)
+	stateRPC.ExpectStorage(
+		t, meta, identityPallet, superOfEntry, superOf{Super: testAccount, Name: NewRawData("validator")}, testSub[:],
+	)
+	expectIdentity(t, stateRPC, meta, testAccount, identity)
+
+	name, err = c.GetDisplayName(ctx, meta, testSub)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice/validator", name)
+
+	expectIdentity(t, stateRPC, meta, testOther, nil)
+	stateRPC.ExpectStorage(t, meta, identityPallet, superOfEntry, nil, testOther[:])
+
+	name, err = c.GetDisplayName(ctx, meta, testOther)
+	require.NoError(t, err)
+	assert.Equal(t, "", name)
+}
@@ -0,0 +1,134 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// DataKind is the kind of the Data of an identity field.
+type DataKind uint8
+
+const (
+	// DataNone is an unset field.
+	DataNone DataKind = iota
+	// DataRaw holds up to 32 bytes of raw data, usually UTF-8 text.
+	DataRaw
+	// DataBlakeTwo256 holds the BLAKE2-256 hash of the data.
+	DataBlakeTwo256
+	// DataSha256 holds the SHA-256 hash of the data.
+	DataSha256
+	// DataKeccak256 holds the Keccak-256 hash of the data.
+	DataKeccak256
+	// DataShaThree256 holds the SHA3-256 hash of the data.
+	DataShaThree256
+)
+
+const (
+	// dataRawOffset is the index of the Raw0 variant, the index of RawN is dataRawOffset + N.
+	dataRawOffset = 1
+	// dataMaxRawLength is the length of the largest raw variant, Raw32.
+	dataMaxRawLength = 32
+	// dataHashOffset is the index of the BlakeTwo256 variant, which is followed by the other hash variants.
+	dataHashOffset = dataRawOffset + dataMaxRawLength + 1
+	dataHashLength = 32
+)
+
+// Data is the value of an identity field, like pallet_identity::Data. The variants Raw0 to Raw32 are flattened into
+// DataRaw with the bytes of the variant.
+type Data struct {
+	Kind  DataKind
+	Bytes []byte
+}
+
+// NewRawData creates raw data from the text, which must not be longer than 32 bytes.
+func NewRawData(text string) Data {
+	return Data{Kind: DataRaw, Bytes: []byte(text)}
+}
+
+// IsNone returns true if the field is not set.
+func (d Data) IsNone() bool {
+	return d.Kind == DataNone
+}
+
+// IsHash returns true if the field holds a hash of the data instead of the data itself.
+func (d Data) IsHash() bool {
+	return d.Kind >= DataBlakeTwo256
+}
+
+// String returns raw data as text, or as hex if it is not valid UTF-8. Hashes are returned as hex and unset fields as
+// empty string.
+func (d Data) String() string {
+	switch {
+	case d.IsNone():
+		return ""
+	case d.Kind == DataRaw && utf8.Valid(d.Bytes):
+		return string(d.Bytes)
+	default:
+		return fmt.Sprintf("%#x", d.Bytes)
+	}
+}
+
+func (d *Data) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	var length int
+
+	switch {
+	case b == 0:
+		*d = Data{}
+
+		return nil
+	case b < dataHashOffset:
+		d.Kind = DataRaw
+		length = int(b) - dataRawOffset
+	case b < dataHashOffset+4:
+		d.Kind = DataBlakeTwo256 + DataKind(b-dataHashOffset)
+		length = dataHashLength
+	default:
+		return fmt.Errorf("invalid data variant %d", b)
+	}
+
+	d.Bytes = make([]byte, length)
+
+	return decoder.Read(d.Bytes)
+}
+
+func (d Data) Encode(encoder scale.Encoder) error {
+	switch {
+	case d.IsNone():
+		return encoder.PushByte(0)
+	case d.Kind == DataRaw && len(d.Bytes) <= dataMaxRawLength:
+		if err := encoder.PushByte(byte(dataRawOffset + len(d.Bytes))); err != nil {
+			return err
+		}
+	case d.IsHash() && d.Kind <= DataShaThree256 && len(d.Bytes) == dataHashLength:
+		if err := encoder.PushByte(byte(dataHashOffset) + byte(d.Kind-DataBlakeTwo256)); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid data of kind %d with %d bytes", d.Kind, len(d.Bytes))
+	}
+
+	return encoder.Write(d.Bytes)
+}
@@ -0,0 +1,71 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestData_EncodeDecode(t *testing.T) {
+	hash := bytes.Repeat([]byte{0xab}, 32)
+
+	tests := []struct {
+		name    string
+		data    Data
+		encoded []byte
+	}{
+		{"none", Data{}, []byte{0}},
+		{"raw0", Data{Kind: DataRaw, Bytes: []byte{}}, []byte{1}},
+		{"raw", NewRawData("alice"), append([]byte{6}, "alice"...)},
+		{"raw32", Data{Kind: DataRaw, Bytes: hash}, append([]byte{33}, hash...)},
+		{"blake2", Data{Kind: DataBlakeTwo256, Bytes: hash}, append([]byte{34}, hash...)},
+		{"sha3", Data{Kind: DataShaThree256, Bytes: hash}, append([]byte{37}, hash...)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			encoded, err := codec.Encode(test.data)
+			require.NoError(t, err)
+			assert.Equal(t, test.encoded, encoded)
+
+			var decoded Data
+			require.NoError(t, codec.Decode(encoded, &decoded))
+			assert.Equal(t, test.data, decoded)
+		})
+	}
+
+	var decoded Data
+	assert.Error(t, codec.Decode([]byte{38}, &decoded))
+
+	_, err := codec.Encode(NewRawData(string(bytes.Repeat([]byte{'a'}, 33))))
+	assert.Error(t, err)
+}
+
+func TestData_String(t *testing.T) {
+	assert.Equal(t, "", Data{}.String())
+	assert.Equal(t, "alice", NewRawData("alice").String())
+	assert.Equal(t, "0xff00", Data{Kind: DataRaw, Bytes: []byte{0xff, 0}}.String())
+
+	hash := Data{Kind: DataKeccak256, Bytes: bytes.Repeat([]byte{1}, 32)}
+	assert.True(t, hash.IsHash())
+	assert.Equal(t, "0x"+string(bytes.Repeat([]byte("01"), 32)), hash.String())
+}
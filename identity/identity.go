@@ -0,0 +1,151 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+
+// JudgementLevel is the judgement of a registrar on an identity, like pallet_identity::Judgement.
+type JudgementLevel uint8
+
+const (
+	JudgementUnknown JudgementLevel = iota
+	JudgementFeePaid
+	JudgementReasonable
+	JudgementKnownGood
+	JudgementOutOfDate
+	JudgementLowQuality
+	JudgementErroneous
+)
+
+var judgementLevelNames = []string{
+	"Unknown",
+	"FeePaid",
+	"Reasonable",
+	"KnownGood",
+	"OutOfDate",
+	"LowQuality",
+	"Erroneous",
+}
+
+// String returns the name of the judgement level, e.g. KnownGood.
+func (j JudgementLevel) String() string {
+	if int(j) < len(judgementLevelNames) {
+		return judgementLevelNames[j]
+	}
+
+	return "Invalid"
+}
+
+// IsGood returns true if the registrar verified the identity, i.e. the judgement is Reasonable or KnownGood.
+func (j JudgementLevel) IsGood() bool {
+	return j == JudgementReasonable || j == JudgementKnownGood
+}
+
+// Judgement is the judgement of a registrar on an identity.
+type Judgement struct {
+	// RegistrarIndex is the index of the registrar in Identity.Registrars.
+	RegistrarIndex uint32
+	Level          JudgementLevel
+	// Fee is the fee paid for a requested judgement, which is only set for JudgementFeePaid.
+	Fee types.U128
+}
+
+// AdditionalField is a custom field of an identity, which is only supported by the identity info of relay chains.
+type AdditionalField struct {
+	Key   Data
+	Value Data
+}
+
+// Info is the information of an identity. The fields are the union of the fields of the identity info of relay chains
+// and people chains, fields the runtime does not declare are unset.
+type Info struct {
+	Display Data
+	Legal   Data
+	Web     Data
+	// Matrix is the matrix handle, which is called riot on relay chains.
+	Matrix         Data
+	Email          Data
+	Image          Data
+	Twitter        Data
+	GitHub         Data
+	Discord        Data
+	PGPFingerprint *[20]byte
+	Additional     []AdditionalField
+	// Other are the fields declared by the runtime that are not known by name.
+	Other map[string]Data
+}
+
+// Identity is the registered identity of an account, as stored in Identity.IdentityOf.
+type Identity struct {
+	Judgements []Judgement
+	// Deposit is the amount reserved for the identity.
+	Deposit types.U128
+	Info    Info
+	// Username is the primary username of the account on people chains, which is empty if there is none.
+	Username string
+}
+
+// HasGoodJudgement returns true if any registrar verified the identity, see JudgementLevel.IsGood.
+func (i *Identity) HasGoodJudgement() bool {
+	for _, judgement := range i.Judgements {
+		if judgement.Level.IsGood() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SuperIdentity links a sub account to its super account, as stored in Identity.SuperOf.
+type SuperIdentity struct {
+	// Super is the account whose identity the sub account belongs to.
+	Super types.AccountID
+	// Name is the name of the sub account.
+	Name Data
+}
+
+// SubIdentity is a sub account of an identity.
+type SubIdentity struct {
+	Account types.AccountID
+	Name    Data
+}
+
+// infoData returns the Data field of the info with the given name, or nil if the field is not known by name.
+func (i *Info) infoData(name string) *Data {
+	switch name {
+	case "display":
+		return &i.Display
+	case "legal":
+		return &i.Legal
+	case "web":
+		return &i.Web
+	case "riot", "matrix":
+		return &i.Matrix
+	case "email":
+		return &i.Email
+	case "image":
+		return &i.Image
+	case "twitter":
+		return &i.Twitter
+	case "github":
+		return &i.GitHub
+	case "discord":
+		return &i.Discord
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,381 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	identityPallet    = "Identity"
+	identityOfEntry   = "IdentityOf"
+	superOfEntry      = "SuperOf"
+	subsOfEntry       = "SubsOf"
+	usernameOfEntry   = "UsernameOf"
+	fingerprintLength = 20
+)
+
+type fieldKind uint8
+
+const (
+	fieldData fieldKind = iota
+	fieldAdditional
+	fieldFingerprint
+)
+
+type infoField struct {
+	name string
+	kind fieldKind
+}
+
+// layout is the layout of the Identity.IdentityOf values of a runtime. Relay chains store the registration, which has
+// an identity info with additional fields, while people chains store the registration with the primary username and
+// an identity info with a fixed set of fields. Newer runtimes of people chains store the username separately.
+type layout struct {
+	// withUsername is true if the values are tuples of the registration and the optional username.
+	withUsername bool
+	// registration are the names of the fields of the registration, in order.
+	registration []string
+	// balanceLength is the length of the encoded balances of the deposit and paid fees.
+	balanceLength int
+	// info are the fields of the identity info, in order.
+	info []infoField
+}
+
+// newLayout returns the layout of the Identity.IdentityOf values declared by the metadata.
+func newLayout(meta *types.Metadata) (*layout, error) {
+	entry, err := meta.FindStorageEntryMetadata(identityPallet, identityOfEntry)
+	if err != nil {
+		return nil, ErrStorageEntryNotFound.WithMsg("%s.%s", identityPallet, identityOfEntry).Wrap(err)
+	}
+
+	entryV14, ok := entry.(types.StorageEntryMetadataV14)
+	if !ok || !entryV14.Type.IsMap {
+		return nil, ErrUnsupportedLayout.WithMsg("%s.%s is not a map", identityPallet, identityOfEntry)
+	}
+
+	l := &layout{}
+	lookup := meta.AsMetadataV14.EfficientLookup
+
+	registrationType, err := types.ResolveSi1Type(lookup, entryV14.Type.AsMap.Value)
+	if err != nil {
+		return nil, ErrUnsupportedLayout.Wrap(err)
+	}
+
+	if registrationType.Def.IsTuple {
+		if len(registrationType.Def.Tuple) != 2 || !isUsernameOption(lookup, registrationType.Def.Tuple[1]) {
+			return nil, ErrUnsupportedLayout.WithMsg("registration tuple")
+		}
+
+		l.withUsername = true
+
+		if registrationType, err = types.ResolveSi1Type(lookup, registrationType.Def.Tuple[0]); err != nil {
+			return nil, ErrUnsupportedLayout.Wrap(err)
+		}
+	}
+
+	if !registrationType.Def.IsComposite {
+		return nil, ErrUnsupportedLayout.WithMsg("registration is not a composite")
+	}
+
+	for _, field := range registrationType.Def.Composite.Fields {
+		name := string(field.Name)
+
+		switch name {
+		case "judgements":
+		case "deposit":
+			if l.balanceLength, err = balanceLengthOf(lookup, field.Type); err != nil {
+				return nil, err
+			}
+		case "info":
+			if l.info, err = infoFieldsOf(lookup, field.Type); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, ErrUnsupportedLayout.WithMsg("registration field %s", name)
+		}
+
+		l.registration = append(l.registration, name)
+	}
+
+	if l.balanceLength == 0 || l.info == nil {
+		return nil, ErrUnsupportedLayout.WithMsg("registration without deposit or info")
+	}
+
+	return l, nil
+}
+
+// infoFieldsOf returns the fields of the identity info type.
+func infoFieldsOf(lookup map[int64]*types.Si1Type, id types.Si1LookupTypeID) ([]infoField, error) {
+	infoType, err := types.ResolveSi1Type(lookup, id)
+	if err != nil {
+		return nil, ErrUnsupportedLayout.Wrap(err)
+	}
+
+	if !infoType.Def.IsComposite {
+		return nil, ErrUnsupportedLayout.WithMsg("identity info is not a composite")
+	}
+
+	fields := make([]infoField, 0, len(infoType.Def.Composite.Fields))
+
+	for _, field := range infoType.Def.Composite.Fields {
+		f := infoField{name: string(field.Name)}
+
+		switch {
+		case isData(lookup, field.Type):
+			f.kind = fieldData
+		case isAdditional(lookup, field.Type):
+			f.kind = fieldAdditional
+		case isFingerprintOption(lookup, field.Type):
+			f.kind = fieldFingerprint
+		default:
+			return nil, ErrUnsupportedLayout.WithMsg("identity info field %s", f.name)
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+// decodeIdentity decodes the Identity.IdentityOf value.
+func (l *layout) decodeIdentity(data []byte) (*Identity, error) {
+	reader := bytes.NewReader(data)
+	decoder := scale.NewDecoder(reader)
+
+	var identity Identity
+
+	for _, field := range l.registration {
+		var err error
+
+		switch field {
+		case "judgements":
+			identity.Judgements, err = l.decodeJudgements(decoder)
+		case "deposit":
+			identity.Deposit, err = l.decodeBalance(decoder)
+		case "info":
+			identity.Info, err = l.decodeInfo(decoder)
+		}
+
+		if err != nil {
+			return nil, ErrIdentityDecoding.WithMsg("registration field %s", field).Wrap(err)
+		}
+	}
+
+	if l.withUsername {
+		var username types.Option[types.Bytes]
+
+		if err := decoder.Decode(&username); err != nil {
+			return nil, ErrIdentityDecoding.WithMsg("username").Wrap(err)
+		}
+
+		if ok, value := username.Unwrap(); ok {
+			identity.Username = string(value)
+		}
+	}
+
+	if reader.Len() > 0 {
+		return nil, ErrIdentityDecoding.WithMsg("%d trailing bytes", reader.Len())
+	}
+
+	return &identity, nil
+}
+
+func (l *layout) decodeJudgements(decoder *scale.Decoder) ([]Judgement, error) {
+	n, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return nil, err
+	}
+
+	judgements := make([]Judgement, 0, n.Uint64())
+
+	for i := uint64(0); i < n.Uint64(); i++ {
+		var (
+			judgement Judgement
+			index     types.U32
+		)
+
+		if err := decoder.Decode(&index); err != nil {
+			return nil, err
+		}
+
+		judgement.RegistrarIndex = uint32(index)
+
+		b, err := decoder.ReadOneByte()
+		if err != nil {
+			return nil, err
+		}
+
+		judgement.Level = JudgementLevel(b)
+
+		if int(b) >= len(judgementLevelNames) {
+			return nil, ErrIdentityDecoding.WithMsg("invalid judgement %d", b)
+		}
+
+		if judgement.Level == JudgementFeePaid {
+			if judgement.Fee, err = l.decodeBalance(decoder); err != nil {
+				return nil, err
+			}
+		}
+
+		judgements = append(judgements, judgement)
+	}
+
+	return judgements, nil
+}
+
+func (l *layout) decodeBalance(decoder *scale.Decoder) (types.U128, error) {
+	if l.balanceLength == 8 {
+		var balance types.U64
+
+		if err := decoder.Decode(&balance); err != nil {
+			return types.U128{}, err
+		}
+
+		return types.NewU128(*new(big.Int).SetUint64(uint64(balance))), nil
+	}
+
+	var balance types.U128
+
+	if err := decoder.Decode(&balance); err != nil {
+		return types.U128{}, err
+	}
+
+	return balance, nil
+}
+
+func (l *layout) decodeInfo(decoder *scale.Decoder) (Info, error) {
+	var info Info
+
+	for _, field := range l.info {
+		switch field.kind {
+		case fieldData:
+			var data Data
+
+			if err := decoder.Decode(&data); err != nil {
+				return Info{}, err
+			}
+
+			if target := info.infoData(field.name); target != nil {
+				*target = data
+				continue
+			}
+
+			if info.Other == nil {
+				info.Other = make(map[string]Data)
+			}
+
+			info.Other[field.name] = data
+		case fieldAdditional:
+			if err := decoder.Decode(&info.Additional); err != nil {
+				return Info{}, err
+			}
+		case fieldFingerprint:
+			var fingerprint types.Option[[fingerprintLength]byte]
+
+			if err := decoder.Decode(&fingerprint); err != nil {
+				return Info{}, err
+			}
+
+			if ok, value := fingerprint.Unwrap(); ok {
+				info.PGPFingerprint = &value
+			}
+		}
+	}
+
+	return info, nil
+}
+
+func balanceLengthOf(lookup map[int64]*types.Si1Type, id types.Si1LookupTypeID) (int, error) {
+	t, err := types.ResolveSi1Type(lookup, id)
+	if err != nil {
+		return 0, ErrUnsupportedLayout.Wrap(err)
+	}
+
+	if t.Def.IsPrimitive {
+		switch t.Def.Primitive.Si0TypeDefPrimitive {
+		case types.IsU64:
+			return 8, nil
+		case types.IsU128:
+			return 16, nil
+		}
+	}
+
+	return 0, ErrUnsupportedLayout.WithMsg("balance type %d", id.Int64())
+}
+
+// isData returns true if the type is pallet_identity::Data.
+func isData(lookup map[int64]*types.Si1Type, id types.Si1LookupTypeID) bool {
+	t, ok := lookup[id.Int64()]
+
+	return ok && t.Def.IsVariant && len(t.Path) > 0 && t.Path[len(t.Path)-1] == "Data"
+}
+
+// isAdditional returns true if the type is a sequence of (Data, Data).
+func isAdditional(lookup map[int64]*types.Si1Type, id types.Si1LookupTypeID) bool {
+	t, err := types.ResolveSi1Type(lookup, id)
+	if err != nil || !t.Def.IsSequence {
+		return false
+	}
+
+	item, ok := lookup[t.Def.Sequence.Type.Int64()]
+
+	return ok && item.Def.IsTuple && len(item.Def.Tuple) == 2 &&
+		isData(lookup, item.Def.Tuple[0]) && isData(lookup, item.Def.Tuple[1])
+}
+
+// optionValueOf returns the type of the value of an Option, or false if the type is no Option.
+func optionValueOf(lookup map[int64]*types.Si1Type, id types.Si1LookupTypeID) (*types.Si1Type, bool) {
+	t, ok := lookup[id.Int64()]
+	if !ok || !t.Def.IsVariant || len(t.Path) != 1 || t.Path[0] != "Option" {
+		return nil, false
+	}
+
+	for _, variant := range t.Def.Variant.Variants {
+		if variant.Name == "Some" && len(variant.Fields) == 1 {
+			value, err := types.ResolveSi1Type(lookup, variant.Fields[0].Type)
+
+			return value, err == nil
+		}
+	}
+
+	return nil, false
+}
+
+// isFingerprintOption returns true if the type is Option<[u8; 20]>.
+func isFingerprintOption(lookup map[int64]*types.Si1Type, id types.Si1LookupTypeID) bool {
+	value, ok := optionValueOf(lookup, id)
+
+	return ok && value.Def.IsArray && value.Def.Array.Len == fingerprintLength && isU8(lookup, value.Def.Array.Type)
+}
+
+// isUsernameOption returns true if the type is Option<Vec<u8>>.
+func isUsernameOption(lookup map[int64]*types.Si1Type, id types.Si1LookupTypeID) bool {
+	value, ok := optionValueOf(lookup, id)
+
+	return ok && value.Def.IsSequence && isU8(lookup, value.Def.Sequence.Type)
+}
+
+func isU8(lookup map[int64]*types.Si1Type, id types.Si1LookupTypeID) bool {
+	t, ok := lookup[id.Int64()]
+
+	return ok && t.Def.IsPrimitive && t.Def.Primitive.Si0TypeDefPrimitive == types.IsU8
+}
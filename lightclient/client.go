@@ -0,0 +1,328 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lightclient serves the legacy RPC methods via the chainHead_v1 and transactionWatch_v1 methods, so that the
+// modules of the RPC and the higher-level helpers built on them can be driven by light clients such as smoldot, which
+// primarily expose the new JSON-RPC API.
+package lightclient
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chainhead"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	defaultMaxFinalizedBlocks = 64
+	defaultRefollowInterval   = time.Second
+)
+
+// Options configure a Client. Zero values are replaced by the defaults.
+type Options struct {
+	// MaxFinalizedBlocks is the number of finalized blocks, including the finalized head, that are kept pinned so
+	// that they can be queried. Older blocks are unpinned. It defaults to 64.
+	MaxFinalizedBlocks int
+
+	// RefollowInterval is the delay before the chain is followed again once the follow subscription ended, e.g.
+	// because the node stopped it, it defaults to 1s.
+	RefollowInterval time.Duration
+
+	// OnError, if set, is called when the follow subscription ended with an error or could not be re-established.
+	// It must not block.
+	OnError func(err error)
+}
+
+// Client is a client.Client that serves the legacy methods the node does not expose via a chainHead_v1_follow
+// subscription and the transactionWatch_v1 methods, all other requests are sent to the node as they are.
+//
+// The served methods are the ones the modules of the RPC use to back the higher-level helpers:
+//
+//   - chain_getBlockHash, chain_getHeader, chain_getFinalizedHead and chain_getBlock
+//   - chain_subscribeNewHead and chain_subscribeFinalizedHeads
+//   - state_getStorage, state_getMetadata, state_getRuntimeVersion, state_call and state_subscribeRuntimeVersion
+//   - author_submitExtrinsic and author_submitAndWatchExtrinsic
+//   - system_accountNextIndex
+//
+// Only the blocks that are pinned by the follow subscription can be queried, i.e. the recent finalized blocks, see
+// Options.MaxFinalizedBlocks, and their descendants. The next index of accounts is retrieved via the AccountNonceApi
+// runtime API at the best block, which does not take the extrinsics in the transaction pool into account.
+type Client struct {
+	client.Client
+
+	opts      Options
+	chainHead chainhead.ChainHead
+
+	// methods are the methods the node exposes, nil if they are unknown.
+	methods       *types.RPCMethods
+	calls         map[string]callHandler
+	subscriptions map[string]subscribeHandler
+
+	mu sync.Mutex
+
+	// follow is the current follow subscription, it is nil until it is initialized.
+	follow *chainhead.FollowSubscription
+	ready  chan struct{}
+
+	// blocks are the blocks pinned by the follow subscription, finalized are the pinned finalized blocks from
+	// lowest to the finalized head.
+	blocks    map[types.Hash]*block
+	finalized []types.Hash
+	best      types.Hash
+
+	subscribers map[string]map[*subscriber]struct{} // by subscribe method
+	subID       atomic.Uint64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New creates a client that serves the legacy methods the node does not expose on top of cl, see Client. It starts
+// following the chain, requests of served methods wait until the follow subscription is initialized.
+func New(cl client.Client, opts Options) (*Client, error) {
+	if opts.MaxFinalizedBlocks <= 0 {
+		opts.MaxFinalizedBlocks = defaultMaxFinalizedBlocks
+	}
+
+	if opts.RefollowInterval <= 0 {
+		opts.RefollowInterval = defaultRefollowInterval
+	}
+
+	methods, err := nodeMethods(cl)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &Client{
+		Client:      cl,
+		opts:        opts,
+		chainHead:   chainhead.NewChainHead(cl),
+		methods:     methods,
+		ready:       make(chan struct{}),
+		blocks:      make(map[types.Hash]*block),
+		subscribers: make(map[string]map[*subscriber]struct{}),
+		ctx:         ctx,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}
+
+	c.calls = served(methods, c.callHandlers())
+	c.subscriptions = served(methods, c.subscribeHandlers())
+
+	fs, err := c.chainHead.FollowContext(ctx, true)
+	if err != nil {
+		cancel()
+
+		return nil, ErrFollow.Wrap(err)
+	}
+
+	go c.run(fs)
+
+	return c, nil
+}
+
+// nodeMethods returns the methods the node exposes, or nil if the node does not support rpc_methods.
+func nodeMethods(cl client.Client) (*types.RPCMethods, error) {
+	if checker, ok := cl.(client.MethodChecker); ok {
+		return checker.Methods(context.Background())
+	}
+
+	var methods types.RPCMethods
+
+	err := cl.Call(&methods, "rpc_methods")
+
+	switch {
+	case types.IsMethodNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	default:
+		return &methods, nil
+	}
+}
+
+// served returns the handlers of the methods the node does not expose, if the methods are unknown, all are served.
+func served[H any](methods *types.RPCMethods, handlers map[string]H) map[string]H {
+	if methods == nil {
+		return handlers
+	}
+
+	for method := range handlers {
+		if methods.Has(method) {
+			delete(handlers, method)
+		}
+	}
+
+	return handlers
+}
+
+// Methods returns the methods the node exposes together with the ones served by the client, or nil if the methods
+// of the node are unknown.
+func (c *Client) Methods(_ context.Context) (*types.RPCMethods, error) {
+	if c.methods == nil {
+		return nil, nil
+	}
+
+	methods := &types.RPCMethods{
+		Version: c.methods.Version,
+		Methods: append([]string(nil), c.methods.Methods...),
+	}
+
+	for method := range c.calls {
+		methods.Methods = append(methods.Methods, method)
+	}
+
+	for method := range c.subscriptions {
+		methods.Methods = append(methods.Methods, method)
+	}
+
+	return methods, nil
+}
+
+func (c *Client) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+// CallContext serves the call if the method is served by the client and sends it to the node otherwise.
+func (c *Client) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if method == "rpc_methods" && c.methods != nil {
+		methods, err := c.Methods(ctx)
+		if err != nil {
+			return err
+		}
+
+		return assign(result, methods)
+	}
+
+	handler, ok := c.calls[method]
+	if !ok {
+		return c.Client.CallContext(ctx, result, method, args...)
+	}
+
+	res, err := handler(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	return assign(result, res)
+}
+
+// BatchCallContext serves the elements of served methods one after another and sends the others to the node in a
+// single batch.
+func (c *Client) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	forwarded := make([]gethrpc.BatchElem, 0, len(b))
+	indexes := make([]int, 0, len(b))
+
+	for i := range b {
+		if _, ok := c.calls[b[i].Method]; !ok {
+			forwarded = append(forwarded, b[i])
+			indexes = append(indexes, i)
+
+			continue
+		}
+
+		b[i].Error = c.CallContext(ctx, b[i].Result, b[i].Method, b[i].Args...)
+	}
+
+	if len(forwarded) == 0 {
+		return nil
+	}
+
+	err := c.Client.BatchCallContext(ctx, forwarded)
+
+	for i, elem := range forwarded {
+		b[indexes[i]] = elem
+	}
+
+	return err
+}
+
+// Batch returns a new, empty batch that is sent via this client
+func (c *Client) Batch() *client.Batch {
+	return client.NewBatch(c)
+}
+
+// Subscribe serves the subscription if the subscribe method is served by the client and sends it to the node
+// otherwise.
+func (c *Client) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	handler, ok := c.subscriptions[namespace+"_"+subscribeMethodSuffix]
+	if !ok {
+		return c.Client.Subscribe(
+			ctx,
+			namespace,
+			subscribeMethodSuffix,
+			unsubscribeMethodSuffix,
+			notificationMethodSuffix,
+			channel,
+			args...,
+		)
+	}
+
+	return handler(ctx, channel, args)
+}
+
+// Close stops following the chain, ends the served subscriptions and closes the underlying client.
+func (c *Client) Close() {
+	c.cancel()
+
+	<-c.done
+
+	c.mu.Lock()
+	subscribers := c.subscribers
+	c.subscribers = make(map[string]map[*subscriber]struct{})
+	c.mu.Unlock()
+
+	for _, subs := range subscribers {
+		for s := range subs {
+			s.close()
+		}
+	}
+
+	c.Client.Close()
+}
+
+// assign marshals v and unmarshals it into result, unless result is nil.
+func assign(result interface{}, v interface{}) error {
+	if result == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ErrResultEncoding.Wrap(err)
+	}
+
+	if err := json.Unmarshal(b, result); err != nil {
+		return ErrResultDecoding.Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,617 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lightclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+var testRuntime = types.ChainHeadRuntimeSpec{
+	SpecName:           "test",
+	ImplName:           "test-node",
+	SpecVersion:        42,
+	ImplVersion:        1,
+	TransactionVersion: 7,
+	APIs:               map[string]types.U32{"0xdf6acb689907609b": 2},
+}
+
+type testRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// testNode is a light client that only exposes the chainHead_v1 and transactionWatch_v1 methods, connected via a
+// stream transport.
+type testNode struct {
+	conn    net.Conn
+	writeMu sync.Mutex
+
+	mu        sync.Mutex
+	methods   []string
+	headers   map[types.Hash]types.Header
+	bodies    map[types.Hash][]types.Bytes
+	storage   map[string]string
+	calls     map[string][]byte
+	finalized []types.Hash
+	txEvents  []types.TransactionWatchEvent
+	follows   int
+	requests  []testRequest
+	operation int
+}
+
+func newTestNode(t *testing.T) (*testNode, client.Client) {
+	server, conn := net.Pipe()
+
+	n := &testNode{
+		conn: server,
+		methods: []string{
+			"chainHead_v1_follow", "chainHead_v1_header", "chainHead_v1_body", "chainHead_v1_call",
+			"chainHead_v1_storage", "chainHead_v1_unpin", "transactionWatch_v1_submitAndWatch", "rpc_methods",
+		},
+		headers: make(map[types.Hash]types.Header),
+		bodies:  make(map[types.Hash][]types.Bytes),
+		storage: make(map[string]string),
+		calls:   make(map[string][]byte),
+	}
+
+	genesis := n.addBlock(types.Hash{}, 0)
+	n.finalized = []types.Hash{genesis, n.addBlock(genesis, 1)}
+
+	go n.serve()
+
+	return n, client.NewTransportClient("pipe://", client.NewStreamTransport(conn))
+}
+
+func newTestClient(t *testing.T, opts Options) (*testNode, *Client) {
+	n, cl := newTestNode(t)
+
+	c, err := New(cl, opts)
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+
+	return n, c
+}
+
+func (n *testNode) addBlock(parent types.Hash, number types.BlockNumber, xts ...types.Bytes) types.Hash {
+	header := types.Header{ParentHash: parent, Number: number}
+
+	enc, err := codec.Encode(header)
+	if err != nil {
+		panic(err)
+	}
+
+	hash := types.Hash(blake2b.Sum256(enc))
+
+	n.mu.Lock()
+	n.headers[hash] = header
+	n.bodies[hash] = xts
+	n.mu.Unlock()
+
+	return hash
+}
+
+func (n *testNode) serve() {
+	dec := json.NewDecoder(n.conn)
+
+	for {
+		var req testRequest
+		if err := dec.Decode(&req); err != nil {
+			return
+		}
+
+		n.mu.Lock()
+		n.requests = append(n.requests, req)
+		n.mu.Unlock()
+
+		n.handle(req)
+	}
+}
+
+func (n *testNode) handle(req testRequest) { //nolint:funlen
+	switch req.Method {
+	case "rpc_methods":
+		n.respond(req, types.RPCMethods{Version: 1, Methods: n.methods})
+	case "chainHead_v1_follow":
+		n.mu.Lock()
+		n.follows++
+		finalized := n.finalized
+		n.mu.Unlock()
+
+		n.respond(req, n.followID())
+		n.emit(types.ChainHeadFollowEvent{
+			Event:                 types.ChainHeadInitialized,
+			FinalizedBlockHashes:  finalized,
+			FinalizedBlockRuntime: &types.ChainHeadRuntimeEvent{Type: "valid", Spec: &testRuntime},
+		})
+	case "chainHead_v1_header":
+		n.mu.Lock()
+		header := n.headers[hashParam(req, 1)]
+		n.mu.Unlock()
+
+		enc, err := codec.EncodeToHex(header)
+		if err != nil {
+			panic(err)
+		}
+
+		n.respond(req, enc)
+	case "chainHead_v1_body":
+		n.mu.Lock()
+		body := n.bodies[hashParam(req, 1)]
+		n.mu.Unlock()
+
+		n.emit(types.ChainHeadFollowEvent{
+			Event:       types.ChainHeadOperationBodyDone,
+			OperationID: n.startOperation(req),
+			Extrinsics:  body,
+		})
+	case "chainHead_v1_call":
+		var function string
+		if err := json.Unmarshal(req.Params[2], &function); err != nil {
+			panic(err)
+		}
+
+		n.mu.Lock()
+		output := n.calls[function]
+		n.mu.Unlock()
+
+		n.emit(types.ChainHeadFollowEvent{
+			Event:       types.ChainHeadOperationCallDone,
+			OperationID: n.startOperation(req),
+			Output:      output,
+		})
+	case "chainHead_v1_storage":
+		var items []struct {
+			Key string `json:"key"`
+		}
+		if err := json.Unmarshal(req.Params[2], &items); err != nil {
+			panic(err)
+		}
+
+		var res []types.ChainHeadStorageResultItem
+
+		n.mu.Lock()
+		for _, item := range items {
+			if value, ok := n.storage[item.Key]; ok {
+				data := types.NewStorageDataRaw(codec.MustHexDecodeString(value))
+				res = append(res, types.ChainHeadStorageResultItem{
+					Key:   codec.MustHexDecodeString(item.Key),
+					Value: &data,
+				})
+			}
+		}
+		n.mu.Unlock()
+
+		operationID := n.startOperation(req)
+
+		n.emit(types.ChainHeadFollowEvent{
+			Event:       types.ChainHeadOperationStorageItems,
+			OperationID: operationID,
+			Items:       res,
+		})
+		n.emit(types.ChainHeadFollowEvent{Event: types.ChainHeadOperationStorageDone, OperationID: operationID})
+	case "chainHead_v1_unpin", "chainHead_v1_unfollow", "transactionWatch_v1_unwatch":
+		n.respond(req, nil)
+	case "transactionWatch_v1_submitAndWatch":
+		n.mu.Lock()
+		n.operation++
+		subscriptionID := fmt.Sprintf("tx-%d", n.operation)
+		events := n.txEvents
+		n.mu.Unlock()
+
+		n.respond(req, subscriptionID)
+
+		for _, event := range events {
+			n.notify("transactionWatch_v1_watchEvent", subscriptionID, event)
+		}
+	default:
+		n.send(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"error":   map[string]interface{}{"code": -32601, "message": "Method not found"},
+		})
+	}
+}
+
+func (n *testNode) startOperation(req testRequest) string {
+	n.mu.Lock()
+	n.operation++
+	operationID := fmt.Sprintf("op-%d", n.operation)
+	n.mu.Unlock()
+
+	n.respond(req, types.ChainHeadOperationStarted{Result: "started", OperationID: operationID})
+
+	return operationID
+}
+
+func (n *testNode) followID() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return fmt.Sprintf("follow-%d", n.follows)
+}
+
+func (n *testNode) emit(event types.ChainHeadFollowEvent) {
+	n.notify("chainHead_v1_followEvent", n.followID(), event)
+}
+
+func (n *testNode) respond(req testRequest, result interface{}) {
+	n.send(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+}
+
+func (n *testNode) notify(method, subscription string, result interface{}) {
+	n.send(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  map[string]interface{}{"subscription": subscription, "result": result},
+	})
+}
+
+func (n *testNode) send(msg interface{}) {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	n.writeMu.Lock()
+	defer n.writeMu.Unlock()
+
+	_, _ = n.conn.Write(append(b, '\n'))
+}
+
+func (n *testNode) called(method string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, req := range n.requests {
+		if req.Method == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hashParam(req testRequest, i int) types.Hash {
+	var hash types.Hash
+	if err := json.Unmarshal(req.Params[i], &hash); err != nil {
+		panic(err)
+	}
+
+	return hash
+}
+
+func testExtrinsic(t *testing.T, section uint8) types.Bytes {
+	xt := types.Extrinsic{
+		Version: types.ExtrinsicVersion4,
+		Method:  types.Call{CallIndex: types.CallIndex{SectionIndex: section}},
+	}
+
+	enc, err := codec.Encode(xt)
+	require.NoError(t, err)
+
+	return enc
+}
+
+func receiveHeader(t *testing.T, ch <-chan types.Header) types.Header {
+	select {
+	case header := <-ch:
+		return header
+	case <-time.After(time.Second):
+		t.Fatal("no header received")
+
+		return types.Header{}
+	}
+}
+
+func TestClient_Chain(t *testing.T) {
+	n, c := newTestClient(t, Options{})
+
+	chainRPC := chain.NewChain(c)
+
+	newHeads, err := chainRPC.SubscribeNewHeads()
+	require.NoError(t, err)
+	defer newHeads.Unsubscribe()
+
+	finalizedHeads, err := chainRPC.SubscribeFinalizedHeads()
+	require.NoError(t, err)
+	defer finalizedHeads.Unsubscribe()
+
+	assert.Equal(t, types.BlockNumber(1), receiveHeader(t, newHeads.Chan()).Number)
+	assert.Equal(t, types.BlockNumber(1), receiveHeader(t, finalizedHeads.Chan()).Number)
+
+	finalizedHead, err := chainRPC.GetFinalizedHead()
+	require.NoError(t, err)
+	assert.Equal(t, n.finalized[1], finalizedHead)
+
+	block2 := n.addBlock(n.finalized[1], 2, testExtrinsic(t, 1), testExtrinsic(t, 2))
+
+	n.emit(types.ChainHeadFollowEvent{Event: types.ChainHeadNewBlock, BlockHash: block2, ParentBlockHash: n.finalized[1]})
+	n.emit(types.ChainHeadFollowEvent{Event: types.ChainHeadBestBlockChanged, BestBlockHash: block2})
+
+	assert.Equal(t, types.BlockNumber(2), receiveHeader(t, newHeads.Chan()).Number)
+
+	hash, err := chainRPC.GetBlockHashLatest()
+	require.NoError(t, err)
+	assert.Equal(t, block2, hash)
+
+	hash, err = chainRPC.GetBlockHash(1)
+	require.NoError(t, err)
+	assert.Equal(t, n.finalized[1], hash)
+
+	header, err := chainRPC.GetHeader(block2)
+	require.NoError(t, err)
+	assert.Equal(t, n.finalized[1], header.ParentHash)
+
+	block, err := chainRPC.GetBlock(block2)
+	require.NoError(t, err)
+	assert.Equal(t, types.BlockNumber(2), block.Block.Header.Number)
+	assert.Len(t, block.Block.Extrinsics, 2)
+
+	_, err = chainRPC.GetHeader(types.NewHash([]byte{0x01}))
+	assert.ErrorIs(t, err, ErrBlockNotPinned)
+
+	n.emit(types.ChainHeadFollowEvent{Event: types.ChainHeadFinalized, FinalizedBlockHashes: []types.Hash{block2}})
+
+	assert.Equal(t, types.BlockNumber(2), receiveHeader(t, finalizedHeads.Chan()).Number)
+
+	finalizedHead, err = chainRPC.GetFinalizedHead()
+	require.NoError(t, err)
+	assert.Equal(t, block2, finalizedHead)
+}
+
+func TestClient_State(t *testing.T) {
+	n, c := newTestClient(t, Options{})
+
+	metadata, err := codec.Encode(types.Bytes(codec.MustHexDecodeString(types.MetadataV14Data)))
+	require.NoError(t, err)
+
+	n.mu.Lock()
+	n.storage["0x0102"] = "0x2a00"
+	n.calls["Metadata_metadata"] = metadata
+	n.calls["Core_version"] = []byte{0x01, 0x02}
+	n.mu.Unlock()
+
+	stateRPC := state.NewState(c)
+
+	value, err := stateRPC.GetStorageRawLatest(types.StorageKey{0x01, 0x02})
+	require.NoError(t, err)
+	assert.Equal(t, types.StorageDataRaw{0x2a, 0x00}, *value)
+
+	value, err = stateRPC.GetStorageRaw(types.StorageKey{0x03}, n.finalized[0])
+	require.NoError(t, err)
+	assert.Empty(t, *value)
+
+	version, err := stateRPC.GetRuntimeVersionLatest()
+	require.NoError(t, err)
+	assert.Equal(t, types.U32(42), version.SpecVersion)
+	assert.Equal(t, types.U32(7), version.TransactionVersion)
+
+	apiVersion, ok := version.APIVersion("Core")
+	assert.True(t, ok)
+	assert.Equal(t, types.U32(2), apiVersion)
+
+	output, err := stateRPC.Call("Core_version", nil, n.finalized[1])
+	require.NoError(t, err)
+	assert.Equal(t, types.Bytes{0x01, 0x02}, output)
+
+	meta, err := stateRPC.GetMetadataLatest()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(14), meta.Version)
+
+	sub, err := stateRPC.SubscribeRuntimeVersion()
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	select {
+	case v := <-sub.Chan():
+		assert.Equal(t, types.U32(42), v.SpecVersion)
+	case <-time.After(time.Second):
+		t.Fatal("no runtime version received")
+	}
+
+	upgraded := testRuntime
+	upgraded.SpecVersion = 43
+
+	block2 := n.addBlock(n.finalized[1], 2)
+
+	n.emit(types.ChainHeadFollowEvent{
+		Event:           types.ChainHeadNewBlock,
+		BlockHash:       block2,
+		ParentBlockHash: n.finalized[1],
+		NewRuntime:      &types.ChainHeadRuntimeEvent{Type: "valid", Spec: &upgraded},
+	})
+	n.emit(types.ChainHeadFollowEvent{Event: types.ChainHeadBestBlockChanged, BestBlockHash: block2})
+
+	select {
+	case v := <-sub.Chan():
+		assert.Equal(t, types.U32(43), v.SpecVersion)
+	case <-time.After(time.Second):
+		t.Fatal("no runtime version received")
+	}
+}
+
+func TestClient_SystemAndAuthor(t *testing.T) {
+	n, c := newTestClient(t, Options{})
+
+	inBlock := types.NewHash([]byte{0x0a})
+
+	n.mu.Lock()
+	n.calls["AccountNonceApi_account_nonce"] = []byte{0x05, 0x00, 0x00, 0x00}
+	n.txEvents = []types.TransactionWatchEvent{
+		{Event: types.TransactionWatchValidated},
+		{Event: types.TransactionWatchBestChainBlockIncluded, Block: &types.TransactionBlock{Hash: inBlock}},
+		{Event: types.TransactionWatchFinalized, Block: &types.TransactionBlock{Hash: inBlock}},
+	}
+	n.mu.Unlock()
+
+	nonce, err := system.NewSystem(c).AccountNextIndex(types.AccountID{0x01})
+	require.NoError(t, err)
+	assert.Equal(t, types.U64(5), nonce)
+
+	authorRPC := author.NewAuthor(c)
+
+	enc := testExtrinsic(t, 1)
+
+	var xt types.Extrinsic
+	require.NoError(t, codec.Decode(enc, &xt))
+
+	hash, err := authorRPC.SubmitExtrinsic(xt)
+	require.NoError(t, err)
+	assert.Equal(t, types.Hash(blake2b.Sum256(enc)), hash)
+
+	sub, err := authorRPC.SubmitAndWatchExtrinsic(xt)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	var statuses []types.ExtrinsicStatus
+
+	for len(statuses) < 3 {
+		select {
+		case status := <-sub.Chan():
+			statuses = append(statuses, status)
+		case <-time.After(time.Second):
+			t.Fatal("no status received")
+		}
+	}
+
+	assert.Equal(t, []types.ExtrinsicStatus{
+		{IsReady: true},
+		{IsInBlock: true, AsInBlock: inBlock},
+		{IsFinalized: true, AsFinalized: inBlock},
+	}, statuses)
+
+	n.mu.Lock()
+	n.txEvents = []types.TransactionWatchEvent{{Event: types.TransactionWatchInvalid, Error: "bad signature"}}
+	n.mu.Unlock()
+
+	_, err = authorRPC.SubmitExtrinsic(xt)
+	assert.ErrorIs(t, err, ErrSubmission)
+}
+
+func TestClient_ExposedMethods(t *testing.T) {
+	n, cl := newTestNode(t)
+
+	n.methods = append(n.methods, "system_chain", "chain_getBlockHash")
+
+	c, err := New(cl, Options{})
+	require.NoError(t, err)
+	defer c.Close()
+
+	methods, err := c.Methods(nil) //nolint:staticcheck
+	require.NoError(t, err)
+	assert.True(t, methods.Has("state_getMetadata"))
+	assert.True(t, methods.Has("chain_subscribeNewHead"))
+	assert.True(t, methods.Has("system_chain"))
+
+	// Methods the node exposes are sent to it.
+	_, err = chain.NewChain(c).GetBlockHashLatest()
+	assert.True(t, types.IsMethodNotFound(err))
+	assert.True(t, n.called("chain_getBlockHash"))
+
+	assert.False(t, n.called("chain_getFinalizedHead"))
+}
+
+func TestClient_Refollow(t *testing.T) {
+	var errs []error
+
+	n, c := newTestClient(t, Options{RefollowInterval: time.Millisecond, OnError: func(err error) {
+		errs = append(errs, err)
+	}})
+
+	newHeads, err := chain.NewChain(c).SubscribeNewHeads()
+	require.NoError(t, err)
+	defer newHeads.Unsubscribe()
+
+	receiveHeader(t, newHeads.Chan())
+
+	block2 := n.addBlock(n.finalized[1], 2)
+
+	n.mu.Lock()
+	n.finalized = append(n.finalized, block2)
+	n.mu.Unlock()
+
+	n.emit(types.ChainHeadFollowEvent{Event: types.ChainHeadStop})
+
+	assert.Equal(t, types.BlockNumber(2), receiveHeader(t, newHeads.Chan()).Number)
+
+	select {
+	case <-newHeads.Gap():
+	case <-time.After(time.Second):
+		t.Fatal("no gap signaled")
+	}
+
+	finalizedHead, err := chain.NewChain(c).GetFinalizedHead()
+	require.NoError(t, err)
+	assert.Equal(t, block2, finalizedHead)
+	assert.Empty(t, errs)
+}
+
+func TestClient_UnpinFinalized(t *testing.T) {
+	n, c := newTestClient(t, Options{MaxFinalizedBlocks: 1})
+
+	chainRPC := chain.NewChain(c)
+
+	_, err := chainRPC.GetHeader(n.finalized[1])
+	require.NoError(t, err)
+
+	_, err = chainRPC.GetHeader(n.finalized[0])
+	assert.ErrorIs(t, err, ErrBlockNotPinned)
+
+	assert.Eventually(t, func() bool {
+		return n.called("chainHead_v1_unpin")
+	}, time.Second, time.Millisecond)
+}
+
+func TestClient_NewRPC(t *testing.T) {
+	n, c := newTestClient(t, Options{})
+
+	metadata, err := codec.Encode(types.Bytes(codec.MustHexDecodeString(types.MetadataV14Data)))
+	require.NoError(t, err)
+
+	n.mu.Lock()
+	n.calls["Metadata_metadata"] = metadata
+	n.mu.Unlock()
+
+	r, err := rpc.NewRPC(c)
+	require.NoError(t, err)
+
+	version, err := r.State.GetRuntimeVersionLatest()
+	require.NoError(t, err)
+	assert.Equal(t, types.U32(42), version.SpecVersion)
+
+	methods, err := r.Methods()
+	require.NoError(t, err)
+	assert.True(t, methods.Has("chain_getFinalizedHead"))
+}
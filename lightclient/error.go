@@ -0,0 +1,33 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lightclient
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrFollow          = libErr.Error("chainHead follow")
+	ErrClientClosed    = libErr.Error("light client is closed")
+	ErrInvalidParams   = libErr.Error("invalid params")
+	ErrBlockNotPinned  = libErr.Error("block is not pinned by the follow subscription")
+	ErrRuntimeUnknown  = libErr.Error("runtime of the block is unknown")
+	ErrRuntimeCall     = libErr.Error("runtime call")
+	ErrSubmission      = libErr.Error("extrinsic submission")
+	ErrResultEncoding  = libErr.Error("result encoding")
+	ErrResultDecoding  = libErr.Error("result decoding")
+	ErrNonceDecoding   = libErr.Error("nonce decoding")
+	ErrHeaderRetrieval = libErr.Error("header retrieval")
+)
@@ -0,0 +1,352 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lightclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chainhead"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	newHeadsMethod       = "chain_subscribeNewHead"
+	finalizedHeadsMethod = "chain_subscribeFinalizedHeads"
+	runtimeVersionMethod = "state_subscribeRuntimeVersion"
+)
+
+// block is a block that is pinned by the follow subscription.
+type block struct {
+	hash   types.Hash
+	header types.Header
+
+	// runtime is the runtime of the block, it is nil if the runtime is invalid.
+	runtime *types.ChainHeadRuntimeSpec
+}
+
+// run follows the chain until the client is closed, the chain is followed again whenever the follow subscription
+// ends.
+func (c *Client) run(fs *chainhead.FollowSubscription) {
+	defer close(c.done)
+
+	for {
+		if err := c.watch(fs); err != nil {
+			c.onError(err)
+		}
+
+		c.reset()
+
+		fs = c.refollow()
+		if fs == nil {
+			return
+		}
+	}
+}
+
+// refollow follows the chain again, it returns nil once the client is closed.
+func (c *Client) refollow() *chainhead.FollowSubscription {
+	for {
+		select {
+		case <-c.ctx.Done():
+			return nil
+		case <-time.After(c.opts.RefollowInterval):
+		}
+
+		fs, err := c.chainHead.FollowContext(c.ctx, true)
+		if err == nil {
+			return fs
+		}
+
+		c.onError(err)
+	}
+}
+
+func (c *Client) onError(err error) {
+	if c.opts.OnError != nil && c.ctx.Err() == nil {
+		c.opts.OnError(ErrFollow.Wrap(err))
+	}
+}
+
+// watch handles the events of the follow subscription until it ends or the client is closed.
+func (c *Client) watch(fs *chainhead.FollowSubscription) error {
+	defer fs.Unsubscribe()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return nil
+		case event, ok := <-fs.Chan():
+			if !ok || event.Event == types.ChainHeadStop {
+				return nil
+			}
+
+			if err := c.handleEvent(fs, event); err != nil {
+				return err
+			}
+		case err, ok := <-fs.Err():
+			if ok && err != nil {
+				return err
+			}
+
+			return nil
+		}
+	}
+}
+
+func (c *Client) handleEvent(fs *chainhead.FollowSubscription, event types.ChainHeadFollowEvent) error {
+	switch event.Event {
+	case types.ChainHeadInitialized:
+		return c.handleInitialized(fs, event)
+	case types.ChainHeadNewBlock:
+		return c.handleNewBlock(fs, event)
+	case types.ChainHeadBestBlockChanged:
+		c.handleBestBlockChanged(event.BestBlockHash)
+	case types.ChainHeadFinalized:
+		c.handleFinalized(fs, event)
+	}
+
+	return nil
+}
+
+func (c *Client) handleInitialized(fs *chainhead.FollowSubscription, event types.ChainHeadFollowEvent) error {
+	var runtime *types.ChainHeadRuntimeSpec
+
+	if event.FinalizedBlockRuntime != nil {
+		runtime = event.FinalizedBlockRuntime.Spec
+	}
+
+	blocks := make([]*block, 0, len(event.FinalizedBlockHashes))
+
+	for _, hash := range event.FinalizedBlockHashes {
+		header, err := fs.HeaderContext(c.ctx, hash)
+		if err != nil {
+			return ErrHeaderRetrieval.Wrap(err)
+		}
+
+		// The runtime is only reported for the finalized head, the runtime of the blocks below is assumed to be the
+		// same, which only differs if one of them enacted a runtime upgrade.
+		blocks = append(blocks, &block{hash: hash, header: *header, runtime: runtime})
+	}
+
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, b := range blocks {
+		c.blocks[b.hash] = b
+	}
+
+	c.finalized = append([]types.Hash(nil), event.FinalizedBlockHashes...)
+	c.best = c.finalized[len(c.finalized)-1]
+	c.follow = fs
+
+	c.unpinFinalized(fs)
+
+	close(c.ready)
+
+	head := c.blocks[c.best]
+
+	c.notify(newHeadsMethod, head.header)
+	c.notify(finalizedHeadsMethod, head.header)
+	c.notifyRuntime(head)
+
+	return nil
+}
+
+func (c *Client) handleNewBlock(fs *chainhead.FollowSubscription, event types.ChainHeadFollowEvent) error {
+	header, err := fs.HeaderContext(c.ctx, event.BlockHash)
+	if err != nil {
+		return ErrHeaderRetrieval.Wrap(err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b := &block{hash: event.BlockHash, header: *header}
+
+	switch {
+	case event.NewRuntime != nil:
+		b.runtime = event.NewRuntime.Spec
+	case c.blocks[event.ParentBlockHash] != nil:
+		b.runtime = c.blocks[event.ParentBlockHash].runtime
+	}
+
+	c.blocks[b.hash] = b
+
+	return nil
+}
+
+func (c *Client) handleBestBlockChanged(hash types.Hash) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.blocks[hash]
+	if !ok {
+		return
+	}
+
+	previous := c.blocks[c.best]
+	c.best = hash
+
+	c.notify(newHeadsMethod, b.header)
+
+	if previous == nil || !sameRuntime(previous.runtime, b.runtime) {
+		c.notifyRuntime(b)
+	}
+}
+
+func (c *Client) handleFinalized(fs *chainhead.FollowSubscription, event types.ChainHeadFollowEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, hash := range event.FinalizedBlockHashes {
+		b, ok := c.blocks[hash]
+		if !ok {
+			continue
+		}
+
+		c.finalized = append(c.finalized, hash)
+
+		c.notify(finalizedHeadsMethod, b.header)
+	}
+
+	// Pruned blocks are unpinned by the follow subscription.
+	for _, hash := range event.PrunedBlockHashes {
+		delete(c.blocks, hash)
+	}
+
+	c.unpinFinalized(fs)
+}
+
+// unpinFinalized unpins the finalized blocks beyond Options.MaxFinalizedBlocks. The caller must hold the lock.
+func (c *Client) unpinFinalized(fs *chainhead.FollowSubscription) {
+	excess := len(c.finalized) - c.opts.MaxFinalizedBlocks
+	if excess <= 0 {
+		return
+	}
+
+	unpinned := append([]types.Hash(nil), c.finalized[:excess]...)
+	c.finalized = c.finalized[excess:]
+
+	for _, hash := range unpinned {
+		delete(c.blocks, hash)
+	}
+
+	go func() {
+		// Failing to unpin the blocks only means that the node keeps them around until the subscription ends.
+		_ = fs.UnpinContext(c.ctx, unpinned...)
+	}()
+}
+
+// reset forgets the blocks of the ended follow subscription. Subscribers of heads are signaled a gap, since heads
+// are missed until the chain is followed again.
+func (c *Client) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.follow != nil {
+		c.ready = make(chan struct{})
+	}
+
+	c.follow = nil
+	c.blocks = make(map[types.Hash]*block)
+	c.finalized = nil
+	c.best = types.Hash{}
+
+	for _, method := range []string{newHeadsMethod, finalizedHeadsMethod} {
+		for s := range c.subscribers[method] {
+			s.sub.SignalGap()
+		}
+	}
+}
+
+// pinned waits until the follow subscription is initialized and returns it together with the pinned block with the
+// given hash, or the best block if blockHash is nil.
+func (c *Client) pinned(ctx context.Context, blockHash *types.Hash) (*chainhead.FollowSubscription, *block, error) {
+	for {
+		c.mu.Lock()
+		ready := c.ready
+		c.mu.Unlock()
+
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-c.ctx.Done():
+			return nil, nil, ErrClientClosed
+		}
+
+		c.mu.Lock()
+
+		if c.follow == nil {
+			// The follow subscription ended in the meantime.
+			c.mu.Unlock()
+
+			continue
+		}
+
+		hash := c.best
+		if blockHash != nil {
+			hash = *blockHash
+		}
+
+		b, ok := c.blocks[hash]
+		fs := c.follow
+
+		c.mu.Unlock()
+
+		if !ok {
+			return nil, nil, ErrBlockNotPinned.WithMsg("%s", hash.Hex())
+		}
+
+		return fs, b, nil
+	}
+}
+
+// blockByNumber returns the block with the given number on the best chain, or nil if it is not pinned.
+func (c *Client) blockByNumber(ctx context.Context, number uint64) (*block, error) {
+	_, b, err := c.pinned(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for b != nil && uint64(b.header.Number) > number {
+		b = c.blocks[b.header.ParentHash]
+	}
+
+	if b == nil || uint64(b.header.Number) != number {
+		return nil, nil
+	}
+
+	return b, nil
+}
+
+func sameRuntime(a, b *types.ChainHeadRuntimeSpec) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.SpecName == b.SpecName && a.SpecVersion == b.SpecVersion &&
+		a.TransactionVersion == b.TransactionVersion && a.ImplVersion == b.ImplVersion
+}
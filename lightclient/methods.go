@@ -0,0 +1,417 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lightclient
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	metadataMethod     = "Metadata_metadata"
+	accountNonceMethod = "AccountNonceApi_account_nonce"
+)
+
+// callHandler serves a legacy method, the returned value is the result of the call.
+type callHandler func(ctx context.Context, params []interface{}) (interface{}, error)
+
+func (c *Client) callHandlers() map[string]callHandler {
+	return map[string]callHandler{
+		"chain_getBlockHash":      c.getBlockHash,
+		"chain_getHeader":         c.getHeader,
+		"chain_getFinalizedHead":  c.getFinalizedHead,
+		"chain_getBlock":          c.getBlock,
+		"state_getStorage":        c.getStorage,
+		"state_getMetadata":       c.getMetadata,
+		"state_getRuntimeVersion": c.getRuntimeVersion,
+		"state_call":              c.call,
+		"system_accountNextIndex": c.accountNextIndex,
+		"author_submitExtrinsic":  c.submitExtrinsic,
+	}
+}
+
+// getBlockHash returns the hash of the block with the given number on the best chain, or of the best block if no
+// number is given. Like the legacy method, it returns null for blocks that are not pinned.
+func (c *Client) getBlockHash(ctx context.Context, params []interface{}) (interface{}, error) {
+	var number json.RawMessage
+
+	if err := decodeParams(params, &number); err != nil {
+		return nil, err
+	}
+
+	if number == nil {
+		_, b, err := c.pinned(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return b.hash, nil
+	}
+
+	n, err := parseBlockNumber(number)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := c.blockByNumber(ctx, n)
+	if err != nil || b == nil {
+		return nil, err
+	}
+
+	return b.hash, nil
+}
+
+func (c *Client) getHeader(ctx context.Context, params []interface{}) (interface{}, error) {
+	var blockHash *types.Hash
+
+	if err := decodeParams(params, &blockHash); err != nil {
+		return nil, err
+	}
+
+	_, b, err := c.pinned(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.header, nil
+}
+
+func (c *Client) getFinalizedHead(ctx context.Context, _ []interface{}) (interface{}, error) {
+	if _, _, err := c.pinned(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.finalized) == 0 {
+		return nil, ErrBlockNotPinned
+	}
+
+	return c.finalized[len(c.finalized)-1], nil
+}
+
+// getBlock returns the header and the extrinsics of the block, justifications are not available.
+func (c *Client) getBlock(ctx context.Context, params []interface{}) (interface{}, error) {
+	var blockHash *types.Hash
+
+	if err := decodeParams(params, &blockHash); err != nil {
+		return nil, err
+	}
+
+	fs, b, err := c.pinned(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := fs.BodyContext(ctx, b.hash)
+	if err != nil {
+		return nil, err
+	}
+
+	extrinsics := make([]string, len(body))
+	for i, xt := range body {
+		extrinsics[i] = codec.HexEncodeToString(xt)
+	}
+
+	return map[string]interface{}{
+		"block": map[string]interface{}{
+			"header":     b.header,
+			"extrinsics": extrinsics,
+		},
+		"justifications": nil,
+	}, nil
+}
+
+func (c *Client) getStorage(ctx context.Context, params []interface{}) (interface{}, error) {
+	var (
+		key       string
+		blockHash *types.Hash
+	)
+
+	if err := decodeParams(params, &key, &blockHash); err != nil {
+		return nil, err
+	}
+
+	storageKey, err := codec.HexDecodeString(key)
+	if err != nil {
+		return nil, ErrInvalidParams.Wrap(err)
+	}
+
+	fs, b, err := c.pinned(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := fs.StorageContext(
+		ctx,
+		b.hash,
+		[]types.ChainHeadStorageQueryItem{{Key: storageKey, Type: types.ChainHeadStorageValue}},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if item.Value != nil {
+			return item.Value.Hex(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getMetadata returns the metadata via the Metadata_metadata runtime API, which returns it in the version chosen by
+// the runtime, just like the legacy method.
+func (c *Client) getMetadata(ctx context.Context, params []interface{}) (interface{}, error) {
+	var blockHash *types.Hash
+
+	if err := decodeParams(params, &blockHash); err != nil {
+		return nil, err
+	}
+
+	output, err := c.runtimeCall(ctx, blockHash, metadataMethod, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata types.Bytes
+
+	if err := codec.Decode(output, &metadata); err != nil {
+		return nil, ErrRuntimeCall.Wrap(err)
+	}
+
+	return codec.HexEncodeToString(metadata), nil
+}
+
+func (c *Client) getRuntimeVersion(ctx context.Context, params []interface{}) (interface{}, error) {
+	var blockHash *types.Hash
+
+	if err := decodeParams(params, &blockHash); err != nil {
+		return nil, err
+	}
+
+	_, b, err := c.pinned(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.runtime == nil {
+		return nil, ErrRuntimeUnknown.WithMsg("%s", b.hash.Hex())
+	}
+
+	return runtimeVersion(b.runtime), nil
+}
+
+func (c *Client) call(ctx context.Context, params []interface{}) (interface{}, error) {
+	var (
+		method    string
+		data      string
+		blockHash *types.Hash
+	)
+
+	if err := decodeParams(params, &method, &data, &blockHash); err != nil {
+		return nil, err
+	}
+
+	args, err := codec.HexDecodeString(data)
+	if err != nil {
+		return nil, ErrInvalidParams.Wrap(err)
+	}
+
+	output, err := c.runtimeCall(ctx, blockHash, method, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.HexEncodeToString(output), nil
+}
+
+// accountNextIndex returns the nonce of the account via the AccountNonceApi runtime API at the best block.
+func (c *Client) accountNextIndex(ctx context.Context, params []interface{}) (interface{}, error) {
+	var address string
+
+	if err := decodeParams(params, &address); err != nil {
+		return nil, err
+	}
+
+	accountID, err := types.NewAccountIDFromSS58(address)
+	if err != nil {
+		return nil, ErrInvalidParams.Wrap(err)
+	}
+
+	output, err := c.runtimeCall(ctx, nil, accountNonceMethod, accountID.ToBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// The nonce type of the runtime is not known, it is a u32 on most chains.
+	switch len(output) {
+	case 4:
+		var nonce types.U32
+
+		err = codec.Decode(output, &nonce)
+
+		return types.U64(nonce), err
+	case 8:
+		var nonce types.U64
+
+		err = codec.Decode(output, &nonce)
+
+		return nonce, err
+	default:
+		return nil, ErrNonceDecoding.WithMsg("unexpected length %d", len(output))
+	}
+}
+
+// submitExtrinsic submits the extrinsic via transactionWatch_v1_submitAndWatch and returns its hash once it was
+// validated. The extrinsic is watched until it is finalized or dropped, since the node only broadcasts watched
+// extrinsics.
+func (c *Client) submitExtrinsic(ctx context.Context, params []interface{}) (interface{}, error) {
+	var xt string
+
+	if err := decodeParams(params, &xt); err != nil {
+		return nil, err
+	}
+
+	encoded, err := codec.HexDecodeString(xt)
+	if err != nil {
+		return nil, ErrInvalidParams.Wrap(err)
+	}
+
+	events := make(chan types.TransactionWatchEvent)
+
+	sub, err := c.Client.Subscribe(ctx, "transactionWatch", "v1_submitAndWatch", "v1_unwatch", "v1_watchEvent",
+		events, xt)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case event := <-events:
+		switch event.Event {
+		case types.TransactionWatchInvalid, types.TransactionWatchError, types.TransactionWatchDropped:
+			sub.Unsubscribe()
+
+			return nil, ErrSubmission.WithMsg("%s: %s", event.Event, event.Error)
+		}
+
+		if event.IsFinal() {
+			sub.Unsubscribe()
+		} else {
+			go c.watchSubmission(sub, events)
+		}
+
+		return types.Hash(blake2b.Sum256(encoded)), nil
+	case err := <-sub.Err():
+		sub.Unsubscribe()
+
+		return nil, ErrSubmission.Wrap(err)
+	case <-ctx.Done():
+		sub.Unsubscribe()
+
+		return nil, ctx.Err()
+	}
+}
+
+// watchSubmission consumes the events of a submitted extrinsic until the final one and ends the watch afterwards.
+func (c *Client) watchSubmission(sub *gethrpc.ClientSubscription, events <-chan types.TransactionWatchEvent) {
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-sub.Err():
+			return
+		case event := <-events:
+			if event.IsFinal() {
+				return
+			}
+		}
+	}
+}
+
+// runtimeCall calls the runtime API function at the pinned block, or at the best block if blockHash is nil.
+func (c *Client) runtimeCall(
+	ctx context.Context,
+	blockHash *types.Hash,
+	function string,
+	args []byte,
+) ([]byte, error) {
+	fs, b, err := c.pinned(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := fs.CallContext(ctx, b.hash, function, args)
+	if err != nil {
+		return nil, ErrRuntimeCall.WithMsg("%s", function).Wrap(err)
+	}
+
+	return output, nil
+}
+
+// decodeParams decodes the params into the targets via their JSON encoding, missing and null params leave their
+// targets unchanged.
+func decodeParams(params []interface{}, targets ...interface{}) error {
+	for i, target := range targets {
+		if i >= len(params) || params[i] == nil {
+			continue
+		}
+
+		b, err := json.Marshal(params[i])
+		if err != nil {
+			return ErrInvalidParams.Wrap(err)
+		}
+
+		if err := json.Unmarshal(b, target); err != nil {
+			return ErrInvalidParams.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// parseBlockNumber parses a block number, which is either a JSON number or a hex encoded string.
+func parseBlockNumber(raw json.RawMessage) (uint64, error) {
+	var s string
+
+	if err := json.Unmarshal(raw, &s); err != nil {
+		n, err := strconv.ParseUint(string(raw), 10, 64)
+		if err != nil {
+			return 0, ErrInvalidParams.Wrap(err)
+		}
+
+		return n, nil
+	}
+
+	n, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+	if err != nil {
+		return 0, ErrInvalidParams.Wrap(err)
+	}
+
+	return n, nil
+}
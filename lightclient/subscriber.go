@@ -0,0 +1,157 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lightclient
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// maxQueuedNotifications is the number of notifications that are queued for a subscriber that does not keep up,
+// after which the subscription ends with gethrpc.ErrSubscriptionQueueOverflow. It matches the limit of gethrpc.
+const maxQueuedNotifications = 20000
+
+// subscriber sends the notifications of a served subscription to its channel in order, so that the client never
+// blocks on subscribers that do not keep up.
+type subscriber struct {
+	sub     *gethrpc.ClientSubscription
+	channel reflect.Value
+
+	mu    sync.Mutex
+	queue []reflect.Value
+
+	wake     chan struct{}
+	quit     chan struct{}
+	quitOnce sync.Once
+	done     chan struct{}
+}
+
+// newSubscriber creates a subscriber for the channel whose subscription has the given ID, onUnsubscribe is called
+// once the subscription is unsubscribed.
+func newSubscriber(id string, channel interface{}, onUnsubscribe func()) (*subscriber, error) {
+	ch := reflect.ValueOf(channel)
+	if ch.Kind() != reflect.Chan || ch.Type().ChanDir()&reflect.SendDir == 0 {
+		return nil, client.ErrTransportChannel
+	}
+
+	s := &subscriber{
+		channel: ch,
+		wake:    make(chan struct{}, 1),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	// Unsubscribe waits for the subscriber to exit, so that the channel can be closed afterwards.
+	s.sub = gethrpc.NewDetachedClientSubscription(func() string { return id }, func() {
+		s.stop()
+		onUnsubscribe()
+
+		<-s.done
+	})
+
+	go s.run()
+
+	return s, nil
+}
+
+// push queues the notification, which is converted into the element type of the channel via its JSON encoding. The
+// subscription ends if the conversion fails or the queue is full.
+func (s *subscriber) push(notification interface{}) {
+	select {
+	case <-s.quit:
+		return
+	default:
+	}
+
+	v := reflect.New(s.channel.Type().Elem())
+
+	if err := assign(v.Interface(), notification); err != nil {
+		s.fail(err)
+
+		return
+	}
+
+	s.mu.Lock()
+
+	if len(s.queue) >= maxQueuedNotifications {
+		s.mu.Unlock()
+		s.fail(gethrpc.ErrSubscriptionQueueOverflow)
+
+		return
+	}
+
+	s.queue = append(s.queue, v.Elem())
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// fail ends the subscription with err.
+func (s *subscriber) fail(err error) {
+	s.stop()
+	s.sub.Fail(err)
+}
+
+// close ends the subscription because the client was closed.
+func (s *subscriber) close() {
+	s.fail(gethrpc.ErrClientQuit)
+}
+
+func (s *subscriber) stop() {
+	s.quitOnce.Do(func() {
+		close(s.quit)
+	})
+}
+
+func (s *subscriber) run() {
+	defer close(s.done)
+
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: s.channel},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(s.quit)},
+	}
+
+	for {
+		s.mu.Lock()
+
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+
+			select {
+			case <-s.wake:
+				continue
+			case <-s.quit:
+				return
+			}
+		}
+
+		cases[0].Send = s.queue[0]
+		s.queue = s.queue[1:]
+
+		s.mu.Unlock()
+
+		if chosen, _, _ := reflect.Select(cases); chosen == 1 {
+			return
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lightclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const submitAndWatchMethod = "author_submitAndWatchExtrinsic"
+
+// subscribeHandler serves a legacy subscription, the notifications are sent to channel.
+type subscribeHandler func(
+	ctx context.Context,
+	channel interface{},
+	params []interface{},
+) (*gethrpc.ClientSubscription, error)
+
+func (c *Client) subscribeHandlers() map[string]subscribeHandler {
+	return map[string]subscribeHandler{
+		newHeadsMethod:       c.subscribeFollowed(newHeadsMethod),
+		finalizedHeadsMethod: c.subscribeFollowed(finalizedHeadsMethod),
+		runtimeVersionMethod: c.subscribeFollowed(runtimeVersionMethod),
+		submitAndWatchMethod: c.submitAndWatchExtrinsic,
+	}
+}
+
+// subscribeFollowed returns the handler of a subscription that is served from the events of the follow subscription.
+// New subscribers receive the current head or runtime version right away, once it is known.
+func (c *Client) subscribeFollowed(method string) subscribeHandler {
+	return func(_ context.Context, channel interface{}, _ []interface{}) (*gethrpc.ClientSubscription, error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.ctx.Err() != nil {
+			return nil, ErrClientClosed
+		}
+
+		var s *subscriber
+
+		s, err := newSubscriber(c.nextSubscriptionID(), channel, func() {
+			c.removeSubscriber(method, s)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if c.subscribers[method] == nil {
+			c.subscribers[method] = make(map[*subscriber]struct{})
+		}
+
+		c.subscribers[method][s] = struct{}{}
+
+		if c.follow != nil {
+			c.pushCurrent(method, s)
+		}
+
+		return s.sub, nil
+	}
+}
+
+// pushCurrent sends the current head or runtime version to a new subscriber. The caller must hold the lock.
+func (c *Client) pushCurrent(method string, s *subscriber) {
+	switch method {
+	case newHeadsMethod:
+		s.push(c.blocks[c.best].header)
+	case finalizedHeadsMethod:
+		s.push(c.blocks[c.finalized[len(c.finalized)-1]].header)
+	case runtimeVersionMethod:
+		if runtime := c.blocks[c.best].runtime; runtime != nil {
+			s.push(runtimeVersion(runtime))
+		}
+	}
+}
+
+func (c *Client) removeSubscriber(method string, s *subscriber) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.subscribers[method], s)
+}
+
+func (c *Client) nextSubscriptionID() string {
+	return fmt.Sprintf("0x%x", c.subID.Add(1))
+}
+
+// notify sends the notification to the subscribers of the method. The caller must hold the lock.
+func (c *Client) notify(method string, notification interface{}) {
+	for s := range c.subscribers[method] {
+		s.push(notification)
+	}
+}
+
+// notifyRuntime sends the runtime version of the block to the subscribers of runtime versions, unless the runtime
+// is invalid. The caller must hold the lock.
+func (c *Client) notifyRuntime(b *block) {
+	if b.runtime != nil {
+		c.notify(runtimeVersionMethod, runtimeVersion(b.runtime))
+	}
+}
+
+// submitAndWatchExtrinsic submits the extrinsic via transactionWatch_v1_submitAndWatch and sends its events as
+// extrinsic statuses.
+func (c *Client) submitAndWatchExtrinsic(
+	ctx context.Context,
+	channel interface{},
+	params []interface{},
+) (*gethrpc.ClientSubscription, error) {
+	var xt string
+
+	if err := decodeParams(params, &xt); err != nil {
+		return nil, err
+	}
+
+	events := make(chan types.TransactionWatchEvent)
+
+	inner, err := c.Client.Subscribe(ctx, "transactionWatch", "v1_submitAndWatch", "v1_unwatch", "v1_watchEvent",
+		events, xt)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newSubscriber(inner.ID(), channel, inner.Unsubscribe)
+	if err != nil {
+		inner.Unsubscribe()
+
+		return nil, err
+	}
+
+	go forwardStatuses(inner, events, s)
+
+	return s.sub, nil
+}
+
+// forwardStatuses sends the events of the transaction watch as extrinsic statuses to the subscriber, the watch is
+// ended after the final event.
+func forwardStatuses(
+	inner *gethrpc.ClientSubscription,
+	events <-chan types.TransactionWatchEvent,
+	s *subscriber,
+) {
+	var inBlock types.Hash
+
+	for {
+		select {
+		case <-s.quit:
+			return
+		case err, ok := <-inner.Err():
+			switch {
+			case !ok:
+			case err == nil:
+				s.close()
+			default:
+				s.fail(err)
+			}
+
+			return
+		case event := <-events:
+			s.push(extrinsicStatus(event, &inBlock))
+
+			if event.IsFinal() {
+				inner.Unsubscribe()
+
+				return
+			}
+		}
+	}
+}
+
+// extrinsicStatus converts the transaction watch event into the corresponding extrinsic status. inBlock holds the
+// hash of the block the extrinsic was last included in, which is reported when the extrinsic is retracted.
+func extrinsicStatus(event types.TransactionWatchEvent, inBlock *types.Hash) types.ExtrinsicStatus {
+	switch event.Event {
+	case types.TransactionWatchValidated:
+		return types.ExtrinsicStatus{IsReady: true}
+	case types.TransactionWatchBestChainBlockIncluded:
+		if event.Block == nil {
+			return types.ExtrinsicStatus{IsRetracted: true, AsRetracted: *inBlock}
+		}
+
+		*inBlock = event.Block.Hash
+
+		return types.ExtrinsicStatus{IsInBlock: true, AsInBlock: event.Block.Hash}
+	case types.TransactionWatchFinalized:
+		status := types.ExtrinsicStatus{IsFinalized: true, AsFinalized: *inBlock}
+
+		if event.Block != nil {
+			status.AsFinalized = event.Block.Hash
+		}
+
+		return status
+	case types.TransactionWatchInvalid:
+		return types.ExtrinsicStatus{IsInvalid: true}
+	default:
+		return types.ExtrinsicStatus{IsDropped: true}
+	}
+}
+
+// runtimeVersion converts the runtime specification reported by the follow subscription into a runtime version.
+func runtimeVersion(spec *types.ChainHeadRuntimeSpec) types.RuntimeVersion {
+	v := types.RuntimeVersion{
+		APIs:               make([]types.RuntimeVersionAPI, 0, len(spec.APIs)),
+		ImplName:           spec.ImplName,
+		ImplVersion:        spec.ImplVersion,
+		SpecName:           spec.SpecName,
+		SpecVersion:        spec.SpecVersion,
+		TransactionVersion: spec.TransactionVersion,
+	}
+
+	for id, version := range spec.APIs {
+		v.APIs = append(v.APIs, types.RuntimeVersionAPI{APIID: id, Version: version})
+	}
+
+	sort.Slice(v.APIs, func(i, j int) bool {
+		return v.APIs[i].APIID < v.APIs[j].APIID
+	})
+
+	return v
+}
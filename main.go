@@ -17,13 +17,22 @@
 package gsrpc
 
 import (
+	"sync"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/lightclient"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
 )
 
 type SubstrateAPI struct {
 	RPC    *rpc.RPC
 	Client client.Client
+
+	metadataCacheMu sync.Mutex
+	metadataCache   *metadataCache
+
+	chainInfoMu sync.Mutex
+	chainInfo   *ChainInfo
 }
 
 func NewSubstrateAPI(url string) (*SubstrateAPI, error) {
@@ -32,6 +41,80 @@ func NewSubstrateAPI(url string) (*SubstrateAPI, error) {
 		return nil, err
 	}
 
+	return newSubstrateAPI(cl)
+}
+
+// NewSubstrateAPIWithOptions is like NewSubstrateAPI but the client connects using the provided options, e.g. to pass
+// API keys or client certificates to managed RPC providers, see client.ConnectWithOptions.
+func NewSubstrateAPIWithOptions(url string, opts client.ConnectionOptions) (*SubstrateAPI, error) {
+	cl, err := client.ConnectWithOptions(url, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSubstrateAPI(cl)
+}
+
+// NewSubstrateAPIWithReconnect is like NewSubstrateAPI but the client reconnects automatically once the connection
+// is lost, see client.ConnectWithReconnect.
+func NewSubstrateAPIWithReconnect(url string, opts client.ReconnectOptions) (*SubstrateAPI, error) {
+	cl, err := client.ConnectWithReconnect(url, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSubstrateAPI(cl)
+}
+
+// NewSubstrateAPIWithFailover is like NewSubstrateAPI but the client fails over between the provided endpoints, see
+// client.ConnectWithFailover.
+func NewSubstrateAPIWithFailover(urls []string, opts client.FailoverOptions) (*SubstrateAPI, error) {
+	cl, err := client.ConnectWithFailover(urls, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSubstrateAPI(cl)
+}
+
+// NewSubstrateAPIWithTimeouts is like NewSubstrateAPI but the client applies the provided timeouts, see
+// client.ConnectWithTimeouts.
+func NewSubstrateAPIWithTimeouts(url string, opts client.TimeoutOptions) (*SubstrateAPI, error) {
+	cl, err := client.ConnectWithTimeouts(url, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSubstrateAPI(cl)
+}
+
+// NewSubstrateAPIWithLightClient is like NewSubstrateAPI but connects to the JSON-RPC server of a light client such as
+// smoldot on the given network address, e.g. "tcp" and "127.0.0.1:9945", see client.ConnectSmoldot. The legacy methods
+// the light client does not expose are served via the new JSON-RPC API, see lightclient.Client.
+func NewSubstrateAPIWithLightClient(network, address string, opts lightclient.Options) (*SubstrateAPI, error) {
+	cl, err := client.ConnectSmoldot(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	lc, err := lightclient.New(cl, opts)
+	if err != nil {
+		cl.Close()
+
+		return nil, err
+	}
+
+	api, err := newSubstrateAPI(lc)
+	if err != nil {
+		lc.Close()
+
+		return nil, err
+	}
+
+	return api, nil
+}
+
+func newSubstrateAPI(cl client.Client) (*SubstrateAPI, error) {
 	newRPC, err := rpc.NewRPC(cl)
 	if err != nil {
 		return nil, err
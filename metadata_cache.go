@@ -0,0 +1,288 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsrpc
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/submit"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	ErrMetadataCacheEnabled   = libErr.Error("metadata cache already enabled")
+	ErrMetadataCacheDisabled  = libErr.Error("metadata cache not enabled")
+	ErrMetadataCacheStale     = libErr.Error("metadata cache stale")
+	ErrMetadataCacheSubscribe = libErr.Error("metadata cache runtime version subscription")
+	ErrMetadataCacheRefresh   = libErr.Error("metadata cache refresh")
+)
+
+const defaultMetadataCacheRetryInterval = 5 * time.Second
+
+// RuntimeUpgrade describes a runtime upgrade that was observed by the metadata cache.
+type RuntimeUpgrade struct {
+	OldSpecVersion types.U32
+	NewSpecVersion types.U32
+
+	// Metadata is the metadata of the new runtime.
+	Metadata *types.Metadata
+}
+
+// MetadataCacheOptions configure the metadata cache enabled via SubstrateAPI.EnableMetadataCache.
+type MetadataCacheOptions struct {
+	// RetryInterval is the delay before a failed metadata refresh is retried, it defaults to 5 seconds.
+	RetryInterval time.Duration
+
+	// OnUpgrade, if set, is called once the metadata of a new runtime was cached. It must not block.
+	OnUpgrade func(upgrade RuntimeUpgrade)
+
+	// OnError, if set, is called when refreshing the metadata failed or the runtime version subscription ended with
+	// an error. It must not block.
+	OnError func(err error)
+}
+
+// runtimeSnapshot is the runtime version and the metadata of a runtime. Snapshots are never modified once they are
+// cached, a refresh replaces the whole snapshot instead.
+type runtimeSnapshot struct {
+	version types.RuntimeVersion
+	meta    *types.Metadata // nil if the last refresh failed
+}
+
+// metadataCache keeps the snapshot of the latest runtime up to date via state_subscribeRuntimeVersion.
+type metadataCache struct {
+	api  *SubstrateAPI
+	sub  *state.RuntimeVersionSubscription
+	opts MetadataCacheOptions
+
+	snapshot atomic.Pointer[runtimeSnapshot]
+	done     chan struct{}
+}
+
+// EnableMetadataCache subscribes to runtime version changes and caches the metadata of the latest runtime, which is
+// refreshed transparently after every runtime upgrade. The cached metadata can be retrieved via GetMetadataCached and
+// is used by the submitters created via NewSubmitter. The cache is disabled once ctx is done, the subscription ended
+// or DisableMetadataCache was called.
+func (api *SubstrateAPI) EnableMetadataCache(ctx context.Context, opts MetadataCacheOptions) error {
+	api.metadataCacheMu.Lock()
+	defer api.metadataCacheMu.Unlock()
+
+	if api.metadataCache != nil && !api.metadataCache.stopped() {
+		return ErrMetadataCacheEnabled
+	}
+
+	if opts.RetryInterval == 0 {
+		opts.RetryInterval = defaultMetadataCacheRetryInterval
+	}
+
+	// Subscribing before fetching the initial snapshot ensures that no runtime upgrade is missed in between.
+	sub, err := api.RPC.State.SubscribeRuntimeVersionContext(ctx)
+	if err != nil {
+		return ErrMetadataCacheSubscribe.Wrap(err)
+	}
+
+	c := &metadataCache{api: api, sub: sub, opts: opts, done: make(chan struct{})}
+
+	snapshot, err := c.fetch(ctx)
+	if err != nil {
+		sub.Unsubscribe()
+		return ErrMetadataCacheRefresh.Wrap(err)
+	}
+
+	c.snapshot.Store(snapshot)
+
+	go c.run(ctx)
+
+	api.metadataCache = c
+
+	return nil
+}
+
+// DisableMetadataCache stops refreshing the cached metadata and unsubscribes from runtime version changes. It can
+// safely be called if the cache is not enabled.
+func (api *SubstrateAPI) DisableMetadataCache() {
+	api.metadataCacheMu.Lock()
+	c := api.metadataCache
+	api.metadataCache = nil
+	api.metadataCacheMu.Unlock()
+
+	if c == nil {
+		return
+	}
+
+	c.sub.Unsubscribe()
+	<-c.done
+}
+
+// GetMetadataCached returns the cached metadata of the latest runtime, see EnableMetadataCache. The returned metadata
+// is a snapshot that is not modified by later refreshes, so it is consistent for the whole duration of e.g. building
+// an extrinsic. It must not be modified by the caller.
+func (api *SubstrateAPI) GetMetadataCached() (*types.Metadata, error) {
+	_, meta, err := api.GetRuntimeCached()
+
+	return meta, err
+}
+
+// GetRuntimeCached is like GetMetadataCached but also returns the runtime version the metadata belongs to.
+func (api *SubstrateAPI) GetRuntimeCached() (types.RuntimeVersion, *types.Metadata, error) {
+	api.metadataCacheMu.Lock()
+	c := api.metadataCache
+	api.metadataCacheMu.Unlock()
+
+	if c == nil || c.stopped() {
+		return types.RuntimeVersion{}, nil, ErrMetadataCacheDisabled
+	}
+
+	snapshot := c.snapshot.Load()
+	if snapshot.meta == nil {
+		return types.RuntimeVersion{}, nil, ErrMetadataCacheStale
+	}
+
+	return snapshot.version, snapshot.meta, nil
+}
+
+// NewSubmitter creates a submit.Submitter that uses the RPCs of the API. If the metadata cache is enabled, the cached
+// metadata is used instead of retrieving it again, see EnableMetadataCache.
+func (api *SubstrateAPI) NewSubmitter(registryFactory registry.Factory) submit.Submitter {
+	return submit.NewSubmitterWithRuntimeCache(
+		api.RPC.State,
+		api.RPC.System,
+		api.RPC.Chain,
+		api.RPC.Author,
+		registryFactory,
+		api,
+	)
+}
+
+// getMetadata returns the metadata at the given block, which is taken from the metadata cache if it holds the runtime
+// of the block.
+func (api *SubstrateAPI) getMetadata(ctx context.Context, blockHash types.Hash) (*types.Metadata, error) {
+	if cachedVersion, meta, err := api.GetRuntimeCached(); err == nil {
+		version, err := api.RPC.State.GetRuntimeVersionContext(ctx, blockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		if version.SpecVersion == cachedVersion.SpecVersion {
+			return meta, nil
+		}
+	}
+
+	return api.RPC.State.GetMetadataContext(ctx, blockHash)
+}
+
+func (c *metadataCache) stopped() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *metadataCache) run(ctx context.Context) {
+	defer close(c.done)
+
+	var retry <-chan time.Time
+
+	for {
+		select {
+		case version, ok := <-c.sub.Chan():
+			if !ok {
+				return
+			}
+
+			current := c.snapshot.Load()
+
+			if current.meta != nil &&
+				version.SpecVersion == current.version.SpecVersion &&
+				version.TransactionVersion == current.version.TransactionVersion {
+				continue
+			}
+		case <-c.sub.Gap():
+		case <-retry:
+		case err, ok := <-c.sub.Err():
+			if ok && err != nil {
+				c.reportError(ErrMetadataCacheSubscribe.Wrap(err))
+			}
+
+			return
+		}
+
+		retry = nil
+
+		if !c.refresh(ctx) {
+			retry = time.After(c.opts.RetryInterval)
+		}
+	}
+}
+
+// refresh replaces the cached snapshot with the one of the latest runtime. If that fails, the cache is marked as
+// stale until the next successful refresh, so that no outdated metadata is handed out.
+func (c *metadataCache) refresh(ctx context.Context) bool {
+	old := c.snapshot.Load()
+
+	snapshot, err := c.fetch(ctx)
+	if err != nil {
+		c.snapshot.Store(&runtimeSnapshot{version: old.version})
+		c.reportError(ErrMetadataCacheRefresh.Wrap(err))
+
+		return false
+	}
+
+	c.snapshot.Store(snapshot)
+
+	if c.opts.OnUpgrade != nil && snapshot.version.SpecVersion != old.version.SpecVersion {
+		c.opts.OnUpgrade(RuntimeUpgrade{
+			OldSpecVersion: old.version.SpecVersion,
+			NewSpecVersion: snapshot.version.SpecVersion,
+			Metadata:       snapshot.meta,
+		})
+	}
+
+	return true
+}
+
+// fetch retrieves the runtime version and the metadata at the same block, so that they always belong together.
+func (c *metadataCache) fetch(ctx context.Context) (*runtimeSnapshot, error) {
+	blockHash, err := c.api.RPC.Chain.GetBlockHashLatestContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := c.api.RPC.State.GetRuntimeVersionContext(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := c.api.RPC.State.GetMetadataContext(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &runtimeSnapshot{version: *version, meta: meta}, nil
+}
+
+func (c *metadataCache) reportError(err error) {
+	if c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
@@ -0,0 +1,142 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsrpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testRuntimeVersion   = types.RuntimeVersion{SpecName: "test", SpecVersion: 1, TransactionVersion: 1}
+	testUpgradedVersion  = types.RuntimeVersion{SpecName: "test", SpecVersion: 2, TransactionVersion: 1}
+	testMetadataCacheErr = rpcmocksrv.FixtureError{Code: -32000, Message: "unavailable"}
+)
+
+func TestSubstrateAPI_MetadataCache(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Notify("state_subscribeRuntimeVersion", []interface{}{testRuntimeVersion, testUpgradedVersion}).
+		Respond("chain_getBlockHash", testBlockHash.Hex()).
+		Respond("state_getRuntimeVersion", testRuntimeVersion).
+		Respond("state_getRuntimeVersion", testUpgradedVersion)
+
+	api := newTestSubstrateAPI(t, cl)
+
+	_, err := api.GetMetadataCached()
+	assert.ErrorIs(t, err, gsrpc.ErrMetadataCacheDisabled)
+
+	upgrades := make(chan gsrpc.RuntimeUpgrade, 1)
+
+	err = api.EnableMetadataCache(context.Background(), gsrpc.MetadataCacheOptions{
+		OnUpgrade: func(upgrade gsrpc.RuntimeUpgrade) { upgrades <- upgrade },
+	})
+	require.NoError(t, err)
+	defer api.DisableMetadataCache()
+
+	err = api.EnableMetadataCache(context.Background(), gsrpc.MetadataCacheOptions{})
+	assert.ErrorIs(t, err, gsrpc.ErrMetadataCacheEnabled)
+
+	select {
+	case upgrade := <-upgrades:
+		assert.Equal(t, types.U32(1), upgrade.OldSpecVersion)
+		assert.Equal(t, types.U32(2), upgrade.NewSpecVersion)
+		assert.NotNil(t, upgrade.Metadata)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no runtime upgrade observed")
+	}
+
+	version, meta, err := api.GetRuntimeCached()
+	assert.NoError(t, err)
+	assert.Equal(t, testUpgradedVersion, version)
+	assert.NotNil(t, meta)
+
+	cl.AssertCalled(t, "state_getMetadata", testBlockHash.Hex())
+}
+
+func TestSubstrateAPI_MetadataCache_Disable(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Notify("state_subscribeRuntimeVersion", []interface{}{testRuntimeVersion}).
+		Respond("chain_getBlockHash", testBlockHash.Hex()).
+		Respond("state_getRuntimeVersion", testRuntimeVersion)
+
+	api := newTestSubstrateAPI(t, cl)
+
+	err := api.EnableMetadataCache(context.Background(), gsrpc.MetadataCacheOptions{})
+	require.NoError(t, err)
+
+	meta, err := api.GetMetadataCached()
+	assert.NoError(t, err)
+	assert.NotNil(t, meta)
+
+	api.DisableMetadataCache()
+
+	_, err = api.GetMetadataCached()
+	assert.ErrorIs(t, err, gsrpc.ErrMetadataCacheDisabled)
+
+	// The cache can be enabled again once it was disabled.
+	err = api.EnableMetadataCache(context.Background(), gsrpc.MetadataCacheOptions{})
+	assert.NoError(t, err)
+
+	api.DisableMetadataCache()
+}
+
+func TestSubstrateAPI_MetadataCache_RefreshError(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Notify("state_subscribeRuntimeVersion", []interface{}{testUpgradedVersion}).
+		Respond("chain_getBlockHash", testBlockHash.Hex()).
+		Respond("state_getRuntimeVersion", testRuntimeVersion).
+		RespondError("state_getRuntimeVersion", testMetadataCacheErr)
+
+	api := newTestSubstrateAPI(t, cl)
+
+	errs := make(chan error, 1)
+
+	err := api.EnableMetadataCache(context.Background(), gsrpc.MetadataCacheOptions{
+		RetryInterval: time.Hour,
+		OnError:       func(err error) { errs <- err },
+	})
+	require.NoError(t, err)
+	defer api.DisableMetadataCache()
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, gsrpc.ErrMetadataCacheRefresh)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no refresh error reported")
+	}
+
+	// The metadata of the old runtime must not be handed out once an upgrade was announced.
+	_, err = api.GetMetadataCached()
+	assert.ErrorIs(t, err, gsrpc.ErrMetadataCacheStale)
+}
+
+func TestSubstrateAPI_MetadataCache_SubscribeError(t *testing.T) {
+	api := newTestSubstrateAPI(t, rpcmocksrv.NewMockClient())
+
+	err := api.EnableMetadataCache(context.Background(), gsrpc.MetadataCacheOptions{})
+	assert.ErrorIs(t, err, gsrpc.ErrMetadataCacheSubscribe)
+
+	_, err = api.GetMetadataCached()
+	assert.ErrorIs(t, err, gsrpc.ErrMetadataCacheDisabled)
+}
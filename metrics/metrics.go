@@ -0,0 +1,89 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics defines the recorder that the components, e.g. the clients, the retrievers and the submitter, report
+// library-level metrics to. It does not depend on a metrics library, the promgsrpc module provides a Recorder that
+// exposes the metrics to Prometheus.
+package metrics
+
+import "time"
+
+// Recorder records the metrics reported by the components. Its methods are called concurrently and must not block.
+type Recorder interface {
+	// CallCompleted is called for every call, batch call and subscription request that a client sent to the node.
+	CallCompleted(call Call)
+
+	// SubscriptionStarted and SubscriptionEnded are called when a subscription with the subscribe method, e.g.
+	// chain_subscribeNewHead, was established and once it ended.
+	SubscriptionStarted(method string)
+	SubscriptionEnded(method string)
+
+	// NotificationReceived is called for every notification of a subscription with the subscribe method, size is the
+	// size of the JSON encoded notification or -1 if it could not be encoded.
+	NotificationReceived(method string, size int)
+
+	// NotificationDropped is called for every notification of a subscription with the subscribe method that was
+	// dropped because its consumer did not keep up.
+	NotificationDropped(method string)
+
+	// Reconnected is called whenever a client connected to the endpoint after the connection was lost or the client
+	// failed over to it.
+	Reconnected(endpoint string)
+
+	// EventsDecoded is called with the number of events that were decoded from the events of a block and the duration
+	// of the decoding.
+	EventsDecoded(count int, duration time.Duration)
+
+	// RegistryLookup is called whenever the component, e.g. event_retriever, looked up the registries of the runtime
+	// it decodes with. It is a hit if the cached registries could be used, and a miss if they had to be created.
+	RegistryLookup(component string, hit bool)
+
+	// ExtrinsicOutcome is called with the terminal status of every extrinsic that was watched until it was included
+	// in a block, see the Outcome constants.
+	ExtrinsicOutcome(outcome Outcome)
+}
+
+// Call is a call, batch call or subscription request that was sent to the node.
+type Call struct {
+	// Method is the called method, "batch" for batch calls or the subscribe method for subscription requests.
+	Method string
+
+	// Code is the outcome of the call: "ok" on success, the JSON-RPC error code for errors returned by the node,
+	// and a short description for all other errors, see client.ErrorCode.
+	Code string
+
+	Duration time.Duration
+
+	// RequestSize and ResponseSize are the sizes of the JSON encoded params and result. They are -1 if unknown, the
+	// response size is only known for successful calls.
+	RequestSize  int
+	ResponseSize int
+}
+
+// Outcome is the terminal status of a watched extrinsic.
+type Outcome string
+
+const (
+	OutcomeInBlock         Outcome = "in_block"
+	OutcomeFinalized       Outcome = "finalized"
+	OutcomeUsurped         Outcome = "usurped"
+	OutcomeDropped         Outcome = "dropped"
+	OutcomeInvalid         Outcome = "invalid"
+	OutcomeRetracted       Outcome = "retracted"
+	OutcomeFinalityTimeout Outcome = "finality_timeout"
+	OutcomeCanceled        Outcome = "canceled"
+	OutcomeError           Outcome = "error"
+)
@@ -0,0 +1,329 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"math/bits"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/sha3"
+)
+
+// VerifyProof verifies the provided MMR proof for a batch of leaves against the MMR root.
+//
+// The proof can be retrieved via mmr_generateProof and the root via mmr_root or from the BEEFY commitment payload
+// of the block the proof was generated for. No RPC connection is needed for the verification itself.
+//
+// Leaves and nodes are hashed with keccak256, like in the MMR used for BEEFY.
+func VerifyProof(root types.H256, proof types.MMRLeavesProof) error {
+	leaves := make([][]byte, len(proof.Leaves))
+	for i, leaf := range proof.Leaves {
+		leaves[i] = leaf
+	}
+
+	return verify(root, leaves, proof.Proof)
+}
+
+// VerifyLeafProof verifies the MMR proof of a single leaf, as returned by the legacy mmr_generateProof taking a leaf
+// index, against the MMR root. See VerifyProof for more details.
+func VerifyLeafProof(root types.H256, leaf types.MMRLeaf, proof types.MMRProof) error {
+	encodedLeaf, err := codec.Encode(leaf)
+	if err != nil {
+		return ErrLeafEncoding.Wrap(err)
+	}
+
+	return verify(root, [][]byte{encodedLeaf}, types.MMRBatchProof{
+		LeafIndices: []types.U64{proof.LeafIndex},
+		LeafCount:   proof.LeafCount,
+		Items:       proof.Items,
+	})
+}
+
+// CalculateRoot calculates the MMR root from the provided leaves and their proof, the leaves are SCALE encoded and in
+// the order of the proof's leaf indices.
+func CalculateRoot(leaves [][]byte, proof types.MMRBatchProof) (types.H256, error) {
+	if len(leaves) == 0 || len(leaves) != len(proof.LeafIndices) {
+		return types.H256{}, ErrLeavesMissing
+	}
+
+	if proof.LeafCount == 0 {
+		return types.H256{}, ErrInvalidProof.WithMsg("empty MMR")
+	}
+
+	nodes := make([]node, len(leaves))
+	for i, leaf := range leaves {
+		nodes[i] = node{pos: leafIndexToPos(uint64(proof.LeafIndices[i])), hash: keccak(leaf)}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].pos < nodes[j].pos
+	})
+
+	size := leafIndexToMMRSize(uint64(proof.LeafCount) - 1)
+
+	if len(nodes) == 1 && size == 1 && nodes[0].pos == 0 {
+		return nodes[0].hash, nil
+	}
+
+	items := proof.Items
+	next := func() (types.H256, bool) {
+		if len(items) == 0 {
+			return types.H256{}, false
+		}
+
+		item := items[0]
+		items = items[1:]
+
+		return item, true
+	}
+
+	var peakHashes []types.H256
+
+	for _, peakPos := range peaks(size) {
+		var peakNodes []node
+		for len(nodes) > 0 && nodes[0].pos <= peakPos {
+			peakNodes = append(peakNodes, nodes[0])
+			nodes = nodes[1:]
+		}
+
+		switch {
+		case len(peakNodes) == 1 && peakNodes[0].pos == peakPos:
+			peakHashes = append(peakHashes, peakNodes[0].hash)
+		case len(peakNodes) == 0:
+			if item, ok := next(); ok {
+				peakHashes = append(peakHashes, item)
+			}
+		default:
+			peakRoot, err := calculatePeakRoot(peakNodes, peakPos, next)
+			if err != nil {
+				return types.H256{}, err
+			}
+
+			peakHashes = append(peakHashes, peakRoot)
+		}
+	}
+
+	if len(nodes) > 0 {
+		return types.H256{}, ErrInvalidProof.WithMsg("leaves outside of the MMR")
+	}
+
+	// the peaks to the right of the leaves are bagged into a single proof item
+	if item, ok := next(); ok {
+		peakHashes = append(peakHashes, item)
+	}
+
+	if len(items) > 0 {
+		return types.H256{}, ErrInvalidProof.WithMsg("unused proof items")
+	}
+
+	return bagPeaks(peakHashes)
+}
+
+func verify(root types.H256, leaves [][]byte, proof types.MMRBatchProof) error {
+	calculated, err := CalculateRoot(leaves, proof)
+	if err != nil {
+		return err
+	}
+
+	if calculated != root {
+		return ErrRootMismatch.WithMsg("expected %s, calculated %s", root.Hex(), calculated.Hex())
+	}
+
+	return nil
+}
+
+// node is a node of the MMR with its position in the MMR.
+type node struct {
+	pos    uint64
+	hash   types.H256
+	height int
+}
+
+// calculatePeakRoot merges the nodes of a single peak with the proof items up to the peak.
+func calculatePeakRoot(nodes []node, peakPos uint64, next func() (types.H256, bool)) (types.H256, error) {
+	queue := nodes
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		if n.pos == peakPos {
+			if len(queue) > 0 {
+				return types.H256{}, ErrInvalidProof.WithMsg("nodes above peak")
+			}
+
+			return n.hash, nil
+		}
+
+		var parent node
+
+		if posHeight(n.pos+1) > n.height {
+			// n is the right sibling
+			sibling, err := siblingHash(&queue, n.pos-siblingOffset(n.height), next)
+			if err != nil {
+				return types.H256{}, err
+			}
+
+			parent = node{pos: n.pos + 1, hash: merge(sibling, n.hash), height: n.height + 1}
+		} else {
+			// n is the left sibling
+			sibling, err := siblingHash(&queue, n.pos+siblingOffset(n.height), next)
+			if err != nil {
+				return types.H256{}, err
+			}
+
+			parent = node{pos: n.pos + parentOffset(n.height), hash: merge(n.hash, sibling), height: n.height + 1}
+		}
+
+		if parent.pos > peakPos {
+			return types.H256{}, ErrInvalidProof.WithMsg("node beyond peak")
+		}
+
+		queue = append(queue, parent)
+	}
+
+	return types.H256{}, ErrInvalidProof.WithMsg("peak not reached")
+}
+
+// siblingHash takes the sibling at pos from the queue if it is next, otherwise from the proof items.
+func siblingHash(queue *[]node, pos uint64, next func() (types.H256, bool)) (types.H256, error) {
+	if len(*queue) > 0 && (*queue)[0].pos == pos {
+		sibling := (*queue)[0]
+		*queue = (*queue)[1:]
+
+		return sibling.hash, nil
+	}
+
+	item, ok := next()
+	if !ok {
+		return types.H256{}, ErrInvalidProof.WithMsg("missing proof items")
+	}
+
+	return item, nil
+}
+
+// bagPeaks folds the peaks from right to left into the root.
+func bagPeaks(peakHashes []types.H256) (types.H256, error) {
+	if len(peakHashes) == 0 {
+		return types.H256{}, ErrInvalidProof.WithMsg("no peaks")
+	}
+
+	for len(peakHashes) > 1 {
+		right := peakHashes[len(peakHashes)-1]
+		left := peakHashes[len(peakHashes)-2]
+		peakHashes = append(peakHashes[:len(peakHashes)-2], merge(right, left))
+	}
+
+	return peakHashes[0], nil
+}
+
+func merge(left, right types.H256) types.H256 {
+	return keccak(left[:], right[:])
+}
+
+func keccak(data ...[]byte) types.H256 {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+
+	return types.NewH256(h.Sum(nil))
+}
+
+// leafIndexToPos returns the position of the leaf with the given index in the MMR.
+func leafIndexToPos(index uint64) uint64 {
+	return leafIndexToMMRSize(index) - uint64(bits.TrailingZeros64(index+1)) - 1
+}
+
+// leafIndexToMMRSize returns the number of nodes of the MMR once the leaf with the given index was added.
+func leafIndexToMMRSize(index uint64) uint64 {
+	leaves := index + 1
+
+	return 2*leaves - uint64(bits.OnesCount64(leaves))
+}
+
+// posHeight returns the height of the node at pos, leaves have height 0.
+func posHeight(pos uint64) int {
+	pos++
+
+	for !allOnes(pos) {
+		pos -= (uint64(1) << (bits.Len64(pos) - 1)) - 1
+	}
+
+	return bits.Len64(pos) - 1
+}
+
+func allOnes(num uint64) bool {
+	return num != 0 && bits.OnesCount64(num) == bits.Len64(num)
+}
+
+func parentOffset(height int) uint64 {
+	return 2 << height
+}
+
+func siblingOffset(height int) uint64 {
+	return (2 << height) - 1
+}
+
+// peaks returns the positions of the peaks of a MMR with the given number of nodes, from left to right.
+func peaks(size uint64) []uint64 {
+	if size == 0 {
+		return nil
+	}
+
+	height, pos := leftPeak(size)
+	positions := []uint64{pos}
+
+	for height > 0 {
+		// move to the right sibling and descend until the position is part of the MMR
+		pos += siblingOffset(height)
+
+		for pos > size-1 {
+			if height == 0 {
+				return positions
+			}
+
+			pos -= parentOffset(height - 1)
+			height--
+		}
+
+		positions = append(positions, pos)
+	}
+
+	return positions
+}
+
+// leftPeak returns the height and position of the highest, leftmost peak of a MMR with the given number of nodes.
+func leftPeak(size uint64) (int, uint64) {
+	height := 1
+	prevPos := uint64(0)
+	pos := peakPosByHeight(height)
+
+	for pos < size {
+		height++
+		prevPos = pos
+		pos = peakPosByHeight(height)
+	}
+
+	return height - 1, prevPos
+}
+
+func peakPosByHeight(height int) uint64 {
+	return (1 << (height + 1)) - 2
+}
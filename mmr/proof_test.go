@@ -0,0 +1,285 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositions(t *testing.T) {
+	assert.Equal(t, []uint64{0, 1, 3, 4, 7, 8, 10, 11}, []uint64{
+		leafIndexToPos(0), leafIndexToPos(1), leafIndexToPos(2), leafIndexToPos(3),
+		leafIndexToPos(4), leafIndexToPos(5), leafIndexToPos(6), leafIndexToPos(7),
+	})
+	assert.Equal(t, []int{0, 0, 1, 0, 0, 1, 2}, []int{
+		posHeight(0), posHeight(1), posHeight(2), posHeight(3), posHeight(4), posHeight(5), posHeight(6),
+	})
+	assert.Equal(t, []uint64{0}, peaks(1))
+	assert.Equal(t, []uint64{2, 3}, peaks(4))
+	assert.Equal(t, []uint64{6, 9, 10}, peaks(11))
+	assert.Equal(t, uint64(11), leafIndexToMMRSize(6))
+}
+
+func TestCalculateRoot_ThreeLeaves(t *testing.T) {
+	leaves := [][]byte{{0}, {1}, {2}}
+
+	// nodes: 0 and 1 are merged into 2, 3 is the second peak
+	expected := merge(keccak(leaves[2]), merge(keccak(leaves[0]), keccak(leaves[1])))
+
+	root, err := CalculateRoot([][]byte{leaves[0]}, types.MMRBatchProof{
+		LeafIndices: []types.U64{0},
+		LeafCount:   3,
+		Items:       []types.H256{keccak(leaves[1]), keccak(leaves[2])},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, root)
+
+	root, err = CalculateRoot([][]byte{leaves[2]}, types.MMRBatchProof{
+		LeafIndices: []types.U64{2},
+		LeafCount:   3,
+		Items:       []types.H256{merge(keccak(leaves[0]), keccak(leaves[1]))},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, root)
+}
+
+func TestVerifyProof(t *testing.T) {
+	for _, leafCount := range []int{1, 2, 3, 7, 8, 11, 32, 45} {
+		m := newTestMMR(leafCount)
+
+		for _, indices := range [][]uint64{
+			{0},
+			{uint64(leafCount - 1)},
+			{uint64(leafCount / 2)},
+			{0, uint64(leafCount - 1)},
+			{uint64(leafCount - 1), uint64(leafCount / 3), 0},
+		} {
+			indices = unique(indices)
+
+			t.Run(fmt.Sprintf("%d leaves %v", leafCount, indices), func(t *testing.T) {
+				proof := m.leavesProof(indices)
+				assert.NoError(t, VerifyProof(m.root(), proof))
+
+				if len(proof.Proof.Items) > 0 {
+					proof.Proof.Items[0][0]++
+					assert.True(t, errors.Is(VerifyProof(m.root(), proof), ErrRootMismatch))
+				}
+			})
+		}
+	}
+}
+
+func TestVerifyProof_Invalid(t *testing.T) {
+	m := newTestMMR(11)
+	proof := m.leavesProof([]uint64{4})
+
+	assert.True(t, errors.Is(VerifyProof(types.H256{}, proof), ErrRootMismatch))
+
+	tampered := proof
+	tampered.Leaves = []types.MMREncodableOpaqueLeaf{{0xff}}
+	assert.True(t, errors.Is(VerifyProof(m.root(), tampered), ErrRootMismatch))
+
+	tampered = proof
+	tampered.Proof.Items = proof.Proof.Items[1:]
+	assert.Error(t, VerifyProof(m.root(), tampered))
+
+	tampered = proof
+	tampered.Proof.LeafIndices = []types.U64{11}
+	assert.True(t, errors.Is(VerifyProof(m.root(), tampered), ErrInvalidProof))
+
+	tampered = proof
+	tampered.Proof.LeafCount = 0
+	assert.True(t, errors.Is(VerifyProof(m.root(), tampered), ErrInvalidProof))
+
+	tampered = proof
+	tampered.Leaves = nil
+	assert.True(t, errors.Is(VerifyProof(m.root(), tampered), ErrLeavesMissing))
+}
+
+func TestVerifyLeafProof(t *testing.T) {
+	leaves := make([]types.MMRLeaf, 5)
+	encoded := make([][]byte, len(leaves))
+
+	for i := range leaves {
+		leaves[i] = types.MMRLeaf{
+			ParentNumberAndHash:   types.ParentNumberAndHash{ParentNumber: types.U32(i)},
+			BeefyNextAuthoritySet: types.BeefyNextAuthoritySet{ID: 1, Len: 3},
+		}
+
+		var err error
+		encoded[i], err = codec.Encode(leaves[i])
+		require.NoError(t, err)
+	}
+
+	m := newTestMMRFromLeaves(encoded)
+	batch := m.leavesProof([]uint64{3})
+
+	err := VerifyLeafProof(m.root(), leaves[3], types.MMRProof{
+		LeafIndex: 3,
+		LeafCount: batch.Proof.LeafCount,
+		Items:     batch.Proof.Items,
+	})
+	assert.NoError(t, err)
+
+	decoded, err := batch.DecodeLeaves()
+	require.NoError(t, err)
+	assert.Equal(t, []types.MMRLeaf{leaves[3]}, decoded)
+}
+
+// testMMR is a naive MMR that keeps all nodes, used to create roots and proofs.
+type testMMR struct {
+	leaves [][]byte
+	nodes  []types.H256
+}
+
+func newTestMMR(leafCount int) *testMMR {
+	leaves := make([][]byte, leafCount)
+	for i := range leaves {
+		leaves[i] = []byte(fmt.Sprintf("leaf %d", i))
+	}
+
+	return newTestMMRFromLeaves(leaves)
+}
+
+func newTestMMRFromLeaves(leaves [][]byte) *testMMR {
+	m := &testMMR{leaves: leaves}
+
+	for _, leaf := range leaves {
+		pos := uint64(len(m.nodes))
+		m.nodes = append(m.nodes, keccak(leaf))
+
+		for height := 0; posHeight(pos+1) > height; height++ {
+			pos++
+			left := pos - parentOffset(height)
+			m.nodes = append(m.nodes, merge(m.nodes[left], m.nodes[left+siblingOffset(height)]))
+		}
+	}
+
+	return m
+}
+
+func (m *testMMR) root() types.H256 {
+	var peakHashes []types.H256
+	for _, pos := range peaks(uint64(len(m.nodes))) {
+		peakHashes = append(peakHashes, m.nodes[pos])
+	}
+
+	root, _ := bagPeaks(peakHashes)
+
+	return root
+}
+
+func (m *testMMR) leavesProof(indices []uint64) types.MMRLeavesProof {
+	proof := types.MMRLeavesProof{Proof: types.MMRBatchProof{LeafCount: types.U64(len(m.leaves))}}
+
+	positions := make([]uint64, 0, len(indices))
+	for _, index := range indices {
+		proof.Leaves = append(proof.Leaves, m.leaves[index])
+		proof.Proof.LeafIndices = append(proof.Proof.LeafIndices, types.U64(index))
+		positions = append(positions, leafIndexToPos(index))
+	}
+
+	sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+
+	var items []types.H256
+	rhsPeaks := 0
+
+	for _, peakPos := range peaks(uint64(len(m.nodes))) {
+		var peakPositions []uint64
+		for len(positions) > 0 && positions[0] <= peakPos {
+			peakPositions = append(peakPositions, positions[0])
+			positions = positions[1:]
+		}
+
+		if len(peakPositions) == 0 {
+			rhsPeaks++
+		} else {
+			rhsPeaks = 0
+		}
+
+		items = m.peakProof(items, peakPositions, peakPos)
+	}
+
+	if rhsPeaks > 1 {
+		bagged, _ := bagPeaks(items[len(items)-rhsPeaks:])
+		items = append(items[:len(items)-rhsPeaks], bagged)
+	}
+
+	proof.Proof.Items = items
+
+	return proof
+}
+
+func (m *testMMR) peakProof(items []types.H256, positions []uint64, peakPos uint64) []types.H256 {
+	if len(positions) == 0 {
+		return append(items, m.nodes[peakPos])
+	}
+
+	queue := make([]node, len(positions))
+	for i, pos := range positions {
+		queue[i] = node{pos: pos}
+	}
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		if n.pos == peakPos {
+			break
+		}
+
+		siblingPos, parentPos := n.pos+siblingOffset(n.height), n.pos+parentOffset(n.height)
+		if posHeight(n.pos+1) > n.height {
+			siblingPos, parentPos = n.pos-siblingOffset(n.height), n.pos+1
+		}
+
+		if len(queue) > 0 && queue[0].pos == siblingPos {
+			queue = queue[1:]
+		} else {
+			items = append(items, m.nodes[siblingPos])
+		}
+
+		if parentPos < peakPos {
+			queue = append(queue, node{pos: parentPos, height: n.height + 1})
+		}
+	}
+
+	return items
+}
+
+func unique(indices []uint64) []uint64 {
+	seen := make(map[uint64]bool)
+
+	var res []uint64
+
+	for _, index := range indices {
+		if !seen[index] {
+			seen[index] = true
+			res = append(res, index)
+		}
+	}
+
+	return res
+}
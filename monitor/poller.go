@@ -0,0 +1,272 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	defaultInterval    = 6 * time.Second
+	defaultEventBuffer = 16
+)
+
+// EventKind is the kind of state change of a node observed by a Poller.
+type EventKind uint8
+
+const (
+	// OutOfSync is sent when the node started syncing or fell behind the highest known block.
+	OutOfSync EventKind = iota
+	// InSync is sent when the node caught up after OutOfSync.
+	InSync
+	// PeersLow is sent when the peer count dropped below PollerOptions.MinPeers.
+	PeersLow
+	// PeersRecovered is sent when the peer count reached PollerOptions.MinPeers again after PeersLow.
+	PeersRecovered
+	// FinalizedLagHigh is sent when the finalized block fell more than PollerOptions.MaxFinalizedLag blocks behind
+	// the best block.
+	FinalizedLagHigh
+	// FinalizedLagRecovered is sent when the finalized lag is within PollerOptions.MaxFinalizedLag again after
+	// FinalizedLagHigh.
+	FinalizedLagRecovered
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case OutOfSync:
+		return "OutOfSync"
+	case InSync:
+		return "InSync"
+	case PeersLow:
+		return "PeersLow"
+	case PeersRecovered:
+		return "PeersRecovered"
+	case FinalizedLagHigh:
+		return "FinalizedLagHigh"
+	case FinalizedLagRecovered:
+		return "FinalizedLagRecovered"
+	default:
+		return "Unknown"
+	}
+}
+
+// Sample is the state of a node at one point in time.
+type Sample struct {
+	Time            time.Time
+	Health          types.Health
+	SyncState       types.SyncState
+	BestNumber      uint64
+	FinalizedNumber uint64
+}
+
+// FinalizedLag returns the number of blocks the finalized block is behind the best block.
+func (s Sample) FinalizedLag() uint64 {
+	if s.FinalizedNumber >= s.BestNumber {
+		return 0
+	}
+
+	return s.BestNumber - s.FinalizedNumber
+}
+
+// BlocksBehind returns the number of blocks the best block of the node is behind the highest known block.
+func (s Sample) BlocksBehind() uint64 {
+	if s.SyncState.HighestBlock <= s.SyncState.CurrentBlock {
+		return 0
+	}
+
+	return uint64(s.SyncState.HighestBlock - s.SyncState.CurrentBlock)
+}
+
+// Event is a state change of a node together with the sample it was observed in.
+type Event struct {
+	Kind   EventKind
+	Sample Sample
+}
+
+// PollerOptions configure a Poller. Zero values are replaced by the defaults, zero thresholds disable their checks.
+type PollerOptions struct {
+	// Interval is the time between two samples, it defaults to 6 seconds.
+	Interval time.Duration
+
+	// MinPeers is the peer count below which PeersLow is sent.
+	MinPeers uint64
+
+	// MaxFinalizedLag is the number of blocks the finalized block may be behind the best block before
+	// FinalizedLagHigh is sent.
+	MaxFinalizedLag uint64
+
+	// MaxBlocksBehind is the number of blocks the node may be behind the highest known block before OutOfSync is
+	// sent. OutOfSync is sent whenever the node reports that it is syncing regardless.
+	MaxBlocksBehind uint64
+
+	// EventBuffer is the capacity of the event channel, it defaults to 16.
+	EventBuffer int
+
+	// OnError, if set, is called when a sample could not be taken. It must not block.
+	OnError func(err error)
+}
+
+// conditions are the states that are checked by a Poller, all of them are false for a healthy node.
+type conditions struct {
+	outOfSync bool
+	peersLow  bool
+	lagHigh   bool
+}
+
+// Poller samples the health, the sync state and the best and finalized heads of a node at an interval and sends an
+// event whenever one of the checked conditions changes.
+type Poller struct {
+	systemRPC system.System
+	chainRPC  chain.Chain
+	opts      PollerOptions
+}
+
+// NewPoller creates a Poller that samples the node via the RPCs.
+func NewPoller(systemRPC system.System, chainRPC chain.Chain, opts PollerOptions) *Poller {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultInterval
+	}
+
+	if opts.EventBuffer <= 0 {
+		opts.EventBuffer = defaultEventBuffer
+	}
+
+	return &Poller{systemRPC: systemRPC, chainRPC: chainRPC, opts: opts}
+}
+
+// Sample takes a single sample of the node.
+func (p *Poller) Sample(ctx context.Context) (Sample, error) {
+	sample := Sample{Time: time.Now()}
+
+	health, err := p.systemRPC.HealthContext(ctx)
+	if err != nil {
+		return Sample{}, ErrHealthRetrieval.Wrap(err)
+	}
+
+	syncState, err := p.systemRPC.SyncStateContext(ctx)
+	if err != nil {
+		return Sample{}, ErrSyncStateRetrieval.Wrap(err)
+	}
+
+	best, err := p.chainRPC.GetHeaderLatestContext(ctx)
+	if err != nil {
+		return Sample{}, ErrBestHeaderRetrieval.Wrap(err)
+	}
+
+	finalizedHash, err := p.chainRPC.GetFinalizedHeadContext(ctx)
+	if err != nil {
+		return Sample{}, ErrFinalizedHeadRetrieval.Wrap(err)
+	}
+
+	finalized, err := p.chainRPC.GetHeaderContext(ctx, finalizedHash)
+	if err != nil {
+		return Sample{}, ErrFinalizedHeaderRetrieval.Wrap(err)
+	}
+
+	sample.Health = health
+	sample.SyncState = syncState
+	sample.BestNumber = uint64(best.Number)
+	sample.FinalizedNumber = uint64(finalized.Number)
+
+	return sample, nil
+}
+
+// Run samples the node until ctx is done and returns the channel that receives the events, it is closed once ctx is
+// done. The first sample is taken immediately, events are sent for the conditions that do not hold for it, e.g.
+// OutOfSync if the node is syncing. Samples that could not be taken are reported to PollerOptions.OnError and do not
+// change the state.
+func (p *Poller) Run(ctx context.Context) <-chan Event {
+	events := make(chan Event, p.opts.EventBuffer)
+
+	go p.run(ctx, events)
+
+	return events
+}
+
+func (p *Poller) run(ctx context.Context, events chan<- Event) {
+	defer close(events)
+
+	ticker := time.NewTicker(p.opts.Interval)
+	defer ticker.Stop()
+
+	var state conditions
+
+	for {
+		sample, err := p.Sample(ctx)
+
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return
+		case err != nil:
+			if p.opts.OnError != nil {
+				p.opts.OnError(err)
+			}
+		default:
+			next := p.check(sample)
+
+			for _, event := range changes(state, next, sample) {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			state = next
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check returns the conditions of the sample.
+func (p *Poller) check(sample Sample) conditions {
+	return conditions{
+		outOfSync: sample.Health.IsSyncing || (p.opts.MaxBlocksBehind > 0 && sample.BlocksBehind() > p.opts.MaxBlocksBehind),
+		peersLow:  uint64(sample.Health.Peers) < p.opts.MinPeers,
+		lagHigh:   p.opts.MaxFinalizedLag > 0 && sample.FinalizedLag() > p.opts.MaxFinalizedLag,
+	}
+}
+
+// changes returns the events for the conditions that changed between the previous and the next state.
+func changes(previous, next conditions, sample Sample) []Event {
+	var events []Event
+
+	add := func(prev, cur bool, set, cleared EventKind) {
+		switch {
+		case cur && !prev:
+			events = append(events, Event{Kind: set, Sample: sample})
+		case prev && !cur:
+			events = append(events, Event{Kind: cleared, Sample: sample})
+		}
+	}
+
+	add(previous.outOfSync, next.outOfSync, OutOfSync, InSync)
+	add(previous.peersLow, next.peersLow, PeersLow, PeersRecovered)
+	add(previous.lagHigh, next.lagHigh, FinalizedLagHigh, FinalizedLagRecovered)
+
+	return events
+}
@@ -0,0 +1,131 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	systemMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testSample struct {
+	peers     types.U64
+	syncing   bool
+	current   types.U64
+	highest   types.U64
+	finalized types.BlockNumber
+}
+
+// expectSample expects the RPC calls of one sample.
+func expectSample(systemMock *systemMocks.System, chainMock *chainMocks.Chain, sample testSample) {
+	finalizedHash := types.Hash{byte(sample.finalized)}
+
+	systemMock.On("HealthContext", mock.Anything).
+		Return(types.Health{Peers: sample.peers, IsSyncing: sample.syncing}, nil).
+		Once()
+	systemMock.On("SyncStateContext", mock.Anything).
+		Return(types.SyncState{CurrentBlock: sample.current, HighestBlock: sample.highest}, nil).
+		Once()
+	chainMock.On("GetHeaderLatestContext", mock.Anything).
+		Return(&types.Header{Number: types.BlockNumber(sample.current)}, nil).
+		Once()
+	chainMock.On("GetFinalizedHeadContext", mock.Anything).Return(finalizedHash, nil).Once()
+	chainMock.On("GetHeaderContext", mock.Anything, finalizedHash).
+		Return(&types.Header{Number: sample.finalized}, nil).
+		Once()
+}
+
+func receiveEvent(t *testing.T, events <-chan Event) Event {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	return Event{}
+}
+
+func TestPoller_Sample(t *testing.T) {
+	systemMock, chainMock := systemMocks.NewSystem(t), chainMocks.NewChain(t)
+	expectSample(systemMock, chainMock, testSample{peers: 5, current: 100, highest: 110, finalized: 97})
+
+	sample, err := NewPoller(systemMock, chainMock, PollerOptions{}).Sample(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), sample.BestNumber)
+	assert.Equal(t, uint64(97), sample.FinalizedNumber)
+	assert.Equal(t, uint64(3), sample.FinalizedLag())
+	assert.Equal(t, uint64(10), sample.BlocksBehind())
+	assert.Equal(t, types.U64(5), sample.Health.Peers)
+
+	systemMock.On("HealthContext", mock.Anything).Return(types.Health{}, errors.New("boom")).Once()
+
+	_, err = NewPoller(systemMock, chainMock, PollerOptions{}).Sample(context.Background())
+	assert.ErrorIs(t, err, ErrHealthRetrieval)
+}
+
+func TestPoller_Run(t *testing.T) {
+	systemMock, chainMock := systemMocks.NewSystem(t), chainMocks.NewChain(t)
+
+	// The node starts syncing with few peers, catches up and then the finality stalls.
+	expectSample(systemMock, chainMock, testSample{peers: 1, syncing: true, current: 10, highest: 100, finalized: 8})
+	expectSample(systemMock, chainMock, testSample{peers: 3, current: 99, highest: 100, finalized: 97})
+	systemMock.On("HealthContext", mock.Anything).Return(types.Health{}, errors.New("boom")).Once()
+	expectSample(systemMock, chainMock, testSample{peers: 3, current: 120, highest: 120, finalized: 100})
+
+	// Samples fail once the expectations are used up, which does not change the state.
+	systemMock.On("HealthContext", mock.Anything).Return(types.Health{}, errors.New("boom")).Maybe()
+
+	errs := make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events := NewPoller(systemMock, chainMock, PollerOptions{
+		Interval:        time.Millisecond,
+		MinPeers:        2,
+		MaxFinalizedLag: 10,
+		MaxBlocksBehind: 5,
+		OnError: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+	}).Run(ctx)
+
+	var kinds []EventKind
+
+	for i := 0; i < 5; i++ {
+		kinds = append(kinds, receiveEvent(t, events).Kind)
+	}
+
+	assert.Equal(t, []EventKind{OutOfSync, PeersLow, InSync, PeersRecovered, FinalizedLagHigh}, kinds)
+	assert.ErrorIs(t, <-errs, ErrHealthRetrieval)
+
+	cancel()
+
+	for range events {
+		t.Fatal("no further events expected")
+	}
+}
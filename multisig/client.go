@@ -0,0 +1,166 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multisig
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/payment"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Operation is a pending operation of a multisig, as stored in Multisig.Multisigs.
+type Operation struct {
+	// When is the timepoint of the first approval, which has to be passed with all other approvals.
+	When types.TimePoint
+	// Deposit is the amount held from the depositor while the operation is pending.
+	Deposit types.U128
+	// Depositor is the signatory that approved the operation first.
+	Depositor types.AccountID
+	// Approvals are the signatories that approved the operation.
+	Approvals []types.AccountID
+}
+
+// IsApprovedBy returns true if the account approved the operation.
+func (o *Operation) IsApprovedBy(accountID types.AccountID) bool {
+	for _, approval := range o.Approvals {
+		if approval == accountID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Client builds the calls of a multisig workflow from the state of its pending operations.
+type Client struct {
+	stateRPC   state.State
+	paymentRPC payment.Payment
+}
+
+// NewClient creates a Client that reads the pending operations via the state RPC and estimates the weight of calls
+// via the payment RPC.
+func NewClient(stateRPC state.State, paymentRPC payment.Payment) *Client {
+	return &Client{
+		stateRPC:   stateRPC,
+		paymentRPC: paymentRPC,
+	}
+}
+
+// GetOperation returns the pending operation of the multisig for the call with the given hash, or nil if there is
+// none, i.e. the call was not approved yet or was already executed or cancelled.
+func (c *Client) GetOperation(
+	ctx context.Context,
+	meta *types.Metadata,
+	m *Multisig,
+	callHash types.Hash,
+) (*Operation, error) {
+	key, err := types.CreateStorageKey(meta, "Multisig", "Multisigs", m.AccountID[:], callHash[:])
+	if err != nil {
+		return nil, ErrStorageKeyCreation.Wrap(err)
+	}
+
+	var op Operation
+
+	ok, err := c.stateRPC.GetStorageLatestContext(ctx, key, &op)
+	if err != nil {
+		return nil, ErrOperationRetrieval.Wrap(err)
+	}
+
+	if !ok {
+		return nil, nil
+	}
+
+	return &op, nil
+}
+
+// Approve creates the call that approves the call on behalf of the signer. The approval that reaches the threshold is
+// created as Multisig.as_multi, with the weight of the call as max weight, which is estimated via
+// TransactionPaymentCallApi. All other approvals are created as Multisig.approve_as_multi, which only needs the hash
+// of the call, with the timepoint of the pending operation if there is one. Multisigs with a threshold of 1 dispatch
+// the call via Multisig.as_multi_threshold_1 right away.
+func (c *Client) Approve(
+	ctx context.Context,
+	meta *types.Metadata,
+	m *Multisig,
+	signer types.AccountID,
+	call types.Call,
+) (types.Call, error) {
+	if m.Threshold == 1 {
+		return m.AsMultiThreshold1(meta, signer, call)
+	}
+
+	callHash, err := CallHash(call)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	op, err := c.GetOperation(ctx, meta, m, callHash)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	var (
+		timepoint *types.TimePoint
+		approvals int
+	)
+
+	if op != nil {
+		if op.IsApprovedBy(signer) {
+			return types.Call{}, ErrAlreadyApproved.WithMsg("%#x", signer[:])
+		}
+
+		timepoint = &op.When
+		approvals = len(op.Approvals)
+	}
+
+	if approvals+1 < int(m.Threshold) {
+		return m.ApproveAsMulti(meta, signer, timepoint, callHash, types.NewWeight(
+			types.NewUCompactFromUInt(0),
+			types.NewUCompactFromUInt(0),
+		))
+	}
+
+	info, err := c.paymentRPC.QueryCallInfoLatestContext(ctx, call)
+	if err != nil {
+		return types.Call{}, ErrCallWeightRetrieval.Wrap(err)
+	}
+
+	return m.AsMulti(meta, signer, timepoint, call, info.Weight)
+}
+
+// Cancel creates the Multisig.cancel_as_multi call that cancels the pending operation of the call with the given hash,
+// which is only allowed for its depositor. ErrOperationNotFound is returned if there is no pending operation.
+func (c *Client) Cancel(
+	ctx context.Context,
+	meta *types.Metadata,
+	m *Multisig,
+	signer types.AccountID,
+	callHash types.Hash,
+) (types.Call, error) {
+	op, err := c.GetOperation(ctx, meta, m, callHash)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	if op == nil {
+		return types.Call{}, ErrOperationNotFound.WithMsg("call hash %s", callHash.Hex())
+	}
+
+	return m.CancelAsMulti(meta, signer, op.When, callHash)
+}
@@ -0,0 +1,217 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multisig
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	paymentMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/payment/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testClient struct {
+	*Client
+
+	stateRPC   *statetest.State
+	paymentRPC *paymentMocks.Payment
+}
+
+func newTestClient(t *testing.T) testClient {
+	paymentRPC := paymentMocks.NewPayment(t)
+
+	c, stateRPC := statetest.NewClient(t, func(stateRPC state.State) *Client {
+		return NewClient(stateRPC, paymentRPC)
+	})
+
+	return testClient{
+		Client:     c,
+		stateRPC:   stateRPC,
+		paymentRPC: paymentRPC,
+	}
+}
+
+func (c testClient) expectOperation(t *testing.T, meta *types.Metadata, m *Multisig, call types.Call, op *Operation) {
+	callHash, err := CallHash(call)
+	require.NoError(t, err)
+
+	var value interface{}
+	if op != nil {
+		value = *op
+	}
+
+	c.stateRPC.ExpectStorage(t, meta, "Multisig", "Multisigs", value, m.AccountID[:], callHash[:])
+}
+
+func TestClient_Approve(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+	a := getTestAccounts(t)
+
+	remark, err := types.NewCall(meta, "System.remark", types.NewBytes([]byte("hello")))
+	require.NoError(t, err)
+
+	callHash, err := CallHash(remark)
+	require.NoError(t, err)
+
+	m, err := New([]types.AccountID{a.alice, a.bob, a.charlie}, 3)
+	require.NoError(t, err)
+
+	timepoint := types.TimePoint{Height: 100, Index: 2}
+	noWeight := types.NewWeight(types.NewUCompactFromUInt(0), types.NewUCompactFromUInt(0))
+	callWeight := types.NewWeight(types.NewUCompactFromUInt(1000), types.NewUCompactFromUInt(10))
+
+	t.Run("first approval", func(t *testing.T) {
+		c := newTestClient(t)
+		c.expectOperation(t, meta, m, remark, nil)
+
+		call, err := c.Approve(ctx, meta, m, a.alice, remark)
+		require.NoError(t, err)
+
+		expected, err := m.ApproveAsMulti(meta, a.alice, nil, callHash, noWeight)
+		require.NoError(t, err)
+		assert.Equal(t, expected, call)
+	})
+
+	t.Run("second approval", func(t *testing.T) {
+		c := newTestClient(t)
+		c.expectOperation(t, meta, m, remark, &Operation{
+			When:      timepoint,
+			Depositor: a.alice,
+			Approvals: []types.AccountID{a.alice},
+		})
+
+		call, err := c.Approve(ctx, meta, m, a.bob, remark)
+		require.NoError(t, err)
+
+		expected, err := m.ApproveAsMulti(meta, a.bob, &timepoint, callHash, noWeight)
+		require.NoError(t, err)
+		assert.Equal(t, expected, call)
+	})
+
+	t.Run("final approval", func(t *testing.T) {
+		c := newTestClient(t)
+		c.expectOperation(t, meta, m, remark, &Operation{
+			When:      timepoint,
+			Depositor: a.alice,
+			Approvals: []types.AccountID{a.alice, a.bob},
+		})
+		c.paymentRPC.On("QueryCallInfoLatestContext", mock.Anything, remark).
+			Return(&types.RuntimeDispatchInfo{Weight: callWeight}, nil).
+			Once()
+
+		call, err := c.Approve(ctx, meta, m, a.charlie, remark)
+		require.NoError(t, err)
+
+		expected, err := m.AsMulti(meta, a.charlie, &timepoint, remark, callWeight)
+		require.NoError(t, err)
+		assert.Equal(t, expected, call)
+	})
+
+	t.Run("already approved", func(t *testing.T) {
+		c := newTestClient(t)
+		c.expectOperation(t, meta, m, remark, &Operation{
+			When:      timepoint,
+			Depositor: a.alice,
+			Approvals: []types.AccountID{a.alice},
+		})
+
+		_, err := c.Approve(ctx, meta, m, a.alice, remark)
+		assert.ErrorIs(t, err, ErrAlreadyApproved)
+	})
+
+	t.Run("threshold 1", func(t *testing.T) {
+		c := newTestClient(t)
+
+		m1, err := New([]types.AccountID{a.alice, a.bob}, 1)
+		require.NoError(t, err)
+
+		call, err := c.Approve(ctx, meta, m1, a.alice, remark)
+		require.NoError(t, err)
+
+		expected, err := m1.AsMultiThreshold1(meta, a.alice, remark)
+		require.NoError(t, err)
+		assert.Equal(t, expected, call)
+	})
+
+	t.Run("weight error", func(t *testing.T) {
+		c := newTestClient(t)
+		c.expectOperation(t, meta, m, remark, &Operation{
+			When:      timepoint,
+			Depositor: a.alice,
+			Approvals: []types.AccountID{a.alice, a.bob},
+		})
+		c.paymentRPC.On("QueryCallInfoLatestContext", mock.Anything, remark).
+			Return(nil, errors.New("error")).
+			Once()
+
+		_, err := c.Approve(ctx, meta, m, a.charlie, remark)
+		assert.ErrorIs(t, err, ErrCallWeightRetrieval)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		c := newTestClient(t)
+		c.stateRPC.On("GetStorageLatestContext", mock.Anything, mock.Anything, mock.Anything).
+			Return(false, errors.New("error")).
+			Once()
+
+		_, err := c.Approve(ctx, meta, m, a.alice, remark)
+		assert.ErrorIs(t, err, ErrOperationRetrieval)
+	})
+}
+
+func TestClient_Cancel(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+	a := getTestAccounts(t)
+
+	remark, err := types.NewCall(meta, "System.remark", types.NewBytes([]byte("hello")))
+	require.NoError(t, err)
+
+	callHash, err := CallHash(remark)
+	require.NoError(t, err)
+
+	m, err := New([]types.AccountID{a.alice, a.bob, a.charlie}, 2)
+	require.NoError(t, err)
+
+	timepoint := types.TimePoint{Height: 100, Index: 2}
+
+	c := newTestClient(t)
+	c.expectOperation(t, meta, m, remark, &Operation{
+		When:      timepoint,
+		Depositor: a.alice,
+		Approvals: []types.AccountID{a.alice},
+	})
+
+	call, err := c.Cancel(ctx, meta, m, a.alice, callHash)
+	require.NoError(t, err)
+
+	expected, err := m.CancelAsMulti(meta, a.alice, timepoint, callHash)
+	require.NoError(t, err)
+	assert.Equal(t, expected, call)
+
+	c.expectOperation(t, meta, m, remark, nil)
+
+	_, err = c.Cancel(ctx, meta, m, a.alice, callHash)
+	assert.ErrorIs(t, err, ErrOperationNotFound)
+}
@@ -0,0 +1,111 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multisig
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	newMultisigEvent       = "Multisig.NewMultisig"
+	multisigApprovalEvent  = "Multisig.MultisigApproval"
+	multisigExecutedEvent  = "Multisig.MultisigExecuted"
+	multisigCancelledEvent = "Multisig.MultisigCancelled"
+)
+
+// NewMultisigEvent holds the fields of the Multisig.NewMultisig event, emitted by the first approval of a call.
+type NewMultisigEvent struct {
+	Approving types.AccountID
+	Multisig  types.AccountID
+	CallHash  types.Hash
+}
+
+// ApprovalEvent holds the fields of the Multisig.MultisigApproval event, emitted by approvals that do not execute the
+// call.
+type ApprovalEvent struct {
+	Approving types.AccountID
+	Timepoint types.TimePoint
+	Multisig  types.AccountID
+	CallHash  types.Hash
+}
+
+// ExecutedEvent holds the fields of the Multisig.MultisigExecuted event, emitted by the approval that reached the
+// threshold. Result is the outcome of the dispatch of the call.
+type ExecutedEvent struct {
+	Approving types.AccountID
+	Timepoint types.TimePoint
+	Multisig  types.AccountID
+	CallHash  types.Hash
+	Result    types.DispatchResult
+}
+
+// CancelledEvent holds the fields of the Multisig.MultisigCancelled event.
+type CancelledEvent struct {
+	Cancelling types.AccountID
+	Timepoint  types.TimePoint
+	Multisig   types.AccountID
+	CallHash   types.Hash
+}
+
+// Events are the events of the multisig pallet emitted by an extrinsic.
+type Events struct {
+	New       []NewMultisigEvent
+	Approvals []ApprovalEvent
+	Executed  []ExecutedEvent
+	Cancelled []CancelledEvent
+}
+
+// DecodeEvents decodes the events of the multisig pallet, e.g. the events of submit.ExtrinsicResult. Other events are
+// ignored.
+func DecodeEvents(events []*parser.Event) (*Events, error) {
+	var res Events
+
+	for _, event := range events {
+		var err error
+
+		switch event.Name {
+		case newMultisigEvent:
+			var e NewMultisigEvent
+			if err = codec.Decode(event.Data, &e); err == nil {
+				res.New = append(res.New, e)
+			}
+		case multisigApprovalEvent:
+			var e ApprovalEvent
+			if err = codec.Decode(event.Data, &e); err == nil {
+				res.Approvals = append(res.Approvals, e)
+			}
+		case multisigExecutedEvent:
+			var e ExecutedEvent
+			if err = codec.Decode(event.Data, &e); err == nil {
+				res.Executed = append(res.Executed, e)
+			}
+		case multisigCancelledEvent:
+			var e CancelledEvent
+			if err = codec.Decode(event.Data, &e); err == nil {
+				res.Cancelled = append(res.Cancelled, e)
+			}
+		}
+
+		if err != nil {
+			return nil, ErrEventDecoding.WithMsg(event.Name).Wrap(err)
+		}
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,66 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multisig
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	a := getTestAccounts(t)
+
+	m, err := New([]types.AccountID{a.alice, a.bob, a.charlie}, 2)
+	require.NoError(t, err)
+
+	callHash := types.NewHash([]byte{1, 2, 3})
+	timepoint := types.TimePoint{Height: 100, Index: 2}
+
+	newMultisig := NewMultisigEvent{Approving: a.alice, Multisig: m.AccountID, CallHash: callHash}
+	approval := ApprovalEvent{Approving: a.bob, Timepoint: timepoint, Multisig: m.AccountID, CallHash: callHash}
+	executed := ExecutedEvent{
+		Approving: a.charlie,
+		Timepoint: timepoint,
+		Multisig:  m.AccountID,
+		CallHash:  callHash,
+		Result:    types.DispatchResult{Ok: true},
+	}
+	cancelled := CancelledEvent{Cancelling: a.alice, Timepoint: timepoint, Multisig: m.AccountID, CallHash: callHash}
+
+	res, err := DecodeEvents([]*parser.Event{
+		{Name: "System.ExtrinsicSuccess", Data: []byte{1}},
+		{Name: newMultisigEvent, Data: statetest.Encode(t, newMultisig)},
+		{Name: multisigApprovalEvent, Data: statetest.Encode(t, approval)},
+		{Name: multisigExecutedEvent, Data: statetest.Encode(t, executed)},
+		{Name: multisigCancelledEvent, Data: statetest.Encode(t, cancelled)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Events{
+		New:       []NewMultisigEvent{newMultisig},
+		Approvals: []ApprovalEvent{approval},
+		Executed:  []ExecutedEvent{executed},
+		Cancelled: []CancelledEvent{cancelled},
+	}, res)
+
+	_, err = DecodeEvents([]*parser.Event{{Name: multisigExecutedEvent, Data: []byte{1}}})
+	assert.ErrorIs(t, err, ErrEventDecoding)
+}
@@ -0,0 +1,231 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multisig
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	asMultiThreshold1Call = "Multisig.as_multi_threshold_1"
+	asMultiCall           = "Multisig.as_multi"
+	approveAsMultiCall    = "Multisig.approve_as_multi"
+	cancelAsMultiCall     = "Multisig.cancel_as_multi"
+)
+
+// accountIDPrefix is the prefix of the preimage of multisig account IDs, see pallet_multisig::multi_account_id.
+var accountIDPrefix = []byte("modlpy/utilisuba")
+
+// Multisig is an account of the multisig pallet that dispatches calls once a threshold of its signatories approved
+// them.
+type Multisig struct {
+	// Signatories are the accounts that can approve calls, sorted in ascending order.
+	Signatories []types.AccountID
+	// Threshold is the number of signatories that have to approve a call.
+	Threshold uint16
+	// AccountID is the account of the multisig.
+	AccountID types.AccountID
+}
+
+// New creates the multisig of the signatories with the given threshold, which has to be between 1 and the number of
+// signatories. The order of the signatories does not matter.
+func New(signatories []types.AccountID, threshold uint16) (*Multisig, error) {
+	if len(signatories) < 2 {
+		return nil, ErrTooFewSignatories
+	}
+
+	if threshold < 1 || int(threshold) > len(signatories) {
+		return nil, ErrInvalidThreshold.WithMsg("%d of %d signatories", threshold, len(signatories))
+	}
+
+	sorted := append([]types.AccountID{}, signatories...)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return nil, ErrDuplicateSignatory.WithMsg("%#x", sorted[i][:])
+		}
+	}
+
+	accountID, err := accountIDOf(sorted, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Multisig{
+		Signatories: sorted,
+		Threshold:   threshold,
+		AccountID:   accountID,
+	}, nil
+}
+
+// accountIDOf returns the blake2-256 hash of the prefix, the sorted signatories and the threshold.
+func accountIDOf(sorted []types.AccountID, threshold uint16) (types.AccountID, error) {
+	encoded, err := codec.Encode(sorted)
+	if err != nil {
+		return types.AccountID{}, ErrSignatoriesEncoding.Wrap(err)
+	}
+
+	preimage := append(append([]byte{}, accountIDPrefix...), encoded...)
+	preimage = binary.LittleEndian.AppendUint16(preimage, threshold)
+
+	return types.AccountID(blake2b.Sum256(preimage)), nil
+}
+
+// IsSignatory returns true if the account is a signatory of the multisig.
+func (m *Multisig) IsSignatory(accountID types.AccountID) bool {
+	for _, signatory := range m.Signatories {
+		if signatory == accountID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OtherSignatories returns the sorted signatories without the signer, as expected by the calls of the multisig
+// pallet.
+func (m *Multisig) OtherSignatories(signer types.AccountID) ([]types.AccountID, error) {
+	if !m.IsSignatory(signer) {
+		return nil, ErrNotSignatory.WithMsg("%#x", signer[:])
+	}
+
+	others := make([]types.AccountID, 0, len(m.Signatories)-1)
+
+	for _, signatory := range m.Signatories {
+		if signatory != signer {
+			others = append(others, signatory)
+		}
+	}
+
+	return others, nil
+}
+
+// CallHash returns the blake2-256 hash of the encoded call, which identifies the operation of a multisig.
+func CallHash(call types.Call) (types.Hash, error) {
+	encoded, err := codec.Encode(call)
+	if err != nil {
+		return types.Hash{}, ErrCallEncoding.Wrap(err)
+	}
+
+	return blake2b.Sum256(encoded), nil
+}
+
+// optionalTimepoint returns the Option<Timepoint> of the timepoint, which has to be nil for the first approval.
+func optionalTimepoint(timepoint *types.TimePoint) types.Option[types.TimePoint] {
+	if timepoint == nil {
+		return types.NewEmptyOption[types.TimePoint]()
+	}
+
+	return types.NewOption(*timepoint)
+}
+
+// AsMultiThreshold1 creates the Multisig.as_multi_threshold_1 call, which dispatches the call right away. It is only
+// valid for multisigs with a threshold of 1.
+func (m *Multisig) AsMultiThreshold1(
+	meta *types.Metadata,
+	signer types.AccountID,
+	call types.Call,
+) (types.Call, error) {
+	if m.Threshold != 1 {
+		return types.Call{}, ErrInvalidThreshold.WithMsg("%s requires a threshold of 1", asMultiThreshold1Call)
+	}
+
+	others, err := m.OtherSignatories(signer)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return newCall(meta, asMultiThreshold1Call, others, call)
+}
+
+// AsMulti creates the Multisig.as_multi call, which approves the call and dispatches it if the threshold is reached.
+// The timepoint of the first approval has to be given for all other approvals, see Client.GetOperation. The max
+// weight has to cover the weight of the call if it is dispatched, see Client.Approve.
+func (m *Multisig) AsMulti(
+	meta *types.Metadata,
+	signer types.AccountID,
+	timepoint *types.TimePoint,
+	call types.Call,
+	maxWeight types.Weight,
+) (types.Call, error) {
+	others, err := m.OtherSignatories(signer)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return newCall(meta, asMultiCall, types.NewU16(m.Threshold), others, optionalTimepoint(timepoint), call, maxWeight)
+}
+
+// ApproveAsMulti creates the Multisig.approve_as_multi call, which approves the call with the given hash without
+// dispatching it. The timepoint of the first approval has to be given for all other approvals.
+func (m *Multisig) ApproveAsMulti(
+	meta *types.Metadata,
+	signer types.AccountID,
+	timepoint *types.TimePoint,
+	callHash types.Hash,
+	maxWeight types.Weight,
+) (types.Call, error) {
+	others, err := m.OtherSignatories(signer)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return newCall(
+		meta,
+		approveAsMultiCall,
+		types.NewU16(m.Threshold),
+		others,
+		optionalTimepoint(timepoint),
+		callHash,
+		maxWeight,
+	)
+}
+
+// CancelAsMulti creates the Multisig.cancel_as_multi call, which cancels the operation with the given hash. Only the
+// signatory that approved it first can cancel it.
+func (m *Multisig) CancelAsMulti(
+	meta *types.Metadata,
+	signer types.AccountID,
+	timepoint types.TimePoint,
+	callHash types.Hash,
+) (types.Call, error) {
+	others, err := m.OtherSignatories(signer)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	return newCall(meta, cancelAsMultiCall, types.NewU16(m.Threshold), others, timepoint, callHash)
+}
+
+func newCall(meta *types.Metadata, name string, args ...interface{}) (types.Call, error) {
+	call, err := types.NewCall(meta, name, args...)
+	if err != nil {
+		return types.Call{}, ErrCallCreation.WithMsg(name).Wrap(err)
+	}
+
+	return call, nil
+}
@@ -0,0 +1,200 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multisig
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestAccountID(t *testing.T, uri string) types.AccountID {
+	kp, err := signature.KeyringPairFromSecret(uri, 42)
+	require.NoError(t, err)
+
+	accountID, err := types.NewAccountID(kp.PublicKey)
+	require.NoError(t, err)
+
+	return *accountID
+}
+
+type testAccounts struct {
+	alice, bob, charlie types.AccountID
+}
+
+func getTestAccounts(t *testing.T) testAccounts {
+	return testAccounts{
+		alice:   getTestAccountID(t, "//Alice"),
+		bob:     getTestAccountID(t, "//Bob"),
+		charlie: getTestAccountID(t, "//Charlie"),
+	}
+}
+
+func TestNew(t *testing.T) {
+	a := getTestAccounts(t)
+
+	m, err := New([]types.AccountID{a.charlie, a.alice, a.bob}, 2)
+	require.NoError(t, err)
+
+	// 5DjYJStmdZ2rcqXbXGX7TW85JsrW6uG4y9MUcLq2BoPMpRA7
+	expectedAccountID, err := types.NewAccountIDFromHexString(
+		"0x49daa32c7287890f38b7e1a8cd2961723d36d20baa0bf3b82e0c4bdda93b1c0a",
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, *expectedAccountID, m.AccountID)
+	assert.Equal(t, uint16(2), m.Threshold)
+	// Bob < Charlie < Alice
+	assert.Equal(t, []types.AccountID{a.bob, a.charlie, a.alice}, m.Signatories)
+
+	// The order of the signatories does not matter.
+	other, err := New([]types.AccountID{a.alice, a.bob, a.charlie}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, m, other)
+
+	// The threshold is part of the account.
+	other, err = New([]types.AccountID{a.alice, a.bob, a.charlie}, 3)
+	require.NoError(t, err)
+	assert.NotEqual(t, m.AccountID, other.AccountID)
+}
+
+func TestNew_Errors(t *testing.T) {
+	a := getTestAccounts(t)
+
+	_, err := New([]types.AccountID{a.alice}, 1)
+	assert.ErrorIs(t, err, ErrTooFewSignatories)
+
+	_, err = New([]types.AccountID{a.alice, a.bob}, 0)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+
+	_, err = New([]types.AccountID{a.alice, a.bob}, 3)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+
+	_, err = New([]types.AccountID{a.alice, a.bob, a.alice}, 2)
+	assert.ErrorIs(t, err, ErrDuplicateSignatory)
+}
+
+func TestMultisig_OtherSignatories(t *testing.T) {
+	a := getTestAccounts(t)
+
+	m, err := New([]types.AccountID{a.alice, a.bob, a.charlie}, 2)
+	require.NoError(t, err)
+
+	others, err := m.OtherSignatories(a.alice)
+	require.NoError(t, err)
+	assert.Equal(t, []types.AccountID{a.bob, a.charlie}, others)
+
+	_, err = m.OtherSignatories(getTestAccountID(t, "//Dave"))
+	assert.ErrorIs(t, err, ErrNotSignatory)
+}
+
+func TestMultisig_Calls(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+	a := getTestAccounts(t)
+
+	m, err := New([]types.AccountID{a.alice, a.bob, a.charlie}, 2)
+	require.NoError(t, err)
+
+	remark, err := types.NewCall(meta, "System.remark", types.NewBytes([]byte("hello")))
+	require.NoError(t, err)
+
+	callHash, err := CallHash(remark)
+	require.NoError(t, err)
+
+	others := []types.AccountID{a.bob, a.charlie}
+	timepoint := types.TimePoint{Height: 100, Index: 2}
+	weight := types.NewWeight(types.NewUCompactFromUInt(1000), types.NewUCompactFromUInt(10))
+	threshold := types.NewU16(2)
+
+	none := types.NewEmptyOption[types.TimePoint]()
+	some := types.NewOption(timepoint)
+
+	tests := []struct {
+		name         string
+		create       func() (types.Call, error)
+		expectedCall types.Call
+	}{
+		{
+			name: "first as_multi",
+			create: func() (types.Call, error) {
+				return m.AsMulti(meta, a.alice, nil, remark, weight)
+			},
+			expectedCall: types.Call{
+				CallIndex: types.CallIndex{SectionIndex: 30, MethodIndex: 1},
+				Args:      statetest.Encode(t, threshold, others, none, remark, weight),
+			},
+		},
+		{
+			name: "as_multi",
+			create: func() (types.Call, error) {
+				return m.AsMulti(meta, a.alice, &timepoint, remark, weight)
+			},
+			expectedCall: types.Call{
+				CallIndex: types.CallIndex{SectionIndex: 30, MethodIndex: 1},
+				Args:      statetest.Encode(t, threshold, others, some, remark, weight),
+			},
+		},
+		{
+			name: "approve_as_multi",
+			create: func() (types.Call, error) {
+				return m.ApproveAsMulti(meta, a.alice, &timepoint, callHash, weight)
+			},
+			expectedCall: types.Call{
+				CallIndex: types.CallIndex{SectionIndex: 30, MethodIndex: 2},
+				Args:      statetest.Encode(t, threshold, others, some, callHash, weight),
+			},
+		},
+		{
+			name: "cancel_as_multi",
+			create: func() (types.Call, error) {
+				return m.CancelAsMulti(meta, a.alice, timepoint, callHash)
+			},
+			expectedCall: types.Call{
+				CallIndex: types.CallIndex{SectionIndex: 30, MethodIndex: 3},
+				Args:      statetest.Encode(t, threshold, others, timepoint, callHash),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			call, err := test.create()
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCall, call)
+		})
+	}
+
+	_, err = m.AsMulti(meta, getTestAccountID(t, "//Dave"), nil, remark, weight)
+	assert.ErrorIs(t, err, ErrNotSignatory)
+
+	_, err = m.AsMultiThreshold1(meta, a.alice, remark)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+
+	m1, err := New([]types.AccountID{a.alice, a.bob}, 1)
+	require.NoError(t, err)
+
+	call, err := m1.AsMultiThreshold1(meta, a.alice, remark)
+	require.NoError(t, err)
+	assert.Equal(t, types.Call{
+		CallIndex: types.CallIndex{SectionIndex: 30, MethodIndex: 0},
+		Args:      statetest.Encode(t, []types.AccountID{a.bob}, remark),
+	}, call)
+}
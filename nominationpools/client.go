@@ -0,0 +1,225 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nominationpools
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/staking"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	nominationPoolsPallet = "NominationPools"
+	poolMembersEntry      = "PoolMembers"
+	bondedPoolsEntry      = "BondedPools"
+	rewardPoolsEntry      = "RewardPools"
+	subPoolsEntry         = "SubPoolsStorage"
+	palletIDConstant      = "PalletId"
+	pendingRewardsMethod  = "NominationPoolsApi_pending_rewards"
+)
+
+// accountType is the type of the accounts of a pool, see BondedAccount and RewardAccount.
+type accountType uint8
+
+const (
+	bondedAccount accountType = iota
+	rewardAccount
+)
+
+// Client reads pools and their members from the nomination pools pallet.
+type Client struct {
+	stateRPC state.State
+	staking  *staking.Client
+}
+
+// NewClient creates a Client that reads the storage of the nomination pools pallet via the state RPC.
+func NewClient(stateRPC state.State) *Client {
+	return &Client{
+		stateRPC: stateRPC,
+		staking:  staking.NewClient(stateRPC),
+	}
+}
+
+// GetBondedPool returns the pool with the given ID, or nil if there is none.
+func (c *Client) GetBondedPool(ctx context.Context, meta *types.Metadata, poolID uint32) (*BondedPool, error) {
+	raw, err := state.GetStorageEntryRawLatest(
+		ctx, c.stateRPC, meta, nominationPoolsPallet, bondedPoolsEntry, encodeU32(poolID),
+	)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+
+	pool, err := decodeBondedPool(meta, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	pool.ID = poolID
+
+	return pool, nil
+}
+
+// GetRewardPool returns the reward pool of the pool with the given ID, or nil if there is none.
+func (c *Client) GetRewardPool(ctx context.Context, meta *types.Metadata, poolID uint32) (*RewardPool, error) {
+	raw, err := state.GetStorageEntryRawLatest(
+		ctx, c.stateRPC, meta, nominationPoolsPallet, rewardPoolsEntry, encodeU32(poolID),
+	)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+
+	return decodeRewardPool(meta, raw)
+}
+
+// GetSubPools returns the unbond pools of the pool with the given ID, or nil if nothing is unbonding.
+func (c *Client) GetSubPools(ctx context.Context, meta *types.Metadata, poolID uint32) (*SubPools, error) {
+	var subPools SubPools
+
+	ok, err := state.GetStorageEntryLatest(
+		ctx, c.stateRPC, meta, nominationPoolsPallet, subPoolsEntry, &subPools, encodeU32(poolID),
+	)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &subPools, nil
+}
+
+// GetPoolBalance returns the balance bonded by the pool with the given ID, which is the active stake of its bonded
+// account.
+func (c *Client) GetPoolBalance(ctx context.Context, meta *types.Metadata, poolID uint32) (types.U128, error) {
+	account, err := BondedAccount(meta, poolID)
+	if err != nil {
+		return types.U128{}, err
+	}
+
+	ledger, err := c.staking.GetLedger(ctx, meta, account)
+	if err != nil {
+		return types.U128{}, ErrBondedBalanceRetrieval.WithMsg("pool %d", poolID).Wrap(err)
+	}
+
+	if ledger == nil {
+		return types.NewU128(*big.NewInt(0)), nil
+	}
+
+	return ledger.Active, nil
+}
+
+// GetPoolMember returns the membership of the account, or nil if the account is no member of a pool. The balances of
+// the points of the member are converted like the pallet does, the pending rewards are computed by the runtime as the
+// reward formula of the pallet changed over time.
+func (c *Client) GetPoolMember(ctx context.Context, meta *types.Metadata, account types.AccountID) (*Member, error) {
+	var poolMember PoolMember
+
+	ok, err := state.GetStorageEntryLatest(
+		ctx, c.stateRPC, meta, nominationPoolsPallet, poolMembersEntry, &poolMember, account[:],
+	)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	poolID := uint32(poolMember.PoolID)
+
+	pool, err := c.GetBondedPool(ctx, meta, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pool == nil {
+		return nil, ErrPoolNotFound.WithMsg("pool %d", poolID)
+	}
+
+	balance, err := c.GetPoolBalance(ctx, meta, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	member := &Member{
+		Account:       account,
+		PoolID:        poolID,
+		Points:        poolMember.Points,
+		ActiveBalance: PointsToBalance(balance, pool.Points, poolMember.Points),
+		Unbonding:     make([]UnbondingBalance, 0, len(poolMember.UnbondingEras)),
+	}
+
+	if len(poolMember.UnbondingEras) > 0 {
+		subPools, err := c.GetSubPools(ctx, meta, poolID)
+		if err != nil {
+			return nil, err
+		}
+
+		if subPools == nil {
+			subPools = &SubPools{}
+		}
+
+		for _, unbonding := range poolMember.UnbondingEras {
+			unbondPool := subPools.UnbondPoolOf(uint32(unbonding.Era))
+
+			member.Unbonding = append(member.Unbonding, UnbondingBalance{
+				Era:     uint32(unbonding.Era),
+				Points:  unbonding.Points,
+				Balance: PointsToBalance(unbondPool.Balance, unbondPool.Points, unbonding.Points),
+			})
+		}
+	}
+
+	if err := c.stateRPC.RuntimeCallLatestContext(ctx, pendingRewardsMethod, &member.PendingRewards, account); err != nil {
+		return nil, ErrPendingRewardsCall.Wrap(err)
+	}
+
+	return member, nil
+}
+
+// BondedAccount returns the account that bonds the funds of the pool with the given ID.
+func BondedAccount(meta *types.Metadata, poolID uint32) (types.AccountID, error) {
+	return poolAccount(meta, bondedAccount, poolID)
+}
+
+// RewardAccount returns the account that receives the rewards of the pool with the given ID.
+func RewardAccount(meta *types.Metadata, poolID uint32) (types.AccountID, error) {
+	return poolAccount(meta, rewardAccount, poolID)
+}
+
+// poolAccount returns the sub account of the pallet ID for the account type and pool, like
+// PalletId::into_sub_account_truncating: "modl", the pallet ID and the encoded account type and pool ID, padded with
+// zeros.
+func poolAccount(meta *types.Metadata, accountType accountType, poolID uint32) (types.AccountID, error) {
+	palletID, err := meta.FindConstantValue(nominationPoolsPallet, palletIDConstant)
+	if err != nil {
+		return types.AccountID{}, ErrPalletIDNotFound.Wrap(err)
+	}
+
+	var account types.AccountID
+
+	n := copy(account[:], "modl")
+	n += copy(account[n:], palletID)
+	n += copy(account[n:], []byte{byte(accountType)})
+	copy(account[n:], encodeU32(poolID))
+
+	return account, nil
+}
+
+// encodeU32 returns the SCALE encoded u32, like the pool IDs in the keys of the pool storage entries.
+func encodeU32(v uint32) []byte {
+	b, _ := codec.Encode(types.NewU32(v))
+
+	return b
+}
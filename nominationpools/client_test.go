@@ -0,0 +1,342 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nominationpools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccount   = types.AccountID{1}
+	testDepositor = types.AccountID{2}
+	testRoot      = types.AccountID{3}
+)
+
+// withCommission returns a copy of the metadata with the layouts of BondedPoolInner and RewardPool of runtimes with
+// pool commissions, which reordered the fields of the pools and renamed the state toggler role to bouncer. Only the
+// names of the fields are declared, the decoders do not depend on their types.
+func withCommission(t *testing.T, meta *types.Metadata) *types.Metadata {
+	withCommission := *meta
+	withCommission.AsMetadataV14.EfficientLookup = make(map[int64]*types.Si1Type)
+
+	for id, ty := range meta.AsMetadataV14.EfficientLookup {
+		withCommission.AsMetadataV14.EfficientLookup[id] = ty
+	}
+
+	nextID := int64(1_000_000)
+	addComposite := func(path types.Text, fields ...types.Si1Field) types.Si1LookupTypeID {
+		nextID++
+		withCommission.AsMetadataV14.EfficientLookup[nextID] = &types.Si1Type{
+			Path: types.Si1Path{"pallet_nomination_pools", path},
+			Def:  types.Si1TypeDef{IsComposite: true, Composite: types.Si1TypeDefComposite{Fields: fields}},
+		}
+
+		return types.NewSi1LookupTypeIDFromUInt(uint64(nextID))
+	}
+	field := func(name types.Text, id ...types.Si1LookupTypeID) types.Si1Field {
+		f := types.Si1Field{HasName: true, Name: name}
+
+		if len(id) > 0 {
+			f.Type = id[0]
+		}
+
+		return f
+	}
+
+	commission := addComposite("Commission",
+		field("current"), field("max"), field("change_rate"), field("throttle_from"), field("claim_permission"),
+	)
+	roles := addComposite("PoolRoles", field("depositor"), field("root"), field("nominator"), field("bouncer"))
+	bondedPool := addComposite("BondedPoolInner",
+		field("commission", commission), field("member_counter"), field("points"), field("roles", roles), field("state"),
+	)
+	rewardPool := addComposite("RewardPool",
+		field("last_recorded_reward_counter"), field("last_recorded_total_payouts"), field("total_rewards_claimed"),
+		field("total_commission_pending"), field("total_commission_claimed"),
+	)
+
+	withCommission.AsMetadataV14.Pallets = append([]types.PalletMetadataV14{}, meta.AsMetadataV14.Pallets...)
+
+	for i, pallet := range withCommission.AsMetadataV14.Pallets {
+		if pallet.Name != nominationPoolsPallet {
+			continue
+		}
+
+		pallet.Storage.Items = append([]types.StorageEntryMetadataV14{}, pallet.Storage.Items...)
+
+		for j, item := range pallet.Storage.Items {
+			switch item.Name {
+			case bondedPoolsEntry:
+				pallet.Storage.Items[j].Type.AsMap.Value = bondedPool
+			case rewardPoolsEntry:
+				pallet.Storage.Items[j].Type.AsMap.Value = rewardPool
+			}
+		}
+
+		withCommission.AsMetadataV14.Pallets[i] = pallet
+
+		return &withCommission
+	}
+
+	t.Fatal("nomination pools pallet not found")
+
+	return nil
+}
+
+func TestClient_GetBondedPool(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without commission", func(t *testing.T) {
+		meta := statetest.PolkadotMetadata(t)
+
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorageRaw(t, meta, nominationPoolsPallet, bondedPoolsEntry, statetest.Encode(t,
+			u128(1000),
+			PoolBlocked,
+			types.NewU32(12),
+			testDepositor,
+			types.NewOption(testRoot),
+			types.NewEmptyOption[types.AccountID](),
+			types.NewOption(testRoot),
+		), encodeU32(7))
+
+		pool, err := c.GetBondedPool(ctx, meta, 7)
+		require.NoError(t, err)
+		assert.Equal(t, &BondedPool{
+			ID:            7,
+			Points:        u128(1000),
+			State:         PoolBlocked,
+			MemberCounter: 12,
+			Roles:         PoolRoles{Depositor: testDepositor, Root: &testRoot, Bouncer: &testRoot},
+		}, pool)
+
+		stateRPC.ExpectStorageRaw(t, meta, nominationPoolsPallet, bondedPoolsEntry, nil, encodeU32(8))
+
+		pool, err = c.GetBondedPool(ctx, meta, 8)
+		require.NoError(t, err)
+		assert.Nil(t, pool)
+	})
+
+	t.Run("with commission", func(t *testing.T) {
+		meta := withCommission(t, statetest.PolkadotMetadata(t))
+		current := CurrentCommission{Rate: types.NewPerbill(50_000_000), Payee: testRoot}
+
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorageRaw(t, meta, nominationPoolsPallet, bondedPoolsEntry, statetest.Encode(t,
+			types.NewOption(current),
+			types.NewOption(types.NewPerbill(100_000_000)),
+			types.NewEmptyOption[CommissionChangeRate](),
+			types.NewOption[types.U32](100),
+			types.NewOption(CommissionClaimPermission{IsPermissionless: true}),
+			types.NewU32(3),
+			u128(500),
+			testDepositor,
+			types.NewEmptyOption[types.AccountID](),
+			types.NewOption(testRoot),
+			types.NewEmptyOption[types.AccountID](),
+			PoolOpen,
+		), encodeU32(7))
+
+		pool, err := c.GetBondedPool(ctx, meta, 7)
+		require.NoError(t, err)
+
+		maxCommission := types.NewPerbill(100_000_000)
+		throttleFrom := uint32(100)
+
+		assert.Equal(t, &BondedPool{
+			ID:            7,
+			Points:        u128(500),
+			State:         PoolOpen,
+			MemberCounter: 3,
+			Roles:         PoolRoles{Depositor: testDepositor, Nominator: &testRoot},
+			Commission: Commission{
+				Current:         &current,
+				Max:             &maxCommission,
+				ThrottleFrom:    &throttleFrom,
+				ClaimPermission: &CommissionClaimPermission{IsPermissionless: true},
+			},
+		}, pool)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		meta := statetest.PolkadotMetadata(t)
+
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorageRaw(
+			t, meta, nominationPoolsPallet, bondedPoolsEntry, statetest.Encode(t, u128(1000), PoolOpen), encodeU32(7),
+		)
+
+		_, err := c.GetBondedPool(ctx, meta, 7)
+		assert.ErrorIs(t, err, ErrStorageDecoding)
+
+		stateRPC.ExpectStorageRaw(t, meta, nominationPoolsPallet, bondedPoolsEntry, append(statetest.Encode(t,
+			u128(1000), PoolOpen, types.NewU32(1), testDepositor,
+		), 0, 0, 0, 0), encodeU32(7))
+
+		_, err = c.GetBondedPool(ctx, meta, 7)
+		assert.ErrorIs(t, err, ErrStorageDecoding)
+
+		stateRPC.On("GetStorageRawLatestContext", mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+		_, err = c.GetBondedPool(ctx, meta, 7)
+		assert.ErrorIs(t, err, ErrStorageRetrieval)
+	})
+}
+
+func TestClient_GetRewardPool(t *testing.T) {
+	ctx := context.Background()
+
+	meta := statetest.PolkadotMetadata(t)
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorageRaw(
+		t, meta, nominationPoolsPallet, rewardPoolsEntry, statetest.Encode(t, u128(1), u128(2), u128(3)), encodeU32(7),
+	)
+
+	pool, err := c.GetRewardPool(ctx, meta, 7)
+	require.NoError(t, err)
+	assert.Equal(t, &RewardPool{
+		LastRecordedRewardCounter: u128(1),
+		LastRecordedTotalPayouts:  u128(2),
+		TotalRewardsClaimed:       u128(3),
+	}, pool)
+
+	meta = withCommission(t, meta)
+
+	stateRPC.ExpectStorageRaw(
+		t,
+		meta,
+		nominationPoolsPallet,
+		rewardPoolsEntry,
+		statetest.Encode(t, u128(1), u128(2), u128(3), u128(4), u128(5)),
+		encodeU32(7),
+	)
+
+	pool, err = c.GetRewardPool(ctx, meta, 7)
+	require.NoError(t, err)
+	assert.Equal(t, &RewardPool{
+		LastRecordedRewardCounter: u128(1),
+		LastRecordedTotalPayouts:  u128(2),
+		TotalRewardsClaimed:       u128(3),
+		TotalCommissionPending:    u128(4),
+		TotalCommissionClaimed:    u128(5),
+	}, pool)
+}
+
+func TestClient_GetPoolMember(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+
+	bonded, err := BondedAccount(meta, 7)
+	require.NoError(t, err)
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorage(t, meta, nominationPoolsPallet, poolMembersEntry, PoolMember{
+		PoolID:                    7,
+		Points:                    u128(200),
+		LastRecordedRewardCounter: u128(0),
+		UnbondingEras: []UnbondingPoints{
+			{Era: 10, Points: u128(50)},
+			{Era: 20, Points: u128(30)},
+		},
+	}, testAccount[:])
+	stateRPC.ExpectStorageRaw(t, meta, nominationPoolsPallet, bondedPoolsEntry, statetest.Encode(t,
+		u128(1000),
+		PoolOpen,
+		types.NewU32(5),
+		testDepositor,
+		types.NewEmptyOption[types.AccountID](),
+		types.NewEmptyOption[types.AccountID](),
+		types.NewEmptyOption[types.AccountID](),
+	), encodeU32(7))
+	stateRPC.ExpectStorage(t, meta, "Staking", "Bonded", bonded, bonded[:])
+	stateRPC.ExpectStorage(t, meta, "Staking", "Ledger", struct {
+		Stash     types.AccountID
+		Total     types.UCompact
+		Active    types.UCompact
+		Unlocking []struct{}
+	}{
+		Stash:  bonded,
+		Total:  types.NewUCompactFromUInt(1500),
+		Active: types.NewUCompactFromUInt(1500),
+	}, bonded[:])
+	stateRPC.ExpectStorage(t, meta, nominationPoolsPallet, subPoolsEntry, SubPools{
+		NoEra:   UnbondPool{Points: u128(100), Balance: u128(80)},
+		WithEra: []EraUnbondPool{{Era: 20, Pool: UnbondPool{Points: u128(60), Balance: u128(60)}}},
+	}, encodeU32(7))
+	stateRPC.On("RuntimeCallLatestContext", mock.Anything, pendingRewardsMethod, mock.Anything, testAccount).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*types.U128) = u128(42)
+		}).
+		Return(nil).
+		Once()
+
+	member, err := c.GetPoolMember(ctx, meta, testAccount)
+	require.NoError(t, err)
+	assert.Equal(t, &Member{
+		Account:       testAccount,
+		PoolID:        7,
+		Points:        u128(200),
+		ActiveBalance: u128(300),
+		Unbonding: []UnbondingBalance{
+			{Era: 10, Points: u128(50), Balance: u128(40)},
+			{Era: 20, Points: u128(30), Balance: u128(30)},
+		},
+		PendingRewards: u128(42),
+	}, member)
+
+	stateRPC.ExpectStorage(t, meta, nominationPoolsPallet, poolMembersEntry, nil, testAccount[:])
+
+	member, err = c.GetPoolMember(ctx, meta, testAccount)
+	require.NoError(t, err)
+	assert.Nil(t, member)
+}
+
+func TestPoolAccounts(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+
+	bonded, err := BondedAccount(meta, 7)
+	require.NoError(t, err)
+	assert.Equal(t, types.AccountID{'m', 'o', 'd', 'l', 'p', 'y', '/', 'n', 'o', 'p', 'l', 's', 0, 7}, bonded)
+
+	reward, err := RewardAccount(meta, 7)
+	require.NoError(t, err)
+	assert.Equal(t, types.AccountID{'m', 'o', 'd', 'l', 'p', 'y', '/', 'n', 'o', 'p', 'l', 's', 1, 7}, reward)
+
+	withoutPools := *meta
+	withoutPools.AsMetadataV14.Pallets = nil
+
+	_, err = BondedAccount(&withoutPools, 7)
+	assert.ErrorIs(t, err, ErrPalletIDNotFound)
+}
+
+func TestPoolState_String(t *testing.T) {
+	assert.Equal(t, "Destroying", PoolDestroying.String())
+	assert.Equal(t, "PoolState(3)", PoolState(3).String())
+
+	var state PoolState
+	assert.Error(t, codec.Decode([]byte{3}, &state))
+}
@@ -0,0 +1,179 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nominationpools
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// fieldDecoder decodes a field of the type with the given ID.
+type fieldDecoder func(decoder *scale.Decoder, id types.Si1LookupTypeID) error
+
+// The fields of BondedPoolInner and RewardPool were reordered and extended with the commission of pools, so their
+// values are decoded by the names of the fields declared by the metadata.
+
+// decodeBondedPool decodes the NominationPools.BondedPools value, a pallet_nomination_pools::BondedPoolInner.
+func decodeBondedPool(meta *types.Metadata, data []byte) (*BondedPool, error) {
+	var (
+		pool          BondedPool
+		memberCounter types.U32
+	)
+
+	lookup := meta.AsMetadataV14.EfficientLookup
+
+	fields := map[string]fieldDecoder{
+		"points":         valueOf(&pool.Points),
+		"state":          valueOf(&pool.State),
+		"member_counter": valueOf(&memberCounter),
+		"roles": func(decoder *scale.Decoder, id types.Si1LookupTypeID) error {
+			return decodeComposite(lookup, id, decoder, rolesFields(&pool.Roles))
+		},
+		"commission": func(decoder *scale.Decoder, id types.Si1LookupTypeID) error {
+			return decodeComposite(lookup, id, decoder, commissionFields(&pool.Commission))
+		},
+	}
+
+	if err := decodeStorageValue(meta, bondedPoolsEntry, data, fields); err != nil {
+		return nil, err
+	}
+
+	pool.MemberCounter = uint32(memberCounter)
+
+	return &pool, nil
+}
+
+// decodeRewardPool decodes the NominationPools.RewardPools value, a pallet_nomination_pools::RewardPool.
+func decodeRewardPool(meta *types.Metadata, data []byte) (*RewardPool, error) {
+	var pool RewardPool
+
+	fields := map[string]fieldDecoder{
+		"last_recorded_reward_counter": valueOf(&pool.LastRecordedRewardCounter),
+		"last_recorded_total_payouts":  valueOf(&pool.LastRecordedTotalPayouts),
+		"total_rewards_claimed":        valueOf(&pool.TotalRewardsClaimed),
+		"total_commission_pending":     valueOf(&pool.TotalCommissionPending),
+		"total_commission_claimed":     valueOf(&pool.TotalCommissionClaimed),
+	}
+
+	if err := decodeStorageValue(meta, rewardPoolsEntry, data, fields); err != nil {
+		return nil, err
+	}
+
+	return &pool, nil
+}
+
+func rolesFields(roles *PoolRoles) map[string]fieldDecoder {
+	return map[string]fieldDecoder{
+		"depositor":     valueOf(&roles.Depositor),
+		"root":          optionOf(&roles.Root),
+		"nominator":     optionOf(&roles.Nominator),
+		"bouncer":       optionOf(&roles.Bouncer),
+		"state_toggler": optionOf(&roles.Bouncer),
+	}
+}
+
+func commissionFields(commission *Commission) map[string]fieldDecoder {
+	return map[string]fieldDecoder{
+		"current":          optionOf(&commission.Current),
+		"max":              optionOf(&commission.Max),
+		"change_rate":      optionOf(&commission.ChangeRate),
+		"throttle_from":    optionOf(&commission.ThrottleFrom),
+		"claim_permission": optionOf(&commission.ClaimPermission),
+	}
+}
+
+// decodeStorageValue decodes the value of the storage entry of the nomination pools pallet with the field decoders.
+func decodeStorageValue(meta *types.Metadata, entry string, data []byte, fields map[string]fieldDecoder) error {
+	entryMetadata, err := meta.FindStorageEntryMetadata(nominationPoolsPallet, entry)
+	if err != nil {
+		return ErrStorageEntryNotFound.WithMsg("%s.%s", nominationPoolsPallet, entry).Wrap(err)
+	}
+
+	entryV14, ok := entryMetadata.(types.StorageEntryMetadataV14)
+	if !ok || !entryV14.Type.IsMap {
+		return ErrUnsupportedLayout.WithMsg("%s.%s is not a map", nominationPoolsPallet, entry)
+	}
+
+	reader := bytes.NewReader(data)
+	decoder := scale.NewDecoder(reader)
+
+	err = decodeComposite(meta.AsMetadataV14.EfficientLookup, entryV14.Type.AsMap.Value, decoder, fields)
+	if err != nil {
+		return err
+	}
+
+	if reader.Len() > 0 {
+		return ErrStorageDecoding.WithMsg("%s.%s has %d trailing bytes", nominationPoolsPallet, entry, reader.Len())
+	}
+
+	return nil
+}
+
+// decodeComposite decodes the fields of the composite type in the order declared by the metadata, using the decoder
+// for the name of each field.
+func decodeComposite(
+	lookup map[int64]*types.Si1Type,
+	id types.Si1LookupTypeID,
+	decoder *scale.Decoder,
+	fields map[string]fieldDecoder,
+) error {
+	t, ok := lookup[id.Int64()]
+	if !ok || !t.Def.IsComposite {
+		return ErrUnsupportedLayout.WithMsg("type %d is not a composite", id.Int64())
+	}
+
+	for _, field := range t.Def.Composite.Fields {
+		name := string(field.Name)
+
+		decode, ok := fields[name]
+		if !ok {
+			return ErrUnsupportedLayout.WithMsg("field %s of %v", name, t.Path)
+		}
+
+		if err := decode(decoder, field.Type); err != nil {
+			return ErrStorageDecoding.WithMsg("field %s of %v", name, t.Path).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// valueOf returns a fieldDecoder that decodes the field into the target.
+func valueOf(target any) fieldDecoder {
+	return func(decoder *scale.Decoder, _ types.Si1LookupTypeID) error {
+		return decoder.Decode(target)
+	}
+}
+
+// optionOf returns a fieldDecoder that decodes an optional field into the target, which is nil if the field is None.
+func optionOf[T any](target **T) fieldDecoder {
+	return func(decoder *scale.Decoder, _ types.Si1LookupTypeID) error {
+		var option types.Option[T]
+
+		if err := decoder.Decode(&option); err != nil {
+			return err
+		}
+
+		if ok, value := option.Unwrap(); ok {
+			*target = &value
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,71 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nominationpools
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// maxU128 is the largest balance, which results of the points conversions saturate at.
+var maxU128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+// BalanceToPoints returns the points the funds are worth in a pool with the given balance and points, like
+// BondedPool::balance_to_point. The points of pools without points are issued 1:1, the points of pools without
+// balance are issued proportional to the points of the pool. Results are rounded down and saturate at the maximum
+// balance.
+func BalanceToPoints(poolBalance, poolPoints, funds types.U128) types.U128 {
+	balance, points, newFunds := intOf(poolBalance), intOf(poolPoints), intOf(funds)
+
+	switch {
+	case points.Sign() == 0:
+		return saturatingU128(newFunds)
+	case balance.Sign() == 0:
+		return saturatingU128(new(big.Int).Mul(newFunds, points))
+	default:
+		return saturatingU128(new(big.Int).Div(new(big.Int).Mul(points, newFunds), balance))
+	}
+}
+
+// PointsToBalance returns the balance the points are worth in a pool with the given balance and points, like
+// BondedPool::point_to_balance, which is proportional to the balance of the pool. Results are rounded down.
+func PointsToBalance(poolBalance, poolPoints, points types.U128) types.U128 {
+	balance, totalPoints, p := intOf(poolBalance), intOf(poolPoints), intOf(points)
+
+	if balance.Sign() == 0 || totalPoints.Sign() == 0 || p.Sign() == 0 {
+		return types.NewU128(*big.NewInt(0))
+	}
+
+	return saturatingU128(new(big.Int).Div(new(big.Int).Mul(balance, p), totalPoints))
+}
+
+func intOf(u types.U128) *big.Int {
+	if u.Int == nil {
+		return new(big.Int)
+	}
+
+	return u.Int
+}
+
+func saturatingU128(i *big.Int) types.U128 {
+	if i.Cmp(maxU128) > 0 {
+		return types.NewU128(*new(big.Int).Set(maxU128))
+	}
+
+	return types.NewU128(*i)
+}
@@ -0,0 +1,73 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nominationpools
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func u128(i int64) types.U128 {
+	return types.NewU128(*big.NewInt(i))
+}
+
+func TestBalanceToPoints(t *testing.T) {
+	tests := []struct {
+		balance, points, funds, expected types.U128
+	}{
+		// Points are issued 1:1 to the first members.
+		{u128(0), u128(0), u128(100), u128(100)},
+		{u128(100), u128(100), u128(50), u128(50)},
+		// Slashed pools issue more points, rounded down.
+		{u128(90), u128(100), u128(50), u128(55)},
+		// Pools without balance issue points proportional to their points.
+		{u128(0), u128(100), u128(5), u128(500)},
+		{u128(100), u128(100), u128(0), u128(0)},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, BalanceToPoints(test.balance, test.points, test.funds))
+	}
+
+	// Results saturate at the maximum balance.
+	assert.Equal(t, types.NewU128(*maxU128), BalanceToPoints(u128(0), types.NewU128(*maxU128), u128(2)))
+	assert.Equal(t, u128(7), BalanceToPoints(types.U128{}, types.U128{}, u128(7)))
+}
+
+func TestPointsToBalance(t *testing.T) {
+	tests := []struct {
+		balance, totalPoints, points, expected types.U128
+	}{
+		{u128(100), u128(100), u128(50), u128(50)},
+		// Slashed pools are worth less, rounded down.
+		{u128(90), u128(100), u128(55), u128(49)},
+		// Pools with rewards bonded are worth more.
+		{u128(150), u128(100), u128(50), u128(75)},
+		{u128(0), u128(100), u128(50), u128(0)},
+		{u128(100), u128(0), u128(50), u128(0)},
+		{u128(100), u128(100), u128(0), u128(0)},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, PointsToBalance(test.balance, test.totalPoints, test.points))
+	}
+
+	assert.Equal(t, u128(0), PointsToBalance(types.U128{}, u128(100), u128(50)))
+}
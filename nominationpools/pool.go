@@ -0,0 +1,221 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nominationpools
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// PoolState is the state of a bonded pool.
+type PoolState uint8
+
+const (
+	// PoolOpen pools can be joined by anyone.
+	PoolOpen PoolState = iota
+	// PoolBlocked pools can not be joined, members can be kicked by the root and bouncer roles.
+	PoolBlocked
+	// PoolDestroying pools can not be joined, members can be kicked by anyone.
+	PoolDestroying
+)
+
+var poolStateNames = []string{"Open", "Blocked", "Destroying"}
+
+func (s PoolState) String() string {
+	if int(s) < len(poolStateNames) {
+		return poolStateNames[s]
+	}
+
+	return fmt.Sprintf("PoolState(%d)", uint8(s))
+}
+
+func (s *PoolState) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	if int(b) >= len(poolStateNames) {
+		return fmt.Errorf("invalid pool state %d", b)
+	}
+
+	*s = PoolState(b)
+
+	return nil
+}
+
+func (s PoolState) Encode(encoder scale.Encoder) error {
+	return encoder.PushByte(byte(s))
+}
+
+// PoolRoles are the accounts with roles in a pool. Roles that are not assigned are nil.
+type PoolRoles struct {
+	Depositor types.AccountID
+	Root      *types.AccountID
+	Nominator *types.AccountID
+	// Bouncer may change the state of the pool, it was called the state toggler in older runtimes.
+	Bouncer *types.AccountID
+}
+
+// CurrentCommission is the commission rate of a pool and the account it is paid to.
+type CurrentCommission struct {
+	Rate  types.Perbill
+	Payee types.AccountID
+}
+
+// CommissionChangeRate limits how much and how often the commission of a pool can be increased.
+type CommissionChangeRate struct {
+	MaxIncrease types.Perbill
+	MinDelay    types.U32
+}
+
+// CommissionClaimPermission is the permission to claim the commission of a pool on behalf of the root role.
+type CommissionClaimPermission struct {
+	IsPermissionless bool
+	IsAccount        bool
+	AsAccount        types.AccountID
+}
+
+func (p *CommissionClaimPermission) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		p.IsPermissionless = true
+
+		return nil
+	case 1:
+		p.IsAccount = true
+
+		return decoder.Decode(&p.AsAccount)
+	}
+
+	return fmt.Errorf("invalid commission claim permission variant %d", b)
+}
+
+func (p CommissionClaimPermission) Encode(encoder scale.Encoder) error {
+	switch {
+	case p.IsPermissionless:
+		return encoder.PushByte(0)
+	case p.IsAccount:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(p.AsAccount)
+	}
+
+	return nil
+}
+
+// Commission is the commission of a pool, which older runtimes do not have. Unset settings are nil.
+type Commission struct {
+	Current         *CurrentCommission
+	Max             *types.Perbill
+	ChangeRate      *CommissionChangeRate
+	ThrottleFrom    *uint32
+	ClaimPermission *CommissionClaimPermission
+}
+
+// BondedPool is a pool as stored in NominationPools.BondedPools.
+type BondedPool struct {
+	ID uint32
+	// Points are the points of all members of the pool, see PointsToBalance.
+	Points        types.U128
+	State         PoolState
+	MemberCounter uint32
+	Roles         PoolRoles
+	Commission    Commission
+}
+
+// RewardPool is the reward pool of a pool as stored in NominationPools.RewardPools. The commission totals are zero on
+// runtimes without commission.
+type RewardPool struct {
+	LastRecordedRewardCounter types.U128
+	LastRecordedTotalPayouts  types.U128
+	TotalRewardsClaimed       types.U128
+	TotalCommissionPending    types.U128
+	TotalCommissionClaimed    types.U128
+}
+
+// UnbondPool is the points and the balance unbonding from a pool.
+type UnbondPool struct {
+	Points  types.U128
+	Balance types.U128
+}
+
+// EraUnbondPool is the unbond pool of the funds that were unbonded in an era.
+type EraUnbondPool struct {
+	Era  types.U32
+	Pool UnbondPool
+}
+
+// SubPools are the unbond pools of a pool as stored in NominationPools.SubPoolsStorage. Unbond pools of eras that are
+// too old are merged into NoEra.
+type SubPools struct {
+	NoEra   UnbondPool
+	WithEra []EraUnbondPool
+}
+
+// UnbondPoolOf returns the unbond pool the funds unbonded in the era are in.
+func (s SubPools) UnbondPoolOf(era uint32) UnbondPool {
+	for _, pool := range s.WithEra {
+		if uint32(pool.Era) == era {
+			return pool.Pool
+		}
+	}
+
+	return s.NoEra
+}
+
+// UnbondingPoints are the points of a member that unbond in an era.
+type UnbondingPoints struct {
+	Era    types.U32
+	Points types.U128
+}
+
+// PoolMember is a member of a pool as stored in NominationPools.PoolMembers.
+type PoolMember struct {
+	PoolID                    types.U32
+	Points                    types.U128
+	LastRecordedRewardCounter types.U128
+	UnbondingEras             []UnbondingPoints
+}
+
+// UnbondingBalance are the points of a member that unbond in an era and the balance they are worth.
+type UnbondingBalance struct {
+	Era     uint32
+	Points  types.U128
+	Balance types.U128
+}
+
+// Member is a member of a pool with the balances its points are worth.
+type Member struct {
+	Account types.AccountID
+	PoolID  uint32
+	Points  types.U128
+	// ActiveBalance is the bonded balance the points are worth.
+	ActiveBalance types.U128
+	Unbonding     []UnbondingBalance
+	// PendingRewards are the rewards the member can claim, as computed by the runtime.
+	PendingRewards types.U128
+}
@@ -0,0 +1,87 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgsrpc_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/otelgsrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/exec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
+	regState "github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Example traces the RPC calls and the event decoding of the latest block, the spans are written to stdout.
+func Example() {
+	exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+	if err != nil {
+		panic(err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer tp.Shutdown(context.Background()) //nolint:errcheck
+
+	endpoint := "wss://rpc.polkadot.io"
+
+	interceptor := otelgsrpc.NewInterceptor(tp, otelgsrpc.InterceptorOptions{Endpoint: endpoint})
+
+	cl, err := client.NewMiddleware().Use(interceptor.Intercept).Connect(endpoint)
+	if err != nil {
+		panic(err)
+	}
+
+	defer cl.Close()
+
+	api, err := rpc.NewRPC(cl)
+	if err != nil {
+		panic(err)
+	}
+
+	eventRetriever, err := retriever.NewEventRetriever(
+		parser.NewEventParser(),
+		regState.NewEventProvider(api.State),
+		api.State,
+		registry.NewFactory(),
+		exec.NewRetryableExecutor[*types.StorageDataRaw](exec.WithRetryTimeout(1*time.Second)),
+		exec.NewRetryableExecutor[[]*parser.Event](exec.WithMaxRetryCount(1)),
+		retriever.WithTracer(otelgsrpc.NewTracer(tp)),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	blockHash, err := api.Chain.GetBlockHashLatest()
+	if err != nil {
+		panic(err)
+	}
+
+	events, err := eventRetriever.GetEvents(blockHash)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Decoded %d events\n", len(events))
+}
@@ -0,0 +1,88 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgsrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InterceptorOptions configure an Interceptor.
+type InterceptorOptions struct {
+	// Endpoint is the endpoint the client is connected to, it is added to the spans if it is not empty. The endpoint
+	// of a client.EndpointError takes precedence.
+	Endpoint string
+}
+
+// Interceptor creates a span of kind client per call and subscription request, named after the method, with the
+// attributes rpc.system, rpc.method, rpc.endpoint, rpc.request.size, i.e. the size of the JSON encoded params, and
+// rpc.error_code for failed calls, see client.ErrorCode.
+type Interceptor struct {
+	tracer trace.Tracer
+	opts   InterceptorOptions
+}
+
+// NewInterceptor creates a new Interceptor that creates its spans via the tracer provider.
+func NewInterceptor(tp trace.TracerProvider, opts InterceptorOptions) *Interceptor {
+	return &Interceptor{
+		tracer: tp.Tracer(InstrumentationName),
+		opts:   opts,
+	}
+}
+
+// Intercept implements client.Interceptor
+func (i *Interceptor) Intercept(
+	ctx context.Context,
+	method string,
+	params []interface{},
+	next client.Invoker,
+) (interface{}, error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.system", "jsonrpc"),
+		attribute.String("rpc.method", method),
+	}
+
+	if i.opts.Endpoint != "" {
+		attrs = append(attrs, attribute.String("rpc.endpoint", i.opts.Endpoint))
+	}
+
+	if b, err := json.Marshal(params); err == nil {
+		attrs = append(attrs, attribute.Int("rpc.request.size", len(b)))
+	}
+
+	ctx, span := i.tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+
+	res, err := next(ctx, method, params)
+
+	if err != nil {
+		var endpointErr *client.EndpointError
+		if errors.As(err, &endpointErr) {
+			span.SetAttributes(attribute.String("rpc.endpoint", endpointErr.Endpoint))
+		}
+
+		span.SetAttributes(attribute.String("rpc.error_code", client.ErrorCode(err)))
+	}
+
+	endSpan(span, err)
+
+	return res, err
+}
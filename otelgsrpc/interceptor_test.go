@@ -0,0 +1,106 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgsrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestTracerProvider() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+
+	return attrs
+}
+
+func TestInterceptor_Intercept(t *testing.T) {
+	tp, recorder := newTestTracerProvider()
+
+	cl := rpcmocksrv.NewMockClient().
+		Respond("chain_getBlockHash", "0x01", 1).
+		RespondError("system_health", rpcmocksrv.FixtureError{Code: -32000, Message: "unhealthy"})
+
+	interceptor := NewInterceptor(tp, InterceptorOptions{Endpoint: "ws://node:9944"})
+	c := client.NewMiddleware().Use(interceptor.Intercept).Wrap(cl)
+
+	var hash string
+	require.NoError(t, c.Call(&hash, "chain_getBlockHash", 1))
+	assert.Error(t, c.Call(nil, "system_health"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "chain_getBlockHash", spans[0].Name())
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	attrs := spanAttributes(spans[0])
+	assert.Equal(t, "jsonrpc", attrs["rpc.system"].AsString())
+	assert.Equal(t, "chain_getBlockHash", attrs["rpc.method"].AsString())
+	assert.Equal(t, "ws://node:9944", attrs["rpc.endpoint"].AsString())
+	assert.Equal(t, int64(len("[1]")), attrs["rpc.request.size"].AsInt64())
+	assert.NotContains(t, attrs, attribute.Key("rpc.error_code"))
+
+	assert.Equal(t, "system_health", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "-32000", spanAttributes(spans[1])["rpc.error_code"].AsString())
+	require.Len(t, spans[1].Events(), 1)
+	assert.Equal(t, "exception", spans[1].Events()[0].Name)
+}
+
+func TestInterceptor_Intercept_EndpointError(t *testing.T) {
+	tp, recorder := newTestTracerProvider()
+
+	interceptor := NewInterceptor(tp, InterceptorOptions{Endpoint: "ws://node-1:9944"})
+
+	_, err := interceptor.Intercept(
+		context.Background(),
+		"system_health",
+		nil,
+		func(context.Context, string, []interface{}) (interface{}, error) {
+			return nil, &client.EndpointError{Endpoint: "ws://node-2:9944", Err: context.DeadlineExceeded}
+		},
+	)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	attrs := spanAttributes(spans[0])
+	assert.Equal(t, "ws://node-2:9944", attrs["rpc.endpoint"].AsString())
+	assert.Equal(t, "deadline_exceeded", attrs["rpc.error_code"].AsString())
+}
@@ -0,0 +1,97 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelgsrpc instruments GSRPC with OpenTelemetry. It is a separate module, so that the main module does not
+// depend on OpenTelemetry.
+//
+// The Interceptor creates a span per RPC call, and the Tracer returned by NewTracer creates the spans of the high-level
+// helpers, e.g. of the event decoding of the retrievers, see retriever.WithTracer.
+package otelgsrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracers that are obtained from the tracer providers.
+const InstrumentationName = "github.com/centrifuge/go-substrate-rpc-client/v4/otelgsrpc"
+
+// NewTracer creates a tracing.Tracer that creates its spans via the tracer provider.
+func NewTracer(tp trace.TracerProvider) tracing.Tracer {
+	return &tracer{tracer: tp.Tracer(InstrumentationName)}
+}
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+func (t *tracer) Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, tracing.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(toKeyValues(attrs)...))
+
+	return ctx, &spanAdapter{span: span}
+}
+
+type spanAdapter struct {
+	span trace.Span
+}
+
+func (s *spanAdapter) SetAttributes(attrs ...slog.Attr) {
+	s.span.SetAttributes(toKeyValues(attrs)...)
+}
+
+func (s *spanAdapter) End(err error) {
+	endSpan(s.span, err)
+}
+
+// endSpan records the error, if any, as the error of the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// toKeyValues converts the attributes, the values of kinds without an attribute counterpart, e.g. durations, are
+// converted to strings.
+func toKeyValues(attrs []slog.Attr) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+
+	for _, attr := range attrs {
+		value := attr.Value.Resolve()
+
+		switch value.Kind() {
+		case slog.KindBool:
+			kvs = append(kvs, attribute.Bool(attr.Key, value.Bool()))
+		case slog.KindInt64:
+			kvs = append(kvs, attribute.Int64(attr.Key, value.Int64()))
+		case slog.KindUint64:
+			kvs = append(kvs, attribute.Int64(attr.Key, int64(value.Uint64())))
+		case slog.KindFloat64:
+			kvs = append(kvs, attribute.Float64(attr.Key, value.Float64()))
+		default:
+			kvs = append(kvs, attribute.String(attr.Key, value.String()))
+		}
+	}
+
+	return kvs
+}
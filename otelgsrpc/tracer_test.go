@@ -0,0 +1,68 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgsrpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestTracer(t *testing.T) {
+	tp, recorder := newTestTracerProvider()
+
+	tracer := NewTracer(tp)
+
+	ctx, parent := tracer.Start(context.Background(), "retriever.GetEvents", slog.String("block_hash", "0x01"))
+
+	_, child := tracer.Start(ctx, "retriever.ParseEvents")
+	child.SetAttributes(
+		slog.Int("events", 2),
+		slog.Uint64("spec_version", 42),
+		slog.Bool("missed", true),
+		slog.Float64("ratio", 0.5),
+		slog.Duration("duration", time.Second),
+	)
+	child.End(errors.New("error"))
+
+	parent.End(nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "retriever.ParseEvents", spans[0].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "error", spans[0].Status().Description)
+
+	attrs := spanAttributes(spans[0])
+	assert.Equal(t, int64(2), attrs["events"].AsInt64())
+	assert.Equal(t, int64(42), attrs["spec_version"].AsInt64())
+	assert.True(t, attrs["missed"].AsBool())
+	assert.Equal(t, 0.5, attrs["ratio"].AsFloat64())
+	assert.Equal(t, "1s", attrs["duration"].AsString())
+
+	assert.Equal(t, "retriever.GetEvents", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Equal(t, "0x01", spanAttributes(spans[1])["block_hash"].AsString())
+}
@@ -0,0 +1,157 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paras
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	candidateBackedEvent   = "ParaInclusion.CandidateBacked"
+	candidateIncludedEvent = "ParaInclusion.CandidateIncluded"
+	candidateTimedOutEvent = "ParaInclusion.CandidateTimedOut"
+)
+
+// CandidateEventKind is the kind of a candidate event of the ParaInclusion pallet.
+type CandidateEventKind uint8
+
+const (
+	// CandidateBacked is emitted when a candidate was backed by the validators of its core.
+	CandidateBacked CandidateEventKind = iota
+	// CandidateIncluded is emitted when a backed candidate became available and was included, i.e. the parachain block
+	// is part of the relay chain.
+	CandidateIncluded
+	// CandidateTimedOut is emitted when a backed candidate did not become available in time.
+	CandidateTimedOut
+)
+
+func (k CandidateEventKind) String() string {
+	switch k {
+	case CandidateBacked:
+		return "CandidateBacked"
+	case CandidateIncluded:
+		return "CandidateIncluded"
+	case CandidateTimedOut:
+		return "CandidateTimedOut"
+	}
+
+	return "Unknown"
+}
+
+// CandidateEvent is a candidate event of the ParaInclusion pallet.
+type CandidateEvent struct {
+	Kind CandidateEventKind
+	// Receipt is the receipt of the candidate, its descriptor holds the para ID, the relay parent, the collator and
+	// the validation code hash.
+	Receipt types.CandidateReceipt
+	// HeadData is the head of the parachain block of the candidate, e.g. its SCALE encoded header, see DecodeHeader.
+	HeadData  types.HeadData
+	CoreIndex types.CoreIndex
+	// GroupIndex is the validator group of the core, it is not set for CandidateTimedOut events.
+	GroupIndex types.GroupIndex
+}
+
+// ParaID returns the ID of the parachain of the candidate.
+func (e CandidateEvent) ParaID() uint32 {
+	return uint32(e.Receipt.Descriptor.ParachainID)
+}
+
+// ParaBlockHash returns the hash of the parachain block of the candidate, which is the hash of its head data.
+func (e CandidateEvent) ParaBlockHash() types.Hash {
+	return e.Receipt.Descriptor.ParaHead
+}
+
+// candidateEvent is the layout of the CandidateBacked and CandidateIncluded events.
+type candidateEvent struct {
+	Receipt    types.CandidateReceipt
+	HeadData   types.HeadData
+	CoreIndex  types.CoreIndex
+	GroupIndex types.GroupIndex
+}
+
+// timedOutEvent is the layout of the CandidateTimedOut event, which has no group index.
+type timedOutEvent struct {
+	Receipt   types.CandidateReceipt
+	HeadData  types.HeadData
+	CoreIndex types.CoreIndex
+}
+
+// DecodeCandidateEvents decodes the candidate events of the ParaInclusion pallet, e.g. the events of a relay chain
+// block retrieved with the event retriever of the registry. If para IDs are given, only the events of candidates of
+// these parachains are returned. Other events are ignored.
+func DecodeCandidateEvents(events []*parser.Event, paraIDs ...uint32) ([]CandidateEvent, error) {
+	var res []CandidateEvent
+
+	for _, event := range events {
+		var (
+			candidate CandidateEvent
+			err       error
+		)
+
+		switch event.Name {
+		case candidateBackedEvent, candidateIncludedEvent:
+			var e candidateEvent
+			if err = codec.Decode(event.Data, &e); err == nil {
+				candidate = CandidateEvent{
+					Kind:       CandidateBacked,
+					Receipt:    e.Receipt,
+					HeadData:   e.HeadData,
+					CoreIndex:  e.CoreIndex,
+					GroupIndex: e.GroupIndex,
+				}
+
+				if event.Name == candidateIncludedEvent {
+					candidate.Kind = CandidateIncluded
+				}
+			}
+		case candidateTimedOutEvent:
+			var e timedOutEvent
+			if err = codec.Decode(event.Data, &e); err == nil {
+				candidate = CandidateEvent{
+					Kind:      CandidateTimedOut,
+					Receipt:   e.Receipt,
+					HeadData:  e.HeadData,
+					CoreIndex: e.CoreIndex,
+				}
+			}
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, ErrEventDecoding.WithMsg(event.Name).Wrap(err)
+		}
+
+		if len(paraIDs) == 0 || containsParaID(paraIDs, candidate.ParaID()) {
+			res = append(res, candidate)
+		}
+	}
+
+	return res, nil
+}
+
+func containsParaID(paraIDs []uint32, paraID uint32) bool {
+	for _, id := range paraIDs {
+		if id == paraID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paras
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHeader(number uint32) types.Header {
+	return types.Header{
+		ParentHash:     types.Hash{byte(number - 1)},
+		Number:         types.BlockNumber(number),
+		StateRoot:      types.Hash{2},
+		ExtrinsicsRoot: types.Hash{3},
+	}
+}
+
+// newTestCandidate returns a candidate of the parachain whose head data is the header of the parachain block.
+func newTestCandidate(t *testing.T, kind CandidateEventKind, paraID uint32, paraBlock uint32) CandidateEvent {
+	head := types.HeadData{}
+	for _, b := range statetest.Encode(t, newTestHeader(paraBlock)) {
+		head = append(head, types.U8(b))
+	}
+
+	candidate := CandidateEvent{
+		Kind: kind,
+		Receipt: types.CandidateReceipt{
+			Descriptor: types.CandidateDescriptor{
+				ParachainID:        types.ParachainID(paraID),
+				RelayParent:        types.Hash{9},
+				CollatorID:         types.CollatorID{1},
+				ParaHead:           HeadHash(head),
+				ValidationCodeHash: types.Hash{8},
+			},
+			CommitmentsHash: types.Hash{7},
+		},
+		HeadData:  head,
+		CoreIndex: 3,
+	}
+
+	if kind != CandidateTimedOut {
+		candidate.GroupIndex = 4
+	}
+
+	return candidate
+}
+
+func newTestEvent(t *testing.T, candidate CandidateEvent) *parser.Event {
+	if candidate.Kind == CandidateTimedOut {
+		return &parser.Event{
+			Name: candidateTimedOutEvent,
+			Data: statetest.Encode(t, timedOutEvent{candidate.Receipt, candidate.HeadData, candidate.CoreIndex}),
+		}
+	}
+
+	name := candidateBackedEvent
+	if candidate.Kind == CandidateIncluded {
+		name = candidateIncludedEvent
+	}
+
+	return &parser.Event{
+		Name: name,
+		Data: statetest.Encode(
+			t, candidateEvent{candidate.Receipt, candidate.HeadData, candidate.CoreIndex, candidate.GroupIndex},
+		),
+	}
+}
+
+func TestDecodeCandidateEvents(t *testing.T) {
+	backed := newTestCandidate(t, CandidateBacked, 1000, 10)
+	included := newTestCandidate(t, CandidateIncluded, 2000, 20)
+	timedOut := newTestCandidate(t, CandidateTimedOut, 1000, 11)
+
+	events := []*parser.Event{
+		{Name: "System.ExtrinsicSuccess", Data: []byte{1}},
+		newTestEvent(t, backed),
+		newTestEvent(t, included),
+		newTestEvent(t, timedOut),
+	}
+
+	res, err := DecodeCandidateEvents(events)
+	require.NoError(t, err)
+	assert.Equal(t, []CandidateEvent{backed, included, timedOut}, res)
+
+	res, err = DecodeCandidateEvents(events, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, []CandidateEvent{backed, timedOut}, res)
+	assert.Equal(t, uint32(1000), res[0].ParaID())
+	assert.Equal(t, HeadHash(backed.HeadData), res[0].ParaBlockHash())
+
+	res, err = DecodeCandidateEvents(events, 3000)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	_, err = DecodeCandidateEvents([]*parser.Event{{Name: candidateIncludedEvent, Data: []byte{1}}})
+	assert.ErrorIs(t, err, ErrEventDecoding)
+}
+
+func TestCandidateEventKind_String(t *testing.T) {
+	assert.Equal(t, "CandidateBacked", CandidateBacked.String())
+	assert.Equal(t, "CandidateIncluded", CandidateIncluded.String())
+	assert.Equal(t, "CandidateTimedOut", CandidateTimedOut.String())
+	assert.Equal(t, "Unknown", CandidateEventKind(3).String())
+}
@@ -0,0 +1,111 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paras
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	parasPallet = "Paras"
+	headsEntry  = "Heads"
+)
+
+// Inclusion is the inclusion of a parachain block in a relay chain block.
+type Inclusion struct {
+	// RelayBlockHash is the hash of the relay chain block whose events hold the CandidateIncluded event.
+	RelayBlockHash types.Hash
+	Event          CandidateEvent
+}
+
+// Client tracks parachain candidates on a relay chain, via the Paras storage and the events of the ParaInclusion
+// pallet.
+type Client struct {
+	stateRPC       state.State
+	eventRetriever retriever.EventRetriever
+}
+
+// NewClient creates a Client that reads the storage of the relay chain via the state RPC and its events via the event
+// retriever.
+func NewClient(stateRPC state.State, eventRetriever retriever.EventRetriever) *Client {
+	return &Client{
+		stateRPC:       stateRPC,
+		eventRetriever: eventRetriever,
+	}
+}
+
+// GetParaHead returns the head of the latest included block of the parachain, as stored in Paras.Heads, or nil if the
+// parachain is not registered. See DecodeHeader and HeadHash.
+func (c *Client) GetParaHead(ctx context.Context, meta *types.Metadata, paraID uint32) (types.HeadData, error) {
+	encodedParaID, err := codec.Encode(types.NewU32(paraID))
+	if err != nil {
+		return nil, err
+	}
+
+	var head types.HeadData
+
+	ok, err := state.GetStorageEntryLatest(ctx, c.stateRPC, meta, parasPallet, headsEntry, &head, encodedParaID)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return head, nil
+}
+
+// GetCandidateEvents returns the candidate events of the relay chain block, only the ones of the given parachains if
+// para IDs are given.
+func (c *Client) GetCandidateEvents(blockHash types.Hash, paraIDs ...uint32) ([]CandidateEvent, error) {
+	events, err := c.eventRetriever.GetEvents(blockHash)
+	if err != nil {
+		return nil, ErrEventRetrieval.WithMsg("block %s", blockHash.Hex()).Wrap(err)
+	}
+
+	return DecodeCandidateEvents(events, paraIDs...)
+}
+
+// FindInclusion searches the relay chain blocks from the start to the end block for the inclusion of the parachain
+// block with the given hash. ErrInclusionNotFound is returned if it was not included in these blocks.
+func (c *Client) FindInclusion(
+	paraID uint32,
+	paraBlockHash types.Hash,
+	startBlock, endBlock types.Hash,
+) (*Inclusion, error) {
+	blockEvents, err := c.eventRetriever.GetEventsRange(startBlock, endBlock)
+	if err != nil {
+		return nil, ErrEventRetrieval.Wrap(err)
+	}
+
+	for _, block := range blockEvents {
+		candidates, err := DecodeCandidateEvents(block.Events, paraID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, candidate := range candidates {
+			if candidate.Kind == CandidateIncluded && candidate.ParaBlockHash() == paraBlockHash {
+				return &Inclusion{RelayBlockHash: block.BlockHash, Event: candidate}, nil
+			}
+		}
+	}
+
+	return nil, ErrInclusionNotFound.WithMsg("block %s of para %d", paraBlockHash.Hex(), paraID)
+}
@@ -0,0 +1,105 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paras
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testClient struct {
+	*Client
+
+	stateRPC       *statetest.State
+	eventRetriever *retriever.EventRetrieverMock
+}
+
+func newTestClient(t *testing.T) testClient {
+	eventRetriever := retriever.NewEventRetrieverMock(t)
+
+	c, stateRPC := statetest.NewClient(t, func(stateRPC state.State) *Client {
+		return NewClient(stateRPC, eventRetriever)
+	})
+
+	return testClient{
+		Client:         c,
+		stateRPC:       stateRPC,
+		eventRetriever: eventRetriever,
+	}
+}
+
+func TestClient_GetParaHead(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+	head := newTestCandidate(t, CandidateIncluded, 1000, 10).HeadData
+
+	c := newTestClient(t)
+	c.stateRPC.ExpectStorage(t, meta, parasPallet, headsEntry, head, statetest.Encode(t, types.NewU32(1000)))
+
+	res, err := c.GetParaHead(ctx, meta, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, head, res)
+
+	c.stateRPC.ExpectStorage(t, meta, parasPallet, headsEntry, nil, statetest.Encode(t, types.NewU32(2000)))
+
+	res, err = c.GetParaHead(ctx, meta, 2000)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	c.stateRPC.On("GetStorageLatestContext", mock.Anything, mock.Anything, mock.Anything).
+		Return(false, errors.New("boom")).
+		Once()
+
+	_, err = c.GetParaHead(ctx, meta, 1000)
+	assert.ErrorIs(t, err, ErrStorageRetrieval)
+}
+
+func TestClient_FindInclusion(t *testing.T) {
+	start, end := types.Hash{1}, types.Hash{3}
+	backed := newTestCandidate(t, CandidateBacked, 1000, 10)
+	included := newTestCandidate(t, CandidateIncluded, 1000, 10)
+	other := newTestCandidate(t, CandidateIncluded, 2000, 10)
+
+	c := newTestClient(t)
+	c.eventRetriever.On("GetEventsRange", start, end).Return([]*retriever.BlockEvents{
+		{BlockHash: types.Hash{1}, Events: []*parser.Event{newTestEvent(t, backed)}},
+		{BlockHash: types.Hash{2}},
+		{BlockHash: types.Hash{3}, Events: []*parser.Event{newTestEvent(t, other), newTestEvent(t, included)}},
+	}, nil)
+
+	inclusion, err := c.FindInclusion(1000, included.ParaBlockHash(), start, end)
+	require.NoError(t, err)
+	assert.Equal(t, &Inclusion{RelayBlockHash: types.Hash{3}, Event: included}, inclusion)
+
+	_, err = c.FindInclusion(1000, types.Hash{4}, start, end)
+	assert.ErrorIs(t, err, ErrInclusionNotFound)
+
+	c.eventRetriever.On("GetEventsRange", end, start).Return(nil, errors.New("boom"))
+
+	_, err = c.FindInclusion(1000, included.ParaBlockHash(), end, start)
+	assert.ErrorIs(t, err, ErrEventRetrieval)
+}
@@ -0,0 +1,59 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paras
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+// HeadHash returns the hash of the head data, which is the hash of the parachain block for Substrate based
+// parachains.
+func HeadHash(head types.HeadData) types.Hash {
+	return blake2b.Sum256(headBytes(head))
+}
+
+// DecodeHeader decodes the head data of a Substrate based parachain, which is the SCALE encoded header of its block.
+func DecodeHeader(head types.HeadData) (*types.Header, error) {
+	var header types.Header
+
+	if err := codec.Decode(headBytes(head), &header); err != nil {
+		return nil, ErrHeaderDecoding.Wrap(err)
+	}
+
+	return &header, nil
+}
+
+// BlockHash returns the hash of the block with the header, the blake2-256 hash of the SCALE encoded header.
+func BlockHash(header types.Header) (types.Hash, error) {
+	encoded, err := codec.Encode(header)
+	if err != nil {
+		return types.Hash{}, ErrHeaderEncoding.Wrap(err)
+	}
+
+	return blake2b.Sum256(encoded), nil
+}
+
+func headBytes(head types.HeadData) []byte {
+	b := make([]byte, len(head))
+	for i, u := range head {
+		b[i] = byte(u)
+	}
+
+	return b
+}
@@ -0,0 +1,41 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paras
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeHeader(t *testing.T) {
+	candidate := newTestCandidate(t, CandidateIncluded, 1000, 10)
+
+	header, err := DecodeHeader(candidate.HeadData)
+	require.NoError(t, err)
+	assert.Equal(t, newTestHeader(10), *header)
+
+	// The hash of the head data is the hash of the parachain block.
+	blockHash, err := BlockHash(*header)
+	require.NoError(t, err)
+	assert.Equal(t, HeadHash(candidate.HeadData), blockHash)
+
+	_, err = DecodeHeader(types.HeadData{1})
+	assert.ErrorIs(t, err, ErrHeaderDecoding)
+}
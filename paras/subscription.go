@@ -0,0 +1,146 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paras
+
+import (
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// HeadsSubscription is a subscription of relay chain headers, e.g. a chain.FinalizedHeadsSubscription.
+type HeadsSubscription interface {
+	Chan() <-chan types.Header
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// BlockCandidateEvents are the candidate events of a relay chain block.
+type BlockCandidateEvents struct {
+	BlockHash   types.Hash
+	BlockNumber uint32
+	Events      []CandidateEvent
+}
+
+// CandidateEventsSubscription is a subscription established through Client.SubscribeCandidateEvents.
+type CandidateEventsSubscription struct {
+	heads    HeadsSubscription
+	channel  chan BlockCandidateEvents
+	err      chan error
+	quit     chan struct{}
+	quitOnce sync.Once // ensures quit is closed once
+}
+
+// Chan returns the subscription channel.
+//
+// The channel is closed when the subscription ends.
+func (s *CandidateEventsSubscription) Chan() <-chan BlockCandidateEvents {
+	return s.channel
+}
+
+// Err returns the subscription error channel.
+//
+// The error channel receives a value when the subscription has ended due to an error, including errors that occurred
+// while retrieving or decoding the events of a block. The error channel is closed when the subscription ends.
+func (s *CandidateEventsSubscription) Err() <-chan error {
+	return s.err
+}
+
+// Unsubscribe ends the subscription and unsubscribes the headers. It can safely be called more than once.
+func (s *CandidateEventsSubscription) Unsubscribe() {
+	s.quitOnce.Do(func() {
+		close(s.quit)
+	})
+	s.heads.Unsubscribe()
+}
+
+// SubscribeCandidateEvents retrieves the candidate events of each relay chain block whose header is received by the
+// heads subscription. Only blocks with candidate events of the given parachains, or of any parachain if no para IDs
+// are given, are sent to the channel of the subscription.
+func (c *Client) SubscribeCandidateEvents(
+	heads HeadsSubscription,
+	paraIDs ...uint32,
+) *CandidateEventsSubscription {
+	subscription := &CandidateEventsSubscription{
+		heads:   heads,
+		channel: make(chan BlockCandidateEvents),
+		err:     make(chan error, 1),
+		quit:    make(chan struct{}),
+	}
+
+	go subscription.run(c, paraIDs)
+
+	return subscription
+}
+
+func (s *CandidateEventsSubscription) run(c *Client, paraIDs []uint32) {
+	defer close(s.err)
+	defer close(s.channel)
+
+	for {
+		select {
+		case <-s.quit:
+			return
+		case err, ok := <-s.heads.Err():
+			if ok && err != nil {
+				s.err <- err
+			}
+
+			return
+		case header, ok := <-s.heads.Chan():
+			if !ok {
+				return
+			}
+
+			block, err := c.getBlockCandidateEvents(header, paraIDs)
+			if err != nil {
+				s.err <- err
+				s.heads.Unsubscribe()
+
+				return
+			}
+
+			if len(block.Events) == 0 {
+				continue
+			}
+
+			select {
+			case s.channel <- block:
+			case <-s.quit:
+				return
+			}
+		}
+	}
+}
+
+func (c *Client) getBlockCandidateEvents(header types.Header, paraIDs []uint32) (BlockCandidateEvents, error) {
+	blockHash, err := BlockHash(header)
+	if err != nil {
+		return BlockCandidateEvents{}, err
+	}
+
+	events, err := c.GetCandidateEvents(blockHash, paraIDs...)
+	if err != nil {
+		return BlockCandidateEvents{}, err
+	}
+
+	return BlockCandidateEvents{
+		BlockHash:   blockHash,
+		BlockNumber: uint32(header.Number),
+		Events:      events,
+	}, nil
+}
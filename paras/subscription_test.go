@@ -0,0 +1,101 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paras
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testHeadsSubscription struct {
+	channel      chan types.Header
+	err          chan error
+	unsubscribed bool
+}
+
+func newTestHeadsSubscription() *testHeadsSubscription {
+	return &testHeadsSubscription{
+		channel: make(chan types.Header),
+		err:     make(chan error, 1),
+	}
+}
+
+func (s *testHeadsSubscription) Chan() <-chan types.Header {
+	return s.channel
+}
+
+func (s *testHeadsSubscription) Err() <-chan error {
+	return s.err
+}
+
+func (s *testHeadsSubscription) Unsubscribe() {
+	s.unsubscribed = true
+}
+
+func TestClient_SubscribeCandidateEvents(t *testing.T) {
+	relayHeaders := []types.Header{newTestHeader(100), newTestHeader(101), newTestHeader(102)}
+
+	var relayHashes []types.Hash
+
+	for _, header := range relayHeaders {
+		hash, err := BlockHash(header)
+		require.NoError(t, err)
+
+		relayHashes = append(relayHashes, hash)
+	}
+
+	included := newTestCandidate(t, CandidateIncluded, 1000, 10)
+
+	c := newTestClient(t)
+	c.eventRetriever.On("GetEvents", relayHashes[0]).
+		Return([]*parser.Event{newTestEvent(t, newTestCandidate(t, CandidateIncluded, 2000, 10))}, nil)
+	c.eventRetriever.On("GetEvents", relayHashes[1]).
+		Return([]*parser.Event{newTestEvent(t, included)}, nil)
+	c.eventRetriever.On("GetEvents", relayHashes[2]).Return(nil, errors.New("boom"))
+
+	heads := newTestHeadsSubscription()
+	sub := c.SubscribeCandidateEvents(heads, 1000)
+
+	go func() {
+		for _, header := range relayHeaders {
+			heads.channel <- header
+		}
+	}()
+
+	// The first block has no events of the parachain.
+	block := <-sub.Chan()
+	assert.Equal(t, BlockCandidateEvents{
+		BlockHash:   relayHashes[1],
+		BlockNumber: 101,
+		Events:      []CandidateEvent{included},
+	}, block)
+
+	err := <-sub.Err()
+	assert.ErrorIs(t, err, ErrEventRetrieval)
+
+	_, ok := <-sub.Chan()
+	assert.False(t, ok)
+	assert.True(t, heads.unsubscribed)
+
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+}
@@ -0,0 +1,250 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package promgsrpc exposes the library-level metrics of GSRPC to Prometheus. It is a separate module, so that the
+// main module does not depend on the Prometheus client.
+//
+// The Collector is registered with a prometheus.Registerer and passed as metrics.Recorder to the components, e.g. via
+// client.Middleware.RecordMetrics, client.ReconnectOptions.Recorder, retriever.WithMetrics and
+// submit.SubmitterOptions.Recorder.
+package promgsrpc
+
+import (
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// durationBuckets are the upper bounds in seconds of the duration histogram buckets.
+	durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+	// sizeBuckets are the upper bounds in bytes of the payload size histogram buckets.
+	sizeBuckets = []float64{64, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304}
+)
+
+// Collector is a metrics.Recorder that exposes the recorded metrics as prometheus.Collector.
+//
+// The following metrics are collected, prefixed with the namespace:
+//   - rpc_calls_total{method,code}: counter of the calls, batch calls and subscription requests by method and
+//     outcome, see client.ErrorCode
+//   - rpc_call_duration_seconds{method}: histogram of the call latency
+//   - rpc_request_size_bytes{method}: histogram of the size of the JSON encoded params
+//   - rpc_response_size_bytes{method}: histogram of the size of the JSON encoded results of successful calls
+//   - subscriptions_active{method}: gauge of the active subscriptions by subscribe method
+//   - subscription_notifications_total{method}: counter of the received notifications by subscribe method
+//   - subscription_notification_size_bytes{method}: histogram of the size of the JSON encoded notifications
+//   - subscription_notifications_dropped_total{method}: counter of the notifications that were dropped because the
+//     consumer did not keep up, by subscribe method
+//   - reconnects_total{endpoint}: counter of the reconnects and failovers by the endpoint that was connected to
+//   - events_decoded_total: counter of the decoded events, its rate is the decoding throughput
+//   - event_decoding_duration_seconds: histogram of the duration of the decoding of the events of a block
+//   - registry_lookups_total{component,result}: counter of the registry lookups by component and result, i.e. hit or
+//     miss
+//   - extrinsic_outcomes_total{outcome}: counter of the terminal statuses of watched extrinsics, see metrics.Outcome
+type Collector struct {
+	calls                *prometheus.CounterVec
+	callDuration         *prometheus.HistogramVec
+	requestSize          *prometheus.HistogramVec
+	responseSize         *prometheus.HistogramVec
+	subscriptions        *prometheus.GaugeVec
+	notifications        *prometheus.CounterVec
+	notificationSize     *prometheus.HistogramVec
+	droppedNotifications *prometheus.CounterVec
+	reconnects           *prometheus.CounterVec
+	decodedEvents        prometheus.Counter
+	eventDecodingTime    prometheus.Histogram
+	registryLookups      *prometheus.CounterVec
+	extrinsicOutcomes    *prometheus.CounterVec
+}
+
+var (
+	_ metrics.Recorder     = (*Collector)(nil)
+	_ prometheus.Collector = (*Collector)(nil)
+)
+
+// NewCollector creates a new Collector, the namespace is used as prefix of all metric names if not empty.
+func NewCollector(namespace string) *Collector {
+	return &Collector{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_calls_total",
+			Help:      "RPC calls by method and error code.",
+		}, []string{"method", "code"}),
+		callDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rpc_call_duration_seconds",
+			Help:      "Duration of RPC calls in seconds.",
+			Buckets:   durationBuckets,
+		}, []string{"method"}),
+		requestSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rpc_request_size_bytes",
+			Help:      "Size of the JSON encoded RPC params.",
+			Buckets:   sizeBuckets,
+		}, []string{"method"}),
+		responseSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rpc_response_size_bytes",
+			Help:      "Size of the JSON encoded RPC results.",
+			Buckets:   sizeBuckets,
+		}, []string{"method"}),
+		subscriptions: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "subscriptions_active",
+			Help:      "Active subscriptions by subscribe method.",
+		}, []string{"method"}),
+		notifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "subscription_notifications_total",
+			Help:      "Received subscription notifications by subscribe method.",
+		}, []string{"method"}),
+		notificationSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "subscription_notification_size_bytes",
+			Help:      "Size of the JSON encoded subscription notifications.",
+			Buckets:   sizeBuckets,
+		}, []string{"method"}),
+		droppedNotifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "subscription_notifications_dropped_total",
+			Help:      "Dropped subscription notifications by subscribe method.",
+		}, []string{"method"}),
+		reconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reconnects_total",
+			Help:      "Reconnects and failovers by the endpoint that was connected to.",
+		}, []string{"endpoint"}),
+		decodedEvents: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "events_decoded_total",
+			Help:      "Decoded events.",
+		}),
+		eventDecodingTime: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "event_decoding_duration_seconds",
+			Help:      "Duration of the decoding of the events of a block.",
+			Buckets:   durationBuckets,
+		}),
+		registryLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "registry_lookups_total",
+			Help:      "Registry lookups by component and result.",
+		}, []string{"component", "result"}),
+		extrinsicOutcomes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "extrinsic_outcomes_total",
+			Help:      "Terminal statuses of watched extrinsics.",
+		}, []string{"outcome"}),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		c.calls,
+		c.callDuration,
+		c.requestSize,
+		c.responseSize,
+		c.subscriptions,
+		c.notifications,
+		c.notificationSize,
+		c.droppedNotifications,
+		c.reconnects,
+		c.decodedEvents,
+		c.eventDecodingTime,
+		c.registryLookups,
+		c.extrinsicOutcomes,
+	}
+}
+
+// CallCompleted implements metrics.Recorder
+func (c *Collector) CallCompleted(call metrics.Call) {
+	c.calls.WithLabelValues(call.Method, call.Code).Inc()
+	c.callDuration.WithLabelValues(call.Method).Observe(call.Duration.Seconds())
+
+	if call.RequestSize >= 0 {
+		c.requestSize.WithLabelValues(call.Method).Observe(float64(call.RequestSize))
+	}
+
+	if call.ResponseSize >= 0 {
+		c.responseSize.WithLabelValues(call.Method).Observe(float64(call.ResponseSize))
+	}
+}
+
+// SubscriptionStarted implements metrics.Recorder
+func (c *Collector) SubscriptionStarted(method string) {
+	c.subscriptions.WithLabelValues(method).Inc()
+}
+
+// SubscriptionEnded implements metrics.Recorder
+func (c *Collector) SubscriptionEnded(method string) {
+	c.subscriptions.WithLabelValues(method).Dec()
+}
+
+// NotificationReceived implements metrics.Recorder
+func (c *Collector) NotificationReceived(method string, size int) {
+	c.notifications.WithLabelValues(method).Inc()
+
+	if size >= 0 {
+		c.notificationSize.WithLabelValues(method).Observe(float64(size))
+	}
+}
+
+// NotificationDropped implements metrics.Recorder
+func (c *Collector) NotificationDropped(method string) {
+	c.droppedNotifications.WithLabelValues(method).Inc()
+}
+
+// Reconnected implements metrics.Recorder
+func (c *Collector) Reconnected(endpoint string) {
+	c.reconnects.WithLabelValues(endpoint).Inc()
+}
+
+// EventsDecoded implements metrics.Recorder
+func (c *Collector) EventsDecoded(count int, duration time.Duration) {
+	c.decodedEvents.Add(float64(count))
+	c.eventDecodingTime.Observe(duration.Seconds())
+}
+
+// RegistryLookup implements metrics.Recorder
+func (c *Collector) RegistryLookup(component string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	c.registryLookups.WithLabelValues(component, result).Inc()
+}
+
+// ExtrinsicOutcome implements metrics.Recorder
+func (c *Collector) ExtrinsicOutcome(outcome metrics.Outcome) {
+	c.extrinsicOutcomes.WithLabelValues(string(outcome)).Inc()
+}
@@ -0,0 +1,178 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promgsrpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/metrics"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector("gsrpc")
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(c))
+
+	c.CallCompleted(metrics.Call{
+		Method:       "chain_getBlockHash",
+		Code:         "ok",
+		Duration:     20 * time.Millisecond,
+		RequestSize:  3,
+		ResponseSize: 68,
+	})
+	c.CallCompleted(metrics.Call{
+		Method:       "chain_getBlockHash",
+		Code:         "-32602",
+		Duration:     10 * time.Millisecond,
+		RequestSize:  -1,
+		ResponseSize: -1,
+	})
+	c.SubscriptionStarted("chain_subscribeNewHead")
+	c.SubscriptionStarted("chain_subscribeNewHead")
+	c.SubscriptionEnded("chain_subscribeNewHead")
+	c.NotificationReceived("chain_subscribeNewHead", 300)
+	c.NotificationDropped("chain_subscribeNewHead")
+	c.Reconnected("ws://node:9944")
+	c.EventsDecoded(10, 20*time.Millisecond)
+	c.EventsDecoded(5, 10*time.Millisecond)
+	c.RegistryLookup("event_retriever", true)
+	c.RegistryLookup("event_retriever", true)
+	c.RegistryLookup("event_retriever", false)
+	c.ExtrinsicOutcome(metrics.OutcomeFinalized)
+
+	expected := `
+# HELP gsrpc_events_decoded_total Decoded events.
+# TYPE gsrpc_events_decoded_total counter
+gsrpc_events_decoded_total 15
+# HELP gsrpc_extrinsic_outcomes_total Terminal statuses of watched extrinsics.
+# TYPE gsrpc_extrinsic_outcomes_total counter
+gsrpc_extrinsic_outcomes_total{outcome="finalized"} 1
+# HELP gsrpc_rpc_calls_total RPC calls by method and error code.
+# TYPE gsrpc_rpc_calls_total counter
+gsrpc_rpc_calls_total{code="-32602",method="chain_getBlockHash"} 1
+gsrpc_rpc_calls_total{code="ok",method="chain_getBlockHash"} 1
+# HELP gsrpc_rpc_response_size_bytes Size of the JSON encoded RPC results.
+# TYPE gsrpc_rpc_response_size_bytes histogram
+gsrpc_rpc_response_size_bytes_bucket{method="chain_getBlockHash",le="64"} 0
+gsrpc_rpc_response_size_bytes_bucket{method="chain_getBlockHash",le="256"} 1
+gsrpc_rpc_response_size_bytes_bucket{method="chain_getBlockHash",le="1024"} 1
+gsrpc_rpc_response_size_bytes_bucket{method="chain_getBlockHash",le="4096"} 1
+gsrpc_rpc_response_size_bytes_bucket{method="chain_getBlockHash",le="16384"} 1
+gsrpc_rpc_response_size_bytes_bucket{method="chain_getBlockHash",le="65536"} 1
+gsrpc_rpc_response_size_bytes_bucket{method="chain_getBlockHash",le="262144"} 1
+gsrpc_rpc_response_size_bytes_bucket{method="chain_getBlockHash",le="1.048576e+06"} 1
+gsrpc_rpc_response_size_bytes_bucket{method="chain_getBlockHash",le="4.194304e+06"} 1
+gsrpc_rpc_response_size_bytes_bucket{method="chain_getBlockHash",le="+Inf"} 1
+gsrpc_rpc_response_size_bytes_sum{method="chain_getBlockHash"} 68
+gsrpc_rpc_response_size_bytes_count{method="chain_getBlockHash"} 1
+# HELP gsrpc_reconnects_total Reconnects and failovers by the endpoint that was connected to.
+# TYPE gsrpc_reconnects_total counter
+gsrpc_reconnects_total{endpoint="ws://node:9944"} 1
+# HELP gsrpc_registry_lookups_total Registry lookups by component and result.
+# TYPE gsrpc_registry_lookups_total counter
+gsrpc_registry_lookups_total{component="event_retriever",result="hit"} 2
+gsrpc_registry_lookups_total{component="event_retriever",result="miss"} 1
+# HELP gsrpc_subscription_notifications_dropped_total Dropped subscription notifications by subscribe method.
+# TYPE gsrpc_subscription_notifications_dropped_total counter
+gsrpc_subscription_notifications_dropped_total{method="chain_subscribeNewHead"} 1
+# HELP gsrpc_subscription_notifications_total Received subscription notifications by subscribe method.
+# TYPE gsrpc_subscription_notifications_total counter
+gsrpc_subscription_notifications_total{method="chain_subscribeNewHead"} 1
+# HELP gsrpc_subscriptions_active Active subscriptions by subscribe method.
+# TYPE gsrpc_subscriptions_active gauge
+gsrpc_subscriptions_active{method="chain_subscribeNewHead"} 1
+`
+
+	require.NoError(t, testutil.GatherAndCompare(
+		reg,
+		strings.NewReader(expected),
+		"gsrpc_events_decoded_total",
+		"gsrpc_extrinsic_outcomes_total",
+		"gsrpc_reconnects_total",
+		"gsrpc_registry_lookups_total",
+		"gsrpc_rpc_calls_total",
+		"gsrpc_rpc_response_size_bytes",
+		"gsrpc_subscription_notifications_dropped_total",
+		"gsrpc_subscription_notifications_total",
+		"gsrpc_subscriptions_active",
+	))
+
+	for name, expectedCount := range map[string]int{
+		"gsrpc_event_decoding_duration_seconds":      1,
+		"gsrpc_rpc_call_duration_seconds":            1,
+		"gsrpc_rpc_request_size_bytes":               1,
+		"gsrpc_subscription_notification_size_bytes": 1,
+	} {
+		count, err := testutil.GatherAndCount(reg, name)
+		require.NoError(t, err)
+		assert.Equal(t, expectedCount, count, name)
+	}
+}
+
+func TestCollector_Subscription(t *testing.T) {
+	c := NewCollector("")
+
+	headers := make([]interface{}, 0, 10)
+
+	for i := 0; i < 10; i++ {
+		headers = append(headers, map[string]interface{}{
+			"parentHash":     types.Hash{}.Hex(),
+			"number":         fmt.Sprintf("0x%x", i),
+			"stateRoot":      types.Hash{}.Hex(),
+			"extrinsicsRoot": types.Hash{}.Hex(),
+			"digest":         map[string]interface{}{"logs": []string{}},
+		})
+	}
+
+	cl := client.NewMiddleware().
+		RecordMetrics(c).
+		Wrap(rpcmocksrv.NewMockClient().Notify("chain_subscribeNewHead", headers))
+
+	sub, err := chain.NewChain(cl).SubscribeNewHeadsWithOptions(context.Background(), client.SubscriptionOptions{
+		BufferSize: 2,
+		Policy:     client.OverflowDropOldest,
+		Recorder:   c,
+	})
+	require.NoError(t, err)
+
+	active := c.subscriptions.WithLabelValues("chain_subscribeNewHead")
+	dropped := c.droppedNotifications.WithLabelValues("chain_subscribeNewHead")
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(active))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.calls.WithLabelValues("chain_subscribeNewHead", "ok")))
+
+	// The consumer stalls until all but the buffered headers are dropped.
+	require.Eventually(t, func() bool { return testutil.ToFloat64(dropped) == 8 }, 5*time.Second, time.Millisecond)
+	assert.Equal(t, float64(10), testutil.ToFloat64(c.notifications.WithLabelValues("chain_subscribeNewHead")))
+
+	sub.Unsubscribe()
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(active))
+}
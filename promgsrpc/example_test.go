@@ -0,0 +1,56 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promgsrpc_test
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/promgsrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/submit"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Example registers the collector with a registry that is served on /metrics, and passes it to the components.
+func Example() {
+	reg := prometheus.NewRegistry()
+
+	collector := promgsrpc.NewCollector("gsrpc")
+	reg.MustRegister(collector)
+
+	http.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+
+	cl, err := client.ConnectWithReconnect("wss://rpc.polkadot.io", client.ReconnectOptions{Recorder: collector})
+	if err != nil {
+		panic(err)
+	}
+
+	defer cl.Close()
+
+	cl = client.NewMiddleware().RecordMetrics(collector).Wrap(cl)
+
+	api, err := rpc.NewRPC(cl)
+	if err != nil {
+		panic(err)
+	}
+
+	_ = submit.NewSubmitterWithOptions(api.State, api.System, api.Chain, api.Author, registry.NewFactory(),
+		submit.SubmitterOptions{Recorder: collector})
+}
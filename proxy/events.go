@@ -0,0 +1,100 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	proxyExecutedEvent = "Proxy.ProxyExecuted"
+	pureCreatedEvent   = "Proxy.PureCreated"
+	announcedEvent     = "Proxy.Announced"
+)
+
+// ProxyExecutedEvent holds the fields of the Proxy.ProxyExecuted event, emitted when a proxy dispatched a call.
+type ProxyExecutedEvent struct {
+	// Result is the outcome of the dispatch of the call.
+	Result types.DispatchResult
+	// ModuleErrorName is the name of the pallet error, e.g. Balances.InsufficientBalance, if the dispatch failed with
+	// a module error that is known to the error registry.
+	ModuleErrorName string
+}
+
+// PureCreatedEvent holds the fields of the Proxy.PureCreated event, emitted when a pure proxy was created.
+type PureCreatedEvent struct {
+	Pure                types.AccountID
+	Who                 types.AccountID
+	ProxyType           ProxyType
+	DisambiguationIndex types.U16
+}
+
+// AnnouncedEvent holds the fields of the Proxy.Announced event, emitted when a proxy announced a call.
+type AnnouncedEvent struct {
+	Real     types.AccountID
+	Proxy    types.AccountID
+	CallHash types.Hash
+}
+
+// Events are the events of the proxy pallet emitted by an extrinsic.
+type Events struct {
+	Executed  []ProxyExecutedEvent
+	Created   []PureCreatedEvent
+	Announced []AnnouncedEvent
+}
+
+// DecodeEvents decodes the events of the proxy pallet, e.g. the events of submit.ExtrinsicResult. The pallet errors of
+// failed dispatches are resolved via the error registry, which may be nil. Other events are ignored.
+func DecodeEvents(events []*parser.Event, errorRegistry registry.ErrorRegistry) (*Events, error) {
+	var res Events
+
+	for _, event := range events {
+		var err error
+
+		switch event.Name {
+		case proxyExecutedEvent:
+			var result types.DispatchResult
+			if err = codec.Decode(event.Data, &result); err == nil {
+				e := ProxyExecutedEvent{Result: result}
+				if !result.Ok {
+					e.ModuleErrorName = errorRegistry.ModuleErrorName(result.Error)
+				}
+
+				res.Executed = append(res.Executed, e)
+			}
+		case pureCreatedEvent:
+			var e PureCreatedEvent
+			if err = codec.Decode(event.Data, &e); err == nil {
+				res.Created = append(res.Created, e)
+			}
+		case announcedEvent:
+			var e AnnouncedEvent
+			if err = codec.Decode(event.Data, &e); err == nil {
+				res.Announced = append(res.Announced, e)
+			}
+		}
+
+		if err != nil {
+			return nil, ErrEventDecoding.WithMsg(event.Name).Wrap(err)
+		}
+	}
+
+	return &res, nil
+}
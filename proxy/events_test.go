@@ -0,0 +1,74 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	accountID := getTestAccountID(t)
+
+	pure, err := PureAccountID(accountID, ProxyTypeAny, 0, 1000, 2)
+	require.NoError(t, err)
+
+	moduleErr := types.DispatchError{
+		IsModule:    true,
+		ModuleError: types.ModuleError{Index: 5, Error: [4]types.U8{2}},
+	}
+
+	errorRegistry := registry.ErrorRegistry{
+		registry.ErrorID{ModuleIndex: 5, ErrorIndex: [4]types.U8{2}}: {Name: "Balances.InsufficientBalance"},
+	}
+
+	created := PureCreatedEvent{Pure: pure, Who: accountID, ProxyType: ProxyTypeAny}
+	announced := AnnouncedEvent{Real: pure, Proxy: accountID, CallHash: types.NewHash([]byte{1, 2, 3})}
+
+	res, err := DecodeEvents([]*parser.Event{
+		{Name: "System.ExtrinsicSuccess", Data: []byte{1}},
+		{Name: pureCreatedEvent, Data: statetest.Encode(t, created)},
+		{Name: announcedEvent, Data: statetest.Encode(t, announced)},
+		{Name: proxyExecutedEvent, Data: statetest.Encode(t, types.DispatchResult{Ok: true})},
+		{Name: proxyExecutedEvent, Data: statetest.Encode(t, types.DispatchResult{Error: moduleErr})},
+	}, errorRegistry)
+	require.NoError(t, err)
+	assert.Equal(t, &Events{
+		Executed: []ProxyExecutedEvent{
+			{Result: types.DispatchResult{Ok: true}},
+			{Result: types.DispatchResult{Error: moduleErr}, ModuleErrorName: "Balances.InsufficientBalance"},
+		},
+		Created:   []PureCreatedEvent{created},
+		Announced: []AnnouncedEvent{announced},
+	}, res)
+
+	// Without an error registry, the pallet errors are not resolved.
+	res, err = DecodeEvents([]*parser.Event{
+		{Name: proxyExecutedEvent, Data: statetest.Encode(t, types.DispatchResult{Error: moduleErr})},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []ProxyExecutedEvent{{Result: types.DispatchResult{Error: moduleErr}}}, res.Executed)
+
+	_, err = DecodeEvents([]*parser.Event{{Name: pureCreatedEvent, Data: []byte{1}}}, errorRegistry)
+	assert.ErrorIs(t, err, ErrEventDecoding)
+}
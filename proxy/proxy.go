@@ -0,0 +1,188 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	proxyPallet = "Proxy"
+
+	proxyCall              = "Proxy.proxy"
+	proxyAnnouncedCall     = "Proxy.proxy_announced"
+	announceCall           = "Proxy.announce"
+	removeAnnouncementCall = "Proxy.remove_announcement"
+	rejectAnnouncementCall = "Proxy.reject_announcement"
+	createPureCall         = "Proxy.create_pure"
+)
+
+// pureAccountPrefix is the prefix of the preimage of pure proxy accounts, see pallet_proxy::pure_account.
+var pureAccountPrefix = []byte("modlpy/proxy____")
+
+// ProxyType is the index of a variant of the ProxyType enum of the runtime, which defines the calls a proxy may
+// dispatch. The variants differ between runtimes, see ProxyTypeByName.
+type ProxyType uint8
+
+// ProxyTypeAny allows the proxy to dispatch any call, it is the first variant in the runtimes of Polkadot, Kusama and
+// most parachains.
+const ProxyTypeAny ProxyType = 0
+
+// ProxyTypeByName returns the variant of the ProxyType enum of the runtime with the given name, e.g. NonTransfer.
+// ErrProxyPalletNotFound is returned if the runtime does not include the proxy pallet.
+func ProxyTypeByName(meta *types.Metadata, name string) (ProxyType, error) {
+	var pallet *types.PalletMetadataV14
+
+	for i := range meta.AsMetadataV14.Pallets {
+		if meta.AsMetadataV14.Pallets[i].Name == proxyPallet {
+			pallet = &meta.AsMetadataV14.Pallets[i]
+			break
+		}
+	}
+
+	if pallet == nil || !pallet.HasCalls {
+		return 0, ErrProxyPalletNotFound
+	}
+
+	calls, ok := meta.AsMetadataV14.EfficientLookup[pallet.Calls.Type.Int64()]
+	if !ok {
+		return 0, ErrProxyTypeNotFound.WithMsg("calls type not found")
+	}
+
+	// The proxy type is the first field of Proxy.create_pure.
+	for _, call := range calls.Def.Variant.Variants {
+		if call.Name != "create_pure" || len(call.Fields) == 0 {
+			continue
+		}
+
+		proxyType, ok := meta.AsMetadataV14.EfficientLookup[call.Fields[0].Type.Int64()]
+		if !ok || !proxyType.Def.IsVariant {
+			return 0, ErrProxyTypeNotFound.WithMsg("proxy type is not an enum")
+		}
+
+		for _, variant := range proxyType.Def.Variant.Variants {
+			if string(variant.Name) == name {
+				return ProxyType(variant.Index), nil
+			}
+		}
+
+		return 0, ErrProxyTypeNotFound.WithMsg(name)
+	}
+
+	return 0, ErrProxyTypeNotFound.WithMsg("%s not found", createPureCall)
+}
+
+// PureAccountID returns the account of the pure proxy that the spawner creates via Proxy.create_pure with the given
+// proxy type and index, in the extrinsic with the given index in the block with the given height. It matches
+// pallet_proxy::pure_account, so pure proxies can be predicted before they are created.
+func PureAccountID(
+	spawner types.AccountID,
+	proxyType ProxyType,
+	index uint16,
+	height uint32,
+	extIndex uint32,
+) (types.AccountID, error) {
+	preimage, err := codec.Encode(struct {
+		Spawner   types.AccountID
+		Height    types.U32
+		ExtIndex  types.U32
+		ProxyType ProxyType
+		Index     types.U16
+	}{spawner, types.U32(height), types.U32(extIndex), proxyType, types.U16(index)})
+	if err != nil {
+		return types.AccountID{}, ErrPureAccountEncoding.Wrap(err)
+	}
+
+	return types.AccountID(blake2b.Sum256(append(append([]byte{}, pureAccountPrefix...), preimage...))), nil
+}
+
+// CallHash returns the blake2-256 hash of the encoded call, which identifies the call of an announcement.
+func CallHash(call types.Call) (types.Hash, error) {
+	encoded, err := codec.Encode(call)
+	if err != nil {
+		return types.Hash{}, ErrCallEncoding.Wrap(err)
+	}
+
+	return blake2b.Sum256(encoded), nil
+}
+
+// optionalProxyType returns the Option<ProxyType> of the proxy type, nil means that any proxy type of the proxy is
+// accepted.
+func optionalProxyType(proxyType *ProxyType) types.Option[ProxyType] {
+	if proxyType == nil {
+		return types.NewEmptyOption[ProxyType]()
+	}
+
+	return types.NewOption(*proxyType)
+}
+
+// Proxy creates the Proxy.proxy call, which dispatches the call on behalf of the real account. If forceProxyType is
+// not nil, the proxy has to be registered with that proxy type.
+func Proxy(
+	meta *types.Metadata,
+	real types.MultiAddress,
+	forceProxyType *ProxyType,
+	call types.Call,
+) (types.Call, error) {
+	return newCall(meta, proxyCall, real, optionalProxyType(forceProxyType), call)
+}
+
+// ProxyAnnounced creates the Proxy.proxy_announced call, which dispatches the call the delegate announced on behalf
+// of the real account once the delay of the proxy has passed. It can be submitted by any account.
+func ProxyAnnounced(
+	meta *types.Metadata,
+	delegate types.MultiAddress,
+	real types.MultiAddress,
+	forceProxyType *ProxyType,
+	call types.Call,
+) (types.Call, error) {
+	return newCall(meta, proxyAnnouncedCall, delegate, real, optionalProxyType(forceProxyType), call)
+}
+
+// Announce creates the Proxy.announce call, which announces the call with the given hash, see CallHash, on behalf of
+// the real account. Proxies with a delay have to announce calls before dispatching them.
+func Announce(meta *types.Metadata, real types.MultiAddress, callHash types.Hash) (types.Call, error) {
+	return newCall(meta, announceCall, real, callHash)
+}
+
+// RemoveAnnouncement creates the Proxy.remove_announcement call, which withdraws an announcement of the proxy.
+func RemoveAnnouncement(meta *types.Metadata, real types.MultiAddress, callHash types.Hash) (types.Call, error) {
+	return newCall(meta, removeAnnouncementCall, real, callHash)
+}
+
+// RejectAnnouncement creates the Proxy.reject_announcement call, which the real account uses to reject an
+// announcement of the delegate.
+func RejectAnnouncement(meta *types.Metadata, delegate types.MultiAddress, callHash types.Hash) (types.Call, error) {
+	return newCall(meta, rejectAnnouncementCall, delegate, callHash)
+}
+
+// CreatePure creates the Proxy.create_pure call, which spawns a pure proxy, see PureAccountID. The index
+// disambiguates pure proxies created in the same extrinsic, e.g. in a batch.
+func CreatePure(meta *types.Metadata, proxyType ProxyType, delay uint32, index uint16) (types.Call, error) {
+	return newCall(meta, createPureCall, proxyType, types.U32(delay), types.U16(index))
+}
+
+func newCall(meta *types.Metadata, name string, args ...interface{}) (types.Call, error) {
+	call, err := types.NewCall(meta, name, args...)
+	if err != nil {
+		return types.Call{}, ErrCallCreation.WithMsg(name).Wrap(err)
+	}
+
+	return call, nil
+}
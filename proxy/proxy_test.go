@@ -0,0 +1,205 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func getTestAccountID(t *testing.T) types.AccountID {
+	accountID, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	return *accountID
+}
+
+func concat(parts ...[]byte) types.Args {
+	var b []byte
+
+	for _, part := range parts {
+		b = append(b, part...)
+	}
+
+	return b
+}
+
+func TestProxyTypeByName(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+
+	proxyType, err := ProxyTypeByName(meta, "Any")
+	require.NoError(t, err)
+	assert.Equal(t, ProxyTypeAny, proxyType)
+
+	// Index 4 is not used in the Polkadot runtime.
+	proxyType, err = ProxyTypeByName(meta, "IdentityJudgement")
+	require.NoError(t, err)
+	assert.Equal(t, ProxyType(5), proxyType)
+
+	_, err = ProxyTypeByName(meta, "Unknown")
+	assert.ErrorIs(t, err, ErrProxyTypeNotFound)
+
+	// The runtime does not include the proxy pallet.
+	withoutProxy := *meta
+	withoutProxy.AsMetadataV14.Pallets = nil
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.Name != proxyPallet {
+			withoutProxy.AsMetadataV14.Pallets = append(withoutProxy.AsMetadataV14.Pallets, pallet)
+		}
+	}
+
+	_, err = ProxyTypeByName(&withoutProxy, "Any")
+	assert.ErrorIs(t, err, ErrProxyPalletNotFound)
+}
+
+func TestPureAccountID(t *testing.T) {
+	spawner := getTestAccountID(t)
+
+	accountID, err := PureAccountID(spawner, ProxyType(1), 3, 1000, 2)
+	require.NoError(t, err)
+
+	// modlpy/proxy____ ++ spawner ++ height (u32) ++ extrinsic index (u32) ++ proxy type ++ index (u16)
+	preimage := append([]byte("modlpy/proxy____"), spawner[:]...)
+	preimage = binary.LittleEndian.AppendUint32(preimage, 1000)
+	preimage = binary.LittleEndian.AppendUint32(preimage, 2)
+	preimage = append(preimage, 1)
+	preimage = binary.LittleEndian.AppendUint16(preimage, 3)
+
+	assert.Equal(t, types.AccountID(blake2b.Sum256(preimage)), accountID)
+
+	other, err := PureAccountID(spawner, ProxyType(1), 4, 1000, 2)
+	require.NoError(t, err)
+	assert.NotEqual(t, accountID, other)
+}
+
+func TestCalls(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+
+	real, err := types.NewMultiAddressFromAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	remark, err := types.NewCall(meta, "System.remark", types.NewBytes([]byte("hello")))
+	require.NoError(t, err)
+
+	callHash, err := CallHash(remark)
+	require.NoError(t, err)
+
+	expectedCallHash := blake2b.Sum256(statetest.Encode(t, remark))
+	assert.Equal(t, types.Hash(expectedCallHash), callHash)
+
+	nonTransfer := ProxyType(1)
+
+	encodedReal := statetest.Encode(t, real)
+	encodedRemark := statetest.Encode(t, remark)
+
+	// Option<ProxyType> is encoded as the option byte followed by the variant index.
+	noProxyType := []byte{0}
+	forcedProxyType := []byte{1, 1}
+
+	tests := []struct {
+		name         string
+		create       func() (types.Call, error)
+		expectedCall types.Call
+	}{
+		{
+			name: "proxy",
+			create: func() (types.Call, error) {
+				return Proxy(meta, real, nil, remark)
+			},
+			expectedCall: types.Call{
+				CallIndex: types.CallIndex{SectionIndex: 29, MethodIndex: 0},
+				Args:      concat(encodedReal, noProxyType, encodedRemark),
+			},
+		},
+		{
+			name: "proxy with forced proxy type",
+			create: func() (types.Call, error) {
+				return Proxy(meta, real, &nonTransfer, remark)
+			},
+			expectedCall: types.Call{
+				CallIndex: types.CallIndex{SectionIndex: 29, MethodIndex: 0},
+				Args:      concat(encodedReal, forcedProxyType, encodedRemark),
+			},
+		},
+		{
+			name: "proxy_announced",
+			create: func() (types.Call, error) {
+				return ProxyAnnounced(meta, real, real, nil, remark)
+			},
+			expectedCall: types.Call{
+				CallIndex: types.CallIndex{SectionIndex: 29, MethodIndex: 9},
+				Args:      concat(encodedReal, encodedReal, noProxyType, encodedRemark),
+			},
+		},
+		{
+			name: "announce",
+			create: func() (types.Call, error) {
+				return Announce(meta, real, callHash)
+			},
+			expectedCall: types.Call{
+				CallIndex: types.CallIndex{SectionIndex: 29, MethodIndex: 6},
+				Args:      concat(encodedReal, callHash[:]),
+			},
+		},
+		{
+			name: "remove_announcement",
+			create: func() (types.Call, error) {
+				return RemoveAnnouncement(meta, real, callHash)
+			},
+			expectedCall: types.Call{
+				CallIndex: types.CallIndex{SectionIndex: 29, MethodIndex: 7},
+				Args:      concat(encodedReal, callHash[:]),
+			},
+		},
+		{
+			name: "reject_announcement",
+			create: func() (types.Call, error) {
+				return RejectAnnouncement(meta, real, callHash)
+			},
+			expectedCall: types.Call{
+				CallIndex: types.CallIndex{SectionIndex: 29, MethodIndex: 8},
+				Args:      concat(encodedReal, callHash[:]),
+			},
+		},
+		{
+			name: "create_pure",
+			create: func() (types.Call, error) {
+				return CreatePure(meta, nonTransfer, 10, 3)
+			},
+			expectedCall: types.Call{
+				CallIndex: types.CallIndex{SectionIndex: 29, MethodIndex: 4},
+				Args:      types.Args{1, 10, 0, 0, 0, 3, 0},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			call, err := test.create()
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCall, call)
+		})
+	}
+}
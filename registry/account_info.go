@@ -0,0 +1,275 @@
+package registry
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	systemPalletName     = "System"
+	accountStorageName   = "Account"
+	accountInfoDataField = "data"
+)
+
+// AccountInfoLayout is the layout of the value of the System.Account storage.
+type AccountInfoLayout uint8
+
+const (
+	// AccountInfoLayoutUnknown is a layout that is not known to this package, its values are decoded dynamically.
+	AccountInfoLayoutUnknown AccountInfoLayout = iota
+	// AccountInfoLayoutCurrent is decoded into a types.AccountInfo.
+	AccountInfoLayoutCurrent
+	// AccountInfoLayoutFeeFrozen is decoded into a types.AccountInfoWithFeeFrozen.
+	AccountInfoLayoutFeeFrozen
+	// AccountInfoLayoutDualRefCount is decoded into a types.AccountInfoWithDualRefCount.
+	AccountInfoLayoutDualRefCount
+	// AccountInfoLayoutRefCount is decoded into a types.AccountInfoWithRefCount.
+	AccountInfoLayoutRefCount
+)
+
+// accountInfoLayoutFields holds the names of the u32 fields of an AccountInfoLayout and of the u128 fields of its data.
+type accountInfoLayoutFields struct {
+	layout     AccountInfoLayout
+	infoFields []string
+	dataFields []string
+}
+
+var (
+	accountDataFields              = []string{"free", "reserved", "frozen", "flags"}
+	accountDataWithFeeFrozenFields = []string{"free", "reserved", "misc_frozen", "fee_frozen"}
+
+	accountInfoLayouts = []accountInfoLayoutFields{
+		{
+			layout:     AccountInfoLayoutCurrent,
+			infoFields: []string{"nonce", "consumers", "providers", "sufficients"},
+			dataFields: accountDataFields,
+		},
+		{
+			layout:     AccountInfoLayoutFeeFrozen,
+			infoFields: []string{"nonce", "consumers", "providers", "sufficients"},
+			dataFields: accountDataWithFeeFrozenFields,
+		},
+		{
+			layout:     AccountInfoLayoutDualRefCount,
+			infoFields: []string{"nonce", "consumers", "providers"},
+			dataFields: accountDataWithFeeFrozenFields,
+		},
+		{
+			layout:     AccountInfoLayoutRefCount,
+			infoFields: []string{"nonce", "refcount"},
+			dataFields: accountDataWithFeeFrozenFields,
+		},
+	}
+)
+
+// AccountInfoDecoder decodes the values of the System.Account storage of a runtime. Values of a known layout are
+// decoded into the matching struct of the types package, any other value is decoded dynamically.
+type AccountInfoDecoder struct {
+	Layout AccountInfoLayout
+	// ValueDecoder decodes values of an unknown layout.
+	ValueDecoder FieldDecoder
+}
+
+// NewAccountInfoDecoder creates an AccountInfoDecoder for the value type of the System.Account storage in the
+// metadata.
+func NewAccountInfoDecoder(meta *types.Metadata) (*AccountInfoDecoder, error) {
+	valueTypeID, err := getAccountInfoTypeID(meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if layout := getAccountInfoLayout(meta, valueTypeID); layout != AccountInfoLayoutUnknown {
+		return &AccountInfoDecoder{Layout: layout}, nil
+	}
+
+	f := &factory{}
+	f.resetStorages()
+
+	valueFields, err := f.getTypeFields(meta, []types.Si1Field{{Type: valueTypeID}})
+
+	if err != nil {
+		return nil, ErrAccountInfoFieldsRetrieval.Wrap(err)
+	}
+
+	if err := f.resolveRecursiveDecoders(); err != nil {
+		return nil, ErrRecursiveDecodersResolving.Wrap(err)
+	}
+
+	return &AccountInfoDecoder{
+		Layout:       AccountInfoLayoutUnknown,
+		ValueDecoder: valueFields[0].FieldDecoder,
+	}, nil
+}
+
+// Decode decodes the SCALE encoded value of the System.Account storage. Depending on the layout, the result is a
+// *types.AccountInfo, *types.AccountInfoWithFeeFrozen, *types.AccountInfoWithDualRefCount or
+// *types.AccountInfoWithRefCount. Values of an unknown layout are returned as a map of their fields by name, see
+// RuntimeAPIResultDecoder.Decode.
+func (a *AccountInfoDecoder) Decode(data []byte) (any, error) {
+	reader := bytes.NewReader(data)
+	decoder := scale.NewDecoder(reader)
+
+	var (
+		res any
+		err error
+	)
+
+	switch a.Layout {
+	case AccountInfoLayoutCurrent:
+		res, err = decodeAccountInfo[types.AccountInfo](decoder)
+	case AccountInfoLayoutFeeFrozen:
+		res, err = decodeAccountInfo[types.AccountInfoWithFeeFrozen](decoder)
+	case AccountInfoLayoutDualRefCount:
+		res, err = decodeAccountInfo[types.AccountInfoWithDualRefCount](decoder)
+	case AccountInfoLayoutRefCount:
+		res, err = decodeAccountInfo[types.AccountInfoWithRefCount](decoder)
+	default:
+		res, err = a.decodeDynamically(decoder)
+	}
+
+	if err != nil {
+		return nil, ErrAccountInfoDecoding.Wrap(err)
+	}
+
+	if reader.Len() > 0 {
+		return nil, ErrAccountInfoTrailingBytes.WithMsg("%d bytes", reader.Len())
+	}
+
+	return res, nil
+}
+
+func (a *AccountInfoDecoder) decodeDynamically(decoder *scale.Decoder) (any, error) {
+	if a.ValueDecoder == nil {
+		return nil, ErrNilFieldDecoder
+	}
+
+	value, err := a.ValueDecoder.Decode(decoder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if decodedFields, ok := value.(DecodedFields); ok {
+		return decodedFieldsToMap(decodedFields), nil
+	}
+
+	return toDynamicValue(value), nil
+}
+
+func decodeAccountInfo[T any](decoder *scale.Decoder) (*T, error) {
+	var accountInfo T
+
+	if err := decoder.Decode(&accountInfo); err != nil {
+		return nil, err
+	}
+
+	return &accountInfo, nil
+}
+
+// GetAccountInfoLayout returns the layout of the value of the System.Account storage in the metadata.
+func GetAccountInfoLayout(meta *types.Metadata) (AccountInfoLayout, error) {
+	valueTypeID, err := getAccountInfoTypeID(meta)
+
+	if err != nil {
+		return AccountInfoLayoutUnknown, err
+	}
+
+	return getAccountInfoLayout(meta, valueTypeID), nil
+}
+
+// getAccountInfoTypeID returns the value type of the System.Account storage.
+func getAccountInfoTypeID(meta *types.Metadata) (types.Si1LookupTypeID, error) {
+	for _, mod := range meta.AsMetadataV14.Pallets {
+		if string(mod.Name) != systemPalletName || !mod.HasStorage {
+			continue
+		}
+
+		for _, entry := range mod.Storage.Items {
+			if string(entry.Name) != accountStorageName {
+				continue
+			}
+
+			if entry.Type.IsMap {
+				return entry.Type.AsMap.Value, nil
+			}
+
+			return entry.Type.AsPlainType, nil
+		}
+	}
+
+	return types.Si1LookupTypeID{}, ErrAccountInfoStorageNotFound
+}
+
+// getAccountInfoLayout returns the known layout that matches the names and types of the fields of the value type.
+func getAccountInfoLayout(meta *types.Metadata, valueTypeID types.Si1LookupTypeID) AccountInfoLayout {
+	for _, layoutFields := range accountInfoLayouts {
+		if matchesAccountInfoLayout(meta, valueTypeID, layoutFields) {
+			return layoutFields.layout
+		}
+	}
+
+	return AccountInfoLayoutUnknown
+}
+
+func matchesAccountInfoLayout(
+	meta *types.Metadata,
+	valueTypeID types.Si1LookupTypeID,
+	layoutFields accountInfoLayoutFields,
+) bool {
+	infoFields := append(append([]string{}, layoutFields.infoFields...), accountInfoDataField)
+
+	fields, ok := getCompositeFields(meta, valueTypeID, infoFields)
+	if !ok {
+		return false
+	}
+
+	for _, field := range fields[:len(fields)-1] {
+		if !isPrimitiveType(meta, field.Type, types.IsU32) {
+			return false
+		}
+	}
+
+	dataFields, ok := getCompositeFields(meta, fields[len(fields)-1].Type, layoutFields.dataFields)
+	if !ok {
+		return false
+	}
+
+	for _, field := range dataFields {
+		if !isPrimitiveType(meta, field.Type, types.IsU128) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getCompositeFields returns the fields of the composite type if they have the given names, in order.
+func getCompositeFields(meta *types.Metadata, typeID types.Si1LookupTypeID, names []string) ([]types.Si1Field, bool) {
+	compositeType, ok := meta.AsMetadataV14.EfficientLookup[typeID.Int64()]
+	if !ok || !compositeType.Def.IsComposite {
+		return nil, false
+	}
+
+	fields := compositeType.Def.Composite.Fields
+
+	if len(fields) != len(names) {
+		return nil, false
+	}
+
+	for i, field := range fields {
+		if !field.HasName || string(field.Name) != names[i] {
+			return nil, false
+		}
+	}
+
+	return fields, true
+}
+
+func isPrimitiveType(meta *types.Metadata, typeID types.Si1LookupTypeID, primitive types.Si0TypeDefPrimitive) bool {
+	fieldType, ok := meta.AsMetadataV14.EfficientLookup[typeID.Int64()]
+
+	return ok && fieldType.Def.IsPrimitive && fieldType.Def.Primitive.Si0TypeDefPrimitive == primitive
+}
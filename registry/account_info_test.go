@@ -0,0 +1,312 @@
+package registry
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAccountInfoField is a field of a System.Account value type that is created by withAccountInfoType.
+type testAccountInfoField struct {
+	name      string
+	primitive types.Si0TypeDefPrimitive
+}
+
+func namedTestAccountInfoFields(primitive types.Si0TypeDefPrimitive, names ...string) []testAccountInfoField {
+	fields := make([]testAccountInfoField, 0, len(names))
+
+	for _, name := range names {
+		fields = append(fields, testAccountInfoField{name: name, primitive: primitive})
+	}
+
+	return fields
+}
+
+// withAccountInfoType returns a copy of the metadata in which the value of the System.Account storage is a composite
+// of the info fields and a data field holding a composite of the data fields.
+func withAccountInfoType(
+	meta *types.Metadata,
+	infoFields []testAccountInfoField,
+	dataFields []testAccountInfoField,
+) *types.Metadata {
+	res := *meta
+	res.AsMetadataV14.EfficientLookup = make(map[int64]*types.Si1Type, len(meta.AsMetadataV14.EfficientLookup)+16)
+
+	nextID := int64(0)
+
+	for id, typ := range meta.AsMetadataV14.EfficientLookup {
+		res.AsMetadataV14.EfficientLookup[id] = typ
+		nextID = max(nextID, id+1)
+	}
+
+	addType := func(typ types.Si1Type) types.Si1LookupTypeID {
+		res.AsMetadataV14.EfficientLookup[nextID] = &typ
+		nextID++
+
+		return types.NewSi1LookupTypeIDFromUInt(uint64(nextID - 1))
+	}
+
+	compositeOf := func(fields []testAccountInfoField) []types.Si1Field {
+		res := make([]types.Si1Field, 0, len(fields))
+
+		for _, field := range fields {
+			res = append(res, types.Si1Field{
+				HasName: true,
+				Name:    types.Text(field.name),
+				Type: addType(types.Si1Type{Def: types.Si1TypeDef{
+					IsPrimitive: true,
+					Primitive:   types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: field.primitive},
+				}}),
+			})
+		}
+
+		return res
+	}
+
+	dataTypeID := addType(types.Si1Type{Def: types.Si1TypeDef{
+		IsComposite: true,
+		Composite:   types.Si1TypeDefComposite{Fields: compositeOf(dataFields)},
+	}})
+
+	valueFields := append(compositeOf(infoFields), types.Si1Field{HasName: true, Name: "data", Type: dataTypeID})
+
+	valueTypeID := addType(types.Si1Type{Def: types.Si1TypeDef{
+		IsComposite: true,
+		Composite:   types.Si1TypeDefComposite{Fields: valueFields},
+	}})
+
+	res.AsMetadataV14.Pallets = append([]types.PalletMetadataV14{}, meta.AsMetadataV14.Pallets...)
+
+	for i, pallet := range res.AsMetadataV14.Pallets {
+		if pallet.Name != systemPalletName {
+			continue
+		}
+
+		items := append([]types.StorageEntryMetadataV14{}, pallet.Storage.Items...)
+
+		for j, item := range items {
+			if item.Name == accountStorageName {
+				items[j].Type.AsMap.Value = valueTypeID
+			}
+		}
+
+		res.AsMetadataV14.Pallets[i].Storage.Items = items
+	}
+
+	return &res
+}
+
+func newTestU128(i int64) types.U128 {
+	return types.NewU128(*big.NewInt(i))
+}
+
+func TestAccountInfoDecoder(t *testing.T) {
+	var polkadotMeta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &polkadotMeta)
+	require.NoError(t, err)
+
+	dataWithFeeFrozen := types.AccountDataWithFeeFrozen{
+		Free:       newTestU128(5),
+		Reserved:   newTestU128(6),
+		MiscFrozen: newTestU128(7),
+		FeeFrozen:  newTestU128(8),
+	}
+
+	var tests = []struct {
+		Chain          string
+		Meta           func(t *testing.T) *types.Metadata
+		ExpectedLayout AccountInfoLayout
+		AccountInfo    any
+	}{
+		{
+			// Kusama runs the current balances pallet, whose layout is not part of the metadata fixtures.
+			Chain: "kusama",
+			Meta: func(t *testing.T) *types.Metadata {
+				return withAccountInfoType(
+					&polkadotMeta,
+					namedTestAccountInfoFields(types.IsU32, "nonce", "consumers", "providers", "sufficients"),
+					namedTestAccountInfoFields(types.IsU128, accountDataFields...),
+				)
+			},
+			ExpectedLayout: AccountInfoLayoutCurrent,
+			AccountInfo: &types.AccountInfo{
+				Nonce:       1,
+				Consumers:   2,
+				Providers:   3,
+				Sufficients: 4,
+				Data: types.AccountData{
+					Free:     newTestU128(5),
+					Reserved: newTestU128(6),
+					Frozen:   newTestU128(7),
+					Flags:    newTestU128(8),
+				},
+			},
+		},
+		{
+			Chain: "polkadot",
+			Meta: func(t *testing.T) *types.Metadata {
+				return &polkadotMeta
+			},
+			ExpectedLayout: AccountInfoLayoutFeeFrozen,
+			AccountInfo: &types.AccountInfoWithFeeFrozen{
+				Nonce:       1,
+				Consumers:   2,
+				Providers:   3,
+				Sufficients: 4,
+				Data:        dataWithFeeFrozen,
+			},
+		},
+		{
+			Chain: "statemint",
+			Meta: func(t *testing.T) *types.Metadata {
+				var meta types.Metadata
+
+				err := codec.DecodeFromHex(test.StatemintMetaHex, &meta)
+				require.NoError(t, err)
+
+				return &meta
+			},
+			ExpectedLayout: AccountInfoLayoutFeeFrozen,
+			AccountInfo: &types.AccountInfoWithFeeFrozen{
+				Nonce:       1,
+				Consumers:   2,
+				Providers:   3,
+				Sufficients: 4,
+				Data:        dataWithFeeFrozen,
+			},
+		},
+		{
+			Chain: "dual ref count",
+			Meta: func(t *testing.T) *types.Metadata {
+				return withAccountInfoType(
+					&polkadotMeta,
+					namedTestAccountInfoFields(types.IsU32, "nonce", "consumers", "providers"),
+					namedTestAccountInfoFields(types.IsU128, accountDataWithFeeFrozenFields...),
+				)
+			},
+			ExpectedLayout: AccountInfoLayoutDualRefCount,
+			AccountInfo: &types.AccountInfoWithDualRefCount{
+				Nonce:     1,
+				Consumers: 2,
+				Providers: 3,
+				Data:      dataWithFeeFrozen,
+			},
+		},
+		{
+			Chain: "ref count",
+			Meta: func(t *testing.T) *types.Metadata {
+				return withAccountInfoType(
+					&polkadotMeta,
+					namedTestAccountInfoFields(types.IsU32, "nonce", "refcount"),
+					namedTestAccountInfoFields(types.IsU128, accountDataWithFeeFrozenFields...),
+				)
+			},
+			ExpectedLayout: AccountInfoLayoutRefCount,
+			AccountInfo: &types.AccountInfoWithRefCount{
+				Nonce:    1,
+				RefCount: 2,
+				Data:     dataWithFeeFrozen,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Chain, func(t *testing.T) {
+			meta := test.Meta(t)
+
+			layout, err := GetAccountInfoLayout(meta)
+			require.NoError(t, err)
+			assert.Equal(t, test.ExpectedLayout, layout)
+
+			decoder, err := NewAccountInfoDecoder(meta)
+			require.NoError(t, err)
+			assert.Equal(t, test.ExpectedLayout, decoder.Layout)
+
+			encodedAccountInfo, err := codec.Encode(test.AccountInfo)
+			require.NoError(t, err)
+
+			res, err := decoder.Decode(encodedAccountInfo)
+			require.NoError(t, err)
+			assert.Equal(t, test.AccountInfo, res)
+
+			_, err = decoder.Decode(append(encodedAccountInfo, 0))
+			assert.ErrorIs(t, err, ErrAccountInfoTrailingBytes)
+
+			_, err = decoder.Decode(encodedAccountInfo[:8])
+			assert.ErrorIs(t, err, ErrAccountInfoDecoding)
+		})
+	}
+}
+
+func TestAccountInfoDecoder_UnknownLayout(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	// The nonce of some chains is a u64, which none of the structs of the types package match.
+	unknownMeta := withAccountInfoType(
+		&meta,
+		[]testAccountInfoField{
+			{name: "nonce", primitive: types.IsU64},
+			{name: "consumers", primitive: types.IsU32},
+			{name: "providers", primitive: types.IsU32},
+			{name: "sufficients", primitive: types.IsU32},
+		},
+		namedTestAccountInfoFields(types.IsU128, accountDataFields...),
+	)
+
+	decoder, err := NewAccountInfoDecoder(unknownMeta)
+	require.NoError(t, err)
+	assert.Equal(t, AccountInfoLayoutUnknown, decoder.Layout)
+
+	encodedAccountInfo, err := codec.Encode(struct {
+		Nonce                             types.U64
+		Consumers, Providers, Sufficients types.U32
+		Data                              types.AccountData
+	}{
+		Nonce:       1,
+		Consumers:   2,
+		Providers:   3,
+		Sufficients: 4,
+		Data: types.AccountData{
+			Free:     newTestU128(5),
+			Reserved: newTestU128(6),
+			Frozen:   newTestU128(7),
+			Flags:    newTestU128(8),
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := decoder.Decode(encodedAccountInfo)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"nonce":       types.U64(1),
+		"consumers":   types.U32(2),
+		"providers":   types.U32(3),
+		"sufficients": types.U32(4),
+		"data": map[string]any{
+			"free":     newTestU128(5),
+			"reserved": newTestU128(6),
+			"frozen":   newTestU128(7),
+			"flags":    newTestU128(8),
+		},
+	}, res)
+}
+
+func TestAccountInfoDecoder_StorageNotFound(t *testing.T) {
+	meta := &types.Metadata{Version: 14}
+
+	_, err := GetAccountInfoLayout(meta)
+	assert.ErrorIs(t, err, ErrAccountInfoStorageNotFound)
+
+	_, err = NewAccountInfoDecoder(meta)
+	assert.ErrorIs(t, err, ErrAccountInfoStorageNotFound)
+}
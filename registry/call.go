@@ -0,0 +1,205 @@
+package registry
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// DecodedCall is a call that was decoded with a CallRegistry, e.g. a call stored as a preimage.
+type DecodedCall struct {
+	CallIndex  types.CallIndex
+	PalletName string
+	CallName   string
+	Args       DecodedFields
+}
+
+// ArgsByName returns the values of the args by their name.
+func (d *DecodedCall) ArgsByName() map[string]any {
+	args := make(map[string]any, len(d.Args))
+
+	for _, arg := range d.Args {
+		args[arg.Name] = arg.Value
+	}
+
+	return args
+}
+
+// DecodeCall decodes the SCALE encoded call, i.e. its call index followed by its args.
+func (r CallRegistry) DecodeCall(data []byte) (*DecodedCall, error) {
+	return (&callDecoder{callRegistry: r}).decodeCallData(data)
+}
+
+// DecodeCallData decodes the SCALE encoded call, e.g. the call data of a governance proposal, with the call registry
+// of the metadata. Nested calls, e.g. the calls of Utility.batch or the call of Proxy.proxy, are decoded as well, their
+// args hold a *DecodedCall, or a []any of *DecodedCall for sequences of calls.
+//
+// The data can also be an unsigned extrinsic, i.e. the call prefixed with the compact length and the version of the
+// extrinsic, the prefix is stripped in that case.
+func DecodeCallData(meta *types.Metadata, data []byte) (*DecodedCall, error) {
+	callRegistry, err := NewFactory().CreateCallRegistry(meta)
+
+	if err != nil {
+		return nil, ErrCallRegistryCreation.Wrap(err)
+	}
+
+	d := &callDecoder{
+		callRegistry: callRegistry,
+		lookup:       meta.AsMetadataV14.EfficientLookup,
+	}
+
+	if extrinsicType, ok := d.lookup[meta.AsMetadataV14.Extrinsic.Type.Int64()]; ok {
+		for _, param := range extrinsicType.Params {
+			if param.Name == "Call" && param.HasType {
+				d.callType = param.Type.Int64()
+				d.hasCallType = true
+			}
+		}
+	}
+
+	call, isExtrinsic, isSigned := splitExtrinsic(data)
+
+	if isExtrinsic && !isSigned {
+		if decodedCall, err := d.decodeCallData(call); err == nil {
+			return decodedCall, nil
+		}
+	}
+
+	// Call data can look like an extrinsic by chance, it is decoded as a call if decoding the extrinsic failed.
+	decodedCall, err := d.decodeCallData(data)
+
+	if err != nil && isSigned {
+		return nil, ErrCallDataSignedExtrinsic
+	}
+
+	return decodedCall, err
+}
+
+// splitExtrinsic returns the call of the data if it is an extrinsic, i.e. if it is prefixed with its compact length
+// and an extrinsic version that is known.
+func splitExtrinsic(data []byte) (call []byte, isExtrinsic bool, isSigned bool) {
+	reader := bytes.NewReader(data)
+
+	length, err := scale.NewDecoder(reader).DecodeUintCompact()
+
+	if err != nil || !length.IsUint64() || length.Uint64() == 0 || length.Uint64() != uint64(reader.Len()) {
+		return nil, false, false
+	}
+
+	rest := data[len(data)-reader.Len():]
+	version := rest[0]
+
+	switch version & types.ExtrinsicUnmaskVersion {
+	case types.ExtrinsicVersion4, types.ExtrinsicVersion5:
+		return rest[1:], true, version&types.ExtrinsicBitSigned == types.ExtrinsicBitSigned
+	default:
+		return nil, false, false
+	}
+}
+
+// callDecoder decodes calls with a CallRegistry. If the type of the runtime call is known, it is a FieldDecoder for
+// the nested calls of the args.
+type callDecoder struct {
+	callRegistry CallRegistry
+	lookup       map[int64]*types.Si1Type
+	callType     int64
+	hasCallType  bool
+}
+
+func (d *callDecoder) decodeCallData(data []byte) (*DecodedCall, error) {
+	reader := bytes.NewReader(data)
+
+	decodedCall, err := d.decodeCall(scale.NewDecoder(reader))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if reader.Len() > 0 {
+		return nil, ErrCallTrailingBytes.WithMsg("%d bytes", reader.Len())
+	}
+
+	return decodedCall, nil
+}
+
+func (d *callDecoder) Decode(decoder *scale.Decoder) (any, error) {
+	return d.decodeCall(decoder)
+}
+
+func (d *callDecoder) decodeCall(decoder *scale.Decoder) (*DecodedCall, error) {
+	var callIndex types.CallIndex
+
+	if err := decoder.Decode(&callIndex); err != nil {
+		return nil, ErrCallIndexDecoding.Wrap(err)
+	}
+
+	typeDecoder, ok := d.callRegistry[callIndex]
+	if !ok {
+		return nil, ErrCallDecoderNotFound.WithMsg("call index %d.%d", callIndex.SectionIndex, callIndex.MethodIndex)
+	}
+
+	args, err := d.decodeArgs(decoder, typeDecoder)
+	if err != nil {
+		return nil, ErrCallArgsDecoding.WithMsg(typeDecoder.Name).Wrap(err)
+	}
+
+	palletName, callName, _ := strings.Cut(typeDecoder.Name, ".")
+
+	return &DecodedCall{
+		CallIndex:  callIndex,
+		PalletName: palletName,
+		CallName:   callName,
+		Args:       args,
+	}, nil
+}
+
+func (d *callDecoder) decodeArgs(decoder *scale.Decoder, typeDecoder *TypeDecoder) (DecodedFields, error) {
+	if !d.hasCallType {
+		return typeDecoder.Decode(decoder)
+	}
+
+	var args DecodedFields
+
+	for _, field := range typeDecoder.Fields {
+		if field == nil {
+			return nil, ErrNilField
+		}
+
+		arg, err := (&Field{
+			Name:         field.Name,
+			FieldDecoder: d.nestedCallDecoder(field),
+			LookupIndex:  field.LookupIndex,
+		}).Decode(decoder)
+
+		if err != nil {
+			return nil, ErrTypeFieldDecoding.Wrap(err)
+		}
+
+		args = append(args, arg)
+	}
+
+	return args, nil
+}
+
+// nestedCallDecoder returns the FieldDecoder for fields that are calls or sequences of calls, and the FieldDecoder of
+// the field otherwise.
+func (d *callDecoder) nestedCallDecoder(field *Field) FieldDecoder {
+	if field.LookupIndex == d.callType {
+		return d
+	}
+
+	fieldType, ok := d.lookup[field.LookupIndex]
+
+	switch {
+	case !ok:
+		return field.FieldDecoder
+	case fieldType.Def.IsSequence && fieldType.Def.Sequence.Type.Int64() == d.callType:
+		return &SliceDecoder{ItemDecoder: d}
+	case fieldType.Def.IsArray && fieldType.Def.Array.Type.Int64() == d.callType:
+		return &ArrayDecoder{Length: uint(fieldType.Def.Array.Len), ItemDecoder: d}
+	default:
+		return field.FieldDecoder
+	}
+}
@@ -0,0 +1,683 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// NewCallFromArgs creates the call with the given name, e.g. Balances.transfer_keep_alive, from its args by name. The
+// args are encoded with the types of the metadata and can be given as plain values, e.g. decoded from JSON:
+//
+//   - integers as Go integers, integral float64 or json.Number values, decimal strings or *big.Int
+//   - accounts as SS58 addresses or hex strings, multi addresses are created from accounts as well
+//   - byte sequences and byte arrays as hex strings, byte sequences also as text
+//   - composites as map[string]any by field name, or []any in field order, newtypes also as their inner value
+//   - enums by the name of their variant, either as string for variants without fields or as map[string]any with the
+//     name of the variant as only key, options as nil or their value
+//   - sequences, arrays and tuples as slices
+//
+// Values of the types package, e.g. types.MultiAddress or types.Call, are encoded as they are.
+func NewCallFromArgs(meta *types.Metadata, call string, args map[string]any) (types.Call, error) {
+	palletName, callName, _ := strings.Cut(call, ".")
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) != palletName || !pallet.HasCalls {
+			continue
+		}
+
+		callsType, ok := meta.AsMetadataV14.EfficientLookup[pallet.Calls.Type.Int64()]
+
+		if !ok || !callsType.Def.IsVariant {
+			return types.Call{}, ErrCallsTypeNotVariant.WithMsg("calls type '%d', module '%s'", pallet.Calls.Type.Int64(),
+				palletName)
+		}
+
+		for _, variant := range callsType.Def.Variant.Variants {
+			if string(variant.Name) != callName {
+				continue
+			}
+
+			encodedArgs, err := encodeCallArgs(meta.AsMetadataV14.EfficientLookup, call, variant.Fields, args)
+
+			if err != nil {
+				return types.Call{}, err
+			}
+
+			return types.Call{
+				CallIndex: types.CallIndex{SectionIndex: uint8(pallet.Index), MethodIndex: uint8(variant.Index)},
+				Args:      encodedArgs,
+			}, nil
+		}
+	}
+
+	return types.Call{}, ErrCallNotFound.WithMsg(call)
+}
+
+func encodeCallArgs(lookup map[int64]*types.Si1Type, call string, fields []types.Si1Field, args map[string]any) (
+	[]byte, error) {
+	var buf bytes.Buffer
+
+	e := &argEncoder{lookup: lookup, encoder: scale.NewEncoder(&buf)}
+
+	for i, field := range fields {
+		name := argName(i, field)
+
+		value, ok := args[name]
+
+		if !ok {
+			return nil, ErrCallArgMissing.WithMsg("arg '%s' of %s, expected %s", name, call,
+				e.typeName(field.Type.Int64()))
+		}
+
+		if err := e.encode(name, field.Type.Int64(), value); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(args) > len(fields) {
+		for _, name := range sortedKeys(args) {
+			if !hasArg(fields, name) {
+				return nil, ErrCallArgUnknown.WithMsg("arg '%s' of %s", name, call)
+			}
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// argName returns the name of the field, or its position for unnamed fields.
+func argName(index int, field types.Si1Field) string {
+	if field.HasName {
+		return string(field.Name)
+	}
+
+	return strconv.Itoa(index)
+}
+
+func hasArg(fields []types.Si1Field, name string) bool {
+	for i, field := range fields {
+		if argName(i, field) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// argEncoder encodes the args of a call with the types of the metadata.
+type argEncoder struct {
+	lookup  map[int64]*types.Si1Type
+	encoder *scale.Encoder
+}
+
+// encode encodes the value with the type, the path names the value in errors, e.g. dest.Id.
+// nolint:funlen
+func (e *argEncoder) encode(path string, typeID int64, value any) error {
+	t, ok := e.lookup[typeID]
+
+	if !ok {
+		return ErrFieldTypeNotFound.WithMsg("arg '%s', type '%d'", path, typeID)
+	}
+
+	if isTypesValue(value) {
+		return e.wrap(path, typeID, e.encoder.Encode(value))
+	}
+
+	switch {
+	case t.Def.IsComposite:
+		return e.encodeComposite(path, typeID, t, value)
+	case t.Def.IsVariant:
+		return e.encodeVariant(path, typeID, t, value)
+	case t.Def.IsSequence:
+		if e.isByte(t.Def.Sequence.Type.Int64()) {
+			b, err := e.bytes(path, typeID, value, true)
+
+			if err != nil {
+				return err
+			}
+
+			return e.wrap(path, typeID, e.encoder.Encode(b))
+		}
+
+		items, err := e.slice(path, typeID, value)
+
+		if err != nil {
+			return err
+		}
+
+		if err := e.encoder.EncodeUintCompact(*big.NewInt(int64(len(items)))); err != nil {
+			return e.wrap(path, typeID, err)
+		}
+
+		return e.encodeItems(path, t.Def.Sequence.Type.Int64(), items)
+	case t.Def.IsArray:
+		if e.isByte(t.Def.Array.Type.Int64()) {
+			b, err := e.bytes(path, typeID, value, false)
+
+			if err != nil {
+				return err
+			}
+
+			if len(b) != int(t.Def.Array.Len) {
+				return e.mismatch(path, typeID, fmt.Sprintf("%d bytes", len(b)))
+			}
+
+			return e.wrap(path, typeID, e.encoder.Write(b))
+		}
+
+		items, err := e.slice(path, typeID, value)
+
+		if err != nil {
+			return err
+		}
+
+		if len(items) != int(t.Def.Array.Len) {
+			return e.mismatch(path, typeID, fmt.Sprintf("%d items", len(items)))
+		}
+
+		return e.encodeItems(path, t.Def.Array.Type.Int64(), items)
+	case t.Def.IsTuple:
+		if len(t.Def.Tuple) == 0 {
+			return nil
+		}
+
+		items, err := e.slice(path, typeID, value)
+
+		if err != nil {
+			return err
+		}
+
+		if len(items) != len(t.Def.Tuple) {
+			return e.mismatch(path, typeID, fmt.Sprintf("%d items", len(items)))
+		}
+
+		for i, item := range items {
+			if err := e.encode(fmt.Sprintf("%s.%d", path, i), t.Def.Tuple[i].Int64(), item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case t.Def.IsPrimitive:
+		return e.encodePrimitive(path, typeID, t.Def.Primitive.Si0TypeDefPrimitive, value)
+	case t.Def.IsCompact:
+		n, err := e.integer(path, typeID, value)
+
+		if err != nil {
+			return err
+		}
+
+		return e.wrap(path, typeID, e.encoder.EncodeUintCompact(*n))
+	default:
+		return ErrFieldTypeDefinitionNotSupported.WithMsg("arg '%s', type %s", path, e.typeName(typeID))
+	}
+}
+
+func (e *argEncoder) encodeItems(path string, itemTypeID int64, items []any) error {
+	for i, item := range items {
+		if err := e.encode(fmt.Sprintf("%s.%d", path, i), itemTypeID, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *argEncoder) encodeComposite(path string, typeID int64, t *types.Si1Type, value any) error {
+	fields := t.Def.Composite.Fields
+
+	if isAccountID(t) {
+		if address, ok := value.(string); ok && !strings.HasPrefix(address, "0x") {
+			_, accountID, err := types.SS58Decode(address)
+
+			if err != nil {
+				return e.wrap(path, typeID, err)
+			}
+
+			value = accountID
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for i, field := range fields {
+			name := argName(i, field)
+
+			fieldValue, ok := v[name]
+
+			if !ok {
+				return ErrCallArgMissing.WithMsg("arg '%s.%s', expected %s", path, name, e.typeName(field.Type.Int64()))
+			}
+
+			if err := e.encode(path+"."+name, field.Type.Int64(), fieldValue); err != nil {
+				return err
+			}
+		}
+
+		if len(v) > len(fields) {
+			for _, name := range sortedKeys(v) {
+				if !hasArg(fields, name) {
+					return ErrCallArgUnknown.WithMsg("arg '%s.%s'", path, name)
+				}
+			}
+		}
+
+		return nil
+	case []any:
+		if len(fields) != 1 {
+			if len(v) != len(fields) {
+				return e.mismatch(path, typeID, fmt.Sprintf("%d items", len(v)))
+			}
+
+			for i, field := range fields {
+				if err := e.encode(fmt.Sprintf("%s.%d", path, i), field.Type.Int64(), v[i]); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	}
+
+	switch len(fields) {
+	case 0:
+		return nil
+	case 1:
+		// Newtypes, e.g. AccountId32 or Perbill, are given as their inner value.
+		return e.encode(path, fields[0].Type.Int64(), value)
+	default:
+		return e.mismatch(path, typeID, fmt.Sprintf("%T", value))
+	}
+}
+
+func (e *argEncoder) encodeVariant(path string, typeID int64, t *types.Si1Type, value any) error {
+	variants := t.Def.Variant.Variants
+
+	name, variantValue := variantOf(value)
+	variant, ok := findVariant(variants, name)
+
+	if !ok && isOption(t) {
+		if value == nil {
+			return e.wrap(path, typeID, e.encoder.PushByte(0))
+		}
+
+		variant, ok = findVariant(variants, "Some")
+		name, variantValue = "Some", value
+	}
+
+	if !ok {
+		// Addresses can be given as their account, i.e. the Id variant of a MultiAddress.
+		if idVariant, hasID := findVariant(variants, "Id"); hasID && len(idVariant.Fields) == 1 {
+			variant, ok = idVariant, true
+			name, variantValue = "Id", value
+		}
+	}
+
+	if !ok {
+		names := make([]string, 0, len(variants))
+
+		for _, variant := range variants {
+			names = append(names, string(variant.Name))
+		}
+
+		return ErrCallArgEncoding.WithMsg(
+			"arg '%s': expected a variant of %s (%s), got %v", path, e.typeName(typeID), strings.Join(names, ", "), value,
+		)
+	}
+
+	if err := e.encoder.PushByte(byte(variant.Index)); err != nil {
+		return e.wrap(path, typeID, err)
+	}
+
+	variantPath := path + "." + name
+
+	switch len(variant.Fields) {
+	case 0:
+		return nil
+	case 1:
+		field := variant.Fields[0]
+
+		// Single named fields can be given as map as well, e.g. {"remark": {"remark": "0x00"}}.
+		if fields, isMap := variantValue.(map[string]any); isMap && field.HasName && len(fields) == 1 {
+			if fieldValue, ok := fields[string(field.Name)]; ok {
+				return e.encode(variantPath+"."+string(field.Name), field.Type.Int64(), fieldValue)
+			}
+		}
+
+		return e.encode(variantPath, field.Type.Int64(), variantValue)
+	default:
+		// The fields of the variant are encoded like a composite.
+		return e.encodeComposite(variantPath, typeID, &types.Si1Type{
+			Def: types.Si1TypeDef{IsComposite: true, Composite: types.Si1TypeDefComposite{Fields: variant.Fields}},
+		}, variantValue)
+	}
+}
+
+func findVariant(variants []types.Si1Variant, name string) (types.Si1Variant, bool) {
+	for _, variant := range variants {
+		if string(variant.Name) == name {
+			return variant, true
+		}
+	}
+
+	return types.Si1Variant{}, false
+}
+
+// variantOf returns the name and the value of the variant given as string or as map with a single key.
+func variantOf(value any) (string, any) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case map[string]any:
+		if len(v) == 1 {
+			for name, variantValue := range v {
+				return name, variantValue
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func (e *argEncoder) encodePrimitive(path string, typeID int64, primitive types.Si0TypeDefPrimitive, value any) error {
+	switch primitive {
+	case types.IsBool:
+		b, ok := value.(bool)
+
+		if s, isString := value.(string); isString {
+			parsed, err := strconv.ParseBool(s)
+			b, ok = parsed, err == nil
+		}
+
+		if !ok {
+			return e.mismatch(path, typeID, fmt.Sprintf("%v", value))
+		}
+
+		return e.wrap(path, typeID, e.encoder.Encode(b))
+	case types.IsStr:
+		s, ok := value.(string)
+
+		if !ok {
+			return e.mismatch(path, typeID, fmt.Sprintf("%T", value))
+		}
+
+		return e.wrap(path, typeID, e.encoder.Encode(s))
+	}
+
+	size, signed, ok := integerSize(primitive)
+
+	if !ok {
+		return ErrPrimitiveTypeNotSupported.WithMsg("arg '%s', primitive type %v", path, primitive)
+	}
+
+	n, err := e.integer(path, typeID, value)
+
+	if err != nil {
+		return err
+	}
+
+	bits := uint(size * 8)
+	minValue, maxValue := big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), bits)
+
+	if signed {
+		maxValue.Rsh(maxValue, 1)
+		minValue.Neg(maxValue)
+	}
+
+	if n.Cmp(minValue) < 0 || n.Cmp(maxValue) >= 0 {
+		return ErrCallArgEncoding.WithMsg("arg '%s': %s is out of range of %s", path, n, e.typeName(typeID))
+	}
+
+	if n.Sign() < 0 {
+		// Negative integers are encoded in two's complement.
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), bits))
+	}
+
+	encoded := make([]byte, size)
+
+	// The integers are encoded in little endian.
+	for i, b := range n.Bytes() {
+		encoded[len(n.Bytes())-1-i] = b
+	}
+
+	return e.wrap(path, typeID, e.encoder.Write(encoded))
+}
+
+func integerSize(primitive types.Si0TypeDefPrimitive) (int, bool, bool) {
+	switch primitive {
+	case types.IsU8, types.IsI8:
+		return 1, primitive == types.IsI8, true
+	case types.IsU16, types.IsI16:
+		return 2, primitive == types.IsI16, true
+	case types.IsU32, types.IsI32:
+		return 4, primitive == types.IsI32, true
+	case types.IsU64, types.IsI64:
+		return 8, primitive == types.IsI64, true
+	case types.IsU128, types.IsI128:
+		return 16, primitive == types.IsI128, true
+	case types.IsU256, types.IsI256:
+		return 32, primitive == types.IsI256, true
+	default:
+		return 0, false, false
+	}
+}
+
+// integer converts the value to an integer, big numbers are usually given as decimal strings.
+func (e *argEncoder) integer(path string, typeID int64, value any) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v != nil {
+			return v, nil
+		}
+	case big.Int:
+		return &v, nil
+	case string:
+		if n, ok := new(big.Int).SetString(v, 0); ok {
+			return n, nil
+		}
+	case json.Number:
+		if n, ok := new(big.Int).SetString(string(v), 10); ok {
+			return n, nil
+		}
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			n, _ := big.NewFloat(v).Int(nil)
+			return n, nil
+		}
+	default:
+		rv := reflect.ValueOf(value)
+
+		switch {
+		case rv.CanInt():
+			return big.NewInt(rv.Int()), nil
+		case rv.CanUint():
+			return new(big.Int).SetUint64(rv.Uint()), nil
+		}
+	}
+
+	return nil, e.mismatch(path, typeID, fmt.Sprintf("%v", value))
+}
+
+// bytes converts the value to bytes, given as hex strings, byte slices or, for byte sequences, as text.
+func (e *argEncoder) bytes(path string, typeID int64, value any, text bool) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		if strings.HasPrefix(v, "0x") {
+			b, err := codec.HexDecodeString(v)
+
+			if err != nil {
+				return nil, e.wrap(path, typeID, err)
+			}
+
+			return b, nil
+		}
+
+		if text {
+			return []byte(v), nil
+		}
+	default:
+		// Byte arrays, e.g. [32]byte, and slices of numbers are converted item by item.
+		rv := reflect.ValueOf(value)
+
+		if rv.Kind() == reflect.Array || rv.Kind() == reflect.Slice {
+			b := make([]byte, rv.Len())
+
+			for i := range b {
+				n, err := e.integer(fmt.Sprintf("%s.%d", path, i), typeID, rv.Index(i).Interface())
+
+				if err != nil {
+					return nil, err
+				}
+
+				if !n.IsUint64() || n.Uint64() > math.MaxUint8 {
+					return nil, ErrCallArgEncoding.WithMsg("arg '%s.%d': %s is out of range of u8", path, i, n)
+				}
+
+				b[i] = byte(n.Uint64())
+			}
+
+			return b, nil
+		}
+	}
+
+	return nil, e.mismatch(path, typeID, fmt.Sprintf("%v", value))
+}
+
+// slice converts the value to a slice of items.
+func (e *argEncoder) slice(path string, typeID int64, value any) ([]any, error) {
+	if items, ok := value.([]any); ok {
+		return items, nil
+	}
+
+	rv := reflect.ValueOf(value)
+
+	if rv.Kind() != reflect.Array && rv.Kind() != reflect.Slice {
+		return nil, e.mismatch(path, typeID, fmt.Sprintf("%T", value))
+	}
+
+	items := make([]any, rv.Len())
+
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+
+	return items, nil
+}
+
+func (e *argEncoder) isByte(typeID int64) bool {
+	t, ok := e.lookup[typeID]
+
+	return ok && t.Def.IsPrimitive && t.Def.Primitive.Si0TypeDefPrimitive == types.IsU8
+}
+
+func (e *argEncoder) mismatch(path string, typeID int64, got string) error {
+	return ErrCallArgEncoding.WithMsg("arg '%s': expected %s, got %s", path, e.typeName(typeID), got)
+}
+
+func (e *argEncoder) wrap(path string, typeID int64, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return ErrCallArgEncoding.WithMsg("arg '%s': expected %s", path, e.typeName(typeID)).Wrap(err)
+}
+
+// typeName returns the name of the type for errors, e.g. Vec<u8> or sp_runtime::multiaddress::MultiAddress.
+func (e *argEncoder) typeName(typeID int64) string {
+	return typeName(e.lookup, typeID, 0)
+}
+
+// maxTypeNameDepth limits the depth of the names of nested types, which may be recursive.
+const maxTypeNameDepth = 4
+
+var primitiveNames = map[types.Si0TypeDefPrimitive]string{
+	types.IsBool: "bool", types.IsChar: "char", types.IsStr: "str",
+	types.IsU8: "u8", types.IsU16: "u16", types.IsU32: "u32", types.IsU64: "u64", types.IsU128: "u128",
+	types.IsU256: "u256", types.IsI8: "i8", types.IsI16: "i16", types.IsI32: "i32", types.IsI64: "i64",
+	types.IsI128: "i128", types.IsI256: "i256",
+}
+
+func typeName(lookup map[int64]*types.Si1Type, typeID int64, depth int) string {
+	t, ok := lookup[typeID]
+
+	if !ok || depth > maxTypeNameDepth {
+		return fmt.Sprintf(lookupIndexFormat, typeID)
+	}
+
+	if len(t.Path) > 0 {
+		path := make([]string, 0, len(t.Path))
+
+		for _, segment := range t.Path {
+			path = append(path, string(segment))
+		}
+
+		return strings.Join(path, "::")
+	}
+
+	switch {
+	case t.Def.IsPrimitive:
+		return primitiveNames[t.Def.Primitive.Si0TypeDefPrimitive]
+	case t.Def.IsCompact:
+		return fmt.Sprintf("Compact<%s>", typeName(lookup, t.Def.Compact.Type.Int64(), depth+1))
+	case t.Def.IsSequence:
+		return fmt.Sprintf("Vec<%s>", typeName(lookup, t.Def.Sequence.Type.Int64(), depth+1))
+	case t.Def.IsArray:
+		return fmt.Sprintf("[%s; %d]", typeName(lookup, t.Def.Array.Type.Int64(), depth+1), t.Def.Array.Len)
+	case t.Def.IsTuple:
+		items := make([]string, 0, len(t.Def.Tuple))
+
+		for _, item := range t.Def.Tuple {
+			items = append(items, typeName(lookup, item.Int64(), depth+1))
+		}
+
+		return fmt.Sprintf("(%s)", strings.Join(items, ", "))
+	default:
+		return fmt.Sprintf(lookupIndexFormat, typeID)
+	}
+}
+
+// typesPackage is the path of the types package, whose values are encoded as they are.
+var typesPackage = reflect.TypeOf(types.Call{}).PkgPath()
+
+func isTypesValue(value any) bool {
+	t := reflect.TypeOf(value)
+
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t != nil && t.PkgPath() == typesPackage
+}
+
+func isAccountID(t *types.Si1Type) bool {
+	return len(t.Path) > 0 && t.Path[len(t.Path)-1] == "AccountId32"
+}
+
+func isOption(t *types.Si1Type) bool {
+	return len(t.Path) == 1 && t.Path[0] == "Option"
+}
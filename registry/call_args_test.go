@@ -0,0 +1,172 @@
+package registry
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCallFromArgs(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	bob, err := types.NewMultiAddressFromHexAccountID("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+	require.NoError(t, err)
+
+	transfer, err := types.NewCall(&meta, "Balances.transfer_keep_alive", bob, types.NewUCompactFromUInt(1000000000000))
+	require.NoError(t, err)
+
+	remark, err := types.NewCall(&meta, "System.remark", types.NewBytes([]byte("hello")))
+	require.NoError(t, err)
+
+	batch, err := types.NewCall(&meta, "Utility.batch_all", []types.Call{remark, transfer})
+	require.NoError(t, err)
+
+	derivative, err := types.NewCall(&meta, "Utility.as_derivative", types.NewU16(7), transfer)
+	require.NoError(t, err)
+
+	var jsonArgs map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{"index": 7, "call": {"Balances": {"transfer_keep_alive": {
+		"dest": {"Id": "0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48"},
+		"value": 1000000000000
+	}}}}`), &jsonArgs))
+
+	tests := []struct {
+		name     string
+		call     string
+		args     map[string]any
+		expected types.Call
+	}{
+		{
+			name: "SS58 address and decimal string",
+			call: "Balances.transfer_keep_alive",
+			args: map[string]any{
+				"dest":  "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty",
+				"value": "1000000000000",
+			},
+			expected: transfer,
+		}, {
+			name:     "types values",
+			call:     "Balances.transfer_keep_alive",
+			args:     map[string]any{"dest": bob, "value": big.NewInt(1000000000000)},
+			expected: transfer,
+		}, {
+			name:     "text",
+			call:     "System.remark",
+			args:     map[string]any{"remark": "hello"},
+			expected: remark,
+		}, {
+			name:     "hex",
+			call:     "System.remark",
+			args:     map[string]any{"remark": "0x68656c6c6f"},
+			expected: remark,
+		}, {
+			name: "nested calls",
+			call: "Utility.batch_all",
+			args: map[string]any{"calls": []any{
+				map[string]any{"System": map[string]any{"remark": map[string]any{"remark": "hello"}}},
+				transfer,
+			}},
+			expected: batch,
+		}, {
+			name:     "JSON",
+			call:     "Utility.as_derivative",
+			args:     jsonArgs,
+			expected: derivative,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			call, err := NewCallFromArgs(&meta, tc.call, tc.args)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, call)
+		})
+	}
+}
+
+func TestNewCallFromArgs_Option(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	remark, err := types.NewCall(&meta, "System.remark", types.NewBytes([]byte{1}))
+	require.NoError(t, err)
+
+	alice := signature.TestKeyringPairAlice.PublicKey
+
+	for _, tc := range []struct {
+		forceProxyType any
+		expected       []byte
+	}{
+		{nil, []byte{0}},
+		{"None", []byte{0}},
+		{"Any", []byte{1, 0}},
+		{map[string]any{"Some": "Governance"}, []byte{1, 2}},
+	} {
+		call, err := NewCallFromArgs(&meta, "Proxy.proxy", map[string]any{
+			"real":             map[string]any{"Id": alice},
+			"force_proxy_type": tc.forceProxyType,
+			"call":             remark,
+		})
+		require.NoError(t, err)
+
+		encodedRemark, err := codec.Encode(remark)
+		require.NoError(t, err)
+
+		expected := append(append(append([]byte{0}, alice...), tc.expected...), encodedRemark...)
+		assert.Equal(t, types.Args(expected), call.Args, "%v", tc.forceProxyType)
+	}
+}
+
+func TestNewCallFromArgs_Errors(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	_, err = NewCallFromArgs(&meta, "Balances.unknown", nil)
+	assert.ErrorIs(t, err, ErrCallNotFound)
+
+	_, err = NewCallFromArgs(&meta, "Balances.transfer_keep_alive", map[string]any{"value": 1})
+	assert.ErrorIs(t, err, ErrCallArgMissing)
+	assert.ErrorContains(t, err,
+		"arg 'dest' of Balances.transfer_keep_alive, expected sp_runtime::multiaddress::MultiAddress")
+
+	_, err = NewCallFromArgs(&meta, "System.remark", map[string]any{"remark": "0x01", "other": 1})
+	assert.ErrorIs(t, err, ErrCallArgUnknown)
+	assert.ErrorContains(t, err, "arg 'other'")
+
+	_, err = NewCallFromArgs(&meta, "Balances.transfer_keep_alive", map[string]any{
+		"dest":  "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty",
+		"value": "ten",
+	})
+	assert.ErrorIs(t, err, ErrCallArgEncoding)
+	assert.ErrorContains(t, err, "arg 'value': expected Compact<u128>, got ten")
+
+	_, err = NewCallFromArgs(&meta, "Balances.transfer_keep_alive", map[string]any{"dest": "invalid", "value": 1})
+	assert.ErrorIs(t, err, ErrCallArgEncoding)
+	assert.ErrorContains(t, err, "arg 'dest.Id'")
+
+	_, err = NewCallFromArgs(&meta, "Utility.as_derivative", map[string]any{"index": 70000, "call": "System"})
+	assert.ErrorIs(t, err, ErrCallArgEncoding)
+	assert.ErrorContains(t, err, "arg 'index': 70000 is out of range of u16")
+
+	_, err = NewCallFromArgs(&meta, "Proxy.proxy", map[string]any{
+		"real":             "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty",
+		"force_proxy_type": "Everything",
+		"call":             map[string]any{"System": map[string]any{"remark": map[string]any{"remark": "0x"}}},
+	})
+	assert.ErrorIs(t, err, ErrCallArgEncoding)
+	assert.ErrorContains(t, err, "arg 'force_proxy_type.Some': expected a variant of polkadot_runtime::ProxyType (Any")
+}
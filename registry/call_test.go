@@ -0,0 +1,121 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallRegistry_DecodeCall(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	callRegistry, err := NewFactory().CreateCallRegistry(&meta)
+	require.NoError(t, err)
+
+	call, err := types.NewCall(&meta, "System.remark", types.NewBytes([]byte{1, 2, 3}))
+	require.NoError(t, err)
+
+	encodedCall, err := codec.Encode(call)
+	require.NoError(t, err)
+
+	decodedCall, err := callRegistry.DecodeCall(encodedCall)
+	require.NoError(t, err)
+	assert.Equal(t, "System", decodedCall.PalletName)
+	assert.Equal(t, "remark", decodedCall.CallName)
+	require.Len(t, decodedCall.Args, 1)
+	assert.Equal(t, "remark", decodedCall.Args[0].Name)
+	assert.Equal(t, []any{types.U8(1), types.U8(2), types.U8(3)}, decodedCall.Args[0].Value)
+
+	_, err = callRegistry.DecodeCall(append(encodedCall, 0))
+	assert.ErrorIs(t, err, ErrCallTrailingBytes)
+
+	_, err = callRegistry.DecodeCall(encodedCall[:len(encodedCall)-1])
+	assert.ErrorIs(t, err, ErrCallArgsDecoding)
+
+	_, err = callRegistry.DecodeCall([]byte{255, 255})
+	assert.ErrorIs(t, err, ErrCallDecoderNotFound)
+
+	_, err = callRegistry.DecodeCall([]byte{0})
+	assert.ErrorIs(t, err, ErrCallIndexDecoding)
+}
+
+func TestDecodeCallData(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	bob, err := types.NewMultiAddressFromHexAccountID("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+	require.NoError(t, err)
+
+	// Utility.as_derivative(1, Utility.batch_all([System.remark(0x010203), Balances.transfer_keep_alive(bob, 12345)]))
+	callData := "0x1a0101001a020800000c0102030503008eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48e5c0"
+	extrinsic := "0xcc04" + callData[2:]
+
+	for name, data := range map[string]string{"call": callData, "extrinsic": extrinsic} {
+		t.Run(name, func(t *testing.T) {
+			decodedCall, err := DecodeCallData(&meta, codec.MustHexDecodeString(data))
+			require.NoError(t, err)
+			assert.Equal(t, types.CallIndex{SectionIndex: 26, MethodIndex: 1}, decodedCall.CallIndex)
+			assert.Equal(t, "Utility", decodedCall.PalletName)
+			assert.Equal(t, "as_derivative", decodedCall.CallName)
+
+			args := decodedCall.ArgsByName()
+			assert.Equal(t, types.U16(1), args["index"])
+
+			batch, ok := args["polkadot_runtime.RuntimeCall.call"].(*DecodedCall)
+			require.True(t, ok)
+			assert.Equal(t, "batch_all", batch.CallName)
+
+			calls, ok := batch.ArgsByName()["calls"].([]any)
+			require.True(t, ok)
+			require.Len(t, calls, 2)
+
+			remark, ok := calls[0].(*DecodedCall)
+			require.True(t, ok)
+			assert.Equal(t, "System", remark.PalletName)
+			assert.Equal(t, "remark", remark.CallName)
+			assert.Equal(t, []any{types.U8(1), types.U8(2), types.U8(3)}, remark.ArgsByName()["remark"])
+
+			transfer, ok := calls[1].(*DecodedCall)
+			require.True(t, ok)
+			assert.Equal(t, "Balances", transfer.PalletName)
+			assert.Equal(t, "transfer_keep_alive", transfer.CallName)
+			require.Len(t, transfer.Args, 2)
+			assert.Equal(t, types.NewUCompactFromUInt(12345), transfer.Args[1].Value)
+		})
+	}
+
+	remark, err := types.NewCall(&meta, "System.remark", types.NewBytes([]byte{1, 2, 3}))
+	require.NoError(t, err)
+
+	transfer, err := types.NewCall(&meta, "Balances.transfer_keep_alive", bob, types.NewUCompactFromUInt(12345))
+	require.NoError(t, err)
+
+	batch, err := types.NewCall(&meta, "Utility.batch_all", []types.Call{remark, transfer})
+	require.NoError(t, err)
+
+	call, err := types.NewCall(&meta, "Utility.as_derivative", types.NewU16(1), batch)
+	require.NoError(t, err)
+
+	encodedCall, err := codec.EncodeToHex(call)
+	require.NoError(t, err)
+	assert.Equal(t, callData, encodedCall)
+
+	encodedExtrinsic, err := codec.EncodeToHex(types.NewExtrinsic(call))
+	require.NoError(t, err)
+	assert.Equal(t, extrinsic, encodedExtrinsic)
+
+	_, err = DecodeCallData(&meta, codec.MustHexDecodeString(callData+"00"))
+	assert.ErrorIs(t, err, ErrCallTrailingBytes)
+
+	_, err = DecodeCallData(&meta, []byte{0x0c, 0x84, 0x00, 0x00})
+	assert.ErrorIs(t, err, ErrCallDataSignedExtrinsic)
+}
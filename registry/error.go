@@ -49,6 +49,7 @@ const (
 	ErrRecursiveFieldDecoderNotFound         = libErr.Error("recursive field decoder not found")
 	ErrBitVecDecoding                        = libErr.Error("bit vec decoding")
 	ErrNilTypeDecoder                        = libErr.Error("nil type decoder")
+	ErrTypeNotStreamable                     = libErr.Error("type not streamable")
 	ErrNilField                              = libErr.Error("nil field")
 	ErrNilFieldDecoder                       = libErr.Error("nil field decoder")
 	ErrFieldEncode                           = libErr.Error("field encoding")
@@ -57,4 +58,36 @@ const (
 	ErrDecodedFieldValueTypeMismatch         = libErr.Error("decoded field value type mismatch")
 	ErrDecodedFieldValueProcessingError      = libErr.Error("decoded field value processing error")
 	ErrDecodedFieldValueNotAGenericSlice     = libErr.Error("decoded field value is not a generic slice")
+	ErrSessionKeysTypeNotFound               = libErr.Error("session keys type not found")
+	ErrSessionKeysTypeNotComposite           = libErr.Error("session keys type not a composite")
+	ErrSessionKeysFieldsRetrieval            = libErr.Error("session keys fields retrieval")
+	ErrSessionKeyDecoding                    = libErr.Error("session key decoding")
+	ErrSessionKeysTrailingBytes              = libErr.Error("session keys trailing bytes")
+	ErrQueuedKeysTypeUnexpected              = libErr.Error("queued keys type unexpected")
+	ErrQueuedKeysFieldsRetrieval             = libErr.Error("queued keys fields retrieval")
+	ErrQueuedKeysDecoding                    = libErr.Error("queued keys decoding")
+	ErrRuntimeAPIOutputFieldsRetrieval       = libErr.Error("runtime API output fields retrieval")
+	ErrRuntimeAPIResultDecoding              = libErr.Error("runtime API result decoding")
+	ErrRuntimeAPIResultTrailingBytes         = libErr.Error("runtime API result trailing bytes")
+	ErrAccountInfoStorageNotFound            = libErr.Error("account info storage not found")
+	ErrAccountInfoFieldsRetrieval            = libErr.Error("account info fields retrieval")
+	ErrAccountInfoDecoding                   = libErr.Error("account info decoding")
+	ErrAccountInfoTrailingBytes              = libErr.Error("account info trailing bytes")
+	ErrStorageEntryNotFound                  = libErr.Error("storage entry not found")
+	ErrStorageValueFieldsRetrieval           = libErr.Error("storage value fields retrieval")
+	ErrStorageValueDecoding                  = libErr.Error("storage value decoding")
+	ErrStorageValueTrailingBytes             = libErr.Error("storage value trailing bytes")
+	ErrTypeFieldsRetrieval                   = libErr.Error("type fields retrieval")
+	ErrCallIndexDecoding                     = libErr.Error("call index decoding")
+	ErrCallDecoderNotFound                   = libErr.Error("call decoder not found")
+	ErrCallArgsDecoding                      = libErr.Error("call args decoding")
+	ErrCallTrailingBytes                     = libErr.Error("call trailing bytes")
+	ErrCallRegistryCreation                  = libErr.Error("call registry creation")
+	ErrCallDataSignedExtrinsic               = libErr.Error("call data is a signed extrinsic")
+	ErrCallNotFound                          = libErr.Error("call not found")
+	ErrCallArgMissing                        = libErr.Error("call arg missing")
+	ErrCallArgUnknown                        = libErr.Error("call arg unknown")
+	ErrCallArgEncoding                       = libErr.Error("call arg encoding")
+	ErrOffsetsNotSupported                   = libErr.Error("offsets not supported")
+	ErrValueSerialization                    = libErr.Error("value serialization")
 )
@@ -1,8 +1,10 @@
 package exec
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 )
@@ -61,12 +63,18 @@ func (r *retryableExecutor[T]) ExecWithFallback(execFn func() (T, error), fallba
 		execErr.AddErr(fmt.Errorf("exec function error: %w", err))
 
 		if retryCount == r.opts.maxRetryCount {
+			r.log(slog.LevelWarn, "Execution failed, no retries left", retryCount, err)
+
 			return res, execErr
 		}
 
+		r.log(slog.LevelDebug, "Execution failed, running the fallback", retryCount, err)
+
 		if err = fallbackFn(); err != nil && !r.opts.retryOnFallbackError {
 			execErr.AddErr(fmt.Errorf("fallback function error: %w", err))
 
+			r.log(slog.LevelWarn, "Fallback failed", retryCount, err)
+
 			return res, execErr
 		}
 
@@ -76,6 +84,20 @@ func (r *retryableExecutor[T]) ExecWithFallback(execFn func() (T, error), fallba
 	}
 }
 
+// log logs the record of the failed execution via the logger of the options, if it is set. The first execution is
+// attempt 1.
+func (r *retryableExecutor[T]) log(level slog.Level, msg string, attempt uint, err error) {
+	if r.opts.logger != nil {
+		r.opts.logger.LogAttrs(
+			context.Background(),
+			level,
+			msg,
+			slog.Uint64("attempt", uint64(attempt)+1),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
 var (
 	ErrMissingExecFn     = errors.New("no exec function provided")
 	ErrMissingFallbackFn = errors.New("no fallback function provided")
@@ -98,6 +120,9 @@ type Opts struct {
 	// retryOnFallbackError specifies whether a retry will be done in the case of
 	// failure of the fallback function.
 	retryOnFallbackError bool
+
+	// logger logs the failed executions and fallbacks, nothing is logged if it is nil.
+	logger *slog.Logger
 }
 
 // NewDefaultExecOpts creates the default Opts.
@@ -139,6 +164,14 @@ func WithRetryOnFallBackError(retryOnFallbackError bool) OptsFn {
 	}
 }
 
+// WithLogger sets the logger that logs failed executions and fallbacks with the keys attempt and error, nothing is
+// logged by default.
+func WithLogger(logger *slog.Logger) OptsFn {
+	return func(opts *Opts) {
+		opts.logger = logger
+	}
+}
+
 // Error holds none or multiple errors that can happen during execution.
 type Error struct {
 	errs []error
@@ -1,11 +1,15 @@
 package exec
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRetryableExecutor_ExecWithFallback(t *testing.T) {
@@ -151,3 +155,38 @@ func TestRetryableExecutor_ExecWithFallback_FallBackFnError_NoRetry(t *testing.T
 	execErr := err.(*Error)
 	assert.Len(t, execErr.errs, 2)
 }
+
+func TestRetryableExecutor_ExecWithFallback_Logger(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	exec := NewRetryableExecutor[int](WithMaxRetryCount(1), WithLogger(logger))
+
+	_, err := exec.ExecWithFallback(func() (int, error) {
+		return 0, errors.New("boom")
+	}, func() error {
+		return nil
+	})
+	assert.NotNil(t, err)
+
+	var entries []map[string]interface{}
+
+	for dec := json.NewDecoder(&buf); dec.More(); {
+		var entry map[string]interface{}
+		require.NoError(t, dec.Decode(&entry))
+
+		entries = append(entries, entry)
+	}
+
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "DEBUG", entries[0]["level"])
+	assert.Equal(t, "Execution failed, running the fallback", entries[0]["msg"])
+	assert.Equal(t, float64(1), entries[0]["attempt"])
+	assert.Equal(t, "boom", entries[0]["error"])
+
+	assert.Equal(t, "WARN", entries[1]["level"])
+	assert.Equal(t, "Execution failed, no retries left", entries[1]["msg"])
+	assert.Equal(t, float64(2), entries[1]["attempt"])
+}
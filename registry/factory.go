@@ -3,7 +3,9 @@ package registry
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
+	"unsafe"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -29,6 +31,25 @@ type ErrorID struct {
 // ErrorRegistry maps an error name to its TypeDecoder.
 type ErrorRegistry map[ErrorID]*TypeDecoder
 
+// ModuleErrorName returns the name of the pallet error, e.g. Balances.InsufficientBalance, if the dispatch error is a
+// module error that is known to the registry.
+func (r ErrorRegistry) ModuleErrorName(err types.DispatchError) string {
+	if !err.IsModule {
+		return ""
+	}
+
+	errorID := ErrorID{
+		ModuleIndex: err.ModuleError.Index,
+		ErrorIndex:  [4]types.U8{err.ModuleError.Error[0]},
+	}
+
+	if errorDecoder, ok := r[errorID]; ok {
+		return errorDecoder.Name
+	}
+
+	return ""
+}
+
 // EventRegistry maps an event ID to its TypeDecoder.
 type EventRegistry map[types.EventID]*TypeDecoder
 
@@ -42,6 +63,67 @@ type factory struct {
 	fieldStorage          map[int64]FieldDecoder
 	recursiveFieldStorage map[int64]*RecursiveDecoder
 	fieldOverrides        []FieldOverride
+
+	// recursiveFieldOrigins holds where each recursive field of recursiveFieldStorage was first encountered, to
+	// report it if the field cannot be resolved.
+	recursiveFieldOrigins map[int64]recursiveFieldOrigin
+
+	// fieldPath holds the names of the pallet item and the fields that lead to the field that is being parsed.
+	fieldPath []string
+
+	// addedLookupIndices holds the lookup indices that were added to the storages since the current pallet started,
+	// to remove them if the pallet is skipped in best effort mode.
+	addedLookupIndices []int64
+
+	includePallets map[string]bool
+	excludePallets map[string]bool
+	bestEffort     bool
+	report         FactoryReport
+
+	logger *slog.Logger
+}
+
+// FactoryOptions configure a Factory created with NewFactoryWithOptions.
+type FactoryOptions struct {
+	// FieldOverrides override the default FieldDecoder of the types, see NewFactory.
+	FieldOverrides []FieldOverride
+
+	// IncludePallets limits the registries to the pallets with the given names, all pallets are included if it is
+	// empty. ExcludePallets leaves out the pallets with the given names. Types of other pallets that are referenced,
+	// e.g. the calls of all pallets by Utility.batch, are decoded regardless.
+	IncludePallets []string
+	ExcludePallets []string
+
+	// BestEffort skips the pallets whose types cannot be resolved instead of failing the creation of the registry,
+	// the skipped pallets are reported by ReportingFactory.Report.
+	BestEffort bool
+
+	// Logger logs every field decoder that is registered at debug level if it is set, see NewDebugFactory.
+	Logger *slog.Logger
+}
+
+// ReportingFactory is a Factory that reports the pallets it skipped.
+type ReportingFactory interface {
+	Factory
+
+	// Report returns the report of the registry that was created last.
+	Report() FactoryReport
+}
+
+// FactoryReport holds the pallets that were skipped while creating a registry in best effort mode.
+type FactoryReport struct {
+	SkippedPallets []SkippedPallet
+}
+
+// SkippedPallet is a pallet that was left out of a registry because its types could not be resolved.
+type SkippedPallet struct {
+	Name string
+	Err  error
+}
+
+type recursiveFieldOrigin struct {
+	typePath  string
+	fieldPath string
 }
 
 // NewFactory creates a new Factory using the provided overrides, if any.
@@ -52,9 +134,89 @@ func NewFactory(fieldOverrides ...FieldOverride) Factory {
 	return f
 }
 
+// NewDebugFactory creates a new Factory that logs every field decoder it registers at debug level, with the lookup
+// index and the path of its type and the path of the field that lead to it, to diagnose metadata incompatibilities.
+func NewDebugFactory(logger *slog.Logger, fieldOverrides ...FieldOverride) Factory {
+	f := &factory{}
+	f.fieldOverrides = fieldOverrides
+	f.logger = logger
+
+	return f
+}
+
+// NewFactoryWithOptions creates a new ReportingFactory that only includes the pallets that pass the filters of the
+// options in the registries it creates.
+func NewFactoryWithOptions(opts FactoryOptions) ReportingFactory {
+	f := &factory{
+		fieldOverrides: opts.FieldOverrides,
+		includePallets: make(map[string]bool),
+		excludePallets: make(map[string]bool),
+		bestEffort:     opts.BestEffort,
+		logger:         opts.Logger,
+	}
+
+	for _, name := range opts.IncludePallets {
+		f.includePallets[name] = true
+	}
+
+	for _, name := range opts.ExcludePallets {
+		f.excludePallets[name] = true
+	}
+
+	return f
+}
+
+// Report returns the report of the registry that was created last.
+func (f *factory) Report() FactoryReport {
+	return FactoryReport{SkippedPallets: append([]SkippedPallet(nil), f.report.SkippedPallets...)}
+}
+
+// includesPallet returns true if the pallet passes the filters of the factory.
+func (f *factory) includesPallet(name types.Text) bool {
+	if len(f.includePallets) > 0 && !f.includePallets[string(name)] {
+		return false
+	}
+
+	return !f.excludePallets[string(name)]
+}
+
+// createPalletEntries runs create for a pallet. In best effort mode, a pallet that fails is skipped and reported,
+// the field decoders it added are removed so that other pallets that refer to the same types build them again.
+func (f *factory) createPalletEntries(palletName types.Text, create func() error) error {
+	if !f.bestEffort {
+		return create()
+	}
+
+	f.addedLookupIndices = f.addedLookupIndices[:0]
+
+	err := create()
+
+	if err == nil {
+		if err = f.resolveRecursiveDecoders(); err != nil {
+			err = ErrRecursiveDecodersResolving.Wrap(err)
+		}
+	}
+
+	if err != nil {
+		for _, lookupIndex := range f.addedLookupIndices {
+			delete(f.fieldStorage, lookupIndex)
+			delete(f.recursiveFieldStorage, lookupIndex)
+			delete(f.recursiveFieldOrigins, lookupIndex)
+		}
+
+		f.report.SkippedPallets = append(f.report.SkippedPallets, SkippedPallet{Name: string(palletName), Err: err})
+	}
+
+	return nil
+}
+
 func (f *factory) resetStorages() {
 	f.fieldStorage = make(map[int64]FieldDecoder)
 	f.recursiveFieldStorage = make(map[int64]*RecursiveDecoder)
+	f.recursiveFieldOrigins = make(map[int64]recursiveFieldOrigin)
+	f.fieldPath = nil
+	f.addedLookupIndices = nil
+	f.report = FactoryReport{}
 
 	for _, fieldOverride := range f.fieldOverrides {
 		f.fieldStorage[fieldOverride.FieldLookupIndex] = fieldOverride.FieldDecoder
@@ -62,45 +224,61 @@ func (f *factory) resetStorages() {
 }
 
 // CreateErrorRegistry creates the registry that contains the types for errors.
-// nolint:dupl
+// nolint:dupl,funlen
 func (f *factory) CreateErrorRegistry(meta *types.Metadata) (ErrorRegistry, error) {
 	f.resetStorages()
 
 	errorRegistry := make(map[ErrorID]*TypeDecoder)
 
 	for _, mod := range meta.AsMetadataV14.Pallets {
-		if !mod.HasErrors {
+		if !mod.HasErrors || !f.includesPallet(mod.Name) {
 			continue
 		}
 
-		errorsType, ok := meta.AsMetadataV14.EfficientLookup[mod.Errors.Type.Int64()]
+		err := f.createPalletEntries(mod.Name, func() error {
+			errorsType, ok := meta.AsMetadataV14.EfficientLookup[mod.Errors.Type.Int64()]
 
-		if !ok {
-			return nil, ErrErrorsTypeNotFound.WithMsg("errors type '%d', module '%s'", mod.Errors.Type.Int64(), mod.Name)
-		}
+			if !ok {
+				return ErrErrorsTypeNotFound.WithMsg("errors type '%d', module '%s'", mod.Errors.Type.Int64(), mod.Name)
+			}
 
-		if !errorsType.Def.IsVariant {
-			return nil, ErrErrorsTypeNotVariant.WithMsg("errors type '%d', module '%s'", mod.Errors.Type.Int64(), mod.Name)
-		}
+			if !errorsType.Def.IsVariant {
+				return ErrErrorsTypeNotVariant.WithMsg("errors type '%d', module '%s'", mod.Errors.Type.Int64(), mod.Name)
+			}
 
-		for _, errorVariant := range errorsType.Def.Variant.Variants {
-			errorName := fmt.Sprintf("%s.%s", mod.Name, errorVariant.Name)
+			palletErrors := make(map[ErrorID]*TypeDecoder)
 
-			errorFields, err := f.getTypeFields(meta, errorVariant.Fields)
+			for _, errorVariant := range errorsType.Def.Variant.Variants {
+				errorName := fmt.Sprintf("%s.%s", mod.Name, errorVariant.Name)
 
-			if err != nil {
-				return nil, ErrErrorFieldsRetrieval.WithMsg(errorName).Wrap(err)
-			}
+				f.fieldPath = []string{errorName}
+
+				errorFields, err := f.getTypeFields(meta, errorVariant.Fields)
+
+				if err != nil {
+					return ErrErrorFieldsRetrieval.WithMsg(errorName).Wrap(err)
+				}
+
+				errorID := ErrorID{
+					ModuleIndex: mod.Index,
+					ErrorIndex:  [4]types.U8{errorVariant.Index},
+				}
 
-			errorID := ErrorID{
-				ModuleIndex: mod.Index,
-				ErrorIndex:  [4]types.U8{errorVariant.Index},
+				palletErrors[errorID] = &TypeDecoder{
+					Name:   errorName,
+					Fields: errorFields,
+				}
 			}
 
-			errorRegistry[errorID] = &TypeDecoder{
-				Name:   errorName,
-				Fields: errorFields,
+			for errorID, errorDecoder := range palletErrors {
+				errorRegistry[errorID] = errorDecoder
 			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -112,45 +290,61 @@ func (f *factory) CreateErrorRegistry(meta *types.Metadata) (ErrorRegistry, erro
 }
 
 // CreateCallRegistry creates the registry that contains the types for calls.
-// nolint:dupl
+// nolint:dupl,funlen
 func (f *factory) CreateCallRegistry(meta *types.Metadata) (CallRegistry, error) {
 	f.resetStorages()
 
 	callRegistry := make(map[types.CallIndex]*TypeDecoder)
 
 	for _, mod := range meta.AsMetadataV14.Pallets {
-		if !mod.HasCalls {
+		if !mod.HasCalls || !f.includesPallet(mod.Name) {
 			continue
 		}
 
-		callsType, ok := meta.AsMetadataV14.EfficientLookup[mod.Calls.Type.Int64()]
-
-		if !ok {
-			return nil, ErrCallsTypeNotFound.WithMsg("calls type '%d', module '%s'", mod.Calls.Type.Int64(), mod.Name)
-		}
+		err := f.createPalletEntries(mod.Name, func() error {
+			callsType, ok := meta.AsMetadataV14.EfficientLookup[mod.Calls.Type.Int64()]
 
-		if !callsType.Def.IsVariant {
-			return nil, ErrCallsTypeNotVariant.WithMsg("calls type '%d', module '%s'", mod.Calls.Type.Int64(), mod.Name)
-		}
+			if !ok {
+				return ErrCallsTypeNotFound.WithMsg("calls type '%d', module '%s'", mod.Calls.Type.Int64(), mod.Name)
+			}
 
-		for _, callVariant := range callsType.Def.Variant.Variants {
-			callIndex := types.CallIndex{
-				SectionIndex: uint8(mod.Index),
-				MethodIndex:  uint8(callVariant.Index),
+			if !callsType.Def.IsVariant {
+				return ErrCallsTypeNotVariant.WithMsg("calls type '%d', module '%s'", mod.Calls.Type.Int64(), mod.Name)
 			}
 
-			callName := fmt.Sprintf("%s.%s", mod.Name, callVariant.Name)
+			palletCalls := make(map[types.CallIndex]*TypeDecoder)
 
-			callFields, err := f.getTypeFields(meta, callVariant.Fields)
+			for _, callVariant := range callsType.Def.Variant.Variants {
+				callIndex := types.CallIndex{
+					SectionIndex: uint8(mod.Index),
+					MethodIndex:  uint8(callVariant.Index),
+				}
 
-			if err != nil {
-				return nil, ErrCallFieldsRetrieval.WithMsg(callName).Wrap(err)
+				callName := fmt.Sprintf("%s.%s", mod.Name, callVariant.Name)
+
+				f.fieldPath = []string{callName}
+
+				callFields, err := f.getTypeFields(meta, callVariant.Fields)
+
+				if err != nil {
+					return ErrCallFieldsRetrieval.WithMsg(callName).Wrap(err)
+				}
+
+				palletCalls[callIndex] = &TypeDecoder{
+					Name:   callName,
+					Fields: callFields,
+				}
 			}
 
-			callRegistry[callIndex] = &TypeDecoder{
-				Name:   callName,
-				Fields: callFields,
+			for callIndex, callDecoder := range palletCalls {
+				callRegistry[callIndex] = callDecoder
 			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -162,41 +356,58 @@ func (f *factory) CreateCallRegistry(meta *types.Metadata) (CallRegistry, error)
 }
 
 // CreateEventRegistry creates the registry that contains the types for events.
+// nolint:funlen
 func (f *factory) CreateEventRegistry(meta *types.Metadata) (EventRegistry, error) {
 	f.resetStorages()
 
 	eventRegistry := make(map[types.EventID]*TypeDecoder)
 
 	for _, mod := range meta.AsMetadataV14.Pallets {
-		if !mod.HasEvents {
+		if !mod.HasEvents || !f.includesPallet(mod.Name) {
 			continue
 		}
 
-		eventsType, ok := meta.AsMetadataV14.EfficientLookup[mod.Events.Type.Int64()]
+		err := f.createPalletEntries(mod.Name, func() error {
+			eventsType, ok := meta.AsMetadataV14.EfficientLookup[mod.Events.Type.Int64()]
 
-		if !ok {
-			return nil, ErrEventsTypeNotFound.WithMsg("events type '%d', module '%s'", mod.Events.Type.Int64(), mod.Name)
-		}
+			if !ok {
+				return ErrEventsTypeNotFound.WithMsg("events type '%d', module '%s'", mod.Events.Type.Int64(), mod.Name)
+			}
 
-		if !eventsType.Def.IsVariant {
-			return nil, ErrEventsTypeNotVariant.WithMsg("events type '%d', module '%s'", mod.Events.Type.Int64(), mod.Name)
-		}
+			if !eventsType.Def.IsVariant {
+				return ErrEventsTypeNotVariant.WithMsg("events type '%d', module '%s'", mod.Events.Type.Int64(), mod.Name)
+			}
 
-		for _, eventVariant := range eventsType.Def.Variant.Variants {
-			eventID := types.EventID{byte(mod.Index), byte(eventVariant.Index)}
+			palletEvents := make(map[types.EventID]*TypeDecoder)
 
-			eventName := fmt.Sprintf("%s.%s", mod.Name, eventVariant.Name)
+			for _, eventVariant := range eventsType.Def.Variant.Variants {
+				eventID := types.EventID{byte(mod.Index), byte(eventVariant.Index)}
 
-			eventFields, err := f.getTypeFields(meta, eventVariant.Fields)
+				eventName := fmt.Sprintf("%s.%s", mod.Name, eventVariant.Name)
 
-			if err != nil {
-				return nil, ErrEventFieldsRetrieval.WithMsg(eventName).Wrap(err)
+				f.fieldPath = []string{eventName}
+
+				eventFields, err := f.getTypeFields(meta, eventVariant.Fields)
+
+				if err != nil {
+					return ErrEventFieldsRetrieval.WithMsg(eventName).Wrap(err)
+				}
+
+				palletEvents[eventID] = &TypeDecoder{
+					Name:   eventName,
+					Fields: eventFields,
+				}
 			}
 
-			eventRegistry[eventID] = &TypeDecoder{
-				Name:   eventName,
-				Fields: eventFields,
+			for eventID, eventDecoder := range palletEvents {
+				eventRegistry[eventID] = eventDecoder
 			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -218,19 +429,25 @@ func (f *factory) resolveRecursiveDecoders() error {
 
 		fieldDecoder, ok := f.fieldStorage[recursiveFieldLookupIndex]
 
+		origin := f.recursiveFieldOrigins[recursiveFieldLookupIndex]
+
 		if !ok {
 			return ErrFieldDecoderForRecursiveFieldNotFound.
 				WithMsg(
-					"recursive field lookup index %d",
+					"recursive field lookup index %d, type '%s', field path '%s'",
 					recursiveFieldLookupIndex,
+					origin.typePath,
+					origin.fieldPath,
 				)
 		}
 
 		if _, ok := fieldDecoder.(*RecursiveDecoder); ok {
 			return ErrRecursiveFieldResolving.
 				WithMsg(
-					"recursive field lookup index %d",
+					"recursive field lookup index %d, type '%s', field path '%s'",
 					recursiveFieldLookupIndex,
+					origin.typePath,
+					origin.fieldPath,
 				)
 		}
 
@@ -245,41 +462,63 @@ func (f *factory) getTypeFields(meta *types.Metadata, fields []types.Si1Field) (
 	var typeFields []*Field
 
 	for _, field := range fields {
-		fieldType, ok := meta.AsMetadataV14.EfficientLookup[field.Type.Int64()]
+		typeField, err := f.getTypeField(meta, field)
 
-		if !ok {
-			return nil, ErrFieldTypeNotFound.WithMsg(string(field.Name))
+		if err != nil {
+			return nil, err
 		}
 
-		fieldName := getFullFieldName(field, fieldType)
+		typeFields = append(typeFields, typeField)
+	}
 
-		if storedFieldDecoder, ok := f.getStoredFieldDecoder(field.Type.Int64()); ok {
-			typeFields = append(typeFields, &Field{
-				Name:         fieldName,
-				FieldDecoder: storedFieldDecoder,
-				LookupIndex:  field.Type.Int64(),
-			})
-			continue
-		}
+	return typeFields, nil
+}
 
-		fieldTypeDef := fieldType.Def
+// getTypeField parses and returns the Field for a field of a type.
+func (f *factory) getTypeField(meta *types.Metadata, field types.Si1Field) (*Field, error) {
+	fieldType, ok := meta.AsMetadataV14.EfficientLookup[field.Type.Int64()]
 
-		fieldDecoder, err := f.getFieldDecoder(meta, fieldName, fieldTypeDef)
+	if !ok {
+		return nil, ErrFieldTypeNotFound.WithMsg(string(field.Name))
+	}
 
-		if err != nil {
-			return nil, ErrFieldDecoderRetrieval.WithMsg(fieldName).Wrap(err)
-		}
+	fieldName := getFullFieldName(field, fieldType)
 
-		f.fieldStorage[field.Type.Int64()] = fieldDecoder
+	f.pushFieldPath(getFieldName(field))
+	defer f.popFieldPath()
 
-		typeFields = append(typeFields, &Field{
+	if storedFieldDecoder, ok := f.getStoredFieldDecoder(field.Type.Int64(), fieldType); ok {
+		return &Field{
 			Name:         fieldName,
-			FieldDecoder: fieldDecoder,
+			FieldDecoder: storedFieldDecoder,
 			LookupIndex:  field.Type.Int64(),
-		})
+		}, nil
 	}
 
-	return typeFields, nil
+	fieldTypeDef := fieldType.Def
+
+	fieldDecoder, err := f.getFieldDecoder(meta, fieldName, fieldTypeDef)
+
+	if err != nil {
+		return nil, ErrFieldDecoderRetrieval.WithMsg(fieldName).Wrap(err)
+	}
+
+	f.fieldStorage[field.Type.Int64()] = fieldDecoder
+
+	if f.logger != nil {
+		f.logger.Debug(
+			"Field decoder registered",
+			slog.Int64("lookup_index", field.Type.Int64()),
+			slog.String("type", getFieldPath(fieldType)),
+			slog.String("field_path", f.getFieldPathString()),
+		)
+	}
+
+	return &Field{
+		Name:         fieldName,
+		FieldDecoder: fieldDecoder,
+		LookupIndex:  field.Type.Int64(),
+	}, nil
 }
 
 // getFieldDecoder returns the FieldDecoder based on the provided type definition.
@@ -367,8 +606,12 @@ func (f *factory) getVariantFieldDecoder(meta *types.Metadata, typeDef types.Si1
 			FieldName: variantFieldName,
 		}
 
+		f.pushFieldPath(variantFieldName)
+
 		fields, err := f.getTypeFields(meta, variant.Fields)
 
+		f.popFieldPath()
+
 		if err != nil {
 			return nil, ErrVariantTypeFieldsRetrieval.WithMsg("variant '%d'", variant.Index).Wrap(err)
 		}
@@ -508,8 +751,12 @@ func (f *factory) getTupleFieldDecoder(
 
 		tupleFieldName := fmt.Sprintf(tupleItemFieldNameFormat, i)
 
+		f.pushFieldPath(tupleFieldName)
+
 		itemFieldDecoder, err := f.getFieldDecoder(meta, tupleFieldName, itemTypeDef.Def)
 
+		f.popFieldPath()
+
 		if err != nil {
 			return nil, ErrTupleItemFieldDecoderRetrieval.Wrap(err)
 		}
@@ -609,10 +856,17 @@ func getPrimitiveDecoder(primitiveTypeDef types.Si0TypeDefPrimitive) (FieldDecod
 
 // getStoredFieldDecoder will attempt to return a FieldDecoder from storage,
 // and perform an extra check for recursive decoders.
-func (f *factory) getStoredFieldDecoder(fieldLookupIndex int64) (FieldDecoder, bool) {
+func (f *factory) getStoredFieldDecoder(fieldLookupIndex int64, fieldType *types.Si1Type) (FieldDecoder, bool) {
 	if ft, ok := f.fieldStorage[fieldLookupIndex]; ok {
 		if rt, ok := ft.(*RecursiveDecoder); ok {
 			f.recursiveFieldStorage[fieldLookupIndex] = rt
+
+			if _, ok := f.recursiveFieldOrigins[fieldLookupIndex]; !ok {
+				f.recursiveFieldOrigins[fieldLookupIndex] = recursiveFieldOrigin{
+					typePath:  getFieldPath(fieldType),
+					fieldPath: f.getFieldPathString(),
+				}
+			}
 		}
 
 		return ft, ok
@@ -621,15 +875,32 @@ func (f *factory) getStoredFieldDecoder(fieldLookupIndex int64) (FieldDecoder, b
 	// Ensure that a recursive type such as Xcm::TransferReserveAsset does not cause an infinite loop
 	// by adding the RecursiveDecoder the first time the field is encountered.
 	f.fieldStorage[fieldLookupIndex] = &RecursiveDecoder{}
+	f.addedLookupIndices = append(f.addedLookupIndices, fieldLookupIndex)
 
 	return nil, false
 }
 
 const (
-	fieldSeparator    = "."
-	lookupIndexFormat = "lookup_index_%d"
+	fieldSeparator     = "."
+	fieldPathSeparator = " → "
+	lookupIndexFormat  = "lookup_index_%d"
 )
 
+func (f *factory) pushFieldPath(name string) {
+	f.fieldPath = append(f.fieldPath, name)
+}
+
+func (f *factory) popFieldPath() {
+	if len(f.fieldPath) > 0 {
+		f.fieldPath = f.fieldPath[:len(f.fieldPath)-1]
+	}
+}
+
+// getFieldPathString returns the field path, e.g. "XcmPallet.execute → message → variant_item_3".
+func (f *factory) getFieldPathString() string {
+	return strings.Join(f.fieldPath, fieldPathSeparator)
+}
+
 func getFieldPath(fieldType *types.Si1Type) string {
 	var nameParts []string
 
@@ -731,29 +1002,70 @@ type SliceDecoder struct {
 }
 
 func (s *SliceDecoder) Decode(decoder *scale.Decoder) (any, error) {
+	// The slice grows with the decoded items, a corrupt length does not allocate more than the data holds.
+	slice := make([]any, 0)
+
+	err := s.DecodeStream(decoder, func(_ int, item any) error {
+		// The items honour the maximum allocation of the decoder, a corrupt length of items that are not read from
+		// the data, e.g. empty tuples, must not exhaust the memory.
+		if err := decoder.Allocate(1, uint64(unsafe.Sizeof(item))); err != nil {
+			return err
+		}
+
+		slice = append(slice, item)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return slice, nil
+}
+
+// DecodeStream decodes the items of the slice one by one and passes each item to fn as soon as it is decoded, instead
+// of building the whole slice. If fn returns scale.ErrStopStream, decoding stops without an error and the remaining
+// items are not read.
+func (s *SliceDecoder) DecodeStream(decoder *scale.Decoder, fn func(index int, item any) error) error {
 	if s.ItemDecoder == nil {
-		return nil, ErrSliceItemDecoderNotFound
+		return ErrSliceItemDecoderNotFound
 	}
 
 	sliceLen, err := decoder.DecodeUintCompact()
 
 	if err != nil {
-		return nil, ErrSliceLengthDecoding.Wrap(err)
+		return ErrSliceLengthDecoding.Wrap(err)
+	}
+
+	if !sliceLen.IsUint64() {
+		return ErrSliceLengthDecoding.WithMsg("length %s overflows u64", sliceLen)
 	}
 
-	slice := make([]any, 0, sliceLen.Uint64())
+	// Byte sequences honour the maximum bytes length of the decoder, like the strings decoded by ValueDecoder.
+	if _, ok := s.ItemDecoder.(*ValueDecoder[types.U8]); ok {
+		if err := decoder.CheckBytesLength(sliceLen.Uint64()); err != nil {
+			return ErrSliceLengthDecoding.Wrap(err)
+		}
+	}
 
 	for i := uint64(0); i < sliceLen.Uint64(); i++ {
 		item, err := s.ItemDecoder.Decode(decoder)
 
 		if err != nil {
-			return nil, ErrSliceItemDecoding.Wrap(err)
+			return ErrSliceItemDecoding.Wrap(err)
 		}
 
-		slice = append(slice, item)
+		if err := fn(int(i), item); err != nil {
+			if errors.Is(err, scale.ErrStopStream) {
+				return nil
+			}
+
+			return err
+		}
 	}
 
-	return slice, nil
+	return nil
 }
 
 // CompositeDecoder holds all the information required to decoder a struct/composite.
@@ -852,6 +1164,26 @@ func (t *TypeDecoder) Decode(decoder *scale.Decoder) (DecodedFields, error) {
 	return decodedFields, nil
 }
 
+// DecodeStream decodes a type whose only field is a sequence, e.g. the value of a storage entry of type Vec<T>, and
+// passes each item to fn as soon as it is decoded, see SliceDecoder.DecodeStream.
+func (t *TypeDecoder) DecodeStream(decoder *scale.Decoder, fn func(index int, item any) error) error {
+	if t == nil {
+		return ErrNilTypeDecoder
+	}
+
+	if len(t.Fields) != 1 || t.Fields[0] == nil {
+		return ErrTypeNotStreamable.WithMsg("%s has %d fields", t.Name, len(t.Fields))
+	}
+
+	sliceDecoder, ok := t.Fields[0].FieldDecoder.(*SliceDecoder)
+
+	if !ok {
+		return ErrTypeNotStreamable.WithMsg("%s is not a sequence", t.Name)
+	}
+
+	return sliceDecoder.DecodeStream(decoder, fn)
+}
+
 // Field represents one field of a TypeDecoder.
 type Field struct {
 	Name         string
@@ -0,0 +1,109 @@
+package registry
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// fuzzMaxAllocation limits the allocations of the fuzzed decoders, hostile lengths must not exceed it.
+const fuzzMaxAllocation = 1 << 20
+
+// newFuzzTypeDecoders returns the decoders of the calls, events and errors of representative runtimes, sorted by name
+// so that the fuzzer input selects the same decoder in every run.
+func newFuzzTypeDecoders(f *testing.F) []*TypeDecoder {
+	var decoders []*TypeDecoder
+
+	for _, metadataHex := range []string{
+		test.PolkadotMetadataHex,
+		test.StatemintMetaHex,
+		test.AcalaMetaHex,
+		test.MoonbeamMetaHex,
+		test.CentrifugeMetadataHex,
+	} {
+		var meta types.Metadata
+		if err := codec.DecodeFromHex(metadataHex, &meta); err != nil {
+			f.Fatal(err)
+		}
+
+		factory := NewFactory()
+
+		callRegistry, err := factory.CreateCallRegistry(&meta)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		eventRegistry, err := factory.CreateEventRegistry(&meta)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		errorRegistry, err := factory.CreateErrorRegistry(&meta)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		var runtimeDecoders []*TypeDecoder
+
+		for _, decoder := range callRegistry {
+			runtimeDecoders = append(runtimeDecoders, decoder)
+		}
+
+		for _, decoder := range eventRegistry {
+			runtimeDecoders = append(runtimeDecoders, decoder)
+		}
+
+		for _, decoder := range errorRegistry {
+			runtimeDecoders = append(runtimeDecoders, decoder)
+		}
+
+		sort.SliceStable(runtimeDecoders, func(i, j int) bool {
+			return runtimeDecoders[i].Name < runtimeDecoders[j].Name
+		})
+
+		decoders = append(decoders, runtimeDecoders...)
+	}
+
+	return decoders
+}
+
+// FuzzTypeDecoder_Decode asserts that malformed call, event and error fields do not panic or allocate more than the
+// maximum allocation. The first two bytes of the input select the decoder.
+func FuzzTypeDecoder_Decode(f *testing.F) {
+	decoders := newFuzzTypeDecoders(f)
+
+	f.Add([]byte{0, 0})
+	f.Add([]byte{0, 1, 0x03, 0xff, 0xff, 0xff, 0xff})
+	f.Add(append([]byte{0x10, 0x20}, bytes.Repeat([]byte{0xff}, 64)...))
+
+	for i := 0; i < 16; i++ {
+		f.Add(append([]byte{byte(i * 37), byte(i * 11)}, bytes.Repeat([]byte{byte(i)}, 128)...))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) < 2 {
+			return
+		}
+
+		decoder := decoders[(int(data[0])<<8|int(data[1]))%len(decoders)]
+
+		for _, scaleDecoder := range []*scale.Decoder{
+			scale.NewDecoder(bytes.NewReader(data[2:])),
+			scale.NewDecoderFromBytes(data[2:]),
+		} {
+			scaleDecoder.SetMaxAllocation(fuzzMaxAllocation)
+
+			fields, err := decoder.Decode(scaleDecoder)
+			if err == nil {
+				// Decoded fields are formatted in logs and errors.
+				_ = fmt.Sprint(fields)
+			}
+		}
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
 	"testing"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
@@ -216,6 +218,28 @@ func TestFactory_CreateErrorRegistry_GetTypeFieldsError(t *testing.T) {
 	assert.Empty(t, reg)
 }
 
+func TestErrorRegistry_ModuleErrorName(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	reg, err := NewFactory().CreateErrorRegistry(&meta)
+	assert.NoError(t, err)
+
+	moduleErr := types.DispatchError{
+		IsModule:    true,
+		ModuleError: types.ModuleError{Index: 5, Error: [4]types.U8{2}},
+	}
+
+	assert.Equal(t, "Balances.InsufficientBalance", reg.ModuleErrorName(moduleErr))
+
+	moduleErr.ModuleError.Index = 255
+	assert.Empty(t, reg.ModuleErrorName(moduleErr))
+
+	assert.Empty(t, reg.ModuleErrorName(types.DispatchError{IsBadOrigin: true}))
+}
+
 func TestFactory_CreateCallRegistry_WithLiveMetadata(t *testing.T) {
 	var tests = []struct {
 		Chain       string
@@ -322,6 +346,174 @@ func TestFactory_CreateCallRegistry_Overrides(t *testing.T) {
 	assert.Equal(t, f.fieldStorage[targetLookupIndex], &ValueDecoder[types.I64]{})
 }
 
+func TestFactory_CreateCallRegistry_RecursiveFieldResolvingError(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	// The decoder of the dest field of Balances.transfer is a recursive decoder that can never be resolved.
+	var destLookupIndex int64
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.Name == "Balances" {
+			transfer := meta.AsMetadataV14.EfficientLookup[pallet.Calls.Type.Int64()].Def.Variant.Variants[0]
+			destLookupIndex = transfer.Fields[0].Type.Int64()
+		}
+	}
+
+	f := NewFactory(FieldOverride{
+		FieldLookupIndex: destLookupIndex,
+		FieldDecoder:     &RecursiveDecoder{},
+	})
+
+	_, err = f.CreateCallRegistry(&meta)
+	assert.ErrorIs(t, err, ErrRecursiveDecodersResolving)
+	assert.ErrorIs(t, err, ErrRecursiveFieldResolving)
+	// The field is first encountered as the new field of Indices.transfer, within the call of Scheduler.schedule.
+	assert.ErrorContains(
+		t,
+		err,
+		fmt.Sprintf(
+			"recursive field lookup index %d, type 'sp_runtime.multiaddress.MultiAddress', field path '%s",
+			destLookupIndex,
+			"Scheduler.schedule → call → variant_item_5 → ",
+		),
+	)
+	assert.ErrorContains(t, err, "CallableCallFor<Indices, Runtime> → variant_item_1 → new'")
+}
+
+func TestFactory_NewDebugFactory(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	_, err = NewDebugFactory(logger).CreateCallRegistry(&meta)
+	assert.NoError(t, err)
+
+	assert.Contains(
+		t,
+		buf.String(),
+		`msg="Field decoder registered" lookup_index=`,
+	)
+	assert.Contains(
+		t,
+		buf.String(),
+		`lookup_index=12 type="" field_path="System.remark → remark"`,
+	)
+}
+
+func TestFactory_NewFactoryWithOptions_PalletFilter(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	f := NewFactoryWithOptions(FactoryOptions{IncludePallets: []string{"Balances", "Utility", "Unknown"}})
+
+	callRegistry, err := f.CreateCallRegistry(&meta)
+	assert.NoError(t, err)
+	assert.Empty(t, f.Report().SkippedPallets)
+
+	for _, callDecoder := range callRegistry {
+		assert.Regexp(t, `^(Balances|Utility)\.`, callDecoder.Name)
+	}
+
+	// The calls of excluded pallets are decoded if they are nested in the calls of included pallets.
+	remark, err := types.NewCall(&meta, "System.remark", []byte("hello"))
+	assert.NoError(t, err)
+
+	batch, err := types.NewCall(&meta, "Utility.batch", []types.Call{remark})
+	assert.NoError(t, err)
+
+	encodedArgs, err := codec.Encode(batch.Args)
+	assert.NoError(t, err)
+
+	batchDecoder, ok := callRegistry[batch.CallIndex]
+	assert.True(t, ok)
+
+	_, err = batchDecoder.Decode(scale.NewDecoder(bytes.NewReader(encodedArgs[1:])))
+	assert.NoError(t, err)
+
+	f = NewFactoryWithOptions(FactoryOptions{ExcludePallets: []string{"System"}})
+
+	eventRegistry, err := f.CreateEventRegistry(&meta)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, eventRegistry)
+
+	for _, eventDecoder := range eventRegistry {
+		assert.NotRegexp(t, `^System\.`, eventDecoder.Name)
+	}
+
+	errorRegistry, err := f.CreateErrorRegistry(&meta)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, errorRegistry)
+
+	for _, errorDecoder := range errorRegistry {
+		assert.NotRegexp(t, `^System\.`, errorDecoder.Name)
+	}
+}
+
+func TestFactory_NewFactoryWithOptions_BestEffort(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	// The account of MultiAddress refers to a type that does not exist, all pallets that refer to it fail.
+	for _, lookupType := range meta.AsMetadataV14.EfficientLookup {
+		if getFieldPath(lookupType) == "sp_runtime.multiaddress.MultiAddress" {
+			lookupType.Def.Variant.Variants[0].Fields[0].Type = types.NewSi1LookupTypeIDFromUInt(999999)
+		}
+	}
+
+	_, err = NewFactory().CreateCallRegistry(&meta)
+	assert.ErrorIs(t, err, ErrFieldTypeNotFound)
+
+	f := NewFactoryWithOptions(FactoryOptions{BestEffort: true})
+
+	callRegistry, err := f.CreateCallRegistry(&meta)
+	assert.NoError(t, err)
+
+	skipped := map[string]bool{}
+
+	for _, skippedPallet := range f.Report().SkippedPallets {
+		skipped[skippedPallet.Name] = true
+
+		// The partially built types of a skipped pallet do not leak into other pallets as unresolved recursive types.
+		assert.ErrorIs(t, skippedPallet.Err, ErrCallFieldsRetrieval, skippedPallet.Name)
+		assert.ErrorIs(t, skippedPallet.Err, ErrFieldTypeNotFound, skippedPallet.Name)
+	}
+
+	assert.True(t, skipped["Balances"])
+	assert.True(t, skipped["Utility"])
+	assert.False(t, skipped["System"])
+
+	registeredPallets := map[string]bool{}
+
+	for _, callDecoder := range callRegistry {
+		palletName, _, _ := strings.Cut(callDecoder.Name, ".")
+		registeredPallets[palletName] = true
+	}
+
+	// Every pallet with calls is either skipped or registered.
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.HasCalls && len(meta.AsMetadataV14.EfficientLookup[pallet.Calls.Type.Int64()].Def.Variant.Variants) > 0 {
+			assert.NotEqual(t, skipped[string(pallet.Name)], registeredPallets[string(pallet.Name)], pallet.Name)
+		}
+	}
+
+	// The report is reset for every registry.
+	_, err = f.CreateEventRegistry(&meta)
+	assert.NoError(t, err)
+	assert.Empty(t, f.Report().SkippedPallets)
+}
+
 func TestFactory_CreateCallRegistry_NoPalletWithCalls(t *testing.T) {
 	testMeta := &types.Metadata{
 		AsMetadataV14: types.MetadataV14{
@@ -2186,6 +2378,113 @@ func Test_TypeDecoder_FieldDecodingError(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func Test_SliceDecoder_DecodeStream(t *testing.T) {
+	encodedSlice, err := codec.Encode([]types.U16{1, 2, 3})
+	assert.NoError(t, err)
+
+	sliceDecoder := &SliceDecoder{ItemDecoder: &ValueDecoder[types.U16]{}}
+
+	var items []any
+
+	err = sliceDecoder.DecodeStream(scale.NewDecoder(bytes.NewReader(encodedSlice)), func(index int, item any) error {
+		assert.Equal(t, len(items), index)
+		items = append(items, item)
+
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{types.U16(1), types.U16(2), types.U16(3)}, items)
+
+	// Decoding stops early.
+	reader := bytes.NewReader(encodedSlice)
+
+	err = sliceDecoder.DecodeStream(scale.NewDecoder(reader), func(index int, item any) error {
+		return scale.ErrStopStream
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, reader.Len())
+
+	err = sliceDecoder.DecodeStream(scale.NewDecoder(bytes.NewReader(encodedSlice[:4])), func(int, any) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrSliceItemDecoding)
+
+	// A slice without items is not nil.
+	res, err := sliceDecoder.Decode(scale.NewDecoder(bytes.NewReader([]byte{0})))
+	assert.NoError(t, err)
+	assert.Equal(t, []any{}, res)
+}
+
+func Test_TypeDecoder_DecodeStream(t *testing.T) {
+	encodedSlice, err := codec.Encode([]types.U16{1, 2, 3})
+	assert.NoError(t, err)
+
+	typeDecoder := &TypeDecoder{
+		Name: "Staking.Nominations",
+		Fields: []*Field{
+			{FieldDecoder: &SliceDecoder{ItemDecoder: &ValueDecoder[types.U16]{}}},
+		},
+	}
+
+	var items []any
+
+	err = typeDecoder.DecodeStream(scale.NewDecoder(bytes.NewReader(encodedSlice)), func(index int, item any) error {
+		items = append(items, item)
+
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, items, 3)
+
+	notStreamable := []*TypeDecoder{
+		{Name: "no fields"},
+		{Name: "not a slice", Fields: []*Field{{FieldDecoder: &ValueDecoder[types.U16]{}}}},
+	}
+
+	for _, typeDecoder := range notStreamable {
+		err = typeDecoder.DecodeStream(scale.NewDecoder(bytes.NewReader(encodedSlice)), func(int, any) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrTypeNotStreamable)
+	}
+
+	var nilTypeDecoder *TypeDecoder
+
+	err = nilTypeDecoder.DecodeStream(scale.NewDecoder(bytes.NewReader(encodedSlice)), func(int, any) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrNilTypeDecoder)
+}
+
+func Test_MaxBytesLength(t *testing.T) {
+	encodedBytes := []byte{4 << 2, 1, 2, 3, 4}
+
+	bytesDecoder := &SliceDecoder{ItemDecoder: &ValueDecoder[types.U8]{}}
+
+	res, err := bytesDecoder.Decode(scale.NewDecoderWithMaxBytesLength(bytes.NewReader(encodedBytes), 4))
+	assert.NoError(t, err)
+	assert.Equal(t, []any{types.U8(1), types.U8(2), types.U8(3), types.U8(4)}, res)
+
+	res, err = bytesDecoder.Decode(scale.NewDecoderWithMaxBytesLength(bytes.NewReader(encodedBytes), 3))
+	assert.ErrorIs(t, err, ErrSliceLengthDecoding)
+	assert.ErrorIs(t, err, scale.ErrMaxBytesLengthExceeded)
+	assert.Nil(t, res)
+
+	// Slices of other items are not limited.
+	sliceDecoder := &SliceDecoder{ItemDecoder: &ValueDecoder[types.I8]{}}
+
+	res, err = sliceDecoder.Decode(scale.NewDecoderWithMaxBytesLength(bytes.NewReader(encodedBytes), 3))
+	assert.NoError(t, err)
+	assert.Len(t, res, 4)
+
+	stringDecoder := &ValueDecoder[string]{}
+
+	res, err = stringDecoder.Decode(scale.NewDecoderWithMaxBytesLength(bytes.NewReader(encodedBytes), 3))
+	assert.ErrorIs(t, err, ErrValueDecoding)
+	assert.ErrorIs(t, err, scale.ErrMaxBytesLengthExceeded)
+	assert.Nil(t, res)
+}
+
 func Test_ProcessDecodedFieldValue(t *testing.T) {
 	testData := []any{
 		types.U8(1),
@@ -0,0 +1,54 @@
+package registry
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// ByteRange is the range of bytes [Start, End) that a decoded value occupied in the input of the decoder.
+type ByteRange struct {
+	Start int64
+	End   int64
+}
+
+// Slice returns the bytes of the range, e.g. to archive the raw bytes of a decoded value or to decode it again.
+func (r ByteRange) Slice(data []byte) []byte {
+	return data[r.Start:r.End]
+}
+
+// Len returns the number of bytes of the range.
+func (r ByteRange) Len() int64 {
+	return r.End - r.Start
+}
+
+// DecodeWithOffsets decodes the type like Decode and also returns the byte range of each decoded field, relative to
+// the start of the input of the decoder. The decoder has to be created by one of the scale.Decoder constructors, see
+// scale.Decoder.Offset.
+func (t *TypeDecoder) DecodeWithOffsets(decoder *scale.Decoder) (DecodedFields, []ByteRange, error) {
+	if t == nil {
+		return nil, nil, ErrNilTypeDecoder
+	}
+
+	if decoder.Offset() < 0 {
+		return nil, nil, ErrOffsetsNotSupported.WithMsg("decoder without offset")
+	}
+
+	var (
+		decodedFields DecodedFields
+		offsets       []ByteRange
+	)
+
+	for _, field := range t.Fields {
+		start := decoder.Offset()
+
+		decodedField, err := field.Decode(decoder)
+
+		if err != nil {
+			return nil, nil, ErrTypeFieldDecoding.Wrap(err)
+		}
+
+		decodedFields = append(decodedFields, decodedField)
+		offsets = append(offsets, ByteRange{Start: start, End: decoder.Offset()})
+	}
+
+	return decodedFields, offsets, nil
+}
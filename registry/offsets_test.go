@@ -0,0 +1,48 @@
+package registry
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeDecoder_DecodeWithOffsets(t *testing.T) {
+	typeDecoder := &TypeDecoder{
+		Name: "test",
+		Fields: []*Field{
+			{Name: "u8", FieldDecoder: &ValueDecoder[types.U8]{}},
+			{Name: "u32", FieldDecoder: &ValueDecoder[types.U32]{}},
+		},
+	}
+
+	data := []byte{7, 1, 2, 0, 0, 0}
+
+	// The offsets are relative to the start of the input, not to the start of the type.
+	decoder := scale.NewDecoder(bytes.NewReader(data))
+
+	_, err := decoder.ReadOneByte()
+	require.NoError(t, err)
+
+	fields, offsets, err := typeDecoder.DecodeWithOffsets(decoder)
+	require.NoError(t, err)
+	assert.Equal(t, []ByteRange{{Start: 1, End: 2}, {Start: 2, End: 6}}, offsets)
+	assert.Equal(t, types.U8(1), fields[0].Value)
+	assert.Equal(t, types.U32(2), fields[1].Value)
+	assert.Equal(t, []byte{2, 0, 0, 0}, offsets[1].Slice(data))
+	assert.Equal(t, int64(4), offsets[1].Len())
+
+	_, _, err = typeDecoder.DecodeWithOffsets(scale.NewDecoder(bytes.NewReader(data[:3])))
+	assert.ErrorIs(t, err, ErrTypeFieldDecoding)
+
+	_, _, err = typeDecoder.DecodeWithOffsets(&scale.Decoder{})
+	assert.ErrorIs(t, err, ErrOffsetsNotSupported)
+
+	var nilDecoder *TypeDecoder
+
+	_, _, err = nilDecoder.DecodeWithOffsets(decoder)
+	assert.ErrorIs(t, err, ErrNilTypeDecoder)
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// ItemOffsets are the byte ranges of a decoded event or extrinsic and of its fields, relative to the decoded input.
+// Slicing the input with the range of the item returns its raw bytes, e.g. to archive and decode them again.
+type ItemOffsets struct {
+	Item   registry.ByteRange
+	Fields []registry.ByteRange
+}
+
+// DecodeOption configures the decoding of DecodeEventRecords, ParseEventRecords and DecodeExtrinsic.
+type DecodeOption func(opts *decodeOptions)
+
+type decodeOptions struct {
+	offsets       *[]ItemOffsets
+	strictCompact bool
+}
+
+// WithOffsets sets offsets to the byte ranges of the decoded items, in the order of the items. The offsets are only
+// set if decoding succeeds.
+func WithOffsets(offsets *[]ItemOffsets) DecodeOption {
+	return func(opts *decodeOptions) {
+		opts.offsets = offsets
+	}
+}
+
+// WithStrictCompact rejects compact-encoded integers that are not minimally encoded, see
+// scale.Decoder.SetStrictCompact.
+func WithStrictCompact() DecodeOption {
+	return func(opts *decodeOptions) {
+		opts.strictCompact = true
+	}
+}
+
+func newDecodeOptions(opts []DecodeOption) *decodeOptions {
+	decodeOpts := &decodeOptions{}
+
+	for _, opt := range opts {
+		opt(decodeOpts)
+	}
+
+	return decodeOpts
+}
+
+// newDecoder creates a decoder for the data with the options applied.
+func (o *decodeOptions) newDecoder(data []byte) *scale.Decoder {
+	decoder := scale.NewDecoder(bytes.NewReader(data))
+	decoder.SetStrictCompact(o.strictCompact)
+
+	return decoder
+}
@@ -2,11 +2,13 @@ package parser
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
 
 // Event holds all the information of a decoded storage event.
@@ -16,6 +18,8 @@ type Event struct {
 	EventID types.EventID
 	Phase   *types.Phase
 	Topics  []types.Hash
+	// Data holds the SCALE encoded fields of the event, e.g. for decoding them into a static type.
+	Data []byte
 }
 
 //go:generate mockery --name EventParser --structname EventParserMock --filename event_parser_mock.go --inpackage
@@ -35,61 +39,188 @@ func (f EventParserFn) ParseEvents(eventRegistry registry.EventRegistry, sd *typ
 
 // NewEventParser creates a new EventParser.
 func NewEventParser() EventParser {
-	// The EventParserFn provided here is decoding the total number of events from the storage data then attempts
-	// to decode all the information for each event.
 	return EventParserFn(func(eventRegistry registry.EventRegistry, sd *types.StorageDataRaw) ([]*Event, error) {
-		decoder := scale.NewDecoder(bytes.NewReader(*sd))
+		var events []*Event
+
+		err := StreamEvents(eventRegistry, sd, func(_ int, event *Event) error {
+			events = append(events, event)
 
-		eventsCount, err := decoder.DecodeUintCompact()
+			return nil
+		})
 
 		if err != nil {
-			return nil, ErrEventsCountDecoding.Wrap(err)
+			return nil, err
 		}
 
-		var events []*Event
+		return events, nil
+	})
+}
 
-		for i := uint64(0); i < eventsCount.Uint64(); i++ {
-			var phase types.Phase
+// TruncatedEventName is the name of the marker event that is appended by the parser of NewLimitedEventParser if a
+// block has more events than the limit.
+const TruncatedEventName = "EventParser.Truncated"
 
-			if err := decoder.Decode(&phase); err != nil {
-				return nil, ErrEventPhaseDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
+// NewLimitedEventParser creates an EventParser that decodes at most maxEvents events, e.g. to bound the memory used
+// for pathological blocks. If the storage data holds more events, the remaining ones are skipped and a marker event
+// named TruncatedEventName is appended, see Truncated.
+func NewLimitedEventParser(maxEvents int) EventParser {
+	return EventParserFn(func(eventRegistry registry.EventRegistry, sd *types.StorageDataRaw) ([]*Event, error) {
+		var events []*Event
+
+		err := StreamEvents(eventRegistry, sd, func(index int, event *Event) error {
+			if index == maxEvents {
+				return scale.ErrStopStream
 			}
 
-			var eventID types.EventID
+			events = append(events, event)
 
-			if err := decoder.Decode(&eventID); err != nil {
-				return nil, ErrEventIDDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
-			}
+			return nil
+		})
 
-			eventDecoder, ok := eventRegistry[eventID]
+		if err != nil {
+			return nil, err
+		}
 
-			if !ok {
-				return nil, ErrEventDecoderNotFound.WithMsg("event #%d with ID: %v", i, eventID)
-			}
+		if len(events) < maxEvents {
+			return events, nil
+		}
 
-			eventFields, err := eventDecoder.Decode(decoder)
+		total, err := scale.NewDecoder(bytes.NewReader(*sd)).DecodeUintCompact()
 
-			if err != nil {
-				return nil, ErrEventFieldsDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
-			}
+		if err != nil {
+			return nil, ErrEventsCountDecoding.Wrap(err)
+		}
 
-			var topics []types.Hash
+		if total.Uint64() <= uint64(maxEvents) {
+			return events, nil
+		}
+
+		data, err := codec.Encode(types.NewU32(uint32(total.Uint64())))
+
+		if err != nil {
+			return nil, err
+		}
 
-			if err := decoder.Decode(&topics); err != nil {
-				return nil, ErrEventTopicsDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
+		return append(events, &Event{Name: TruncatedEventName, Data: data}), nil
+	})
+}
+
+// Truncated returns the total number of events of the block if the events were truncated by the parser of
+// NewLimitedEventParser.
+func Truncated(events []*Event) (total uint32, ok bool) {
+	if len(events) == 0 || events[len(events)-1].Name != TruncatedEventName {
+		return 0, false
+	}
+
+	if err := codec.Decode(events[len(events)-1].Data, &total); err != nil {
+		return 0, false
+	}
+
+	return total, true
+}
+
+// StreamEvents decodes the events of the event storage data one by one and passes each event to fn as soon as it is
+// decoded, e.g. to filter the events of a busy block without keeping the others. If fn returns scale.ErrStopStream,
+// parsing stops without an error and the remaining events are not decoded.
+func StreamEvents(
+	eventRegistry registry.EventRegistry,
+	sd *types.StorageDataRaw,
+	fn func(index int, event *Event) error,
+) error {
+	return streamEvents(eventRegistry, sd, &decodeOptions{}, func(index int, event *Event, _ *ItemOffsets) error {
+		return fn(index, event)
+	})
+}
+
+// streamEvents implements StreamEvents, the offsets of the events are only passed to fn if they are requested by
+// the options.
+func streamEvents(
+	eventRegistry registry.EventRegistry,
+	sd *types.StorageDataRaw,
+	opts *decodeOptions,
+	fn func(index int, event *Event, offsets *ItemOffsets) error,
+) error {
+	decoder := opts.newDecoder(*sd)
+
+	// The total number of events is decoded first, followed by all the information of each event.
+	eventsCount, err := decoder.DecodeUintCompact()
+
+	if err != nil {
+		return ErrEventsCountDecoding.Wrap(err)
+	}
+
+	for i := uint64(0); i < eventsCount.Uint64(); i++ {
+		eventStart := decoder.Offset()
+
+		var phase types.Phase
+
+		if err := decoder.Decode(&phase); err != nil {
+			return ErrEventPhaseDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
+		}
+
+		var eventID types.EventID
+
+		if err := decoder.Decode(&eventID); err != nil {
+			return ErrEventIDDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
+		}
+
+		eventDecoder, ok := eventRegistry[eventID]
+
+		if !ok {
+			return ErrEventDecoderNotFound.WithMsg("event #%d with ID: %v", i, eventID)
+		}
+
+		fieldsStart := decoder.Offset()
+
+		var (
+			eventFields  registry.DecodedFields
+			fieldOffsets []registry.ByteRange
+		)
+
+		if opts.offsets != nil {
+			eventFields, fieldOffsets, err = eventDecoder.DecodeWithOffsets(decoder)
+		} else {
+			eventFields, err = eventDecoder.Decode(decoder)
+		}
+
+		if err != nil {
+			return ErrEventFieldsDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
+		}
+
+		fieldsEnd := decoder.Offset()
+
+		var topics []types.Hash
+
+		if err := decoder.Decode(&topics); err != nil {
+			return ErrEventTopicsDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
+		}
+
+		event := &Event{
+			Name:    eventDecoder.Name,
+			Fields:  eventFields,
+			EventID: eventID,
+			Phase:   &phase,
+			Topics:  topics,
+			Data:    (*sd)[fieldsStart:fieldsEnd],
+		}
+
+		var offsets *ItemOffsets
+
+		if opts.offsets != nil {
+			offsets = &ItemOffsets{
+				Item:   registry.ByteRange{Start: eventStart, End: decoder.Offset()},
+				Fields: fieldOffsets,
 			}
+		}
 
-			event := &Event{
-				Name:    eventDecoder.Name,
-				Fields:  eventFields,
-				EventID: eventID,
-				Phase:   &phase,
-				Topics:  topics,
+		if err := fn(int(i), event, offsets); err != nil {
+			if errors.Is(err, scale.ErrStopStream) {
+				return nil
 			}
 
-			events = append(events, event)
+			return err
 		}
+	}
 
-		return events, nil
-	})
+	return nil
 }
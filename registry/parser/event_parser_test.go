@@ -2,6 +2,7 @@ package parser
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
@@ -128,6 +129,14 @@ func TestEventParserFn_ParseEvents(t *testing.T) {
 		assertEventFieldInformationIsCorrect(t, testEvent.EventFields, res[i])
 		assert.Equal(t, testEvent.Phase, res[i].Phase)
 		assert.Equal(t, testEvent.Topics, res[i].Topics)
+
+		var data bytes.Buffer
+
+		for _, field := range testEvent.EventFields {
+			assert.NoError(t, scale.NewEncoder(&data).Encode(field.Value))
+		}
+
+		assert.Equal(t, data.Bytes(), res[i].Data)
 	}
 }
 
@@ -421,6 +430,113 @@ func TestEventParserFn_ParseEvents_TopicsDecodeError(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func TestStreamEvents(t *testing.T) {
+	testEvents := make([]testEvent, 0, 3)
+
+	for i := 0; i < 3; i++ {
+		testEvents = append(testEvents, testEvent{
+			Name: fmt.Sprintf("test_event_%d", i),
+			Phase: &types.Phase{
+				IsApplyExtrinsic: true,
+				AsApplyExtrinsic: uint32(i),
+			},
+			EventID: types.EventID([2]byte{0, byte(i)}),
+			EventFields: []testField{
+				{
+					Name:  "u32_value",
+					Value: types.NewU32(uint32(i)),
+				},
+			},
+		})
+	}
+
+	encodedEvents, reg, err := getEventParsingTestData(testEvents)
+	assert.NoError(t, err)
+
+	// Only the matching events are kept.
+	var filtered []*Event
+
+	err = StreamEvents(reg, encodedEvents, func(index int, event *Event) error {
+		assert.Equal(t, testEvents[index].Name, event.Name)
+
+		if index != 1 {
+			filtered = append(filtered, event)
+		}
+
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, filtered, 2)
+	assert.Equal(t, "test_event_0", filtered[0].Name)
+	assert.Equal(t, "test_event_2", filtered[1].Name)
+
+	// Parsing stops early.
+	var count int
+
+	err = StreamEvents(reg, encodedEvents, func(index int, event *Event) error {
+		count++
+
+		return scale.ErrStopStream
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	errCallback := errors.New("callback")
+
+	err = StreamEvents(reg, encodedEvents, func(index int, event *Event) error {
+		return errCallback
+	})
+	assert.ErrorIs(t, err, errCallback)
+}
+
+func TestNewLimitedEventParser(t *testing.T) {
+	testEvents := make([]testEvent, 0, 3)
+
+	for i := 0; i < 3; i++ {
+		testEvents = append(testEvents, testEvent{
+			Name: fmt.Sprintf("test_event_%d", i),
+			Phase: &types.Phase{
+				IsApplyExtrinsic: true,
+				AsApplyExtrinsic: uint32(i),
+			},
+			EventID: types.EventID([2]byte{0, byte(i)}),
+			EventFields: []testField{
+				{
+					Name:  "u32_value",
+					Value: types.NewU32(uint32(i)),
+				},
+			},
+		})
+	}
+
+	encodedEvents, reg, err := getEventParsingTestData(testEvents)
+	assert.NoError(t, err)
+
+	// The events are truncated and a marker with the total number of events is appended.
+	events, err := NewLimitedEventParser(2).ParseEvents(reg, encodedEvents)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, "test_event_1", events[1].Name)
+	assert.Equal(t, TruncatedEventName, events[2].Name)
+
+	total, ok := Truncated(events)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(3), total)
+
+	// Blocks within the limit are not truncated.
+	for _, maxEvents := range []int{3, 4} {
+		events, err = NewLimitedEventParser(maxEvents).ParseEvents(reg, encodedEvents)
+		assert.NoError(t, err)
+		assert.Len(t, events, 3)
+
+		_, ok = Truncated(events)
+		assert.False(t, ok)
+	}
+
+	_, err = NewLimitedEventParser(2).ParseEvents(reg, &types.StorageDataRaw{})
+	assert.ErrorIs(t, err, ErrEventsCountDecoding)
+}
+
 func assertEventFieldInformationIsCorrect(t *testing.T, testFields []testField, event *Event) {
 	for testFieldIndex, testField := range testFields {
 		assert.Equal(t, testField.Value, event.Fields[testFieldIndex].Value)
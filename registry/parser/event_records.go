@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// DecodeEventRecords decodes the raw event records of a block, e.g. the storage data of System.Events, using the
+// event types of the metadata. Unlike types.EventRecordsRaw.DecodeEventRecords, it does not require a struct that
+// holds the events of the runtime, so it decodes the events of custom pallets as well. The metadata has to be V14 or
+// later.
+//
+// Code that decodes the events of many blocks should create the event registry once and use ParseEventRecords
+// instead. The options can request the byte ranges of the events and their fields, see WithOffsets.
+func DecodeEventRecords(meta *types.Metadata, raw types.EventRecordsRaw, opts ...DecodeOption) ([]*Event, error) {
+	if meta.Version < 14 {
+		return nil, ErrMetadataNotSupported.WithMsg("metadata version %d", meta.Version)
+	}
+
+	eventRegistry, err := registry.NewFactory().CreateEventRegistry(meta)
+	if err != nil {
+		return nil, ErrEventRegistryCreation.Wrap(err)
+	}
+
+	return ParseEventRecords(eventRegistry, raw, opts...)
+}
+
+// ParseEventRecords decodes the raw event records of a block using the event registry, see DecodeEventRecords.
+func ParseEventRecords(
+	eventRegistry registry.EventRegistry,
+	raw types.EventRecordsRaw,
+	opts ...DecodeOption,
+) ([]*Event, error) {
+	decodeOpts := newDecodeOptions(opts)
+	sd := types.StorageDataRaw(raw)
+
+	var (
+		events  []*Event
+		offsets []ItemOffsets
+	)
+
+	err := streamEvents(eventRegistry, &sd, decodeOpts, func(_ int, event *Event, eventOffsets *ItemOffsets) error {
+		events = append(events, event)
+
+		if eventOffsets != nil {
+			offsets = append(offsets, *eventOffsets)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if decodeOpts.offsets != nil {
+		*decodeOpts.offsets = offsets
+	}
+
+	return events, nil
+}
@@ -0,0 +1,250 @@
+package parser
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getEventID(t *testing.T, eventRegistry registry.EventRegistry, name string) types.EventID {
+	for eventID, eventDecoder := range eventRegistry {
+		if eventDecoder.Name == name {
+			return eventID
+		}
+	}
+
+	t.Fatalf("event %s not found", name)
+
+	return types.EventID{}
+}
+
+func encodeTestEventRecord(t *testing.T, phase types.Phase, eventID types.EventID, topics []types.Hash, fields ...any) (
+	[]byte,
+	[]byte,
+) {
+	var data []byte
+
+	for _, field := range fields {
+		b, err := codec.Encode(field)
+		require.NoError(t, err)
+
+		data = append(data, b...)
+	}
+
+	encodedPhase, err := codec.Encode(phase)
+	require.NoError(t, err)
+
+	encodedTopics, err := codec.Encode(topics)
+	require.NoError(t, err)
+
+	record := append(append(append(encodedPhase, eventID[:]...), data...), encodedTopics...)
+
+	return record, data
+}
+
+func TestDecodeEventRecords(t *testing.T) {
+	tests := []struct {
+		name        string
+		metadataHex string
+		sender      any
+	}{
+		{"polkadot", test.PolkadotMetadataHex, types.AccountID{1, 2, 3}},
+		{"statemint", test.StatemintMetaHex, types.AccountID{1, 2, 3}},
+		{"acala", test.AcalaMetaHex, types.AccountID{1, 2, 3}},
+		{"moonbeam", test.MoonbeamMetaHex, [20]byte{1, 2, 3}},
+		{"centrifuge", test.CentrifugeMetadataHex, types.AccountID{1, 2, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var meta types.Metadata
+
+			err := codec.DecodeFromHex(test.metadataHex, &meta)
+			require.NoError(t, err)
+
+			eventRegistry, err := registry.NewFactory().CreateEventRegistry(&meta)
+			require.NoError(t, err)
+
+			remarkedID := getEventID(t, eventRegistry, "System.Remarked")
+			codeUpdatedID := getEventID(t, eventRegistry, "System.CodeUpdated")
+
+			applyExtrinsic := types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 2}
+			finalization := types.Phase{IsFinalization: true}
+			topics := []types.Hash{{4, 5, 6}, {7, 8, 9}}
+
+			remarked, remarkedData := encodeTestEventRecord(
+				t,
+				applyExtrinsic,
+				remarkedID,
+				topics,
+				test.sender,
+				types.Hash{1, 1, 1},
+			)
+			codeUpdated, _ := encodeTestEventRecord(t, finalization, codeUpdatedID, nil)
+
+			raw := types.EventRecordsRaw(append(append([]byte{2 << 2}, remarked...), codeUpdated...))
+
+			events, err := DecodeEventRecords(&meta, raw)
+			require.NoError(t, err)
+			require.Len(t, events, 2)
+
+			assert.Equal(t, "System.Remarked", events[0].Name)
+			assert.Equal(t, remarkedID, events[0].EventID)
+			assert.Equal(t, &applyExtrinsic, events[0].Phase)
+			assert.Equal(t, topics, events[0].Topics)
+			assert.Equal(t, remarkedData, events[0].Data)
+			assert.Len(t, events[0].Fields, 2)
+
+			assert.Equal(t, "System.CodeUpdated", events[1].Name)
+			assert.Equal(t, &finalization, events[1].Phase)
+			assert.Empty(t, events[1].Topics)
+			assert.Empty(t, events[1].Fields)
+
+			// Decoding with a registry that is created once gives the same events.
+			parsed, err := ParseEventRecords(eventRegistry, raw)
+			require.NoError(t, err)
+			assert.Equal(t, events, parsed)
+		})
+	}
+}
+
+func TestDecodeEventRecords_StaticFallback(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
+	require.NoError(t, err)
+
+	eventRegistry, err := registry.NewFactory().CreateEventRegistry(&meta)
+	require.NoError(t, err)
+
+	// Find an event of a custom pallet that types.EventRecords does not hold.
+	var names []string
+
+	for _, eventDecoder := range eventRegistry {
+		fieldName := strings.ReplaceAll(eventDecoder.Name, ".", "_")
+
+		if _, ok := reflect.TypeOf(types.EventRecords{}).FieldByName(fieldName); !ok && len(eventDecoder.Fields) == 0 {
+			names = append(names, eventDecoder.Name)
+		}
+	}
+
+	require.NotEmpty(t, names)
+	sort.Strings(names)
+
+	record, _ := encodeTestEventRecord(t, types.Phase{IsInitialization: true}, getEventID(t, eventRegistry, names[0]), nil)
+	raw := types.EventRecordsRaw(append([]byte{1 << 2}, record...))
+
+	var records types.EventRecords
+
+	err = raw.DecodeEventRecords(&meta, &records)
+	assert.ErrorContains(t, err, "use parser.DecodeEventRecords")
+
+	events, err := DecodeEventRecords(&meta, raw)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, names[0], events[0].Name)
+}
+
+func TestDecodeEventRecords_Errors(t *testing.T) {
+	_, err := DecodeEventRecords(types.ExamplaryMetadataV13, types.EventRecordsRaw{0})
+	assert.ErrorIs(t, err, ErrMetadataNotSupported)
+
+	var meta types.Metadata
+
+	err = codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	_, err = DecodeEventRecords(&meta, types.EventRecordsRaw{1 << 2, 0x00})
+	assert.ErrorIs(t, err, ErrEventPhaseDecoding)
+
+	_, err = DecodeEventRecords(&meta, types.EventRecordsRaw{1 << 2, 0x02, 0xff, 0xff})
+	assert.ErrorIs(t, err, ErrEventDecoderNotFound)
+}
+
+func TestParseEventRecords_WithOffsets(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	eventRegistry, err := registry.NewFactory().CreateEventRegistry(&meta)
+	require.NoError(t, err)
+
+	remarkedID := getEventID(t, eventRegistry, "System.Remarked")
+	codeUpdatedID := getEventID(t, eventRegistry, "System.CodeUpdated")
+
+	remarked, _ := encodeTestEventRecord(
+		t,
+		types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 2},
+		remarkedID,
+		[]types.Hash{{4, 5, 6}},
+		types.AccountID{1, 2, 3},
+		types.Hash{1, 1, 1},
+	)
+	codeUpdated, _ := encodeTestEventRecord(t, types.Phase{IsFinalization: true}, codeUpdatedID, nil)
+
+	raw := types.EventRecordsRaw(append(append([]byte{2 << 2}, remarked...), codeUpdated...))
+
+	for _, opts := range [][]DecodeOption{nil, {WithStrictCompact()}} {
+		var offsets []ItemOffsets
+
+		events, err := ParseEventRecords(eventRegistry, raw, append(opts, WithOffsets(&offsets))...)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		// The offsets are relative to the raw records, the fields of the first event follow the count of the events,
+		// the phase and the event ID.
+		remarkedFieldsStart := int64(1 + 5 + 2)
+		assert.Equal(t, []ItemOffsets{
+			{
+				Item: registry.ByteRange{Start: 1, End: int64(1 + len(remarked))},
+				Fields: []registry.ByteRange{
+					{Start: remarkedFieldsStart, End: remarkedFieldsStart + 32},
+					{Start: remarkedFieldsStart + 32, End: remarkedFieldsStart + 64},
+				},
+			},
+			{
+				Item: registry.ByteRange{Start: int64(1 + len(remarked)), End: int64(len(raw))},
+			},
+		}, offsets)
+
+		for i, event := range events {
+			// Every event decodes again from its own bytes.
+			item := offsets[i].Item.Slice(raw)
+
+			reparsed, err := ParseEventRecords(eventRegistry, append([]byte{1 << 2}, item...), opts...)
+			require.NoError(t, err)
+			assert.Equal(t, []*Event{event}, reparsed)
+
+			for j, fieldOffsets := range offsets[i].Fields {
+				field, err := eventRegistry[event.EventID].Fields[j].Decode(
+					scale.NewDecoder(bytes.NewReader(fieldOffsets.Slice(raw))),
+				)
+				require.NoError(t, err)
+				assert.Equal(t, event.Fields[j], field)
+			}
+		}
+	}
+
+	// Non-canonical compact integers are only rejected in strict mode.
+	nonCanonical := types.EventRecordsRaw(append([]byte{2<<2 | 1, 0}, raw[1:]...))
+
+	var offsets []ItemOffsets
+
+	_, err = ParseEventRecords(eventRegistry, nonCanonical, WithOffsets(&offsets))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), offsets[0].Item.Start)
+
+	_, err = ParseEventRecords(eventRegistry, nonCanonical, WithStrictCompact())
+	assert.ErrorIs(t, err, ErrEventsCountDecoding)
+}
@@ -31,6 +31,8 @@ type Extrinsic[A, S, P any] struct {
 	CallIndex  types.CallIndex
 	Version    byte
 	Signature  generic.GenericExtrinsicSignature[A, S, P]
+	// Extensions are the transaction extensions of a general transaction, nil for all other extrinsics.
+	Extensions generic.GenericExtrinsicExtensions[P]
 }
 
 //nolint:lll
@@ -90,6 +92,7 @@ func NewExtrinsicParser[A, S, P any]() ExtrinsicParser[A, S, P] {
 				CallIndex:  callIndex,
 				Version:    extrinsic.GetVersion(),
 				Signature:  extrinsic.GetSignature(),
+				Extensions: extrinsic.GetExtensions(),
 			}
 
 			extrinsics = append(extrinsics, call)
@@ -99,6 +102,75 @@ func NewExtrinsicParser[A, S, P any]() ExtrinsicParser[A, S, P] {
 	})
 }
 
+// DecodeExtrinsic decodes a SCALE encoded extrinsic with its length prefix, e.g. one of the extrinsics of a block
+// returned by chain_getBlock, and its call fields. The options can request the byte ranges of the extrinsic and of
+// its call fields, see WithOffsets.
+func DecodeExtrinsic[A, S, P any](
+	callRegistry registry.CallRegistry,
+	data []byte,
+	opts ...DecodeOption,
+) (*Extrinsic[A, S, P], error) {
+	decodeOpts := newDecodeOptions(opts)
+	decoder := decodeOpts.newDecoder(data)
+
+	var extrinsic generic.Extrinsic[A, S, P]
+
+	if err := decoder.Decode(&extrinsic); err != nil {
+		return nil, ErrExtrinsicDecoding.Wrap(err)
+	}
+
+	callIndex := extrinsic.Method.CallIndex
+
+	callDecoder, ok := callRegistry[callIndex]
+
+	if !ok {
+		return nil, ErrCallDecoderNotFound.WithMsg("call index %d.%d", callIndex.SectionIndex, callIndex.MethodIndex)
+	}
+
+	// The args are the remaining bytes of the extrinsic.
+	end := decoder.Offset()
+	argsStart := end - int64(len(extrinsic.Method.Args))
+
+	var (
+		callFields   registry.DecodedFields
+		fieldOffsets []registry.ByteRange
+		err          error
+	)
+
+	argsDecoder := decodeOpts.newDecoder(extrinsic.Method.Args)
+
+	if decodeOpts.offsets != nil {
+		callFields, fieldOffsets, err = callDecoder.DecodeWithOffsets(argsDecoder)
+	} else {
+		callFields, err = callDecoder.Decode(argsDecoder)
+	}
+
+	if err != nil {
+		return nil, ErrCallFieldsDecoding.Wrap(err)
+	}
+
+	if decodeOpts.offsets != nil {
+		for i := range fieldOffsets {
+			fieldOffsets[i].Start += argsStart
+			fieldOffsets[i].End += argsStart
+		}
+
+		*decodeOpts.offsets = []ItemOffsets{{
+			Item:   registry.ByteRange{Start: 0, End: end},
+			Fields: fieldOffsets,
+		}}
+	}
+
+	return &Extrinsic[A, S, P]{
+		Name:       callDecoder.Name,
+		CallFields: callFields,
+		CallIndex:  callIndex,
+		Version:    extrinsic.GetVersion(),
+		Signature:  extrinsic.GetSignature(),
+		Extensions: extrinsic.GetExtensions(),
+	}, nil
+}
+
 // DefaultExtrinsicParser is the ExtrinsicParser interface with defaults for the generic types:
 //
 // Address - types.MultiAddress
@@ -9,9 +9,13 @@ import (
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/generic"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExtrinsicParserFn_ParseExtrinsics(t *testing.T) {
@@ -342,3 +346,87 @@ func (t testExtrinsic[A, S, P]) Encode() ([]byte, error) {
 
 	return buf.Bytes(), nil
 }
+
+func TestDecodeExtrinsic(t *testing.T) {
+	var meta types.Metadata
+
+	require.NoError(t, codec.DecodeFromHex(test.PolkadotMetadataHex, &meta))
+
+	callRegistry, err := registry.NewFactory().CreateCallRegistry(&meta)
+	require.NoError(t, err)
+
+	dest, err := types.NewMultiAddressFromAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	call, err := types.NewCall(&meta, "Balances.transfer_keep_alive", dest, types.NewUCompactFromUInt(12345))
+	require.NoError(t, err)
+
+	signed := types.NewExtrinsic(call)
+	require.NoError(t, signed.Sign(signature.TestKeyringPairAlice, types.SignatureOptions{
+		Era:   types.ExtrinsicEra{IsImmortalEra: true},
+		Nonce: types.NewUCompactFromUInt(1),
+		Tip:   types.NewUCompactFromUInt(0),
+	}))
+
+	for _, xt := range []types.Extrinsic{types.NewExtrinsic(call), signed} {
+		data, err := codec.Encode(xt)
+		require.NoError(t, err)
+
+		for _, opts := range [][]DecodeOption{nil, {WithStrictCompact()}} {
+			var offsets []ItemOffsets
+
+			extrinsic, err := DecodeExtrinsic[types.MultiAddress, types.MultiSignature, generic.DefaultPaymentFields](
+				callRegistry,
+				data,
+				append(opts, WithOffsets(&offsets))...,
+			)
+			require.NoError(t, err)
+			assert.Equal(t, "Balances.transfer_keep_alive", extrinsic.Name)
+			assert.Equal(t, call.CallIndex, extrinsic.CallIndex)
+			assert.Equal(t, xt.IsSigned(), extrinsic.Signature.(*generic.ExtrinsicSignature[
+				types.MultiAddress,
+				types.MultiSignature,
+				generic.DefaultPaymentFields,
+			]) != nil)
+
+			// The call args are the last bytes of the extrinsic, the amount is compact encoded in 2 bytes.
+			argsStart := int64(len(data) - len(call.Args))
+			assert.Equal(t, []ItemOffsets{{
+				Item: registry.ByteRange{Start: 0, End: int64(len(data))},
+				Fields: []registry.ByteRange{
+					{Start: argsStart, End: argsStart + 33},
+					{Start: argsStart + 33, End: argsStart + 35},
+				},
+			}}, offsets)
+
+			for i, fieldOffsets := range offsets[0].Fields {
+				field, err := callRegistry[call.CallIndex].Fields[i].Decode(
+					scale.NewDecoder(bytes.NewReader(fieldOffsets.Slice(data))),
+				)
+				require.NoError(t, err)
+				assert.Equal(t, extrinsic.CallFields[i], field)
+			}
+		}
+	}
+
+	_, err = DecodeExtrinsic[types.MultiAddress, types.MultiSignature, generic.DefaultPaymentFields](
+		callRegistry,
+		[]byte{4, 0xff},
+	)
+	assert.ErrorIs(t, err, ErrExtrinsicDecoding)
+
+	_, err = DecodeExtrinsic[types.MultiAddress, types.MultiSignature, generic.DefaultPaymentFields](
+		callRegistry,
+		[]byte{12, 4, 0xff, 0xff},
+	)
+	assert.ErrorIs(t, err, ErrCallDecoderNotFound)
+
+	data, err := codec.Encode(types.NewExtrinsic(call))
+	require.NoError(t, err)
+
+	_, err = DecodeExtrinsic[types.MultiAddress, types.MultiSignature, generic.DefaultPaymentFields](
+		callRegistry,
+		data[:len(data)-1],
+	)
+	assert.ErrorIs(t, err, ErrCallFieldsDecoding)
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/generic"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+func getFuzzMetadata(f *testing.F) *types.Metadata {
+	var meta types.Metadata
+
+	if err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta); err != nil {
+		f.Fatal(err)
+	}
+
+	return &meta
+}
+
+// FuzzEventParser_ParseEvents asserts that malformed event storage does not panic.
+func FuzzEventParser_ParseEvents(f *testing.F) {
+	eventRegistry, err := registry.NewFactory().CreateEventRegistry(getFuzzMetadata(f))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	// A System.ExtrinsicSuccess event in the ApplyExtrinsic phase, and one with a hostile topics length.
+	f.Add([]byte{0x04, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})
+	f.Add([]byte{0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte{0x03, 0xff, 0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		sd := types.StorageDataRaw(data)
+
+		_, _ = NewEventParser().ParseEvents(eventRegistry, &sd)
+
+		_ = StreamEvents(eventRegistry, &sd, func(_ int, _ *Event) error {
+			return nil
+		})
+	})
+}
+
+// FuzzExtrinsicParser_ParseExtrinsics asserts that malformed extrinsics do not panic.
+func FuzzExtrinsicParser_ParseExtrinsics(f *testing.F) {
+	meta := getFuzzMetadata(f)
+
+	callRegistry, err := registry.NewFactory().CreateCallRegistry(meta)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	call, err := types.NewCall(meta, "System.remark", types.NewBytes([]byte("remark")))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	encodedCall, err := codec.Encode(call)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	encodedExtrinsic, err := codec.Encode(append([]byte{types.ExtrinsicVersion4}, encodedCall...))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(encodedExtrinsic)
+	f.Add([]byte{0x00, 0x84, 0x00})
+	f.Add([]byte{0x00, 0x04, 0x00, 0x00, 0x03, 0xff, 0xff, 0xff, 0xff})
+
+	parser := NewDefaultExtrinsicParser()
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var extrinsic generic.Extrinsic[types.MultiAddress, types.MultiSignature, generic.DefaultPaymentFields]
+
+		decoder := scale.NewDecoderFromBytes(data)
+		decoder.SetMaxAllocation(1 << 20)
+
+		if err := decoder.Decode(&extrinsic); err != nil {
+			return
+		}
+
+		block := &generic.DefaultGenericSignedBlock{
+			Block: &generic.Block[types.MultiAddress, types.MultiSignature, generic.DefaultPaymentFields]{
+				Extrinsics: []*generic.Extrinsic[
+					types.MultiAddress,
+					types.MultiSignature,
+					generic.DefaultPaymentFields,
+				]{&extrinsic},
+			},
+		}
+
+		_, _ = parser.ParseExtrinsics(callRegistry, block)
+	})
+}
@@ -0,0 +1,160 @@
+package retriever
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	defaultEventCacheMaxEntries = 128
+
+	// eventSizeOverhead is the estimated memory of a decoded event without its data, i.e. of the event, its phase and
+	// its fields.
+	eventSizeOverhead = 256
+)
+
+// EventCacheOptions configure the cache of an EventRetriever created via NewCachedEventRetriever.
+type EventCacheOptions struct {
+	// MaxEntries is the number of blocks whose events are cached, it defaults to 128.
+	MaxEntries int
+
+	// MaxSize caps the estimated memory of the cached events in bytes, no cap is applied if it is 0. The events of a
+	// block count with a fixed overhead per event plus twice the size of their encoded data and topics.
+	MaxSize int
+
+	// OnHit and OnMiss, if set, are called for every block whose events are requested via GetEvents, e.g. to record
+	// metrics. Requests that wait for a concurrent retrieval of the same block count as hits. They must not block.
+	OnHit  func(blockHash types.Hash)
+	OnMiss func(blockHash types.Hash)
+}
+
+// cachedEventRetriever is an EventRetriever that caches the decoded events of the blocks in an LRU cache.
+type cachedEventRetriever struct {
+	EventRetriever
+
+	opts EventCacheOptions
+
+	mu       sync.Mutex
+	entries  map[types.Hash]*list.Element
+	lru      *list.List // of *eventCacheEntry, most recently used first
+	size     int
+	inflight map[types.Hash]*inflightEvents
+}
+
+type eventCacheEntry struct {
+	blockHash types.Hash
+	events    []*parser.Event
+	size      int
+}
+
+// inflightEvents is the retrieval of the events of a block that concurrent requests for the block wait for.
+type inflightEvents struct {
+	done   chan struct{}
+	events []*parser.Event
+	err    error
+}
+
+// NewCachedEventRetriever wraps the EventRetriever so that the decoded events of recently requested blocks are
+// cached, which avoids fetching and decoding the events of busy blocks again if several callers need them.
+// Concurrent requests for a block that is not cached are coalesced into a single retrieval, errors are not cached.
+//
+// The cached events are shared between the callers of GetEvents and must not be modified. GetEventsRange is not
+// cached. To bound the memory of pathological blocks, the wrapped retriever can use the parser of
+// parser.NewLimitedEventParser.
+func NewCachedEventRetriever(retriever EventRetriever, opts EventCacheOptions) EventRetriever {
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = defaultEventCacheMaxEntries
+	}
+
+	return &cachedEventRetriever{
+		EventRetriever: retriever,
+		opts:           opts,
+		entries:        make(map[types.Hash]*list.Element),
+		lru:            list.New(),
+		inflight:       make(map[types.Hash]*inflightEvents),
+	}
+}
+
+// GetEvents returns the cached events of the block or retrieves them via the wrapped EventRetriever.
+func (c *cachedEventRetriever) GetEvents(blockHash types.Hash) ([]*parser.Event, error) {
+	c.mu.Lock()
+
+	if elem, ok := c.entries[blockHash]; ok {
+		c.lru.MoveToFront(elem)
+		c.mu.Unlock()
+
+		c.report(c.opts.OnHit, blockHash)
+
+		return elem.Value.(*eventCacheEntry).events, nil
+	}
+
+	if call, ok := c.inflight[blockHash]; ok {
+		c.mu.Unlock()
+
+		c.report(c.opts.OnHit, blockHash)
+
+		<-call.done
+
+		return call.events, call.err
+	}
+
+	call := &inflightEvents{done: make(chan struct{})}
+	c.inflight[blockHash] = call
+	c.mu.Unlock()
+
+	c.report(c.opts.OnMiss, blockHash)
+
+	call.events, call.err = c.EventRetriever.GetEvents(blockHash)
+
+	c.mu.Lock()
+	delete(c.inflight, blockHash)
+
+	if call.err == nil {
+		c.add(blockHash, call.events)
+	}
+
+	c.mu.Unlock()
+	close(call.done)
+
+	return call.events, call.err
+}
+
+// add caches the events of the block and evicts the least recently used blocks that exceed the limits. The events of
+// a block that exceed MaxSize on their own are not cached.
+func (c *cachedEventRetriever) add(blockHash types.Hash, events []*parser.Event) {
+	size := estimateEventsSize(events)
+
+	if c.opts.MaxSize > 0 && size > c.opts.MaxSize {
+		return
+	}
+
+	c.entries[blockHash] = c.lru.PushFront(&eventCacheEntry{blockHash: blockHash, events: events, size: size})
+	c.size += size
+
+	for c.lru.Len() > c.opts.MaxEntries || (c.opts.MaxSize > 0 && c.size > c.opts.MaxSize) {
+		entry := c.lru.Remove(c.lru.Back()).(*eventCacheEntry)
+
+		delete(c.entries, entry.blockHash)
+		c.size -= entry.size
+	}
+}
+
+func (c *cachedEventRetriever) report(fn func(blockHash types.Hash), blockHash types.Hash) {
+	if fn != nil {
+		fn(blockHash)
+	}
+}
+
+// estimateEventsSize returns the estimated memory of the decoded events in bytes.
+func estimateEventsSize(events []*parser.Event) int {
+	size := 0
+
+	for _, event := range events {
+		size += eventSizeOverhead + 2*(len(event.Data)+len(event.Topics)*len(types.Hash{}))
+	}
+
+	return size
+}
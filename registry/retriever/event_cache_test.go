@@ -0,0 +1,149 @@
+package retriever
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestCacheEvents(dataLen int) []*parser.Event {
+	return []*parser.Event{
+		{Name: "System.ExtrinsicSuccess", Data: make([]byte, dataLen)},
+	}
+}
+
+func TestCachedEventRetriever_GetEvents(t *testing.T) {
+	retrieverMock := NewEventRetrieverMock(t)
+
+	var hits, misses []types.Hash
+
+	retriever := NewCachedEventRetriever(retrieverMock, EventCacheOptions{
+		MaxEntries: 2,
+		OnHit: func(blockHash types.Hash) {
+			hits = append(hits, blockHash)
+		},
+		OnMiss: func(blockHash types.Hash) {
+			misses = append(misses, blockHash)
+		},
+	})
+
+	blockHash1, blockHash2, blockHash3 := types.Hash{1}, types.Hash{2}, types.Hash{3}
+	events := newTestCacheEvents(10)
+
+	retrieverMock.On("GetEvents", blockHash1).Return(events, nil).Twice()
+	retrieverMock.On("GetEvents", blockHash2).Return(events, nil).Twice()
+	retrieverMock.On("GetEvents", blockHash3).Return(events, nil).Once()
+
+	for _, blockHash := range []types.Hash{blockHash1, blockHash2, blockHash1, blockHash3, blockHash2, blockHash1} {
+		res, err := retriever.GetEvents(blockHash)
+		assert.NoError(t, err)
+		assert.Equal(t, events, res)
+	}
+
+	// Block 2 is evicted by block 3 as block 1 was used more recently, block 1 is evicted by block 2 afterwards.
+	assert.Equal(t, []types.Hash{blockHash1}, hits)
+	assert.Equal(t, []types.Hash{blockHash1, blockHash2, blockHash3, blockHash2, blockHash1}, misses)
+}
+
+func TestCachedEventRetriever_GetEvents_MaxSize(t *testing.T) {
+	retrieverMock := NewEventRetrieverMock(t)
+
+	size := estimateEventsSize(newTestCacheEvents(10))
+	retriever := NewCachedEventRetriever(retrieverMock, EventCacheOptions{MaxSize: size + 1})
+
+	blockHash1, blockHash2 := types.Hash{1}, types.Hash{2}
+
+	// The events of block 2 exceed the size on their own and are not cached.
+	retrieverMock.On("GetEvents", blockHash1).Return(newTestCacheEvents(10), nil).Once()
+	retrieverMock.On("GetEvents", blockHash2).Return(newTestCacheEvents(20), nil).Twice()
+
+	for _, blockHash := range []types.Hash{blockHash1, blockHash2, blockHash1, blockHash2} {
+		_, err := retriever.GetEvents(blockHash)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, size, retriever.(*cachedEventRetriever).size)
+}
+
+func TestCachedEventRetriever_GetEvents_Error(t *testing.T) {
+	retrieverMock := NewEventRetrieverMock(t)
+	retriever := NewCachedEventRetriever(retrieverMock, EventCacheOptions{})
+
+	blockHash := types.Hash{1}
+	retrievalErr := errors.New("error")
+
+	retrieverMock.On("GetEvents", blockHash).Return(nil, retrievalErr).Once()
+	retrieverMock.On("GetEvents", blockHash).Return(newTestCacheEvents(1), nil).Once()
+
+	res, err := retriever.GetEvents(blockHash)
+	assert.ErrorIs(t, err, retrievalErr)
+	assert.Nil(t, res)
+
+	res, err = retriever.GetEvents(blockHash)
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+}
+
+func TestCachedEventRetriever_GetEvents_Coalesced(t *testing.T) {
+	const callers = 5
+
+	retrieverMock := NewEventRetrieverMock(t)
+	waiting := make(chan types.Hash, callers)
+
+	retriever := NewCachedEventRetriever(retrieverMock, EventCacheOptions{
+		OnHit: func(blockHash types.Hash) {
+			waiting <- blockHash
+		},
+	})
+
+	blockHash := types.Hash{1}
+	events := newTestCacheEvents(1)
+	release := make(chan struct{})
+
+	retrieverMock.On("GetEvents", blockHash).
+		Run(func(args mock.Arguments) {
+			<-release
+		}).
+		Return(events, nil).
+		Once()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			res, err := retriever.GetEvents(blockHash)
+			assert.NoError(t, err)
+			assert.Equal(t, events, res)
+		}()
+	}
+
+	// All callers but the one retrieving the events wait for it.
+	for i := 0; i < callers-1; i++ {
+		<-waiting
+	}
+
+	close(release)
+	wg.Wait()
+}
+
+func TestCachedEventRetriever_GetEventsRange(t *testing.T) {
+	retrieverMock := NewEventRetrieverMock(t)
+	retriever := NewCachedEventRetriever(retrieverMock, EventCacheOptions{})
+
+	blockEvents := []*BlockEvents{{BlockHash: types.Hash{1}}}
+
+	retrieverMock.On("GetEventsRange", types.Hash{1}, types.Hash{2}).Return(blockEvents, nil).Once()
+
+	res, err := retriever.GetEventsRange(types.Hash{1}, types.Hash{2})
+	assert.NoError(t, err)
+	assert.Equal(t, blockEvents, res)
+}
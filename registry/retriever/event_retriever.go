@@ -1,6 +1,8 @@
 package retriever
 
 import (
+	"context"
+	"log/slog"
 	"time"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
@@ -17,14 +19,24 @@ import (
 // EventRetriever is the interface used for retrieving and decoding events.
 type EventRetriever interface {
 	GetEvents(blockHash types.Hash) ([]*parser.Event, error)
+	GetEventsRange(startBlock, endBlock types.Hash) ([]*BlockEvents, error)
 }
 
+// BlockEvents holds the events found at a specific block.
+type BlockEvents struct {
+	BlockHash types.Hash
+	Events    []*parser.Event
+}
+
+// eventRetrieverComponent is the component of the event retriever in the recorded metrics.
+const eventRetrieverComponent = "event_retriever"
+
 // eventRetriever implements the EventRetriever interface.
 type eventRetriever struct {
 	eventParser parser.EventParser
 
 	eventProvider regState.EventProvider
-	stateRPC      state.State
+	stateRPC      state.Provider
 
 	registryFactory registry.Factory
 
@@ -33,16 +45,19 @@ type eventRetriever struct {
 
 	eventRegistry registry.EventRegistry
 	meta          *types.Metadata
+
+	opts Opts
 }
 
-// NewEventRetriever creates a new EventRetriever.
+// NewEventRetriever creates a new EventRetriever, the options configure e.g. its logger, see WithLogger.
 func NewEventRetriever(
 	eventParser parser.EventParser,
 	eventProvider regState.EventProvider,
-	stateRPC state.State,
+	stateRPC state.Provider,
 	registryFactory registry.Factory,
 	eventStorageExecutor exec.RetryableExecutor[*types.StorageDataRaw],
 	eventParsingExecutor exec.RetryableExecutor[[]*parser.Event],
+	opts ...OptsFn,
 ) (EventRetriever, error) {
 	retriever := &eventRetriever{
 		eventParser:          eventParser,
@@ -51,9 +66,10 @@ func NewEventRetriever(
 		registryFactory:      registryFactory,
 		eventStorageExecutor: eventStorageExecutor,
 		eventParsingExecutor: eventParsingExecutor,
+		opts:                 newOpts(opts),
 	}
 
-	if err := retriever.updateInternalState(nil); err != nil {
+	if err := retriever.updateInternalState(context.Background(), nil); err != nil {
 		return nil, ErrInternalStateUpdate.Wrap(err)
 	}
 
@@ -68,7 +84,7 @@ func NewEventRetriever(
 // - exec.RetryableExecutor - used for parsing events.
 func NewDefaultEventRetriever(
 	eventProvider regState.EventProvider,
-	stateRPC state.State,
+	stateRPC state.Provider,
 	fieldOverrides ...registry.FieldOverride,
 ) (EventRetriever, error) {
 	eventParser := parser.NewEventParser()
@@ -92,28 +108,68 @@ func NewDefaultEventRetriever(
 // Both the event storage data retrieval and the event parsing are handled via the exec.RetryableExecutor
 // in order to ensure retries in case of network errors or parsing errors due to an outdated event registry.
 func (e *eventRetriever) GetEvents(blockHash types.Hash) ([]*parser.Event, error) {
+	ctx, span := e.opts.startSpan(context.Background(), "retriever.GetEvents", &blockHash)
+
+	events, err := e.getEvents(ctx, blockHash)
+
+	span.End(err)
+
+	return events, err
+}
+
+func (e *eventRetriever) getEvents(ctx context.Context, blockHash types.Hash) ([]*parser.Event, error) {
+	storageCtx, storageSpan := e.opts.startSpan(ctx, "retriever.GetStorageEvents", nil)
+
 	storageEvents, err := e.eventStorageExecutor.ExecWithFallback(
 		func() (*types.StorageDataRaw, error) {
 			return e.eventProvider.GetStorageEvents(e.meta, blockHash)
 		},
 		func() error {
-			return e.updateInternalState(&blockHash)
+			return e.updateInternalState(storageCtx, &blockHash)
 		},
 	)
 
+	storageSpan.End(err)
+
 	if err != nil {
 		return nil, ErrStorageEventRetrieval.Wrap(err)
 	}
 
+	return e.parseEvents(ctx, blockHash, storageEvents)
+}
+
+// parseEvents parses the events of the block via the parsing executor.
+func (e *eventRetriever) parseEvents(
+	ctx context.Context,
+	blockHash types.Hash,
+	storageEvents *types.StorageDataRaw,
+) ([]*parser.Event, error) {
+	ctx, span := e.opts.startSpan(ctx, "retriever.ParseEvents", &blockHash)
+
+	start := time.Now()
+	refreshed := false
+
 	events, err := e.eventParsingExecutor.ExecWithFallback(
 		func() ([]*parser.Event, error) {
 			return e.eventParser.ParseEvents(e.eventRegistry, storageEvents)
 		},
 		func() error {
-			return e.updateInternalState(&blockHash)
+			refreshed = true
+
+			return e.updateInternalState(ctx, &blockHash)
 		},
 	)
 
+	e.opts.recordRegistryLookup(eventRetrieverComponent, !refreshed)
+
+	if err == nil {
+		span.SetAttributes(slog.Int("events", len(events)))
+
+		e.opts.recordEventsDecoded(len(events), time.Since(start))
+	}
+
+	span.End(err)
+
 	if err != nil {
 		return nil, ErrEventParsing.Wrap(err)
 	}
@@ -121,13 +177,68 @@ func (e *eventRetriever) GetEvents(blockHash types.Hash) ([]*parser.Event, error
 	return events, nil
 }
 
+// GetEventsRange retrieves the event storage changes between the provided start and end blocks with a single
+// storage query and then parses them.
+//
+// The node only reports a block if its event storage differs from the one of the previous block, as such,
+// blocks that are not present in the result have the same events as the closest previous block that is.
+//
+// Parsing is handled via the exec.RetryableExecutor in order to ensure retries in case of parsing errors due to an
+// outdated event registry, for example, after a runtime upgrade that happened within the range.
+func (e *eventRetriever) GetEventsRange(startBlock, endBlock types.Hash) ([]*BlockEvents, error) {
+	ctx, span := e.opts.startSpan(context.Background(), "retriever.GetEventsRange", &endBlock)
+
+	blockEvents, err := e.getEventsRange(ctx, startBlock, endBlock)
+
+	span.End(err)
+
+	return blockEvents, err
+}
+
+func (e *eventRetriever) getEventsRange(ctx context.Context, startBlock, endBlock types.Hash) ([]*BlockEvents, error) {
+	changeSets, err := e.eventProvider.GetStorageEventsRange(e.meta, startBlock, endBlock)
+
+	if err != nil {
+		return nil, ErrStorageEventRetrieval.Wrap(err)
+	}
+
+	var blockEvents []*BlockEvents
+
+	for _, changeSet := range changeSets {
+		blockHash := changeSet.Block
+
+		for _, change := range changeSet.Changes {
+			if !change.HasStorageData {
+				blockEvents = append(blockEvents, &BlockEvents{BlockHash: blockHash})
+
+				continue
+			}
+
+			storageEvents := change.StorageData
+
+			events, err := e.parseEvents(ctx, blockHash, &storageEvents)
+			if err != nil {
+				return nil, err
+			}
+
+			blockEvents = append(blockEvents, &BlockEvents{
+				BlockHash: blockHash,
+				Events:    events,
+			})
+		}
+	}
+
+	return blockEvents, nil
+}
+
 // updateInternalState will retrieve the metadata at the provided blockHash, if provided,
 // create an event registry based on this metadata and store both.
-func (e *eventRetriever) updateInternalState(blockHash *types.Hash) error {
-	var (
-		meta *types.Metadata
-		err  error
-	)
+func (e *eventRetriever) updateInternalState(ctx context.Context, blockHash *types.Hash) (err error) {
+	defer func() {
+		e.opts.logRefresh(blockHash, err)
+	}()
+
+	var meta *types.Metadata
 
 	if blockHash == nil {
 		meta, err = e.stateRPC.GetMetadataLatest()
@@ -139,8 +250,12 @@ func (e *eventRetriever) updateInternalState(blockHash *types.Hash) error {
 		return ErrMetadataRetrieval.Wrap(err)
 	}
 
+	_, span := e.opts.startSpan(ctx, "retriever.CreateEventRegistry", blockHash)
+
 	eventRegistry, err := e.registryFactory.CreateEventRegistry(meta)
 
+	span.End(err)
+
 	if err != nil {
 		return ErrEventRegistryCreation.Wrap(err)
 	}
@@ -36,6 +36,29 @@ func (_m *EventRetrieverMock) GetEvents(blockHash types.Hash) ([]*parser.Event,
 	return r0, r1
 }
 
+// GetEventsRange provides a mock function with given fields: startBlock, endBlock
+func (_m *EventRetrieverMock) GetEventsRange(startBlock types.Hash, endBlock types.Hash) ([]*BlockEvents, error) {
+	ret := _m.Called(startBlock, endBlock)
+
+	var r0 []*BlockEvents
+	if rf, ok := ret.Get(0).(func(types.Hash, types.Hash) []*BlockEvents); ok {
+		r0 = rf(startBlock, endBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*BlockEvents)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Hash, types.Hash) error); ok {
+		r1 = rf(startBlock, endBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewEventRetrieverMockT interface {
 	mock.TestingT
 	Cleanup(func())
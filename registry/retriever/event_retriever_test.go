@@ -1,17 +1,25 @@
 package retriever
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"log/slog"
 	"testing"
+	"time"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/metrics"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/exec"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
 	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/tracing"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEventRetriever_New(t *testing.T) {
@@ -186,6 +194,192 @@ func TestEventRetriever_GetEvents(t *testing.T) {
 	assert.Equal(t, parsedEvents, res)
 }
 
+func TestEventRetriever_GetEvents_Tracer(t *testing.T) {
+	eventParserMock := parser.NewEventParserMock(t)
+	eventProviderMock := state.NewEventProviderMock(t)
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	tracer := &testTracer{}
+
+	eventRetriever := &eventRetriever{
+		eventParser:          eventParserMock,
+		eventProvider:        eventProviderMock,
+		stateRPC:             stateRPCMock,
+		registryFactory:      registryFactoryMock,
+		eventStorageExecutor: exec.NewRetryableExecutor[*types.StorageDataRaw](exec.WithMaxRetryCount(1)),
+		eventParsingExecutor: exec.NewRetryableExecutor[[]*parser.Event](exec.WithMaxRetryCount(1)),
+		opts:                 newOpts([]OptsFn{WithTracer(tracer)}),
+	}
+
+	testMeta := &types.Metadata{}
+	outdatedRegistry := registry.EventRegistry(map[types.EventID]*registry.TypeDecoder{})
+	eventRegistry := registry.EventRegistry(map[types.EventID]*registry.TypeDecoder{{0, 1}: {Name: "test"}})
+
+	eventRetriever.meta = testMeta
+	eventRetriever.eventRegistry = outdatedRegistry
+
+	blockHash := types.NewHash([]byte{0, 1, 2, 3})
+
+	storageEvents := &types.StorageDataRaw{}
+
+	eventProviderMock.On("GetStorageEvents", testMeta, blockHash).
+		Return(storageEvents, nil).
+		Once()
+
+	// The events are parsed again after the registry was refreshed.
+	eventParserMock.On("ParseEvents", outdatedRegistry, storageEvents).
+		Return(nil, errors.New("error")).
+		Once()
+	stateRPCMock.On("GetMetadata", blockHash).
+		Return(testMeta, nil).
+		Once()
+	registryFactoryMock.On("CreateEventRegistry", testMeta).
+		Return(eventRegistry, nil).
+		Once()
+	eventParserMock.On("ParseEvents", eventRegistry, storageEvents).
+		Return([]*parser.Event{{}, {}}, nil).
+		Once()
+
+	_, err := eventRetriever.GetEvents(blockHash)
+	assert.NoError(t, err)
+
+	require.Len(t, tracer.spans, 4)
+
+	assert.Equal(t, "retriever.GetEvents/retriever.GetStorageEvents", tracer.spans[0].name)
+	assert.Equal(t, "retriever.GetEvents/retriever.ParseEvents/retriever.CreateEventRegistry", tracer.spans[1].name)
+	assert.Equal(t, "retriever.GetEvents/retriever.ParseEvents", tracer.spans[2].name)
+	assert.Equal(t, blockHash.Hex(), tracer.spans[2].attrs["block_hash"].String())
+	assert.Equal(t, int64(2), tracer.spans[2].attrs["events"].Int64())
+	assert.Equal(t, "retriever.GetEvents", tracer.spans[3].name)
+
+	for _, span := range tracer.spans {
+		assert.NoError(t, span.err)
+	}
+}
+
+func TestEventRetriever_GetEvents_Metrics(t *testing.T) {
+	eventParserMock := parser.NewEventParserMock(t)
+	eventProviderMock := state.NewEventProviderMock(t)
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	recorder := &testRecorder{}
+
+	eventRetriever := &eventRetriever{
+		eventParser:          eventParserMock,
+		eventProvider:        eventProviderMock,
+		stateRPC:             stateRPCMock,
+		registryFactory:      registryFactoryMock,
+		eventStorageExecutor: exec.NewRetryableExecutor[*types.StorageDataRaw](exec.WithMaxRetryCount(1)),
+		eventParsingExecutor: exec.NewRetryableExecutor[[]*parser.Event](exec.WithMaxRetryCount(1)),
+		opts:                 newOpts([]OptsFn{WithMetrics(recorder)}),
+	}
+
+	testMeta := &types.Metadata{}
+	outdatedRegistry := registry.EventRegistry(map[types.EventID]*registry.TypeDecoder{})
+	eventRegistry := registry.EventRegistry(map[types.EventID]*registry.TypeDecoder{{0, 1}: {Name: "test"}})
+
+	eventRetriever.meta = testMeta
+	eventRetriever.eventRegistry = outdatedRegistry
+
+	blockHash := types.NewHash([]byte{0, 1, 2, 3})
+
+	storageEvents := &types.StorageDataRaw{}
+
+	eventProviderMock.On("GetStorageEvents", testMeta, blockHash).
+		Return(storageEvents, nil).
+		Twice()
+
+	// The registry is refreshed for the first block, and used as is for the second one.
+	eventParserMock.On("ParseEvents", outdatedRegistry, storageEvents).
+		Return(nil, errors.New("error")).
+		Once()
+	stateRPCMock.On("GetMetadata", blockHash).
+		Return(testMeta, nil).
+		Once()
+	registryFactoryMock.On("CreateEventRegistry", testMeta).
+		Return(eventRegistry, nil).
+		Once()
+	eventParserMock.On("ParseEvents", eventRegistry, storageEvents).
+		Return([]*parser.Event{{}, {}}, nil).
+		Twice()
+
+	for i := 0; i < 2; i++ {
+		_, err := eventRetriever.GetEvents(blockHash)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, []bool{false, true}, recorder.lookups)
+	assert.Equal(t, []int{2, 2}, recorder.decodedEvents)
+}
+
+// testRecorder records the registry lookups and the decoded events, and ignores all other metrics.
+type testRecorder struct {
+	lookups       []bool
+	decodedEvents []int
+}
+
+func (r *testRecorder) CallCompleted(metrics.Call) {}
+
+func (r *testRecorder) SubscriptionStarted(string) {}
+
+func (r *testRecorder) SubscriptionEnded(string) {}
+
+func (r *testRecorder) NotificationReceived(string, int) {}
+
+func (r *testRecorder) NotificationDropped(string) {}
+
+func (r *testRecorder) Reconnected(string) {}
+
+func (r *testRecorder) EventsDecoded(count int, _ time.Duration) {
+	r.decodedEvents = append(r.decodedEvents, count)
+}
+
+func (r *testRecorder) RegistryLookup(component string, hit bool) {
+	if component == eventRetrieverComponent {
+		r.lookups = append(r.lookups, hit)
+	}
+}
+
+func (r *testRecorder) ExtrinsicOutcome(metrics.Outcome) {}
+
+// testTracer records the spans once they ended, their names are prefixed with the names of their parents.
+type testTracer struct {
+	spans []*testSpan
+}
+
+type testSpanKey struct{}
+
+func (t *testTracer) Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, tracing.Span) {
+	if parent, ok := ctx.Value(testSpanKey{}).(string); ok {
+		name = parent + "/" + name
+	}
+
+	span := &testSpan{tracer: t, name: name, attrs: make(map[string]slog.Value)}
+	span.SetAttributes(attrs...)
+
+	return context.WithValue(ctx, testSpanKey{}, name), span
+}
+
+type testSpan struct {
+	tracer *testTracer
+	name   string
+	attrs  map[string]slog.Value
+	err    error
+}
+
+func (s *testSpan) SetAttributes(attrs ...slog.Attr) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *testSpan) End(err error) {
+	s.err = err
+	s.tracer.spans = append(s.tracer.spans, s)
+}
+
 func TestEventRetriever_GetEvents_StorageRetrievalError(t *testing.T) {
 	eventParserMock := parser.NewEventParserMock(t)
 	eventProviderMock := state.NewEventProviderMock(t)
@@ -332,6 +526,210 @@ func TestEventRetriever_GetEvents_EventParsingError(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func TestEventRetriever_GetEventsRange(t *testing.T) {
+	eventParserMock := parser.NewEventParserMock(t)
+	eventProviderMock := state.NewEventProviderMock(t)
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+	storageExecMock := exec.NewRetryableExecutorMock[*types.StorageDataRaw](t)
+	parsingExecMock := exec.NewRetryableExecutorMock[[]*parser.Event](t)
+
+	eventRetriever := &eventRetriever{
+		eventParser:          eventParserMock,
+		eventProvider:        eventProviderMock,
+		stateRPC:             stateRPCMock,
+		registryFactory:      registryFactoryMock,
+		eventStorageExecutor: storageExecMock,
+		eventParsingExecutor: parsingExecMock,
+	}
+
+	testMeta := &types.Metadata{}
+
+	eventRetriever.meta = testMeta
+
+	eventRegistry := registry.EventRegistry(map[types.EventID]*registry.TypeDecoder{})
+
+	eventRetriever.eventRegistry = eventRegistry
+
+	startHash := types.NewHash([]byte{0, 1, 2, 3})
+	endHash := types.NewHash([]byte{4, 5, 6, 7})
+
+	storageEvents := types.StorageDataRaw{0}
+
+	changeSets := []types.StorageChangeSet{
+		{
+			Block: startHash,
+			Changes: []types.KeyValueOption{
+				{
+					HasStorageData: true,
+					StorageData:    storageEvents,
+				},
+			},
+		},
+		{
+			Block: endHash,
+			Changes: []types.KeyValueOption{
+				{
+					HasStorageData: false,
+				},
+			},
+		},
+	}
+
+	eventProviderMock.On("GetStorageEventsRange", testMeta, startHash, endHash).
+		Return(changeSets, nil).
+		Once()
+
+	parsedEvents := []*parser.Event{}
+
+	eventParserMock.On("ParseEvents", eventRegistry, &storageEvents).
+		Return(parsedEvents, nil).
+		Once()
+
+	parsingExecMock.On("ExecWithFallback", mock.Anything, mock.Anything).
+		Run(
+			func(args mock.Arguments) {
+				execFn, ok := args.Get(0).(func() ([]*parser.Event, error))
+				assert.True(t, ok)
+
+				execFnRes, err := execFn()
+				assert.NoError(t, err)
+				assert.Equal(t, parsedEvents, execFnRes)
+			},
+		).Return(parsedEvents, nil).
+		Once()
+
+	res, err := eventRetriever.GetEventsRange(startHash, endHash)
+	assert.NoError(t, err)
+	assert.Equal(t, []*BlockEvents{
+		{
+			BlockHash: startHash,
+			Events:    parsedEvents,
+		},
+		{
+			BlockHash: endHash,
+		},
+	}, res)
+}
+
+func TestEventRetriever_GetEventsRange_StorageRetrievalError(t *testing.T) {
+	eventParserMock := parser.NewEventParserMock(t)
+	eventProviderMock := state.NewEventProviderMock(t)
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+	storageExecMock := exec.NewRetryableExecutorMock[*types.StorageDataRaw](t)
+	parsingExecMock := exec.NewRetryableExecutorMock[[]*parser.Event](t)
+
+	eventRetriever := &eventRetriever{
+		eventParser:          eventParserMock,
+		eventProvider:        eventProviderMock,
+		stateRPC:             stateRPCMock,
+		registryFactory:      registryFactoryMock,
+		eventStorageExecutor: storageExecMock,
+		eventParsingExecutor: parsingExecMock,
+	}
+
+	testMeta := &types.Metadata{}
+
+	eventRetriever.meta = testMeta
+
+	startHash := types.NewHash([]byte{0, 1, 2, 3})
+	endHash := types.NewHash([]byte{4, 5, 6, 7})
+
+	storageRetrievalError := errors.New("error")
+
+	eventProviderMock.On("GetStorageEventsRange", testMeta, startHash, endHash).
+		Return(nil, storageRetrievalError).
+		Once()
+
+	res, err := eventRetriever.GetEventsRange(startHash, endHash)
+	assert.ErrorIs(t, err, ErrStorageEventRetrieval)
+	assert.Nil(t, res)
+}
+
+func TestEventRetriever_GetEventsRange_EventParsingError(t *testing.T) {
+	eventParserMock := parser.NewEventParserMock(t)
+	eventProviderMock := state.NewEventProviderMock(t)
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+	storageExecMock := exec.NewRetryableExecutorMock[*types.StorageDataRaw](t)
+	parsingExecMock := exec.NewRetryableExecutorMock[[]*parser.Event](t)
+
+	eventRetriever := &eventRetriever{
+		eventParser:          eventParserMock,
+		eventProvider:        eventProviderMock,
+		stateRPC:             stateRPCMock,
+		registryFactory:      registryFactoryMock,
+		eventStorageExecutor: storageExecMock,
+		eventParsingExecutor: parsingExecMock,
+	}
+
+	testMeta := &types.Metadata{}
+
+	eventRetriever.meta = testMeta
+
+	eventRegistry := registry.EventRegistry(map[types.EventID]*registry.TypeDecoder{})
+
+	eventRetriever.eventRegistry = eventRegistry
+
+	startHash := types.NewHash([]byte{0, 1, 2, 3})
+	endHash := types.NewHash([]byte{4, 5, 6, 7})
+
+	storageEvents := types.StorageDataRaw{0}
+
+	changeSets := []types.StorageChangeSet{
+		{
+			Block: startHash,
+			Changes: []types.KeyValueOption{
+				{
+					HasStorageData: true,
+					StorageData:    storageEvents,
+				},
+			},
+		},
+	}
+
+	eventProviderMock.On("GetStorageEventsRange", testMeta, startHash, endHash).
+		Return(changeSets, nil).
+		Once()
+
+	eventParsingError := errors.New("error")
+
+	eventParserMock.On("ParseEvents", eventRegistry, &storageEvents).
+		Return(nil, eventParsingError).
+		Once()
+
+	stateRPCMock.On("GetMetadata", startHash).
+		Return(testMeta, nil).
+		Once()
+
+	registryFactoryMock.On("CreateEventRegistry", testMeta).
+		Return(eventRegistry, nil).
+		Once()
+
+	parsingExecMock.On("ExecWithFallback", mock.Anything, mock.Anything).
+		Run(
+			func(args mock.Arguments) {
+				execFn, ok := args.Get(0).(func() ([]*parser.Event, error))
+				assert.True(t, ok)
+
+				execFnRes, err := execFn()
+				assert.ErrorIs(t, err, eventParsingError)
+				assert.Nil(t, execFnRes)
+
+				fallbackFn, ok := args.Get(1).(func() error)
+				assert.True(t, ok)
+
+				err = fallbackFn()
+				assert.NoError(t, err)
+			},
+		).Return([]*parser.Event{}, eventParsingError)
+
+	res, err := eventRetriever.GetEventsRange(startHash, endHash)
+	assert.ErrorIs(t, err, ErrEventParsing)
+	assert.Nil(t, res)
+}
+
 func TestEventRetriever_updateInternalState(t *testing.T) {
 	eventParserMock := parser.NewEventParserMock(t)
 	eventProviderMock := state.NewEventProviderMock(t)
@@ -363,7 +761,7 @@ func TestEventRetriever_updateInternalState(t *testing.T) {
 		Return(eventRegistry, nil).
 		Once()
 
-	err := eventRetriever.updateInternalState(&blockHash)
+	err := eventRetriever.updateInternalState(context.Background(), &blockHash)
 	assert.NoError(t, err)
 	assert.Equal(t, testMeta, eventRetriever.meta)
 	assert.Equal(t, eventRegistry, eventRetriever.eventRegistry)
@@ -378,12 +776,64 @@ func TestEventRetriever_updateInternalState(t *testing.T) {
 		Return(eventRegistry, nil).
 		Once()
 
-	err = eventRetriever.updateInternalState(nil)
+	err = eventRetriever.updateInternalState(context.Background(), nil)
 	assert.NoError(t, err)
 	assert.Equal(t, latestMeta, eventRetriever.meta)
 	assert.Equal(t, eventRegistry, eventRetriever.eventRegistry)
 }
 
+func TestEventRetriever_updateInternalState_Logger(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	var buf bytes.Buffer
+
+	eventRetriever := &eventRetriever{
+		stateRPC:        stateRPCMock,
+		registryFactory: registryFactoryMock,
+		opts:            newOpts([]OptsFn{WithLogger(slog.New(slog.NewJSONHandler(&buf, nil)))}),
+	}
+
+	testMeta := &types.Metadata{}
+
+	blockHash := types.NewHash([]byte{0, 1, 2, 3})
+
+	stateRPCMock.On("GetMetadata", blockHash).
+		Return(testMeta, nil).
+		Once()
+
+	registryFactoryMock.On("CreateEventRegistry", testMeta).
+		Return(registry.EventRegistry(map[types.EventID]*registry.TypeDecoder{}), nil).
+		Once()
+
+	err := eventRetriever.updateInternalState(context.Background(), &blockHash)
+	assert.NoError(t, err)
+
+	stateRPCMock.On("GetMetadata", blockHash).
+		Return(nil, errors.New("error")).
+		Once()
+
+	err = eventRetriever.updateInternalState(context.Background(), &blockHash)
+	assert.ErrorIs(t, err, ErrMetadataRetrieval)
+
+	dec := json.NewDecoder(&buf)
+
+	var entry map[string]interface{}
+
+	require.NoError(t, dec.Decode(&entry))
+	assert.Equal(t, "INFO", entry["level"])
+	assert.Equal(t, "Metadata refreshed", entry["msg"])
+	assert.Equal(t, blockHash.Hex(), entry["block_hash"])
+
+	entry = nil
+
+	require.NoError(t, dec.Decode(&entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "Metadata refresh failed", entry["msg"])
+	assert.Equal(t, blockHash.Hex(), entry["block_hash"])
+	assert.Contains(t, entry["error"], ErrMetadataRetrieval.Error())
+}
+
 func TestEventRetriever_updateInternalState_MetadataRetrievalError(t *testing.T) {
 	eventParserMock := parser.NewEventParserMock(t)
 	eventProviderMock := state.NewEventProviderMock(t)
@@ -409,14 +859,14 @@ func TestEventRetriever_updateInternalState_MetadataRetrievalError(t *testing.T)
 		Return(nil, metadataRetrievalError).
 		Once()
 
-	err := eventRetriever.updateInternalState(&blockHash)
+	err := eventRetriever.updateInternalState(context.Background(), &blockHash)
 	assert.ErrorIs(t, err, ErrMetadataRetrieval)
 
 	stateRPCMock.On("GetMetadataLatest").
 		Return(nil, metadataRetrievalError).
 		Once()
 
-	err = eventRetriever.updateInternalState(nil)
+	err = eventRetriever.updateInternalState(context.Background(), nil)
 	assert.ErrorIs(t, err, ErrMetadataRetrieval)
 }
 
@@ -451,7 +901,7 @@ func TestEventRetriever_updateInternalState_RegistryFactoryError(t *testing.T) {
 		Return(nil, registryFactoryError).
 		Once()
 
-	err := eventRetriever.updateInternalState(&blockHash)
+	err := eventRetriever.updateInternalState(context.Background(), &blockHash)
 	assert.ErrorIs(t, err, ErrEventRegistryCreation)
 
 	latestMeta := &types.Metadata{}
@@ -464,6 +914,6 @@ func TestEventRetriever_updateInternalState_RegistryFactoryError(t *testing.T) {
 		Return(nil, registryFactoryError).
 		Once()
 
-	err = eventRetriever.updateInternalState(nil)
+	err = eventRetriever.updateInternalState(context.Background(), nil)
 	assert.ErrorIs(t, err, ErrEventRegistryCreation)
 }
@@ -1,6 +1,9 @@
 package retriever
 
 import (
+	"context"
+	"log/slog"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/exec"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
@@ -20,6 +23,9 @@ type ExtrinsicRetriever[A, S, P any] interface {
 	GetExtrinsics(blockHash types.Hash) ([]*parser.Extrinsic[A, S, P], error)
 }
 
+// extrinsicRetrieverComponent is the component of the extrinsic retriever in the recorded metrics.
+const extrinsicRetrieverComponent = "extrinsic_retriever"
+
 // extrinsicRetriever implements the ExtrinsicRetriever interface.
 type extrinsicRetriever[
 	A, S, P any,
@@ -28,7 +34,7 @@ type extrinsicRetriever[
 	extrinsicParser parser.ExtrinsicParser[A, S, P]
 
 	genericChain generic.Chain[A, S, P, B]
-	stateRPC     state.State
+	stateRPC     state.Provider
 
 	registryFactory registry.Factory
 
@@ -37,19 +43,22 @@ type extrinsicRetriever[
 
 	callRegistry registry.CallRegistry
 	meta         *types.Metadata
+
+	opts Opts
 }
 
-// NewExtrinsicRetriever creates a new ExtrinsicRetriever.
+// NewExtrinsicRetriever creates a new ExtrinsicRetriever, the options configure e.g. its logger, see WithLogger.
 func NewExtrinsicRetriever[
 	A, S, P any,
 	B generic.GenericSignedBlock[A, S, P],
 ](
 	extrinsicParser parser.ExtrinsicParser[A, S, P],
 	genericChain generic.Chain[A, S, P, B],
-	stateRPC state.State,
+	stateRPC state.Provider,
 	registryFactory registry.Factory,
 	chainExecutor exec.RetryableExecutor[B],
 	extrinsicParsingExecutor exec.RetryableExecutor[[]*parser.Extrinsic[A, S, P]],
+	opts ...OptsFn,
 ) (ExtrinsicRetriever[A, S, P], error) {
 	retriever := &extrinsicRetriever[A, S, P, B]{
 		extrinsicParser:          extrinsicParser,
@@ -58,9 +67,10 @@ func NewExtrinsicRetriever[
 		registryFactory:          registryFactory,
 		chainExecutor:            chainExecutor,
 		extrinsicParsingExecutor: extrinsicParsingExecutor,
+		opts:                     newOpts(opts),
 	}
 
-	if err := retriever.updateInternalState(nil); err != nil {
+	if err := retriever.updateInternalState(context.Background(), nil); err != nil {
 		return nil, ErrInternalStateUpdate.Wrap(err)
 	}
 
@@ -89,10 +99,11 @@ type DefaultExtrinsicRetriever = ExtrinsicRetriever[
 func NewDefaultExtrinsicRetriever(
 	extrinsicParser parser.DefaultExtrinsicParser,
 	genericChain generic.DefaultChain,
-	stateRPC state.State,
+	stateRPC state.Provider,
 	registryFactory registry.Factory,
 	chainExecutor exec.RetryableExecutor[*generic.DefaultGenericSignedBlock],
 	extrinsicParsingExecutor exec.RetryableExecutor[[]*parser.DefaultExtrinsic],
+	opts ...OptsFn,
 ) (DefaultExtrinsicRetriever, error) {
 	return NewExtrinsicRetriever[
 		types.MultiAddress,
@@ -106,6 +117,7 @@ func NewDefaultExtrinsicRetriever(
 		registryFactory,
 		chainExecutor,
 		extrinsicParsingExecutor,
+		opts...,
 	)
 }
 
@@ -114,6 +126,21 @@ func NewDefaultExtrinsicRetriever(
 // Both the block retrieval and the extrinsic parsing are handled via the exec.RetryableExecutor
 // in order to ensure retries in case of network errors or parsing errors due to an outdated call registry.
 func (e *extrinsicRetriever[A, S, P, B]) GetExtrinsics(blockHash types.Hash) ([]*parser.Extrinsic[A, S, P], error) {
+	ctx, span := e.opts.startSpan(context.Background(), "retriever.GetExtrinsics", &blockHash)
+
+	calls, err := e.getExtrinsics(ctx, blockHash)
+
+	span.End(err)
+
+	return calls, err
+}
+
+func (e *extrinsicRetriever[A, S, P, B]) getExtrinsics(
+	ctx context.Context,
+	blockHash types.Hash,
+) ([]*parser.Extrinsic[A, S, P], error) {
+	_, blockSpan := e.opts.startSpan(ctx, "retriever.GetBlock", nil)
+
 	block, err := e.chainExecutor.ExecWithFallback(
 		func() (B, error) {
 			return e.genericChain.GetBlock(blockHash)
@@ -123,19 +150,35 @@ func (e *extrinsicRetriever[A, S, P, B]) GetExtrinsics(blockHash types.Hash) ([]
 		},
 	)
 
+	blockSpan.End(err)
+
 	if err != nil {
 		return nil, ErrBlockRetrieval.Wrap(err)
 	}
 
+	parsingCtx, parsingSpan := e.opts.startSpan(ctx, "retriever.ParseExtrinsics", nil)
+
+	refreshed := false
+
 	calls, err := e.extrinsicParsingExecutor.ExecWithFallback(
 		func() ([]*parser.Extrinsic[A, S, P], error) {
 			return e.extrinsicParser.ParseExtrinsics(e.callRegistry, block)
 		},
 		func() error {
-			return e.updateInternalState(&blockHash)
+			refreshed = true
+
+			return e.updateInternalState(parsingCtx, &blockHash)
 		},
 	)
 
+	e.opts.recordRegistryLookup(extrinsicRetrieverComponent, !refreshed)
+
+	if err == nil {
+		parsingSpan.SetAttributes(slog.Int("extrinsics", len(calls)))
+	}
+
+	parsingSpan.End(err)
+
 	if err != nil {
 		return nil, ErrExtrinsicParsing.Wrap(err)
 	}
@@ -145,11 +188,15 @@ func (e *extrinsicRetriever[A, S, P, B]) GetExtrinsics(blockHash types.Hash) ([]
 
 // updateInternalState will retrieve the metadata at the provided blockHash, if provided,
 // create a call registry based on this metadata and store both.
-func (e *extrinsicRetriever[A, S, P, B]) updateInternalState(blockHash *types.Hash) error {
-	var (
-		meta *types.Metadata
-		err  error
-	)
+func (e *extrinsicRetriever[A, S, P, B]) updateInternalState(
+	ctx context.Context,
+	blockHash *types.Hash,
+) (err error) {
+	defer func() {
+		e.opts.logRefresh(blockHash, err)
+	}()
+
+	var meta *types.Metadata
 
 	if blockHash == nil {
 		meta, err = e.stateRPC.GetMetadataLatest()
@@ -161,8 +208,12 @@ func (e *extrinsicRetriever[A, S, P, B]) updateInternalState(blockHash *types.Ha
 		return ErrMetadataRetrieval.Wrap(err)
 	}
 
+	_, span := e.opts.startSpan(ctx, "retriever.CreateCallRegistry", blockHash)
+
 	callRegistry, err := e.registryFactory.CreateCallRegistry(meta)
 
+	span.End(err)
+
 	if err != nil {
 		return ErrCallRegistryCreation.Wrap(err)
 	}
@@ -1,6 +1,7 @@
 package retriever
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -638,7 +639,7 @@ func TestExtrinsicRetriever_updateInternalState(t *testing.T) {
 		Return(callRegistry, nil).
 		Once()
 
-	err := extrinsicRetriever.updateInternalState(&blockHash)
+	err := extrinsicRetriever.updateInternalState(context.Background(), &blockHash)
 	assert.NoError(t, err)
 
 	latestMeta := &types.Metadata{}
@@ -651,7 +652,7 @@ func TestExtrinsicRetriever_updateInternalState(t *testing.T) {
 		Return(callRegistry, nil).
 		Once()
 
-	err = extrinsicRetriever.updateInternalState(nil)
+	err = extrinsicRetriever.updateInternalState(context.Background(), nil)
 	assert.NoError(t, err)
 }
 
@@ -712,14 +713,14 @@ func TestExtrinsicRetriever_updateInternalState_MetadataRetrievalError(t *testin
 		Return(nil, metadataRetrievalError).
 		Once()
 
-	err := extrinsicRetriever.updateInternalState(&blockHash)
+	err := extrinsicRetriever.updateInternalState(context.Background(), &blockHash)
 	assert.ErrorIs(t, err, ErrMetadataRetrieval)
 
 	stateRPCMock.On("GetMetadataLatest").
 		Return(nil, metadataRetrievalError).
 		Once()
 
-	err = extrinsicRetriever.updateInternalState(nil)
+	err = extrinsicRetriever.updateInternalState(context.Background(), nil)
 	assert.ErrorIs(t, err, ErrMetadataRetrieval)
 }
 
@@ -786,7 +787,7 @@ func TestExtrinsicRetriever_updateInternalState_RegistryFactoryError(t *testing.
 		Return(nil, registryFactoryError).
 		Once()
 
-	err := extrinsicRetriever.updateInternalState(&blockHash)
+	err := extrinsicRetriever.updateInternalState(context.Background(), &blockHash)
 	assert.ErrorIs(t, err, ErrCallRegistryCreation)
 
 	latestMeta := &types.Metadata{}
@@ -799,6 +800,6 @@ func TestExtrinsicRetriever_updateInternalState_RegistryFactoryError(t *testing.
 		Return(nil, registryFactoryError).
 		Once()
 
-	err = extrinsicRetriever.updateInternalState(nil)
+	err = extrinsicRetriever.updateInternalState(context.Background(), nil)
 	assert.ErrorIs(t, err, ErrCallRegistryCreation)
 }
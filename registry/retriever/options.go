@@ -0,0 +1,118 @@
+package retriever
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/metrics"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/tracing"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Opts holds the configurable options of the retrievers.
+type Opts struct {
+	// logger logs the metadata refreshes, nothing is logged if it is nil.
+	logger *slog.Logger
+
+	// tracer traces the retrieval and decoding of blocks, nothing is traced if it is nil.
+	tracer tracing.Tracer
+
+	// recorder records the decoded events and the registry lookups, nothing is recorded if it is nil.
+	recorder metrics.Recorder
+}
+
+// OptsFn is a function that operates on Opts.
+type OptsFn func(opts *Opts)
+
+// WithLogger sets the logger that logs the metadata refreshes of a retriever with the keys block_hash and error,
+// nothing is logged by default. The failed attempts that lead to a refresh are logged by the executors of the
+// retriever, see exec.WithLogger.
+func WithLogger(logger *slog.Logger) OptsFn {
+	return func(opts *Opts) {
+		opts.logger = logger
+	}
+}
+
+// WithTracer sets the tracer that traces the retrieval and decoding of the events or extrinsics of blocks, with child
+// spans for the storage or block retrieval, the decoding and the creation of the registries after metadata refreshes.
+// Nothing is traced by default.
+func WithTracer(tracer tracing.Tracer) OptsFn {
+	return func(opts *Opts) {
+		opts.tracer = tracer
+	}
+}
+
+// WithMetrics sets the recorder that a retriever reports its registry lookups to, with the components event_retriever
+// and extrinsic_retriever. A decoding is a hit if it succeeded with the cached registry and a miss if the registry had
+// to be refreshed. The event retriever additionally reports the decoded events of every block.
+func WithMetrics(recorder metrics.Recorder) OptsFn {
+	return func(opts *Opts) {
+		opts.recorder = recorder
+	}
+}
+
+func newOpts(optsFns []OptsFn) Opts {
+	var opts Opts
+
+	for _, fn := range optsFns {
+		fn(&opts)
+	}
+
+	return opts
+}
+
+// logRefresh logs the outcome of the refresh of the metadata at the block. The retrieval of the latest metadata,
+// which only happens when the retriever is created, is not logged.
+func (o Opts) logRefresh(blockHash *types.Hash, err error) {
+	if o.logger == nil || blockHash == nil {
+		return
+	}
+
+	if err != nil {
+		o.logger.LogAttrs(
+			context.Background(),
+			slog.LevelWarn,
+			"Metadata refresh failed",
+			slog.String("block_hash", blockHash.Hex()),
+			slog.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	o.logger.LogAttrs(
+		context.Background(),
+		slog.LevelInfo,
+		"Metadata refreshed",
+		slog.String("block_hash", blockHash.Hex()),
+	)
+}
+
+// startSpan starts a span with the hash of the block, if any, via the tracer. It returns tracing.NoopSpan if no tracer
+// is set.
+func (o Opts) startSpan(ctx context.Context, name string, blockHash *types.Hash) (context.Context, tracing.Span) {
+	if o.tracer == nil {
+		return ctx, tracing.NoopSpan
+	}
+
+	if blockHash == nil {
+		return o.tracer.Start(ctx, name)
+	}
+
+	return o.tracer.Start(ctx, name, slog.String("block_hash", blockHash.Hex()))
+}
+
+// recordRegistryLookup records the lookup of the registry of the component via the recorder, if it is set.
+func (o Opts) recordRegistryLookup(component string, hit bool) {
+	if o.recorder != nil {
+		o.recorder.RegistryLookup(component, hit)
+	}
+}
+
+// recordEventsDecoded records the decoded events of a block via the recorder, if it is set.
+func (o Opts) recordEventsDecoded(count int, duration time.Duration) {
+	if o.recorder != nil {
+		o.recorder.EventsDecoded(count, duration)
+	}
+}
@@ -0,0 +1,102 @@
+package registry
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// RuntimeAPIResultValueKey is the key that holds the result of a runtime API method whose output type is not a
+// composite, e.g. the u32 returned by AccountNonceApi.account_nonce.
+const RuntimeAPIResultValueKey = "value"
+
+// RuntimeAPIResultDecoder decodes the result of a runtime API method dynamically, based on the output type of the
+// method in metadata V15.
+type RuntimeAPIResultDecoder struct {
+	MethodName    string
+	OutputDecoder FieldDecoder
+}
+
+// NewRuntimeAPIResultDecoder creates a RuntimeAPIResultDecoder for the provided runtime API method.
+func NewRuntimeAPIResultDecoder(
+	meta *types.MetadataV15,
+	method *types.RuntimeAPIMethodMetadataV15,
+) (*RuntimeAPIResultDecoder, error) {
+	lookupMeta := &types.Metadata{
+		Version: 14,
+		AsMetadataV14: types.MetadataV14{
+			Lookup:          meta.Lookup,
+			EfficientLookup: meta.EfficientLookup,
+		},
+	}
+
+	f := &factory{}
+	f.resetStorages()
+
+	outputFields, err := f.getTypeFields(lookupMeta, []types.Si1Field{{Type: method.Output}})
+
+	if err != nil {
+		return nil, ErrRuntimeAPIOutputFieldsRetrieval.WithMsg(string(method.Name)).Wrap(err)
+	}
+
+	if err := f.resolveRecursiveDecoders(); err != nil {
+		return nil, ErrRecursiveDecodersResolving.Wrap(err)
+	}
+
+	return &RuntimeAPIResultDecoder{
+		MethodName:    string(method.Name),
+		OutputDecoder: outputFields[0].FieldDecoder,
+	}, nil
+}
+
+// Decode decodes the SCALE encoded result. The fields of a composite output are returned by name, any other output
+// is returned under RuntimeAPIResultValueKey. Nested composites are returned as maps as well.
+func (r *RuntimeAPIResultDecoder) Decode(data []byte) (map[string]any, error) {
+	reader := bytes.NewReader(data)
+
+	value, err := r.OutputDecoder.Decode(scale.NewDecoder(reader))
+
+	if err != nil {
+		return nil, ErrRuntimeAPIResultDecoding.WithMsg(r.MethodName).Wrap(err)
+	}
+
+	if reader.Len() > 0 {
+		return nil, ErrRuntimeAPIResultTrailingBytes.WithMsg("%d bytes", reader.Len())
+	}
+
+	if decodedFields, ok := value.(DecodedFields); ok {
+		return decodedFieldsToMap(decodedFields), nil
+	}
+
+	return map[string]any{
+		RuntimeAPIResultValueKey: toDynamicValue(value),
+	}, nil
+}
+
+func decodedFieldsToMap(decodedFields DecodedFields) map[string]any {
+	res := make(map[string]any, len(decodedFields))
+
+	for _, decodedField := range decodedFields {
+		res[decodedField.Name] = toDynamicValue(decodedField.Value)
+	}
+
+	return res
+}
+
+func toDynamicValue(value any) any {
+	switch v := value.(type) {
+	case DecodedFields:
+		return decodedFieldsToMap(v)
+	case []any:
+		res := make([]any, 0, len(v))
+
+		for _, item := range v {
+			res = append(res, toDynamicValue(item))
+		}
+
+		return res
+	default:
+		return value
+	}
+}
@@ -0,0 +1,105 @@
+package registry
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMetadataV15(t *testing.T) *types.MetadataV15 {
+	var meta types.MetadataV15
+
+	err := types.DecodeMetadataV15(codec.MustHexDecodeString(test.MetadataV15Hex), &meta)
+	require.NoError(t, err)
+
+	return &meta
+}
+
+func TestRuntimeAPIResultDecoder_Composite(t *testing.T) {
+	meta := newTestMetadataV15(t)
+
+	method, err := meta.FindRuntimeAPIMethod("TestApi", "info")
+	require.NoError(t, err)
+
+	decoder, err := NewRuntimeAPIResultDecoder(meta, method)
+	require.NoError(t, err)
+
+	encodedInfo, err := codec.Encode(struct {
+		Nonce   types.U32
+		Balance types.U128
+	}{
+		Nonce:   5,
+		Balance: types.NewU128(*big.NewInt(1_000_000)),
+	})
+	require.NoError(t, err)
+
+	res, err := decoder.Decode(encodedInfo)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"nonce":   types.U32(5),
+		"balance": types.NewU128(*big.NewInt(1_000_000)),
+	}, res)
+}
+
+func TestRuntimeAPIResultDecoder_Value(t *testing.T) {
+	meta := newTestMetadataV15(t)
+
+	method, err := meta.FindRuntimeAPIMethod("AccountNonceApi", "account_nonce")
+	require.NoError(t, err)
+
+	decoder, err := NewRuntimeAPIResultDecoder(meta, method)
+	require.NoError(t, err)
+
+	res, err := decoder.Decode([]byte{7, 0, 0, 0})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{RuntimeAPIResultValueKey: types.U32(7)}, res)
+}
+
+func TestRuntimeAPIResultDecoder_DecodeError(t *testing.T) {
+	meta := newTestMetadataV15(t)
+
+	method, err := meta.FindRuntimeAPIMethod("TestApi", "info")
+	require.NoError(t, err)
+
+	decoder, err := NewRuntimeAPIResultDecoder(meta, method)
+	require.NoError(t, err)
+
+	res, err := decoder.Decode([]byte{7, 0, 0, 0})
+	assert.True(t, errors.Is(err, ErrRuntimeAPIResultDecoding))
+	assert.Nil(t, res)
+}
+
+func TestRuntimeAPIResultDecoder_TrailingBytes(t *testing.T) {
+	meta := newTestMetadataV15(t)
+
+	method, err := meta.FindRuntimeAPIMethod("AccountNonceApi", "account_nonce")
+	require.NoError(t, err)
+
+	decoder, err := NewRuntimeAPIResultDecoder(meta, method)
+	require.NoError(t, err)
+
+	res, err := decoder.Decode([]byte{7, 0, 0, 0, 0})
+	assert.True(t, errors.Is(err, ErrRuntimeAPIResultTrailingBytes))
+	assert.Nil(t, res)
+}
+
+func TestNewRuntimeAPIResultDecoder_OutputTypeNotFound(t *testing.T) {
+	meta := newTestMetadataV15(t)
+
+	method := &types.RuntimeAPIMethodMetadataV15{
+		Name:   "unknown_output",
+		Output: types.NewSi1LookupTypeIDFromUInt(99),
+	}
+
+	res, err := NewRuntimeAPIResultDecoder(meta, method)
+	assert.True(t, errors.Is(err, ErrRuntimeAPIOutputFieldsRetrieval))
+	assert.Nil(t, res)
+}
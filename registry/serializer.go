@@ -0,0 +1,657 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// SerializationStyle selects the representation of the values of a ValueSerializer, following the toJSON and toHuman
+// methods of the polkadot-js codecs.
+type SerializationStyle int
+
+const (
+	// JSONStyle follows toJSON: integers up to 32 bits are numbers and larger integers decimal strings, bytes are hex
+	// strings, enums without fields the name of their variant and other enums an object with the camel case name of
+	// the variant as key.
+	JSONStyle SerializationStyle = iota
+
+	// HumanStyle follows toHuman: integers are decimal strings with thousands separators, byte sequences that are
+	// printable ASCII are text and the keys of enums are the names of their variants.
+	HumanStyle
+)
+
+// SerializerOptions configure a ValueSerializer.
+type SerializerOptions struct {
+	// Style is the representation of the values, JSONStyle by default.
+	Style SerializationStyle
+
+	// SS58Prefix is the network prefix of the SS58 addresses of accounts, 0 i.e. Polkadot by default.
+	SS58Prefix uint16
+}
+
+// ValueSerializer serializes the values decoded by the registries to JSON. Unlike the encoding/json package, it
+// uses the types of the metadata:
+//   - bytes, i.e. sequences and arrays of u8, are 0x-prefixed hex strings
+//   - integers of 64 bits and more, including compacts, are decimal strings, since they exceed the precision of JSON
+//     numbers in most consumers
+//   - AccountId32 values, and types.AccountID values of field overrides, are SS58 addresses
+//   - enum values are named after their variant, Option values are null or their inner value
+//   - fields keep the order of the metadata, named fields are objects with camel case keys and unnamed fields arrays
+//
+// Values whose type is not known, e.g. values of field overrides, are serialized by their Go type following the same
+// conventions. Variants with fields are matched by the names and types of their fields, if several variants match,
+// only the fields are serialized.
+type ValueSerializer struct {
+	lookup map[int64]*types.Si1Type
+	opts   SerializerOptions
+}
+
+// NewValueSerializer creates a ValueSerializer for the values decoded with the metadata.
+func NewValueSerializer(meta *types.Metadata, opts SerializerOptions) *ValueSerializer {
+	return &ValueSerializer{
+		lookup: meta.AsMetadataV14.EfficientLookup,
+		opts:   opts,
+	}
+}
+
+// MarshalFields returns the JSON of decoded fields, e.g. the fields of an event or a call. Fields are an object if
+// all of them are named, an array otherwise.
+func (s *ValueSerializer) MarshalFields(fields DecodedFields) ([]byte, error) {
+	value, err := s.decodedFields(fields)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalSerialized(value)
+}
+
+// MarshalValue returns the JSON of a value decoded from the type with the lookup index.
+func (s *ValueSerializer) MarshalValue(value any, lookupIndex int64) ([]byte, error) {
+	serialized, err := s.value(value, lookupIndex)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalSerialized(serialized)
+}
+
+func marshalSerialized(value any) ([]byte, error) {
+	b, err := json.Marshal(value)
+
+	if err != nil {
+		return nil, ErrValueSerialization.Wrap(err)
+	}
+
+	return b, nil
+}
+
+// orderedObject is a JSON object whose keys keep their order.
+type orderedObject struct {
+	keys   []string
+	values []any
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+
+	for i, key := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		encodedKey, err := json.Marshal(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		encodedValue, err := json.Marshal(o.values[i])
+
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(encodedKey)
+		b.WriteByte(':')
+		b.Write(encodedValue)
+	}
+
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+// fieldNameRegex matches the names of named fields, unnamed fields are named after their type or lookup index.
+var fieldNameRegex = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// decodedFields serializes fields whose types are only known by their lookup index.
+func (s *ValueSerializer) decodedFields(fields DecodedFields) (any, error) {
+	named := len(fields) > 0
+	object := orderedObject{}
+	items := make([]any, 0, len(fields))
+
+	for _, field := range fields {
+		name := field.Name
+
+		if t, ok := s.lookup[field.LookupIndex]; ok {
+			if path := getFieldPath(t); path != "" {
+				name = strings.TrimPrefix(name, path+fieldSeparator)
+			}
+		}
+
+		named = named && fieldNameRegex.MatchString(name) && !strings.HasPrefix(name, "lookup_index_")
+
+		value, err := s.value(field.Value, field.LookupIndex)
+
+		if err != nil {
+			return nil, err
+		}
+
+		object.keys = append(object.keys, camelCase(name))
+		object.values = append(object.values, value)
+		items = append(items, value)
+	}
+
+	if named {
+		return object, nil
+	}
+
+	return items, nil
+}
+
+func (s *ValueSerializer) value(value any, typeID int64) (any, error) {
+	t, ok := s.lookup[typeID]
+
+	if !ok {
+		return s.untyped(value)
+	}
+
+	switch def := t.Def; {
+	case def.IsComposite:
+		return s.composite(value, t)
+	case def.IsVariant:
+		return s.variant(value, t)
+	case def.IsSequence:
+		return s.items(value, def.Sequence.Type.Int64(), true)
+	case def.IsArray:
+		return s.items(value, def.Array.Type.Int64(), false)
+	case def.IsTuple:
+		return s.tuple(value, def.Tuple)
+	case def.IsCompact:
+		// Compact integers are decoded as types.UCompact, which the primitive of the compact type serializes.
+		return s.value(value, def.Compact.Type.Int64())
+	case def.IsPrimitive:
+		return s.primitive(value, def.Primitive.Si0TypeDefPrimitive)
+	default:
+		return s.untyped(value)
+	}
+}
+
+func (s *ValueSerializer) composite(value any, t *types.Si1Type) (any, error) {
+	if isAccountID(t) {
+		if accountID, ok := accountIDOf(value); ok {
+			return s.address(accountID)
+		}
+	}
+
+	decoded, ok := value.(DecodedFields)
+
+	if !ok || len(decoded) != len(t.Def.Composite.Fields) {
+		return s.untyped(value)
+	}
+
+	return s.fields(decoded, t.Def.Composite.Fields)
+}
+
+// fields serializes the fields of a composite or a variant: named fields as object, a single unnamed field as its
+// value and several unnamed fields as array.
+func (s *ValueSerializer) fields(decoded DecodedFields, fields []types.Si1Field) (any, error) {
+	if len(fields) == 1 && !fields[0].HasName {
+		return s.value(decoded[0].Value, fields[0].Type.Int64())
+	}
+
+	named := true
+	object := orderedObject{}
+	items := make([]any, 0, len(fields))
+
+	for i, field := range fields {
+		value, err := s.value(decoded[i].Value, field.Type.Int64())
+
+		if err != nil {
+			return nil, err
+		}
+
+		named = named && field.HasName
+
+		object.keys = append(object.keys, camelCase(string(field.Name)))
+		object.values = append(object.values, value)
+		items = append(items, value)
+	}
+
+	if named {
+		return object, nil
+	}
+
+	return items, nil
+}
+
+func (s *ValueSerializer) variant(value any, t *types.Si1Type) (any, error) {
+	variants := t.Def.Variant.Variants
+
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case byte:
+		for _, variant := range variants {
+			if byte(variant.Index) != v {
+				continue
+			}
+
+			switch {
+			case isOption(t):
+				return nil, nil
+			case !hasVariantFields(variants):
+				return string(variant.Name), nil
+			default:
+				return orderedObject{keys: []string{s.variantKey(variant)}, values: []any{nil}}, nil
+			}
+		}
+
+		return s.untyped(value)
+	case DecodedFields:
+		variant, ok := s.matchVariant(variants, v)
+
+		if !ok {
+			return s.decodedFields(v)
+		}
+
+		fields, err := s.fields(v, variant.Fields)
+
+		if err != nil || isOption(t) {
+			return fields, err
+		}
+
+		return orderedObject{keys: []string{s.variantKey(variant)}, values: []any{fields}}, nil
+	default:
+		return s.untyped(value)
+	}
+}
+
+// matchVariant returns the only variant whose fields have the names and types of the decoded fields.
+func (s *ValueSerializer) matchVariant(variants []types.Si1Variant, decoded DecodedFields) (types.Si1Variant, bool) {
+	var (
+		match   types.Si1Variant
+		matches int
+	)
+
+	for _, variant := range variants {
+		if len(variant.Fields) == 0 || len(variant.Fields) != len(decoded) {
+			continue
+		}
+
+		matched := true
+
+		for i, field := range variant.Fields {
+			fieldType, ok := s.lookup[field.Type.Int64()]
+
+			if !ok || decoded[i].LookupIndex != field.Type.Int64() ||
+				decoded[i].Name != getFullFieldName(field, fieldType) {
+				matched = false
+
+				break
+			}
+		}
+
+		if matched {
+			match = variant
+			matches++
+		}
+	}
+
+	return match, matches == 1
+}
+
+func (s *ValueSerializer) variantKey(variant types.Si1Variant) string {
+	if s.opts.Style == HumanStyle {
+		return string(variant.Name)
+	}
+
+	return camelCase(string(variant.Name))
+}
+
+func (s *ValueSerializer) items(value any, itemTypeID int64, sequence bool) (any, error) {
+	if itemType, ok := s.lookup[itemTypeID]; ok && itemType.Def.IsPrimitive &&
+		itemType.Def.Primitive.Si0TypeDefPrimitive == types.IsU8 {
+		if b, ok := bytesOf(value); ok {
+			if sequence && s.opts.Style == HumanStyle && isPrintable(b) {
+				return string(b), nil
+			}
+
+			return codec.HexEncodeToString(b), nil
+		}
+	}
+
+	values, ok := value.([]any)
+
+	if !ok {
+		return s.untyped(value)
+	}
+
+	items := make([]any, 0, len(values))
+
+	for _, item := range values {
+		serialized, err := s.value(item, itemTypeID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, serialized)
+	}
+
+	return items, nil
+}
+
+func (s *ValueSerializer) tuple(value any, tuple types.Si1TypeDefTuple) (any, error) {
+	if len(tuple) == 0 {
+		return nil, nil
+	}
+
+	decoded, ok := value.(DecodedFields)
+
+	if !ok || len(decoded) != len(tuple) {
+		return s.untyped(value)
+	}
+
+	items := make([]any, 0, len(tuple))
+
+	for i, item := range tuple {
+		serialized, err := s.value(decoded[i].Value, item.Int64())
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, serialized)
+	}
+
+	return items, nil
+}
+
+func (s *ValueSerializer) primitive(value any, primitive types.Si0TypeDefPrimitive) (any, error) {
+	if c, ok := value.(byte); ok && primitive == types.IsChar {
+		return string(rune(c)), nil
+	}
+
+	if n, _, ok := integerOf(value); ok {
+		if size, _, ok := integerSize(primitive); ok {
+			return s.integer(n, size), nil
+		}
+	}
+
+	return s.untyped(value)
+}
+
+// untyped serializes a value by its Go type.
+func (s *ValueSerializer) untyped(value any) (any, error) {
+	if n, size, ok := integerOf(value); ok {
+		return s.integer(n, size), nil
+	}
+
+	if accountID, ok := value.(types.AccountID); ok {
+		return s.address(accountID)
+	}
+
+	if b, ok := bytesOf(value); ok {
+		return codec.HexEncodeToString(b), nil
+	}
+
+	switch v := value.(type) {
+	case DecodedFields:
+		return s.decodedFields(v)
+	case []any:
+		items := make([]any, 0, len(v))
+
+		for _, item := range v {
+			serialized, err := s.untyped(item)
+
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, serialized)
+		}
+
+		return items, nil
+	case map[string]string:
+		// Bit sequences are decoded as their bits by field name.
+		keys := make([]string, 0, len(v))
+
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		if len(keys) == 1 {
+			return v[keys[0]], nil
+		}
+
+		sort.Strings(keys)
+
+		object := orderedObject{}
+
+		for _, key := range keys {
+			object.keys = append(object.keys, key)
+			object.values = append(object.values, v[key])
+		}
+
+		return object, nil
+	default:
+		return value, nil
+	}
+}
+
+func (s *ValueSerializer) integer(n *big.Int, size int) any {
+	if s.opts.Style == HumanStyle {
+		return formatThousands(n.String())
+	}
+
+	if size <= 4 {
+		return json.Number(n.String())
+	}
+
+	return n.String()
+}
+
+func (s *ValueSerializer) address(accountID types.AccountID) (any, error) {
+	address, err := types.SS58Encode(s.opts.SS58Prefix, accountID.ToBytes())
+
+	if err != nil {
+		return nil, ErrValueSerialization.WithMsg("account %s", accountID.ToHexString()).Wrap(err)
+	}
+
+	return address, nil
+}
+
+// integerOf returns the integer and its size in bytes, compact integers have the size of u128.
+func integerOf(value any) (*big.Int, int, bool) {
+	switch v := value.(type) {
+	case byte:
+		// Fieldless variants are decoded as the byte of their index.
+		return big.NewInt(int64(v)), 1, true
+	case types.U8:
+		return big.NewInt(int64(v)), 1, true
+	case types.U16:
+		return big.NewInt(int64(v)), 2, true
+	case types.U32:
+		return big.NewInt(int64(v)), 4, true
+	case types.U64:
+		return new(big.Int).SetUint64(uint64(v)), 8, true
+	case types.I8:
+		return big.NewInt(int64(v)), 1, true
+	case types.I16:
+		return big.NewInt(int64(v)), 2, true
+	case types.I32:
+		return big.NewInt(int64(v)), 4, true
+	case types.I64:
+		return big.NewInt(int64(v)), 8, true
+	case types.U128:
+		return bigIntOrZero(v.Int), 16, true
+	case types.I128:
+		return bigIntOrZero(v.Int), 16, true
+	case types.U256:
+		return bigIntOrZero(v.Int), 32, true
+	case types.I256:
+		return bigIntOrZero(v.Int), 32, true
+	case types.UCompact:
+		return new(big.Int).Set((*big.Int)(&v)), 16, true
+	default:
+		return nil, 0, false
+	}
+}
+
+func bigIntOrZero(n *big.Int) *big.Int {
+	if n == nil {
+		return new(big.Int)
+	}
+
+	return n
+}
+
+// bytesOf returns the bytes of byte slices and arrays and of decoded sequences of u8.
+func bytesOf(value any) ([]byte, bool) {
+	if items, ok := value.([]any); ok {
+		if len(items) == 0 {
+			return nil, false
+		}
+
+		b := make([]byte, 0, len(items))
+
+		for _, item := range items {
+			u8, ok := item.(types.U8)
+
+			if !ok {
+				return nil, false
+			}
+
+			b = append(b, byte(u8))
+		}
+
+		return b, true
+	}
+
+	v := reflect.ValueOf(value)
+
+	if !v.IsValid() || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) ||
+		v.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+
+	b := make([]byte, v.Len())
+
+	reflect.Copy(reflect.ValueOf(b), v)
+
+	return b, true
+}
+
+// accountIDOf returns the account of a decoded AccountId32, whose only field holds its 32 bytes.
+func accountIDOf(value any) (types.AccountID, bool) {
+	if accountID, ok := value.(types.AccountID); ok {
+		return accountID, true
+	}
+
+	decoded, ok := value.(DecodedFields)
+
+	if !ok || len(decoded) != 1 {
+		return types.AccountID{}, false
+	}
+
+	b, ok := bytesOf(decoded[0].Value)
+
+	if !ok || len(b) != types.AccountIDLen {
+		return types.AccountID{}, false
+	}
+
+	return types.AccountID(b), true
+}
+
+func hasVariantFields(variants []types.Si1Variant) bool {
+	for _, variant := range variants {
+		if len(variant.Fields) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPrintable returns true for non-empty ASCII text.
+func isPrintable(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+
+	for _, c := range b {
+		if (c < 0x20 || c > 0x7e) && c != '\t' && c != '\n' && c != '\r' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// camelCase converts snake case field names and pascal case variant names, e.g. dispatch_info or ExtrinsicSuccess,
+// to camel case as polkadot-js does.
+func camelCase(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+
+	for i, part := range parts {
+		switch {
+		case i > 0:
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		case strings.ToUpper(part) == part:
+			b.WriteString(strings.ToLower(part))
+		default:
+			b.WriteString(strings.ToLower(part[:1]) + part[1:])
+		}
+	}
+
+	return b.String()
+}
+
+// formatThousands inserts thousands separators into a decimal integer, e.g. 1,000,000.
+func formatThousands(decimal string) string {
+	sign := ""
+
+	if strings.HasPrefix(decimal, "-") {
+		sign, decimal = "-", decimal[1:]
+	}
+
+	var b strings.Builder
+
+	for i, digit := range decimal {
+		if i > 0 && (len(decimal)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+
+		b.WriteRune(digit)
+	}
+
+	return sign + b.String()
+}
@@ -0,0 +1,296 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the serializer")
+
+// serializerTestEvent is an event of the Polkadot metadata with its SCALE encoded fields.
+type serializerTestEvent struct {
+	name    string
+	eventID types.EventID
+	fields  []any
+}
+
+func serializerTestEvents(t *testing.T) []serializerTestEvent {
+	alice, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	bob, err := types.NewAccountIDFromHexString("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+	require.NoError(t, err)
+
+	amount, ok := new(big.Int).SetString("12345678901234567890123", 10)
+	require.True(t, ok)
+
+	dispatchInfo := []any{
+		types.NewUCompactFromUInt(248_117_000),
+		types.NewUCompactFromUInt(3_593),
+		types.U8(1), // Operational
+		types.U8(0), // Pays::Yes
+	}
+
+	return []serializerTestEvent{
+		{
+			name:    "System.ExtrinsicSuccess",
+			eventID: types.EventID{0, 0},
+			fields:  dispatchInfo,
+		},
+		{
+			name:    "System.ExtrinsicFailed",
+			eventID: types.EventID{0, 1},
+			fields: append([]any{
+				types.U8(3), // Module
+				types.U8(5),
+				[4]types.U8{2, 0, 0, 0},
+			}, dispatchInfo...),
+		},
+		{
+			name:    "System.Remarked",
+			eventID: types.EventID{0, 5},
+			fields:  []any{alice, types.NewHash(codec.MustHexDecodeString("0x" + string(bytes.Repeat([]byte("ab"), 32))))},
+		},
+		{
+			name:    "Scheduler.Dispatched",
+			eventID: types.EventID{1, 2},
+			fields: []any{
+				types.U32(15_000_000),
+				types.U32(1),
+				types.NewOption([32]byte{1, 2, 3}),
+				types.U8(1), // Err
+				types.U8(7), // Token
+				types.U8(5), // Frozen
+			},
+		},
+		{
+			name:    "Balances.Transfer",
+			eventID: types.EventID{5, 2},
+			fields:  []any{alice, bob, types.NewU128(*amount)},
+		},
+	}
+}
+
+func TestValueSerializer_MarshalFields(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	eventRegistry, err := NewFactory().CreateEventRegistry(&meta)
+	require.NoError(t, err)
+
+	tests := []struct {
+		golden string
+		opts   SerializerOptions
+	}{
+		{golden: "events_json.golden", opts: SerializerOptions{Style: JSONStyle}},
+		{golden: "events_human.golden", opts: SerializerOptions{Style: HumanStyle}},
+		{golden: "events_json_kusama.golden", opts: SerializerOptions{Style: JSONStyle, SS58Prefix: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			serializer := NewValueSerializer(&meta, tt.opts)
+
+			var out bytes.Buffer
+
+			out.WriteString("[\n")
+
+			for i, event := range serializerTestEvents(t) {
+				fields := decodeSerializerTestEvent(t, eventRegistry, event)
+
+				b, err := serializer.MarshalFields(fields)
+				require.NoError(t, err)
+
+				if i > 0 {
+					out.WriteString(",\n")
+				}
+
+				out.WriteString(`{"name":"` + event.name + `","fields":`)
+				out.Write(b)
+				out.WriteString("}")
+			}
+
+			out.WriteString("\n]")
+
+			var indented bytes.Buffer
+
+			require.NoError(t, json.Indent(&indented, out.Bytes(), "", "  "))
+			indented.WriteString("\n")
+
+			assertGolden(t, filepath.Join("testdata", "serializer", tt.golden), indented.Bytes())
+		})
+	}
+}
+
+func TestValueSerializer_MarshalFields_AccountIDOverride(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	eventRegistry, err := NewFactory().CreateEventRegistry(&meta)
+	require.NoError(t, err)
+
+	overriddenRegistry, err := NewFactory(FieldOverride{
+		FieldLookupIndex: 0, // sp_core::crypto::AccountId32
+		FieldDecoder:     &ValueDecoder[types.AccountID]{},
+	}).CreateEventRegistry(&meta)
+	require.NoError(t, err)
+
+	serializer := NewValueSerializer(&meta, SerializerOptions{})
+
+	for _, event := range serializerTestEvents(t) {
+		expected, err := serializer.MarshalFields(decodeSerializerTestEvent(t, eventRegistry, event))
+		require.NoError(t, err)
+
+		actual, err := serializer.MarshalFields(decodeSerializerTestEvent(t, overriddenRegistry, event))
+		require.NoError(t, err)
+
+		assert.JSONEq(t, string(expected), string(actual), event.name)
+	}
+}
+
+func TestValueSerializer_MarshalValue(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	jsonSerializer := NewValueSerializer(&meta, SerializerOptions{Style: JSONStyle})
+	humanSerializer := NewValueSerializer(&meta, SerializerOptions{Style: HumanStyle})
+
+	tests := []struct {
+		name          string
+		value         any
+		lookupIndex   int64
+		expectedJSON  string
+		expectedHuman string
+	}{
+		{
+			name:          "text bytes",
+			value:         []any{types.U8('h'), types.U8('i')},
+			lookupIndex:   12, // Vec<u8>
+			expectedJSON:  `"0x6869"`,
+			expectedHuman: `"hi"`,
+		},
+		{
+			name:          "binary bytes",
+			value:         []any{types.U8(0), types.U8(0xff)},
+			lookupIndex:   12,
+			expectedJSON:  `"0x00ff"`,
+			expectedHuman: `"0x00ff"`,
+		},
+		{
+			name:          "compact u64",
+			value:         types.NewUCompactFromUInt(1_000_000),
+			lookupIndex:   9, // Compact<u64>
+			expectedJSON:  `"1000000"`,
+			expectedHuman: `"1,000,000"`,
+		},
+		{
+			name:          "unknown type",
+			value:         DecodedFields{{Name: "amount", Value: types.U32(7), LookupIndex: -1}},
+			lookupIndex:   -1,
+			expectedJSON:  `{"amount":7}`,
+			expectedHuman: `{"amount":"7"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := jsonSerializer.MarshalValue(tt.value, tt.lookupIndex)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedJSON, string(b))
+
+			b, err = humanSerializer.MarshalValue(tt.value, tt.lookupIndex)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedHuman, string(b))
+		})
+	}
+}
+
+func TestValueSerializer_MarshalValue_InvalidSS58Prefix(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	serializer := NewValueSerializer(&meta, SerializerOptions{SS58Prefix: 16384})
+
+	_, err = serializer.MarshalValue(types.AccountID{}, 0)
+	assert.ErrorIs(t, err, ErrValueSerialization)
+}
+
+func TestCamelCase(t *testing.T) {
+	for name, expected := range map[string]string{
+		"dispatch_info":    "dispatchInfo",
+		"ExtrinsicSuccess": "extrinsicSuccess",
+		"ID":               "id",
+		"XcmV3":            "xcmV3",
+		"proof_size":       "proofSize",
+	} {
+		assert.Equal(t, expected, camelCase(name), name)
+	}
+}
+
+func TestFormatThousands(t *testing.T) {
+	for decimal, expected := range map[string]string{
+		"0":        "0",
+		"999":      "999",
+		"1000":     "1,000",
+		"-1234567": "-1,234,567",
+	} {
+		assert.Equal(t, expected, formatThousands(decimal), decimal)
+	}
+}
+
+func decodeSerializerTestEvent(t *testing.T, eventRegistry EventRegistry, event serializerTestEvent) DecodedFields {
+	var encoded []byte
+
+	for _, field := range event.fields {
+		b, err := codec.Encode(field)
+		require.NoError(t, err)
+
+		encoded = append(encoded, b...)
+	}
+
+	eventDecoder, ok := eventRegistry[event.eventID]
+	require.True(t, ok, event.name)
+	require.Equal(t, event.name, eventDecoder.Name)
+
+	decoder := scale.NewDecoder(bytes.NewReader(encoded))
+
+	fields, err := eventDecoder.Decode(decoder)
+	require.NoError(t, err, event.name)
+
+	_, err = decoder.ReadOneByte()
+	require.Error(t, err, "%s has trailing bytes", event.name)
+
+	return fields
+}
+
+func assertGolden(t *testing.T, path string, actual []byte) {
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(path, actual, 0o644))
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), string(actual), "run the tests with -update to update the golden files")
+}
@@ -0,0 +1,240 @@
+package registry
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	sessionPalletName  = "Session"
+	setKeysCallName    = "set_keys"
+	setKeysKeysArgName = "keys"
+
+	queuedKeysStorageName = "QueuedKeys"
+)
+
+// SessionKey is a single public key of the session keys of a validator.
+type SessionKey struct {
+	// Name is the name of the key in the runtime's SessionKeys type, e.g. grandpa or babe.
+	Name      string
+	PublicKey types.Bytes
+}
+
+// QueuedSessionKeys are the session keys of a validator for the next session, see DecodeQueuedKeys.
+type QueuedSessionKeys struct {
+	// ValidatorID is the encoded ValidatorId of the runtime, usually an AccountId32.
+	ValidatorID types.Bytes
+	Keys        []SessionKey
+}
+
+// sessionKeysDecoder splits encoded session keys into the named keys of the runtime's SessionKeys type.
+type sessionKeysDecoder struct {
+	keysType  *types.Si1Type
+	keyFields []*Field
+}
+
+func newSessionKeysDecoder(f *factory, meta *types.Metadata) (*sessionKeysDecoder, error) {
+	keysType, err := getSessionKeysType(meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keyFields, err := f.getTypeFields(meta, keysType.Def.Composite.Fields)
+
+	if err != nil {
+		return nil, ErrSessionKeysFieldsRetrieval.Wrap(err)
+	}
+
+	return &sessionKeysDecoder{keysType: keysType, keyFields: keyFields}, nil
+}
+
+// decode decodes the session keys at the position of the reader, which reads data.
+func (d *sessionKeysDecoder) decode(data []byte, reader *bytes.Reader, decoder *scale.Decoder) ([]SessionKey, error) {
+	keys := make([]SessionKey, 0, len(d.keyFields))
+
+	for i, keyField := range d.keyFields {
+		start := len(data) - reader.Len()
+
+		if _, err := keyField.FieldDecoder.Decode(decoder); err != nil {
+			return nil, ErrSessionKeyDecoding.WithMsg(keyField.Name).Wrap(err)
+		}
+
+		keys = append(keys, SessionKey{
+			Name:      getFieldName(d.keysType.Def.Composite.Fields[i]),
+			PublicKey: data[start : len(data)-reader.Len()],
+		})
+	}
+
+	return keys, nil
+}
+
+// DecodeSessionKeys splits the concatenated public keys returned by author_rotateKeys into the named keys of the
+// runtime's SessionKeys type. The layout of the keys differs per chain, so it is read from the keys argument of the
+// Session.set_keys call in the metadata.
+func DecodeSessionKeys(meta *types.Metadata, sessionKeys []byte) ([]SessionKey, error) {
+	f := &factory{}
+	f.resetStorages()
+
+	keysDecoder, err := newSessionKeysDecoder(f, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.resolveRecursiveDecoders(); err != nil {
+		return nil, ErrRecursiveDecodersResolving.Wrap(err)
+	}
+
+	reader := bytes.NewReader(sessionKeys)
+
+	keys, err := keysDecoder.decode(sessionKeys, reader, scale.NewDecoder(reader))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if reader.Len() > 0 {
+		return nil, ErrSessionKeysTrailingBytes.WithMsg("%d bytes", reader.Len())
+	}
+
+	return keys, nil
+}
+
+// DecodeQueuedKeys decodes the value of the Session.QueuedKeys storage, which holds the validators of the next session
+// with their session keys. The keys are split like by DecodeSessionKeys.
+func DecodeQueuedKeys(meta *types.Metadata, queuedKeys []byte) ([]QueuedSessionKeys, error) {
+	validatorIDTypeID, err := getQueuedKeysValidatorIDType(meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f := &factory{}
+	f.resetStorages()
+
+	validatorIDFields, err := f.getTypeFields(meta, []types.Si1Field{{Type: validatorIDTypeID}})
+
+	if err != nil {
+		return nil, ErrQueuedKeysFieldsRetrieval.Wrap(err)
+	}
+
+	keysDecoder, err := newSessionKeysDecoder(f, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.resolveRecursiveDecoders(); err != nil {
+		return nil, ErrRecursiveDecodersResolving.Wrap(err)
+	}
+
+	reader := bytes.NewReader(queuedKeys)
+	decoder := scale.NewDecoder(reader)
+
+	count, err := decoder.DecodeUintCompact()
+
+	if err != nil {
+		return nil, ErrQueuedKeysDecoding.Wrap(err)
+	}
+
+	if count.Uint64() > uint64(len(queuedKeys)) {
+		return nil, ErrQueuedKeysDecoding.WithMsg("%d validators in %d bytes", count.Uint64(), len(queuedKeys))
+	}
+
+	res := make([]QueuedSessionKeys, 0, count.Uint64())
+
+	for i := uint64(0); i < count.Uint64(); i++ {
+		start := len(queuedKeys) - reader.Len()
+
+		if _, err := validatorIDFields[0].FieldDecoder.Decode(decoder); err != nil {
+			return nil, ErrQueuedKeysDecoding.WithMsg("validator %d", i).Wrap(err)
+		}
+
+		validatorID := queuedKeys[start : len(queuedKeys)-reader.Len()]
+
+		keys, err := keysDecoder.decode(queuedKeys, reader, decoder)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, QueuedSessionKeys{ValidatorID: validatorID, Keys: keys})
+	}
+
+	if reader.Len() > 0 {
+		return nil, ErrSessionKeysTrailingBytes.WithMsg("%d bytes", reader.Len())
+	}
+
+	return res, nil
+}
+
+// getQueuedKeysValidatorIDType returns the type of the validator IDs of the Vec<(ValidatorId, Keys)> value of the
+// Session.QueuedKeys storage.
+func getQueuedKeysValidatorIDType(meta *types.Metadata) (types.Si1LookupTypeID, error) {
+	valueTypeID, ok := getStorageValueTypeID(meta, sessionPalletName, queuedKeysStorageName)
+
+	if !ok {
+		return types.Si1LookupTypeID{}, ErrStorageEntryNotFound.WithMsg("%s.%s", sessionPalletName, queuedKeysStorageName)
+	}
+
+	valueType, ok := meta.AsMetadataV14.EfficientLookup[valueTypeID.Int64()]
+
+	if !ok || !valueType.Def.IsSequence {
+		return types.Si1LookupTypeID{}, ErrQueuedKeysTypeUnexpected.WithMsg("value type '%d'", valueTypeID.Int64())
+	}
+
+	itemType, ok := meta.AsMetadataV14.EfficientLookup[valueType.Def.Sequence.Type.Int64()]
+
+	if !ok || !itemType.Def.IsTuple || len(itemType.Def.Tuple) != 2 {
+		return types.Si1LookupTypeID{}, ErrQueuedKeysTypeUnexpected.WithMsg(
+			"item type '%d'",
+			valueType.Def.Sequence.Type.Int64(),
+		)
+	}
+
+	return itemType.Def.Tuple[0], nil
+}
+
+// getSessionKeysType returns the type of the keys argument of the Session.set_keys call.
+func getSessionKeysType(meta *types.Metadata) (*types.Si1Type, error) {
+	for _, mod := range meta.AsMetadataV14.Pallets {
+		if string(mod.Name) != sessionPalletName || !mod.HasCalls {
+			continue
+		}
+
+		callsType, ok := meta.AsMetadataV14.EfficientLookup[mod.Calls.Type.Int64()]
+
+		if !ok || !callsType.Def.IsVariant {
+			break
+		}
+
+		for _, callVariant := range callsType.Def.Variant.Variants {
+			if string(callVariant.Name) != setKeysCallName {
+				continue
+			}
+
+			for _, arg := range callVariant.Fields {
+				if string(arg.Name) != setKeysKeysArgName {
+					continue
+				}
+
+				keysType, ok := meta.AsMetadataV14.EfficientLookup[arg.Type.Int64()]
+
+				if !ok {
+					return nil, ErrSessionKeysTypeNotFound.WithMsg("keys type '%d'", arg.Type.Int64())
+				}
+
+				if !keysType.Def.IsComposite {
+					return nil, ErrSessionKeysTypeNotComposite
+				}
+
+				return keysType, nil
+			}
+		}
+	}
+
+	return nil, ErrSessionKeysTypeNotFound.WithMsg("no %s.%s call", sessionPalletName, setKeysCallName)
+}
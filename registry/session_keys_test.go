@@ -0,0 +1,117 @@
+package registry
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeSessionKeys(t *testing.T) {
+	var tests = []struct {
+		Chain       string
+		MetadataHex string
+		KeyNames    []string
+	}{
+		{
+			Chain:       "polkadot",
+			MetadataHex: test.PolkadotMetadataHex,
+			KeyNames: []string{
+				"grandpa", "babe", "im_online", "para_validator", "para_assignment", "authority_discovery",
+			},
+		},
+		{
+			Chain:       "centrifuge",
+			MetadataHex: test.CentrifugeMetadataHex,
+			KeyNames:    []string{"aura"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Chain, func(t *testing.T) {
+			var meta types.Metadata
+
+			err := codec.DecodeFromHex(test.MetadataHex, &meta)
+			require.NoError(t, err)
+
+			var sessionKeys []byte
+			for i := range test.KeyNames {
+				sessionKeys = append(sessionKeys, bytes.Repeat([]byte{byte(i + 1)}, 32)...)
+			}
+
+			keys, err := DecodeSessionKeys(&meta, sessionKeys)
+			require.NoError(t, err)
+			require.Len(t, keys, len(test.KeyNames))
+
+			for i, key := range keys {
+				assert.Equal(t, test.KeyNames[i], key.Name)
+				assert.Equal(t, types.Bytes(bytes.Repeat([]byte{byte(i + 1)}, 32)), key.PublicKey)
+			}
+
+			_, err = DecodeSessionKeys(&meta, append(sessionKeys, 0))
+			assert.True(t, errors.Is(err, ErrSessionKeysTrailingBytes))
+
+			_, err = DecodeSessionKeys(&meta, sessionKeys[:len(sessionKeys)-1])
+			assert.True(t, errors.Is(err, ErrSessionKeyDecoding))
+		})
+	}
+}
+
+func TestDecodeSessionKeys_NoSessionPallet(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.MoonbeamMetaHex, &meta)
+	require.NoError(t, err)
+
+	_, err = DecodeSessionKeys(&meta, make([]byte, 32))
+	assert.True(t, errors.Is(err, ErrSessionKeysTypeNotFound))
+}
+
+func TestDecodeQueuedKeys(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
+	require.NoError(t, err)
+
+	// Two validators with their aura keys.
+	queuedKeys := []byte{2 << 2}
+	for i := byte(1); i <= 2; i++ {
+		queuedKeys = append(queuedKeys, bytes.Repeat([]byte{i}, 32)...)
+		queuedKeys = append(queuedKeys, bytes.Repeat([]byte{i + 0x10}, 32)...)
+	}
+
+	res, err := DecodeQueuedKeys(&meta, queuedKeys)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	for i, validator := range res {
+		assert.Equal(t, types.Bytes(bytes.Repeat([]byte{byte(i + 1)}, 32)), validator.ValidatorID)
+		assert.Equal(t, []SessionKey{
+			{Name: "aura", PublicKey: bytes.Repeat([]byte{byte(i + 0x11)}, 32)},
+		}, validator.Keys)
+	}
+
+	res, err = DecodeQueuedKeys(&meta, []byte{0})
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	_, err = DecodeQueuedKeys(&meta, append(queuedKeys, 0))
+	assert.True(t, errors.Is(err, ErrSessionKeysTrailingBytes))
+
+	_, err = DecodeQueuedKeys(&meta, queuedKeys[:len(queuedKeys)-1])
+	assert.True(t, errors.Is(err, ErrSessionKeyDecoding))
+
+	_, err = DecodeQueuedKeys(&meta, []byte{0xfc})
+	assert.True(t, errors.Is(err, ErrQueuedKeysDecoding))
+
+	err = codec.DecodeFromHex(test.MoonbeamMetaHex, &meta)
+	require.NoError(t, err)
+
+	_, err = DecodeQueuedKeys(&meta, []byte{0})
+	assert.True(t, errors.Is(err, ErrStorageEntryNotFound))
+}
@@ -0,0 +1,187 @@
+package registry
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// RegistryEntry is an entry of a CallRegistry, ErrorRegistry or EventRegistry, i.e. the key of a call, error or event
+// with its TypeDecoder.
+type RegistryEntry[K comparable] struct {
+	Key     K
+	Decoder *TypeDecoder
+}
+
+// PalletEntries are the entries of a registry that belong to one pallet, ordered by their variant index.
+type PalletEntries[K comparable] struct {
+	PalletIndex uint8
+	PalletName  string
+	Entries     []RegistryEntry[K]
+}
+
+// SortedEntries returns the calls ordered by pallet index and call index, i.e. in the order of the metadata.
+func (r CallRegistry) SortedEntries() []RegistryEntry[types.CallIndex] {
+	return sortedEntries(r, callIndexSortKey)
+}
+
+// SortedNames returns the names of the calls, e.g. Balances.transfer, in the order of SortedEntries.
+func (r CallRegistry) SortedNames() []string {
+	return entryNames(r.SortedEntries())
+}
+
+// ForEachPallet passes the calls of each pallet to fn, ordered by pallet index. If fn returns scale.ErrStopStream,
+// the iteration stops without an error.
+func (r CallRegistry) ForEachPallet(fn func(pallet PalletEntries[types.CallIndex]) error) error {
+	return forEachPallet(r, callIndexSortKey, fn)
+}
+
+// SortedEntries returns the errors ordered by pallet index and error index, i.e. in the order of the metadata.
+func (r ErrorRegistry) SortedEntries() []RegistryEntry[ErrorID] {
+	return sortedEntries(r, errorIDSortKey)
+}
+
+// SortedNames returns the names of the errors, e.g. Balances.InsufficientBalance, in the order of SortedEntries.
+func (r ErrorRegistry) SortedNames() []string {
+	return entryNames(r.SortedEntries())
+}
+
+// ForEachPallet passes the errors of each pallet to fn, ordered by pallet index. If fn returns scale.ErrStopStream,
+// the iteration stops without an error.
+func (r ErrorRegistry) ForEachPallet(fn func(pallet PalletEntries[ErrorID]) error) error {
+	return forEachPallet(r, errorIDSortKey, fn)
+}
+
+// SortedEntries returns the events ordered by their event ID, i.e. by pallet index and event index as in the
+// metadata.
+func (r EventRegistry) SortedEntries() []RegistryEntry[types.EventID] {
+	return sortedEntries(r, eventIDSortKey)
+}
+
+// SortedNames returns the names of the events, e.g. System.ExtrinsicSuccess, in the order of SortedEntries.
+func (r EventRegistry) SortedNames() []string {
+	return entryNames(r.SortedEntries())
+}
+
+// ForEachPallet passes the events of each pallet to fn, ordered by pallet index. If fn returns scale.ErrStopStream,
+// the iteration stops without an error.
+func (r EventRegistry) ForEachPallet(fn func(pallet PalletEntries[types.EventID]) error) error {
+	return forEachPallet(r, eventIDSortKey, fn)
+}
+
+// Page returns the items from offset up to limit items, e.g. a page of SortedEntries. All items from offset are
+// returned if limit is not positive.
+func Page[T any](items []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+
+	if offset >= len(items) {
+		return nil
+	}
+
+	items = items[offset:]
+
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+
+	return items
+}
+
+// sortKey orders the keys of a registry, its first byte is the pallet index followed by the variant index.
+type sortKey [5]byte
+
+func callIndexSortKey(callIndex types.CallIndex) sortKey {
+	return sortKey{callIndex.SectionIndex, callIndex.MethodIndex}
+}
+
+func errorIDSortKey(errorID ErrorID) sortKey {
+	return sortKey{
+		byte(errorID.ModuleIndex),
+		byte(errorID.ErrorIndex[0]),
+		byte(errorID.ErrorIndex[1]),
+		byte(errorID.ErrorIndex[2]),
+		byte(errorID.ErrorIndex[3]),
+	}
+}
+
+func eventIDSortKey(eventID types.EventID) sortKey {
+	return sortKey{eventID[0], eventID[1]}
+}
+
+func sortedEntries[K comparable](registry map[K]*TypeDecoder, key func(K) sortKey) []RegistryEntry[K] {
+	entries := make([]RegistryEntry[K], 0, len(registry))
+
+	for k, decoder := range registry {
+		entries = append(entries, RegistryEntry[K]{Key: k, Decoder: decoder})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := key(entries[i].Key), key(entries[j].Key)
+
+		return bytes.Compare(a[:], b[:]) < 0
+	})
+
+	return entries
+}
+
+func forEachPallet[K comparable](
+	registry map[K]*TypeDecoder,
+	key func(K) sortKey,
+	fn func(pallet PalletEntries[K]) error,
+) error {
+	entries := sortedEntries(registry, key)
+
+	for start := 0; start < len(entries); {
+		palletIndex := key(entries[start].Key)[0]
+
+		end := start + 1
+		for end < len(entries) && key(entries[end].Key)[0] == palletIndex {
+			end++
+		}
+
+		pallet := PalletEntries[K]{
+			PalletIndex: palletIndex,
+			PalletName:  palletName(entries[start].Decoder),
+			Entries:     entries[start:end],
+		}
+
+		if err := fn(pallet); err != nil {
+			if errors.Is(err, scale.ErrStopStream) {
+				return nil
+			}
+
+			return err
+		}
+
+		start = end
+	}
+
+	return nil
+}
+
+func entryNames[K comparable](entries []RegistryEntry[K]) []string {
+	names := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		names = append(names, entry.Decoder.Name)
+	}
+
+	return names
+}
+
+// palletName returns the pallet of the decoder name, e.g. Balances for Balances.transfer.
+func palletName(decoder *TypeDecoder) string {
+	if decoder == nil {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(decoder.Name, ".")
+
+	return name
+}
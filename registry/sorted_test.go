@@ -0,0 +1,158 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getMetadataOrderedNames returns the names of the variants of the pallets in the order of their indices.
+func getMetadataOrderedNames(
+	t *testing.T,
+	meta *types.Metadata,
+	variantsType func(pallet types.PalletMetadataV14) (types.Si1LookupTypeID, bool),
+) []string {
+	pallets := append([]types.PalletMetadataV14{}, meta.AsMetadataV14.Pallets...)
+
+	sort.Slice(pallets, func(i, j int) bool {
+		return pallets[i].Index < pallets[j].Index
+	})
+
+	var names []string
+
+	for _, pallet := range pallets {
+		typeID, ok := variantsType(pallet)
+		if !ok {
+			continue
+		}
+
+		variants := append([]types.Si1Variant{}, meta.AsMetadataV14.EfficientLookup[typeID.Int64()].Def.Variant.Variants...)
+
+		sort.Slice(variants, func(i, j int) bool {
+			return variants[i].Index < variants[j].Index
+		})
+
+		for _, variant := range variants {
+			names = append(names, fmt.Sprintf("%s.%s", pallet.Name, variant.Name))
+		}
+	}
+
+	require.NotEmpty(t, names)
+
+	return names
+}
+
+func TestRegistries_SortedNames(t *testing.T) {
+	var meta types.Metadata
+
+	require.NoError(t, codec.DecodeFromHex(test.PolkadotMetadataHex, &meta))
+
+	callRegistry, err := NewFactory().CreateCallRegistry(&meta)
+	require.NoError(t, err)
+
+	errorRegistry, err := NewFactory().CreateErrorRegistry(&meta)
+	require.NoError(t, err)
+
+	eventRegistry, err := NewFactory().CreateEventRegistry(&meta)
+	require.NoError(t, err)
+
+	callNames := getMetadataOrderedNames(t, &meta, func(pallet types.PalletMetadataV14) (types.Si1LookupTypeID, bool) {
+		return pallet.Calls.Type, pallet.HasCalls
+	})
+	errorNames := getMetadataOrderedNames(t, &meta, func(pallet types.PalletMetadataV14) (types.Si1LookupTypeID, bool) {
+		return pallet.Errors.Type, pallet.HasErrors
+	})
+	eventNames := getMetadataOrderedNames(t, &meta, func(pallet types.PalletMetadataV14) (types.Si1LookupTypeID, bool) {
+		return pallet.Events.Type, pallet.HasEvents
+	})
+
+	// The order is stable across calls.
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, callNames, callRegistry.SortedNames())
+		assert.Equal(t, errorNames, errorRegistry.SortedNames())
+		assert.Equal(t, eventNames, eventRegistry.SortedNames())
+	}
+
+	entries := eventRegistry.SortedEntries()
+	assert.Equal(t, types.EventID{0, 0}, entries[0].Key)
+	assert.Equal(t, "System.ExtrinsicSuccess", entries[0].Decoder.Name)
+}
+
+func TestRegistries_ForEachPallet(t *testing.T) {
+	callRegistry := CallRegistry{
+		{SectionIndex: 5, MethodIndex: 1}: {Name: "Balances.transfer_keep_alive"},
+		{SectionIndex: 5, MethodIndex: 0}: {Name: "Balances.transfer"},
+		{SectionIndex: 0, MethodIndex: 0}: {Name: "System.remark"},
+	}
+
+	var pallets []PalletEntries[types.CallIndex]
+
+	err := callRegistry.ForEachPallet(func(pallet PalletEntries[types.CallIndex]) error {
+		pallets = append(pallets, pallet)
+
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []PalletEntries[types.CallIndex]{
+		{
+			PalletIndex: 0,
+			PalletName:  "System",
+			Entries: []RegistryEntry[types.CallIndex]{
+				{Key: types.CallIndex{SectionIndex: 0, MethodIndex: 0}, Decoder: &TypeDecoder{Name: "System.remark"}},
+			},
+		},
+		{
+			PalletIndex: 5,
+			PalletName:  "Balances",
+			Entries: []RegistryEntry[types.CallIndex]{
+				{Key: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Decoder: &TypeDecoder{Name: "Balances.transfer"}},
+				{
+					Key:     types.CallIndex{SectionIndex: 5, MethodIndex: 1},
+					Decoder: &TypeDecoder{Name: "Balances.transfer_keep_alive"},
+				},
+			},
+		},
+	}, pallets)
+
+	errorRegistry := ErrorRegistry{
+		{ModuleIndex: 5, ErrorIndex: [4]types.U8{2}}: {Name: "Balances.InsufficientBalance"},
+		{ModuleIndex: 0, ErrorIndex: [4]types.U8{1}}: {Name: "System.InvalidSpecName"},
+	}
+
+	var palletNames []string
+
+	err = errorRegistry.ForEachPallet(func(pallet PalletEntries[ErrorID]) error {
+		palletNames = append(palletNames, pallet.PalletName)
+
+		return scale.ErrStopStream
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"System"}, palletNames)
+
+	errCallback := errors.New("callback")
+
+	err = EventRegistry{{1, 0}: {Name: "Indices.IndexAssigned"}}.ForEachPallet(
+		func(pallet PalletEntries[types.EventID]) error {
+			return errCallback
+		},
+	)
+	assert.ErrorIs(t, err, errCallback)
+}
+
+func TestPage(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4}
+
+	assert.Equal(t, []int{0, 1}, Page(items, 0, 2))
+	assert.Equal(t, []int{4}, Page(items, 4, 2))
+	assert.Equal(t, []int{2, 3, 4}, Page(items, 2, 0))
+	assert.Equal(t, []int{0, 1, 2}, Page(items, -1, 3))
+	assert.Nil(t, Page(items, 5, 2))
+}
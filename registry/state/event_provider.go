@@ -1,7 +1,10 @@
 package state
 
 import (
+	"errors"
+
 	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/archive"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
@@ -9,6 +12,8 @@ import (
 const (
 	ErrEventStorageKeyCreation = libErr.Error("event storage key creation")
 	ErrEventStorageRetrieval   = libErr.Error("event storage retrieval")
+	ErrEventStorageQuery       = libErr.Error("event storage query")
+	ErrStartBlockNotAncestor   = libErr.Error("start block is not an ancestor of the end block")
 )
 
 //go:generate mockery --name EventProvider --structname EventProviderMock --filename event_provider_mock.go --inpackage
@@ -16,18 +21,27 @@ const (
 // EventProvider is the interface used for retrieving event data from the storage.
 type EventProvider interface {
 	GetStorageEvents(meta *types.Metadata, blockHash types.Hash) (*types.StorageDataRaw, error)
+	GetStorageEventsRange(meta *types.Metadata, startBlock, endBlock types.Hash) ([]types.StorageChangeSet, error)
 }
 
 // eventProvider implements the EventProvider interface.
 type eventProvider struct {
-	stateRPC state.State
+	stateRPC   state.Provider
+	archiveRPC archive.Archive
 }
 
 // NewEventProvider creates a new EventProvider.
-func NewEventProvider(stateRPC state.State) EventProvider {
+func NewEventProvider(stateRPC state.Provider) EventProvider {
 	return &eventProvider{stateRPC: stateRPC}
 }
 
+// NewEventProviderWithArchive creates a new EventProvider that retrieves event storage ranges via the archive methods
+// if the node advertises them, see archive.Archive.Supported, which unlike state_queryStorage report every block of
+// the range. Otherwise, or if the node does not answer them, the state RPC is used.
+func NewEventProviderWithArchive(stateRPC state.Provider, archiveRPC archive.Archive) EventProvider {
+	return &eventProvider{stateRPC: stateRPC, archiveRPC: archiveRPC}
+}
+
 const (
 	storagePrefix = "System"
 	storageMethod = "Events"
@@ -49,3 +63,94 @@ func (p *eventProvider) GetStorageEvents(meta *types.Metadata, blockHash types.H
 
 	return storageData, nil
 }
+
+// GetStorageEventsRange returns the event storage changes found between the provided start and end blocks,
+// using a single state_queryStorage call, or the archive methods, see NewEventProviderWithArchive.
+func (p *eventProvider) GetStorageEventsRange(
+	meta *types.Metadata,
+	startBlock types.Hash,
+	endBlock types.Hash,
+) ([]types.StorageChangeSet, error) {
+	key, err := types.CreateStorageKey(meta, storagePrefix, storageMethod, nil)
+
+	if err != nil {
+		return nil, ErrEventStorageKeyCreation.Wrap(err)
+	}
+
+	if p.archiveRPC != nil && p.archiveRPC.Supported() {
+		changeSets, err := p.getArchiveStorageEventsRange(key, startBlock, endBlock)
+
+		if !errors.Is(err, archive.ErrNotSupported) {
+			if err != nil {
+				return nil, ErrEventStorageQuery.Wrap(err)
+			}
+
+			return changeSets, nil
+		}
+	}
+
+	changeSets, err := p.stateRPC.QueryStorage([]types.StorageKey{key}, startBlock, endBlock)
+
+	if err != nil {
+		return nil, ErrEventStorageQuery.Wrap(err)
+	}
+
+	return changeSets, nil
+}
+
+// getArchiveStorageEventsRange returns the event storage of every block from the start to the end block via the
+// archive methods. The blocks of the range are found by following the parent hashes from the end block.
+func (p *eventProvider) getArchiveStorageEventsRange(
+	key types.StorageKey,
+	startBlock types.Hash,
+	endBlock types.Hash,
+) ([]types.StorageChangeSet, error) {
+	blockHashes := []types.Hash{endBlock}
+
+	for blockHash := endBlock; blockHash != startBlock; {
+		header, err := p.archiveRPC.Header(blockHash)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Number == 0 {
+			return nil, ErrStartBlockNotAncestor
+		}
+
+		blockHash = header.ParentHash
+		blockHashes = append(blockHashes, blockHash)
+	}
+
+	changeSets := make([]types.StorageChangeSet, 0, len(blockHashes))
+
+	for i := len(blockHashes) - 1; i >= 0; i-- {
+		res, err := p.archiveRPC.Storage(blockHashes[i], []types.ArchiveStorageQueryItem{
+			{Key: key, Type: types.ChainHeadStorageValue},
+		}, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if res.DiscardedItems > 0 {
+			return nil, archive.ErrStorageItemsDiscarded
+		}
+
+		change := types.KeyValueOption{StorageKey: key}
+
+		for _, item := range res.Items {
+			if item.Value != nil {
+				change.HasStorageData = true
+				change.StorageData = *item.Value
+			}
+		}
+
+		changeSets = append(changeSets, types.StorageChangeSet{
+			Block:   blockHashes[i],
+			Changes: []types.KeyValueOption{change},
+		})
+	}
+
+	return changeSets, nil
+}
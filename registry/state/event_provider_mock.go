@@ -35,6 +35,29 @@ func (_m *EventProviderMock) GetStorageEvents(meta *types.Metadata, blockHash ty
 	return r0, r1
 }
 
+// GetStorageEventsRange provides a mock function with given fields: meta, startBlock, endBlock
+func (_m *EventProviderMock) GetStorageEventsRange(meta *types.Metadata, startBlock types.Hash, endBlock types.Hash) ([]types.StorageChangeSet, error) {
+	ret := _m.Called(meta, startBlock, endBlock)
+
+	var r0 []types.StorageChangeSet
+	if rf, ok := ret.Get(0).(func(*types.Metadata, types.Hash, types.Hash) []types.StorageChangeSet); ok {
+		r0 = rf(meta, startBlock, endBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageChangeSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Metadata, types.Hash, types.Hash) error); ok {
+		r1 = rf(meta, startBlock, endBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewEventProviderMockT interface {
 	mock.TestingT
 	Cleanup(func())
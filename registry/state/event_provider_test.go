@@ -4,6 +4,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/archive"
+	archiveMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/archive/mocks"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
@@ -50,3 +52,130 @@ func TestProvider_GetStorageEvents(t *testing.T) {
 	assert.ErrorIs(t, err, ErrEventStorageRetrieval)
 	assert.Nil(t, res)
 }
+
+func TestProvider_GetStorageEventsRange(t *testing.T) {
+	stateRPCMock := mocks.NewState(t)
+
+	provider := NewEventProvider(stateRPCMock)
+
+	startHash := types.Hash{1}
+	endHash := types.Hash{2}
+
+	var testMeta types.Metadata
+
+	// Empty metadata should cause an error when creating the storage key.
+	res, err := provider.GetStorageEventsRange(&testMeta, startHash, endHash)
+	assert.ErrorIs(t, err, ErrEventStorageKeyCreation)
+	assert.Nil(t, res)
+
+	err = codec.DecodeFromHex(types.MetadataV14Data, &testMeta)
+	assert.NoError(t, err)
+
+	storageKey, err := types.CreateStorageKey(&testMeta, storagePrefix, storageMethod, nil)
+	assert.NoError(t, err)
+
+	changeSets := []types.StorageChangeSet{
+		{
+			Block: startHash,
+			Changes: []types.KeyValueOption{
+				{
+					StorageKey:     storageKey,
+					HasStorageData: true,
+					StorageData:    types.StorageDataRaw{0},
+				},
+			},
+		},
+	}
+
+	stateRPCMock.On("QueryStorage", []types.StorageKey{storageKey}, startHash, endHash).
+		Return(changeSets, nil).
+		Once()
+
+	res, err = provider.GetStorageEventsRange(&testMeta, startHash, endHash)
+	assert.NoError(t, err)
+	assert.Equal(t, changeSets, res)
+
+	stateRPCError := errors.New("error")
+
+	stateRPCMock.On("QueryStorage", []types.StorageKey{storageKey}, startHash, endHash).
+		Return(nil, stateRPCError).
+		Once()
+
+	res, err = provider.GetStorageEventsRange(&testMeta, startHash, endHash)
+	assert.ErrorIs(t, err, ErrEventStorageQuery)
+	assert.Nil(t, res)
+}
+
+func TestProvider_GetStorageEventsRange_Archive(t *testing.T) {
+	stateRPCMock := mocks.NewState(t)
+	archiveRPCMock := archiveMocks.NewArchive(t)
+
+	provider := NewEventProviderWithArchive(stateRPCMock, archiveRPCMock)
+
+	var testMeta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &testMeta)
+	assert.NoError(t, err)
+
+	storageKey, err := types.CreateStorageKey(&testMeta, storagePrefix, storageMethod, nil)
+	assert.NoError(t, err)
+
+	startHash := types.Hash{1}
+	middleHash := types.Hash{2}
+	endHash := types.Hash{3}
+
+	archiveRPCMock.On("Supported").Return(true)
+	archiveRPCMock.On("Header", endHash).
+		Return(&types.Header{Number: 3, ParentHash: middleHash}, nil).
+		Once()
+	archiveRPCMock.On("Header", middleHash).
+		Return(&types.Header{Number: 2, ParentHash: startHash}, nil).
+		Once()
+
+	query := []types.ArchiveStorageQueryItem{{Key: storageKey, Type: types.ChainHeadStorageValue}}
+	value := types.NewStorageDataRaw([]byte{0})
+	valueItems := []types.ChainHeadStorageResultItem{{Key: storageKey, Value: &value}}
+	valueChanges := []types.KeyValueOption{{StorageKey: storageKey, HasStorageData: true, StorageData: value}}
+
+	archiveRPCMock.On("Storage", startHash, query, (*types.StorageKey)(nil)).
+		Return(&types.ArchiveStorageResult{}, nil).
+		Once()
+	archiveRPCMock.On("Storage", middleHash, query, (*types.StorageKey)(nil)).
+		Return(&types.ArchiveStorageResult{Items: valueItems}, nil).
+		Once()
+	archiveRPCMock.On("Storage", endHash, query, (*types.StorageKey)(nil)).
+		Return(&types.ArchiveStorageResult{Items: valueItems}, nil).
+		Once()
+
+	res, err := provider.GetStorageEventsRange(&testMeta, startHash, endHash)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.StorageChangeSet{
+		{Block: startHash, Changes: []types.KeyValueOption{{StorageKey: storageKey}}},
+		{Block: middleHash, Changes: valueChanges},
+		{Block: endHash, Changes: valueChanges},
+	}, res)
+
+	// The start block must be an ancestor of the end block.
+	archiveRPCMock.On("Header", endHash).
+		Return(&types.Header{Number: 0}, nil).
+		Once()
+
+	res, err = provider.GetStorageEventsRange(&testMeta, startHash, endHash)
+	assert.ErrorIs(t, err, ErrStartBlockNotAncestor)
+	assert.Nil(t, res)
+
+	// The state RPC is used if the node does not answer the archive methods.
+	archiveRPCMock.On("Header", endHash).
+		Return(nil, archive.ErrNotSupported).
+		Once()
+
+	changeSets := []types.StorageChangeSet{{Block: endHash}}
+
+	stateRPCMock.On("QueryStorage", []types.StorageKey{storageKey}, startHash, endHash).
+		Return(changeSets, nil).
+		Once()
+
+	res, err = provider.GetStorageEventsRange(&testMeta, startHash, endHash)
+	assert.NoError(t, err)
+	assert.Equal(t, changeSets, res)
+}
@@ -0,0 +1,104 @@
+package registry
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// StorageValueDecoder decodes the values of a storage entry dynamically, based on the value type of the entry in the
+// metadata.
+type StorageValueDecoder struct {
+	Name         string
+	ValueDecoder FieldDecoder
+}
+
+// NewStorageValueDecoder creates a StorageValueDecoder for the storage entry of the pallet.
+func NewStorageValueDecoder(meta *types.Metadata, pallet, entry string) (*StorageValueDecoder, error) {
+	name := pallet + "." + entry
+
+	valueTypeID, ok := getStorageValueTypeID(meta, pallet, entry)
+
+	if !ok {
+		return nil, ErrStorageEntryNotFound.WithMsg(name)
+	}
+
+	f := &factory{}
+	f.resetStorages()
+
+	valueFields, err := f.getTypeFields(meta, []types.Si1Field{{Type: valueTypeID}})
+
+	if err != nil {
+		return nil, ErrStorageValueFieldsRetrieval.WithMsg(name).Wrap(err)
+	}
+
+	if err := f.resolveRecursiveDecoders(); err != nil {
+		return nil, ErrRecursiveDecodersResolving.Wrap(err)
+	}
+
+	return &StorageValueDecoder{
+		Name:         name,
+		ValueDecoder: valueFields[0].FieldDecoder,
+	}, nil
+}
+
+// NewFieldDecoder creates a FieldDecoder for the type with the given ID, e.g. for runtime specific types like the
+// OriginCaller that are part of otherwise statically decoded values.
+func NewFieldDecoder(meta *types.Metadata, typeID types.Si1LookupTypeID) (FieldDecoder, error) {
+	f := &factory{}
+	f.resetStorages()
+
+	fields, err := f.getTypeFields(meta, []types.Si1Field{{Type: typeID}})
+
+	if err != nil {
+		return nil, ErrTypeFieldsRetrieval.WithMsg("type %d", typeID.Int64()).Wrap(err)
+	}
+
+	if err := f.resolveRecursiveDecoders(); err != nil {
+		return nil, ErrRecursiveDecodersResolving.Wrap(err)
+	}
+
+	return fields[0].FieldDecoder, nil
+}
+
+// Decode decodes the SCALE encoded storage value. Composite values are returned as a map of their fields by name, see
+// RuntimeAPIResultDecoder.Decode.
+func (s *StorageValueDecoder) Decode(data []byte) (any, error) {
+	reader := bytes.NewReader(data)
+
+	value, err := s.ValueDecoder.Decode(scale.NewDecoder(reader))
+
+	if err != nil {
+		return nil, ErrStorageValueDecoding.WithMsg(s.Name).Wrap(err)
+	}
+
+	if reader.Len() > 0 {
+		return nil, ErrStorageValueTrailingBytes.WithMsg("%d bytes", reader.Len())
+	}
+
+	return toDynamicValue(value), nil
+}
+
+// getStorageValueTypeID returns the value type of the storage entry of the pallet.
+func getStorageValueTypeID(meta *types.Metadata, pallet, entry string) (types.Si1LookupTypeID, bool) {
+	for _, mod := range meta.AsMetadataV14.Pallets {
+		if string(mod.Name) != pallet || !mod.HasStorage {
+			continue
+		}
+
+		for _, item := range mod.Storage.Items {
+			if string(item.Name) != entry {
+				continue
+			}
+
+			if item.Type.IsMap {
+				return item.Type.AsMap.Value, true
+			}
+
+			return item.Type.AsPlainType, true
+		}
+	}
+
+	return types.Si1LookupTypeID{}, false
+}
@@ -0,0 +1,72 @@
+package registry
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageValueDecoder_Decode(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	decoder, err := NewStorageValueDecoder(&meta, "Timestamp", "Now")
+	require.NoError(t, err)
+	assert.Equal(t, "Timestamp.Now", decoder.Name)
+
+	value, err := decoder.Decode(codec.MustHexDecodeString("0x2a00000000000000"))
+	require.NoError(t, err)
+	assert.Equal(t, types.NewU64(42), value)
+
+	_, err = decoder.Decode(codec.MustHexDecodeString("0x2a0000000000000000"))
+	assert.ErrorIs(t, err, ErrStorageValueTrailingBytes)
+
+	_, err = decoder.Decode([]byte{1})
+	assert.ErrorIs(t, err, ErrStorageValueDecoding)
+
+	decoder, err = NewStorageValueDecoder(&meta, "System", "Account")
+	require.NoError(t, err)
+
+	encodedAccountInfo, err := codec.Encode(types.AccountInfo{Nonce: 3, Providers: 1})
+	require.NoError(t, err)
+
+	value, err = decoder.Decode(encodedAccountInfo)
+	require.NoError(t, err)
+
+	accountInfo, ok := value.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, types.NewU32(3), accountInfo["nonce"])
+	assert.Equal(t, types.NewU32(1), accountInfo["providers"])
+	assert.IsType(t, map[string]any{}, accountInfo["pallet_balances.AccountData.data"])
+
+	_, err = NewStorageValueDecoder(&meta, "System", "Unknown")
+	assert.ErrorIs(t, err, ErrStorageEntryNotFound)
+}
+
+func TestNewFieldDecoder(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	typeID, ok := getStorageValueTypeID(&meta, "Timestamp", "Now")
+	require.True(t, ok)
+
+	fieldDecoder, err := NewFieldDecoder(&meta, typeID)
+	require.NoError(t, err)
+
+	value, err := fieldDecoder.Decode(scale.NewDecoder(bytes.NewReader(codec.MustHexDecodeString("0x2a00000000000000"))))
+	require.NoError(t, err)
+	assert.Equal(t, types.NewU64(42), value)
+
+	_, err = NewFieldDecoder(&meta, types.NewSi1LookupTypeIDFromUInt(1<<30))
+	assert.ErrorIs(t, err, ErrTypeFieldsRetrieval)
+}
@@ -0,0 +1,9 @@
+package test
+
+var (
+	// MetadataV15Hex is a minimal metadata V15, as returned by the Metadata_metadata_at_version runtime API, with the
+	// runtime APIs AccountNonceApi.account_nonce(account: AccountId32) -> u32 and
+	// TestApi.info(account: AccountId32, at: u32) -> pallet_test.Info { nonce: u32, balance: u128 }.
+	// nolint:lll
+	MetadataV15Hex = "0x6d6574610f14000000050500040000050300080c1c73705f636f72651863727970746f2c4163636f756e744964333200032000000004000c000005070010082c70616c6c65745f7465737410496e666f00000801146e6f6e63650001144e6f6e636500011c62616c616e63650c011c42616c616e63650000041853797374656d0000000000000004080000000000083c4163636f756e744e6f6e636541706904346163636f756e745f6e6f6e6365041c6163636f756e74080000001c546573744170690410696e666f081c6163636f756e74080861740010000000000000"
+)
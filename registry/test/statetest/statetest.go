@@ -0,0 +1,129 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package statetest provides the fixtures of the tests of the clients that read the storage of pallets via a mocked
+// state.State.
+package statetest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// State is a mocked state.State with expectations for the storage reads of state.GetStorageEntryLatest and
+// state.GetStorageEntryRawLatest.
+type State struct {
+	*stateMocks.State
+}
+
+// NewState creates a State whose expectations are asserted when the test ends.
+func NewState(t *testing.T) *State {
+	return &State{State: stateMocks.NewState(t)}
+}
+
+// NewClient creates a client of a pallet via its constructor, e.g. staking.NewClient, with a new State.
+func NewClient[C any](t *testing.T, newClient func(stateRPC state.State) C) (C, *State) {
+	stateRPC := NewState(t)
+
+	return newClient(stateRPC), stateRPC
+}
+
+// ExpectStorage expects the latest value of the storage entry of the pallet with the given encoded keys to be read
+// once. The encoded value is decoded into the target, no value is stored if it is nil.
+func (s *State) ExpectStorage(
+	t *testing.T,
+	meta *types.Metadata,
+	pallet, entry string,
+	value interface{},
+	args ...[]byte,
+) {
+	key, err := types.CreateStorageKey(meta, pallet, entry, args...)
+	require.NoError(t, err)
+
+	s.On("GetStorageLatestContext", mock.Anything, key, mock.Anything).
+		Run(func(args mock.Arguments) {
+			if value != nil {
+				require.NoError(t, codec.Decode(Encode(t, value), args.Get(2)))
+			}
+		}).
+		Return(value != nil, nil).
+		Once()
+}
+
+// ExpectStorageRaw is like ExpectStorage but expects the raw value to be read, no value is stored if it is empty.
+func (s *State) ExpectStorageRaw(
+	t *testing.T,
+	meta *types.Metadata,
+	pallet, entry string,
+	raw []byte,
+	args ...[]byte,
+) {
+	key, err := types.CreateStorageKey(meta, pallet, entry, args...)
+	require.NoError(t, err)
+
+	value := types.NewStorageDataRaw(raw)
+
+	s.On("GetStorageRawLatestContext", mock.Anything, key).Return(&value, nil).Once()
+}
+
+// PolkadotMetadata returns the decoded test.PolkadotMetadataHex, which can be modified by the test.
+func PolkadotMetadata(t *testing.T) *types.Metadata {
+	return DecodeMetadata(t, test.PolkadotMetadataHex)
+}
+
+// DecodeMetadata returns the decoded hex encoded metadata, e.g. test.StatemintMetaHex.
+func DecodeMetadata(t *testing.T, metadataHex string) *types.Metadata {
+	var meta types.Metadata
+
+	require.NoError(t, codec.DecodeFromHex(metadataHex, &meta))
+
+	return &meta
+}
+
+// NewStoragePallet returns a pallet with the given storage entries only, to add the storage of pallets that the test
+// metadata lacks.
+func NewStoragePallet(name string, items ...types.StorageEntryMetadataV14) types.PalletMetadataV14 {
+	return types.PalletMetadataV14{
+		Name:       types.Text(name),
+		HasStorage: true,
+		Storage: types.StorageMetadataV14{
+			Prefix: types.Text(name),
+			Items:  items,
+		},
+	}
+}
+
+// Encode returns the concatenated SCALE encodings of the values.
+func Encode(t *testing.T, values ...interface{}) []byte {
+	var buf bytes.Buffer
+
+	for _, value := range values {
+		encoded, err := codec.Encode(value)
+		require.NoError(t, err)
+
+		buf.Write(encoded)
+	}
+
+	return buf.Bytes()
+}
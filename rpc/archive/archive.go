@@ -0,0 +1,152 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name Archive --filename archive.go
+
+package archive
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	ErrNotSupported          = libErr.Error("method not supported by the node")
+	ErrBlockNotFound         = libErr.Error("block not found")
+	ErrCallFailed            = libErr.Error("runtime call failed")
+	ErrStorageItemsDiscarded = libErr.Error("storage items discarded")
+	ErrDescendantsRetrieval  = libErr.Error("descendants retrieval")
+)
+
+// Archive exposes the archive_v1 group of RPC methods, which query the blocks and the state of an archive node by
+// block hash. Unlike the legacy chain_* and state_* methods, they don't require the block to be pinned, which makes
+// them suitable for indexing historical blocks. Nodes that only expose the archive_unstable group that preceded it are
+// queried via the archive_unstable methods instead. Storage is always queried via archive_unstable_storage, as
+// archive_v1_storage returns its results in a different format.
+//
+// Nodes that don't expose these methods cause an ErrNotSupported, Supported tells whether the node advertises them
+// via rpc_methods.
+type Archive interface {
+	Supported() bool
+	SupportedContext(ctx context.Context) bool
+	HashByHeight(height uint64) ([]types.Hash, error)
+	HashByHeightContext(ctx context.Context, height uint64) ([]types.Hash, error)
+	Header(blockHash types.Hash) (*types.Header, error)
+	HeaderContext(ctx context.Context, blockHash types.Hash) (*types.Header, error)
+	Body(blockHash types.Hash) ([]types.Extrinsic, error)
+	BodyContext(ctx context.Context, blockHash types.Hash) ([]types.Extrinsic, error)
+	Call(blockHash types.Hash, function string, params []byte) (types.Bytes, error)
+	CallContext(ctx context.Context, blockHash types.Hash, function string, params []byte) (types.Bytes, error)
+	Storage(
+		blockHash types.Hash,
+		items []types.ArchiveStorageQueryItem,
+		childTrie *types.StorageKey,
+	) (*types.ArchiveStorageResult, error)
+	StorageContext(
+		ctx context.Context,
+		blockHash types.Hash,
+		items []types.ArchiveStorageQueryItem,
+		childTrie *types.StorageKey,
+	) (*types.ArchiveStorageResult, error)
+	ForEachDescendant(
+		ctx context.Context,
+		prefix types.StorageKey,
+		blockHash types.Hash,
+		opts ForEachDescendantOptions,
+		fn func(item types.ChainHeadStorageResultItem) error,
+	) (cursor types.StorageKey, err error)
+}
+
+// archive exposes methods for querying archive nodes
+type archive struct {
+	client client.Client
+
+	// unstable is set once the node turned out to expose the archive_unstable methods only.
+	unstable atomic.Bool
+}
+
+// NewArchive creates a new archive struct
+func NewArchive(cl client.Client) Archive {
+	return &archive{client: cl}
+}
+
+// Prefixes of the archive_v1 methods and of the archive_unstable methods that preceded them.
+const (
+	v1Prefix       = "archive_v1_"
+	unstablePrefix = "archive_unstable_"
+)
+
+// storageMethod is the method that Storage calls, its presence in rpc_methods or the presence of its archive_v1
+// counterpart indicates that the node supports the archive methods.
+const storageMethod = unstablePrefix + "storage"
+
+// Supported returns true if the node advertises the archive_v1 or the archive_unstable methods via rpc_methods. It is
+// false if the client does not know the methods of the node, see client.WithMethodDiscovery, which is used by
+// gsrpc.NewSubstrateAPI.
+func (a *archive) Supported() bool {
+	return a.SupportedContext(context.Background())
+}
+
+// SupportedContext is like Supported but uses the provided context for the rpc_methods call.
+func (a *archive) SupportedContext(ctx context.Context) bool {
+	return client.AdvertisesMethod(ctx, a.client, v1Prefix+"storage") ||
+		client.AdvertisesMethod(ctx, a.client, storageMethod)
+}
+
+// call calls the archive_v1 method with the given name, e.g. "header", and falls back to the archive_unstable one if
+// the node does not expose it.
+func (a *archive) call(ctx context.Context, result interface{}, name string, args ...interface{}) error {
+	if !a.unstable.Load() {
+		err := mapError(a.client.CallContext(ctx, result, v1Prefix+name, args...))
+		if !errors.Is(err, ErrNotSupported) {
+			return err
+		}
+	}
+
+	err := mapError(a.client.CallContext(ctx, result, unstablePrefix+name, args...))
+	if err == nil {
+		a.unstable.Store(true)
+	}
+
+	return err
+}
+
+// mapError returns ErrNotSupported if the node does not expose the called method.
+func mapError(err error) error {
+	if types.IsMethodNotFound(err) || errors.Is(err, client.ErrUnsupportedMethod) {
+		return ErrNotSupported.Wrap(err)
+	}
+
+	return err
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,174 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBlockHash = types.Hash{1, 2, 3}
+
+// testPrefixes are the prefixes of the archive methods that the node is expected to expose, the archive_v1 methods
+// are preferred over the archive_unstable ones.
+var testPrefixes = []string{v1Prefix, unstablePrefix}
+
+func TestArchive_Supported(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient()
+
+	// Methods are unknown without method discovery.
+	assert.False(t, NewArchive(cl).Supported())
+
+	cl.Respond("rpc_methods", types.RPCMethods{Methods: []string{"archive_unstable_storage"}})
+	assert.True(t, NewArchive(client.WithMethodDiscovery(cl)).Supported())
+
+	cl = rpcmocksrv.NewMockClient().
+		Respond("rpc_methods", types.RPCMethods{Methods: []string{"archive_v1_storage"}})
+	assert.True(t, NewArchive(client.WithMethodDiscovery(cl)).Supported())
+
+	cl = rpcmocksrv.NewMockClient().
+		Respond("rpc_methods", types.RPCMethods{Methods: []string{"state_getStorage"}})
+	assert.False(t, NewArchive(client.WithMethodDiscovery(cl)).Supported())
+}
+
+func TestArchive_HashByHeight(t *testing.T) {
+	for _, prefix := range testPrefixes {
+		t.Run(prefix, func(t *testing.T) {
+			cl := rpcmocksrv.NewMockClient().
+				Respond(prefix+"hashByHeight", []string{testBlockHash.Hex()}, 7)
+
+			hashes, err := NewArchive(cl).HashByHeight(7)
+			require.NoError(t, err)
+			assert.Equal(t, []types.Hash{testBlockHash}, hashes)
+
+			_, err = NewArchive(cl).HashByHeight(8)
+			assert.ErrorIs(t, err, ErrNotSupported)
+		})
+	}
+}
+
+func TestArchive_Header(t *testing.T) {
+	header := types.Header{Number: 7, ParentHash: types.Hash{4}}
+
+	enc, err := codec.EncodeToHex(header)
+	require.NoError(t, err)
+
+	unknownHash := types.Hash{9}
+
+	for _, prefix := range testPrefixes {
+		t.Run(prefix, func(t *testing.T) {
+			cl := rpcmocksrv.NewMockClient().
+				Respond(prefix+"header", enc, testBlockHash.Hex()).
+				Respond(prefix+"header", nil, unknownHash.Hex())
+
+			res, err := NewArchive(cl).Header(testBlockHash)
+			require.NoError(t, err)
+			assert.Equal(t, &header, res)
+
+			_, err = NewArchive(cl).Header(unknownHash)
+			assert.ErrorIs(t, err, ErrBlockNotFound)
+		})
+	}
+}
+
+func TestArchive_Body(t *testing.T) {
+	xt := types.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 5}, Args: types.Args{1}})
+
+	enc, err := codec.EncodeToHex(xt)
+	require.NoError(t, err)
+
+	unknownHash := types.Hash{9}
+
+	for _, prefix := range testPrefixes {
+		t.Run(prefix, func(t *testing.T) {
+			cl := rpcmocksrv.NewMockClient().
+				Respond(prefix+"body", []string{enc}, testBlockHash.Hex()).
+				Respond(prefix+"body", nil, unknownHash.Hex())
+
+			res, err := NewArchive(cl).Body(testBlockHash)
+			require.NoError(t, err)
+			assert.Equal(t, []types.Extrinsic{xt}, res)
+
+			_, err = NewArchive(cl).Body(unknownHash)
+			assert.ErrorIs(t, err, ErrBlockNotFound)
+		})
+	}
+}
+
+func TestArchive_Call(t *testing.T) {
+	for _, prefix := range testPrefixes {
+		t.Run(prefix, func(t *testing.T) {
+			cl := rpcmocksrv.NewMockClient().
+				Respond(prefix+"call", types.ArchiveCallResult{Success: true, Value: types.Bytes{7}},
+					testBlockHash.Hex(), "Core_version", "0x01").
+				Respond(prefix+"call", types.ArchiveCallResult{Error: "wasm trap"},
+					testBlockHash.Hex(), "Core_version", "0x02")
+
+			res, err := NewArchive(cl).Call(testBlockHash, "Core_version", []byte{1})
+			require.NoError(t, err)
+			assert.Equal(t, types.Bytes{7}, res)
+
+			_, err = NewArchive(cl).Call(testBlockHash, "Core_version", []byte{2})
+			assert.ErrorIs(t, err, ErrCallFailed)
+			assert.ErrorContains(t, err, "wasm trap")
+		})
+	}
+}
+
+func TestArchive_PrefersV1(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Respond("archive_v1_hashByHeight", []string{testBlockHash.Hex()}, 7).
+		Respond("archive_unstable_hashByHeight", []string{}, 7)
+
+	hashes, err := NewArchive(cl).HashByHeight(7)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Hash{testBlockHash}, hashes)
+
+	cl.AssertNotCalled(t, "archive_unstable_hashByHeight")
+}
+
+func TestArchive_FallsBackToUnstable(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Respond("archive_unstable_hashByHeight", []string{testBlockHash.Hex()}, 7)
+
+	a := NewArchive(cl)
+
+	for i := 0; i < 2; i++ {
+		hashes, err := a.HashByHeight(7)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Hash{testBlockHash}, hashes)
+	}
+
+	// Once the node turned out to expose the archive_unstable methods only, the archive_v1 ones aren't tried again.
+	var methods []string
+
+	for _, call := range cl.Calls() {
+		methods = append(methods, call.Method)
+	}
+
+	assert.Equal(t, []string{
+		"archive_v1_hashByHeight",
+		"archive_unstable_hashByHeight",
+		"archive_unstable_hashByHeight",
+	}, methods)
+}
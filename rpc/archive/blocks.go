@@ -0,0 +1,126 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// HashByHeight returns the hashes of the blocks at the given height, which can be more than one if the height is not
+// finalized yet, or none if it is higher than the best block.
+func (a *archive) HashByHeight(height uint64) ([]types.Hash, error) {
+	return a.HashByHeightContext(context.Background(), height)
+}
+
+// HashByHeightContext is like HashByHeight but uses the provided context for the RPC call.
+func (a *archive) HashByHeightContext(ctx context.Context, height uint64) ([]types.Hash, error) {
+	var res []types.Hash
+
+	if err := a.call(ctx, &res, "hashByHeight", height); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Header returns the header of the block, ErrBlockNotFound if the node does not know the block.
+func (a *archive) Header(blockHash types.Hash) (*types.Header, error) {
+	return a.HeaderContext(context.Background(), blockHash)
+}
+
+// HeaderContext is like Header but uses the provided context for the RPC call.
+func (a *archive) HeaderContext(ctx context.Context, blockHash types.Hash) (*types.Header, error) {
+	var res *string
+
+	if err := a.call(ctx, &res, "header", blockHash.Hex()); err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, ErrBlockNotFound.WithMsg(blockHash.Hex())
+	}
+
+	var header types.Header
+
+	if err := codec.DecodeFromHex(*res, &header); err != nil {
+		return nil, err
+	}
+
+	return &header, nil
+}
+
+// Body returns the extrinsics of the block, ErrBlockNotFound if the node does not know the block.
+func (a *archive) Body(blockHash types.Hash) ([]types.Extrinsic, error) {
+	return a.BodyContext(context.Background(), blockHash)
+}
+
+// BodyContext is like Body but uses the provided context for the RPC call.
+func (a *archive) BodyContext(ctx context.Context, blockHash types.Hash) ([]types.Extrinsic, error) {
+	var res *[]string
+
+	if err := a.call(ctx, &res, "body", blockHash.Hex()); err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, ErrBlockNotFound.WithMsg(blockHash.Hex())
+	}
+
+	extrinsics := make([]types.Extrinsic, len(*res))
+
+	for i, enc := range *res {
+		if err := codec.DecodeFromHex(enc, &extrinsics[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return extrinsics, nil
+}
+
+// Call calls the runtime API function with the SCALE encoded params at the block and returns the SCALE encoded
+// result. Runtime API functions are named after the API and the function, e.g. "Core_version". ErrCallFailed is
+// returned with the reason of the node if the call failed.
+func (a *archive) Call(blockHash types.Hash, function string, params []byte) (types.Bytes, error) {
+	return a.CallContext(context.Background(), blockHash, function, params)
+}
+
+// CallContext is like Call but uses the provided context for the RPC call.
+func (a *archive) CallContext(
+	ctx context.Context,
+	blockHash types.Hash,
+	function string,
+	params []byte,
+) (types.Bytes, error) {
+	var res *types.ArchiveCallResult
+
+	if err := a.call(ctx, &res, "call", blockHash.Hex(), function, codec.HexEncodeToString(params)); err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, ErrBlockNotFound.WithMsg(blockHash.Hex())
+	}
+
+	if !res.Success {
+		return nil, ErrCallFailed.WithMsg(res.Error)
+	}
+
+	return res.Value, nil
+}
@@ -0,0 +1,147 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// maxDiscardedRetries is the number of times ForEachDescendant queries a page again that the node discarded.
+const maxDiscardedRetries = 3
+
+// Storage queries the storage of the block. Items at the end of the query that the node discarded, e.g. because it is
+// overloaded, are counted in the DiscardedItems of the result and have to be queried again.
+//
+// The childTrie is optional, if provided, the items are queried from the child trie instead of the main trie.
+func (a *archive) Storage(
+	blockHash types.Hash,
+	items []types.ArchiveStorageQueryItem,
+	childTrie *types.StorageKey,
+) (*types.ArchiveStorageResult, error) {
+	return a.StorageContext(context.Background(), blockHash, items, childTrie)
+}
+
+// StorageContext is like Storage but uses the provided context for the RPC call.
+func (a *archive) StorageContext(
+	ctx context.Context,
+	blockHash types.Hash,
+	items []types.ArchiveStorageQueryItem,
+	childTrie *types.StorageKey,
+) (*types.ArchiveStorageResult, error) {
+	var childTrieHex *string
+
+	if childTrie != nil {
+		h := childTrie.Hex()
+		childTrieHex = &h
+	}
+
+	var res types.ArchiveStorageResult
+
+	if err := a.client.CallContext(ctx, &res, storageMethod, blockHash.Hex(), items, childTrieHex); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &res, nil
+}
+
+// ForEachDescendantOptions configures the iteration of ForEachDescendant.
+type ForEachDescendantOptions struct {
+	// Cursor resumes the iteration after the given key, which is the cursor returned by a previous iteration.
+	Cursor types.StorageKey
+	// Hashes queries the hashes of the values instead of the values.
+	Hashes bool
+	// PageInterval is the minimum time between two page retrievals, to limit the load on public nodes.
+	PageInterval time.Duration
+}
+
+// ForEachDescendant calls fn for the key with the given prefix and each of its descendants at the given block, in
+// lexicographic order, with either the value or its hash. The descendants are retrieved in pages, each page returns
+// all descendants in one call as far as the node is willing to.
+//
+// The iteration ends when all descendants were visited, fn returns an error or ctx is done. The returned cursor is
+// the last key fn was called for successfully, the iteration can be resumed by passing it in
+// ForEachDescendantOptions.Cursor.
+func (a *archive) ForEachDescendant(
+	ctx context.Context,
+	prefix types.StorageKey,
+	blockHash types.Hash,
+	opts ForEachDescendantOptions,
+	fn func(item types.ChainHeadStorageResultItem) error,
+) (cursor types.StorageKey, err error) {
+	queryType := types.ChainHeadStorageDescendantsValues
+	if opts.Hashes {
+		queryType = types.ChainHeadStorageDescendantsHashes
+	}
+
+	cursor = opts.Cursor
+	discarded := 0
+
+	for page := 0; ; page++ {
+		if page > 0 && opts.PageInterval > 0 {
+			if err := sleepContext(ctx, opts.PageInterval); err != nil {
+				return cursor, err
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return cursor, err
+		}
+
+		res, err := a.StorageContext(ctx, blockHash, []types.ArchiveStorageQueryItem{{
+			Key:                prefix,
+			Type:               queryType,
+			PaginationStartKey: cursor,
+		}}, nil)
+		if err != nil {
+			return cursor, ErrDescendantsRetrieval.WithMsg("page %d", page).Wrap(err)
+		}
+
+		if res.DiscardedItems > 0 {
+			if discarded++; discarded > maxDiscardedRetries {
+				return cursor, ErrStorageItemsDiscarded.WithMsg("page %d", page)
+			}
+
+			continue
+		}
+
+		discarded = 0
+
+		visited := 0
+
+		for _, item := range res.Items {
+			// Keys up to the cursor are skipped in case the node ignores the pagination start key.
+			if len(cursor) > 0 && bytes.Compare(item.Key, cursor) <= 0 {
+				continue
+			}
+
+			if err := fn(item); err != nil {
+				return cursor, err
+			}
+
+			cursor = item.Key
+			visited++
+		}
+
+		if visited == 0 {
+			return cursor, nil
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testPrefix = types.StorageKey{0xaa}
+	testKeys   = []types.StorageKey{{0xaa, 1}, {0xaa, 2}, {0xaa, 3}}
+)
+
+func testItems(keys ...types.StorageKey) []types.ChainHeadStorageResultItem {
+	items := make([]types.ChainHeadStorageResultItem, len(keys))
+
+	for i, key := range keys {
+		value := types.NewStorageDataRaw([]byte{key[1]})
+		items[i] = types.ChainHeadStorageResultItem{Key: key, Value: &value}
+	}
+
+	return items
+}
+
+func testDescendantsQuery(startKey types.StorageKey) []types.ArchiveStorageQueryItem {
+	return []types.ArchiveStorageQueryItem{{
+		Key:                testPrefix,
+		Type:               types.ChainHeadStorageDescendantsValues,
+		PaginationStartKey: startKey,
+	}}
+}
+
+func TestArchive_Storage(t *testing.T) {
+	childTrie := types.StorageKey{0xcc}
+	query := []types.ArchiveStorageQueryItem{{Key: testKeys[0], Type: types.ChainHeadStorageValue}}
+
+	cl := rpcmocksrv.NewMockClient().
+		Respond("archive_unstable_storage", types.ArchiveStorageResult{Items: testItems(testKeys[0])},
+			testBlockHash.Hex(), query, nil).
+		Respond("archive_unstable_storage", types.ArchiveStorageResult{DiscardedItems: 1},
+			testBlockHash.Hex(), query, childTrie.Hex())
+
+	res, err := NewArchive(cl).Storage(testBlockHash, query, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &types.ArchiveStorageResult{Items: testItems(testKeys[0])}, res)
+
+	res, err = NewArchive(cl).Storage(testBlockHash, query, &childTrie)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), res.DiscardedItems)
+}
+
+func TestArchive_ForEachDescendant(t *testing.T) {
+	block := testBlockHash.Hex()
+
+	// The second page repeats the last key of the first one, which must be skipped.
+	cl := rpcmocksrv.NewMockClient().
+		Respond("archive_unstable_storage", types.ArchiveStorageResult{Items: testItems(testKeys[0:2]...)},
+			block, testDescendantsQuery(nil), nil).
+		Respond("archive_unstable_storage", types.ArchiveStorageResult{Items: testItems(testKeys[1:]...)},
+			block, testDescendantsQuery(testKeys[1]), nil).
+		Respond("archive_unstable_storage", types.ArchiveStorageResult{},
+			block, testDescendantsQuery(testKeys[2]), nil)
+
+	var items []types.ChainHeadStorageResultItem
+
+	cursor, err := NewArchive(cl).ForEachDescendant(context.Background(), testPrefix, testBlockHash,
+		ForEachDescendantOptions{}, func(item types.ChainHeadStorageResultItem) error {
+			items = append(items, item)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, testKeys[2], cursor)
+	assert.Equal(t, testItems(testKeys...), items)
+
+	// The iteration ends with the error of fn, the cursor is the last key fn succeeded for.
+	fnErr := errors.New("fn error")
+
+	cursor, err = NewArchive(cl).ForEachDescendant(context.Background(), testPrefix, testBlockHash,
+		ForEachDescendantOptions{Cursor: testKeys[1]}, func(item types.ChainHeadStorageResultItem) error {
+			return fnErr
+		})
+	assert.ErrorIs(t, err, fnErr)
+	assert.Equal(t, testKeys[1], cursor)
+}
+
+func TestArchive_ForEachDescendant_Discarded(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Respond("archive_unstable_storage", types.ArchiveStorageResult{DiscardedItems: 1},
+			testBlockHash.Hex(), testDescendantsQuery(nil), nil)
+
+	_, err := NewArchive(cl).ForEachDescendant(context.Background(), testPrefix, testBlockHash,
+		ForEachDescendantOptions{}, func(types.ChainHeadStorageResultItem) error {
+			return nil
+		})
+	assert.ErrorIs(t, err, ErrStorageItemsDiscarded)
+	assert.Len(t, cl.Calls(), maxDiscardedRetries+1)
+
+	// Nodes without the archive methods cause ErrNotSupported.
+	_, err = NewArchive(rpcmocksrv.NewMockClient()).ForEachDescendant(context.Background(), testPrefix,
+		testBlockHash, ForEachDescendantOptions{}, func(types.ChainHeadStorageResultItem) error {
+			return nil
+		})
+	assert.ErrorIs(t, err, ErrNotSupported)
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	author "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
 	mock "github.com/stretchr/testify/mock"
 
@@ -14,6 +16,90 @@ type Author struct {
 	mock.Mock
 }
 
+// HasKey provides a mock function with given fields: publicKey, keyType
+func (_m *Author) HasKey(publicKey types.Bytes, keyType string) (bool, error) {
+	ret := _m.Called(publicKey, keyType)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(types.Bytes, string) bool); ok {
+		r0 = rf(publicKey, keyType)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Bytes, string) error); ok {
+		r1 = rf(publicKey, keyType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasKeyContext provides a mock function with given fields: ctx, publicKey, keyType
+func (_m *Author) HasKeyContext(ctx context.Context, publicKey types.Bytes, keyType string) (bool, error) {
+	ret := _m.Called(ctx, publicKey, keyType)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.Bytes, string) bool); ok {
+		r0 = rf(ctx, publicKey, keyType)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Bytes, string) error); ok {
+		r1 = rf(ctx, publicKey, keyType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasSessionKeys provides a mock function with given fields: sessionKeys
+func (_m *Author) HasSessionKeys(sessionKeys types.Bytes) (bool, error) {
+	ret := _m.Called(sessionKeys)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(types.Bytes) bool); ok {
+		r0 = rf(sessionKeys)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Bytes) error); ok {
+		r1 = rf(sessionKeys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasSessionKeysContext provides a mock function with given fields: ctx, sessionKeys
+func (_m *Author) HasSessionKeysContext(ctx context.Context, sessionKeys types.Bytes) (bool, error) {
+	ret := _m.Called(ctx, sessionKeys)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.Bytes) bool); ok {
+		r0 = rf(ctx, sessionKeys)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Bytes) error); ok {
+		r1 = rf(ctx, sessionKeys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PendingExtrinsics provides a mock function with given fields:
 func (_m *Author) PendingExtrinsics() ([]types.Extrinsic, error) {
 	ret := _m.Called()
@@ -37,6 +123,75 @@ func (_m *Author) PendingExtrinsics() ([]types.Extrinsic, error) {
 	return r0, r1
 }
 
+// PendingExtrinsicsContext provides a mock function with given fields: ctx
+func (_m *Author) PendingExtrinsicsContext(ctx context.Context) ([]types.Extrinsic, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []types.Extrinsic
+	if rf, ok := ret.Get(0).(func(context.Context) []types.Extrinsic); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Extrinsic)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateKeys provides a mock function with given fields:
+func (_m *Author) RotateKeys() (types.Bytes, error) {
+	ret := _m.Called()
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func() types.Bytes); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateKeysContext provides a mock function with given fields: ctx
+func (_m *Author) RotateKeysContext(ctx context.Context) (types.Bytes, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func(context.Context) types.Bytes); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitAndWatchExtrinsic provides a mock function with given fields: xt
 func (_m *Author) SubmitAndWatchExtrinsic(xt types.Extrinsic) (*author.ExtrinsicStatusSubscription, error) {
 	ret := _m.Called(xt)
@@ -60,6 +215,29 @@ func (_m *Author) SubmitAndWatchExtrinsic(xt types.Extrinsic) (*author.Extrinsic
 	return r0, r1
 }
 
+// SubmitAndWatchExtrinsicContext provides a mock function with given fields: ctx, xt
+func (_m *Author) SubmitAndWatchExtrinsicContext(ctx context.Context, xt types.Extrinsic) (*author.ExtrinsicStatusSubscription, error) {
+	ret := _m.Called(ctx, xt)
+
+	var r0 *author.ExtrinsicStatusSubscription
+	if rf, ok := ret.Get(0).(func(context.Context, types.Extrinsic) *author.ExtrinsicStatusSubscription); ok {
+		r0 = rf(ctx, xt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*author.ExtrinsicStatusSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Extrinsic) error); ok {
+		r1 = rf(ctx, xt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitExtrinsic provides a mock function with given fields: xt
 func (_m *Author) SubmitExtrinsic(xt types.Extrinsic) (types.Hash, error) {
 	ret := _m.Called(xt)
@@ -83,6 +261,27 @@ func (_m *Author) SubmitExtrinsic(xt types.Extrinsic) (types.Hash, error) {
 	return r0, r1
 }
 
+// SubmitExtrinsicContext provides a mock function with given fields: ctx, xt
+func (_m *Author) SubmitExtrinsicContext(ctx context.Context, xt types.Extrinsic) (types.Hash, error) {
+	ret := _m.Called(ctx, xt)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, types.Extrinsic) types.Hash); ok {
+		r0 = rf(ctx, xt)
+	} else {
+		r0 = ret.Get(0).(types.Hash)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Extrinsic) error); ok {
+		r1 = rf(ctx, xt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewAuthorT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -20,14 +20,11 @@ import (
 	"fmt"
 	"testing"
 
-	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestAuthor_PendingExtrinsics(t *testing.T) {
-	api, err := gsrpc.NewSubstrateAPI(config.Default().RPCURL)
-	assert.NoError(t, err)
+	api := newLiveAPI(t)
 	res, err := api.RPC.Author.PendingExtrinsics()
 	assert.NoError(t, err)
 	for _, ext := range res {
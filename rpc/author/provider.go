@@ -0,0 +1,34 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Provider is the subset of Author that the submitter depends on. It is satisfied by Author and by the fakes of the
+// fakes package.
+type Provider interface {
+	SubmitExtrinsic(xt types.Extrinsic) (types.Hash, error)
+	SubmitExtrinsicContext(ctx context.Context, xt types.Extrinsic) (types.Hash, error)
+	SubmitAndWatchExtrinsic(xt types.Extrinsic) (*ExtrinsicStatusSubscription, error)
+	SubmitAndWatchExtrinsicContext(ctx context.Context, xt types.Extrinsic) (*ExtrinsicStatusSubscription, error)
+}
+
+var _ Provider = (*author)(nil)
@@ -0,0 +1,78 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// RotateKeys generates new session keys in the keystore of the node and returns their public keys, concatenated in
+// the order of the runtime's SessionKeys type, see registry.DecodeSessionKeys.
+func (a *author) RotateKeys() (types.Bytes, error) {
+	return a.RotateKeysContext(context.Background())
+}
+
+// RotateKeysContext is like RotateKeys but uses the provided context for the RPC call.
+func (a *author) RotateKeysContext(ctx context.Context) (types.Bytes, error) {
+	var res string
+
+	err := a.client.CallContext(ctx, &res, "author_rotateKeys")
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.HexDecodeString(res)
+}
+
+// HasSessionKeys returns true if the keystore of the node holds the private keys of all the given session keys, as
+// returned by RotateKeys
+func (a *author) HasSessionKeys(sessionKeys types.Bytes) (bool, error) {
+	return a.HasSessionKeysContext(context.Background(), sessionKeys)
+}
+
+// HasSessionKeysContext is like HasSessionKeys but uses the provided context for the RPC call.
+func (a *author) HasSessionKeysContext(ctx context.Context, sessionKeys types.Bytes) (bool, error) {
+	var res bool
+
+	err := a.client.CallContext(ctx, &res, "author_hasSessionKeys", codec.HexEncodeToString(sessionKeys))
+	if err != nil {
+		return false, err
+	}
+
+	return res, nil
+}
+
+// HasKey returns true if the keystore of the node holds the private key of the given public key and key type, e.g.
+// "gran" or "babe"
+func (a *author) HasKey(publicKey types.Bytes, keyType string) (bool, error) {
+	return a.HasKeyContext(context.Background(), publicKey, keyType)
+}
+
+// HasKeyContext is like HasKey but uses the provided context for the RPC call.
+func (a *author) HasKeyContext(ctx context.Context, publicKey types.Bytes, keyType string) (bool, error) {
+	var res bool
+
+	err := a.client.CallContext(ctx, &res, "author_hasKey", codec.HexEncodeToString(publicKey), keyType)
+	if err != nil {
+		return false, err
+	}
+
+	return res, nil
+}
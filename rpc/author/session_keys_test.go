@@ -0,0 +1,69 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReplayAuthor(t *testing.T) author.Author {
+	s, err := rpcmocksrv.NewReplayServerFromFile("testdata/fixtures.json")
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+
+	cl, err := client.Connect(s.URL)
+	require.NoError(t, err)
+	t.Cleanup(cl.Close)
+
+	return author.NewAuthor(cl)
+}
+
+func TestAuthor_RotateKeys(t *testing.T) {
+	a := newReplayAuthor(t)
+
+	keys, err := a.RotateKeys()
+	require.NoError(t, err)
+	assert.Len(t, keys, 6*32)
+
+	ok, err := a.HasSessionKeys(keys)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = a.HasSessionKeys(keys[:32])
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestAuthor_HasKey(t *testing.T) {
+	a := newReplayAuthor(t)
+
+	ok, err := a.HasKey(types.Bytes(bytes.Repeat([]byte{1}, 32)), "gran")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = a.HasKey(types.Bytes(bytes.Repeat([]byte{1}, 32)), "babe")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -63,8 +64,15 @@ func (s *ExtrinsicStatusSubscription) Unsubscribe() {
 
 // SubmitAndWatchExtrinsic will submit and subscribe to watch an extrinsic until unsubscribed, returning a subscription
 // that will receive server notifications containing the extrinsic status updates.
-func (a *author) SubmitAndWatchExtrinsic(xt types.Extrinsic) (*ExtrinsicStatusSubscription, error) { //nolint:lll
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+func (a *author) SubmitAndWatchExtrinsic(xt types.Extrinsic) (*ExtrinsicStatusSubscription, error) {
+	return a.SubmitAndWatchExtrinsicContext(context.Background(), xt)
+}
+
+// SubmitAndWatchExtrinsicContext is like SubmitAndWatchExtrinsic but the subscription is ended once ctx is done.
+// The subscription request itself is bound by both ctx and the configured subscribe timeout.
+func (a *author) SubmitAndWatchExtrinsicContext(ctx context.Context, xt types.Extrinsic) (
+	*ExtrinsicStatusSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
 	defer cancel()
 
 	c := make(chan types.ExtrinsicStatus)
@@ -74,11 +82,14 @@ func (a *author) SubmitAndWatchExtrinsic(xt types.Extrinsic) (*ExtrinsicStatusSu
 		return nil, err
 	}
 
-	sub, err := a.client.Subscribe(ctx, "author", "submitAndWatchExtrinsic", "unwatchExtrinsic", "extrinsicUpdate",
-		c, enc)
+	sub, err := a.client.Subscribe(subscribeCtx, "author", "submitAndWatchExtrinsic", "unwatchExtrinsic",
+		"extrinsicUpdate", c, enc)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ExtrinsicStatusSubscription{sub: sub, channel: c}, nil
+	subscription := &ExtrinsicStatusSubscription{sub: sub, channel: c}
+	client.UnsubscribeOnCancel(ctx, sub.Done(), subscription.Unsubscribe)
+
+	return subscription, nil
 }
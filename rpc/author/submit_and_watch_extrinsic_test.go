@@ -2,19 +2,18 @@ package author_test
 
 import (
 	"testing"
+	"time"
 
-	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAuthor_SubmitAndWatchExtrinsic(t *testing.T) {
 	// Instantiate the API
-	api, err := gsrpc.NewSubstrateAPI(config.Default().RPCURL)
-	assert.NoError(t, err)
+	api := newLiveAPI(t)
 
 	meta, err := api.RPC.State.GetMetadataLatest()
 	assert.NoError(t, err)
@@ -84,3 +83,57 @@ func TestAuthor_SubmitAndWatchExtrinsic(t *testing.T) {
 	}
 
 }
+
+func TestAuthor_SubmitAndWatchExtrinsic_Replay(t *testing.T) {
+	a := newReplayAuthor(t)
+
+	sub, err := a.SubmitAndWatchExtrinsic(types.NewExtrinsic(types.Call{}))
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	var statuses []types.ExtrinsicStatus
+
+	for len(statuses) < 7 {
+		select {
+		case status := <-sub.Chan():
+			statuses = append(statuses, status)
+		case err := <-sub.Err():
+			t.Fatalf("subscription ended: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no status received")
+		}
+	}
+
+	retractedBlock, includedBlock := types.Hash{}, types.Hash{}
+	for i := range retractedBlock {
+		retractedBlock[i], includedBlock[i] = 0x0a, 0x0b
+	}
+
+	assert.True(t, statuses[0].IsReady)
+
+	peers, ok := statuses[1].Broadcast()
+	assert.True(t, ok)
+	assert.Equal(t, []types.Text{"12D3KooWEyoppNCUx8Yx66oV9fJnriXwCcXwDDUA2kj6vnc6iDEp"}, peers)
+
+	// The block the extrinsic was included in is retracted, the extrinsic is included in another block afterwards.
+	blockHash, ok := statuses[2].InBlock()
+	assert.True(t, ok)
+	assert.Equal(t, retractedBlock, blockHash)
+
+	blockHash, ok = statuses[3].Retracted()
+	assert.True(t, ok)
+	assert.Equal(t, retractedBlock, blockHash)
+
+	blockHash, ok = statuses[4].InBlock()
+	assert.True(t, ok)
+	assert.Equal(t, includedBlock, blockHash)
+
+	// Statuses of newer nodes don't end the subscription.
+	unknown, ok := statuses[5].Unknown()
+	assert.True(t, ok)
+	assert.Contains(t, string(unknown.Raw), "finalizing")
+
+	blockHash, ok = statuses[6].Finalized()
+	assert.True(t, ok)
+	assert.Equal(t, includedBlock, blockHash)
+}
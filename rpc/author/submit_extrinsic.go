@@ -17,19 +17,26 @@
 package author
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
 
 // SubmitExtrinsic will submit a fully formatted extrinsic for block inclusion
 func (a *author) SubmitExtrinsic(xt types.Extrinsic) (types.Hash, error) {
+	return a.SubmitExtrinsicContext(context.Background(), xt)
+}
+
+// SubmitExtrinsicContext is like SubmitExtrinsic but uses the provided context for the RPC call.
+func (a *author) SubmitExtrinsicContext(ctx context.Context, xt types.Extrinsic) (types.Hash, error) {
 	enc, err := codec.EncodeToHex(xt)
 	if err != nil {
 		return types.Hash{}, err
 	}
 
 	var res string
-	err = a.client.Call(&res, "author_submitExtrinsic", enc)
+	err = a.client.CallContext(ctx, &res, "author_submitExtrinsic", enc)
 	if err != nil {
 		return types.Hash{}, err
 	}
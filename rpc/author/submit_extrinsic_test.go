@@ -20,8 +20,6 @@ import (
 	"fmt"
 	"testing"
 
-	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
@@ -30,8 +28,7 @@ import (
 
 func TestAuthor_SubmitExtrinsic(t *testing.T) {
 	// Instantiate the API
-	api, err := gsrpc.NewSubstrateAPI(config.Default().RPCURL)
-	assert.NoError(t, err)
+	api := newLiveAPI(t)
 
 	meta, err := api.RPC.State.GetMetadataLatest()
 	assert.NoError(t, err)
@@ -0,0 +1,41 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name Babe --filename babe.go
+
+package babe
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+type Babe interface {
+	EpochAuthorship() (map[string]types.EpochAuthorship, error)
+	EpochAuthorshipContext(ctx context.Context) (map[string]types.EpochAuthorship, error)
+}
+
+// babe exposes methods for retrieval of BABE block production data
+type babe struct {
+	client client.Client
+}
+
+// NewBabe creates a new babe struct
+func NewBabe(cl client.Client) Babe {
+	return &babe{cl}
+}
@@ -0,0 +1,41 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package babe
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// EpochAuthorship returns the slots of the current epoch that the authorities with keys in the keystore of the node
+// can claim, by SS58 address of the authority. It can be used by validators to monitor their block production.
+func (b *babe) EpochAuthorship() (map[string]types.EpochAuthorship, error) {
+	return b.EpochAuthorshipContext(context.Background())
+}
+
+// EpochAuthorshipContext is like EpochAuthorship but uses the provided context for the RPC call.
+func (b *babe) EpochAuthorshipContext(ctx context.Context) (map[string]types.EpochAuthorship, error) {
+	var res map[string]types.EpochAuthorship
+
+	err := b.client.CallContext(ctx, &res, "babe_epochAuthorship")
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -5,13 +5,19 @@ import (
 	"testing"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
 )
 
 var testBeefy Beefy
 
 func TestMain(m *testing.M) {
-	cl, err := client.Connect(config.Default().RPCURL)
+	s, err := rpcmocksrv.NewReplayServerFromFile("testdata/fixtures.json")
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	// cl, err := client.Connect(config.Default().RPCURL)
 	if err != nil {
 		panic(err)
 	}
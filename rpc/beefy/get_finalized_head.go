@@ -17,14 +17,21 @@
 package beefy
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // GetFinalizedHead returns the hash of the latest BEEFY block
 func (b *beefy) GetFinalizedHead() (types.Hash, error) {
+	return b.GetFinalizedHeadContext(context.Background())
+}
+
+// GetFinalizedHeadContext is like GetFinalizedHead but uses the provided context for the RPC call.
+func (b *beefy) GetFinalizedHeadContext(ctx context.Context) (types.Hash, error) {
 	var res string
 
-	err := b.client.Call(&res, "beefy_getFinalizedHead")
+	err := b.client.CallContext(ctx, &res, "beefy_getFinalizedHead")
 	if err != nil {
 		return types.Hash{}, err
 	}
@@ -19,11 +19,14 @@ package beefy
 import (
 	"testing"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestBeefy_GetFinalizedHead(t *testing.T) {
-	t.Skip("API only available in Polkadot")
-	_, err := testBeefy.GetFinalizedHead()
+	hash, err := testBeefy.GetFinalizedHead()
 	assert.NoError(t, err)
+	assert.Equal(t, types.NewHash(codec.MustHexDecodeString(
+		"0x0a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20212223242526272829")), hash)
 }
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	beefy "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/beefy"
 	mock "github.com/stretchr/testify/mock"
 
@@ -37,6 +39,27 @@ func (_m *Beefy) GetFinalizedHead() (types.Hash, error) {
 	return r0, r1
 }
 
+// GetFinalizedHeadContext provides a mock function with given fields: ctx
+func (_m *Beefy) GetFinalizedHeadContext(ctx context.Context) (types.Hash, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context) types.Hash); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Hash)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubscribeJustifications provides a mock function with given fields:
 func (_m *Beefy) SubscribeJustifications() (*beefy.JustificationsSubscription, error) {
 	ret := _m.Called()
@@ -60,6 +83,29 @@ func (_m *Beefy) SubscribeJustifications() (*beefy.JustificationsSubscription, e
 	return r0, r1
 }
 
+// SubscribeJustificationsContext provides a mock function with given fields: ctx
+func (_m *Beefy) SubscribeJustificationsContext(ctx context.Context) (*beefy.JustificationsSubscription, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *beefy.JustificationsSubscription
+	if rf, ok := ret.Get(0).(func(context.Context) *beefy.JustificationsSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*beefy.JustificationsSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewBeefyT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -20,6 +20,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -28,14 +29,14 @@ import (
 // JustificationsSubscription is a subscription established through one of the Client's subscribe methods.
 type JustificationsSubscription struct {
 	sub      *gethrpc.ClientSubscription
-	channel  chan types.SignedCommitment
+	channel  chan types.VersionedFinalityProof
 	quitOnce sync.Once // ensures quit is closed once
 }
 
 // Chan returns the subscription channel.
 //
 // The channel is closed when Unsubscribe is called on the subscription.
-func (s *JustificationsSubscription) Chan() <-chan types.SignedCommitment {
+func (s *JustificationsSubscription) Chan() <-chan types.VersionedFinalityProof {
 	return s.channel
 }
 
@@ -51,6 +52,12 @@ func (s *JustificationsSubscription) Err() <-chan error {
 	return s.sub.Err()
 }
 
+// Gap returns a channel that receives a value when notifications might have been missed, which happens
+// when the subscription was re-established after a reconnect, see client.ConnectWithReconnect.
+func (s *JustificationsSubscription) Gap() <-chan struct{} {
+	return s.sub.Gap()
+}
+
 // Unsubscribe unsubscribes the notification and closes the error channel.
 // It can safely be called more than once.
 func (s *JustificationsSubscription) Unsubscribe() {
@@ -61,18 +68,27 @@ func (s *JustificationsSubscription) Unsubscribe() {
 }
 
 // SubscribeJustifications subscribes beefy justifications, returning a subscription that will
-// receive server notifications containing the Header.
+// receive server notifications containing the finality proof of every block finalized by BEEFY.
 func (b *beefy) SubscribeJustifications() (*JustificationsSubscription, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	return b.SubscribeJustificationsContext(context.Background())
+}
+
+// SubscribeJustificationsContext is like SubscribeJustifications but the subscription is ended once ctx is done.
+// The subscription request itself is bound by both ctx and the configured subscribe timeout.
+func (b *beefy) SubscribeJustificationsContext(ctx context.Context) (*JustificationsSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
 	defer cancel()
 
-	ch := make(chan types.SignedCommitment)
+	ch := make(chan types.VersionedFinalityProof)
 
-	sub, err := b.client.Subscribe(ctx, "beefy", "subscribeJustifications", "unsubscribeJustifications",
+	sub, err := b.client.Subscribe(subscribeCtx, "beefy", "subscribeJustifications", "unsubscribeJustifications",
 		"justifications", ch)
 	if err != nil {
 		return nil, err
 	}
 
-	return &JustificationsSubscription{sub: sub, channel: ch}, nil
+	subscription := &JustificationsSubscription{sub: sub, channel: ch}
+	client.UnsubscribeOnCancel(ctx, sub.Done(), subscription.Unsubscribe)
+
+	return subscription, nil
 }
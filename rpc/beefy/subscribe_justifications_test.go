@@ -0,0 +1,48 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beefy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeefy_SubscribeJustifications(t *testing.T) {
+	sub, err := testBeefy.SubscribeJustifications()
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	select {
+	case proof := <-sub.Chan():
+		require.True(t, proof.IsV1)
+		assert.Equal(t, uint32(5), proof.AsV1.Commitment.BlockNumber)
+		assert.Len(t, proof.AsV1.Signatures, 4)
+		assert.True(t, proof.AsV1.Signatures[2].IsSome())
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no justification received")
+	}
+
+	sub.Unsubscribe()
+
+	_, ok := <-sub.Chan()
+	assert.False(t, ok)
+}
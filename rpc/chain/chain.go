@@ -19,20 +19,45 @@
 package chain
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 type Chain interface {
 	SubscribeFinalizedHeads() (*FinalizedHeadsSubscription, error)
+	SubscribeFinalizedHeadsContext(ctx context.Context) (*FinalizedHeadsSubscription, error)
+	SubscribeFinalizedHeadsWithOptions(
+		ctx context.Context,
+		opts client.SubscriptionOptions,
+	) (*FinalizedHeadsSubscription, error)
 	SubscribeNewHeads() (*NewHeadsSubscription, error)
+	SubscribeNewHeadsContext(ctx context.Context) (*NewHeadsSubscription, error)
+	SubscribeNewHeadsWithOptions(ctx context.Context, opts client.SubscriptionOptions) (*NewHeadsSubscription, error)
 	GetBlockHash(blockNumber uint64) (types.Hash, error)
+	GetBlockHashContext(ctx context.Context, blockNumber uint64) (types.Hash, error)
 	GetBlockHashLatest() (types.Hash, error)
+	GetBlockHashLatestContext(ctx context.Context) (types.Hash, error)
+	GetBlockHashRange(start, end uint64) ([]client.BatchResult[types.Hash], error)
+	GetBlockHashRangeContext(ctx context.Context, start, end uint64) ([]client.BatchResult[types.Hash], error)
 	GetFinalizedHead() (types.Hash, error)
+	GetFinalizedHeadContext(ctx context.Context) (types.Hash, error)
 	GetBlock(blockHash types.Hash) (*types.SignedBlock, error)
+	GetBlockContext(ctx context.Context, blockHash types.Hash) (*types.SignedBlock, error)
 	GetBlockLatest() (*types.SignedBlock, error)
+	GetBlockLatestContext(ctx context.Context) (*types.SignedBlock, error)
+	GetBlockWithJustifications(blockHash types.Hash) (*types.SignedBlockWithJustifications, error)
+	GetBlockWithJustificationsContext(
+		ctx context.Context,
+		blockHash types.Hash,
+	) (*types.SignedBlockWithJustifications, error)
 	GetHeader(blockHash types.Hash) (*types.Header, error)
+	GetHeaderContext(ctx context.Context, blockHash types.Hash) (*types.Header, error)
 	GetHeaderLatest() (*types.Header, error)
+	GetHeaderLatestContext(ctx context.Context) (*types.Header, error)
+	IsFinalized(blockHash types.Hash) (bool, error)
+	IsFinalizedContext(ctx context.Context, blockHash types.Hash) (bool, error)
 }
 
 // chain exposes methods for retrieval of chain data
@@ -52,6 +52,9 @@ type GenericBlock[A, S, P any] interface {
 type GenericExtrinsic[A, S, P any] interface {
 	GetVersion() byte
 	GetSignature() GenericExtrinsicSignature[A, S, P]
+	// GetExtensions returns the transaction extensions of a general transaction of extrinsic format version 5, nil for
+	// all other extrinsics.
+	GetExtensions() GenericExtrinsicExtensions[P]
 	GetCall() types.Call
 }
 
@@ -77,6 +80,19 @@ type GenericExtrinsicSignature[A, S, P any] interface {
 	GetPaymentFields() P
 }
 
+// GenericExtrinsicExtensions is the interface that holds the transaction extensions of a general transaction, which
+// carries them without a signer and a signature.
+//
+// This interface is generic over P, please check GenericExtrinsicSignature for more information.
+//
+//nolint:revive
+type GenericExtrinsicExtensions[P any] interface {
+	GetExtensionVersion() byte
+	GetEra() types.ExtrinsicEra
+	GetNonce() types.UCompact
+	GetPaymentFields() P
+}
+
 // SignedBlock implements the GenericSignedBlock interface.
 type SignedBlock[A, S, P any] struct {
 	Block         *Block[A, S, P] `json:"block"`
@@ -118,6 +134,8 @@ type Extrinsic[A, S, P any] struct {
 	Version byte `json:"version"`
 	// Signature is the ExtrinsicSignature, its presence depends on the Version flag
 	Signature *ExtrinsicSignature[A, S, P] `json:"signature"`
+	// Extensions are the ExtrinsicExtensions of a general transaction, their presence depends on the Version flag
+	Extensions *ExtrinsicExtensions[P] `json:"extensions"`
 	// Method is the call this extrinsic wraps
 	Method types.Call `json:"method"`
 }
@@ -132,6 +150,15 @@ func (e *Extrinsic[A, S, P]) GetSignature() GenericExtrinsicSignature[A, S, P] {
 	return e.Signature
 }
 
+//nolint:revive
+func (e *Extrinsic[A, S, P]) GetExtensions() GenericExtrinsicExtensions[P] {
+	if e.Extensions == nil {
+		return nil
+	}
+
+	return e.Extensions
+}
+
 //nolint:revive
 func (e *Extrinsic[A, S, P]) GetCall() types.Call {
 	return e.Method
@@ -181,10 +208,16 @@ func (e *Extrinsic[A, S, P]) IsSigned() bool {
 	return e.Version&types.ExtrinsicBitSigned == types.ExtrinsicBitSigned
 }
 
+// IsGeneral returns true if the extrinsic is a general transaction.
+//nolint:revive
+func (e *Extrinsic[A, S, P]) IsGeneral() bool {
+	return e.Version&types.ExtrinsicTypeMask == types.ExtrinsicBitGeneral
+}
+
 // Type returns the raw transaction version.
 //nolint:revive
 func (e *Extrinsic[A, S, P]) Type() uint8 {
-	return e.Version & types.ExtrinsicUnmaskVersion
+	return e.Version &^ types.ExtrinsicTypeMask
 }
 
 // Decode decodes the extrinsic based on the data present in the decoder.
@@ -199,17 +232,24 @@ func (e *Extrinsic[A, S, P]) Decode(decoder scale.Decoder) error {
 		return err
 	}
 
-	if e.IsSigned() {
-		if e.Type() != types.ExtrinsicVersion4 {
-			return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(),
-				e.Type())
-		}
-
+	switch {
+	case e.Version&types.ExtrinsicTypeMask == types.ExtrinsicTypeMask,
+		e.IsSigned() && e.Type() != types.ExtrinsicVersion4,
+		e.IsGeneral() && e.Type() != types.ExtrinsicVersion5:
+		return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(),
+			e.Type())
+	case e.IsSigned():
 		e.Signature = new(ExtrinsicSignature[A, S, P])
 
 		if err := decoder.Decode(&e.Signature); err != nil {
 			return err
 		}
+	case e.IsGeneral():
+		e.Extensions = new(ExtrinsicExtensions[P])
+
+		if err := decoder.Decode(&e.Extensions); err != nil {
+			return err
+		}
 	}
 
 	if err := decoder.Decode(&e.Method); err != nil {
@@ -253,6 +293,34 @@ func (e *ExtrinsicSignature[A, S, P]) GetPaymentFields() P {
 	return e.PaymentFields
 }
 
+// ExtrinsicExtensions implements the GenericExtrinsicExtensions interface.
+type ExtrinsicExtensions[P any] struct {
+	ExtensionVersion byte
+	Era              types.ExtrinsicEra
+	Nonce            types.UCompact
+	PaymentFields    P
+}
+
+//nolint:revive
+func (e *ExtrinsicExtensions[P]) GetExtensionVersion() byte {
+	return e.ExtensionVersion
+}
+
+//nolint:revive
+func (e *ExtrinsicExtensions[P]) GetEra() types.ExtrinsicEra {
+	return e.Era
+}
+
+//nolint:revive
+func (e *ExtrinsicExtensions[P]) GetNonce() types.UCompact {
+	return e.Nonce
+}
+
+//nolint:revive
+func (e *ExtrinsicExtensions[P]) GetPaymentFields() P {
+	return e.PaymentFields
+}
+
 // DefaultPaymentFields represents the default payment fields found in the extrinsics of most substrate chains.
 type DefaultPaymentFields struct {
 	Tip types.UCompact
@@ -0,0 +1,52 @@
+package generic
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtrinsic_Decode_V5(t *testing.T) {
+	var extrinsics []Extrinsic[types.MultiAddress, types.MultiSignature, DefaultPaymentFields]
+
+	// Timestamp.set as bare extrinsic.
+	err := codec.Decode(codec.MustHexDecodeString("0x04280502000b0068e5cf8b01"), &extrinsics)
+	assert.NoError(t, err)
+
+	xt := &extrinsics[0]
+	assert.False(t, xt.IsSigned())
+	assert.False(t, xt.IsGeneral())
+	assert.Equal(t, uint8(types.ExtrinsicVersion5), xt.Type())
+	assert.Nil(t, xt.GetSignature())
+	assert.Nil(t, xt.GetExtensions())
+	assert.Equal(t, types.CallIndex{SectionIndex: 2, MethodIndex: 0}, xt.GetCall().CallIndex)
+
+	// System.remark as general transaction.
+	err = codec.Decode(codec.MustHexDecodeString("0x042845000004000000086869"), &extrinsics)
+	assert.NoError(t, err)
+
+	xt = &extrinsics[0]
+	assert.False(t, xt.IsSigned())
+	assert.True(t, xt.IsGeneral())
+	assert.Equal(t, uint8(types.ExtrinsicVersion5), xt.Type())
+	assert.Nil(t, xt.GetSignature())
+
+	extensions := xt.GetExtensions()
+	assert.NotNil(t, extensions)
+	assert.Equal(t, byte(0), extensions.GetExtensionVersion())
+	assert.True(t, extensions.GetEra().IsImmortalEra)
+	assert.Equal(t, types.NewUCompactFromUInt(1), extensions.GetNonce())
+	assert.Equal(t, types.NewUCompactFromUInt(0), extensions.GetPaymentFields().Tip)
+	assert.Equal(t, types.Args{0x08, 'h', 'i'}, xt.GetCall().Args)
+
+	for _, encoded := range []string{
+		"0x04288502000b0068e5cf8b01", // signed v5
+		"0x04284402000b0068e5cf8b01", // general v4
+		"0x0428c502000b0068e5cf8b01", // signed and general
+	} {
+		err = codec.Decode(codec.MustHexDecodeString(encoded), &extrinsics)
+		assert.Error(t, err, encoded)
+	}
+}
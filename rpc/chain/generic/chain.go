@@ -1,6 +1,9 @@
 package generic
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -21,7 +24,9 @@ type Chain[
 	B GenericSignedBlock[A, S, P],
 ] interface {
 	GetBlock(blockHash types.Hash) (B, error)
+	GetBlockContext(ctx context.Context, blockHash types.Hash) (B, error)
 	GetBlockLatest() (B, error)
+	GetBlockLatestContext(ctx context.Context) (B, error)
 }
 
 // genericChain implements the Chain interface.
@@ -67,12 +72,22 @@ func NewDefaultChain(client client.Client) DefaultChain {
 
 // GetBlock retrieves a generic block B found at blockHash.
 func (g *genericChain[A, S, P, B]) GetBlock(blockHash types.Hash) (B, error) {
-	return g.getBlock(&blockHash)
+	return g.GetBlockContext(context.Background(), blockHash)
+}
+
+// GetBlockContext retrieves a generic block B found at blockHash, using the provided context for the call.
+func (g *genericChain[A, S, P, B]) GetBlockContext(ctx context.Context, blockHash types.Hash) (B, error) {
+	return g.getBlock(ctx, &blockHash)
 }
 
 // GetBlockLatest returns the latest generic block B.
 func (g *genericChain[A, S, P, B]) GetBlockLatest() (B, error) {
-	return g.getBlock(nil)
+	return g.GetBlockLatestContext(context.Background())
+}
+
+// GetBlockLatestContext returns the latest generic block B, using the provided context for the call.
+func (g *genericChain[A, S, P, B]) GetBlockLatestContext(ctx context.Context) (B, error) {
+	return g.getBlock(ctx, nil)
 }
 
 const (
@@ -80,11 +95,12 @@ const (
 )
 
 // getBlock retrieves the generic block B.
-func (g *genericChain[A, S, P, B]) getBlock(blockHash *types.Hash) (B, error) {
+func (g *genericChain[A, S, P, B]) getBlock(ctx context.Context, blockHash *types.Hash) (B, error) {
 	block := new(B)
 
-	if err := client.CallWithBlockHash(g.client, block, getBlockMethod, blockHash); err != nil {
-		return *block, ErrGetBlockCall.Wrap(err)
+	if err := client.CallWithBlockHashContext(ctx, g.client, block, getBlockMethod, blockHash); err != nil {
+		// Wrap both errors so that errors returned by the node can still be retrieved as types.RPCError.
+		return *block, fmt.Errorf("%w: %w", ErrGetBlockCall, err)
 	}
 
 	return *block, nil
@@ -17,23 +17,35 @@
 package chain
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // GetBlock returns the header and body of the relay chain block with the given hash
 func (c *chain) GetBlock(blockHash types.Hash) (*types.SignedBlock, error) {
-	return c.getBlock(&blockHash)
+	return c.GetBlockContext(context.Background(), blockHash)
+}
+
+// GetBlockContext is like GetBlock but uses the provided context for the RPC call.
+func (c *chain) GetBlockContext(ctx context.Context, blockHash types.Hash) (*types.SignedBlock, error) {
+	return c.getBlock(ctx, &blockHash)
 }
 
 // GetBlockLatest returns the header and body of the latest relay chain block
 func (c *chain) GetBlockLatest() (*types.SignedBlock, error) {
-	return c.getBlock(nil)
+	return c.GetBlockLatestContext(context.Background())
+}
+
+// GetBlockLatestContext is like GetBlockLatest but uses the provided context for the RPC call.
+func (c *chain) GetBlockLatestContext(ctx context.Context) (*types.SignedBlock, error) {
+	return c.getBlock(ctx, nil)
 }
 
-func (c *chain) getBlock(blockHash *types.Hash) (*types.SignedBlock, error) {
+func (c *chain) getBlock(ctx context.Context, blockHash *types.Hash) (*types.SignedBlock, error) {
 	var SignedBlock types.SignedBlock
-	err := client.CallWithBlockHash(c.client, &SignedBlock, "chain_getBlock", blockHash)
+	err := client.CallWithBlockHashContext(ctx, c.client, &SignedBlock, "chain_getBlock", blockHash)
 	if err != nil {
 		return nil, err
 	}
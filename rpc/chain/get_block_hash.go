@@ -17,27 +17,39 @@
 package chain
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // GetBlockHash returns the block hash for a specific block height
 func (c *chain) GetBlockHash(blockNumber uint64) (types.Hash, error) {
-	return c.getBlockHash(&blockNumber)
+	return c.GetBlockHashContext(context.Background(), blockNumber)
+}
+
+// GetBlockHashContext is like GetBlockHash but uses the provided context for the RPC call.
+func (c *chain) GetBlockHashContext(ctx context.Context, blockNumber uint64) (types.Hash, error) {
+	return c.getBlockHash(ctx, &blockNumber)
 }
 
 // GetBlockHashLatest returns the latest block hash
 func (c *chain) GetBlockHashLatest() (types.Hash, error) {
-	return c.getBlockHash(nil)
+	return c.GetBlockHashLatestContext(context.Background())
+}
+
+// GetBlockHashLatestContext is like GetBlockHashLatest but uses the provided context for the RPC call.
+func (c *chain) GetBlockHashLatestContext(ctx context.Context) (types.Hash, error) {
+	return c.getBlockHash(ctx, nil)
 }
 
-func (c *chain) getBlockHash(blockNumber *uint64) (types.Hash, error) {
+func (c *chain) getBlockHash(ctx context.Context, blockNumber *uint64) (types.Hash, error) {
 	var res string
 	var err error
 
 	if blockNumber == nil {
-		err = c.client.Call(&res, "chain_getBlockHash")
+		err = c.client.CallContext(ctx, &res, "chain_getBlockHash")
 	} else {
-		err = c.client.Call(&res, "chain_getBlockHash", *blockNumber)
+		err = c.client.CallContext(ctx, &res, "chain_getBlockHash", *blockNumber)
 	}
 
 	if err != nil {
@@ -0,0 +1,69 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	ErrInvalidBlockRange = libErr.Error("invalid block range")
+)
+
+// GetBlockHashRange returns the block hashes for the block heights from start to end (inclusive), using a single
+// batch request. The result of each block height is reported separately, in ascending order.
+func (c *chain) GetBlockHashRange(start, end uint64) ([]client.BatchResult[types.Hash], error) {
+	return c.GetBlockHashRangeContext(context.Background(), start, end)
+}
+
+// GetBlockHashRangeContext is like GetBlockHashRange but uses the provided context for the RPC call.
+func (c *chain) GetBlockHashRangeContext(
+	ctx context.Context,
+	start, end uint64,
+) ([]client.BatchResult[types.Hash], error) {
+	if end < start {
+		return nil, ErrInvalidBlockRange.WithMsg("end %d is lower than start %d", end, start)
+	}
+
+	batch := client.NewBatch(c.client)
+	hexHashes := make([]string, end-start+1)
+
+	for i := range hexHashes {
+		batch.Add(&hexHashes[i], "chain_getBlockHash", start+uint64(i))
+	}
+
+	if err := batch.Send(ctx); err != nil {
+		return nil, err
+	}
+
+	res := make([]client.BatchResult[types.Hash], len(hexHashes))
+
+	for i, hexHash := range hexHashes {
+		if err := batch.Err(i); err != nil {
+			res[i].Err = err
+			continue
+		}
+
+		res[i].Value, res[i].Err = types.NewHashFromHexString(hexHash)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,82 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client/mocks"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChain_GetBlockHashRange(t *testing.T) {
+	cl := mocks.NewClient(t)
+	c := NewChain(cl)
+
+	blockHash := types.NewHash([]byte{0x01, 0x02})
+	callErr := errors.New("block not found")
+
+	cl.On("BatchCallContext", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			elems := args.Get(1).([]gethrpc.BatchElem)
+
+			assert.Len(t, elems, 3)
+
+			for i, elem := range elems {
+				assert.Equal(t, "chain_getBlockHash", elem.Method)
+				assert.Equal(t, []interface{}{uint64(10 + i)}, elem.Args)
+			}
+
+			*elems[0].Result.(*string) = blockHash.Hex()
+			elems[1].Error = callErr
+			*elems[2].Result.(*string) = "invalid"
+		}).
+		Return(nil).
+		Once()
+
+	res, err := c.GetBlockHashRange(10, 12)
+	assert.NoError(t, err)
+	assert.Len(t, res, 3)
+
+	assert.NoError(t, res[0].Err)
+	assert.Equal(t, blockHash, res[0].Value)
+	assert.ErrorIs(t, res[1].Err, callErr)
+	assert.Error(t, res[2].Err)
+}
+
+func TestChain_GetBlockHashRangeErrors(t *testing.T) {
+	cl := mocks.NewClient(t)
+	c := NewChain(cl)
+
+	res, err := c.GetBlockHashRange(2, 1)
+	assert.ErrorIs(t, err, ErrInvalidBlockRange)
+	assert.Nil(t, res)
+
+	sendErr := errors.New("connection closed")
+
+	cl.On("BatchCallContext", mock.Anything, mock.Anything).
+		Return(sendErr).
+		Once()
+
+	res, err = c.GetBlockHashRange(1, 2)
+	assert.ErrorIs(t, err, sendErr)
+	assert.Nil(t, res)
+}
@@ -24,6 +24,8 @@ import (
 )
 
 func TestChain_GetBlockHash(t *testing.T) {
+	skipWithoutNode(t)
+
 	res, err := testChain.GetBlockHash(1)
 	assert.NoError(t, err)
 
@@ -33,6 +35,8 @@ func TestChain_GetBlockHash(t *testing.T) {
 }
 
 func TestChain_GetBlockHashLatest(t *testing.T) {
+	skipWithoutNode(t)
+
 	res, err := testChain.GetBlockHashLatest()
 	assert.NoError(t, err)
 
@@ -23,12 +23,16 @@ import (
 )
 
 func TestChain_GetBlockLatest(t *testing.T) {
+	skipWithoutNode(t)
+
 	rv, err := testChain.GetBlockLatest()
 	assert.NoError(t, err)
 	assert.True(t, rv.Block.Header.Number > 0)
 }
 
 func TestChain_GetBlock(t *testing.T) {
+	skipWithoutNode(t)
+
 	rv, err := testChain.GetBlockLatest()
 	assert.NoError(t, err)
 
@@ -0,0 +1,46 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// GetBlockWithJustifications is like GetBlock but also returns the justifications of the block, e.g. the GRANDPA
+// justification of blocks that end an authority set. Archive nodes keep these justifications for all such blocks.
+func (c *chain) GetBlockWithJustifications(blockHash types.Hash) (*types.SignedBlockWithJustifications, error) {
+	return c.GetBlockWithJustificationsContext(context.Background(), blockHash)
+}
+
+// GetBlockWithJustificationsContext is like GetBlockWithJustifications but uses the provided context for the RPC
+// call.
+func (c *chain) GetBlockWithJustificationsContext(
+	ctx context.Context,
+	blockHash types.Hash,
+) (*types.SignedBlockWithJustifications, error) {
+	var block types.SignedBlockWithJustifications
+
+	err := client.CallWithBlockHashContext(ctx, c.client, &block, "chain_getBlock", &blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
@@ -0,0 +1,46 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_GetBlockWithJustifications(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Respond("chain_getBlock", map[string]interface{}{
+			"block": map[string]interface{}{
+				"header":     testHeader(8),
+				"extrinsics": []string{},
+			},
+			"justifications": [][]interface{}{{[]int{70, 82, 78, 75}, []int{1, 2, 3}}},
+		}, testBlockHash.Hex())
+
+	res, err := NewChain(cl).GetBlockWithJustifications(testBlockHash)
+	require.NoError(t, err)
+
+	assert.Equal(t, types.BlockNumber(8), res.Block.Header.Number)
+
+	justification, ok := res.Justifications.Get(types.GrandpaEngineID)
+	assert.True(t, ok)
+	assert.Equal(t, types.Justification{1, 2, 3}, justification)
+}
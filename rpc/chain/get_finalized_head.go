@@ -17,14 +17,21 @@
 package chain
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // GetFinalizedHead returns the hash of the last finalized block in the canon chain
 func (c *chain) GetFinalizedHead() (types.Hash, error) {
+	return c.GetFinalizedHeadContext(context.Background())
+}
+
+// GetFinalizedHeadContext is like GetFinalizedHead but uses the provided context for the RPC call.
+func (c *chain) GetFinalizedHeadContext(ctx context.Context) (types.Hash, error) {
 	var res string
 
-	err := c.client.Call(&res, "chain_getFinalizedHead")
+	err := c.client.CallContext(ctx, &res, "chain_getFinalizedHead")
 	if err != nil {
 		return types.Hash{}, err
 	}
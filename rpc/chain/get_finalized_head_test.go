@@ -23,6 +23,8 @@ import (
 )
 
 func TestChain_GetFinalizedHead(t *testing.T) {
+	skipWithoutNode(t)
+
 	res, err := testChain.GetFinalizedHead()
 	assert.NoError(t, err)
 
@@ -23,12 +23,16 @@ import (
 )
 
 func TestChain_GetHeaderLatest(t *testing.T) {
+	skipWithoutNode(t)
+
 	header, err := testChain.GetHeaderLatest()
 	assert.NoError(t, err)
 	assert.NotEmpty(t, header.Number)
 }
 
 func TestChain_GetHeader(t *testing.T) {
+	skipWithoutNode(t)
+
 	res, err := testChain.GetFinalizedHead()
 	assert.NoError(t, err)
 
@@ -0,0 +1,63 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// IsFinalized checks whether the block with the given hash is finalized. A block is finalized if it is not above the
+// finalized head and it is the block of the canonical chain at its height, since the canonical chain never changes
+// below the finalized head. Blocks that are unknown to the node result in an error.
+func (c *chain) IsFinalized(blockHash types.Hash) (bool, error) {
+	return c.IsFinalizedContext(context.Background(), blockHash)
+}
+
+// IsFinalizedContext is like IsFinalized but uses the provided context for the RPC calls.
+func (c *chain) IsFinalizedContext(ctx context.Context, blockHash types.Hash) (bool, error) {
+	header, err := c.GetHeaderContext(ctx, blockHash)
+	if err != nil {
+		return false, err
+	}
+
+	finalizedHash, err := c.GetFinalizedHeadContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if finalizedHash == blockHash {
+		return true, nil
+	}
+
+	finalizedHeader, err := c.GetHeaderContext(ctx, finalizedHash)
+	if err != nil {
+		return false, err
+	}
+
+	if header.Number >= finalizedHeader.Number {
+		return false, nil
+	}
+
+	canonicalHash, err := c.GetBlockHashContext(ctx, uint64(header.Number))
+	if err != nil {
+		return false, err
+	}
+
+	return canonicalHash == blockHash, nil
+}
@@ -0,0 +1,94 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testFinalizedHash = types.Hash{1}
+	testBlockHash     = types.Hash{2}
+	testForkHash      = types.Hash{3}
+)
+
+func testHeader(number uint32) map[string]interface{} {
+	return map[string]interface{}{
+		"parentHash":     types.Hash{}.Hex(),
+		"number":         fmt.Sprintf("0x%x", number),
+		"stateRoot":      types.Hash{}.Hex(),
+		"extrinsicsRoot": types.Hash{}.Hex(),
+		"digest":         map[string]interface{}{"logs": []string{}},
+	}
+}
+
+func newTestFinalityClient() *rpcmocksrv.MockClient {
+	return rpcmocksrv.NewMockClient().
+		Respond("chain_getFinalizedHead", testFinalizedHash.Hex()).
+		Respond("chain_getHeader", testHeader(10), testFinalizedHash.Hex()).
+		Respond("chain_getBlockHash", testBlockHash.Hex(), 8)
+}
+
+func TestChain_IsFinalized(t *testing.T) {
+	cl := newTestFinalityClient().
+		Respond("chain_getHeader", testHeader(8), testBlockHash.Hex())
+
+	res, err := NewChain(cl).IsFinalized(testBlockHash)
+	assert.NoError(t, err)
+	assert.True(t, res)
+}
+
+func TestChain_IsFinalized_FinalizedHead(t *testing.T) {
+	res, err := NewChain(newTestFinalityClient()).IsFinalized(testFinalizedHash)
+	assert.NoError(t, err)
+	assert.True(t, res)
+}
+
+func TestChain_IsFinalized_Fork(t *testing.T) {
+	// The fork block is below the finalized head, but not on the canonical chain.
+	cl := newTestFinalityClient().
+		Respond("chain_getHeader", testHeader(8), testForkHash.Hex())
+
+	res, err := NewChain(cl).IsFinalized(testForkHash)
+	assert.NoError(t, err)
+	assert.False(t, res)
+}
+
+func TestChain_IsFinalized_AboveFinalizedHead(t *testing.T) {
+	cl := newTestFinalityClient().
+		Respond("chain_getHeader", testHeader(11), testBlockHash.Hex())
+
+	res, err := NewChain(cl).IsFinalized(testBlockHash)
+	assert.NoError(t, err)
+	assert.False(t, res)
+
+	cl.AssertNotCalled(t, "chain_getBlockHash")
+}
+
+func TestChain_IsFinalized_UnknownBlock(t *testing.T) {
+	cl := newTestFinalityClient().
+		RespondError("chain_getHeader", rpcmocksrv.FixtureError{Code: 4003, Message: "unknown block"}, testBlockHash.Hex())
+
+	res, err := NewChain(cl).IsFinalized(testBlockHash)
+	assert.Error(t, err)
+	assert.False(t, res)
+}
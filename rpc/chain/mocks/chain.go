@@ -3,6 +3,9 @@
 package mocks
 
 import (
+	context "context"
+
+	client "github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	chain "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
 	mock "github.com/stretchr/testify/mock"
 
@@ -37,6 +40,29 @@ func (_m *Chain) GetBlock(blockHash types.Hash) (*types.SignedBlock, error) {
 	return r0, r1
 }
 
+// GetBlockContext provides a mock function with given fields: ctx, blockHash
+func (_m *Chain) GetBlockContext(ctx context.Context, blockHash types.Hash) (*types.SignedBlock, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 *types.SignedBlock
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) *types.SignedBlock); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.SignedBlock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockHash provides a mock function with given fields: blockNumber
 func (_m *Chain) GetBlockHash(blockNumber uint64) (types.Hash, error) {
 	ret := _m.Called(blockNumber)
@@ -60,6 +86,27 @@ func (_m *Chain) GetBlockHash(blockNumber uint64) (types.Hash, error) {
 	return r0, r1
 }
 
+// GetBlockHashContext provides a mock function with given fields: ctx, blockNumber
+func (_m *Chain) GetBlockHashContext(ctx context.Context, blockNumber uint64) (types.Hash, error) {
+	ret := _m.Called(ctx, blockNumber)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) types.Hash); ok {
+		r0 = rf(ctx, blockNumber)
+	} else {
+		r0 = ret.Get(0).(types.Hash)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockHashLatest provides a mock function with given fields:
 func (_m *Chain) GetBlockHashLatest() (types.Hash, error) {
 	ret := _m.Called()
@@ -83,6 +130,73 @@ func (_m *Chain) GetBlockHashLatest() (types.Hash, error) {
 	return r0, r1
 }
 
+// GetBlockHashLatestContext provides a mock function with given fields: ctx
+func (_m *Chain) GetBlockHashLatestContext(ctx context.Context) (types.Hash, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context) types.Hash); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Hash)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockHashRange provides a mock function with given fields: start, end
+func (_m *Chain) GetBlockHashRange(start uint64, end uint64) ([]client.BatchResult[types.Hash], error) {
+	ret := _m.Called(start, end)
+
+	var r0 []client.BatchResult[types.Hash]
+	if rf, ok := ret.Get(0).(func(uint64, uint64) []client.BatchResult[types.Hash]); ok {
+		r0 = rf(start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.BatchResult[types.Hash])
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockHashRangeContext provides a mock function with given fields: ctx, start, end
+func (_m *Chain) GetBlockHashRangeContext(ctx context.Context, start uint64, end uint64) ([]client.BatchResult[types.Hash], error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []client.BatchResult[types.Hash]
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []client.BatchResult[types.Hash]); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.BatchResult[types.Hash])
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockLatest provides a mock function with given fields:
 func (_m *Chain) GetBlockLatest() (*types.SignedBlock, error) {
 	ret := _m.Called()
@@ -106,6 +220,75 @@ func (_m *Chain) GetBlockLatest() (*types.SignedBlock, error) {
 	return r0, r1
 }
 
+// GetBlockLatestContext provides a mock function with given fields: ctx
+func (_m *Chain) GetBlockLatestContext(ctx context.Context) (*types.SignedBlock, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *types.SignedBlock
+	if rf, ok := ret.Get(0).(func(context.Context) *types.SignedBlock); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.SignedBlock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockWithJustifications provides a mock function with given fields: blockHash
+func (_m *Chain) GetBlockWithJustifications(blockHash types.Hash) (*types.SignedBlockWithJustifications, error) {
+	ret := _m.Called(blockHash)
+
+	var r0 *types.SignedBlockWithJustifications
+	if rf, ok := ret.Get(0).(func(types.Hash) *types.SignedBlockWithJustifications); ok {
+		r0 = rf(blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.SignedBlockWithJustifications)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
+		r1 = rf(blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockWithJustificationsContext provides a mock function with given fields: ctx, blockHash
+func (_m *Chain) GetBlockWithJustificationsContext(ctx context.Context, blockHash types.Hash) (*types.SignedBlockWithJustifications, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 *types.SignedBlockWithJustifications
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) *types.SignedBlockWithJustifications); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.SignedBlockWithJustifications)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFinalizedHead provides a mock function with given fields:
 func (_m *Chain) GetFinalizedHead() (types.Hash, error) {
 	ret := _m.Called()
@@ -129,6 +312,27 @@ func (_m *Chain) GetFinalizedHead() (types.Hash, error) {
 	return r0, r1
 }
 
+// GetFinalizedHeadContext provides a mock function with given fields: ctx
+func (_m *Chain) GetFinalizedHeadContext(ctx context.Context) (types.Hash, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context) types.Hash); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Hash)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetHeader provides a mock function with given fields: blockHash
 func (_m *Chain) GetHeader(blockHash types.Hash) (*types.Header, error) {
 	ret := _m.Called(blockHash)
@@ -152,6 +356,29 @@ func (_m *Chain) GetHeader(blockHash types.Hash) (*types.Header, error) {
 	return r0, r1
 }
 
+// GetHeaderContext provides a mock function with given fields: ctx, blockHash
+func (_m *Chain) GetHeaderContext(ctx context.Context, blockHash types.Hash) (*types.Header, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 *types.Header
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) *types.Header); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Header)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetHeaderLatest provides a mock function with given fields:
 func (_m *Chain) GetHeaderLatest() (*types.Header, error) {
 	ret := _m.Called()
@@ -175,6 +402,71 @@ func (_m *Chain) GetHeaderLatest() (*types.Header, error) {
 	return r0, r1
 }
 
+// GetHeaderLatestContext provides a mock function with given fields: ctx
+func (_m *Chain) GetHeaderLatestContext(ctx context.Context) (*types.Header, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *types.Header
+	if rf, ok := ret.Get(0).(func(context.Context) *types.Header); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Header)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsFinalized provides a mock function with given fields: blockHash
+func (_m *Chain) IsFinalized(blockHash types.Hash) (bool, error) {
+	ret := _m.Called(blockHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(types.Hash) bool); ok {
+		r0 = rf(blockHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
+		r1 = rf(blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsFinalizedContext provides a mock function with given fields: ctx, blockHash
+func (_m *Chain) IsFinalizedContext(ctx context.Context, blockHash types.Hash) (bool, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) bool); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubscribeFinalizedHeads provides a mock function with given fields:
 func (_m *Chain) SubscribeFinalizedHeads() (*chain.FinalizedHeadsSubscription, error) {
 	ret := _m.Called()
@@ -198,6 +490,52 @@ func (_m *Chain) SubscribeFinalizedHeads() (*chain.FinalizedHeadsSubscription, e
 	return r0, r1
 }
 
+// SubscribeFinalizedHeadsContext provides a mock function with given fields: ctx
+func (_m *Chain) SubscribeFinalizedHeadsContext(ctx context.Context) (*chain.FinalizedHeadsSubscription, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *chain.FinalizedHeadsSubscription
+	if rf, ok := ret.Get(0).(func(context.Context) *chain.FinalizedHeadsSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*chain.FinalizedHeadsSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeFinalizedHeadsWithOptions provides a mock function with given fields: ctx, opts
+func (_m *Chain) SubscribeFinalizedHeadsWithOptions(ctx context.Context, opts client.SubscriptionOptions) (*chain.FinalizedHeadsSubscription, error) {
+	ret := _m.Called(ctx, opts)
+
+	var r0 *chain.FinalizedHeadsSubscription
+	if rf, ok := ret.Get(0).(func(context.Context, client.SubscriptionOptions) *chain.FinalizedHeadsSubscription); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*chain.FinalizedHeadsSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, client.SubscriptionOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubscribeNewHeads provides a mock function with given fields:
 func (_m *Chain) SubscribeNewHeads() (*chain.NewHeadsSubscription, error) {
 	ret := _m.Called()
@@ -221,6 +559,52 @@ func (_m *Chain) SubscribeNewHeads() (*chain.NewHeadsSubscription, error) {
 	return r0, r1
 }
 
+// SubscribeNewHeadsContext provides a mock function with given fields: ctx
+func (_m *Chain) SubscribeNewHeadsContext(ctx context.Context) (*chain.NewHeadsSubscription, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *chain.NewHeadsSubscription
+	if rf, ok := ret.Get(0).(func(context.Context) *chain.NewHeadsSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*chain.NewHeadsSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeNewHeadsWithOptions provides a mock function with given fields: ctx, opts
+func (_m *Chain) SubscribeNewHeadsWithOptions(ctx context.Context, opts client.SubscriptionOptions) (*chain.NewHeadsSubscription, error) {
+	ret := _m.Called(ctx, opts)
+
+	var r0 *chain.NewHeadsSubscription
+	if rf, ok := ret.Get(0).(func(context.Context, client.SubscriptionOptions) *chain.NewHeadsSubscription); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*chain.NewHeadsSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, client.SubscriptionOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewChainT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,48 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Provider is the subset of Chain that the higher-level helpers of the library depend on, e.g. the submitter and the
+// upgrade watcher. It is satisfied by Chain and by the fakes of the fakes package.
+type Provider interface {
+	SubscribeNewHeads() (*NewHeadsSubscription, error)
+	SubscribeNewHeadsContext(ctx context.Context) (*NewHeadsSubscription, error)
+	SubscribeFinalizedHeads() (*FinalizedHeadsSubscription, error)
+	SubscribeFinalizedHeadsContext(ctx context.Context) (*FinalizedHeadsSubscription, error)
+
+	GetBlockHash(blockNumber uint64) (types.Hash, error)
+	GetBlockHashContext(ctx context.Context, blockNumber uint64) (types.Hash, error)
+	GetBlockHashLatest() (types.Hash, error)
+	GetBlockHashLatestContext(ctx context.Context) (types.Hash, error)
+	GetFinalizedHead() (types.Hash, error)
+	GetFinalizedHeadContext(ctx context.Context) (types.Hash, error)
+
+	GetBlock(blockHash types.Hash) (*types.SignedBlock, error)
+	GetBlockContext(ctx context.Context, blockHash types.Hash) (*types.SignedBlock, error)
+	GetHeader(blockHash types.Hash) (*types.Header, error)
+	GetHeaderContext(ctx context.Context, blockHash types.Hash) (*types.Header, error)
+	GetHeaderLatest() (*types.Header, error)
+	GetHeaderLatestContext(ctx context.Context) (*types.Header, error)
+}
+
+var _ Provider = (*chain)(nil)
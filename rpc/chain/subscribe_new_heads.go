@@ -18,8 +18,8 @@ package chain
 
 import (
 	"context"
-	"sync"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -27,16 +27,15 @@ import (
 
 // NewHeadsSubscription is a subscription established through one of the Client's subscribe methods.
 type NewHeadsSubscription struct {
-	sub      *gethrpc.ClientSubscription
-	channel  chan types.Header
-	quitOnce sync.Once // ensures quit is closed once
+	sub    *gethrpc.ClientSubscription
+	buffer *client.SubscriptionBuffer[types.Header]
 }
 
 // Chan returns the subscription channel.
 //
 // The channel is closed when Unsubscribe is called on the subscription.
 func (s *NewHeadsSubscription) Chan() <-chan types.Header {
-	return s.channel
+	return s.buffer.Out()
 }
 
 // Err returns the subscription error channel. The intended use of Err is to schedule
@@ -51,27 +50,58 @@ func (s *NewHeadsSubscription) Err() <-chan error {
 	return s.sub.Err()
 }
 
+// Gap returns a channel that receives a value when notifications might have been missed, which happens
+// when the subscription was re-established after a reconnect, see client.ConnectWithReconnect, or when
+// notifications were dropped, see client.OverflowDropOldest.
+func (s *NewHeadsSubscription) Gap() <-chan struct{} {
+	return s.sub.Gap()
+}
+
+// Dropped returns the number of notifications that were dropped because the consumer did not keep up, see
+// client.OverflowDropOldest.
+func (s *NewHeadsSubscription) Dropped() uint64 {
+	return s.buffer.Dropped()
+}
+
 // Unsubscribe unsubscribes the notification and closes the error channel.
 // It can safely be called more than once.
 func (s *NewHeadsSubscription) Unsubscribe() {
 	s.sub.Unsubscribe()
-	s.quitOnce.Do(func() {
-		close(s.channel)
-	})
+	s.buffer.Close()
 }
 
 // SubscribeNewHeads subscribes the best headers, returning a subscription that will
 // receive server notifications containing the Header.
 func (c *chain) SubscribeNewHeads() (*NewHeadsSubscription, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	return c.SubscribeNewHeadsContext(context.Background())
+}
+
+// SubscribeNewHeadsContext is like SubscribeNewHeads but the subscription is ended once ctx is done.
+// The subscription request itself is bound by both ctx and the configured subscribe timeout.
+func (c *chain) SubscribeNewHeadsContext(ctx context.Context) (*NewHeadsSubscription, error) {
+	return c.SubscribeNewHeadsWithOptions(ctx, client.SubscriptionOptions{})
+}
+
+// SubscribeNewHeadsWithOptions is like SubscribeNewHeadsContext but buffers the notifications according
+// to the options, e.g. to drop the oldest headers when the consumer does not keep up.
+func (c *chain) SubscribeNewHeadsWithOptions(
+	ctx context.Context,
+	opts client.SubscriptionOptions,
+) (*NewHeadsSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
 	defer cancel()
 
-	ch := make(chan types.Header)
+	buffer := client.NewSubscriptionBuffer[types.Header]("chain_subscribeNewHead", opts)
 
-	sub, err := c.client.Subscribe(ctx, "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	sub, err := c.client.Subscribe(subscribeCtx, "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", buffer.In())
 	if err != nil {
 		return nil, err
 	}
 
-	return &NewHeadsSubscription{sub: sub, channel: ch}, nil
+	buffer.Forward(sub)
+
+	subscription := &NewHeadsSubscription{sub: sub, buffer: buffer}
+	client.UnsubscribeOnCancel(ctx, sub.Done(), subscription.Unsubscribe)
+
+	return subscription, nil
 }
@@ -0,0 +1,99 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func subscribeTestHeads(t *testing.T, count int, opts client.SubscriptionOptions) *NewHeadsSubscription {
+	headers := make([]interface{}, 0, count)
+
+	for i := 0; i < count; i++ {
+		headers = append(headers, testHeader(uint32(i)))
+	}
+
+	cl := rpcmocksrv.NewMockClient().Notify("chain_subscribeNewHead", headers)
+
+	sub, err := NewChain(cl).SubscribeNewHeadsWithOptions(context.Background(), opts)
+	require.NoError(t, err)
+
+	t.Cleanup(sub.Unsubscribe)
+
+	return sub
+}
+
+func TestChain_SubscribeNewHeadsWithOptions_Block(t *testing.T) {
+	sub := subscribeTestHeads(t, 10, client.SubscriptionOptions{BufferSize: 2})
+
+	// The slow consumer receives all headers in order.
+	for i := 0; i < 10; i++ {
+		time.Sleep(time.Millisecond)
+
+		assert.Equal(t, types.BlockNumber(i), (<-sub.Chan()).Number)
+	}
+
+	assert.Zero(t, sub.Dropped())
+}
+
+func TestChain_SubscribeNewHeadsWithOptions_DropOldest(t *testing.T) {
+	var onDrop atomic.Uint64
+
+	sub := subscribeTestHeads(t, 10, client.SubscriptionOptions{
+		BufferSize: 2,
+		Policy:     client.OverflowDropOldest,
+		OnDrop:     onDrop.Store,
+	})
+
+	// The consumer stalls until all but the buffered headers are dropped, the newest headers are kept.
+	require.Eventually(t, func() bool { return sub.Dropped() == 8 }, 5*time.Second, time.Millisecond)
+	assert.Equal(t, uint64(8), onDrop.Load())
+
+	assert.Equal(t, types.BlockNumber(8), (<-sub.Chan()).Number)
+	assert.Equal(t, types.BlockNumber(9), (<-sub.Chan()).Number)
+
+	select {
+	case <-sub.Gap():
+	default:
+		t.Fatal("dropped headers were not signaled as gap")
+	}
+}
+
+func TestChain_SubscribeNewHeadsWithOptions_Error(t *testing.T) {
+	sub := subscribeTestHeads(t, 10, client.SubscriptionOptions{BufferSize: 2, Policy: client.OverflowError})
+
+	select {
+	case err := <-sub.Err():
+		assert.ErrorIs(t, err, client.ErrSubscriptionBufferOverflow)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription did not fail")
+	}
+
+	// The headers that were buffered before the overflow are still received.
+	assert.Equal(t, types.BlockNumber(0), (<-sub.Chan()).Number)
+	assert.Equal(t, types.BlockNumber(1), (<-sub.Chan()).Number)
+	assert.Zero(t, sub.Dropped())
+}
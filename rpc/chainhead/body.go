@@ -0,0 +1,49 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Body returns the SCALE encoded extrinsics of a pinned block.
+func (s *FollowSubscription) Body(blockHash types.Hash) ([]types.Bytes, error) {
+	return s.BodyContext(context.Background(), blockHash)
+}
+
+// BodyContext is like Body but uses the provided context for the operation, which is stopped once ctx is done.
+func (s *FollowSubscription) BodyContext(ctx context.Context, blockHash types.Hash) ([]types.Bytes, error) {
+	operationID, ch, err := s.startOperation(ctx, "chainHead_v1_body", blockHash.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.finishOperation(operationID)
+
+	event, err := s.waitOperationEvent(ctx, operationID, ch)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Event != types.ChainHeadOperationBodyDone {
+		return nil, operationEventError(event)
+	}
+
+	return event.Extrinsics, nil
+}
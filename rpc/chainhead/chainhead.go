@@ -0,0 +1,93 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name ChainHead --filename chainhead.go
+
+package chainhead
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	ErrLimitReached               = libErr.Error("operation limit reached")
+	ErrOperationInaccessible      = libErr.Error("operation inaccessible")
+	ErrOperationFailed            = libErr.Error("operation failed")
+	ErrUnexpectedOperationEvent   = libErr.Error("unexpected operation event")
+	ErrFollowSubscriptionStopped  = libErr.Error("follow subscription stopped")
+	ErrHeaderNotFound             = libErr.Error("header not found")
+	ErrOperationStart             = libErr.Error("operation start")
+	ErrStorageOperationContinue   = libErr.Error("storage operation continue")
+	ErrFollowSubscriptionCreation = libErr.Error("follow subscription creation")
+)
+
+// ChainHead exposes the chainHead_v1 group of RPC methods, which replaces the legacy chain_* subscriptions.
+type ChainHead interface {
+	Follow(withRuntime bool) (*FollowSubscription, error)
+	FollowContext(ctx context.Context, withRuntime bool) (*FollowSubscription, error)
+}
+
+// chainHead exposes methods for following the head of the chain
+type chainHead struct {
+	client client.Client
+}
+
+// NewChainHead creates a new chainHead struct
+func NewChainHead(cl client.Client) ChainHead {
+	return &chainHead{cl}
+}
+
+// Follow starts a chainHead_v1_follow subscription, returning a subscription that will receive the block events
+// of the chain. All other chainHead_v1 methods are available on the returned subscription.
+//
+// If withRuntime is true, the node reports the runtime of the finalized block in the initialized event and the
+// runtime changes in the newBlock events.
+func (c *chainHead) Follow(withRuntime bool) (*FollowSubscription, error) {
+	return c.FollowContext(context.Background(), withRuntime)
+}
+
+// FollowContext is like Follow but the subscription is ended once ctx is done.
+// The subscription request itself is bound by both ctx and the configured subscribe timeout.
+func (c *chainHead) FollowContext(ctx context.Context, withRuntime bool) (*FollowSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
+	defer cancel()
+
+	events := make(chan types.ChainHeadFollowEvent)
+
+	sub, err := c.client.Subscribe(
+		subscribeCtx,
+		"chainHead",
+		"v1_follow",
+		"v1_unfollow",
+		"v1_followEvent",
+		events,
+		withRuntime,
+	)
+	if err != nil {
+		return nil, ErrFollowSubscriptionCreation.Wrap(err)
+	}
+
+	fs := newFollowSubscription(c.client, sub, events)
+
+	client.UnsubscribeOnCancel(ctx, fs.done, fs.Unsubscribe)
+
+	return fs, nil
+}
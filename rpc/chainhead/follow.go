@@ -0,0 +1,257 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// subscription is the part of the gethrpc.ClientSubscription that is used by the FollowSubscription.
+type subscription interface {
+	ID() string
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// FollowSubscription is a subscription established through ChainHead.Follow.
+//
+// The block events (initialized, newBlock, bestBlockChanged, finalized and stop) are delivered via Chan, while the
+// events of the operations started via Body, Call and Storage are handled internally. As such, Chan must be
+// consumed for operations to make progress.
+type FollowSubscription struct {
+	client client.Client
+	sub    subscription
+
+	events  chan types.ChainHeadFollowEvent
+	channel chan types.ChainHeadFollowEvent
+	err     chan error
+
+	opsMu sync.Mutex
+	ops   map[string]*operation
+
+	quit     chan struct{}
+	done     chan struct{}
+	quitOnce sync.Once // ensures quit is closed once
+}
+
+// operation is an operation started via the FollowSubscription.
+type operation struct {
+	events chan types.ChainHeadFollowEvent
+	// done is closed once the caller that started the operation no longer waits for its events.
+	done chan struct{}
+}
+
+func newFollowSubscription(
+	cl client.Client,
+	sub subscription,
+	events chan types.ChainHeadFollowEvent,
+) *FollowSubscription {
+	s := &FollowSubscription{
+		client:  cl,
+		sub:     sub,
+		events:  events,
+		channel: make(chan types.ChainHeadFollowEvent),
+		err:     make(chan error, 1),
+		ops:     make(map[string]*operation),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go s.dispatch()
+
+	return s
+}
+
+// ID returns the ID of the follow subscription, as assigned by the node.
+func (s *FollowSubscription) ID() string {
+	return s.sub.ID()
+}
+
+// Chan returns the subscription channel.
+//
+// The channel is closed when Unsubscribe is called on the subscription.
+func (s *FollowSubscription) Chan() <-chan types.ChainHeadFollowEvent {
+	return s.channel
+}
+
+// Err returns the subscription error channel. The intended use of Err is to schedule
+// resubscription when the client connection is closed unexpectedly.
+//
+// The error channel receives a value when the subscription has ended due
+// to an error. The received error is nil if Close has been called
+// on the underlying client and no other error has occurred.
+//
+// The error channel is closed when Unsubscribe is called on the subscription.
+func (s *FollowSubscription) Err() <-chan error {
+	return s.err
+}
+
+// Unsubscribe stops following the chain and closes the subscription and error channels.
+// It can safely be called more than once.
+func (s *FollowSubscription) Unsubscribe() {
+	s.quitOnce.Do(func() {
+		close(s.quit)
+		s.sub.Unsubscribe()
+
+		<-s.done
+
+		close(s.channel)
+		close(s.err)
+	})
+}
+
+// dispatch forwards the block events to the subscription channel and the operation events to
+// the operation that they belong to.
+func (s *FollowSubscription) dispatch() {
+	defer close(s.done)
+
+	for {
+		select {
+		case <-s.quit:
+			return
+		case err, ok := <-s.sub.Err():
+			if ok {
+				s.err <- err
+			}
+
+			return
+		case event := <-s.events:
+			if event.IsOperationEvent() {
+				s.dispatchOperationEvent(event)
+
+				continue
+			}
+
+			if event.Event == types.ChainHeadFinalized && len(event.PrunedBlockHashes) > 0 {
+				// Pruned blocks can't be used anymore, failing to unpin them only means that
+				// the node will keep them around until the subscription ends.
+				_ = s.Unpin(event.PrunedBlockHashes...)
+			}
+
+			select {
+			case s.channel <- event:
+			case <-s.quit:
+				return
+			}
+
+			if event.Event == types.ChainHeadStop {
+				return
+			}
+		}
+	}
+}
+
+func (s *FollowSubscription) dispatchOperationEvent(event types.ChainHeadFollowEvent) {
+	s.opsMu.Lock()
+	op, ok := s.ops[event.OperationID]
+	s.opsMu.Unlock()
+
+	if !ok {
+		// The operation was not started via this subscription or it already finished.
+		return
+	}
+
+	select {
+	case op.events <- event:
+	case <-op.done:
+		// The caller returned while the event was in flight, the event is dropped.
+	case <-s.quit:
+	}
+}
+
+// startOperation calls the provided method and registers the operation that it started.
+//
+// The lock is held during the call so that the events of the operation are only dispatched once the
+// operation is registered.
+func (s *FollowSubscription) startOperation(
+	ctx context.Context,
+	method string,
+	args ...interface{},
+) (string, chan types.ChainHeadFollowEvent, error) {
+	s.opsMu.Lock()
+	defer s.opsMu.Unlock()
+
+	var res types.ChainHeadOperationStarted
+
+	if err := s.client.CallContext(ctx, &res, method, append([]interface{}{s.sub.ID()}, args...)...); err != nil {
+		return "", nil, ErrOperationStart.Wrap(err)
+	}
+
+	if res.Result != "started" {
+		return "", nil, ErrLimitReached
+	}
+
+	op := &operation{
+		events: make(chan types.ChainHeadFollowEvent),
+		done:   make(chan struct{}),
+	}
+
+	s.ops[res.OperationID] = op
+
+	return res.OperationID, op.events, nil
+}
+
+// finishOperation deregisters an operation, it must be called on every return path of the operation's caller.
+func (s *FollowSubscription) finishOperation(operationID string) {
+	s.opsMu.Lock()
+	defer s.opsMu.Unlock()
+
+	op, ok := s.ops[operationID]
+	if !ok {
+		return
+	}
+
+	delete(s.ops, operationID)
+	close(op.done)
+}
+
+// waitOperationEvent waits for the next event of an operation.
+//
+// The operation is stopped if ctx is done before that.
+func (s *FollowSubscription) waitOperationEvent(
+	ctx context.Context,
+	operationID string,
+	ch chan types.ChainHeadFollowEvent,
+) (types.ChainHeadFollowEvent, error) {
+	select {
+	case event := <-ch:
+		return event, nil
+	case <-s.done:
+		return types.ChainHeadFollowEvent{}, ErrFollowSubscriptionStopped
+	case <-ctx.Done():
+		// The operation is abandoned either way, the node only needs to be told to spare the work.
+		_ = s.client.CallContext(context.Background(), nil, "chainHead_v1_stopOperation", s.sub.ID(), operationID)
+
+		return types.ChainHeadFollowEvent{}, ctx.Err()
+	}
+}
+
+// operationEventError returns the error for an event that does not hold the expected operation result.
+func operationEventError(event types.ChainHeadFollowEvent) error {
+	switch event.Event {
+	case types.ChainHeadOperationInaccessible:
+		return ErrOperationInaccessible
+	case types.ChainHeadOperationError:
+		return ErrOperationFailed.WithMsg("%s", event.Error)
+	default:
+		return ErrUnexpectedOperationEvent.WithMsg("%s", event.Event)
+	}
+}
@@ -0,0 +1,445 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testSubscriptionID = "test-subscription"
+
+var testBlockHash = types.NewHash([]byte{0x01, 0x02, 0x03})
+
+type testSubscription struct {
+	err            chan error
+	unsubscribeCnt int
+}
+
+func newTestSubscription() *testSubscription {
+	return &testSubscription{err: make(chan error, 1)}
+}
+
+func (s *testSubscription) ID() string {
+	return testSubscriptionID
+}
+
+func (s *testSubscription) Err() <-chan error {
+	return s.err
+}
+
+func (s *testSubscription) Unsubscribe() {
+	s.unsubscribeCnt++
+	close(s.err)
+}
+
+func newTestFollowSubscription(t *testing.T) (
+	*FollowSubscription,
+	*mocks.Client,
+	*testSubscription,
+	chan types.ChainHeadFollowEvent,
+) {
+	cl := mocks.NewClient(t)
+	sub := newTestSubscription()
+	events := make(chan types.ChainHeadFollowEvent)
+
+	fs := newFollowSubscription(cl, sub, events)
+
+	return fs, cl, sub, events
+}
+
+func mockOperationStarted(cl *mocks.Client, operationID string, args ...interface{}) {
+	cl.On("CallContext", append([]interface{}{mock.Anything, mock.Anything}, args...)...).
+		Run(func(args mock.Arguments) {
+			res := args.Get(1).(*types.ChainHeadOperationStarted)
+			res.Result = "started"
+			res.OperationID = operationID
+		}).
+		Return(nil).
+		Once()
+}
+
+func TestFollowSubscription_BlockEvents(t *testing.T) {
+	fs, cl, sub, events := newTestFollowSubscription(t)
+
+	prunedHash := types.NewHash([]byte{0x04})
+
+	cl.On("CallContext", mock.Anything, nil, "chainHead_v1_unpin", testSubscriptionID, []string{prunedHash.Hex()}).
+		Return(nil).
+		Once()
+
+	newBlock := types.ChainHeadFollowEvent{Event: types.ChainHeadNewBlock, BlockHash: testBlockHash}
+	finalized := types.ChainHeadFollowEvent{
+		Event:                types.ChainHeadFinalized,
+		FinalizedBlockHashes: []types.Hash{testBlockHash},
+		PrunedBlockHashes:    []types.Hash{prunedHash},
+	}
+
+	go func() {
+		events <- newBlock
+		events <- finalized
+	}()
+
+	assert.Equal(t, newBlock, <-fs.Chan())
+	assert.Equal(t, finalized, <-fs.Chan())
+
+	fs.Unsubscribe()
+	fs.Unsubscribe()
+
+	assert.Equal(t, 1, sub.unsubscribeCnt)
+
+	_, ok := <-fs.Chan()
+	assert.False(t, ok)
+
+	_, ok = <-fs.Err()
+	assert.False(t, ok)
+}
+
+func TestFollowSubscription_Err(t *testing.T) {
+	fs, _, sub, _ := newTestFollowSubscription(t)
+
+	subErr := errors.New("connection closed")
+
+	sub.err <- subErr
+
+	assert.Equal(t, subErr, <-fs.Err())
+}
+
+func TestFollowSubscription_Header(t *testing.T) {
+	fs, cl, _, _ := newTestFollowSubscription(t)
+	defer fs.Unsubscribe()
+
+	header := types.Header{
+		ParentHash: testBlockHash,
+		Number:     12,
+	}
+
+	encodedHeader, err := codec.EncodeToHex(header)
+	assert.NoError(t, err)
+
+	cl.On("CallContext", mock.Anything, mock.Anything, "chainHead_v1_header", testSubscriptionID, testBlockHash.Hex()).
+		Run(func(args mock.Arguments) {
+			res := args.Get(1).(**string)
+			*res = &encodedHeader
+		}).
+		Return(nil).
+		Once()
+
+	res, err := fs.Header(testBlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, &header, res)
+
+	cl.On("CallContext", mock.Anything, mock.Anything, "chainHead_v1_header", testSubscriptionID, testBlockHash.Hex()).
+		Return(nil).
+		Once()
+
+	res, err = fs.Header(testBlockHash)
+	assert.ErrorIs(t, err, ErrHeaderNotFound)
+	assert.Nil(t, res)
+}
+
+func TestFollowSubscription_Body(t *testing.T) {
+	fs, cl, _, events := newTestFollowSubscription(t)
+	defer fs.Unsubscribe()
+
+	mockOperationStarted(cl, "1", "chainHead_v1_body", testSubscriptionID, testBlockHash.Hex())
+
+	extrinsics := []types.Bytes{{0x01}, {0x02, 0x03}}
+
+	go func() {
+		// Events of unknown operations are ignored.
+		events <- types.ChainHeadFollowEvent{Event: types.ChainHeadOperationBodyDone, OperationID: "unknown"}
+		events <- types.ChainHeadFollowEvent{
+			Event:       types.ChainHeadOperationBodyDone,
+			OperationID: "1",
+			Extrinsics:  extrinsics,
+		}
+	}()
+
+	res, err := fs.Body(testBlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, extrinsics, res)
+	assert.Empty(t, fs.ops)
+}
+
+func TestFollowSubscription_Call(t *testing.T) {
+	fs, cl, _, events := newTestFollowSubscription(t)
+	defer fs.Unsubscribe()
+
+	mockOperationStarted(
+		cl,
+		"2",
+		"chainHead_v1_call",
+		testSubscriptionID,
+		testBlockHash.Hex(),
+		"Core_version",
+		"0x0102",
+	)
+
+	go func() {
+		events <- types.ChainHeadFollowEvent{
+			Event:       types.ChainHeadOperationCallDone,
+			OperationID: "2",
+			Output:      types.Bytes{0x04},
+		}
+	}()
+
+	res, err := fs.Call(testBlockHash, "Core_version", []byte{0x01, 0x02})
+	assert.NoError(t, err)
+	assert.Equal(t, types.Bytes{0x04}, res)
+}
+
+func TestFollowSubscription_Storage(t *testing.T) {
+	fs, cl, _, events := newTestFollowSubscription(t)
+	defer fs.Unsubscribe()
+
+	items := []types.ChainHeadStorageQueryItem{
+		{Key: types.StorageKey{0x01}, Type: types.ChainHeadStorageDescendantsValues},
+	}
+
+	mockOperationStarted(
+		cl,
+		"3",
+		"chainHead_v1_storage",
+		testSubscriptionID,
+		testBlockHash.Hex(),
+		items,
+		(*string)(nil),
+	)
+
+	value1 := types.NewStorageDataRaw([]byte{0x0a})
+	value2 := types.NewStorageDataRaw([]byte{0x0b})
+
+	item1 := types.ChainHeadStorageResultItem{Key: types.StorageKey{0x01, 0x01}, Value: &value1}
+	item2 := types.ChainHeadStorageResultItem{Key: types.StorageKey{0x01, 0x02}, Value: &value2}
+
+	continued := make(chan struct{})
+
+	cl.On("CallContext", mock.Anything, nil, "chainHead_v1_continue", testSubscriptionID, "3").
+		Run(func(_ mock.Arguments) {
+			close(continued)
+		}).
+		Return(nil).
+		Once()
+
+	go func() {
+		events <- types.ChainHeadFollowEvent{
+			Event:       types.ChainHeadOperationStorageItems,
+			OperationID: "3",
+			Items:       []types.ChainHeadStorageResultItem{item1},
+		}
+		events <- types.ChainHeadFollowEvent{Event: types.ChainHeadOperationWaitingForContinue, OperationID: "3"}
+
+		<-continued
+
+		events <- types.ChainHeadFollowEvent{
+			Event:       types.ChainHeadOperationStorageItems,
+			OperationID: "3",
+			Items:       []types.ChainHeadStorageResultItem{item2},
+		}
+		events <- types.ChainHeadFollowEvent{Event: types.ChainHeadOperationStorageDone, OperationID: "3"}
+	}()
+
+	res, err := fs.Storage(testBlockHash, items, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.ChainHeadStorageResultItem{item1, item2}, res)
+}
+
+func TestFollowSubscription_StorageContinueError(t *testing.T) {
+	fs, cl, _, events := newTestFollowSubscription(t)
+	defer fs.Unsubscribe()
+
+	items := []types.ChainHeadStorageQueryItem{
+		{Key: types.StorageKey{0x01}, Type: types.ChainHeadStorageDescendantsValues},
+	}
+
+	mockOperationStarted(
+		cl,
+		"3",
+		"chainHead_v1_storage",
+		testSubscriptionID,
+		testBlockHash.Hex(),
+		items,
+		(*string)(nil),
+	)
+
+	continueErr := errors.New("continue error")
+
+	cl.On("CallContext", mock.Anything, nil, "chainHead_v1_continue", testSubscriptionID, "3").
+		Run(func(_ mock.Arguments) {
+			// The node keeps sending events for the operation while its caller is about to return.
+			events <- types.ChainHeadFollowEvent{Event: types.ChainHeadOperationStorageItems, OperationID: "3"}
+
+			// Give the dispatcher time to look up the operation before its caller returns.
+			time.Sleep(50 * time.Millisecond)
+		}).
+		Return(continueErr).
+		Once()
+
+	go func() {
+		events <- types.ChainHeadFollowEvent{Event: types.ChainHeadOperationWaitingForContinue, OperationID: "3"}
+	}()
+
+	res, err := fs.Storage(testBlockHash, items, nil)
+	assert.ErrorIs(t, err, ErrStorageOperationContinue)
+	assert.ErrorIs(t, err, continueErr)
+	assert.Nil(t, res)
+	assert.Empty(t, fs.ops)
+
+	newBlock := types.ChainHeadFollowEvent{Event: types.ChainHeadNewBlock, BlockHash: testBlockHash}
+
+	go func() {
+		events <- newBlock
+	}()
+
+	select {
+	case event := <-fs.Chan():
+		assert.Equal(t, newBlock, event)
+	case <-time.After(5 * time.Second):
+		t.Fatal("block event was not dispatched")
+	}
+}
+
+func TestFollowSubscription_OperationErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		event       types.ChainHeadFollowEvent
+		expectedErr error
+	}{
+		{
+			name:        "inaccessible",
+			event:       types.ChainHeadFollowEvent{Event: types.ChainHeadOperationInaccessible},
+			expectedErr: ErrOperationInaccessible,
+		},
+		{
+			name:        "error",
+			event:       types.ChainHeadFollowEvent{Event: types.ChainHeadOperationError, Error: "boom"},
+			expectedErr: ErrOperationFailed,
+		},
+		{
+			name:        "unexpected event",
+			event:       types.ChainHeadFollowEvent{Event: types.ChainHeadOperationCallDone},
+			expectedErr: ErrUnexpectedOperationEvent,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs, cl, _, events := newTestFollowSubscription(t)
+			defer fs.Unsubscribe()
+
+			mockOperationStarted(cl, "1", "chainHead_v1_body", testSubscriptionID, testBlockHash.Hex())
+
+			go func() {
+				event := test.event
+				event.OperationID = "1"
+
+				events <- event
+			}()
+
+			res, err := fs.Body(testBlockHash)
+			assert.ErrorIs(t, err, test.expectedErr)
+			assert.Nil(t, res)
+		})
+	}
+}
+
+func TestFollowSubscription_LimitReached(t *testing.T) {
+	fs, cl, _, _ := newTestFollowSubscription(t)
+	defer fs.Unsubscribe()
+
+	cl.On("CallContext", mock.Anything, mock.Anything, "chainHead_v1_body", testSubscriptionID, testBlockHash.Hex()).
+		Run(func(args mock.Arguments) {
+			res := args.Get(1).(*types.ChainHeadOperationStarted)
+			res.Result = "limitReached"
+		}).
+		Return(nil).
+		Once()
+
+	res, err := fs.Body(testBlockHash)
+	assert.ErrorIs(t, err, ErrLimitReached)
+	assert.Nil(t, res)
+}
+
+func TestFollowSubscription_Stop(t *testing.T) {
+	fs, cl, _, events := newTestFollowSubscription(t)
+	defer fs.Unsubscribe()
+
+	mockOperationStarted(cl, "1", "chainHead_v1_body", testSubscriptionID, testBlockHash.Hex())
+
+	go func() {
+		events <- types.ChainHeadFollowEvent{Event: types.ChainHeadStop}
+	}()
+
+	errCh := make(chan error)
+
+	go func() {
+		_, err := fs.Body(testBlockHash)
+		errCh <- err
+	}()
+
+	assert.Equal(t, types.ChainHeadFollowEvent{Event: types.ChainHeadStop}, <-fs.Chan())
+
+	select {
+	case err := <-errCh:
+		assert.ErrorIs(t, err, ErrFollowSubscriptionStopped)
+	case <-time.After(5 * time.Second):
+		t.Fatal("operation did not stop")
+	}
+}
+
+func TestFollowSubscription_BodyContextCanceled(t *testing.T) {
+	fs, cl, _, _ := newTestFollowSubscription(t)
+	defer fs.Unsubscribe()
+
+	mockOperationStarted(cl, "1", "chainHead_v1_body", testSubscriptionID, testBlockHash.Hex())
+
+	cl.On("CallContext", mock.Anything, nil, "chainHead_v1_stopOperation", testSubscriptionID, "1").
+		Return(nil).
+		Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		// Cancel once the operation is registered.
+		for {
+			fs.opsMu.Lock()
+			_, ok := fs.ops["1"]
+			fs.opsMu.Unlock()
+
+			if ok {
+				cancel()
+				return
+			}
+
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	res, err := fs.BodyContext(ctx, testBlockHash)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, res)
+	assert.Empty(t, fs.ops)
+}
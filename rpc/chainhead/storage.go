@@ -0,0 +1,79 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Storage queries the storage of a pinned block, returning all the items reported by the node.
+//
+// The childTrie is optional, if provided, the items are queried from the child trie instead of the main trie.
+// The operation is continued automatically when the node waits for it.
+func (s *FollowSubscription) Storage(
+	blockHash types.Hash,
+	items []types.ChainHeadStorageQueryItem,
+	childTrie *types.StorageKey,
+) ([]types.ChainHeadStorageResultItem, error) {
+	return s.StorageContext(context.Background(), blockHash, items, childTrie)
+}
+
+// StorageContext is like Storage but uses the provided context for the operation, which is stopped once ctx is done.
+func (s *FollowSubscription) StorageContext(
+	ctx context.Context,
+	blockHash types.Hash,
+	items []types.ChainHeadStorageQueryItem,
+	childTrie *types.StorageKey,
+) ([]types.ChainHeadStorageResultItem, error) {
+	var childTrieHex *string
+
+	if childTrie != nil {
+		h := childTrie.Hex()
+		childTrieHex = &h
+	}
+
+	operationID, ch, err := s.startOperation(ctx, "chainHead_v1_storage", blockHash.Hex(), items, childTrieHex)
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.finishOperation(operationID)
+
+	var res []types.ChainHeadStorageResultItem
+
+	for {
+		event, err := s.waitOperationEvent(ctx, operationID, ch)
+		if err != nil {
+			return nil, err
+		}
+
+		switch event.Event {
+		case types.ChainHeadOperationStorageItems:
+			res = append(res, event.Items...)
+		case types.ChainHeadOperationWaitingForContinue:
+			if err := s.client.CallContext(ctx, nil, "chainHead_v1_continue", s.sub.ID(), operationID); err != nil {
+				return nil, ErrStorageOperationContinue.Wrap(err)
+			}
+		case types.ChainHeadOperationStorageDone:
+			return res, nil
+		default:
+			return nil, operationEventError(event)
+		}
+	}
+}
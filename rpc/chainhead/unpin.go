@@ -0,0 +1,40 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Unpin releases the provided blocks, after which they can't be used with this subscription anymore.
+//
+// Blocks that are pruned as part of a finalized event are unpinned automatically.
+func (s *FollowSubscription) Unpin(blockHashes ...types.Hash) error {
+	return s.UnpinContext(context.Background(), blockHashes...)
+}
+
+// UnpinContext is like Unpin but uses the provided context for the RPC call.
+func (s *FollowSubscription) UnpinContext(ctx context.Context, blockHashes ...types.Hash) error {
+	hexHashes := make([]string, len(blockHashes))
+	for i, blockHash := range blockHashes {
+		hexHashes[i] = blockHash.Hex()
+	}
+
+	return s.client.CallContext(ctx, nil, "chainHead_v1_unpin", s.sub.ID(), hexHashes)
+}
@@ -0,0 +1,144 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// CallRequest holds the args of a contract call
+type CallRequest struct {
+	// Origin is the account that calls the contract
+	Origin types.AccountID
+	// Dest is the address of the contract
+	Dest types.AccountID
+	// Value is the balance that is transferred to the contract
+	Value types.U128
+	// GasLimit is the maximum weight the call may consume, nil uses the maximum weight of a block
+	GasLimit *types.Weight
+	// StorageDepositLimit is the maximum storage deposit that may be charged, nil means no limit
+	StorageDepositLimit *types.U128
+	// InputData is the encoded contract message, i.e. its selector followed by its SCALE encoded args
+	InputData []byte
+}
+
+// CallResult is the result of dry-running a contract call
+type CallResult struct {
+	Result types.ContractExecResult
+	// ModuleErrorName is the name of the pallet error, e.g. "Contracts.ContractTrapped", if the execution failed with
+	// a module error that is known to the metadata.
+	ModuleErrorName string
+}
+
+// Err returns ErrContractExecution if the execution failed, ErrContractReverted if the contract reverted, and nil
+// otherwise.
+func (r *CallResult) Err() error {
+	return executionErr(r.Result.IsError, r.Result.Error, r.ModuleErrorName, r.Result.Ok.Flags)
+}
+
+// Call dry-runs the contract call at the given block and returns the consumed and required gas, the storage deposit
+// and the output of the contract. The GasRequired and a charged StorageDeposit of the result can be used as limits of
+// the Contracts.call extrinsic, see NewCallFromDryRun.
+func (c *contracts) Call(req CallRequest, blockHash types.Hash) (*CallResult, error) {
+	return c.CallContext(context.Background(), req, blockHash)
+}
+
+// CallContext is like Call but uses the provided context for the RPC calls.
+func (c *contracts) CallContext(ctx context.Context, req CallRequest, blockHash types.Hash) (*CallResult, error) {
+	return c.call(ctx, req, &blockHash)
+}
+
+// CallLatest dry-runs the contract call at the latest block
+func (c *contracts) CallLatest(req CallRequest) (*CallResult, error) {
+	return c.CallLatestContext(context.Background(), req)
+}
+
+// CallLatestContext is like CallLatest but uses the provided context for the RPC calls.
+func (c *contracts) CallLatestContext(ctx context.Context, req CallRequest) (*CallResult, error) {
+	return c.call(ctx, req, nil)
+}
+
+func (c *contracts) call(ctx context.Context, req CallRequest, blockHash *types.Hash) (*CallResult, error) {
+	var res CallResult
+
+	runtimeVersion, err := c.runtimeCall(
+		ctx,
+		"call",
+		blockHash,
+		&res.Result,
+		req.Origin,
+		req.Dest,
+		req.Value,
+		optionalWeight(req.GasLimit),
+		optionalBalance(req.StorageDepositLimit),
+		types.Bytes(req.InputData),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Result.IsError {
+		res.ModuleErrorName, err = c.moduleErrorName(ctx, runtimeVersion, blockHash, res.Result.Error)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &res, nil
+}
+
+func optionalWeight(w *types.Weight) types.Option[types.Weight] {
+	if w == nil {
+		return types.NewEmptyOption[types.Weight]()
+	}
+
+	return types.NewOption(*w)
+}
+
+func optionalBalance(b *types.U128) types.Option[types.U128] {
+	if b == nil {
+		return types.NewEmptyOption[types.U128]()
+	}
+
+	return types.NewOption(*b)
+}
+
+func executionErr(
+	isError bool,
+	dispatchErr types.DispatchError,
+	moduleErrorName string,
+	flags types.ContractReturnFlags,
+) error {
+	switch {
+	case isError && moduleErrorName != "":
+		return ErrContractExecution.WithMsg("%s", moduleErrorName)
+	case isError && dispatchErr.IsModule:
+		return ErrContractExecution.WithMsg(
+			"module error %d in pallet %d",
+			dispatchErr.ModuleError.Error[0],
+			dispatchErr.ModuleError.Index,
+		)
+	case isError:
+		return ErrContractExecution
+	case flags.IsRevert():
+		return ErrContractReverted
+	}
+
+	return nil
+}
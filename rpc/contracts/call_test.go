@@ -0,0 +1,161 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testCallRequest = CallRequest{
+		Origin:    testOrigin,
+		Dest:      testContract,
+		Value:     types.NewU128(*big.NewInt(10)),
+		InputData: []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+
+	testCallArgs = []interface{}{
+		testOrigin,
+		testContract,
+		types.NewU128(*big.NewInt(10)),
+		types.NewEmptyOption[types.Weight](),
+		types.NewEmptyOption[types.U128](),
+		types.Bytes{0xde, 0xad, 0xbe, 0xef},
+	}
+
+	testContractExecResult = types.ContractExecResult{
+		GasConsumed:    types.NewWeight(types.NewUCompactFromUInt(1_000_000), types.NewUCompactFromUInt(1_000)),
+		GasRequired:    types.NewWeight(types.NewUCompactFromUInt(2_000_000), types.NewUCompactFromUInt(2_000)),
+		StorageDeposit: types.StorageDeposit{IsCharge: true, AsCharge: types.NewU128(*big.NewInt(500))},
+		IsOk:           true,
+		Ok:             types.ContractExecReturnValue{Data: types.Bytes{0, 1}},
+	}
+)
+
+func TestContracts_Call(t *testing.T) {
+	c := newTestContracts(
+		t,
+		runtimeVersionFixture(t, 2),
+		stateCallFixture(t, "call", testCallArgs, testContractExecResult),
+	)
+
+	res, err := c.Call(testCallRequest, testBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, testContractExecResult, res.Result)
+	assert.Empty(t, res.ModuleErrorName)
+	assert.NoError(t, res.Err())
+}
+
+func TestContracts_Call_Args(t *testing.T) {
+	gasLimit := types.NewWeight(types.NewUCompactFromUInt(1), types.NewUCompactFromUInt(2))
+	storageDepositLimit := types.NewU128(*big.NewInt(3))
+
+	req := testCallRequest
+	req.GasLimit = &gasLimit
+	req.StorageDepositLimit = &storageDepositLimit
+
+	// origin, dest, value, Some(gas limit), Some(storage deposit limit), input data
+	data := "0x" +
+		"0100000000000000000000000000000000000000000000000000000000000000" +
+		"0200000000000000000000000000000000000000000000000000000000000000" +
+		"0a000000000000000000000000000000" +
+		"010408" +
+		"0103000000000000000000000000000000" +
+		"10deadbeef"
+
+	res, err := codec.EncodeToHex(testContractExecResult)
+	require.NoError(t, err)
+
+	c := newTestContracts(
+		t,
+		runtimeVersionFixture(t, 2),
+		rpcmocksrv.Fixture{
+			Method: "state_call",
+			Params: mustMarshalJSON(t, []string{"ContractsApi_call", data, testBlockHash.Hex()}),
+			Result: mustMarshalJSON(t, res),
+		},
+	)
+
+	_, err = c.Call(req, testBlockHash)
+	assert.NoError(t, err)
+}
+
+func TestContracts_Call_ModuleError(t *testing.T) {
+	execResult := testContractExecResult
+	execResult.IsOk = false
+	execResult.Ok = types.ContractExecReturnValue{}
+	execResult.IsError = true
+	execResult.Error = testContractTrapped
+	execResult.Events = types.Bytes{0}
+
+	c := newTestContracts(
+		t,
+		runtimeVersionFixture(t, 2),
+		stateCallFixture(t, "call", testCallArgs, execResult),
+		metadataFixture(t),
+	)
+
+	res, err := c.Call(testCallRequest, testBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, execResult, res.Result)
+	assert.Equal(t, "Contracts.ContractTrapped", res.ModuleErrorName)
+	assert.EqualError(t, res.Err(), "contract execution failed: Contracts.ContractTrapped")
+	assert.True(t, errors.Is(res.Err(), ErrContractExecution))
+
+	// the metadata is cached for the spec version
+	res, err = c.Call(testCallRequest, testBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, "Contracts.ContractTrapped", res.ModuleErrorName)
+}
+
+func TestContracts_Call_Reverted(t *testing.T) {
+	execResult := testContractExecResult
+	execResult.Ok = types.ContractExecReturnValue{Flags: types.ContractReturnFlagRevert, Data: types.Bytes{1}}
+
+	c := newTestContracts(
+		t,
+		runtimeVersionFixture(t, 2),
+		stateCallFixture(t, "call", testCallArgs, execResult),
+	)
+
+	res, err := c.Call(testCallRequest, testBlockHash)
+	require.NoError(t, err)
+	assert.True(t, errors.Is(res.Err(), ErrContractReverted))
+}
+
+func TestContracts_Call_APINotSupported(t *testing.T) {
+	c := newTestContracts(t, runtimeVersionFixture(t, 1))
+
+	_, err := c.Call(testCallRequest, testBlockHash)
+	assert.True(t, errors.Is(err, ErrContractsAPINotSupported))
+}
+
+func TestCallResult_Err(t *testing.T) {
+	res := CallResult{Result: types.ContractExecResult{IsError: true, Error: testContractTrapped}}
+	assert.EqualError(t, res.Err(), "contract execution failed: module error 11 in pallet 19")
+
+	res = CallResult{Result: types.ContractExecResult{IsError: true, Error: types.DispatchError{IsBadOrigin: true}}}
+	assert.Equal(t, ErrContractExecution, res.Err())
+}
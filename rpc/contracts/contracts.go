@@ -0,0 +1,212 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name Contracts --filename contracts.go
+
+package contracts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	ErrContractsAPINotSupported = libErr.Error("ContractsApi v2 not provided by the runtime")
+	ErrContractAccess           = libErr.Error("contract storage access")
+	ErrContractExecution        = libErr.Error("contract execution failed")
+	ErrContractReverted         = libErr.Error("contract reverted")
+)
+
+const (
+	contractsAPI = "ContractsApi"
+
+	// contractsAPIVersion is the version of the ContractsApi that uses V2 weights as gas limit
+	contractsAPIVersion = 2
+)
+
+// Contracts exposes the ContractsApi runtime API of the contracts pallet, which dry-runs contract calls,
+// instantiations and code uploads and reads the storage of contracts. Selectors and ABI encoding of the input data
+// are left to the caller, e.g. to the metadata of the ink! contract.
+//
+// Module errors of failed executions are resolved to their name with the metadata of the runtime, which is only
+// retrieved if needed and cached per spec version.
+type Contracts interface {
+	Call(req CallRequest, blockHash types.Hash) (*CallResult, error)
+	CallContext(ctx context.Context, req CallRequest, blockHash types.Hash) (*CallResult, error)
+	CallLatest(req CallRequest) (*CallResult, error)
+	CallLatestContext(ctx context.Context, req CallRequest) (*CallResult, error)
+	Instantiate(req InstantiateRequest, blockHash types.Hash) (*InstantiateResult, error)
+	InstantiateContext(ctx context.Context, req InstantiateRequest, blockHash types.Hash) (*InstantiateResult, error)
+	InstantiateLatest(req InstantiateRequest) (*InstantiateResult, error)
+	InstantiateLatestContext(ctx context.Context, req InstantiateRequest) (*InstantiateResult, error)
+	UploadCode(req UploadCodeRequest, blockHash types.Hash) (*UploadCodeResult, error)
+	UploadCodeContext(ctx context.Context, req UploadCodeRequest, blockHash types.Hash) (*UploadCodeResult, error)
+	UploadCodeLatest(req UploadCodeRequest) (*UploadCodeResult, error)
+	UploadCodeLatestContext(ctx context.Context, req UploadCodeRequest) (*UploadCodeResult, error)
+	GetStorage(address types.AccountID, key []byte, blockHash types.Hash) (*types.StorageDataRaw, error)
+	GetStorageContext(
+		ctx context.Context,
+		address types.AccountID,
+		key []byte,
+		blockHash types.Hash,
+	) (*types.StorageDataRaw, error)
+	GetStorageLatest(address types.AccountID, key []byte) (*types.StorageDataRaw, error)
+	GetStorageLatestContext(ctx context.Context, address types.AccountID, key []byte) (*types.StorageDataRaw, error)
+}
+
+// contracts exposes methods for dry-running contracts
+type contracts struct {
+	state state.State
+
+	mu              sync.Mutex
+	meta            *types.Metadata
+	metaSpecVersion types.U32
+}
+
+// NewContracts creates a new contracts struct
+func NewContracts(cl client.Client) Contracts {
+	return &contracts{state: state.NewState(cl)}
+}
+
+// runtimeCall calls the method of the ContractsApi with the SCALE encoded args and decodes the result into target.
+// The runtime version at the block is returned, so that module errors can be resolved with the matching metadata.
+func (c *contracts) runtimeCall(
+	ctx context.Context,
+	method string,
+	blockHash *types.Hash,
+	target interface{},
+	args ...interface{},
+) (*types.RuntimeVersion, error) {
+	runtimeVersion, err := c.getRuntimeVersion(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiVersion, ok := runtimeVersion.APIVersion(contractsAPI); !ok || apiVersion < contractsAPIVersion {
+		return nil, ErrContractsAPINotSupported
+	}
+
+	var buf bytes.Buffer
+
+	encoder := scale.NewEncoder(&buf)
+
+	for _, arg := range args {
+		if err := encoder.Encode(arg); err != nil {
+			return nil, err
+		}
+	}
+
+	var res types.Bytes
+
+	if blockHash == nil {
+		res, err = c.state.CallLatestContext(ctx, contractsAPI+"_"+method, buf.Bytes())
+	} else {
+		res, err = c.state.CallContext(ctx, contractsAPI+"_"+method, buf.Bytes(), *blockHash)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := scale.NewDecoder(bytes.NewReader(res)).Decode(target); err != nil {
+		return nil, err
+	}
+
+	return runtimeVersion, nil
+}
+
+func (c *contracts) getRuntimeVersion(ctx context.Context, blockHash *types.Hash) (*types.RuntimeVersion, error) {
+	if blockHash == nil {
+		return c.state.GetRuntimeVersionLatestContext(ctx)
+	}
+
+	return c.state.GetRuntimeVersionContext(ctx, *blockHash)
+}
+
+// moduleErrorName returns the name of the pallet error, e.g. "Contracts.ContractTrapped", if dispatchErr is a module
+// error that is known to the metadata. The metadata is only retrieved again if the spec version changed.
+func (c *contracts) moduleErrorName(
+	ctx context.Context,
+	runtimeVersion *types.RuntimeVersion,
+	blockHash *types.Hash,
+	dispatchErr types.DispatchError,
+) (string, error) {
+	if !dispatchErr.IsModule {
+		return "", nil
+	}
+
+	meta, err := c.getMetadata(ctx, runtimeVersion, blockHash)
+	if err != nil {
+		return "", err
+	}
+
+	moduleErr := dispatchErr.ModuleError
+
+	// errors that are unknown to the metadata are reported without a name
+	metaErr, err := meta.FindError(moduleErr.Index, moduleErr.Error)
+	if err != nil {
+		return "", nil //nolint:nilerr
+	}
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.Index == moduleErr.Index {
+			return fmt.Sprintf("%s.%s", pallet.Name, metaErr.Name), nil
+		}
+	}
+
+	return metaErr.Name, nil
+}
+
+func (c *contracts) getMetadata(
+	ctx context.Context,
+	runtimeVersion *types.RuntimeVersion,
+	blockHash *types.Hash,
+) (*types.Metadata, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.meta != nil && c.metaSpecVersion == runtimeVersion.SpecVersion {
+		return c.meta, nil
+	}
+
+	var (
+		meta *types.Metadata
+		err  error
+	)
+
+	if blockHash == nil {
+		meta, err = c.state.GetMetadataLatestContext(ctx)
+	} else {
+		meta, err = c.state.GetMetadataContext(ctx, *blockHash)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.meta = meta
+	c.metaSpecVersion = runtimeVersion.SpecVersion
+
+	return meta, nil
+}
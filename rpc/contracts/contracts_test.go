@@ -0,0 +1,102 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testBlockHash = types.Hash{1, 2, 3}
+
+	testOrigin   = types.AccountID{1}
+	testContract = types.AccountID{2}
+
+	// testContractTrapped is the ContractTrapped error of the contracts pallet in types.MetadataV14Data
+	testContractTrapped = types.DispatchError{
+		IsModule:    true,
+		ModuleError: types.ModuleError{Index: 19, Error: [4]types.U8{11}},
+	}
+)
+
+func newTestContracts(t *testing.T, fixtures ...rpcmocksrv.Fixture) Contracts {
+	s := rpcmocksrv.NewReplayServer(fixtures...)
+	t.Cleanup(s.Close)
+
+	cl, err := client.Connect(s.URL)
+	require.NoError(t, err)
+	t.Cleanup(cl.Close)
+
+	return NewContracts(cl)
+}
+
+// runtimeVersionFixture returns a fixture for a runtime that provides the ContractsApi in the given version.
+func runtimeVersionFixture(t *testing.T, contractsAPIVersion types.U32) rpcmocksrv.Fixture {
+	id, err := types.NewRuntimeAPIID(contractsAPI)
+	require.NoError(t, err)
+
+	runtimeVersion := types.NewRuntimeVersion()
+	runtimeVersion.SpecVersion = 100
+	runtimeVersion.APIs = append(runtimeVersion.APIs, types.RuntimeVersionAPI{APIID: id, Version: contractsAPIVersion})
+
+	return rpcmocksrv.Fixture{Method: "state_getRuntimeVersion", Result: mustMarshalJSON(t, runtimeVersion)}
+}
+
+// stateCallFixture returns a fixture for a state_call of the ContractsApi method with the encoded args at the test
+// block, that returns the encoded result.
+func stateCallFixture(t *testing.T, method string, args []interface{}, result interface{}) rpcmocksrv.Fixture {
+	res, err := codec.Encode(result)
+	require.NoError(t, err)
+
+	return rawStateCallFixture(t, method, args, res)
+}
+
+// rawStateCallFixture is like stateCallFixture but returns the result as it is.
+func rawStateCallFixture(t *testing.T, method string, args []interface{}, result []byte) rpcmocksrv.Fixture {
+	var data []byte
+
+	for _, arg := range args {
+		enc, err := codec.Encode(arg)
+		require.NoError(t, err)
+
+		data = append(data, enc...)
+	}
+
+	return rpcmocksrv.Fixture{
+		Method: "state_call",
+		Params: mustMarshalJSON(t, []string{contractsAPI + "_" + method, codec.HexEncodeToString(data), testBlockHash.Hex()}),
+		Result: mustMarshalJSON(t, codec.HexEncodeToString(result)),
+	}
+}
+
+func metadataFixture(t *testing.T) rpcmocksrv.Fixture {
+	return rpcmocksrv.Fixture{Method: "state_getMetadata", Result: mustMarshalJSON(t, types.MetadataV14Data)}
+}
+
+func mustMarshalJSON(t *testing.T, v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return b
+}
@@ -0,0 +1,79 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const contractsCall = "Contracts.call"
+
+// NewCall creates the Contracts.call call that executes the contract message with the given input data. The gas
+// limit and the storage deposit limit are usually taken from a dry run, see NewCallFromDryRun. A nil storage deposit
+// limit means no limit.
+func NewCall(
+	meta *types.Metadata,
+	dest types.MultiAddress,
+	value types.UCompact,
+	gasLimit types.Weight,
+	storageDepositLimit *types.UCompact,
+	data []byte,
+) (types.Call, error) {
+	limit := types.NewEmptyOption[types.UCompact]()
+	if storageDepositLimit != nil {
+		limit = types.NewOption(*storageDepositLimit)
+	}
+
+	return types.NewCall(meta, contractsCall, dest, value, gasLimit, limit, types.Bytes(data))
+}
+
+// NewCallFromDryRun creates the Contracts.call call for the request, using the required gas and the charged storage
+// deposit of the dry run as limits. The error of the dry run is returned if the execution failed or reverted.
+func NewCallFromDryRun(meta *types.Metadata, req CallRequest, res *CallResult) (types.Call, error) {
+	if err := res.Err(); err != nil {
+		return types.Call{}, err
+	}
+
+	dest, err := types.NewMultiAddressFromAccountID(req.Dest[:])
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	storageDepositLimit := types.NewUCompactFromUInt(0)
+	if res.Result.StorageDeposit.IsCharge {
+		storageDepositLimit = uCompactFromU128(res.Result.StorageDeposit.AsCharge)
+	}
+
+	return NewCall(
+		meta,
+		dest,
+		uCompactFromU128(req.Value),
+		res.Result.GasRequired,
+		&storageDepositLimit,
+		req.InputData,
+	)
+}
+
+func uCompactFromU128(v types.U128) types.UCompact {
+	if v.Int == nil {
+		return types.NewUCompact(big.NewInt(0))
+	}
+
+	return types.NewUCompact(v.Int)
+}
@@ -0,0 +1,111 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	require.NoError(t, codec.DecodeFromHex(types.MetadataV14Data, &meta))
+
+	return &meta
+}
+
+func TestNewCall(t *testing.T) {
+	meta := newTestMetadata(t)
+
+	dest, err := types.NewMultiAddressFromAccountID(testContract[:])
+	require.NoError(t, err)
+
+	gasLimit := types.NewWeight(types.NewUCompactFromUInt(1), types.NewUCompactFromUInt(2))
+
+	call, err := NewCall(meta, dest, types.NewUCompactFromUInt(3), gasLimit, nil, []byte{0xaa})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.CallIndex{SectionIndex: 19, MethodIndex: 0}, call.CallIndex)
+	assert.Equal(
+		t,
+		// dest, value, gas limit, no storage deposit limit, data
+		"0x000200000000000000000000000000000000000000000000000000000000000000"+"0c"+"0408"+"00"+"04aa",
+		codec.HexEncodeToString(call.Args),
+	)
+}
+
+func TestNewCallFromDryRun(t *testing.T) {
+	meta := newTestMetadata(t)
+
+	call, err := NewCallFromDryRun(meta, testCallRequest, &CallResult{Result: testContractExecResult})
+	require.NoError(t, err)
+
+	dest, err := types.NewMultiAddressFromAccountID(testContract[:])
+	require.NoError(t, err)
+
+	storageDepositLimit := types.NewUCompactFromUInt(500)
+
+	expected, err := NewCall(
+		meta,
+		dest,
+		types.NewUCompactFromUInt(10),
+		testContractExecResult.GasRequired,
+		&storageDepositLimit,
+		testCallRequest.InputData,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, expected, call)
+}
+
+func TestNewCallFromDryRun_Refund(t *testing.T) {
+	meta := newTestMetadata(t)
+
+	res := testContractExecResult
+	res.StorageDeposit = types.StorageDeposit{IsRefund: true, AsRefund: types.U128{}}
+
+	req := testCallRequest
+	req.Value = types.U128{}
+
+	call, err := NewCallFromDryRun(meta, req, &CallResult{Result: res})
+	require.NoError(t, err)
+
+	dest, err := types.NewMultiAddressFromAccountID(testContract[:])
+	require.NoError(t, err)
+
+	// the storage deposit limit is Some(0) if the call refunds the deposit
+	zero := types.NewUCompactFromUInt(0)
+
+	expected, err := NewCall(meta, dest, zero, res.GasRequired, &zero, req.InputData)
+	require.NoError(t, err)
+	assert.Equal(t, expected, call)
+}
+
+func TestNewCallFromDryRun_Failed(t *testing.T) {
+	meta := newTestMetadata(t)
+
+	res := testContractExecResult
+	res.Ok.Flags = types.ContractReturnFlagRevert
+
+	_, err := NewCallFromDryRun(meta, testCallRequest, &CallResult{Result: res})
+	assert.True(t, errors.Is(err, ErrContractReverted))
+}
@@ -0,0 +1,110 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// getStorageResult is the Result<Option<Vec<u8>>, ContractAccessError> returned by ContractsApi_get_storage
+type getStorageResult struct {
+	Value *types.StorageDataRaw
+	Err   *types.ContractAccessError
+}
+
+func (r *getStorageResult) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	if b != 0 {
+		r.Err = &types.ContractAccessError{}
+
+		return decoder.Decode(r.Err)
+	}
+
+	var value types.Option[types.Bytes]
+
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	if ok, data := value.Unwrap(); ok {
+		raw := types.NewStorageDataRaw(data)
+		r.Value = &raw
+	}
+
+	return nil
+}
+
+// GetStorage returns the value stored under the key in the storage of the contract at the given block, or nil if the
+// key is not set. For ink! contracts, the key is the storage key of the lazy field or mapping entry. If the contract
+// does not exist, ErrContractAccess is returned.
+func (c *contracts) GetStorage(
+	address types.AccountID,
+	key []byte,
+	blockHash types.Hash,
+) (*types.StorageDataRaw, error) {
+	return c.GetStorageContext(context.Background(), address, key, blockHash)
+}
+
+// GetStorageContext is like GetStorage but uses the provided context for the RPC calls.
+func (c *contracts) GetStorageContext(
+	ctx context.Context,
+	address types.AccountID,
+	key []byte,
+	blockHash types.Hash,
+) (*types.StorageDataRaw, error) {
+	return c.getStorage(ctx, address, key, &blockHash)
+}
+
+// GetStorageLatest returns the value stored under the key in the storage of the contract at the latest block
+func (c *contracts) GetStorageLatest(address types.AccountID, key []byte) (*types.StorageDataRaw, error) {
+	return c.GetStorageLatestContext(context.Background(), address, key)
+}
+
+// GetStorageLatestContext is like GetStorageLatest but uses the provided context for the RPC calls.
+func (c *contracts) GetStorageLatestContext(
+	ctx context.Context,
+	address types.AccountID,
+	key []byte,
+) (*types.StorageDataRaw, error) {
+	return c.getStorage(ctx, address, key, nil)
+}
+
+func (c *contracts) getStorage(
+	ctx context.Context,
+	address types.AccountID,
+	key []byte,
+	blockHash *types.Hash,
+) (*types.StorageDataRaw, error) {
+	var res getStorageResult
+
+	if _, err := c.runtimeCall(ctx, "get_storage", blockHash, &res, address, types.Bytes(key)); err != nil {
+		return nil, err
+	}
+
+	if res.Err != nil {
+		return nil, ErrContractAccess.WithMsg("%s", res.Err)
+	}
+
+	return res.Value, nil
+}
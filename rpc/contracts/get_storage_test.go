@@ -0,0 +1,56 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getStorageFixture(t *testing.T, key []byte, result []byte) rpcmocksrv.Fixture {
+	return rawStateCallFixture(t, "get_storage", []interface{}{testContract, types.Bytes(key)}, result)
+}
+
+func TestContracts_GetStorage(t *testing.T) {
+	c := newTestContracts(
+		t,
+		runtimeVersionFixture(t, 2),
+		// Ok(Some(0x2a000000))
+		getStorageFixture(t, []byte{1}, []byte{0, 1, 0x10, 0x2a, 0, 0, 0}),
+		// Ok(None)
+		getStorageFixture(t, []byte{2}, []byte{0, 0}),
+		// Err(DoesntExist)
+		getStorageFixture(t, []byte{3}, []byte{1, 0}),
+	)
+
+	res, err := c.GetStorage(testContract, []byte{1}, testBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, types.NewStorageDataRaw([]byte{0x2a, 0, 0, 0}), *res)
+
+	res, err = c.GetStorage(testContract, []byte{2}, testBlockHash)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	_, err = c.GetStorage(testContract, []byte{3}, testBlockHash)
+	assert.True(t, errors.Is(err, ErrContractAccess))
+	assert.EqualError(t, err, "contract storage access: DoesntExist")
+}
@@ -0,0 +1,114 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// InstantiateRequest holds the args of a contract instantiation
+type InstantiateRequest struct {
+	// Origin is the account that instantiates the contract
+	Origin types.AccountID
+	// Value is the balance that is transferred to the new contract
+	Value types.U128
+	// GasLimit is the maximum weight the instantiation may consume, nil uses the maximum weight of a block
+	GasLimit *types.Weight
+	// StorageDepositLimit is the maximum storage deposit that may be charged, nil means no limit
+	StorageDepositLimit *types.U128
+	// Code is either the Wasm code, which is uploaded with the instantiation, or the hash of existing code
+	Code types.ContractCode
+	// Data is the encoded constructor, i.e. its selector followed by its SCALE encoded args
+	Data []byte
+	// Salt is used to derive the address of the contract
+	Salt []byte
+}
+
+// InstantiateResult is the result of dry-running a contract instantiation
+type InstantiateResult struct {
+	Result types.ContractInstantiateResult
+	// ModuleErrorName is the name of the pallet error, e.g. "Contracts.DuplicateContract", if the execution failed
+	// with a module error that is known to the metadata.
+	ModuleErrorName string
+}
+
+// Err returns ErrContractExecution if the instantiation failed, ErrContractReverted if the constructor reverted, and
+// nil otherwise.
+func (r *InstantiateResult) Err() error {
+	return executionErr(r.Result.IsError, r.Result.Error, r.ModuleErrorName, r.Result.Ok.Result.Flags)
+}
+
+// Instantiate dry-runs the contract instantiation at the given block and returns the consumed and required gas, the
+// storage deposit and the address of the new contract.
+func (c *contracts) Instantiate(req InstantiateRequest, blockHash types.Hash) (*InstantiateResult, error) {
+	return c.InstantiateContext(context.Background(), req, blockHash)
+}
+
+// InstantiateContext is like Instantiate but uses the provided context for the RPC calls.
+func (c *contracts) InstantiateContext(
+	ctx context.Context,
+	req InstantiateRequest,
+	blockHash types.Hash,
+) (*InstantiateResult, error) {
+	return c.instantiate(ctx, req, &blockHash)
+}
+
+// InstantiateLatest dry-runs the contract instantiation at the latest block
+func (c *contracts) InstantiateLatest(req InstantiateRequest) (*InstantiateResult, error) {
+	return c.InstantiateLatestContext(context.Background(), req)
+}
+
+// InstantiateLatestContext is like InstantiateLatest but uses the provided context for the RPC calls.
+func (c *contracts) InstantiateLatestContext(ctx context.Context, req InstantiateRequest) (*InstantiateResult, error) {
+	return c.instantiate(ctx, req, nil)
+}
+
+func (c *contracts) instantiate(
+	ctx context.Context,
+	req InstantiateRequest,
+	blockHash *types.Hash,
+) (*InstantiateResult, error) {
+	var res InstantiateResult
+
+	runtimeVersion, err := c.runtimeCall(
+		ctx,
+		"instantiate",
+		blockHash,
+		&res.Result,
+		req.Origin,
+		req.Value,
+		optionalWeight(req.GasLimit),
+		optionalBalance(req.StorageDepositLimit),
+		req.Code,
+		types.Bytes(req.Data),
+		types.Bytes(req.Salt),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Result.IsError {
+		res.ModuleErrorName, err = c.moduleErrorName(ctx, runtimeVersion, blockHash, res.Result.Error)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &res, nil
+}
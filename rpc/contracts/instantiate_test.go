@@ -0,0 +1,97 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContracts_Instantiate(t *testing.T) {
+	req := InstantiateRequest{
+		Origin: testOrigin,
+		Code:   types.ContractCode{IsExisting: true, AsExisting: types.Hash{9}},
+		Data:   []byte{0x9b, 0xae, 0x9d, 0x5e},
+		Salt:   []byte{1},
+	}
+
+	instantiateResult := types.ContractInstantiateResult{
+		GasConsumed:    types.NewWeight(types.NewUCompactFromUInt(5), types.NewUCompactFromUInt(6)),
+		GasRequired:    types.NewWeight(types.NewUCompactFromUInt(7), types.NewUCompactFromUInt(8)),
+		StorageDeposit: types.StorageDeposit{IsCharge: true, AsCharge: types.NewU128(*big.NewInt(9))},
+		IsOk:           true,
+		Ok: types.ContractInstantiateReturnValue{
+			Result:    types.ContractExecReturnValue{Data: types.Bytes{0}},
+			AccountID: testContract,
+		},
+	}
+
+	c := newTestContracts(
+		t,
+		runtimeVersionFixture(t, 2),
+		stateCallFixture(t, "instantiate", []interface{}{
+			testOrigin,
+			types.U128{},
+			types.NewEmptyOption[types.Weight](),
+			types.NewEmptyOption[types.U128](),
+			req.Code,
+			types.Bytes(req.Data),
+			types.Bytes(req.Salt),
+		}, instantiateResult),
+	)
+
+	res, err := c.Instantiate(req, testBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, instantiateResult, res.Result)
+	assert.NoError(t, res.Err())
+}
+
+func TestContracts_InstantiateLatest_ModuleError(t *testing.T) {
+	// DuplicateContract of the contracts pallet in types.MetadataV14Data
+	instantiateResult := types.ContractInstantiateResult{
+		StorageDeposit: types.StorageDeposit{IsRefund: true, AsRefund: types.NewU128(*big.NewInt(0))},
+		IsError:        true,
+		Error: types.DispatchError{
+			IsModule:    true,
+			ModuleError: types.ModuleError{Index: 19, Error: [4]types.U8{20}},
+		},
+	}
+
+	res, err := codec.EncodeToHex(instantiateResult)
+	require.NoError(t, err)
+
+	c := newTestContracts(
+		t,
+		runtimeVersionFixture(t, 3),
+		rpcmocksrv.Fixture{Method: "state_call", Result: mustMarshalJSON(t, res)},
+		metadataFixture(t),
+	)
+
+	instantiateRes, err := c.InstantiateLatest(InstantiateRequest{
+		Origin: testOrigin,
+		Code:   types.ContractCode{IsUpload: true, AsUpload: types.Bytes{0, 0x61, 0x73, 0x6d}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Contracts.DuplicateContract", instantiateRes.ModuleErrorName)
+	assert.EqualError(t, instantiateRes.Err(), "contract execution failed: Contracts.DuplicateContract")
+}
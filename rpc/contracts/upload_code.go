@@ -0,0 +1,104 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// UploadCodeRequest holds the args of a code upload
+type UploadCodeRequest struct {
+	// Origin is the account that uploads the code
+	Origin types.AccountID
+	// Code is the Wasm code of the contract
+	Code []byte
+	// StorageDepositLimit is the maximum storage deposit that may be charged, nil means no limit
+	StorageDepositLimit *types.U128
+	// Determinism specifies whether the code may use non-deterministic instructions
+	Determinism types.ContractDeterminism
+}
+
+// UploadCodeResult is the result of dry-running a code upload
+type UploadCodeResult struct {
+	Result types.CodeUploadResult
+	// ModuleErrorName is the name of the pallet error, e.g. "Contracts.CodeRejected", if the upload failed with a
+	// module error that is known to the metadata.
+	ModuleErrorName string
+}
+
+// Err returns ErrContractExecution if the upload failed and nil otherwise.
+func (r *UploadCodeResult) Err() error {
+	return executionErr(r.Result.IsError, r.Result.Error, r.ModuleErrorName, 0)
+}
+
+// UploadCode dry-runs the upload of the contract code at the given block and returns the code hash and the storage
+// deposit.
+func (c *contracts) UploadCode(req UploadCodeRequest, blockHash types.Hash) (*UploadCodeResult, error) {
+	return c.UploadCodeContext(context.Background(), req, blockHash)
+}
+
+// UploadCodeContext is like UploadCode but uses the provided context for the RPC calls.
+func (c *contracts) UploadCodeContext(
+	ctx context.Context,
+	req UploadCodeRequest,
+	blockHash types.Hash,
+) (*UploadCodeResult, error) {
+	return c.uploadCode(ctx, req, &blockHash)
+}
+
+// UploadCodeLatest dry-runs the upload of the contract code at the latest block
+func (c *contracts) UploadCodeLatest(req UploadCodeRequest) (*UploadCodeResult, error) {
+	return c.UploadCodeLatestContext(context.Background(), req)
+}
+
+// UploadCodeLatestContext is like UploadCodeLatest but uses the provided context for the RPC calls.
+func (c *contracts) UploadCodeLatestContext(ctx context.Context, req UploadCodeRequest) (*UploadCodeResult, error) {
+	return c.uploadCode(ctx, req, nil)
+}
+
+func (c *contracts) uploadCode(
+	ctx context.Context,
+	req UploadCodeRequest,
+	blockHash *types.Hash,
+) (*UploadCodeResult, error) {
+	var res UploadCodeResult
+
+	runtimeVersion, err := c.runtimeCall(
+		ctx,
+		"upload_code",
+		blockHash,
+		&res.Result,
+		req.Origin,
+		types.Bytes(req.Code),
+		optionalBalance(req.StorageDepositLimit),
+		req.Determinism,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Result.IsError {
+		res.ModuleErrorName, err = c.moduleErrorName(ctx, runtimeVersion, blockHash, res.Result.Error)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &res, nil
+}
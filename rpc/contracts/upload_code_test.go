@@ -0,0 +1,78 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContracts_UploadCode(t *testing.T) {
+	storageDepositLimit := types.NewU128(*big.NewInt(1_000))
+
+	req := UploadCodeRequest{
+		Origin:              testOrigin,
+		Code:                []byte{0, 0x61, 0x73, 0x6d},
+		StorageDepositLimit: &storageDepositLimit,
+		Determinism:         types.ContractDeterminismRelaxed,
+	}
+
+	uploadResult := types.CodeUploadResult{
+		IsOk: true,
+		Ok:   types.CodeUploadReturnValue{CodeHash: types.Hash{4, 5}, Deposit: types.NewU128(*big.NewInt(800))},
+	}
+
+	c := newTestContracts(
+		t,
+		runtimeVersionFixture(t, 2),
+		stateCallFixture(t, "upload_code", []interface{}{
+			testOrigin,
+			types.Bytes(req.Code),
+			types.NewOption(storageDepositLimit),
+			types.U8(1),
+		}, uploadResult),
+	)
+
+	res, err := c.UploadCode(req, testBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, uploadResult, res.Result)
+	assert.NoError(t, res.Err())
+}
+
+func TestContracts_UploadCode_Error(t *testing.T) {
+	uploadResult := types.CodeUploadResult{IsError: true, Error: types.DispatchError{IsBadOrigin: true}}
+
+	c := newTestContracts(
+		t,
+		runtimeVersionFixture(t, 2),
+		stateCallFixture(t, "upload_code", []interface{}{
+			testOrigin,
+			types.Bytes{1},
+			types.NewEmptyOption[types.U128](),
+			types.U8(0),
+		}, uploadResult),
+	)
+
+	res, err := c.UploadCode(UploadCodeRequest{Origin: testOrigin, Code: []byte{1}}, testBlockHash)
+	require.NoError(t, err)
+	assert.Empty(t, res.ModuleErrorName)
+	assert.Equal(t, ErrContractExecution, res.Err())
+}
@@ -0,0 +1,41 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name Dev --filename dev.go
+
+package dev
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+type Dev interface {
+	GetBlockStats(blockHash types.Hash) (*types.BlockStats, error)
+	GetBlockStatsContext(ctx context.Context, blockHash types.Hash) (*types.BlockStats, error)
+}
+
+// dev exposes methods for development and monitoring of the node
+type dev struct {
+	client client.Client
+}
+
+// NewDev creates a new dev struct
+func NewDev(cl client.Client) Dev {
+	return &dev{cl}
+}
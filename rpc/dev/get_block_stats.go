@@ -0,0 +1,48 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dev
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// GetBlockStats re-executes the block with the given hash and returns its witness and length stats. Nil is returned
+// if the stats are not available, e.g. because the state of the parent block was already pruned. The method is
+// unsafe, so the node must be started with --rpc-methods=unsafe.
+func (d *dev) GetBlockStats(blockHash types.Hash) (*types.BlockStats, error) {
+	return d.GetBlockStatsContext(context.Background(), blockHash)
+}
+
+// GetBlockStatsContext is like GetBlockStats but uses the provided context for the RPC call.
+func (d *dev) GetBlockStatsContext(ctx context.Context, blockHash types.Hash) (*types.BlockStats, error) {
+	hexHash, err := codec.Hex(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *types.BlockStats
+
+	err = d.client.CallContext(ctx, &res, "dev_getBlockStats", hexHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
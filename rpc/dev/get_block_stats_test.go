@@ -0,0 +1,47 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dev
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDev_GetBlockStats(t *testing.T) {
+	blockHash := types.NewHash(codec.MustHexDecodeString("0x" + strings.Repeat("11", 32)))
+
+	res, err := testDev.GetBlockStats(blockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.BlockStats{
+		WitnessLen:        41023,
+		WitnessCompactLen: 39876,
+		BlockLen:          1249,
+		NumExtrinsics:     3,
+	}, res)
+}
+
+func TestDev_GetBlockStats_Unavailable(t *testing.T) {
+	blockHash := types.NewHash(codec.MustHexDecodeString("0x" + strings.Repeat("22", 32)))
+
+	res, err := testDev.GetBlockStats(blockHash)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
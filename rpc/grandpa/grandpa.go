@@ -0,0 +1,45 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name Grandpa --filename grandpa.go
+
+package grandpa
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+type Grandpa interface {
+	RoundState() (*types.GrandpaRoundStates, error)
+	RoundStateContext(ctx context.Context) (*types.GrandpaRoundStates, error)
+	ProveFinality(blockNumber uint64) (types.Bytes, error)
+	ProveFinalityContext(ctx context.Context, blockNumber uint64) (types.Bytes, error)
+	SubscribeJustifications() (*JustificationsSubscription, error)
+	SubscribeJustificationsContext(ctx context.Context) (*JustificationsSubscription, error)
+}
+
+// grandpa exposes methods for retrieval of GRANDPA finality data
+type grandpa struct {
+	client client.Client
+}
+
+// NewGrandpa creates a new grandpa struct
+func NewGrandpa(cl client.Client) Grandpa {
+	return &grandpa{cl}
+}
@@ -0,0 +1,47 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// ProveFinality returns the SCALE encoded proof that the block with the given number is finalized, which decodes
+// into a types.GrandpaFinalityProof. The proof is nil if the node cannot prove the finality of the block, e.g.
+// because the block is not finalized yet.
+func (g *grandpa) ProveFinality(blockNumber uint64) (types.Bytes, error) {
+	return g.ProveFinalityContext(context.Background(), blockNumber)
+}
+
+// ProveFinalityContext is like ProveFinality but uses the provided context for the RPC call.
+func (g *grandpa) ProveFinalityContext(ctx context.Context, blockNumber uint64) (types.Bytes, error) {
+	var res string
+
+	err := g.client.CallContext(ctx, &res, "grandpa_proveFinality", blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == "" {
+		return nil, nil
+	}
+
+	return codec.HexDecodeString(res)
+}
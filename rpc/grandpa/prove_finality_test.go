@@ -0,0 +1,48 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrandpa_ProveFinality(t *testing.T) {
+	res, err := testGrandpa.ProveFinality(42)
+	require.NoError(t, err)
+
+	var proof types.GrandpaFinalityProof
+	require.NoError(t, codec.Decode(res, &proof))
+	assert.Equal(t, types.NewHash(codec.MustHexDecodeString(
+		"0x0a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20212223242526272829")), proof.Block)
+
+	justification, err := types.GrandpaEncodedJustification(proof.Justification).Decode()
+	require.NoError(t, err)
+	assert.Equal(t, proof.Block, justification.Commit.TargetHash)
+	assert.Equal(t, types.U32(42), justification.Commit.TargetNumber)
+	assert.Len(t, justification.Commit.Precommits, 1)
+}
+
+func TestGrandpa_ProveFinality_NotFinalized(t *testing.T) {
+	res, err := testGrandpa.ProveFinality(1000)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
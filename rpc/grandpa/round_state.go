@@ -0,0 +1,40 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// RoundState returns the state of the current GRANDPA voting rounds
+func (g *grandpa) RoundState() (*types.GrandpaRoundStates, error) {
+	return g.RoundStateContext(context.Background())
+}
+
+// RoundStateContext is like RoundState but uses the provided context for the RPC call.
+func (g *grandpa) RoundStateContext(ctx context.Context) (*types.GrandpaRoundStates, error) {
+	var res types.GrandpaRoundStates
+
+	err := g.client.CallContext(ctx, &res, "grandpa_roundState")
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
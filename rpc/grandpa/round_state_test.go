@@ -0,0 +1,37 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrandpa_RoundState(t *testing.T) {
+	res, err := testGrandpa.RoundState()
+	assert.NoError(t, err)
+
+	assert.Equal(t, types.U64(3), res.SetID)
+	assert.Equal(t, types.U32(12), res.Best.Round)
+	assert.Equal(t, types.U32(3), res.Best.ThresholdWeight)
+	assert.Equal(t, types.U32(3), res.Best.Prevotes.CurrentWeight)
+	assert.Len(t, res.Best.Prevotes.Missing, 1)
+	assert.Equal(t, types.U32(0), res.Best.Precommits.CurrentWeight)
+	assert.Empty(t, res.Background)
+}
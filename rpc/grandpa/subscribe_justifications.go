@@ -0,0 +1,94 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// JustificationsSubscription is a subscription established through one of the Client's subscribe methods.
+type JustificationsSubscription struct {
+	sub      *gethrpc.ClientSubscription
+	channel  chan types.GrandpaEncodedJustification
+	quitOnce sync.Once // ensures quit is closed once
+}
+
+// Chan returns the subscription channel.
+//
+// The channel is closed when Unsubscribe is called on the subscription.
+func (s *JustificationsSubscription) Chan() <-chan types.GrandpaEncodedJustification {
+	return s.channel
+}
+
+// Err returns the subscription error channel. The intended use of Err is to schedule
+// resubscription when the client connection is closed unexpectedly.
+//
+// The error channel receives a value when the subscription has ended due
+// to an error. The received error is nil if Close has been called
+// on the underlying client and no other error has occurred.
+//
+// The error channel is closed when Unsubscribe is called on the subscription.
+func (s *JustificationsSubscription) Err() <-chan error {
+	return s.sub.Err()
+}
+
+// Gap returns a channel that receives a value when notifications might have been missed, which happens
+// when the subscription was re-established after a reconnect, see client.ConnectWithReconnect.
+func (s *JustificationsSubscription) Gap() <-chan struct{} {
+	return s.sub.Gap()
+}
+
+// Unsubscribe unsubscribes the notification and closes the error channel.
+// It can safely be called more than once.
+func (s *JustificationsSubscription) Unsubscribe() {
+	s.sub.Unsubscribe()
+	s.quitOnce.Do(func() {
+		close(s.channel)
+	})
+}
+
+// SubscribeJustifications subscribes GRANDPA justifications, returning a subscription that will receive the encoded
+// justification of every block finalized by GRANDPA, see types.GrandpaEncodedJustification.
+func (g *grandpa) SubscribeJustifications() (*JustificationsSubscription, error) {
+	return g.SubscribeJustificationsContext(context.Background())
+}
+
+// SubscribeJustificationsContext is like SubscribeJustifications but the subscription is ended once ctx is done.
+// The subscription request itself is bound by both ctx and the configured subscribe timeout.
+func (g *grandpa) SubscribeJustificationsContext(ctx context.Context) (*JustificationsSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
+	defer cancel()
+
+	ch := make(chan types.GrandpaEncodedJustification)
+
+	sub, err := g.client.Subscribe(subscribeCtx, "grandpa", "subscribeJustifications", "unsubscribeJustifications",
+		"justifications", ch)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &JustificationsSubscription{sub: sub, channel: ch}
+	client.UnsubscribeOnCancel(ctx, sub.Done(), subscription.Unsubscribe)
+
+	return subscription, nil
+}
@@ -0,0 +1,69 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrandpa_SubscribeJustifications(t *testing.T) {
+	sub, err := testGrandpa.SubscribeJustifications()
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	select {
+	case encoded := <-sub.Chan():
+		justification, err := encoded.Decode()
+		require.NoError(t, err)
+		assert.Equal(t, types.U64(7), justification.Round)
+		assert.Equal(t, types.U32(42), justification.Commit.TargetNumber)
+		assert.Len(t, justification.VotesAncestries, 1)
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no justification received")
+	}
+
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+
+	_, ok := <-sub.Chan()
+	assert.False(t, ok)
+}
+
+func TestGrandpa_SubscribeJustificationsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	sub, err := testGrandpa.SubscribeJustificationsContext(ctx)
+	require.NoError(t, err)
+
+	<-sub.Chan()
+	cancel()
+
+	select {
+	case _, ok := <-sub.Chan():
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not ended")
+	}
+}
@@ -17,29 +17,55 @@
 package rpc
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/archive"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/babe"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/beefy"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chainhead"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/contracts"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/dev"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/grandpa"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/mmr"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/offchain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/payment"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/syncstate"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/transaction"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 type RPC struct {
-	Author   author.Author
-	Beefy    beefy.Beefy
-	Chain    chain.Chain
-	MMR      mmr.MMR
-	Offchain offchain.Offchain
-	State    state.State
-	System   system.System
-	client   client.Client
+	Archive     archive.Archive
+	Author      author.Author
+	Babe        babe.Babe
+	Beefy       beefy.Beefy
+	Chain       chain.Chain
+	ChainHead   chainhead.ChainHead
+	Contracts   contracts.Contracts
+	Dev         dev.Dev
+	Grandpa     grandpa.Grandpa
+	MMR         mmr.MMR
+	Offchain    offchain.Offchain
+	Payment     payment.Payment
+	State       state.State
+	SyncState   syncstate.SyncState
+	System      system.System
+	Transaction transaction.Transaction
+	client      client.Client
 }
 
+// NewRPC creates the RPC modules. Calls of methods the node does not expose fail with client.ErrUnsupportedMethod,
+// see client.WithMethodDiscovery.
 func NewRPC(cl client.Client) (*RPC, error) {
+	if _, ok := cl.(client.MethodChecker); !ok {
+		cl = client.WithMethodDiscovery(cl)
+	}
+
 	st := state.NewState(cl)
 	meta, err := st.GetMetadataLatest()
 	if err != nil {
@@ -50,13 +76,32 @@ func NewRPC(cl client.Client) (*RPC, error) {
 	types.SetSerDeOptions(opts)
 
 	return &RPC{
-		Author:   author.NewAuthor(cl),
-		Beefy:    beefy.NewBeefy(cl),
-		Chain:    chain.NewChain(cl),
-		MMR:      mmr.NewMMR(cl),
-		Offchain: offchain.NewOffchain(cl),
-		State:    st,
-		System:   system.NewSystem(cl),
-		client:   cl,
+		Archive:     archive.NewArchive(cl),
+		Author:      author.NewAuthor(cl),
+		Babe:        babe.NewBabe(cl),
+		Beefy:       beefy.NewBeefy(cl),
+		Chain:       chain.NewChain(cl),
+		ChainHead:   chainhead.NewChainHead(cl),
+		Contracts:   contracts.NewContracts(cl),
+		Dev:         dev.NewDev(cl),
+		Grandpa:     grandpa.NewGrandpa(cl),
+		MMR:         mmr.NewMMR(cl),
+		Offchain:    offchain.NewOffchain(cl),
+		Payment:     payment.NewPayment(cl),
+		State:       st,
+		SyncState:   syncstate.NewSyncState(cl),
+		System:      system.NewSystem(cl),
+		Transaction: transaction.NewTransaction(cl),
+		client:      cl,
 	}, nil
 }
+
+// Methods returns the methods the node exposes, or nil if the node does not support rpc_methods
+func (r *RPC) Methods() (*types.RPCMethods, error) {
+	return r.MethodsContext(context.Background())
+}
+
+// MethodsContext is like Methods but uses the provided context
+func (r *RPC) MethodsContext(ctx context.Context) (*types.RPCMethods, error) {
+	return r.client.(client.MethodChecker).Methods(ctx)
+}
@@ -0,0 +1,74 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// GenerateBatchProof retrieves a MMR proof and the leaves for the blocks with the given numbers, against the MMR
+// at the block with bestKnownBlockNumber, e.g. the latest block finalized by BEEFY, whose MMR root is known to the
+// verifier.
+func (c *mmr) GenerateBatchProof(blockNumbers []uint32, bestKnownBlockNumber uint32) (types.MMRLeavesProof, error) {
+	return c.GenerateBatchProofContext(context.Background(), blockNumbers, bestKnownBlockNumber)
+}
+
+// GenerateBatchProofContext is like GenerateBatchProof but uses the provided context for the RPC call.
+func (c *mmr) GenerateBatchProofContext(
+	ctx context.Context,
+	blockNumbers []uint32,
+	bestKnownBlockNumber uint32,
+) (types.MMRLeavesProof, error) {
+	return c.generateBatchProof(ctx, blockNumbers, &bestKnownBlockNumber)
+}
+
+// GenerateBatchProofLatest retrieves a MMR proof and the leaves for the blocks with the given numbers, against the
+// MMR at the best block
+func (c *mmr) GenerateBatchProofLatest(blockNumbers []uint32) (types.MMRLeavesProof, error) {
+	return c.GenerateBatchProofLatestContext(context.Background(), blockNumbers)
+}
+
+// GenerateBatchProofLatestContext is like GenerateBatchProofLatest but uses the provided context for the RPC call.
+func (c *mmr) GenerateBatchProofLatestContext(
+	ctx context.Context,
+	blockNumbers []uint32,
+) (types.MMRLeavesProof, error) {
+	return c.generateBatchProof(ctx, blockNumbers, nil)
+}
+
+func (c *mmr) generateBatchProof(
+	ctx context.Context,
+	blockNumbers []uint32,
+	bestKnownBlockNumber *uint32,
+) (types.MMRLeavesProof, error) {
+	var res types.MMRLeavesProof
+	var err error
+
+	if bestKnownBlockNumber == nil {
+		err = c.client.CallContext(ctx, &res, "mmr_generateProof", blockNumbers)
+	} else {
+		err = c.client.CallContext(ctx, &res, "mmr_generateProof", blockNumbers, *bestKnownBlockNumber)
+	}
+
+	if err != nil {
+		return types.MMRLeavesProof{}, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,52 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"testing"
+
+	mmrproof "github.com/centrifuge/go-substrate-rpc-client/v4/mmr"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMMR_GenerateBatchProof(t *testing.T) {
+	proof, err := testMMR.GenerateBatchProof([]uint32{1, 3}, 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, types.H256(testBlockHash), proof.BlockHash)
+	assert.Equal(t, []types.U64{1, 3}, proof.Proof.LeafIndices)
+	assert.Equal(t, types.U64(5), proof.Proof.LeafCount)
+
+	leaves, err := proof.DecodeLeaves()
+	require.NoError(t, err)
+	assert.Len(t, leaves, 2)
+	assert.Equal(t, types.U32(3), leaves[1].ParentNumberAndHash.ParentNumber)
+	assert.Equal(t, types.U64(1), leaves[1].BeefyNextAuthoritySet.ID)
+
+	assert.NoError(t, mmrproof.VerifyProof(testRoot, proof))
+}
+
+func TestMMR_GenerateBatchProofLatest(t *testing.T) {
+	proof, err := testMMR.GenerateBatchProofLatest([]uint32{1, 3})
+	require.NoError(t, err)
+
+	root, err := testMMR.RootLatest()
+	require.NoError(t, err)
+	assert.NoError(t, mmrproof.VerifyProof(root, proof))
+}
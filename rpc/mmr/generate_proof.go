@@ -17,6 +17,8 @@
 package mmr
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
@@ -24,17 +26,38 @@ import (
 // GenerateProof retrieves a MMR proof and leaf for the specified leave index, at the given blockHash (useful to query a
 // proof at an earlier block, likely with antoher MMR root)
 func (c *mmr) GenerateProof(leafIndex uint64, blockHash types.Hash) (types.GenerateMMRProofResponse, error) {
-	return c.generateProof(leafIndex, &blockHash)
+	return c.GenerateProofContext(context.Background(), leafIndex, blockHash)
+}
+
+// GenerateProofContext is like GenerateProof but uses the provided context for the RPC call.
+func (c *mmr) GenerateProofContext(
+	ctx context.Context,
+	leafIndex uint64,
+	blockHash types.Hash,
+) (types.GenerateMMRProofResponse, error) {
+	return c.generateProof(ctx, leafIndex, &blockHash)
 }
 
 // GenerateProofLatest retrieves the latest MMR proof and leaf for the specified leave index
 func (c *mmr) GenerateProofLatest(leafIndex uint64) (types.GenerateMMRProofResponse, error) {
-	return c.generateProof(leafIndex, nil)
+	return c.GenerateProofLatestContext(context.Background(), leafIndex)
+}
+
+// GenerateProofLatestContext is like GenerateProofLatest but uses the provided context for the RPC call.
+func (c *mmr) GenerateProofLatestContext(
+	ctx context.Context,
+	leafIndex uint64,
+) (types.GenerateMMRProofResponse, error) {
+	return c.generateProof(ctx, leafIndex, nil)
 }
 
-func (c *mmr) generateProof(leafIndex uint64, blockHash *types.Hash) (types.GenerateMMRProofResponse, error) {
+func (c *mmr) generateProof(
+	ctx context.Context,
+	leafIndex uint64,
+	blockHash *types.Hash,
+) (types.GenerateMMRProofResponse, error) {
 	var res types.GenerateMMRProofResponse
-	err := client.CallWithBlockHash(c.client, &res, "mmr_generateProof", blockHash, leafIndex)
+	err := client.CallWithBlockHashContext(ctx, c.client, &res, "mmr_generateProof", blockHash, leafIndex)
 	if err != nil {
 		return types.GenerateMMRProofResponse{}, err
 	}
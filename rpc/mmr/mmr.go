@@ -19,6 +19,8 @@
 package mmr
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
@@ -26,7 +28,29 @@ import (
 // MMR exposes methods for retrieval of MMR data
 type MMR interface {
 	GenerateProof(leafIndex uint64, blockHash types.Hash) (types.GenerateMMRProofResponse, error)
+	GenerateProofContext(
+		ctx context.Context,
+		leafIndex uint64,
+		blockHash types.Hash,
+	) (types.GenerateMMRProofResponse, error)
 	GenerateProofLatest(leafIndex uint64) (types.GenerateMMRProofResponse, error)
+	GenerateProofLatestContext(ctx context.Context, leafIndex uint64) (types.GenerateMMRProofResponse, error)
+	GenerateBatchProof(blockNumbers []uint32, bestKnownBlockNumber uint32) (types.MMRLeavesProof, error)
+	GenerateBatchProofContext(
+		ctx context.Context,
+		blockNumbers []uint32,
+		bestKnownBlockNumber uint32,
+	) (types.MMRLeavesProof, error)
+	GenerateBatchProofLatest(blockNumbers []uint32) (types.MMRLeavesProof, error)
+	GenerateBatchProofLatestContext(ctx context.Context, blockNumbers []uint32) (types.MMRLeavesProof, error)
+	Root(blockHash types.Hash) (types.H256, error)
+	RootContext(ctx context.Context, blockHash types.Hash) (types.H256, error)
+	RootLatest() (types.H256, error)
+	RootLatestContext(ctx context.Context) (types.H256, error)
+	VerifyProof(proof types.MMRLeavesProof) (bool, error)
+	VerifyProofContext(ctx context.Context, proof types.MMRLeavesProof) (bool, error)
+	VerifyProofStateless(root types.H256, proof types.MMRLeavesProof) (bool, error)
+	VerifyProofStatelessContext(ctx context.Context, root types.H256, proof types.MMRLeavesProof) (bool, error)
 }
 
 type mmr struct {
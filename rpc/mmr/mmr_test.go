@@ -0,0 +1,50 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var testMMR MMR
+
+var (
+	testBlockHash = types.NewHash(codec.MustHexDecodeString(
+		"0x0a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20212223242526272829"))
+	testRoot = types.NewH256(codec.MustHexDecodeString(
+		"0x56b9c02337830adefc4d434eed253a12b41b4686120b6b063fba97d9bb4a29e0"))
+)
+
+func TestMain(m *testing.M) {
+	s, err := rpcmocksrv.NewReplayServerFromFile("testdata/fixtures.json")
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	testMMR = NewMMR(cl)
+	os.Exit(m.Run())
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,6 +14,90 @@ type MMR struct {
 	mock.Mock
 }
 
+// GenerateBatchProof provides a mock function with given fields: blockNumbers, bestKnownBlockNumber
+func (_m *MMR) GenerateBatchProof(blockNumbers []uint32, bestKnownBlockNumber uint32) (types.MMRLeavesProof, error) {
+	ret := _m.Called(blockNumbers, bestKnownBlockNumber)
+
+	var r0 types.MMRLeavesProof
+	if rf, ok := ret.Get(0).(func([]uint32, uint32) types.MMRLeavesProof); ok {
+		r0 = rf(blockNumbers, bestKnownBlockNumber)
+	} else {
+		r0 = ret.Get(0).(types.MMRLeavesProof)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]uint32, uint32) error); ok {
+		r1 = rf(blockNumbers, bestKnownBlockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateBatchProofContext provides a mock function with given fields: ctx, blockNumbers, bestKnownBlockNumber
+func (_m *MMR) GenerateBatchProofContext(ctx context.Context, blockNumbers []uint32, bestKnownBlockNumber uint32) (types.MMRLeavesProof, error) {
+	ret := _m.Called(ctx, blockNumbers, bestKnownBlockNumber)
+
+	var r0 types.MMRLeavesProof
+	if rf, ok := ret.Get(0).(func(context.Context, []uint32, uint32) types.MMRLeavesProof); ok {
+		r0 = rf(ctx, blockNumbers, bestKnownBlockNumber)
+	} else {
+		r0 = ret.Get(0).(types.MMRLeavesProof)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []uint32, uint32) error); ok {
+		r1 = rf(ctx, blockNumbers, bestKnownBlockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateBatchProofLatest provides a mock function with given fields: blockNumbers
+func (_m *MMR) GenerateBatchProofLatest(blockNumbers []uint32) (types.MMRLeavesProof, error) {
+	ret := _m.Called(blockNumbers)
+
+	var r0 types.MMRLeavesProof
+	if rf, ok := ret.Get(0).(func([]uint32) types.MMRLeavesProof); ok {
+		r0 = rf(blockNumbers)
+	} else {
+		r0 = ret.Get(0).(types.MMRLeavesProof)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]uint32) error); ok {
+		r1 = rf(blockNumbers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateBatchProofLatestContext provides a mock function with given fields: ctx, blockNumbers
+func (_m *MMR) GenerateBatchProofLatestContext(ctx context.Context, blockNumbers []uint32) (types.MMRLeavesProof, error) {
+	ret := _m.Called(ctx, blockNumbers)
+
+	var r0 types.MMRLeavesProof
+	if rf, ok := ret.Get(0).(func(context.Context, []uint32) types.MMRLeavesProof); ok {
+		r0 = rf(ctx, blockNumbers)
+	} else {
+		r0 = ret.Get(0).(types.MMRLeavesProof)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []uint32) error); ok {
+		r1 = rf(ctx, blockNumbers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GenerateProof provides a mock function with given fields: leafIndex, blockHash
 func (_m *MMR) GenerateProof(leafIndex uint64, blockHash types.Hash) (types.GenerateMMRProofResponse, error) {
 	ret := _m.Called(leafIndex, blockHash)
@@ -33,6 +119,27 @@ func (_m *MMR) GenerateProof(leafIndex uint64, blockHash types.Hash) (types.Gene
 	return r0, r1
 }
 
+// GenerateProofContext provides a mock function with given fields: ctx, leafIndex, blockHash
+func (_m *MMR) GenerateProofContext(ctx context.Context, leafIndex uint64, blockHash types.Hash) (types.GenerateMMRProofResponse, error) {
+	ret := _m.Called(ctx, leafIndex, blockHash)
+
+	var r0 types.GenerateMMRProofResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, types.Hash) types.GenerateMMRProofResponse); ok {
+		r0 = rf(ctx, leafIndex, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.GenerateMMRProofResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, types.Hash) error); ok {
+		r1 = rf(ctx, leafIndex, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GenerateProofLatest provides a mock function with given fields: leafIndex
 func (_m *MMR) GenerateProofLatest(leafIndex uint64) (types.GenerateMMRProofResponse, error) {
 	ret := _m.Called(leafIndex)
@@ -54,6 +161,195 @@ func (_m *MMR) GenerateProofLatest(leafIndex uint64) (types.GenerateMMRProofResp
 	return r0, r1
 }
 
+// GenerateProofLatestContext provides a mock function with given fields: ctx, leafIndex
+func (_m *MMR) GenerateProofLatestContext(ctx context.Context, leafIndex uint64) (types.GenerateMMRProofResponse, error) {
+	ret := _m.Called(ctx, leafIndex)
+
+	var r0 types.GenerateMMRProofResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) types.GenerateMMRProofResponse); ok {
+		r0 = rf(ctx, leafIndex)
+	} else {
+		r0 = ret.Get(0).(types.GenerateMMRProofResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, leafIndex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Root provides a mock function with given fields: blockHash
+func (_m *MMR) Root(blockHash types.Hash) (types.H256, error) {
+	ret := _m.Called(blockHash)
+
+	var r0 types.H256
+	if rf, ok := ret.Get(0).(func(types.Hash) types.H256); ok {
+		r0 = rf(blockHash)
+	} else {
+		r0 = ret.Get(0).(types.H256)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
+		r1 = rf(blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RootContext provides a mock function with given fields: ctx, blockHash
+func (_m *MMR) RootContext(ctx context.Context, blockHash types.Hash) (types.H256, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 types.H256
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) types.H256); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.H256)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RootLatest provides a mock function with given fields:
+func (_m *MMR) RootLatest() (types.H256, error) {
+	ret := _m.Called()
+
+	var r0 types.H256
+	if rf, ok := ret.Get(0).(func() types.H256); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(types.H256)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RootLatestContext provides a mock function with given fields: ctx
+func (_m *MMR) RootLatestContext(ctx context.Context) (types.H256, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.H256
+	if rf, ok := ret.Get(0).(func(context.Context) types.H256); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.H256)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyProof provides a mock function with given fields: proof
+func (_m *MMR) VerifyProof(proof types.MMRLeavesProof) (bool, error) {
+	ret := _m.Called(proof)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(types.MMRLeavesProof) bool); ok {
+		r0 = rf(proof)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.MMRLeavesProof) error); ok {
+		r1 = rf(proof)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyProofContext provides a mock function with given fields: ctx, proof
+func (_m *MMR) VerifyProofContext(ctx context.Context, proof types.MMRLeavesProof) (bool, error) {
+	ret := _m.Called(ctx, proof)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.MMRLeavesProof) bool); ok {
+		r0 = rf(ctx, proof)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.MMRLeavesProof) error); ok {
+		r1 = rf(ctx, proof)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyProofStateless provides a mock function with given fields: root, proof
+func (_m *MMR) VerifyProofStateless(root types.H256, proof types.MMRLeavesProof) (bool, error) {
+	ret := _m.Called(root, proof)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(types.H256, types.MMRLeavesProof) bool); ok {
+		r0 = rf(root, proof)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.H256, types.MMRLeavesProof) error); ok {
+		r1 = rf(root, proof)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyProofStatelessContext provides a mock function with given fields: ctx, root, proof
+func (_m *MMR) VerifyProofStatelessContext(ctx context.Context, root types.H256, proof types.MMRLeavesProof) (bool, error) {
+	ret := _m.Called(ctx, root, proof)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.H256, types.MMRLeavesProof) bool); ok {
+		r0 = rf(ctx, root, proof)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.H256, types.MMRLeavesProof) error); ok {
+		r1 = rf(ctx, root, proof)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewMMRT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,48 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMMR_VerifyProof(t *testing.T) {
+	proof, err := testMMR.GenerateBatchProofLatest([]uint32{1, 3})
+	require.NoError(t, err)
+
+	ok, err := testMMR.VerifyProof(proof)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestMMR_VerifyProofStateless(t *testing.T) {
+	proof, err := testMMR.GenerateBatchProofLatest([]uint32{1, 3})
+	require.NoError(t, err)
+
+	ok, err := testMMR.VerifyProofStateless(testRoot, proof)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = testMMR.VerifyProofStateless(types.H256{}, proof)
+	rpcErr, isRPCErr := types.AsRPCError(err)
+	require.True(t, isRPCErr)
+	assert.Equal(t, 8004, rpcErr.Code)
+}
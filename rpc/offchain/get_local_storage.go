@@ -17,27 +17,39 @@
 package offchain
 
 import (
+	"context"
+
 	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
 
-// StorageKind ...
+// StorageKind is the kind of the offchain storage
 type StorageKind string
 
 const (
-	// Persistent storage
+	// Persistent storage is shared by all offchain workers and not reverted on forks, offchain indexing writes to it.
 	Persistent StorageKind = "PERSISTENT"
-	// Local storage
+	// Local storage is specific to the offchain worker of a block and reverted on forks.
 	Local StorageKind = "LOCAL"
 )
 
-// LocalStorageGet retrieves the stored data
+// LocalStorageGet retrieves the stored data. It returns nil if no data is stored under the key, which is different
+// from data that is stored but empty.
 func (c *offchain) LocalStorageGet(kind StorageKind, key []byte) (*types.StorageDataRaw, error) {
+	return c.LocalStorageGetContext(context.Background(), kind, key)
+}
+
+// LocalStorageGetContext is like LocalStorageGet but uses the provided context for the RPC call.
+func (c *offchain) LocalStorageGetContext(
+	ctx context.Context,
+	kind StorageKind,
+	key []byte,
+) (*types.StorageDataRaw, error) {
 	var res string
 
-	err := c.client.Call(&res, "offchain_localStorageGet", kind, fmt.Sprintf("%#x", key))
+	err := c.client.CallContext(ctx, &res, "offchain_localStorageGet", kind, fmt.Sprintf("%#x", key))
 	if err != nil {
 		return nil, err
 	}
@@ -57,12 +69,50 @@ func (c *offchain) LocalStorageGet(kind StorageKind, key []byte) (*types.Storage
 
 // LocalStorageSet saves the data
 func (c *offchain) LocalStorageSet(kind StorageKind, key []byte, value []byte) error {
+	return c.LocalStorageSetContext(context.Background(), kind, key, value)
+}
+
+// LocalStorageSetContext is like LocalStorageSet but uses the provided context for the RPC call.
+func (c *offchain) LocalStorageSetContext(ctx context.Context, kind StorageKind, key []byte, value []byte) error {
 	var res string
 
-	err := c.client.Call(&res, "offchain_localStorageSet", kind, fmt.Sprintf("%#x", key), fmt.Sprintf("%#x", value))
+	err := c.client.CallContext(ctx, &res, "offchain_localStorageSet", kind, fmt.Sprintf("%#x", key),
+		fmt.Sprintf("%#x", value))
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// LocalStorageClear removes the data stored under the key
+func (c *offchain) LocalStorageClear(kind StorageKind, key []byte) error {
+	return c.LocalStorageClearContext(context.Background(), kind, key)
+}
+
+// LocalStorageClearContext is like LocalStorageClear but uses the provided context for the RPC call.
+func (c *offchain) LocalStorageClearContext(ctx context.Context, kind StorageKind, key []byte) error {
+	var res string
+
+	return c.client.CallContext(ctx, &res, "offchain_localStorageClear", kind, fmt.Sprintf("%#x", key))
+}
+
+// LocalStorageGetIndexed retrieves the data that a pallet stored under the key via offchain indexing, which writes to
+// the PERSISTENT storage, and decodes it into target. It returns false if no data is stored under the key.
+func (c *offchain) LocalStorageGetIndexed(key []byte, target interface{}) (ok bool, err error) {
+	return c.LocalStorageGetIndexedContext(context.Background(), key, target)
+}
+
+// LocalStorageGetIndexedContext is like LocalStorageGetIndexed but uses the provided context for the RPC call.
+func (c *offchain) LocalStorageGetIndexedContext(
+	ctx context.Context,
+	key []byte,
+	target interface{},
+) (ok bool, err error) {
+	data, err := c.LocalStorageGetContext(ctx, Persistent, key)
+	if err != nil || data == nil {
+		return false, err
+	}
+
+	return true, codec.Decode(*data, target)
+}
@@ -17,17 +17,16 @@
 package offchain
 
 import (
-	"crypto/rand"
+	"bytes"
 	"testing"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestOffchain_LocalStorageGetSet(t *testing.T) {
-	key := make([]byte, 20)
-	n, err := rand.Read(key)
-	assert.NoError(t, err)
-	assert.Equal(t, 20, n)
+	key := codec.MustHexDecodeString("0x0102030405060708090a0b0c0d0e0f1011121314")
 
 	value := []byte{0, 1, 2}
 
@@ -43,3 +42,48 @@ func TestOffchain_LocalStorageGetSet(t *testing.T) {
 
 	assert.Equal(t, value, []byte(*data))
 }
+
+func TestOffchain_LocalStorageClear(t *testing.T) {
+	key := codec.MustHexDecodeString("0xaa01")
+
+	value := []byte{1, 2}
+
+	err := testOffchain.LocalStorageSet(Local, key, value)
+	assert.NoError(t, err)
+
+	data, err := testOffchain.LocalStorageGet(Local, key)
+	assert.NoError(t, err)
+	assert.Equal(t, value, []byte(*data))
+
+	err = testOffchain.LocalStorageClear(Local, key)
+	assert.NoError(t, err)
+
+	data, err = testOffchain.LocalStorageGet(Local, key)
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestOffchain_LocalStorageGetEmptyValue(t *testing.T) {
+	data, err := testOffchain.LocalStorageGet(Persistent, codec.MustHexDecodeString("0xaa02"))
+	assert.NoError(t, err)
+	assert.NotNil(t, data)
+	assert.Empty(t, *data)
+}
+
+func TestOffchain_LocalStorageGetIndexed(t *testing.T) {
+	key, err := NewMMRNodeKey(DefaultMMRIndexingPrefix, 0)
+	assert.NoError(t, err)
+
+	var node types.Hash
+	ok, err := testOffchain.LocalStorageGetIndexed(key, &node)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.NewHash(bytes.Repeat([]byte{0x11}, 32)), node)
+
+	key, err = NewMMRTempNodeKey(DefaultMMRIndexingPrefix, 1, types.NewHash(bytes.Repeat([]byte{0x22}, 32)))
+	assert.NoError(t, err)
+
+	ok, err = testOffchain.LocalStorageGetIndexed(key, &node)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,60 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2022 Snowfork
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offchain
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// DefaultMMRIndexingPrefix is the indexing prefix of pallet-mmr in most runtimes.
+var DefaultMMRIndexingPrefix = []byte("mmr")
+
+// NewIndexingKey returns the key of data written via offchain indexing by pallets that prefix the SCALE encoded
+// parts of the key with a raw prefix, i.e. [PREFIX, key.encode()].concat().
+func NewIndexingKey(prefix []byte, parts ...interface{}) ([]byte, error) {
+	key := append([]byte{}, prefix...)
+
+	for _, part := range parts {
+		encodedPart, err := codec.Encode(part)
+		if err != nil {
+			return nil, err
+		}
+
+		key = append(key, encodedPart...)
+	}
+
+	return key, nil
+}
+
+// NewEncodedIndexingKey returns the key of data written via offchain indexing by pallets that SCALE encode the prefix
+// together with the parts of the key, i.e. (prefix, key).encode().
+func NewEncodedIndexingKey(prefix []byte, parts ...interface{}) ([]byte, error) {
+	return NewIndexingKey(nil, append([]interface{}{prefix}, parts...)...)
+}
+
+// NewMMRNodeKey returns the key of the MMR node at the position once pallet-mmr canonicalized it, i.e. after the block
+// that added it is finalized.
+func NewMMRNodeKey(prefix []byte, pos types.U64) ([]byte, error) {
+	return NewEncodedIndexingKey(prefix, pos)
+}
+
+// NewMMRTempNodeKey returns the key of the MMR node at the position that was added by the block with the parent hash,
+// before pallet-mmr canonicalized it.
+func NewMMRTempNodeKey(prefix []byte, pos types.U64, parentHash types.Hash) ([]byte, error) {
+	return NewEncodedIndexingKey(prefix, pos, parentHash)
+}
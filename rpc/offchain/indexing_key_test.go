@@ -0,0 +1,58 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2022 Snowfork
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offchain
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIndexingKey(t *testing.T) {
+	key, err := NewIndexingKey([]byte("prefix"), types.NewU32(1), types.NewBytes([]byte{7}))
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte("prefix"), 1, 0, 0, 0, 4, 7), key)
+
+	key, err = NewIndexingKey([]byte("prefix"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("prefix"), key)
+}
+
+func TestNewEncodedIndexingKey(t *testing.T) {
+	key, err := NewEncodedIndexingKey([]byte("mmr"), types.NewU64(3))
+	assert.NoError(t, err)
+	assert.Equal(t, codec.MustHexDecodeString("0x0c6d6d720300000000000000"), key)
+}
+
+func TestNewMMRNodeKeys(t *testing.T) {
+	key, err := NewMMRNodeKey(DefaultMMRIndexingPrefix, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, codec.MustHexDecodeString("0x0c6d6d720300000000000000"), key)
+
+	parentHash := types.Hash{1, 2, 3}
+
+	key, err = NewMMRTempNodeKey(DefaultMMRIndexingPrefix, 3, parentHash)
+	assert.NoError(t, err)
+	assert.Equal(t, append(codec.MustHexDecodeString("0x0c6d6d720300000000000000"), parentHash[:]...), key)
+}
+
+func TestNewIndexingKey_EncodeError(t *testing.T) {
+	_, err := NewIndexingKey(nil, make(chan int))
+	assert.Error(t, err)
+}
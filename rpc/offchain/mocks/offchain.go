@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	offchain "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/offchain"
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	mock "github.com/stretchr/testify/mock"
@@ -13,6 +15,34 @@ type Offchain struct {
 	mock.Mock
 }
 
+// LocalStorageClear provides a mock function with given fields: kind, key
+func (_m *Offchain) LocalStorageClear(kind offchain.StorageKind, key []byte) error {
+	ret := _m.Called(kind, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(offchain.StorageKind, []byte) error); ok {
+		r0 = rf(kind, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LocalStorageClearContext provides a mock function with given fields: ctx, kind, key
+func (_m *Offchain) LocalStorageClearContext(ctx context.Context, kind offchain.StorageKind, key []byte) error {
+	ret := _m.Called(ctx, kind, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, offchain.StorageKind, []byte) error); ok {
+		r0 = rf(ctx, kind, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // LocalStorageGet provides a mock function with given fields: kind, key
 func (_m *Offchain) LocalStorageGet(kind offchain.StorageKind, key []byte) (*types.StorageDataRaw, error) {
 	ret := _m.Called(kind, key)
@@ -36,6 +66,71 @@ func (_m *Offchain) LocalStorageGet(kind offchain.StorageKind, key []byte) (*typ
 	return r0, r1
 }
 
+// LocalStorageGetContext provides a mock function with given fields: ctx, kind, key
+func (_m *Offchain) LocalStorageGetContext(ctx context.Context, kind offchain.StorageKind, key []byte) (*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, kind, key)
+
+	var r0 *types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(context.Context, offchain.StorageKind, []byte) *types.StorageDataRaw); ok {
+		r0 = rf(ctx, kind, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.StorageDataRaw)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, offchain.StorageKind, []byte) error); ok {
+		r1 = rf(ctx, kind, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LocalStorageGetIndexed provides a mock function with given fields: key, target
+func (_m *Offchain) LocalStorageGetIndexed(key []byte, target interface{}) (bool, error) {
+	ret := _m.Called(key, target)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func([]byte, interface{}) bool); ok {
+		r0 = rf(key, target)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, interface{}) error); ok {
+		r1 = rf(key, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LocalStorageGetIndexedContext provides a mock function with given fields: ctx, key, target
+func (_m *Offchain) LocalStorageGetIndexedContext(ctx context.Context, key []byte, target interface{}) (bool, error) {
+	ret := _m.Called(ctx, key, target)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, interface{}) bool); ok {
+		r0 = rf(ctx, key, target)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, interface{}) error); ok {
+		r1 = rf(ctx, key, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LocalStorageSet provides a mock function with given fields: kind, key, value
 func (_m *Offchain) LocalStorageSet(kind offchain.StorageKind, key []byte, value []byte) error {
 	ret := _m.Called(kind, key, value)
@@ -50,6 +145,20 @@ func (_m *Offchain) LocalStorageSet(kind offchain.StorageKind, key []byte, value
 	return r0
 }
 
+// LocalStorageSetContext provides a mock function with given fields: ctx, kind, key, value
+func (_m *Offchain) LocalStorageSetContext(ctx context.Context, kind offchain.StorageKind, key []byte, value []byte) error {
+	ret := _m.Called(ctx, kind, key, value)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, offchain.StorageKind, []byte, []byte) error); ok {
+		r0 = rf(ctx, kind, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type NewOffchainT interface {
 	mock.TestingT
 	Cleanup(func())
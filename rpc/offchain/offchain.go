@@ -19,13 +19,21 @@
 package offchain
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 type Offchain interface {
 	LocalStorageGet(kind StorageKind, key []byte) (*types.StorageDataRaw, error)
+	LocalStorageGetContext(ctx context.Context, kind StorageKind, key []byte) (*types.StorageDataRaw, error)
 	LocalStorageSet(kind StorageKind, key []byte, value []byte) error
+	LocalStorageSetContext(ctx context.Context, kind StorageKind, key []byte, value []byte) error
+	LocalStorageClear(kind StorageKind, key []byte) error
+	LocalStorageClearContext(ctx context.Context, kind StorageKind, key []byte) error
+	LocalStorageGetIndexed(key []byte, target interface{}) (ok bool, err error)
+	LocalStorageGetIndexedContext(ctx context.Context, key []byte, target interface{}) (ok bool, err error)
 }
 
 // offchain exposes methods for retrieval of off-chain data
@@ -0,0 +1,96 @@
+//go:build live
+
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2022 Snowfork
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offchain
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The live tests expect a dev node that exposes the unsafe RPC methods and runs with --enable-offchain-indexing=true.
+
+func newLiveClient(t *testing.T) client.Client {
+	cl, err := client.Connect(config.Default().RPCURL)
+	require.NoError(t, err)
+	t.Cleanup(cl.Close)
+
+	return cl
+}
+
+func TestLive_Offchain_LocalStorage(t *testing.T) {
+	o := NewOffchain(newLiveClient(t))
+
+	key := []byte("gsrpc-live-test")
+
+	for _, kind := range []StorageKind{Persistent, Local} {
+		err := o.LocalStorageSet(kind, key, []byte{})
+		require.NoError(t, err)
+
+		data, err := o.LocalStorageGet(kind, key)
+		require.NoError(t, err)
+		require.NotNil(t, data, "empty value of kind %s", kind)
+		assert.Empty(t, *data)
+
+		err = o.LocalStorageSet(kind, key, []byte{1, 2, 3})
+		require.NoError(t, err)
+
+		data, err = o.LocalStorageGet(kind, key)
+		require.NoError(t, err)
+		require.NotNil(t, data)
+		assert.Equal(t, types.StorageDataRaw{1, 2, 3}, *data)
+
+		err = o.LocalStorageClear(kind, key)
+		require.NoError(t, err)
+
+		data, err = o.LocalStorageGet(kind, key)
+		require.NoError(t, err)
+		assert.Nil(t, data, "cleared value of kind %s", kind)
+	}
+}
+
+func TestLive_Offchain_LocalStorageGetIndexed(t *testing.T) {
+	cl := newLiveClient(t)
+	o := NewOffchain(cl)
+
+	// pallet-mmr indexes the first leaf, which is at position 0, in the first block
+	genesisHash, err := chain.NewChain(cl).GetBlockHash(0)
+	require.NoError(t, err)
+
+	canonKey, err := NewMMRNodeKey(DefaultMMRIndexingPrefix, 0)
+	require.NoError(t, err)
+
+	tempKey, err := NewMMRTempNodeKey(DefaultMMRIndexingPrefix, 0, genesisHash)
+	require.NoError(t, err)
+
+	var node types.StorageDataRaw
+
+	canonOK, err := o.LocalStorageGetIndexed(canonKey, &node)
+	require.NoError(t, err)
+
+	tempOK, err := o.LocalStorageGetIndexed(tempKey, &node)
+	require.NoError(t, err)
+
+	assert.True(t, canonOK || tempOK, "MMR leaf not found in the offchain storage")
+}
@@ -0,0 +1,123 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name Payment --filename payment.go
+
+package payment
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	ErrCallAPINotSupported = libErr.Error("TransactionPaymentCallApi not provided by the runtime")
+)
+
+const (
+	transactionPaymentAPI     = "TransactionPaymentApi"
+	transactionPaymentCallAPI = "TransactionPaymentCallApi"
+)
+
+// Payment exposes the fee estimation of the transaction payment pallet.
+//
+// Fees are queried via the TransactionPaymentApi and TransactionPaymentCallApi runtime APIs using state_call, which
+// also decodes V2 weights correctly. For runtimes that don't provide the TransactionPaymentApi, the legacy
+// payment_queryInfo and payment_queryFeeDetails RPC methods are used instead.
+type Payment interface {
+	QueryInfo(xt types.Extrinsic, blockHash types.Hash) (*types.RuntimeDispatchInfo, error)
+	QueryInfoContext(ctx context.Context, xt types.Extrinsic, blockHash types.Hash) (*types.RuntimeDispatchInfo, error)
+	QueryInfoLatest(xt types.Extrinsic) (*types.RuntimeDispatchInfo, error)
+	QueryInfoLatestContext(ctx context.Context, xt types.Extrinsic) (*types.RuntimeDispatchInfo, error)
+	QueryFeeDetails(xt types.Extrinsic, blockHash types.Hash) (*types.FeeDetails, error)
+	QueryFeeDetailsContext(ctx context.Context, xt types.Extrinsic, blockHash types.Hash) (*types.FeeDetails, error)
+	QueryFeeDetailsLatest(xt types.Extrinsic) (*types.FeeDetails, error)
+	QueryFeeDetailsLatestContext(ctx context.Context, xt types.Extrinsic) (*types.FeeDetails, error)
+	QueryCallInfo(call types.Call, blockHash types.Hash) (*types.RuntimeDispatchInfo, error)
+	QueryCallInfoContext(ctx context.Context, call types.Call, blockHash types.Hash) (*types.RuntimeDispatchInfo, error)
+	QueryCallInfoLatest(call types.Call) (*types.RuntimeDispatchInfo, error)
+	QueryCallInfoLatestContext(ctx context.Context, call types.Call) (*types.RuntimeDispatchInfo, error)
+	QueryCallFeeDetails(call types.Call, blockHash types.Hash) (*types.FeeDetails, error)
+	QueryCallFeeDetailsContext(ctx context.Context, call types.Call, blockHash types.Hash) (*types.FeeDetails, error)
+	QueryCallFeeDetailsLatest(call types.Call) (*types.FeeDetails, error)
+	QueryCallFeeDetailsLatestContext(ctx context.Context, call types.Call) (*types.FeeDetails, error)
+}
+
+// payment exposes methods for fee estimation
+type payment struct {
+	client client.Client
+}
+
+// NewPayment creates a new payment struct
+func NewPayment(cl client.Client) Payment {
+	return &payment{cl}
+}
+
+// runtimeCall calls the method of the runtime API with the SCALE encoded arg, followed by the length of the encoded
+// arg, as expected by the query functions of the transaction payment APIs. ok is false if the runtime does not
+// provide the API.
+func (p *payment) runtimeCall(
+	ctx context.Context,
+	api, method string,
+	arg interface{},
+	blockHash *types.Hash,
+) (res []byte, apiVersion types.U32, ok bool, err error) {
+	var runtimeVersion types.RuntimeVersion
+
+	err = client.CallWithBlockHashContext(ctx, p.client, &runtimeVersion, "state_getRuntimeVersion", blockHash)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	apiVersion, ok = runtimeVersion.APIVersion(api)
+	if !ok {
+		return nil, 0, false, nil
+	}
+
+	enc, err := codec.Encode(arg)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	data := binary.LittleEndian.AppendUint32(enc, uint32(len(enc)))
+
+	var hexRes string
+
+	err = client.CallWithBlockHashContext(
+		ctx,
+		p.client,
+		&hexRes,
+		"state_call",
+		blockHash,
+		api+"_"+method,
+		codec.HexEncodeToString(data),
+	)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	res, err = codec.HexDecodeString(hexRes)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	return res, apiVersion, true, nil
+}
@@ -0,0 +1,89 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testBlockHash = types.Hash{1, 2, 3}
+
+	testExtrinsic = types.NewExtrinsic(types.Call{
+		CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 3},
+		Args:      []byte{1, 2, 3},
+	})
+)
+
+func newTestPayment(t *testing.T, fixtures ...rpcmocksrv.Fixture) Payment {
+	s := rpcmocksrv.NewReplayServer(fixtures...)
+	t.Cleanup(s.Close)
+
+	cl, err := client.Connect(s.URL)
+	require.NoError(t, err)
+	t.Cleanup(cl.Close)
+
+	return NewPayment(cl)
+}
+
+// runtimeVersionFixture returns a fixture for a runtime that provides the APIs with the given versions.
+func runtimeVersionFixture(t *testing.T, apis map[string]types.U32) rpcmocksrv.Fixture {
+	runtimeVersion := types.NewRuntimeVersion()
+
+	for name, version := range apis {
+		id, err := types.NewRuntimeAPIID(name)
+		require.NoError(t, err)
+
+		runtimeVersion.APIs = append(runtimeVersion.APIs, types.RuntimeVersionAPI{APIID: id, Version: version})
+	}
+
+	return rpcmocksrv.Fixture{Method: "state_getRuntimeVersion", Result: mustMarshalJSON(t, runtimeVersion)}
+}
+
+// stateCallFixture returns a fixture for a state_call of the method with the encoded arg and its length at the test
+// block, that returns the encoded result.
+func stateCallFixture(t *testing.T, method string, arg, result interface{}) rpcmocksrv.Fixture {
+	enc, err := codec.Encode(arg)
+	require.NoError(t, err)
+
+	res, err := codec.EncodeToHex(result)
+	require.NoError(t, err)
+
+	data := codec.HexEncodeToString(binary.LittleEndian.AppendUint32(enc, uint32(len(enc))))
+
+	return rpcmocksrv.Fixture{
+		Method: "state_call",
+		Params: mustMarshalJSON(t, []string{method, data, testBlockHash.Hex()}),
+		Result: mustMarshalJSON(t, res),
+	}
+}
+
+func mustMarshalJSON(t *testing.T, v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	require.NoError(t, err, fmt.Sprintf("%v", v))
+
+	return b
+}
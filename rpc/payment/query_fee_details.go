@@ -0,0 +1,129 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// QueryFeeDetails returns the breakdown of the fee of the extrinsic at the given block
+func (p *payment) QueryFeeDetails(xt types.Extrinsic, blockHash types.Hash) (*types.FeeDetails, error) {
+	return p.QueryFeeDetailsContext(context.Background(), xt, blockHash)
+}
+
+// QueryFeeDetailsContext is like QueryFeeDetails but uses the provided context for the RPC calls.
+func (p *payment) QueryFeeDetailsContext(
+	ctx context.Context,
+	xt types.Extrinsic,
+	blockHash types.Hash,
+) (*types.FeeDetails, error) {
+	return p.queryFeeDetails(ctx, xt, &blockHash)
+}
+
+// QueryFeeDetailsLatest returns the breakdown of the fee of the extrinsic at the latest block
+func (p *payment) QueryFeeDetailsLatest(xt types.Extrinsic) (*types.FeeDetails, error) {
+	return p.QueryFeeDetailsLatestContext(context.Background(), xt)
+}
+
+// QueryFeeDetailsLatestContext is like QueryFeeDetailsLatest but uses the provided context for the RPC calls.
+func (p *payment) QueryFeeDetailsLatestContext(ctx context.Context, xt types.Extrinsic) (*types.FeeDetails, error) {
+	return p.queryFeeDetails(ctx, xt, nil)
+}
+
+func (p *payment) queryFeeDetails(
+	ctx context.Context,
+	xt types.Extrinsic,
+	blockHash *types.Hash,
+) (*types.FeeDetails, error) {
+	res, _, ok, err := p.runtimeCall(ctx, transactionPaymentAPI, "query_fee_details", xt, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return decodeFeeDetails(res)
+	}
+
+	enc, err := codec.EncodeToHex(xt)
+	if err != nil {
+		return nil, err
+	}
+
+	var details types.FeeDetails
+
+	err = client.CallWithBlockHashContext(ctx, p.client, &details, "payment_queryFeeDetails", blockHash, enc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &details, nil
+}
+
+// QueryCallFeeDetails returns the breakdown of the fee of the call at the given block
+func (p *payment) QueryCallFeeDetails(call types.Call, blockHash types.Hash) (*types.FeeDetails, error) {
+	return p.QueryCallFeeDetailsContext(context.Background(), call, blockHash)
+}
+
+// QueryCallFeeDetailsContext is like QueryCallFeeDetails but uses the provided context for the RPC calls.
+func (p *payment) QueryCallFeeDetailsContext(
+	ctx context.Context,
+	call types.Call,
+	blockHash types.Hash,
+) (*types.FeeDetails, error) {
+	return p.queryCallFeeDetails(ctx, call, &blockHash)
+}
+
+// QueryCallFeeDetailsLatest returns the breakdown of the fee of the call at the latest block
+func (p *payment) QueryCallFeeDetailsLatest(call types.Call) (*types.FeeDetails, error) {
+	return p.QueryCallFeeDetailsLatestContext(context.Background(), call)
+}
+
+// QueryCallFeeDetailsLatestContext is like QueryCallFeeDetailsLatest but uses the provided context for the RPC calls.
+func (p *payment) QueryCallFeeDetailsLatestContext(ctx context.Context, call types.Call) (*types.FeeDetails, error) {
+	return p.queryCallFeeDetails(ctx, call, nil)
+}
+
+func (p *payment) queryCallFeeDetails(
+	ctx context.Context,
+	call types.Call,
+	blockHash *types.Hash,
+) (*types.FeeDetails, error) {
+	res, _, ok, err := p.runtimeCall(ctx, transactionPaymentCallAPI, "query_call_fee_details", call, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrCallAPINotSupported
+	}
+
+	return decodeFeeDetails(res)
+}
+
+func decodeFeeDetails(b []byte) (*types.FeeDetails, error) {
+	var details types.FeeDetails
+
+	if err := codec.Decode(b, &details); err != nil {
+		return nil, err
+	}
+
+	return &details, nil
+}
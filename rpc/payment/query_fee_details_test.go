@@ -0,0 +1,90 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFeeDetails = types.FeeDetails{
+	InclusionFee: types.NewOption(types.InclusionFee{
+		BaseFee:           types.NewU128(*big.NewInt(15_000_000_000)),
+		LenFee:            types.NewU128(*big.NewInt(700_000_000)),
+		AdjustedWeightFee: types.NewU128(*big.NewInt(100_000_000)),
+	}),
+	Tip: types.NewU128(*big.NewInt(0)),
+}
+
+func TestPayment_QueryFeeDetails(t *testing.T) {
+	p := newTestPayment(
+		t,
+		runtimeVersionFixture(t, map[string]types.U32{transactionPaymentAPI: 4}),
+		stateCallFixture(t, "TransactionPaymentApi_query_fee_details", testExtrinsic, testFeeDetails),
+	)
+
+	details, err := p.QueryFeeDetails(testExtrinsic, testBlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, &testFeeDetails, details)
+}
+
+func TestPayment_QueryFeeDetailsLatest_LegacyRPC(t *testing.T) {
+	xtHex, err := codec.EncodeToHex(testExtrinsic)
+	require.NoError(t, err)
+
+	p := newTestPayment(
+		t,
+		runtimeVersionFixture(t, nil),
+		rpcmocksrv.Fixture{
+			Method: "payment_queryFeeDetails",
+			Params: mustMarshalJSON(t, []string{xtHex}),
+			Result: []byte(`{"inclusionFee":{"baseFee":15000000000,"lenFee":"0x29b92700","adjustedWeightFee":"0x5f5e100"}}`),
+		},
+	)
+
+	details, err := p.QueryFeeDetailsLatest(testExtrinsic)
+	assert.NoError(t, err)
+	assert.Equal(t, &testFeeDetails, details)
+}
+
+func TestPayment_QueryCallFeeDetails(t *testing.T) {
+	call := testExtrinsic.Method
+
+	p := newTestPayment(
+		t,
+		runtimeVersionFixture(t, map[string]types.U32{transactionPaymentCallAPI: 3}),
+		stateCallFixture(t, "TransactionPaymentCallApi_query_call_fee_details", call, testFeeDetails),
+	)
+
+	details, err := p.QueryCallFeeDetails(call, testBlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, &testFeeDetails, details)
+}
+
+func TestPayment_QueryCallFeeDetails_NotSupported(t *testing.T) {
+	p := newTestPayment(t, runtimeVersionFixture(t, nil))
+
+	details, err := p.QueryCallFeeDetails(testExtrinsic.Method, testBlockHash)
+	assert.ErrorIs(t, err, ErrCallAPINotSupported)
+	assert.Nil(t, details)
+}
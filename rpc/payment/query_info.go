@@ -0,0 +1,143 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// QueryInfo returns the dispatch info and the partial fee of the extrinsic at the given block
+func (p *payment) QueryInfo(xt types.Extrinsic, blockHash types.Hash) (*types.RuntimeDispatchInfo, error) {
+	return p.QueryInfoContext(context.Background(), xt, blockHash)
+}
+
+// QueryInfoContext is like QueryInfo but uses the provided context for the RPC calls.
+func (p *payment) QueryInfoContext(
+	ctx context.Context,
+	xt types.Extrinsic,
+	blockHash types.Hash,
+) (*types.RuntimeDispatchInfo, error) {
+	return p.queryInfo(ctx, xt, &blockHash)
+}
+
+// QueryInfoLatest returns the dispatch info and the partial fee of the extrinsic at the latest block
+func (p *payment) QueryInfoLatest(xt types.Extrinsic) (*types.RuntimeDispatchInfo, error) {
+	return p.QueryInfoLatestContext(context.Background(), xt)
+}
+
+// QueryInfoLatestContext is like QueryInfoLatest but uses the provided context for the RPC calls.
+func (p *payment) QueryInfoLatestContext(ctx context.Context, xt types.Extrinsic) (*types.RuntimeDispatchInfo, error) {
+	return p.queryInfo(ctx, xt, nil)
+}
+
+func (p *payment) queryInfo(
+	ctx context.Context,
+	xt types.Extrinsic,
+	blockHash *types.Hash,
+) (*types.RuntimeDispatchInfo, error) {
+	res, apiVersion, ok, err := p.runtimeCall(ctx, transactionPaymentAPI, "query_info", xt, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return decodeRuntimeDispatchInfo(res, apiVersion)
+	}
+
+	enc, err := codec.EncodeToHex(xt)
+	if err != nil {
+		return nil, err
+	}
+
+	var info types.RuntimeDispatchInfo
+
+	err = client.CallWithBlockHashContext(ctx, p.client, &info, "payment_queryInfo", blockHash, enc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// QueryCallInfo returns the dispatch info and the partial fee of the call at the given block. The call is not wrapped
+// in an extrinsic, so the fee does not include the length of the signature.
+func (p *payment) QueryCallInfo(call types.Call, blockHash types.Hash) (*types.RuntimeDispatchInfo, error) {
+	return p.QueryCallInfoContext(context.Background(), call, blockHash)
+}
+
+// QueryCallInfoContext is like QueryCallInfo but uses the provided context for the RPC calls.
+func (p *payment) QueryCallInfoContext(
+	ctx context.Context,
+	call types.Call,
+	blockHash types.Hash,
+) (*types.RuntimeDispatchInfo, error) {
+	return p.queryCallInfo(ctx, call, &blockHash)
+}
+
+// QueryCallInfoLatest returns the dispatch info and the partial fee of the call at the latest block
+func (p *payment) QueryCallInfoLatest(call types.Call) (*types.RuntimeDispatchInfo, error) {
+	return p.QueryCallInfoLatestContext(context.Background(), call)
+}
+
+// QueryCallInfoLatestContext is like QueryCallInfoLatest but uses the provided context for the RPC calls.
+func (p *payment) QueryCallInfoLatestContext(ctx context.Context, call types.Call) (*types.RuntimeDispatchInfo, error) {
+	return p.queryCallInfo(ctx, call, nil)
+}
+
+func (p *payment) queryCallInfo(
+	ctx context.Context,
+	call types.Call,
+	blockHash *types.Hash,
+) (*types.RuntimeDispatchInfo, error) {
+	res, apiVersion, ok, err := p.runtimeCall(ctx, transactionPaymentCallAPI, "query_call_info", call, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrCallAPINotSupported
+	}
+
+	return decodeRuntimeDispatchInfo(res, apiVersion)
+}
+
+func decodeRuntimeDispatchInfo(b []byte, apiVersion types.U32) (*types.RuntimeDispatchInfo, error) {
+	if apiVersion < 2 {
+		// Version 1 of the APIs still used V1 weights.
+		var infoV1 types.RuntimeDispatchInfoV1
+
+		if err := codec.Decode(b, &infoV1); err != nil {
+			return nil, err
+		}
+
+		info := infoV1.ToRuntimeDispatchInfo()
+
+		return &info, nil
+	}
+
+	var info types.RuntimeDispatchInfo
+
+	if err := codec.Decode(b, &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
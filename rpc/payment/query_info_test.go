@@ -0,0 +1,115 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRuntimeDispatchInfo = types.RuntimeDispatchInfo{
+	Weight:     types.NewWeight(types.NewUCompactFromUInt(154_000_000), types.NewUCompactFromUInt(3_593)),
+	Class:      types.DispatchClass{IsNormal: true},
+	PartialFee: types.NewU128(*big.NewInt(15_800_000_000)),
+}
+
+func TestPayment_QueryInfo(t *testing.T) {
+	p := newTestPayment(
+		t,
+		runtimeVersionFixture(t, map[string]types.U32{transactionPaymentAPI: 4}),
+		stateCallFixture(t, "TransactionPaymentApi_query_info", testExtrinsic, testRuntimeDispatchInfo),
+	)
+
+	info, err := p.QueryInfo(testExtrinsic, testBlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, &testRuntimeDispatchInfo, info)
+}
+
+func TestPayment_QueryInfo_WeightV1(t *testing.T) {
+	p := newTestPayment(
+		t,
+		runtimeVersionFixture(t, map[string]types.U32{transactionPaymentAPI: 1}),
+		stateCallFixture(t, "TransactionPaymentApi_query_info", testExtrinsic, types.RuntimeDispatchInfoV1{
+			Weight:     154_000_000,
+			Class:      types.DispatchClass{IsOperational: true},
+			PartialFee: types.NewU128(*big.NewInt(42)),
+		}),
+	)
+
+	info, err := p.QueryInfo(testExtrinsic, testBlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.RuntimeDispatchInfo{
+		Weight:     types.NewWeight(types.NewUCompactFromUInt(154_000_000), types.NewUCompactFromUInt(0)),
+		Class:      types.DispatchClass{IsOperational: true},
+		PartialFee: types.NewU128(*big.NewInt(42)),
+	}, info)
+}
+
+func TestPayment_QueryInfoLatest_LegacyRPC(t *testing.T) {
+	xtHex, err := codec.EncodeToHex(testExtrinsic)
+	require.NoError(t, err)
+
+	p := newTestPayment(
+		t,
+		runtimeVersionFixture(t, nil),
+		rpcmocksrv.Fixture{
+			Method: "payment_queryInfo",
+			Params: mustMarshalJSON(t, []string{xtHex}),
+			Result: []byte(`{"weight":{"ref_time":154000000,"proof_size":3593},"class":"normal","partialFee":"15800000000"}`),
+		},
+	)
+
+	info, err := p.QueryInfoLatest(testExtrinsic)
+	assert.NoError(t, err)
+	assert.Equal(t, &testRuntimeDispatchInfo, info)
+}
+
+func TestPayment_QueryInfo_RuntimeVersionError(t *testing.T) {
+	p := newTestPayment(t)
+
+	info, err := p.QueryInfo(testExtrinsic, testBlockHash)
+	assert.True(t, types.IsMethodNotFound(err))
+	assert.Nil(t, info)
+}
+
+func TestPayment_QueryCallInfo(t *testing.T) {
+	call := testExtrinsic.Method
+
+	p := newTestPayment(
+		t,
+		runtimeVersionFixture(t, map[string]types.U32{transactionPaymentAPI: 4, transactionPaymentCallAPI: 3}),
+		stateCallFixture(t, "TransactionPaymentCallApi_query_call_info", call, testRuntimeDispatchInfo),
+	)
+
+	info, err := p.QueryCallInfo(call, testBlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, &testRuntimeDispatchInfo, info)
+}
+
+func TestPayment_QueryCallInfoLatest_NotSupported(t *testing.T) {
+	p := newTestPayment(t, runtimeVersionFixture(t, map[string]types.U32{transactionPaymentAPI: 4}))
+
+	info, err := p.QueryCallInfoLatest(testExtrinsic.Method)
+	assert.ErrorIs(t, err, ErrCallAPINotSupported)
+	assert.Nil(t, info)
+}
@@ -0,0 +1,203 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/archive"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	// MaxKeysPageSize is the maximum number of keys a node returns for a single state_getKeysPaged call.
+	MaxKeysPageSize = 1000
+	// DefaultKeysPageSize is the page size ForEachKey uses if none is set.
+	DefaultKeysPageSize = MaxKeysPageSize
+)
+
+const (
+	ErrInvalidKeysPageSize = libErr.Error("invalid keys page size")
+	ErrKeysPageRetrieval   = libErr.Error("keys page retrieval")
+	ErrKeysPageValues      = libErr.Error("keys page values retrieval")
+)
+
+// ErrStopIteration can be returned by the callback of ForEachKey to end the iteration early without an error.
+var ErrStopIteration = errors.New("stop iteration")
+
+// ForEachKeyOptions configures the iteration of ForEachKey.
+type ForEachKeyOptions struct {
+	// PageSize is the number of keys that are retrieved per call, DefaultKeysPageSize if zero. It must not exceed
+	// MaxKeysPageSize.
+	PageSize uint32
+	// Cursor resumes the iteration after the given key, which is the cursor returned by a previous iteration.
+	Cursor types.StorageKey
+	// FetchValues retrieves the values of each page with a single state_queryStorageAt call.
+	FetchValues bool
+	// PageInterval is the minimum time between two page retrievals, to limit the load on public nodes.
+	PageInterval time.Duration
+}
+
+// ForEachKey calls fn for each key with the given prefix at the given block, in lexicographic order. Pinning the block
+// keeps the iteration consistent while the chain progresses, e.g. use the finalized head. The value of each key is
+// passed to fn if FetchValues is set, otherwise it is nil, as it is for keys that were removed.
+//
+// The iteration ends when all keys were visited, fn returns an error or ctx is done. The returned cursor is the last
+// key fn was called for successfully, the iteration can be resumed by passing it in ForEachKeyOptions.Cursor. If fn
+// returns ErrStopIteration, the iteration ends without an error.
+//
+// If the node advertises the archive methods, see archive.Archive.Supported, the keys are retrieved together with
+// their values via archive_unstable_storage instead, which needs a single call per page and ignores the PageSize.
+func (s *state) ForEachKey( //nolint:funlen
+	ctx context.Context,
+	prefix types.StorageKey,
+	blockHash types.Hash,
+	opts ForEachKeyOptions,
+	fn func(key types.StorageKey, value *types.StorageDataRaw) error,
+) (cursor types.StorageKey, err error) {
+	pageSize := opts.PageSize
+	if pageSize == 0 {
+		pageSize = DefaultKeysPageSize
+	}
+
+	if pageSize > MaxKeysPageSize {
+		return opts.Cursor, ErrInvalidKeysPageSize.WithMsg("%d exceeds %d", pageSize, MaxKeysPageSize)
+	}
+
+	if archiveRPC := archive.NewArchive(s.client); archiveRPC.SupportedContext(ctx) {
+		cursor, err := forEachDescendant(ctx, archiveRPC, prefix, blockHash, opts, fn)
+		if !errors.Is(err, archive.ErrNotSupported) {
+			return cursor, err
+		}
+
+		// The node does not answer the archive methods it advertises, the iteration is resumed via the legacy ones.
+		opts.Cursor = cursor
+	}
+
+	cursor = opts.Cursor
+
+	for page := 0; ; page++ {
+		if page > 0 && opts.PageInterval > 0 {
+			if err := sleepContext(ctx, opts.PageInterval); err != nil {
+				return cursor, err
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return cursor, err
+		}
+
+		keys, err := s.getKeysPaged(ctx, prefix, pageSize, cursor, &blockHash)
+		if err != nil {
+			return cursor, ErrKeysPageRetrieval.WithMsg("page %d", page).Wrap(err)
+		}
+
+		var values map[string]*types.StorageDataRaw
+
+		if opts.FetchValues && len(keys) > 0 {
+			values, err = s.getPageValues(ctx, keys, blockHash)
+			if err != nil {
+				return cursor, ErrKeysPageValues.WithMsg("page %d", page).Wrap(err)
+			}
+		}
+
+		for _, key := range keys {
+			if err := fn(key, values[key.Hex()]); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return cursor, nil
+				}
+
+				return cursor, err
+			}
+
+			cursor = key
+		}
+
+		if len(keys) < int(pageSize) {
+			return cursor, nil
+		}
+	}
+}
+
+// forEachDescendant calls fn for each key with the given prefix via archive_unstable_storage, see ForEachKey.
+func forEachDescendant(
+	ctx context.Context,
+	archiveRPC archive.Archive,
+	prefix types.StorageKey,
+	blockHash types.Hash,
+	opts ForEachKeyOptions,
+	fn func(key types.StorageKey, value *types.StorageDataRaw) error,
+) (types.StorageKey, error) {
+	cursor, err := archiveRPC.ForEachDescendant(ctx, prefix, blockHash, archive.ForEachDescendantOptions{
+		Cursor:       opts.Cursor,
+		Hashes:       !opts.FetchValues,
+		PageInterval: opts.PageInterval,
+	}, func(item types.ChainHeadStorageResultItem) error {
+		if !opts.FetchValues {
+			return fn(item.Key, nil)
+		}
+
+		return fn(item.Key, item.Value)
+	})
+	if errors.Is(err, ErrStopIteration) {
+		return cursor, nil
+	}
+
+	return cursor, err
+}
+
+// getPageValues returns the values of the keys at the block by their hex encoding.
+func (s *state) getPageValues(
+	ctx context.Context,
+	keys []types.StorageKey,
+	blockHash types.Hash,
+) (map[string]*types.StorageDataRaw, error) {
+	changeSets, err := s.queryStorageAt(ctx, keys, &blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]*types.StorageDataRaw, len(keys))
+
+	for _, changeSet := range changeSets {
+		for _, change := range changeSet.Changes {
+			if !change.HasStorageData {
+				continue
+			}
+
+			value := change.StorageData
+			values[change.StorageKey.Hex()] = &value
+		}
+	}
+
+	return values, nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,321 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKeysPrefix    = types.StorageKey{0xaa}
+	testKeysBlockHash = types.Hash{9}
+	testKeys          = []types.StorageKey{{0xaa, 1}, {0xaa, 2}, {0xaa, 3}, {0xaa, 4}, {0xaa, 5}}
+)
+
+func testKeysHex(keys ...types.StorageKey) []string {
+	res := make([]string, len(keys))
+	for i, key := range keys {
+		res[i] = key.Hex()
+	}
+
+	return res
+}
+
+// newTestKeysClient returns a client that answers paged key requests of testKeys with a page size of 2, and value
+// requests for all but the last key.
+func newTestKeysClient() *rpcmocksrv.MockClient {
+	prefix, block := testKeysPrefix.Hex(), testKeysBlockHash.Hex()
+
+	cl := rpcmocksrv.NewMockClient().
+		Respond("state_getKeysPaged", testKeysHex(testKeys[0:2]...), prefix, 2, nil, block).
+		Respond("state_getKeysPaged", testKeysHex(testKeys[2:4]...), prefix, 2, testKeys[1].Hex(), block).
+		Respond("state_getKeysPaged", testKeysHex(testKeys[4]), prefix, 2, testKeys[3].Hex(), block)
+
+	for i := 0; i < len(testKeys); i += 2 {
+		page := testKeys[i:min(i+2, len(testKeys))]
+		changes := make([][]interface{}, 0, len(page))
+
+		for _, key := range page {
+			var value interface{}
+			if key[1] != 5 {
+				value = codec.HexEncodeToString([]byte{key[1], 0})
+			}
+
+			changes = append(changes, []interface{}{key.Hex(), value})
+		}
+
+		res := []map[string]interface{}{{"block": block, "changes": changes}}
+		cl.Respond("state_queryStorageAt", res, testKeysHex(page...), block)
+	}
+
+	return cl
+}
+
+func TestState_GetKeysPaged(t *testing.T) {
+	s := NewState(newTestKeysClient())
+
+	keys, err := s.GetKeysPaged(testKeysPrefix, 2, nil, testKeysBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, testKeys[0:2], keys)
+
+	keys, err = s.GetKeysPaged(testKeysPrefix, 2, testKeys[1], testKeysBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, testKeys[2:4], keys)
+
+	cl := rpcmocksrv.NewMockClient().
+		Respond("state_getKeysPaged", testKeysHex(testKeys[4]), testKeysPrefix.Hex(), 10, testKeys[3].Hex())
+
+	keys, err = NewState(cl).GetKeysPagedLatest(testKeysPrefix, 10, testKeys[3])
+	require.NoError(t, err)
+	assert.Equal(t, testKeys[4:], keys)
+}
+
+func TestState_ForEachKey(t *testing.T) {
+	s := NewState(newTestKeysClient())
+
+	var keys []types.StorageKey
+
+	cursor, err := s.ForEachKey(
+		context.Background(),
+		testKeysPrefix,
+		testKeysBlockHash,
+		ForEachKeyOptions{PageSize: 2},
+		func(key types.StorageKey, value *types.StorageDataRaw) error {
+			assert.Nil(t, value)
+			keys = append(keys, key)
+
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, testKeys, keys)
+	assert.Equal(t, testKeys[4], cursor)
+}
+
+func TestState_ForEachKey_FetchValues(t *testing.T) {
+	cl := newTestKeysClient()
+	s := NewState(cl)
+
+	values := map[string]*types.StorageDataRaw{}
+
+	_, err := s.ForEachKey(
+		context.Background(),
+		testKeysPrefix,
+		testKeysBlockHash,
+		ForEachKeyOptions{PageSize: 2, FetchValues: true},
+		func(key types.StorageKey, value *types.StorageDataRaw) error {
+			values[key.Hex()] = value
+
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Len(t, values, 5)
+	assert.Equal(t, &types.StorageDataRaw{1, 0}, values[testKeys[0].Hex()])
+	assert.Equal(t, &types.StorageDataRaw{4, 0}, values[testKeys[3].Hex()])
+	assert.Nil(t, values[testKeys[4].Hex()])
+
+	cl.AssertCalled(t, "state_queryStorageAt", testKeysHex(testKeys[4]), testKeysBlockHash.Hex())
+}
+
+func TestState_ForEachKey_Resume(t *testing.T) {
+	s := NewState(newTestKeysClient())
+
+	var (
+		keys    []types.StorageKey
+		stopped bool
+	)
+
+	fn := func(key types.StorageKey, _ *types.StorageDataRaw) error {
+		if key[1] == 3 && !stopped {
+			stopped = true
+
+			return ErrStopIteration
+		}
+
+		keys = append(keys, key)
+
+		return nil
+	}
+
+	opts := ForEachKeyOptions{PageSize: 2}
+
+	cursor, err := s.ForEachKey(context.Background(), testKeysPrefix, testKeysBlockHash, opts, fn)
+	require.NoError(t, err)
+	assert.Equal(t, testKeys[1], cursor)
+
+	opts.Cursor = cursor
+
+	cursor, err = s.ForEachKey(context.Background(), testKeysPrefix, testKeysBlockHash, opts, fn)
+	require.NoError(t, err)
+	assert.Equal(t, testKeys[4], cursor)
+	assert.Equal(t, testKeys, keys)
+}
+
+func TestState_ForEachKey_Errors(t *testing.T) {
+	s := NewState(newTestKeysClient())
+	noop := func(types.StorageKey, *types.StorageDataRaw) error { return nil }
+
+	_, err := s.ForEachKey(
+		context.Background(),
+		testKeysPrefix,
+		testKeysBlockHash,
+		ForEachKeyOptions{PageSize: MaxKeysPageSize + 1},
+		noop,
+	)
+	assert.ErrorIs(t, err, ErrInvalidKeysPageSize)
+
+	// There is no fixture for the default page size.
+	_, err = s.ForEachKey(context.Background(), testKeysPrefix, testKeysBlockHash, ForEachKeyOptions{}, noop)
+	assert.ErrorIs(t, err, ErrKeysPageRetrieval)
+
+	fnErr := errors.New("fn error")
+
+	cursor, err := s.ForEachKey(
+		context.Background(),
+		testKeysPrefix,
+		testKeysBlockHash,
+		ForEachKeyOptions{PageSize: 2},
+		func(key types.StorageKey, _ *types.StorageDataRaw) error {
+			if key[1] == 4 {
+				return fnErr
+			}
+
+			return nil
+		},
+	)
+	assert.ErrorIs(t, err, fnErr)
+	assert.Equal(t, testKeys[2], cursor)
+}
+
+func TestState_ForEachKey_Cancel(t *testing.T) {
+	s := NewState(newTestKeysClient())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	start := time.Now()
+
+	cursor, err := s.ForEachKey(
+		ctx,
+		testKeysPrefix,
+		testKeysBlockHash,
+		ForEachKeyOptions{PageSize: 2, PageInterval: time.Minute},
+		func(types.StorageKey, *types.StorageDataRaw) error {
+			cancel()
+
+			return nil
+		},
+	)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, testKeys[1], cursor)
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestState_ForEachKey_Archive(t *testing.T) {
+	block := testKeysBlockHash.Hex()
+
+	items := make([]types.ChainHeadStorageResultItem, len(testKeys))
+	for i, key := range testKeys {
+		value := types.NewStorageDataRaw([]byte{key[1], 0})
+		items[i] = types.ChainHeadStorageResultItem{Key: key, Value: &value}
+	}
+
+	query := func(startKey types.StorageKey) []types.ArchiveStorageQueryItem {
+		return []types.ArchiveStorageQueryItem{{
+			Key:                testKeysPrefix,
+			Type:               types.ChainHeadStorageDescendantsValues,
+			PaginationStartKey: startKey,
+		}}
+	}
+
+	cl := rpcmocksrv.NewMockClient().
+		Respond("rpc_methods", types.RPCMethods{Methods: []string{"archive_unstable_storage"}}).
+		Respond("archive_unstable_storage", types.ArchiveStorageResult{Items: items}, block, query(nil), nil).
+		Respond("archive_unstable_storage", types.ArchiveStorageResult{}, block, query(testKeys[4]), nil)
+
+	s := NewState(client.WithMethodDiscovery(cl))
+
+	values := map[string]*types.StorageDataRaw{}
+
+	cursor, err := s.ForEachKey(
+		context.Background(),
+		testKeysPrefix,
+		testKeysBlockHash,
+		ForEachKeyOptions{FetchValues: true},
+		func(key types.StorageKey, value *types.StorageDataRaw) error {
+			values[key.Hex()] = value
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, testKeys[4], cursor)
+	assert.Len(t, values, len(testKeys))
+	assert.Equal(t, types.StorageDataRaw{3, 0}, *values[testKeys[2].Hex()])
+
+	cursor, err = s.ForEachKey(
+		context.Background(),
+		testKeysPrefix,
+		testKeysBlockHash,
+		ForEachKeyOptions{FetchValues: true},
+		func(key types.StorageKey, _ *types.StorageDataRaw) error {
+			if key[1] == 2 {
+				return ErrStopIteration
+			}
+
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, testKeys[0], cursor)
+}
+
+func TestState_ForEachKey_ArchiveFallback(t *testing.T) {
+	// The node advertises the archive methods but does not answer them.
+	cl := newTestKeysClient().
+		Respond("rpc_methods", types.RPCMethods{Methods: []string{
+			"archive_unstable_storage",
+			"state_getKeysPaged",
+			"state_queryStorageAt",
+		}})
+
+	s := NewState(client.WithMethodDiscovery(cl))
+
+	var keys []types.StorageKey
+
+	_, err := s.ForEachKey(
+		context.Background(),
+		testKeysPrefix,
+		testKeysBlockHash,
+		ForEachKeyOptions{PageSize: 2},
+		func(key types.StorageKey, _ *types.StorageDataRaw) error {
+			keys = append(keys, key)
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, testKeys, keys)
+	cl.AssertCalled(t, "archive_unstable_storage")
+}
@@ -17,6 +17,8 @@
 package state
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
@@ -24,17 +26,35 @@ import (
 
 // GetKeys retreives the keys with the given prefix
 func (s *state) GetKeys(prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
-	return s.getKeys(prefix, &blockHash)
+	return s.GetKeysContext(context.Background(), prefix, blockHash)
+}
+
+// GetKeysContext is like GetKeys but uses the provided context for the RPC call.
+func (s *state) GetKeysContext(
+	ctx context.Context,
+	prefix types.StorageKey,
+	blockHash types.Hash,
+) ([]types.StorageKey, error) {
+	return s.getKeys(ctx, prefix, &blockHash)
 }
 
 // GetKeysLatest retreives the keys with the given prefix for the latest block height
 func (s *state) GetKeysLatest(prefix types.StorageKey) ([]types.StorageKey, error) {
-	return s.getKeys(prefix, nil)
+	return s.GetKeysLatestContext(context.Background(), prefix)
+}
+
+// GetKeysLatestContext is like GetKeysLatest but uses the provided context for the RPC call.
+func (s *state) GetKeysLatestContext(ctx context.Context, prefix types.StorageKey) ([]types.StorageKey, error) {
+	return s.getKeys(ctx, prefix, nil)
 }
 
-func (s *state) getKeys(prefix types.StorageKey, blockHash *types.Hash) ([]types.StorageKey, error) {
+func (s *state) getKeys(
+	ctx context.Context,
+	prefix types.StorageKey,
+	blockHash *types.Hash,
+) ([]types.StorageKey, error) {
 	var res []string
-	err := client.CallWithBlockHash(s.client, &res, "state_getKeys", blockHash, prefix.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getKeys", blockHash, prefix.Hex())
 	if err != nil {
 		return nil, err
 	}
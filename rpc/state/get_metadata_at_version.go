@@ -0,0 +1,218 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	ErrMetadataVersionNotSupported = libErr.Error("metadata version not provided by the runtime")
+
+	metadataRuntimeAPI      = "Metadata"
+	metadataAtVersionMethod = "Metadata_metadata_at_version"
+	metadataVersionsMethod  = "Metadata_metadata_versions"
+
+	// metadataAtVersionAPIVersion is the version of the Metadata runtime API that added metadata_at_version and
+	// metadata_versions.
+	metadataAtVersionAPIVersion = 2
+)
+
+// GetMetadataAtVersion returns the metadata in the given version at the given block. It is retrieved using the
+// Metadata_metadata_at_version runtime API, runtimes without it fall back to state_getMetadata, which returns the
+// metadata in the version chosen by the runtime.
+func (s *state) GetMetadataAtVersion(version uint32, blockHash types.Hash) (*types.Metadata, error) {
+	return s.GetMetadataAtVersionContext(context.Background(), version, blockHash)
+}
+
+// GetMetadataAtVersionContext is like GetMetadataAtVersion but uses the provided context for the RPC call.
+func (s *state) GetMetadataAtVersionContext(
+	ctx context.Context,
+	version uint32,
+	blockHash types.Hash,
+) (*types.Metadata, error) {
+	return s.getMetadataAtVersion(ctx, version, &blockHash)
+}
+
+// GetMetadataAtVersionLatest returns the latest metadata in the given version
+func (s *state) GetMetadataAtVersionLatest(version uint32) (*types.Metadata, error) {
+	return s.GetMetadataAtVersionLatestContext(context.Background(), version)
+}
+
+// GetMetadataAtVersionLatestContext is like GetMetadataAtVersionLatest but uses the provided context for the RPC call.
+func (s *state) GetMetadataAtVersionLatestContext(ctx context.Context, version uint32) (*types.Metadata, error) {
+	return s.getMetadataAtVersion(ctx, version, nil)
+}
+
+// GetMetadataVersions returns the metadata versions that the runtime provides at the given block. For runtimes
+// without the Metadata_metadata_versions runtime API, it returns the version of the metadata returned by
+// state_getMetadata.
+func (s *state) GetMetadataVersions(blockHash types.Hash) ([]types.U32, error) {
+	return s.GetMetadataVersionsContext(context.Background(), blockHash)
+}
+
+// GetMetadataVersionsContext is like GetMetadataVersions but uses the provided context for the RPC call.
+func (s *state) GetMetadataVersionsContext(ctx context.Context, blockHash types.Hash) ([]types.U32, error) {
+	return s.getMetadataVersions(ctx, &blockHash)
+}
+
+// GetMetadataVersionsLatest returns the metadata versions that the runtime provides at the latest block
+func (s *state) GetMetadataVersionsLatest() ([]types.U32, error) {
+	return s.GetMetadataVersionsLatestContext(context.Background())
+}
+
+// GetMetadataVersionsLatestContext is like GetMetadataVersionsLatest but uses the provided context for the RPC call.
+func (s *state) GetMetadataVersionsLatestContext(ctx context.Context) ([]types.U32, error) {
+	return s.getMetadataVersions(ctx, nil)
+}
+
+// GetMetadataHighest returns the metadata at the given block in the highest version that is provided by the runtime
+// and can be decoded, see types.LatestMetadataVersion. Runtimes without the Metadata_metadata_versions runtime API
+// fall back to state_getMetadata.
+func (s *state) GetMetadataHighest(blockHash types.Hash) (*types.Metadata, error) {
+	return s.GetMetadataHighestContext(context.Background(), blockHash)
+}
+
+// GetMetadataHighestContext is like GetMetadataHighest but uses the provided context for the RPC call.
+func (s *state) GetMetadataHighestContext(ctx context.Context, blockHash types.Hash) (*types.Metadata, error) {
+	return s.getMetadataHighest(ctx, &blockHash)
+}
+
+// GetMetadataHighestLatest returns the latest metadata in the highest version that is provided by the runtime and
+// can be decoded
+func (s *state) GetMetadataHighestLatest() (*types.Metadata, error) {
+	return s.GetMetadataHighestLatestContext(context.Background())
+}
+
+// GetMetadataHighestLatestContext is like GetMetadataHighestLatest but uses the provided context for the RPC call.
+func (s *state) GetMetadataHighestLatestContext(ctx context.Context) (*types.Metadata, error) {
+	return s.getMetadataHighest(ctx, nil)
+}
+
+func (s *state) getMetadataAtVersion(
+	ctx context.Context,
+	version uint32,
+	blockHash *types.Hash,
+) (*types.Metadata, error) {
+	ok, err := s.hasMetadataAtVersion(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return s.getMetadata(ctx, blockHash)
+	}
+
+	return s.callMetadataAtVersion(ctx, version, blockHash)
+}
+
+func (s *state) getMetadataVersions(ctx context.Context, blockHash *types.Hash) ([]types.U32, error) {
+	ok, err := s.hasMetadataAtVersion(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		metadata, err := s.getMetadata(ctx, blockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		return []types.U32{types.U32(metadata.Version)}, nil
+	}
+
+	var versions []types.U32
+
+	err = s.runtimeCall(ctx, metadataVersionsMethod, blockHash, &versions, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (s *state) getMetadataHighest(ctx context.Context, blockHash *types.Hash) (*types.Metadata, error) {
+	ok, err := s.hasMetadataAtVersion(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return s.getMetadata(ctx, blockHash)
+	}
+
+	var versions []types.U32
+
+	err = s.runtimeCall(ctx, metadataVersionsMethod, blockHash, &versions, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var highestVersion uint32
+
+	for _, version := range versions {
+		// runtimes also list unstable versions, e.g. u32::MAX, which are skipped as they can't be decoded
+		if uint32(version) > highestVersion && uint32(version) <= types.LatestMetadataVersion {
+			highestVersion = uint32(version)
+		}
+	}
+
+	if highestVersion == 0 {
+		return s.getMetadata(ctx, blockHash)
+	}
+
+	return s.callMetadataAtVersion(ctx, highestVersion, blockHash)
+}
+
+// hasMetadataAtVersion returns whether the runtime provides the Metadata_metadata_at_version and
+// Metadata_metadata_versions runtime APIs.
+func (s *state) hasMetadataAtVersion(ctx context.Context, blockHash *types.Hash) (bool, error) {
+	runtimeVersion, err := s.getRuntimeVersion(ctx, blockHash)
+	if err != nil {
+		return false, err
+	}
+
+	apiVersion, ok := runtimeVersion.APIVersion(metadataRuntimeAPI)
+
+	return ok && apiVersion >= metadataAtVersionAPIVersion, nil
+}
+
+func (s *state) callMetadataAtVersion(
+	ctx context.Context,
+	version uint32,
+	blockHash *types.Hash,
+) (*types.Metadata, error) {
+	var opaqueMetadata types.Option[types.Bytes]
+
+	err := s.runtimeCall(ctx, metadataAtVersionMethod, blockHash, &opaqueMetadata, []interface{}{types.NewU32(version)})
+	if err != nil {
+		return nil, err
+	}
+
+	ok, data := opaqueMetadata.Unwrap()
+	if !ok {
+		return nil, ErrMetadataVersionNotSupported.WithMsg("version %d", version)
+	}
+
+	var metadata types.Metadata
+	err = codec.Decode(data, &metadata)
+	return &metadata, err
+}
@@ -0,0 +1,188 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMetadataBlockHash = types.Hash{1, 2, 3}
+
+func newReplayState(t *testing.T, fixtures ...rpcmocksrv.Fixture) State {
+	s := rpcmocksrv.NewReplayServer(fixtures...)
+	t.Cleanup(s.Close)
+
+	cl, err := client.Connect(s.URL)
+	require.NoError(t, err)
+	t.Cleanup(cl.Close)
+
+	return NewState(cl)
+}
+
+func mustMarshalJSON(t *testing.T, v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return b
+}
+
+// metadataAPIFixture returns a fixture for a runtime that provides the Metadata runtime API in the given version.
+func metadataAPIFixture(t *testing.T, version types.U32) rpcmocksrv.Fixture {
+	id, err := types.NewRuntimeAPIID(metadataRuntimeAPI)
+	require.NoError(t, err)
+
+	runtimeVersion := types.NewRuntimeVersion()
+	runtimeVersion.APIs = append(runtimeVersion.APIs, types.RuntimeVersionAPI{APIID: id, Version: version})
+
+	return rpcmocksrv.Fixture{Method: "state_getRuntimeVersion", Result: mustMarshalJSON(t, runtimeVersion)}
+}
+
+// runtimeCallFixture returns a fixture for a state_call of the method with the encoded arg, if any, at the test
+// block.
+func runtimeCallFixture(t *testing.T, method string, arg, result interface{}) rpcmocksrv.Fixture {
+	data := "0x"
+
+	if arg != nil {
+		var err error
+
+		data, err = codec.EncodeToHex(arg)
+		require.NoError(t, err)
+	}
+
+	res, err := codec.EncodeToHex(result)
+	require.NoError(t, err)
+
+	return rpcmocksrv.Fixture{
+		Method: "state_call",
+		Params: mustMarshalJSON(t, []string{method, data, testMetadataBlockHash.Hex()}),
+		Result: mustMarshalJSON(t, res),
+	}
+}
+
+func legacyMetadataFixture(t *testing.T) rpcmocksrv.Fixture {
+	return rpcmocksrv.Fixture{Method: "state_getMetadata", Result: mustMarshalJSON(t, types.MetadataV14Data)}
+}
+
+func opaqueMetadata(metadataHex string) types.Option[types.Bytes] {
+	return types.NewOption(types.Bytes(codec.MustHexDecodeString(metadataHex)))
+}
+
+func TestState_GetMetadataAtVersion(t *testing.T) {
+	st := newReplayState(
+		t,
+		metadataAPIFixture(t, 2),
+		runtimeCallFixture(t, metadataAtVersionMethod, types.NewU32(15), opaqueMetadata(test.MetadataV15Hex)),
+	)
+
+	md, err := st.GetMetadataAtVersion(15, testMetadataBlockHash)
+	require.NoError(t, err)
+	assert.EqualValues(t, 15, md.Version)
+
+	_, err = md.AsMetadataV15.FindRuntimeAPIMethod("AccountNonceApi", "account_nonce")
+	assert.NoError(t, err)
+	assert.True(t, md.ExistsModuleMetadata("System"))
+}
+
+func TestState_GetMetadataAtVersion_NotProvided(t *testing.T) {
+	st := newReplayState(
+		t,
+		metadataAPIFixture(t, 2),
+		runtimeCallFixture(t, metadataAtVersionMethod, types.NewU32(16), types.NewEmptyOption[types.Bytes]()),
+	)
+
+	md, err := st.GetMetadataAtVersion(16, testMetadataBlockHash)
+	assert.True(t, errors.Is(err, ErrMetadataVersionNotSupported))
+	assert.Nil(t, md)
+}
+
+func TestState_GetMetadataAtVersion_LegacyFallback(t *testing.T) {
+	st := newReplayState(t, metadataAPIFixture(t, 1), legacyMetadataFixture(t))
+
+	md, err := st.GetMetadataAtVersion(15, testMetadataBlockHash)
+	require.NoError(t, err)
+	assert.EqualValues(t, 14, md.Version)
+
+	_, err = st.GetMetadataV15(testMetadataBlockHash)
+	assert.True(t, errors.Is(err, ErrMetadataVersionNotSupported))
+}
+
+func TestState_GetMetadataVersions(t *testing.T) {
+	versions := []types.U32{14, 15, math.MaxUint32}
+
+	st := newReplayState(
+		t,
+		metadataAPIFixture(t, 2),
+		runtimeCallFixture(t, metadataVersionsMethod, nil, versions),
+	)
+
+	res, err := st.GetMetadataVersions(testMetadataBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, versions, res)
+}
+
+func TestState_GetMetadataVersions_LegacyFallback(t *testing.T) {
+	st := newReplayState(t, metadataAPIFixture(t, 1), legacyMetadataFixture(t))
+
+	res, err := st.GetMetadataVersions(testMetadataBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, []types.U32{14}, res)
+}
+
+func TestState_GetMetadataHighest(t *testing.T) {
+	st := newReplayState(
+		t,
+		metadataAPIFixture(t, 2),
+		runtimeCallFixture(t, metadataVersionsMethod, nil, []types.U32{14, 15, math.MaxUint32}),
+		runtimeCallFixture(t, metadataAtVersionMethod, types.NewU32(15), opaqueMetadata(test.MetadataV15Hex)),
+	)
+
+	md, err := st.GetMetadataHighest(testMetadataBlockHash)
+	require.NoError(t, err)
+	assert.EqualValues(t, 15, md.Version)
+}
+
+func TestState_GetMetadataHighest_V14(t *testing.T) {
+	st := newReplayState(
+		t,
+		metadataAPIFixture(t, 2),
+		runtimeCallFixture(t, metadataVersionsMethod, nil, []types.U32{14}),
+		runtimeCallFixture(t, metadataAtVersionMethod, types.NewU32(14), opaqueMetadata(types.MetadataV14Data)),
+	)
+
+	md, err := st.GetMetadataHighest(testMetadataBlockHash)
+	require.NoError(t, err)
+	assert.EqualValues(t, 14, md.Version)
+}
+
+func TestState_GetMetadataHighest_LegacyFallback(t *testing.T) {
+	st := newReplayState(t, metadataAPIFixture(t, 1), legacyMetadataFixture(t))
+
+	md, err := st.GetMetadataHighest(testMetadataBlockHash)
+	require.NoError(t, err)
+	assert.EqualValues(t, 14, md.Version)
+}
@@ -0,0 +1,59 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const metadataV15Version = 15
+
+// GetMetadataV15 returns the metadata V15 at the given block, it is retrieved using the Metadata_metadata_at_version
+// runtime API since state_getMetadata only returns V14.
+func (s *state) GetMetadataV15(blockHash types.Hash) (*types.MetadataV15, error) {
+	return s.GetMetadataV15Context(context.Background(), blockHash)
+}
+
+// GetMetadataV15Context is like GetMetadataV15 but uses the provided context for the RPC call.
+func (s *state) GetMetadataV15Context(ctx context.Context, blockHash types.Hash) (*types.MetadataV15, error) {
+	return s.getMetadataV15(ctx, &blockHash)
+}
+
+// GetMetadataV15Latest returns the latest metadata V15
+func (s *state) GetMetadataV15Latest() (*types.MetadataV15, error) {
+	return s.GetMetadataV15LatestContext(context.Background())
+}
+
+// GetMetadataV15LatestContext is like GetMetadataV15Latest but uses the provided context for the RPC call.
+func (s *state) GetMetadataV15LatestContext(ctx context.Context) (*types.MetadataV15, error) {
+	return s.getMetadataV15(ctx, nil)
+}
+
+func (s *state) getMetadataV15(ctx context.Context, blockHash *types.Hash) (*types.MetadataV15, error) {
+	metadata, err := s.getMetadataAtVersion(ctx, metadataV15Version, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.Version != metadataV15Version {
+		return nil, ErrMetadataVersionNotSupported.WithMsg("version %d", metadataV15Version)
+	}
+
+	return &metadata.AsMetadataV15, nil
+}
@@ -0,0 +1,44 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_GetMetadataV15Latest(t *testing.T) {
+	var expected types.MetadataV15
+	err := types.DecodeMetadataV15(codec.MustHexDecodeString(test.MetadataV15Hex), &expected)
+	assert.NoError(t, err)
+
+	md, err := testState.GetMetadataV15Latest()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, *md)
+}
+
+func TestState_GetMetadataV15(t *testing.T) {
+	md, err := testState.GetMetadataV15(mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+
+	_, err = md.FindRuntimeAPIMethod("AccountNonceApi", "account_nonce")
+	assert.NoError(t, err)
+}
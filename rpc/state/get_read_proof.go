@@ -0,0 +1,68 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// GetReadProof returns the storage proof for the given keys at the given block. The proof can be verified against the
+// state root of the block header using the trie package, without needing a connection to a node.
+func (s *state) GetReadProof(keys []types.StorageKey, blockHash types.Hash) (types.ReadProof, error) {
+	return s.GetReadProofContext(context.Background(), keys, blockHash)
+}
+
+// GetReadProofContext is like GetReadProof but uses the provided context for the RPC call.
+func (s *state) GetReadProofContext(
+	ctx context.Context,
+	keys []types.StorageKey,
+	blockHash types.Hash,
+) (types.ReadProof, error) {
+	return s.getReadProof(ctx, keys, &blockHash)
+}
+
+// GetReadProofLatest returns the storage proof for the given keys at the latest block
+func (s *state) GetReadProofLatest(keys []types.StorageKey) (types.ReadProof, error) {
+	return s.GetReadProofLatestContext(context.Background(), keys)
+}
+
+// GetReadProofLatestContext is like GetReadProofLatest but uses the provided context for the RPC call.
+func (s *state) GetReadProofLatestContext(ctx context.Context, keys []types.StorageKey) (types.ReadProof, error) {
+	return s.getReadProof(ctx, keys, nil)
+}
+
+func (s *state) getReadProof(
+	ctx context.Context,
+	keys []types.StorageKey,
+	blockHash *types.Hash,
+) (types.ReadProof, error) {
+	hexKeys := make([]string, len(keys))
+	for i, key := range keys {
+		hexKeys[i] = key.Hex()
+	}
+
+	var res types.ReadProof
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getReadProof", blockHash, hexKeys)
+	if err != nil {
+		return types.ReadProof{}, err
+	}
+
+	return res, nil
+}
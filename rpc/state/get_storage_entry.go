@@ -0,0 +1,80 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	ErrStorageKeyCreation = libErr.Error("storage key creation")
+	ErrStorageRetrieval   = libErr.Error("storage retrieval")
+)
+
+// GetStorageEntryLatest reads the latest value of the storage entry of the pallet with the given encoded keys into
+// the target. False is returned if there is no value stored.
+func GetStorageEntryLatest(
+	ctx context.Context,
+	s State,
+	meta *types.Metadata,
+	pallet string,
+	entry string,
+	target interface{},
+	args ...[]byte,
+) (bool, error) {
+	key, err := types.CreateStorageKey(meta, pallet, entry, args...)
+	if err != nil {
+		return false, ErrStorageKeyCreation.WithMsg("%s.%s", pallet, entry).Wrap(err)
+	}
+
+	ok, err := s.GetStorageLatestContext(ctx, key, target)
+	if err != nil {
+		return false, ErrStorageRetrieval.WithMsg("%s.%s", pallet, entry).Wrap(err)
+	}
+
+	return ok, nil
+}
+
+// GetStorageEntryRawLatest is like GetStorageEntryLatest but returns the raw value, or nil if there is no value
+// stored.
+func GetStorageEntryRawLatest(
+	ctx context.Context,
+	s State,
+	meta *types.Metadata,
+	pallet string,
+	entry string,
+	args ...[]byte,
+) ([]byte, error) {
+	key, err := types.CreateStorageKey(meta, pallet, entry, args...)
+	if err != nil {
+		return nil, ErrStorageKeyCreation.WithMsg("%s.%s", pallet, entry).Wrap(err)
+	}
+
+	raw, err := s.GetStorageRawLatestContext(ctx, key)
+	if err != nil {
+		return nil, ErrStorageRetrieval.WithMsg("%s.%s", pallet, entry).Wrap(err)
+	}
+
+	if raw == nil || len(*raw) == 0 {
+		return nil, nil
+	}
+
+	return *raw, nil
+}
@@ -0,0 +1,74 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStorageEntryLatest(t *testing.T) {
+	ctx := context.Background()
+
+	var meta types.Metadata
+	require.NoError(t, codec.DecodeFromHex(test.PolkadotMetadataHex, &meta))
+
+	key, err := types.CreateStorageKey(&meta, "System", "Number")
+	require.NoError(t, err)
+
+	emptyKey, err := types.CreateStorageKey(&meta, "Timestamp", "Now")
+	require.NoError(t, err)
+
+	s := NewState(rpcmocksrv.NewMockClient().
+		Respond("state_getStorage", "0x0a000000", key.Hex()).
+		Respond("state_getStorage", nil, emptyKey.Hex()))
+
+	var number types.U32
+
+	ok, err := GetStorageEntryLatest(ctx, s, &meta, "System", "Number", &number)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.U32(10), number)
+
+	raw, err := GetStorageEntryRawLatest(ctx, s, &meta, "System", "Number")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{10, 0, 0, 0}, raw)
+
+	var now types.U64
+
+	ok, err = GetStorageEntryLatest(ctx, s, &meta, "Timestamp", "Now", &now)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	raw, err = GetStorageEntryRawLatest(ctx, s, &meta, "Timestamp", "Now")
+	require.NoError(t, err)
+	assert.Nil(t, raw)
+
+	_, err = GetStorageEntryLatest(ctx, s, &meta, "System", "Unknown", &number)
+	assert.ErrorIs(t, err, ErrStorageKeyCreation)
+
+	_, err = GetStorageEntryRawLatest(ctx, s, &meta, "System", "ParentHash")
+	assert.ErrorIs(t, err, ErrStorageRetrieval)
+	assert.ErrorContains(t, err, "System.ParentHash")
+}
@@ -0,0 +1,94 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// GetStorageRawBatch retreives the stored data of multiple keys as raw bytes, using a single batch request.
+// The result of each key is reported separately, in the order of the keys.
+func (s *state) GetStorageRawBatch(
+	keys []types.StorageKey,
+	blockHash types.Hash,
+) ([]client.BatchResult[*types.StorageDataRaw], error) {
+	return s.GetStorageRawBatchContext(context.Background(), keys, blockHash)
+}
+
+// GetStorageRawBatchContext is like GetStorageRawBatch but uses the provided context for the RPC call.
+func (s *state) GetStorageRawBatchContext(
+	ctx context.Context,
+	keys []types.StorageKey,
+	blockHash types.Hash,
+) ([]client.BatchResult[*types.StorageDataRaw], error) {
+	return s.getStorageRawBatch(ctx, keys, &blockHash)
+}
+
+// GetStorageRawBatchLatest retreives the stored data of multiple keys for the latest block height as raw bytes,
+// using a single batch request. The result of each key is reported separately, in the order of the keys.
+func (s *state) GetStorageRawBatchLatest(keys []types.StorageKey) ([]client.BatchResult[*types.StorageDataRaw], error) {
+	return s.GetStorageRawBatchLatestContext(context.Background(), keys)
+}
+
+// GetStorageRawBatchLatestContext is like GetStorageRawBatchLatest but uses the provided context for the RPC call.
+func (s *state) GetStorageRawBatchLatestContext(
+	ctx context.Context,
+	keys []types.StorageKey,
+) ([]client.BatchResult[*types.StorageDataRaw], error) {
+	return s.getStorageRawBatch(ctx, keys, nil)
+}
+
+func (s *state) getStorageRawBatch(
+	ctx context.Context,
+	keys []types.StorageKey,
+	blockHash *types.Hash,
+) ([]client.BatchResult[*types.StorageDataRaw], error) {
+	batch := client.NewBatch(s.client)
+	hexData := make([]string, len(keys))
+
+	for i, key := range keys {
+		batch.AddWithBlockHash(&hexData[i], "state_getStorage", blockHash, key.Hex())
+	}
+
+	if err := batch.Send(ctx); err != nil {
+		return nil, err
+	}
+
+	res := make([]client.BatchResult[*types.StorageDataRaw], len(keys))
+
+	for i, hexValue := range hexData {
+		if err := batch.Err(i); err != nil {
+			res[i].Err = err
+			continue
+		}
+
+		bz, err := codec.HexDecodeString(hexValue)
+		if err != nil {
+			res[i].Err = err
+			continue
+		}
+
+		data := types.NewStorageDataRaw(bz)
+		res[i].Value = &data
+	}
+
+	return res, nil
+}
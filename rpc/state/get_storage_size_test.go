@@ -37,3 +37,19 @@ func TestState_GetStorageSize(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, mockSrv.storageSize, size)
 }
+
+// unknownBlockHash is a block the mock server fails for, like a node that already pruned its state
+var (
+	unknownBlockHash    = types.Hash{4, 5, 6}
+	unknownBlockHashHex = unknownBlockHash.Hex()
+)
+
+func TestState_GetStorageSize_UnknownBlock(t *testing.T) {
+	key := types.NewStorageKey(codec.MustHexDecodeString("0x3a636f6465"))
+	_, err := testState.GetStorageSize(key, unknownBlockHash)
+	assert.True(t, types.IsBlockUnknown(err))
+
+	rpcErr, ok := types.AsRPCError(err)
+	assert.True(t, ok)
+	assert.Equal(t, types.RPCErrorCodeStateClient, rpcErr.Code)
+}
@@ -17,6 +17,7 @@
 package state
 
 import (
+	"context"
 	"testing"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -58,3 +59,19 @@ func TestState_GetStorageRaw(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, mockSrv.storageDataHex, data.Hex())
 }
+
+func TestState_GetStorageRawContext(t *testing.T) {
+	data, err := testState.GetStorageRawContext(
+		context.Background(),
+		codec.MustHexDecodeString(mockSrv.storageKeyHex),
+		mockSrv.blockHashLatest,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, mockSrv.storageDataHex, data.Hex())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = testState.GetStorageRawContext(ctx, codec.MustHexDecodeString(mockSrv.storageKeyHex), mockSrv.blockHashLatest)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -3,6 +3,11 @@
 package mocks
 
 import (
+	context "context"
+
+	io "io"
+
+	client "github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	state "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	mock "github.com/stretchr/testify/mock"
@@ -13,6 +18,121 @@ type State struct {
 	mock.Mock
 }
 
+// Call provides a mock function with given fields: method, data, blockHash
+func (_m *State) Call(method string, data []byte, blockHash types.Hash) (types.Bytes, error) {
+	ret := _m.Called(method, data, blockHash)
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func(string, []byte, types.Hash) types.Bytes); ok {
+		r0 = rf(method, data, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte, types.Hash) error); ok {
+		r1 = rf(method, data, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CallContext provides a mock function with given fields: ctx, method, data, blockHash
+func (_m *State) CallContext(ctx context.Context, method string, data []byte, blockHash types.Hash) (types.Bytes, error) {
+	ret := _m.Called(ctx, method, data, blockHash)
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, types.Hash) types.Bytes); ok {
+		r0 = rf(ctx, method, data, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte, types.Hash) error); ok {
+		r1 = rf(ctx, method, data, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CallLatest provides a mock function with given fields: method, data
+func (_m *State) CallLatest(method string, data []byte) (types.Bytes, error) {
+	ret := _m.Called(method, data)
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func(string, []byte) types.Bytes); ok {
+		r0 = rf(method, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
+		r1 = rf(method, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CallLatestContext provides a mock function with given fields: ctx, method, data
+func (_m *State) CallLatestContext(ctx context.Context, method string, data []byte) (types.Bytes, error) {
+	ret := _m.Called(ctx, method, data)
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) types.Bytes); ok {
+		r0 = rf(ctx, method, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte) error); ok {
+		r1 = rf(ctx, method, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForEachKey provides a mock function with given fields: ctx, prefix, blockHash, opts, fn
+func (_m *State) ForEachKey(ctx context.Context, prefix types.StorageKey, blockHash types.Hash, opts state.ForEachKeyOptions, fn func(types.StorageKey, *types.StorageDataRaw) error) (types.StorageKey, error) {
+	ret := _m.Called(ctx, prefix, blockHash, opts, fn)
+
+	var r0 types.StorageKey
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.Hash, state.ForEachKeyOptions, func(types.StorageKey, *types.StorageDataRaw) error) types.StorageKey); ok {
+		r0 = rf(ctx, prefix, blockHash, opts, fn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.Hash, state.ForEachKeyOptions, func(types.StorageKey, *types.StorageDataRaw) error) error); ok {
+		r1 = rf(ctx, prefix, blockHash, opts, fn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildKeys provides a mock function with given fields: childStorageKey, prefix, blockHash
 func (_m *State) GetChildKeys(childStorageKey types.StorageKey, prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
 	ret := _m.Called(childStorageKey, prefix, blockHash)
@@ -36,6 +156,29 @@ func (_m *State) GetChildKeys(childStorageKey types.StorageKey, prefix types.Sto
 	return r0, r1
 }
 
+// GetChildKeysContext provides a mock function with given fields: ctx, childStorageKey, prefix, blockHash
+func (_m *State) GetChildKeysContext(ctx context.Context, childStorageKey types.StorageKey, prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
+	ret := _m.Called(ctx, childStorageKey, prefix, blockHash)
+
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) []types.StorageKey); ok {
+		r0 = rf(ctx, childStorageKey, prefix, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, childStorageKey, prefix, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildKeysLatest provides a mock function with given fields: childStorageKey, prefix
 func (_m *State) GetChildKeysLatest(childStorageKey types.StorageKey, prefix types.StorageKey) ([]types.StorageKey, error) {
 	ret := _m.Called(childStorageKey, prefix)
@@ -59,6 +202,29 @@ func (_m *State) GetChildKeysLatest(childStorageKey types.StorageKey, prefix typ
 	return r0, r1
 }
 
+// GetChildKeysLatestContext provides a mock function with given fields: ctx, childStorageKey, prefix
+func (_m *State) GetChildKeysLatestContext(ctx context.Context, childStorageKey types.StorageKey, prefix types.StorageKey) ([]types.StorageKey, error) {
+	ret := _m.Called(ctx, childStorageKey, prefix)
+
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey) []types.StorageKey); ok {
+		r0 = rf(ctx, childStorageKey, prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey) error); ok {
+		r1 = rf(ctx, childStorageKey, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorage provides a mock function with given fields: childStorageKey, key, target, blockHash
 func (_m *State) GetChildStorage(childStorageKey types.StorageKey, key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
 	ret := _m.Called(childStorageKey, key, target, blockHash)
@@ -80,6 +246,27 @@ func (_m *State) GetChildStorage(childStorageKey types.StorageKey, key types.Sto
 	return r0, r1
 }
 
+// GetChildStorageContext provides a mock function with given fields: ctx, childStorageKey, key, target, blockHash
+func (_m *State) GetChildStorageContext(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
+	ret := _m.Called(ctx, childStorageKey, key, target, blockHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey, interface{}, types.Hash) bool); ok {
+		r0 = rf(ctx, childStorageKey, key, target, blockHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey, interface{}, types.Hash) error); ok {
+		r1 = rf(ctx, childStorageKey, key, target, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageHash provides a mock function with given fields: childStorageKey, key, blockHash
 func (_m *State) GetChildStorageHash(childStorageKey types.StorageKey, key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
 	ret := _m.Called(childStorageKey, key, blockHash)
@@ -103,6 +290,27 @@ func (_m *State) GetChildStorageHash(childStorageKey types.StorageKey, key types
 	return r0, r1
 }
 
+// GetChildStorageHashContext provides a mock function with given fields: ctx, childStorageKey, key, blockHash
+func (_m *State) GetChildStorageHashContext(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
+	ret := _m.Called(ctx, childStorageKey, key, blockHash)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) types.Hash); ok {
+		r0 = rf(ctx, childStorageKey, key, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.Hash)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, childStorageKey, key, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageHashLatest provides a mock function with given fields: childStorageKey, key
 func (_m *State) GetChildStorageHashLatest(childStorageKey types.StorageKey, key types.StorageKey) (types.Hash, error) {
 	ret := _m.Called(childStorageKey, key)
@@ -126,6 +334,27 @@ func (_m *State) GetChildStorageHashLatest(childStorageKey types.StorageKey, key
 	return r0, r1
 }
 
+// GetChildStorageHashLatestContext provides a mock function with given fields: ctx, childStorageKey, key
+func (_m *State) GetChildStorageHashLatestContext(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey) (types.Hash, error) {
+	ret := _m.Called(ctx, childStorageKey, key)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey) types.Hash); ok {
+		r0 = rf(ctx, childStorageKey, key)
+	} else {
+		r0 = ret.Get(0).(types.Hash)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey) error); ok {
+		r1 = rf(ctx, childStorageKey, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageLatest provides a mock function with given fields: childStorageKey, key, target
 func (_m *State) GetChildStorageLatest(childStorageKey types.StorageKey, key types.StorageKey, target interface{}) (bool, error) {
 	ret := _m.Called(childStorageKey, key, target)
@@ -147,6 +376,27 @@ func (_m *State) GetChildStorageLatest(childStorageKey types.StorageKey, key typ
 	return r0, r1
 }
 
+// GetChildStorageLatestContext provides a mock function with given fields: ctx, childStorageKey, key, target
+func (_m *State) GetChildStorageLatestContext(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey, target interface{}) (bool, error) {
+	ret := _m.Called(ctx, childStorageKey, key, target)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey, interface{}) bool); ok {
+		r0 = rf(ctx, childStorageKey, key, target)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey, interface{}) error); ok {
+		r1 = rf(ctx, childStorageKey, key, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageRaw provides a mock function with given fields: childStorageKey, key, blockHash
 func (_m *State) GetChildStorageRaw(childStorageKey types.StorageKey, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
 	ret := _m.Called(childStorageKey, key, blockHash)
@@ -170,6 +420,29 @@ func (_m *State) GetChildStorageRaw(childStorageKey types.StorageKey, key types.
 	return r0, r1
 }
 
+// GetChildStorageRawContext provides a mock function with given fields: ctx, childStorageKey, key, blockHash
+func (_m *State) GetChildStorageRawContext(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, childStorageKey, key, blockHash)
+
+	var r0 *types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) *types.StorageDataRaw); ok {
+		r0 = rf(ctx, childStorageKey, key, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.StorageDataRaw)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, childStorageKey, key, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageRawLatest provides a mock function with given fields: childStorageKey, key
 func (_m *State) GetChildStorageRawLatest(childStorageKey types.StorageKey, key types.StorageKey) (*types.StorageDataRaw, error) {
 	ret := _m.Called(childStorageKey, key)
@@ -193,6 +466,29 @@ func (_m *State) GetChildStorageRawLatest(childStorageKey types.StorageKey, key
 	return r0, r1
 }
 
+// GetChildStorageRawLatestContext provides a mock function with given fields: ctx, childStorageKey, key
+func (_m *State) GetChildStorageRawLatestContext(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey) (*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, childStorageKey, key)
+
+	var r0 *types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey) *types.StorageDataRaw); ok {
+		r0 = rf(ctx, childStorageKey, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.StorageDataRaw)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey) error); ok {
+		r1 = rf(ctx, childStorageKey, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageSize provides a mock function with given fields: childStorageKey, key, blockHash
 func (_m *State) GetChildStorageSize(childStorageKey types.StorageKey, key types.StorageKey, blockHash types.Hash) (types.U64, error) {
 	ret := _m.Called(childStorageKey, key, blockHash)
@@ -214,6 +510,27 @@ func (_m *State) GetChildStorageSize(childStorageKey types.StorageKey, key types
 	return r0, r1
 }
 
+// GetChildStorageSizeContext provides a mock function with given fields: ctx, childStorageKey, key, blockHash
+func (_m *State) GetChildStorageSizeContext(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey, blockHash types.Hash) (types.U64, error) {
+	ret := _m.Called(ctx, childStorageKey, key, blockHash)
+
+	var r0 types.U64
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) types.U64); ok {
+		r0 = rf(ctx, childStorageKey, key, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.U64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, childStorageKey, key, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageSizeLatest provides a mock function with given fields: childStorageKey, key
 func (_m *State) GetChildStorageSizeLatest(childStorageKey types.StorageKey, key types.StorageKey) (types.U64, error) {
 	ret := _m.Called(childStorageKey, key)
@@ -235,6 +552,27 @@ func (_m *State) GetChildStorageSizeLatest(childStorageKey types.StorageKey, key
 	return r0, r1
 }
 
+// GetChildStorageSizeLatestContext provides a mock function with given fields: ctx, childStorageKey, key
+func (_m *State) GetChildStorageSizeLatestContext(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey) (types.U64, error) {
+	ret := _m.Called(ctx, childStorageKey, key)
+
+	var r0 types.U64
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey) types.U64); ok {
+		r0 = rf(ctx, childStorageKey, key)
+	} else {
+		r0 = ret.Get(0).(types.U64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey) error); ok {
+		r1 = rf(ctx, childStorageKey, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetKeys provides a mock function with given fields: prefix, blockHash
 func (_m *State) GetKeys(prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
 	ret := _m.Called(prefix, blockHash)
@@ -258,6 +596,29 @@ func (_m *State) GetKeys(prefix types.StorageKey, blockHash types.Hash) ([]types
 	return r0, r1
 }
 
+// GetKeysContext provides a mock function with given fields: ctx, prefix, blockHash
+func (_m *State) GetKeysContext(ctx context.Context, prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
+	ret := _m.Called(ctx, prefix, blockHash)
+
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.Hash) []types.StorageKey); ok {
+		r0 = rf(ctx, prefix, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, prefix, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetKeysLatest provides a mock function with given fields: prefix
 func (_m *State) GetKeysLatest(prefix types.StorageKey) ([]types.StorageKey, error) {
 	ret := _m.Called(prefix)
@@ -281,22 +642,22 @@ func (_m *State) GetKeysLatest(prefix types.StorageKey) ([]types.StorageKey, err
 	return r0, r1
 }
 
-// GetMetadata provides a mock function with given fields: blockHash
-func (_m *State) GetMetadata(blockHash types.Hash) (*types.Metadata, error) {
-	ret := _m.Called(blockHash)
+// GetKeysLatestContext provides a mock function with given fields: ctx, prefix
+func (_m *State) GetKeysLatestContext(ctx context.Context, prefix types.StorageKey) ([]types.StorageKey, error) {
+	ret := _m.Called(ctx, prefix)
 
-	var r0 *types.Metadata
-	if rf, ok := ret.Get(0).(func(types.Hash) *types.Metadata); ok {
-		r0 = rf(blockHash)
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey) []types.StorageKey); ok {
+		r0 = rf(ctx, prefix)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.Metadata)
+			r0 = ret.Get(0).([]types.StorageKey)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
-		r1 = rf(blockHash)
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey) error); ok {
+		r1 = rf(ctx, prefix)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -304,22 +665,635 @@ func (_m *State) GetMetadata(blockHash types.Hash) (*types.Metadata, error) {
 	return r0, r1
 }
 
-// GetMetadataLatest provides a mock function with given fields:
-func (_m *State) GetMetadataLatest() (*types.Metadata, error) {
-	ret := _m.Called()
+// GetKeysPaged provides a mock function with given fields: prefix, count, startKey, blockHash
+func (_m *State) GetKeysPaged(prefix types.StorageKey, count uint32, startKey types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
+	ret := _m.Called(prefix, count, startKey, blockHash)
 
-	var r0 *types.Metadata
-	if rf, ok := ret.Get(0).(func() *types.Metadata); ok {
-		r0 = rf()
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(types.StorageKey, uint32, types.StorageKey, types.Hash) []types.StorageKey); ok {
+		r0 = rf(prefix, count, startKey, blockHash)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.Metadata)
+			r0 = ret.Get(0).([]types.StorageKey)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(types.StorageKey, uint32, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(prefix, count, startKey, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeysPagedContext provides a mock function with given fields: ctx, prefix, count, startKey, blockHash
+func (_m *State) GetKeysPagedContext(ctx context.Context, prefix types.StorageKey, count uint32, startKey types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
+	ret := _m.Called(ctx, prefix, count, startKey, blockHash)
+
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, uint32, types.StorageKey, types.Hash) []types.StorageKey); ok {
+		r0 = rf(ctx, prefix, count, startKey, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, uint32, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, prefix, count, startKey, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeysPagedLatest provides a mock function with given fields: prefix, count, startKey
+func (_m *State) GetKeysPagedLatest(prefix types.StorageKey, count uint32, startKey types.StorageKey) ([]types.StorageKey, error) {
+	ret := _m.Called(prefix, count, startKey)
+
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(types.StorageKey, uint32, types.StorageKey) []types.StorageKey); ok {
+		r0 = rf(prefix, count, startKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.StorageKey, uint32, types.StorageKey) error); ok {
+		r1 = rf(prefix, count, startKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeysPagedLatestContext provides a mock function with given fields: ctx, prefix, count, startKey
+func (_m *State) GetKeysPagedLatestContext(ctx context.Context, prefix types.StorageKey, count uint32, startKey types.StorageKey) ([]types.StorageKey, error) {
+	ret := _m.Called(ctx, prefix, count, startKey)
+
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, uint32, types.StorageKey) []types.StorageKey); ok {
+		r0 = rf(ctx, prefix, count, startKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, uint32, types.StorageKey) error); ok {
+		r1 = rf(ctx, prefix, count, startKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadata provides a mock function with given fields: blockHash
+func (_m *State) GetMetadata(blockHash types.Hash) (*types.Metadata, error) {
+	ret := _m.Called(blockHash)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(types.Hash) *types.Metadata); ok {
+		r0 = rf(blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
+		r1 = rf(blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataAtVersion provides a mock function with given fields: version, blockHash
+func (_m *State) GetMetadataAtVersion(version uint32, blockHash types.Hash) (*types.Metadata, error) {
+	ret := _m.Called(version, blockHash)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(uint32, types.Hash) *types.Metadata); ok {
+		r0 = rf(version, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint32, types.Hash) error); ok {
+		r1 = rf(version, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataAtVersionContext provides a mock function with given fields: ctx, version, blockHash
+func (_m *State) GetMetadataAtVersionContext(ctx context.Context, version uint32, blockHash types.Hash) (*types.Metadata, error) {
+	ret := _m.Called(ctx, version, blockHash)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, types.Hash) *types.Metadata); ok {
+		r0 = rf(ctx, version, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint32, types.Hash) error); ok {
+		r1 = rf(ctx, version, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataAtVersionLatest provides a mock function with given fields: version
+func (_m *State) GetMetadataAtVersionLatest(version uint32) (*types.Metadata, error) {
+	ret := _m.Called(version)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(uint32) *types.Metadata); ok {
+		r0 = rf(version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint32) error); ok {
+		r1 = rf(version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataAtVersionLatestContext provides a mock function with given fields: ctx, version
+func (_m *State) GetMetadataAtVersionLatestContext(ctx context.Context, version uint32) (*types.Metadata, error) {
+	ret := _m.Called(ctx, version)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(context.Context, uint32) *types.Metadata); ok {
+		r0 = rf(ctx, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
+		r1 = rf(ctx, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataContext provides a mock function with given fields: ctx, blockHash
+func (_m *State) GetMetadataContext(ctx context.Context, blockHash types.Hash) (*types.Metadata, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) *types.Metadata); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataHighest provides a mock function with given fields: blockHash
+func (_m *State) GetMetadataHighest(blockHash types.Hash) (*types.Metadata, error) {
+	ret := _m.Called(blockHash)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(types.Hash) *types.Metadata); ok {
+		r0 = rf(blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
+		r1 = rf(blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataHighestContext provides a mock function with given fields: ctx, blockHash
+func (_m *State) GetMetadataHighestContext(ctx context.Context, blockHash types.Hash) (*types.Metadata, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) *types.Metadata); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataHighestLatest provides a mock function with given fields:
+func (_m *State) GetMetadataHighestLatest() (*types.Metadata, error) {
+	ret := _m.Called()
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func() *types.Metadata); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataHighestLatestContext provides a mock function with given fields: ctx
+func (_m *State) GetMetadataHighestLatestContext(ctx context.Context) (*types.Metadata, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(context.Context) *types.Metadata); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataLatest provides a mock function with given fields:
+func (_m *State) GetMetadataLatest() (*types.Metadata, error) {
+	ret := _m.Called()
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func() *types.Metadata); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataLatestContext provides a mock function with given fields: ctx
+func (_m *State) GetMetadataLatestContext(ctx context.Context) (*types.Metadata, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(context.Context) *types.Metadata); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataV15 provides a mock function with given fields: blockHash
+func (_m *State) GetMetadataV15(blockHash types.Hash) (*types.MetadataV15, error) {
+	ret := _m.Called(blockHash)
+
+	var r0 *types.MetadataV15
+	if rf, ok := ret.Get(0).(func(types.Hash) *types.MetadataV15); ok {
+		r0 = rf(blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.MetadataV15)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
+		r1 = rf(blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataV15Context provides a mock function with given fields: ctx, blockHash
+func (_m *State) GetMetadataV15Context(ctx context.Context, blockHash types.Hash) (*types.MetadataV15, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 *types.MetadataV15
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) *types.MetadataV15); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.MetadataV15)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataV15Latest provides a mock function with given fields:
+func (_m *State) GetMetadataV15Latest() (*types.MetadataV15, error) {
+	ret := _m.Called()
+
+	var r0 *types.MetadataV15
+	if rf, ok := ret.Get(0).(func() *types.MetadataV15); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.MetadataV15)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataV15LatestContext provides a mock function with given fields: ctx
+func (_m *State) GetMetadataV15LatestContext(ctx context.Context) (*types.MetadataV15, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *types.MetadataV15
+	if rf, ok := ret.Get(0).(func(context.Context) *types.MetadataV15); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.MetadataV15)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataVersions provides a mock function with given fields: blockHash
+func (_m *State) GetMetadataVersions(blockHash types.Hash) ([]types.U32, error) {
+	ret := _m.Called(blockHash)
+
+	var r0 []types.U32
+	if rf, ok := ret.Get(0).(func(types.Hash) []types.U32); ok {
+		r0 = rf(blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.U32)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
+		r1 = rf(blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataVersionsContext provides a mock function with given fields: ctx, blockHash
+func (_m *State) GetMetadataVersionsContext(ctx context.Context, blockHash types.Hash) ([]types.U32, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 []types.U32
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) []types.U32); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.U32)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataVersionsLatest provides a mock function with given fields:
+func (_m *State) GetMetadataVersionsLatest() ([]types.U32, error) {
+	ret := _m.Called()
+
+	var r0 []types.U32
+	if rf, ok := ret.Get(0).(func() []types.U32); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.U32)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataVersionsLatestContext provides a mock function with given fields: ctx
+func (_m *State) GetMetadataVersionsLatestContext(ctx context.Context) ([]types.U32, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []types.U32
+	if rf, ok := ret.Get(0).(func(context.Context) []types.U32); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.U32)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadProof provides a mock function with given fields: keys, blockHash
+func (_m *State) GetReadProof(keys []types.StorageKey, blockHash types.Hash) (types.ReadProof, error) {
+	ret := _m.Called(keys, blockHash)
+
+	var r0 types.ReadProof
+	if rf, ok := ret.Get(0).(func([]types.StorageKey, types.Hash) types.ReadProof); ok {
+		r0 = rf(keys, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.ReadProof)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]types.StorageKey, types.Hash) error); ok {
+		r1 = rf(keys, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadProofContext provides a mock function with given fields: ctx, keys, blockHash
+func (_m *State) GetReadProofContext(ctx context.Context, keys []types.StorageKey, blockHash types.Hash) (types.ReadProof, error) {
+	ret := _m.Called(ctx, keys, blockHash)
+
+	var r0 types.ReadProof
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash) types.ReadProof); ok {
+		r0 = rf(ctx, keys, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.ReadProof)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, keys, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadProofLatest provides a mock function with given fields: keys
+func (_m *State) GetReadProofLatest(keys []types.StorageKey) (types.ReadProof, error) {
+	ret := _m.Called(keys)
+
+	var r0 types.ReadProof
+	if rf, ok := ret.Get(0).(func([]types.StorageKey) types.ReadProof); ok {
+		r0 = rf(keys)
+	} else {
+		r0 = ret.Get(0).(types.ReadProof)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]types.StorageKey) error); ok {
+		r1 = rf(keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadProofLatestContext provides a mock function with given fields: ctx, keys
+func (_m *State) GetReadProofLatestContext(ctx context.Context, keys []types.StorageKey) (types.ReadProof, error) {
+	ret := _m.Called(ctx, keys)
+
+	var r0 types.ReadProof
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey) types.ReadProof); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		r0 = ret.Get(0).(types.ReadProof)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey) error); ok {
+		r1 = rf(ctx, keys)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -350,6 +1324,29 @@ func (_m *State) GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion,
 	return r0, r1
 }
 
+// GetRuntimeVersionContext provides a mock function with given fields: ctx, blockHash
+func (_m *State) GetRuntimeVersionContext(ctx context.Context, blockHash types.Hash) (*types.RuntimeVersion, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 *types.RuntimeVersion
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) *types.RuntimeVersion); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RuntimeVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRuntimeVersionLatest provides a mock function with given fields:
 func (_m *State) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
 	ret := _m.Called()
@@ -373,6 +1370,29 @@ func (_m *State) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
 	return r0, r1
 }
 
+// GetRuntimeVersionLatestContext provides a mock function with given fields: ctx
+func (_m *State) GetRuntimeVersionLatestContext(ctx context.Context) (*types.RuntimeVersion, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *types.RuntimeVersion
+	if rf, ok := ret.Get(0).(func(context.Context) *types.RuntimeVersion); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RuntimeVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorage provides a mock function with given fields: key, target, blockHash
 func (_m *State) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
 	ret := _m.Called(key, target, blockHash)
@@ -394,6 +1414,27 @@ func (_m *State) GetStorage(key types.StorageKey, target interface{}, blockHash
 	return r0, r1
 }
 
+// GetStorageContext provides a mock function with given fields: ctx, key, target, blockHash
+func (_m *State) GetStorageContext(ctx context.Context, key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
+	ret := _m.Called(ctx, key, target, blockHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, interface{}, types.Hash) bool); ok {
+		r0 = rf(ctx, key, target, blockHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, interface{}, types.Hash) error); ok {
+		r1 = rf(ctx, key, target, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorageHash provides a mock function with given fields: key, blockHash
 func (_m *State) GetStorageHash(key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
 	ret := _m.Called(key, blockHash)
@@ -417,6 +1458,27 @@ func (_m *State) GetStorageHash(key types.StorageKey, blockHash types.Hash) (typ
 	return r0, r1
 }
 
+// GetStorageHashContext provides a mock function with given fields: ctx, key, blockHash
+func (_m *State) GetStorageHashContext(ctx context.Context, key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
+	ret := _m.Called(ctx, key, blockHash)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.Hash) types.Hash); ok {
+		r0 = rf(ctx, key, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.Hash)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, key, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorageHashLatest provides a mock function with given fields: key
 func (_m *State) GetStorageHashLatest(key types.StorageKey) (types.Hash, error) {
 	ret := _m.Called(key)
@@ -440,6 +1502,27 @@ func (_m *State) GetStorageHashLatest(key types.StorageKey) (types.Hash, error)
 	return r0, r1
 }
 
+// GetStorageHashLatestContext provides a mock function with given fields: ctx, key
+func (_m *State) GetStorageHashLatestContext(ctx context.Context, key types.StorageKey) (types.Hash, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey) types.Hash); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(types.Hash)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorageLatest provides a mock function with given fields: key, target
 func (_m *State) GetStorageLatest(key types.StorageKey, target interface{}) (bool, error) {
 	ret := _m.Called(key, target)
@@ -461,13 +1544,149 @@ func (_m *State) GetStorageLatest(key types.StorageKey, target interface{}) (boo
 	return r0, r1
 }
 
-// GetStorageRaw provides a mock function with given fields: key, blockHash
-func (_m *State) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
-	ret := _m.Called(key, blockHash)
+// GetStorageLatestContext provides a mock function with given fields: ctx, key, target
+func (_m *State) GetStorageLatestContext(ctx context.Context, key types.StorageKey, target interface{}) (bool, error) {
+	ret := _m.Called(ctx, key, target)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, interface{}) bool); ok {
+		r0 = rf(ctx, key, target)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, interface{}) error); ok {
+		r1 = rf(ctx, key, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageRaw provides a mock function with given fields: key, blockHash
+func (_m *State) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	ret := _m.Called(key, blockHash)
+
+	var r0 *types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(types.StorageKey, types.Hash) *types.StorageDataRaw); ok {
+		r0 = rf(key, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.StorageDataRaw)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.StorageKey, types.Hash) error); ok {
+		r1 = rf(key, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageRawBatch provides a mock function with given fields: keys, blockHash
+func (_m *State) GetStorageRawBatch(keys []types.StorageKey, blockHash types.Hash) ([]client.BatchResult[*types.StorageDataRaw], error) {
+	ret := _m.Called(keys, blockHash)
+
+	var r0 []client.BatchResult[*types.StorageDataRaw]
+	if rf, ok := ret.Get(0).(func([]types.StorageKey, types.Hash) []client.BatchResult[*types.StorageDataRaw]); ok {
+		r0 = rf(keys, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.BatchResult[*types.StorageDataRaw])
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]types.StorageKey, types.Hash) error); ok {
+		r1 = rf(keys, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageRawBatchContext provides a mock function with given fields: ctx, keys, blockHash
+func (_m *State) GetStorageRawBatchContext(ctx context.Context, keys []types.StorageKey, blockHash types.Hash) ([]client.BatchResult[*types.StorageDataRaw], error) {
+	ret := _m.Called(ctx, keys, blockHash)
+
+	var r0 []client.BatchResult[*types.StorageDataRaw]
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash) []client.BatchResult[*types.StorageDataRaw]); ok {
+		r0 = rf(ctx, keys, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.BatchResult[*types.StorageDataRaw])
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, keys, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageRawBatchLatest provides a mock function with given fields: keys
+func (_m *State) GetStorageRawBatchLatest(keys []types.StorageKey) ([]client.BatchResult[*types.StorageDataRaw], error) {
+	ret := _m.Called(keys)
+
+	var r0 []client.BatchResult[*types.StorageDataRaw]
+	if rf, ok := ret.Get(0).(func([]types.StorageKey) []client.BatchResult[*types.StorageDataRaw]); ok {
+		r0 = rf(keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.BatchResult[*types.StorageDataRaw])
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]types.StorageKey) error); ok {
+		r1 = rf(keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageRawBatchLatestContext provides a mock function with given fields: ctx, keys
+func (_m *State) GetStorageRawBatchLatestContext(ctx context.Context, keys []types.StorageKey) ([]client.BatchResult[*types.StorageDataRaw], error) {
+	ret := _m.Called(ctx, keys)
+
+	var r0 []client.BatchResult[*types.StorageDataRaw]
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey) []client.BatchResult[*types.StorageDataRaw]); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.BatchResult[*types.StorageDataRaw])
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageRawContext provides a mock function with given fields: ctx, key, blockHash
+func (_m *State) GetStorageRawContext(ctx context.Context, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, key, blockHash)
 
 	var r0 *types.StorageDataRaw
-	if rf, ok := ret.Get(0).(func(types.StorageKey, types.Hash) *types.StorageDataRaw); ok {
-		r0 = rf(key, blockHash)
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.Hash) *types.StorageDataRaw); ok {
+		r0 = rf(ctx, key, blockHash)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.StorageDataRaw)
@@ -475,8 +1694,8 @@ func (_m *State) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*typ
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.StorageKey, types.Hash) error); ok {
-		r1 = rf(key, blockHash)
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, key, blockHash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -507,6 +1726,29 @@ func (_m *State) GetStorageRawLatest(key types.StorageKey) (*types.StorageDataRa
 	return r0, r1
 }
 
+// GetStorageRawLatestContext provides a mock function with given fields: ctx, key
+func (_m *State) GetStorageRawLatestContext(ctx context.Context, key types.StorageKey) (*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey) *types.StorageDataRaw); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.StorageDataRaw)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorageSize provides a mock function with given fields: key, blockHash
 func (_m *State) GetStorageSize(key types.StorageKey, blockHash types.Hash) (types.U64, error) {
 	ret := _m.Called(key, blockHash)
@@ -528,6 +1770,27 @@ func (_m *State) GetStorageSize(key types.StorageKey, blockHash types.Hash) (typ
 	return r0, r1
 }
 
+// GetStorageSizeContext provides a mock function with given fields: ctx, key, blockHash
+func (_m *State) GetStorageSizeContext(ctx context.Context, key types.StorageKey, blockHash types.Hash) (types.U64, error) {
+	ret := _m.Called(ctx, key, blockHash)
+
+	var r0 types.U64
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.Hash) types.U64); ok {
+		r0 = rf(ctx, key, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.U64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, key, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorageSizeLatest provides a mock function with given fields: key
 func (_m *State) GetStorageSizeLatest(key types.StorageKey) (types.U64, error) {
 	ret := _m.Called(key)
@@ -549,6 +1812,27 @@ func (_m *State) GetStorageSizeLatest(key types.StorageKey) (types.U64, error) {
 	return r0, r1
 }
 
+// GetStorageSizeLatestContext provides a mock function with given fields: ctx, key
+func (_m *State) GetStorageSizeLatestContext(ctx context.Context, key types.StorageKey) (types.U64, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 types.U64
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey) types.U64); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(types.U64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // QueryStorage provides a mock function with given fields: keys, startBlock, block
 func (_m *State) QueryStorage(keys []types.StorageKey, startBlock types.Hash, block types.Hash) ([]types.StorageChangeSet, error) {
 	ret := _m.Called(keys, startBlock, block)
@@ -595,6 +1879,29 @@ func (_m *State) QueryStorageAt(keys []types.StorageKey, block types.Hash) ([]ty
 	return r0, r1
 }
 
+// QueryStorageAtContext provides a mock function with given fields: ctx, keys, block
+func (_m *State) QueryStorageAtContext(ctx context.Context, keys []types.StorageKey, block types.Hash) ([]types.StorageChangeSet, error) {
+	ret := _m.Called(ctx, keys, block)
+
+	var r0 []types.StorageChangeSet
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash) []types.StorageChangeSet); ok {
+		r0 = rf(ctx, keys, block)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageChangeSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, keys, block)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // QueryStorageAtLatest provides a mock function with given fields: keys
 func (_m *State) QueryStorageAtLatest(keys []types.StorageKey) ([]types.StorageChangeSet, error) {
 	ret := _m.Called(keys)
@@ -618,6 +1925,52 @@ func (_m *State) QueryStorageAtLatest(keys []types.StorageKey) ([]types.StorageC
 	return r0, r1
 }
 
+// QueryStorageAtLatestContext provides a mock function with given fields: ctx, keys
+func (_m *State) QueryStorageAtLatestContext(ctx context.Context, keys []types.StorageKey) ([]types.StorageChangeSet, error) {
+	ret := _m.Called(ctx, keys)
+
+	var r0 []types.StorageChangeSet
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey) []types.StorageChangeSet); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageChangeSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryStorageContext provides a mock function with given fields: ctx, keys, startBlock, block
+func (_m *State) QueryStorageContext(ctx context.Context, keys []types.StorageKey, startBlock types.Hash, block types.Hash) ([]types.StorageChangeSet, error) {
+	ret := _m.Called(ctx, keys, startBlock, block)
+
+	var r0 []types.StorageChangeSet
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash, types.Hash) []types.StorageChangeSet); ok {
+		r0 = rf(ctx, keys, startBlock, block)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageChangeSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, types.Hash, types.Hash) error); ok {
+		r1 = rf(ctx, keys, startBlock, block)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // QueryStorageLatest provides a mock function with given fields: keys, startBlock
 func (_m *State) QueryStorageLatest(keys []types.StorageKey, startBlock types.Hash) ([]types.StorageChangeSet, error) {
 	ret := _m.Called(keys, startBlock)
@@ -641,6 +1994,113 @@ func (_m *State) QueryStorageLatest(keys []types.StorageKey, startBlock types.Ha
 	return r0, r1
 }
 
+// QueryStorageLatestContext provides a mock function with given fields: ctx, keys, startBlock
+func (_m *State) QueryStorageLatestContext(ctx context.Context, keys []types.StorageKey, startBlock types.Hash) ([]types.StorageChangeSet, error) {
+	ret := _m.Called(ctx, keys, startBlock)
+
+	var r0 []types.StorageChangeSet
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash) []types.StorageChangeSet); ok {
+		r0 = rf(ctx, keys, startBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageChangeSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, keys, startBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RuntimeCall provides a mock function with given fields: method, blockHash, target, args
+func (_m *State) RuntimeCall(method string, blockHash types.Hash, target interface{}, args ...interface{}) error {
+	_va := make([]interface{}, len(args))
+	for _i := range args {
+		_va[_i] = args[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, method, blockHash, target)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, types.Hash, interface{}, ...interface{}) error); ok {
+		r0 = rf(method, blockHash, target, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RuntimeCallContext provides a mock function with given fields: ctx, method, blockHash, target, args
+func (_m *State) RuntimeCallContext(ctx context.Context, method string, blockHash types.Hash, target interface{}, args ...interface{}) error {
+	_va := make([]interface{}, len(args))
+	for _i := range args {
+		_va[_i] = args[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, method, blockHash, target)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, types.Hash, interface{}, ...interface{}) error); ok {
+		r0 = rf(ctx, method, blockHash, target, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RuntimeCallLatest provides a mock function with given fields: method, target, args
+func (_m *State) RuntimeCallLatest(method string, target interface{}, args ...interface{}) error {
+	_va := make([]interface{}, len(args))
+	for _i := range args {
+		_va[_i] = args[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, method, target)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, interface{}, ...interface{}) error); ok {
+		r0 = rf(method, target, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RuntimeCallLatestContext provides a mock function with given fields: ctx, method, target, args
+func (_m *State) RuntimeCallLatestContext(ctx context.Context, method string, target interface{}, args ...interface{}) error {
+	_va := make([]interface{}, len(args))
+	for _i := range args {
+		_va[_i] = args[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, method, target)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, ...interface{}) error); ok {
+		r0 = rf(ctx, method, target, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SubscribeRuntimeVersion provides a mock function with given fields:
 func (_m *State) SubscribeRuntimeVersion() (*state.RuntimeVersionSubscription, error) {
 	ret := _m.Called()
@@ -664,6 +2124,75 @@ func (_m *State) SubscribeRuntimeVersion() (*state.RuntimeVersionSubscription, e
 	return r0, r1
 }
 
+// SubscribeRuntimeVersionContext provides a mock function with given fields: ctx
+func (_m *State) SubscribeRuntimeVersionContext(ctx context.Context) (*state.RuntimeVersionSubscription, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *state.RuntimeVersionSubscription
+	if rf, ok := ret.Get(0).(func(context.Context) *state.RuntimeVersionSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.RuntimeVersionSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeStorageDecoded provides a mock function with given fields: queries
+func (_m *State) SubscribeStorageDecoded(queries []state.StorageQuery) (*state.DecodedStorageSubscription, error) {
+	ret := _m.Called(queries)
+
+	var r0 *state.DecodedStorageSubscription
+	if rf, ok := ret.Get(0).(func([]state.StorageQuery) *state.DecodedStorageSubscription); ok {
+		r0 = rf(queries)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.DecodedStorageSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]state.StorageQuery) error); ok {
+		r1 = rf(queries)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeStorageDecodedContext provides a mock function with given fields: ctx, queries
+func (_m *State) SubscribeStorageDecodedContext(ctx context.Context, queries []state.StorageQuery) (*state.DecodedStorageSubscription, error) {
+	ret := _m.Called(ctx, queries)
+
+	var r0 *state.DecodedStorageSubscription
+	if rf, ok := ret.Get(0).(func(context.Context, []state.StorageQuery) *state.DecodedStorageSubscription); ok {
+		r0 = rf(ctx, queries)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.DecodedStorageSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []state.StorageQuery) error); ok {
+		r1 = rf(ctx, queries)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubscribeStorageRaw provides a mock function with given fields: keys
 func (_m *State) SubscribeStorageRaw(keys []types.StorageKey) (*state.StorageSubscription, error) {
 	ret := _m.Called(keys)
@@ -687,6 +2216,126 @@ func (_m *State) SubscribeStorageRaw(keys []types.StorageKey) (*state.StorageSub
 	return r0, r1
 }
 
+// SubscribeStorageRawContext provides a mock function with given fields: ctx, keys
+func (_m *State) SubscribeStorageRawContext(ctx context.Context, keys []types.StorageKey) (*state.StorageSubscription, error) {
+	ret := _m.Called(ctx, keys)
+
+	var r0 *state.StorageSubscription
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey) *state.StorageSubscription); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.StorageSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeStorageRawWithOptions provides a mock function with given fields: ctx, keys, opts
+func (_m *State) SubscribeStorageRawWithOptions(ctx context.Context, keys []types.StorageKey, opts client.SubscriptionOptions) (*state.StorageSubscription, error) {
+	ret := _m.Called(ctx, keys, opts)
+
+	var r0 *state.StorageSubscription
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, client.SubscriptionOptions) *state.StorageSubscription); ok {
+		r0 = rf(ctx, keys, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.StorageSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, client.SubscriptionOptions) error); ok {
+		r1 = rf(ctx, keys, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TraceBlock provides a mock function with given fields: blockHash, targets, storageKeys, methods
+func (_m *State) TraceBlock(blockHash types.Hash, targets string, storageKeys string, methods string) (*types.BlockTrace, error) {
+	ret := _m.Called(blockHash, targets, storageKeys, methods)
+
+	var r0 *types.BlockTrace
+	if rf, ok := ret.Get(0).(func(types.Hash, string, string, string) *types.BlockTrace); ok {
+		r0 = rf(blockHash, targets, storageKeys, methods)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.BlockTrace)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Hash, string, string, string) error); ok {
+		r1 = rf(blockHash, targets, storageKeys, methods)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TraceBlockContext provides a mock function with given fields: ctx, blockHash, targets, storageKeys, methods
+func (_m *State) TraceBlockContext(ctx context.Context, blockHash types.Hash, targets string, storageKeys string, methods string) (*types.BlockTrace, error) {
+	ret := _m.Called(ctx, blockHash, targets, storageKeys, methods)
+
+	var r0 *types.BlockTrace
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash, string, string, string) *types.BlockTrace); ok {
+		r0 = rf(ctx, blockHash, targets, storageKeys, methods)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.BlockTrace)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash, string, string, string) error); ok {
+		r1 = rf(ctx, blockHash, targets, storageKeys, methods)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TraceBlockRaw provides a mock function with given fields: w, blockHash, targets, storageKeys, methods
+func (_m *State) TraceBlockRaw(w io.Writer, blockHash types.Hash, targets string, storageKeys string, methods string) error {
+	ret := _m.Called(w, blockHash, targets, storageKeys, methods)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(io.Writer, types.Hash, string, string, string) error); ok {
+		r0 = rf(w, blockHash, targets, storageKeys, methods)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TraceBlockRawContext provides a mock function with given fields: ctx, w, blockHash, targets, storageKeys, methods
+func (_m *State) TraceBlockRawContext(ctx context.Context, w io.Writer, blockHash types.Hash, targets string, storageKeys string, methods string) error {
+	ret := _m.Called(ctx, w, blockHash, targets, storageKeys, methods)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer, types.Hash, string, string, string) error); ok {
+		r0 = rf(ctx, w, blockHash, targets, storageKeys, methods)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type NewStateT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,64 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Provider is the subset of State that the higher-level helpers of the library depend on, e.g. the retrievers, the
+// submitter and the upgrade watcher. It is satisfied by State, and by the in-memory fakes of the fakes package, which
+// allows to test code using these helpers without a node.
+type Provider interface {
+	GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) (ok bool, err error)
+	GetStorageContext(
+		ctx context.Context,
+		key types.StorageKey,
+		target interface{},
+		blockHash types.Hash,
+	) (ok bool, err error)
+	GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error)
+	GetStorageRawContext(ctx context.Context, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error)
+
+	GetMetadata(blockHash types.Hash) (*types.Metadata, error)
+	GetMetadataContext(ctx context.Context, blockHash types.Hash) (*types.Metadata, error)
+	GetMetadataLatest() (*types.Metadata, error)
+	GetMetadataLatestContext(ctx context.Context) (*types.Metadata, error)
+
+	GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion, error)
+	GetRuntimeVersionContext(ctx context.Context, blockHash types.Hash) (*types.RuntimeVersion, error)
+	GetRuntimeVersionLatest() (*types.RuntimeVersion, error)
+	GetRuntimeVersionLatestContext(ctx context.Context) (*types.RuntimeVersion, error)
+
+	SubscribeRuntimeVersion() (*RuntimeVersionSubscription, error)
+	SubscribeRuntimeVersionContext(ctx context.Context) (*RuntimeVersionSubscription, error)
+
+	QueryStorage(keys []types.StorageKey, startBlock types.Hash, block types.Hash) ([]types.StorageChangeSet, error)
+	QueryStorageContext(
+		ctx context.Context,
+		keys []types.StorageKey,
+		startBlock types.Hash,
+		block types.Hash,
+	) ([]types.StorageChangeSet, error)
+
+	Call(method string, data []byte, blockHash types.Hash) (types.Bytes, error)
+	CallContext(ctx context.Context, method string, data []byte, blockHash types.Hash) (types.Bytes, error)
+}
+
+var _ Provider = (*state)(nil)
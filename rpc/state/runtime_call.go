@@ -0,0 +1,83 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// RuntimeCall calls the runtime API method with the SCALE encoded args at the given block and decodes the result
+// into target, e.g. RuntimeCall("AccountNonceApi_account_nonce", blockHash, &nonce, accountID).
+func (s *state) RuntimeCall(method string, blockHash types.Hash, target interface{}, args ...interface{}) error {
+	return s.RuntimeCallContext(context.Background(), method, blockHash, target, args...)
+}
+
+// RuntimeCallContext is like RuntimeCall but uses the provided context for the RPC call.
+func (s *state) RuntimeCallContext(
+	ctx context.Context,
+	method string,
+	blockHash types.Hash,
+	target interface{},
+	args ...interface{},
+) error {
+	return s.runtimeCall(ctx, method, &blockHash, target, args)
+}
+
+// RuntimeCallLatest calls the runtime API method with the SCALE encoded args at the latest block and decodes the
+// result into target
+func (s *state) RuntimeCallLatest(method string, target interface{}, args ...interface{}) error {
+	return s.RuntimeCallLatestContext(context.Background(), method, target, args...)
+}
+
+// RuntimeCallLatestContext is like RuntimeCallLatest but uses the provided context for the RPC call.
+func (s *state) RuntimeCallLatestContext(
+	ctx context.Context,
+	method string,
+	target interface{},
+	args ...interface{},
+) error {
+	return s.runtimeCall(ctx, method, nil, target, args)
+}
+
+func (s *state) runtimeCall(
+	ctx context.Context,
+	method string,
+	blockHash *types.Hash,
+	target interface{},
+	args []interface{},
+) error {
+	var data []byte
+
+	for _, arg := range args {
+		encodedArg, err := codec.Encode(arg)
+		if err != nil {
+			return err
+		}
+
+		data = append(data, encodedArg...)
+	}
+
+	res, err := s.call(ctx, method, data, blockHash)
+	if err != nil {
+		return err
+	}
+
+	return codec.Decode(res, target)
+}
@@ -0,0 +1,71 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRuntimeVersion struct {
+	SpecName         types.Text
+	ImplName         types.Text
+	AuthoringVersion types.U32
+	SpecVersion      types.U32
+	ImplVersion      types.U32
+}
+
+var expectedTestRuntimeVersion = testRuntimeVersion{
+	SpecName:         "node",
+	ImplName:         "node-substrate",
+	AuthoringVersion: 10,
+	SpecVersion:      60,
+	ImplVersion:      0,
+}
+
+func TestState_RuntimeCallLatest(t *testing.T) {
+	var version testRuntimeVersion
+	err := testState.RuntimeCallLatest("Core_version", &version)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTestRuntimeVersion, version)
+}
+
+func TestState_RuntimeCall(t *testing.T) {
+	var version testRuntimeVersion
+	err := testState.RuntimeCall("Core_version", mockSrv.blockHashLatest, &version)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTestRuntimeVersion, version)
+}
+
+func TestState_RuntimeCallArgs(t *testing.T) {
+	var opaqueMetadata types.Option[types.Bytes]
+	err := testState.RuntimeCallLatest("Metadata_metadata_at_version", &opaqueMetadata, types.NewU32(14))
+	assert.NoError(t, err)
+	assert.False(t, opaqueMetadata.HasValue())
+
+	err = testState.RuntimeCallLatest("Metadata_metadata_at_version", &opaqueMetadata, types.NewU32(15))
+	assert.NoError(t, err)
+	assert.True(t, opaqueMetadata.HasValue())
+}
+
+func TestState_RuntimeCallUnknownMethod(t *testing.T) {
+	var version testRuntimeVersion
+	err := testState.RuntimeCallLatest("Unknown_method", &version)
+	assert.Error(t, err)
+}
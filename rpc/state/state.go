@@ -19,53 +19,228 @@
 package state
 
 import (
+	"context"
+	"io"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 type State interface {
 	GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) (ok bool, err error)
+	GetStorageContext(
+		ctx context.Context,
+		key types.StorageKey,
+		target interface{},
+		blockHash types.Hash,
+	) (ok bool, err error)
 	GetStorageLatest(key types.StorageKey, target interface{}) (ok bool, err error)
+	GetStorageLatestContext(ctx context.Context, key types.StorageKey, target interface{}) (ok bool, err error)
 	GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error)
+	GetStorageRawContext(ctx context.Context, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error)
 	GetStorageRawLatest(key types.StorageKey) (*types.StorageDataRaw, error)
+	GetStorageRawLatestContext(ctx context.Context, key types.StorageKey) (*types.StorageDataRaw, error)
+	GetStorageRawBatch(keys []types.StorageKey, blockHash types.Hash) ([]client.BatchResult[*types.StorageDataRaw], error)
+	GetStorageRawBatchContext(
+		ctx context.Context,
+		keys []types.StorageKey,
+		blockHash types.Hash,
+	) ([]client.BatchResult[*types.StorageDataRaw], error)
+	GetStorageRawBatchLatest(keys []types.StorageKey) ([]client.BatchResult[*types.StorageDataRaw], error)
+	GetStorageRawBatchLatestContext(
+		ctx context.Context,
+		keys []types.StorageKey,
+	) ([]client.BatchResult[*types.StorageDataRaw], error)
 
 	GetChildStorageSize(childStorageKey, key types.StorageKey, blockHash types.Hash) (types.U64, error)
+	GetChildStorageSizeContext(
+		ctx context.Context,
+		childStorageKey, key types.StorageKey,
+		blockHash types.Hash,
+	) (types.U64, error)
 	GetChildStorageSizeLatest(childStorageKey, key types.StorageKey) (types.U64, error)
+	GetChildStorageSizeLatestContext(ctx context.Context, childStorageKey, key types.StorageKey) (types.U64, error)
 	GetChildStorage(childStorageKey, key types.StorageKey, target interface{}, blockHash types.Hash) (ok bool, err error)
+	GetChildStorageContext(
+		ctx context.Context,
+		childStorageKey, key types.StorageKey,
+		target interface{},
+		blockHash types.Hash,
+	) (ok bool, err error)
 	GetChildStorageLatest(childStorageKey, key types.StorageKey, target interface{}) (ok bool, err error)
+	GetChildStorageLatestContext(
+		ctx context.Context,
+		childStorageKey, key types.StorageKey,
+		target interface{},
+	) (ok bool, err error)
 	GetChildStorageRaw(childStorageKey, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error)
+	GetChildStorageRawContext(
+		ctx context.Context,
+		childStorageKey, key types.StorageKey,
+		blockHash types.Hash,
+	) (*types.StorageDataRaw, error)
 	GetChildStorageRawLatest(childStorageKey, key types.StorageKey) (*types.StorageDataRaw, error)
+	GetChildStorageRawLatestContext(
+		ctx context.Context,
+		childStorageKey, key types.StorageKey,
+	) (*types.StorageDataRaw, error)
 
 	GetMetadata(blockHash types.Hash) (*types.Metadata, error)
+	GetMetadataContext(ctx context.Context, blockHash types.Hash) (*types.Metadata, error)
 	GetMetadataLatest() (*types.Metadata, error)
+	GetMetadataLatestContext(ctx context.Context) (*types.Metadata, error)
+	GetMetadataV15(blockHash types.Hash) (*types.MetadataV15, error)
+	GetMetadataV15Context(ctx context.Context, blockHash types.Hash) (*types.MetadataV15, error)
+	GetMetadataV15Latest() (*types.MetadataV15, error)
+	GetMetadataV15LatestContext(ctx context.Context) (*types.MetadataV15, error)
+	GetMetadataAtVersion(version uint32, blockHash types.Hash) (*types.Metadata, error)
+	GetMetadataAtVersionContext(ctx context.Context, version uint32, blockHash types.Hash) (*types.Metadata, error)
+	GetMetadataAtVersionLatest(version uint32) (*types.Metadata, error)
+	GetMetadataAtVersionLatestContext(ctx context.Context, version uint32) (*types.Metadata, error)
+	GetMetadataVersions(blockHash types.Hash) ([]types.U32, error)
+	GetMetadataVersionsContext(ctx context.Context, blockHash types.Hash) ([]types.U32, error)
+	GetMetadataVersionsLatest() ([]types.U32, error)
+	GetMetadataVersionsLatestContext(ctx context.Context) ([]types.U32, error)
+	GetMetadataHighest(blockHash types.Hash) (*types.Metadata, error)
+	GetMetadataHighestContext(ctx context.Context, blockHash types.Hash) (*types.Metadata, error)
+	GetMetadataHighestLatest() (*types.Metadata, error)
+	GetMetadataHighestLatestContext(ctx context.Context) (*types.Metadata, error)
 
 	GetStorageHash(key types.StorageKey, blockHash types.Hash) (types.Hash, error)
+	GetStorageHashContext(ctx context.Context, key types.StorageKey, blockHash types.Hash) (types.Hash, error)
 	GetStorageHashLatest(key types.StorageKey) (types.Hash, error)
+	GetStorageHashLatestContext(ctx context.Context, key types.StorageKey) (types.Hash, error)
 
 	SubscribeStorageRaw(keys []types.StorageKey) (*StorageSubscription, error)
+	SubscribeStorageRawContext(ctx context.Context, keys []types.StorageKey) (*StorageSubscription, error)
+	SubscribeStorageRawWithOptions(
+		ctx context.Context,
+		keys []types.StorageKey,
+		opts client.SubscriptionOptions,
+	) (*StorageSubscription, error)
+	SubscribeStorageDecoded(queries []StorageQuery) (*DecodedStorageSubscription, error)
+	SubscribeStorageDecodedContext(ctx context.Context, queries []StorageQuery) (*DecodedStorageSubscription, error)
 
 	GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion, error)
+	GetRuntimeVersionContext(ctx context.Context, blockHash types.Hash) (*types.RuntimeVersion, error)
 	GetRuntimeVersionLatest() (*types.RuntimeVersion, error)
+	GetRuntimeVersionLatestContext(ctx context.Context) (*types.RuntimeVersion, error)
 
 	GetChildKeys(childStorageKey, prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error)
+	GetChildKeysContext(
+		ctx context.Context,
+		childStorageKey, prefix types.StorageKey,
+		blockHash types.Hash,
+	) ([]types.StorageKey, error)
 	GetChildKeysLatest(childStorageKey, prefix types.StorageKey) ([]types.StorageKey, error)
+	GetChildKeysLatestContext(ctx context.Context, childStorageKey, prefix types.StorageKey) ([]types.StorageKey, error)
 
 	SubscribeRuntimeVersion() (*RuntimeVersionSubscription, error)
+	SubscribeRuntimeVersionContext(ctx context.Context) (*RuntimeVersionSubscription, error)
 
 	QueryStorage(keys []types.StorageKey, startBlock types.Hash, block types.Hash) ([]types.StorageChangeSet, error)
+	QueryStorageContext(
+		ctx context.Context,
+		keys []types.StorageKey,
+		startBlock types.Hash,
+		block types.Hash,
+	) ([]types.StorageChangeSet, error)
 	QueryStorageLatest(keys []types.StorageKey, startBlock types.Hash) ([]types.StorageChangeSet, error)
+	QueryStorageLatestContext(
+		ctx context.Context,
+		keys []types.StorageKey,
+		startBlock types.Hash,
+	) ([]types.StorageChangeSet, error)
 
 	QueryStorageAt(keys []types.StorageKey, block types.Hash) ([]types.StorageChangeSet, error)
+	QueryStorageAtContext(
+		ctx context.Context,
+		keys []types.StorageKey,
+		block types.Hash,
+	) ([]types.StorageChangeSet, error)
 	QueryStorageAtLatest(keys []types.StorageKey) ([]types.StorageChangeSet, error)
+	QueryStorageAtLatestContext(ctx context.Context, keys []types.StorageKey) ([]types.StorageChangeSet, error)
 
 	GetKeys(prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error)
+	GetKeysContext(ctx context.Context, prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error)
 	GetKeysLatest(prefix types.StorageKey) ([]types.StorageKey, error)
+	GetKeysLatestContext(ctx context.Context, prefix types.StorageKey) ([]types.StorageKey, error)
+	GetKeysPaged(
+		prefix types.StorageKey,
+		count uint32,
+		startKey types.StorageKey,
+		blockHash types.Hash,
+	) ([]types.StorageKey, error)
+	GetKeysPagedContext(
+		ctx context.Context,
+		prefix types.StorageKey,
+		count uint32,
+		startKey types.StorageKey,
+		blockHash types.Hash,
+	) ([]types.StorageKey, error)
+	GetKeysPagedLatest(prefix types.StorageKey, count uint32, startKey types.StorageKey) ([]types.StorageKey, error)
+	GetKeysPagedLatestContext(
+		ctx context.Context,
+		prefix types.StorageKey,
+		count uint32,
+		startKey types.StorageKey,
+	) ([]types.StorageKey, error)
+	ForEachKey(
+		ctx context.Context,
+		prefix types.StorageKey,
+		blockHash types.Hash,
+		opts ForEachKeyOptions,
+		fn func(key types.StorageKey, value *types.StorageDataRaw) error,
+	) (types.StorageKey, error)
 
 	GetStorageSize(key types.StorageKey, blockHash types.Hash) (types.U64, error)
+	GetStorageSizeContext(ctx context.Context, key types.StorageKey, blockHash types.Hash) (types.U64, error)
 	GetStorageSizeLatest(key types.StorageKey) (types.U64, error)
+	GetStorageSizeLatestContext(ctx context.Context, key types.StorageKey) (types.U64, error)
 
 	GetChildStorageHash(childStorageKey, key types.StorageKey, blockHash types.Hash) (types.Hash, error)
+	GetChildStorageHashContext(
+		ctx context.Context,
+		childStorageKey, key types.StorageKey,
+		blockHash types.Hash,
+	) (types.Hash, error)
 	GetChildStorageHashLatest(childStorageKey, key types.StorageKey) (types.Hash, error)
+	GetChildStorageHashLatestContext(ctx context.Context, childStorageKey, key types.StorageKey) (types.Hash, error)
+
+	GetReadProof(keys []types.StorageKey, blockHash types.Hash) (types.ReadProof, error)
+	GetReadProofContext(ctx context.Context, keys []types.StorageKey, blockHash types.Hash) (types.ReadProof, error)
+	GetReadProofLatest(keys []types.StorageKey) (types.ReadProof, error)
+	GetReadProofLatestContext(ctx context.Context, keys []types.StorageKey) (types.ReadProof, error)
+
+	Call(method string, data []byte, blockHash types.Hash) (types.Bytes, error)
+	CallContext(ctx context.Context, method string, data []byte, blockHash types.Hash) (types.Bytes, error)
+	CallLatest(method string, data []byte) (types.Bytes, error)
+	CallLatestContext(ctx context.Context, method string, data []byte) (types.Bytes, error)
+
+	RuntimeCall(method string, blockHash types.Hash, target interface{}, args ...interface{}) error
+	RuntimeCallContext(
+		ctx context.Context,
+		method string,
+		blockHash types.Hash,
+		target interface{},
+		args ...interface{},
+	) error
+	RuntimeCallLatest(method string, target interface{}, args ...interface{}) error
+	RuntimeCallLatestContext(ctx context.Context, method string, target interface{}, args ...interface{}) error
+
+	TraceBlock(blockHash types.Hash, targets, storageKeys, methods string) (*types.BlockTrace, error)
+	TraceBlockContext(
+		ctx context.Context,
+		blockHash types.Hash,
+		targets, storageKeys, methods string,
+	) (*types.BlockTrace, error)
+	TraceBlockRaw(w io.Writer, blockHash types.Hash, targets, storageKeys, methods string) error
+	TraceBlockRawContext(
+		ctx context.Context,
+		w io.Writer,
+		blockHash types.Hash,
+		targets, storageKeys, methods string,
+	) error
 }
 
 // state exposes methods for querying state
@@ -17,11 +17,13 @@
 package state
 
 import (
+	"errors"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
@@ -65,6 +67,8 @@ type MockSrv struct {
 	childStorageTrieValue    ChildStorageTrieTestVal
 	childStorageTrieSize     types.U64
 	childStorageTrieHashHex  string
+	readProof                types.ReadProof
+	stateCallResultHex       string
 }
 
 func (s *MockSrv) GetMetadata(hash *string) string {
@@ -89,8 +93,14 @@ func (s *MockSrv) GetStorage(key string, hash *string) string {
 	return mockSrv.storageDataHex
 }
 
-func (s *MockSrv) GetStorageSize(key string, hash *string) types.U64 {
-	return mockSrv.storageSize
+func (s *MockSrv) GetStorageSize(key string, hash *string) (types.U64, error) {
+	if hash != nil && *hash == unknownBlockHashHex {
+		return 0, types.RPCError{
+			Code:    types.RPCErrorCodeStateClient,
+			Message: "Client error: UnknownBlock: State already discarded for " + unknownBlockHashHex,
+		}
+	}
+	return mockSrv.storageSize, nil
 }
 
 func (s *MockSrv) GetStorageHash(key string, hash *string) string {
@@ -162,6 +172,30 @@ func (s *MockSrv) QueryStorageAt(keys []string, hash *string) []types.StorageCha
 	return mockSrv.storageChangeSets
 }
 
+func (s *MockSrv) GetReadProof(keys []string, hash *string) types.ReadProof {
+	if len(keys) != 1 {
+		panic("keys need to have len of 1 in tests")
+	}
+	if keys[0] != mockSrv.storageKeyHex {
+		panic("key not found")
+	}
+
+	return mockSrv.readProof
+}
+
+func (s *MockSrv) Call(method, data string, hash *string) (string, error) {
+	switch {
+	case method == "Core_version":
+		return mockSrv.stateCallResultHex, nil
+	case method == "Metadata_metadata_at_version" && data == "0x0f000000":
+		return codec.EncodeToHex(types.NewOption(types.Bytes(codec.MustHexDecodeString(test.MetadataV15Hex))))
+	case method == "Metadata_metadata_at_version":
+		return codec.EncodeToHex(types.NewEmptyOption[types.Bytes]())
+	default:
+		return "", errors.New("exported method Unknown_method is not found")
+	}
+}
+
 // func (s *MockSrv) SubscribeStorage(args []string) {
 // 	fmt.Println("Hit")
 // }
@@ -179,7 +213,7 @@ var mockSrv = MockSrv{
 	blockHashLatest:          types.Hash{1, 2, 3},
 	metadata:                 types.ExamplaryMetadataV4,
 	metadataString:           types.ExamplaryMetadataV4String,
-	runtimeVersion:           types.RuntimeVersion{APIs: []types.RuntimeVersionAPI{{APIID: "0xdf6acb689907609b", Version: 0x2}, {APIID: "0x37e397fc7c91f5e4", Version: 0x1}, {APIID: "0x40fe3ad401f8959a", Version: 0x3}, {APIID: "0xd2bc9897eed08f15", Version: 0x1}, {APIID: "0xf78b278be53f454c", Version: 0x1}, {APIID: "0xed99c5acb25eedf5", Version: 0x2}, {APIID: "0xdd718d5cc53262d4", Version: 0x1}, {APIID: "0x7801759919ee83e5", Version: 0x1}}, AuthoringVersion: 0xa, ImplName: "substrate-node", ImplVersion: 0x3e, SpecName: "node", SpecVersion: 0x3c}, //nolint:lll
+	runtimeVersion:           types.RuntimeVersion{APIs: []types.RuntimeVersionAPI{{APIID: "0xdf6acb689907609b", Version: 0x2}, {APIID: "0x37e397fc7c91f5e4", Version: 0x2}, {APIID: "0x40fe3ad401f8959a", Version: 0x3}, {APIID: "0xd2bc9897eed08f15", Version: 0x1}, {APIID: "0xf78b278be53f454c", Version: 0x1}, {APIID: "0xed99c5acb25eedf5", Version: 0x2}, {APIID: "0xdd718d5cc53262d4", Version: 0x1}, {APIID: "0x7801759919ee83e5", Version: 0x1}}, AuthoringVersion: 0xa, ImplName: "substrate-node", ImplVersion: 0x3e, SpecName: "node", SpecVersion: 0x3c}, //nolint:lll
 	storageKeyHex:            "0x0e4944cfd98d6f4cc374d16f5a4e3f9c",
 	storageKeyHexEmpty:       "0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee",
 	storageChangeSets:        []types.StorageChangeSet{{Block: types.Hash{0xdd, 0x18, 0x16, 0xb6, 0xf6, 0x88, 0x9f, 0x46, 0xe2, 0x3b, 0xd, 0x67, 0x50, 0xbc, 0x44, 0x1a, 0xf9, 0xda, 0xd0, 0xfd, 0xa8, 0xba, 0xe9, 0x6, 0x77, 0xc1, 0x70, 0x8d, 0x1, 0x3, 0x5f, 0xbe}, Changes: []types.KeyValueOption{{StorageKey: types.StorageKey{0xe, 0x49, 0x44, 0xcf, 0xd9, 0x8d, 0x6f, 0x4c, 0xc3, 0x74, 0xd1, 0x6f, 0x5a, 0x4e, 0x3f, 0x9c}, HasStorageData: true, StorageData: types.StorageDataRaw{0x88, 0x2, 0x66, 0x9f, 0x6e, 0x1, 0x0, 0x0}}}}, {Block: types.Hash{0x82, 0x14, 0xa1, 0x80, 0x8b, 0xd6, 0xb0, 0x46, 0xc8, 0x77, 0xa6, 0x4f, 0xce, 0xad, 0xb4, 0xa2, 0xa7, 0x3a, 0x65, 0x76, 0x9f, 0x61, 0x4, 0xc0, 0x20, 0xd7, 0x59, 0xad, 0x8f, 0x61, 0xc0, 0xd8}, Changes: []types.KeyValueOption{{StorageKey: types.StorageKey{0xe, 0x49, 0x44, 0xcf, 0xd9, 0x8d, 0x6f, 0x4c, 0xc3, 0x74, 0xd1, 0x6f, 0x5a, 0x4e, 0x3f, 0x9c}, HasStorageData: true, StorageData: types.StorageDataRaw{0x40, 0xe, 0x66, 0x9f, 0x6e, 0x1, 0x0, 0x0}}}}}, //nolint:lll
@@ -196,4 +230,9 @@ var mockSrv = MockSrv{
 	},
 	childStorageTrieSize:    68,
 	childStorageTrieHashHex: "0x20e3fc48a91087d091c17de08a5c470de53ccdaebd361025b0e5b7c65b9a0d30", //nolint:lll
+	readProof: types.ReadProof{
+		At:    types.Hash{1, 2, 3},
+		Proof: []types.Bytes{codec.MustHexDecodeString("0x600e4944cfd98d6f4cc374d16f5a4e3f9c20b82d895d00000000")},
+	},
+	stateCallResultHex: "0x106e6f6465386e6f64652d7375627374726174650a0000003c000000000000000000000000000000",
 }
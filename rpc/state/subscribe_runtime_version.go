@@ -20,6 +20,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -51,6 +52,12 @@ func (s *RuntimeVersionSubscription) Err() <-chan error {
 	return s.sub.Err()
 }
 
+// Gap returns a channel that receives a value when notifications might have been missed, which happens
+// when the subscription was re-established after a reconnect, see client.ConnectWithReconnect.
+func (s *RuntimeVersionSubscription) Gap() <-chan struct{} {
+	return s.sub.Gap()
+}
+
 // Unsubscribe unsubscribes the notification and closes the error channel.
 // It can safely be called more than once.
 func (s *RuntimeVersionSubscription) Unsubscribe() {
@@ -64,16 +71,25 @@ func (s *RuntimeVersionSubscription) Unsubscribe() {
 // receive server notifications containing the RuntimeVersion.
 func (s *state) SubscribeRuntimeVersion() (
 	*RuntimeVersionSubscription, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	return s.SubscribeRuntimeVersionContext(context.Background())
+}
+
+// SubscribeRuntimeVersionContext is like SubscribeRuntimeVersion but the subscription is ended once ctx is done.
+// The subscription request itself is bound by both ctx and the configured subscribe timeout.
+func (s *state) SubscribeRuntimeVersionContext(ctx context.Context) (*RuntimeVersionSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
 	defer cancel()
 
 	c := make(chan types.RuntimeVersion)
 
-	sub, err := s.client.Subscribe(ctx, "state", "subscribeRuntimeVersion", "unsubscribeRuntimeVersion",
+	sub, err := s.client.Subscribe(subscribeCtx, "state", "subscribeRuntimeVersion", "unsubscribeRuntimeVersion",
 		"runtimeVersion", c)
 	if err != nil {
 		return nil, err
 	}
 
-	return &RuntimeVersionSubscription{sub: sub, channel: c}, nil
+	subscription := &RuntimeVersionSubscription{sub: sub, channel: c}
+	client.UnsubscribeOnCancel(ctx, sub.Done(), subscription.Unsubscribe)
+
+	return subscription, nil
 }
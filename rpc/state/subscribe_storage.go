@@ -18,8 +18,8 @@ package state
 
 import (
 	"context"
-	"sync"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -27,16 +27,15 @@ import (
 
 // StorageSubscription is a subscription established through one of the Client's subscribe methods.
 type StorageSubscription struct {
-	sub      *gethrpc.ClientSubscription
-	channel  chan types.StorageChangeSet
-	quitOnce sync.Once // ensures quit is closed once
+	sub    *gethrpc.ClientSubscription
+	buffer *client.SubscriptionBuffer[types.StorageChangeSet]
 }
 
 // Chan returns the subscription channel.
 //
 // The channel is closed when Unsubscribe is called on the subscription.
 func (s *StorageSubscription) Chan() <-chan types.StorageChangeSet {
-	return s.channel
+	return s.buffer.Out()
 }
 
 // Err returns the subscription error channel. The intended use of Err is to schedule
@@ -51,13 +50,24 @@ func (s *StorageSubscription) Err() <-chan error {
 	return s.sub.Err()
 }
 
+// Gap returns a channel that receives a value when notifications might have been missed, which happens
+// when the subscription was re-established after a reconnect, see client.ConnectWithReconnect, or when
+// notifications were dropped, see client.OverflowDropOldest.
+func (s *StorageSubscription) Gap() <-chan struct{} {
+	return s.sub.Gap()
+}
+
+// Dropped returns the number of notifications that were dropped because the consumer did not keep up, see
+// client.OverflowDropOldest.
+func (s *StorageSubscription) Dropped() uint64 {
+	return s.buffer.Dropped()
+}
+
 // Unsubscribe unsubscribes the notification and closes the error channel.
 // It can safely be called more than once.
 func (s *StorageSubscription) Unsubscribe() {
 	s.sub.Unsubscribe()
-	s.quitOnce.Do(func() {
-		close(s.channel)
-	})
+	s.buffer.Close()
 }
 
 // SubscribeStorageRaw subscribes the storage for the given keys, returning a subscription that will
@@ -68,20 +78,42 @@ func (s *StorageSubscription) Unsubscribe() {
 // large buffer on the channel or ensure that the channel usually has at least one reader to prevent this issue.
 func (s *state) SubscribeStorageRaw(keys []types.StorageKey) (
 	*StorageSubscription, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	return s.SubscribeStorageRawContext(context.Background(), keys)
+}
+
+// SubscribeStorageRawContext is like SubscribeStorageRaw but the subscription is ended once ctx is done.
+// The subscription request itself is bound by both ctx and the configured subscribe timeout.
+func (s *state) SubscribeStorageRawContext(ctx context.Context, keys []types.StorageKey) (*StorageSubscription, error) {
+	return s.SubscribeStorageRawWithOptions(ctx, keys, client.SubscriptionOptions{})
+}
+
+// SubscribeStorageRawWithOptions is like SubscribeStorageRawContext but buffers the notifications according to the
+// options, e.g. to drop the oldest change sets when the consumer does not keep up.
+func (s *state) SubscribeStorageRawWithOptions(
+	ctx context.Context,
+	keys []types.StorageKey,
+	opts client.SubscriptionOptions,
+) (*StorageSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
 	defer cancel()
 
-	c := make(chan types.StorageChangeSet)
+	buffer := client.NewSubscriptionBuffer[types.StorageChangeSet]("state_subscribeStorage", opts)
 
 	keyss := make([]string, len(keys))
 	for i := range keys {
 		keyss[i] = keys[i].Hex()
 	}
 
-	sub, err := s.client.Subscribe(ctx, "state", "subscribeStorage", "unsubscribeStorage", "storage", c, keyss)
+	sub, err := s.client.Subscribe(subscribeCtx, "state", "subscribeStorage", "unsubscribeStorage", "storage", buffer.In(),
+		keyss)
 	if err != nil {
 		return nil, err
 	}
 
-	return &StorageSubscription{sub: sub, channel: c}, nil
+	buffer.Forward(sub)
+
+	subscription := &StorageSubscription{sub: sub, buffer: buffer}
+	client.UnsubscribeOnCancel(ctx, sub.Done(), subscription.Unsubscribe)
+
+	return subscription, nil
 }
@@ -0,0 +1,315 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	ErrNoStorageQueries            = libErr.Error("no storage queries")
+	ErrStorageQueryKeyCreation     = libErr.Error("storage query key creation")
+	ErrStorageQueryDuplicateKey    = libErr.Error("storage query duplicate key")
+	ErrStorageQueryDecoderCreation = libErr.Error("storage query decoder creation")
+	ErrStorageChangeDecoding       = libErr.Error("storage change decoding")
+)
+
+// seenBlocksLimit is the number of block hashes a DecodedStorageSubscription remembers to drop duplicate
+// notifications.
+const seenBlocksLimit = 1024
+
+// StorageQuery selects a storage entry for SubscribeStorageDecoded.
+type StorageQuery struct {
+	// Pallet is the name of the pallet, e.g. "System".
+	Pallet string
+	// Entry is the name of the storage entry, e.g. "Account".
+	Entry string
+	// Args are the SCALE encoded keys of a map entry, see types.CreateStorageKey.
+	Args [][]byte
+	// Target is an optional value of the type the storage values are decoded into, e.g. new(types.AccountInfo). It
+	// is only used for its type, each value is decoded into a new pointer of that type. Values are decoded
+	// dynamically based on the metadata if Target is nil, see registry.StorageValueDecoder.
+	Target any
+}
+
+// DecodedStorageChange is the change of the storage entry of a StorageQuery.
+type DecodedStorageChange struct {
+	// Query is the index of the StorageQuery the change belongs to.
+	Query int
+	Key   types.StorageKey
+	// Value is the decoded value, nil if the entry was deleted.
+	Value any
+	// Deleted is true if the entry was removed from storage.
+	Deleted bool
+}
+
+// DecodedStorageChangeSet holds the decoded changes of the queried storage entries in a block.
+type DecodedStorageChangeSet struct {
+	Block   types.Hash
+	Changes []DecodedStorageChange
+}
+
+// DecodedStorageSubscription is a subscription established through SubscribeStorageDecoded.
+type DecodedStorageSubscription struct {
+	sub      *StorageSubscription
+	channel  chan DecodedStorageChangeSet
+	err      chan error
+	quit     chan struct{}
+	quitOnce sync.Once // ensures quit is closed once
+}
+
+// Chan returns the subscription channel.
+//
+// The channel is closed when the subscription ends.
+func (s *DecodedStorageSubscription) Chan() <-chan DecodedStorageChangeSet {
+	return s.channel
+}
+
+// Err returns the subscription error channel.
+//
+// The error channel receives a value when the subscription has ended due to an error, including errors that occurred
+// while decoding a notification. The error channel is closed when the subscription ends.
+func (s *DecodedStorageSubscription) Err() <-chan error {
+	return s.err
+}
+
+// Gap returns a channel that receives a value when notifications might have been missed, see StorageSubscription.Gap.
+func (s *DecodedStorageSubscription) Gap() <-chan struct{} {
+	return s.sub.Gap()
+}
+
+// Unsubscribe unsubscribes the notification and ends the subscription.
+// It can safely be called more than once.
+func (s *DecodedStorageSubscription) Unsubscribe() {
+	s.quitOnce.Do(func() {
+		close(s.quit)
+	})
+	s.sub.Unsubscribe()
+}
+
+// SubscribeStorageDecoded subscribes the storage entries of the queries, whose keys are created based on the latest
+// metadata. Each notification holds the decoded values of the entries that changed in a block, or a Deleted marker
+// for entries that were removed. Notifications of a block that was already received are dropped.
+func (s *state) SubscribeStorageDecoded(queries []StorageQuery) (*DecodedStorageSubscription, error) {
+	return s.SubscribeStorageDecodedContext(context.Background(), queries)
+}
+
+// SubscribeStorageDecodedContext is like SubscribeStorageDecoded but the subscription is ended once ctx is done.
+func (s *state) SubscribeStorageDecodedContext(
+	ctx context.Context,
+	queries []StorageQuery,
+) (*DecodedStorageSubscription, error) {
+	meta, err := s.GetMetadataLatestContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder, err := newStorageChangeDecoder(meta, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.SubscribeStorageRawContext(ctx, decoder.keys)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &DecodedStorageSubscription{
+		sub:     sub,
+		channel: make(chan DecodedStorageChangeSet),
+		err:     make(chan error, 1),
+		quit:    make(chan struct{}),
+	}
+
+	go subscription.run(decoder)
+
+	return subscription, nil
+}
+
+func (s *DecodedStorageSubscription) run(decoder *storageChangeDecoder) {
+	defer close(s.err)
+	defer close(s.channel)
+
+	for {
+		select {
+		case <-s.quit:
+			return
+		case err, ok := <-s.sub.Err():
+			if ok {
+				s.err <- err
+			}
+
+			return
+		case set, ok := <-s.sub.Chan():
+			if !ok {
+				return
+			}
+
+			decoded, ok, err := decoder.decode(set)
+			if err != nil {
+				s.err <- err
+				s.sub.Unsubscribe()
+
+				return
+			}
+
+			if !ok {
+				continue
+			}
+
+			select {
+			case s.channel <- decoded:
+			case <-s.quit:
+				return
+			}
+		}
+	}
+}
+
+// storageValueDecoder decodes the values of a storage entry.
+type storageValueDecoder interface {
+	Decode(data []byte) (any, error)
+}
+
+// targetDecoder decodes values into new pointers of the target type.
+type targetDecoder struct {
+	targetType reflect.Type
+}
+
+func newTargetDecoder(target any) *targetDecoder {
+	targetType := reflect.TypeOf(target)
+	if targetType.Kind() == reflect.Pointer {
+		targetType = targetType.Elem()
+	}
+
+	return &targetDecoder{targetType: targetType}
+}
+
+func (d *targetDecoder) Decode(data []byte) (any, error) {
+	target := reflect.New(d.targetType).Interface()
+
+	if err := codec.Decode(data, target); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// storageChangeDecoder maps the keys of storage change sets to their queries and decodes their values.
+type storageChangeDecoder struct {
+	keys     []types.StorageKey
+	queries  map[string]int
+	decoders []storageValueDecoder
+
+	seen      map[types.Hash]struct{}
+	seenOrder []types.Hash
+}
+
+func newStorageChangeDecoder(meta *types.Metadata, queries []StorageQuery) (*storageChangeDecoder, error) {
+	if len(queries) == 0 {
+		return nil, ErrNoStorageQueries
+	}
+
+	d := &storageChangeDecoder{
+		keys:     make([]types.StorageKey, 0, len(queries)),
+		queries:  make(map[string]int, len(queries)),
+		decoders: make([]storageValueDecoder, 0, len(queries)),
+		seen:     make(map[types.Hash]struct{}),
+	}
+
+	for i, query := range queries {
+		name := query.Pallet + "." + query.Entry
+
+		key, err := types.CreateStorageKey(meta, query.Pallet, query.Entry, query.Args...)
+		if err != nil {
+			return nil, ErrStorageQueryKeyCreation.WithMsg(name).Wrap(err)
+		}
+
+		if _, ok := d.queries[key.Hex()]; ok {
+			return nil, ErrStorageQueryDuplicateKey.WithMsg("%s, query %d", name, i)
+		}
+
+		var decoder storageValueDecoder
+
+		if query.Target != nil {
+			decoder = newTargetDecoder(query.Target)
+		} else {
+			decoder, err = registry.NewStorageValueDecoder(meta, query.Pallet, query.Entry)
+			if err != nil {
+				return nil, ErrStorageQueryDecoderCreation.WithMsg(name).Wrap(err)
+			}
+		}
+
+		d.keys = append(d.keys, key)
+		d.queries[key.Hex()] = i
+		d.decoders = append(d.decoders, decoder)
+	}
+
+	return d, nil
+}
+
+// decode decodes the changes of the queried keys, ok is false if the block was already received.
+func (d *storageChangeDecoder) decode(
+	set types.StorageChangeSet,
+) (decoded DecodedStorageChangeSet, ok bool, err error) {
+	if _, seen := d.seen[set.Block]; seen {
+		return DecodedStorageChangeSet{}, false, nil
+	}
+
+	decoded = DecodedStorageChangeSet{Block: set.Block}
+
+	for _, change := range set.Changes {
+		query, known := d.queries[change.StorageKey.Hex()]
+		if !known {
+			continue
+		}
+
+		decodedChange := DecodedStorageChange{Query: query, Key: change.StorageKey, Deleted: !change.HasStorageData}
+
+		if change.HasStorageData {
+			decodedChange.Value, err = d.decoders[query].Decode(change.StorageData)
+			if err != nil {
+				return DecodedStorageChangeSet{}, false, ErrStorageChangeDecoding.
+					WithMsg("query %d at block %s", query, set.Block.Hex()).
+					Wrap(err)
+			}
+		}
+
+		decoded.Changes = append(decoded.Changes, decodedChange)
+	}
+
+	d.markSeen(set.Block)
+
+	return decoded, true, nil
+}
+
+func (d *storageChangeDecoder) markSeen(block types.Hash) {
+	if len(d.seenOrder) == seenBlocksLimit {
+		delete(d.seen, d.seenOrder[0])
+		d.seenOrder = d.seenOrder[1:]
+	}
+
+	d.seen[block] = struct{}{}
+	d.seenOrder = append(d.seenOrder, block)
+}
@@ -0,0 +1,158 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStorageChangeDecoder(t *testing.T) (*storageChangeDecoder, *types.Metadata) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	require.NoError(t, err)
+
+	decoder, err := newStorageChangeDecoder(&meta, []StorageQuery{
+		{Pallet: "System", Entry: "Number", Target: new(types.U32)},
+		{Pallet: "System", Entry: "Account", Args: [][]byte{signature.TestKeyringPairAlice.PublicKey}},
+		{Pallet: "Timestamp", Entry: "Now"},
+	})
+	require.NoError(t, err)
+
+	return decoder, &meta
+}
+
+func TestStorageChangeDecoder_Decode(t *testing.T) {
+	decoder, _ := newTestStorageChangeDecoder(t)
+	require.Len(t, decoder.keys, 3)
+
+	accountInfo, err := codec.Encode(types.AccountInfo{Nonce: 7})
+	require.NoError(t, err)
+
+	set := types.StorageChangeSet{
+		Block: types.Hash{1},
+		Changes: []types.KeyValueOption{
+			{StorageKey: decoder.keys[2], HasStorageData: true, StorageData: codec.MustHexDecodeString("0x2a00000000000000")},
+			{StorageKey: types.StorageKey{0xff}, HasStorageData: true, StorageData: types.StorageDataRaw{1}},
+			{StorageKey: decoder.keys[0], HasStorageData: true, StorageData: codec.MustHexDecodeString("0x0a000000")},
+			{StorageKey: decoder.keys[1], HasStorageData: true, StorageData: accountInfo},
+		},
+	}
+
+	decoded, ok, err := decoder.decode(set)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.Hash{1}, decoded.Block)
+	require.Len(t, decoded.Changes, 3)
+
+	// Timestamp.Now is decoded dynamically.
+	assert.Equal(t, 2, decoded.Changes[0].Query)
+	assert.Equal(t, decoder.keys[2], decoded.Changes[0].Key)
+	assert.Equal(t, types.NewU64(42), decoded.Changes[0].Value)
+
+	// System.Number is decoded into the target type.
+	blockNumber := types.NewU32(10)
+	assert.Equal(t, DecodedStorageChange{Query: 0, Key: decoder.keys[0], Value: &blockNumber}, decoded.Changes[1])
+
+	// System.Account is decoded into a map of its fields.
+	assert.Equal(t, 1, decoded.Changes[2].Query)
+	assert.Equal(t, types.NewU32(7), decoded.Changes[2].Value.(map[string]any)["nonce"])
+
+	// Notifications of known blocks are dropped.
+	_, ok, err = decoder.decode(set)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	decoded, ok, err = decoder.decode(types.StorageChangeSet{
+		Block:   types.Hash{2},
+		Changes: []types.KeyValueOption{{StorageKey: decoder.keys[1]}},
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []DecodedStorageChange{{Query: 1, Key: decoder.keys[1], Deleted: true}}, decoded.Changes)
+
+	_, ok, err = decoder.decode(set)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestStorageChangeDecoder_Decode_Error(t *testing.T) {
+	decoder, _ := newTestStorageChangeDecoder(t)
+
+	set := types.StorageChangeSet{
+		Block:   types.Hash{1},
+		Changes: []types.KeyValueOption{{StorageKey: decoder.keys[0], HasStorageData: true, StorageData: []byte{1}}},
+	}
+
+	_, _, err := decoder.decode(set)
+	assert.ErrorIs(t, err, ErrStorageChangeDecoding)
+
+	// The block is not marked as received.
+	set.Changes = nil
+
+	_, ok, err := decoder.decode(set)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestStorageChangeDecoder_SeenBlocksLimit(t *testing.T) {
+	decoder, _ := newTestStorageChangeDecoder(t)
+
+	for i := 0; i <= seenBlocksLimit; i++ {
+		_, ok, err := decoder.decode(types.StorageChangeSet{Block: types.Hash{byte(i), byte(i >> 8)}})
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	assert.Len(t, decoder.seen, seenBlocksLimit)
+
+	// The oldest block is forgotten.
+	_, ok, err := decoder.decode(types.StorageChangeSet{Block: types.Hash{}})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestNewStorageChangeDecoder_Errors(t *testing.T) {
+	_, meta := newTestStorageChangeDecoder(t)
+
+	_, err := newStorageChangeDecoder(meta, nil)
+	assert.ErrorIs(t, err, ErrNoStorageQueries)
+
+	_, err = newStorageChangeDecoder(meta, []StorageQuery{{Pallet: "System", Entry: "Unknown"}})
+	assert.ErrorIs(t, err, ErrStorageQueryKeyCreation)
+
+	_, err = newStorageChangeDecoder(meta, []StorageQuery{
+		{Pallet: "System", Entry: "Number"},
+		{Pallet: "System", Entry: "Number", Target: types.U32(0)},
+	})
+	assert.ErrorIs(t, err, ErrStorageQueryDuplicateKey)
+
+	// Values of metadata before V14 can only be decoded into a target.
+	v4 := types.ExamplaryMetadataV4
+
+	_, err = newStorageChangeDecoder(v4, []StorageQuery{{Pallet: "System", Entry: "AccountNonce", Args: [][]byte{{1}}}})
+	assert.ErrorIs(t, err, ErrStorageQueryDecoderCreation)
+	assert.ErrorIs(t, err, registry.ErrStorageEntryNotFound)
+}
@@ -0,0 +1,98 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func subscribeTestStorage(t *testing.T, count int, opts client.SubscriptionOptions) *StorageSubscription {
+	changeSets := make([]interface{}, 0, count)
+
+	for i := 0; i < count; i++ {
+		changeSets = append(changeSets, types.StorageChangeSet{Block: types.Hash{byte(i)}})
+	}
+
+	cl := rpcmocksrv.NewMockClient().Notify("state_subscribeStorage", changeSets)
+
+	sub, err := NewState(cl).SubscribeStorageRawWithOptions(context.Background(), []types.StorageKey{{1}}, opts)
+	require.NoError(t, err)
+
+	t.Cleanup(sub.Unsubscribe)
+
+	return sub
+}
+
+func TestState_SubscribeStorageRawWithOptions(t *testing.T) {
+	t.Run("block", func(t *testing.T) {
+		sub := subscribeTestStorage(t, 10, client.SubscriptionOptions{BufferSize: 2})
+
+		for i := 0; i < 10; i++ {
+			time.Sleep(time.Millisecond)
+
+			assert.Equal(t, types.Hash{byte(i)}, (<-sub.Chan()).Block)
+		}
+
+		assert.Zero(t, sub.Dropped())
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		sub := subscribeTestStorage(t, 10, client.SubscriptionOptions{BufferSize: 3, Policy: client.OverflowDropOldest})
+
+		require.Eventually(t, func() bool { return sub.Dropped() == 7 }, 5*time.Second, time.Millisecond)
+
+		for i := 7; i < 10; i++ {
+			assert.Equal(t, types.Hash{byte(i)}, (<-sub.Chan()).Block)
+		}
+
+		<-sub.Gap()
+	})
+
+	t.Run("error", func(t *testing.T) {
+		sub := subscribeTestStorage(t, 10, client.SubscriptionOptions{Policy: client.OverflowError})
+
+		select {
+		case err := <-sub.Err():
+			assert.ErrorIs(t, err, client.ErrSubscriptionBufferOverflow)
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscription did not fail")
+		}
+
+		assert.Equal(t, types.Hash{0}, (<-sub.Chan()).Block)
+	})
+
+	t.Run("unsubscribe closes the channel", func(t *testing.T) {
+		sub := subscribeTestStorage(t, 10, client.SubscriptionOptions{Policy: client.OverflowDropOldest})
+
+		sub.Unsubscribe()
+		sub.Unsubscribe()
+
+		// Buffered change sets are still received before the channel is closed.
+		assert.Eventually(t, func() bool {
+			_, ok := <-sub.Chan()
+			return !ok
+		}, 5*time.Second, time.Millisecond)
+	})
+}
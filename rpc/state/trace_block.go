@@ -0,0 +1,133 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	ErrTraceBlockUnsafe = libErr.Error(
+		"state_traceBlock refused by the node, it requires --rpc-methods=unsafe and a node built with tracing",
+	)
+)
+
+// TraceBlock re-executes the given block and returns its trace, which is restricted to the given tracing targets,
+// hex encoded storage key prefixes and RPC methods. Empty strings leave the defaults of the node in place. The
+// response is decoded span by span, see types.DecodeTraceBlockResponse.
+//
+// Note that state_traceBlock is an unsafe RPC method, ErrTraceBlockUnsafe is returned if the node refuses the call.
+func (s *state) TraceBlock(blockHash types.Hash, targets, storageKeys, methods string) (*types.BlockTrace, error) {
+	return s.TraceBlockContext(context.Background(), blockHash, targets, storageKeys, methods)
+}
+
+// TraceBlockContext is like TraceBlock but uses the provided context for the RPC call.
+func (s *state) TraceBlockContext(
+	ctx context.Context,
+	blockHash types.Hash,
+	targets, storageKeys, methods string,
+) (*types.BlockTrace, error) {
+	raw, err := s.traceBlock(ctx, blockHash, targets, storageKeys, methods)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.DecodeTraceBlockResponse(bytes.NewReader(raw))
+}
+
+// TraceBlockRaw is like TraceBlock but writes the undecoded JSON response to w, e.g. to store large traces in a file.
+func (s *state) TraceBlockRaw(w io.Writer, blockHash types.Hash, targets, storageKeys, methods string) error {
+	return s.TraceBlockRawContext(context.Background(), w, blockHash, targets, storageKeys, methods)
+}
+
+// TraceBlockRawContext is like TraceBlockRaw but uses the provided context for the RPC call.
+func (s *state) TraceBlockRawContext(
+	ctx context.Context,
+	w io.Writer,
+	blockHash types.Hash,
+	targets, storageKeys, methods string,
+) error {
+	raw, err := s.traceBlock(ctx, blockHash, targets, storageKeys, methods)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(raw)
+
+	return err
+}
+
+func (s *state) traceBlock(
+	ctx context.Context,
+	blockHash types.Hash,
+	targets, storageKeys, methods string,
+) (json.RawMessage, error) {
+	hexHash, err := codec.Hex(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var res json.RawMessage
+
+	err = s.client.CallContext(
+		ctx,
+		&res,
+		"state_traceBlock",
+		hexHash,
+		optionalTraceParam(targets),
+		optionalTraceParam(storageKeys),
+		optionalTraceParam(methods),
+	)
+	if err != nil {
+		if isUnsafeRefusal(err) {
+			return nil, ErrTraceBlockUnsafe.Wrap(err)
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// optionalTraceParam maps empty params to null, so that the node uses its defaults
+func optionalTraceParam(param string) *string {
+	if param == "" {
+		return nil
+	}
+
+	return &param
+}
+
+// isUnsafeRefusal returns true if the node refused an unsafe RPC method, either because unsafe methods are denied or
+// because the method is not exposed at all.
+func isUnsafeRefusal(err error) bool {
+	if types.IsMethodNotFound(err) {
+		return true
+	}
+
+	rpcErr, ok := types.AsRPCError(err)
+
+	return ok && strings.Contains(strings.ToLower(rpcErr.Message), "unsafe")
+}
@@ -0,0 +1,108 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTraceBlockHash = types.Hash{4, 5, 6}
+
+const testTraceBlockResult = `{"blockTrace":{"blockHash":"0x0405","parentHash":"0x0102","tracingTargets":"state",` +
+	`"storageKeys":"26aa394eea5630e07c48ae0c9558cef7","methods":"",` +
+	`"spans":[{"id":1,"parentId":null,"name":"apply_extrinsic","target":"frame_executive","wasm":true}],` +
+	`"events":[{"target":"state","data":{"stringValues":{"key":"26aa394eea5630e07c48ae0c9558cef7"}},"parentId":1}]}}`
+
+func traceBlockFixture(t *testing.T, targets, storageKeys interface{}) rpcmocksrv.Fixture {
+	return rpcmocksrv.Fixture{
+		Method: "state_traceBlock",
+		Params: mustMarshalJSON(t, []interface{}{codec.HexEncodeToString(testTraceBlockHash[:]), targets, storageKeys, nil}),
+		Result: []byte(testTraceBlockResult),
+	}
+}
+
+func TestState_TraceBlock(t *testing.T) {
+	st := newReplayState(t, traceBlockFixture(t, "state", "26aa394eea5630e07c48ae0c9558cef7"))
+
+	trace, err := st.TraceBlock(testTraceBlockHash, "state", "26aa394eea5630e07c48ae0c9558cef7", "")
+	require.NoError(t, err)
+	assert.Equal(t, "0x0405", trace.BlockHash)
+	assert.Len(t, trace.Spans, 1)
+	assert.Len(t, trace.Events, 1)
+}
+
+func TestState_TraceBlock_DefaultParams(t *testing.T) {
+	st := newReplayState(t, traceBlockFixture(t, nil, nil))
+
+	trace, err := st.TraceBlock(testTraceBlockHash, "", "", "")
+	require.NoError(t, err)
+	assert.Len(t, trace.Spans, 1)
+}
+
+func TestState_TraceBlockRaw(t *testing.T) {
+	st := newReplayState(t, traceBlockFixture(t, "state", nil))
+
+	var buf bytes.Buffer
+	err := st.TraceBlockRaw(&buf, testTraceBlockHash, "state", "", "")
+	require.NoError(t, err)
+	assert.JSONEq(t, testTraceBlockResult, buf.String())
+}
+
+func TestState_TraceBlock_TraceError(t *testing.T) {
+	st := newReplayState(t, rpcmocksrv.Fixture{
+		Method: "state_traceBlock",
+		Result: []byte(`{"traceError":{"error":"Invalid block hash"}}`),
+	})
+
+	_, err := st.TraceBlock(testTraceBlockHash, "", "", "")
+
+	var traceErr types.TraceError
+	require.True(t, errors.As(err, &traceErr))
+	assert.Equal(t, "Invalid block hash", traceErr.Message)
+}
+
+func TestState_TraceBlock_Unsafe(t *testing.T) {
+	st := newReplayState(t, rpcmocksrv.Fixture{
+		Method: "state_traceBlock",
+		Error: &rpcmocksrv.FixtureError{
+			Code:    types.RPCErrorCodeMethodNotFound,
+			Message: "RPC call is unsafe to be called externally",
+		},
+	})
+
+	_, err := st.TraceBlock(testTraceBlockHash, "", "", "")
+	assert.True(t, errors.Is(err, ErrTraceBlockUnsafe))
+
+	err = st.TraceBlockRaw(&bytes.Buffer{}, testTraceBlockHash, "", "", "")
+	assert.True(t, errors.Is(err, ErrTraceBlockUnsafe))
+}
+
+func TestState_TraceBlock_NotExposed(t *testing.T) {
+	// the replay server answers requests without a fixture with method not found
+	st := newReplayState(t)
+
+	_, err := st.TraceBlock(testTraceBlockHash, "", "", "")
+	assert.True(t, errors.Is(err, ErrTraceBlockUnsafe))
+}
@@ -0,0 +1,43 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncstate
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// GenSyncSpec returns the chain spec of the node, extended by the light sync state at the latest finalized block, so
+// that light clients can start syncing from there instead of from genesis. If raw is true, the genesis is returned as
+// raw storage. The method is only exposed by nodes that support light client bootstrapping, e.g. BABE and GRANDPA
+// based ones.
+func (s *syncState) GenSyncSpec(raw bool) (*types.ChainSpec, error) {
+	return s.GenSyncSpecContext(context.Background(), raw)
+}
+
+// GenSyncSpecContext is like GenSyncSpec but uses the provided context for the RPC call.
+func (s *syncState) GenSyncSpecContext(ctx context.Context, raw bool) (*types.ChainSpec, error) {
+	var res types.ChainSpec
+
+	err := s.client.CallContext(ctx, &res, "sync_state_genSyncSpec", raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,62 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncstate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncState_GenSyncSpec(t *testing.T) {
+	res, err := testSyncState.GenSyncSpec(true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Development", res.Name)
+	assert.Equal(t, "dev", res.ID)
+	assert.True(t, res.ChainType.IsDevelopment)
+	assert.Empty(t, res.BootNodes)
+
+	var genesis struct {
+		Raw struct {
+			Top map[string]string `json:"top"`
+		} `json:"raw"`
+	}
+	require.NoError(t, json.Unmarshal(res.Genesis, &genesis))
+	assert.Equal(t, "0x0061736d", genesis.Raw.Top["0x3a636f6465"])
+
+	require.NotNil(t, res.LightSyncState)
+	assert.EqualValues(t, 5, res.LightSyncState.FinalizedBlockHeader.Number)
+	assert.Equal(
+		t,
+		types.NewHash(codec.MustHexDecodeString("0x"+strings.Repeat("11", 32))),
+		res.LightSyncState.FinalizedBlockHeader.ParentHash,
+	)
+	assert.Equal(t, types.Bytes{4, 0}, res.LightSyncState.BabeEpochChanges)
+	assert.EqualValues(t, 5, res.LightSyncState.BabeFinalizedBlockWeight)
+	assert.Equal(t, types.Bytes{8, 0}, res.LightSyncState.GrandpaAuthoritySet)
+
+	// the complete chain spec is kept, e.g. to write it to a file
+	var raw map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(res.Raw, &raw))
+	assert.Contains(t, raw, "codeSubstitutes")
+}
@@ -0,0 +1,45 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// accountNextIndexNetwork is the SS58 network prefix of the address that is sent to the node, the generic Substrate
+// prefix is accepted by every chain.
+const accountNextIndexNetwork = 42
+
+// AccountNextIndex retrieves the next nonce of the account, which takes the extrinsics of the account that are in the
+// transaction pool of the node into account.
+func (c *system) AccountNextIndex(accountID types.AccountID) (types.U64, error) {
+	return c.AccountNextIndexContext(context.Background(), accountID)
+}
+
+// AccountNextIndexContext is like AccountNextIndex but uses the provided context for the RPC call.
+func (c *system) AccountNextIndexContext(ctx context.Context, accountID types.AccountID) (types.U64, error) {
+	address, err := accountID.ToSS58(accountNextIndexNetwork)
+	if err != nil {
+		return 0, err
+	}
+
+	var nonce types.U64
+	err = c.client.CallContext(ctx, &nonce, "system_accountNextIndex", address)
+	return nonce, err
+}
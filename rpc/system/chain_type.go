@@ -0,0 +1,35 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ChainType retrieves the type of the chain, e.g. Development or Live
+func (c *system) ChainType() (types.ChainType, error) {
+	return c.ChainTypeContext(context.Background())
+}
+
+// ChainTypeContext is like ChainType but uses the provided context for the RPC call.
+func (c *system) ChainTypeContext(ctx context.Context) (types.ChainType, error) {
+	var t types.ChainType
+	err := c.client.CallContext(ctx, &t, "system_chainType")
+	return t, err
+}
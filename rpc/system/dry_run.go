@@ -0,0 +1,75 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// DryRun applies the extrinsic on top of the state of the given block without importing it and returns the result.
+// Note that system_dryRun is an unsafe RPC method and might not be exposed by public nodes.
+func (c *system) DryRun(xt types.Extrinsic, blockHash types.Hash) (types.ApplyExtrinsicResult, error) {
+	return c.DryRunContext(context.Background(), xt, blockHash)
+}
+
+// DryRunContext is like DryRun but uses the provided context for the RPC call.
+func (c *system) DryRunContext(
+	ctx context.Context,
+	xt types.Extrinsic,
+	blockHash types.Hash,
+) (types.ApplyExtrinsicResult, error) {
+	return c.dryRun(ctx, xt, &blockHash)
+}
+
+// DryRunLatest applies the extrinsic on top of the state of the latest block without importing it and returns the
+// result.
+func (c *system) DryRunLatest(xt types.Extrinsic) (types.ApplyExtrinsicResult, error) {
+	return c.DryRunLatestContext(context.Background(), xt)
+}
+
+// DryRunLatestContext is like DryRunLatest but uses the provided context for the RPC call.
+func (c *system) DryRunLatestContext(ctx context.Context, xt types.Extrinsic) (types.ApplyExtrinsicResult, error) {
+	return c.dryRun(ctx, xt, nil)
+}
+
+func (c *system) dryRun(
+	ctx context.Context,
+	xt types.Extrinsic,
+	blockHash *types.Hash,
+) (types.ApplyExtrinsicResult, error) {
+	enc, err := codec.EncodeToHex(xt)
+	if err != nil {
+		return types.ApplyExtrinsicResult{}, err
+	}
+
+	var res string
+	err = client.CallWithBlockHashContext(ctx, c.client, &res, "system_dryRun", blockHash, enc)
+	if err != nil {
+		return types.ApplyExtrinsicResult{}, err
+	}
+
+	var result types.ApplyExtrinsicResult
+	if err := codec.DecodeFromHex(res, &result); err != nil {
+		return types.ApplyExtrinsicResult{}, err
+	}
+
+	return result, nil
+}
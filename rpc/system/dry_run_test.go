@@ -0,0 +1,38 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystem_DryRunLatest(t *testing.T) {
+	res, err := testSystem.DryRunLatest(types.NewExtrinsic(types.Call{}))
+	assert.NoError(t, err)
+	assert.Equal(t, types.ApplyExtrinsicResult{IsOk: true, Ok: types.DispatchOutcome{IsOk: true}}, res)
+}
+
+func TestSystem_DryRun(t *testing.T) {
+	res, err := testSystem.DryRun(types.NewExtrinsic(types.Call{Args: []byte{1}}), types.Hash{1, 2, 3})
+	assert.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.True(t, res.Error.IsInvalid)
+	assert.Equal(t, types.InvalidTransactionPayment, res.Error.InvalidTransaction.Kind)
+}
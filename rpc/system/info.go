@@ -0,0 +1,63 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Info holds the node information retrieved by System.Info, the result of each call is reported separately.
+type Info struct {
+	Chain      client.BatchResult[types.Text]
+	Name       client.BatchResult[types.Text]
+	Version    client.BatchResult[types.Text]
+	Properties client.BatchResult[types.ChainProperties]
+	Health     client.BatchResult[types.Health]
+}
+
+// Info retrieves the chain, name, version, properties and health of the connected node in a single batch request
+func (c *system) Info() (*Info, error) {
+	return c.InfoContext(context.Background())
+}
+
+// InfoContext is like Info but uses the provided context for the RPC call.
+func (c *system) InfoContext(ctx context.Context) (*Info, error) {
+	var info Info
+
+	batch := client.NewBatch(c.client)
+
+	chainIdx := batch.Add(&info.Chain.Value, "system_chain")
+	nameIdx := batch.Add(&info.Name.Value, "system_name")
+	versionIdx := batch.Add(&info.Version.Value, "system_version")
+	propertiesIdx := batch.Add(&info.Properties.Value, "system_properties")
+	healthIdx := batch.Add(&info.Health.Value, "system_health")
+
+	if err := batch.Send(ctx); err != nil {
+		return nil, err
+	}
+
+	info.Chain.Err = batch.Err(chainIdx)
+	info.Name.Err = batch.Err(nameIdx)
+	info.Version.Err = batch.Err(versionIdx)
+	info.Properties.Err = batch.Err(propertiesIdx)
+	info.Health.Err = batch.Err(healthIdx)
+
+	return &info, nil
+}
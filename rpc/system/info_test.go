@@ -0,0 +1,39 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystem_Info(t *testing.T) {
+	info, err := testSystem.Info()
+	assert.NoError(t, err)
+
+	assert.NoError(t, info.Chain.Err)
+	assert.Equal(t, mockSrv.chain, info.Chain.Value)
+	assert.NoError(t, info.Name.Err)
+	assert.Equal(t, mockSrv.name, info.Name.Value)
+	assert.NoError(t, info.Version.Err)
+	assert.Equal(t, mockSrv.version, info.Version.Value)
+	assert.NoError(t, info.Properties.Err)
+	assert.Equal(t, mockSrv.properties, info.Properties.Value)
+	assert.NoError(t, info.Health.Err)
+	assert.Equal(t, mockSrv.health, info.Health.Value)
+}
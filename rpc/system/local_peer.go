@@ -0,0 +1,48 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// LocalPeerID retrieves the base58 encoded peer ID of the node
+func (c *system) LocalPeerID() (types.Text, error) {
+	return c.LocalPeerIDContext(context.Background())
+}
+
+// LocalPeerIDContext is like LocalPeerID but uses the provided context for the RPC call.
+func (c *system) LocalPeerIDContext(ctx context.Context) (types.Text, error) {
+	var t types.Text
+	err := c.client.CallContext(ctx, &t, "system_localPeerId")
+	return t, err
+}
+
+// LocalListenAddresses retrieves the multiaddresses the node listens on, including its peer ID. They can be used as
+// boot nodes or reserved peers of other nodes.
+func (c *system) LocalListenAddresses() ([]types.Text, error) {
+	return c.LocalListenAddressesContext(context.Background())
+}
+
+// LocalListenAddressesContext is like LocalListenAddresses but uses the provided context for the RPC call.
+func (c *system) LocalListenAddressesContext(ctx context.Context) ([]types.Text, error) {
+	var addrs []types.Text
+	err := c.client.CallContext(ctx, &addrs, "system_localListenAddresses")
+	return addrs, err
+}
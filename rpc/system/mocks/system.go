@@ -3,8 +3,12 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
+	system "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system"
+
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
@@ -13,6 +17,76 @@ type System struct {
 	mock.Mock
 }
 
+// AccountNextIndex provides a mock function with given fields: accountID
+func (_m *System) AccountNextIndex(accountID types.AccountID) (types.U64, error) {
+	ret := _m.Called(accountID)
+
+	var r0 types.U64
+	if rf, ok := ret.Get(0).(func(types.AccountID) types.U64); ok {
+		r0 = rf(accountID)
+	} else {
+		r0 = ret.Get(0).(types.U64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.AccountID) error); ok {
+		r1 = rf(accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccountNextIndexContext provides a mock function with given fields: ctx, accountID
+func (_m *System) AccountNextIndexContext(ctx context.Context, accountID types.AccountID) (types.U64, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 types.U64
+	if rf, ok := ret.Get(0).(func(context.Context, types.AccountID) types.U64); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(types.U64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.AccountID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddReservedPeer provides a mock function with given fields: peer
+func (_m *System) AddReservedPeer(peer string) error {
+	ret := _m.Called(peer)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(peer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddReservedPeerContext provides a mock function with given fields: ctx, peer
+func (_m *System) AddReservedPeerContext(ctx context.Context, peer string) error {
+	ret := _m.Called(ctx, peer)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, peer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Chain provides a mock function with given fields:
 func (_m *System) Chain() (types.Text, error) {
 	ret := _m.Called()
@@ -34,6 +108,153 @@ func (_m *System) Chain() (types.Text, error) {
 	return r0, r1
 }
 
+// ChainContext provides a mock function with given fields: ctx
+func (_m *System) ChainContext(ctx context.Context) (types.Text, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Text
+	if rf, ok := ret.Get(0).(func(context.Context) types.Text); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Text)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChainType provides a mock function with given fields:
+func (_m *System) ChainType() (types.ChainType, error) {
+	ret := _m.Called()
+
+	var r0 types.ChainType
+	if rf, ok := ret.Get(0).(func() types.ChainType); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(types.ChainType)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChainTypeContext provides a mock function with given fields: ctx
+func (_m *System) ChainTypeContext(ctx context.Context) (types.ChainType, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.ChainType
+	if rf, ok := ret.Get(0).(func(context.Context) types.ChainType); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.ChainType)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DryRun provides a mock function with given fields: xt, blockHash
+func (_m *System) DryRun(xt types.Extrinsic, blockHash types.Hash) (types.ApplyExtrinsicResult, error) {
+	ret := _m.Called(xt, blockHash)
+
+	var r0 types.ApplyExtrinsicResult
+	if rf, ok := ret.Get(0).(func(types.Extrinsic, types.Hash) types.ApplyExtrinsicResult); ok {
+		r0 = rf(xt, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.ApplyExtrinsicResult)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Extrinsic, types.Hash) error); ok {
+		r1 = rf(xt, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DryRunContext provides a mock function with given fields: ctx, xt, blockHash
+func (_m *System) DryRunContext(ctx context.Context, xt types.Extrinsic, blockHash types.Hash) (types.ApplyExtrinsicResult, error) {
+	ret := _m.Called(ctx, xt, blockHash)
+
+	var r0 types.ApplyExtrinsicResult
+	if rf, ok := ret.Get(0).(func(context.Context, types.Extrinsic, types.Hash) types.ApplyExtrinsicResult); ok {
+		r0 = rf(ctx, xt, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.ApplyExtrinsicResult)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Extrinsic, types.Hash) error); ok {
+		r1 = rf(ctx, xt, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DryRunLatest provides a mock function with given fields: xt
+func (_m *System) DryRunLatest(xt types.Extrinsic) (types.ApplyExtrinsicResult, error) {
+	ret := _m.Called(xt)
+
+	var r0 types.ApplyExtrinsicResult
+	if rf, ok := ret.Get(0).(func(types.Extrinsic) types.ApplyExtrinsicResult); ok {
+		r0 = rf(xt)
+	} else {
+		r0 = ret.Get(0).(types.ApplyExtrinsicResult)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Extrinsic) error); ok {
+		r1 = rf(xt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DryRunLatestContext provides a mock function with given fields: ctx, xt
+func (_m *System) DryRunLatestContext(ctx context.Context, xt types.Extrinsic) (types.ApplyExtrinsicResult, error) {
+	ret := _m.Called(ctx, xt)
+
+	var r0 types.ApplyExtrinsicResult
+	if rf, ok := ret.Get(0).(func(context.Context, types.Extrinsic) types.ApplyExtrinsicResult); ok {
+		r0 = rf(ctx, xt)
+	} else {
+		r0 = ret.Get(0).(types.ApplyExtrinsicResult)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Extrinsic) error); ok {
+		r1 = rf(ctx, xt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Health provides a mock function with given fields:
 func (_m *System) Health() (types.Health, error) {
 	ret := _m.Called()
@@ -55,6 +276,161 @@ func (_m *System) Health() (types.Health, error) {
 	return r0, r1
 }
 
+// HealthContext provides a mock function with given fields: ctx
+func (_m *System) HealthContext(ctx context.Context) (types.Health, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Health
+	if rf, ok := ret.Get(0).(func(context.Context) types.Health); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Health)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Info provides a mock function with given fields:
+func (_m *System) Info() (*system.Info, error) {
+	ret := _m.Called()
+
+	var r0 *system.Info
+	if rf, ok := ret.Get(0).(func() *system.Info); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*system.Info)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InfoContext provides a mock function with given fields: ctx
+func (_m *System) InfoContext(ctx context.Context) (*system.Info, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *system.Info
+	if rf, ok := ret.Get(0).(func(context.Context) *system.Info); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*system.Info)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LocalListenAddresses provides a mock function with given fields:
+func (_m *System) LocalListenAddresses() ([]types.Text, error) {
+	ret := _m.Called()
+
+	var r0 []types.Text
+	if rf, ok := ret.Get(0).(func() []types.Text); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Text)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LocalListenAddressesContext provides a mock function with given fields: ctx
+func (_m *System) LocalListenAddressesContext(ctx context.Context) ([]types.Text, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []types.Text
+	if rf, ok := ret.Get(0).(func(context.Context) []types.Text); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Text)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LocalPeerID provides a mock function with given fields:
+func (_m *System) LocalPeerID() (types.Text, error) {
+	ret := _m.Called()
+
+	var r0 types.Text
+	if rf, ok := ret.Get(0).(func() types.Text); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(types.Text)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LocalPeerIDContext provides a mock function with given fields: ctx
+func (_m *System) LocalPeerIDContext(ctx context.Context) (types.Text, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Text
+	if rf, ok := ret.Get(0).(func(context.Context) types.Text); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Text)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Name provides a mock function with given fields:
 func (_m *System) Name() (types.Text, error) {
 	ret := _m.Called()
@@ -76,6 +452,27 @@ func (_m *System) Name() (types.Text, error) {
 	return r0, r1
 }
 
+// NameContext provides a mock function with given fields: ctx
+func (_m *System) NameContext(ctx context.Context) (types.Text, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Text
+	if rf, ok := ret.Get(0).(func(context.Context) types.Text); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Text)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NetworkState provides a mock function with given fields:
 func (_m *System) NetworkState() (types.NetworkState, error) {
 	ret := _m.Called()
@@ -97,6 +494,27 @@ func (_m *System) NetworkState() (types.NetworkState, error) {
 	return r0, r1
 }
 
+// NetworkStateContext provides a mock function with given fields: ctx
+func (_m *System) NetworkStateContext(ctx context.Context) (types.NetworkState, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.NetworkState
+	if rf, ok := ret.Get(0).(func(context.Context) types.NetworkState); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.NetworkState)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Peers provides a mock function with given fields:
 func (_m *System) Peers() ([]types.PeerInfo, error) {
 	ret := _m.Called()
@@ -120,6 +538,29 @@ func (_m *System) Peers() ([]types.PeerInfo, error) {
 	return r0, r1
 }
 
+// PeersContext provides a mock function with given fields: ctx
+func (_m *System) PeersContext(ctx context.Context) ([]types.PeerInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []types.PeerInfo
+	if rf, ok := ret.Get(0).(func(context.Context) []types.PeerInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.PeerInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Properties provides a mock function with given fields:
 func (_m *System) Properties() (types.ChainProperties, error) {
 	ret := _m.Called()
@@ -141,6 +582,143 @@ func (_m *System) Properties() (types.ChainProperties, error) {
 	return r0, r1
 }
 
+// PropertiesContext provides a mock function with given fields: ctx
+func (_m *System) PropertiesContext(ctx context.Context) (types.ChainProperties, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.ChainProperties
+	if rf, ok := ret.Get(0).(func(context.Context) types.ChainProperties); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.ChainProperties)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveReservedPeer provides a mock function with given fields: peerID
+func (_m *System) RemoveReservedPeer(peerID string) error {
+	ret := _m.Called(peerID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(peerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveReservedPeerContext provides a mock function with given fields: ctx, peerID
+func (_m *System) RemoveReservedPeerContext(ctx context.Context, peerID string) error {
+	ret := _m.Called(ctx, peerID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, peerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReservedPeers provides a mock function with given fields:
+func (_m *System) ReservedPeers() ([]types.Text, error) {
+	ret := _m.Called()
+
+	var r0 []types.Text
+	if rf, ok := ret.Get(0).(func() []types.Text); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Text)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReservedPeersContext provides a mock function with given fields: ctx
+func (_m *System) ReservedPeersContext(ctx context.Context) ([]types.Text, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []types.Text
+	if rf, ok := ret.Get(0).(func(context.Context) []types.Text); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Text)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncState provides a mock function with given fields:
+func (_m *System) SyncState() (types.SyncState, error) {
+	ret := _m.Called()
+
+	var r0 types.SyncState
+	if rf, ok := ret.Get(0).(func() types.SyncState); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(types.SyncState)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncStateContext provides a mock function with given fields: ctx
+func (_m *System) SyncStateContext(ctx context.Context) (types.SyncState, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.SyncState
+	if rf, ok := ret.Get(0).(func(context.Context) types.SyncState); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.SyncState)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Version provides a mock function with given fields:
 func (_m *System) Version() (types.Text, error) {
 	ret := _m.Called()
@@ -162,6 +740,27 @@ func (_m *System) Version() (types.Text, error) {
 	return r0, r1
 }
 
+// VersionContext provides a mock function with given fields: ctx
+func (_m *System) VersionContext(ctx context.Context) (types.Text, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Text
+	if rf, ok := ret.Get(0).(func(context.Context) types.Text); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Text)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewSystemT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,58 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ReservedPeers retrieves the peer IDs of the reserved peers of the node
+func (c *system) ReservedPeers() ([]types.Text, error) {
+	return c.ReservedPeersContext(context.Background())
+}
+
+// ReservedPeersContext is like ReservedPeers but uses the provided context for the RPC call.
+func (c *system) ReservedPeersContext(ctx context.Context) ([]types.Text, error) {
+	var peers []types.Text
+	err := c.client.CallContext(ctx, &peers, "system_reservedPeers")
+	return peers, err
+}
+
+// AddReservedPeer adds a reserved peer to the node, given as multiaddress including the peer ID, e.g.
+// /ip4/198.51.100.19/tcp/30333/p2p/QmSk5HQbn6LhUwDiNMseVUjuRYhEtYj4aUZ6WfWoGURpdV. Note that system_addReservedPeer is
+// an unsafe RPC method and might not be exposed by public nodes.
+func (c *system) AddReservedPeer(peer string) error {
+	return c.AddReservedPeerContext(context.Background(), peer)
+}
+
+// AddReservedPeerContext is like AddReservedPeer but uses the provided context for the RPC call.
+func (c *system) AddReservedPeerContext(ctx context.Context, peer string) error {
+	return c.client.CallContext(ctx, nil, "system_addReservedPeer", peer)
+}
+
+// RemoveReservedPeer removes the reserved peer with the given peer ID from the node. Note that
+// system_removeReservedPeer is an unsafe RPC method and might not be exposed by public nodes.
+func (c *system) RemoveReservedPeer(peerID string) error {
+	return c.RemoveReservedPeerContext(context.Background(), peerID)
+}
+
+// RemoveReservedPeerContext is like RemoveReservedPeer but uses the provided context for the RPC call.
+func (c *system) RemoveReservedPeerContext(ctx context.Context, peerID string) error {
+	return c.client.CallContext(ctx, nil, "system_removeReservedPeer", peerID)
+}
@@ -0,0 +1,50 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystem_ReservedPeers(t *testing.T) {
+	const peerID = "12D3KooWHdiAxVd8uMQR1hGWXccidmfCwLqcMpGwR6QcTP6QRMuD"
+
+	peers, err := testSystem.ReservedPeers()
+	require.NoError(t, err)
+	assert.Empty(t, peers)
+
+	err = testSystem.AddReservedPeer("/ip4/127.0.0.1/tcp/30334/p2p/" + peerID)
+	require.NoError(t, err)
+
+	peers, err = testSystem.ReservedPeers()
+	require.NoError(t, err)
+	assert.Equal(t, []types.Text{peerID}, peers)
+
+	err = testSystem.RemoveReservedPeer(peerID)
+	require.NoError(t, err)
+
+	peers, err = testSystem.ReservedPeers()
+	require.NoError(t, err)
+	assert.Empty(t, peers)
+
+	assert.Error(t, testSystem.AddReservedPeer("/ip4/127.0.0.1/tcp/30334"))
+	assert.Error(t, testSystem.RemoveReservedPeer(peerID))
+}
@@ -19,18 +19,49 @@
 package system
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 type System interface {
 	Properties() (types.ChainProperties, error)
+	PropertiesContext(ctx context.Context) (types.ChainProperties, error)
 	Health() (types.Health, error)
+	HealthContext(ctx context.Context) (types.Health, error)
+	SyncState() (types.SyncState, error)
+	SyncStateContext(ctx context.Context) (types.SyncState, error)
 	Peers() ([]types.PeerInfo, error)
+	PeersContext(ctx context.Context) ([]types.PeerInfo, error)
 	Name() (types.Text, error)
+	NameContext(ctx context.Context) (types.Text, error)
 	Chain() (types.Text, error)
+	ChainContext(ctx context.Context) (types.Text, error)
+	ChainType() (types.ChainType, error)
+	ChainTypeContext(ctx context.Context) (types.ChainType, error)
 	Version() (types.Text, error)
+	VersionContext(ctx context.Context) (types.Text, error)
 	NetworkState() (types.NetworkState, error)
+	NetworkStateContext(ctx context.Context) (types.NetworkState, error)
+	LocalPeerID() (types.Text, error)
+	LocalPeerIDContext(ctx context.Context) (types.Text, error)
+	LocalListenAddresses() ([]types.Text, error)
+	LocalListenAddressesContext(ctx context.Context) ([]types.Text, error)
+	ReservedPeers() ([]types.Text, error)
+	ReservedPeersContext(ctx context.Context) ([]types.Text, error)
+	AddReservedPeer(peer string) error
+	AddReservedPeerContext(ctx context.Context, peer string) error
+	RemoveReservedPeer(peerID string) error
+	RemoveReservedPeerContext(ctx context.Context, peerID string) error
+	Info() (*Info, error)
+	InfoContext(ctx context.Context) (*Info, error)
+	DryRun(xt types.Extrinsic, blockHash types.Hash) (types.ApplyExtrinsicResult, error)
+	DryRunContext(ctx context.Context, xt types.Extrinsic, blockHash types.Hash) (types.ApplyExtrinsicResult, error)
+	DryRunLatest(xt types.Extrinsic) (types.ApplyExtrinsicResult, error)
+	DryRunLatestContext(ctx context.Context, xt types.Extrinsic) (types.ApplyExtrinsicResult, error)
+	AccountNextIndex(accountID types.AccountID) (types.U64, error)
+	AccountNextIndexContext(ctx context.Context, accountID types.AccountID) (types.U64, error)
 }
 
 // system exposes methods for retrieval of system data
@@ -17,13 +17,16 @@
 package system
 
 import (
+	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
@@ -48,23 +51,73 @@ func TestMain(m *testing.M) {
 
 // MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
 type MockSrv struct {
-	chain        types.Text
-	health       types.Health
-	name         types.Text
-	networkState types.NetworkState
-	peers        []types.PeerInfo
-	properties   types.ChainProperties
-	version      types.Text
+	chain           types.Text
+	chainType       types.ChainType
+	health          types.Health
+	listenAddresses []types.Text
+	localPeerID     types.Text
+	name            types.Text
+	networkState    types.NetworkState
+	peers           []types.PeerInfo
+	properties      types.ChainProperties
+	reservedPeers   []types.Text
+	syncState       types.SyncState
+	version         types.Text
+}
+
+func (s *MockSrv) AccountNextIndex(address string) (uint64, error) {
+	if address != signature.TestKeyringPairAlice.Address {
+		return 0, errors.New("invalid address")
+	}
+
+	return 7, nil
+}
+
+func (s *MockSrv) AddReservedPeer(peer string) error {
+	// reserved peers are given as multiaddress, the peer ID is the last component
+	idx := strings.LastIndex(peer, "/p2p/")
+	if idx < 0 {
+		return errors.New("invalid multiaddress: missing peer id")
+	}
+
+	mockSrv.reservedPeers = append(mockSrv.reservedPeers, types.Text(peer[idx+len("/p2p/"):]))
+
+	return nil
 }
 
 func (s *MockSrv) Chain() types.Text {
 	return mockSrv.chain
 }
 
+func (s *MockSrv) ChainType() types.ChainType {
+	return mockSrv.chainType
+}
+
+func (s *MockSrv) DryRun(xt string, hash *string) string {
+	// the empty call is encoded as 0x0c040000, everything else is rejected with an invalid payment
+	if xt == "0x0c040000" {
+		return "0x0000"
+	}
+
+	return "0x010001"
+}
+
 func (s *MockSrv) Health() types.Health {
 	return mockSrv.health
 }
 
+func (s *MockSrv) SyncState() types.SyncState {
+	return mockSrv.syncState
+}
+
+func (s *MockSrv) LocalListenAddresses() []types.Text {
+	return mockSrv.listenAddresses
+}
+
+func (s *MockSrv) LocalPeerId() types.Text { //nolint:stylecheck,golint
+	return mockSrv.localPeerID
+}
+
 func (s *MockSrv) Name() types.Text {
 	return mockSrv.name
 }
@@ -81,6 +134,21 @@ func (s *MockSrv) Properties() types.ChainProperties {
 	return mockSrv.properties
 }
 
+func (s *MockSrv) RemoveReservedPeer(peerID string) error {
+	for i, peer := range mockSrv.reservedPeers {
+		if string(peer) == peerID {
+			mockSrv.reservedPeers = append(mockSrv.reservedPeers[:i], mockSrv.reservedPeers[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New("peer not reserved")
+}
+
+func (s *MockSrv) ReservedPeers() []types.Text {
+	return mockSrv.reservedPeers
+}
+
 func (s *MockSrv) Version() types.Text {
 	return mockSrv.version
 }
@@ -89,8 +157,14 @@ func (s *MockSrv) Version() types.Text {
 // against real servers and update the values stored here. To do that, replace s.URL with
 // config.Default().RPCURL
 var mockSrv = MockSrv{
-	chain:        "test-chain",
-	health:       types.Health{Peers: 2, IsSyncing: false, ShouldHavePeers: true},
+	chain:     "test-chain",
+	chainType: types.ChainType{IsDevelopment: true},
+	health:    types.Health{Peers: 2, IsSyncing: false, ShouldHavePeers: true},
+	syncState: types.SyncState{StartingBlock: 0, CurrentBlock: 42, HighestBlock: 45},
+	listenAddresses: []types.Text{
+		"/ip4/127.0.0.1/tcp/30333/p2p/12D3KooWEyoppNCUx8Yx66oV9fJnriXwCcXwDDUA2kj6vnc6iDEp",
+	},
+	localPeerID:  "12D3KooWEyoppNCUx8Yx66oV9fJnriXwCcXwDDUA2kj6vnc6iDEp",
 	name:         "test-node",
 	networkState: types.NetworkState{PeerID: "my-peer-id"},
 	peers: []types.PeerInfo{{PeerID: "another-peer-id", Roles: "Role", ProtocolVersion: 42,
@@ -0,0 +1,73 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transaction
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// BroadcastOperation is a broadcast started through Transaction.Broadcast.
+type BroadcastOperation struct {
+	client      client.Client
+	operationID string
+}
+
+// OperationID returns the ID of the broadcast, as assigned by the node.
+func (o *BroadcastOperation) OperationID() string {
+	return o.operationID
+}
+
+// Stop stops the node from broadcasting the transaction.
+func (o *BroadcastOperation) Stop() error {
+	return o.StopContext(context.Background())
+}
+
+// StopContext is like Stop but uses the provided context for the RPC call.
+func (o *BroadcastOperation) StopContext(ctx context.Context) error {
+	return mapError(o.client.CallContext(ctx, nil, "transaction_v1_stop", o.operationID))
+}
+
+// Broadcast will make the node broadcast a fully formatted extrinsic to its peers until the broadcast is stopped.
+//
+// Contrary to SubmitAndWatch, the extrinsic is not validated by the node before broadcasting it.
+func (t *transaction) Broadcast(xt types.Extrinsic) (*BroadcastOperation, error) {
+	return t.BroadcastContext(context.Background(), xt)
+}
+
+// BroadcastContext is like Broadcast but uses the provided context for the RPC call.
+func (t *transaction) BroadcastContext(ctx context.Context, xt types.Extrinsic) (*BroadcastOperation, error) {
+	enc, err := codec.EncodeToHex(xt)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *string
+
+	if err := t.client.CallContext(ctx, &res, "transaction_v1_broadcast", enc); err != nil {
+		return nil, mapError(err)
+	}
+
+	if res == nil {
+		return nil, ErrBroadcastLimitReached
+	}
+
+	return &BroadcastOperation{client: t.client, operationID: *res}, nil
+}
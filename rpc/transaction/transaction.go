@@ -0,0 +1,70 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name Transaction --filename transaction.go
+
+package transaction
+
+import (
+	"context"
+
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	ErrNotSupported          = libErr.Error("method not supported by the node")
+	ErrBroadcastLimitReached = libErr.Error("broadcast limit reached")
+)
+
+// methodNotFoundCode is the JSON-RPC error code returned for methods that the node does not expose.
+const methodNotFoundCode = -32601
+
+// Transaction exposes the transaction_v1 and transactionWatch_v1 groups of RPC methods, which replace
+// author_submitExtrinsic and author_submitAndWatchExtrinsic.
+//
+// Nodes that don't expose these methods cause an ErrNotSupported, in which case the Author module can be used instead.
+type Transaction interface {
+	Broadcast(xt types.Extrinsic) (*BroadcastOperation, error)
+	BroadcastContext(ctx context.Context, xt types.Extrinsic) (*BroadcastOperation, error)
+	SubmitAndWatch(xt types.Extrinsic) (*WatchSubscription, error)
+	SubmitAndWatchContext(ctx context.Context, xt types.Extrinsic) (*WatchSubscription, error)
+}
+
+// transaction exposes methods for submitting transactions
+type transaction struct {
+	client client.Client
+}
+
+// NewTransaction creates a new transaction struct
+func NewTransaction(cl client.Client) Transaction {
+	return &transaction{cl}
+}
+
+// mapError returns ErrNotSupported if the node does not expose the called method.
+func mapError(err error) error {
+	var rpcErr gethrpc.Error
+
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundCode {
+		return ErrNotSupported.Wrap(err)
+	}
+
+	return err
+}
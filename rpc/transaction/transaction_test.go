@@ -0,0 +1,143 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transaction
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testRPCError struct {
+	code int
+}
+
+func (e testRPCError) Error() string {
+	return "rpc error"
+}
+
+func (e testRPCError) ErrorCode() int {
+	return e.code
+}
+
+var testExtrinsic = types.Extrinsic{
+	Version: types.ExtrinsicVersion4,
+	Method: types.Call{
+		CallIndex: types.CallIndex{SectionIndex: 4, MethodIndex: 0},
+		Args:      types.Args{0x01, 0x02},
+	},
+}
+
+func TestTransaction_Broadcast(t *testing.T) {
+	cl := mocks.NewClient(t)
+	tx := NewTransaction(cl)
+
+	enc, err := codec.EncodeToHex(testExtrinsic)
+	assert.NoError(t, err)
+
+	operationID := "operation-1"
+
+	cl.On("CallContext", mock.Anything, mock.Anything, "transaction_v1_broadcast", enc).
+		Run(func(args mock.Arguments) {
+			res := args.Get(1).(**string)
+			*res = &operationID
+		}).
+		Return(nil).
+		Once()
+
+	op, err := tx.Broadcast(testExtrinsic)
+	assert.NoError(t, err)
+	assert.Equal(t, operationID, op.OperationID())
+
+	cl.On("CallContext", mock.Anything, nil, "transaction_v1_stop", operationID).
+		Return(nil).
+		Once()
+
+	assert.NoError(t, op.Stop())
+}
+
+func TestTransaction_BroadcastLimitReached(t *testing.T) {
+	cl := mocks.NewClient(t)
+	tx := NewTransaction(cl)
+
+	cl.On("CallContext", mock.Anything, mock.Anything, "transaction_v1_broadcast", mock.Anything).
+		Return(nil).
+		Once()
+
+	op, err := tx.Broadcast(testExtrinsic)
+	assert.ErrorIs(t, err, ErrBroadcastLimitReached)
+	assert.Nil(t, op)
+}
+
+func TestTransaction_NotSupported(t *testing.T) {
+	cl := mocks.NewClient(t)
+	tx := NewTransaction(cl)
+
+	cl.On("CallContext", mock.Anything, mock.Anything, "transaction_v1_broadcast", mock.Anything).
+		Return(testRPCError{code: methodNotFoundCode}).
+		Once()
+
+	op, err := tx.Broadcast(testExtrinsic)
+	assert.ErrorIs(t, err, ErrNotSupported)
+	assert.Nil(t, op)
+
+	cl.On(
+		"Subscribe",
+		mock.Anything,
+		"transactionWatch",
+		"v1_submitAndWatch",
+		"v1_unwatch",
+		"v1_watchEvent",
+		mock.Anything,
+		mock.Anything,
+	).
+		Return(nil, testRPCError{code: methodNotFoundCode}).
+		Once()
+
+	sub, err := tx.SubmitAndWatch(testExtrinsic)
+	assert.ErrorIs(t, err, ErrNotSupported)
+	assert.Nil(t, sub)
+}
+
+func TestTransaction_OtherErrors(t *testing.T) {
+	cl := mocks.NewClient(t)
+	tx := NewTransaction(cl)
+
+	rpcErr := testRPCError{code: -32602}
+
+	cl.On("CallContext", mock.Anything, mock.Anything, "transaction_v1_broadcast", mock.Anything).
+		Return(rpcErr).
+		Once()
+
+	_, err := tx.Broadcast(testExtrinsic)
+	assert.Equal(t, rpcErr, err)
+
+	connErr := errors.New("connection closed")
+
+	cl.On("Subscribe", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything).
+		Return(nil, connErr).
+		Once()
+
+	_, err = tx.SubmitAndWatch(testExtrinsic)
+	assert.Equal(t, connErr, err)
+}
@@ -0,0 +1,260 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcmocksrv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// Call is a request that was made via a MockClient
+type Call struct {
+	// Method is the called method, or the subscribe method of subscriptions, e.g. chain_subscribeNewHead
+	Method string
+	Params []interface{}
+}
+
+// MockClient is a client.Client that answers calls and subscriptions with fixtures instead of talking to a node, and
+// records all requests so that they can be asserted. Requests are matched against the fixtures just like by the
+// ReplayServer, requests without a matching fixture fail with a types.RPCError.
+type MockClient struct {
+	fixtures fixtureSet
+	subID    atomic.Uint64
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// NewMockClient creates a mock client that answers requests with the provided fixtures
+func NewMockClient(fixtures ...Fixture) *MockClient {
+	c := &MockClient{}
+	c.fixtures.add(fixtures...)
+
+	return c
+}
+
+// Respond registers the result for calls of the method. If params are provided, only calls with equal params match.
+func (c *MockClient) Respond(method string, result interface{}, params ...interface{}) *MockClient {
+	c.fixtures.add(Fixture{Method: method, Params: fixtureParams(params), Result: mustMarshal(result)})
+
+	return c
+}
+
+// RespondError registers the error for calls of the method. If params are provided, only calls with equal params
+// match.
+func (c *MockClient) RespondError(method string, err FixtureError, params ...interface{}) *MockClient {
+	c.fixtures.add(Fixture{Method: method, Params: fixtureParams(params), Error: &err})
+
+	return c
+}
+
+// Notify registers the notifications for subscriptions via the subscribe method, e.g. chain_subscribeNewHead. If
+// params are provided, only subscriptions with equal params match.
+func (c *MockClient) Notify(method string, notifications []interface{}, params ...interface{}) *MockClient {
+	f := Fixture{Method: method, Params: fixtureParams(params), Subscription: true}
+
+	for _, n := range notifications {
+		f.Notifications = append(f.Notifications, mustMarshal(n))
+	}
+
+	c.fixtures.add(f)
+
+	return c
+}
+
+// Calls returns the requests that were made so far, in order
+func (c *MockClient) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Call(nil), c.calls...)
+}
+
+// AssertCalled asserts that the method was called. If params are provided, the call must have had equal params.
+func (c *MockClient) AssertCalled(t assert.TestingT, method string, params ...interface{}) bool {
+	if c.called(method, params) {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf("%s was not called with params %v", method, params), "calls: %v", c.Calls())
+}
+
+// AssertNotCalled asserts that the method was not called. If params are provided, only calls with equal params are
+// considered.
+func (c *MockClient) AssertNotCalled(t assert.TestingT, method string, params ...interface{}) bool {
+	if !c.called(method, params) {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf("%s was called with params %v", method, params), "calls: %v", c.Calls())
+}
+
+func (c *MockClient) called(method string, params []interface{}) bool {
+	for _, call := range c.Calls() {
+		if call.Method == method && paramsMatch(fixtureParams(params), mustMarshal(call.Params)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *MockClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *MockClient) CallContext(_ context.Context, result interface{}, method string, args ...interface{}) error {
+	f, err := c.request(method, args)
+	if err != nil {
+		return err
+	}
+
+	if result == nil || len(f.Result) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(f.Result, result)
+}
+
+func (c *MockClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	for i := range b {
+		b[i].Error = c.CallContext(ctx, b[i].Result, b[i].Method, b[i].Args...)
+	}
+
+	return nil
+}
+
+// Batch returns a new, empty batch that is sent via this client
+func (c *MockClient) Batch() *client.Batch {
+	return client.NewBatch(c)
+}
+
+func (c *MockClient) Subscribe(
+	_ context.Context,
+	namespace, subscribeMethodSuffix, _, _ string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	f, err := c.request(namespace+"_"+subscribeMethodSuffix, args)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := reflect.ValueOf(channel)
+	elem := ch.Type().Elem()
+
+	notifications := make([]reflect.Value, 0, len(f.Notifications))
+
+	for _, n := range f.Notifications {
+		v := reflect.New(elem)
+		if err := json.Unmarshal(n, v.Interface()); err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, v.Elem())
+	}
+
+	id := fmt.Sprintf("0x%x", c.subID.Add(1))
+	quit := make(chan struct{})
+	done := make(chan struct{})
+
+	var quitOnce sync.Once
+
+	sub := gethrpc.NewDetachedClientSubscription(func() string { return id }, func() {
+		quitOnce.Do(func() { close(quit) })
+		<-done
+	})
+
+	go func() {
+		defer close(done)
+
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectSend, Chan: ch},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(quit)},
+		}
+
+		for _, n := range notifications {
+			cases[0].Send = n
+
+			if chosen, _, _ := reflect.Select(cases); chosen == 1 {
+				return
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+// URL returns the URL of the mock client, which does not connect anywhere
+func (c *MockClient) URL() string {
+	return "mock://"
+}
+
+func (c *MockClient) Close() {}
+
+// request records the request and returns the matching fixture, or the error of the fixture.
+func (c *MockClient) request(method string, args []interface{}) (Fixture, error) {
+	c.mu.Lock()
+	c.calls = append(c.calls, Call{Method: method, Params: args})
+	c.mu.Unlock()
+
+	if args == nil {
+		args = []interface{}{}
+	}
+
+	params, err := json.Marshal(args)
+	if err != nil {
+		return Fixture{}, err
+	}
+
+	f, ok := c.fixtures.match(method, params)
+	if !ok {
+		return Fixture{}, noFixtureError(method, params).RPCError()
+	}
+
+	if f.Error != nil {
+		return Fixture{}, f.Error.RPCError()
+	}
+
+	return f, nil
+}
+
+// fixtureParams returns the params of a fixture, no params match all requests.
+func fixtureParams(params []interface{}) json.RawMessage {
+	if len(params) == 0 {
+		return nil
+	}
+
+	return mustMarshal(params)
+}
+
+func mustMarshal(v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
@@ -0,0 +1,101 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcmocksrv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockClient(t *testing.T) {
+	c := NewMockClient().
+		Respond("chain_getBlockHash", types.Hash{1}, 1).
+		Respond("chain_getBlockHash", types.Hash{2}).
+		RespondError("author_submitExtrinsic", FixtureError{Code: 1010, Message: "Invalid Transaction"})
+
+	hash, err := chain.NewChain(c).GetBlockHash(1)
+	require.NoError(t, err)
+	assert.Equal(t, types.Hash{1}, hash)
+
+	// Fixtures without params match all params.
+	hash, err = chain.NewChain(c).GetBlockHash(3)
+	require.NoError(t, err)
+	assert.Equal(t, types.Hash{2}, hash)
+
+	var res string
+	err = c.Call(&res, "author_submitExtrinsic", "0x00")
+	assert.True(t, types.IsInvalidTransaction(err))
+
+	err = c.Call(&res, "system_chain")
+	assert.True(t, types.IsMethodNotFound(err))
+
+	batch := c.Batch()
+	batch.Add(&res, "author_submitExtrinsic", "0x00")
+	require.NoError(t, batch.Send(context.Background()))
+	assert.True(t, types.IsInvalidTransaction(batch.Err(0)))
+
+	c.AssertCalled(t, "chain_getBlockHash")
+	c.AssertCalled(t, "chain_getBlockHash", 3)
+	c.AssertNotCalled(t, "chain_getBlockHash", 2)
+	c.AssertNotCalled(t, "state_getMetadata")
+
+	assert.Equal(t, Call{Method: "system_chain"}, c.Calls()[3])
+	assert.Len(t, c.Calls(), 5)
+
+	mockT := new(testing.T)
+	assert.False(t, c.AssertCalled(mockT, "state_getMetadata"))
+	assert.True(t, mockT.Failed())
+}
+
+func TestMockClient_Subscription(t *testing.T) {
+	fixtures, err := LoadFixtures("testdata/fixtures.json")
+	require.NoError(t, err)
+
+	c := NewMockClient(fixtures...)
+
+	sub, err := chain.NewChain(c).SubscribeNewHeads()
+	require.NoError(t, err)
+
+	for _, number := range []types.BlockNumber{1, 2} {
+		select {
+		case head := <-sub.Chan():
+			assert.Equal(t, number, head.Number)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no notification received")
+		}
+	}
+
+	sub.Unsubscribe()
+
+	c.AssertCalled(t, "chain_subscribeNewHead")
+}
+
+func TestMockClient_UnsubscribeBeforeDelivery(t *testing.T) {
+	c := NewMockClient().Notify("chain_subscribeNewHead", []interface{}{types.Header{Number: 1}})
+
+	sub, err := chain.NewChain(c).SubscribeNewHeads()
+	require.NoError(t, err)
+
+	// Unsubscribing does not block on the undelivered notification.
+	sub.Unsubscribe()
+}
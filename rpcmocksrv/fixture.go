@@ -0,0 +1,177 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcmocksrv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Fixture is a request to the node together with the answer of the node, used to replay the node in tests, see
+// NewReplayServer and NewMockClient. Fixtures can be written by hand or recorded from a real node, see Recorder.
+type Fixture struct {
+	// Method is the called method, or the subscribe method of subscriptions, e.g. chain_subscribeNewHead
+	Method string `json:"method"`
+
+	// Params is the JSON array of params a request must have to match the fixture, if unset requests match
+	// regardless of their params.
+	Params json.RawMessage `json:"params,omitempty"`
+
+	// Result is the result of a call
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// Error is the error of a call, it takes precedence over the Result
+	Error *FixtureError `json:"error,omitempty"`
+
+	// Subscription marks the fixture as subscription. The request is answered with a new subscription ID, followed by
+	// the Notifications in order. The subscription stays open afterwards until it is unsubscribed.
+	Subscription bool `json:"subscription,omitempty"`
+
+	Notifications []json.RawMessage `json:"notifications,omitempty"`
+}
+
+// FixtureError is the JSON-RPC error of a Fixture
+type FixtureError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// RPCError returns the error as it is returned by the clients of this package.
+func (e *FixtureError) RPCError() types.RPCError {
+	rpcErr := types.RPCError{Code: e.Code, Message: e.Message}
+
+	if len(e.Data) > 0 {
+		_ = json.Unmarshal(e.Data, &rpcErr.Data)
+	}
+
+	return rpcErr
+}
+
+// LoadFixtures reads the fixtures from the JSON file at path
+func LoadFixtures(path string) ([]Fixture, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures []Fixture
+
+	if err := json.Unmarshal(b, &fixtures); err != nil {
+		return nil, fmt.Errorf("decoding fixtures %s: %w", path, err)
+	}
+
+	return fixtures, nil
+}
+
+// SaveFixtures writes the fixtures as JSON file to path
+func SaveFixtures(path string, fixtures []Fixture) error {
+	b, err := json.MarshalIndent(fixtures, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o600)
+}
+
+// fixtureSet matches requests against fixtures. Of all fixtures that match a request, the first one that was not used
+// yet is chosen, which allows replaying sequences of different answers to the same request. Once all of them were
+// used, the last one is repeated.
+type fixtureSet struct {
+	mu       sync.Mutex
+	fixtures []Fixture
+	used     []bool
+}
+
+func (s *fixtureSet) add(fixtures ...Fixture) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fixtures = append(s.fixtures, fixtures...)
+	s.used = append(s.used, make([]bool, len(fixtures))...)
+}
+
+// match returns the fixture for the request with the given method and JSON encoded params.
+func (s *fixtureSet) match(method string, params json.RawMessage) (Fixture, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last := -1
+
+	for i, f := range s.fixtures {
+		if f.Method != method || !paramsMatch(f.Params, params) {
+			continue
+		}
+
+		if !s.used[i] {
+			s.used[i] = true
+			return f, true
+		}
+
+		last = i
+	}
+
+	if last < 0 {
+		return Fixture{}, false
+	}
+
+	return s.fixtures[last], true
+}
+
+// paramsMatch checks whether the params of a request equal the params of a fixture, regardless of their formatting.
+// Unset fixture params match all requests, and requests without params equal an empty array.
+func paramsMatch(fixture, request json.RawMessage) bool {
+	if len(fixture) == 0 {
+		return true
+	}
+
+	if bytes.Equal(fixture, request) {
+		return true
+	}
+
+	var f, r []interface{}
+
+	if err := json.Unmarshal(fixture, &f); err != nil {
+		return false
+	}
+
+	if len(request) > 0 {
+		if err := json.Unmarshal(request, &r); err != nil {
+			return false
+		}
+	}
+
+	if len(f) == 0 && len(r) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(f, r)
+}
+
+// noFixtureError is returned for requests that do not match any fixture.
+func noFixtureError(method string, params json.RawMessage) *FixtureError {
+	return &FixtureError{
+		Code:    types.RPCErrorCodeMethodNotFound,
+		Message: fmt.Sprintf("no fixture for %s with params %s", method, params),
+	}
+}
@@ -0,0 +1,189 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcmocksrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Recorder records the calls, subscriptions and notifications of a client connected to a real node as fixtures,
+// which can be replayed in tests via NewReplayServer or NewMockClient. Errors that were not returned by the node,
+// e.g. because the connection was lost, are not recorded.
+//
+// The recorder is applied to a client via Wrap, or as client.Interceptor and client.NotificationHook:
+//
+//	rec := rpcmocksrv.NewRecorder()
+//	c, err := client.NewMiddleware().Use(rec.Intercept).OnNotification(rec.OnNotification).Connect(url)
+//	...
+//	err = rec.Save("testdata/fixtures.json")
+type Recorder struct {
+	mu       sync.Mutex
+	fixtures []Fixture
+	subs     map[string]int               // index of the fixture of a subscription by subscription ID
+	pending  map[string][]json.RawMessage // notifications that were received before their subscription was recorded
+}
+
+// NewRecorder creates a new, empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		subs:    make(map[string]int),
+		pending: make(map[string][]json.RawMessage),
+	}
+}
+
+// Wrap applies the recorder to the provided client
+func (r *Recorder) Wrap(c client.Client) client.Client {
+	return client.NewMiddleware().Use(r.Intercept).OnNotification(r.OnNotification).Wrap(c)
+}
+
+// Intercept implements client.Interceptor
+func (r *Recorder) Intercept(
+	ctx context.Context,
+	method string,
+	params []interface{},
+	next client.Invoker,
+) (interface{}, error) {
+	res, err := next(ctx, method, params)
+
+	if method == client.BatchMethod {
+		for _, elem := range params[0].([]gethrpc.BatchElem) {
+			r.recordCall(elem.Method, elem.Args, elem.Result, elem.Error)
+		}
+
+		return res, err
+	}
+
+	if sub, ok := res.(*gethrpc.ClientSubscription); ok && err == nil {
+		r.recordSubscription(method, params, sub.ID())
+
+		return res, err
+	}
+
+	r.recordCall(method, params, res, err)
+
+	return res, err
+}
+
+// OnNotification implements client.NotificationHook
+func (r *Recorder) OnNotification(n client.Notification) {
+	b, err := json.Marshal(n.Result)
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.subs[n.SubscriptionID]
+	if !ok {
+		r.pending[n.SubscriptionID] = append(r.pending[n.SubscriptionID], b)
+		return
+	}
+
+	r.fixtures[i].Notifications = append(r.fixtures[i].Notifications, b)
+}
+
+// Fixtures returns the fixtures that were recorded so far
+func (r *Recorder) Fixtures() []Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fixtures := make([]Fixture, len(r.fixtures))
+
+	for i, f := range r.fixtures {
+		f.Notifications = append([]json.RawMessage(nil), f.Notifications...)
+		fixtures[i] = f
+	}
+
+	return fixtures
+}
+
+// Save writes the fixtures that were recorded so far as JSON file to path, see SaveFixtures
+func (r *Recorder) Save(path string) error {
+	return SaveFixtures(path, r.Fixtures())
+}
+
+func (r *Recorder) recordCall(method string, params []interface{}, result interface{}, err error) {
+	f := Fixture{Method: method}
+
+	if f.Params = r.marshalParams(params); f.Params == nil {
+		return
+	}
+
+	var rpcErr types.RPCError
+
+	switch {
+	case errors.As(err, &rpcErr):
+		f.Error = &FixtureError{Code: rpcErr.Code, Message: rpcErr.Message}
+
+		if rpcErr.Data != nil {
+			f.Error.Data, _ = json.Marshal(rpcErr.Data)
+		}
+	case err != nil:
+		return
+	default:
+		b, err := json.Marshal(result)
+		if err != nil {
+			return
+		}
+
+		f.Result = b
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fixtures = append(r.fixtures, f)
+}
+
+func (r *Recorder) recordSubscription(method string, params []interface{}, id string) {
+	f := Fixture{Method: method, Subscription: true}
+
+	if f.Params = r.marshalParams(params); f.Params == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f.Notifications = r.pending[id]
+	delete(r.pending, id)
+
+	r.subs[id] = len(r.fixtures)
+	r.fixtures = append(r.fixtures, f)
+}
+
+// marshalParams returns the params as JSON array, or nil if they cannot be marshalled.
+func (r *Recorder) marshalParams(params []interface{}) json.RawMessage {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil
+	}
+
+	return b
+}
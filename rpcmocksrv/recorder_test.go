@@ -0,0 +1,103 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcmocksrv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	// The replay server stands in for a real node.
+	node, err := NewReplayServerFromFile("testdata/fixtures.json")
+	require.NoError(t, err)
+	defer node.Close()
+
+	cl, err := client.Connect(node.URL)
+	require.NoError(t, err)
+	defer cl.Close()
+
+	rec := NewRecorder()
+	c := rec.Wrap(cl)
+
+	hash, err := chain.NewChain(c).GetBlockHash(1)
+	require.NoError(t, err)
+
+	var res string
+	err = c.Call(&res, "author_submitExtrinsic", "0x00")
+	require.Error(t, err)
+
+	var chainName string
+	batch := c.Batch()
+	batch.Add(&chainName, "system_chain")
+	require.NoError(t, batch.Send(context.Background()))
+
+	sub, err := chain.NewChain(c).SubscribeNewHeads()
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-sub.Chan():
+		case <-time.After(5 * time.Second):
+			t.Fatal("no notification received")
+		}
+	}
+
+	sub.Unsubscribe()
+
+	path := t.TempDir() + "/fixtures.json"
+	require.NoError(t, rec.Save(path))
+
+	fixtures, err := LoadFixtures(path)
+	require.NoError(t, err)
+	require.Len(t, fixtures, 4)
+
+	assert.Equal(t, "chain_getBlockHash", fixtures[0].Method)
+	assert.JSONEq(t, `[1]`, string(fixtures[0].Params))
+	assert.Equal(t, 1010, fixtures[1].Error.Code)
+	assert.Equal(t, "system_chain", fixtures[2].Method)
+	assert.True(t, fixtures[3].Subscription)
+	assert.Len(t, fixtures[3].Notifications, 2)
+
+	// The recorded fixtures replay the node.
+	replay := NewMockClient(fixtures...)
+
+	replayedHash, err := chain.NewChain(replay).GetBlockHash(1)
+	require.NoError(t, err)
+	assert.Equal(t, hash, replayedHash)
+
+	err = replay.Call(&res, "author_submitExtrinsic", "0x00")
+	assert.True(t, types.IsInvalidTransaction(err))
+
+	replayedSub, err := chain.NewChain(replay).SubscribeNewHeads()
+	require.NoError(t, err)
+	defer replayedSub.Unsubscribe()
+
+	select {
+	case head := <-replayedSub.Chan():
+		assert.Equal(t, types.BlockNumber(1), head.Number)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification received")
+	}
+}
@@ -0,0 +1,213 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcmocksrv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+)
+
+// ReplayServer is an in-process websocket JSON-RPC server that answers requests with fixtures instead of services,
+// e.g. with fixtures recorded from a real node.
+//
+// Calls are answered with the result or error of the matching fixture, subscriptions with a new subscription ID and
+// the notifications of the fixture. Requests without a matching fixture fail with a method not found error, except
+// for unsubscribe requests, which are answered with true if their only param is the ID of an open subscription.
+type ReplayServer struct {
+	// URL consists of protocol, hostname and port
+	URL string
+
+	srv      *httptest.Server
+	upgrader websocket.Upgrader
+	fixtures fixtureSet
+	subID    atomic.Uint64
+
+	mu   sync.Mutex
+	subs map[string]struct{}
+}
+
+// NewReplayServer starts a replay server on a random port that answers requests with the provided fixtures
+func NewReplayServer(fixtures ...Fixture) *ReplayServer {
+	s := &ReplayServer{subs: make(map[string]struct{})}
+	s.fixtures.add(fixtures...)
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serve))
+	s.URL = "ws" + strings.TrimPrefix(s.srv.URL, "http")
+
+	return s
+}
+
+// NewReplayServerFromFile starts a replay server with the fixtures of the JSON file at path, see LoadFixtures
+func NewReplayServerFromFile(path string) (*ReplayServer, error) {
+	fixtures, err := LoadFixtures(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReplayServer(fixtures...), nil
+}
+
+// AddFixtures adds fixtures to the server, they are matched after the existing ones
+func (s *ReplayServer) AddFixtures(fixtures ...Fixture) {
+	s.fixtures.add(fixtures...)
+}
+
+// Close closes all connections and stops the server
+func (s *ReplayServer) Close() {
+	s.srv.CloseClientConnections()
+	s.srv.Close()
+}
+
+type replayRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type replayMessage struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  interface{}     `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *FixtureError   `json:"error,omitempty"`
+}
+
+func (s *ReplayServer) serve(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		if len(msg) > 0 && msg[0] == '[' {
+			var reqs []replayRequest
+			if err := json.Unmarshal(msg, &reqs); err != nil {
+				return
+			}
+
+			res := make([]replayMessage, 0, len(reqs))
+			for _, req := range reqs {
+				f, ok := s.fixtures.match(req.Method, req.Params)
+				res = append(res, s.answer(req, f, ok))
+			}
+
+			if err := conn.WriteJSON(res); err != nil {
+				return
+			}
+
+			continue
+		}
+
+		var req replayRequest
+		if err := json.Unmarshal(msg, &req); err != nil {
+			return
+		}
+
+		if err := s.serveRequest(conn, req); err != nil {
+			return
+		}
+	}
+}
+
+func (s *ReplayServer) serveRequest(conn *websocket.Conn, req replayRequest) error {
+	f, ok := s.fixtures.match(req.Method, req.Params)
+	if !ok || !f.Subscription {
+		return conn.WriteJSON(s.answer(req, f, ok))
+	}
+
+	id := fmt.Sprintf("0x%x", s.subID.Add(1))
+
+	s.mu.Lock()
+	s.subs[id] = struct{}{}
+	s.mu.Unlock()
+
+	if err := conn.WriteJSON(result(req.ID, id)); err != nil {
+		return err
+	}
+
+	for _, n := range f.Notifications {
+		err := conn.WriteJSON(replayMessage{
+			Version: "2.0",
+			Method:  f.Method,
+			Params: map[string]interface{}{
+				"subscription": id,
+				"result":       n,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// answer returns the response to a call, given its matching fixture if ok.
+func (s *ReplayServer) answer(req replayRequest, f Fixture, ok bool) replayMessage {
+	switch {
+	case ok && f.Error != nil:
+		return replayMessage{Version: "2.0", ID: req.ID, Error: f.Error}
+	case ok:
+		return result(req.ID, f.Result)
+	case s.unsubscribe(req.Params):
+		return result(req.ID, true)
+	default:
+		return replayMessage{Version: "2.0", ID: req.ID, Error: noFixtureError(req.Method, req.Params)}
+	}
+}
+
+// unsubscribe closes the subscription whose ID is the only param, if there is one.
+func (s *ReplayServer) unsubscribe(params json.RawMessage) bool {
+	var ids []string
+	if err := json.Unmarshal(params, &ids); err != nil || len(ids) != 1 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subs[ids[0]]; !ok {
+		return false
+	}
+
+	delete(s.subs, ids[0])
+
+	return true
+}
+
+func result(id json.RawMessage, res interface{}) replayMessage {
+	b, err := json.Marshal(res)
+	if err != nil {
+		b = []byte("null")
+	}
+
+	return replayMessage{Version: "2.0", ID: id, Result: b}
+}
@@ -0,0 +1,145 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcmocksrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayServer(t *testing.T) {
+	s, err := NewReplayServerFromFile("testdata/fixtures.json")
+	require.NoError(t, err)
+	defer s.Close()
+
+	c, err := client.Connect(s.URL)
+	require.NoError(t, err)
+	defer c.Close()
+
+	var chainName string
+	require.NoError(t, c.Call(&chainName, "system_chain"))
+	assert.Equal(t, "Development", chainName)
+
+	hash, err := chain.NewChain(c).GetBlockHash(1)
+	require.NoError(t, err)
+	assert.Equal(t, "0x0101010101010101010101010101010101010101010101010101010101010101", hash.Hex())
+
+	_, err = chain.NewChain(c).GetBlockHash(2)
+	assert.True(t, types.IsMethodNotFound(err))
+
+	var res string
+	err = c.Call(&res, "author_submitExtrinsic", "0x00")
+	assert.Equal(t, types.RPCError{
+		Code:    1010,
+		Message: "Invalid Transaction",
+		Data:    "Transaction has a bad signature",
+	}, err)
+
+	batch := c.Batch()
+	batch.Add(&chainName, "system_chain")
+	batch.Add(&res, "author_submitExtrinsic", "0x00")
+	require.NoError(t, batch.Send(context.Background()))
+	assert.NoError(t, batch.Err(0))
+	assert.True(t, types.IsInvalidTransaction(batch.Err(1)))
+}
+
+func TestReplayServer_Subscription(t *testing.T) {
+	s, err := NewReplayServerFromFile("testdata/fixtures.json")
+	require.NoError(t, err)
+	defer s.Close()
+
+	c, err := client.Connect(s.URL)
+	require.NoError(t, err)
+	defer c.Close()
+
+	ch := make(chan types.Header)
+
+	sub, err := c.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	require.NoError(t, err)
+
+	for _, number := range []types.BlockNumber{1, 2} {
+		select {
+		case head := <-ch:
+			assert.Equal(t, number, head.Number)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no notification received")
+		}
+	}
+
+	sub.Unsubscribe()
+
+	// The subscription was closed on the server, so unsubscribing again fails.
+	var ok bool
+	err = c.Call(&ok, "chain_unsubscribeNewHead", sub.ID())
+	assert.True(t, types.IsMethodNotFound(err))
+}
+
+func TestReplayServer_Sequence(t *testing.T) {
+	s := NewReplayServer(
+		Fixture{Method: "test_counter", Result: json.RawMessage(`1`)},
+		Fixture{Method: "test_counter", Result: json.RawMessage(`2`)},
+	)
+	defer s.Close()
+
+	c, err := client.Connect(s.URL)
+	require.NoError(t, err)
+	defer c.Close()
+
+	var counts []int
+
+	for i := 0; i < 3; i++ {
+		var count int
+		require.NoError(t, c.Call(&count, "test_counter"))
+		counts = append(counts, count)
+	}
+
+	// The last fixture is repeated once the sequence is exhausted.
+	assert.Equal(t, []int{1, 2, 2}, counts)
+
+	s.AddFixtures(Fixture{Method: "test_other", Params: json.RawMessage(`[]`), Result: json.RawMessage(`true`)})
+
+	var ok bool
+	require.NoError(t, c.Call(&ok, "test_other"))
+	assert.True(t, ok)
+}
+
+func TestLoadFixtures(t *testing.T) {
+	_, err := LoadFixtures("testdata/missing.json")
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	fixtures, err := LoadFixtures("testdata/fixtures.json")
+	require.NoError(t, err)
+
+	path := t.TempDir() + "/fixtures.json"
+	require.NoError(t, SaveFixtures(path, fixtures))
+
+	saved, err := LoadFixtures(path)
+	require.NoError(t, err)
+	assert.Len(t, saved, len(fixtures))
+	assert.Equal(t, "chain_subscribeNewHead", saved[3].Method)
+	assert.Len(t, saved[3].Notifications, 2)
+}
@@ -0,0 +1,129 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// RuntimeCall calls the runtime API method at the latest block, the args are SCALE encoded and the result is decoded
+// into target, e.g.:
+//
+//	var nonce types.U32
+//	err := api.RuntimeCall(ctx, "AccountNonceApi_account_nonce", &nonce, accountID)
+func (api *SubstrateAPI) RuntimeCall(
+	ctx context.Context,
+	method string,
+	target interface{},
+	args ...interface{},
+) error {
+	return api.RPC.State.RuntimeCallLatestContext(ctx, method, target, args...)
+}
+
+// RuntimeCallAt is like RuntimeCall but calls the runtime API method at the given block.
+func (api *SubstrateAPI) RuntimeCallAt(
+	ctx context.Context,
+	blockHash types.Hash,
+	method string,
+	target interface{},
+	args ...interface{},
+) error {
+	return api.RPC.State.RuntimeCallContext(ctx, method, blockHash, target, args...)
+}
+
+// DynamicRuntimeCall calls the method of the runtime API described in the metadata at the latest block, e.g.
+// DynamicRuntimeCall(ctx, meta, "AccountNonceApi", "account_nonce", accountID). The API and method names and the
+// number of args are validated against the metadata, which can be retrieved via State.GetMetadataV15Latest, and the
+// result is decoded dynamically, see registry.RuntimeAPIResultDecoder.
+func (api *SubstrateAPI) DynamicRuntimeCall(
+	ctx context.Context,
+	meta *types.MetadataV15,
+	runtimeAPI string,
+	method string,
+	args ...interface{},
+) (map[string]any, error) {
+	return api.dynamicRuntimeCall(ctx, nil, meta, runtimeAPI, method, args)
+}
+
+// DynamicRuntimeCallAt is like DynamicRuntimeCall but calls the runtime API method at the given block.
+func (api *SubstrateAPI) DynamicRuntimeCallAt(
+	ctx context.Context,
+	blockHash types.Hash,
+	meta *types.MetadataV15,
+	runtimeAPI string,
+	method string,
+	args ...interface{},
+) (map[string]any, error) {
+	return api.dynamicRuntimeCall(ctx, &blockHash, meta, runtimeAPI, method, args)
+}
+
+func (api *SubstrateAPI) dynamicRuntimeCall(
+	ctx context.Context,
+	blockHash *types.Hash,
+	meta *types.MetadataV15,
+	runtimeAPI string,
+	method string,
+	args []interface{},
+) (map[string]any, error) {
+	apiMethod, err := meta.FindRuntimeAPIMethod(runtimeAPI, method)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) != len(apiMethod.Inputs) {
+		return nil, fmt.Errorf(
+			"runtime API method %s.%s expects %d args, got %d", runtimeAPI, method, len(apiMethod.Inputs), len(args),
+		)
+	}
+
+	resultDecoder, err := registry.NewRuntimeAPIResultDecoder(meta, apiMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+
+	for _, arg := range args {
+		encodedArg, err := codec.Encode(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, encodedArg...)
+	}
+
+	callMethod := fmt.Sprintf("%s_%s", runtimeAPI, method)
+
+	var res types.Bytes
+
+	if blockHash == nil {
+		res, err = api.RPC.State.CallLatestContext(ctx, callMethod, data)
+	} else {
+		res, err = api.RPC.State.CallContext(ctx, callMethod, data, *blockHash)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resultDecoder.Decode(res)
+}
@@ -0,0 +1,154 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsrpc_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccountID = types.AccountID(codec.MustHexDecodeString(
+		"0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+	))
+	testBlockHash = types.NewHash(codec.MustHexDecodeString(
+		"0x8e1f5bf4a3c1f0f5e2f0c1d3a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7",
+	))
+)
+
+func newTestSubstrateAPI(t *testing.T, cl *rpcmocksrv.MockClient) *gsrpc.SubstrateAPI {
+	cl.Respond("state_getMetadata", types.MetadataV14Data)
+
+	r, err := rpc.NewRPC(cl)
+	require.NoError(t, err)
+
+	return &gsrpc.SubstrateAPI{RPC: r, Client: cl}
+}
+
+func newTestMetadataV15(t *testing.T) *types.MetadataV15 {
+	var meta types.MetadataV15
+
+	err := types.DecodeMetadataV15(codec.MustHexDecodeString(test.MetadataV15Hex), &meta)
+	require.NoError(t, err)
+
+	return &meta
+}
+
+func TestSubstrateAPI_RuntimeCall(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Respond("state_call", "0x07000000", "AccountNonceApi_account_nonce", testAccountID.ToHexString())
+
+	api := newTestSubstrateAPI(t, cl)
+
+	var nonce types.U32
+	err := api.RuntimeCall(context.Background(), "AccountNonceApi_account_nonce", &nonce, testAccountID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(7), nonce)
+}
+
+func TestSubstrateAPI_RuntimeCallAt(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().Respond(
+		"state_call",
+		"0x07000000",
+		"AccountNonceApi_account_nonce",
+		testAccountID.ToHexString(),
+		testBlockHash.Hex(),
+	)
+
+	api := newTestSubstrateAPI(t, cl)
+
+	var nonce types.U32
+	err := api.RuntimeCallAt(context.Background(), testBlockHash, "AccountNonceApi_account_nonce", &nonce, testAccountID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(7), nonce)
+}
+
+func TestSubstrateAPI_DynamicRuntimeCall(t *testing.T) {
+	encodedArgs := append(testAccountID.ToBytes(), 10, 0, 0, 0)
+
+	cl := rpcmocksrv.NewMockClient().Respond(
+		"state_call",
+		"0x0500000040420f00000000000000000000000000",
+		"TestApi_info",
+		codec.HexEncodeToString(encodedArgs),
+	)
+
+	api := newTestSubstrateAPI(t, cl)
+
+	res, err := api.DynamicRuntimeCall(
+		context.Background(),
+		newTestMetadataV15(t),
+		"TestApi",
+		"info",
+		testAccountID,
+		types.NewU32(10),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"nonce":   types.U32(5),
+		"balance": types.NewU128(*big.NewInt(1_000_000)),
+	}, res)
+}
+
+func TestSubstrateAPI_DynamicRuntimeCallAt(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().Respond(
+		"state_call",
+		"0x07000000",
+		"AccountNonceApi_account_nonce",
+		testAccountID.ToHexString(),
+		testBlockHash.Hex(),
+	)
+
+	api := newTestSubstrateAPI(t, cl)
+
+	res, err := api.DynamicRuntimeCallAt(
+		context.Background(),
+		testBlockHash,
+		newTestMetadataV15(t),
+		"AccountNonceApi",
+		"account_nonce",
+		testAccountID,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{registry.RuntimeAPIResultValueKey: types.U32(7)}, res)
+}
+
+func TestSubstrateAPI_DynamicRuntimeCall_Validation(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient()
+
+	api := newTestSubstrateAPI(t, cl)
+	meta := newTestMetadataV15(t)
+
+	_, err := api.DynamicRuntimeCall(context.Background(), meta, "AccountNonceApi", "unknown", testAccountID)
+	assert.EqualError(t, err, "method unknown not found within runtime API AccountNonceApi")
+
+	_, err = api.DynamicRuntimeCall(context.Background(), meta, "AccountNonceApi", "account_nonce")
+	assert.EqualError(t, err, "runtime API method AccountNonceApi.account_nonce expects 1 args, got 0")
+
+	cl.AssertNotCalled(t, "state_call")
+}
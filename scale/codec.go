@@ -23,7 +23,13 @@ import (
 	"log"
 	"math"
 	"math/big"
+	"math/bits"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Implementation for Parity codec in Go.
@@ -39,10 +45,12 @@ const maxInt = int(maxUint >> 1)
 // Allows passing encoding options
 type Encoder struct {
 	writer io.Writer
+	// scratch is reused to write numbers without allocating, nil if the encoder was not created via NewEncoder.
+	scratch *[8]byte
 }
 
 func NewEncoder(writer io.Writer) *Encoder {
-	return &Encoder{writer: writer}
+	return &Encoder{writer: writer, scratch: new([8]byte)}
 }
 
 // Write several bytes to the encoder.
@@ -73,123 +81,274 @@ func (pe Encoder) PushByte(b byte) error {
 // Rust implementation: see impl<'a> Encode for CompactRef<'a, u64>
 func (pe Encoder) EncodeUintCompact(v big.Int) error {
 	if v.Sign() == -1 {
-		return errors.New("Assertion error: EncodeUintCompact cannot process negative numbers")
+		return fmt.Errorf("%w: %s", ErrNegativeCompact, v.String())
 	}
 
-	if v.IsUint64() {
-		if v.Uint64() < 1<<30 {
-			if v.Uint64() < 1<<6 {
-				err := pe.PushByte(byte(v.Uint64()) << 2)
-				if err != nil {
-					return err
-				}
-			} else if v.Uint64() < 1<<14 {
-				err := binary.Write(pe.writer, binary.LittleEndian, uint16(v.Uint64()<<2)+1)
-				if err != nil {
-					return err
-				}
-			} else {
-				err := binary.Write(pe.writer, binary.LittleEndian, uint32(v.Uint64()<<2)+2)
-				if err != nil {
-					return err
-				}
+	if v.IsUint64() && v.Uint64() < 1<<30 {
+		return pe.encodeLength(v.Uint64())
+	}
+
+	// Bytes has no leading zero bytes, so the length prefix is minimal. Values of 2**30 and above need at least 4 bytes.
+	buf := v.Bytes()
+	if len(buf) > 67 {
+		return errors.New("Assertion error: n<=63 needed to compact-encode substrate unsigned big integer")
+	}
+	Reverse(buf)
+
+	err := pe.PushByte(byte(len(buf)-4)<<2 + 3)
+	if err != nil {
+		return err
+	}
+	return pe.Write(buf)
+}
+
+// ErrNegativeCompact is returned when encoding a negative number as a compact unsigned integer.
+var ErrNegativeCompact = errors.New("compact encoding of negative number")
+
+// typeInfo holds what Encode and Decode need to know about a type. It is derived via reflection once per type and
+// cached, see getTypeInfo.
+type typeInfo struct {
+	kind reflect.Kind
+	// encodeable is true if the type implements Encodeable.
+	encodeable bool
+	// decodeable is true if a pointer to the type implements Decodeable.
+	decodeable bool
+	// encodeBytes is true for arrays and slices of bytes that can be written at once.
+	encodeBytes bool
+	// decodeBytes is true for arrays and slices of bytes that can be read at once.
+	decodeBytes bool
+	// fields are the struct fields that are not skipped via the `scale:"-"` tag.
+	fields []fieldInfo
+	// err is returned when encoding or decoding values of the type, if they can only be encoded as struct fields.
+	err error
+}
+
+// fieldInfo holds the index of a struct field and the options of its `scale` tag, see Encoder.Encode.
+type fieldInfo struct {
+	index    int
+	compact  bool
+	optional bool
+	moment   bool
+	// err is returned when encoding or decoding the field, if its tag is invalid for its type.
+	err error
+}
+
+var (
+	encodeableType = reflect.TypeOf((*Encodeable)(nil)).Elem()
+	decodeableType = reflect.TypeOf((*Decodeable)(nil)).Elem()
+
+	// typeInfoCache maps reflect.Type to *typeInfo.
+	typeInfoCache sync.Map
+	// typeInfoCacheDisabled derives the typeInfo on every use, which is only used to verify the cache in tests.
+	typeInfoCacheDisabled atomic.Bool
+)
+
+// getTypeInfo returns the cached typeInfo of t.
+func getTypeInfo(t reflect.Type) *typeInfo {
+	if typeInfoCacheDisabled.Load() {
+		return newTypeInfo(t)
+	}
+
+	if info, ok := typeInfoCache.Load(t); ok {
+		return info.(*typeInfo)
+	}
+
+	info, _ := typeInfoCache.LoadOrStore(t, newTypeInfo(t))
+
+	return info.(*typeInfo)
+}
+
+func newTypeInfo(t reflect.Type) *typeInfo {
+	info := &typeInfo{
+		kind:       t.Kind(),
+		encodeable: t.Implements(encodeableType),
+		decodeable: reflect.PtrTo(t).Implements(decodeableType),
+	}
+
+	if t == timeType {
+		info.err = errors.New("time.Time can only be encoded as a struct field with the `scale:\"moment\"` tag")
+	}
+
+	switch info.kind {
+	case reflect.Array, reflect.Slice:
+		elem := t.Elem()
+		if elem.Kind() == reflect.Uint8 {
+			info.encodeBytes = !elem.Implements(encodeableType)
+			info.decodeBytes = !reflect.PtrTo(elem).Implements(decodeableType)
+		}
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			tv, ok := t.Field(i).Tag.Lookup("scale")
+			if ok && tv == "-" {
+				continue
 			}
-			return nil
+			info.fields = append(info.fields, newFieldInfo(i, t.Field(i).Type, tv))
 		}
 	}
 
-	numBytes := len(v.Bytes())
-	if numBytes > 255 {
-		return errors.New("Assertion error: numBytes>255 exeeds allowed for length prefix")
+	return info
+}
+
+var (
+	bigIntType = reflect.TypeOf(big.Int{})
+	timeType   = reflect.TypeOf(time.Time{})
+)
+
+func newFieldInfo(index int, t reflect.Type, tag string) fieldInfo {
+	field := fieldInfo{index: index}
+	if tag == "" {
+		return field
 	}
-	topSixBits := uint8(numBytes - 4)
-	lengthByte := topSixBits<<2 + 3
 
-	if topSixBits > 63 {
-		return errors.New("Assertion error: n<=63 needed to compact-encode substrate unsigned big integer")
+	for _, option := range strings.Split(tag, ",") {
+		switch option {
+		case "compact":
+			field.compact = true
+		case "optional":
+			field.optional = true
+		case "moment":
+			field.moment = true
+		default:
+			field.err = fmt.Errorf("unknown scale tag option %q of field of type %s", option, t)
+			return field
+		}
 	}
-	err := pe.PushByte(lengthByte)
-	if err != nil {
-		return err
+
+	if field.optional {
+		if t.Kind() != reflect.Ptr {
+			field.err = fmt.Errorf("optional field must be a pointer, but was %s", t)
+			return field
+		}
+		t = t.Elem()
 	}
-	buf := v.Bytes()
-	Reverse(buf)
-	err = pe.Write(buf)
-	if err != nil {
-		return err
+
+	switch {
+	case field.moment && t != timeType:
+		field.err = fmt.Errorf("moment field must be a time.Time, but was %s", t)
+	case field.compact && !field.moment && !isCompactSupported(t):
+		field.err = fmt.Errorf("compact encoding is not supported for field of type %s", t)
 	}
-	return nil
+
+	return field
+}
+
+// isCompactSupported returns true if values of type t can be compact-encoded, either as unsigned integers or via
+// their custom Encode and Decode methods.
+func isCompactSupported(t reflect.Type) bool {
+	if t.Implements(encodeableType) && reflect.PtrTo(t).Implements(decodeableType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return true
+	case reflect.Struct:
+		return t == bigIntType
+	case reflect.Ptr:
+		return t.Elem() == bigIntType
+	default:
+		return false
+	}
+}
+
+// scratchBuffer returns a buffer of n <= 8 bytes, which is only valid until the next read or write.
+func scratchBuffer(scratch *[8]byte, n int) []byte {
+	if scratch == nil {
+		return make([]byte, n)
+	}
+	return scratch[:n]
 }
 
 // Encode a value to the stream.
+//
+// Struct fields are encoded in order, which can be changed per field via the `scale` tag:
+//
+//	`scale:"-"`                 skips the field
+//	`scale:"compact"`           compact-encodes an unsigned integer, big.Int or *big.Int field
+//	`scale:"optional"`          encodes a pointer field as Option, a nil pointer is None
+//	`scale:"optional,compact"`  encodes a pointer field as Option of its compact-encoded value
+//	`scale:"moment"`            encodes a time.Time field as Moment, the milliseconds since the Unix epoch in an u64
+//	`scale:"moment,compact"`    encodes a time.Time field as compact-encoded Moment
+//
+// Pointers are followed by default, the optional tag can be combined with the other options, e.g. a *time.Time field
+// tagged with `scale:"optional,moment"` is encoded as Option<Moment>. Maps are encoded like a BTreeMap in Rust, their
+// entries are sorted by key so that the encoding is deterministic.
+//
+// The tags apply to nested structs, slices of structs and pointers to structs alike, and are used by Decode as well.
+// Custom Encode and Decode methods take precedence over tags: the tags of the fields of a type that implements
+// Encodeable or Decodeable are not used, and a compact field whose type implements them is encoded with them.
 func (pe Encoder) Encode(value interface{}) error {
-	t := reflect.TypeOf(value)
+	return pe.encodeValue(reflect.ValueOf(value))
+}
+
+func (pe Encoder) encodeValue(rv reflect.Value) error {
+	// Struct fields and items of interface types are encoded as their dynamic values
+	if rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return fmt.Errorf("Type %s cannot be encoded", reflect.Invalid)
+	}
+
+	t := rv.Type()
+	info := getTypeInfo(t)
 
 	// If the type implements encodeable, use that implementation
-	encodeable := reflect.TypeOf((*Encodeable)(nil)).Elem()
-	if t.Implements(encodeable) {
-		err := value.(Encodeable).Encode(pe)
+	if info.encodeable {
+		err := rv.Interface().(Encodeable).Encode(pe)
 		if err != nil {
 			return err
 		}
 		return nil
 	}
 
-	tk := t.Kind()
-	switch tk {
+	if info.err != nil {
+		return info.err
+	}
 
-	// Boolean and numbers are trivially encoded via binary.Write
-	// It will use reflection again and take a performance hit
-	// TODO: consider handling every case directly
+	switch info.kind {
+
+	// Boolean and numbers of a fixed size are written in little endian, like binary.Write does
 	case reflect.Bool:
-		fallthrough
-	case reflect.Int8:
-		fallthrough
-	case reflect.Uint8:
-		fallthrough
-	case reflect.Int:
-		fallthrough
-	case reflect.Int16:
-		fallthrough
-	case reflect.Int32:
-		fallthrough
-	case reflect.Int64:
-		fallthrough
-	case reflect.Uint:
-		fallthrough
-	case reflect.Uint16:
-		fallthrough
-	case reflect.Uint32:
-		fallthrough
-	case reflect.Uint64:
-		fallthrough
-	case reflect.Uintptr:
-		fallthrough
+		buf := scratchBuffer(pe.scratch, 1)
+		buf[0] = 0
+		if rv.Bool() {
+			buf[0] = 1
+		}
+		return pe.writeFixed(buf)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return pe.writeFixedUint(uint64(rv.Int()), int(t.Size()))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return pe.writeFixedUint(rv.Uint(), int(t.Size()))
 	case reflect.Float32:
-		fallthrough
+		return pe.writeFixedUint(uint64(math.Float32bits(float32(rv.Float()))), 4)
 	case reflect.Float64:
-		err := binary.Write(pe.writer, binary.LittleEndian, value)
+		return pe.writeFixedUint(math.Float64bits(rv.Float()), 8)
+
+	// Numbers of a platform dependent size are rejected by binary.Write
+	case reflect.Int, reflect.Uint, reflect.Uintptr:
+		err := binary.Write(pe.writer, binary.LittleEndian, rv.Interface())
 		if err != nil {
 			return err
 		}
+
 	case reflect.Ptr:
-		rv := reflect.ValueOf(value)
 		if rv.IsNil() {
 			return errors.New("Encoding null pointers not supported; consider using Option type")
-		} else {
-			dereferenced := rv.Elem()
-			err := pe.Encode(dereferenced.Interface())
-			if err != nil {
-				return err
-			}
+		}
+		err := pe.encodeValue(rv.Elem())
+		if err != nil {
+			return err
 		}
 
 	// Arrays: no compact-encoded length prefix
 	case reflect.Array:
-		rv := reflect.ValueOf(value)
 		l := rv.Len()
+		if info.encodeBytes {
+			return pe.writeBytes(rv, l)
+		}
 		for i := 0; i < l; i++ {
-			err := pe.Encode(rv.Index(i).Interface())
+			err := pe.encodeValue(rv.Index(i))
 			if err != nil {
 				return err
 			}
@@ -197,18 +356,20 @@ func (pe Encoder) Encode(value interface{}) error {
 
 	// Slices: first compact-encode length, then each item individually
 	case reflect.Slice:
-		rv := reflect.ValueOf(value)
 		l := rv.Len()
 		len64 := uint64(l)
 		if len64 > math.MaxUint32 {
 			return errors.New("Attempted to serialize a collection with too many elements.")
 		}
-		err := pe.EncodeUintCompact(*big.NewInt(0).SetUint64(len64))
+		err := pe.encodeLength(len64)
 		if err != nil {
 			return err
 		}
+		if info.encodeBytes {
+			return pe.writeBytes(rv, l)
+		}
 		for i := 0; i < l; i++ {
-			err = pe.Encode(rv.Index(i).Interface())
+			err = pe.encodeValue(rv.Index(i))
 			if err != nil {
 				return err
 			}
@@ -216,27 +377,28 @@ func (pe Encoder) Encode(value interface{}) error {
 
 	// Strings are encoded as UTF-8 byte slices, just as in Rust
 	case reflect.String:
-		s := reflect.ValueOf(value).String()
-		err := pe.Encode([]byte(s))
+		s := rv.String()
+		err := pe.encodeLength(uint64(len(s)))
 		if err != nil {
 			return err
 		}
+		if len(s) > 0 {
+			return pe.writeFixed([]byte(s))
+		}
 
 	case reflect.Struct:
-		rv := reflect.ValueOf(value)
-		for i := 0; i < rv.NumField(); i++ {
-			ft := rv.Type().Field(i)
-			tv, ok := ft.Tag.Lookup("scale")
-			if ok && tv == "-" {
-				continue
-			}
-			err := pe.Encode(rv.Field(i).Interface())
+		for _, field := range info.fields {
+			err := pe.encodeField(rv.Field(field.index), field)
 			if err != nil {
 				return fmt.Errorf("type %s does not support Encodeable interface and could not be "+
-					"encoded field by field, error: %v", t, err)
+					"encoded field by field, error: %w", t, err)
 			}
 		}
 
+	// Maps are encoded like a BTreeMap in Rust: first compact-encode length, then each key and value sorted by key
+	case reflect.Map:
+		return pe.encodeMap(rv)
+
 	// Currently unsupported types
 	case reflect.Complex64:
 		fallthrough
@@ -248,8 +410,6 @@ func (pe Encoder) Encode(value interface{}) error {
 		fallthrough
 	case reflect.Interface:
 		fallthrough
-	case reflect.Map:
-		fallthrough
 	case reflect.UnsafePointer:
 		fallthrough
 	case reflect.Invalid:
@@ -260,6 +420,171 @@ func (pe Encoder) Encode(value interface{}) error {
 	return nil
 }
 
+// encodeField encodes a struct field according to the options of its tag.
+func (pe Encoder) encodeField(rv reflect.Value, field fieldInfo) error {
+	if field.err != nil {
+		return field.err
+	}
+
+	if field.optional {
+		if rv.IsNil() {
+			return pe.PushByte(0)
+		}
+		err := pe.PushByte(1)
+		if err != nil {
+			return err
+		}
+		rv = rv.Elem()
+	}
+
+	switch {
+	case field.moment:
+		return pe.encodeMoment(rv, field.compact)
+	case field.compact:
+		return pe.encodeCompact(rv)
+	default:
+		return pe.encodeValue(rv)
+	}
+}
+
+// encodeMoment encodes a time.Time as the milliseconds since the Unix epoch in an u64, like the Moment of the
+// timestamp pallet.
+func (pe Encoder) encodeMoment(rv reflect.Value, compact bool) error {
+	millis := rv.Interface().(time.Time).UnixMilli()
+	if millis < 0 {
+		return fmt.Errorf("cannot encode a time before the Unix epoch as moment: %d ms", millis)
+	}
+
+	if compact {
+		return pe.encodeLength(uint64(millis))
+	}
+
+	return pe.writeFixedUint(uint64(millis), 8)
+}
+
+// encodeMap encodes the entries of a map sorted by key, so that the encoding is deterministic and matches the
+// BTreeMap of the same entries. Keys of numbers, booleans and strings are sorted by their value, all other keys by
+// their encoding, which matches the order of byte arrays like account IDs.
+func (pe Encoder) encodeMap(rv reflect.Value) error {
+	l := rv.Len()
+	if uint64(l) > math.MaxUint32 {
+		return errors.New("Attempted to serialize a collection with too many elements.")
+	}
+	err := pe.encodeLength(uint64(l))
+	if err != nil {
+		return err
+	}
+
+	type entry struct {
+		key     reflect.Value
+		encoded []byte
+	}
+
+	entries := make([]entry, 0, l)
+	iter := rv.MapRange()
+	for iter.Next() {
+		var buffer bytes.Buffer
+		err := Encoder{writer: &buffer, scratch: pe.scratch}.encodeValue(iter.Key())
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{iter.Key(), buffer.Bytes()})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].key, entries[j].key
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.Bool:
+			return !a.Bool() && b.Bool()
+		case reflect.String:
+			return a.String() < b.String()
+		default:
+			return bytes.Compare(entries[i].encoded, entries[j].encoded) < 0
+		}
+	})
+
+	for _, e := range entries {
+		err = pe.Write(e.encoded)
+		if err != nil {
+			return err
+		}
+		err = pe.encodeValue(rv.MapIndex(e.key))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeCompact compact-encodes an unsigned integer, big.Int or *big.Int, see isCompactSupported.
+func (pe Encoder) encodeCompact(rv reflect.Value) error {
+	if getTypeInfo(rv.Type()).encodeable {
+		return pe.encodeValue(rv)
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		return pe.EncodeUintCompact(rv.Interface().(big.Int))
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return errors.New("Encoding null pointers not supported; consider using Option type")
+		}
+		return pe.EncodeUintCompact(*rv.Interface().(*big.Int))
+	default:
+		return pe.encodeLength(rv.Uint())
+	}
+}
+
+// writeFixed writes bytes without checking for short writes, like binary.Write.
+func (pe Encoder) writeFixed(buf []byte) error {
+	_, err := pe.writer.Write(buf)
+	return err
+}
+
+// writeFixedUint writes the size lowest bytes of v in little endian.
+func (pe Encoder) writeFixedUint(v uint64, size int) error {
+	buf := scratchBuffer(pe.scratch, 8)
+	binary.LittleEndian.PutUint64(buf, v)
+	return pe.writeFixed(buf[:size])
+}
+
+// writeBytes writes the l items of an array or slice of bytes at once.
+func (pe Encoder) writeBytes(rv reflect.Value, l int) error {
+	if l == 0 {
+		return nil
+	}
+
+	if rv.Kind() == reflect.Slice || rv.CanAddr() {
+		return pe.writeFixed(rv.Bytes())
+	}
+
+	buf := make([]byte, l)
+	for i := range buf {
+		buf[i] = byte(rv.Index(i).Uint())
+	}
+	return pe.writeFixed(buf)
+}
+
+// encodeLength compact-encodes the length of a collection, see EncodeUintCompact.
+func (pe Encoder) encodeLength(l uint64) error {
+	switch {
+	case l < 1<<6:
+		return pe.PushByte(byte(l) << 2)
+	case l < 1<<14:
+		return pe.writeFixedUint(l<<2+1, 2)
+	case l < 1<<30:
+		return pe.writeFixedUint(l<<2+2, 4)
+	default:
+		return pe.EncodeUintCompact(*new(big.Int).SetUint64(l))
+	}
+}
+
 // EncodeOption stores optionally present value to the stream.
 func (pe Encoder) EncodeOption(hasValue bool, value interface{}) error {
 	if !hasValue {
@@ -280,31 +605,188 @@ func (pe Encoder) EncodeOption(hasValue bool, value interface{}) error {
 	return nil
 }
 
+// ErrMaxBytesLengthExceeded is returned when a decoded string or byte slice is longer than the maximum length of the
+// decoder, see NewDecoderWithMaxBytesLength.
+var ErrMaxBytesLengthExceeded = errors.New("max bytes length exceeded")
+
 // Decoder is a wraper around a Reader that allows decoding data items from a stream.
 type Decoder struct {
 	reader io.Reader
+	// maxBytesLength is the maximum length of decoded strings and byte slices, 0 if it is not limited.
+	maxBytesLength uint64
+	// scratch is reused to read numbers without allocating, nil if the decoder was not created via NewDecoder.
+	scratch *[8]byte
+	// guard limits the total bytes allocated for decoded values, nil if they are not limited.
+	guard *allocationGuard
+	// strictCompact rejects compact-encoded integers that are not encoded in their smallest mode.
+	strictCompact bool
+}
+
+// ErrNonCanonicalCompact is returned by decoders in strict compact mode when a compact-encoded integer does not use
+// the smallest possible mode and length, see Decoder.SetStrictCompact.
+var ErrNonCanonicalCompact = errors.New("non-canonical compact encoding")
+
+// SetStrictCompact sets whether the decoder rejects compact-encoded integers that are not minimally encoded with
+// ErrNonCanonicalCompact, like parity-scale-codec and polkadot-js do, e.g. 64 encoded in 4 bytes or a big integer
+// with leading zero bytes. The setting applies to the copies of the decoder that are made after setting it.
+func (pd *Decoder) SetStrictCompact(strict bool) {
+	pd.strictCompact = strict
+}
+
+// ErrMaxAllocationExceeded is returned when the values decoded by a decoder would allocate more than its maximum
+// allocation, see Decoder.SetMaxAllocation.
+var ErrMaxAllocationExceeded = errors.New("max allocation exceeded")
+
+// allocationGuard counts the bytes allocated by a decoder and its copies.
+type allocationGuard struct {
+	max       uint64
+	allocated uint64
+}
+
+// SetMaxAllocation limits the total bytes that the decoder allocates for slices, strings and maps, e.g. when decoding
+// data from an untrusted node, whose lengths may be hostile. Decoding fails with ErrMaxAllocationExceeded once the
+// limit is reached. The limit applies to all values decoded by the decoder, and is shared with the copies of the
+// decoder that are made after setting it, e.g. the ones passed to Decodeable.Decode. A maxAllocation of 0 does not
+// limit the allocations.
+func (pd *Decoder) SetMaxAllocation(maxAllocation uint64) {
+	if maxAllocation == 0 {
+		pd.guard = nil
+		return
+	}
+
+	pd.guard = &allocationGuard{max: maxAllocation}
+}
+
+// Allocate accounts for count values of size bytes that are about to be allocated, and returns
+// ErrMaxAllocationExceeded if they exceed the maximum allocation of the decoder, see SetMaxAllocation. Types that
+// allocate slices or maps of a decoded length themselves should call it before allocating them.
+func (pd Decoder) Allocate(count, size uint64) error {
+	if pd.guard == nil {
+		return nil
+	}
+
+	hi, total := bits.Mul64(count, size)
+	if hi != 0 || total > pd.guard.max-pd.guard.allocated {
+		return fmt.Errorf("%w: %d bytes allocated, %d x %d more requested, max %d", ErrMaxAllocationExceeded,
+			pd.guard.allocated, count, size, pd.guard.max)
+	}
+
+	pd.guard.allocated += total
+	return nil
 }
 
 func NewDecoder(reader io.Reader) *Decoder {
-	return &Decoder{reader: reader}
+	return &Decoder{reader: &countingReader{reader: reader}, scratch: new([8]byte)}
+}
+
+// NewDecoderFromBytes creates a Decoder that reads from data without copying it: decoded byte slices, e.g. types.Bytes,
+// are slices of data instead of copies. They must not be modified as long as data is used, and they keep data from
+// being garbage collected. Use NewDecoder with a bytes.Reader to decode copies instead.
+func NewDecoderFromBytes(data []byte) *Decoder {
+	return &Decoder{reader: &bytesReader{data: data}, scratch: new([8]byte)}
+}
+
+// NewDecoderFromBytesWithMaxBytesLength creates a Decoder like NewDecoderFromBytes that fails with
+// ErrMaxBytesLengthExceeded for strings or byte slices that are longer than maxBytesLength.
+func NewDecoderFromBytesWithMaxBytesLength(data []byte, maxBytesLength uint64) *Decoder {
+	return &Decoder{reader: &bytesReader{data: data}, maxBytesLength: maxBytesLength, scratch: new([8]byte)}
+}
+
+// NewDecoderWithMaxBytesLength creates a Decoder that fails with ErrMaxBytesLengthExceeded instead of allocating
+// strings or byte slices that are longer than maxBytesLength, e.g. when decoding corrupt data from an untrusted node.
+// A maxBytesLength of 0 does not limit the length.
+func NewDecoderWithMaxBytesLength(reader io.Reader, maxBytesLength uint64) *Decoder {
+	return &Decoder{reader: &countingReader{reader: reader}, maxBytesLength: maxBytesLength, scratch: new([8]byte)}
+}
+
+// Offset returns the number of bytes that were read so far, e.g. to report the position of a decoding error. It is -1
+// if the decoder was not created by one of the constructors.
+func (pd Decoder) Offset() int64 {
+	switch r := pd.reader.(type) {
+	case *bytesReader:
+		return int64(r.offset)
+	case *countingReader:
+		return r.count
+	default:
+		return -1
+	}
+}
+
+// countingReader counts the bytes that were read from a reader, see Decoder.Offset.
+type countingReader struct {
+	reader io.Reader
+	count  int64
 }
 
-// Read reads bytes from a stream into a buffer
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+// bytesReader reads from a byte slice and allows to take slices of it, see NewDecoderFromBytes.
+type bytesReader struct {
+	data   []byte
+	offset int
+}
+
+func (r *bytesReader) Read(p []byte) (int, error) {
+	if r.offset >= len(r.data) {
+		if len(p) == 0 {
+			return 0, nil
+		}
+		return 0, io.EOF
+	}
+
+	n := copy(p, r.data[r.offset:])
+	r.offset += n
+	return n, nil
+}
+
+// next returns the next n bytes of the data without copying them. The capacity of the returned slice is limited to n,
+// so that appending to it does not overwrite the data.
+func (r *bytesReader) next(n int) ([]byte, error) {
+	if len(r.data)-r.offset < n {
+		return nil, errExpectedMoreBytes
+	}
+
+	b := r.data[r.offset : r.offset+n : r.offset+n]
+	r.offset += n
+	return b, nil
+}
+
+// MaxBytesLength returns the maximum length of decoded strings and byte slices, 0 if it is not limited.
+func (pd Decoder) MaxBytesLength() uint64 {
+	return pd.maxBytesLength
+}
+
+// CheckBytesLength returns ErrMaxBytesLengthExceeded if a string or byte slice of the given length must not be
+// decoded. Types that decode strings or byte slices themselves should call it before allocating them.
+func (pd Decoder) CheckBytesLength(length uint64) error {
+	if pd.maxBytesLength > 0 && length > pd.maxBytesLength {
+		return fmt.Errorf("%w: %d > %d", ErrMaxBytesLengthExceeded, length, pd.maxBytesLength)
+	}
+
+	return nil
+}
+
+// Read reads bytes from a stream into a buffer. Readers that return less bytes than requested, e.g. network
+// connections, are read from until the buffer is full or the stream ends.
 func (pd Decoder) Read(bytes []byte) error {
-	c, err := pd.reader.Read(bytes)
+	c, err := io.ReadFull(pd.reader, bytes)
+	if err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("Cannot read the required number of bytes %d, only %d available", len(bytes), c)
+	}
 	if err != nil {
 		return err
 	}
-	if c < len(bytes) {
-		return fmt.Errorf("Cannot read the required number of bytes %d, only %d available", len(bytes), c)
-	}
 	return nil
 }
 
 // ReadOneByte reads a next byte from the stream.
 // Named so to avoid a linter warning about a clash with io.ByteReader.ReadByte
 func (pd Decoder) ReadOneByte() (byte, error) {
-	buf := []byte{0}
+	buf := scratchBuffer(pd.scratch, 1)
 	err := pd.Read(buf)
 	if err != nil {
 		return buf[0], err
@@ -332,10 +814,10 @@ func (pd Decoder) DecodeIntoReflectValue(target reflect.Value) error {
 		return fmt.Errorf("Unsettable value %v", t)
 	}
 
+	info := getTypeInfo(t)
+
 	// If the type implements decodeable, use that implementation
-	decodeable := reflect.TypeOf((*Decodeable)(nil)).Elem()
-	ptrType := reflect.PtrTo(t)
-	if ptrType.Implements(decodeable) {
+	if info.decodeable {
 		var holder reflect.Value
 		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
 			slice := reflect.MakeSlice(t, target.Len(), target.Len())
@@ -353,38 +835,47 @@ func (pd Decoder) DecodeIntoReflectValue(target reflect.Value) error {
 		return nil
 	}
 
-	switch t.Kind() {
+	if info.err != nil {
+		return info.err
+	}
 
-	// Boolean and numbers are trivially decoded via binary.Read
-	// It will use reflection again and take a performance hit
-	// TODO: consider handling every case directly
+	switch info.kind {
+
+	// Boolean and numbers of a fixed size are read in little endian, like binary.Read does
 	case reflect.Bool:
-		fallthrough
-	case reflect.Int8:
-		fallthrough
-	case reflect.Uint8:
-		fallthrough
-	case reflect.Int:
-		fallthrough
-	case reflect.Int16:
-		fallthrough
-	case reflect.Int32:
-		fallthrough
-	case reflect.Int64:
-		fallthrough
-	case reflect.Uint:
-		fallthrough
-	case reflect.Uint16:
-		fallthrough
-	case reflect.Uint32:
-		fallthrough
-	case reflect.Uint64:
-		fallthrough
-	case reflect.Uintptr:
-		fallthrough
+		buf, err := pd.readFixed(1)
+		if err != nil {
+			return err
+		}
+		target.SetBool(buf[0] != 0)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := pd.readFixedUint(int(t.Size()))
+		if err != nil {
+			return err
+		}
+		shift := 64 - 8*t.Size()
+		target.SetInt(int64(v<<shift) >> shift)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := pd.readFixedUint(int(t.Size()))
+		if err != nil {
+			return err
+		}
+		target.SetUint(v)
 	case reflect.Float32:
-		fallthrough
+		v, err := pd.readFixedUint(4)
+		if err != nil {
+			return err
+		}
+		target.SetFloat(float64(math.Float32frombits(uint32(v))))
 	case reflect.Float64:
+		v, err := pd.readFixedUint(8)
+		if err != nil {
+			return err
+		}
+		target.SetFloat(math.Float64frombits(v))
+
+	// Numbers of a platform dependent size are rejected by binary.Read
+	case reflect.Int, reflect.Uint, reflect.Uintptr:
 		intHolder := reflect.New(t)
 		intPointer := intHolder.Interface()
 		err := binary.Read(pd.reader, binary.LittleEndian, intPointer)
@@ -399,10 +890,8 @@ func (pd Decoder) DecodeIntoReflectValue(target reflect.Value) error {
 	// If you want to replicate Option<T> behavior in Rust, see OptionBool and an
 	// example type OptionInt8 in tests.
 	case reflect.Ptr:
-		isNil := target.IsNil()
-		if isNil {
-			// target.set
-			// return nil
+		if target.IsNil() {
+			target.Set(reflect.New(t.Elem()))
 		}
 		ptr := target.Elem()
 		err := pd.DecodeIntoReflectValue(ptr)
@@ -413,6 +902,9 @@ func (pd Decoder) DecodeIntoReflectValue(target reflect.Value) error {
 	// Arrays: derive the length from the array length
 	case reflect.Array:
 		targetLen := target.Len()
+		if info.decodeBytes {
+			return pd.readBytes(target.Bytes())
+		}
 		for i := 0; i < targetLen; i++ {
 			err := pd.DecodeIntoReflectValue(target.Index(i))
 			if err != nil {
@@ -422,23 +914,50 @@ func (pd Decoder) DecodeIntoReflectValue(target reflect.Value) error {
 
 	// Slices: first compact-encode length, then each item individually
 	case reflect.Slice:
-		codedLen64, _ := pd.DecodeUintCompact()
+		codedLen64, err := pd.DecodeUintCompact()
+		if err != nil {
+			return err
+		}
 		if codedLen64.Uint64() > math.MaxUint32 {
 			return errors.New("Encoded array length is higher than allowed by the protocol (32-bit unsigned integer)")
 		}
 		if codedLen64.Uint64() > uint64(maxInt) {
 			return errors.New("Encoded array length is higher than allowed by the platform")
 		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			if err := pd.CheckBytesLength(codedLen64.Uint64()); err != nil {
+				return err
+			}
+		}
 		codedLen := int(codedLen64.Uint64())
+		if info.decodeBytes && codedLen > 0 {
+			if b, ok, err := pd.readByteSlice(codedLen); ok {
+				if err != nil {
+					return err
+				}
+				target.SetBytes(b)
+				return nil
+			}
+		}
 		targetLen := target.Len()
 		if codedLen != targetLen {
 			if int(codedLen) > target.Cap() {
+				elemSize := uint64(t.Elem().Size())
+				if err := pd.Allocate(codedLen64.Uint64(), elemSize); err != nil {
+					return err
+				}
+				if elemSize > 0 && codedLen64.Uint64() > maxPreallocatedBytes/elemSize {
+					return pd.decodeGrowingSlice(target, codedLen)
+				}
 				newSlice := reflect.MakeSlice(t, int(codedLen), int(codedLen))
 				target.Set(newSlice)
 			} else {
 				target.SetLen(int(codedLen))
 			}
 		}
+		if info.decodeBytes {
+			return pd.readBytes(target.Bytes())
+		}
 		for i := 0; i < codedLen; i++ {
 			err := pd.DecodeIntoReflectValue(target.Index(i))
 			if err != nil {
@@ -453,22 +972,50 @@ func (pd Decoder) DecodeIntoReflectValue(target reflect.Value) error {
 		if err != nil {
 			return err
 		}
+		if err := pd.Allocate(uint64(len(b)), 1); err != nil {
+			return err
+		}
 		target.SetString(string(b))
 
 	case reflect.Struct:
-		for i := 0; i < target.NumField(); i++ {
-			ft := target.Type().Field(i)
-			tv, ok := ft.Tag.Lookup("scale")
-			if ok && tv == "-" {
-				continue
-			}
-			err := pd.DecodeIntoReflectValue(target.Field(i))
+		for _, field := range info.fields {
+			err := pd.decodeField(target.Field(field.index), field)
 			if err != nil {
 				return fmt.Errorf("type %s does not support Decodeable interface and could not be "+
-					"decoded field by field, error: %v", ptrType, err)
+					"decoded field by field, error: %w", reflect.PtrTo(t), err)
 			}
 		}
 
+	// Maps: first compact-encode length, then each key and value
+	case reflect.Map:
+		codedLen64, err := pd.DecodeUintCompact()
+		if err != nil {
+			return err
+		}
+		if codedLen64.Uint64() > math.MaxUint32 {
+			return errors.New("Encoded map length is higher than allowed by the protocol (32-bit unsigned integer)")
+		}
+		codedLen := int(codedLen64.Uint64())
+		// The length is not used as size hint, as it may be corrupt
+		m := reflect.MakeMap(t)
+		for i := 0; i < codedLen; i++ {
+			if err := pd.Allocate(1, uint64(t.Key().Size()+t.Elem().Size())); err != nil {
+				return err
+			}
+			key := reflect.New(t.Key()).Elem()
+			err := pd.DecodeIntoReflectValue(key)
+			if err != nil {
+				return err
+			}
+			value := reflect.New(t.Elem()).Elem()
+			err = pd.DecodeIntoReflectValue(value)
+			if err != nil {
+				return err
+			}
+			m.SetMapIndex(key, value)
+		}
+		target.Set(m)
+
 	// Currently unsupported types
 	case reflect.Complex64:
 		fallthrough
@@ -480,8 +1027,6 @@ func (pd Decoder) DecodeIntoReflectValue(target reflect.Value) error {
 		fallthrough
 	case reflect.Interface:
 		fallthrough
-	case reflect.Map:
-		fallthrough
 	case reflect.UnsafePointer:
 		fallthrough
 	case reflect.Invalid:
@@ -490,7 +1035,246 @@ func (pd Decoder) DecodeIntoReflectValue(target reflect.Value) error {
 	return nil
 }
 
-// DecodeUintCompact decodes a compact-encoded integer. See EncodeUintCompact method.
+// decodeField decodes a struct field according to the options of its tag.
+func (pd Decoder) decodeField(target reflect.Value, field fieldInfo) error {
+	if field.err != nil {
+		return field.err
+	}
+
+	if !field.optional {
+		return pd.decodeFieldValue(target, field)
+	}
+
+	b, err := pd.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	case 1:
+		holder := reflect.New(target.Type().Elem())
+		err = pd.decodeFieldValue(holder.Elem(), field)
+		if err != nil {
+			return err
+		}
+		target.Set(holder)
+		return nil
+	default:
+		return fmt.Errorf("Unknown byte prefix for encoded optional field: %d", b)
+	}
+}
+
+func (pd Decoder) decodeFieldValue(target reflect.Value, field fieldInfo) error {
+	switch {
+	case field.moment:
+		return pd.decodeMoment(target, field.compact)
+	case field.compact:
+		return pd.decodeCompact(target)
+	default:
+		return pd.DecodeIntoReflectValue(target)
+	}
+}
+
+// decodeMoment decodes a time.Time from the milliseconds since the Unix epoch, see Encoder.encodeMoment.
+func (pd Decoder) decodeMoment(target reflect.Value, compact bool) error {
+	var millis uint64
+	if compact {
+		v, err := pd.DecodeUintCompact()
+		if err != nil {
+			return err
+		}
+		if !v.IsUint64() {
+			return fmt.Errorf("compact-encoded moment %s overflows u64", v)
+		}
+		millis = v.Uint64()
+	} else {
+		v, err := pd.readFixedUint(8)
+		if err != nil {
+			return err
+		}
+		millis = v
+	}
+
+	if millis > math.MaxInt64 {
+		return fmt.Errorf("cannot decode moment %d ms, it overflows int64", millis)
+	}
+
+	target.Set(reflect.ValueOf(time.UnixMilli(int64(millis))))
+	return nil
+}
+
+// decodeCompact decodes a compact-encoded unsigned integer, big.Int or *big.Int, see isCompactSupported.
+func (pd Decoder) decodeCompact(target reflect.Value) error {
+	t := target.Type()
+	if getTypeInfo(t).decodeable {
+		return pd.DecodeIntoReflectValue(target)
+	}
+
+	v, err := pd.DecodeUintCompact()
+	if err != nil {
+		return err
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		target.Set(reflect.ValueOf(*v))
+	case reflect.Ptr:
+		target.Set(reflect.ValueOf(v))
+	default:
+		if !v.IsUint64() || target.OverflowUint(v.Uint64()) {
+			return fmt.Errorf("compact-encoded value %s overflows %s", v, t)
+		}
+		target.SetUint(v.Uint64())
+	}
+	return nil
+}
+
+var errExpectedMoreBytes = errors.New("expected more bytes, but could not decode any more")
+
+// readFixed reads n <= 8 bytes like binary.Read does. The returned buffer is only valid until the next read.
+func (pd Decoder) readFixed(n int) ([]byte, error) {
+	buf := scratchBuffer(pd.scratch, n)
+	_, err := io.ReadFull(pd.reader, buf)
+	if err == io.EOF {
+		return nil, errExpectedMoreBytes
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// readFixedUint reads an unsigned integer of size bytes in little endian.
+func (pd Decoder) readFixedUint(size int) (uint64, error) {
+	buf, err := pd.readFixed(size)
+	if err != nil {
+		return 0, err
+	}
+
+	var v uint64
+	for i := size - 1; i >= 0; i-- {
+		v = v<<8 | uint64(buf[i])
+	}
+	return v, nil
+}
+
+// maxPreallocatedBytes is the size up to which slices are allocated before they are decoded. Longer ones grow while
+// they are decoded, so that a corrupt length does not allocate much more than what the input contains. Byte slices
+// are allocated at once if the stream reports its remaining length like bytes.Reader.
+const maxPreallocatedBytes = 64 * 1024
+
+// readByteSlice reads a byte slice of length n, if it can be done without decoding into the existing slice: slices are
+// taken from the data of a decoder created via NewDecoderFromBytes, and long slices are read from streams in chunks.
+func (pd Decoder) readByteSlice(n int) ([]byte, bool, error) {
+	reader := pd.reader
+	if r, ok := reader.(*countingReader); ok {
+		reader = r.reader
+	}
+
+	switch r := reader.(type) {
+	case *bytesReader:
+		b, err := r.next(n)
+		return b, true, err
+	case interface{ Len() int }:
+		if r.Len() >= n {
+			return nil, false, nil
+		}
+	}
+
+	if n <= maxPreallocatedBytes {
+		return nil, false, nil
+	}
+
+	if err := pd.Allocate(uint64(n), 1); err != nil {
+		return nil, true, err
+	}
+
+	b := make([]byte, 0, maxPreallocatedBytes)
+	for len(b) < n {
+		if len(b) == cap(b) {
+			b = append(b, make([]byte, min(n, 2*cap(b))-len(b))...)[:len(b)]
+		}
+		chunk := b[len(b):min(n, cap(b))]
+		err := pd.readBytes(chunk)
+		if err != nil {
+			return nil, true, err
+		}
+		b = b[:len(b)+len(chunk)]
+	}
+	return b, true, nil
+}
+
+// decodeGrowingSlice decodes the items of a slice of length n into a new slice that grows with the decoded items,
+// instead of allocating all of them before, see maxPreallocatedBytes.
+func (pd Decoder) decodeGrowingSlice(target reflect.Value, n int) error {
+	t := target.Type()
+	holder := reflect.New(t).Elem()
+	holder.Set(reflect.MakeSlice(t, 0, int(maxPreallocatedBytes/t.Elem().Size())))
+
+	for i := 0; i < n; i++ {
+		if i == holder.Cap() {
+			holder.Grow(max(1, min(i, n-i)))
+		}
+		holder.SetLen(i + 1)
+		err := pd.DecodeIntoReflectValue(holder.Index(i))
+		if err != nil {
+			return err
+		}
+	}
+
+	target.Set(holder)
+	return nil
+}
+
+// readBytes fills an array or slice of bytes at once. Running out of bytes fails like reading them one by one.
+func (pd Decoder) readBytes(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+
+	_, err := io.ReadFull(pd.reader, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errExpectedMoreBytes
+	}
+	return err
+}
+
+// ErrStopStream can be returned by the callback of DecodeStream to stop decoding the remaining items without an error.
+var ErrStopStream = errors.New("stop stream")
+
+// DecodeStream decodes a sequence, e.g. a Vec<T>, item by item and passes each item to fn as soon as it is decoded,
+// instead of allocating all items at once. If fn returns ErrStopStream, decoding stops without an error and the
+// remaining items are not read from the stream.
+func DecodeStream[T any](decoder *Decoder, fn func(index int, item T) error) error {
+	length, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < length.Uint64(); i++ {
+		var item T
+
+		if err := decoder.Decode(&item); err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+
+		if err := fn(int(i), item); err != nil {
+			if errors.Is(err, ErrStopStream) {
+				return nil
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecodeUintCompact decodes a compact-encoded integer. See EncodeUintCompact method. In strict compact mode, values
+// that are not encoded in their smallest mode are rejected with ErrNonCanonicalCompact, see SetStrictCompact.
 func (pd Decoder) DecodeUintCompact() (*big.Int, error) {
 	b, err := pd.ReadOneByte()
 
@@ -513,6 +1297,9 @@ func (pd Decoder) DecodeUintCompact() (*big.Int, error) {
 		r <<= 6
 		// right shift to remove mode bits and add to prev
 		r += uint64(b >> 2)
+		if pd.strictCompact && r < 1<<6 {
+			return nil, fmt.Errorf("%w: %d in 2 bytes", ErrNonCanonicalCompact, r)
+		}
 		return big.NewInt(0).SetUint64(r), nil
 	case 2:
 		// value = 32 bits + mode
@@ -526,6 +1313,9 @@ func (pd Decoder) DecodeUintCompact() (*big.Int, error) {
 		r := binary.LittleEndian.Uint32(buf)
 		// remove the last 2 mode bits
 		r >>= 2
+		if pd.strictCompact && r < 1<<14 {
+			return nil, fmt.Errorf("%w: %d in 4 bytes", ErrNonCanonicalCompact, r)
+		}
 		return big.NewInt(0).SetUint64(uint64(r)), nil
 	case 3:
 		// remove mode bits
@@ -540,7 +1330,11 @@ func (pd Decoder) DecodeUintCompact() (*big.Int, error) {
 			return nil, err
 		}
 		Reverse(buf)
-		return new(big.Int).SetBytes(buf), nil
+		r := new(big.Int).SetBytes(buf)
+		if pd.strictCompact && (buf[0] == 0 || r.BitLen() <= 30) {
+			return nil, fmt.Errorf("%w: %s in %d bytes", ErrNonCanonicalCompact, r, len(buf)+1)
+		}
+		return r, nil
 	default:
 		return nil, errors.New("Code should be unreachable")
 	}
@@ -644,7 +1438,7 @@ func (o *OptionBool) Decode(decoder Decoder) error {
 // ToKeyedVec replicates the behaviour of Rust's to_keyed_vec helper.
 func ToKeyedVec(value interface{}, prependKey []byte) ([]byte, error) {
 	var buffer = bytes.NewBuffer(prependKey)
-	err := Encoder{buffer}.Encode(value)
+	err := Encoder{writer: buffer}.Encode(value)
 	if err != nil {
 		return nil, err
 	}
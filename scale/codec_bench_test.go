@@ -0,0 +1,124 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// newBenchmarkEventRecords returns the events of a busy block, which are typical for the structs decoded by indexers.
+func newBenchmarkEventRecords() types.EventRecords {
+	var records types.EventRecords
+
+	for i := 0; i < 50; i++ {
+		records.Balances_Transfer = append(records.Balances_Transfer, types.EventBalancesTransfer{
+			Phase:  types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: uint32(i)},
+			From:   types.AccountID{byte(i)},
+			To:     types.AccountID{byte(i + 1)},
+			Value:  types.NewU128(*big.NewInt(int64(i) * 1_000_000_000_000)),
+			Topics: []types.Hash{{byte(i)}},
+		})
+
+		records.System_ExtrinsicSuccess = append(records.System_ExtrinsicSuccess, types.EventSystemExtrinsicSuccess{
+			Phase: types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: uint32(i)},
+			DispatchInfo: types.DispatchInfo{
+				Weight:  types.NewWeight(types.NewUCompactFromUInt(uint64(i)), types.NewUCompactFromUInt(uint64(i))),
+				Class:   types.DispatchClass{IsNormal: true},
+				PaysFee: types.Pays{IsYes: true},
+			},
+		})
+	}
+
+	return records
+}
+
+func BenchmarkEncoder_Encode_EventRecords(b *testing.B) {
+	records := newBenchmarkEventRecords()
+
+	var buffer bytes.Buffer
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buffer.Reset()
+
+		if err := scale.NewEncoder(&buffer).Encode(records); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecoder_Decode_EventRecords(b *testing.B) {
+	var buffer bytes.Buffer
+
+	if err := scale.NewEncoder(&buffer).Encode(newBenchmarkEventRecords()); err != nil {
+		b.Fatal(err)
+	}
+
+	encoded := buffer.Bytes()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var records types.EventRecords
+
+		if err := scale.NewDecoder(bytes.NewReader(encoded)).Decode(&records); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecoder_Decode_LargeBytes(b *testing.B) {
+	var buffer bytes.Buffer
+
+	if err := scale.NewEncoder(&buffer).Encode(bytes.Repeat([]byte{0xab}, 4*1024*1024)); err != nil {
+		b.Fatal(err)
+	}
+
+	encoded := buffer.Bytes()
+
+	b.Run("Reader", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			var value types.Bytes
+
+			if err := scale.NewDecoder(bytes.NewReader(encoded)).Decode(&value); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("FromBytes", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			var value types.Bytes
+
+			if err := scale.NewDecoderFromBytes(encoded).Decode(&value); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,117 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// fuzzMaxAllocation limits the allocations of the fuzzed decoders, hostile lengths must not exceed it.
+const fuzzMaxAllocation = 1 << 20
+
+type fuzzItem struct {
+	Hash   [32]byte
+	Values []uint16
+}
+
+type fuzzStruct struct {
+	Bool    bool
+	Int     int32
+	Float   float64
+	Compact uint64    `scale:"compact"`
+	Big     *big.Int  `scale:"compact"`
+	Since   *uint32   `scale:"optional"`
+	Tip     *uint8    `scale:"optional,compact"`
+	At      time.Time `scale:"moment"`
+	Text    string
+	Bytes   []byte
+	Array   [4]byte
+	Items   []fuzzItem
+	Nested  [][]uint64
+	Map     map[uint8]string
+	Option  scale.OptionBool
+	Pointer *fuzzItem
+}
+
+func newFuzzDecoders(data []byte) []*scale.Decoder {
+	decoders := []*scale.Decoder{
+		scale.NewDecoder(bytes.NewReader(data)),
+		scale.NewDecoderFromBytes(data),
+		scale.NewDecoderWithMaxBytesLength(bytes.NewReader(data), 1024),
+	}
+
+	for _, decoder := range decoders {
+		decoder.SetMaxAllocation(fuzzMaxAllocation)
+	}
+
+	return decoders
+}
+
+// FuzzDecoder_Decode asserts that malformed input does not panic or allocate more than the maximum allocation.
+func FuzzDecoder_Decode(f *testing.F) {
+	since := uint32(7)
+	tip := uint8(1)
+
+	var buffer bytes.Buffer
+
+	err := scale.NewEncoder(&buffer).Encode(fuzzStruct{
+		Bool:    true,
+		Int:     -1,
+		Compact: 1 << 20,
+		Big:     big.NewInt(1 << 40),
+		Since:   &since,
+		Tip:     &tip,
+		At:      time.UnixMilli(1_700_000_000_000),
+		Text:    "text",
+		Bytes:   []byte{1, 2, 3},
+		Items:   []fuzzItem{{Hash: [32]byte{1}, Values: []uint16{1, 2}}},
+		Nested:  [][]uint64{{1}, {}},
+		Map:     map[uint8]string{1: "a", 2: "b"},
+		Option:  scale.NewOptionBool(true),
+		Pointer: &fuzzItem{Values: []uint16{3}},
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(buffer.Bytes())
+	f.Add([]byte{})
+	f.Add([]byte{0x03, 0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte{0x13, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, decoder := range newFuzzDecoders(data) {
+			var value fuzzStruct
+			_ = decoder.Decode(&value)
+		}
+
+		for _, decoder := range newFuzzDecoders(data) {
+			var value []map[string][]fuzzItem
+			_ = decoder.Decode(&value)
+		}
+
+		for _, decoder := range newFuzzDecoders(data) {
+			_, _ = decoder.DecodeUintCompact()
+		}
+	})
+}
@@ -16,12 +16,15 @@ package scale
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"reflect"
 	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -181,6 +184,50 @@ func TestSliceOfInt16EncodedAsExpected(t *testing.T) {
 	assertEqual(t, hexify(encodeToBytes(t, value)), "1c 00 00 01 00 ff ff 02 00 fe ff 03 00 fd ff")
 }
 
+func TestDecodeStream(t *testing.T) {
+	value := []int16{0, 1, -1, 2, -2, 3, -3}
+	encoded := encodeToBytes(t, value)
+
+	var items []int16
+
+	err := DecodeStream(NewDecoder(bytes.NewReader(encoded)), func(index int, item int16) error {
+		assert.Equal(t, len(items), index)
+		items = append(items, item)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, value, items)
+
+	// Decoding stops early without reading the remaining items.
+	reader := bytes.NewReader(encoded)
+	items = nil
+
+	err = DecodeStream(NewDecoder(reader), func(index int, item int16) error {
+		items = append(items, item)
+
+		if index == 2 {
+			return ErrStopStream
+		}
+
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, value[:3], items)
+	assert.Equal(t, 8, reader.Len())
+
+	errCallback := errors.New("callback")
+
+	err = DecodeStream(NewDecoder(bytes.NewReader(encoded)), func(index int, item int16) error {
+		return errCallback
+	})
+	assert.ErrorIs(t, err, errCallback)
+
+	err = DecodeStream(NewDecoder(bytes.NewReader(encoded[:5])), func(index int, item int16) error {
+		return nil
+	})
+	assert.ErrorContains(t, err, "item 2")
+}
+
 func TestStructFieldByFieldEncoding(t *testing.T) {
 	value := struct {
 		A string
@@ -257,3 +304,402 @@ func TestCompactIntegersEncodedAsExpected(t *testing.T) {
 		assertEqual(t, decoded, big.NewInt(0).SetUint64(value))
 	}
 }
+
+func TestCompactBigIntegersEncodedAsExpected(t *testing.T) {
+	maxU128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	maxU256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	maxCompact := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 536), big.NewInt(1))
+
+	// Vectors from parity-scale-codec, the prefix holds the number of bytes minus 4.
+	tests := []struct {
+		value       *big.Int
+		expectedHex string
+	}{
+		{new(big.Int).Lsh(big.NewInt(1), 64), "17 00 00 00 00 00 00 00 00 01"},
+		{maxU128, "33" + strings.Repeat(" ff", 16)},
+		{maxU256, "73" + strings.Repeat(" ff", 32)},
+		{maxCompact, "ff" + strings.Repeat(" ff", 67)},
+	}
+	for _, test := range tests {
+		var buffer = bytes.Buffer{}
+		err := NewEncoder(&buffer).EncodeUintCompact(*test.value)
+		assert.NoError(t, err)
+		assertEqual(t, hexify(buffer.Bytes()), test.expectedHex)
+
+		decoder := NewDecoder(&buffer)
+		decoder.SetStrictCompact(true)
+		decoded, err := decoder.DecodeUintCompact()
+		assert.NoError(t, err)
+		assertEqual(t, decoded, test.value)
+	}
+
+	err := NewEncoder(&bytes.Buffer{}).EncodeUintCompact(*new(big.Int).Add(maxCompact, big.NewInt(1)))
+	assert.EqualError(t, err, "Assertion error: n<=63 needed to compact-encode substrate unsigned big integer")
+
+	err = NewEncoder(&bytes.Buffer{}).EncodeUintCompact(*big.NewInt(-1))
+	assert.True(t, errors.Is(err, ErrNegativeCompact))
+
+	err = NewEncoder(&bytes.Buffer{}).Encode(struct {
+		Balance *big.Int `scale:"compact"`
+	}{big.NewInt(-64)})
+	assert.True(t, errors.Is(err, ErrNegativeCompact))
+}
+
+// compactLength returns the length of the smallest compact encoding of v.
+func compactLength(v *big.Int) int {
+	switch {
+	case v.BitLen() <= 6:
+		return 1
+	case v.BitLen() <= 14:
+		return 2
+	case v.BitLen() <= 30:
+		return 4
+	default:
+		return 1 + max(4, (v.BitLen()+7)/8)
+	}
+}
+
+func TestCompactIntegersAtModeBoundaries(t *testing.T) {
+	for bits := 1; bits <= 536; bits++ {
+		boundary := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+		for _, delta := range []int64{-2, -1, 0, 1} {
+			value := new(big.Int).Add(boundary, big.NewInt(delta))
+			if value.BitLen() > 536 {
+				continue
+			}
+
+			var buffer = bytes.Buffer{}
+			err := NewEncoder(&buffer).EncodeUintCompact(*value)
+			assert.NoError(t, err)
+			assert.Equal(t, compactLength(value), buffer.Len(), "encoded length of %s", value)
+
+			decoder := NewDecoder(&buffer)
+			decoder.SetStrictCompact(true)
+			decoded, err := decoder.DecodeUintCompact()
+			assert.NoError(t, err)
+			assert.Equal(t, 0, value.Cmp(decoded), "decoded %s, expected %s", decoded, value)
+		}
+	}
+}
+
+func TestDecodeUintCompact_Strict(t *testing.T) {
+	// Encodings that are accepted by default, but rejected by parity-scale-codec as not canonical.
+	tests := []struct {
+		encoded  []byte
+		expected uint64
+	}{
+		{[]byte{0x01, 0x00}, 0},
+		{[]byte{0xfd, 0x00}, 63},
+		{[]byte{0x02, 0x00, 0x00, 0x00}, 0},
+		{[]byte{0xfe, 0xff, 0x00, 0x00}, 16383},
+		{[]byte{0x03, 0x00, 0x00, 0x00, 0x00}, 0},
+		{[]byte{0x03, 0xff, 0xff, 0xff, 0x3f}, 1<<30 - 1},
+		{[]byte{0x07, 0xff, 0xff, 0xff, 0xff, 0x00}, 1<<32 - 1},
+		{[]byte{0x13, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00}, 1 << 48},
+	}
+	for _, test := range tests {
+		decoded, err := NewDecoderFromBytes(test.encoded).DecodeUintCompact()
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, decoded.Uint64())
+
+		decoder := NewDecoderFromBytes(test.encoded)
+		decoder.SetStrictCompact(true)
+		_, err = decoder.DecodeUintCompact()
+		assert.True(t, errors.Is(err, ErrNonCanonicalCompact), "%x: %v", test.encoded, err)
+	}
+
+	// The setting applies to lengths and compact fields decoded by the decoder.
+	decoder := NewDecoderFromBytes([]byte{0x05, 0x00, 0x01})
+	decoder.SetStrictCompact(true)
+
+	var b []byte
+	err := decoder.Decode(&b)
+	assert.True(t, errors.Is(err, ErrNonCanonicalCompact))
+}
+
+type taggedInner struct {
+	Nonce   uint32   `scale:"compact"`
+	Balance big.Int  `scale:"compact"`
+	Fee     *big.Int `scale:"compact"`
+	Cache   string   `scale:"-"`
+}
+
+type taggedStruct struct {
+	Version uint16
+	Since   *uint32 `scale:"optional"`
+	Tip     *uint64 `scale:"optional,compact"`
+	Inner   taggedInner
+	Items   []taggedInner
+	Pointer *taggedInner
+	// Custom Encode and Decode methods take precedence over the compact tag.
+	Custom CustomBool `scale:"compact"`
+}
+
+func TestStructTagsEncodedAsExpected(t *testing.T) {
+	since := uint32(7)
+	tip := uint64(1 << 14)
+	inner := taggedInner{Nonce: 1, Balance: *big.NewInt(64), Fee: big.NewInt(1 << 30)}
+
+	value := taggedStruct{
+		Version: 2,
+		Since:   &since,
+		Tip:     &tip,
+		Inner:   inner,
+		Items:   []taggedInner{inner, {Nonce: 16384, Balance: *big.NewInt(1), Fee: big.NewInt(2)}},
+		Pointer: &inner,
+		Custom:  true,
+	}
+
+	assertRoundtrip(t, value)
+	assertEqual(t, hexify(encodeToBytes(t, value)), "02 00 01 07 00 00 00 01 02 00 01 00 "+
+		"04 01 01 03 00 00 00 40 "+
+		"08 04 01 01 03 00 00 00 40 02 00 01 00 04 08 "+
+		"04 01 01 03 00 00 00 40 "+
+		"05")
+
+	// Nil optional fields are None, skipped fields are neither encoded nor decoded.
+	inner.Cache = "cache"
+	value = taggedStruct{Inner: inner, Pointer: &inner}
+	assertEqual(t, hexify(encodeToBytes(t, value)), "00 00 00 00 04 01 01 03 00 00 00 40 00 "+
+		"04 01 01 03 00 00 00 40 10")
+
+	var decoded taggedStruct
+	err := Decoder{reader: bytes.NewReader(encodeToBytes(t, value))}.Decode(&decoded)
+	assert.NoError(t, err)
+	assert.Nil(t, decoded.Since)
+	assert.Nil(t, decoded.Tip)
+	assert.Empty(t, decoded.Inner.Cache)
+	assert.Empty(t, decoded.Pointer.Cache)
+}
+
+func TestStructTagsErrors(t *testing.T) {
+	var buffer = bytes.Buffer{}
+
+	err := Encoder{writer: &buffer}.Encode(struct {
+		Name string `scale:"compact"`
+	}{})
+	assert.ErrorContains(t, err, "compact encoding is not supported for field of type string")
+
+	err = Encoder{writer: &buffer}.Encode(struct {
+		Value int32 `scale:"compact"`
+	}{})
+	assert.ErrorContains(t, err, "compact encoding is not supported for field of type int32")
+
+	err = Encoder{writer: &buffer}.Encode(struct {
+		Value uint32 `scale:"optional"`
+	}{})
+	assert.ErrorContains(t, err, "optional field must be a pointer, but was uint32")
+
+	err = Encoder{writer: &buffer}.Encode(struct {
+		Value uint32 `scale:"compat"`
+	}{})
+	assert.ErrorContains(t, err, `unknown scale tag option "compat"`)
+
+	var overflow struct {
+		Value uint8 `scale:"compact"`
+	}
+	err = Decoder{reader: bytes.NewReader([]byte{0x01, 0x04})}.Decode(&overflow)
+	assert.ErrorContains(t, err, "compact-encoded value 256 overflows uint8")
+
+	var optional struct {
+		Value *uint8 `scale:"optional"`
+	}
+	err = Decoder{reader: bytes.NewReader([]byte{0x02})}.Decode(&optional)
+	assert.ErrorContains(t, err, "Unknown byte prefix for encoded optional field: 2")
+}
+
+func TestMapEncodedAsExpected(t *testing.T) {
+	value := map[uint16]string{256: "b", 1: "a", 2: ""}
+	assertRoundtrip(t, value)
+	assertEqual(t, hexify(encodeToBytes(t, value)), "0c 01 00 04 61 02 00 00 00 01 04 62")
+
+	// Strings are sorted by value, other keys by their encoding.
+	assertEqual(t, hexify(encodeToBytes(t, map[string]bool{"ba": true, "c": false})), "08 08 62 61 01 04 63 00")
+	assertEqual(t, hexify(encodeToBytes(t, map[[2]byte]int8{{2, 0}: 1, {1, 9}: -1})), "08 01 09 ff 02 00 01")
+	assertEqual(t, hexify(encodeToBytes(t, map[int8]bool{1: true, -1: false})), "08 ff 00 01 01")
+
+	nested := map[uint8][]map[uint8]uint8{3: {{2: 1, 1: 2}}, 1: nil}
+	assertEqual(t, hexify(encodeToBytes(t, nested)), "08 01 00 03 04 08 01 02 02 01")
+
+	// The encoding does not depend on the iteration order of the map.
+	large := make(map[uint32]uint32)
+	for i := uint32(0); i < 100; i++ {
+		large[i*7919%101] = i
+	}
+	expected := encodeToBytes(t, large)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, expected, encodeToBytes(t, large))
+	}
+	assertRoundtrip(t, large)
+
+	// Nil maps are encoded like empty ones.
+	assertEqual(t, hexify(encodeToBytes(t, map[uint8]uint8(nil))), "00")
+
+	var decoded map[uint8]uint8
+	err := Decoder{reader: bytes.NewReader([]byte{0x00})}.Decode(&decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint8]uint8{}, decoded)
+
+	err = Decoder{reader: bytes.NewReader([]byte{0x04, 0x01})}.Decode(&decoded)
+	assert.EqualError(t, err, "expected more bytes, but could not decode any more")
+}
+
+type momentStruct struct {
+	At    time.Time  `scale:"moment"`
+	Now   time.Time  `scale:"moment,compact"`
+	Until *time.Time `scale:"optional,moment"`
+}
+
+func TestMomentTagEncodedAsExpected(t *testing.T) {
+	at := time.UnixMilli(1_700_000_000_123)
+	value := momentStruct{At: at, Now: time.UnixMilli(1 << 14), Until: &at}
+
+	assertRoundtrip(t, value)
+	assertEqual(t, hexify(encodeToBytes(t, value)), "7b 68 e5 cf 8b 01 00 00 02 00 01 00 01 7b 68 e5 cf 8b 01 00 00")
+
+	value = momentStruct{At: at, Now: at}
+	assertRoundtrip(t, value)
+	assertEqual(t, hexify(encodeToBytes(t, value)), "7b 68 e5 cf 8b 01 00 00 0b 7b 68 e5 cf 8b 01 00")
+
+	var buffer = bytes.Buffer{}
+
+	err := Encoder{writer: &buffer}.Encode(momentStruct{At: time.UnixMilli(-1)})
+	assert.ErrorContains(t, err, "cannot encode a time before the Unix epoch as moment: -1 ms")
+
+	err = Encoder{writer: &buffer}.Encode(struct{ At time.Time }{})
+	assert.ErrorContains(t, err, "time.Time can only be encoded as a struct field with the `scale:\"moment\"` tag")
+
+	err = Encoder{writer: &buffer}.Encode(struct {
+		At uint64 `scale:"moment"`
+	}{})
+	assert.ErrorContains(t, err, "moment field must be a time.Time, but was uint64")
+
+	var decoded momentStruct
+	err = Decoder{reader: bytes.NewReader([]byte{0, 0, 0, 0, 0, 0, 0, 0x80, 0})}.Decode(&decoded)
+	assert.ErrorContains(t, err, "cannot decode moment 9223372036854775808 ms, it overflows int64")
+}
+
+func TestNewDecoderFromBytes(t *testing.T) {
+	type value struct {
+		Data  []byte
+		Array [2]byte
+		Text  string
+		Items []uint16
+	}
+
+	data := encodeToBytes(t, value{Data: []byte{1, 2, 3}, Array: [2]byte{4, 5}, Text: "abc", Items: []uint16{6}})
+
+	decoder := NewDecoderFromBytes(data)
+	assert.Equal(t, int64(0), decoder.Offset())
+
+	var decoded value
+	err := decoder.Decode(&decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, value{Data: []byte{1, 2, 3}, Array: [2]byte{4, 5}, Text: "abc", Items: []uint16{6}}, decoded)
+	assert.Equal(t, int64(len(data)), decoder.Offset())
+
+	// Byte slices alias the data, but appending to them does not overwrite it.
+	data[1] = 9
+	assert.Equal(t, []byte{9, 2, 3}, decoded.Data)
+	assert.Equal(t, 3, cap(decoded.Data))
+	_ = append(decoded.Data, 0)
+	assert.Equal(t, byte(4), data[4])
+
+	// Other values are copies.
+	data[5] = 0
+	assert.Equal(t, [2]byte{4, 5}, decoded.Array)
+
+	var empty []byte
+	err = NewDecoderFromBytes([]byte{0x00}).Decode(&empty)
+	assert.NoError(t, err)
+	assert.Nil(t, empty)
+
+	decoder = NewDecoderFromBytes([]byte{0x0c, 1, 2})
+	err = decoder.Decode(&empty)
+	assert.EqualError(t, err, "expected more bytes, but could not decode any more")
+	assert.Equal(t, int64(1), decoder.Offset())
+
+	err = NewDecoderFromBytesWithMaxBytesLength([]byte{0x0c, 1, 2, 3}, 2).Decode(&empty)
+	assert.ErrorIs(t, err, ErrMaxBytesLengthExceeded)
+}
+
+func TestDecoder_Offset(t *testing.T) {
+	decoder := NewDecoder(bytes.NewReader([]byte{1, 0, 2, 0, 0, 0}))
+
+	var u16 uint16
+	err := decoder.Decode(&u16)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), decoder.Offset())
+
+	var u32 uint32
+	err = decoder.Decode(&u32)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), decoder.Offset())
+
+	assert.Equal(t, int64(-1), Decoder{reader: &bytes.Buffer{}}.Offset())
+}
+
+func TestDecoder_ShortReads(t *testing.T) {
+	type value struct {
+		Data   []byte
+		Number uint64
+		Option OptionBool
+	}
+
+	expected := value{
+		Data:   bytes.Repeat([]byte{7}, maxPreallocatedBytes*2+1),
+		Number: 1 << 40,
+		Option: NewOptionBool(true),
+	}
+	data := encodeToBytes(t, expected)
+
+	// Streams that return one byte at a time, like slow network connections, decode like complete data.
+	var decoded value
+	err := NewDecoder(iotest.OneByteReader(bytes.NewReader(data))).Decode(&decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, decoded)
+
+	var b [4]byte
+	err = NewDecoder(iotest.HalfReader(bytes.NewReader([]byte{1, 2, 3}))).Decode(&b)
+	assert.EqualError(t, err, "expected more bytes, but could not decode any more")
+
+	// Long byte slices are read in chunks, a corrupt length fails when the stream ends.
+	decoder := NewDecoder(bytes.NewReader([]byte{0x03, 0xff, 0xff, 0xff, 0xff, 1, 2, 3}))
+	err = decoder.Decode(&decoded.Data)
+	assert.EqualError(t, err, "expected more bytes, but could not decode any more")
+	assert.Equal(t, int64(8), decoder.Offset())
+}
+
+func TestDecoder_SetMaxAllocation(t *testing.T) {
+	// A Vec<u64> that claims 2^30 items, but only holds one.
+	hostile := append([]byte{0x03, 0x00, 0x00, 0x00, 0x40}, make([]byte, 8)...)
+
+	decoder := NewDecoderFromBytes(hostile)
+	decoder.SetMaxAllocation(1 << 20)
+
+	var items []uint64
+	err := decoder.Decode(&items)
+	assert.True(t, errors.Is(err, ErrMaxAllocationExceeded))
+
+	// Without a limit, the items are decoded into a growing slice until the data ends.
+	err = NewDecoderFromBytes(hostile).Decode(&items)
+	assert.EqualError(t, err, "expected more bytes, but could not decode any more")
+
+	// The limit is shared by all values decoded by the decoder.
+	expected := make([]uint64, maxPreallocatedBytes)
+	for i := range expected {
+		expected[i] = uint64(i)
+	}
+	data := encodeToBytes(t, expected)
+
+	decoder = NewDecoderFromBytes(append(data, data...))
+	decoder.SetMaxAllocation(uint64(len(expected)*8*3) / 2)
+
+	err = decoder.Decode(&items)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, items)
+
+	var more []uint64
+	err = decoder.Decode(&more)
+	assert.True(t, errors.Is(err, ErrMaxAllocationExceeded))
+}
@@ -0,0 +1,169 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale_test
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type differentialInner struct {
+	Flag  bool
+	Items []uint16
+}
+
+type differentialStruct struct {
+	Bool    bool
+	Int8    int8
+	Int16   int16
+	Int32   int32
+	Int64   int64
+	Uint8   uint8
+	Uint16  uint16
+	Uint32  uint32
+	Uint64  uint64
+	Float32 float32
+	Float64 float64
+	String  string
+	Bytes   []byte
+	Array   [4]byte
+	U8s     []types.U8
+	U16s    []types.U16
+	Skipped uint64  `scale:"-"`
+	Since   *uint32 `scale:"optional"`
+	Tip     uint64  `scale:"compact"`
+	Map     map[uint16]string
+	Inner   []differentialInner
+	U128    types.U128
+	Account types.AccountID
+	Hashes  []types.Hash
+	Option  types.Option[types.U32]
+}
+
+type encodeResult struct {
+	encoded []byte
+	err     error
+}
+
+type decodeResult struct {
+	decoded any
+	err     error
+}
+
+func encodeDifferential(value any) encodeResult {
+	var buffer bytes.Buffer
+	err := scale.NewEncoder(&buffer).Encode(value)
+	return encodeResult{buffer.Bytes(), err}
+}
+
+func decodeDifferential(t reflect.Type, encoded []byte) decodeResult {
+	target := reflect.New(t)
+	err := scale.NewDecoder(bytes.NewReader(encoded)).Decode(target.Interface())
+	return decodeResult{target.Elem().Interface(), err}
+}
+
+// assertDifferential fuzzes values of T and asserts that encoding and decoding them gives the same results with and
+// without the type info cache.
+func assertDifferential[T any](t *testing.T, f *fuzz.Fuzzer, count int) {
+	defer scale.SetTypeInfoCacheEnabled(true)
+
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	for i := 0; i < count; i++ {
+		var value T
+		f.Fuzz(&value)
+
+		scale.SetTypeInfoCacheEnabled(true)
+		cachedEncode := encodeDifferential(value)
+
+		scale.SetTypeInfoCacheEnabled(false)
+		uncachedEncode := encodeDifferential(value)
+
+		require.Equal(t, uncachedEncode, cachedEncode)
+
+		if cachedEncode.err != nil {
+			continue
+		}
+
+		// Complete and truncated input decodes the same way.
+		for _, encoded := range [][]byte{cachedEncode.encoded, cachedEncode.encoded[:len(cachedEncode.encoded)/2]} {
+			scale.SetTypeInfoCacheEnabled(true)
+			cachedDecode := decodeDifferential(typ, encoded)
+
+			scale.SetTypeInfoCacheEnabled(false)
+			uncachedDecode := decodeDifferential(typ, encoded)
+
+			require.Equal(t, uncachedDecode, cachedDecode)
+		}
+	}
+}
+
+func TestTypeInfoCache_Differential(t *testing.T) {
+	// Enums are not fuzzed, as invalid ones do not decode to the same value and the misaligned data may allocate
+	// huge slices.
+	f := fuzz.NewWithSeed(1).NilChance(0.1).NumElements(0, 5)
+
+	t.Run("differentialStruct", func(t *testing.T) {
+		assertDifferential[differentialStruct](t, f, 500)
+	})
+	t.Run("Hashes", func(t *testing.T) {
+		assertDifferential[[]types.Hash](t, f, 100)
+	})
+	t.Run("AccountInfo", func(t *testing.T) {
+		assertDifferential[types.AccountInfo](t, f, 100)
+	})
+	t.Run("Weight", func(t *testing.T) {
+		assertDifferential[types.Weight](t, f, 100)
+	})
+	t.Run("Bytes", func(t *testing.T) {
+		assertDifferential[types.Bytes](t, f, 100)
+	})
+}
+
+func TestTypeInfoCache_Concurrent(t *testing.T) {
+	f := fuzz.NewWithSeed(2).NilChance(0).NumElements(1, 5)
+
+	values := make([]differentialStruct, 20)
+	expected := make([]encodeResult, len(values))
+
+	for i := range values {
+		f.Fuzz(&values[i])
+		expected[i] = encodeDifferential(values[i])
+	}
+
+	var wg sync.WaitGroup
+
+	for i := range values {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			assert.Equal(t, expected[i], encodeDifferential(values[i]))
+		}(i)
+	}
+
+	wg.Wait()
+}
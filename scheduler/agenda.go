@@ -0,0 +1,301 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/governance"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Periodic is the schedule of a periodic call, which is dispatched Count more times every Period blocks.
+type Periodic struct {
+	Period uint32
+	Count  uint32
+}
+
+// Origin is the origin a scheduled call is dispatched with, a variant of the OriginCaller of the runtime.
+type Origin struct {
+	// Caller is the name of the variant of the OriginCaller, e.g. system or Origins.
+	Caller string
+	// Name is the name of the origin of the caller, e.g. Root, Signed or the origin of a track like Treasurer.
+	Name string
+	// Fields are the dynamically decoded fields of the origin, e.g. the account of a Signed origin.
+	Fields []any
+}
+
+// IsRoot returns true for the Root origin of the system pallet.
+func (o Origin) IsRoot() bool {
+	return o.Caller == "system" && o.Name == "Root"
+}
+
+// Scheduled is a call that is scheduled to be dispatched at a block, as stored in Scheduler.Agenda.
+type Scheduled struct {
+	// Block is the block the call is dispatched at.
+	Block uint32
+	// Index is the index of the call in the agenda of the block, which identifies anonymous scheduled calls.
+	Index uint32
+	// ID is the name of named scheduled calls, nil for anonymous ones.
+	ID       []byte
+	Priority uint8
+	// Call is the bounded call as stored, see Client.ResolveCall.
+	Call     governance.Proposal
+	Periodic *Periodic
+	Origin   Origin
+	// Decoded is the resolved and decoded call, it is nil if the call could not be resolved.
+	Decoded *registry.DecodedCall
+	// Err is the error of resolving the call, ErrPreimageNotFound if the preimage of the call is not stored and
+	// ErrCallDecoding if the call could not be decoded.
+	Err error
+}
+
+// decodeAgenda decodes the Scheduler.Agenda value of the block. The slots of the agenda whose calls were dispatched or
+// cancelled are empty and skipped.
+func decodeAgenda(meta *types.Metadata, block uint32, data []byte) ([]*Scheduled, error) {
+	lookup := meta.AsMetadataV14.EfficientLookup
+
+	entry, err := storageEntry(meta, schedulerPallet, agendaEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	agendaType, err := types.ResolveSi1Type(lookup, entry.Type.AsMap.Value)
+	if err != nil {
+		return nil, ErrUnsupportedLayout.Wrap(err)
+	}
+
+	if !agendaType.Def.IsSequence {
+		return nil, ErrUnsupportedLayout.WithMsg("agenda is not a sequence")
+	}
+
+	scheduledType, ok := optionValueOf(lookup, agendaType.Def.Sequence.Type)
+	if !ok {
+		return nil, ErrUnsupportedLayout.WithMsg("agenda item is not an option")
+	}
+
+	reader := bytes.NewReader(data)
+	decoder := scale.NewDecoder(reader)
+
+	n, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return nil, ErrAgendaDecoding.Wrap(err)
+	}
+
+	var agenda []*Scheduled
+
+	for i := uint64(0); i < n.Uint64(); i++ {
+		some, err := decoder.ReadOneByte()
+		if err != nil {
+			return nil, ErrAgendaDecoding.Wrap(err)
+		}
+
+		if some == 0 {
+			continue
+		}
+
+		scheduled := &Scheduled{Block: block, Index: uint32(i)}
+
+		if err := decodeScheduled(meta, scheduledType, decoder, scheduled); err != nil {
+			return nil, err
+		}
+
+		agenda = append(agenda, scheduled)
+	}
+
+	if reader.Len() > 0 {
+		return nil, ErrAgendaDecoding.WithMsg("%d trailing bytes", reader.Len())
+	}
+
+	return agenda, nil
+}
+
+// decodeScheduled decodes the fields of a pallet_scheduler::Scheduled by their names. Only calls that are stored as
+// Bounded are supported, runtimes that store them as MaybeHashed predate the preimage pallet.
+func decodeScheduled(
+	meta *types.Metadata,
+	scheduledType types.Si1LookupTypeID,
+	decoder *scale.Decoder,
+	scheduled *Scheduled,
+) error {
+	lookup := meta.AsMetadataV14.EfficientLookup
+
+	t, ok := lookup[scheduledType.Int64()]
+	if !ok || !t.Def.IsComposite {
+		return ErrUnsupportedLayout.WithMsg("scheduled is not a composite")
+	}
+
+	for _, field := range t.Def.Composite.Fields {
+		var err error
+
+		switch field.Name {
+		case "maybe_id":
+			scheduled.ID, err = decodeID(lookup, field.Type, decoder)
+		case "priority":
+			scheduled.Priority, err = decoder.ReadOneByte()
+		case "call":
+			if !isBounded(lookup, field.Type) {
+				return ErrUnsupportedLayout.WithMsg("call is not bounded")
+			}
+
+			err = decoder.Decode(&scheduled.Call)
+		case "maybe_periodic":
+			var periodic types.Option[struct{ Period, Count types.U32 }]
+
+			if err = decoder.Decode(&periodic); err == nil {
+				if ok, value := periodic.Unwrap(); ok {
+					scheduled.Periodic = &Periodic{Period: uint32(value.Period), Count: uint32(value.Count)}
+				}
+			}
+		case "origin":
+			scheduled.Origin, err = decodeOrigin(meta, field.Type, decoder)
+		case "_phantom":
+		default:
+			return ErrUnsupportedLayout.WithMsg("scheduled field %s", field.Name)
+		}
+
+		if err != nil {
+			return ErrAgendaDecoding.WithMsg("field %s", field.Name).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// decodeID decodes the optional name of a scheduled call, a [u8; 32] or, in older runtimes, a Vec<u8>.
+func decodeID(lookup map[int64]*types.Si1Type, id types.Si1LookupTypeID, decoder *scale.Decoder) ([]byte, error) {
+	value, ok := optionValueOf(lookup, id)
+	if !ok {
+		return nil, ErrUnsupportedLayout.WithMsg("id is not an option")
+	}
+
+	t, err := types.ResolveSi1Type(lookup, value)
+	if err != nil {
+		return nil, ErrUnsupportedLayout.Wrap(err)
+	}
+
+	some, err := decoder.ReadOneByte()
+	if err != nil || some == 0 {
+		return nil, err
+	}
+
+	switch {
+	case t.Def.IsArray:
+		name := make([]byte, t.Def.Array.Len)
+
+		return name, decoder.Read(name)
+	case t.Def.IsSequence:
+		var name types.Bytes
+
+		return name, decoder.Decode(&name)
+	}
+
+	return nil, ErrUnsupportedLayout.WithMsg("id type %d", value.Int64())
+}
+
+// decodeOrigin decodes the OriginCaller with the names of its variants. The fields of the origins are decoded
+// dynamically with the registry.
+func decodeOrigin(meta *types.Metadata, id types.Si1LookupTypeID, decoder *scale.Decoder) (Origin, error) {
+	lookup := meta.AsMetadataV14.EfficientLookup
+
+	caller, err := decodeVariant(lookup, id, decoder)
+	if err != nil {
+		return Origin{}, err
+	}
+
+	origin := Origin{Caller: string(caller.Name)}
+	fields := caller.Fields
+
+	// The variants of the OriginCaller wrap the origins of the pallets.
+	if len(fields) == 1 {
+		if t, ok := lookup[fields[0].Type.Int64()]; ok && t.Def.IsVariant {
+			variant, err := decodeVariant(lookup, fields[0].Type, decoder)
+			if err != nil {
+				return Origin{}, err
+			}
+
+			origin.Name = string(variant.Name)
+			fields = variant.Fields
+		}
+	}
+
+	for _, field := range fields {
+		fieldDecoder, err := registry.NewFieldDecoder(meta, field.Type)
+		if err != nil {
+			return Origin{}, err
+		}
+
+		value, err := fieldDecoder.Decode(decoder)
+		if err != nil {
+			return Origin{}, err
+		}
+
+		origin.Fields = append(origin.Fields, value)
+	}
+
+	return origin, nil
+}
+
+// decodeVariant reads the index of the variant of the type with the given ID and returns the variant.
+func decodeVariant(
+	lookup map[int64]*types.Si1Type,
+	id types.Si1LookupTypeID,
+	decoder *scale.Decoder,
+) (*types.Si1Variant, error) {
+	t, ok := lookup[id.Int64()]
+	if !ok || !t.Def.IsVariant {
+		return nil, ErrUnsupportedLayout.WithMsg("type %d is not a variant", id.Int64())
+	}
+
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, variant := range t.Def.Variant.Variants {
+		if uint8(variant.Index) == b {
+			return &t.Def.Variant.Variants[i], nil
+		}
+	}
+
+	return nil, ErrUnsupportedLayout.WithMsg("variant %d of %v not found", b, t.Path)
+}
+
+// isBounded returns true if the type is frame_support::traits::Bounded.
+func isBounded(lookup map[int64]*types.Si1Type, id types.Si1LookupTypeID) bool {
+	t, ok := lookup[id.Int64()]
+
+	return ok && t.Def.IsVariant && len(t.Path) > 0 && t.Path[len(t.Path)-1] == "Bounded"
+}
+
+// optionValueOf returns the type ID of the value of an Option, or false if the type is no Option.
+func optionValueOf(lookup map[int64]*types.Si1Type, id types.Si1LookupTypeID) (types.Si1LookupTypeID, bool) {
+	t, ok := lookup[id.Int64()]
+	if !ok || !t.Def.IsVariant || len(t.Path) != 1 || t.Path[0] != "Option" {
+		return types.Si1LookupTypeID{}, false
+	}
+
+	for _, variant := range t.Def.Variant.Variants {
+		if variant.Name == "Some" && len(variant.Fields) == 1 {
+			return variant.Fields[0].Type, true
+		}
+	}
+
+	return types.Si1LookupTypeID{}, false
+}
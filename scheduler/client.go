@@ -0,0 +1,100 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	schedulerPallet = "Scheduler"
+	agendaEntry     = "Agenda"
+)
+
+// Client reads the calls scheduled by the scheduler pallet and resolves them with the preimages of the preimage
+// pallet.
+type Client struct {
+	stateRPC        state.State
+	registryFactory registry.Factory
+}
+
+// NewClient creates a Client that reads the storage of the scheduler and preimage pallets via the state RPC.
+func NewClient(stateRPC state.State) *Client {
+	return &Client{
+		stateRPC:        stateRPC,
+		registryFactory: registry.NewFactory(),
+	}
+}
+
+// GetAgenda returns the calls that are scheduled to be dispatched at the block, with their resolved calls. Calls that
+// can not be resolved are returned with the error, see Scheduled.Err.
+func (c *Client) GetAgenda(ctx context.Context, meta *types.Metadata, block uint32) ([]*Scheduled, error) {
+	raw, err := state.GetStorageEntryRawLatest(ctx, c.stateRPC, meta, schedulerPallet, agendaEntry, encodeU32(block))
+	if err != nil || raw == nil {
+		return nil, err
+	}
+
+	agenda, err := decodeAgenda(meta, block, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	callRegistry, err := c.registryFactory.CreateCallRegistry(meta)
+	if err != nil {
+		return nil, ErrCallRegistryCreation.Wrap(err)
+	}
+
+	for _, scheduled := range agenda {
+		scheduled.Decoded, scheduled.Err = c.ResolveCall(ctx, meta, callRegistry, scheduled.Call)
+	}
+
+	return agenda, nil
+}
+
+// storageEntry returns the metadata of the map storage entry of the pallet.
+func storageEntry(meta *types.Metadata, pallet, entry string) (types.StorageEntryMetadataV14, error) {
+	entryMetadata, err := meta.FindStorageEntryMetadata(pallet, entry)
+	if err != nil {
+		return types.StorageEntryMetadataV14{}, ErrStorageEntryNotFound.WithMsg("%s.%s", pallet, entry).Wrap(err)
+	}
+
+	entryV14, ok := entryMetadata.(types.StorageEntryMetadataV14)
+	if !ok || !entryV14.Type.IsMap {
+		return types.StorageEntryMetadataV14{}, ErrUnsupportedLayout.WithMsg("%s.%s is not a map", pallet, entry)
+	}
+
+	return entryV14, nil
+}
+
+// hasStorageEntry returns true if the runtime has the storage entry of the pallet.
+func hasStorageEntry(meta *types.Metadata, pallet, entry string) bool {
+	_, err := meta.FindStorageEntryMetadata(pallet, entry)
+
+	return err == nil
+}
+
+// encodeU32 returns the SCALE encoded u32, like the block numbers and lengths in the storage keys.
+func encodeU32(v uint32) []byte {
+	b, _ := codec.Encode(types.NewU32(v))
+
+	return b
+}
@@ -0,0 +1,191 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/governance"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testAccount = types.AccountID{1}
+
+// testScheduled is the encoding of a Scheduled of the Polkadot runtime, the origin is encoded by the test.
+type testScheduled struct {
+	ID       types.Option[[32]byte]
+	Priority types.U8
+	Call     governance.Proposal
+	Periodic types.Option[struct{ Period, Count types.U32 }]
+	Origin   types.Data
+}
+
+func newTestCall(t *testing.T, meta *types.Metadata, remark []byte) []byte {
+	call, err := types.NewCall(meta, "System.remark", types.NewBytes(remark))
+	require.NoError(t, err)
+
+	return statetest.Encode(t, call)
+}
+
+func TestClient_GetAgenda(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+
+	inline := newTestCall(t, meta, []byte{1})
+	preimage := newTestCall(t, meta, []byte{2, 3})
+	lookup := governance.ProposalLookup{Hash: types.Hash{1}, Len: types.U32(len(preimage))}
+	missing := governance.ProposalLookup{Hash: types.Hash{2}, Len: 10}
+	rootOrigin := types.NewData([]byte{0, 0})
+	signedOrigin := types.NewData(append([]byte{0, 1}, testAccount[:]...))
+
+	agenda := []types.Option[testScheduled]{
+		types.NewOption(testScheduled{
+			ID:       types.NewOption([32]byte{7}),
+			Priority: 63,
+			Call:     governance.Proposal{IsInline: true, AsInline: inline},
+			Periodic: types.NewOption(struct{ Period, Count types.U32 }{Period: 100, Count: 5}),
+			Origin:   rootOrigin,
+		}),
+		types.NewEmptyOption[testScheduled](),
+		types.NewOption(testScheduled{
+			Call:   governance.Proposal{IsLookup: true, AsLookup: lookup},
+			Origin: signedOrigin,
+		}),
+		types.NewOption(testScheduled{
+			Call:   governance.Proposal{IsLookup: true, AsLookup: missing},
+			Origin: rootOrigin,
+		}),
+		types.NewOption(testScheduled{
+			Call:   governance.Proposal{IsInline: true, AsInline: types.Bytes{255, 255}},
+			Origin: rootOrigin,
+		}),
+	}
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorageRaw(t, meta, schedulerPallet, agendaEntry, statetest.Encode(t, agenda), []byte{10, 0, 0, 0})
+	stateRPC.ExpectStorage(
+		t, meta, preimagePallet, preimageForEntry, types.Bytes(preimage), statetest.Encode(t, lookup.Hash, lookup.Len),
+	)
+	stateRPC.ExpectStorage(
+		t, meta, preimagePallet, preimageForEntry, nil, statetest.Encode(t, missing.Hash, missing.Len),
+	)
+
+	res, err := c.GetAgenda(ctx, meta, 10)
+	require.NoError(t, err)
+	require.Len(t, res, 4)
+
+	assert.Equal(t, uint32(10), res[0].Block)
+	assert.Equal(t, uint32(0), res[0].Index)
+	assert.Equal(t, []byte{7, 31: 0}, res[0].ID)
+	assert.Equal(t, uint8(63), res[0].Priority)
+	assert.Equal(t, &Periodic{Period: 100, Count: 5}, res[0].Periodic)
+	assert.True(t, res[0].Origin.IsRoot())
+	require.NoError(t, res[0].Err)
+	assert.Equal(t, "System", res[0].Decoded.PalletName)
+	assert.Equal(t, "remark", res[0].Decoded.CallName)
+	assert.Equal(t, []any{types.U8(1)}, res[0].Decoded.Args[0].Value)
+
+	assert.Equal(t, uint32(2), res[1].Index)
+	assert.Nil(t, res[1].ID)
+	assert.Nil(t, res[1].Periodic)
+	assert.Equal(t, "system", res[1].Origin.Caller)
+	assert.Equal(t, "Signed", res[1].Origin.Name)
+	assert.Len(t, res[1].Origin.Fields, 1)
+	require.NoError(t, res[1].Err)
+	assert.Equal(t, []any{types.U8(2), types.U8(3)}, res[1].Decoded.Args[0].Value)
+
+	assert.Nil(t, res[2].Decoded)
+	assert.ErrorIs(t, res[2].Err, ErrPreimageNotFound)
+
+	assert.Nil(t, res[3].Decoded)
+	assert.ErrorIs(t, res[3].Err, ErrCallDecoding)
+	assert.NotErrorIs(t, res[3].Err, ErrPreimageNotFound)
+
+	stateRPC.ExpectStorageRaw(t, meta, schedulerPallet, agendaEntry, nil, []byte{11, 0, 0, 0})
+
+	res, err = c.GetAgenda(ctx, meta, 11)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	stateRPC.ExpectStorageRaw(t, meta, schedulerPallet, agendaEntry, []byte{4, 1, 0}, []byte{12, 0, 0, 0})
+
+	_, err = c.GetAgenda(ctx, meta, 12)
+	assert.ErrorIs(t, err, ErrAgendaDecoding)
+}
+
+func TestClient_ResolveCall(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+	preimage := newTestCall(t, meta, []byte{4})
+	hash := types.Hash{3}
+
+	callRegistry, err := NewClient(nil).registryFactory.CreateCallRegistry(meta)
+	require.NoError(t, err)
+
+	t.Run("legacy", func(t *testing.T) {
+		// Unrequested with the deposit and the length.
+		status := append(append([]byte{0}, testAccount[:]...), make([]byte, 16)...)
+		status = append(status, statetest.Encode(t, types.NewU32(uint32(len(preimage))))...)
+
+		key := append(hash[:], statetest.Encode(t, types.U32(len(preimage)))...)
+
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorageRaw(t, meta, preimagePallet, statusForEntry, status, hash[:])
+		stateRPC.ExpectStorage(t, meta, preimagePallet, preimageForEntry, types.Bytes(preimage), key)
+
+		decoded, err := c.ResolveCall(ctx, meta, callRegistry, governance.Proposal{IsLegacy: true, AsLegacy: hash})
+		require.NoError(t, err)
+		assert.Equal(t, "remark", decoded.CallName)
+		assert.Equal(t, []any{types.U8(4)}, decoded.Args[0].Value)
+	})
+
+	t.Run("requested", func(t *testing.T) {
+		// Requested without deposit, with a count of 1 and without a length.
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorageRaw(
+			t, meta, preimagePallet, statusForEntry, []byte{1, 0, 1, 0, 0, 0, 0}, hash[:],
+		)
+
+		_, err := c.ResolveCall(ctx, meta, callRegistry, governance.Proposal{IsLegacy: true, AsLegacy: hash})
+		assert.ErrorIs(t, err, ErrPreimageNotFound)
+
+		stateRPC.ExpectStorageRaw(t, meta, preimagePallet, statusForEntry, nil, hash[:])
+
+		_, err = c.GetPreimage(ctx, meta, hash)
+		assert.ErrorIs(t, err, ErrPreimageNotFound)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.On("GetStorageRawLatestContext", mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+		_, err := c.ResolveCall(ctx, meta, callRegistry, governance.Proposal{IsLegacy: true, AsLegacy: hash})
+		assert.ErrorIs(t, err, ErrStorageRetrieval)
+
+		_, err = c.GetAgenda(ctx, meta, 10)
+		assert.ErrorIs(t, err, ErrStorageRetrieval)
+
+		_, err = c.ResolveCall(ctx, meta, callRegistry, governance.Proposal{})
+		assert.ErrorIs(t, err, ErrCallDecoding)
+	})
+}
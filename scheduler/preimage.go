@@ -0,0 +1,208 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/governance"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	preimagePallet        = "Preimage"
+	preimageForEntry      = "PreimageFor"
+	requestStatusForEntry = "RequestStatusFor"
+	statusForEntry        = "StatusFor"
+)
+
+// ResolveCall returns the decoded call. Inline calls are decoded directly, the preimages of Legacy and Lookup calls
+// are read from the preimage pallet. ErrPreimageNotFound is returned if the preimage is not stored, ErrCallDecoding
+// if the call can not be decoded with the call registry.
+func (c *Client) ResolveCall(
+	ctx context.Context,
+	meta *types.Metadata,
+	callRegistry registry.CallRegistry,
+	call governance.Proposal,
+) (*registry.DecodedCall, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch {
+	case call.IsInline:
+		data = call.AsInline
+	case call.IsLookup:
+		length := uint32(call.AsLookup.Len)
+
+		data, err = c.getPreimage(ctx, meta, call.AsLookup.Hash, &length)
+	case call.IsLegacy:
+		data, err = c.getPreimage(ctx, meta, call.AsLegacy, nil)
+	default:
+		return nil, ErrCallDecoding.WithMsg("empty call")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := callRegistry.DecodeCall(data)
+	if err != nil {
+		return nil, ErrCallDecoding.WithMsg("call %s", call.Hash().Hex()).Wrap(err)
+	}
+
+	return decoded, nil
+}
+
+// GetPreimage returns the preimage with the given hash. ErrPreimageNotFound is returned if it is not stored.
+func (c *Client) GetPreimage(ctx context.Context, meta *types.Metadata, hash types.Hash) ([]byte, error) {
+	return c.getPreimage(ctx, meta, hash, nil)
+}
+
+// getPreimage returns the preimage with the given hash. Newer runtimes store the preimages by hash and length, the
+// length is read from the request status of the preimage if it is not known.
+func (c *Client) getPreimage(
+	ctx context.Context,
+	meta *types.Metadata,
+	hash types.Hash,
+	length *uint32,
+) ([]byte, error) {
+	entry, err := storageEntry(meta, preimagePallet, preimageForEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	key := hash[:]
+
+	// The preimages of newer runtimes are stored by the (hash, len) tuple.
+	if keys, ok := meta.AsMetadataV14.EfficientLookup[entry.Type.AsMap.Key.Int64()]; ok && keys.Def.IsTuple {
+		if length == nil {
+			if length, err = c.getPreimageLength(ctx, meta, hash); err != nil {
+				return nil, err
+			}
+		}
+
+		key = append(key, encodeU32(*length)...)
+	}
+
+	var preimage types.Bytes
+
+	ok, err := state.GetStorageEntryLatest(ctx, c.stateRPC, meta, preimagePallet, preimageForEntry, &preimage, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrPreimageNotFound.WithMsg("preimage %s", hash.Hex())
+	}
+
+	return preimage, nil
+}
+
+// getPreimageLength returns the length of the preimage from its request status, which is stored in RequestStatusFor
+// or, in older runtimes, in StatusFor.
+func (c *Client) getPreimageLength(ctx context.Context, meta *types.Metadata, hash types.Hash) (*uint32, error) {
+	entryName := requestStatusForEntry
+	if !hasStorageEntry(meta, preimagePallet, requestStatusForEntry) {
+		entryName = statusForEntry
+	}
+
+	entry, err := storageEntry(meta, preimagePallet, entryName)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := state.GetStorageEntryRawLatest(ctx, c.stateRPC, meta, preimagePallet, entryName, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	if raw == nil {
+		return nil, ErrPreimageNotFound.WithMsg("status of preimage %s", hash.Hex())
+	}
+
+	length, err := decodeRequestStatusLength(meta, entry.Type.AsMap.Value, raw)
+	if err != nil {
+		return nil, ErrPreimageStatusDecoding.WithMsg("preimage %s", hash.Hex()).Wrap(err)
+	}
+
+	if length == nil {
+		return nil, ErrPreimageNotFound.WithMsg("preimage %s is requested but not noted", hash.Hex())
+	}
+
+	return length, nil
+}
+
+// decodeRequestStatusLength decodes the length of the preimage from its request status, nil if the preimage was
+// requested but not noted. The other fields, e.g. the deposits or tickets, are decoded dynamically.
+func decodeRequestStatusLength(meta *types.Metadata, id types.Si1LookupTypeID, data []byte) (*uint32, error) {
+	lookup := meta.AsMetadataV14.EfficientLookup
+	decoder := scale.NewDecoder(bytes.NewReader(data))
+
+	variant, err := decodeVariant(lookup, id, decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range variant.Fields {
+		switch field.Name {
+		case "len", "maybe_len":
+			if _, ok := optionValueOf(lookup, field.Type); ok {
+				var length types.Option[types.U32]
+
+				if err := decoder.Decode(&length); err != nil {
+					return nil, err
+				}
+
+				ok, value := length.Unwrap()
+				if !ok {
+					return nil, nil
+				}
+
+				l := uint32(value)
+
+				return &l, nil
+			}
+
+			var length types.U32
+
+			if err := decoder.Decode(&length); err != nil {
+				return nil, err
+			}
+
+			l := uint32(length)
+
+			return &l, nil
+		default:
+			fieldDecoder, err := registry.NewFieldDecoder(meta, field.Type)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, err := fieldDecoder.Decode(decoder); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, ErrUnsupportedLayout.WithMsg("request status %s has no length", variant.Name)
+}
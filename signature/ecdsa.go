@@ -0,0 +1,178 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	secp256k1 "github.com/ethereum/go-ethereum/crypto"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"golang.org/x/crypto/blake2b"
+)
+
+// EcdsaHasher is the hash of the payload that is signed by ecdsa keys, which depends on the chain.
+type EcdsaHasher uint8
+
+const (
+	// EcdsaHasherBlake2_256 is used by Substrate chains with the MultiSignature::Ecdsa signature.
+	EcdsaHasherBlake2_256 EcdsaHasher = iota
+	// EcdsaHasherKeccak256 is used by Ethereum-compatible chains, e.g. Moonbeam.
+	EcdsaHasherKeccak256
+)
+
+const (
+	// EcdsaSignatureLength is the length of a recoverable ecdsa signature, R || S || V.
+	EcdsaSignatureLength = 65
+	// EcdsaPublicKeyLength is the length of a compressed ecdsa public key.
+	EcdsaPublicKeyLength = 33
+)
+
+var (
+	ErrEcdsaUnknownHasher          = errors.New("unknown ecdsa hasher")
+	ErrEcdsaInvalidSignatureLength = errors.New("invalid ecdsa signature length")
+	ErrEcdsaInvalidPublicKey       = errors.New("invalid ecdsa public key")
+)
+
+// EcdsaKeyringPairFromSecret creates an Ecdsa KeyPair based on seed/phrase, network and the hasher of the chain. Only
+// hard derivation paths are supported for ecdsa keys.
+func EcdsaKeyringPairFromSecret(seedOrPhrase string, network uint16, hasher EcdsaHasher) (KeyringPair, error) {
+	kp, err := KeyringPairFromSecretWithScheme(seedOrPhrase, network, Ecdsa)
+	if err != nil {
+		return KeyringPair{}, err
+	}
+
+	kp.Hasher = hasher
+
+	return kp, nil
+}
+
+var TestEcdsaKeyringPairAlice = KeyringPair{
+	URI:       "//Alice",
+	PublicKey: []byte{0x02, 0x0a, 0x10, 0x91, 0x34, 0x1f, 0xe5, 0x66, 0x4b, 0xfa, 0x17, 0x82, 0xd5, 0xe0, 0x47, 0x79, 0x68, 0x90, 0x68, 0xc9, 0x16, 0xb0, 0x4c, 0xb3, 0x65, 0xec, 0x31, 0x53, 0x75, 0x56, 0x84, 0xd9, 0xa1}, //nolint:lll
+	Address:   "5C7C2Z5sWbytvHpuLTvzKunnnRwQxft1jiqrLD5rhucQ5S9X",
+	Scheme:    Ecdsa,
+	Hasher:    EcdsaHasherBlake2_256,
+}
+
+// AccountID20 returns the Ethereum-style AccountId20 of an Ecdsa key pair, see EcdsaAccountID20.
+func (kp KeyringPair) AccountID20() ([]byte, error) {
+	return EcdsaAccountID20(kp.PublicKey)
+}
+
+// SignEcdsa signs data with the ecdsa private key under the given derivation path, returning the 65 byte recoverable
+// signature. Like for sr25519 signatures, data that is longer than 256 bytes is hashed with blake2-256 first, the
+// result is then hashed with the given hasher before signing.
+func SignEcdsa(data []byte, privateKeyURI string, hasher EcdsaHasher) ([]byte, error) {
+	digest, err := ecdsaDigest(data, hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	kyr, err := subkey.DeriveKeyPair(ecdsa.Scheme{}, privateKeyURI)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := secp256k1.ToECDSA(kyr.Seed())
+	if err != nil {
+		return nil, err
+	}
+
+	return secp256k1.Sign(digest, secret)
+}
+
+// VerifyEcdsa verifies data using the provided recoverable signature and the compressed public key, see SignEcdsa.
+func VerifyEcdsa(data []byte, sig []byte, publicKey []byte, hasher EcdsaHasher) (bool, error) {
+	if len(sig) != EcdsaSignatureLength {
+		return false, fmt.Errorf("%w: %d", ErrEcdsaInvalidSignatureLength, len(sig))
+	}
+
+	if len(publicKey) != EcdsaPublicKeyLength {
+		return false, fmt.Errorf("%w: length %d", ErrEcdsaInvalidPublicKey, len(publicKey))
+	}
+
+	digest, err := ecdsaDigest(data, hasher)
+	if err != nil {
+		return false, err
+	}
+
+	recovered, err := secp256k1.SigToPub(digest, sig)
+	if err != nil {
+		return false, nil //nolint:nilerr
+	}
+
+	return bytes.Equal(secp256k1.CompressPubkey(recovered), publicKey), nil
+}
+
+// RecoverEcdsaPublicKey returns the compressed public key that created the recoverable signature of data, see
+// SignEcdsa. Ecdsa accounts are identified by the hash of their public key, which can be verified against the
+// recovered key, see EcdsaAccountID and EcdsaAccountID20.
+func RecoverEcdsaPublicKey(data []byte, sig []byte, hasher EcdsaHasher) ([]byte, error) {
+	if len(sig) != EcdsaSignatureLength {
+		return nil, fmt.Errorf("%w: %d", ErrEcdsaInvalidSignatureLength, len(sig))
+	}
+
+	digest, err := ecdsaDigest(data, hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	recovered, err := secp256k1.SigToPub(digest, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	return secp256k1.CompressPubkey(recovered), nil
+}
+
+// EcdsaAccountID returns the AccountId32 of the compressed public key, which is its blake2-256 hash.
+func EcdsaAccountID(publicKey []byte) []byte {
+	h := blake2b.Sum256(publicKey)
+	return h[:]
+}
+
+// EcdsaAccountID20 returns the Ethereum-style AccountId20 of the compressed public key, which is the last 20 bytes of
+// the keccak-256 hash of the uncompressed public key.
+func EcdsaAccountID20(publicKey []byte) ([]byte, error) {
+	pub, err := secp256k1.DecompressPubkey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEcdsaInvalidPublicKey, err)
+	}
+
+	return secp256k1.PubkeyToAddress(*pub).Bytes(), nil
+}
+
+func ecdsaDigest(data []byte, hasher EcdsaHasher) ([]byte, error) {
+	// if data is longer than 256 bytes, hash it first
+	if len(data) > 256 {
+		h := blake2b.Sum256(data)
+		data = h[:]
+	}
+
+	switch hasher {
+	case EcdsaHasherBlake2_256:
+		h := blake2b.Sum256(data)
+		return h[:], nil
+	case EcdsaHasherKeccak256:
+		return secp256k1.Keccak256(data), nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrEcdsaUnknownHasher, hasher)
+	}
+}
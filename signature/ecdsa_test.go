@@ -0,0 +1,168 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+)
+
+// testAlithSeed is the private key of the Alith development account of Moonbeam.
+var testAlithSeed = "0x5fb92d6e98884f76de468fa3f6278f8807c48bebc13595d45af5bdc4da702133"
+
+func TestEcdsaKeyringPairFromSecret(t *testing.T) {
+	p, err := EcdsaKeyringPairFromSecret("//Alice", 42, EcdsaHasherBlake2_256)
+	assert.NoError(t, err)
+	assert.Equal(t, TestEcdsaKeyringPairAlice, p)
+
+	assert.Equal(t, codec.MustHexDecodeString("0x01e552298e47454041ea31273b4b630c64c104e4514aa3643490b8aaca9cf8ed"),
+		p.AccountID())
+}
+
+func TestKeyringPairFromSecretWithScheme_Ecdsa(t *testing.T) {
+	p, err := KeyringPairFromSecretWithScheme("//Alice", 42, Ecdsa)
+	assert.NoError(t, err)
+	assert.Equal(t, TestEcdsaKeyringPairAlice, p)
+	assert.Equal(t, "ecdsa", p.Scheme.String())
+
+	data := []byte("hello!")
+
+	sig, err := SignWithScheme(data, p.URI, Ecdsa)
+	assert.NoError(t, err)
+
+	ok, err := VerifyWithScheme(data, sig, p.URI, Ecdsa)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = VerifyWithPublicKey(data, sig, p.PublicKey, Ecdsa)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = p.Verify(data, sig)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestEcdsaKeyringPair_AccountID20(t *testing.T) {
+	p, err := EcdsaKeyringPairFromSecret(testAlithSeed, 1287, EcdsaHasherKeccak256)
+	assert.NoError(t, err)
+
+	accountID20, err := p.AccountID20()
+	assert.NoError(t, err)
+	assert.Equal(t, codec.MustHexDecodeString("0xf24ff3a9cf04c71dbc94d0b566f7a27b94566cac"), accountID20)
+
+	_, err = EcdsaAccountID20([]byte{1, 2, 3})
+	assert.ErrorIs(t, err, ErrEcdsaInvalidPublicKey)
+}
+
+func TestEcdsaKeyringPair_SignAndVerify(t *testing.T) {
+	data := []byte("hello!")
+	longData := make([]byte, 1000)
+
+	for _, hasher := range []EcdsaHasher{EcdsaHasherBlake2_256, EcdsaHasherKeccak256} {
+		p := TestEcdsaKeyringPairAlice
+		p.Hasher = hasher
+
+		for _, d := range [][]byte{data, longData} {
+			sig, err := p.Sign(d)
+			assert.NoError(t, err)
+			assert.Len(t, sig, EcdsaSignatureLength)
+
+			ok, err := p.Verify(d, sig)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = p.Verify(append([]byte{0}, d...), sig)
+			assert.NoError(t, err)
+			assert.False(t, ok)
+		}
+	}
+
+	// The signature is only valid for the hasher it was created with.
+	sig, err := SignEcdsa(data, TestEcdsaKeyringPairAlice.URI, EcdsaHasherKeccak256)
+	assert.NoError(t, err)
+
+	ok, err := VerifyEcdsa(data, sig, TestEcdsaKeyringPairAlice.PublicKey, EcdsaHasherBlake2_256)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestSignEcdsa_Subkey(t *testing.T) {
+	data := []byte("hello!")
+
+	sig, err := SignEcdsa(data, TestEcdsaKeyringPairAlice.URI, EcdsaHasherBlake2_256)
+	assert.NoError(t, err)
+
+	pub, err := ecdsa.Scheme{}.FromPublicKey(TestEcdsaKeyringPairAlice.PublicKey)
+	assert.NoError(t, err)
+	assert.True(t, pub.Verify(data, sig))
+
+	kyr, err := subkey.DeriveKeyPair(ecdsa.Scheme{}, TestEcdsaKeyringPairAlice.URI)
+	assert.NoError(t, err)
+
+	subkeySig, err := kyr.Sign(data)
+	assert.NoError(t, err)
+
+	ok, err := VerifyEcdsa(data, subkeySig, TestEcdsaKeyringPairAlice.PublicKey, EcdsaHasherBlake2_256)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestRecoverEcdsaPublicKey(t *testing.T) {
+	data := []byte("hello!")
+
+	sig, err := TestEcdsaKeyringPairAlice.Sign(data)
+	assert.NoError(t, err)
+
+	publicKey, err := RecoverEcdsaPublicKey(data, sig, EcdsaHasherBlake2_256)
+	assert.NoError(t, err)
+	assert.Equal(t, TestEcdsaKeyringPairAlice.PublicKey, publicKey)
+
+	// A signature of other data recovers another key.
+	publicKey, err = RecoverEcdsaPublicKey([]byte("bye!"), sig, EcdsaHasherBlake2_256)
+	assert.NoError(t, err)
+	assert.NotEqual(t, TestEcdsaKeyringPairAlice.PublicKey, publicKey)
+
+	_, err = RecoverEcdsaPublicKey(data, sig[:64], EcdsaHasherBlake2_256)
+	assert.ErrorIs(t, err, ErrEcdsaInvalidSignatureLength)
+
+	_, err = RecoverEcdsaPublicKey(data, sig, EcdsaHasher(9))
+	assert.ErrorIs(t, err, ErrEcdsaUnknownHasher)
+}
+
+func TestEcdsa_Errors(t *testing.T) {
+	_, err := SignEcdsa([]byte{1}, TestEcdsaKeyringPairAlice.URI, EcdsaHasher(9))
+	assert.ErrorIs(t, err, ErrEcdsaUnknownHasher)
+
+	_, err = VerifyEcdsa([]byte{1}, make([]byte, 64), TestEcdsaKeyringPairAlice.PublicKey, EcdsaHasherBlake2_256)
+	assert.ErrorIs(t, err, ErrEcdsaInvalidSignatureLength)
+
+	_, err = VerifyEcdsa([]byte{1}, make([]byte, 65), []byte{1}, EcdsaHasherBlake2_256)
+	assert.ErrorIs(t, err, ErrEcdsaInvalidPublicKey)
+
+	ok, err := VerifyEcdsa([]byte{1}, make([]byte, 65), TestEcdsaKeyringPairAlice.PublicKey, EcdsaHasherBlake2_256)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = EcdsaKeyringPairFromSecret("//Alice/soft", 42, EcdsaHasherBlake2_256)
+	assert.Error(t, err)
+}
@@ -0,0 +1,354 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vedhavyas/go-subkey/v2"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keystoreVersion      = "3"
+	keystoreContentPkcs8 = "pkcs8"
+	keystoreTypeScrypt   = "scrypt"
+	keystoreTypeXsalsa20 = "xsalsa20-poly1305"
+	keystoreSaltLength   = 32
+	// The scrypt parameters N, p and r are encoded as little endian u32 after the salt.
+	keystoreScryptParamsLength = 12
+	keystoreNonceLength        = 24
+	keystoreSecretLength       = 64
+	keystorePublicKeyLength    = 32
+
+	// The scrypt parameters used by polkadot-js, which rejects any others.
+	keystoreScryptN = 1 << 15
+	keystoreScryptP = 1
+	keystoreScryptR = 8
+
+	keystoreMaxScryptN = 1 << 20
+)
+
+var (
+	keystorePkcs8Header  = []byte{48, 83, 2, 1, 1, 48, 5, 6, 3, 43, 101, 112, 4, 34, 4, 32}
+	keystorePkcs8Divider = []byte{161, 35, 3, 33, 0}
+)
+
+var (
+	ErrKeystoreInvalidPassphrase   = errors.New("invalid keystore passphrase")
+	ErrKeystoreUnsupportedEncoding = errors.New("unsupported keystore encoding")
+	ErrKeystoreInvalidData         = errors.New("invalid keystore data")
+	ErrKeystoreUnsupportedKey      = errors.New("key pair cannot be exported")
+)
+
+// keystoreJSON is the JSON of accounts exported from polkadot-js.
+type keystoreJSON struct {
+	Address  string           `json:"address"`
+	Encoded  string           `json:"encoded"`
+	Encoding keystoreEncoding `json:"encoding"`
+	Meta     json.RawMessage  `json:"meta"`
+}
+
+type keystoreEncoding struct {
+	Content []string `json:"content"`
+	Type    []string `json:"type"`
+	Version string   `json:"version"`
+}
+
+// KeyringPairFromJSON decrypts an account that was exported from polkadot-js in the version 3 JSON format, which is
+// encrypted via scrypt and xsalsa20-poly1305. Both sr25519 and ed25519 accounts are supported. The address of the key
+// pair keeps the network of the exported address.
+func KeyringPairFromJSON(jsonBytes []byte, passphrase string) (KeyringPair, error) {
+	var keystore keystoreJSON
+	if err := json.Unmarshal(jsonBytes, &keystore); err != nil {
+		return KeyringPair{}, fmt.Errorf("%w: %v", ErrKeystoreInvalidData, err)
+	}
+
+	scheme, err := keystore.Encoding.scheme()
+	if err != nil {
+		return KeyringPair{}, err
+	}
+
+	encoded, err := base64.StdEncoding.DecodeString(keystore.Encoded)
+	if err != nil {
+		return KeyringPair{}, fmt.Errorf("%w: %v", ErrKeystoreInvalidData, err)
+	}
+
+	decrypted, err := keystoreDecrypt(encoded, passphrase)
+	if err != nil {
+		return KeyringPair{}, err
+	}
+
+	secretKey, publicKey, err := keystoreDecodePkcs8(decrypted)
+	if err != nil {
+		return KeyringPair{}, err
+	}
+
+	network, addressPublicKey, err := subkey.SS58Decode(keystore.Address)
+	if err != nil {
+		return KeyringPair{}, fmt.Errorf("%w: address: %v", ErrKeystoreInvalidData, err)
+	}
+
+	if !bytes.Equal(addressPublicKey, publicKey) {
+		return KeyringPair{}, fmt.Errorf("%w: address does not match the public key", ErrKeystoreInvalidData)
+	}
+
+	var seed []byte
+
+	switch scheme {
+	case Sr25519:
+		// polkadot-js keeps the secret key in the ed25519 format, i.e. the scalar multiplied by the cofactor.
+		seed = append(divideScalarByCofactor(secretKey[:32]), secretKey[32:]...)
+	case Ed25519:
+		// polkadot-js keeps the seed followed by the public key.
+		seed = secretKey[:32]
+	}
+
+	kp, err := KeyringPairFromSecretWithScheme(subkey.EncodeHex(seed), network, scheme)
+	if err != nil {
+		return KeyringPair{}, err
+	}
+
+	if !bytes.Equal(kp.PublicKey, publicKey) {
+		return KeyringPair{}, fmt.Errorf("%w: secret key does not match the public key", ErrKeystoreInvalidData)
+	}
+
+	return kp, nil
+}
+
+// ExportToJSON encrypts the key pair in the version 3 JSON format of polkadot-js, which can be imported there. Key
+// pairs with soft derivation paths cannot be exported, since their seed is unknown.
+func ExportToJSON(pair KeyringPair, passphrase string) ([]byte, error) {
+	subkeyScheme, err := pair.Scheme.subkeyScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	kyr, err := subkey.DeriveKeyPair(subkeyScheme, pair.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := kyr.Seed()
+
+	var secretKey []byte
+
+	switch {
+	case pair.Scheme == Sr25519 && len(seed) == 32:
+		// The seed is a mini secret key, which is expanded like schnorrkel does, but without the division by the
+		// cofactor.
+		h := sha512.Sum512(seed)
+		h[0] &= 248
+		h[31] &= 63
+		h[31] |= 64
+		secretKey = h[:]
+	case pair.Scheme == Sr25519 && len(seed) == keystoreSecretLength:
+		secretKey = append(multiplyScalarByCofactor(seed[:32]), seed[32:]...)
+	case pair.Scheme == Ed25519 && len(seed) == 32:
+		secretKey = append(append([]byte{}, seed...), kyr.Public()...)
+	default:
+		return nil, ErrKeystoreUnsupportedKey
+	}
+
+	plain := make([]byte, 0, len(keystorePkcs8Header)+len(secretKey)+len(keystorePkcs8Divider)+keystorePublicKeyLength)
+	plain = append(plain, keystorePkcs8Header...)
+	plain = append(plain, secretKey...)
+	plain = append(plain, keystorePkcs8Divider...)
+	plain = append(plain, kyr.Public()...)
+
+	encoded, err := keystoreEncrypt(plain, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	address := pair.Address
+	if address == "" {
+		address = kyr.SS58Address(42)
+	}
+
+	meta, err := json.Marshal(map[string]interface{}{"whenCreated": time.Now().UnixMilli()})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(keystoreJSON{
+		Address: address,
+		Encoded: base64.StdEncoding.EncodeToString(encoded),
+		Encoding: keystoreEncoding{
+			Content: []string{keystoreContentPkcs8, pair.Scheme.String()},
+			Type:    []string{keystoreTypeScrypt, keystoreTypeXsalsa20},
+			Version: keystoreVersion,
+		},
+		Meta: meta,
+	})
+}
+
+// scheme returns the crypto scheme of the keystore, if its encoding is supported.
+func (e keystoreEncoding) scheme() (Scheme, error) {
+	if e.Version != keystoreVersion {
+		return 0, fmt.Errorf("%w: version %q", ErrKeystoreUnsupportedEncoding, e.Version)
+	}
+
+	if len(e.Type) != 2 || e.Type[0] != keystoreTypeScrypt || e.Type[1] != keystoreTypeXsalsa20 {
+		return 0, fmt.Errorf("%w: type %v", ErrKeystoreUnsupportedEncoding, e.Type)
+	}
+
+	if len(e.Content) < 2 || e.Content[0] != keystoreContentPkcs8 {
+		return 0, fmt.Errorf("%w: content %v", ErrKeystoreUnsupportedEncoding, e.Content)
+	}
+
+	switch e.Content[1] {
+	case Sr25519.String():
+		return Sr25519, nil
+	case Ed25519.String():
+		return Ed25519, nil
+	default:
+		return 0, fmt.Errorf("%w: key type %q", ErrKeystoreUnsupportedEncoding, e.Content[1])
+	}
+}
+
+// keystoreDecrypt decrypts the encoded keystore, which holds the scrypt salt and parameters, followed by the nonce
+// and the secretbox.
+func keystoreDecrypt(encoded []byte, passphrase string) ([]byte, error) {
+	minLength := keystoreSaltLength + keystoreScryptParamsLength + keystoreNonceLength + secretbox.Overhead
+	if len(encoded) < minLength {
+		return nil, fmt.Errorf("%w: encoded length %d", ErrKeystoreInvalidData, len(encoded))
+	}
+
+	salt, encoded := encoded[:keystoreSaltLength], encoded[keystoreSaltLength:]
+
+	n := binary.LittleEndian.Uint32(encoded[0:4])
+	p := binary.LittleEndian.Uint32(encoded[4:8])
+	r := binary.LittleEndian.Uint32(encoded[8:12])
+	encoded = encoded[keystoreScryptParamsLength:]
+
+	// Limit the memory used by scrypt, the parameters of polkadot-js are way below.
+	if n > keystoreMaxScryptN || r > keystoreScryptR || p > keystoreScryptP {
+		return nil, fmt.Errorf("%w: scrypt parameters N=%d, p=%d, r=%d", ErrKeystoreUnsupportedEncoding, n, p, r)
+	}
+
+	key, err := keystoreKey(passphrase, salt, int(n), int(r), int(p))
+	if err != nil {
+		return nil, fmt.Errorf("%w: scrypt: %v", ErrKeystoreInvalidData, err)
+	}
+
+	var nonce [keystoreNonceLength]byte
+	copy(nonce[:], encoded[:keystoreNonceLength])
+
+	decrypted, ok := secretbox.Open(nil, encoded[keystoreNonceLength:], &nonce, key)
+	if !ok {
+		return nil, ErrKeystoreInvalidPassphrase
+	}
+
+	return decrypted, nil
+}
+
+func keystoreEncrypt(plain []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, keystoreSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	var nonce [keystoreNonceLength]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+
+	key, err := keystoreKey(passphrase, salt, keystoreScryptN, keystoreScryptR, keystoreScryptP)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := make([]byte, keystoreSaltLength+keystoreScryptParamsLength)
+	copy(encoded, salt)
+	binary.LittleEndian.PutUint32(encoded[keystoreSaltLength:], keystoreScryptN)
+	binary.LittleEndian.PutUint32(encoded[keystoreSaltLength+4:], keystoreScryptP)
+	binary.LittleEndian.PutUint32(encoded[keystoreSaltLength+8:], keystoreScryptR)
+
+	encoded = append(encoded, nonce[:]...)
+
+	return secretbox.Seal(encoded, plain, &nonce, key), nil
+}
+
+// keystoreKey derives the secretbox key from the passphrase, which are the first 32 bytes of the 64 byte scrypt key.
+func keystoreKey(passphrase string, salt []byte, n, r, p int) (*[32]byte, error) {
+	derived, err := scrypt.Key([]byte(passphrase), salt, n, r, p, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	var key [32]byte
+	copy(key[:], derived)
+
+	return &key, nil
+}
+
+func keystoreDecodePkcs8(decrypted []byte) ([]byte, []byte, error) {
+	divOffset := len(keystorePkcs8Header) + keystoreSecretLength
+	pubOffset := divOffset + len(keystorePkcs8Divider)
+
+	if len(decrypted) != pubOffset+keystorePublicKeyLength {
+		return nil, nil, fmt.Errorf("%w: pkcs8 length %d", ErrKeystoreInvalidData, len(decrypted))
+	}
+
+	if !bytes.Equal(decrypted[:len(keystorePkcs8Header)], keystorePkcs8Header) {
+		return nil, nil, fmt.Errorf("%w: invalid pkcs8 header", ErrKeystoreInvalidData)
+	}
+
+	if !bytes.Equal(decrypted[divOffset:pubOffset], keystorePkcs8Divider) {
+		return nil, nil, fmt.Errorf("%w: invalid pkcs8 divider", ErrKeystoreInvalidData)
+	}
+
+	return decrypted[len(keystorePkcs8Header):divOffset], decrypted[pubOffset:], nil
+}
+
+// divideScalarByCofactor divides the little endian scalar by the cofactor 8, like schnorrkel does.
+func divideScalarByCofactor(s []byte) []byte {
+	res := make([]byte, len(s))
+
+	var low byte
+	for i := len(s) - 1; i >= 0; i-- {
+		r := s[i] & 0b00000111
+		res[i] = s[i]>>3 + low
+		low = r << 5
+	}
+
+	return res
+}
+
+// multiplyScalarByCofactor multiplies the little endian scalar by the cofactor 8, like schnorrkel does.
+func multiplyScalarByCofactor(s []byte) []byte {
+	res := make([]byte, len(s))
+
+	var high byte
+	for i := 0; i < len(s); i++ {
+		r := s[i] & 0b11100000
+		res[i] = s[i]<<3 + high
+		high = r >> 5
+	}
+
+	return res
+}
@@ -0,0 +1,140 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystore_ExportAndImport(t *testing.T) {
+	srSeed, err := KeyringPairFromSecret(testSecretSeed, 0)
+	require.NoError(t, err)
+
+	srPhrase, err := KeyringPairFromSecret(testSecretPhrase, 2)
+	require.NoError(t, err)
+
+	for _, pair := range []KeyringPair{
+		TestKeyringPairAlice,
+		TestKeyringPairAliceEd25519,
+		srSeed,
+		srPhrase,
+	} {
+		exported, err := ExportToJSON(pair, "passphrase")
+		assert.NoError(t, err)
+
+		imported, err := KeyringPairFromJSON(exported, "passphrase")
+		assert.NoError(t, err)
+
+		assert.Equal(t, pair.PublicKey, imported.PublicKey)
+		assert.Equal(t, pair.Address, imported.Address)
+		assert.Equal(t, pair.Scheme, imported.Scheme)
+
+		sig, err := imported.Sign([]byte("hello!"))
+		assert.NoError(t, err)
+
+		ok, err := pair.Verify([]byte("hello!"), sig)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		// Imported key pairs can be exported again.
+		reexported, err := ExportToJSON(imported, "other")
+		assert.NoError(t, err)
+
+		reimported, err := KeyringPairFromJSON(reexported, "other")
+		assert.NoError(t, err)
+		assert.Equal(t, imported, reimported)
+	}
+}
+
+func TestExportToJSON_Format(t *testing.T) {
+	exported, err := ExportToJSON(TestKeyringPairAlice, "passphrase")
+	require.NoError(t, err)
+
+	var keystore struct {
+		Address  string `json:"address"`
+		Encoded  string `json:"encoded"`
+		Encoding struct {
+			Content []string `json:"content"`
+			Type    []string `json:"type"`
+			Version string   `json:"version"`
+		} `json:"encoding"`
+		Meta map[string]interface{} `json:"meta"`
+	}
+
+	err = json.Unmarshal(exported, &keystore)
+	require.NoError(t, err)
+
+	assert.Equal(t, TestKeyringPairAlice.Address, keystore.Address)
+	assert.Equal(t, []string{"pkcs8", "sr25519"}, keystore.Encoding.Content)
+	assert.Equal(t, []string{"scrypt", "xsalsa20-poly1305"}, keystore.Encoding.Type)
+	assert.Equal(t, "3", keystore.Encoding.Version)
+	assert.Contains(t, keystore.Meta, "whenCreated")
+}
+
+func TestKeyringPairFromJSON_Errors(t *testing.T) {
+	exported, err := ExportToJSON(TestKeyringPairAlice, "passphrase")
+	require.NoError(t, err)
+
+	_, err = KeyringPairFromJSON(exported, "wrong")
+	assert.ErrorIs(t, err, ErrKeystoreInvalidPassphrase)
+
+	_, err = KeyringPairFromJSON([]byte("{"), "passphrase")
+	assert.ErrorIs(t, err, ErrKeystoreInvalidData)
+
+	var keystore map[string]interface{}
+	require.NoError(t, json.Unmarshal(exported, &keystore))
+
+	for _, encoding := range []map[string]interface{}{
+		{"content": []string{"pkcs8", "sr25519"}, "type": []string{"scrypt", "xsalsa20-poly1305"}, "version": "2"},
+		{"content": []string{"pkcs8", "sr25519"}, "type": []string{"none"}, "version": "3"},
+		{"content": []string{"pkcs8", "ecdsa"}, "type": []string{"scrypt", "xsalsa20-poly1305"}, "version": "3"},
+	} {
+		keystore["encoding"] = encoding
+
+		b, err := json.Marshal(keystore)
+		require.NoError(t, err)
+
+		_, err = KeyringPairFromJSON(b, "passphrase")
+		assert.ErrorIs(t, err, ErrKeystoreUnsupportedEncoding)
+	}
+
+	keystore["encoding"] = map[string]interface{}{
+		"content": []string{"pkcs8", "sr25519"},
+		"type":    []string{"scrypt", "xsalsa20-poly1305"},
+		"version": "3",
+	}
+	keystore["address"] = TestKeyringPairAliceEd25519.Address
+
+	b, err := json.Marshal(keystore)
+	require.NoError(t, err)
+
+	_, err = KeyringPairFromJSON(b, "passphrase")
+	assert.ErrorIs(t, err, ErrKeystoreInvalidData)
+}
+
+func TestExportToJSON_SoftDerivation(t *testing.T) {
+	pair, err := KeyringPairFromSecret("//Alice/soft", 42)
+	require.NoError(t, err)
+
+	_, err = ExportToJSON(pair, "passphrase")
+	assert.ErrorIs(t, err, ErrKeystoreUnsupportedKey)
+}
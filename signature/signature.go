@@ -23,10 +23,53 @@ import (
 	"strconv"
 
 	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
 	"github.com/vedhavyas/go-subkey/v2/sr25519"
 	"golang.org/x/crypto/blake2b"
 )
 
+// Scheme is the crypto scheme of a KeyringPair.
+type Scheme uint8
+
+const (
+	// Sr25519 is the default scheme of Substrate accounts.
+	Sr25519 Scheme = iota
+	// Ed25519 only supports hard derivation paths.
+	Ed25519
+	// Ecdsa is the secp256k1 scheme, which only supports hard derivation paths. Its key pairs sign the hash of the
+	// payload of their Hasher, see SignEcdsa.
+	Ecdsa
+)
+
+var ErrUnknownScheme = errors.New("unknown crypto scheme")
+
+func (s Scheme) String() string {
+	switch s {
+	case Sr25519:
+		return "sr25519"
+	case Ed25519:
+		return "ed25519"
+	case Ecdsa:
+		return "ecdsa"
+	default:
+		return fmt.Sprintf("Scheme(%d)", uint8(s))
+	}
+}
+
+func (s Scheme) subkeyScheme() (subkey.Scheme, error) {
+	switch s {
+	case Sr25519:
+		return sr25519.Scheme{}, nil
+	case Ed25519:
+		return ed25519.Scheme{}, nil
+	case Ecdsa:
+		return ecdsa.Scheme{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownScheme, uint8(s))
+	}
+}
+
 type KeyringPair struct {
 	// URI is the derivation path for the private key in subkey
 	URI string
@@ -34,13 +77,33 @@ type KeyringPair struct {
 	Address string
 	// PublicKey
 	PublicKey []byte
+	// Scheme is the crypto scheme of the key pair, the zero value is Sr25519
+	Scheme Scheme
+	// Hasher is the hash of the payload that is signed by Ecdsa key pairs, the zero value is EcdsaHasherBlake2_256
+	Hasher EcdsaHasher
 }
 
-// KeyringPairFromSecret creates KeyPair based on seed/phrase and network
+// KeyringPairFromSecret creates an sr25519 KeyPair based on seed/phrase and network
 // Leave network empty for default behavior
 func KeyringPairFromSecret(seedOrPhrase string, network uint16) (KeyringPair, error) {
-	scheme := sr25519.Scheme{}
-	kyr, err := subkey.DeriveKeyPair(scheme, seedOrPhrase)
+	return KeyringPairFromSecretWithScheme(seedOrPhrase, network, Sr25519)
+}
+
+// KeyringPairFromSecretWithScheme creates a KeyPair of the given crypto scheme based on seed/phrase and network. The
+// secret is a secret URI as accepted by subkey, see ParseSecretURI. Soft junctions are only supported for sr25519.
+func KeyringPairFromSecretWithScheme(seedOrPhrase string, network uint16, scheme Scheme) (KeyringPair, error) {
+	subkeyScheme, err := scheme.subkeyScheme()
+	if err != nil {
+		return KeyringPair{}, err
+	}
+
+	if scheme != Sr25519 {
+		if err := checkHardDerivation(seedOrPhrase); err != nil {
+			return KeyringPair{}, err
+		}
+	}
+
+	kyr, err := subkey.DeriveKeyPair(subkeyScheme, seedOrPhrase)
 	if err != nil {
 		return KeyringPair{}, err
 	}
@@ -53,26 +116,87 @@ func KeyringPairFromSecret(seedOrPhrase string, network uint16) (KeyringPair, er
 		URI:       seedOrPhrase,
 		Address:   ss58Address,
 		PublicKey: pk,
+		Scheme:    scheme,
 	}, nil
 }
 
+// KeyringPairFromSeed creates a KeyPair of the given crypto scheme based on the 32 byte seed and network
+func KeyringPairFromSeed(seed []byte, network uint16, scheme Scheme) (KeyringPair, error) {
+	if len(seed) != 32 {
+		return KeyringPair{}, fmt.Errorf("invalid seed length %d, expected 32", len(seed))
+	}
+
+	return KeyringPairFromSecretWithScheme(subkey.EncodeHex(seed), network, scheme)
+}
+
 var TestKeyringPairAlice = KeyringPair{
 	URI:       "//Alice",
 	PublicKey: []byte{0xd4, 0x35, 0x93, 0xc7, 0x15, 0xfd, 0xd3, 0x1c, 0x61, 0x14, 0x1a, 0xbd, 0x4, 0xa9, 0x9f, 0xd6, 0x82, 0x2c, 0x85, 0x58, 0x85, 0x4c, 0xcd, 0xe3, 0x9a, 0x56, 0x84, 0xe7, 0xa5, 0x6d, 0xa2, 0x7d}, //nolint:lll
 	Address:   "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
 }
 
-// Sign signs data with the private key under the given derivation path, returning the signature. Requires the subkey
-// command to be in path
+var TestKeyringPairAliceEd25519 = KeyringPair{
+	URI:       "//Alice",
+	PublicKey: []byte{0x88, 0xdc, 0x34, 0x17, 0xd5, 0x05, 0x8e, 0xc4, 0xb4, 0x50, 0x3e, 0x0c, 0x12, 0xea, 0x1a, 0x0a, 0x89, 0xbe, 0x20, 0x0f, 0xe9, 0x89, 0x22, 0x42, 0x3d, 0x43, 0x34, 0x01, 0x4f, 0xa6, 0xb0, 0xee}, //nolint:lll
+	Address:   "5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu",
+	Scheme:    Ed25519,
+}
+
+// AccountID returns the AccountId32 of the key pair, which is its public key, or the blake2-256 hash of it for Ecdsa
+// key pairs, see EcdsaAccountID.
+func (kp KeyringPair) AccountID() []byte {
+	if kp.Scheme == Ecdsa {
+		return EcdsaAccountID(kp.PublicKey)
+	}
+
+	return kp.PublicKey
+}
+
+// Sign signs data with the private key of the key pair, see SignWithScheme. Ecdsa key pairs sign with their Hasher,
+// see SignEcdsa.
+func (kp KeyringPair) Sign(data []byte) ([]byte, error) {
+	if kp.Scheme == Ecdsa {
+		return SignEcdsa(data, kp.URI, kp.Hasher)
+	}
+
+	return SignWithScheme(data, kp.URI, kp.Scheme)
+}
+
+// Verify verifies data using the provided signature and the key of the key pair, see VerifyWithScheme. Signatures of
+// Ecdsa key pairs are verified with their Hasher, see VerifyEcdsa.
+func (kp KeyringPair) Verify(data []byte, sig []byte) (bool, error) {
+	if kp.Scheme == Ecdsa {
+		return VerifyEcdsa(data, sig, kp.PublicKey, kp.Hasher)
+	}
+
+	return VerifyWithScheme(data, sig, kp.URI, kp.Scheme)
+}
+
+// Sign signs data with the sr25519 private key under the given derivation path, returning the signature. Requires
+// the subkey command to be in path
 func Sign(data []byte, privateKeyURI string) ([]byte, error) {
+	return SignWithScheme(data, privateKeyURI, Sr25519)
+}
+
+// SignWithScheme signs data with the private key of the given crypto scheme under the given derivation path,
+// returning the signature. Ecdsa signatures are of the blake2-256 hash, see SignEcdsa for other hashers.
+func SignWithScheme(data []byte, privateKeyURI string, scheme Scheme) ([]byte, error) {
+	if scheme == Ecdsa {
+		return SignEcdsa(data, privateKeyURI, EcdsaHasherBlake2_256)
+	}
+
+	subkeyScheme, err := scheme.subkeyScheme()
+	if err != nil {
+		return nil, err
+	}
+
 	// if data is longer than 256 bytes, hash it first
 	if len(data) > 256 {
 		h := blake2b.Sum256(data)
 		data = h[:]
 	}
 
-	scheme := sr25519.Scheme{}
-	kyr, err := subkey.DeriveKeyPair(scheme, privateKeyURI)
+	kyr, err := subkey.DeriveKeyPair(subkeyScheme, privateKeyURI)
 	if err != nil {
 		return nil, err
 	}
@@ -85,17 +209,47 @@ func Sign(data []byte, privateKeyURI string) ([]byte, error) {
 	return signature, nil
 }
 
-// Verify verifies data using the provided signature and the key under the derivation path. Requires the subkey
-// command to be in path
+// Verify verifies data using the provided signature and the key under the derivation path. Both sr25519 and ed25519
+// signatures are accepted. Requires the subkey command to be in path
 func Verify(data []byte, sig []byte, privateKeyURI string) (bool, error) {
+	ok, err := VerifyWithScheme(data, sig, privateKeyURI, Sr25519)
+	if err != nil || ok {
+		return ok, err
+	}
+
+	// The derivation fails for soft derivation paths, which are not supported by ed25519 keys.
+	ok, err = VerifyWithScheme(data, sig, privateKeyURI, Ed25519)
+	if err != nil {
+		return false, nil //nolint:nilerr
+	}
+
+	return ok, nil
+}
+
+// VerifyWithScheme verifies data using the provided signature and the key of the given crypto scheme under the
+// derivation path. Ecdsa signatures are verified like by VerifyWithPublicKey.
+func VerifyWithScheme(data []byte, sig []byte, privateKeyURI string, scheme Scheme) (bool, error) {
+	subkeyScheme, err := scheme.subkeyScheme()
+	if err != nil {
+		return false, err
+	}
+
+	if scheme == Ecdsa {
+		kyr, err := subkey.DeriveKeyPair(subkeyScheme, privateKeyURI)
+		if err != nil {
+			return false, err
+		}
+
+		return VerifyEcdsa(data, sig, kyr.Public(), EcdsaHasherBlake2_256)
+	}
+
 	// if data is longer than 256 bytes, hash it first
 	if len(data) > 256 {
 		h := blake2b.Sum256(data)
 		data = h[:]
 	}
 
-	scheme := sr25519.Scheme{}
-	kyr, err := subkey.DeriveKeyPair(scheme, privateKeyURI)
+	kyr, err := subkey.DeriveKeyPair(subkeyScheme, privateKeyURI)
 	if err != nil {
 		return false, err
 	}
@@ -109,6 +263,37 @@ func Verify(data []byte, sig []byte, privateKeyURI string) (bool, error) {
 	return v, nil
 }
 
+// VerifyWithPublicKey verifies data using the provided signature and the public key of the given crypto scheme, e.g.
+// for signatures that were created by an offline signer. Ecdsa signatures are verified against the blake2-256 hash,
+// see VerifyEcdsa for other hashers.
+func VerifyWithPublicKey(data []byte, sig []byte, publicKey []byte, scheme Scheme) (bool, error) {
+	if scheme == Ecdsa {
+		return VerifyEcdsa(data, sig, publicKey, EcdsaHasherBlake2_256)
+	}
+
+	subkeyScheme, err := scheme.subkeyScheme()
+	if err != nil {
+		return false, err
+	}
+
+	// if data is longer than 256 bytes, hash it first
+	if len(data) > 256 {
+		h := blake2b.Sum256(data)
+		data = h[:]
+	}
+
+	pub, err := subkeyScheme.FromPublicKey(publicKey)
+	if err != nil {
+		return false, err
+	}
+
+	if len(sig) != 64 {
+		return false, errors.New("wrong signature length")
+	}
+
+	return pub.Verify(data, sig), nil
+}
+
 // LoadKeyringPairFromEnv looks up whether the env variable TEST_PRIV_KEY is set and is not empty and tries to use its
 // content as a private phrase, seed or URI to derive a key ring pair. Panics if the private phrase, seed or URI is
 // not valid or the keyring pair cannot be derived
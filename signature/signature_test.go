@@ -141,3 +141,97 @@ func TestSignAndVerifyLong(t *testing.T) {
 
 	assert.True(t, ok)
 }
+
+func TestKeyringPairFromSecretWithScheme_Ed25519(t *testing.T) {
+	p, err := KeyringPairFromSecretWithScheme("//Alice", 42, Ed25519)
+	assert.NoError(t, err)
+	assert.Equal(t, TestKeyringPairAliceEd25519, p)
+
+	_, err = KeyringPairFromSecretWithScheme("//Alice/soft", 42, Ed25519)
+	assert.Error(t, err)
+
+	_, err = KeyringPairFromSecretWithScheme("//Alice", 42, Scheme(9))
+	assert.ErrorIs(t, err, ErrUnknownScheme)
+}
+
+func TestKeyringPairFromSeed(t *testing.T) {
+	seed := codec.MustHexDecodeString(testSecretSeed)
+
+	p, err := KeyringPairFromSeed(seed, 42, Ed25519)
+	assert.NoError(t, err)
+
+	fromHex, err := KeyringPairFromSecretWithScheme(testSecretSeed, 42, Ed25519)
+	assert.NoError(t, err)
+	assert.Equal(t, fromHex.PublicKey, p.PublicKey)
+	assert.Equal(t, fromHex.Address, p.Address)
+	assert.Equal(t, Ed25519, p.Scheme)
+
+	p, err = KeyringPairFromSeed(seed, 42, Sr25519)
+	assert.NoError(t, err)
+	assert.Equal(t, codec.MustHexDecodeString(testPubKey), p.PublicKey)
+
+	_, err = KeyringPairFromSeed(seed[:31], 42, Ed25519)
+	assert.Error(t, err)
+}
+
+func TestKeyringPair_SignAndVerify_Ed25519(t *testing.T) {
+	data := []byte("hello!")
+
+	sig, err := TestKeyringPairAliceEd25519.Sign(data)
+	assert.NoError(t, err)
+
+	// ed25519 signatures are deterministic.
+	sig2, err := SignWithScheme(data, TestKeyringPairAliceEd25519.URI, Ed25519)
+	assert.NoError(t, err)
+	assert.Equal(t, sig, sig2)
+
+	ok, err := TestKeyringPairAliceEd25519.Verify(data, sig)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = VerifyWithScheme(data, sig, TestKeyringPairAliceEd25519.URI, Sr25519)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Verify accepts both sr25519 and ed25519 signatures.
+	ok, err = Verify(data, sig, TestKeyringPairAliceEd25519.URI)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	srSig, err := TestKeyringPairAlice.Sign(data)
+	assert.NoError(t, err)
+
+	ok, err = Verify(data, srSig, TestKeyringPairAlice.URI)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = Verify(append([]byte{0}, data...), sig, TestKeyringPairAliceEd25519.URI)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestVerifyWithPublicKey(t *testing.T) {
+	data := make([]byte, 300)
+
+	for _, kp := range []KeyringPair{TestKeyringPairAlice, TestKeyringPairAliceEd25519} {
+		sig, err := kp.Sign(data)
+		assert.NoError(t, err)
+
+		ok, err := VerifyWithPublicKey(data, sig, kp.PublicKey, kp.Scheme)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = VerifyWithPublicKey([]byte("hello!"), sig, kp.PublicKey, kp.Scheme)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	}
+
+	_, err := VerifyWithPublicKey(data, make([]byte, 63), TestKeyringPairAlice.PublicKey, Sr25519)
+	assert.Error(t, err)
+
+	_, err = VerifyWithPublicKey(data, make([]byte, 64), []byte{1}, Sr25519)
+	assert.Error(t, err)
+
+	_, err = VerifyWithPublicKey(data, make([]byte, 64), TestKeyringPairAlice.PublicKey, Scheme(9))
+	assert.ErrorIs(t, err, ErrUnknownScheme)
+}
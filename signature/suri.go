@@ -0,0 +1,165 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// DevPhrase is the mnemonic of the well-known development accounts such as "//Alice", it is used if a secret URI
+// does not contain a phrase or seed.
+const DevPhrase = subkey.DevPhrase
+
+var (
+	ErrInvalidMnemonicWordCount = errors.New("invalid mnemonic word count, expected 12, 15, 18, 21 or 24")
+	ErrInvalidSecretURI         = errors.New("invalid secret URI")
+	ErrSoftJunctionNotSupported = errors.New("soft derivation junctions are not supported by the crypto scheme")
+)
+
+// secretURIRegex matches the secret URI format of subkey, <phrase or hex seed><path>///<password>.
+var secretURIRegex = regexp.MustCompile(`^(?P<phrase>[\d\w ]+)?(?P<path>(//?[^/]+)*)(///(?P<password>.*))?$`)
+
+// DeriveJunction is a single junction of a derivation path, "/name" for a soft and "//name" for a hard junction.
+type DeriveJunction struct {
+	Name string
+	Hard bool
+}
+
+func (j DeriveJunction) String() string {
+	if j.Hard {
+		return "//" + j.Name
+	}
+
+	return "/" + j.Name
+}
+
+// SecretURI is a parsed secret URI as accepted by subkey, e.g. "<mnemonic>//polkadot//0/1///password".
+type SecretURI struct {
+	// Phrase is the mnemonic or the hex encoded seed, empty for the dev phrase
+	Phrase string
+	// Junctions is the derivation path applied to the key pair derived from the phrase
+	Junctions []DeriveJunction
+	// Password is the BIP39 password used when deriving the seed from the mnemonic
+	Password string
+}
+
+// ParseSecretURI splits a secret URI into the phrase or seed, the derivation junctions and the password.
+func ParseSecretURI(suri string) (SecretURI, error) {
+	match := secretURIRegex.FindStringSubmatch(suri)
+	if match == nil {
+		return SecretURI{}, fmt.Errorf("%w: %q", ErrInvalidSecretURI, suri)
+	}
+
+	parsed := SecretURI{
+		Phrase:   match[secretURIRegex.SubexpIndex("phrase")],
+		Password: match[secretURIRegex.SubexpIndex("password")],
+	}
+
+	path := match[secretURIRegex.SubexpIndex("path")]
+
+	for path != "" {
+		var junction DeriveJunction
+
+		path = strings.TrimPrefix(path, "/")
+
+		if strings.HasPrefix(path, "/") {
+			junction.Hard = true
+			path = path[1:]
+		}
+
+		end := strings.Index(path, "/")
+		if end < 0 {
+			end = len(path)
+		}
+
+		junction.Name, path = path[:end], path[end:]
+		parsed.Junctions = append(parsed.Junctions, junction)
+	}
+
+	return parsed, nil
+}
+
+// HasSoftJunction returns true if the derivation path contains a soft junction.
+func (s SecretURI) HasSoftJunction() bool {
+	for _, junction := range s.Junctions {
+		if !junction.Hard {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s SecretURI) String() string {
+	var b strings.Builder
+
+	b.WriteString(s.Phrase)
+
+	for _, junction := range s.Junctions {
+		b.WriteString(junction.String())
+	}
+
+	if s.Password != "" {
+		b.WriteString("///")
+		b.WriteString(s.Password)
+	}
+
+	return b.String()
+}
+
+// checkHardDerivation returns an error if the secret URI is invalid or contains soft junctions, which can only be
+// derived for sr25519 keys.
+func checkHardDerivation(suri string) error {
+	parsed, err := ParseSecretURI(suri)
+	if err != nil {
+		return err
+	}
+
+	if parsed.HasSoftJunction() {
+		return ErrSoftJunctionNotSupported
+	}
+
+	return nil
+}
+
+// GenerateMnemonic generates a random BIP39 mnemonic with the given number of words, which must be one of 12, 15,
+// 18, 21 or 24.
+func GenerateMnemonic(words int) (string, error) {
+	switch words {
+	case 12, 15, 18, 21, 24:
+	default:
+		return "", fmt.Errorf("%w: %d", ErrInvalidMnemonicWordCount, words)
+	}
+
+	entropy, err := bip39.NewEntropy(words * 32 / 3)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// IsMnemonicValid returns true if the mnemonic consists of valid BIP39 words with a valid checksum.
+func IsMnemonicValid(mnemonic string) bool {
+	return bip39.IsMnemonicValid(mnemonic)
+}
@@ -0,0 +1,160 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateMnemonic(t *testing.T) {
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		mnemonic, err := GenerateMnemonic(words)
+		require.NoError(t, err)
+
+		assert.Len(t, strings.Fields(mnemonic), words)
+		assert.True(t, IsMnemonicValid(mnemonic))
+
+		_, err = KeyringPairFromSecret(mnemonic+"//0", 42)
+		assert.NoError(t, err)
+	}
+
+	for _, words := range []int{0, 11, 13, 25} {
+		_, err := GenerateMnemonic(words)
+		assert.ErrorIs(t, err, ErrInvalidMnemonicWordCount)
+	}
+
+	assert.False(t, IsMnemonicValid("bottom drive obey lake curtain smoke basket hold race lonely fit wlak"))
+}
+
+func TestParseSecretURI(t *testing.T) {
+	tests := []struct {
+		suri     string
+		expected SecretURI
+	}{
+		{"//Alice", SecretURI{Junctions: []DeriveJunction{{Name: "Alice", Hard: true}}}},
+		{"/Alice", SecretURI{Junctions: []DeriveJunction{{Name: "Alice"}}}},
+		{
+			testSecretPhrase + "//polkadot//0/1///secret",
+			SecretURI{
+				Phrase: testSecretPhrase,
+				Junctions: []DeriveJunction{
+					{Name: "polkadot", Hard: true},
+					{Name: "0", Hard: true},
+					{Name: "1"},
+				},
+				Password: "secret",
+			},
+		},
+		{testSecretSeed, SecretURI{Phrase: testSecretSeed}},
+		{"///password", SecretURI{Password: "password"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.suri, func(t *testing.T) {
+			parsed, err := ParseSecretURI(test.suri)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, parsed)
+			assert.Equal(t, test.suri, parsed.String())
+		})
+	}
+
+	_, err := ParseSecretURI("invalid-phrase//Alice")
+	assert.ErrorIs(t, err, ErrInvalidSecretURI)
+}
+
+// The expected public keys are the ones of subkey and the sp-core tests.
+func TestKeyringPairFromSecretWithScheme_Vectors(t *testing.T) {
+	tests := []struct {
+		suri      string
+		scheme    Scheme
+		publicKey string
+	}{
+		{"//Alice", Sr25519, "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"},
+		{"//Bob", Sr25519, "0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48"},
+		{"//Charlie", Sr25519, "0x90b5ab205c6974c9ea841be688864633dc9ca8a357843eeacf2314649965fe22"},
+		{"//Dave", Sr25519, "0x306721211d5404bd9da88e0204360a1a9ab8b87c66c1bc2fcdd37f3c2222cc20"},
+		{"//Eve", Sr25519, "0xe659a7a1628cdd93febc04a4e0646ea20e9f5f0ce097d9a05290d4a9e054df4e"},
+		{"//Ferdie", Sr25519, "0x1cbd2d43530a44705ad088af313e18f80b53ef16b36177cd4b77b846f2a5f07c"},
+		{"//Alice//stash", Sr25519, "0xbe5ddb1579b72e84524fc29e78609e3caf42e85aa118ebfe0b0ad404b5bdd25f"},
+		{"//Bob//stash", Sr25519, "0xfe65717dad0447d715f660a0a58411de509b42e6efb8375f562f58a554d5860e"},
+		{DevPhrase + "/Alice", Sr25519, "0xd6c71059dbbe9ad2b0ed3f289738b800836eb425544ce694825285b958ca755e"},
+		{
+			"0xfac7959dbfe72f052e5a0c3c8d6530f202b02fd8f9f5ca3580ec8deb7797479e", Sr25519,
+			"0x46ebddef8cd9bb167dc30878d7113b7e168e6f0646beffd77d69d39bad76b47a",
+		},
+		{"//Alice", Ed25519, "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee"},
+		{"//Bob", Ed25519, "0xd17c2d7823ebf260fd138f2d7e27d114c0145d968b5ff5006125f2414fadae69"},
+		{
+			// RFC 8032 test vector 1
+			"0x9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60", Ed25519,
+			"0xd75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scheme.String()+test.suri, func(t *testing.T) {
+			kp, err := KeyringPairFromSecretWithScheme(test.suri, 42, test.scheme)
+			require.NoError(t, err)
+			assert.Equal(t, codec.MustHexDecodeString(test.publicKey), kp.PublicKey)
+		})
+	}
+
+	kp, err := EcdsaKeyringPairFromSecret("//Bob", 42, EcdsaHasherBlake2_256)
+	require.NoError(t, err)
+	assert.Equal(t, codec.MustHexDecodeString("0x0390084fdbf27d2b79d26a4f13f0ccd982cb755a661969143c37cbc49ef5b91f27"), kp.PublicKey)
+}
+
+func TestKeyringPairFromSecretWithScheme_DerivationPaths(t *testing.T) {
+	derive := func(suri string) []byte {
+		kp, err := KeyringPairFromSecret(suri, 42)
+		require.NoError(t, err)
+		return kp.PublicKey
+	}
+
+	// The dev phrase is used if the secret URI does not contain a phrase.
+	assert.Equal(t, derive(DevPhrase+"//Alice"), derive("//Alice"))
+
+	multi := derive(testSecretPhrase + "//polkadot//0/1")
+	assert.Equal(t, codec.MustHexDecodeString("0x12dd9e9dab925b8f02cb70c8698a2d5ae9b76f7c7496ae076883a23b10d3431c"), multi)
+	assert.NotEqual(t, derive(testSecretPhrase+"//polkadot//0//1"), multi)
+	assert.NotEqual(t, derive(testSecretPhrase+"//polkadot/0/1"), multi)
+	assert.NotEqual(t, derive(testSecretPhrase+"//polkadot//1/0"), multi)
+
+	// The password changes the seed derived from the mnemonic.
+	assert.NotEqual(t, derive("//Alice"), derive("//Alice///password"))
+	assert.Equal(t, derive(DevPhrase+"//Alice///password"), derive("//Alice///password"))
+}
+
+func TestKeyringPairFromSecretWithScheme_SoftJunction(t *testing.T) {
+	_, err := KeyringPairFromSecretWithScheme("//polkadot//0/1", 42, Ed25519)
+	assert.ErrorIs(t, err, ErrSoftJunctionNotSupported)
+
+	_, err = EcdsaKeyringPairFromSecret("//polkadot//0/1", 42, EcdsaHasherBlake2_256)
+	assert.ErrorIs(t, err, ErrSoftJunctionNotSupported)
+
+	_, err = KeyringPairFromSecretWithScheme("//polkadot//0//1", 42, Ed25519)
+	assert.NoError(t, err)
+
+	_, err = EcdsaKeyringPairFromSecret("//polkadot//0//1", 42, EcdsaHasherBlake2_256)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,161 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const stakingPallet = "Staking"
+
+// UnlockChunk is a part of the ledger that is being unbonded and can be withdrawn in the given era.
+type UnlockChunk struct {
+	Value types.U128
+	Era   uint32
+}
+
+// Ledger is the staking ledger of a stash, as stored in Staking.Ledger.
+type Ledger struct {
+	// Stash is the account whose balance is bonded.
+	Stash types.AccountID
+	// Controller is the account that controls the stake, which is the stash itself on recent runtimes.
+	Controller types.AccountID
+	// Total is the bonded balance, including the unlocking chunks.
+	Total types.U128
+	// Active is the bonded balance that is at stake.
+	Active types.U128
+	// Unlocking are the chunks that are being unbonded.
+	Unlocking []UnlockChunk
+}
+
+// Nominations are the validators a stash nominates, as stored in Staking.Nominators.
+type Nominations struct {
+	// Targets are the nominated validators.
+	Targets []types.AccountID
+	// SubmittedIn is the era in which the nominations were submitted.
+	SubmittedIn uint32
+	// Suppressed is true if the nominations were suppressed due to a slash.
+	Suppressed bool
+}
+
+// stakingLedger is the prefix of pallet_staking::StakingLedger that is common to all runtime versions. The claimed
+// rewards that follow it were renamed and changed over time and are not decoded.
+type stakingLedger struct {
+	Stash     types.AccountID
+	Total     types.UCompact
+	Active    types.UCompact
+	Unlocking []struct {
+		Value types.UCompact
+		Era   types.UCompact
+	}
+}
+
+type nominations struct {
+	Targets     []types.AccountID
+	SubmittedIn types.U32
+	Suppressed  bool
+}
+
+// Client reads the state of the staking pallet into typed structs.
+type Client struct {
+	stateRPC state.State
+}
+
+// NewClient creates a Client that reads the storage of the staking pallet via the state RPC.
+func NewClient(stateRPC state.State) *Client {
+	return &Client{
+		stateRPC: stateRPC,
+	}
+}
+
+// GetLedger returns the ledger of the stash, or nil if the stash is not bonded.
+func (c *Client) GetLedger(ctx context.Context, meta *types.Metadata, stash types.AccountID) (*Ledger, error) {
+	var controller types.AccountID
+
+	ok, err := state.GetStorageEntryLatest(ctx, c.stateRPC, meta, stakingPallet, "Bonded", &controller, stash[:])
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var ledger stakingLedger
+
+	ok, err = state.GetStorageEntryLatest(ctx, c.stateRPC, meta, stakingPallet, "Ledger", &ledger, controller[:])
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	unlocking := make([]UnlockChunk, 0, len(ledger.Unlocking))
+
+	for _, chunk := range ledger.Unlocking {
+		unlocking = append(unlocking, UnlockChunk{
+			Value: u128(chunk.Value),
+			Era:   uint32(chunk.Era.Int64()),
+		})
+	}
+
+	return &Ledger{
+		Stash:      ledger.Stash,
+		Controller: controller,
+		Total:      u128(ledger.Total),
+		Active:     u128(ledger.Active),
+		Unlocking:  unlocking,
+	}, nil
+}
+
+// GetNominators returns the nominations of the stash, or nil if the stash does not nominate.
+func (c *Client) GetNominators(
+	ctx context.Context,
+	meta *types.Metadata,
+	stash types.AccountID,
+) (*Nominations, error) {
+	var n nominations
+
+	ok, err := state.GetStorageEntryLatest(ctx, c.stateRPC, meta, stakingPallet, "Nominators", &n, stash[:])
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &Nominations{
+		Targets:     n.Targets,
+		SubmittedIn: uint32(n.SubmittedIn),
+		Suppressed:  n.Suppressed,
+	}, nil
+}
+
+// hasStorageEntry returns true if the runtime has the storage entry of the staking pallet.
+func hasStorageEntry(meta *types.Metadata, entry string) bool {
+	_, err := meta.FindStorageEntryMetadata(stakingPallet, entry)
+
+	return err == nil
+}
+
+// encodeU32 returns the SCALE encoded u32, like the era indices and page numbers in the keys of the era storage
+// entries.
+func encodeU32(v uint32) []byte {
+	b, _ := codec.Encode(types.NewU32(v))
+
+	return b
+}
+
+func u128(c types.UCompact) types.U128 {
+	return types.NewU128(big.Int(c))
+}
@@ -0,0 +1,126 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staking
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testStash      = types.AccountID{1}
+	testController = types.AccountID{2}
+	testValidator  = types.AccountID{3}
+	testNominator  = types.AccountID{4}
+)
+
+func compact(i int64) types.UCompact {
+	return types.NewUCompactFromUInt(uint64(i))
+}
+
+func u128Of(i int64) types.U128 {
+	return types.NewU128(*big.NewInt(i))
+}
+
+func TestClient_GetLedger(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+
+	t.Run("bonded", func(t *testing.T) {
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorage(t, meta, stakingPallet, "Bonded", testController, testStash[:])
+		stateRPC.ExpectStorage(t, meta, stakingPallet, "Ledger", struct {
+			Stash     types.AccountID
+			Total     types.UCompact
+			Active    types.UCompact
+			Unlocking []struct {
+				Value types.UCompact
+				Era   types.UCompact
+			}
+			ClaimedRewards []types.U32
+		}{
+			Stash:  testStash,
+			Total:  compact(1500),
+			Active: compact(1000),
+			Unlocking: []struct {
+				Value types.UCompact
+				Era   types.UCompact
+			}{{Value: compact(500), Era: compact(42)}},
+			ClaimedRewards: []types.U32{38, 39, 40},
+		}, testController[:])
+
+		ledger, err := c.GetLedger(ctx, meta, testStash)
+		require.NoError(t, err)
+		assert.Equal(t, &Ledger{
+			Stash:      testStash,
+			Controller: testController,
+			Total:      u128Of(1500),
+			Active:     u128Of(1000),
+			Unlocking:  []UnlockChunk{{Value: u128Of(500), Era: 42}},
+		}, ledger)
+	})
+
+	t.Run("not bonded", func(t *testing.T) {
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.ExpectStorage(t, meta, stakingPallet, "Bonded", nil, testStash[:])
+
+		ledger, err := c.GetLedger(ctx, meta, testStash)
+		require.NoError(t, err)
+		assert.Nil(t, ledger)
+	})
+
+	t.Run("retrieval error", func(t *testing.T) {
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		stateRPC.On("GetStorageLatestContext", mock.Anything, mock.Anything, mock.Anything).
+			Return(false, errors.New("boom"))
+
+		_, err := c.GetLedger(ctx, meta, testStash)
+		assert.ErrorIs(t, err, ErrStorageRetrieval)
+	})
+}
+
+func TestClient_GetNominators(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorage(t, meta, stakingPallet, "Nominators", nominations{
+		Targets:     []types.AccountID{testValidator, testController},
+		SubmittedIn: 41,
+	}, testStash[:])
+
+	n, err := c.GetNominators(ctx, meta, testStash)
+	require.NoError(t, err)
+	assert.Equal(t, &Nominations{
+		Targets:     []types.AccountID{testValidator, testController},
+		SubmittedIn: 41,
+	}, n)
+
+	stateRPC.ExpectStorage(t, meta, stakingPallet, "Nominators", nil, testValidator[:])
+
+	n, err = c.GetNominators(ctx, meta, testValidator)
+	require.NoError(t, err)
+	assert.Nil(t, n)
+}
@@ -0,0 +1,34 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staking
+
+import (
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+)
+
+const (
+	ErrStorageKeyCreation      = state.ErrStorageKeyCreation
+	ErrStorageRetrieval        = state.ErrStorageRetrieval
+	ErrExposureStorageNotFound = libErr.Error("exposure storage not found")
+	ErrExposurePageNotFound    = libErr.Error("exposure page not found")
+	ErrValidatorNotExposed     = libErr.Error("validator not exposed")
+	ErrNominatorNotExposed     = libErr.Error("nominator not exposed")
+	ErrEraRewardNotFound       = libErr.Error("era reward not found")
+	ErrRewardPointsNotFound    = libErr.Error("reward points not found")
+	ErrValidatorPrefsNotFound  = libErr.Error("validator prefs not found")
+)
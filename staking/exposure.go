@@ -0,0 +1,192 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staking
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	erasStakersEntry         = "ErasStakers"
+	erasStakersOverviewEntry = "ErasStakersOverview"
+	erasStakersPagedEntry    = "ErasStakersPaged"
+)
+
+// IndividualExposure is the stake of a nominator behind a validator.
+type IndividualExposure struct {
+	Who   types.AccountID
+	Value types.U128
+}
+
+// Exposure is the stake behind a validator in an era. The nominators of all pages of paged exposures are merged.
+type Exposure struct {
+	// Total is the stake of the validator and its nominators.
+	Total types.U128
+	// Own is the stake of the validator.
+	Own types.U128
+	// Others are the stakes of the nominators.
+	Others []IndividualExposure
+	// PageCount is the number of pages the nominators are stored in, which is 1 for exposures of the legacy
+	// Staking.ErasStakers layout.
+	PageCount uint32
+}
+
+// Nominator returns the stake of the nominator, or false if the nominator is not exposed.
+func (e *Exposure) Nominator(who types.AccountID) (types.U128, bool) {
+	for _, other := range e.Others {
+		if other.Who == who {
+			return other.Value, true
+		}
+	}
+
+	return types.U128{}, false
+}
+
+type individualExposure struct {
+	Who   types.AccountID
+	Value types.UCompact
+}
+
+type exposure struct {
+	Total  types.UCompact
+	Own    types.UCompact
+	Others []individualExposure
+}
+
+type pagedExposureMetadata struct {
+	Total          types.UCompact
+	Own            types.UCompact
+	NominatorCount types.U32
+	PageCount      types.U32
+}
+
+type exposurePage struct {
+	PageTotal types.UCompact
+	Others    []individualExposure
+}
+
+// GetEraExposure returns the exposure of the validator in the era, or nil if the validator was not elected in the era.
+//
+// Runtimes with paged exposures store the exposure in Staking.ErasStakersOverview and Staking.ErasStakersPaged, while
+// older runtimes store it in Staking.ErasStakers. Both layouts exist while a runtime migrates, in which case exposures
+// of eras before the migration are only found in Staking.ErasStakers. The layouts are detected via the metadata.
+func (c *Client) GetEraExposure(
+	ctx context.Context,
+	meta *types.Metadata,
+	era uint32,
+	validator types.AccountID,
+) (*Exposure, error) {
+	paged := hasStorageEntry(meta, erasStakersOverviewEntry)
+	legacy := hasStorageEntry(meta, erasStakersEntry)
+
+	if !paged && !legacy {
+		return nil, ErrExposureStorageNotFound
+	}
+
+	if paged {
+		e, err := c.getPagedExposure(ctx, meta, era, validator)
+		if err != nil || e != nil || !legacy {
+			return e, err
+		}
+	}
+
+	var e exposure
+
+	ok, err := state.GetStorageEntryLatest(
+		ctx, c.stateRPC, meta, stakingPallet, erasStakersEntry, &e, encodeU32(era), validator[:],
+	)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &Exposure{
+		Total:     u128(e.Total),
+		Own:       u128(e.Own),
+		Others:    toIndividualExposures(nil, e.Others),
+		PageCount: 1,
+	}, nil
+}
+
+// getPagedExposure returns the exposure of the validator from the overview and all pages of the era, or nil if there
+// is no overview.
+func (c *Client) getPagedExposure(
+	ctx context.Context,
+	meta *types.Metadata,
+	era uint32,
+	validator types.AccountID,
+) (*Exposure, error) {
+	var overview pagedExposureMetadata
+
+	ok, err := state.GetStorageEntryLatest(
+		ctx, c.stateRPC, meta, stakingPallet, erasStakersOverviewEntry, &overview, encodeU32(era), validator[:],
+	)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	others := make([]IndividualExposure, 0, overview.NominatorCount)
+
+	for page := types.U32(0); page < overview.PageCount; page++ {
+		var p exposurePage
+
+		ok, err := state.GetStorageEntryLatest(
+			ctx,
+			c.stateRPC,
+			meta,
+			stakingPallet,
+			erasStakersPagedEntry,
+			&p,
+			encodeU32(era),
+			validator[:],
+			encodeU32(uint32(page)),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, ErrExposurePageNotFound.WithMsg("page %d of %d", page, overview.PageCount)
+		}
+
+		others = toIndividualExposures(others, p.Others)
+	}
+
+	return &Exposure{
+		Total:     u128(overview.Total),
+		Own:       u128(overview.Own),
+		Others:    others,
+		PageCount: uint32(overview.PageCount),
+	}, nil
+}
+
+func toIndividualExposures(dst []IndividualExposure, src []individualExposure) []IndividualExposure {
+	if dst == nil {
+		dst = make([]IndividualExposure, 0, len(src))
+	}
+
+	for _, other := range src {
+		dst = append(dst, IndividualExposure{
+			Who:   other.Who,
+			Value: u128(other.Value),
+		})
+	}
+
+	return dst
+}
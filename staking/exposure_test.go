@@ -0,0 +1,177 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staking
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withPagedExposures returns a copy of the metadata with the storage entries of paged exposures, like the metadata of
+// runtimes that migrate to them. The legacy Staking.ErasStakers entry is kept if requested.
+func withPagedExposures(t *testing.T, meta *types.Metadata, keepLegacy bool) *types.Metadata {
+	paged := *meta
+	paged.AsMetadataV14.Pallets = append([]types.PalletMetadataV14{}, meta.AsMetadataV14.Pallets...)
+
+	for i, pallet := range paged.AsMetadataV14.Pallets {
+		if pallet.Name != stakingPallet {
+			continue
+		}
+
+		var items []types.StorageEntryMetadataV14
+
+		for _, item := range pallet.Storage.Items {
+			if item.Name != erasStakersEntry {
+				items = append(items, item)
+				continue
+			}
+
+			if keepLegacy {
+				items = append(items, item)
+			}
+
+			overview := item
+			overview.Name = erasStakersOverviewEntry
+
+			page := item
+			page.Name = erasStakersPagedEntry
+			page.Type.AsMap.Hashers = append(
+				append([]types.StorageHasherV10{}, item.Type.AsMap.Hashers...),
+				types.StorageHasherV10{IsTwox64Concat: true},
+			)
+
+			items = append(items, overview, page)
+		}
+
+		pallet.Storage.Items = items
+		paged.AsMetadataV14.Pallets[i] = pallet
+
+		return &paged
+	}
+
+	t.Fatal("staking pallet not found")
+
+	return nil
+}
+
+func TestClient_GetEraExposure_Legacy(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+	era := encodeU32(40)
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorage(t, meta, stakingPallet, erasStakersEntry, exposure{
+		Total:  compact(3000),
+		Own:    compact(1000),
+		Others: []individualExposure{{Who: testNominator, Value: compact(2000)}},
+	}, era, testValidator[:])
+
+	e, err := c.GetEraExposure(ctx, meta, 40, testValidator)
+	require.NoError(t, err)
+	assert.Equal(t, &Exposure{
+		Total:     u128Of(3000),
+		Own:       u128Of(1000),
+		Others:    []IndividualExposure{{Who: testNominator, Value: u128Of(2000)}},
+		PageCount: 1,
+	}, e)
+
+	stateRPC.ExpectStorage(t, meta, stakingPallet, erasStakersEntry, nil, era, testNominator[:])
+
+	e, err = c.GetEraExposure(ctx, meta, 40, testNominator)
+	require.NoError(t, err)
+	assert.Nil(t, e)
+}
+
+func TestClient_GetEraExposure_Paged(t *testing.T) {
+	ctx := context.Background()
+	meta := withPagedExposures(t, statetest.PolkadotMetadata(t), true)
+	era := encodeU32(40)
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorage(t, meta, stakingPallet, erasStakersOverviewEntry, pagedExposureMetadata{
+		Total:          compact(6000),
+		Own:            compact(1000),
+		NominatorCount: 2,
+		PageCount:      2,
+	}, era, testValidator[:])
+	stateRPC.ExpectStorage(t, meta, stakingPallet, erasStakersPagedEntry, exposurePage{
+		PageTotal: compact(2000),
+		Others:    []individualExposure{{Who: testNominator, Value: compact(2000)}},
+	}, era, testValidator[:], encodeU32(0))
+	stateRPC.ExpectStorage(t, meta, stakingPallet, erasStakersPagedEntry, exposurePage{
+		PageTotal: compact(3000),
+		Others:    []individualExposure{{Who: testStash, Value: compact(3000)}},
+	}, era, testValidator[:], encodeU32(1))
+
+	e, err := c.GetEraExposure(ctx, meta, 40, testValidator)
+	require.NoError(t, err)
+	assert.Equal(t, &Exposure{
+		Total: u128Of(6000),
+		Own:   u128Of(1000),
+		Others: []IndividualExposure{
+			{Who: testNominator, Value: u128Of(2000)},
+			{Who: testStash, Value: u128Of(3000)},
+		},
+		PageCount: 2,
+	}, e)
+
+	// Eras before the migration are only found in the legacy layout.
+	stateRPC.ExpectStorage(t, meta, stakingPallet, erasStakersOverviewEntry, nil, encodeU32(30), testValidator[:])
+	stateRPC.ExpectStorage(t, meta, stakingPallet, erasStakersEntry, exposure{
+		Total: compact(1000),
+		Own:   compact(1000),
+	}, encodeU32(30), testValidator[:])
+
+	e, err = c.GetEraExposure(ctx, meta, 30, testValidator)
+	require.NoError(t, err)
+	assert.Equal(t, &Exposure{Total: u128Of(1000), Own: u128Of(1000), Others: []IndividualExposure{}, PageCount: 1}, e)
+}
+
+func TestClient_GetEraExposure_PagedOnly(t *testing.T) {
+	ctx := context.Background()
+	meta := withPagedExposures(t, statetest.PolkadotMetadata(t), false)
+	era := encodeU32(40)
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorage(t, meta, stakingPallet, erasStakersOverviewEntry, nil, era, testValidator[:])
+
+	e, err := c.GetEraExposure(ctx, meta, 40, testValidator)
+	require.NoError(t, err)
+	assert.Nil(t, e)
+
+	stateRPC.ExpectStorage(t, meta, stakingPallet, erasStakersOverviewEntry, pagedExposureMetadata{
+		Total:          compact(3000),
+		Own:            compact(1000),
+		NominatorCount: 1,
+		PageCount:      1,
+	}, era, testValidator[:])
+	stateRPC.ExpectStorage(t, meta, stakingPallet, erasStakersPagedEntry, nil, era, testValidator[:], encodeU32(0))
+
+	_, err = c.GetEraExposure(ctx, meta, 40, testValidator)
+	assert.ErrorIs(t, err, ErrExposurePageNotFound)
+
+	withoutStaking := *meta
+	withoutStaking.AsMetadataV14.Pallets = nil
+
+	_, err = c.GetEraExposure(ctx, &withoutStaking, 40, testValidator)
+	assert.ErrorIs(t, err, ErrExposureStorageNotFound)
+}
@@ -0,0 +1,145 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+type validatorPrefs struct {
+	Commission types.UCompact
+	Blocked    bool
+}
+
+type eraRewardPoints struct {
+	Total      types.U32
+	Individual []struct {
+		Validator types.AccountID
+		Points    types.U32
+	}
+}
+
+// points returns the reward points the validator earned in the era.
+func (p *eraRewardPoints) points(validator types.AccountID) types.U32 {
+	for _, individual := range p.Individual {
+		if individual.Validator == validator {
+			return individual.Points
+		}
+	}
+
+	return 0
+}
+
+// EstimatePayout returns the reward of the staker for backing the validator in the era, which is paid out once the
+// rewards of the validator are claimed. The staker is either the validator itself, whose reward includes the
+// commission, or one of its nominators.
+//
+// The reward is calculated like pallet_staking::Pallet::do_payout_stakers: the validator gets the share of the era
+// reward of its reward points, the commission is deducted from it, and the rest is split between the validator and
+// its nominators in proportion to their stake. Rewards are only known for eras that ended, ErrEraRewardNotFound is
+// returned otherwise.
+func (c *Client) EstimatePayout(
+	ctx context.Context,
+	meta *types.Metadata,
+	era uint32,
+	validator types.AccountID,
+	staker types.AccountID,
+) (types.U128, error) {
+	var eraReward types.U128
+
+	ok, err := state.GetStorageEntryLatest(
+		ctx, c.stateRPC, meta, stakingPallet, "ErasValidatorReward", &eraReward, encodeU32(era),
+	)
+	if err != nil {
+		return types.U128{}, err
+	}
+
+	if !ok {
+		return types.U128{}, ErrEraRewardNotFound.WithMsg("era %d", era)
+	}
+
+	var rewardPoints eraRewardPoints
+
+	ok, err = state.GetStorageEntryLatest(
+		ctx, c.stateRPC, meta, stakingPallet, "ErasRewardPoints", &rewardPoints, encodeU32(era),
+	)
+	if err != nil {
+		return types.U128{}, err
+	}
+
+	if !ok {
+		return types.U128{}, ErrRewardPointsNotFound.WithMsg("era %d", era)
+	}
+
+	exposure, err := c.GetEraExposure(ctx, meta, era, validator)
+	if err != nil {
+		return types.U128{}, err
+	}
+
+	if exposure == nil {
+		return types.U128{}, ErrValidatorNotExposed.WithMsg("%#x in era %d", validator[:], era)
+	}
+
+	stake := exposure.Own
+
+	if staker != validator {
+		var exposed bool
+
+		if stake, exposed = exposure.Nominator(staker); !exposed {
+			return types.U128{}, ErrNominatorNotExposed.WithMsg("%#x behind %#x in era %d", staker[:], validator[:], era)
+		}
+	}
+
+	var prefs validatorPrefs
+
+	ok, err = state.GetStorageEntryLatest(
+		ctx, c.stateRPC, meta, stakingPallet, "ErasValidatorPrefs", &prefs, encodeU32(era), validator[:],
+	)
+	if err != nil {
+		return types.U128{}, err
+	}
+
+	if !ok {
+		return types.U128{}, ErrValidatorPrefsNotFound.WithMsg("%#x in era %d", validator[:], era)
+	}
+
+	points := rewardPoints.points(validator)
+
+	// Validators without reward points are not paid at all.
+	if points == 0 {
+		return types.NewU128(*big.NewInt(0)), nil
+	}
+
+	validatorPart := types.NewPerbillFromRational(big.NewInt(int64(points)), big.NewInt(int64(rewardPoints.Total)))
+	validatorPayout := validatorPart.Mul(eraReward)
+
+	commission := types.NewPerbill(uint32(prefs.Commission.Int64()))
+	commissionPayout := commission.Mul(validatorPayout)
+	leftoverPayout := types.NewU128(*new(big.Int).Sub(validatorPayout.Int, commissionPayout.Int))
+
+	payout := types.NewPerbillFromRational(stake.Int, exposure.Total.Int).Mul(leftoverPayout)
+
+	if staker == validator {
+		payout = types.NewU128(*new(big.Int).Add(payout.Int, commissionPayout.Int))
+	}
+
+	return payout, nil
+}
@@ -0,0 +1,113 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staking
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func expectEraRewards(t *testing.T, stateRPC *statetest.State, meta *types.Metadata, validatorPoints types.U32) {
+	era := encodeU32(40)
+
+	stateRPC.ExpectStorage(t, meta, stakingPallet, "ErasValidatorReward", u128Of(1_000_000), era)
+	stateRPC.ExpectStorage(t, meta, stakingPallet, "ErasRewardPoints", eraRewardPoints{
+		Total: 100,
+		Individual: []struct {
+			Validator types.AccountID
+			Points    types.U32
+		}{
+			{Validator: testStash, Points: 100 - validatorPoints},
+			{Validator: testValidator, Points: validatorPoints},
+		},
+	}, era)
+	stateRPC.ExpectStorage(t, meta, stakingPallet, erasStakersEntry, exposure{
+		Total:  compact(3000),
+		Own:    compact(1000),
+		Others: []individualExposure{{Who: testNominator, Value: compact(2000)}},
+	}, era, testValidator[:])
+}
+
+func TestClient_EstimatePayout(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+	era := encodeU32(40)
+
+	// The validator earns 20% of the era reward, of which 10% are commission. The rest is split by a third for the
+	// validator and two thirds for the nominator.
+	tests := []struct {
+		name     string
+		staker   types.AccountID
+		expected types.U128
+	}{
+		{"validator", testValidator, u128Of(20_000 + 60_000)},
+		{"nominator", testNominator, u128Of(120_000)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, stateRPC := statetest.NewClient(t, NewClient)
+			expectEraRewards(t, stateRPC, meta, 20)
+			stateRPC.ExpectStorage(t, meta, stakingPallet, "ErasValidatorPrefs", validatorPrefs{
+				Commission: compact(100_000_000),
+			}, era, testValidator[:])
+
+			payout, err := c.EstimatePayout(ctx, meta, 40, testValidator, test.staker)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, payout)
+		})
+	}
+
+	t.Run("no reward points", func(t *testing.T) {
+		c, stateRPC := statetest.NewClient(t, NewClient)
+		expectEraRewards(t, stateRPC, meta, 0)
+		stateRPC.ExpectStorage(t, meta, stakingPallet, "ErasValidatorPrefs", validatorPrefs{}, era, testValidator[:])
+
+		payout, err := c.EstimatePayout(ctx, meta, 40, testValidator, testNominator)
+		require.NoError(t, err)
+		assert.Equal(t, u128Of(0), payout)
+	})
+}
+
+func TestClient_EstimatePayout_Errors(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+	era := encodeU32(40)
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorage(t, meta, stakingPallet, "ErasValidatorReward", nil, era)
+
+	_, err := c.EstimatePayout(ctx, meta, 40, testValidator, testNominator)
+	assert.ErrorIs(t, err, ErrEraRewardNotFound)
+
+	expectEraRewards(t, stateRPC, meta, 20)
+
+	_, err = c.EstimatePayout(ctx, meta, 40, testValidator, testStash)
+	assert.ErrorIs(t, err, ErrNominatorNotExposed)
+
+	stateRPC.ExpectStorage(t, meta, stakingPallet, "ErasValidatorReward", u128Of(1_000_000), era)
+	stateRPC.ExpectStorage(t, meta, stakingPallet, "ErasRewardPoints", eraRewardPoints{Total: 100}, era)
+	stateRPC.ExpectStorage(t, meta, stakingPallet, erasStakersEntry, nil, era, testNominator[:])
+
+	_, err = c.EstimatePayout(ctx, meta, 40, testNominator, testNominator)
+	assert.ErrorIs(t, err, ErrValidatorNotExposed)
+}
@@ -0,0 +1,224 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	utilityPallet = "Utility"
+
+	itemCompletedEvent            = "Utility.ItemCompleted"
+	itemFailedEvent               = "Utility.ItemFailed"
+	batchInterruptedEvent         = "Utility.BatchInterrupted"
+	batchCompletedEvent           = "Utility.BatchCompleted"
+	batchCompletedWithErrorsEvent = "Utility.BatchCompletedWithErrors"
+)
+
+// BatchMode selects the call of the utility pallet that dispatches a batch.
+type BatchMode uint8
+
+const (
+	// BatchModeBatch dispatches the calls until the first one fails, the calls before it are not reverted.
+	BatchModeBatch BatchMode = iota
+	// BatchModeAll dispatches all calls or none, the batch fails if any call fails.
+	BatchModeAll
+	// BatchModeForce dispatches all calls, regardless of whether some of them fail.
+	BatchModeForce
+)
+
+func (m BatchMode) callName() string {
+	switch m {
+	case BatchModeAll:
+		return "Utility.batch_all"
+	case BatchModeForce:
+		return "Utility.force_batch"
+	default:
+		return "Utility.batch"
+	}
+}
+
+// Batch builds a call of the utility pallet that dispatches several calls in one extrinsic.
+type Batch struct {
+	meta  *types.Metadata
+	calls []types.Call
+	err   error
+}
+
+// NewBatch creates an empty batch for the runtime of the metadata.
+func NewBatch(meta *types.Metadata) *Batch {
+	return &Batch{meta: meta}
+}
+
+// Add adds a call to the batch, e.g. one created via types.NewCall.
+func (b *Batch) Add(call types.Call) *Batch {
+	b.calls = append(b.calls, call)
+
+	return b
+}
+
+// AddEncoded adds a SCALE encoded call to the batch, e.g. one created by another encoder. Decoding errors are
+// returned by Build.
+func (b *Batch) AddEncoded(call []byte) *Batch {
+	var c types.Call
+
+	if err := codec.Decode(call, &c); err != nil {
+		if b.err == nil {
+			b.err = ErrBatchCallDecoding.WithMsg("call %d", len(b.calls)).Wrap(err)
+		}
+
+		return b
+	}
+
+	return b.Add(c)
+}
+
+// Len returns the number of calls in the batch.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Build creates the call that dispatches the calls of the batch in the given mode. ErrUtilityPalletNotFound is
+// returned if the runtime does not include the utility pallet.
+func (b *Batch) Build(mode BatchMode) (types.Call, error) {
+	if b.err != nil {
+		return types.Call{}, b.err
+	}
+
+	if b.meta.Version >= 14 && !hasPallet(b.meta, utilityPallet) {
+		return types.Call{}, ErrUtilityPalletNotFound
+	}
+
+	call, err := types.NewCall(b.meta, mode.callName(), b.calls)
+	if err != nil {
+		return types.Call{}, ErrBatchCallCreation.WithMsg(mode.callName()).Wrap(err)
+	}
+
+	return call, nil
+}
+
+func hasPallet(meta *types.Metadata, name string) bool {
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BatchItemStatus is the outcome of a call of a batch.
+type BatchItemStatus uint8
+
+const (
+	// BatchItemNotExecuted means that the call was not dispatched or was reverted, because the batch was interrupted
+	// before or failed as a whole.
+	BatchItemNotExecuted BatchItemStatus = iota
+	// BatchItemSucceeded means that the call was dispatched successfully.
+	BatchItemSucceeded
+	// BatchItemFailed means that the dispatch of the call failed.
+	BatchItemFailed
+)
+
+// BatchItemOutcome is the outcome of a call of a batch.
+type BatchItemOutcome struct {
+	Status BatchItemStatus
+	// Err is the error of the dispatch if it failed, with the name of the pallet error if any.
+	Err *DispatchError
+}
+
+// batchInterrupted holds the fields of the Utility.BatchInterrupted event.
+type batchInterrupted struct {
+	Index types.U32
+	Error types.DispatchError
+}
+
+// BatchOutcomes returns the outcome of each of the count calls of the batch the extrinsic dispatched, decoded from the
+// ItemCompleted, ItemFailed, BatchInterrupted and BatchCompleted events of the utility pallet. If the extrinsic failed,
+// e.g. because a call of a BatchModeAll batch failed, none of the calls were executed. Batches nested in batches are
+// not supported.
+func (r *ExtrinsicResult) BatchOutcomes(count int) ([]BatchItemOutcome, error) {
+	outcomes := make([]BatchItemOutcome, count)
+
+	if r.DispatchError != nil {
+		return outcomes, nil
+	}
+
+	next := 0
+
+	for _, event := range r.Events {
+		switch event.Name {
+		case itemCompletedEvent, itemFailedEvent:
+			if next >= count {
+				return nil, ErrBatchOutcomeMismatch.WithMsg("more than %d items", count)
+			}
+
+			outcome := BatchItemOutcome{Status: BatchItemSucceeded}
+
+			if event.Name == itemFailedEvent {
+				var dispatchErr types.DispatchError
+
+				if err := codec.Decode(event.Data, &dispatchErr); err != nil {
+					return nil, ErrEventDecoding.WithMsg(itemFailedEvent).Wrap(err)
+				}
+
+				outcome = BatchItemOutcome{Status: BatchItemFailed, Err: r.dispatchError(dispatchErr)}
+			}
+
+			outcomes[next] = outcome
+			next++
+		case batchInterruptedEvent:
+			var interrupted batchInterrupted
+
+			if err := codec.Decode(event.Data, &interrupted); err != nil {
+				return nil, ErrEventDecoding.WithMsg(batchInterruptedEvent).Wrap(err)
+			}
+
+			index := int(interrupted.Index)
+			if index >= count {
+				return nil, ErrBatchOutcomeMismatch.WithMsg("interrupted at item %d of %d", index, count)
+			}
+
+			// Runtimes before ItemCompleted was introduced only emit the BatchInterrupted event.
+			for i := 0; i < index; i++ {
+				outcomes[i].Status = BatchItemSucceeded
+			}
+
+			outcomes[index] = BatchItemOutcome{Status: BatchItemFailed, Err: r.dispatchError(interrupted.Error)}
+		case batchCompletedEvent:
+			for i := range outcomes {
+				outcomes[i].Status = BatchItemSucceeded
+			}
+		case batchCompletedWithErrorsEvent:
+			// The outcomes of the items were set by their ItemCompleted and ItemFailed events.
+		}
+	}
+
+	return outcomes, nil
+}
+
+// dispatchError returns the DispatchError with the name of the pallet error, if it is known.
+func (r *ExtrinsicResult) dispatchError(err types.DispatchError) *DispatchError {
+	var moduleErrorName string
+	if r.rt != nil {
+		moduleErrorName = r.rt.moduleErrorName(err)
+	}
+
+	return &DispatchError{Err: err, ModuleErrorName: moduleErrorName}
+}
@@ -0,0 +1,206 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch_Build(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+
+	remark, err := types.NewCall(meta, "System.remark", types.NewBytes([]byte("hello")))
+	require.NoError(t, err)
+
+	accountID := getTestAccountID(t)
+
+	dest, err := types.NewMultiAddressFromAccountID(accountID.ToBytes())
+	require.NoError(t, err)
+
+	transfer, err := types.NewCall(meta, "Balances.transfer_keep_alive", dest, types.NewUCompactFromUInt(12345))
+	require.NoError(t, err)
+
+	// Calls encoded elsewhere are added as is.
+	encodedTransfer, err := codec.Encode(transfer)
+	require.NoError(t, err)
+
+	batch := NewBatch(meta).Add(remark).AddEncoded(encodedTransfer)
+	assert.Equal(t, 2, batch.Len())
+
+	encodedRemark, err := codec.Encode(remark)
+	require.NoError(t, err)
+
+	expectedArgs := append(append([]byte{2 << 2}, encodedRemark...), encodedTransfer...)
+
+	callRegistry, err := registry.NewFactory().CreateCallRegistry(meta)
+	require.NoError(t, err)
+
+	tests := []struct {
+		mode      BatchMode
+		callIndex types.CallIndex
+	}{
+		{BatchModeBatch, types.CallIndex{SectionIndex: 26, MethodIndex: 0}},
+		{BatchModeAll, types.CallIndex{SectionIndex: 26, MethodIndex: 2}},
+		{BatchModeForce, types.CallIndex{SectionIndex: 26, MethodIndex: 4}},
+	}
+
+	for _, test := range tests {
+		call, err := batch.Build(test.mode)
+		require.NoError(t, err)
+		assert.Equal(t, test.callIndex, call.CallIndex)
+		assert.Equal(t, types.Args(expectedArgs), call.Args)
+
+		// The batch decodes with the call registry.
+		fields, err := callRegistry[call.CallIndex].Decode(scale.NewDecoder(bytes.NewReader(call.Args)))
+		require.NoError(t, err)
+		require.Len(t, fields, 1)
+		assert.Len(t, fields[0].Value, 2)
+	}
+}
+
+func TestBatch_Build_Errors(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+
+	_, err := NewBatch(meta).AddEncoded([]byte{1}).Build(BatchModeAll)
+	assert.ErrorIs(t, err, ErrBatchCallDecoding)
+
+	// The runtime does not include the utility pallet.
+	withoutUtility := *meta
+	withoutUtility.AsMetadataV14.Pallets = nil
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.Name != utilityPallet {
+			withoutUtility.AsMetadataV14.Pallets = append(withoutUtility.AsMetadataV14.Pallets, pallet)
+		}
+	}
+
+	_, err = NewBatch(&withoutUtility).Add(types.Call{}).Build(BatchModeBatch)
+	assert.ErrorIs(t, err, ErrUtilityPalletNotFound)
+}
+
+func encodeTestBatchInterrupted(t *testing.T, index uint32, dispatchErr types.DispatchError) []byte {
+	b, err := codec.Encode(batchInterrupted{Index: types.U32(index), Error: dispatchErr})
+	require.NoError(t, err)
+
+	return b
+}
+
+func TestExtrinsicResult_BatchOutcomes(t *testing.T) {
+	rt := &runtime{errorRegistry: testErrorRegistry}
+
+	encodedModuleError, err := codec.Encode(testModuleError)
+	require.NoError(t, err)
+
+	failed := BatchItemOutcome{
+		Status: BatchItemFailed,
+		Err:    &DispatchError{Err: testModuleError, ModuleErrorName: "Balances.InsufficientBalance"},
+	}
+	succeeded := BatchItemOutcome{Status: BatchItemSucceeded}
+	notExecuted := BatchItemOutcome{Status: BatchItemNotExecuted}
+
+	tests := []struct {
+		name     string
+		res      *ExtrinsicResult
+		expected []BatchItemOutcome
+	}{
+		{
+			name: "batch completed",
+			res: &ExtrinsicResult{Events: []*parser.Event{
+				{Name: itemCompletedEvent},
+				{Name: itemCompletedEvent},
+				{Name: itemCompletedEvent},
+				{Name: batchCompletedEvent},
+			}},
+			expected: []BatchItemOutcome{succeeded, succeeded, succeeded},
+		},
+		{
+			name: "batch interrupted",
+			res: &ExtrinsicResult{Events: []*parser.Event{
+				{Name: itemCompletedEvent},
+				{Name: batchInterruptedEvent, Data: encodeTestBatchInterrupted(t, 1, testModuleError)},
+			}},
+			expected: []BatchItemOutcome{succeeded, failed, notExecuted},
+		},
+		{
+			name: "batch interrupted without item events",
+			res: &ExtrinsicResult{Events: []*parser.Event{
+				{Name: batchInterruptedEvent, Data: encodeTestBatchInterrupted(t, 2, testModuleError)},
+			}},
+			expected: []BatchItemOutcome{succeeded, succeeded, failed},
+		},
+		{
+			name: "force batch completed with errors",
+			res: &ExtrinsicResult{Events: []*parser.Event{
+				{Name: itemFailedEvent, Data: encodedModuleError},
+				{Name: itemCompletedEvent},
+				{Name: itemFailedEvent, Data: encodedModuleError},
+				{Name: batchCompletedWithErrorsEvent},
+			}},
+			expected: []BatchItemOutcome{failed, succeeded, failed},
+		},
+		{
+			name: "batch all failed",
+			res: &ExtrinsicResult{
+				Events:        []*parser.Event{{Name: extrinsicFailedEvent}},
+				DispatchError: &DispatchError{Err: testModuleError},
+			},
+			expected: []BatchItemOutcome{notExecuted, notExecuted, notExecuted},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.res.rt = rt
+
+			outcomes, err := test.res.BatchOutcomes(3)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, outcomes)
+		})
+	}
+}
+
+func TestExtrinsicResult_BatchOutcomes_Mismatch(t *testing.T) {
+	res := &ExtrinsicResult{Events: []*parser.Event{
+		{Name: itemCompletedEvent},
+		{Name: itemCompletedEvent},
+	}}
+
+	_, err := res.BatchOutcomes(1)
+	assert.ErrorIs(t, err, ErrBatchOutcomeMismatch)
+
+	res = &ExtrinsicResult{Events: []*parser.Event{
+		{Name: batchInterruptedEvent, Data: encodeTestBatchInterrupted(t, 3, testModuleError)},
+	}}
+
+	_, err = res.BatchOutcomes(3)
+	assert.ErrorIs(t, err, ErrBatchOutcomeMismatch)
+
+	res = &ExtrinsicResult{Events: []*parser.Event{{Name: itemFailedEvent, Data: []byte{9}}}}
+
+	_, err = res.BatchOutcomes(3)
+	assert.ErrorIs(t, err, ErrEventDecoding)
+}
@@ -0,0 +1,69 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrBlockHashRetrieval       = libErr.Error("block hash retrieval")
+	ErrGenesisHashRetrieval     = libErr.Error("genesis hash retrieval")
+	ErrHeaderRetrieval          = libErr.Error("header retrieval")
+	ErrInvalidMortalPeriod      = libErr.Error("invalid mortal period")
+	ErrRuntimeVersionRetrieval  = libErr.Error("runtime version retrieval")
+	ErrMetadataRetrieval        = libErr.Error("metadata retrieval")
+	ErrErrorRegistryCreation    = libErr.Error("error registry creation")
+	ErrStorageKeyCreation       = libErr.Error("storage key creation")
+	ErrAccountInfoRetrieval     = libErr.Error("account info retrieval")
+	ErrExtrinsicSigning         = libErr.Error("extrinsic signing")
+	ErrExtrinsicEncoding        = libErr.Error("extrinsic encoding")
+	ErrExtrinsicSubmission      = libErr.Error("extrinsic submission")
+	ErrSystemPalletNotFound     = libErr.Error("system pallet not found")
+	ErrRuntimeAPICall           = libErr.Error("runtime API call")
+	ErrRuntimeAPIResultDecoding = libErr.Error("runtime API result decoding")
+	ErrDryRunAPI                = libErr.Error("dry run API")
+	ErrSystemDryRun             = libErr.Error("system dry run")
+	ErrNonceRetrieval           = libErr.Error("nonce retrieval")
+	ErrNonceAccountMismatch     = libErr.Error("nonce manager account mismatch")
+	ErrExtrinsicWatch           = libErr.Error("extrinsic watch")
+	ErrExtrinsicDropped         = libErr.Error("extrinsic dropped")
+	ErrExtrinsicInvalid         = libErr.Error("extrinsic invalid")
+	ErrExtrinsicUsurped         = libErr.Error("extrinsic usurped")
+	ErrExtrinsicRetracted       = libErr.Error("extrinsic retracted")
+	ErrFinalityTimeout          = libErr.Error("finality timeout")
+	ErrFinalityTracking         = libErr.Error("finality tracking")
+	ErrBlockRetrieval           = libErr.Error("block retrieval")
+	ErrExtrinsicNotFound        = libErr.Error("extrinsic not found in block")
+	ErrEventsRetrieval          = libErr.Error("events retrieval")
+	ErrEventRegistryCreation    = libErr.Error("event registry creation")
+	ErrEventsParsing            = libErr.Error("events parsing")
+	ErrEventDecoding            = libErr.Error("event decoding")
+	ErrUtilityPalletNotFound    = libErr.Error("utility pallet not found")
+	ErrBatchCallDecoding        = libErr.Error("batch call decoding")
+	ErrBatchCallCreation        = libErr.Error("batch call creation")
+	ErrBatchOutcomeMismatch     = libErr.Error("batch outcome mismatch")
+	ErrFeeEstimation            = libErr.Error("fee estimation")
+	ErrConstantDecoding         = libErr.Error("constant decoding")
+	ErrPreflightAccount         = libErr.Error("pre-flight account")
+	ErrEraExpired               = libErr.Error("era expired")
+	ErrAncientBirthBlock        = libErr.Error("ancient birth block")
+	ErrStaleNonce               = libErr.Error("stale nonce")
+	ErrInsufficientBalance      = libErr.Error("insufficient balance")
+	ErrRuntimeVersionMismatch   = libErr.Error("runtime version mismatch")
+	ErrSigningContextMissing    = libErr.Error("signing context missing")
+	ErrTipNotIncreased          = libErr.Error("tip not increased")
+	ErrSubmissionEnded          = libErr.Error("submission ended")
+)
@@ -0,0 +1,168 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// maxStaleNonceRetries is the number of times an extrinsic is built again with a new nonce if the node reported that
+// its nonce is stale.
+const maxStaleNonceRetries = 3
+
+// NonceManager hands out the nonces of an account for submitting many extrinsics in quick succession, without
+// retrieving the nonce from the chain for every extrinsic, which would return the same nonce until the previous
+// extrinsics were included.
+//
+// The nonce is initialised via system_accountNextIndex, which takes the extrinsics in the transaction pool into
+// account, and incremented for every extrinsic afterwards. Nonces of extrinsics that were not submitted, or that were
+// dropped from the transaction pool, must be handed back via Release, so that they are reused by the next extrinsic
+// and the extrinsics with higher nonces don't get stuck in the future queue of the pool.
+//
+// A NonceManager is safe for concurrent use, but it must be the only one submitting extrinsics of the account.
+type NonceManager struct {
+	systemRPC system.Provider
+	accountID types.AccountID
+
+	mu          sync.Mutex
+	initialized bool
+	next        uint64
+	released    []uint64 // sorted in ascending order
+}
+
+// NewNonceManager creates a NonceManager for the account. The nonce is retrieved on the first call to Next.
+func NewNonceManager(systemRPC system.Provider, accountID types.AccountID) *NonceManager {
+	return &NonceManager{
+		systemRPC: systemRPC,
+		accountID: accountID,
+	}
+}
+
+// AccountID returns the account the nonces are managed for.
+func (m *NonceManager) AccountID() types.AccountID {
+	return m.accountID
+}
+
+// Next returns the next nonce of the account. Released nonces are returned first, lowest first, to fill the gaps they
+// left.
+func (m *NonceManager) Next(ctx context.Context) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.initialized {
+		nonce, err := m.systemRPC.AccountNextIndexContext(ctx, m.accountID)
+		if err != nil {
+			return 0, ErrNonceRetrieval.Wrap(err)
+		}
+
+		m.next = uint64(nonce)
+		m.initialized = true
+	}
+
+	if len(m.released) > 0 {
+		nonce := m.released[0]
+		m.released = m.released[1:]
+
+		return nonce, nil
+	}
+
+	nonce := m.next
+	m.next++
+
+	return nonce, nil
+}
+
+// Release hands back a nonce that was returned by Next but not used, e.g. because the extrinsic could not be
+// submitted or was dropped from the transaction pool. The nonce is returned by the next call to Next.
+func (m *NonceManager) Release(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.initialized || nonce >= m.next {
+		return
+	}
+
+	i := sort.Search(len(m.released), func(i int) bool { return m.released[i] >= nonce })
+	if i < len(m.released) && m.released[i] == nonce {
+		return
+	}
+
+	m.released = append(m.released, 0)
+	copy(m.released[i+1:], m.released[i:])
+	m.released[i] = nonce
+}
+
+// Resync retrieves the nonce of the account again, e.g. after the node reported a stale nonce because extrinsics of
+// the account were submitted by someone else. Nonces that were handed out already are never handed out again, unless
+// they were released.
+func (m *NonceManager) Resync(ctx context.Context) error {
+	nonce, err := m.systemRPC.AccountNextIndexContext(ctx, m.accountID)
+	if err != nil {
+		return ErrNonceRetrieval.Wrap(err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.initialized || uint64(nonce) > m.next {
+		m.next = uint64(nonce)
+		m.initialized = true
+	}
+
+	// Released nonces below the nonce of the chain were used in the meantime.
+	i := sort.Search(len(m.released), func(i int) bool { return m.released[i] >= uint64(nonce) })
+	m.released = m.released[i:]
+
+	return nil
+}
+
+// isStaleNonce returns true if the extrinsic was rejected because its nonce was used already, either by an extrinsic
+// that was included or by one that is in the transaction pool.
+func isStaleNonce(err error) bool {
+	var validityErr *ValidityError
+	if errors.As(err, &validityErr) {
+		return validityErr.IsInvalid(types.InvalidTransactionStale)
+	}
+
+	if types.IsTransactionPoolError(err, types.TransactionPoolErrorTooLowPriority) {
+		return true
+	}
+
+	if !types.IsInvalidTransaction(err) {
+		return false
+	}
+
+	rpcErr, _ := types.AsRPCError(err)
+	msg := strings.ToLower(rpcErr.Error())
+
+	return strings.Contains(msg, "outdated") || strings.Contains(msg, "stale")
+}
+
+// isFutureNonce returns true if the extrinsic is not valid yet because its nonce is higher than the nonce of the
+// account, i.e. extrinsics with lower nonces are still pending.
+func isFutureNonce(err error) bool {
+	var validityErr *ValidityError
+
+	return errors.As(err, &validityErr) && validityErr.IsInvalid(types.InvalidTransactionFuture)
+}
@@ -0,0 +1,329 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	systemMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestAccountID(t *testing.T) types.AccountID {
+	accountID, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	return *accountID
+}
+
+func TestNonceManager_Concurrent(t *testing.T) {
+	systemRPC := systemMocks.NewSystem(t)
+	accountID := getTestAccountID(t)
+
+	systemRPC.On("AccountNextIndexContext", mock.Anything, accountID).
+		Return(types.U64(5), nil).
+		Once()
+
+	nonces := NewNonceManager(systemRPC, accountID)
+
+	const count = 50
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		got []uint64
+	)
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			nonce, err := nonces.Next(context.Background())
+			assert.NoError(t, err)
+
+			mu.Lock()
+			got = append(got, nonce)
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+
+	for i, nonce := range got {
+		assert.Equal(t, uint64(5+i), nonce)
+	}
+}
+
+func TestNonceManager_Release(t *testing.T) {
+	systemRPC := systemMocks.NewSystem(t)
+	accountID := getTestAccountID(t)
+
+	systemRPC.On("AccountNextIndexContext", mock.Anything, accountID).
+		Return(types.U64(5), nil).
+		Once()
+
+	nonces := NewNonceManager(systemRPC, accountID)
+	ctx := context.Background()
+
+	for i := 5; i < 9; i++ {
+		nonce, err := nonces.Next(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(i), nonce)
+	}
+
+	// Released nonces fill the gaps, lowest first. Nonces that were not handed out are ignored.
+	nonces.Release(7)
+	nonces.Release(6)
+	nonces.Release(7)
+	nonces.Release(9)
+
+	for _, expected := range []uint64{6, 7, 9} {
+		nonce, err := nonces.Next(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, nonce)
+	}
+}
+
+func TestNonceManager_Resync(t *testing.T) {
+	systemRPC := systemMocks.NewSystem(t)
+	accountID := getTestAccountID(t)
+	ctx := context.Background()
+
+	systemRPC.On("AccountNextIndexContext", mock.Anything, accountID).
+		Return(types.U64(5), nil).
+		Once()
+
+	nonces := NewNonceManager(systemRPC, accountID)
+
+	for i := 0; i < 3; i++ {
+		_, err := nonces.Next(ctx)
+		assert.NoError(t, err)
+	}
+
+	nonces.Release(5)
+	nonces.Release(6)
+
+	// Nonce 5 was used by someone else, so it is not handed out again.
+	systemRPC.On("AccountNextIndexContext", mock.Anything, accountID).
+		Return(types.U64(6), nil).
+		Once()
+
+	assert.NoError(t, nonces.Resync(ctx))
+
+	for _, expected := range []uint64{6, 8} {
+		nonce, err := nonces.Next(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, nonce)
+	}
+
+	systemRPC.On("AccountNextIndexContext", mock.Anything, accountID).
+		Return(types.U64(12), nil).
+		Once()
+
+	assert.NoError(t, nonces.Resync(ctx))
+
+	nonce, err := nonces.Next(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(12), nonce)
+
+	systemRPC.On("AccountNextIndexContext", mock.Anything, accountID).
+		Return(types.U64(0), errors.New("boom")).
+		Once()
+
+	assert.ErrorIs(t, nonces.Resync(ctx), ErrNonceRetrieval)
+}
+
+// expectManagedBuild sets up the mocks for building any number of extrinsics, the nonce is never retrieved from the
+// account info.
+func (m testMocks) expectManagedBuild(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+
+	m.state.On("GetRuntimeVersionContext", mock.Anything, testBlockHash).
+		Return(&types.RuntimeVersion{SpecVersion: 42, TransactionVersion: 7}, nil)
+	m.state.On("GetMetadataContext", mock.Anything, testBlockHash).
+		Return(meta, nil).
+		Once()
+	m.registryFactory.On("CreateErrorRegistry", meta).
+		Return(testErrorRegistry, nil).
+		Once()
+	m.chain.On("GetBlockHashLatestContext", mock.Anything).
+		Return(testBlockHash, nil)
+	m.chain.On("GetBlockHashContext", mock.Anything, uint64(0)).
+		Return(testGenesisHash, nil)
+}
+
+func TestSubmitter_SubmitManaged_Concurrent(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectManagedBuild(t)
+
+	accountID := getTestAccountID(t)
+
+	m.system.On("AccountNextIndexContext", mock.Anything, accountID).
+		Return(types.U64(3), nil).
+		Once()
+
+	// The extrinsics with higher nonces are not valid yet at the latest block.
+	m.system.On("DryRunContext", mock.Anything, mock.Anything, testBlockHash).
+		Return(types.ApplyExtrinsicResult{
+			IsError: true,
+			Error: types.TransactionValidityError{
+				IsInvalid:          true,
+				InvalidTransaction: types.InvalidTransaction{Kind: types.InvalidTransactionFuture},
+			},
+		}, nil)
+
+	var (
+		mu  sync.Mutex
+		got []uint64
+	)
+
+	m.author.On("SubmitExtrinsicContext", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			xt := args.Get(1).(types.Extrinsic)
+
+			mu.Lock()
+			got = append(got, uint64(xt.Signature.Nonce.Int64()))
+			mu.Unlock()
+		}).
+		Return(types.Hash{7, 8, 9}, nil)
+
+	nonces := NewNonceManager(m.system, accountID)
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	const count = 20
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := s.SubmitManaged(call, testSigner, nonces)
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+
+	require.Len(t, got, count)
+
+	for i, nonce := range got {
+		assert.Equal(t, uint64(3+i), nonce)
+	}
+}
+
+func TestSubmitter_SubmitManaged_StaleNonce(t *testing.T) {
+	s, m, buf := newTestLogSubmitter(t)
+	m.expectManagedBuild(t)
+
+	accountID := getTestAccountID(t)
+
+	m.system.On("AccountNextIndexContext", mock.Anything, accountID).
+		Return(types.U64(3), nil).
+		Once()
+	m.system.On("DryRunContext", mock.Anything, mock.Anything, testBlockHash).
+		Return(types.ApplyExtrinsicResult{IsOk: true, Ok: types.DispatchOutcome{IsOk: true}}, nil)
+
+	// The extrinsic with nonce 3 was submitted by someone else in the meantime.
+	m.author.On("SubmitExtrinsicContext", mock.Anything, mock.MatchedBy(func(xt types.Extrinsic) bool {
+		return xt.Signature.Nonce.Int64() == 3
+	})).
+		Return(types.Hash{}, types.RPCError{
+			Code:    int(types.TransactionPoolErrorInvalidTransaction),
+			Message: "Invalid Transaction",
+			Data:    "Transaction is outdated",
+		}).
+		Once()
+	m.system.On("AccountNextIndexContext", mock.Anything, accountID).
+		Return(types.U64(4), nil).
+		Once()
+	m.author.On("SubmitExtrinsicContext", mock.Anything, mock.MatchedBy(func(xt types.Extrinsic) bool {
+		return xt.Signature.Nonce.Int64() == 4
+	})).
+		Return(types.Hash{7, 8, 9}, nil).
+		Once()
+
+	nonces := NewNonceManager(m.system, accountID)
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	res, err := s.SubmitManaged(call, testSigner, nonces)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Hash{7, 8, 9}, res)
+
+	nonce, err := nonces.Next(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), nonce)
+
+	entry := decodeTestLogEntries(t, buf)["Stale nonce, resyncing the nonces"]
+	require.NotNil(t, entry)
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, float64(1), entry["attempt"])
+	assert.Equal(t, float64(3), entry["nonce"])
+}
+
+func TestSubmitter_SubmitManaged_Failure(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectManagedBuild(t)
+
+	accountID := getTestAccountID(t)
+
+	m.system.On("AccountNextIndexContext", mock.Anything, accountID).
+		Return(types.U64(3), nil).
+		Once()
+	m.system.On("DryRunContext", mock.Anything, mock.Anything, testBlockHash).
+		Return(types.ApplyExtrinsicResult{
+			IsError: true,
+			Error: types.TransactionValidityError{
+				IsInvalid:          true,
+				InvalidTransaction: types.InvalidTransaction{Kind: types.InvalidTransactionPayment},
+			},
+		}, nil).
+		Once()
+
+	nonces := NewNonceManager(m.system, accountID)
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	_, err := s.SubmitManaged(call, testSigner, nonces)
+
+	var validityErr *ValidityError
+	assert.True(t, errors.As(err, &validityErr))
+
+	// The nonce was not used, so it is handed out again.
+	nonce, err := nonces.Next(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), nonce)
+
+	_, err = s.SubmitManaged(call, types.NewKeyringPairSigner(signature.TestKeyringPairAliceEd25519), nonces)
+	assert.ErrorIs(t, err, ErrNonceAccountMismatch)
+}
@@ -0,0 +1,439 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// FeeEstimator estimates the fee of extrinsics, e.g. the payment RPC.
+type FeeEstimator interface {
+	QueryInfoContext(ctx context.Context, xt types.Extrinsic, blockHash types.Hash) (*types.RuntimeDispatchInfo, error)
+}
+
+// PreflightOptions configure Preflight.
+type PreflightOptions struct {
+	// SignatureOptions are the options the extrinsic was signed with, or will be signed with if it is not signed yet.
+	// The era, nonce and tip of signed extrinsics are taken from their signature instead. The BlockHash is the block
+	// the extrinsic was built at, as returned by Build, or the birth block of its era, the era is only checked if it
+	// is set. The spec and transaction versions are only checked if they are set.
+	SignatureOptions types.SignatureOptions
+
+	// Account is the account that signs the extrinsic, it is required if the extrinsic is not signed yet.
+	Account *types.AccountID
+
+	// FeeEstimator, if set, estimates the fee that is checked against the free balance of the account together with
+	// the tip, e.g. api.RPC.Payment. Otherwise, only the tip is checked. Extrinsics that are not signed yet are
+	// estimated with an empty signature.
+	FeeEstimator FeeEstimator
+}
+
+// PreflightCheck is a check of Preflight.
+type PreflightCheck uint8
+
+const (
+	// PreflightEra checks that the era of a mortal extrinsic did not expire and that its birth block is still known
+	// to the runtime.
+	PreflightEra PreflightCheck = iota
+	// PreflightNonce checks that the nonce is not behind the on-chain nonce of the account.
+	PreflightNonce
+	// PreflightBalance checks that the free balance of the account covers the fee and the tip, while keeping the
+	// existential deposit.
+	PreflightBalance
+	// PreflightRuntimeVersion checks that the spec and transaction versions match the runtime.
+	PreflightRuntimeVersion
+)
+
+func (c PreflightCheck) String() string {
+	switch c {
+	case PreflightEra:
+		return "era"
+	case PreflightNonce:
+		return "nonce"
+	case PreflightBalance:
+		return "balance"
+	case PreflightRuntimeVersion:
+		return "runtime version"
+	}
+
+	return "unknown"
+}
+
+// Violation is a reason for nodes to reject an extrinsic that was found by Preflight.
+type Violation struct {
+	Check PreflightCheck
+	// Err is one of ErrEraExpired, ErrAncientBirthBlock, ErrStaleNonce, ErrInsufficientBalance and
+	// ErrRuntimeVersionMismatch, with a message on how to resolve the violation.
+	Err error
+}
+
+// PreflightError is returned by SubmitAndWait if the pre-flight checks found violations, the extrinsic was not
+// submitted.
+type PreflightError struct {
+	Violations []Violation
+}
+
+func (e *PreflightError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+
+	for _, violation := range e.Violations {
+		msgs = append(msgs, violation.Err.Error())
+	}
+
+	return fmt.Sprintf("pre-flight checks failed: %s", strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the violations, so that errors.Is matches each of them.
+func (e *PreflightError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Violations))
+
+	for _, violation := range e.Violations {
+		errs = append(errs, violation.Err)
+	}
+
+	return errs
+}
+
+// Preflight checks the extrinsic against the latest block for the reasons nodes commonly reject extrinsics for: an
+// expired era, a stale nonce, a balance that does not cover the fee and the tip, and versions that don't match the
+// runtime. All violations are returned, an error is only returned if the checks themselves failed.
+//
+// Nonces ahead of the on-chain nonce are not a violation, since earlier extrinsics of the account might still be in
+// the transaction pool.
+func (s *submitter) Preflight( //nolint:funlen
+	ctx context.Context,
+	xt types.Extrinsic,
+	opts PreflightOptions,
+) ([]Violation, error) {
+	blockHash, err := s.chainRPC.GetBlockHashLatestContext(ctx)
+	if err != nil {
+		return nil, ErrBlockHashRetrieval.Wrap(err)
+	}
+
+	header, err := s.chainRPC.GetHeaderContext(ctx, blockHash)
+	if err != nil {
+		return nil, ErrHeaderRetrieval.Wrap(err)
+	}
+
+	rt, err := s.getRuntime(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	era, nonce, tip := opts.SignatureOptions.Era, opts.SignatureOptions.Nonce, opts.SignatureOptions.Tip
+
+	if xt.IsSigned() {
+		era, nonce, tip = xt.Signature.Era, xt.Signature.Nonce, xt.Signature.Tip
+	}
+
+	var violations []Violation
+
+	if era.IsMortalEra && opts.SignatureOptions.BlockHash != (types.Hash{}) {
+		eraViolations, err := s.checkEra(ctx, rt, era, opts.SignatureOptions.BlockHash, uint64(header.Number))
+		if err != nil {
+			return nil, err
+		}
+
+		violations = append(violations, eraViolations...)
+	}
+
+	account, err := preflightAccount(xt, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := types.CreateStorageKey(rt.meta, "System", "Account", account)
+	if err != nil {
+		return nil, ErrStorageKeyCreation.Wrap(err)
+	}
+
+	// Accounts that don't exist yet have a nonce and a balance of 0.
+	var accountInfo types.AccountInfo
+
+	if _, err := s.stateRPC.GetStorageContext(ctx, key, &accountInfo, blockHash); err != nil {
+		return nil, ErrAccountInfoRetrieval.Wrap(err)
+	}
+
+	if n := (*big.Int)(&nonce).Uint64(); n < uint64(accountInfo.Nonce) {
+		violations = append(violations, Violation{
+			Check: PreflightNonce,
+			Err: ErrStaleNonce.WithMsg(
+				"nonce %d is behind the on-chain nonce %d, build the extrinsic again with the current nonce",
+				n, accountInfo.Nonce,
+			),
+		})
+	}
+
+	balanceViolations, err := s.checkBalance(ctx, rt, xt, opts, blockHash, (*big.Int)(&tip), accountInfo.Data.Free)
+	if err != nil {
+		return nil, err
+	}
+
+	violations = append(violations, balanceViolations...)
+
+	return append(violations, checkRuntimeVersion(rt, opts.SignatureOptions)...), nil
+}
+
+// checkEra checks the mortal era of an extrinsic built at the given block against the latest block.
+func (s *submitter) checkEra(
+	ctx context.Context,
+	rt *runtime,
+	era types.ExtrinsicEra,
+	builtAt types.Hash,
+	latest uint64,
+) ([]Violation, error) {
+	header, err := s.chainRPC.GetHeaderContext(ctx, builtAt)
+	if err != nil {
+		return nil, ErrHeaderRetrieval.Wrap(err)
+	}
+
+	birth, death := era.Birth(uint64(header.Number)), era.Death(uint64(header.Number))
+
+	if latest >= death {
+		return []Violation{{
+			Check: PreflightEra,
+			Err: ErrEraExpired.WithMsg(
+				"the era of %d blocks born at block %d expired at block %d, the latest block is %d, build the "+
+					"extrinsic again",
+				era.AsMortalEra.Period(), birth, death, latest,
+			),
+		}}, nil
+	}
+
+	blockHashCount, ok, err := getBlockHashCount(rt.meta)
+	if err != nil {
+		return nil, err
+	}
+
+	// The runtime only keeps the hashes of the last BlockHashCount blocks, which are needed to check the signed
+	// birth block.
+	if ok && birth+blockHashCount <= latest {
+		return []Violation{{
+			Check: PreflightEra,
+			Err: ErrAncientBirthBlock.WithMsg(
+				"birth block %d is older than the last %d blocks the runtime keeps, the latest block is %d, build "+
+					"the extrinsic again with a period of at most %d blocks",
+				birth, blockHashCount, latest, blockHashCount,
+			),
+		}}, nil
+	}
+
+	return nil, nil
+}
+
+// checkBalance checks that the free balance covers the fee and the tip while keeping the existential deposit.
+func (s *submitter) checkBalance(
+	ctx context.Context,
+	rt *runtime,
+	xt types.Extrinsic,
+	opts PreflightOptions,
+	blockHash types.Hash,
+	tip *big.Int,
+	free types.U128,
+) ([]Violation, error) {
+	fee := new(big.Int)
+
+	if opts.FeeEstimator != nil {
+		estimated, err := s.estimateFee(ctx, rt, xt, opts, blockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		fee = estimated
+	}
+
+	existentialDeposit, err := getExistentialDeposit(rt.meta)
+	if err != nil {
+		return nil, err
+	}
+
+	required := new(big.Int).Add(fee, tip)
+	required.Add(required, existentialDeposit)
+
+	if bigInt(free).Cmp(required) >= 0 {
+		return nil, nil
+	}
+
+	return []Violation{{
+		Check: PreflightBalance,
+		Err: ErrInsufficientBalance.WithMsg(
+			"the free balance %s does not cover the fee %s and the tip %s while keeping the existential deposit %s, "+
+				"fund the account with at least %s",
+			bigInt(free), fee, tip, existentialDeposit, new(big.Int).Sub(required, bigInt(free)),
+		),
+	}}, nil
+}
+
+// estimateFee estimates the fee of the extrinsic. Extrinsics that are not signed yet are signed with an empty
+// signature first, since the fee depends on the length of the extrinsic and only signed extrinsics pay fees.
+func (s *submitter) estimateFee(
+	ctx context.Context,
+	rt *runtime,
+	xt types.Extrinsic,
+	opts PreflightOptions,
+	blockHash types.Hash,
+) (*big.Int, error) {
+	if !xt.IsSigned() {
+		err := xt.SignWithMetadata(emptySigner{account: *opts.Account}, rt.meta, opts.SignatureOptions, nil)
+		if err != nil {
+			return nil, ErrExtrinsicSigning.Wrap(err)
+		}
+	}
+
+	info, err := opts.FeeEstimator.QueryInfoContext(ctx, xt, blockHash)
+	if err != nil {
+		return nil, ErrFeeEstimation.Wrap(err)
+	}
+
+	return bigInt(info.PartialFee), nil
+}
+
+// checkRuntimeVersion checks the versions the extrinsic was signed with against the runtime, unset versions are not
+// checked.
+func checkRuntimeVersion(rt *runtime, opts types.SignatureOptions) []Violation {
+	var violations []Violation
+
+	if opts.SpecVersion != 0 && opts.SpecVersion != rt.version.SpecVersion {
+		violations = append(violations, Violation{
+			Check: PreflightRuntimeVersion,
+			Err: ErrRuntimeVersionMismatch.WithMsg(
+				"signed with spec version %d, the runtime has spec version %d, sign the extrinsic again",
+				opts.SpecVersion, rt.version.SpecVersion,
+			),
+		})
+	}
+
+	if opts.TransactionVersion != 0 && opts.TransactionVersion != rt.version.TransactionVersion {
+		violations = append(violations, Violation{
+			Check: PreflightRuntimeVersion,
+			Err: ErrRuntimeVersionMismatch.WithMsg(
+				"signed with transaction version %d, the runtime has transaction version %d, sign the extrinsic again",
+				opts.TransactionVersion, rt.version.TransactionVersion,
+			),
+		})
+	}
+
+	return violations
+}
+
+// preflightAccount returns the account of the extrinsic as used in storage keys.
+func preflightAccount(xt types.Extrinsic, opts PreflightOptions) ([]byte, error) {
+	if !xt.IsSigned() {
+		if opts.Account == nil {
+			return nil, ErrPreflightAccount.WithMsg("the account of unsigned extrinsics is required")
+		}
+
+		return opts.Account.ToBytes(), nil
+	}
+
+	signer := xt.Signature.Signer
+
+	switch {
+	case signer.IsID:
+		return signer.AsID.ToBytes(), nil
+	case signer.IsAddress32:
+		return signer.AsAddress32[:], nil
+	case signer.IsAddress20:
+		return signer.AsAddress20[:], nil
+	}
+
+	return nil, ErrPreflightAccount.WithMsg("unsupported signer address")
+}
+
+// getBlockHashCount returns the System.BlockHashCount constant, if the runtime declares it.
+func getBlockHashCount(meta *types.Metadata) (uint64, bool, error) {
+	value, err := meta.FindConstantValue("System", "BlockHashCount")
+	if err != nil {
+		return 0, false, nil //nolint:nilerr
+	}
+
+	// The constant has the block number type of the runtime.
+	switch len(value) {
+	case 4:
+		var count types.U32
+
+		err = codec.Decode(value, &count)
+
+		return uint64(count), true, wrapConstantDecoding(err, "System.BlockHashCount")
+	case 8:
+		var count types.U64
+
+		err = codec.Decode(value, &count)
+
+		return uint64(count), true, wrapConstantDecoding(err, "System.BlockHashCount")
+	}
+
+	return 0, false, nil
+}
+
+// getExistentialDeposit returns the Balances.ExistentialDeposit constant, or 0 if the runtime has no Balances
+// pallet.
+func getExistentialDeposit(meta *types.Metadata) (*big.Int, error) {
+	value, err := meta.FindConstantValue("Balances", "ExistentialDeposit")
+	if err != nil {
+		return new(big.Int), nil //nolint:nilerr
+	}
+
+	var existentialDeposit types.U128
+
+	if err := codec.Decode(value, &existentialDeposit); err != nil {
+		return nil, wrapConstantDecoding(err, "Balances.ExistentialDeposit")
+	}
+
+	return bigInt(existentialDeposit), nil
+}
+
+func wrapConstantDecoding(err error, constant string) error {
+	if err == nil {
+		return nil
+	}
+
+	return ErrConstantDecoding.WithMsg(constant).Wrap(err)
+}
+
+// bigInt returns the value of u, which is 0 for the zero value.
+func bigInt(u types.U128) *big.Int {
+	if u.Int == nil {
+		return new(big.Int)
+	}
+
+	return u.Int
+}
+
+// emptySigner signs with an empty sr25519 signature, it is used to estimate the fees of extrinsics that are not
+// signed yet.
+type emptySigner struct {
+	account types.AccountID
+}
+
+func (s emptySigner) PublicKey() []byte {
+	return s.account.ToBytes()
+}
+
+func (s emptySigner) AccountID() types.AccountID {
+	return s.account
+}
+
+func (s emptySigner) Sign([]byte) (types.MultiSignature, error) {
+	return types.MultiSignature{IsSr25519: true}, nil
+}
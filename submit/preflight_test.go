@@ -0,0 +1,208 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/fakes"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFeeEstimator struct {
+	fee       int64
+	estimated []types.Extrinsic
+}
+
+func (e *testFeeEstimator) QueryInfoContext(
+	_ context.Context,
+	xt types.Extrinsic,
+	_ types.Hash,
+) (*types.RuntimeDispatchInfo, error) {
+	e.estimated = append(e.estimated, xt)
+
+	return &types.RuntimeDispatchInfo{PartialFee: types.NewU128(*big.NewInt(e.fee))}, nil
+}
+
+// newTestPreflightSubmitter creates a submitter for a fake chain with the given number of blocks on top of the genesis
+// block, and Alice's account with the given nonce and free balance.
+func newTestPreflightSubmitter(t *testing.T, blocks int, nonce types.U32, free int64) (Submitter, *fakes.API) {
+	meta := statetest.PolkadotMetadata(t)
+
+	api := fakes.NewAPI()
+	api.State.SetMetadata(meta)
+	api.State.SetRuntimeVersion(types.RuntimeVersion{SpecVersion: 42, TransactionVersion: 7})
+
+	for i := 0; i < blocks; i++ {
+		_, err := api.Chain.AddBlock()
+		require.NoError(t, err)
+	}
+
+	key, err := types.CreateStorageKey(meta, "System", "Account", signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+	require.NoError(t, api.State.SetStorage(key, types.AccountInfo{
+		Nonce: nonce,
+		Data:  types.AccountData{Free: types.NewU128(*big.NewInt(free))},
+	}))
+
+	return NewSubmitter(api.State, api.System, api.Chain, api.Author, registry.NewFactory()), api
+}
+
+// newTestMortalExtrinsic creates an extrinsic of Alice with an era of the given period that was built at the block
+// with the given number.
+func newTestMortalExtrinsic(
+	t *testing.T,
+	api *fakes.API,
+	builtAt uint64,
+	period uint64,
+	nonce uint64,
+) (types.Extrinsic, types.SignatureOptions) {
+	blockHash, err := api.Chain.GetBlockHash(builtAt)
+	require.NoError(t, err)
+
+	era, _ := types.NewMortalEra(builtAt, period)
+
+	opts := types.SignatureOptions{
+		Era:                era,
+		Nonce:              types.NewUCompactFromUInt(nonce),
+		Tip:                types.NewUCompactFromUInt(100),
+		SpecVersion:        42,
+		TransactionVersion: 7,
+		GenesisHash:        testGenesisHash,
+		BlockHash:          blockHash,
+	}
+
+	xt := types.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}})
+	require.NoError(t, xt.Sign(signature.TestKeyringPairAlice, opts))
+
+	return xt, opts
+}
+
+func TestSubmitter_Preflight(t *testing.T) {
+	s, api := newTestPreflightSubmitter(t, 100, 3, 20_000_000_000)
+
+	xt, opts := newTestMortalExtrinsic(t, api, 90, 64, 3)
+	fees := &testFeeEstimator{fee: 1_000_000}
+
+	violations, err := s.Preflight(context.Background(), xt, PreflightOptions{
+		SignatureOptions: opts,
+		FeeEstimator:     fees,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+	assert.Equal(t, []types.Extrinsic{xt}, fees.estimated)
+
+	// Nonces ahead of the on-chain nonce are fine, the era is not checked without the block the extrinsic was built
+	// at.
+	xt, _ = newTestMortalExtrinsic(t, api, 1, 64, 5)
+
+	violations, err = s.Preflight(context.Background(), xt, PreflightOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestSubmitter_Preflight_Violations(t *testing.T) {
+	// The existential deposit of Polkadot is 10_000_000_000.
+	s, api := newTestPreflightSubmitter(t, 100, 3, 10_000_500_000)
+
+	xt, opts := newTestMortalExtrinsic(t, api, 1, 64, 2)
+	opts.SpecVersion = 41
+
+	violations, err := s.Preflight(context.Background(), xt, PreflightOptions{
+		SignatureOptions: opts,
+		FeeEstimator:     &testFeeEstimator{fee: 1_000_000},
+	})
+	require.NoError(t, err)
+	require.Len(t, violations, 4)
+
+	assert.Equal(t, PreflightEra, violations[0].Check)
+	assert.ErrorIs(t, violations[0].Err, ErrEraExpired)
+	assert.Contains(t, violations[0].Err.Error(), "born at block 1 expired at block 65, the latest block is 100")
+
+	assert.Equal(t, PreflightNonce, violations[1].Check)
+	assert.ErrorIs(t, violations[1].Err, ErrStaleNonce)
+
+	assert.Equal(t, PreflightBalance, violations[2].Check)
+	assert.ErrorIs(t, violations[2].Err, ErrInsufficientBalance)
+	assert.Contains(t, violations[2].Err.Error(), "fund the account with at least 500100")
+
+	assert.Equal(t, PreflightRuntimeVersion, violations[3].Check)
+	assert.ErrorIs(t, violations[3].Err, ErrRuntimeVersionMismatch)
+}
+
+func TestSubmitter_Preflight_AncientBirthBlock(t *testing.T) {
+	// Polkadot keeps the hashes of the last 4096 blocks.
+	s, api := newTestPreflightSubmitter(t, 4200, 0, 20_000_000_000)
+
+	xt, opts := newTestMortalExtrinsic(t, api, 1, 8192, 0)
+
+	violations, err := s.Preflight(context.Background(), xt, PreflightOptions{SignatureOptions: opts})
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, PreflightEra, violations[0].Check)
+	assert.ErrorIs(t, violations[0].Err, ErrAncientBirthBlock)
+}
+
+func TestSubmitter_Preflight_Unsigned(t *testing.T) {
+	s, api := newTestPreflightSubmitter(t, 10, 0, 20_000_000_000)
+
+	xt, opts := newTestMortalExtrinsic(t, api, 5, 64, 0)
+	unsigned := types.NewExtrinsic(xt.Method)
+	fees := &testFeeEstimator{fee: 1_000_000}
+
+	_, err := s.Preflight(context.Background(), unsigned, PreflightOptions{SignatureOptions: opts})
+	assert.ErrorIs(t, err, ErrPreflightAccount)
+
+	alice, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	violations, err := s.Preflight(context.Background(), unsigned, PreflightOptions{
+		SignatureOptions: opts,
+		Account:          alice,
+		FeeEstimator:     fees,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	// The fee is estimated for the extrinsic signed with an empty signature.
+	require.Len(t, fees.estimated, 1)
+	assert.True(t, fees.estimated[0].IsSigned())
+	assert.Equal(t, *alice, fees.estimated[0].Signature.Signer.AsID)
+	assert.False(t, unsigned.IsSigned())
+}
+
+func TestSubmitter_SubmitAndWait_Preflight(t *testing.T) {
+	s, api := newTestPreflightSubmitter(t, 100, 3, 20_000_000_000)
+
+	xt, opts := newTestMortalExtrinsic(t, api, 1, 64, 3)
+
+	_, err := s.SubmitAndWait(context.Background(), xt, WaitOptions{Preflight: &PreflightOptions{SignatureOptions: opts}})
+
+	var preflightErr *PreflightError
+
+	require.ErrorAs(t, err, &preflightErr)
+	assert.Len(t, preflightErr.Violations, 1)
+	assert.ErrorIs(t, err, ErrEraExpired)
+	assert.Empty(t, api.Author.Submitted())
+}
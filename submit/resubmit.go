@@ -0,0 +1,353 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"context"
+	"log/slog"
+	"math/big"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// SignedExtrinsic is a signed extrinsic together with the context it was signed in, so that it can be signed again
+// with a higher tip, see WithTip and Submission.ResubmitWithTip.
+type SignedExtrinsic struct {
+	Extrinsic types.Extrinsic
+	// BlockHash is the latest block at the time the extrinsic was built, it can be dry-run against it.
+	BlockHash types.Hash
+	// SignatureOptions are the options the extrinsic was signed with.
+	SignatureOptions types.SignatureOptions
+
+	signer types.Signer
+	meta   *types.Metadata
+}
+
+// NewSignedExtrinsic signs the call with the signer and the options, including the signed extensions declared by the
+// metadata, see types.Extrinsic.SignWithMetadata. The BlockHash of the result is the one of the options.
+func NewSignedExtrinsic(
+	call types.Call,
+	signer types.Signer,
+	meta *types.Metadata,
+	opts types.SignatureOptions,
+) (*SignedExtrinsic, error) {
+	signed := &SignedExtrinsic{
+		Extrinsic:        types.NewExtrinsic(call),
+		BlockHash:        opts.BlockHash,
+		SignatureOptions: opts,
+		signer:           signer,
+		meta:             meta,
+	}
+
+	if err := signed.sign(); err != nil {
+		return nil, err
+	}
+
+	return signed, nil
+}
+
+// WithTip returns the extrinsic signed again with the tip, keeping its call, nonce, era and all other options. The tip
+// has to be higher than the current one, since the node only replaces extrinsics in the transaction pool by ones with
+// a higher priority, otherwise ErrTipNotIncreased is returned.
+func (x *SignedExtrinsic) WithTip(tip types.UCompact) (*SignedExtrinsic, error) {
+	if x.signer == nil {
+		return nil, ErrSigningContextMissing
+	}
+
+	current := (*big.Int)(&x.SignatureOptions.Tip)
+
+	if (*big.Int)(&tip).Cmp(current) <= 0 {
+		return nil, ErrTipNotIncreased.WithMsg("tip %s is not higher than %s", (*big.Int)(&tip), current)
+	}
+
+	resigned := *x
+	resigned.SignatureOptions.Tip = tip
+
+	if err := resigned.sign(); err != nil {
+		return nil, err
+	}
+
+	return &resigned, nil
+}
+
+func (x *SignedExtrinsic) sign() error {
+	x.Extrinsic = types.NewExtrinsic(x.Extrinsic.Method)
+
+	// The signed extensions declared by the metadata are signed in their declared order, with default values.
+	if err := x.Extrinsic.SignWithMetadata(x.signer, x.meta, x.SignatureOptions, nil); err != nil {
+		return ErrExtrinsicSigning.Wrap(err)
+	}
+
+	return nil
+}
+
+// BuildSigned is like BuildMortalContext but returns the extrinsic together with the context it was signed in, so
+// that it can be resubmitted with a higher tip. The extrinsic is immortal if the period is 0.
+func (s *submitter) BuildSigned(
+	ctx context.Context,
+	call types.Call,
+	signer types.Signer,
+	period uint64,
+) (*SignedExtrinsic, error) {
+	return s.buildSigned(ctx, call, signer, period, nil)
+}
+
+// SubmitAndWatch submits the extrinsic and watches it, see Submission. The watch ends once ctx is done.
+func (s *submitter) SubmitAndWatch(ctx context.Context, xt *SignedExtrinsic) (*Submission, error) {
+	sub, err := s.authorRPC.SubmitAndWatchExtrinsicContext(ctx, xt.Extrinsic)
+	if err != nil {
+		return nil, ErrExtrinsicSubmission.Wrap(err)
+	}
+
+	submission := &Submission{
+		submitter: s,
+		included:  make(map[types.Hash]*SignedExtrinsic),
+		statuses:  make(chan types.ExtrinsicStatus),
+		errs:      make(chan error, 1),
+		quit:      make(chan struct{}),
+	}
+
+	submission.add(&attempt{xt: xt, sub: sub})
+
+	return submission, nil
+}
+
+// Submission is a submitted extrinsic that is watched until it was included in a block, also across resubmissions
+// with a higher tip via ResubmitWithTip. The attempts share their nonce, so at most one of them is included, the
+// others end as usurped or invalid. Such outcomes are only reported once no other attempt is pending, so that Wait
+// reports a single terminal outcome.
+type Submission struct {
+	submitter *submitter
+
+	mu       sync.Mutex
+	attempts []*attempt
+	pending  int
+	included map[types.Hash]*SignedExtrinsic // by the block hash of InBlock and Finalized statuses
+
+	resubmitMu sync.Mutex
+
+	statuses chan types.ExtrinsicStatus
+	errs     chan error
+	quit     chan struct{}
+	quitOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// attempt is a submission of the extrinsic with a tip.
+type attempt struct {
+	xt  *SignedExtrinsic
+	sub *author.ExtrinsicStatusSubscription
+}
+
+// Current returns the most recently submitted extrinsic.
+func (s *Submission) Current() *SignedExtrinsic {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.attempts[len(s.attempts)-1].xt
+}
+
+// ResubmitWithTip signs the most recently submitted extrinsic again with the tip, keeping its call, nonce and era, and
+// submits and watches it. The previous attempts are still watched, they are expected to end as usurped once the node
+// replaced them. The watch of the new attempt ends once ctx is done.
+func (s *Submission) ResubmitWithTip(ctx context.Context, tip types.UCompact) (*SignedExtrinsic, error) {
+	s.resubmitMu.Lock()
+	defer s.resubmitMu.Unlock()
+
+	replacement, err := s.Current().WithTip(tip)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.submitter.authorRPC.SubmitAndWatchExtrinsicContext(ctx, replacement.Extrinsic)
+	if err != nil {
+		return nil, ErrExtrinsicSubmission.Wrap(err)
+	}
+
+	attempts, ok := s.add(&attempt{xt: replacement, sub: sub})
+	if !ok {
+		sub.Unsubscribe()
+
+		return nil, ErrSubmissionEnded
+	}
+
+	s.submitter.log(
+		ctx,
+		slog.LevelInfo,
+		"Resubmitted extrinsic with a higher tip",
+		slog.Int("attempt", attempts),
+		slog.String("tip", (*big.Int)(&tip).String()),
+		slog.Uint64("nonce", (*big.Int)(&replacement.SignatureOptions.Nonce).Uint64()),
+	)
+
+	return replacement, nil
+}
+
+// Wait waits for the outcome of the submission like SubmitAndWait, and returns the result of the attempt that was
+// included in a block. WaitOptions.Preflight is ignored, since the extrinsic was submitted already. The watches of all
+// attempts end once Wait returns.
+func (s *Submission) Wait(ctx context.Context, opts WaitOptions) (*ExtrinsicResult, error) {
+	defer s.Unsubscribe()
+
+	if opts.RetractedTimeout == 0 {
+		opts.RetractedTimeout = defaultRetractedTimeout
+	}
+
+	blockHash, finalized, err := s.submitter.waitFor(ctx, s, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	xt := s.included[blockHash]
+	s.mu.Unlock()
+
+	return s.submitter.getExtrinsicResult(ctx, xt.Extrinsic, blockHash, finalized)
+}
+
+// Chan returns the statuses of all attempts. Usurped, dropped and invalid statuses are only delivered if no other
+// attempt is pending.
+func (s *Submission) Chan() <-chan types.ExtrinsicStatus {
+	return s.statuses
+}
+
+// Err returns the error channel, which receives the error of the watch of the last pending attempt.
+func (s *Submission) Err() <-chan error {
+	return s.errs
+}
+
+// Unsubscribe ends the watches of all attempts. It can safely be called more than once.
+func (s *Submission) Unsubscribe() {
+	s.quitOnce.Do(func() {
+		s.mu.Lock()
+		close(s.quit)
+		attempts := s.attempts
+		s.mu.Unlock()
+
+		for _, a := range attempts {
+			a.sub.Unsubscribe()
+		}
+
+		s.wg.Wait()
+	})
+}
+
+// add watches the attempt and returns the number of attempts, it returns false if the submission was unsubscribed
+// already.
+func (s *Submission) add(a *attempt) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.quit:
+		return 0, false
+	default:
+	}
+
+	s.attempts = append(s.attempts, a)
+	s.pending++
+
+	s.wg.Add(1)
+
+	go s.watch(a)
+
+	return len(s.attempts), true
+}
+
+// watch forwards the statuses of the attempt until it ended or the submission was unsubscribed.
+func (s *Submission) watch(a *attempt) {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case status, ok := <-a.sub.Chan():
+			if !ok {
+				s.endWatch(nil)
+
+				return
+			}
+
+			if !s.forward(a, status) {
+				return
+			}
+		case err, ok := <-a.sub.Err():
+			if !ok {
+				err = nil
+			}
+
+			s.endWatch(err)
+
+			return
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// endWatch marks an attempt whose watch ended without a terminal status as ended, e.g. because the context of its
+// watch is done. If it was the last pending attempt, err is reported, which is nil if the watch was closed.
+func (s *Submission) endWatch(err error) {
+	if !s.end() {
+		return
+	}
+
+	select {
+	case s.errs <- err:
+	default:
+	}
+}
+
+// forward delivers the status of the attempt, unless the attempt ended while other attempts are pending. It returns
+// false once the attempt ended.
+func (s *Submission) forward(a *attempt, status types.ExtrinsicStatus) bool {
+	ended := status.IsUsurped || status.IsDropped || status.IsInvalid
+
+	if ended && !s.end() {
+		return false
+	}
+
+	s.mu.Lock()
+
+	switch {
+	case status.IsInBlock:
+		s.included[status.AsInBlock] = a.xt
+	case status.IsFinalized:
+		s.included[status.AsFinalized] = a.xt
+	}
+
+	s.mu.Unlock()
+
+	select {
+	case s.statuses <- status:
+	case <-s.quit:
+		return false
+	}
+
+	return !ended
+}
+
+// end marks an attempt as ended, it returns true if it was the last pending attempt.
+func (s *Submission) end() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending--
+
+	return s.pending == 0
+}
@@ -0,0 +1,248 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSignedExtrinsic(t *testing.T) *SignedExtrinsic {
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+	era, _ := types.NewMortalEra(42, 64)
+
+	meta := statetest.PolkadotMetadata(t)
+
+	xt, err := NewSignedExtrinsic(call, types.NewKeyringPairSigner(signature.TestKeyringPairAlice), meta,
+		types.SignatureOptions{
+			BlockHash:          testBlockHash,
+			Era:                era,
+			GenesisHash:        testGenesisHash,
+			Nonce:              types.NewUCompactFromUInt(3),
+			SpecVersion:        42,
+			Tip:                types.NewUCompactFromUInt(1),
+			TransactionVersion: 7,
+		})
+	require.NoError(t, err)
+
+	return xt
+}
+
+// newTestResubmitter creates a submitter whose watches of the original extrinsic and of its replacement receive the
+// given statuses. It logs to the returned buffer.
+func newTestResubmitter(
+	t *testing.T,
+	original *SignedExtrinsic,
+	originalStatuses []interface{},
+	replacementStatuses []interface{},
+) (Submitter, testMocks, *bytes.Buffer) {
+	_, m := newTestSubmitter(t)
+
+	var buf bytes.Buffer
+
+	enc, err := codec.EncodeToHex(original.Extrinsic)
+	require.NoError(t, err)
+
+	// The replacement is only known once it was signed.
+	cl := rpcmocksrv.NewMockClient().
+		Notify("author_submitAndWatchExtrinsic", originalStatuses, enc).
+		Notify("author_submitAndWatchExtrinsic", replacementStatuses)
+
+	s := NewSubmitterWithOptions(m.state, m.system, m.chain, author.NewAuthor(cl), registry.NewFactory(), SubmitterOptions{
+		Logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+	})
+
+	return s, m, &buf
+}
+
+func TestSignedExtrinsic_WithTip(t *testing.T) {
+	original := newTestSignedExtrinsic(t)
+
+	replacement, err := original.WithTip(types.NewUCompactFromUInt(10))
+	require.NoError(t, err)
+
+	assert.Equal(t, original.Extrinsic.Method, replacement.Extrinsic.Method)
+	assert.Equal(t, original.Extrinsic.Signature.Nonce, replacement.Extrinsic.Signature.Nonce)
+	assert.Equal(t, original.Extrinsic.Signature.Era, replacement.Extrinsic.Signature.Era)
+	assert.Equal(t, types.NewUCompactFromUInt(10), replacement.Extrinsic.Signature.Tip)
+	assert.Equal(t, types.NewUCompactFromUInt(1), original.Extrinsic.Signature.Tip)
+	assert.NotEqual(t, original.Extrinsic.Signature.Signature, replacement.Extrinsic.Signature.Signature)
+
+	_, err = replacement.WithTip(types.NewUCompactFromUInt(10))
+	assert.ErrorIs(t, err, ErrTipNotIncreased)
+
+	_, err = (&SignedExtrinsic{Extrinsic: original.Extrinsic}).WithTip(types.NewUCompactFromUInt(10))
+	assert.ErrorIs(t, err, ErrSigningContextMissing)
+}
+
+func TestSubmitter_BuildSigned(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectBuild(t, 3)
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	xt, err := s.BuildSigned(context.Background(), call, testSigner, 0)
+	require.NoError(t, err)
+	assert.Equal(t, testBlockHash, xt.BlockHash)
+	assert.Equal(t, types.NewUCompactFromUInt(3), xt.SignatureOptions.Nonce)
+	assert.Equal(t, types.U32(42), xt.SignatureOptions.SpecVersion)
+
+	replacement, err := xt.WithTip(types.NewUCompactFromUInt(5))
+	require.NoError(t, err)
+	assert.Equal(t, types.NewUCompactFromUInt(3), replacement.Extrinsic.Signature.Nonce)
+	assert.Equal(t, types.NewUCompactFromUInt(5), replacement.Extrinsic.Signature.Tip)
+}
+
+func TestSubmission_ResubmitWithTip(t *testing.T) {
+	original := newTestSignedExtrinsic(t)
+
+	s, m, buf := newTestResubmitter(
+		t,
+		original,
+		[]interface{}{
+			types.ExtrinsicStatus{IsReady: true},
+			types.ExtrinsicStatus{IsUsurped: true, AsUsurped: types.Hash{1}},
+		},
+		[]interface{}{
+			types.ExtrinsicStatus{IsReady: true},
+			types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testInBlockHash},
+		},
+	)
+
+	submission, err := s.SubmitAndWatch(context.Background(), original)
+	require.NoError(t, err)
+
+	replacement, err := submission.ResubmitWithTip(context.Background(), types.NewUCompactFromUInt(10))
+	require.NoError(t, err)
+	assert.Equal(t, replacement, submission.Current())
+
+	// The replacement is looked up in the block, the usurped original is not reported.
+	m.expectExtrinsicResult(t, testInBlockHash, replacement.Extrinsic, encodeTestEvents(t, extrinsicSuccess(1)))
+
+	res, err := submission.Wait(context.Background(), WaitOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, testInBlockHash, res.BlockHash)
+	assert.Equal(t, uint32(1), res.ExtrinsicIndex)
+
+	_, err = submission.ResubmitWithTip(context.Background(), types.NewUCompactFromUInt(20))
+	assert.ErrorIs(t, err, ErrSubmissionEnded)
+	entry := decodeTestLogEntries(t, buf)["Resubmitted extrinsic with a higher tip"]
+	require.NotNil(t, entry)
+	assert.Equal(t, float64(2), entry["attempt"])
+	assert.Equal(t, "10", entry["tip"])
+	assert.Equal(t, float64(3), entry["nonce"])
+}
+
+func TestSubmission_ResubmitWithTip_OriginalIncluded(t *testing.T) {
+	original := newTestSignedExtrinsic(t)
+
+	s, m, _ := newTestResubmitter(
+		t,
+		original,
+		[]interface{}{
+			types.ExtrinsicStatus{IsReady: true},
+			types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testInBlockHash},
+		},
+		[]interface{}{
+			types.ExtrinsicStatus{IsInvalid: true},
+		},
+	)
+
+	submission, err := s.SubmitAndWatch(context.Background(), original)
+	require.NoError(t, err)
+
+	_, err = submission.ResubmitWithTip(context.Background(), types.NewUCompactFromUInt(10))
+	require.NoError(t, err)
+
+	m.expectExtrinsicResult(t, testInBlockHash, original.Extrinsic, encodeTestEvents(t, extrinsicSuccess(1)))
+
+	res, err := submission.Wait(context.Background(), WaitOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, testInBlockHash, res.BlockHash)
+}
+
+func TestSubmission_Usurped(t *testing.T) {
+	original := newTestSignedExtrinsic(t)
+
+	s, _, _ := newTestResubmitter(
+		t,
+		original,
+		[]interface{}{
+			types.ExtrinsicStatus{IsReady: true},
+			types.ExtrinsicStatus{IsUsurped: true, AsUsurped: types.Hash{1}},
+		},
+		nil,
+	)
+
+	submission, err := s.SubmitAndWatch(context.Background(), original)
+	require.NoError(t, err)
+
+	// Without a pending replacement, the usurped original is the outcome.
+	_, err = submission.Wait(context.Background(), WaitOptions{})
+	assert.ErrorIs(t, err, ErrExtrinsicUsurped)
+}
+
+func TestSubmission_ResubmitWithTip_ContextDone(t *testing.T) {
+	original := newTestSignedExtrinsic(t)
+
+	s, _, _ := newTestResubmitter(
+		t,
+		original,
+		[]interface{}{
+			types.ExtrinsicStatus{IsReady: true},
+			types.ExtrinsicStatus{IsUsurped: true, AsUsurped: types.Hash{1}},
+		},
+		nil,
+	)
+
+	submission, err := s.SubmitAndWatch(context.Background(), original)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, err = submission.ResubmitWithTip(ctx, types.NewUCompactFromUInt(10))
+	require.NoError(t, err)
+
+	// The watch of the replacement ends with its context, which leaves the original as the only pending attempt.
+	cancel()
+
+	require.Eventually(t, func() bool {
+		submission.mu.Lock()
+		defer submission.mu.Unlock()
+
+		return submission.pending == 1
+	}, 5*time.Second, time.Millisecond)
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer waitCancel()
+
+	_, err = submission.Wait(waitCtx, WaitOptions{})
+	assert.ErrorIs(t, err, ErrExtrinsicUsurped)
+}
@@ -0,0 +1,113 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// DryRunResult is the result of dry-running an extrinsic.
+type DryRunResult struct {
+	// Result is the outcome of applying the extrinsic.
+	Result types.ApplyExtrinsicResult
+	// PostDispatchInfo holds the actual weight and fee payment of the dispatch. It is only available if the runtime
+	// provides the DryRunApi, since system_dryRun does not return it.
+	PostDispatchInfo *types.PostDispatchInfo
+	// ModuleErrorName is the name of the pallet error, e.g. "Balances.InsufficientBalance", if the dispatch failed
+	// with a module error that is known to the ErrorRegistry.
+	ModuleErrorName string
+}
+
+// Err returns a *ValidityError if the extrinsic is not valid, a *DispatchError if its dispatch failed, and nil
+// otherwise.
+func (r *DryRunResult) Err() error {
+	switch {
+	case r.Result.IsError:
+		return &ValidityError{Err: r.Result.Error}
+	case r.Result.Ok.IsError:
+		return &DispatchError{Err: r.Result.Ok.Error, ModuleErrorName: r.ModuleErrorName}
+	}
+
+	return nil
+}
+
+// ValidityError is returned if the extrinsic can't be included in a block, e.g. because the signer is not able to
+// pay the fees or the nonce is outdated.
+type ValidityError struct {
+	Err types.TransactionValidityError
+}
+
+func (e *ValidityError) Error() string {
+	return fmt.Sprintf("transaction validity error: %s", e.Err)
+}
+
+// IsInvalid returns true if the extrinsic is invalid for the provided reason.
+func (e *ValidityError) IsInvalid(kind types.InvalidTransactionKind) bool {
+	return e.Err.IsInvalid && e.Err.InvalidTransaction.Kind == kind
+}
+
+// DispatchError is returned if the extrinsic is valid but its dispatch fails.
+type DispatchError struct {
+	Err types.DispatchError
+	// ModuleErrorName is the name of the pallet error, if any.
+	ModuleErrorName string
+}
+
+func (e *DispatchError) Error() string {
+	if e.ModuleErrorName != "" {
+		return fmt.Sprintf("dispatch error: %s", e.ModuleErrorName)
+	}
+
+	if e.Err.IsModule {
+		return fmt.Sprintf(
+			"dispatch error: module error %d in pallet %d",
+			e.Err.ModuleError.Error[0],
+			e.Err.ModuleError.Index,
+		)
+	}
+
+	return fmt.Sprintf("dispatch error: %s", dispatchErrorKind(e.Err))
+}
+
+func dispatchErrorKind(err types.DispatchError) string {
+	switch {
+	case err.IsOther:
+		return "Other"
+	case err.IsCannotLookup:
+		return "CannotLookup"
+	case err.IsBadOrigin:
+		return "BadOrigin"
+	case err.IsModule:
+		return "Module"
+	case err.IsConsumerRemaining:
+		return "ConsumerRemaining"
+	case err.IsNoProviders:
+		return "NoProviders"
+	case err.IsTooManyConsumers:
+		return "TooManyConsumers"
+	case err.IsToken:
+		return "Token"
+	case err.IsArithmetic:
+		return "Arithmetic"
+	case err.IsTransactional:
+		return "Transactional"
+	}
+
+	return "Unknown"
+}
@@ -0,0 +1,150 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	dryRunAPIName              = "DryRunApi"
+	taggedTransactionQueueName = "TaggedTransactionQueue"
+
+	dryRunCallMethod          = "DryRunApi_dry_run_call"
+	validateTransactionMethod = "TaggedTransactionQueue_validate_transaction"
+
+	// dryRunXCMVersion is the XCM version that is requested for the messages in the effects of the DryRunApi.
+	// These messages are not decoded, it only needs to be supported by the runtime.
+	dryRunXCMVersion = 4
+
+	// transactionSourceExternal is the TransactionSource of transactions that are received from the network or RPC.
+	transactionSourceExternal = 2
+
+	// rawOriginSigned is the index of RawOrigin::Signed in frame_system.
+	rawOriginSigned = 1
+)
+
+// encodeArgs SCALE encodes and concatenates the args of a runtime API call.
+func encodeArgs(args ...interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := scale.NewEncoder(&buf)
+
+	for _, arg := range args {
+		if err := encoder.Encode(arg); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// validateTransactionArgs returns the args of TaggedTransactionQueue_validate_transaction for the provided API
+// version.
+func validateTransactionArgs(apiVersion types.U32, xt types.Extrinsic, blockHash types.Hash) ([]byte, error) {
+	switch {
+	case apiVersion >= 3:
+		return encodeArgs(types.U8(transactionSourceExternal), xt, blockHash)
+	case apiVersion == 2:
+		return encodeArgs(types.U8(transactionSourceExternal), xt)
+	default:
+		return encodeArgs(xt)
+	}
+}
+
+// decodeTransactionValidity decodes the TransactionValidity returned by TaggedTransactionQueue_validate_transaction.
+// The ValidTransaction is not needed and therefore not decoded.
+func decodeTransactionValidity(b []byte) (*types.TransactionValidityError, error) {
+	decoder := scale.NewDecoder(bytes.NewReader(b))
+
+	isErr, err := decoder.ReadOneByte()
+	if err != nil {
+		return nil, err
+	}
+
+	if isErr == 0 {
+		return nil, nil
+	}
+
+	var validityErr types.TransactionValidityError
+
+	if err := decoder.Decode(&validityErr); err != nil {
+		return nil, err
+	}
+
+	return &validityErr, nil
+}
+
+// dryRunCallArgs returns the args of DryRunApi_dry_run_call for the provided API version. The origin is the signed
+// origin of the system pallet, which is encoded as OriginCaller::system(RawOrigin::Signed(account)).
+func dryRunCallArgs(
+	apiVersion types.U32,
+	systemPalletIndex types.U8,
+	signer types.AccountID,
+	call types.Call,
+) ([]byte, error) {
+	args := []interface{}{systemPalletIndex, types.U8(rawOriginSigned), signer, call}
+
+	if apiVersion >= 2 {
+		args = append(args, types.U32(dryRunXCMVersion))
+	}
+
+	return encodeArgs(args...)
+}
+
+// decodeDryRunCallResult decodes the execution result from the Result<CallDryRunEffects, Error> returned by
+// DryRunApi_dry_run_call. The execution result is the first field of the effects, the emitted events and XCMs that
+// follow are not decoded.
+func decodeDryRunCallResult(b []byte) (types.DispatchResultWithPostInfo, error) {
+	decoder := scale.NewDecoder(bytes.NewReader(b))
+
+	isErr, err := decoder.ReadOneByte()
+	if err != nil {
+		return types.DispatchResultWithPostInfo{}, err
+	}
+
+	if isErr != 0 {
+		apiErr, err := decoder.ReadOneByte()
+		if err != nil {
+			return types.DispatchResultWithPostInfo{}, err
+		}
+
+		return types.DispatchResultWithPostInfo{}, ErrDryRunAPI.WithMsg("%s", dryRunAPIError(apiErr))
+	}
+
+	var res types.DispatchResultWithPostInfo
+
+	if err := decoder.Decode(&res); err != nil {
+		return types.DispatchResultWithPostInfo{}, err
+	}
+
+	return res, nil
+}
+
+func dryRunAPIError(b byte) string {
+	switch b {
+	case 0:
+		return "Unimplemented"
+	case 1:
+		return "VersionedConversionFailed"
+	default:
+		return "unknown error"
+	}
+}
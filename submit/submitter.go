@@ -0,0 +1,715 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/metrics"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// submitterComponent is the component of the submitter in the recorded metrics.
+const submitterComponent = "submitter"
+
+//go:generate mockery --name Submitter --structname SubmitterMock --filename submitter_mock.go --inpackage
+
+// Submitter is the interface used for building, dry-running and submitting extrinsics.
+type Submitter interface {
+	Build(call types.Call, signer types.Signer) (types.Extrinsic, types.Hash, error)
+	BuildContext(
+		ctx context.Context,
+		call types.Call,
+		signer types.Signer,
+	) (types.Extrinsic, types.Hash, error)
+	DryRun(xt types.Extrinsic, blockHash types.Hash) (*DryRunResult, error)
+	DryRunContext(ctx context.Context, xt types.Extrinsic, blockHash types.Hash) (*DryRunResult, error)
+	Submit(call types.Call, signer types.Signer) (types.Hash, error)
+	SubmitContext(ctx context.Context, call types.Call, signer types.Signer) (types.Hash, error)
+
+	BuildMortal(call types.Call, signer types.Signer, period uint64) (types.Extrinsic, types.Hash, error)
+	BuildMortalContext(
+		ctx context.Context,
+		call types.Call,
+		signer types.Signer,
+		period uint64,
+	) (types.Extrinsic, types.Hash, error)
+	SubmitMortal(call types.Call, signer types.Signer, period uint64) (types.Hash, error)
+	SubmitMortalContext(
+		ctx context.Context,
+		call types.Call,
+		signer types.Signer,
+		period uint64,
+	) (types.Hash, error)
+
+	SubmitManaged(call types.Call, signer types.Signer, nonces *NonceManager) (types.Hash, error)
+	SubmitManagedContext(
+		ctx context.Context,
+		call types.Call,
+		signer types.Signer,
+		nonces *NonceManager,
+	) (types.Hash, error)
+
+	SubmitAndWait(ctx context.Context, xt types.Extrinsic, opts WaitOptions) (*ExtrinsicResult, error)
+
+	BuildSigned(
+		ctx context.Context,
+		call types.Call,
+		signer types.Signer,
+		period uint64,
+	) (*SignedExtrinsic, error)
+	SubmitAndWatch(ctx context.Context, xt *SignedExtrinsic) (*Submission, error)
+
+	Preflight(ctx context.Context, xt types.Extrinsic, opts PreflightOptions) ([]Violation, error)
+}
+
+// runtime holds the runtime dependent data that is needed for dry-running extrinsics.
+type runtime struct {
+	version       *types.RuntimeVersion
+	meta          *types.Metadata
+	errorRegistry registry.ErrorRegistry
+	eventRegistry registry.EventRegistry // created on demand, see getEventRegistry
+}
+
+// moduleErrorName returns the name of the pallet error if the dispatch failed with a module error that is known to
+// the error registry.
+func (rt *runtime) moduleErrorName(err types.DispatchError) string {
+	return rt.errorRegistry.ModuleErrorName(err)
+}
+
+// RuntimeCache provides the runtime version and the metadata of the latest runtime from a cache, e.g. the metadata
+// cache of gsrpc.SubstrateAPI.
+type RuntimeCache interface {
+	GetRuntimeCached() (types.RuntimeVersion, *types.Metadata, error)
+}
+
+// submitter implements the Submitter interface.
+type submitter struct {
+	stateRPC  state.Provider
+	systemRPC system.Provider
+	chainRPC  chain.Provider
+	authorRPC author.Provider
+
+	registryFactory registry.Factory
+	runtimeCache    RuntimeCache
+	logger          *slog.Logger
+	recorder        metrics.Recorder
+
+	mu      sync.Mutex
+	runtime *runtime
+}
+
+// NewSubmitter creates a new Submitter.
+func NewSubmitter(
+	stateRPC state.Provider,
+	systemRPC system.Provider,
+	chainRPC chain.Provider,
+	authorRPC author.Provider,
+	registryFactory registry.Factory,
+) Submitter {
+	return &submitter{
+		stateRPC:        stateRPC,
+		systemRPC:       systemRPC,
+		chainRPC:        chainRPC,
+		authorRPC:       authorRPC,
+		registryFactory: registryFactory,
+	}
+}
+
+// NewSubmitterWithRuntimeCache is like NewSubmitter but the metadata is taken from the runtime cache whenever it
+// belongs to the runtime at the block in question, instead of retrieving it again after runtime upgrades.
+func NewSubmitterWithRuntimeCache(
+	stateRPC state.Provider,
+	systemRPC system.Provider,
+	chainRPC chain.Provider,
+	authorRPC author.Provider,
+	registryFactory registry.Factory,
+	runtimeCache RuntimeCache,
+) Submitter {
+	return &submitter{
+		stateRPC:        stateRPC,
+		systemRPC:       systemRPC,
+		chainRPC:        chainRPC,
+		authorRPC:       authorRPC,
+		registryFactory: registryFactory,
+		runtimeCache:    runtimeCache,
+	}
+}
+
+// SubmitterOptions configure a Submitter created via NewSubmitterWithOptions.
+type SubmitterOptions struct {
+	// RuntimeCache, if set, provides the metadata of the latest runtime, see NewSubmitterWithRuntimeCache.
+	RuntimeCache RuntimeCache
+
+	// Logger, if set, logs runtime refreshes, stale nonce retries, retracted blocks and resubmissions with the keys
+	// block_hash, spec_version, attempt and nonce. Nothing is logged by default.
+	Logger *slog.Logger
+
+	// Recorder, if set, records the outcomes of the watched extrinsics and the lookups of the registries of the
+	// runtime with the component submitter, a lookup is a miss if the runtime changed since the previous one.
+	Recorder metrics.Recorder
+}
+
+// NewSubmitterWithOptions is like NewSubmitter but configured via the options.
+func NewSubmitterWithOptions(
+	stateRPC state.Provider,
+	systemRPC system.Provider,
+	chainRPC chain.Provider,
+	authorRPC author.Provider,
+	registryFactory registry.Factory,
+	opts SubmitterOptions,
+) Submitter {
+	return &submitter{
+		stateRPC:        stateRPC,
+		systemRPC:       systemRPC,
+		chainRPC:        chainRPC,
+		authorRPC:       authorRPC,
+		registryFactory: registryFactory,
+		runtimeCache:    opts.RuntimeCache,
+		logger:          opts.Logger,
+		recorder:        opts.Recorder,
+	}
+}
+
+// Build creates an immortal extrinsic for the call that is signed by the signer, using the signer's nonce at the
+// latest block. The hash of the latest block is returned as well, so that the extrinsic can be dry-run against it.
+func (s *submitter) Build(call types.Call, signer types.Signer) (types.Extrinsic, types.Hash, error) {
+	return s.BuildContext(context.Background(), call, signer)
+}
+
+// BuildContext is like Build but uses the provided context for the RPC calls.
+func (s *submitter) BuildContext(
+	ctx context.Context,
+	call types.Call,
+	signer types.Signer,
+) (types.Extrinsic, types.Hash, error) {
+	return s.build(ctx, call, signer, 0, nil)
+}
+
+// BuildMortal is like Build but creates an extrinsic that is only valid for the given number of blocks, starting at
+// the latest block. The period is rounded up to a power of two between 4 and 65536, see types.NewMortalEra.
+func (s *submitter) BuildMortal(
+	call types.Call,
+	signer types.Signer,
+	period uint64,
+) (types.Extrinsic, types.Hash, error) {
+	return s.BuildMortalContext(context.Background(), call, signer, period)
+}
+
+// BuildMortalContext is like BuildMortal but uses the provided context for the RPC calls.
+func (s *submitter) BuildMortalContext(
+	ctx context.Context,
+	call types.Call,
+	signer types.Signer,
+	period uint64,
+) (types.Extrinsic, types.Hash, error) {
+	if period == 0 {
+		return types.Extrinsic{}, types.Hash{}, ErrInvalidMortalPeriod
+	}
+
+	return s.build(ctx, call, signer, period, nil)
+}
+
+// build creates the signed extrinsic, it is immortal if the period is 0. The nonce of the signer at the latest block is
+// used if no nonce is provided.
+func (s *submitter) build(
+	ctx context.Context,
+	call types.Call,
+	signer types.Signer,
+	period uint64,
+	nonce *uint64,
+) (types.Extrinsic, types.Hash, error) {
+	signed, err := s.buildSigned(ctx, call, signer, period, nonce)
+	if err != nil {
+		return types.Extrinsic{}, types.Hash{}, err
+	}
+
+	return signed.Extrinsic, signed.BlockHash, nil
+}
+
+// buildSigned is like build but returns the extrinsic together with the context it was signed in.
+func (s *submitter) buildSigned(
+	ctx context.Context,
+	call types.Call,
+	signer types.Signer,
+	period uint64,
+	nonce *uint64,
+) (*SignedExtrinsic, error) {
+	blockHash, err := s.chainRPC.GetBlockHashLatestContext(ctx)
+	if err != nil {
+		return nil, ErrBlockHashRetrieval.Wrap(err)
+	}
+
+	genesisHash, err := s.chainRPC.GetBlockHashContext(ctx, 0)
+	if err != nil {
+		return nil, ErrGenesisHashRetrieval.Wrap(err)
+	}
+
+	era := types.ExtrinsicEra{IsImmortalEra: true}
+	eraBlockHash := genesisHash
+
+	if period > 0 {
+		era, eraBlockHash, err = s.getMortalEra(ctx, blockHash, period)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rt, err := s.getRuntime(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if nonce == nil {
+		accountNonce, err := s.getNonce(ctx, rt, signer, blockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		nonce = &accountNonce
+	}
+
+	signed := &SignedExtrinsic{
+		Extrinsic: types.NewExtrinsic(call),
+		BlockHash: blockHash,
+		SignatureOptions: types.SignatureOptions{
+			BlockHash:          eraBlockHash,
+			Era:                era,
+			GenesisHash:        genesisHash,
+			Nonce:              types.NewUCompactFromUInt(*nonce),
+			SpecVersion:        rt.version.SpecVersion,
+			Tip:                types.NewUCompactFromUInt(0),
+			TransactionVersion: rt.version.TransactionVersion,
+		},
+		signer: signer,
+		meta:   rt.meta,
+	}
+
+	if err := signed.sign(); err != nil {
+		return nil, err
+	}
+
+	return signed, nil
+}
+
+// getNonce returns the nonce of the signer at the given block.
+func (s *submitter) getNonce(
+	ctx context.Context,
+	rt *runtime,
+	signer types.Signer,
+	blockHash types.Hash,
+) (uint64, error) {
+	accountID := signer.AccountID()
+
+	key, err := types.CreateStorageKey(rt.meta, "System", "Account", accountID[:])
+	if err != nil {
+		return 0, ErrStorageKeyCreation.Wrap(err)
+	}
+
+	// The nonce of accounts that don't exist yet is 0.
+	var accountInfo types.AccountInfo
+
+	if _, err := s.stateRPC.GetStorageContext(ctx, key, &accountInfo, blockHash); err != nil {
+		return 0, ErrAccountInfoRetrieval.Wrap(err)
+	}
+
+	return uint64(accountInfo.Nonce), nil
+}
+
+// getMortalEra returns the mortal era for the given period starting at the given block, and the hash of the birth
+// block of the era, which has to be signed by the CheckMortality signed extension.
+func (s *submitter) getMortalEra(
+	ctx context.Context,
+	blockHash types.Hash,
+	period uint64,
+) (types.ExtrinsicEra, types.Hash, error) {
+	header, err := s.chainRPC.GetHeaderContext(ctx, blockHash)
+	if err != nil {
+		return types.ExtrinsicEra{}, types.Hash{}, ErrHeaderRetrieval.Wrap(err)
+	}
+
+	blockNumber := uint64(header.Number)
+
+	era, birth := types.NewMortalEra(blockNumber, period)
+
+	// The birth block is before the given block if the phase of the era was quantized.
+	if birth == blockNumber {
+		return era, blockHash, nil
+	}
+
+	birthHash, err := s.chainRPC.GetBlockHashContext(ctx, birth)
+	if err != nil {
+		return types.ExtrinsicEra{}, types.Hash{}, ErrBlockHashRetrieval.Wrap(err)
+	}
+
+	return era, birthHash, nil
+}
+
+// DryRun applies the extrinsic on top of the state of the given block without importing it.
+//
+// If the runtime provides the DryRunApi and the extrinsic is signed by an account ID, the validity of the extrinsic
+// is checked via the TaggedTransactionQueue runtime API and the call is dispatched via the DryRunApi, which also
+// returns the post dispatch info. Otherwise, the extrinsic is dry-run via system_dryRun.
+//
+// An error is only returned if the dry run itself failed, the outcome of the extrinsic is returned via
+// DryRunResult.Err.
+func (s *submitter) DryRun(xt types.Extrinsic, blockHash types.Hash) (*DryRunResult, error) {
+	return s.DryRunContext(context.Background(), xt, blockHash)
+}
+
+// DryRunContext is like DryRun but uses the provided context for the RPC calls.
+func (s *submitter) DryRunContext(
+	ctx context.Context,
+	xt types.Extrinsic,
+	blockHash types.Hash,
+) (*DryRunResult, error) {
+	rt, err := s.getRuntime(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *DryRunResult
+
+	dryRunAPIVersion, ok := rt.version.APIVersion(dryRunAPIName)
+
+	if ok && xt.IsSigned() && xt.Signature.Signer.IsID {
+		res, err = s.dryRunCall(ctx, rt, dryRunAPIVersion, xt, blockHash)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		applyRes, err := s.systemRPC.DryRunContext(ctx, xt, blockHash)
+		if err != nil {
+			return nil, ErrSystemDryRun.Wrap(err)
+		}
+
+		res = &DryRunResult{Result: applyRes}
+	}
+
+	if res.Result.IsOk && res.Result.Ok.IsError {
+		res.ModuleErrorName = rt.moduleErrorName(res.Result.Ok.Error)
+	}
+
+	return res, nil
+}
+
+func (s *submitter) dryRunCall(
+	ctx context.Context,
+	rt *runtime,
+	dryRunAPIVersion types.U32,
+	xt types.Extrinsic,
+	blockHash types.Hash,
+) (*DryRunResult, error) {
+	// The DryRunApi only dispatches the call, the validity of the extrinsic is checked separately.
+	if txQueueVersion, ok := rt.version.APIVersion(taggedTransactionQueueName); ok {
+		args, err := validateTransactionArgs(txQueueVersion, xt, blockHash)
+		if err != nil {
+			return nil, ErrExtrinsicEncoding.Wrap(err)
+		}
+
+		b, err := s.stateRPC.CallContext(ctx, validateTransactionMethod, args, blockHash)
+		if err != nil {
+			return nil, ErrRuntimeAPICall.WithMsg(validateTransactionMethod).Wrap(err)
+		}
+
+		validityErr, err := decodeTransactionValidity(b)
+		if err != nil {
+			return nil, ErrRuntimeAPIResultDecoding.WithMsg(validateTransactionMethod).Wrap(err)
+		}
+
+		if validityErr != nil {
+			return &DryRunResult{
+				Result: types.ApplyExtrinsicResult{IsError: true, Error: *validityErr},
+			}, nil
+		}
+	}
+
+	systemPalletIndex, err := getSystemPalletIndex(rt.meta)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := dryRunCallArgs(dryRunAPIVersion, systemPalletIndex, xt.Signature.Signer.AsID, xt.Method)
+	if err != nil {
+		return nil, ErrExtrinsicEncoding.Wrap(err)
+	}
+
+	b, err := s.stateRPC.CallContext(ctx, dryRunCallMethod, args, blockHash)
+	if err != nil {
+		return nil, ErrRuntimeAPICall.WithMsg(dryRunCallMethod).Wrap(err)
+	}
+
+	dispatchRes, err := decodeDryRunCallResult(b)
+	if err != nil {
+		return nil, ErrRuntimeAPIResultDecoding.WithMsg(dryRunCallMethod).Wrap(err)
+	}
+
+	if dispatchRes.IsError {
+		return &DryRunResult{
+			Result: types.ApplyExtrinsicResult{
+				IsOk: true,
+				Ok:   types.DispatchOutcome{IsError: true, Error: dispatchRes.Error.Error},
+			},
+			PostDispatchInfo: &dispatchRes.Error.PostInfo,
+		}, nil
+	}
+
+	return &DryRunResult{
+		Result: types.ApplyExtrinsicResult{
+			IsOk: true,
+			Ok:   types.DispatchOutcome{IsOk: true},
+		},
+		PostDispatchInfo: &dispatchRes.Ok,
+	}, nil
+}
+
+// Submit builds the extrinsic, dry-runs it and only submits it if the dry run succeeded. If the extrinsic is not
+// valid or its dispatch would fail, the *ValidityError or *DispatchError of the dry run is returned.
+func (s *submitter) Submit(call types.Call, signer types.Signer) (types.Hash, error) {
+	return s.SubmitContext(context.Background(), call, signer)
+}
+
+// SubmitContext is like Submit but uses the provided context for the RPC calls.
+func (s *submitter) SubmitContext(
+	ctx context.Context,
+	call types.Call,
+	signer types.Signer,
+) (types.Hash, error) {
+	xt, blockHash, err := s.BuildContext(ctx, call, signer)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return s.dryRunAndSubmit(ctx, xt, blockHash)
+}
+
+// dryRunAndSubmit dry-runs the extrinsic at the given block and submits it if the dry run succeeded.
+func (s *submitter) dryRunAndSubmit(ctx context.Context, xt types.Extrinsic, blockHash types.Hash) (types.Hash, error) {
+	res, err := s.DryRunContext(ctx, xt, blockHash)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	if err := res.Err(); err != nil {
+		return types.Hash{}, err
+	}
+
+	hash, err := s.authorRPC.SubmitExtrinsicContext(ctx, xt)
+	if err != nil {
+		return types.Hash{}, ErrExtrinsicSubmission.Wrap(err)
+	}
+
+	return hash, nil
+}
+
+// SubmitMortal is like Submit but submits an extrinsic that is only valid for the given number of blocks, see
+// BuildMortal.
+func (s *submitter) SubmitMortal(call types.Call, signer types.Signer, period uint64) (types.Hash, error) {
+	return s.SubmitMortalContext(context.Background(), call, signer, period)
+}
+
+// SubmitMortalContext is like SubmitMortal but uses the provided context for the RPC calls.
+func (s *submitter) SubmitMortalContext(
+	ctx context.Context,
+	call types.Call,
+	signer types.Signer,
+	period uint64,
+) (types.Hash, error) {
+	xt, blockHash, err := s.BuildMortalContext(ctx, call, signer, period)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return s.dryRunAndSubmit(ctx, xt, blockHash)
+}
+
+// SubmitManaged is like Submit but takes the nonce from the NonceManager of the signer, so that many extrinsics of
+// the signer can be submitted in quick succession, also concurrently. If the node reports that the nonce is stale,
+// the NonceManager is resynced and the extrinsic is built again with a new nonce, up to maxStaleNonceRetries times.
+// The nonce is released if the extrinsic was not submitted for any other reason.
+func (s *submitter) SubmitManaged(
+	call types.Call,
+	signer types.Signer,
+	nonces *NonceManager,
+) (types.Hash, error) {
+	return s.SubmitManagedContext(context.Background(), call, signer, nonces)
+}
+
+// SubmitManagedContext is like SubmitManaged but uses the provided context for the RPC calls.
+func (s *submitter) SubmitManagedContext(
+	ctx context.Context,
+	call types.Call,
+	signer types.Signer,
+	nonces *NonceManager,
+) (types.Hash, error) {
+	if nonces.AccountID() != signer.AccountID() {
+		return types.Hash{}, ErrNonceAccountMismatch
+	}
+
+	for attempt := 0; ; attempt++ {
+		nonce, err := nonces.Next(ctx)
+		if err != nil {
+			return types.Hash{}, err
+		}
+
+		hash, stale, err := s.submitWithNonce(ctx, call, signer, nonce)
+		if err == nil {
+			return hash, nil
+		}
+
+		if !stale || attempt == maxStaleNonceRetries {
+			nonces.Release(nonce)
+			return types.Hash{}, err
+		}
+
+		s.log(
+			ctx,
+			slog.LevelWarn,
+			"Stale nonce, resyncing the nonces",
+			slog.Int("attempt", attempt+1),
+			slog.Uint64("nonce", nonce),
+		)
+
+		if err := nonces.Resync(ctx); err != nil {
+			return types.Hash{}, err
+		}
+	}
+}
+
+// submitWithNonce builds the extrinsic with the given nonce, dry-runs and submits it. It also returns whether the
+// extrinsic was rejected because the nonce is stale.
+func (s *submitter) submitWithNonce(
+	ctx context.Context,
+	call types.Call,
+	signer types.Signer,
+	nonce uint64,
+) (types.Hash, bool, error) {
+	xt, blockHash, err := s.build(ctx, call, signer, 0, &nonce)
+	if err != nil {
+		return types.Hash{}, false, err
+	}
+
+	res, err := s.DryRunContext(ctx, xt, blockHash)
+	if err != nil {
+		return types.Hash{}, false, err
+	}
+
+	// The nonce is ahead of the nonce at the block if extrinsics of the signer are still in the transaction pool, which
+	// system_dryRun reports as a future transaction.
+	if err := res.Err(); err != nil && !isFutureNonce(err) {
+		return types.Hash{}, isStaleNonce(err), err
+	}
+
+	hash, err := s.authorRPC.SubmitExtrinsicContext(ctx, xt)
+	if err != nil {
+		return types.Hash{}, isStaleNonce(err), ErrExtrinsicSubmission.Wrap(err)
+	}
+
+	return hash, false, nil
+}
+
+// getRuntime returns the runtime at the given block. The metadata and the error registry are only retrieved again
+// if the spec version changed, and the metadata is taken from the runtime cache if it holds the same spec version.
+func (s *submitter) getRuntime(ctx context.Context, blockHash types.Hash) (*runtime, error) {
+	version, err := s.stateRPC.GetRuntimeVersionContext(ctx, blockHash)
+	if err != nil {
+		return nil, ErrRuntimeVersionRetrieval.Wrap(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hit := s.runtime != nil && s.runtime.version.SpecVersion == version.SpecVersion
+
+	if s.recorder != nil {
+		s.recorder.RegistryLookup(submitterComponent, hit)
+	}
+
+	if hit {
+		return &runtime{version: version, meta: s.runtime.meta, errorRegistry: s.runtime.errorRegistry}, nil
+	}
+
+	meta, err := s.getMetadata(ctx, blockHash, version.SpecVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	errorRegistry, err := s.registryFactory.CreateErrorRegistry(meta)
+	if err != nil {
+		return nil, ErrErrorRegistryCreation.Wrap(err)
+	}
+
+	attrs := []slog.Attr{
+		slog.String("block_hash", blockHash.Hex()),
+		slog.Uint64("spec_version", uint64(version.SpecVersion)),
+	}
+
+	if s.runtime != nil {
+		attrs = append(attrs, slog.Uint64("old_spec_version", uint64(s.runtime.version.SpecVersion)))
+	}
+
+	s.log(ctx, slog.LevelInfo, "Runtime refreshed", attrs...)
+
+	s.runtime = &runtime{version: version, meta: meta, errorRegistry: errorRegistry}
+
+	return s.runtime, nil
+}
+
+func (s *submitter) getMetadata(
+	ctx context.Context,
+	blockHash types.Hash,
+	specVersion types.U32,
+) (*types.Metadata, error) {
+	if s.runtimeCache != nil {
+		// The cache being disabled or stale is not an error, the metadata is retrieved instead.
+		cachedVersion, meta, err := s.runtimeCache.GetRuntimeCached()
+		if err == nil && cachedVersion.SpecVersion == specVersion {
+			return meta, nil
+		}
+	}
+
+	meta, err := s.stateRPC.GetMetadataContext(ctx, blockHash)
+	if err != nil {
+		return nil, ErrMetadataRetrieval.Wrap(err)
+	}
+
+	return meta, nil
+}
+
+// log logs the record via the logger of the options, if it is set.
+func (s *submitter) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if s.logger != nil {
+		s.logger.LogAttrs(ctx, level, msg, attrs...)
+	}
+}
+
+func getSystemPalletIndex(meta *types.Metadata) (types.U8, error) {
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.Name == "System" {
+			return pallet.Index, nil
+		}
+	}
+
+	return 0, ErrSystemPalletNotFound
+}
@@ -0,0 +1,670 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/fakes"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	authorMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author/mocks"
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	systemMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testMocks struct {
+	state           *stateMocks.State
+	system          *systemMocks.System
+	chain           *chainMocks.Chain
+	author          *authorMocks.Author
+	registryFactory *registry.FactoryMock
+}
+
+func newTestSubmitter(t *testing.T) (Submitter, testMocks) {
+	m := testMocks{
+		state:           stateMocks.NewState(t),
+		system:          systemMocks.NewSystem(t),
+		chain:           chainMocks.NewChain(t),
+		author:          authorMocks.NewAuthor(t),
+		registryFactory: registry.NewFactoryMock(t),
+	}
+
+	return NewSubmitter(m.state, m.system, m.chain, m.author, m.registryFactory), m
+}
+
+// newTestLogSubmitter creates a submitter like newTestSubmitter that logs to the returned buffer.
+func newTestLogSubmitter(t *testing.T) (Submitter, testMocks, *bytes.Buffer) {
+	_, m := newTestSubmitter(t)
+
+	var buf bytes.Buffer
+
+	s := NewSubmitterWithOptions(m.state, m.system, m.chain, m.author, m.registryFactory, SubmitterOptions{
+		Logger: slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+
+	return s, m, &buf
+}
+
+// decodeTestLogEntries decodes the JSON log entries in the buffer by their message.
+func decodeTestLogEntries(t *testing.T, buf *bytes.Buffer) map[string]map[string]interface{} {
+	entries := make(map[string]map[string]interface{})
+
+	for dec := json.NewDecoder(buf); dec.More(); {
+		var entry map[string]interface{}
+		require.NoError(t, dec.Decode(&entry))
+
+		entries[entry["msg"].(string)] = entry
+	}
+
+	return entries
+}
+
+func getTestAPIID(t *testing.T, name string) string {
+	id, err := types.NewRuntimeAPIID(name)
+	require.NoError(t, err)
+
+	return id
+}
+
+var (
+	testBlockHash   = types.Hash{1, 2, 3}
+	testGenesisHash = types.Hash{4, 5, 6}
+
+	testSigner = types.NewKeyringPairSigner(signature.TestKeyringPairAlice)
+
+	testErrorRegistry = registry.ErrorRegistry{
+		registry.ErrorID{ModuleIndex: 5, ErrorIndex: [4]types.U8{2}}: {Name: "Balances.InsufficientBalance"},
+	}
+
+	testModuleError = types.DispatchError{
+		IsModule:    true,
+		ModuleError: types.ModuleError{Index: 5, Error: [4]types.U8{2}},
+	}
+)
+
+func (m testMocks) expectRuntime(t *testing.T, apis ...types.RuntimeVersionAPI) *types.Metadata {
+	meta := statetest.PolkadotMetadata(t)
+
+	m.state.On("GetRuntimeVersionContext", mock.Anything, testBlockHash).
+		Return(&types.RuntimeVersion{APIs: apis, SpecVersion: 42, TransactionVersion: 7}, nil)
+
+	m.state.On("GetMetadataContext", mock.Anything, testBlockHash).
+		Return(meta, nil).
+		Once()
+
+	m.registryFactory.On("CreateErrorRegistry", meta).
+		Return(testErrorRegistry, nil).
+		Once()
+
+	return meta
+}
+
+func newTestExtrinsic(t *testing.T) types.Extrinsic {
+	xt := types.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}})
+
+	err := xt.Sign(signature.TestKeyringPairAlice, types.SignatureOptions{
+		BlockHash:   testGenesisHash,
+		GenesisHash: testGenesisHash,
+		Nonce:       types.NewUCompactFromUInt(1),
+		Tip:         types.NewUCompactFromUInt(0),
+	})
+	require.NoError(t, err)
+
+	return xt
+}
+
+func TestSubmitter_DryRun_SystemDryRun(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectRuntime(t)
+
+	xt := newTestExtrinsic(t)
+
+	m.system.On("DryRunContext", mock.Anything, xt, testBlockHash).
+		Return(types.ApplyExtrinsicResult{
+			IsOk: true,
+			Ok:   types.DispatchOutcome{IsError: true, Error: testModuleError},
+		}, nil).
+		Once()
+
+	res, err := s.DryRun(xt, testBlockHash)
+	assert.NoError(t, err)
+	assert.Nil(t, res.PostDispatchInfo)
+	assert.Equal(t, "Balances.InsufficientBalance", res.ModuleErrorName)
+
+	var dispatchErr *DispatchError
+	assert.True(t, errors.As(res.Err(), &dispatchErr))
+	assert.Equal(t, "dispatch error: Balances.InsufficientBalance", dispatchErr.Error())
+
+	// The metadata and error registry are cached for the same spec version.
+	m.system.On("DryRunContext", mock.Anything, xt, testBlockHash).
+		Return(types.ApplyExtrinsicResult{IsOk: true, Ok: types.DispatchOutcome{IsOk: true}}, nil).
+		Once()
+
+	res, err = s.DryRun(xt, testBlockHash)
+	assert.NoError(t, err)
+	assert.NoError(t, res.Err())
+}
+
+func TestSubmitter_DryRun_SystemDryRunError(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectRuntime(t)
+
+	xt := newTestExtrinsic(t)
+
+	m.system.On("DryRunContext", mock.Anything, xt, testBlockHash).
+		Return(types.ApplyExtrinsicResult{}, errors.New("method not found")).
+		Once()
+
+	res, err := s.DryRun(xt, testBlockHash)
+	assert.ErrorIs(t, err, ErrSystemDryRun)
+	assert.Nil(t, res)
+}
+
+func TestSubmitter_DryRun_DryRunAPI(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectRuntime(
+		t,
+		types.RuntimeVersionAPI{APIID: getTestAPIID(t, dryRunAPIName), Version: 2},
+		types.RuntimeVersionAPI{APIID: getTestAPIID(t, taggedTransactionQueueName), Version: 3},
+	)
+
+	xt := newTestExtrinsic(t)
+
+	validateArgs, err := encodeArgs(types.U8(transactionSourceExternal), xt, testBlockHash)
+	require.NoError(t, err)
+
+	m.state.On("CallContext", mock.Anything, validateTransactionMethod, validateArgs, testBlockHash).
+		Return(types.Bytes{0, 1, 2, 3}, nil).
+		Once()
+
+	signer, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	dryRunArgs, err := encodeArgs(
+		types.U8(0),
+		types.U8(rawOriginSigned),
+		*signer,
+		xt.Method,
+		types.U32(dryRunXCMVersion),
+	)
+	require.NoError(t, err)
+
+	postInfo := types.PostDispatchInfo{
+		ActualWeight: types.NewOption(types.NewWeight(types.NewUCompactFromUInt(1), types.NewUCompactFromUInt(2))),
+		PaysFee:      types.Pays{IsYes: true},
+	}
+
+	dryRunRes, err := codec.Encode(types.DispatchResultWithPostInfo{IsOk: true, Ok: postInfo})
+	require.NoError(t, err)
+
+	// Ok variant, followed by the execution result and the not decoded events and XCMs.
+	m.state.On("CallContext", mock.Anything, dryRunCallMethod, dryRunArgs, testBlockHash).
+		Return(types.Bytes(append(append([]byte{0}, dryRunRes...), 0, 0, 0)), nil).
+		Once()
+
+	res, err := s.DryRun(xt, testBlockHash)
+	assert.NoError(t, err)
+	assert.NoError(t, res.Err())
+	assert.Equal(t, &postInfo, res.PostDispatchInfo)
+}
+
+func TestSubmitter_DryRun_DryRunAPIDispatchError(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectRuntime(t, types.RuntimeVersionAPI{APIID: getTestAPIID(t, dryRunAPIName), Version: 1})
+
+	xt := newTestExtrinsic(t)
+
+	dryRunRes, err := codec.Encode(types.DispatchResultWithPostInfo{
+		IsError: true,
+		Error: types.DispatchErrorWithPostInfo{
+			PostInfo: types.PostDispatchInfo{PaysFee: types.Pays{IsNo: true}},
+			Error:    testModuleError,
+		},
+	})
+	require.NoError(t, err)
+
+	m.state.On("CallContext", mock.Anything, dryRunCallMethod, mock.Anything, testBlockHash).
+		Return(types.Bytes(append([]byte{0}, dryRunRes...)), nil).
+		Once()
+
+	res, err := s.DryRun(xt, testBlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.PostDispatchInfo{PaysFee: types.Pays{IsNo: true}}, res.PostDispatchInfo)
+
+	var dispatchErr *DispatchError
+	assert.True(t, errors.As(res.Err(), &dispatchErr))
+	assert.Equal(t, "Balances.InsufficientBalance", dispatchErr.ModuleErrorName)
+}
+
+func TestSubmitter_DryRun_DryRunAPIValidityError(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectRuntime(
+		t,
+		types.RuntimeVersionAPI{APIID: getTestAPIID(t, dryRunAPIName), Version: 1},
+		types.RuntimeVersionAPI{APIID: getTestAPIID(t, taggedTransactionQueueName), Version: 3},
+	)
+
+	xt := newTestExtrinsic(t)
+
+	m.state.On("CallContext", mock.Anything, validateTransactionMethod, mock.Anything, testBlockHash).
+		Return(types.Bytes{1, 0, byte(types.InvalidTransactionStale)}, nil).
+		Once()
+
+	res, err := s.DryRun(xt, testBlockHash)
+	assert.NoError(t, err)
+
+	var validityErr *ValidityError
+	assert.True(t, errors.As(res.Err(), &validityErr))
+	assert.True(t, validityErr.IsInvalid(types.InvalidTransactionStale))
+	assert.False(t, validityErr.IsInvalid(types.InvalidTransactionPayment))
+	assert.Equal(t, "transaction validity error: Invalid(Stale)", validityErr.Error())
+}
+
+func TestSubmitter_DryRun_DryRunAPIError(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectRuntime(t, types.RuntimeVersionAPI{APIID: getTestAPIID(t, dryRunAPIName), Version: 1})
+
+	xt := newTestExtrinsic(t)
+
+	m.state.On("CallContext", mock.Anything, dryRunCallMethod, mock.Anything, testBlockHash).
+		Return(types.Bytes{1, 0}, nil).
+		Once()
+
+	res, err := s.DryRun(xt, testBlockHash)
+	assert.ErrorIs(t, err, ErrRuntimeAPIResultDecoding)
+	assert.ErrorIs(t, err, ErrDryRunAPI)
+	assert.Nil(t, res)
+}
+
+func TestSubmitter_DryRun_RuntimeVersionError(t *testing.T) {
+	s, m := newTestSubmitter(t)
+
+	m.state.On("GetRuntimeVersionContext", mock.Anything, testBlockHash).
+		Return(nil, errors.New("boom")).
+		Once()
+
+	res, err := s.DryRun(newTestExtrinsic(t), testBlockHash)
+	assert.ErrorIs(t, err, ErrRuntimeVersionRetrieval)
+	assert.Nil(t, res)
+}
+
+func (m testMocks) expectBuild(t *testing.T, nonce types.U32) *types.Metadata {
+	meta := m.expectRuntime(t)
+
+	m.chain.On("GetBlockHashLatestContext", mock.Anything).
+		Return(testBlockHash, nil).
+		Once()
+	m.chain.On("GetBlockHashContext", mock.Anything, uint64(0)).
+		Return(testGenesisHash, nil).
+		Once()
+
+	key, err := types.CreateStorageKey(meta, "System", "Account", signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	m.state.On("GetStorageContext", mock.Anything, key, mock.Anything, testBlockHash).
+		Run(func(args mock.Arguments) {
+			args.Get(2).(*types.AccountInfo).Nonce = nonce
+		}).
+		Return(true, nil).
+		Once()
+
+	return meta
+}
+
+func TestSubmitter_Build(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectBuild(t, 3)
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	xt, blockHash, err := s.Build(call, testSigner)
+	assert.NoError(t, err)
+	assert.Equal(t, testBlockHash, blockHash)
+	assert.True(t, xt.IsSigned())
+	assert.Equal(t, call, xt.Method)
+	assert.Equal(t, types.NewUCompactFromUInt(3), xt.Signature.Nonce)
+	assert.True(t, xt.Signature.Era.IsImmortalEra)
+	assert.True(t, xt.Signature.MetadataHashMode.IsNone())
+}
+
+func TestSubmitter_Build_CheckMetadataHash(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	meta := m.expectBuild(t, 0)
+
+	meta.AsMetadataV14.Extrinsic.SignedExtensions = append(
+		meta.AsMetadataV14.Extrinsic.SignedExtensions,
+		types.SignedExtensionMetadataV14{Identifier: types.CheckMetadataHashExtension},
+	)
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	xt, _, err := s.Build(call, testSigner)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewOption(types.MetadataHashDisabled), xt.Signature.MetadataHashMode)
+}
+
+func TestSubmitter_Build_UnknownSignedExtension(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	meta := m.expectBuild(t, 0)
+
+	// The CheckNonce type is not empty, so the unknown signed extension needs a value.
+	for _, signedExtension := range meta.AsMetadataV14.Extrinsic.SignedExtensions {
+		if signedExtension.Identifier == types.CheckNonceExtension {
+			signedExtension.Identifier = "CheckAppId"
+
+			meta.AsMetadataV14.Extrinsic.SignedExtensions = append(
+				meta.AsMetadataV14.Extrinsic.SignedExtensions,
+				signedExtension,
+			)
+
+			break
+		}
+	}
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	_, _, err := s.Build(call, testSigner)
+	assert.ErrorIs(t, err, ErrExtrinsicSigning)
+	assert.ErrorIs(t, err, types.ErrSignedExtensionValueMissing)
+}
+
+func TestSubmitter_BuildMortal(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectBuild(t, 3)
+
+	m.chain.On("GetHeaderContext", mock.Anything, testBlockHash).
+		Return(&types.Header{Number: 42}, nil).
+		Once()
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	xt, blockHash, err := s.BuildMortal(call, testSigner, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, testBlockHash, blockHash)
+	assert.Equal(t, types.ExtrinsicEra{
+		IsMortalEra: true,
+		AsMortalEra: types.MortalEra{First: 0xa5, Second: 0x02},
+	}, xt.Signature.Era)
+	assert.Equal(t, types.NewUCompactFromUInt(3), xt.Signature.Nonce)
+}
+
+func TestSubmitter_BuildMortal_QuantizedBirth(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectBuild(t, 0)
+
+	birthHash := types.Hash{7}
+
+	m.chain.On("GetHeaderContext", mock.Anything, testBlockHash).
+		Return(&types.Header{Number: 1_000_007}, nil).
+		Once()
+	// The phase of long periods is quantized, so the era starts before the latest block.
+	m.chain.On("GetBlockHashContext", mock.Anything, uint64(1_000_000)).
+		Return(birthHash, nil).
+		Once()
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	xt, _, err := s.BuildMortalContext(context.Background(), call, testSigner, 65536)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1_000_000), xt.Signature.Era.Birth(1_000_007))
+
+	// The signature has to cover the hash of the birth block.
+	mb, err := codec.Encode(call)
+	require.NoError(t, err)
+
+	payload, err := codec.Encode(types.ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: types.ExtrinsicPayloadV3{
+			Method:      mb,
+			Era:         xt.Signature.Era,
+			Nonce:       types.NewUCompactFromUInt(0),
+			Tip:         types.NewUCompactFromUInt(0),
+			SpecVersion: 42,
+			GenesisHash: testGenesisHash,
+			BlockHash:   birthHash,
+		},
+		TransactionVersion: 7,
+	})
+	require.NoError(t, err)
+
+	ok, err := signature.Verify(payload, xt.Signature.Signature.AsSr25519[:], signature.TestKeyringPairAlice.URI)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestSubmitter_BuildMortal_Errors(t *testing.T) {
+	s, m := newTestSubmitter(t)
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	_, _, err := s.BuildMortal(call, testSigner, 0)
+	assert.ErrorIs(t, err, ErrInvalidMortalPeriod)
+
+	m.chain.On("GetBlockHashLatestContext", mock.Anything).
+		Return(testBlockHash, nil).
+		Once()
+	m.chain.On("GetBlockHashContext", mock.Anything, uint64(0)).
+		Return(testGenesisHash, nil).
+		Once()
+	m.chain.On("GetHeaderContext", mock.Anything, testBlockHash).
+		Return(nil, errors.New("boom")).
+		Once()
+
+	_, _, err = s.BuildMortal(call, testSigner, 64)
+	assert.ErrorIs(t, err, ErrHeaderRetrieval)
+}
+
+func TestSubmitter_Submit(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectBuild(t, 0)
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+	txHash := types.Hash{7, 8, 9}
+
+	m.system.On("DryRunContext", mock.Anything, mock.Anything, testBlockHash).
+		Return(types.ApplyExtrinsicResult{IsOk: true, Ok: types.DispatchOutcome{IsOk: true}}, nil).
+		Once()
+	m.author.On("SubmitExtrinsicContext", mock.Anything, mock.Anything).
+		Return(txHash, nil).
+		Once()
+
+	res, err := s.SubmitContext(context.Background(), call, testSigner)
+	assert.NoError(t, err)
+	assert.Equal(t, txHash, res)
+}
+
+func TestSubmitter_Submit_DryRunFailure(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectBuild(t, 0)
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	m.system.On("DryRunContext", mock.Anything, mock.Anything, testBlockHash).
+		Return(types.ApplyExtrinsicResult{
+			IsError: true,
+			Error: types.TransactionValidityError{
+				IsInvalid:          true,
+				InvalidTransaction: types.InvalidTransaction{Kind: types.InvalidTransactionPayment},
+			},
+		}, nil).
+		Once()
+
+	res, err := s.Submit(call, testSigner)
+	assert.Equal(t, types.Hash{}, res)
+
+	var validityErr *ValidityError
+	assert.True(t, errors.As(err, &validityErr))
+	assert.True(t, validityErr.IsInvalid(types.InvalidTransactionPayment))
+}
+
+func TestSubmitter_SubmitMortal(t *testing.T) {
+	s, m := newTestSubmitter(t)
+	m.expectBuild(t, 0)
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+	txHash := types.Hash{7, 8, 9}
+
+	m.chain.On("GetHeaderContext", mock.Anything, testBlockHash).
+		Return(&types.Header{Number: 42}, nil).
+		Once()
+	m.system.On("DryRunContext", mock.Anything, mock.MatchedBy(func(xt types.Extrinsic) bool {
+		return xt.Signature.Era.IsMortalEra
+	}), testBlockHash).
+		Return(types.ApplyExtrinsicResult{IsOk: true, Ok: types.DispatchOutcome{IsOk: true}}, nil).
+		Once()
+	m.author.On("SubmitExtrinsicContext", mock.Anything, mock.Anything).
+		Return(txHash, nil).
+		Once()
+
+	res, err := s.SubmitMortal(call, testSigner, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, txHash, res)
+}
+
+type testRuntimeCache struct {
+	version types.RuntimeVersion
+	meta    *types.Metadata
+	err     error
+}
+
+func (c testRuntimeCache) GetRuntimeCached() (types.RuntimeVersion, *types.Metadata, error) {
+	return c.version, c.meta, c.err
+}
+
+func TestSubmitter_DryRun_RuntimeCache(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+
+	_, m := newTestSubmitter(t)
+	s := NewSubmitterWithRuntimeCache(m.state, m.system, m.chain, m.author, m.registryFactory, testRuntimeCache{
+		version: types.RuntimeVersion{SpecVersion: 42},
+		meta:    meta,
+	})
+
+	// The metadata is taken from the cache since it holds the same spec version.
+	m.state.On("GetRuntimeVersionContext", mock.Anything, testBlockHash).
+		Return(&types.RuntimeVersion{SpecVersion: 42}, nil).
+		Once()
+	m.registryFactory.On("CreateErrorRegistry", meta).
+		Return(testErrorRegistry, nil).
+		Once()
+
+	xt := newTestExtrinsic(t)
+
+	m.system.On("DryRunContext", mock.Anything, xt, testBlockHash).
+		Return(types.ApplyExtrinsicResult{IsOk: true, Ok: types.DispatchOutcome{IsOk: true}}, nil).
+		Once()
+
+	res, err := s.DryRun(xt, testBlockHash)
+	assert.NoError(t, err)
+	assert.NoError(t, res.Err())
+	m.state.AssertNotCalled(t, "GetMetadataContext", mock.Anything, mock.Anything)
+}
+
+func TestSubmitter_DryRun_Logger(t *testing.T) {
+	s, m, buf := newTestLogSubmitter(t)
+	m.expectRuntime(t)
+
+	xt := newTestExtrinsic(t)
+
+	m.system.On("DryRunContext", mock.Anything, xt, testBlockHash).
+		Return(types.ApplyExtrinsicResult{IsOk: true, Ok: types.DispatchOutcome{IsOk: true}}, nil).
+		Twice()
+
+	// The runtime is only refreshed once for the same spec version.
+	for i := 0; i < 2; i++ {
+		_, err := s.DryRun(xt, testBlockHash)
+		require.NoError(t, err)
+	}
+
+	entries := decodeTestLogEntries(t, buf)
+	require.Len(t, entries, 1)
+
+	entry := entries["Runtime refreshed"]
+	require.NotNil(t, entry)
+	assert.Equal(t, "INFO", entry["level"])
+	assert.Equal(t, testBlockHash.Hex(), entry["block_hash"])
+	assert.Equal(t, float64(42), entry["spec_version"])
+	assert.NotContains(t, entry, "old_spec_version")
+}
+
+func TestSubmitter_DryRun_RuntimeCacheOutdated(t *testing.T) {
+	_, m := newTestSubmitter(t)
+	s := NewSubmitterWithRuntimeCache(m.state, m.system, m.chain, m.author, m.registryFactory, testRuntimeCache{
+		version: types.RuntimeVersion{SpecVersion: 41},
+		meta:    statetest.PolkadotMetadata(t),
+	})
+
+	// The cached metadata belongs to another runtime, so it is retrieved instead.
+	m.expectRuntime(t)
+
+	xt := newTestExtrinsic(t)
+
+	m.system.On("DryRunContext", mock.Anything, xt, testBlockHash).
+		Return(types.ApplyExtrinsicResult{IsOk: true, Ok: types.DispatchOutcome{IsOk: true}}, nil).
+		Once()
+
+	res, err := s.DryRun(xt, testBlockHash)
+	assert.NoError(t, err)
+	assert.NoError(t, res.Err())
+}
+
+func TestSubmitter_Submit_Fakes(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+
+	api := fakes.NewAPI()
+	api.State.SetMetadata(meta)
+	api.State.SetRuntimeVersion(types.RuntimeVersion{SpecVersion: 42, TransactionVersion: 7})
+
+	key, err := types.CreateStorageKey(meta, "System", "Account", signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+	require.NoError(t, api.State.SetStorage(key, types.AccountInfo{Nonce: 3}))
+
+	s := NewSubmitter(api.State, api.System, api.Chain, api.Author, registry.NewFactory())
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	_, err = s.Submit(call, testSigner)
+	require.NoError(t, err)
+
+	submitted := api.Author.Submitted()
+	require.Len(t, submitted, 1)
+	assert.True(t, submitted[0].IsSigned())
+	assert.Equal(t, types.NewUCompactFromUInt(3), submitted[0].Signature.Nonce)
+
+	errRejected := errors.New("rejected")
+
+	api.Author.SetAcceptanceRule(func(types.Extrinsic) error {
+		return errRejected
+	})
+
+	_, err = s.Submit(call, testSigner)
+	assert.ErrorIs(t, err, ErrExtrinsicSubmission)
+	assert.ErrorIs(t, err, errRejected)
+	assert.Len(t, api.Author.Submitted(), 1)
+}
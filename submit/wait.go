@@ -0,0 +1,446 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/finality"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/metrics"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	extrinsicSuccessEvent = "System.ExtrinsicSuccess"
+	extrinsicFailedEvent  = "System.ExtrinsicFailed"
+	feePaidEvent          = "TransactionPayment.TransactionFeePaid"
+	feePaidActualFeeField = "actual_fee"
+
+	defaultRetractedTimeout = 2 * time.Minute
+)
+
+// WaitCondition is the status of an extrinsic that SubmitAndWait waits for.
+type WaitCondition uint8
+
+const (
+	// WaitForInBlock waits until the extrinsic was included in a block, which might still be retracted.
+	WaitForInBlock WaitCondition = iota
+	// WaitForFinalized waits until the block the extrinsic was included in was finalized.
+	WaitForFinalized
+)
+
+// FinalityTracker waits for the finalization of blocks, e.g. a *finality.Tracker. It returns an error wrapping
+// finality.ErrBlockRetracted if another block was finalized at the height of the block.
+type FinalityTracker interface {
+	WaitForFinalization(ctx context.Context, blockHash types.Hash) error
+}
+
+// WaitOptions configure SubmitAndWait.
+type WaitOptions struct {
+	// Until is the status that is waited for, it defaults to WaitForInBlock.
+	Until WaitCondition
+
+	// RetractedTimeout is the time to wait for the extrinsic to be included again after the block it was included
+	// in was retracted, it defaults to 2 minutes.
+	RetractedTimeout time.Duration
+
+	// FinalityTracker, if set, is used to wait for the finalization of the block the extrinsic was included in,
+	// instead of the finalized status of the extrinsic watch, e.g. a finality.Tracker shared with other components.
+	FinalityTracker FinalityTracker
+
+	// Preflight, if set, checks the extrinsic via Preflight before it is submitted, a *PreflightError is returned if
+	// violations were found.
+	Preflight *PreflightOptions
+}
+
+// ExtrinsicResult is the outcome of an extrinsic that was included in a block.
+type ExtrinsicResult struct {
+	// BlockHash is the hash of the block the extrinsic was included in.
+	BlockHash types.Hash
+	// Finalized is true if the block was finalized.
+	Finalized bool
+	// ExtrinsicIndex is the index of the extrinsic in the block.
+	ExtrinsicIndex uint32
+	// Events are the events that were emitted by the extrinsic.
+	Events []*parser.Event
+	// DispatchError is the error of the dispatch if it failed, with the name of the pallet error if any.
+	DispatchError *DispatchError
+	// Fee is the fee that was paid for the extrinsic, nil if the runtime does not emit the TransactionFeePaid
+	// event.
+	Fee *types.U128
+
+	rt *runtime
+}
+
+// Err returns the *DispatchError if the dispatch of the extrinsic failed, nil otherwise.
+func (r *ExtrinsicResult) Err() error {
+	if r.DispatchError != nil {
+		return r.DispatchError
+	}
+
+	return nil
+}
+
+// SubmitAndWait submits the extrinsic and watches it until it was included in a block or the block was finalized,
+// depending on the wait condition. The result holds the events of the extrinsic and whether its dispatch failed,
+// which is not returned as an error.
+//
+// ErrExtrinsicDropped, ErrExtrinsicInvalid and ErrExtrinsicUsurped are returned if the extrinsic was removed from the
+// transaction pool, and ErrFinalityTimeout if the node stopped watching it before its block was finalized. If the
+// block the extrinsic was included in is retracted, it waits for the extrinsic to be included again for up to
+// WaitOptions.RetractedTimeout, and returns ErrExtrinsicRetracted otherwise. The finalization is taken from
+// WaitOptions.FinalityTracker if it is set.
+//
+// If WaitOptions.Preflight is set, the extrinsic is only submitted if Preflight found no violations, so that e.g. an
+// expired era is reported with an actionable message instead of being rejected by the node.
+func (s *submitter) SubmitAndWait(
+	ctx context.Context,
+	xt types.Extrinsic,
+	opts WaitOptions,
+) (*ExtrinsicResult, error) {
+	if opts.RetractedTimeout == 0 {
+		opts.RetractedTimeout = defaultRetractedTimeout
+	}
+
+	if opts.Preflight != nil {
+		violations, err := s.Preflight(ctx, xt, *opts.Preflight)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(violations) > 0 {
+			for _, violation := range violations {
+				s.log(
+					ctx,
+					slog.LevelWarn,
+					"Preflight violation",
+					slog.String("check", violation.Check.String()),
+					slog.String("error", violation.Err.Error()),
+				)
+			}
+
+			return nil, &PreflightError{Violations: violations}
+		}
+	}
+
+	sub, err := s.authorRPC.SubmitAndWatchExtrinsicContext(ctx, xt)
+	if err != nil {
+		return nil, ErrExtrinsicSubmission.Wrap(err)
+	}
+
+	blockHash, finalized, err := s.waitFor(ctx, sub, opts)
+
+	sub.Unsubscribe()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getExtrinsicResult(ctx, xt, blockHash, finalized)
+}
+
+// statusSubscription delivers the statuses of watched extrinsics, e.g. an *author.ExtrinsicStatusSubscription.
+type statusSubscription interface {
+	Chan() <-chan types.ExtrinsicStatus
+	Err() <-chan error
+}
+
+// waitFor returns the hash of the block the extrinsic was included in once the wait condition is met, and whether the
+// block was finalized.
+// The outcome is recorded via SubmitterOptions.Recorder, if it is set.
+func (s *submitter) waitFor(ctx context.Context, sub statusSubscription, opts WaitOptions) (types.Hash, bool, error) {
+	var (
+		blockHash types.Hash
+		finalized bool
+		err       error
+	)
+
+	if opts.Until == WaitForFinalized && opts.FinalityTracker != nil {
+		blockHash, err = s.waitForTrackedFinalization(ctx, sub, opts)
+		finalized = err == nil
+	} else {
+		blockHash, finalized, err = s.waitForStatus(ctx, sub, opts)
+	}
+
+	if s.recorder != nil {
+		s.recorder.ExtrinsicOutcome(extrinsicOutcome(finalized, err))
+	}
+
+	return blockHash, finalized, err
+}
+
+// extrinsicOutcome returns the outcome of the wait for a watched extrinsic.
+func extrinsicOutcome(finalized bool, err error) metrics.Outcome {
+	switch {
+	case err == nil && finalized:
+		return metrics.OutcomeFinalized
+	case err == nil:
+		return metrics.OutcomeInBlock
+	case errors.Is(err, ErrExtrinsicUsurped):
+		return metrics.OutcomeUsurped
+	case errors.Is(err, ErrExtrinsicDropped):
+		return metrics.OutcomeDropped
+	case errors.Is(err, ErrExtrinsicInvalid):
+		return metrics.OutcomeInvalid
+	case errors.Is(err, ErrExtrinsicRetracted):
+		return metrics.OutcomeRetracted
+	case errors.Is(err, ErrFinalityTimeout):
+		return metrics.OutcomeFinalityTimeout
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return metrics.OutcomeCanceled
+	default:
+		return metrics.OutcomeError
+	}
+}
+
+// waitForStatus returns the hash of the block the extrinsic was included in once the wait condition is met, and
+// whether the block was finalized.
+func (s *submitter) waitForStatus(
+	ctx context.Context,
+	sub statusSubscription,
+	opts WaitOptions,
+) (types.Hash, bool, error) {
+	var retracted <-chan time.Time
+
+	for {
+		select {
+		case status, ok := <-sub.Chan():
+			if !ok {
+				if ctx.Err() != nil {
+					return types.Hash{}, false, ctx.Err()
+				}
+
+				return types.Hash{}, false, ErrExtrinsicWatch.WithMsg("subscription closed")
+			}
+
+			switch {
+			case status.IsInBlock:
+				retracted = nil
+
+				if opts.Until == WaitForInBlock {
+					return status.AsInBlock, false, nil
+				}
+			case status.IsRetracted:
+				s.log(
+					ctx,
+					slog.LevelWarn,
+					"Block of the extrinsic retracted, waiting for its inclusion in another block",
+					slog.String("block_hash", status.AsRetracted.Hex()),
+				)
+
+				if retracted == nil {
+					retracted = time.After(opts.RetractedTimeout)
+				}
+			case status.IsFinalized:
+				return status.AsFinalized, true, nil
+			case status.IsFinalityTimeout:
+				return types.Hash{}, false, ErrFinalityTimeout.WithMsg("block %s", status.AsFinalityTimeout.Hex())
+			case status.IsUsurped:
+				return types.Hash{}, false, ErrExtrinsicUsurped.WithMsg("by %s", status.AsUsurped.Hex())
+			case status.IsDropped:
+				return types.Hash{}, false, ErrExtrinsicDropped
+			case status.IsInvalid:
+				return types.Hash{}, false, ErrExtrinsicInvalid
+			}
+		case err := <-sub.Err():
+			if err == nil {
+				return types.Hash{}, false, ErrExtrinsicWatch.WithMsg("subscription ended")
+			}
+
+			return types.Hash{}, false, ErrExtrinsicWatch.Wrap(err)
+		case <-retracted:
+			return types.Hash{}, false, ErrExtrinsicRetracted
+		case <-ctx.Done():
+			return types.Hash{}, false, ctx.Err()
+		}
+	}
+}
+
+// waitForTrackedFinalization returns the hash of the block the extrinsic was included in once the finality tracker
+// reports it as finalized. If another block was finalized instead, it waits for the extrinsic to be included again.
+func (s *submitter) waitForTrackedFinalization(
+	ctx context.Context,
+	sub statusSubscription,
+	opts WaitOptions,
+) (types.Hash, error) {
+	inBlockOpts := opts
+	inBlockOpts.Until = WaitForInBlock
+
+	for {
+		blockHash, finalized, err := s.waitForStatus(ctx, sub, inBlockOpts)
+		if err != nil || finalized {
+			return blockHash, err
+		}
+
+		err = opts.FinalityTracker.WaitForFinalization(ctx, blockHash)
+
+		switch {
+		case err == nil:
+			return blockHash, nil
+		case errors.Is(err, finality.ErrBlockRetracted):
+			s.log(
+				ctx,
+				slog.LevelWarn,
+				"Block of the extrinsic not finalized, waiting for its inclusion in another block",
+				slog.String("block_hash", blockHash.Hex()),
+			)
+
+			continue
+		case ctx.Err() != nil:
+			return types.Hash{}, ctx.Err()
+		default:
+			return types.Hash{}, ErrFinalityTracking.Wrap(err)
+		}
+	}
+}
+
+// getExtrinsicResult looks up the extrinsic in the block and decodes its events.
+func (s *submitter) getExtrinsicResult(
+	ctx context.Context,
+	xt types.Extrinsic,
+	blockHash types.Hash,
+	finalized bool,
+) (*ExtrinsicResult, error) {
+	encoded, err := codec.Encode(xt)
+	if err != nil {
+		return nil, ErrExtrinsicEncoding.Wrap(err)
+	}
+
+	block, err := s.chainRPC.GetBlockContext(ctx, blockHash)
+	if err != nil {
+		return nil, ErrBlockRetrieval.Wrap(err)
+	}
+
+	index := -1
+
+	for i, blockXt := range block.Block.Extrinsics {
+		b, err := codec.Encode(blockXt)
+		if err == nil && bytes.Equal(b, encoded) {
+			index = i
+			break
+		}
+	}
+
+	if index < 0 {
+		return nil, ErrExtrinsicNotFound.WithMsg("block %s", blockHash.Hex())
+	}
+
+	rt, err := s.getRuntime(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.getEvents(ctx, rt, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ExtrinsicResult{
+		BlockHash:      blockHash,
+		Finalized:      finalized,
+		ExtrinsicIndex: uint32(index),
+		rt:             rt,
+	}
+
+	for _, event := range events {
+		if !event.Phase.IsApplyExtrinsic || event.Phase.AsApplyExtrinsic != res.ExtrinsicIndex {
+			continue
+		}
+
+		res.Events = append(res.Events, event)
+
+		switch event.Name {
+		case extrinsicFailedEvent:
+			var dispatchErr types.DispatchError
+
+			if err := codec.Decode(event.Data, &dispatchErr); err != nil {
+				return nil, ErrEventDecoding.WithMsg(extrinsicFailedEvent).Wrap(err)
+			}
+
+			res.DispatchError = res.dispatchError(dispatchErr)
+		case feePaidEvent:
+			fee, err := registry.GetDecodedFieldAsType[types.U128](
+				event.Fields,
+				func(_ int, field *registry.DecodedField) bool {
+					return field.Name == feePaidActualFeeField
+				},
+			)
+			if err != nil {
+				return nil, ErrEventDecoding.WithMsg(feePaidEvent).Wrap(err)
+			}
+
+			res.Fee = &fee
+		}
+	}
+
+	return res, nil
+}
+
+// getEvents retrieves and parses the events of the block.
+func (s *submitter) getEvents(ctx context.Context, rt *runtime, blockHash types.Hash) ([]*parser.Event, error) {
+	eventRegistry, err := s.getEventRegistry(rt)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := types.CreateStorageKey(rt.meta, "System", "Events")
+	if err != nil {
+		return nil, ErrStorageKeyCreation.Wrap(err)
+	}
+
+	raw, err := s.stateRPC.GetStorageRawContext(ctx, key, blockHash)
+	if err != nil {
+		return nil, ErrEventsRetrieval.Wrap(err)
+	}
+
+	events, err := parser.NewEventParser().ParseEvents(eventRegistry, raw)
+	if err != nil {
+		return nil, ErrEventsParsing.Wrap(err)
+	}
+
+	return events, nil
+}
+
+// getEventRegistry returns the event registry of the runtime, which is only created once per runtime.
+func (s *submitter) getEventRegistry(rt *runtime) (registry.EventRegistry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := s.runtime != nil && s.runtime.meta == rt.meta
+
+	if current && s.runtime.eventRegistry != nil {
+		return s.runtime.eventRegistry, nil
+	}
+
+	eventRegistry, err := s.registryFactory.CreateEventRegistry(rt.meta)
+	if err != nil {
+		return nil, ErrEventRegistryCreation.Wrap(err)
+	}
+
+	if current {
+		s.runtime.eventRegistry = eventRegistry
+	}
+
+	return eventRegistry, nil
+}
@@ -0,0 +1,392 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/finality"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/metrics"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testInBlockHash    = types.Hash{10, 11, 12}
+	testReincludedHash = types.Hash{13, 14, 15}
+
+	testDispatchInfo = types.DispatchInfo{
+		Weight:  types.NewWeight(types.NewUCompactFromUInt(1), types.NewUCompactFromUInt(2)),
+		Class:   types.DispatchClass{IsNormal: true},
+		PaysFee: types.Pays{IsYes: true},
+	}
+)
+
+type testEventRecord struct {
+	extrinsicIndex uint32
+	eventID        types.EventID
+	fields         []interface{}
+}
+
+func encodeTestEvents(t *testing.T, records ...testEventRecord) *types.StorageDataRaw {
+	var buf bytes.Buffer
+
+	encoder := scale.NewEncoder(&buf)
+
+	require.NoError(t, encoder.Encode(types.NewUCompactFromUInt(uint64(len(records)))))
+
+	for _, record := range records {
+		require.NoError(t, encoder.Encode(types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: record.extrinsicIndex}))
+		require.NoError(t, encoder.Encode(record.eventID))
+
+		for _, field := range record.fields {
+			require.NoError(t, encoder.Encode(field))
+		}
+
+		require.NoError(t, encoder.Encode([]types.Hash{}))
+	}
+
+	raw := types.StorageDataRaw(buf.Bytes())
+
+	return &raw
+}
+
+func extrinsicSuccess(extrinsicIndex uint32) testEventRecord {
+	return testEventRecord{
+		extrinsicIndex: extrinsicIndex,
+		eventID:        types.EventID{0, 0},
+		fields:         []interface{}{testDispatchInfo},
+	}
+}
+
+// newTestWaitSubmitter creates a submitter that watches extrinsics via a mock client that sends the given status
+// updates, and that parses the events with the registries of the test metadata.
+func newTestWaitSubmitter(t *testing.T, statuses ...types.ExtrinsicStatus) (Submitter, testMocks) {
+	_, m := newTestSubmitter(t)
+
+	notifications := make([]interface{}, 0, len(statuses))
+	for _, status := range statuses {
+		notifications = append(notifications, status)
+	}
+
+	cl := rpcmocksrv.NewMockClient().Notify("author_submitAndWatchExtrinsic", notifications)
+
+	return NewSubmitter(m.state, m.system, m.chain, author.NewAuthor(cl), registry.NewFactory()), m
+}
+
+func (m testMocks) expectExtrinsicResult(
+	t *testing.T,
+	blockHash types.Hash,
+	xt types.Extrinsic,
+	events *types.StorageDataRaw,
+) {
+	meta := statetest.PolkadotMetadata(t)
+
+	m.chain.On("GetBlockContext", mock.Anything, blockHash).
+		Return(&types.SignedBlock{
+			Block: types.Block{Extrinsics: []types.Extrinsic{types.NewExtrinsic(types.Call{}), xt}},
+		}, nil).
+		Once()
+	m.state.On("GetRuntimeVersionContext", mock.Anything, blockHash).
+		Return(&types.RuntimeVersion{SpecVersion: 42}, nil).
+		Once()
+	m.state.On("GetMetadataContext", mock.Anything, blockHash).
+		Return(meta, nil).
+		Once()
+
+	key, err := types.CreateStorageKey(meta, "System", "Events")
+	require.NoError(t, err)
+
+	m.state.On("GetStorageRawContext", mock.Anything, key, blockHash).
+		Return(events, nil).
+		Once()
+}
+
+func TestSubmitter_SubmitAndWait_InBlock(t *testing.T) {
+	s, m := newTestWaitSubmitter(
+		t,
+		types.ExtrinsicStatus{IsReady: true},
+		types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testInBlockHash},
+	)
+
+	xt := newTestExtrinsic(t)
+	signer, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	m.expectExtrinsicResult(t, testInBlockHash, xt, encodeTestEvents(
+		t,
+		extrinsicSuccess(0),
+		testEventRecord{
+			extrinsicIndex: 1,
+			eventID:        types.EventID{32, 0},
+			fields:         []interface{}{*signer, types.NewU128(*big.NewInt(1234)), types.NewU128(*big.NewInt(0))},
+		},
+		extrinsicSuccess(1),
+	))
+
+	res, err := s.SubmitAndWait(context.Background(), xt, WaitOptions{})
+	require.NoError(t, err)
+	assert.NoError(t, res.Err())
+	assert.Equal(t, testInBlockHash, res.BlockHash)
+	assert.False(t, res.Finalized)
+	assert.Equal(t, uint32(1), res.ExtrinsicIndex)
+	assert.Equal(t, types.NewU128(*big.NewInt(1234)), *res.Fee)
+
+	require.Len(t, res.Events, 2)
+	assert.Equal(t, "TransactionPayment.TransactionFeePaid", res.Events[0].Name)
+	assert.Equal(t, "System.ExtrinsicSuccess", res.Events[1].Name)
+}
+
+func TestSubmitter_SubmitAndWait_Finalized(t *testing.T) {
+	// The extrinsic is included again after its first block was retracted.
+	s, m := newTestWaitSubmitter(
+		t,
+		types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testInBlockHash},
+		types.ExtrinsicStatus{IsRetracted: true, AsRetracted: testInBlockHash},
+		types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testReincludedHash},
+		types.ExtrinsicStatus{IsFinalized: true, AsFinalized: testReincludedHash},
+	)
+
+	xt := newTestExtrinsic(t)
+
+	m.expectExtrinsicResult(t, testReincludedHash, xt, encodeTestEvents(
+		t,
+		testEventRecord{
+			extrinsicIndex: 1,
+			eventID:        types.EventID{0, 1},
+			fields:         []interface{}{testModuleError, testDispatchInfo},
+		},
+	))
+
+	res, err := s.SubmitAndWait(context.Background(), xt, WaitOptions{Until: WaitForFinalized})
+	require.NoError(t, err)
+	assert.Equal(t, testReincludedHash, res.BlockHash)
+	assert.True(t, res.Finalized)
+	assert.Nil(t, res.Fee)
+
+	var dispatchErr *DispatchError
+	require.True(t, errors.As(res.Err(), &dispatchErr))
+	assert.Equal(t, testModuleError, dispatchErr.Err)
+	assert.Equal(t, "Balances.InsufficientBalance", dispatchErr.ModuleErrorName)
+}
+
+type testFinalityTracker map[types.Hash]error
+
+func (t testFinalityTracker) WaitForFinalization(_ context.Context, blockHash types.Hash) error {
+	return t[blockHash]
+}
+
+func TestSubmitter_SubmitAndWait_FinalityTracker(t *testing.T) {
+	// The tracker reports that another block was finalized instead of the first block of the extrinsic.
+	s, m := newTestWaitSubmitter(
+		t,
+		types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testInBlockHash},
+		types.ExtrinsicStatus{IsRetracted: true, AsRetracted: testInBlockHash},
+		types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testReincludedHash},
+	)
+
+	xt := newTestExtrinsic(t)
+
+	m.expectExtrinsicResult(t, testReincludedHash, xt, encodeTestEvents(t, extrinsicSuccess(1)))
+
+	res, err := s.SubmitAndWait(context.Background(), xt, WaitOptions{
+		Until: WaitForFinalized,
+		FinalityTracker: testFinalityTracker{
+			testInBlockHash: finality.ErrBlockRetracted.WithMsg("block %s", testInBlockHash.Hex()),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testReincludedHash, res.BlockHash)
+	assert.True(t, res.Finalized)
+
+	s, _ = newTestWaitSubmitter(t, types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testInBlockHash})
+
+	res, err = s.SubmitAndWait(context.Background(), xt, WaitOptions{
+		Until:           WaitForFinalized,
+		FinalityTracker: testFinalityTracker{testInBlockHash: finality.ErrHeaderRetrieval},
+	})
+	assert.ErrorIs(t, err, ErrFinalityTracking)
+	assert.Nil(t, res)
+}
+
+func TestSubmitter_SubmitAndWait_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   types.ExtrinsicStatus
+		expected error
+	}{
+		{"dropped", types.ExtrinsicStatus{IsDropped: true}, ErrExtrinsicDropped},
+		{"invalid", types.ExtrinsicStatus{IsInvalid: true}, ErrExtrinsicInvalid},
+		{"usurped", types.ExtrinsicStatus{IsUsurped: true, AsUsurped: testBlockHash}, ErrExtrinsicUsurped},
+		{
+			"finality timeout",
+			types.ExtrinsicStatus{IsFinalityTimeout: true, AsFinalityTimeout: testInBlockHash},
+			ErrFinalityTimeout,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, _ := newTestWaitSubmitter(t, types.ExtrinsicStatus{IsReady: true}, test.status)
+
+			res, err := s.SubmitAndWait(context.Background(), newTestExtrinsic(t), WaitOptions{Until: WaitForFinalized})
+			assert.ErrorIs(t, err, test.expected)
+			assert.Nil(t, res)
+		})
+	}
+}
+
+func TestSubmitter_SubmitAndWait_Recorder(t *testing.T) {
+	_, m := newTestSubmitter(t)
+
+	cl := rpcmocksrv.NewMockClient().Notify("author_submitAndWatchExtrinsic", []interface{}{
+		types.ExtrinsicStatus{IsReady: true},
+		types.ExtrinsicStatus{IsDropped: true},
+	})
+
+	recorder := &testRecorder{}
+
+	s := NewSubmitterWithOptions(m.state, m.system, m.chain, author.NewAuthor(cl), registry.NewFactory(), SubmitterOptions{
+		Recorder: recorder,
+	})
+
+	_, err := s.SubmitAndWait(context.Background(), newTestExtrinsic(t), WaitOptions{})
+	assert.ErrorIs(t, err, ErrExtrinsicDropped)
+	assert.Equal(t, []metrics.Outcome{metrics.OutcomeDropped}, recorder.outcomes)
+}
+
+func TestExtrinsicOutcome(t *testing.T) {
+	tests := []struct {
+		finalized bool
+		err       error
+		expected  metrics.Outcome
+	}{
+		{false, nil, metrics.OutcomeInBlock},
+		{true, nil, metrics.OutcomeFinalized},
+		{false, ErrExtrinsicUsurped.WithMsg("by %s", testBlockHash.Hex()), metrics.OutcomeUsurped},
+		{false, ErrExtrinsicDropped, metrics.OutcomeDropped},
+		{false, ErrExtrinsicInvalid, metrics.OutcomeInvalid},
+		{false, ErrExtrinsicRetracted, metrics.OutcomeRetracted},
+		{false, ErrFinalityTimeout.WithMsg("block %s", testInBlockHash.Hex()), metrics.OutcomeFinalityTimeout},
+		{false, context.DeadlineExceeded, metrics.OutcomeCanceled},
+		{false, ErrExtrinsicWatch.WithMsg("subscription ended"), metrics.OutcomeError},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, extrinsicOutcome(test.finalized, test.err))
+	}
+}
+
+// testRecorder records the outcomes of the extrinsics and ignores all other metrics.
+type testRecorder struct {
+	outcomes []metrics.Outcome
+}
+
+func (r *testRecorder) CallCompleted(metrics.Call) {}
+
+func (r *testRecorder) SubscriptionStarted(string) {}
+
+func (r *testRecorder) SubscriptionEnded(string) {}
+
+func (r *testRecorder) NotificationReceived(string, int) {}
+
+func (r *testRecorder) NotificationDropped(string) {}
+
+func (r *testRecorder) Reconnected(string) {}
+
+func (r *testRecorder) EventsDecoded(int, time.Duration) {}
+
+func (r *testRecorder) RegistryLookup(string, bool) {}
+
+func (r *testRecorder) ExtrinsicOutcome(outcome metrics.Outcome) {
+	r.outcomes = append(r.outcomes, outcome)
+}
+
+func TestSubmitter_SubmitAndWait_Retracted(t *testing.T) {
+	s, _ := newTestWaitSubmitter(
+		t,
+		types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testInBlockHash},
+		types.ExtrinsicStatus{IsRetracted: true, AsRetracted: testInBlockHash},
+	)
+
+	res, err := s.SubmitAndWait(context.Background(), newTestExtrinsic(t), WaitOptions{
+		Until:            WaitForFinalized,
+		RetractedTimeout: 10 * time.Millisecond,
+	})
+	assert.ErrorIs(t, err, ErrExtrinsicRetracted)
+	assert.Nil(t, res)
+}
+
+func TestSubmitter_SubmitAndWait_Retracted_Logger(t *testing.T) {
+	_, m, buf := newTestLogSubmitter(t)
+
+	cl := rpcmocksrv.NewMockClient().Notify("author_submitAndWatchExtrinsic", []interface{}{
+		types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testInBlockHash},
+		types.ExtrinsicStatus{IsRetracted: true, AsRetracted: testInBlockHash},
+	})
+
+	s := NewSubmitterWithOptions(m.state, m.system, m.chain, author.NewAuthor(cl), registry.NewFactory(), SubmitterOptions{
+		Logger: slog.New(slog.NewJSONHandler(buf, nil)),
+	})
+
+	_, err := s.SubmitAndWait(context.Background(), newTestExtrinsic(t), WaitOptions{
+		Until:            WaitForFinalized,
+		RetractedTimeout: 10 * time.Millisecond,
+	})
+	assert.ErrorIs(t, err, ErrExtrinsicRetracted)
+
+	entry := decodeTestLogEntries(t, buf)["Block of the extrinsic retracted, waiting for its inclusion in another block"]
+	require.NotNil(t, entry)
+	assert.Equal(t, testInBlockHash.Hex(), entry["block_hash"])
+}
+
+func TestSubmitter_SubmitAndWait_ContextDone(t *testing.T) {
+	s, _ := newTestWaitSubmitter(t, types.ExtrinsicStatus{IsReady: true})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	res, err := s.SubmitAndWait(ctx, newTestExtrinsic(t), WaitOptions{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, res)
+}
+
+func TestSubmitter_SubmitAndWait_NotInBlock(t *testing.T) {
+	s, m := newTestWaitSubmitter(t, types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testInBlockHash})
+
+	m.chain.On("GetBlockContext", mock.Anything, testInBlockHash).
+		Return(&types.SignedBlock{}, nil).
+		Once()
+
+	res, err := s.SubmitAndWait(context.Background(), newTestExtrinsic(t), WaitOptions{})
+	assert.ErrorIs(t, err, ErrExtrinsicNotFound)
+	assert.Nil(t, res)
+}
@@ -0,0 +1,94 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teste2e
+
+import (
+	"os"
+	"testing"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChain_SubmitExtrinsicEcdsa submits a transfer signed by the ecdsa key of Alith to a Moonbase development node,
+// e.g. `moonbeam --dev`, whose RPC endpoint is set via MOONBASE_RPC_URL.
+func TestChain_SubmitExtrinsicEcdsa(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping end-to-end test in short mode.")
+	}
+
+	url, ok := os.LookupEnv("MOONBASE_RPC_URL")
+	if !ok {
+		t.Skip("skipping since MOONBASE_RPC_URL is not set")
+	}
+
+	alith, err := signature.EcdsaKeyringPairFromSecret(
+		"0x5fb92d6e98884f76de468fa3f6278f8807c48bebc13595d45af5bdc4da702133",
+		1287,
+		signature.EcdsaHasherKeccak256,
+	)
+	require.NoError(t, err)
+
+	alithID, err := alith.AccountID20()
+	require.NoError(t, err)
+
+	api, err := gsrpc.NewSubstrateAPI(url)
+	require.NoError(t, err)
+
+	meta, err := api.RPC.State.GetMetadataLatest()
+	require.NoError(t, err)
+
+	var baltathar [20]byte
+	copy(baltathar[:], codec.MustHexDecodeString("0x3cd0a705a2dc65e5b1e1205896baa2be8a07c6e0"))
+
+	c, err := types.NewCall(meta, "Balances.transfer_allow_death", baltathar, types.NewUCompactFromUInt(6969))
+	require.NoError(t, err)
+
+	genesisHash, err := api.RPC.Chain.GetBlockHash(0)
+	require.NoError(t, err)
+
+	rv, err := api.RPC.State.GetRuntimeVersionLatest()
+	require.NoError(t, err)
+
+	key, err := types.CreateStorageKey(meta, "System", "Account", alithID)
+	require.NoError(t, err)
+
+	var accountInfo types.AccountInfo
+	ok, err = api.RPC.State.GetStorageLatest(key, &accountInfo)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ext := types.NewExtrinsic(c)
+
+	err = ext.SignWithMetadataEcdsa(alith, meta, types.SignatureOptions{
+		BlockHash:          genesisHash,
+		GenesisHash:        genesisHash,
+		Nonce:              types.NewUCompactFromUInt(uint64(accountInfo.Nonce)),
+		SpecVersion:        rv.SpecVersion,
+		Tip:                types.NewUCompactFromUInt(0),
+		TransactionVersion: rv.TransactionVersion,
+	}, nil)
+	require.NoError(t, err)
+
+	txn, err := api.RPC.Author.SubmitExtrinsic(ext)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, txn)
+}
@@ -43,8 +43,8 @@ func TestBeefy_SubscribeJustifications(t *testing.T) {
 
 	for {
 		select {
-		case commitment := <-sub.Chan():
-			fmt.Printf("%#v\n", commitment)
+		case proof := <-sub.Chan():
+			fmt.Printf("%#v\n", proof)
 
 			received++
 
@@ -23,6 +23,7 @@ import (
 
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/stretchr/testify/assert"
@@ -117,3 +118,40 @@ func TestState_SubscribeStorage_Events(t *testing.T) {
 		}
 	}
 }
+
+func TestState_SubscribeStorageDecoded(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping end-to-end test in short mode.")
+	}
+
+	api, err := gsrpc.NewSubstrateAPI(config.Default().RPCURL)
+	assert.NoError(t, err)
+
+	sub, err := api.RPC.State.SubscribeStorageDecoded([]state.StorageQuery{
+		{Pallet: "System", Entry: "Number", Target: new(types.U32)},
+		{Pallet: "Timestamp", Entry: "Now"},
+	})
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	timeout := time.After(20 * time.Second)
+	received := 0
+
+	for {
+		select {
+		case set := <-sub.Chan():
+			fmt.Printf("%s: %#v\n", set.Block.Hex(), set.Changes)
+			received++
+
+			if received >= 2 {
+				return
+			}
+		case err := <-sub.Err():
+			assert.FailNow(t, err.Error())
+			return
+		case <-timeout:
+			assert.FailNow(t, "timeout reached without getting 2 notifications from subscription")
+			return
+		}
+	}
+}
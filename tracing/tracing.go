@@ -0,0 +1,48 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing defines the tracer that the high-level helpers, e.g. the retrievers and the upgrade watcher, create
+// spans with. It does not depend on a tracing library, the otelgsrpc module provides a Tracer backed by OpenTelemetry.
+package tracing
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Tracer starts spans.
+type Tracer interface {
+	// Start starts a span that is a child of the span in ctx, if any, and returns the context that holds the new span.
+	Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, Span)
+}
+
+// Span is a span that was started by a Tracer.
+type Span interface {
+	// SetAttributes adds the attributes to the span.
+	SetAttributes(attrs ...slog.Attr)
+
+	// End ends the span, err is recorded as the error of the span if it is not nil.
+	End(err error)
+}
+
+// NoopSpan is a span that records nothing, the helpers use it if no tracer is set.
+var NoopSpan Span = noopSpan{}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...slog.Attr) {}
+
+func (noopSpan) End(error) {}
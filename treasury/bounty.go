@@ -0,0 +1,221 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treasury
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// BountyActive is the state of a bounty whose curator accepted it.
+type BountyActive struct {
+	Curator types.AccountID
+	// UpdateDue is the block until which the curator has to extend the bounty, otherwise it can be unassigned.
+	UpdateDue types.U32
+}
+
+// BountyPendingPayout is the state of an awarded bounty, which can be claimed by the beneficiary from UnlockAt.
+type BountyPendingPayout struct {
+	Curator     types.AccountID
+	Beneficiary types.AccountID
+	UnlockAt    types.U32
+}
+
+// BountyStatus is the status of a bounty, see pallet_bounties::BountyStatus.
+type BountyStatus struct {
+	IsProposed bool
+
+	IsApproved bool
+
+	IsFunded bool
+
+	IsCuratorProposed bool
+	AsCuratorProposed types.AccountID
+
+	IsActive bool
+	AsActive BountyActive
+
+	IsPendingPayout bool
+	AsPendingPayout BountyPendingPayout
+
+	IsApprovedWithCurator bool
+	AsApprovedWithCurator types.AccountID
+}
+
+func (s *BountyStatus) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		s.IsProposed = true
+	case 1:
+		s.IsApproved = true
+	case 2:
+		s.IsFunded = true
+	case 3:
+		s.IsCuratorProposed = true
+
+		return decoder.Decode(&s.AsCuratorProposed)
+	case 4:
+		s.IsActive = true
+
+		return decoder.Decode(&s.AsActive)
+	case 5:
+		s.IsPendingPayout = true
+
+		return decoder.Decode(&s.AsPendingPayout)
+	case 6:
+		s.IsApprovedWithCurator = true
+
+		return decoder.Decode(&s.AsApprovedWithCurator)
+	default:
+		return fmt.Errorf("invalid bounty status %d", b)
+	}
+
+	return nil
+}
+
+func (s BountyStatus) Encode(encoder scale.Encoder) error {
+	switch {
+	case s.IsProposed:
+		return encoder.PushByte(0)
+	case s.IsApproved:
+		return encoder.PushByte(1)
+	case s.IsFunded:
+		return encoder.PushByte(2)
+	case s.IsCuratorProposed:
+		if err := encoder.PushByte(3); err != nil {
+			return err
+		}
+
+		return encoder.Encode(s.AsCuratorProposed)
+	case s.IsActive:
+		if err := encoder.PushByte(4); err != nil {
+			return err
+		}
+
+		return encoder.Encode(s.AsActive)
+	case s.IsPendingPayout:
+		if err := encoder.PushByte(5); err != nil {
+			return err
+		}
+
+		return encoder.Encode(s.AsPendingPayout)
+	case s.IsApprovedWithCurator:
+		if err := encoder.PushByte(6); err != nil {
+			return err
+		}
+
+		return encoder.Encode(s.AsApprovedWithCurator)
+	}
+
+	return nil
+}
+
+// Bounty is a bounty of the bounties pallet, as stored in Bounties.Bounties.
+type Bounty struct {
+	Proposer types.AccountID
+	Value    types.U128
+	// Fee is the fee of the curator, which is paid out of the value.
+	Fee            types.U128
+	CuratorDeposit types.U128
+	Bond           types.U128
+	Status         BountyStatus
+}
+
+// ChildBountyStatus is the status of a child bounty, see pallet_child_bounties::ChildBountyStatus.
+type ChildBountyStatus struct {
+	IsAdded bool
+
+	IsCuratorProposed bool
+	AsCuratorProposed types.AccountID
+
+	IsActive bool
+	AsActive types.AccountID
+
+	IsPendingPayout bool
+	AsPendingPayout BountyPendingPayout
+}
+
+func (s *ChildBountyStatus) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		s.IsAdded = true
+	case 1:
+		s.IsCuratorProposed = true
+
+		return decoder.Decode(&s.AsCuratorProposed)
+	case 2:
+		s.IsActive = true
+
+		return decoder.Decode(&s.AsActive)
+	case 3:
+		s.IsPendingPayout = true
+
+		return decoder.Decode(&s.AsPendingPayout)
+	default:
+		return fmt.Errorf("invalid child bounty status %d", b)
+	}
+
+	return nil
+}
+
+func (s ChildBountyStatus) Encode(encoder scale.Encoder) error {
+	switch {
+	case s.IsAdded:
+		return encoder.PushByte(0)
+	case s.IsCuratorProposed:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(s.AsCuratorProposed)
+	case s.IsActive:
+		if err := encoder.PushByte(2); err != nil {
+			return err
+		}
+
+		return encoder.Encode(s.AsActive)
+	case s.IsPendingPayout:
+		if err := encoder.PushByte(3); err != nil {
+			return err
+		}
+
+		return encoder.Encode(s.AsPendingPayout)
+	}
+
+	return nil
+}
+
+// ChildBounty is a child bounty of a bounty, as stored in ChildBounties.ChildBounties.
+type ChildBounty struct {
+	ParentBounty   types.U32
+	Value          types.U128
+	Fee            types.U128
+	CuratorDeposit types.U128
+	Status         ChildBountyStatus
+}
@@ -0,0 +1,77 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treasury
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBounty(status BountyStatus) Bounty {
+	return Bounty{
+		Proposer:       testAccount,
+		Value:          u128(1000),
+		Fee:            u128(100),
+		CuratorDeposit: u128(10),
+		Bond:           u128(1),
+		Status:         status,
+	}
+}
+
+func TestBounty_EncodeDecode(t *testing.T) {
+	pendingPayout := BountyPendingPayout{Curator: testAccount, Beneficiary: testBeneficiary, UnlockAt: 300}
+
+	for _, status := range []BountyStatus{
+		{IsProposed: true},
+		{IsApproved: true},
+		{IsFunded: true},
+		{IsCuratorProposed: true, AsCuratorProposed: testAccount},
+		{IsActive: true, AsActive: BountyActive{Curator: testAccount, UpdateDue: 200}},
+		{IsPendingPayout: true, AsPendingPayout: pendingPayout},
+		{IsApprovedWithCurator: true, AsApprovedWithCurator: testAccount},
+	} {
+		assertRoundTrip(t, newTestBounty(status))
+	}
+
+	for _, status := range []ChildBountyStatus{
+		{IsAdded: true},
+		{IsCuratorProposed: true, AsCuratorProposed: testAccount},
+		{IsActive: true, AsActive: testAccount},
+		{IsPendingPayout: true, AsPendingPayout: pendingPayout},
+	} {
+		assertRoundTrip(t, ChildBounty{
+			ParentBounty:   1,
+			Value:          u128(100),
+			Fee:            u128(10),
+			CuratorDeposit: u128(1),
+			Status:         status,
+		})
+	}
+
+	// Active with the curator and the block the bounty has to be extended until.
+	var status BountyStatus
+	require.NoError(t, codec.Decode(append(append([]byte{4}, testAccount[:]...), 200, 0, 0, 0), &status))
+	assert.Equal(t, BountyStatus{IsActive: true, AsActive: BountyActive{Curator: testAccount, UpdateDue: 200}}, status)
+
+	assert.Error(t, codec.Decode([]byte{7}, &status))
+
+	var childStatus ChildBountyStatus
+	assert.Error(t, codec.Decode([]byte{4}, &childStatus))
+}
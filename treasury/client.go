@@ -0,0 +1,198 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treasury
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/xxhash"
+)
+
+const (
+	treasuryPallet      = "Treasury"
+	proposalsEntry      = "Proposals"
+	spendsEntry         = "Spends"
+	bountiesPallet      = "Bounties"
+	bountiesEntry       = "Bounties"
+	childBountiesPallet = "ChildBounties"
+	childBountiesEntry  = "ChildBounties"
+)
+
+// Client reads the proposals and spends of the treasury pallet and the bounties of the bounties and child bounties
+// pallets.
+type Client struct {
+	stateRPC state.State
+}
+
+// NewClient creates a Client that reads the storage of the treasury pallets via the state RPC.
+func NewClient(stateRPC state.State) *Client {
+	return &Client{
+		stateRPC: stateRPC,
+	}
+}
+
+// GetProposal returns the spend proposal with the given index, or nil if there is none.
+func (c *Client) GetProposal(ctx context.Context, meta *types.Metadata, index uint32) (*Proposal, error) {
+	var proposal Proposal
+
+	ok, err := state.GetStorageEntryLatest(
+		ctx, c.stateRPC, meta, treasuryPallet, proposalsEntry, &proposal, encodeU32(index),
+	)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &proposal, nil
+}
+
+// GetSpend returns the spend with the given index, or nil if there is none, e.g. because it was paid out.
+func (c *Client) GetSpend(ctx context.Context, meta *types.Metadata, index uint32) (*SpendStatus, error) {
+	var spend SpendStatus
+
+	ok, err := state.GetStorageEntryLatest(ctx, c.stateRPC, meta, treasuryPallet, spendsEntry, &spend, encodeU32(index))
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &spend, nil
+}
+
+// GetActiveSpends returns the spends stored at the block that did not expire yet, ordered by their index. The block
+// number is the one the expiry of the spends is compared to.
+func (c *Client) GetActiveSpends(
+	ctx context.Context,
+	meta *types.Metadata,
+	blockHash types.Hash,
+	blockNumber uint32,
+) ([]Spend, error) {
+	prefix, err := spendsPrefix(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	var spends []Spend
+
+	_, err = c.stateRPC.ForEachKey(
+		ctx,
+		prefix,
+		blockHash,
+		state.ForEachKeyOptions{FetchValues: true},
+		func(key types.StorageKey, value *types.StorageDataRaw) error {
+			if value == nil {
+				return nil
+			}
+
+			// The keys end with the Twox64Concat hashed index.
+			if len(key) != len(prefix)+8+4 {
+				return ErrSpendDecoding.WithMsg("key %#x", []byte(key))
+			}
+
+			spend := Spend{Index: binary.LittleEndian.Uint32(key[len(key)-4:])}
+
+			if err := codec.Decode(*value, &spend.SpendStatus); err != nil {
+				return ErrSpendDecoding.WithMsg("spend %d", spend.Index).Wrap(err)
+			}
+
+			if !spend.IsExpired(blockNumber) {
+				spends = append(spends, spend)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, ErrStorageRetrieval.WithMsg("%s.%s", treasuryPallet, spendsEntry).Wrap(err)
+	}
+
+	sort.Slice(spends, func(i, j int) bool {
+		return spends[i].Index < spends[j].Index
+	})
+
+	return spends, nil
+}
+
+// GetBounty returns the bounty with the given index, or nil if there is none.
+func (c *Client) GetBounty(ctx context.Context, meta *types.Metadata, index uint32) (*Bounty, error) {
+	var bounty Bounty
+
+	ok, err := state.GetStorageEntryLatest(
+		ctx, c.stateRPC, meta, bountiesPallet, bountiesEntry, &bounty, encodeU32(index),
+	)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &bounty, nil
+}
+
+// GetChildBounty returns the child bounty of the parent bounty with the given index, or nil if there is none.
+func (c *Client) GetChildBounty(
+	ctx context.Context,
+	meta *types.Metadata,
+	parentIndex, index uint32,
+) (*ChildBounty, error) {
+	var childBounty ChildBounty
+
+	ok, err := state.GetStorageEntryLatest(
+		ctx,
+		c.stateRPC,
+		meta,
+		childBountiesPallet,
+		childBountiesEntry,
+		&childBounty,
+		encodeU32(parentIndex),
+		encodeU32(index),
+	)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &childBounty, nil
+}
+
+// spendsPrefix returns the prefix of the keys of Treasury.Spends, whose indices are hashed with Twox64Concat.
+func spendsPrefix(meta *types.Metadata) (types.StorageKey, error) {
+	entry, err := meta.FindStorageEntryMetadata(treasuryPallet, spendsEntry)
+	if err != nil {
+		return nil, ErrStorageEntryNotFound.WithMsg("%s.%s", treasuryPallet, spendsEntry).Wrap(err)
+	}
+
+	if !isTwox64Concat(entry) {
+		return nil, ErrUnsupportedLayout.WithMsg("%s.%s hashers", treasuryPallet, spendsEntry)
+	}
+
+	return append(xxhash.New128([]byte(treasuryPallet)).Sum(nil), xxhash.New128([]byte(spendsEntry)).Sum(nil)...), nil
+}
+
+// isTwox64Concat returns true if the single key of the map storage entry is hashed with Twox64Concat.
+func isTwox64Concat(entry types.StorageEntryMetadata) bool {
+	entryV14, ok := entry.(types.StorageEntryMetadataV14)
+
+	return ok && len(entryV14.Type.AsMap.Hashers) == 1 && entryV14.Type.AsMap.Hashers[0].IsTwox64Concat
+}
+
+// encodeU32 returns the SCALE encoded u32, like the indices in the storage keys.
+func encodeU32(v uint32) []byte {
+	b, _ := codec.Encode(types.NewU32(v))
+
+	return b
+}
@@ -0,0 +1,182 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treasury
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// withSpends returns a copy of the metadata with the Treasury.Spends storage entry, which is derived from
+// Treasury.Proposals as both are maps of the u32 indices.
+func withSpends(t *testing.T, meta *types.Metadata) *types.Metadata {
+	spends := *meta
+	spends.AsMetadataV14.Pallets = append([]types.PalletMetadataV14{}, meta.AsMetadataV14.Pallets...)
+
+	for i, pallet := range spends.AsMetadataV14.Pallets {
+		if pallet.Name != treasuryPallet {
+			continue
+		}
+
+		for _, item := range pallet.Storage.Items {
+			if item.Name == proposalsEntry {
+				item.Name = spendsEntry
+				pallet.Storage.Items = append(append([]types.StorageEntryMetadataV14{}, pallet.Storage.Items...), item)
+				spends.AsMetadataV14.Pallets[i] = pallet
+
+				return &spends
+			}
+		}
+	}
+
+	t.Fatal("treasury proposals not found")
+
+	return nil
+}
+
+func TestClient_GetProposal(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+	proposal := Proposal{Proposer: testAccount, Value: u128(1000), Beneficiary: testBeneficiary, Bond: u128(50)}
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorage(t, meta, treasuryPallet, proposalsEntry, proposal, encodeU32(3))
+
+	res, err := c.GetProposal(ctx, meta, 3)
+	require.NoError(t, err)
+	assert.Equal(t, &proposal, res)
+
+	stateRPC.ExpectStorage(t, meta, treasuryPallet, proposalsEntry, nil, encodeU32(4))
+
+	res, err = c.GetProposal(ctx, meta, 4)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestClient_GetSpend(t *testing.T) {
+	ctx := context.Background()
+	meta := withSpends(t, statetest.PolkadotMetadata(t))
+	spend := newTestSpend(PaymentState{IsPending: true})
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorage(t, meta, treasuryPallet, spendsEntry, spend, encodeU32(5))
+
+	res, err := c.GetSpend(ctx, meta, 5)
+	require.NoError(t, err)
+	assert.Equal(t, &spend, res)
+
+	// The Polkadot runtime of the test metadata predates the spends of assets.
+	_, err = c.GetSpend(ctx, statetest.PolkadotMetadata(t), 5)
+	assert.ErrorIs(t, err, ErrStorageKeyCreation)
+}
+
+func TestClient_GetActiveSpends(t *testing.T) {
+	ctx := context.Background()
+	meta := withSpends(t, statetest.PolkadotMetadata(t))
+	blockHash := types.Hash{1}
+
+	expired := newTestSpend(PaymentState{IsFailed: true})
+	expired.ExpireAt = 150
+
+	values := map[uint32]SpendStatus{
+		7: newTestSpend(PaymentState{IsAttempted: true, AsAttempted: 3}),
+		2: newTestSpend(PaymentState{IsPending: true}),
+		4: expired,
+	}
+
+	prefix, err := spendsPrefix(meta)
+	require.NoError(t, err)
+
+	opts := state.ForEachKeyOptions{FetchValues: true}
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.On("ForEachKey", mock.Anything, prefix, blockHash, opts, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(4).(func(types.StorageKey, *types.StorageDataRaw) error)
+
+			for _, index := range []uint32{7, 2, 4} {
+				key, err := types.CreateStorageKey(meta, treasuryPallet, spendsEntry, encodeU32(index))
+				require.NoError(t, err)
+
+				value := types.StorageDataRaw(statetest.Encode(t, values[index]))
+				require.NoError(t, fn(key, &value))
+			}
+
+			// Keys that were removed have no value.
+			require.NoError(t, fn(append(prefix, make([]byte, 12)...), nil))
+		}).
+		Return(nil, nil).
+		Once()
+
+	spends, err := c.GetActiveSpends(ctx, meta, blockHash, 160)
+	require.NoError(t, err)
+	assert.Equal(t, []Spend{{Index: 2, SpendStatus: values[2]}, {Index: 7, SpendStatus: values[7]}}, spends)
+
+	stateRPC.On("ForEachKey", mock.Anything, prefix, blockHash, mock.Anything, mock.Anything).
+		Return(nil, errors.New("boom")).
+		Once()
+
+	_, err = c.GetActiveSpends(ctx, meta, blockHash, 160)
+	assert.ErrorIs(t, err, ErrStorageRetrieval)
+
+	_, err = c.GetActiveSpends(ctx, statetest.PolkadotMetadata(t), blockHash, 160)
+	assert.ErrorIs(t, err, ErrStorageEntryNotFound)
+}
+
+func TestClient_GetBounty(t *testing.T) {
+	ctx := context.Background()
+	meta := statetest.PolkadotMetadata(t)
+	bounty := newTestBounty(BountyStatus{IsFunded: true})
+	childBounty := ChildBounty{
+		ParentBounty:   1,
+		Value:          u128(100),
+		Fee:            u128(10),
+		CuratorDeposit: u128(1),
+		Status:         ChildBountyStatus{IsActive: true, AsActive: testAccount},
+	}
+
+	c, stateRPC := statetest.NewClient(t, NewClient)
+	stateRPC.ExpectStorage(t, meta, bountiesPallet, bountiesEntry, bounty, encodeU32(1))
+	stateRPC.ExpectStorage(t, meta, childBountiesPallet, childBountiesEntry, childBounty, encodeU32(1), encodeU32(2))
+	stateRPC.ExpectStorage(t, meta, childBountiesPallet, childBountiesEntry, nil, encodeU32(1), encodeU32(3))
+
+	res, err := c.GetBounty(ctx, meta, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &bounty, res)
+
+	child, err := c.GetChildBounty(ctx, meta, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, &childBounty, child)
+
+	child, err = c.GetChildBounty(ctx, meta, 1, 3)
+	require.NoError(t, err)
+	assert.Nil(t, child)
+
+	stateRPC.On("GetStorageLatestContext", mock.Anything, mock.Anything, mock.Anything).
+		Return(false, errors.New("boom"))
+
+	_, err = c.GetBounty(ctx, meta, 1)
+	assert.ErrorIs(t, err, ErrStorageRetrieval)
+}
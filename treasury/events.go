@@ -0,0 +1,93 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treasury
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	spendApprovedEvent = "Treasury.SpendApproved"
+	awardedEvent       = "Treasury.Awarded"
+	bountyClaimedEvent = "Bounties.BountyClaimed"
+)
+
+// SpendApprovedEvent holds the fields of the Treasury.SpendApproved event, emitted when a spend of the native currency
+// was approved. It is paid out at the end of the spend period.
+type SpendApprovedEvent struct {
+	ProposalIndex types.U32
+	Amount        types.U128
+	Beneficiary   types.AccountID
+}
+
+// AwardedEvent holds the fields of the Treasury.Awarded event, emitted when an approved proposal was paid out.
+type AwardedEvent struct {
+	ProposalIndex types.U32
+	Award         types.U128
+	Account       types.AccountID
+}
+
+// BountyClaimedEvent holds the fields of the Bounties.BountyClaimed event, emitted when the beneficiary claimed the
+// payout of a bounty.
+type BountyClaimedEvent struct {
+	Index       types.U32
+	Payout      types.U128
+	Beneficiary types.AccountID
+}
+
+// Events are the events of the treasury and bounties pallets emitted in a block or by an extrinsic.
+type Events struct {
+	SpendApproved []SpendApprovedEvent
+	Awarded       []AwardedEvent
+	BountyClaimed []BountyClaimedEvent
+}
+
+// DecodeEvents decodes the SpendApproved and Awarded events of the treasury pallet and the BountyClaimed events of the
+// bounties pallet, e.g. the events parsed by the event parser of the registry. Other events are ignored.
+func DecodeEvents(events []*parser.Event) (*Events, error) {
+	var res Events
+
+	for _, event := range events {
+		var err error
+
+		switch event.Name {
+		case spendApprovedEvent:
+			var e SpendApprovedEvent
+			if err = codec.Decode(event.Data, &e); err == nil {
+				res.SpendApproved = append(res.SpendApproved, e)
+			}
+		case awardedEvent:
+			var e AwardedEvent
+			if err = codec.Decode(event.Data, &e); err == nil {
+				res.Awarded = append(res.Awarded, e)
+			}
+		case bountyClaimedEvent:
+			var e BountyClaimedEvent
+			if err = codec.Decode(event.Data, &e); err == nil {
+				res.BountyClaimed = append(res.BountyClaimed, e)
+			}
+		}
+
+		if err != nil {
+			return nil, ErrEventDecoding.WithMsg(event.Name).Wrap(err)
+		}
+	}
+
+	return &res, nil
+}
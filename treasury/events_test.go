@@ -0,0 +1,48 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treasury
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	approved := SpendApprovedEvent{ProposalIndex: 1, Amount: u128(100), Beneficiary: testBeneficiary}
+	awarded := AwardedEvent{ProposalIndex: 1, Award: u128(100), Account: testBeneficiary}
+	claimed := BountyClaimedEvent{Index: 2, Payout: u128(900), Beneficiary: testBeneficiary}
+
+	res, err := DecodeEvents([]*parser.Event{
+		{Name: "System.ExtrinsicSuccess", Data: []byte{1}},
+		{Name: spendApprovedEvent, Data: statetest.Encode(t, approved)},
+		{Name: awardedEvent, Data: statetest.Encode(t, awarded)},
+		{Name: bountyClaimedEvent, Data: statetest.Encode(t, claimed)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Events{
+		SpendApproved: []SpendApprovedEvent{approved},
+		Awarded:       []AwardedEvent{awarded},
+		BountyClaimed: []BountyClaimedEvent{claimed},
+	}, res)
+
+	_, err = DecodeEvents([]*parser.Event{{Name: awardedEvent, Data: []byte{1}}})
+	assert.ErrorIs(t, err, ErrEventDecoding)
+}
@@ -0,0 +1,187 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treasury
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Proposal is a spend proposal of the treasury, as stored in Treasury.Proposals. Approved proposals are paid out in
+// the native currency at the end of the spend period.
+type Proposal struct {
+	Proposer    types.AccountID
+	Value       types.U128
+	Beneficiary types.AccountID
+	Bond        types.U128
+}
+
+// LocatableAsset is an asset with the location of the chain it is paid out on, e.g. USDT on Asset Hub.
+type LocatableAsset struct {
+	// Location is the location of the chain, relative to the chain of the treasury.
+	Location types.MultiLocationV3
+	AssetID  types.AssetIDV3
+}
+
+// LocatableAssetV4 is the LocatableAsset of XCM v4, whose assets are identified by their location.
+type LocatableAssetV4 struct {
+	Location types.LocationV4
+	AssetID  types.LocationV4
+}
+
+// VersionedLocatableAsset is the asset kind of the spends of the treasury of the system chains, see
+// polkadot_runtime_common::impls::VersionedLocatableAsset.
+type VersionedLocatableAsset struct {
+	IsV3 bool
+	V3   LocatableAsset
+
+	IsV4 bool
+	V4   LocatableAssetV4
+}
+
+func (v *VersionedLocatableAsset) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 3:
+		v.IsV3 = true
+
+		return decoder.Decode(&v.V3)
+	case 4:
+		v.IsV4 = true
+
+		return decoder.Decode(&v.V4)
+	}
+
+	return fmt.Errorf("unsupported locatable asset version %d", b)
+}
+
+func (v VersionedLocatableAsset) Encode(encoder scale.Encoder) error {
+	switch {
+	case v.IsV3:
+		if err := encoder.PushByte(3); err != nil {
+			return err
+		}
+
+		return encoder.Encode(v.V3)
+	case v.IsV4:
+		if err := encoder.PushByte(4); err != nil {
+			return err
+		}
+
+		return encoder.Encode(v.V4)
+	}
+
+	return nil
+}
+
+// PaymentState is the state of the payout of a spend.
+type PaymentState struct {
+	// IsPending is set if the payout was not attempted yet or has to be checked.
+	IsPending bool
+	// IsAttempted is set if the payout was initiated, AsAttempted is the ID of the payment, e.g. the XCM query ID.
+	IsAttempted bool
+	AsAttempted types.U64
+	// IsFailed is set if the payout failed, it can be retried until the spend expires.
+	IsFailed bool
+}
+
+func (p *PaymentState) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		p.IsPending = true
+	case 1:
+		p.IsAttempted = true
+
+		return decoder.Decode(&p.AsAttempted)
+	case 2:
+		p.IsFailed = true
+	default:
+		return fmt.Errorf("invalid payment state %d", b)
+	}
+
+	return nil
+}
+
+func (p PaymentState) Encode(encoder scale.Encoder) error {
+	switch {
+	case p.IsPending:
+		return encoder.PushByte(0)
+	case p.IsAttempted:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(p.AsAttempted)
+	case p.IsFailed:
+		return encoder.PushByte(2)
+	}
+
+	return nil
+}
+
+// String returns the name of the state, e.g. for dashboards.
+func (p PaymentState) String() string {
+	switch {
+	case p.IsPending:
+		return "Pending"
+	case p.IsAttempted:
+		return fmt.Sprintf("Attempted(%d)", p.AsAttempted)
+	case p.IsFailed:
+		return "Failed"
+	}
+
+	return "Unknown"
+}
+
+// SpendStatus is an approved spend of an asset, as stored in Treasury.Spends. The spend can be paid out from
+// ValidFrom until ExpireAt, it is removed once its payout succeeded or it expired.
+type SpendStatus struct {
+	AssetKind   VersionedLocatableAsset
+	Amount      types.U128
+	Beneficiary types.VersionedLocation
+	ValidFrom   types.U32
+	ExpireAt    types.U32
+	Status      PaymentState
+}
+
+// IsExpired returns true if the spend can no longer be paid out at the block.
+func (s SpendStatus) IsExpired(block uint32) bool {
+	return uint32(s.ExpireAt) <= block
+}
+
+// IsPayable returns true if the payout of the spend can be attempted at the block, i.e. it is valid, not expired and
+// no payout is in progress.
+func (s SpendStatus) IsPayable(block uint32) bool {
+	return uint32(s.ValidFrom) <= block && !s.IsExpired(block) && !s.Status.IsAttempted
+}
+
+// Spend is a spend of the treasury with its index.
+type Spend struct {
+	Index uint32
+	SpendStatus
+}
@@ -0,0 +1,120 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treasury
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/xcm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccount     = types.AccountID{1}
+	testBeneficiary = types.AccountID{2}
+)
+
+func u128(i int64) types.U128 {
+	return types.NewU128(*big.NewInt(i))
+}
+
+func assertRoundTrip[T any](t *testing.T, value T) {
+	var decoded T
+	require.NoError(t, codec.Decode(statetest.Encode(t, value), &decoded))
+	assert.Equal(t, value, decoded)
+}
+
+// usdt returns USDT on Asset Hub as seen from the relay chain, i.e. the asset with the ID 1984 of the assets pallet.
+func usdt() VersionedLocatableAsset {
+	return VersionedLocatableAsset{
+		IsV4: true,
+		V4: LocatableAssetV4{
+			Location: xcm.Parachain(1000),
+			AssetID: types.LocationV4{Interior: types.JunctionsV4{
+				IsX2: true,
+				X2: [2]types.JunctionV4{
+					{IsPalletInstance: true, PalletIndex: 50},
+					{IsGeneralIndex: true, GeneralIndex: types.NewUCompactFromUInt(1984)},
+				},
+			}},
+		},
+	}
+}
+
+func newTestSpend(status PaymentState) SpendStatus {
+	return SpendStatus{
+		AssetKind:   usdt(),
+		Amount:      u128(1_000_000),
+		Beneficiary: types.VersionedLocation{IsV4: true, V4: xcm.AccountID32(testBeneficiary)},
+		ValidFrom:   100,
+		ExpireAt:    200,
+		Status:      status,
+	}
+}
+
+func TestSpendStatus_EncodeDecode(t *testing.T) {
+	for _, status := range []PaymentState{
+		{IsPending: true},
+		{IsAttempted: true, AsAttempted: 7},
+		{IsFailed: true},
+	} {
+		assertRoundTrip(t, newTestSpend(status))
+	}
+
+	v3 := newTestSpend(PaymentState{IsPending: true})
+	v3.AssetKind = VersionedLocatableAsset{
+		IsV3: true,
+		V3: LocatableAsset{
+			Location: xcm.Parachain(1000),
+			AssetID:  types.AssetIDV3{IsConcrete: true, MultiLocation: xcm.Here()},
+		},
+	}
+	assertRoundTrip(t, v3)
+
+	// The asset kind is encoded with its XCM version.
+	assert.Equal(t, byte(4), statetest.Encode(t, newTestSpend(PaymentState{IsPending: true}))[0])
+
+	var asset VersionedLocatableAsset
+	assert.Error(t, codec.Decode([]byte{5}, &asset))
+
+	var state PaymentState
+	assert.Error(t, codec.Decode([]byte{3}, &state))
+}
+
+func TestSpendStatus_IsPayable(t *testing.T) {
+	spend := newTestSpend(PaymentState{IsPending: true})
+
+	assert.False(t, spend.IsPayable(99))
+	assert.True(t, spend.IsPayable(100))
+	assert.True(t, spend.IsPayable(199))
+	assert.False(t, spend.IsPayable(200))
+	assert.True(t, spend.IsExpired(200))
+
+	assert.True(t, newTestSpend(PaymentState{IsFailed: true}).IsPayable(150))
+	assert.False(t, newTestSpend(PaymentState{IsAttempted: true, AsAttempted: 1}).IsPayable(150))
+}
+
+func TestPaymentState_String(t *testing.T) {
+	assert.Equal(t, "Pending", PaymentState{IsPending: true}.String())
+	assert.Equal(t, "Attempted(7)", PaymentState{IsAttempted: true, AsAttempted: 7}.String())
+	assert.Equal(t, "Failed", PaymentState{IsFailed: true}.String())
+}
@@ -0,0 +1,33 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrEmptyNode          = libErr.Error("empty node")
+	ErrInvalidNodeHeader  = libErr.Error("invalid node header")
+	ErrInvalidPartialKey  = libErr.Error("invalid partial key")
+	ErrInvalidBitmap      = libErr.Error("invalid children bitmap")
+	ErrNodeValueDecoding  = libErr.Error("node value decoding")
+	ErrNodeChildDecoding  = libErr.Error("node child decoding")
+	ErrTrailingNodeData   = libErr.Error("trailing node data")
+	ErrProofNodeDecoding  = libErr.Error("proof node decoding")
+	ErrMissingProofNode   = libErr.Error("missing proof node")
+	ErrMissingProofValue  = libErr.Error("missing proof value")
+	ErrUnexpectedNodeKind = libErr.Error("unexpected node kind")
+)
@@ -0,0 +1,268 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// Node header masks as defined by the substrate trie node codec.
+const (
+	emptyTrie              = 0b0000_0000
+	leafPrefixMask         = 0b01 << 6
+	branchWithoutValueMask = 0b10 << 6
+	branchWithValueMask    = 0b11 << 6
+	hashedValueLeafMask    = 0b001 << 5
+	hashedValueBranchMask  = 0b0001 << 4
+)
+
+const (
+	nibbleSizeBound = 65535
+	hashLength      = 32
+	childrenCount   = 16
+	bitmapLength    = 2
+	nibblesPerByte  = 2
+	highNibbleShift = 4
+	lowNibbleMask   = 0x0f
+)
+
+type nodeKind uint8
+
+const (
+	emptyNode nodeKind = iota
+	leafNode
+	branchNode
+)
+
+// node holds a decoded trie node.
+type node struct {
+	kind nodeKind
+
+	// partialKey holds the nibbles of the partial key of the node.
+	partialKey []byte
+
+	hasValue bool
+	// hashedValue specifies whether the value holds the hash of the actual value.
+	hashedValue bool
+	value       []byte
+
+	// children holds the references to the children of a branch, a reference is either
+	// the hash of the child node or, if shorter than a hash, the encoded child node itself.
+	children [childrenCount][]byte
+}
+
+// decodeNode decodes a trie node encoded with the substrate node codec.
+func decodeNode(encoded []byte) (*node, error) {
+	if len(encoded) == 0 {
+		return nil, ErrEmptyNode
+	}
+
+	d := newNodeDecoder(encoded)
+
+	header, err := d.ReadOneByte()
+	if err != nil {
+		return nil, ErrInvalidNodeHeader.Wrap(err)
+	}
+
+	var (
+		n          node
+		prefixBits uint
+	)
+
+	switch header & (0b11 << 6) {
+	case leafPrefixMask:
+		n.kind, n.hasValue, prefixBits = leafNode, true, 2
+	case branchWithValueMask:
+		n.kind, n.hasValue, prefixBits = branchNode, true, 2
+	case branchWithoutValueMask:
+		n.kind, prefixBits = branchNode, 2
+	case emptyTrie:
+		switch {
+		case header == emptyTrie:
+			return &node{kind: emptyNode}, nil
+		case header&(0b111<<5) == hashedValueLeafMask:
+			n.kind, n.hasValue, n.hashedValue, prefixBits = leafNode, true, true, 3
+		case header&(0b1111<<4) == hashedValueBranchMask:
+			n.kind, n.hasValue, n.hashedValue, prefixBits = branchNode, true, true, 4
+		default:
+			return nil, ErrInvalidNodeHeader.WithMsg("unsupported header %#x", header)
+		}
+	}
+
+	nibbleCount, err := d.decodeNibbleCount(header, prefixBits)
+	if err != nil {
+		return nil, ErrInvalidNodeHeader.Wrap(err)
+	}
+
+	if n.partialKey, err = d.decodePartialKey(nibbleCount); err != nil {
+		return nil, ErrInvalidPartialKey.Wrap(err)
+	}
+
+	var bitmap uint16
+
+	if n.kind == branchNode {
+		b, err := d.readBytes(bitmapLength)
+		if err != nil {
+			return nil, ErrInvalidBitmap.Wrap(err)
+		}
+
+		bitmap = uint16(b[0]) | uint16(b[1])<<8
+
+		if bitmap == 0 {
+			return nil, ErrInvalidBitmap.WithMsg("branch without children")
+		}
+	}
+
+	if n.hasValue {
+		if n.hashedValue {
+			n.value, err = d.readBytes(hashLength)
+		} else {
+			n.value, err = d.decodeLengthPrefixed()
+		}
+
+		if err != nil {
+			return nil, ErrNodeValueDecoding.Wrap(err)
+		}
+	}
+
+	for i := 0; i < childrenCount; i++ {
+		if bitmap&(1<<i) == 0 {
+			continue
+		}
+
+		if n.children[i], err = d.decodeLengthPrefixed(); err != nil {
+			return nil, ErrNodeChildDecoding.Wrap(err)
+		}
+	}
+
+	if d.reader.Len() != 0 {
+		return nil, ErrTrailingNodeData.WithMsg("%d bytes", d.reader.Len())
+	}
+
+	return &n, nil
+}
+
+// nodeDecoder wraps a scale.Decoder and keeps track of the remaining bytes of the encoded node.
+type nodeDecoder struct {
+	*scale.Decoder
+
+	reader *bytes.Reader
+}
+
+func newNodeDecoder(encoded []byte) *nodeDecoder {
+	reader := bytes.NewReader(encoded)
+
+	return &nodeDecoder{
+		Decoder: scale.NewDecoder(reader),
+		reader:  reader,
+	}
+}
+
+// readBytes reads exactly n bytes.
+func (d *nodeDecoder) readBytes(n int) ([]byte, error) {
+	if n > d.reader.Len() {
+		return nil, fmt.Errorf("expected %d bytes, only %d available", n, d.reader.Len())
+	}
+
+	b := make([]byte, n)
+
+	if n == 0 {
+		return b, nil
+	}
+
+	if err := d.Read(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// decodeNibbleCount decodes the nibble count found in the header and, if needed, in the following bytes.
+func (d *nodeDecoder) decodeNibbleCount(header byte, prefixBits uint) (int, error) {
+	maxValue := 255 >> prefixBits
+
+	res := int(header) & maxValue
+
+	if res < maxValue {
+		return res, nil
+	}
+
+	res--
+
+	for res <= nibbleSizeBound {
+		b, err := d.ReadOneByte()
+		if err != nil {
+			return 0, err
+		}
+
+		if b < 255 {
+			return res + int(b) + 1, nil
+		}
+
+		res += 255
+	}
+
+	return nibbleSizeBound, nil
+}
+
+// decodePartialKey reads the partial key and returns its nibbles.
+func (d *nodeDecoder) decodePartialKey(nibbleCount int) ([]byte, error) {
+	b, err := d.readBytes((nibbleCount + 1) / nibblesPerByte)
+	if err != nil {
+		return nil, err
+	}
+
+	nibbles := keyToNibbles(b)
+
+	if nibbleCount%nibblesPerByte == 1 {
+		if nibbles[0] != 0 {
+			return nil, fmt.Errorf("non-zero padding")
+		}
+
+		nibbles = nibbles[1:]
+	}
+
+	return nibbles, nil
+}
+
+// decodeLengthPrefixed reads a compact length followed by the bytes.
+func (d *nodeDecoder) decodeLengthPrefixed() ([]byte, error) {
+	l, err := d.DecodeUintCompact()
+	if err != nil {
+		return nil, err
+	}
+
+	if !l.IsInt64() || l.Int64() > int64(d.reader.Len()) {
+		return nil, fmt.Errorf("length %s exceeds the remaining %d bytes", l, d.reader.Len())
+	}
+
+	return d.readBytes(int(l.Int64()))
+}
+
+// keyToNibbles returns the nibbles of the provided key, high nibble first.
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, len(key)*nibblesPerByte)
+
+	for _, b := range key {
+		nibbles = append(nibbles, b>>highNibbleShift, b&lowNibbleMask)
+	}
+
+	return nibbles
+}
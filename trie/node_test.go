@@ -0,0 +1,141 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeNode_Empty(t *testing.T) {
+	n, err := decodeNode([]byte{0})
+	assert.NoError(t, err)
+	assert.Equal(t, emptyNode, n.kind)
+
+	n, err = decodeNode(nil)
+	assert.ErrorIs(t, err, ErrEmptyNode)
+	assert.Nil(t, n)
+}
+
+func TestDecodeNode_Leaf(t *testing.T) {
+	// Leaf with 3 nibbles - 0x1, 0x2, 0x3 and value 0x0405.
+	n, err := decodeNode(codec.MustHexDecodeString("0x430123080405"))
+	assert.NoError(t, err)
+	assert.Equal(t, leafNode, n.kind)
+	assert.Equal(t, []byte{1, 2, 3}, n.partialKey)
+	assert.True(t, n.hasValue)
+	assert.False(t, n.hashedValue)
+	assert.Equal(t, []byte{4, 5}, n.value)
+}
+
+func TestDecodeNode_HashedValueLeaf(t *testing.T) {
+	valueHash := make([]byte, hashLength)
+	valueHash[0] = 1
+
+	encoded := append([]byte{hashedValueLeafMask | 2, 0xab}, valueHash...)
+
+	n, err := decodeNode(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, leafNode, n.kind)
+	assert.Equal(t, []byte{0xa, 0xb}, n.partialKey)
+	assert.True(t, n.hashedValue)
+	assert.Equal(t, valueHash, n.value)
+}
+
+func TestDecodeNode_Branch(t *testing.T) {
+	// Branch with value 0x01, no partial key and two inline children at indexes 0 and 15.
+	n, err := decodeNode(codec.MustHexDecodeString("0xc001800401084000084000"))
+	assert.NoError(t, err)
+	assert.Equal(t, branchNode, n.kind)
+	assert.Empty(t, n.partialKey)
+	assert.True(t, n.hasValue)
+	assert.Equal(t, []byte{1}, n.value)
+	assert.Equal(t, []byte{0x40, 0x00}, n.children[0])
+	assert.Equal(t, []byte{0x40, 0x00}, n.children[15])
+
+	for i := 1; i < 15; i++ {
+		assert.Nil(t, n.children[i])
+	}
+}
+
+func TestDecodeNode_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		err     error
+	}{
+		{"unsupported header", "0x01", ErrInvalidNodeHeader},
+		{"missing nibble count", "0x7f", ErrInvalidNodeHeader},
+		{"missing partial key", "0x4301", ErrInvalidPartialKey},
+		{"non-zero padding", "0x41100400", ErrInvalidPartialKey},
+		{"missing bitmap", "0x8000", ErrInvalidBitmap},
+		{"branch without children", "0x800000", ErrInvalidBitmap},
+		{"missing value", "0x4008", ErrNodeValueDecoding},
+		{"missing child", "0x80010004", ErrNodeChildDecoding},
+		{"trailing data", "0x400000", ErrTrailingNodeData},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n, err := decodeNode(codec.MustHexDecodeString(test.encoded))
+			assert.ErrorIs(t, err, test.err)
+			assert.Nil(t, n)
+		})
+	}
+}
+
+func TestNodeDecoder_DecodeNibbleCount(t *testing.T) {
+	tests := []struct {
+		encoded  []byte
+		expected int
+	}{
+		{[]byte{leafPrefixMask | 5}, 5},
+		{[]byte{leafPrefixMask | 62}, 62},
+		{[]byte{leafPrefixMask | 63, 0}, 63},
+		{[]byte{leafPrefixMask | 63, 1}, 64},
+		{[]byte{leafPrefixMask | 63, 255, 0}, 318},
+		{[]byte{hashedValueLeafMask | 31, 0}, 31},
+		{[]byte{hashedValueBranchMask | 15, 2}, 17},
+	}
+
+	for _, test := range tests {
+		d := newNodeDecoder(test.encoded)
+
+		header, err := d.ReadOneByte()
+		assert.NoError(t, err)
+
+		prefixBits := uint(2)
+
+		switch {
+		case header&(0b111<<5) == hashedValueLeafMask:
+			prefixBits = 3
+		case header&(0b1111<<4) == hashedValueBranchMask:
+			prefixBits = 4
+		}
+
+		res, err := d.decodeNibbleCount(header, prefixBits)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, res)
+	}
+}
+
+func TestKeyToNibbles(t *testing.T) {
+	assert.Equal(t, []byte{0x1, 0x2, 0xa, 0xb}, keyToNibbles([]byte{0x12, 0xab}))
+	assert.Empty(t, keyToNibbles(nil))
+}
@@ -0,0 +1,149 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+// VerifyProof verifies the provided storage proof against the state root and returns the values
+// of the provided keys, in the same order.
+//
+// Keys that are proven to not be part of the trie are returned with HasStorageData set to false.
+//
+// The proof can be retrieved via state_getReadProof and the state root is found in the header
+// of the block that the proof was created at. No RPC connection is needed for the verification itself.
+func VerifyProof(stateRoot types.Hash, proof []types.Bytes, keys []types.StorageKey) ([]types.KeyValueOption, error) {
+	db := make(map[types.Hash][]byte, len(proof))
+
+	for _, encodedNode := range proof {
+		db[blake2b.Sum256(encodedNode)] = encodedNode
+	}
+
+	res := make([]types.KeyValueOption, 0, len(keys))
+
+	for _, key := range keys {
+		value, ok, err := lookup(db, stateRoot, key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, types.KeyValueOption{
+			StorageKey:     key,
+			HasStorageData: ok,
+			StorageData:    value,
+		})
+	}
+
+	return res, nil
+}
+
+// VerifyReadProof verifies the proof returned by state_getReadProof, see VerifyProof for more details.
+func VerifyReadProof(
+	stateRoot types.Hash,
+	readProof types.ReadProof,
+	keys []types.StorageKey,
+) ([]types.KeyValueOption, error) {
+	return VerifyProof(stateRoot, readProof.Proof, keys)
+}
+
+// lookup walks the trie from the root down to the node that holds the value of the provided key.
+func lookup(db map[types.Hash][]byte, root types.Hash, key []byte) ([]byte, bool, error) {
+	encodedNode, ok := db[root]
+
+	if !ok {
+		return nil, false, ErrMissingProofNode.WithMsg("root %s", root.Hex())
+	}
+
+	nibbles := keyToNibbles(key)
+
+	for {
+		n, err := decodeNode(encodedNode)
+
+		if err != nil {
+			return nil, false, ErrProofNodeDecoding.Wrap(err)
+		}
+
+		switch n.kind {
+		case emptyNode:
+			return nil, false, nil
+		case leafNode:
+			if !bytes.Equal(n.partialKey, nibbles) {
+				return nil, false, nil
+			}
+
+			return resolveValue(db, n)
+		case branchNode:
+			if !bytes.HasPrefix(nibbles, n.partialKey) {
+				return nil, false, nil
+			}
+
+			nibbles = nibbles[len(n.partialKey):]
+
+			if len(nibbles) == 0 {
+				if !n.hasValue {
+					return nil, false, nil
+				}
+
+				return resolveValue(db, n)
+			}
+
+			child := n.children[nibbles[0]]
+
+			if child == nil {
+				return nil, false, nil
+			}
+
+			nibbles = nibbles[1:]
+
+			if len(child) < hashLength {
+				encodedNode = child
+
+				continue
+			}
+
+			childHash := types.NewHash(child)
+
+			if encodedNode, ok = db[childHash]; !ok {
+				return nil, false, ErrMissingProofNode.WithMsg("node %s", childHash.Hex())
+			}
+		default:
+			return nil, false, ErrUnexpectedNodeKind.WithMsg("%d", n.kind)
+		}
+	}
+}
+
+// resolveValue returns the value of the node, looking it up in the proof if the node only holds its hash.
+func resolveValue(db map[types.Hash][]byte, n *node) ([]byte, bool, error) {
+	if !n.hashedValue {
+		return n.value, true, nil
+	}
+
+	valueHash := types.NewHash(n.value)
+
+	value, ok := db[valueHash]
+
+	if !ok {
+		return nil, false, ErrMissingProofValue.WithMsg("value %s", valueHash.Hex())
+	}
+
+	return value, true, nil
+}
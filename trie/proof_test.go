@@ -0,0 +1,195 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestVerifyProof_EmptyTrie(t *testing.T) {
+	// Root of an empty substrate trie.
+	root := types.NewHash(codec.MustHexDecodeString("0x03170a2e7597b7b7e3d84c05391d139a62b157e78786d8c082f29dcf4c111314"))
+
+	res, err := VerifyProof(root, []types.Bytes{{emptyTrie}}, []types.StorageKey{{1, 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, []types.KeyValueOption{{StorageKey: types.StorageKey{1, 2}}}, res)
+}
+
+func TestVerifyProof(t *testing.T) {
+	longValue := bytes.Repeat([]byte{7}, 40)
+	hashedValue := bytes.Repeat([]byte{8}, 64)
+	hashedValueHash := blake2b.Sum256(hashedValue)
+
+	// Key 0x0120 - inline leaf.
+	leaf1 := encodeTestNode(leafPrefixMask, []byte{0}, []byte{1}, nil)
+	// Key 0x0130 - leaf that is referenced by hash due to its size.
+	leaf2 := encodeTestNode(leafPrefixMask, []byte{0}, longValue, nil)
+	// Key 0x0150 - leaf that only holds the hash of its value.
+	leaf3 := encodeTestNode(hashedValueLeafMask, []byte{0}, hashedValueHash[:], nil)
+
+	// Key 0x01 - branch with value.
+	branch := encodeTestNode(
+		branchWithValueMask,
+		[]byte{1},
+		[]byte{9},
+		map[int][]byte{
+			2: testNodeRef(leaf1),
+			3: testNodeRef(leaf2),
+			5: testNodeRef(leaf3),
+		},
+	)
+
+	root := encodeTestNode(branchWithoutValueMask, nil, nil, map[int][]byte{0: testNodeRef(branch)})
+	rootHash := types.Hash(blake2b.Sum256(root))
+
+	proof := []types.Bytes{root, branch, leaf2, leaf3, hashedValue}
+
+	keys := []types.StorageKey{
+		{0x01},
+		{0x01, 0x20},
+		{0x01, 0x30},
+		{0x01, 0x50},
+		{0x01, 0x40},
+		{0x01, 0x20, 0x03},
+		{0x02},
+		{},
+	}
+
+	res, err := VerifyProof(rootHash, proof, keys)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.KeyValueOption{
+		{StorageKey: keys[0], HasStorageData: true, StorageData: []byte{9}},
+		{StorageKey: keys[1], HasStorageData: true, StorageData: []byte{1}},
+		{StorageKey: keys[2], HasStorageData: true, StorageData: longValue},
+		{StorageKey: keys[3], HasStorageData: true, StorageData: hashedValue},
+		{StorageKey: keys[4]},
+		{StorageKey: keys[5]},
+		{StorageKey: keys[6]},
+		{StorageKey: keys[7]},
+	}, res)
+
+	res, err = VerifyReadProof(rootHash, types.ReadProof{Proof: proof}, keys[:1])
+	assert.NoError(t, err)
+	assert.Equal(t, []types.KeyValueOption{{StorageKey: keys[0], HasStorageData: true, StorageData: []byte{9}}}, res)
+}
+
+func TestVerifyProof_Errors(t *testing.T) {
+	hashedValue := bytes.Repeat([]byte{8}, 64)
+	hashedValueHash := blake2b.Sum256(hashedValue)
+
+	leaf1 := encodeTestNode(leafPrefixMask, []byte{2}, bytes.Repeat([]byte{7}, 40), nil)
+	leaf2 := encodeTestNode(hashedValueLeafMask, []byte{3}, hashedValueHash[:], nil)
+
+	root := encodeTestNode(
+		branchWithoutValueMask,
+		nil,
+		nil,
+		map[int][]byte{
+			1: testNodeRef(leaf1),
+			2: testNodeRef(leaf2),
+		},
+	)
+	rootHash := types.Hash(blake2b.Sum256(root))
+
+	res, err := VerifyProof(types.Hash{1}, []types.Bytes{root}, []types.StorageKey{{0x12}})
+	assert.ErrorIs(t, err, ErrMissingProofNode)
+	assert.Nil(t, res)
+
+	res, err = VerifyProof(rootHash, []types.Bytes{root}, []types.StorageKey{{0x12}})
+	assert.ErrorIs(t, err, ErrMissingProofNode)
+	assert.Nil(t, res)
+
+	res, err = VerifyProof(rootHash, []types.Bytes{root, leaf2}, []types.StorageKey{{0x23}})
+	assert.ErrorIs(t, err, ErrMissingProofValue)
+	assert.Nil(t, res)
+
+	invalidRoot := []byte{0x01}
+	invalidRootHash := types.Hash(blake2b.Sum256(invalidRoot))
+
+	res, err = VerifyProof(invalidRootHash, []types.Bytes{invalidRoot}, []types.StorageKey{{0x12}})
+	assert.ErrorIs(t, err, ErrProofNodeDecoding)
+	assert.Nil(t, res)
+}
+
+// encodeTestNode encodes a node using the substrate trie node codec.
+//
+// Partial keys with less than 63 nibbles are supported, which is enough for the test cases.
+func encodeTestNode(mask byte, partialKey []byte, value []byte, children map[int][]byte) []byte {
+	var buf bytes.Buffer
+
+	encoder := scale.NewEncoder(&buf)
+
+	_ = encoder.PushByte(mask | byte(len(partialKey)))
+
+	if len(partialKey)%nibblesPerByte == 1 {
+		partialKey = append([]byte{0}, partialKey...)
+	}
+
+	for i := 0; i < len(partialKey); i += nibblesPerByte {
+		_ = encoder.PushByte(partialKey[i]<<highNibbleShift | partialKey[i+1])
+	}
+
+	if mask != leafPrefixMask && mask != hashedValueLeafMask {
+		var bitmap uint16
+
+		for i := range children {
+			bitmap |= 1 << i
+		}
+
+		_ = encoder.Write([]byte{byte(bitmap), byte(bitmap >> 8)})
+	}
+
+	switch mask {
+	case hashedValueLeafMask, hashedValueBranchMask:
+		_ = encoder.Write(value)
+	case leafPrefixMask, branchWithValueMask:
+		_ = encoder.EncodeUintCompact(*big.NewInt(int64(len(value))))
+		_ = encoder.Write(value)
+	}
+
+	for i := 0; i < childrenCount; i++ {
+		child, ok := children[i]
+
+		if !ok {
+			continue
+		}
+
+		_ = encoder.EncodeUintCompact(*big.NewInt(int64(len(child))))
+		_ = encoder.Write(child)
+	}
+
+	return buf.Bytes()
+}
+
+// testNodeRef returns the reference that a branch holds for the provided child.
+func testNodeRef(encodedNode []byte) []byte {
+	if len(encodedNode) < hashLength {
+		return encodedNode
+	}
+
+	h := blake2b.Sum256(encodedNode)
+
+	return h[:]
+}
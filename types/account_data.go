@@ -16,16 +16,56 @@
 
 package types
 
-// AccountInfo contains information of an account
+// AccountInfo contains information of an account, as stored in System.Account by current runtimes. Runtimes that
+// still use an older layout can be decoded with AccountInfoWithFeeFrozen, AccountInfoWithDualRefCount or
+// AccountInfoWithRefCount, see registry.NewAccountInfoDecoder.
 type AccountInfo struct {
 	Nonce       U32
 	Consumers   U32
 	Providers   U32
 	Sufficients U32
-	Data        struct {
-		Free       U128
-		Reserved   U128
-		MiscFrozen U128
-		Flags      U128
-	}
+	Data        AccountData
+}
+
+// AccountData contains the balances of an account, as stored by current versions of the balances pallet.
+type AccountData struct {
+	Free     U128
+	Reserved U128
+	Frozen   U128
+	Flags    U128
+}
+
+// AccountDataWithFeeFrozen contains the balances of an account, as stored by versions of the balances pallet before
+// the frozen balance replaced the misc and fee frozen balances.
+type AccountDataWithFeeFrozen struct {
+	Free       U128
+	Reserved   U128
+	MiscFrozen U128
+	FeeFrozen  U128
+}
+
+// AccountInfoWithFeeFrozen contains information of an account with the balances of AccountDataWithFeeFrozen.
+type AccountInfoWithFeeFrozen struct {
+	Nonce       U32
+	Consumers   U32
+	Providers   U32
+	Sufficients U32
+	Data        AccountDataWithFeeFrozen
+}
+
+// AccountInfoWithDualRefCount contains information of an account, as stored before sufficient references were
+// introduced.
+type AccountInfoWithDualRefCount struct {
+	Nonce     U32
+	Consumers U32
+	Providers U32
+	Data      AccountDataWithFeeFrozen
+}
+
+// AccountInfoWithRefCount contains information of an account, as stored before the reference count was split into
+// consumers and providers.
+type AccountInfoWithRefCount struct {
+	Nonce    U32
+	RefCount U32
+	Data     AccountDataWithFeeFrozen
 }
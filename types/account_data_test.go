@@ -0,0 +1,96 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+)
+
+var (
+	testAccountInfo = AccountInfo{
+		Nonce:       1,
+		Consumers:   2,
+		Providers:   3,
+		Sufficients: 4,
+		Data: AccountData{
+			Free:     NewU128(*big.NewInt(5)),
+			Reserved: NewU128(*big.NewInt(6)),
+			Frozen:   NewU128(*big.NewInt(7)),
+			Flags:    NewU128(*big.NewInt(8)),
+		},
+	}
+
+	testAccountDataWithFeeFrozen = AccountDataWithFeeFrozen{
+		Free:       NewU128(*big.NewInt(5)),
+		Reserved:   NewU128(*big.NewInt(6)),
+		MiscFrozen: NewU128(*big.NewInt(7)),
+		FeeFrozen:  NewU128(*big.NewInt(8)),
+	}
+
+	testAccountDataHex = "05000000000000000000000000000000" +
+		"06000000000000000000000000000000" +
+		"07000000000000000000000000000000" +
+		"08000000000000000000000000000000"
+)
+
+func TestAccountInfo_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[AccountInfo](t, 100)
+	AssertDecodeNilData[AccountInfo](t)
+	AssertEncodeEmptyObj[AccountInfo](t, 80)
+
+	AssertRoundTripFuzz[AccountInfoWithFeeFrozen](t, 100)
+	AssertEncodeEmptyObj[AccountInfoWithFeeFrozen](t, 80)
+
+	AssertRoundTripFuzz[AccountInfoWithDualRefCount](t, 100)
+	AssertEncodeEmptyObj[AccountInfoWithDualRefCount](t, 76)
+
+	AssertRoundTripFuzz[AccountInfoWithRefCount](t, 100)
+	AssertEncodeEmptyObj[AccountInfoWithRefCount](t, 72)
+}
+
+func TestAccountInfo_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testAccountInfo, MustHexDecodeString("0x01000000020000000300000004000000" + testAccountDataHex)},
+		{
+			AccountInfoWithFeeFrozen{Nonce: 1, Consumers: 2, Providers: 3, Sufficients: 4, Data: testAccountDataWithFeeFrozen},
+			MustHexDecodeString("0x01000000020000000300000004000000" + testAccountDataHex),
+		},
+		{
+			AccountInfoWithDualRefCount{Nonce: 1, Consumers: 2, Providers: 3, Data: testAccountDataWithFeeFrozen},
+			MustHexDecodeString("0x010000000200000003000000" + testAccountDataHex),
+		},
+		{
+			AccountInfoWithRefCount{Nonce: 1, RefCount: 2, Data: testAccountDataWithFeeFrozen},
+			MustHexDecodeString("0x0100000002000000" + testAccountDataHex),
+		},
+	})
+}
+
+func TestAccountInfo_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x01000000020000000300000004000000" + testAccountDataHex), testAccountInfo},
+		{
+			MustHexDecodeString("0x0100000002000000" + testAccountDataHex),
+			AccountInfoWithRefCount{Nonce: 1, RefCount: 2, Data: testAccountDataWithFeeFrozen},
+		},
+	})
+}
@@ -0,0 +1,361 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// InvalidTransactionKind is the reason why a transaction is invalid, as defined by InvalidTransaction in
+// sp_runtime::transaction_validity.
+type InvalidTransactionKind uint8
+
+const (
+	// InvalidTransactionCall signals that the call of the transaction is not expected.
+	InvalidTransactionCall InvalidTransactionKind = iota
+	// InvalidTransactionPayment signals that the sender is not able to pay the fees.
+	InvalidTransactionPayment
+	// InvalidTransactionFuture signals that the nonce is too high, the transaction is not yet valid.
+	InvalidTransactionFuture
+	// InvalidTransactionStale signals that the nonce is too low, the transaction is outdated.
+	InvalidTransactionStale
+	// InvalidTransactionBadProof signals that the signature is invalid, e.g. because of a wrong genesis hash,
+	// runtime version or mortal era.
+	InvalidTransactionBadProof
+	// InvalidTransactionAncientBirthBlock signals that the mortal era of the transaction has expired.
+	InvalidTransactionAncientBirthBlock
+	// InvalidTransactionExhaustsResources signals that the transaction would exhaust the resources of the block.
+	InvalidTransactionExhaustsResources
+	// InvalidTransactionCustom signals a runtime specific error, see InvalidTransaction.Custom.
+	InvalidTransactionCustom
+	// InvalidTransactionBadMandatory signals that a mandatory dispatch failed.
+	InvalidTransactionBadMandatory
+	// InvalidTransactionMandatoryValidation signals that a mandatory dispatch was submitted as transaction.
+	InvalidTransactionMandatoryValidation
+	// InvalidTransactionBadSigner signals that the signer is not allowed to send the transaction.
+	InvalidTransactionBadSigner
+	// InvalidTransactionIndeterminateImplicit signals that the implicit data of the transaction could not be
+	// determined.
+	InvalidTransactionIndeterminateImplicit
+	// InvalidTransactionUnknownOrigin signals that the origin of the transaction is unknown.
+	InvalidTransactionUnknownOrigin
+)
+
+func (k InvalidTransactionKind) String() string {
+	switch k {
+	case InvalidTransactionCall:
+		return "Call"
+	case InvalidTransactionPayment:
+		return "Payment"
+	case InvalidTransactionFuture:
+		return "Future"
+	case InvalidTransactionStale:
+		return "Stale"
+	case InvalidTransactionBadProof:
+		return "BadProof"
+	case InvalidTransactionAncientBirthBlock:
+		return "AncientBirthBlock"
+	case InvalidTransactionExhaustsResources:
+		return "ExhaustsResources"
+	case InvalidTransactionCustom:
+		return "Custom"
+	case InvalidTransactionBadMandatory:
+		return "BadMandatory"
+	case InvalidTransactionMandatoryValidation:
+		return "MandatoryValidation"
+	case InvalidTransactionBadSigner:
+		return "BadSigner"
+	case InvalidTransactionIndeterminateImplicit:
+		return "IndeterminateImplicit"
+	case InvalidTransactionUnknownOrigin:
+		return "UnknownOrigin"
+	default:
+		return fmt.Sprintf("InvalidTransactionKind(%d)", uint8(k))
+	}
+}
+
+// InvalidTransaction is returned if a transaction is invalid.
+type InvalidTransaction struct {
+	Kind InvalidTransactionKind
+	// Custom is the runtime specific error code, only set for InvalidTransactionCustom.
+	Custom U8
+}
+
+func (i *InvalidTransaction) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	i.Kind = InvalidTransactionKind(b)
+
+	if i.Kind > InvalidTransactionUnknownOrigin {
+		return fmt.Errorf("unsupported invalid transaction kind: %d", b)
+	}
+
+	if i.Kind == InvalidTransactionCustom {
+		return decoder.Decode(&i.Custom)
+	}
+
+	return nil
+}
+
+func (i InvalidTransaction) Encode(encoder scale.Encoder) error {
+	if err := encoder.PushByte(byte(i.Kind)); err != nil {
+		return err
+	}
+
+	if i.Kind == InvalidTransactionCustom {
+		return encoder.Encode(i.Custom)
+	}
+
+	return nil
+}
+
+func (i InvalidTransaction) String() string {
+	if i.Kind == InvalidTransactionCustom {
+		return fmt.Sprintf("Custom(%d)", i.Custom)
+	}
+
+	return i.Kind.String()
+}
+
+// UnknownTransactionKind is the reason why the validity of a transaction could not be determined, as defined by
+// UnknownTransaction in sp_runtime::transaction_validity.
+type UnknownTransactionKind uint8
+
+const (
+	// UnknownTransactionCannotLookup signals that a lookup, e.g. of the sender, failed.
+	UnknownTransactionCannotLookup UnknownTransactionKind = iota
+	// UnknownTransactionNoUnsignedValidator signals that no validator accepted the unsigned transaction.
+	UnknownTransactionNoUnsignedValidator
+	// UnknownTransactionCustom signals a runtime specific error, see UnknownTransaction.Custom.
+	UnknownTransactionCustom
+)
+
+func (k UnknownTransactionKind) String() string {
+	switch k {
+	case UnknownTransactionCannotLookup:
+		return "CannotLookup"
+	case UnknownTransactionNoUnsignedValidator:
+		return "NoUnsignedValidator"
+	case UnknownTransactionCustom:
+		return "Custom"
+	default:
+		return fmt.Sprintf("UnknownTransactionKind(%d)", uint8(k))
+	}
+}
+
+// UnknownTransaction is returned if the validity of a transaction could not be determined.
+type UnknownTransaction struct {
+	Kind UnknownTransactionKind
+	// Custom is the runtime specific error code, only set for UnknownTransactionCustom.
+	Custom U8
+}
+
+func (u *UnknownTransaction) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	u.Kind = UnknownTransactionKind(b)
+
+	if u.Kind > UnknownTransactionCustom {
+		return fmt.Errorf("unsupported unknown transaction kind: %d", b)
+	}
+
+	if u.Kind == UnknownTransactionCustom {
+		return decoder.Decode(&u.Custom)
+	}
+
+	return nil
+}
+
+func (u UnknownTransaction) Encode(encoder scale.Encoder) error {
+	if err := encoder.PushByte(byte(u.Kind)); err != nil {
+		return err
+	}
+
+	if u.Kind == UnknownTransactionCustom {
+		return encoder.Encode(u.Custom)
+	}
+
+	return nil
+}
+
+func (u UnknownTransaction) String() string {
+	if u.Kind == UnknownTransactionCustom {
+		return fmt.Sprintf("Custom(%d)", u.Custom)
+	}
+
+	return u.Kind.String()
+}
+
+// TransactionValidityError is returned by the runtime if a transaction is not valid and can't be included in a
+// block.
+type TransactionValidityError struct {
+	IsInvalid          bool
+	InvalidTransaction InvalidTransaction
+
+	IsUnknown          bool
+	UnknownTransaction UnknownTransaction
+}
+
+func (t *TransactionValidityError) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		t.IsInvalid = true
+
+		return decoder.Decode(&t.InvalidTransaction)
+	case 1:
+		t.IsUnknown = true
+
+		return decoder.Decode(&t.UnknownTransaction)
+	}
+
+	return fmt.Errorf("unsupported transaction validity error: %d", b)
+}
+
+func (t TransactionValidityError) Encode(encoder scale.Encoder) error {
+	switch {
+	case t.IsInvalid:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(t.InvalidTransaction)
+	case t.IsUnknown:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(t.UnknownTransaction)
+	}
+
+	return nil
+}
+
+func (t TransactionValidityError) String() string {
+	switch {
+	case t.IsInvalid:
+		return fmt.Sprintf("Invalid(%s)", t.InvalidTransaction)
+	case t.IsUnknown:
+		return fmt.Sprintf("Unknown(%s)", t.UnknownTransaction)
+	}
+
+	return ""
+}
+
+// DispatchOutcome is the outcome of the dispatch of an extrinsic.
+type DispatchOutcome struct {
+	IsOk bool
+
+	IsError bool
+	Error   DispatchError
+}
+
+func (d *DispatchOutcome) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		d.IsOk = true
+
+		return nil
+	case 1:
+		d.IsError = true
+
+		return decoder.Decode(&d.Error)
+	}
+
+	return fmt.Errorf("unsupported dispatch outcome: %d", b)
+}
+
+func (d DispatchOutcome) Encode(encoder scale.Encoder) error {
+	switch {
+	case d.IsOk:
+		return encoder.PushByte(0)
+	case d.IsError:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(d.Error)
+	}
+
+	return nil
+}
+
+// ApplyExtrinsicResult is the result of applying an extrinsic, as returned by system_dryRun. It is either a
+// DispatchOutcome if the extrinsic is valid, or a TransactionValidityError if it is not.
+type ApplyExtrinsicResult struct {
+	IsOk bool
+	Ok   DispatchOutcome
+
+	IsError bool
+	Error   TransactionValidityError
+}
+
+func (a *ApplyExtrinsicResult) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		a.IsOk = true
+
+		return decoder.Decode(&a.Ok)
+	case 1:
+		a.IsError = true
+
+		return decoder.Decode(&a.Error)
+	}
+
+	return fmt.Errorf("unsupported apply extrinsic result: %d", b)
+}
+
+func (a ApplyExtrinsicResult) Encode(encoder scale.Encoder) error {
+	switch {
+	case a.IsOk:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.Ok)
+	case a.IsError:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testApplyExtrinsicResultOk = ApplyExtrinsicResult{
+		IsOk: true,
+		Ok: DispatchOutcome{
+			IsOk: true,
+		},
+	}
+	testApplyExtrinsicResultDispatchError = ApplyExtrinsicResult{
+		IsOk: true,
+		Ok: DispatchOutcome{
+			IsError: true,
+			Error: DispatchError{
+				IsModule: true,
+				ModuleError: ModuleError{
+					Index: 5,
+					Error: [4]U8{2},
+				},
+			},
+		},
+	}
+	testApplyExtrinsicResultPayment = ApplyExtrinsicResult{
+		IsError: true,
+		Error: TransactionValidityError{
+			IsInvalid: true,
+			InvalidTransaction: InvalidTransaction{
+				Kind: InvalidTransactionPayment,
+			},
+		},
+	}
+	testApplyExtrinsicResultCustom = ApplyExtrinsicResult{
+		IsError: true,
+		Error: TransactionValidityError{
+			IsUnknown: true,
+			UnknownTransaction: UnknownTransaction{
+				Kind:   UnknownTransactionCustom,
+				Custom: 7,
+			},
+		},
+	}
+
+	invalidTransactionFuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(i *InvalidTransaction, c fuzz.Continue) {
+			i.Kind = InvalidTransactionKind(c.Intn(int(InvalidTransactionUnknownOrigin) + 1))
+
+			if i.Kind == InvalidTransactionCustom {
+				c.Fuzz(&i.Custom)
+			}
+		}),
+	}
+
+	unknownTransactionFuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(u *UnknownTransaction, c fuzz.Continue) {
+			u.Kind = UnknownTransactionKind(c.Intn(int(UnknownTransactionCustom) + 1))
+
+			if u.Kind == UnknownTransactionCustom {
+				c.Fuzz(&u.Custom)
+			}
+		}),
+	}
+
+	transactionValidityErrorFuzzOpts = CombineFuzzOpts(
+		invalidTransactionFuzzOpts,
+		unknownTransactionFuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(t *TransactionValidityError, c fuzz.Continue) {
+				if c.RandBool() {
+					t.IsInvalid = true
+					c.Fuzz(&t.InvalidTransaction)
+					return
+				}
+
+				t.IsUnknown = true
+				c.Fuzz(&t.UnknownTransaction)
+			}),
+		},
+	)
+
+	dispatchOutcomeFuzzOpts = CombineFuzzOpts(
+		dispatchErrorFuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(d *DispatchOutcome, c fuzz.Continue) {
+				if c.RandBool() {
+					d.IsOk = true
+					return
+				}
+
+				d.IsError = true
+				c.Fuzz(&d.Error)
+			}),
+		},
+	)
+
+	applyExtrinsicResultFuzzOpts = CombineFuzzOpts(
+		transactionValidityErrorFuzzOpts,
+		dispatchOutcomeFuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(a *ApplyExtrinsicResult, c fuzz.Continue) {
+				if c.RandBool() {
+					a.IsOk = true
+					c.Fuzz(&a.Ok)
+					return
+				}
+
+				a.IsError = true
+				c.Fuzz(&a.Error)
+			}),
+		},
+	)
+)
+
+func TestApplyExtrinsicResult_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[ApplyExtrinsicResult](t, 1000, applyExtrinsicResultFuzzOpts...)
+	AssertDecodeNilData[ApplyExtrinsicResult](t)
+	AssertEncodeEmptyObj[ApplyExtrinsicResult](t, 0)
+}
+
+func TestTransactionValidityError_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[TransactionValidityError](t, 1000, transactionValidityErrorFuzzOpts...)
+	AssertDecodeNilData[TransactionValidityError](t)
+	AssertEncodeEmptyObj[TransactionValidityError](t, 0)
+}
+
+func TestApplyExtrinsicResult_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testApplyExtrinsicResultOk, MustHexDecodeString("0x0000")},
+		{testApplyExtrinsicResultDispatchError, MustHexDecodeString("0x0001030502000000")},
+		{testApplyExtrinsicResultPayment, MustHexDecodeString("0x010001")},
+		{testApplyExtrinsicResultCustom, MustHexDecodeString("0x01010207")},
+	})
+}
+
+func TestApplyExtrinsicResult_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x0000"), testApplyExtrinsicResultOk},
+		{MustHexDecodeString("0x0001030502000000"), testApplyExtrinsicResultDispatchError},
+		{MustHexDecodeString("0x010001"), testApplyExtrinsicResultPayment},
+		{MustHexDecodeString("0x01010207"), testApplyExtrinsicResultCustom},
+	})
+}
+
+func TestApplyExtrinsicResult_DecodeUnsupported(t *testing.T) {
+	var res ApplyExtrinsicResult
+
+	assert.Error(t, Decode(MustHexDecodeString("0x02"), &res))
+	assert.Error(t, Decode(MustHexDecodeString("0x0002"), &res))
+	assert.Error(t, Decode(MustHexDecodeString("0x01000d"), &res))
+	assert.Error(t, Decode(MustHexDecodeString("0x010103"), &res))
+}
+
+func TestTransactionValidityError_String(t *testing.T) {
+	assert.Equal(t, "Invalid(Payment)", testApplyExtrinsicResultPayment.Error.String())
+	assert.Equal(t, "Unknown(Custom(7))", testApplyExtrinsicResultCustom.Error.String())
+	assert.Equal(t, "Invalid(Stale)", TransactionValidityError{
+		IsInvalid:          true,
+		InvalidTransaction: InvalidTransaction{Kind: InvalidTransactionStale},
+	}.String())
+	assert.Equal(t, "InvalidTransactionKind(42)", InvalidTransactionKind(42).String())
+}
@@ -0,0 +1,112 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// ArchiveStorageQueryItem is a single item of an archive_unstable_storage query
+type ArchiveStorageQueryItem struct {
+	Key  StorageKey
+	Type ChainHeadStorageQueryType
+	// PaginationStartKey is only valid for the descendants query types, only the descendants whose keys are greater
+	// than it are returned
+	PaginationStartKey StorageKey
+}
+
+// MarshalJSON returns a JSON encoded byte array of i
+func (i ArchiveStorageQueryItem) MarshalJSON() ([]byte, error) {
+	tmp := struct {
+		Key                string                    `json:"key"`
+		Type               ChainHeadStorageQueryType `json:"type"`
+		PaginationStartKey *string                   `json:"paginationStartKey,omitempty"`
+	}{
+		Key:  i.Key.Hex(),
+		Type: i.Type,
+	}
+
+	if len(i.PaginationStartKey) > 0 {
+		startKey := i.PaginationStartKey.Hex()
+		tmp.PaginationStartKey = &startKey
+	}
+
+	return json.Marshal(tmp)
+}
+
+// ArchiveStorageResult is the result of an archive_unstable_storage query.
+//
+// The node might discard items at the end of the query, e.g. when it is overloaded. DiscardedItems is their count,
+// they have to be queried again.
+type ArchiveStorageResult struct {
+	Items          []ChainHeadStorageResultItem `json:"result"`
+	DiscardedItems uint32                       `json:"discardedItems"`
+}
+
+// ArchiveCallResult is the result of an archive_unstable_call. Value holds the SCALE encoded output of the runtime
+// API call if Success is true, otherwise Error describes why it failed.
+type ArchiveCallResult struct {
+	Success bool
+	Value   Bytes
+	Error   string
+}
+
+type archiveCallResultJSON struct {
+	Success bool   `json:"success"`
+	Value   string `json:"value,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// UnmarshalJSON fills r with the JSON encoded byte array given by b
+func (r *ArchiveCallResult) UnmarshalJSON(b []byte) error {
+	var tmp archiveCallResultJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*r = ArchiveCallResult{
+		Success: tmp.Success,
+		Error:   tmp.Error,
+	}
+
+	if tmp.Value != "" {
+		value, err := codec.HexDecodeString(tmp.Value)
+		if err != nil {
+			return err
+		}
+
+		r.Value = value
+	}
+
+	return nil
+}
+
+// MarshalJSON returns a JSON encoded byte array of r
+func (r ArchiveCallResult) MarshalJSON() ([]byte, error) {
+	tmp := archiveCallResultJSON{
+		Success: r.Success,
+		Error:   r.Error,
+	}
+
+	if r.Success {
+		tmp.Value = codec.HexEncodeToString(r.Value)
+	}
+
+	return json.Marshal(tmp)
+}
@@ -0,0 +1,74 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveStorageQueryItem_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(ArchiveStorageQueryItem{
+		Key:  StorageKey{0x01, 0x02},
+		Type: ChainHeadStorageValue,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"key":"0x0102","type":"value"}`, string(b))
+
+	b, err = json.Marshal(ArchiveStorageQueryItem{
+		Key:                StorageKey{0x01},
+		Type:               ChainHeadStorageDescendantsValues,
+		PaginationStartKey: StorageKey{0x01, 0x05},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"key":"0x01","type":"descendantsValues","paginationStartKey":"0x0105"}`, string(b))
+}
+
+func TestArchiveStorageResult_UnmarshalJSON(t *testing.T) {
+	var res ArchiveStorageResult
+
+	err := json.Unmarshal([]byte(`{"result":[{"key":"0x0102","value":"0x0304"}],"discardedItems":1}`), &res)
+	assert.NoError(t, err)
+	assert.Equal(t, ArchiveStorageResult{
+		Items: []ChainHeadStorageResultItem{
+			{Key: StorageKey{0x01, 0x02}, Value: newStorageDataRawPtr(StorageDataRaw{0x03, 0x04})},
+		},
+		DiscardedItems: 1,
+	}, res)
+}
+
+func TestArchiveCallResult_JSON(t *testing.T) {
+	for _, test := range []struct {
+		json   string
+		result ArchiveCallResult
+	}{
+		{json: `{"success":true,"value":"0x0102"}`, result: ArchiveCallResult{Success: true, Value: Bytes{0x01, 0x02}}},
+		{json: `{"success":false,"error":"wasm trap"}`, result: ArchiveCallResult{Error: "wasm trap"}},
+	} {
+		var res ArchiveCallResult
+
+		assert.NoError(t, json.Unmarshal([]byte(test.json), &res))
+		assert.Equal(t, test.result, res)
+
+		b, err := json.Marshal(res)
+		assert.NoError(t, err)
+		assert.Equal(t, test.json, string(b))
+	}
+}
@@ -0,0 +1,87 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math/big"
+	"strings"
+)
+
+// DefaultBalancePrecision is the number of fractional digits Balance.Format shows.
+const DefaultBalancePrecision = 4
+
+// Balance is an amount of tokens in their smallest unit, e.g. planck for DOT. It is encoded as a u128.
+type Balance struct {
+	U128
+}
+
+// NewBalance creates a new Balance
+func NewBalance(i big.Int) Balance {
+	return Balance{NewU128(i)}
+}
+
+// Format returns the balance in whole tokens with up to DefaultBalancePrecision fractional digits, followed by the
+// symbol if it is not empty, e.g. "12.3456 DOT" for 123456000000 planck with 10 decimals.
+func (b Balance) Format(decimals uint32, symbol string) string {
+	return b.FormatWithPrecision(decimals, symbol, DefaultBalancePrecision)
+}
+
+// FormatWithPrecision is like Format but shows up to precision fractional digits, all of them if precision is
+// negative. Further digits are truncated and trailing zeros are omitted.
+func (b Balance) FormatWithPrecision(decimals uint32, symbol string, precision int) string {
+	amount := new(big.Int)
+	if b.Int != nil {
+		amount.Set(b.Int)
+	}
+
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	whole, fraction := new(big.Int).QuoRem(amount, unit, new(big.Int))
+
+	var sb strings.Builder
+
+	if amount.Sign() < 0 {
+		sb.WriteByte('-')
+		whole.Abs(whole)
+		fraction.Abs(fraction)
+	}
+
+	sb.WriteString(whole.String())
+
+	// The fraction is left padded with zeros to the number of decimals, e.g. 0001 for 0.0001 with 4 decimals.
+	var digits string
+
+	if decimals > 0 {
+		digits = fraction.String()
+		digits = strings.Repeat("0", int(decimals)-len(digits)) + digits
+	}
+
+	if precision >= 0 && precision < len(digits) {
+		digits = digits[:precision]
+	}
+
+	if digits = strings.TrimRight(digits, "0"); digits != "" {
+		sb.WriteByte('.')
+		sb.WriteString(digits)
+	}
+
+	if symbol != "" {
+		sb.WriteByte(' ')
+		sb.WriteString(symbol)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,48 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalance_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, NewBalance(*big.NewInt(123456000000)))
+	AssertEncode(t, []EncodingAssert{
+		{NewBalance(*big.NewInt(100_000_000_000_000)), MustHexDecodeString("0x00407a10f35a00000000000000000000")},
+	})
+}
+
+func TestBalance_Format(t *testing.T) {
+	assert.Equal(t, "12.3456 DOT", NewBalance(*big.NewInt(123_456_789_000)).Format(10, "DOT"))
+	assert.Equal(t, "12 DOT", NewBalance(*big.NewInt(120_000_000_000)).Format(10, "DOT"))
+	assert.Equal(t, "0.0001 KSM", NewBalance(*big.NewInt(100_000_000)).Format(12, "KSM"))
+	assert.Equal(t, "0 KSM", NewBalance(*big.NewInt(99_999_999)).Format(12, "KSM"))
+	assert.Equal(t, "42", NewBalance(*big.NewInt(42)).Format(0, ""))
+	assert.Equal(t, "0", Balance{}.Format(10, ""))
+
+	balance := NewBalance(*big.NewInt(123_456_789_000))
+	assert.Equal(t, "12.34 DOT", balance.FormatWithPrecision(10, "DOT", 2))
+	assert.Equal(t, "12 DOT", balance.FormatWithPrecision(10, "DOT", 0))
+	assert.Equal(t, "12.3456789 DOT", balance.FormatWithPrecision(10, "DOT", -1))
+}
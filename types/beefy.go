@@ -18,6 +18,8 @@
 package types
 
 import (
+	"fmt"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
@@ -218,3 +220,42 @@ func (o *OptionalSignedCommitment) SetNone() {
 	o.hasValue = false
 	o.value = SignedCommitment{}
 }
+
+// VersionedFinalityProof is a versioned BEEFY finality proof, as received via beefy_subscribeJustifications
+type VersionedFinalityProof struct {
+	IsV1 bool
+	AsV1 SignedCommitment
+}
+
+func (v *VersionedFinalityProof) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	if b != 1 {
+		return fmt.Errorf("unknown VersionedFinalityProof enum: %v", b)
+	}
+
+	v.IsV1 = true
+
+	return decoder.Decode(&v.AsV1)
+}
+
+func (v VersionedFinalityProof) Encode(encoder scale.Encoder) error {
+	if !v.IsV1 {
+		return nil
+	}
+
+	if err := encoder.PushByte(1); err != nil {
+		return err
+	}
+
+	return encoder.Encode(v.AsV1)
+}
+
+// UnmarshalText deserializes hex string into a VersionedFinalityProof.
+// Used for decoding JSON-RPC subscription messages (beefy_subscribeJustifications)
+func (v *VersionedFinalityProof) UnmarshalText(text []byte) error {
+	return codec.DecodeFromHex(string(text), v)
+}
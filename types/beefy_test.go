@@ -17,6 +17,7 @@
 package types_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -161,6 +162,34 @@ func TestSignedCommitment_EncodeDecode(t *testing.T) {
 	AssertRoundtrip(t, s)
 }
 
+func TestVersionedFinalityProof_Decode(t *testing.T) {
+	c, err := makeCommitment()
+	assert.NoError(t, err)
+
+	v := VersionedFinalityProof{
+		IsV1: true,
+		AsV1: SignedCommitment{
+			Commitment: *c,
+			Signatures: []OptionBeefySignature{
+				NewOptionBeefySignatureEmpty(),
+				NewOptionBeefySignatureEmpty(),
+				NewOptionBeefySignature(sig1),
+				NewOptionBeefySignature(sig2),
+			},
+		},
+	}
+
+	var decoded VersionedFinalityProof
+	err = json.Unmarshal([]byte(`"0x01046d68343048656c6c6f20576f726c642105000000000000000000000004300400000008558455ad81279df0795cc985580e4fb75d72d948d1107b2ac80a09abed4da8480c746cc321f2319a5e99a830e314d10dd3cd68ce3dc0c33c86e99bcb7816f9ba012d6e1f8105c337a86cdd9aaacdc496577f3db8c55ef9e6fd48f2c5c05a2274707491635d8ba3df64f324575b7b2a34487bca2324b6a0046395a71681be3d0c2a00"`), &decoded) //nolint:lll
+	assert.NoError(t, err)
+	assert.Equal(t, v, decoded)
+
+	AssertRoundtrip(t, v)
+
+	err = Decode(MustHexDecodeString("0x02"), &decoded)
+	assert.Error(t, err)
+}
+
 func TestBeefySignature_EncodeDecode(t *testing.T) {
 	AssertRoundTripFuzz[BeefySignature](t, 100)
 	AssertDecodeNilData[BeefySignature](t)
@@ -0,0 +1,289 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync/atomic"
+)
+
+// BigIntFormat is the format of U128, U256, I128 and I256 values in JSON and text, see SetBigIntFormat.
+type BigIntFormat uint32
+
+const (
+	// BigIntFormatDecimal formats values as decimal strings, e.g. "1000000000000".
+	BigIntFormatDecimal BigIntFormat = iota
+	// BigIntFormatHex formats values as hex strings with a 0x prefix, e.g. "0xe8d4a51000".
+	BigIntFormatHex
+)
+
+var (
+	ErrBigIntInvalid    = errors.New("invalid big integer")
+	ErrBigIntOutOfRange = errors.New("big integer out of range")
+
+	maxU128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	maxU256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	minI128 = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+	maxI128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	minI256 = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+	maxI256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+
+	bigIntFormat atomic.Uint32
+)
+
+// SetBigIntFormat sets the format of U128, U256, I128 and I256 values in JSON and text for the whole program. Values
+// are formatted as decimal strings by default, which unlike JSON numbers keep their precision in JavaScript clients.
+// Both formats are accepted when unmarshalling.
+func SetBigIntFormat(format BigIntFormat) {
+	bigIntFormat.Store(uint32(format))
+}
+
+// MarshalText returns the value as a decimal or hex string, see SetBigIntFormat.
+func (i U128) MarshalText() ([]byte, error) {
+	return marshalBigIntText(i.Int), nil
+}
+
+// UnmarshalText sets the value from a decimal or hex string.
+func (i *U128) UnmarshalText(b []byte) error {
+	v, err := unmarshalBigIntText(b, new(big.Int), maxU128)
+	if err != nil {
+		return err
+	}
+
+	*i = U128{v}
+
+	return nil
+}
+
+// MarshalJSON returns the value as a JSON string, see MarshalText.
+func (i U128) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(marshalBigIntText(i.Int)))
+}
+
+// UnmarshalJSON sets the value from a JSON string or number.
+func (i *U128) UnmarshalJSON(b []byte) error {
+	return unmarshalBigIntJSON(b, i.UnmarshalText)
+}
+
+// Value returns the value as a decimal string, which can be stored in a numeric column.
+func (i U128) Value() (driver.Value, error) {
+	return bigIntValue(i.Int), nil
+}
+
+// Scan sets the value from a database column, see Value.
+func (i *U128) Scan(src any) error {
+	return scanBigInt(src, i.UnmarshalText)
+}
+
+// MarshalText returns the value as a decimal or hex string, see SetBigIntFormat.
+func (i U256) MarshalText() ([]byte, error) {
+	return marshalBigIntText(i.Int), nil
+}
+
+// UnmarshalText sets the value from a decimal or hex string.
+func (i *U256) UnmarshalText(b []byte) error {
+	v, err := unmarshalBigIntText(b, new(big.Int), maxU256)
+	if err != nil {
+		return err
+	}
+
+	*i = U256{v}
+
+	return nil
+}
+
+// MarshalJSON returns the value as a JSON string, see MarshalText.
+func (i U256) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(marshalBigIntText(i.Int)))
+}
+
+// UnmarshalJSON sets the value from a JSON string or number.
+func (i *U256) UnmarshalJSON(b []byte) error {
+	return unmarshalBigIntJSON(b, i.UnmarshalText)
+}
+
+// Value returns the value as a decimal string, which can be stored in a numeric column.
+func (i U256) Value() (driver.Value, error) {
+	return bigIntValue(i.Int), nil
+}
+
+// Scan sets the value from a database column, see Value.
+func (i *U256) Scan(src any) error {
+	return scanBigInt(src, i.UnmarshalText)
+}
+
+// MarshalText returns the value as a decimal or hex string, see SetBigIntFormat.
+func (i I128) MarshalText() ([]byte, error) {
+	return marshalBigIntText(i.Int), nil
+}
+
+// UnmarshalText sets the value from a decimal or hex string.
+func (i *I128) UnmarshalText(b []byte) error {
+	v, err := unmarshalBigIntText(b, minI128, maxI128)
+	if err != nil {
+		return err
+	}
+
+	*i = I128{v}
+
+	return nil
+}
+
+// MarshalJSON returns the value as a JSON string, see MarshalText.
+func (i I128) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(marshalBigIntText(i.Int)))
+}
+
+// UnmarshalJSON sets the value from a JSON string or number.
+func (i *I128) UnmarshalJSON(b []byte) error {
+	return unmarshalBigIntJSON(b, i.UnmarshalText)
+}
+
+// Value returns the value as a decimal string, which can be stored in a numeric column.
+func (i I128) Value() (driver.Value, error) {
+	return bigIntValue(i.Int), nil
+}
+
+// Scan sets the value from a database column, see Value.
+func (i *I128) Scan(src any) error {
+	return scanBigInt(src, i.UnmarshalText)
+}
+
+// MarshalText returns the value as a decimal or hex string, see SetBigIntFormat.
+func (i I256) MarshalText() ([]byte, error) {
+	return marshalBigIntText(i.Int), nil
+}
+
+// UnmarshalText sets the value from a decimal or hex string.
+func (i *I256) UnmarshalText(b []byte) error {
+	v, err := unmarshalBigIntText(b, minI256, maxI256)
+	if err != nil {
+		return err
+	}
+
+	*i = I256{v}
+
+	return nil
+}
+
+// MarshalJSON returns the value as a JSON string, see MarshalText.
+func (i I256) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(marshalBigIntText(i.Int)))
+}
+
+// UnmarshalJSON sets the value from a JSON string or number.
+func (i *I256) UnmarshalJSON(b []byte) error {
+	return unmarshalBigIntJSON(b, i.UnmarshalText)
+}
+
+// Value returns the value as a decimal string, which can be stored in a numeric column.
+func (i I256) Value() (driver.Value, error) {
+	return bigIntValue(i.Int), nil
+}
+
+// Scan sets the value from a database column, see Value.
+func (i *I256) Scan(src any) error {
+	return scanBigInt(src, i.UnmarshalText)
+}
+
+func marshalBigIntText(i *big.Int) []byte {
+	if i == nil {
+		i = new(big.Int)
+	}
+
+	if BigIntFormat(bigIntFormat.Load()) != BigIntFormatHex {
+		return []byte(i.String())
+	}
+
+	if i.Sign() < 0 {
+		return []byte("-0x" + new(big.Int).Neg(i).Text(16))
+	}
+
+	return []byte("0x" + i.Text(16))
+}
+
+// unmarshalBigIntText parses a decimal or hex string and checks that it is within the range of the type.
+func unmarshalBigIntText(b []byte, minValue, maxValue *big.Int) (*big.Int, error) {
+	s := string(b)
+
+	negative := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(s, "-")
+
+	v, ok := new(big.Int), false
+
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		v, ok = v.SetString(digits[2:], 16)
+	} else {
+		v, ok = v.SetString(digits, 10)
+	}
+
+	// SetString accepts signs, which must not follow the prefix or the minus.
+	if !ok || strings.ContainsAny(digits, "+-") {
+		return nil, fmt.Errorf("%w: %q", ErrBigIntInvalid, s)
+	}
+
+	if negative {
+		v.Neg(v)
+	}
+
+	if v.Cmp(minValue) < 0 || v.Cmp(maxValue) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrBigIntOutOfRange, s)
+	}
+
+	return v, nil
+}
+
+// unmarshalBigIntJSON unmarshals a JSON string or number, null is ignored like by big.Int.
+func unmarshalBigIntJSON(b []byte, unmarshalText func([]byte) error) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var s string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return unmarshalText(b)
+	}
+
+	return unmarshalText([]byte(s))
+}
+
+func bigIntValue(i *big.Int) driver.Value {
+	if i == nil {
+		return "0"
+	}
+
+	return i.String()
+}
+
+func scanBigInt(src any, unmarshalText func([]byte) error) error {
+	switch v := src.(type) {
+	case string:
+		return unmarshalText([]byte(v))
+	case []byte:
+		return unmarshalText(v)
+	case int64:
+		return unmarshalText([]byte(fmt.Sprint(v)))
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrBigIntInvalid, src)
+	}
+}
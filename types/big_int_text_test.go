@@ -0,0 +1,173 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustBigInt(t *testing.T, s string) big.Int {
+	i, ok := new(big.Int).SetString(s, 0)
+	require.True(t, ok)
+
+	return *i
+}
+
+func TestBigInt_JSON(t *testing.T) {
+	maxU256 := mustBigInt(t, "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+	tests := []struct {
+		value    json.Marshaler
+		expected string
+		hex      string
+	}{
+		{NewU128(*big.NewInt(0)), `"0"`, `"0x0"`},
+		{
+			NewU128(mustBigInt(t, "170141183460469231731687303715884105727")),
+			`"170141183460469231731687303715884105727"`,
+			`"0x7fffffffffffffffffffffffffffffff"`,
+		},
+		{
+			NewU128(mustBigInt(t, "340282366920938463463374607431768211455")),
+			`"340282366920938463463374607431768211455"`,
+			`"0xffffffffffffffffffffffffffffffff"`,
+		},
+		{
+			NewU256(maxU256),
+			`"115792089237316195423570985008687907853269984665640564039457584007913129639935"`,
+			`"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"`,
+		},
+		{
+			NewI128(mustBigInt(t, "-170141183460469231731687303715884105728")),
+			`"-170141183460469231731687303715884105728"`,
+			`"-0x80000000000000000000000000000000"`,
+		},
+		{
+			NewI256(mustBigInt(t, "-0x8000000000000000000000000000000000000000000000000000000000000000")),
+			`"-57896044618658097711785492504343953926634992332820282019728792003956564819968"`,
+			`"-0x8000000000000000000000000000000000000000000000000000000000000000"`,
+		},
+	}
+
+	for _, test := range tests {
+		b, err := json.Marshal(test.value)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, string(b))
+
+		SetBigIntFormat(BigIntFormatHex)
+		b, err = json.Marshal(test.value)
+		SetBigIntFormat(BigIntFormatDecimal)
+		require.NoError(t, err)
+		assert.Equal(t, test.hex, string(b))
+
+		switch v := test.value.(type) {
+		case U128:
+			AssertJSONRoundTrip(t, &v)
+		case U256:
+			AssertJSONRoundTrip(t, &v)
+		case I128:
+			AssertJSONRoundTrip(t, &v)
+		case I256:
+			AssertJSONRoundTrip(t, &v)
+		}
+	}
+}
+
+func TestBigInt_UnmarshalJSON(t *testing.T) {
+	var u U128
+
+	b, err := json.Marshal(u)
+	require.NoError(t, err)
+	assert.Equal(t, `"0"`, string(b))
+
+	// Numbers, decimal and hex strings are accepted.
+	for _, s := range []string{`12345`, `"12345"`, `"0x3039"`} {
+		require.NoError(t, json.Unmarshal([]byte(s), &u))
+		assert.Equal(t, "12345", u.String())
+	}
+
+	var i I128
+
+	require.NoError(t, json.Unmarshal([]byte(`"-0x3039"`), &i))
+	assert.Equal(t, "-12345", i.String())
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"-1"`), &u), ErrBigIntOutOfRange)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"0x100000000000000000000000000000000"`), &u), ErrBigIntOutOfRange)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"170141183460469231731687303715884105728"`), &i), ErrBigIntOutOfRange)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"0x-1"`), &i), ErrBigIntInvalid)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"abc"`), &u), ErrBigIntInvalid)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`""`), &u), ErrBigIntInvalid)
+
+	// Values are text in struct fields and map keys.
+	var balances map[string]struct {
+		Free U256 `json:"free"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"alice": {"free": "0x10"}}`), &balances))
+	assert.Equal(t, "16", balances["alice"].Free.String())
+}
+
+func TestBigInt_Text(t *testing.T) {
+	b, err := NewI256(*big.NewInt(-42)).MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "-42", string(b))
+
+	var u U256
+	require.NoError(t, u.UnmarshalText([]byte("0xff")))
+	assert.Equal(t, "255", u.String())
+}
+
+func TestBigInt_SQL(t *testing.T) {
+	maxU128 := NewU128(mustBigInt(t, "340282366920938463463374607431768211455"))
+
+	value, err := maxU128.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "340282366920938463463374607431768211455", value)
+
+	// Hex is only used for JSON and text.
+	SetBigIntFormat(BigIntFormatHex)
+	value, err = NewI128(*big.NewInt(-1)).Value()
+	SetBigIntFormat(BigIntFormatDecimal)
+	require.NoError(t, err)
+	assert.Equal(t, "-1", value)
+
+	value, err = U256{}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "0", value)
+
+	var u U128
+	require.NoError(t, u.Scan("340282366920938463463374607431768211455"))
+	assert.Equal(t, maxU128.String(), u.String())
+
+	var i I256
+	require.NoError(t, i.Scan([]byte("-5")))
+	assert.Equal(t, "-5", i.String())
+
+	require.NoError(t, i.Scan(int64(7)))
+	assert.Equal(t, "7", i.String())
+
+	assert.ErrorIs(t, u.Scan(1.5), ErrBigIntInvalid)
+	assert.ErrorIs(t, u.Scan(nil), ErrBigIntInvalid)
+	assert.ErrorIs(t, u.Scan("-1"), ErrBigIntOutOfRange)
+}
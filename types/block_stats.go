@@ -0,0 +1,29 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// BlockStats are the stats of a block as returned by dev_getBlockStats
+type BlockStats struct {
+	// WitnessLen is the size of the storage proof that is needed to re-execute the block, in bytes
+	WitnessLen U64 `json:"witnessLen"`
+	// WitnessCompactLen is the size of the compacted storage proof, in bytes
+	WitnessCompactLen U64 `json:"witnessCompactLen"`
+	// BlockLen is the size of the SCALE encoded block, in bytes
+	BlockLen U64 `json:"blockLen"`
+	// NumExtrinsics is the number of extrinsics in the block
+	NumExtrinsics U64 `json:"numExtrinsics"`
+}
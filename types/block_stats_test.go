@@ -0,0 +1,38 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockStats_UnmarshalJSON(t *testing.T) {
+	var stats BlockStats
+
+	err := json.Unmarshal(
+		[]byte(`{"witnessLen": 41023, "witnessCompactLen": 39876, "blockLen": 1249, "numExtrinsics": 3}`),
+		&stats,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, BlockStats{WitnessLen: 41023, WitnessCompactLen: 39876, BlockLen: 1249, NumExtrinsics: 3}, stats)
+}
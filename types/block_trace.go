@@ -0,0 +1,347 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	// TraceApplyExtrinsicSpanName is the name of the span that frame-executive opens for every applied extrinsic
+	TraceApplyExtrinsicSpanName = "apply_extrinsic"
+
+	// TraceStorageKeyField is the field of storage events that holds the hex encoded storage key
+	TraceStorageKeyField = "key"
+)
+
+// BlockTrace is the trace of a block that is returned by state_traceBlock
+type BlockTrace struct {
+	// BlockHash is the hash of the traced block
+	BlockHash string `json:"blockHash"`
+	// ParentHash is the hash of the parent of the traced block
+	ParentHash string `json:"parentHash"`
+	// TracingTargets are the targets that were requested, e.g. "state,pallet"
+	TracingTargets string `json:"tracingTargets"`
+	// StorageKeys are the hex encoded storage key prefixes that were requested
+	StorageKeys string `json:"storageKeys"`
+	// Methods are the RPC methods that were requested
+	Methods string `json:"methods"`
+	// Spans are the spans that were recorded while executing the block
+	Spans []TraceSpan `json:"spans"`
+	// Events are the events that were recorded while executing the block
+	Events []TraceEvent `json:"events"`
+}
+
+// TraceSpan is a span of a BlockTrace
+type TraceSpan struct {
+	ID       uint64  `json:"id"`
+	ParentID *uint64 `json:"parentId"`
+	Name     string  `json:"name"`
+	Target   string  `json:"target"`
+	// Wasm is true if the span was recorded in the runtime
+	Wasm bool `json:"wasm"`
+}
+
+// TraceEvent is an event of a BlockTrace
+type TraceEvent struct {
+	Target   string         `json:"target"`
+	Data     TraceEventData `json:"data"`
+	ParentID *uint64        `json:"parentId"`
+}
+
+// TraceEventData holds the fields of a TraceEvent
+type TraceEventData struct {
+	StringValues map[string]string `json:"stringValues"`
+}
+
+// TraceError is returned by state_traceBlock instead of a BlockTrace if the block could not be traced
+type TraceError struct {
+	Message string `json:"error"`
+}
+
+func (e TraceError) Error() string {
+	return fmt.Sprintf("block trace error: %s", e.Message)
+}
+
+// DecodeTraceBlockResponse decodes the JSON response of state_traceBlock from r. Spans and events are decoded one at a
+// time, so that large traces do not need to be held in memory twice. A TraceError is returned if the node could not
+// trace the block.
+func DecodeTraceBlockResponse(r io.Reader) (*BlockTrace, error) {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	key, err := nextKey(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key {
+	case "traceError":
+		var traceErr TraceError
+		if err := dec.Decode(&traceErr); err != nil {
+			return nil, err
+		}
+
+		return nil, traceErr
+	case "blockTrace":
+		return decodeBlockTrace(dec)
+	default:
+		return nil, fmt.Errorf("unexpected trace block response variant %q", key)
+	}
+}
+
+func decodeBlockTrace(dec *json.Decoder) (*BlockTrace, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var trace BlockTrace
+
+	for dec.More() {
+		key, err := nextKey(dec)
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "blockHash":
+			err = dec.Decode(&trace.BlockHash)
+		case "parentHash":
+			err = dec.Decode(&trace.ParentHash)
+		case "tracingTargets":
+			err = dec.Decode(&trace.TracingTargets)
+		case "storageKeys":
+			err = dec.Decode(&trace.StorageKeys)
+		case "methods":
+			err = dec.Decode(&trace.Methods)
+		case "spans":
+			trace.Spans, err = decodeArray[TraceSpan](dec)
+		case "events":
+			trace.Events, err = decodeArray[TraceEvent](dec)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("decode block trace field %s: %w", key, err)
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	return &trace, nil
+}
+
+func decodeArray[T any](dec *json.Decoder) ([]T, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok == nil {
+		return nil, nil
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("expected [, got %v", tok)
+	}
+
+	res := make([]T, 0)
+
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return nil, err
+		}
+
+		res = append(res, item)
+	}
+
+	return res, expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+
+	return nil
+}
+
+func nextKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("expected object key, got %v", tok)
+	}
+
+	return key, nil
+}
+
+// ExtrinsicSpans returns the apply_extrinsic spans of the trace ordered by their ID, which is the order in which the
+// extrinsics were applied. Nested apply_extrinsic spans, e.g. the runtime span within the native one, are omitted.
+func (b *BlockTrace) ExtrinsicSpans() []TraceSpan {
+	spans := b.spansByID()
+
+	var res []TraceSpan
+
+	for _, span := range b.Spans {
+		if span.Name != TraceApplyExtrinsicSpanName || hasAncestorNamed(spans, span.ParentID, span.Name) {
+			continue
+		}
+
+		res = append(res, span)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
+	return res
+}
+
+// ForExtrinsic returns a trace that only holds the spans and events of the extrinsic with the given index within the
+// block, i.e. its apply_extrinsic span and everything recorded within it.
+func (b *BlockTrace) ForExtrinsic(index int) (*BlockTrace, error) {
+	extrinsics := b.ExtrinsicSpans()
+
+	if index < 0 || index >= len(extrinsics) {
+		return nil, fmt.Errorf("extrinsic %d not found in trace with %d extrinsics", index, len(extrinsics))
+	}
+
+	root := extrinsics[index].ID
+	spans := b.spansByID()
+
+	res := b.withoutSpansAndEvents()
+
+	for _, span := range b.Spans {
+		if span.ID == root || hasAncestor(spans, span.ParentID, root) {
+			res.Spans = append(res.Spans, span)
+		}
+	}
+
+	for _, event := range b.Events {
+		if hasAncestor(spans, event.ParentID, root) {
+			res.Events = append(res.Events, event)
+		}
+	}
+
+	return res, nil
+}
+
+// ForStorageKeyPrefix returns a trace that only holds the events that accessed a storage key with the given hex
+// encoded prefix, with or without 0x. Spans are kept, so that the events can still be related to their extrinsic.
+func (b *BlockTrace) ForStorageKeyPrefix(prefix string) *BlockTrace {
+	prefix = normalizeTraceHex(prefix)
+
+	res := b.withoutSpansAndEvents()
+	res.Spans = b.Spans
+
+	for _, event := range b.Events {
+		key, ok := event.Data.StringValues[TraceStorageKeyField]
+		if !ok {
+			continue
+		}
+
+		if strings.HasPrefix(normalizeTraceHex(key), prefix) {
+			res.Events = append(res.Events, event)
+		}
+	}
+
+	return res
+}
+
+func (b *BlockTrace) withoutSpansAndEvents() *BlockTrace {
+	return &BlockTrace{
+		BlockHash:      b.BlockHash,
+		ParentHash:     b.ParentHash,
+		TracingTargets: b.TracingTargets,
+		StorageKeys:    b.StorageKeys,
+		Methods:        b.Methods,
+		Spans:          make([]TraceSpan, 0),
+		Events:         make([]TraceEvent, 0),
+	}
+}
+
+func (b *BlockTrace) spansByID() map[uint64]TraceSpan {
+	spans := make(map[uint64]TraceSpan, len(b.Spans))
+
+	for _, span := range b.Spans {
+		spans[span.ID] = span
+	}
+
+	return spans
+}
+
+// hasAncestor returns true if the span with the given parent ID is, or descends from, the span with the ancestor ID
+func hasAncestor(spans map[uint64]TraceSpan, parentID *uint64, ancestor uint64) bool {
+	// the depth is limited by the number of spans to guard against cycles
+	for i := 0; parentID != nil && i <= len(spans); i++ {
+		if *parentID == ancestor {
+			return true
+		}
+
+		parent, ok := spans[*parentID]
+		if !ok {
+			return false
+		}
+
+		parentID = parent.ParentID
+	}
+
+	return false
+}
+
+func hasAncestorNamed(spans map[uint64]TraceSpan, parentID *uint64, name string) bool {
+	for i := 0; parentID != nil && i <= len(spans); i++ {
+		parent, ok := spans[*parentID]
+		if !ok {
+			return false
+		}
+
+		if parent.Name == name {
+			return true
+		}
+
+		parentID = parent.ParentID
+	}
+
+	return false
+}
+
+func normalizeTraceHex(s string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+}
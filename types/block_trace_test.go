@@ -0,0 +1,154 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTraceBlockResponse = `{"blockTrace":{
+	"blockHash":"0xa230d0b6dc75868237b08d71618f3d19526b8aa346d94c792a4fce0a945b1e3f",
+	"parentHash":"0x4b2ed7e4bb0bf0fb8a1cd3e2ad23ad4ccbb7b0f4a47c95a3ef63c1d13a0c7da6",
+	"tracingTargets":"state,pallet",
+	"storageKeys":"",
+	"methods":"",
+	"unknownField":{"ignored":[1,2]},
+	"spans":[
+		{"id":4,"parentId":1,"name":"apply_extrinsic","target":"frame_executive","wasm":false},
+		{"id":1,"parentId":null,"name":"execute_block","target":"frame_executive","wasm":false},
+		{"id":2,"parentId":1,"name":"apply_extrinsic","target":"frame_executive","wasm":false},
+		{"id":3,"parentId":2,"name":"apply_extrinsic","target":"frame_executive","wasm":true},
+		{"id":5,"parentId":4,"name":"transfer","target":"pallet_balances","wasm":true}
+	],
+	"events":[
+		{"target":"state","data":{"stringValues":{"key":"26aa394eea5630e07c48ae0c9558cef7","method":"Get"}},"parentId":3},
+		{"target":"state","data":{"stringValues":{"key":"26AA394EEA5630E07C48AE0C9558CEF702a5","method":"Put"}},"parentId":5},
+		{"target":"state","data":{"stringValues":{"key":"c2261276cc9d1f8598ea4b6a74b15c2f","method":"Get"}},"parentId":1},
+		{"target":"pallet_balances","data":{"stringValues":{"message":"transfer"}},"parentId":5}
+	]
+}}`
+
+func TestDecodeTraceBlockResponse(t *testing.T) {
+	trace, err := DecodeTraceBlockResponse(strings.NewReader(testTraceBlockResponse))
+	require.NoError(t, err)
+
+	assert.Equal(t, "0xa230d0b6dc75868237b08d71618f3d19526b8aa346d94c792a4fce0a945b1e3f", trace.BlockHash)
+	assert.Equal(t, "state,pallet", trace.TracingTargets)
+	assert.Len(t, trace.Spans, 5)
+	assert.Len(t, trace.Events, 4)
+
+	assert.Nil(t, trace.Spans[1].ParentID)
+	assert.Equal(t, TraceSpan{
+		ID:       3,
+		ParentID: uint64Ptr(2),
+		Name:     "apply_extrinsic",
+		Target:   "frame_executive",
+		Wasm:     true,
+	}, trace.Spans[3])
+	assert.Equal(t, TraceEvent{
+		Target:   "state",
+		Data:     TraceEventData{StringValues: map[string]string{"key": "c2261276cc9d1f8598ea4b6a74b15c2f", "method": "Get"}},
+		ParentID: uint64Ptr(1),
+	}, trace.Events[2])
+}
+
+func TestDecodeTraceBlockResponse_TraceError(t *testing.T) {
+	_, err := DecodeTraceBlockResponse(strings.NewReader(`{"traceError":{"error":"Invalid block hash"}}`))
+
+	var traceErr TraceError
+	require.True(t, errors.As(err, &traceErr))
+	assert.Equal(t, "Invalid block hash", traceErr.Message)
+}
+
+func TestDecodeTraceBlockResponse_Invalid(t *testing.T) {
+	for _, input := range []string{
+		``,
+		`[]`,
+		`{"unknown":{}}`,
+		`{"blockTrace":{"spans":{}}}`,
+		`{"blockTrace":{"spans":[{"id":"1"}]}}`,
+		`{"blockTrace":{"events":[]`,
+	} {
+		_, err := DecodeTraceBlockResponse(strings.NewReader(input))
+		assert.Error(t, err, input)
+	}
+}
+
+func TestBlockTrace_ExtrinsicSpans(t *testing.T) {
+	trace, err := DecodeTraceBlockResponse(strings.NewReader(testTraceBlockResponse))
+	require.NoError(t, err)
+
+	spans := trace.ExtrinsicSpans()
+	require.Len(t, spans, 2)
+	assert.EqualValues(t, 2, spans[0].ID)
+	assert.EqualValues(t, 4, spans[1].ID)
+}
+
+func TestBlockTrace_ForExtrinsic(t *testing.T) {
+	trace, err := DecodeTraceBlockResponse(strings.NewReader(testTraceBlockResponse))
+	require.NoError(t, err)
+
+	first, err := trace.ForExtrinsic(0)
+	require.NoError(t, err)
+	assert.Equal(t, trace.BlockHash, first.BlockHash)
+	assert.Equal(t, []uint64{2, 3}, spanIDs(first.Spans))
+	require.Len(t, first.Events, 1)
+	assert.Equal(t, "Get", first.Events[0].Data.StringValues["method"])
+
+	second, err := trace.ForExtrinsic(1)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{4, 5}, spanIDs(second.Spans))
+	assert.Len(t, second.Events, 2)
+
+	_, err = trace.ForExtrinsic(2)
+	assert.EqualError(t, err, "extrinsic 2 not found in trace with 2 extrinsics")
+
+	_, err = trace.ForExtrinsic(-1)
+	assert.Error(t, err)
+}
+
+func TestBlockTrace_ForStorageKeyPrefix(t *testing.T) {
+	trace, err := DecodeTraceBlockResponse(strings.NewReader(testTraceBlockResponse))
+	require.NoError(t, err)
+
+	filtered := trace.ForStorageKeyPrefix("0x26aa394eea5630e07c48ae0c9558cef7")
+	assert.Len(t, filtered.Spans, 5)
+	require.Len(t, filtered.Events, 2)
+	assert.Equal(t, "Get", filtered.Events[0].Data.StringValues["method"])
+	assert.Equal(t, "Put", filtered.Events[1].Data.StringValues["method"])
+
+	assert.Empty(t, trace.ForStorageKeyPrefix("ffff").Events)
+}
+
+func spanIDs(spans []TraceSpan) []uint64 {
+	ids := make([]uint64, len(spans))
+	for i, span := range spans {
+		ids[i] = span.ID
+	}
+
+	return ids
+}
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
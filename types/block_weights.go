@@ -0,0 +1,110 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PerDispatchClass holds a value for every dispatch class, see DispatchClass
+type PerDispatchClass[T any] struct {
+	Normal      T
+	Operational T
+	Mandatory   T
+}
+
+// Get returns the value of the dispatch class
+func (p PerDispatchClass[T]) Get(class DispatchClass) T {
+	switch {
+	case class.IsOperational:
+		return p.Operational
+	case class.IsMandatory:
+		return p.Mandatory
+	default:
+		return p.Normal
+	}
+}
+
+// WeightsPerClass are the weight limits of a dispatch class
+type WeightsPerClass struct {
+	// BaseExtrinsic is the base weight of a single extrinsic of the class
+	BaseExtrinsic Weight
+	// MaxExtrinsic is the maximum weight of a single extrinsic of the class, none if unlimited
+	MaxExtrinsic Option[Weight]
+	// MaxTotal is the maximum weight of all extrinsics of the class in a block, none if unlimited
+	MaxTotal Option[Weight]
+	// Reserved is the weight that is reserved for the class, even if the block is otherwise full
+	Reserved Option[Weight]
+}
+
+// BlockWeights are the weight limits of a block, as found in the System.BlockWeights constant
+type BlockWeights struct {
+	// BaseBlock is the base weight of a block
+	BaseBlock Weight
+	// MaxBlock is the maximum weight of a block
+	MaxBlock Weight
+	// PerClass holds the weight limits of every dispatch class
+	PerClass PerDispatchClass[WeightsPerClass]
+}
+
+// WeightsPerClassV1 are the weight limits of a dispatch class of runtimes that still used V1 weights
+type WeightsPerClassV1 struct {
+	BaseExtrinsic WeightV1
+	MaxExtrinsic  Option[WeightV1]
+	MaxTotal      Option[WeightV1]
+	Reserved      Option[WeightV1]
+}
+
+// ToWeightsPerClass converts the limits to V2 weights without a proof size
+func (w WeightsPerClassV1) ToWeightsPerClass() WeightsPerClass {
+	return WeightsPerClass{
+		BaseExtrinsic: w.BaseExtrinsic.ToWeight(),
+		MaxExtrinsic:  weightV1OptionToWeight(w.MaxExtrinsic),
+		MaxTotal:      weightV1OptionToWeight(w.MaxTotal),
+		Reserved:      weightV1OptionToWeight(w.Reserved),
+	}
+}
+
+// BlockWeightsV1 is the System.BlockWeights constant of runtimes that still used V1 weights
+type BlockWeightsV1 struct {
+	BaseBlock WeightV1
+	MaxBlock  WeightV1
+	PerClass  PerDispatchClass[WeightsPerClassV1]
+}
+
+// ToBlockWeights converts the limits to V2 weights without a proof size
+func (b BlockWeightsV1) ToBlockWeights() BlockWeights {
+	return BlockWeights{
+		BaseBlock: b.BaseBlock.ToWeight(),
+		MaxBlock:  b.MaxBlock.ToWeight(),
+		PerClass: PerDispatchClass[WeightsPerClass]{
+			Normal:      b.PerClass.Normal.ToWeightsPerClass(),
+			Operational: b.PerClass.Operational.ToWeightsPerClass(),
+			Mandatory:   b.PerClass.Mandatory.ToWeightsPerClass(),
+		},
+	}
+}
+
+func weightV1OptionToWeight(o Option[WeightV1]) Option[Weight] {
+	if ok, w := o.Unwrap(); ok {
+		return NewOption(w.ToWeight())
+	}
+
+	return NewEmptyOption[Weight]()
+}
+
+// BlockLength are the length limits of a block, as found in the System.BlockLength constant
+type BlockLength struct {
+	// Max is the maximum length of all extrinsics of a dispatch class in a block, in bytes
+	Max PerDispatchClass[U32]
+}
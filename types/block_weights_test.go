@@ -0,0 +1,102 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testBlockWeights = BlockWeights{
+		BaseBlock: NewWeight(NewUCompactFromUInt(100), NewUCompactFromUInt(0)),
+		MaxBlock:  NewWeight(NewUCompactFromUInt(2000), NewUCompactFromUInt(5000)),
+		PerClass: PerDispatchClass[WeightsPerClass]{
+			Normal: WeightsPerClass{
+				BaseExtrinsic: NewWeight(NewUCompactFromUInt(10), NewUCompactFromUInt(0)),
+				MaxExtrinsic:  NewOption(NewWeight(NewUCompactFromUInt(1000), NewUCompactFromUInt(3000))),
+				MaxTotal:      NewOption(NewWeight(NewUCompactFromUInt(1500), NewUCompactFromUInt(3750))),
+				Reserved:      NewEmptyOption[Weight](),
+			},
+			Operational: WeightsPerClass{
+				BaseExtrinsic: NewWeight(NewUCompactFromUInt(10), NewUCompactFromUInt(0)),
+				MaxTotal:      NewOption(NewWeight(NewUCompactFromUInt(2000), NewUCompactFromUInt(5000))),
+				Reserved:      NewOption(NewWeight(NewUCompactFromUInt(500), NewUCompactFromUInt(1250))),
+			},
+			Mandatory: WeightsPerClass{
+				BaseExtrinsic: NewWeight(NewUCompactFromUInt(10), NewUCompactFromUInt(0)),
+			},
+		},
+	}
+	testBlockWeightsHex = "0x910100411f214e280001a10fe12e017117993a0028000001411f214e01d10789132800000000"
+
+	testBlockLength = BlockLength{
+		Max: PerDispatchClass[U32]{Normal: 3932160, Operational: 5242880, Mandatory: 5242880},
+	}
+	testBlockLengthHex = "0x00003c000000500000005000"
+)
+
+func TestBlockWeights_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{Input: testBlockWeights, Expected: MustHexDecodeString(testBlockWeightsHex)},
+		{Input: testBlockLength, Expected: MustHexDecodeString(testBlockLengthHex)},
+	})
+}
+
+func TestBlockWeights_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{Input: MustHexDecodeString(testBlockWeightsHex), Expected: testBlockWeights},
+		{Input: MustHexDecodeString(testBlockLengthHex), Expected: testBlockLength},
+	})
+}
+
+func TestBlockWeightsV1_ToBlockWeights(t *testing.T) {
+	weightsV1 := BlockWeightsV1{
+		BaseBlock: NewWeightV1(100),
+		MaxBlock:  NewWeightV1(2000),
+		PerClass: PerDispatchClass[WeightsPerClassV1]{
+			Normal: WeightsPerClassV1{
+				BaseExtrinsic: NewWeightV1(10),
+				MaxExtrinsic:  NewOption(NewWeightV1(1000)),
+				MaxTotal:      NewOption(NewWeightV1(1500)),
+				Reserved:      NewEmptyOption[WeightV1](),
+			},
+		},
+	}
+
+	AssertRoundtrip(t, weightsV1)
+
+	weights := weightsV1.ToBlockWeights()
+
+	assert.Equal(t, NewWeight(NewUCompactFromUInt(2000), NewUCompactFromUInt(0)), weights.MaxBlock)
+	assert.Equal(t, testBlockWeights.PerClass.Normal.BaseExtrinsic, weights.PerClass.Normal.BaseExtrinsic)
+	assert.Equal(t, NewOption(NewWeight(NewUCompactFromUInt(1500), NewUCompactFromUInt(0))), weights.PerClass.Normal.MaxTotal)
+	assert.Equal(t, NewEmptyOption[Weight](), weights.PerClass.Normal.Reserved)
+	assert.Equal(t, NewEmptyOption[Weight](), weights.PerClass.Mandatory.MaxExtrinsic)
+}
+
+func TestPerDispatchClass_Get(t *testing.T) {
+	p := PerDispatchClass[U32]{Normal: 1, Operational: 2, Mandatory: 3}
+
+	assert.Equal(t, U32(1), p.Get(DispatchClass{IsNormal: true}))
+	assert.Equal(t, U32(2), p.Get(DispatchClass{IsOperational: true}))
+	assert.Equal(t, U32(3), p.Get(DispatchClass{IsMandatory: true}))
+}
@@ -0,0 +1,156 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	fuzz "github.com/google/gofuzz"
+)
+
+var (
+	testBodyIDV3n1 = BodyIDV3{
+		IsMoniker: true,
+		Moniker:   [4]U8{'t', 'e', 's', 't'},
+	}
+	testBodyIDV3n2 = BodyIDV3{
+		IsIndex: true,
+		Index:   NewUCompactFromUInt(64),
+	}
+	testBodyIDV3n3 = BodyIDV3{
+		IsTreasury: true,
+	}
+
+	bodyIDV3FuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(b *BodyIDV3, c fuzz.Continue) {
+			switch c.Intn(10) {
+			case 0:
+				b.IsUnit = true
+			case 1:
+				b.IsMoniker = true
+
+				c.Fuzz(&b.Moniker)
+			case 2:
+				b.IsIndex = true
+
+				c.Fuzz(&b.Index)
+			case 3:
+				b.IsExecutive = true
+			case 4:
+				b.IsTechnical = true
+			case 5:
+				b.IsLegislative = true
+			case 6:
+				b.IsJudicial = true
+			case 7:
+				b.IsDefense = true
+			case 8:
+				b.IsAdministration = true
+			case 9:
+				b.IsTreasury = true
+			}
+		}),
+	}
+)
+
+func TestBodyIDV3_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[BodyIDV3](t, 100, bodyIDV3FuzzOpts...)
+	AssertDecodeNilData[BodyIDV3](t)
+	AssertEncodeEmptyObj[BodyIDV3](t, 0)
+}
+
+func TestBodyIDV3_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testBodyIDV3n1, MustHexDecodeString("0x0174657374")},
+		{testBodyIDV3n2, MustHexDecodeString("0x020101")},
+		{testBodyIDV3n3, MustHexDecodeString("0x09")},
+	})
+}
+
+func TestBodyIDV3_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x0174657374"), testBodyIDV3n1},
+		{MustHexDecodeString("0x020101"), testBodyIDV3n2},
+		{MustHexDecodeString("0x09"), testBodyIDV3n3},
+	})
+}
+
+var (
+	testBodyPartV3n1 = BodyPartV3{
+		IsMembers:    true,
+		MembersCount: NewUCompactFromUInt(3),
+	}
+	testBodyPartV3n2 = BodyPartV3{
+		IsFraction:    true,
+		FractionNom:   NewUCompactFromUInt(1),
+		FractionDenom: NewUCompactFromUInt(2),
+	}
+
+	bodyPartV3FuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(b *BodyPartV3, c fuzz.Continue) {
+			switch c.Intn(5) {
+			case 0:
+				b.IsVoice = true
+			case 1:
+				b.IsMembers = true
+
+				c.Fuzz(&b.MembersCount)
+			case 2:
+				b.IsFraction = true
+
+				c.Fuzz(&b.FractionNom)
+
+				c.Fuzz(&b.FractionDenom)
+			case 3:
+				b.IsAtLeastProportion = true
+
+				c.Fuzz(&b.AtLeastProportionNom)
+
+				c.Fuzz(&b.AtLeastProportionDenom)
+			case 4:
+				b.IsMoreThanProportion = true
+
+				c.Fuzz(&b.MoreThanProportionNom)
+
+				c.Fuzz(&b.MoreThanProportionDenom)
+			}
+		}),
+	}
+)
+
+func TestBodyPartV3_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[BodyPartV3](t, 100, bodyPartV3FuzzOpts...)
+	AssertDecodeNilData[BodyPartV3](t)
+	AssertEncodeEmptyObj[BodyPartV3](t, 0)
+}
+
+func TestBodyPartV3_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testBodyPartV3n1, MustHexDecodeString("0x010c")},
+		{testBodyPartV3n2, MustHexDecodeString("0x020408")},
+	})
+}
+
+func TestBodyPartV3_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x010c"), testBodyPartV3n1},
+		{MustHexDecodeString("0x020408"), testBodyPartV3n2},
+	})
+}
@@ -0,0 +1,166 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+var ErrBoundExceeded = errors.New("bound exceeded")
+
+// Bound is the maximum length of a bounded collection, like the Get<u32> bound of BoundedVec<T, S> in Rust. Bounds are
+// types without fields, e.g.
+//
+//	type MaxNameLength struct{}
+//
+//	func (MaxNameLength) MaxLength() uint32 { return 64 }
+type Bound interface {
+	MaxLength() uint32
+}
+
+// BoundedVec is a slice of at most B.MaxLength() items, like BoundedVec<T, S> in Rust. It is encoded like a Vec<T>,
+// encoding and decoding fail with ErrBoundExceeded if the length exceeds the bound. The length is checked before the
+// items are allocated.
+//
+// WeakBoundedVec<T, S> values can exceed their bound, they have to be decoded as plain slices.
+type BoundedVec[T any, B Bound] []T
+
+func (v *BoundedVec[T, B]) Decode(decoder scale.Decoder) error {
+	length, err := decodeBoundedLength[B](decoder)
+	if err != nil {
+		return err
+	}
+
+	items := make([]T, length)
+
+	for i := range items {
+		if err := decoder.Decode(&items[i]); err != nil {
+			return err
+		}
+	}
+
+	*v = items
+
+	return nil
+}
+
+func (v BoundedVec[T, B]) Encode(encoder scale.Encoder) error {
+	if err := checkBound[B](len(v)); err != nil {
+		return err
+	}
+
+	return encoder.Encode([]T(v))
+}
+
+// BoundedBytes is a byte slice of at most B.MaxLength() bytes, like BoundedVec<u8, S> in Rust, see BoundedVec.
+type BoundedBytes[B Bound] []byte
+
+func (b *BoundedBytes[B]) Decode(decoder scale.Decoder) error {
+	bz, err := decodeBoundedBytes[B](decoder)
+	if err != nil {
+		return err
+	}
+
+	*b = bz
+
+	return nil
+}
+
+func (b BoundedBytes[B]) Encode(encoder scale.Encoder) error {
+	if err := checkBound[B](len(b)); err != nil {
+		return err
+	}
+
+	return encoder.Encode([]byte(b))
+}
+
+// BoundedText is a string of at most B.MaxLength() bytes, like a BoundedVec<u8, S> in Rust that holds UTF-8, see
+// BoundedVec.
+type BoundedText[B Bound] string
+
+func (t *BoundedText[B]) Decode(decoder scale.Decoder) error {
+	bz, err := decodeBoundedBytes[B](decoder)
+	if err != nil {
+		return err
+	}
+
+	*t = BoundedText[B](bz)
+
+	return nil
+}
+
+func (t BoundedText[B]) Encode(encoder scale.Encoder) error {
+	if err := checkBound[B](len(t)); err != nil {
+		return err
+	}
+
+	return encoder.Encode(string(t))
+}
+
+func checkBound[B Bound](length int) error {
+	var bound B
+
+	if uint64(length) > uint64(bound.MaxLength()) {
+		return fmt.Errorf("%w: length %d > %d", ErrBoundExceeded, length, bound.MaxLength())
+	}
+
+	return nil
+}
+
+// decodeBoundedLength decodes the compact length of a bounded collection and checks it against the bound.
+func decodeBoundedLength[B Bound](decoder scale.Decoder) (int, error) {
+	length, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return 0, err
+	}
+
+	var bound B
+
+	if !length.IsUint64() || length.Uint64() > uint64(bound.MaxLength()) {
+		return 0, fmt.Errorf("%w: length %s > %d", ErrBoundExceeded, length, bound.MaxLength())
+	}
+
+	return int(length.Uint64()), nil
+}
+
+// decodeBoundedBytes decodes the bytes of a bounded collection, which also honour the max bytes length of the
+// decoder.
+func decodeBoundedBytes[B Bound](decoder scale.Decoder) ([]byte, error) {
+	length, err := decodeBoundedLength[B](decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := decoder.CheckBytesLength(uint64(length)); err != nil {
+		return nil, err
+	}
+
+	bz := make([]byte, length)
+
+	if length == 0 {
+		return bz, nil
+	}
+
+	if err := decoder.Read(bz); err != nil {
+		return nil, err
+	}
+
+	return bz, nil
+}
@@ -0,0 +1,105 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type testBound struct{}
+
+func (testBound) MaxLength() uint32 { return 4 }
+
+var boundedFuzzOpts = []FuzzOpt{WithNilChance(0), WithNumElements(0, 4)}
+
+func TestBoundedVec_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[BoundedVec[U32, testBound]](t, 100, boundedFuzzOpts...)
+	AssertRoundTripFuzz[BoundedBytes[testBound]](t, 100, boundedFuzzOpts...)
+	AssertDecodeNilData[BoundedVec[U32, testBound]](t)
+	AssertDecodeNilData[BoundedBytes[testBound]](t)
+	AssertDecodeNilData[BoundedText[testBound]](t)
+	AssertEncodeEmptyObj[BoundedVec[U32, testBound]](t, 1)
+	AssertEncodeEmptyObj[BoundedBytes[testBound]](t, 1)
+	AssertEncodeEmptyObj[BoundedText[testBound]](t, 1)
+}
+
+func TestBoundedVec_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{BoundedVec[U32, testBound]{1, 2}, MustHexDecodeString("0x080100000002000000")},
+		{BoundedBytes[testBound]{1, 2, 3, 4}, MustHexDecodeString("0x1001020304")},
+		{BoundedText[testBound]("abc"), MustHexDecodeString("0x0c616263")},
+	})
+
+	for _, value := range []any{
+		BoundedVec[U32, testBound]{1, 2, 3, 4, 5},
+		BoundedBytes[testBound]{1, 2, 3, 4, 5},
+		BoundedText[testBound]("abcde"),
+	} {
+		_, err := Encode(value)
+		assert.ErrorIs(t, err, ErrBoundExceeded)
+	}
+}
+
+func TestBoundedVec_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x080100000002000000"), BoundedVec[U32, testBound]{1, 2}},
+		{MustHexDecodeString("0x1001020304"), BoundedBytes[testBound]{1, 2, 3, 4}},
+		{MustHexDecodeString("0x00"), BoundedBytes[testBound]{}},
+		{MustHexDecodeString("0x0c616263"), BoundedText[testBound]("abc")},
+	})
+
+	var vec BoundedVec[U32, testBound]
+	assert.ErrorIs(t, Decode(MustHexDecodeString("0x14"), &vec), ErrBoundExceeded)
+
+	var bz BoundedBytes[testBound]
+	assert.ErrorIs(t, Decode(MustHexDecodeString("0x140102030405"), &bz), ErrBoundExceeded)
+
+	// The length is checked before the bytes are allocated.
+	var text BoundedText[testBound]
+	assert.ErrorIs(t, Decode(MustHexDecodeString("0xfeffffff"), &text), ErrBoundExceeded)
+
+	// Truncated data.
+	assert.Error(t, Decode(MustHexDecodeString("0x0c6162"), &text))
+}
+
+func TestDecodeWithMaxBytesLength(t *testing.T) {
+	var text Text
+	assert.NoError(t, DecodeWithMaxBytesLength(MustHexDecodeString("0x0c616263"), &text, 3))
+	assert.Equal(t, NewText("abc"), text)
+
+	// A corrupt length of a gigabyte does not allocate a gigabyte.
+	err := DecodeWithMaxBytesLength(MustHexDecodeString("0x03000000400000"), &text, 3)
+	assert.ErrorIs(t, err, scale.ErrMaxBytesLengthExceeded)
+
+	var bz Bytes
+	err = DecodeWithMaxBytesLength(MustHexDecodeString("0x1001020304"), &bz, 3)
+	assert.ErrorIs(t, err, scale.ErrMaxBytesLengthExceeded)
+
+	var bounded BoundedBytes[testBound]
+	err = DecodeWithMaxBytesLength(MustHexDecodeString("0x1001020304"), &bounded, 3)
+	assert.ErrorIs(t, err, scale.ErrMaxBytesLengthExceeded)
+
+	// Other slices are not limited.
+	var numbers []U16
+	assert.NoError(t, DecodeWithMaxBytesLength(MustHexDecodeString("0x100100020003000400"), &numbers, 3))
+}
@@ -0,0 +1,314 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// ChainHeadFollowEventType is the type of event received via the chainHead_v1_follow subscription
+type ChainHeadFollowEventType string
+
+const (
+	ChainHeadInitialized                 ChainHeadFollowEventType = "initialized"
+	ChainHeadNewBlock                    ChainHeadFollowEventType = "newBlock"
+	ChainHeadBestBlockChanged            ChainHeadFollowEventType = "bestBlockChanged"
+	ChainHeadFinalized                   ChainHeadFollowEventType = "finalized"
+	ChainHeadOperationBodyDone           ChainHeadFollowEventType = "operationBodyDone"
+	ChainHeadOperationCallDone           ChainHeadFollowEventType = "operationCallDone"
+	ChainHeadOperationStorageItems       ChainHeadFollowEventType = "operationStorageItems"
+	ChainHeadOperationWaitingForContinue ChainHeadFollowEventType = "operationWaitingForContinue"
+	ChainHeadOperationStorageDone        ChainHeadFollowEventType = "operationStorageDone"
+	ChainHeadOperationInaccessible       ChainHeadFollowEventType = "operationInaccessible"
+	ChainHeadOperationError              ChainHeadFollowEventType = "operationError"
+	ChainHeadStop                        ChainHeadFollowEventType = "stop"
+)
+
+// ChainHeadFollowEvent is an event received via the chainHead_v1_follow subscription.
+//
+// Only the fields that belong to the Event type are set.
+type ChainHeadFollowEvent struct {
+	Event ChainHeadFollowEventType
+
+	// FinalizedBlockHashes is set for initialized and finalized events
+	FinalizedBlockHashes []Hash
+	// FinalizedBlockRuntime is set for initialized events if the subscription was created with runtime updates
+	FinalizedBlockRuntime *ChainHeadRuntimeEvent
+
+	// BlockHash, ParentBlockHash and NewRuntime are set for newBlock events
+	BlockHash       Hash
+	ParentBlockHash Hash
+	NewRuntime      *ChainHeadRuntimeEvent
+
+	// BestBlockHash is set for bestBlockChanged events
+	BestBlockHash Hash
+
+	// PrunedBlockHashes is set for finalized events
+	PrunedBlockHashes []Hash
+
+	// OperationID is set for all operation events
+	OperationID string
+	// Extrinsics holds the SCALE encoded extrinsics of an operationBodyDone event
+	Extrinsics []Bytes
+	// Output holds the SCALE encoded result of an operationCallDone event
+	Output Bytes
+	// Items holds the storage items of an operationStorageItems event
+	Items []ChainHeadStorageResultItem
+	// Error is set for operationError events
+	Error string
+}
+
+// IsOperationEvent returns true if the event belongs to an operation started via the follow subscription
+func (e ChainHeadFollowEvent) IsOperationEvent() bool {
+	switch e.Event {
+	case ChainHeadOperationBodyDone, ChainHeadOperationCallDone, ChainHeadOperationStorageItems,
+		ChainHeadOperationWaitingForContinue, ChainHeadOperationStorageDone, ChainHeadOperationInaccessible,
+		ChainHeadOperationError:
+		return true
+	default:
+		return false
+	}
+}
+
+type chainHeadFollowEventJSON struct {
+	Event                 ChainHeadFollowEventType     `json:"event"`
+	FinalizedBlockHashes  []Hash                       `json:"finalizedBlockHashes,omitempty"`
+	FinalizedBlockRuntime *ChainHeadRuntimeEvent       `json:"finalizedBlockRuntime,omitempty"`
+	BlockHash             *Hash                        `json:"blockHash,omitempty"`
+	ParentBlockHash       *Hash                        `json:"parentBlockHash,omitempty"`
+	NewRuntime            *ChainHeadRuntimeEvent       `json:"newRuntime,omitempty"`
+	BestBlockHash         *Hash                        `json:"bestBlockHash,omitempty"`
+	PrunedBlockHashes     []Hash                       `json:"prunedBlockHashes,omitempty"`
+	OperationID           string                       `json:"operationId,omitempty"`
+	Value                 []string                     `json:"value,omitempty"`
+	Output                string                       `json:"output,omitempty"`
+	Items                 []ChainHeadStorageResultItem `json:"items,omitempty"`
+	Error                 string                       `json:"error,omitempty"`
+}
+
+// UnmarshalJSON fills e with the JSON encoded byte array given by b
+func (e *ChainHeadFollowEvent) UnmarshalJSON(b []byte) error {
+	var tmp chainHeadFollowEventJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = ChainHeadFollowEvent{
+		Event:                 tmp.Event,
+		FinalizedBlockHashes:  tmp.FinalizedBlockHashes,
+		FinalizedBlockRuntime: tmp.FinalizedBlockRuntime,
+		NewRuntime:            tmp.NewRuntime,
+		PrunedBlockHashes:     tmp.PrunedBlockHashes,
+		OperationID:           tmp.OperationID,
+		Items:                 tmp.Items,
+		Error:                 tmp.Error,
+	}
+
+	if tmp.BlockHash != nil {
+		e.BlockHash = *tmp.BlockHash
+	}
+	if tmp.ParentBlockHash != nil {
+		e.ParentBlockHash = *tmp.ParentBlockHash
+	}
+	if tmp.BestBlockHash != nil {
+		e.BestBlockHash = *tmp.BestBlockHash
+	}
+
+	if tmp.Value != nil {
+		e.Extrinsics = make([]Bytes, len(tmp.Value))
+		for i, xt := range tmp.Value {
+			bz, err := codec.HexDecodeString(xt)
+			if err != nil {
+				return err
+			}
+			e.Extrinsics[i] = bz
+		}
+	}
+
+	if tmp.Output != "" {
+		bz, err := codec.HexDecodeString(tmp.Output)
+		if err != nil {
+			return err
+		}
+		e.Output = bz
+	}
+
+	return nil
+}
+
+// MarshalJSON returns a JSON encoded byte array of e
+func (e ChainHeadFollowEvent) MarshalJSON() ([]byte, error) {
+	tmp := chainHeadFollowEventJSON{
+		Event:                 e.Event,
+		FinalizedBlockHashes:  e.FinalizedBlockHashes,
+		FinalizedBlockRuntime: e.FinalizedBlockRuntime,
+		NewRuntime:            e.NewRuntime,
+		PrunedBlockHashes:     e.PrunedBlockHashes,
+		OperationID:           e.OperationID,
+		Items:                 e.Items,
+		Error:                 e.Error,
+	}
+
+	switch e.Event {
+	case ChainHeadNewBlock:
+		tmp.BlockHash = &e.BlockHash
+		tmp.ParentBlockHash = &e.ParentBlockHash
+	case ChainHeadBestBlockChanged:
+		tmp.BestBlockHash = &e.BestBlockHash
+	case ChainHeadOperationBodyDone:
+		tmp.Value = make([]string, len(e.Extrinsics))
+		for i, xt := range e.Extrinsics {
+			tmp.Value[i] = codec.HexEncodeToString(xt)
+		}
+	case ChainHeadOperationCallDone:
+		tmp.Output = codec.HexEncodeToString(e.Output)
+	}
+
+	return json.Marshal(tmp)
+}
+
+// ChainHeadRuntimeEvent describes the runtime of a block reported via the chainHead_v1_follow subscription
+type ChainHeadRuntimeEvent struct {
+	// Type is either "valid" or "invalid"
+	Type  string                `json:"type"`
+	Spec  *ChainHeadRuntimeSpec `json:"spec,omitempty"`
+	Error string                `json:"error,omitempty"`
+}
+
+// ChainHeadRuntimeSpec holds the specification of a valid runtime
+type ChainHeadRuntimeSpec struct {
+	SpecName           string         `json:"specName"`
+	ImplName           string         `json:"implName"`
+	SpecVersion        U32            `json:"specVersion"`
+	ImplVersion        U32            `json:"implVersion"`
+	TransactionVersion U32            `json:"transactionVersion"`
+	APIs               map[string]U32 `json:"apis"`
+}
+
+// ChainHeadStorageQueryType is the type of a storage query made via chainHead_v1_storage
+type ChainHeadStorageQueryType string
+
+const (
+	ChainHeadStorageValue                        ChainHeadStorageQueryType = "value"
+	ChainHeadStorageHash                         ChainHeadStorageQueryType = "hash"
+	ChainHeadStorageClosestDescendantMerkleValue ChainHeadStorageQueryType = "closestDescendantMerkleValue"
+	ChainHeadStorageDescendantsValues            ChainHeadStorageQueryType = "descendantsValues"
+	ChainHeadStorageDescendantsHashes            ChainHeadStorageQueryType = "descendantsHashes"
+)
+
+// ChainHeadStorageQueryItem is a single item of a chainHead_v1_storage query
+type ChainHeadStorageQueryItem struct {
+	Key  StorageKey
+	Type ChainHeadStorageQueryType
+}
+
+// MarshalJSON returns a JSON encoded byte array of i
+func (i ChainHeadStorageQueryItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Key  string                    `json:"key"`
+		Type ChainHeadStorageQueryType `json:"type"`
+	}{
+		Key:  i.Key.Hex(),
+		Type: i.Type,
+	})
+}
+
+// ChainHeadStorageResultItem is a single storage item reported for a chainHead_v1_storage operation.
+//
+// Only the field that corresponds to the query type of the item is set.
+type ChainHeadStorageResultItem struct {
+	Key                          StorageKey
+	Value                        *StorageDataRaw
+	Hash                         *Hash
+	ClosestDescendantMerkleValue *Bytes
+}
+
+type chainHeadStorageResultItemJSON struct {
+	Key                          string  `json:"key"`
+	Value                        *string `json:"value,omitempty"`
+	Hash                         *Hash   `json:"hash,omitempty"`
+	ClosestDescendantMerkleValue *string `json:"closestDescendantMerkleValue,omitempty"`
+}
+
+// UnmarshalJSON fills i with the JSON encoded byte array given by b
+func (i *ChainHeadStorageResultItem) UnmarshalJSON(b []byte) error {
+	var tmp chainHeadStorageResultItemJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	key, err := codec.HexDecodeString(tmp.Key)
+	if err != nil {
+		return err
+	}
+
+	*i = ChainHeadStorageResultItem{
+		Key:  key,
+		Hash: tmp.Hash,
+	}
+
+	if tmp.Value != nil {
+		bz, err := codec.HexDecodeString(*tmp.Value)
+		if err != nil {
+			return err
+		}
+		value := NewStorageDataRaw(bz)
+		i.Value = &value
+	}
+
+	if tmp.ClosestDescendantMerkleValue != nil {
+		bz, err := codec.HexDecodeString(*tmp.ClosestDescendantMerkleValue)
+		if err != nil {
+			return err
+		}
+		merkleValue := NewBytes(bz)
+		i.ClosestDescendantMerkleValue = &merkleValue
+	}
+
+	return nil
+}
+
+// MarshalJSON returns a JSON encoded byte array of i
+func (i ChainHeadStorageResultItem) MarshalJSON() ([]byte, error) {
+	tmp := chainHeadStorageResultItemJSON{
+		Key:  i.Key.Hex(),
+		Hash: i.Hash,
+	}
+
+	if i.Value != nil {
+		value := i.Value.Hex()
+		tmp.Value = &value
+	}
+
+	if i.ClosestDescendantMerkleValue != nil {
+		merkleValue := codec.HexEncodeToString(*i.ClosestDescendantMerkleValue)
+		tmp.ClosestDescendantMerkleValue = &merkleValue
+	}
+
+	return json.Marshal(tmp)
+}
+
+// ChainHeadOperationStarted is the response of the chainHead_v1 methods that start an operation
+type ChainHeadOperationStarted struct {
+	// Result is either "started" or "limitReached"
+	Result      string `json:"result"`
+	OperationID string `json:"operationId,omitempty"`
+	// DiscardedItems is the number of storage items that were not queried by the node
+	DiscardedItems uint32 `json:"discardedItems,omitempty"`
+}
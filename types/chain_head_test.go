@@ -0,0 +1,184 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testChainHeadBlockHash  = NewHash(MustHexDecodeString("0xa230d0b6dc75868237b08d71618f3d19526b8aa346d94c792a4fce0a945b1e3f")) //nolint:lll
+	testChainHeadParentHash = NewHash(MustHexDecodeString("0x8e1b5a38c5ee5eea8d1d0aa4d5a2fe0b1b38a2a0fb1f7b0ab1bd2e0d74cf6e2c")) //nolint:lll
+)
+
+func TestChainHeadFollowEvent_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected ChainHeadFollowEvent
+	}{
+		{
+			name:  "initialized",
+			input: `{"event":"initialized","finalizedBlockHashes":["` + testChainHeadBlockHash.Hex() + `"],"finalizedBlockRuntime":{"type":"valid","spec":{"specName":"polkadot","implName":"parity-polkadot","specVersion":9430,"implVersion":0,"transactionVersion":24,"apis":{"0xdf6acb689907609b":4}}}}`, //nolint:lll
+			expected: ChainHeadFollowEvent{
+				Event:                ChainHeadInitialized,
+				FinalizedBlockHashes: []Hash{testChainHeadBlockHash},
+				FinalizedBlockRuntime: &ChainHeadRuntimeEvent{
+					Type: "valid",
+					Spec: &ChainHeadRuntimeSpec{
+						SpecName:           "polkadot",
+						ImplName:           "parity-polkadot",
+						SpecVersion:        9430,
+						TransactionVersion: 24,
+						APIs:               map[string]U32{"0xdf6acb689907609b": 4},
+					},
+				},
+			},
+		},
+		{
+			name:  "newBlock",
+			input: `{"event":"newBlock","blockHash":"` + testChainHeadBlockHash.Hex() + `","parentBlockHash":"` + testChainHeadParentHash.Hex() + `","newRuntime":null}`, //nolint:lll
+			expected: ChainHeadFollowEvent{
+				Event:           ChainHeadNewBlock,
+				BlockHash:       testChainHeadBlockHash,
+				ParentBlockHash: testChainHeadParentHash,
+			},
+		},
+		{
+			name:  "finalized",
+			input: `{"event":"finalized","finalizedBlockHashes":["` + testChainHeadBlockHash.Hex() + `"],"prunedBlockHashes":["` + testChainHeadParentHash.Hex() + `"]}`, //nolint:lll
+			expected: ChainHeadFollowEvent{
+				Event:                ChainHeadFinalized,
+				FinalizedBlockHashes: []Hash{testChainHeadBlockHash},
+				PrunedBlockHashes:    []Hash{testChainHeadParentHash},
+			},
+		},
+		{
+			name:  "operationBodyDone",
+			input: `{"event":"operationBodyDone","operationId":"1","value":["0x0102","0x03"]}`,
+			expected: ChainHeadFollowEvent{
+				Event:       ChainHeadOperationBodyDone,
+				OperationID: "1",
+				Extrinsics:  []Bytes{{0x01, 0x02}, {0x03}},
+			},
+		},
+		{
+			name:  "operationCallDone",
+			input: `{"event":"operationCallDone","operationId":"2","output":"0x0405"}`,
+			expected: ChainHeadFollowEvent{
+				Event:       ChainHeadOperationCallDone,
+				OperationID: "2",
+				Output:      Bytes{0x04, 0x05},
+			},
+		},
+		{
+			name:  "operationStorageItems",
+			input: `{"event":"operationStorageItems","operationId":"3","items":[{"key":"0x0102","value":"0x0304"},{"key":"0x0506","hash":"` + testChainHeadBlockHash.Hex() + `"}]}`, //nolint:lll
+			expected: ChainHeadFollowEvent{
+				Event:       ChainHeadOperationStorageItems,
+				OperationID: "3",
+				Items: []ChainHeadStorageResultItem{
+					{
+						Key:   StorageKey{0x01, 0x02},
+						Value: newStorageDataRawPtr(StorageDataRaw{0x03, 0x04}),
+					},
+					{
+						Key:  StorageKey{0x05, 0x06},
+						Hash: &testChainHeadBlockHash,
+					},
+				},
+			},
+		},
+		{
+			name:  "operationError",
+			input: `{"event":"operationError","operationId":"4","error":"boom"}`,
+			expected: ChainHeadFollowEvent{
+				Event:       ChainHeadOperationError,
+				OperationID: "4",
+				Error:       "boom",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var event ChainHeadFollowEvent
+
+			err := json.Unmarshal([]byte(test.input), &event)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, event)
+
+			b, err := json.Marshal(event)
+			assert.NoError(t, err)
+
+			var decoded ChainHeadFollowEvent
+
+			err = json.Unmarshal(b, &decoded)
+			assert.NoError(t, err)
+			assert.Equal(t, event, decoded)
+		})
+	}
+}
+
+func TestChainHeadFollowEvent_UnmarshalJSONInvalidHex(t *testing.T) {
+	var event ChainHeadFollowEvent
+
+	err := json.Unmarshal([]byte(`{"event":"operationBodyDone","operationId":"1","value":["0xzz"]}`), &event)
+	assert.Error(t, err)
+}
+
+func TestChainHeadFollowEvent_IsOperationEvent(t *testing.T) {
+	assert.True(t, ChainHeadFollowEvent{Event: ChainHeadOperationStorageDone}.IsOperationEvent())
+	assert.True(t, ChainHeadFollowEvent{Event: ChainHeadOperationInaccessible}.IsOperationEvent())
+	assert.False(t, ChainHeadFollowEvent{Event: ChainHeadNewBlock}.IsOperationEvent())
+	assert.False(t, ChainHeadFollowEvent{Event: ChainHeadStop}.IsOperationEvent())
+}
+
+func TestChainHeadStorageQueryItem_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(ChainHeadStorageQueryItem{
+		Key:  StorageKey{0x01, 0x02},
+		Type: ChainHeadStorageDescendantsValues,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"key":"0x0102","type":"descendantsValues"}`, string(b))
+}
+
+func TestChainHeadStorageResultItem_ClosestDescendantMerkleValue(t *testing.T) {
+	var item ChainHeadStorageResultItem
+
+	err := json.Unmarshal([]byte(`{"key":"0x01","closestDescendantMerkleValue":"0xabcd"}`), &item)
+	assert.NoError(t, err)
+
+	merkleValue := NewBytes([]byte{0xab, 0xcd})
+	assert.Equal(t, ChainHeadStorageResultItem{
+		Key:                          StorageKey{0x01},
+		ClosestDescendantMerkleValue: &merkleValue,
+	}, item)
+
+	b, err := json.Marshal(item)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"key":"0x01","closestDescendantMerkleValue":"0xabcd"}`, string(b))
+}
+
+func newStorageDataRawPtr(s StorageDataRaw) *StorageDataRaw {
+	return &s
+}
@@ -0,0 +1,119 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// ChainSpec holds the common fields of a chain spec, e.g. as returned by sync_state_genSyncSpec. The complete chain
+// spec is kept in Raw, so that it can be written to a file and used to start a node.
+type ChainSpec struct {
+	// Raw is the chain spec as it was received
+	Raw json.RawMessage `json:"-"`
+
+	Name       string    `json:"name"`
+	ID         string    `json:"id"`
+	ChainType  ChainType `json:"chainType"`
+	BootNodes  []string  `json:"bootNodes"`
+	ProtocolID string    `json:"protocolId"`
+	// Genesis is either the raw genesis storage, {"raw": {"top": ...}}, or the genesis config of the runtime
+	Genesis json.RawMessage `json:"genesis"`
+	// LightSyncState is the checkpoint light clients can start syncing from, it is only set in specs that were
+	// generated by sync_state_genSyncSpec.
+	LightSyncState *LightSyncState `json:"lightSyncState"`
+}
+
+// UnmarshalJSON fills c with the JSON encoded chain spec given by b
+func (c *ChainSpec) UnmarshalJSON(b []byte) error {
+	type chainSpec ChainSpec
+
+	var tmp chainSpec
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*c = ChainSpec(tmp)
+	c.Raw = append(json.RawMessage{}, b...)
+
+	return nil
+}
+
+// LightSyncState is the state a light client needs to start syncing from a finalized block instead of genesis
+type LightSyncState struct {
+	// FinalizedBlockHeader is the header of the finalized block the state was taken at
+	FinalizedBlockHeader Header
+	// BabeEpochChanges are the SCALE encoded BABE epoch changes
+	BabeEpochChanges Bytes
+	// BabeFinalizedBlockWeight is the BABE weight of the finalized block
+	BabeFinalizedBlockWeight U32
+	// GrandpaAuthoritySet is the SCALE encoded GRANDPA authority set
+	GrandpaAuthoritySet Bytes
+}
+
+type lightSyncStateJSON struct {
+	FinalizedBlockHeader     string `json:"finalizedBlockHeader"`
+	BabeEpochChanges         string `json:"babeEpochChanges"`
+	BabeFinalizedBlockWeight U32    `json:"babeFinalizedBlockWeight"`
+	GrandpaAuthoritySet      string `json:"grandpaAuthoritySet"`
+}
+
+// UnmarshalJSON fills l with the JSON encoded light sync state given by b, the header and the consensus data are hex
+// encoded SCALE values.
+func (l *LightSyncState) UnmarshalJSON(b []byte) error {
+	var tmp lightSyncStateJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	if err := codec.DecodeFromHex(tmp.FinalizedBlockHeader, &l.FinalizedBlockHeader); err != nil {
+		return err
+	}
+
+	epochChanges, err := codec.HexDecodeString(tmp.BabeEpochChanges)
+	if err != nil {
+		return err
+	}
+
+	authoritySet, err := codec.HexDecodeString(tmp.GrandpaAuthoritySet)
+	if err != nil {
+		return err
+	}
+
+	l.BabeEpochChanges = epochChanges
+	l.BabeFinalizedBlockWeight = tmp.BabeFinalizedBlockWeight
+	l.GrandpaAuthoritySet = authoritySet
+
+	return nil
+}
+
+// MarshalJSON returns a JSON encoded byte array of l
+func (l LightSyncState) MarshalJSON() ([]byte, error) {
+	header, err := codec.EncodeToHex(l.FinalizedBlockHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(lightSyncStateJSON{
+		FinalizedBlockHeader:     header,
+		BabeEpochChanges:         codec.HexEncodeToString(l.BabeEpochChanges),
+		BabeFinalizedBlockWeight: l.BabeFinalizedBlockWeight,
+		GrandpaAuthoritySet:      codec.HexEncodeToString(l.GrandpaAuthoritySet),
+	})
+}
@@ -0,0 +1,99 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainSpec_UnmarshalJSON(t *testing.T) {
+	header, err := EncodeToHex(exampleHeader)
+	require.NoError(t, err)
+
+	spec := fmt.Sprintf(`{
+		"name": "Development",
+		"id": "dev",
+		"chainType": "Development",
+		"bootNodes": ["/ip4/127.0.0.1/tcp/30333/p2p/12D3KooWEyoppNCUx8Yx66oV9fJnriXwCcXwDDUA2kj6vnc6iDEp"],
+		"telemetryEndpoints": null,
+		"protocolId": "dot",
+		"properties": {"tokenDecimals": 12},
+		"genesis": {"raw": {"top": {"0x3a636f6465": "0x00"}, "childrenDefault": {}}},
+		"lightSyncState": {
+			"finalizedBlockHeader": "%s",
+			"babeEpochChanges": "0x0102",
+			"babeFinalizedBlockWeight": 7,
+			"grandpaAuthoritySet": "0x0304"
+		}
+	}`, header)
+
+	var cs ChainSpec
+	require.NoError(t, json.Unmarshal([]byte(spec), &cs))
+
+	assert.Equal(t, "Development", cs.Name)
+	assert.Equal(t, "dev", cs.ID)
+	assert.Equal(t, ChainType{IsDevelopment: true}, cs.ChainType)
+	assert.Len(t, cs.BootNodes, 1)
+	assert.Equal(t, "dot", cs.ProtocolID)
+	assert.JSONEq(t, `{"raw": {"top": {"0x3a636f6465": "0x00"}, "childrenDefault": {}}}`, string(cs.Genesis))
+	assert.Equal(t, spec, string(cs.Raw))
+
+	require.NotNil(t, cs.LightSyncState)
+	assert.Equal(t, exampleHeader, cs.LightSyncState.FinalizedBlockHeader)
+	assert.Equal(t, Bytes{1, 2}, cs.LightSyncState.BabeEpochChanges)
+	assert.EqualValues(t, 7, cs.LightSyncState.BabeFinalizedBlockWeight)
+	assert.Equal(t, Bytes{3, 4}, cs.LightSyncState.GrandpaAuthoritySet)
+}
+
+func TestChainSpec_UnmarshalJSON_WithoutLightSyncState(t *testing.T) {
+	var cs ChainSpec
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"Local","id":"local","chainType":{"Custom":"x"}}`), &cs))
+
+	assert.Nil(t, cs.LightSyncState)
+	assert.Equal(t, ChainType{IsCustom: true, AsCustom: "x"}, cs.ChainType)
+}
+
+func TestLightSyncState_UnmarshalMarshalJSON(t *testing.T) {
+	l := LightSyncState{
+		FinalizedBlockHeader:     exampleHeader,
+		BabeEpochChanges:         Bytes{1, 2, 3},
+		BabeFinalizedBlockWeight: 42,
+		GrandpaAuthoritySet:      Bytes{4, 5},
+	}
+
+	b, err := json.Marshal(l)
+	require.NoError(t, err)
+
+	var res LightSyncState
+	require.NoError(t, json.Unmarshal(b, &res))
+	assert.Equal(t, l, res)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"finalizedBlockHeader":"0x01"}`), &res))
+
+	header, err := EncodeToHex(exampleHeader)
+	require.NoError(t, err)
+
+	invalid := fmt.Sprintf(`{"finalizedBlockHeader":"%s","babeEpochChanges":"0xzz"}`, header)
+	assert.Error(t, json.Unmarshal([]byte(invalid), &res))
+}
@@ -0,0 +1,82 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ChainType is the type of a chain, as returned by system_chainType and stored in chain specs
+type ChainType struct {
+	IsDevelopment bool // 0:: Development
+	IsLocal       bool // 1:: Local
+	IsLive        bool // 2:: Live
+	IsCustom      bool // 3:: Custom(String)
+	AsCustom      string
+}
+
+func (c *ChainType) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		switch name {
+		case "Development":
+			c.IsDevelopment = true
+		case "Local":
+			c.IsLocal = true
+		case "Live":
+			c.IsLive = true
+		default:
+			return fmt.Errorf("unexpected chain type %q", name)
+		}
+
+		return nil
+	}
+
+	var tmp struct {
+		AsCustom *string `json:"Custom"`
+	}
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	if tmp.AsCustom == nil {
+		return fmt.Errorf("unexpected JSON for ChainType, got %v", string(b))
+	}
+
+	c.IsCustom = true
+	c.AsCustom = *tmp.AsCustom
+
+	return nil
+}
+
+func (c ChainType) MarshalJSON() ([]byte, error) {
+	switch {
+	case c.IsDevelopment:
+		return []byte("\"Development\""), nil
+	case c.IsLocal:
+		return []byte("\"Local\""), nil
+	case c.IsLive:
+		return []byte("\"Live\""), nil
+	case c.IsCustom:
+		return json.Marshal(struct {
+			AsCustom string `json:"Custom"`
+		}{c.AsCustom})
+	}
+
+	return nil, fmt.Errorf("cannot marshal ChainType, no variant is set")
+}
@@ -0,0 +1,56 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainType_UnmarshalMarshalJSON(t *testing.T) {
+	for _, test := range []struct {
+		input    string
+		expected ChainType
+	}{
+		{`"Development"`, ChainType{IsDevelopment: true}},
+		{`"Local"`, ChainType{IsLocal: true}},
+		{`"Live"`, ChainType{IsLive: true}},
+		{`{"Custom":"staging"}`, ChainType{IsCustom: true, AsCustom: "staging"}},
+	} {
+		var chainType ChainType
+		require.NoError(t, json.Unmarshal([]byte(test.input), &chainType))
+		assert.Equal(t, test.expected, chainType)
+
+		b, err := json.Marshal(chainType)
+		require.NoError(t, err)
+		assert.Equal(t, test.input, string(b))
+	}
+}
+
+func TestChainType_UnmarshalJSON_Invalid(t *testing.T) {
+	for _, input := range []string{`"Production"`, `{"Other":"x"}`, `1`} {
+		var chainType ChainType
+		assert.Error(t, json.Unmarshal([]byte(input), &chainType), input)
+	}
+
+	_, err := json.Marshal(ChainType{})
+	assert.Error(t, err)
+}
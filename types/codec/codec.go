@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 )
@@ -31,24 +32,60 @@ type Hexer interface {
 	Hex() string
 }
 
+// maxPooledBufferSize is the capacity above which buffers are not returned to the pool, so that encoding a single large
+// value, e.g. a runtime upgrade, does not keep its buffer alive.
+const maxPooledBufferSize = 64 * 1024
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// encodeWithBuffer encodes `value` into a pooled buffer, which is only valid until fn returns.
+func encodeWithBuffer(value interface{}, fn func(buffer *bytes.Buffer)) error {
+	buffer := bufferPool.Get().(*bytes.Buffer)
+	buffer.Reset()
+
+	defer func() {
+		if buffer.Cap() <= maxPooledBufferSize {
+			bufferPool.Put(buffer)
+		}
+	}()
+
+	err := scale.NewEncoder(buffer).Encode(value)
+	fn(buffer)
+
+	return err
+}
+
 // Encode encodes `value` with the scale codec with passed EncoderOptions, returning []byte
 func Encode(value interface{}) ([]byte, error) {
-	var buffer = bytes.Buffer{}
-	err := scale.NewEncoder(&buffer).Encode(value)
+	var bz []byte
+	err := encodeWithBuffer(value, func(buffer *bytes.Buffer) {
+		if buffer.Len() > 0 {
+			bz = append([]byte(nil), buffer.Bytes()...)
+		}
+	})
 	if err != nil {
-		return buffer.Bytes(), err
+		return bz, err
 	}
-	return buffer.Bytes(), nil
+	return bz, nil
 }
 
 // EncodeToHex encodes `value` with the scale codec, returning a hex string (prefixed by 0x)
 func EncodeToHex(value interface{}) (string, error) {
-	bz, err := Encode(value)
+	var str string
+	err := encodeWithBuffer(value, func(buffer *bytes.Buffer) {
+		if buffer.Len() > 0 {
+			str = HexEncodeToString(buffer.Bytes())
+		}
+	})
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("%#x", bz), nil
+	return str, nil
 }
 
 // Decode decodes `bz` with the scale codec into `target`. `target` should be a pointer.
@@ -56,6 +93,12 @@ func Decode(bz []byte, target interface{}) error {
 	return scale.NewDecoder(bytes.NewReader(bz)).Decode(target)
 }
 
+// DecodeWithMaxBytesLength decodes `bz` with the scale codec into `target` like Decode, but fails instead of
+// allocating strings or byte slices that are longer than maxBytesLength, see scale.NewDecoderWithMaxBytesLength.
+func DecodeWithMaxBytesLength(bz []byte, target interface{}, maxBytesLength uint64) error {
+	return scale.NewDecoderWithMaxBytesLength(bytes.NewReader(bz), maxBytesLength).Decode(target)
+}
+
 // DecodeFromHex decodes `str` with the scale codec into `target`. `target` should be a pointer.
 func DecodeFromHex(str string, target interface{}) error {
 	bz, err := HexDecodeString(str)
@@ -67,12 +110,14 @@ func DecodeFromHex(str string, target interface{}) error {
 
 // EncodedLength returns the length of the value when encoded as a byte array
 func EncodedLength(value interface{}) (int, error) {
-	var buffer = bytes.Buffer{}
-	err := scale.NewEncoder(&buffer).Encode(value)
+	var length int
+	err := encodeWithBuffer(value, func(buffer *bytes.Buffer) {
+		length = buffer.Len()
+	})
 	if err != nil {
 		return 0, err
 	}
-	return buffer.Len(), nil
+	return length, nil
 }
 
 // Eq compares the value of the input to see if there is a match
@@ -0,0 +1,488 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	// BabeEngineID identifies BABE, it is [b'B', b'A', b'B', b'E']
+	BabeEngineID ConsensusEngineID = 0x45424142
+	// AuraEngineID identifies Aura, it is [b'a', b'u', b'r', b'a']
+	AuraEngineID ConsensusEngineID = 0x61727561
+	// NimbusEngineID identifies Nimbus, which is used by e.g. Moonbeam, it is [b'n', b'm', b'b', b's']
+	NimbusEngineID ConsensusEngineID = 0x73626d6e
+	// GrandpaEngineID identifies GRANDPA, it is [b'F', b'R', b'N', b'K']
+	GrandpaEngineID ConsensusEngineID = 0x4b4e5246
+	// BeefyEngineID identifies BEEFY, it is [b'B', b'E', b'E', b'F']
+	BeefyEngineID ConsensusEngineID = 0x46454542
+)
+
+var (
+	ErrAuthorNotFound         = errors.New("no pre-runtime digest identifies the block author")
+	ErrAuthorityIndexOutOfSet = errors.New("authority index is not in the validator set")
+	ErrEmptyValidatorSet      = errors.New("empty validator set")
+	ErrUnsupportedConsensus   = errors.New("unsupported consensus engine")
+)
+
+// String returns the engine ID as the 4 characters it consists of, e.g. BABE
+func (c ConsensusEngineID) String() string {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, uint32(c))
+
+	return string(b)
+}
+
+// PreRuntimeDigest is the decoded data of a pre-runtime digest item, see PreRuntime.Decoded
+type PreRuntimeDigest struct {
+	IsBabe   bool
+	AsBabe   BabePreDigest
+	IsAura   bool
+	AsAura   AuraPreDigest
+	IsNimbus bool
+	AsNimbus NimbusPreDigest
+	// IsUnknown is set for engines that are not supported, the data is kept as is
+	IsUnknown bool
+	AsUnknown Bytes
+}
+
+// Decoded decodes the data of the pre-runtime digest item depending on the consensus engine. The data of unknown
+// engines is returned as is.
+func (p PreRuntime) Decoded() (PreRuntimeDigest, error) {
+	var (
+		d   PreRuntimeDigest
+		err error
+	)
+
+	switch p.ConsensusEngineID {
+	case BabeEngineID:
+		d.IsBabe = true
+		err = codec.Decode(p.Bytes, &d.AsBabe)
+	case AuraEngineID:
+		d.IsAura = true
+		err = codec.Decode(p.Bytes, &d.AsAura)
+	case NimbusEngineID:
+		d.IsNimbus = true
+		err = codec.Decode(p.Bytes, &d.AsNimbus)
+	default:
+		d.IsUnknown = true
+		d.AsUnknown = p.Bytes
+	}
+
+	if err != nil {
+		return PreRuntimeDigest{}, fmt.Errorf("decoding %s pre-runtime digest: %w", p.ConsensusEngineID, err)
+	}
+
+	return d, nil
+}
+
+// ConsensusDigest is the decoded data of a consensus digest item, see Consensus.Decoded
+type ConsensusDigest struct {
+	IsBabe bool
+	AsBabe BabeConsensusLog
+	IsAura bool
+	AsAura AuraConsensusLog
+	// IsUnknown is set for engines that are not supported, the data is kept as is
+	IsUnknown bool
+	AsUnknown Bytes
+}
+
+// Decoded decodes the data of the consensus digest item depending on the consensus engine. The data of unknown
+// engines is returned as is.
+func (c Consensus) Decoded() (ConsensusDigest, error) {
+	var (
+		d   ConsensusDigest
+		err error
+	)
+
+	switch c.ConsensusEngineID {
+	case BabeEngineID:
+		d.IsBabe = true
+		err = codec.Decode(c.Bytes, &d.AsBabe)
+	case AuraEngineID:
+		d.IsAura = true
+		err = codec.Decode(c.Bytes, &d.AsAura)
+	default:
+		d.IsUnknown = true
+		d.AsUnknown = c.Bytes
+	}
+
+	if err != nil {
+		return ConsensusDigest{}, fmt.Errorf("decoding %s consensus digest: %w", c.ConsensusEngineID, err)
+	}
+
+	return d, nil
+}
+
+// SealDigest is the decoded data of a seal digest item, see Seal.Decoded
+type SealDigest struct {
+	// IsSignature is set for BABE, Aura and Nimbus, which seal blocks with the sr25519 signature of the author over
+	// the header hash
+	IsSignature bool
+	AsSignature Signature
+	// IsUnknown is set for engines that are not supported, the data is kept as is
+	IsUnknown bool
+	AsUnknown Bytes
+}
+
+// Decoded decodes the data of the seal digest item depending on the consensus engine. The data of unknown engines is
+// returned as is.
+func (s Seal) Decoded() (SealDigest, error) {
+	switch s.ConsensusEngineID {
+	case BabeEngineID, AuraEngineID, NimbusEngineID:
+		var sig Signature
+
+		if err := codec.Decode(s.Bytes, &sig); err != nil {
+			return SealDigest{}, fmt.Errorf("decoding %s seal: %w", s.ConsensusEngineID, err)
+		}
+
+		return SealDigest{IsSignature: true, AsSignature: sig}, nil
+	default:
+		return SealDigest{IsUnknown: true, AsUnknown: s.Bytes}, nil
+	}
+}
+
+// BabePreDigest is the BABE pre-runtime digest, it identifies the slot and the authority that claimed it
+type BabePreDigest struct {
+	IsPrimary        bool // 1
+	AsPrimary        BabePrimaryPreDigest
+	IsSecondaryPlain bool // 2
+	AsSecondaryPlain BabeSecondaryPlainPreDigest
+	IsSecondaryVRF   bool // 3
+	AsSecondaryVRF   BabeSecondaryVRFPreDigest
+}
+
+func (d *BabePreDigest) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 1:
+		d.IsPrimary = true
+		return decoder.Decode(&d.AsPrimary)
+	case 2:
+		d.IsSecondaryPlain = true
+		return decoder.Decode(&d.AsSecondaryPlain)
+	case 3:
+		d.IsSecondaryVRF = true
+		return decoder.Decode(&d.AsSecondaryVRF)
+	default:
+		return fmt.Errorf("unknown BabePreDigest enum: %v", b)
+	}
+}
+
+func (d BabePreDigest) Encode(encoder scale.Encoder) error {
+	switch {
+	case d.IsPrimary:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(d.AsPrimary)
+	case d.IsSecondaryPlain:
+		if err := encoder.PushByte(2); err != nil {
+			return err
+		}
+
+		return encoder.Encode(d.AsSecondaryPlain)
+	case d.IsSecondaryVRF:
+		if err := encoder.PushByte(3); err != nil {
+			return err
+		}
+
+		return encoder.Encode(d.AsSecondaryVRF)
+	default:
+		return errors.New("empty BabePreDigest")
+	}
+}
+
+// AuthorityIndex returns the index of the authority that claimed the slot
+func (d BabePreDigest) AuthorityIndex() U32 {
+	switch {
+	case d.IsPrimary:
+		return d.AsPrimary.AuthorityIndex
+	case d.IsSecondaryPlain:
+		return d.AsSecondaryPlain.AuthorityIndex
+	default:
+		return d.AsSecondaryVRF.AuthorityIndex
+	}
+}
+
+// Slot returns the slot that was claimed
+func (d BabePreDigest) Slot() U64 {
+	switch {
+	case d.IsPrimary:
+		return d.AsPrimary.Slot
+	case d.IsSecondaryPlain:
+		return d.AsSecondaryPlain.Slot
+	default:
+		return d.AsSecondaryVRF.Slot
+	}
+}
+
+// BabePrimaryPreDigest is the pre-digest of a primary slot, which was won via the VRF output of the authority
+type BabePrimaryPreDigest struct {
+	AuthorityIndex U32
+	Slot           U64
+	VRFSignature   BabeVRFSignature
+}
+
+// BabeSecondaryPlainPreDigest is the pre-digest of a secondary slot that was assigned to the authority
+type BabeSecondaryPlainPreDigest struct {
+	AuthorityIndex U32
+	Slot           U64
+}
+
+// BabeSecondaryVRFPreDigest is the pre-digest of a secondary slot that was assigned to the authority and claimed with
+// a VRF output
+type BabeSecondaryVRFPreDigest struct {
+	AuthorityIndex U32
+	Slot           U64
+	VRFSignature   BabeVRFSignature
+}
+
+// BabeVRFSignature is the VRF output of a slot claim together with its proof
+type BabeVRFSignature struct {
+	PreOutput [32]byte
+	Proof     [64]byte
+}
+
+// BabeConsensusLog is a BABE consensus digest, it announces changes of the next epoch
+type BabeConsensusLog struct {
+	IsNextEpochData  bool // 1
+	AsNextEpochData  BabeNextEpochDescriptor
+	IsOnDisabled     bool // 2
+	AsOnDisabled     U32
+	IsNextConfigData bool // 3
+	AsNextConfigData BabeNextConfigDescriptor
+}
+
+func (l *BabeConsensusLog) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 1:
+		l.IsNextEpochData = true
+		return decoder.Decode(&l.AsNextEpochData)
+	case 2:
+		l.IsOnDisabled = true
+		return decoder.Decode(&l.AsOnDisabled)
+	case 3:
+		l.IsNextConfigData = true
+		return decoder.Decode(&l.AsNextConfigData)
+	default:
+		return fmt.Errorf("unknown BabeConsensusLog enum: %v", b)
+	}
+}
+
+func (l BabeConsensusLog) Encode(encoder scale.Encoder) error {
+	switch {
+	case l.IsNextEpochData:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(l.AsNextEpochData)
+	case l.IsOnDisabled:
+		if err := encoder.PushByte(2); err != nil {
+			return err
+		}
+
+		return encoder.Encode(l.AsOnDisabled)
+	case l.IsNextConfigData:
+		if err := encoder.PushByte(3); err != nil {
+			return err
+		}
+
+		return encoder.Encode(l.AsNextConfigData)
+	default:
+		return errors.New("empty BabeConsensusLog")
+	}
+}
+
+// BabeNextEpochDescriptor holds the authorities and the randomness of the next epoch
+type BabeNextEpochDescriptor struct {
+	Authorities []BabeAuthority
+	Randomness  [32]byte
+}
+
+// BabeAuthority is a BABE authority together with its weight
+type BabeAuthority struct {
+	ID     AuthorityID
+	Weight U64
+}
+
+// BabeNextConfigDescriptor is the configuration of the next epoch, only version 1 exists
+type BabeNextConfigDescriptor struct {
+	IsV1 bool // 1
+	AsV1 BabeNextConfigDescriptorV1
+}
+
+func (c *BabeNextConfigDescriptor) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	if b != 1 {
+		return fmt.Errorf("unknown BabeNextConfigDescriptor enum: %v", b)
+	}
+
+	c.IsV1 = true
+
+	return decoder.Decode(&c.AsV1)
+}
+
+func (c BabeNextConfigDescriptor) Encode(encoder scale.Encoder) error {
+	if !c.IsV1 {
+		return errors.New("empty BabeNextConfigDescriptor")
+	}
+
+	if err := encoder.PushByte(1); err != nil {
+		return err
+	}
+
+	return encoder.Encode(c.AsV1)
+}
+
+// BabeNextConfigDescriptorV1 is version 1 of the BABE epoch configuration
+type BabeNextConfigDescriptorV1 struct {
+	// C is the probability of a slot being empty, as a fraction C[0] / C[1]
+	C [2]U64
+	// AllowedSlots is 0 for primary slots only, 1 for primary and secondary plain slots and 2 for primary and
+	// secondary VRF slots
+	AllowedSlots U8
+}
+
+// AuraPreDigest is the Aura pre-runtime digest, it holds the slot of the block
+type AuraPreDigest struct {
+	Slot U64
+}
+
+// AuraConsensusLog is an Aura consensus digest
+type AuraConsensusLog struct {
+	IsAuthoritiesChange bool // 1
+	AsAuthoritiesChange []AuthorityID
+	IsOnDisabled        bool // 2
+	AsOnDisabled        U32
+}
+
+func (l *AuraConsensusLog) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 1:
+		l.IsAuthoritiesChange = true
+		return decoder.Decode(&l.AsAuthoritiesChange)
+	case 2:
+		l.IsOnDisabled = true
+		return decoder.Decode(&l.AsOnDisabled)
+	default:
+		return fmt.Errorf("unknown AuraConsensusLog enum: %v", b)
+	}
+}
+
+func (l AuraConsensusLog) Encode(encoder scale.Encoder) error {
+	switch {
+	case l.IsAuthoritiesChange:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(l.AsAuthoritiesChange)
+	case l.IsOnDisabled:
+		if err := encoder.PushByte(2); err != nil {
+			return err
+		}
+
+		return encoder.Encode(l.AsOnDisabled)
+	default:
+		return errors.New("empty AuraConsensusLog")
+	}
+}
+
+// NimbusPreDigest is the Nimbus pre-runtime digest, it holds the Nimbus key of the block author
+type NimbusPreDigest struct {
+	AuthorID AuthorityID
+}
+
+// ExtractAuthor returns the author of the block with the given header. For BABE, the authority index of the
+// pre-runtime digest is resolved against the validators, for Aura the author is the validator at the index of the
+// slot modulo the number of validators. The validators are usually those of the Session.Validators storage at the
+// parent block. For Nimbus, the Nimbus key of the author is returned, which might have to be mapped to the account of
+// the author, e.g. via the AuthorMapping pallet on Moonbeam. If the block only has pre-runtime digests of other
+// engines, the error wraps both ErrAuthorNotFound and ErrUnsupportedConsensus.
+func ExtractAuthor(header Header, validators []AccountID) (*AccountID, error) {
+	var unsupported []ConsensusEngineID
+
+	for _, item := range header.Digest {
+		if !item.IsPreRuntime {
+			continue
+		}
+
+		d, err := item.AsPreRuntime.Decoded()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case d.IsBabe:
+			return validatorAt(validators, uint64(d.AsBabe.AuthorityIndex()))
+		case d.IsAura:
+			if len(validators) == 0 {
+				return nil, ErrEmptyValidatorSet
+			}
+
+			return validatorAt(validators, uint64(d.AsAura.Slot)%uint64(len(validators)))
+		case d.IsNimbus:
+			author := AccountID(d.AsNimbus.AuthorID)
+			return &author, nil
+		case d.IsUnknown:
+			unsupported = append(unsupported, item.AsPreRuntime.ConsensusEngineID)
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("%w: %w %v", ErrAuthorNotFound, ErrUnsupportedConsensus, unsupported)
+	}
+
+	return nil, ErrAuthorNotFound
+}
+
+func validatorAt(validators []AccountID, index uint64) (*AccountID, error) {
+	if index >= uint64(len(validators)) {
+		return nil, fmt.Errorf("%w: index %d, %d validators", ErrAuthorityIndexOutOfSet, index, len(validators))
+	}
+
+	author := validators[index]
+
+	return &author, nil
+}
@@ -0,0 +1,208 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testValidators = []AccountID{{1}, {2}, {3}}
+
+	testNimbusAuthor = AuthorityID{9}
+
+	testBabeVRFSignature = BabeVRFSignature{PreOutput: [32]byte{4}, Proof: [64]byte{5}}
+)
+
+func TestConsensusEngineID_String(t *testing.T) {
+	assert.Equal(t, "BABE", BabeEngineID.String())
+	assert.Equal(t, "aura", AuraEngineID.String())
+	assert.Equal(t, "nmbs", NimbusEngineID.String())
+	assert.Equal(t, "FRNK", GrandpaEngineID.String())
+	assert.Equal(t, "BEEF", BeefyEngineID.String())
+}
+
+func TestBabePreDigest_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, BabePreDigest{IsPrimary: true, AsPrimary: BabePrimaryPreDigest{
+		AuthorityIndex: 1, Slot: 2, VRFSignature: testBabeVRFSignature,
+	}})
+	AssertRoundtrip(t, BabePreDigest{IsSecondaryPlain: true, AsSecondaryPlain: BabeSecondaryPlainPreDigest{
+		AuthorityIndex: 1, Slot: 2,
+	}})
+	AssertRoundtrip(t, BabePreDigest{IsSecondaryVRF: true, AsSecondaryVRF: BabeSecondaryVRFPreDigest{
+		AuthorityIndex: 1, Slot: 2, VRFSignature: testBabeVRFSignature,
+	}})
+	AssertDecodeNilData[BabePreDigest](t)
+
+	AssertEncode(t, []EncodingAssert{
+		{Input: BabePreDigest{IsSecondaryPlain: true, AsSecondaryPlain: BabeSecondaryPlainPreDigest{
+			AuthorityIndex: 1, Slot: 2,
+		}}, Expected: MustHexDecodeString("0x02010000000200000000000000")},
+	})
+
+	var d BabePreDigest
+
+	err := d.Encode(*scale.NewEncoder(bytes.NewBuffer(nil)))
+	assert.NotNil(t, err)
+
+	err = d.Decode(*scale.NewDecoder(bytes.NewReader([]byte{4})))
+	assert.NotNil(t, err)
+}
+
+func TestBabeConsensusLog_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, BabeConsensusLog{IsNextEpochData: true, AsNextEpochData: BabeNextEpochDescriptor{
+		Authorities: []BabeAuthority{{ID: AuthorityID{1}, Weight: 1}, {ID: AuthorityID{2}, Weight: 1}},
+		Randomness:  [32]byte{3},
+	}})
+	AssertRoundtrip(t, BabeConsensusLog{IsOnDisabled: true, AsOnDisabled: 4})
+	AssertRoundtrip(t, BabeConsensusLog{IsNextConfigData: true, AsNextConfigData: BabeNextConfigDescriptor{
+		IsV1: true,
+		AsV1: BabeNextConfigDescriptorV1{C: [2]U64{1, 4}, AllowedSlots: 2},
+	}})
+	AssertDecodeNilData[BabeConsensusLog](t)
+
+	var l BabeConsensusLog
+
+	err := l.Encode(*scale.NewEncoder(bytes.NewBuffer(nil)))
+	assert.NotNil(t, err)
+
+	err = l.Decode(*scale.NewDecoder(bytes.NewReader([]byte{4})))
+	assert.NotNil(t, err)
+
+	err = DecodeFromHex("0x0302", &l)
+	assert.NotNil(t, err)
+}
+
+func TestAuraConsensusLog_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, AuraConsensusLog{IsAuthoritiesChange: true, AsAuthoritiesChange: []AuthorityID{{1}, {2}}})
+	AssertRoundtrip(t, AuraConsensusLog{IsOnDisabled: true, AsOnDisabled: 1})
+	AssertDecodeNilData[AuraConsensusLog](t)
+
+	var l AuraConsensusLog
+
+	err := l.Encode(*scale.NewEncoder(bytes.NewBuffer(nil)))
+	assert.NotNil(t, err)
+
+	err = l.Decode(*scale.NewDecoder(bytes.NewReader([]byte{3})))
+	assert.NotNil(t, err)
+}
+
+func TestPreRuntime_Decoded(t *testing.T) {
+	d, err := PreRuntime{
+		ConsensusEngineID: BabeEngineID,
+		Bytes:             MustHexDecodeString("0x02010000000200000000000000"),
+	}.Decoded()
+	require.NoError(t, err)
+	assert.True(t, d.IsBabe)
+	assert.Equal(t, U32(1), d.AsBabe.AuthorityIndex())
+	assert.Equal(t, U64(2), d.AsBabe.Slot())
+
+	d, err = PreRuntime{ConsensusEngineID: AuraEngineID, Bytes: MustHexDecodeString("0x0700000000000000")}.Decoded()
+	require.NoError(t, err)
+	assert.True(t, d.IsAura)
+	assert.Equal(t, U64(7), d.AsAura.Slot)
+
+	d, err = PreRuntime{ConsensusEngineID: NimbusEngineID, Bytes: Bytes(testNimbusAuthor[:])}.Decoded()
+	require.NoError(t, err)
+	assert.True(t, d.IsNimbus)
+	assert.Equal(t, AuthorityID{9}, d.AsNimbus.AuthorID)
+
+	d, err = PreRuntime{ConsensusEngineID: 1, Bytes: Bytes{1, 2}}.Decoded()
+	require.NoError(t, err)
+	assert.True(t, d.IsUnknown)
+	assert.Equal(t, Bytes{1, 2}, d.AsUnknown)
+
+	_, err = PreRuntime{ConsensusEngineID: BabeEngineID, Bytes: Bytes{4}}.Decoded()
+	assert.ErrorContains(t, err, "decoding BABE pre-runtime digest")
+}
+
+func TestConsensus_Decoded(t *testing.T) {
+	d, err := Consensus{ConsensusEngineID: BabeEngineID, Bytes: MustHexDecodeString("0x0204000000")}.Decoded()
+	require.NoError(t, err)
+	assert.True(t, d.IsBabe)
+	assert.Equal(t, BabeConsensusLog{IsOnDisabled: true, AsOnDisabled: 4}, d.AsBabe)
+
+	d, err = Consensus{ConsensusEngineID: AuraEngineID, Bytes: MustHexDecodeString("0x0201000000")}.Decoded()
+	require.NoError(t, err)
+	assert.True(t, d.IsAura)
+	assert.Equal(t, AuraConsensusLog{IsOnDisabled: true, AsOnDisabled: 1}, d.AsAura)
+
+	d, err = Consensus{ConsensusEngineID: GrandpaEngineID, Bytes: Bytes{1}}.Decoded()
+	require.NoError(t, err)
+	assert.True(t, d.IsUnknown)
+	assert.Equal(t, Bytes{1}, d.AsUnknown)
+}
+
+func TestSeal_Decoded(t *testing.T) {
+	sig := NewSignature(bytes.Repeat([]byte{1}, 64))
+
+	d, err := Seal{ConsensusEngineID: AuraEngineID, Bytes: Bytes(sig[:])}.Decoded()
+	require.NoError(t, err)
+	assert.True(t, d.IsSignature)
+	assert.Equal(t, sig, d.AsSignature)
+
+	d, err = Seal{ConsensusEngineID: 1, Bytes: Bytes{1}}.Decoded()
+	require.NoError(t, err)
+	assert.True(t, d.IsUnknown)
+
+	_, err = Seal{ConsensusEngineID: BabeEngineID, Bytes: Bytes{1}}.Decoded()
+	assert.ErrorContains(t, err, "decoding BABE seal")
+}
+
+func TestExtractAuthor(t *testing.T) {
+	header := func(engineID ConsensusEngineID, data string) Header {
+		return Header{Digest: Digest{
+			{IsSeal: true, AsSeal: Seal{ConsensusEngineID: engineID, Bytes: Bytes{1}}},
+			{IsPreRuntime: true, AsPreRuntime: PreRuntime{ConsensusEngineID: engineID, Bytes: MustHexDecodeString(data)}},
+		}}
+	}
+
+	author, err := ExtractAuthor(header(BabeEngineID, "0x02010000000200000000000000"), testValidators)
+	require.NoError(t, err)
+	assert.Equal(t, testValidators[1], *author)
+
+	author, err = ExtractAuthor(header(AuraEngineID, "0x0500000000000000"), testValidators)
+	require.NoError(t, err)
+	assert.Equal(t, testValidators[2], *author)
+
+	author, err = ExtractAuthor(header(NimbusEngineID, HexEncodeToString(testNimbusAuthor[:])), nil)
+	require.NoError(t, err)
+	assert.Equal(t, AccountID{9}, *author)
+
+	_, err = ExtractAuthor(header(BabeEngineID, "0x02030000000200000000000000"), testValidators)
+	assert.ErrorIs(t, err, ErrAuthorityIndexOutOfSet)
+
+	_, err = ExtractAuthor(header(AuraEngineID, "0x0500000000000000"), nil)
+	assert.ErrorIs(t, err, ErrEmptyValidatorSet)
+
+	_, err = ExtractAuthor(header(GrandpaEngineID, "0x01"), testValidators)
+	assert.ErrorIs(t, err, ErrAuthorNotFound)
+	assert.ErrorIs(t, err, ErrUnsupportedConsensus)
+	assert.ErrorContains(t, err, "FRNK")
+
+	_, err = ExtractAuthor(Header{}, testValidators)
+	assert.ErrorIs(t, err, ErrAuthorNotFound)
+	assert.NotErrorIs(t, err, ErrUnsupportedConsensus)
+}
@@ -0,0 +1,381 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// StorageDeposit is the storage deposit that is charged or refunded by a contract execution
+type StorageDeposit struct {
+	IsRefund bool
+	AsRefund U128
+
+	IsCharge bool
+	AsCharge U128
+}
+
+func (s *StorageDeposit) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		s.IsRefund = true
+
+		return decoder.Decode(&s.AsRefund)
+	case 1:
+		s.IsCharge = true
+
+		return decoder.Decode(&s.AsCharge)
+	}
+
+	return fmt.Errorf("unsupported storage deposit: %d", b)
+}
+
+func (s StorageDeposit) Encode(encoder scale.Encoder) error {
+	switch {
+	case s.IsRefund:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(s.AsRefund)
+	case s.IsCharge:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(s.AsCharge)
+	}
+
+	return nil
+}
+
+// ContractReturnFlags are the flags a contract returns with its output
+type ContractReturnFlags U32
+
+const (
+	// ContractReturnFlagRevert signals that the contract reverted its state changes
+	ContractReturnFlagRevert ContractReturnFlags = 1
+)
+
+// IsRevert returns true if the contract reverted its state changes
+func (f ContractReturnFlags) IsRevert() bool {
+	return f&ContractReturnFlagRevert != 0
+}
+
+// ContractExecReturnValue is the output of a contract execution
+type ContractExecReturnValue struct {
+	Flags ContractReturnFlags
+	// Data is the SCALE encoded return value of the contract message, or the revert data
+	Data Bytes
+}
+
+// ContractInstantiateReturnValue is the output of a contract instantiation
+type ContractInstantiateReturnValue struct {
+	Result ContractExecReturnValue
+	// AccountID is the address of the new contract
+	AccountID AccountID
+}
+
+// ContractResult is the result of dry-running a contract via the ContractsApi runtime API. The execution either
+// returned Ok, which might still be a revert, see ContractReturnFlags, or failed with a DispatchError.
+type ContractResult[T any] struct {
+	// GasConsumed is the weight that was consumed by the execution
+	GasConsumed Weight
+	// GasRequired is the weight that is required as gas limit to execute the contract, it can be higher than
+	// GasConsumed since weight can be refunded during the execution
+	GasRequired Weight
+	// StorageDeposit is the storage deposit that was charged or refunded, a charge is the required storage deposit
+	// limit
+	StorageDeposit StorageDeposit
+	// DebugMessage holds the debug output of the contract, if debug output is enabled in the runtime
+	DebugMessage Bytes
+
+	IsOk bool
+	Ok   T
+
+	IsError bool
+	Error   DispatchError
+
+	// Events holds the SCALE encoded Option<Vec<EventRecord>> that newer runtimes return after the result, it is
+	// empty for older runtimes. Since the events can't be decoded without the metadata, they are kept encoded and
+	// the result must be decoded from the complete output of the runtime API.
+	Events Bytes
+}
+
+// ContractExecResult is the result of ContractsApi_call
+type ContractExecResult = ContractResult[ContractExecReturnValue]
+
+// ContractInstantiateResult is the result of ContractsApi_instantiate
+type ContractInstantiateResult = ContractResult[ContractInstantiateReturnValue]
+
+func (r *ContractResult[T]) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&r.GasConsumed); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&r.GasRequired); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&r.StorageDeposit); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&r.DebugMessage); err != nil {
+		return err
+	}
+
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		r.IsOk = true
+		err = decoder.Decode(&r.Ok)
+	case 1:
+		r.IsError = true
+		err = decoder.Decode(&r.Error)
+	default:
+		return fmt.Errorf("unsupported contract result: %d", b)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	r.Events = nil
+
+	for {
+		b, err := decoder.ReadOneByte()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		r.Events = append(r.Events, b)
+	}
+}
+
+func (r ContractResult[T]) Encode(encoder scale.Encoder) error {
+	for _, v := range []interface{}{r.GasConsumed, r.GasRequired, r.StorageDeposit, r.DebugMessage} {
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case r.IsOk:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		if err := encoder.Encode(r.Ok); err != nil {
+			return err
+		}
+	case r.IsError:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		if err := encoder.Encode(r.Error); err != nil {
+			return err
+		}
+	}
+
+	return encoder.Write(r.Events)
+}
+
+// ContractCode is the code of a contract that is instantiated, either uploaded with the instantiation or already
+// stored on chain
+type ContractCode struct {
+	IsUpload bool
+	AsUpload Bytes
+
+	IsExisting bool
+	AsExisting Hash
+}
+
+func (c *ContractCode) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		c.IsUpload = true
+
+		return decoder.Decode(&c.AsUpload)
+	case 1:
+		c.IsExisting = true
+
+		return decoder.Decode(&c.AsExisting)
+	}
+
+	return fmt.Errorf("unsupported contract code: %d", b)
+}
+
+func (c ContractCode) Encode(encoder scale.Encoder) error {
+	switch {
+	case c.IsUpload:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(c.AsUpload)
+	case c.IsExisting:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(c.AsExisting)
+	}
+
+	return errors.New("contract code is neither upload nor existing")
+}
+
+// ContractDeterminism specifies whether uploaded code may use non-deterministic instructions
+type ContractDeterminism U8
+
+const (
+	// ContractDeterminismEnforced only allows deterministic code, which can be used by all calls
+	ContractDeterminismEnforced ContractDeterminism = 0
+	// ContractDeterminismRelaxed allows non-deterministic code, which can only be used in off-chain calls
+	ContractDeterminismRelaxed ContractDeterminism = 1
+)
+
+// CodeUploadReturnValue is the output of an upload of contract code
+type CodeUploadReturnValue struct {
+	CodeHash Hash
+	// Deposit is the storage deposit that is reserved for the code
+	Deposit U128
+}
+
+// CodeUploadResult is the result of ContractsApi_upload_code
+type CodeUploadResult struct {
+	IsOk bool
+	Ok   CodeUploadReturnValue
+
+	IsError bool
+	Error   DispatchError
+}
+
+func (r *CodeUploadResult) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		r.IsOk = true
+
+		return decoder.Decode(&r.Ok)
+	case 1:
+		r.IsError = true
+
+		return decoder.Decode(&r.Error)
+	}
+
+	return fmt.Errorf("unsupported code upload result: %d", b)
+}
+
+func (r CodeUploadResult) Encode(encoder scale.Encoder) error {
+	switch {
+	case r.IsOk:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(r.Ok)
+	case r.IsError:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(r.Error)
+	}
+
+	return nil
+}
+
+// ContractAccessError is returned by ContractsApi_get_storage if the storage of the contract can't be read
+type ContractAccessError struct {
+	IsDoesntExist         bool
+	IsKeyDecodingFailed   bool
+	IsMigrationInProgress bool
+}
+
+func (e *ContractAccessError) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		e.IsDoesntExist = true
+	case 1:
+		e.IsKeyDecodingFailed = true
+	case 2:
+		e.IsMigrationInProgress = true
+	default:
+		return fmt.Errorf("unsupported contract access error: %d", b)
+	}
+
+	return nil
+}
+
+func (e ContractAccessError) Encode(encoder scale.Encoder) error {
+	switch {
+	case e.IsDoesntExist:
+		return encoder.PushByte(0)
+	case e.IsKeyDecodingFailed:
+		return encoder.PushByte(1)
+	case e.IsMigrationInProgress:
+		return encoder.PushByte(2)
+	}
+
+	return nil
+}
+
+func (e ContractAccessError) String() string {
+	switch {
+	case e.IsDoesntExist:
+		return "DoesntExist"
+	case e.IsKeyDecodingFailed:
+		return "KeyDecodingFailed"
+	case e.IsMigrationInProgress:
+		return "MigrationInProgress"
+	}
+
+	return "Unknown"
+}
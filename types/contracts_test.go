@@ -0,0 +1,118 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testContractExecResult = ContractExecResult{
+		GasConsumed:    NewWeight(NewUCompactFromUInt(1000), NewUCompactFromUInt(10)),
+		GasRequired:    NewWeight(NewUCompactFromUInt(2000), NewUCompactFromUInt(20)),
+		StorageDeposit: StorageDeposit{IsCharge: true, AsCharge: NewU128(*big.NewInt(500))},
+		IsOk:           true,
+		Ok:             ContractExecReturnValue{Flags: ContractReturnFlagRevert, Data: Bytes{0x2a, 0}},
+	}
+
+	testContractExecResultHex = "0xa10f28411f5001f401000000000000000000000000000000000100000008" + "2a00"
+)
+
+func TestContractExecResult_Decode(t *testing.T) {
+	withEvents := testContractExecResult
+	withEvents.Events = Bytes{0}
+
+	AssertDecode(t, []DecodingAssert{
+		{Input: MustHexDecodeString(testContractExecResultHex), Expected: testContractExecResult},
+		{Input: MustHexDecodeString(testContractExecResultHex + "00"), Expected: withEvents},
+	})
+}
+
+func TestContractExecResult_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, testContractExecResult)
+
+	withEvents := testContractExecResult
+	withEvents.Events = Bytes{1, 0}
+	AssertRoundtrip(t, withEvents)
+
+	AssertRoundtrip(t, ContractInstantiateResult{
+		GasConsumed:    NewWeight(NewUCompactFromUInt(1), NewUCompactFromUInt(2)),
+		GasRequired:    NewWeight(NewUCompactFromUInt(3), NewUCompactFromUInt(4)),
+		StorageDeposit: StorageDeposit{IsRefund: true, AsRefund: NewU128(*big.NewInt(5))},
+		DebugMessage:   Bytes("debug"),
+		IsError:        true,
+		Error:          DispatchError{IsModule: true, ModuleError: ModuleError{Index: 8, Error: [4]U8{11}}},
+	})
+
+	AssertRoundtrip(t, ContractInstantiateResult{
+		GasConsumed:    NewWeight(NewUCompactFromUInt(1), NewUCompactFromUInt(2)),
+		GasRequired:    NewWeight(NewUCompactFromUInt(3), NewUCompactFromUInt(4)),
+		StorageDeposit: StorageDeposit{IsCharge: true, AsCharge: NewU128(*big.NewInt(5))},
+		IsOk:           true,
+		Ok: ContractInstantiateReturnValue{
+			Result:    ContractExecReturnValue{Data: Bytes{1}},
+			AccountID: AccountID{1, 2, 3},
+		},
+	})
+}
+
+func TestContractReturnFlags_IsRevert(t *testing.T) {
+	assert.True(t, ContractReturnFlagRevert.IsRevert())
+	assert.False(t, ContractReturnFlags(0).IsRevert())
+}
+
+func TestContractCode_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, ContractCode{IsUpload: true, AsUpload: Bytes{0, 0x61, 0x73, 0x6d}})
+	AssertRoundtrip(t, ContractCode{IsExisting: true, AsExisting: Hash{1, 2, 3}})
+
+	AssertEncode(t, []EncodingAssert{
+		{Input: ContractCode{IsUpload: true, AsUpload: Bytes{1}}, Expected: MustHexDecodeString("0x000401")},
+	})
+
+	_, err := Encode(ContractCode{})
+	assert.Error(t, err)
+}
+
+func TestCodeUploadResult_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, CodeUploadResult{
+		IsOk: true,
+		Ok:   CodeUploadReturnValue{CodeHash: Hash{1, 2}, Deposit: NewU128(*big.NewInt(1000))},
+	})
+	AssertRoundtrip(t, CodeUploadResult{IsError: true, Error: DispatchError{IsBadOrigin: true}})
+}
+
+func TestContractAccessError_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, ContractAccessError{IsDoesntExist: true})
+	AssertRoundtrip(t, ContractAccessError{IsKeyDecodingFailed: true})
+	AssertRoundtrip(t, ContractAccessError{IsMigrationInProgress: true})
+
+	AssertString(t, []StringAssert{
+		{Input: ContractAccessError{IsDoesntExist: true}, Expected: "DoesntExist"},
+		{Input: ContractAccessError{IsMigrationInProgress: true}, Expected: "MigrationInProgress"},
+	})
+}
+
+func TestStorageDeposit_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, StorageDeposit{IsRefund: true, AsRefund: NewU128(*big.NewInt(1))})
+	AssertRoundtrip(t, StorageDeposit{IsCharge: true, AsCharge: NewU128(*big.NewInt(2))})
+}
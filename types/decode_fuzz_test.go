@@ -0,0 +1,65 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// FuzzEventRecordsRaw_DecodeEventRecords asserts that malformed event records do not panic.
+func FuzzEventRecordsRaw_DecodeEventRecords(f *testing.F) {
+	var meta Metadata
+	if err := DecodeFromHex(MetadataV14Data, &meta); err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(MustHexDecodeString("0x0400020000000302d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48266d00000000000000000000000000000010a5d4e8000000000000000000000000")) //nolint:lll
+	f.Add([]byte{0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte{0x03, 0xff, 0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, m := range []*Metadata{&meta, ExamplaryMetadataV8} {
+			var events EventRecords
+			_ = EventRecordsRaw(data).DecodeEventRecords(m, &events)
+		}
+	})
+}
+
+// FuzzExtrinsic_Decode asserts that malformed extrinsics do not panic.
+func FuzzExtrinsic_Decode(f *testing.F) {
+	encoded, err := Encode(ExamplaryExtrinsic)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(encoded)
+	f.Add([]byte{0x00, 0x84, 0x00})
+	f.Add([]byte{0x00, 0x04, 0x00, 0x00, 0x03, 0xff, 0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var extrinsic Extrinsic
+		if err := Decode(data, &extrinsic); err != nil {
+			return
+		}
+
+		// Decoded extrinsics are encoded again when they are submitted or hashed.
+		_, _ = Encode(extrinsic)
+	})
+}
@@ -473,10 +473,18 @@ type EventRecords struct {
 	XcmPallet_NotifyTargetMigrationFail []EventXcmPalletNotifyTargetMigrationFail `test-gen-blockchain:"polkadot"`
 }
 
+// dynamicEventDecodingHint is added to the errors of DecodeEventRecords that are caused by events that the target
+// does not know or defines differently than the runtime.
+const dynamicEventDecodingHint = "use parser.DecodeEventRecords to decode the events of any runtime via its metadata"
+
 // DecodeEventRecords decodes the events records from an EventRecordRaw into a target t using the given Metadata m
 // If this method returns an error like `unable to decode Phase for event #x: EOF`, it is likely that you have defined
 // a custom event record with a wrong type. For example your custom event record has a field with a length prefixed
 // type, such as types.Bytes, where your event in reallity contains a fixed width type, such as a types.U32.
+//
+// Deprecated: The target has to hold the events of all pallets of the runtime, which breaks on chains with custom
+// pallets and on runtime upgrades. Use parser.DecodeEventRecords of the registry/parser package instead, which decodes
+// the events via the types of the metadata.
 func (e EventRecordsRaw) DecodeEventRecords(m *Metadata, t interface{}) error { //nolint:funlen
 	log.Debug(fmt.Sprintf("will decode event records from raw hex: %#x", e))
 
@@ -543,7 +551,8 @@ func (e EventRecordsRaw) DecodeEventRecords(m *Metadata, t interface{}) error {
 		// check whether name for eventID exists in t
 		field := val.FieldByName(fmt.Sprintf("%v_%v", moduleName, eventName))
 		if !field.IsValid() {
-			return fmt.Errorf("unable to find field %v_%v for event #%v with EventID %v", moduleName, eventName, i, id)
+			return fmt.Errorf("unable to find field %v_%v for event #%v with EventID %v, %s", moduleName, eventName, i,
+				id, dynamicEventDecodingHint)
 		}
 
 		// create a pointer to with the correct type that will hold the decoded event
@@ -573,8 +582,8 @@ func (e EventRecordsRaw) DecodeEventRecords(m *Metadata, t interface{}) error {
 		for j := 1; j < numFields; j++ {
 			err = decoder.Decode(holder.Elem().FieldByIndex([]int{j}).Addr().Interface())
 			if err != nil {
-				return fmt.Errorf("unable to decode field %v event #%v with EventID %v, field %v_%v: %v", j, i, id, moduleName,
-					eventName, err)
+				return fmt.Errorf("unable to decode field %v event #%v with EventID %v, field %v_%v: %v, %s", j, i, id,
+					moduleName, eventName, err, dynamicEventDecodingHint)
 			}
 		}
 
@@ -898,6 +898,25 @@ func (d *DispatchInfo) Decode(decoder scale.Decoder) error {
 	return decoder.Decode(&d.PaysFee)
 }
 
+// DispatchInfoV1 is the DispatchInfo of runtimes that still used V1 weights, it allows to decode historical events
+type DispatchInfoV1 struct {
+	// Weight of this transaction
+	Weight WeightV1
+	// Class of this transaction
+	Class DispatchClass
+	// PaysFee indicates whether this transaction pays fees
+	PaysFee Pays
+}
+
+// ToDispatchInfo converts the dispatch info to a DispatchInfo with a V2 weight without a proof size
+func (d DispatchInfoV1) ToDispatchInfo() DispatchInfo {
+	return DispatchInfo{
+		Weight:  d.Weight.ToWeight(),
+		Class:   d.Class,
+		PaysFee: d.PaysFee,
+	}
+}
+
 // DispatchClass is a generalized group of dispatch types. This is only distinguishing normal, user-triggered
 // transactions (`Normal`) and anything beyond which serves a higher purpose to the system (`Operational`).
 type DispatchClass struct {
@@ -958,6 +977,27 @@ type EventSystemExtrinsicFailed struct {
 	Topics        []Hash
 }
 
+// EventSystemExtrinsicSuccessWeightV1 is emitted when an extrinsic completed successfully
+//
+// EventSystemExtrinsicSuccessWeightV1 exists to allow users to simply implement their own EventRecords struct to decode
+// the events of runtimes that still used V1 weights. Use EventSystemExtrinsicSuccess otherwise
+type EventSystemExtrinsicSuccessWeightV1 struct {
+	Phase        Phase
+	DispatchInfo DispatchInfoV1
+	Topics       []Hash
+}
+
+// EventSystemExtrinsicFailedWeightV1 is emitted when an extrinsic failed
+//
+// EventSystemExtrinsicFailedWeightV1 exists to allow users to simply implement their own EventRecords struct to decode
+// the events of runtimes that still used V1 weights. Use EventSystemExtrinsicFailed otherwise
+type EventSystemExtrinsicFailedWeightV1 struct {
+	Phase         Phase
+	DispatchError DispatchError
+	DispatchInfo  DispatchInfoV1
+	Topics        []Hash
+}
+
 // EventSystemCodeUpdated is emitted when the runtime code (`:code`) is updated
 type EventSystemCodeUpdated struct {
 	Phase  Phase
@@ -1372,11 +1412,21 @@ type VoteAccountVoteAsSplit struct {
 	Nay U128
 }
 
+// VoteAccountVoteAsSplitAbstain is a vote split into aye, nay and abstain balances, which is only supported by the
+// conviction voting pallet.
+type VoteAccountVoteAsSplitAbstain struct {
+	Aye     U128
+	Nay     U128
+	Abstain U128
+}
+
 type VoteAccountVote struct {
-	IsStandard bool
-	AsStandard VoteAccountVoteAsStandard
-	IsSplit    bool
-	AsSplit    VoteAccountVoteAsSplit
+	IsStandard     bool
+	AsStandard     VoteAccountVoteAsStandard
+	IsSplit        bool
+	AsSplit        VoteAccountVoteAsSplit
+	IsSplitAbstain bool
+	AsSplitAbstain VoteAccountVoteAsSplitAbstain
 }
 
 func (vv *VoteAccountVote) Decode(decoder scale.Decoder) error {
@@ -1395,6 +1445,10 @@ func (vv *VoteAccountVote) Decode(decoder scale.Decoder) error {
 		vv.IsSplit = true
 
 		return decoder.Decode(&vv.AsSplit)
+	case 2:
+		vv.IsSplitAbstain = true
+
+		return decoder.Decode(&vv.AsSplitAbstain)
 	}
 
 	return nil
@@ -1414,6 +1468,12 @@ func (vv VoteAccountVote) Encode(encoder scale.Encoder) error {
 		}
 
 		return encoder.Encode(vv.AsSplit)
+	case vv.IsSplitAbstain:
+		if err := encoder.PushByte(2); err != nil {
+			return err
+		}
+
+		return encoder.Encode(vv.AsSplitAbstain)
 	}
 
 	return nil
@@ -40,6 +40,22 @@ func TestDispatchInfo_EncodeDecode(t *testing.T) {
 	AssertEncodeEmptyObj[DispatchInfo](t, 2)
 }
 
+func TestDispatchInfoV1_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[DispatchInfoV1](t, 100, dispatchInfoFuzzOpts...)
+	AssertDecodeNilData[DispatchInfoV1](t)
+	AssertEncodeEmptyObj[DispatchInfoV1](t, 8)
+}
+
+func TestDispatchInfoV1_ToDispatchInfo(t *testing.T) {
+	info := DispatchInfoV1{Weight: NewWeightV1(11), Class: DispatchClass{IsMandatory: true}, PaysFee: Pays{IsNo: true}}
+
+	assert.Equal(t, DispatchInfo{
+		Weight:  NewWeight(NewUCompactFromUInt(11), NewUCompactFromUInt(0)),
+		Class:   DispatchClass{IsMandatory: true},
+		PaysFee: Pays{IsNo: true},
+	}, info.ToDispatchInfo())
+}
+
 func TestVoteThreshold_Decoder(t *testing.T) {
 	// SuperMajorityAgainst
 	decoder := scale.NewDecoder(bytes.NewReader([]byte{1}))
@@ -141,14 +157,17 @@ var (
 		democracyConvictionFuzzOpts,
 		[]FuzzOpt{
 			WithFuzzFuncs(func(v *VoteAccountVote, c fuzz.Continue) {
-				if c.RandBool() {
+				switch c.Intn(3) {
+				case 0:
 					v.IsStandard = true
 					c.Fuzz(&v.AsStandard)
-					return
+				case 1:
+					v.IsSplit = true
+					c.Fuzz(&v.AsSplit)
+				case 2:
+					v.IsSplitAbstain = true
+					c.Fuzz(&v.AsSplitAbstain)
 				}
-
-				v.IsSplit = true
-				c.Fuzz(&v.AsSplit)
 			}),
 		},
 	)
@@ -39,6 +39,13 @@ const (
 	ExtrinsicVersion2       = 2
 	ExtrinsicVersion3       = 3
 	ExtrinsicVersion4       = 4
+	ExtrinsicVersion5       = 5
+	// ExtrinsicBitGeneral marks general transactions, which carry transaction extensions without a signature. They
+	// exist since extrinsic format version 5.
+	ExtrinsicBitGeneral = 0x40
+	// ExtrinsicTypeMask masks the two bits of the version flag that encode the type of the extrinsic: bare, signed or
+	// general.
+	ExtrinsicTypeMask = 0xc0
 )
 
 // Extrinsic is a piece of Args bundled into a block that expresses something from the "external" (i.e. off-chain)
@@ -49,6 +56,8 @@ type Extrinsic struct {
 	Version byte
 	// Signature is the ExtrinsicSignatureV4, it's presence depends on the Version flag
 	Signature ExtrinsicSignatureV4
+	// Extensions are the transaction extensions of a general transaction, their presence depends on the Version flag
+	Extensions ExtrinsicExtensionsV5
 	// Method is the call this extrinsic wraps
 	Method Call
 }
@@ -61,6 +70,25 @@ func NewExtrinsic(c Call) Extrinsic {
 	}
 }
 
+// NewExtrinsicV5 creates a new bare Extrinsic of format version 5 from the provided Call, e.g. an inherent.
+func NewExtrinsicV5(c Call) Extrinsic {
+	return Extrinsic{
+		Version: ExtrinsicVersion5,
+		Method:  c,
+	}
+}
+
+// NewGeneralExtrinsic creates a general transaction of format version 5, which carries the transaction extensions
+// without a signature, e.g. for calls that are authorized by one of the extensions. The extensions can be set via
+// SetExtensionsWithMetadata.
+func NewGeneralExtrinsic(c Call, extensions ExtrinsicExtensionsV5) Extrinsic {
+	return Extrinsic{
+		Version:    ExtrinsicVersion5 | ExtrinsicBitGeneral,
+		Extensions: extensions,
+		Method:     c,
+	}
+}
+
 // UnmarshalJSON fills Extrinsic with the JSON encoded byte array given by bz
 func (e *Extrinsic) UnmarshalJSON(bz []byte) error {
 	var tmp string
@@ -115,68 +143,236 @@ func (e Extrinsic) IsSigned() bool {
 	return e.Version&ExtrinsicBitSigned == ExtrinsicBitSigned
 }
 
-// Type returns the raw transaction version (not flagged with signing information)
+// IsGeneral returns true if the extrinsic is a general transaction, see NewGeneralExtrinsic
+func (e Extrinsic) IsGeneral() bool {
+	return e.Version&ExtrinsicTypeMask == ExtrinsicBitGeneral
+}
+
+// Type returns the raw transaction version (not flagged with signing information or the general bit)
 func (e Extrinsic) Type() uint8 {
-	return e.Version & ExtrinsicUnmaskVersion
+	return e.Version &^ ExtrinsicTypeMask
+}
+
+// checkVersion returns an error for version flags that cannot be encoded or decoded: signed extrinsics of format
+// version 4 and general ones of format version 5.
+func (e Extrinsic) checkVersion() error {
+	switch {
+	case e.Version&ExtrinsicTypeMask == ExtrinsicTypeMask,
+		e.IsSigned() && e.Type() != ExtrinsicVersion4,
+		e.IsGeneral() && e.Type() != ExtrinsicVersion5:
+		return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(),
+			e.Type())
+	}
+
+	return nil
+}
+
+// SetExtensionsWithMetadata sets the transaction extensions of a general transaction to the ones declared by the
+// metadata in their declared order, like SignWithMetadata includes them in signed extrinsics.
+func (e *Extrinsic) SetExtensionsWithMetadata(meta *Metadata, o SignatureOptions, values SignedExtensionValues) error {
+	if !e.IsGeneral() {
+		return fmt.Errorf("extrinsic with version %v is not a general transaction", e.Version)
+	}
+
+	if meta.Version < 14 {
+		return fmt.Errorf("transaction extensions are not supported for metadata V%d", meta.Version)
+	}
+
+	signedExtensions, err := NewSignedExtensionPayload(meta, o, values)
+	if err != nil {
+		return err
+	}
+
+	e.Extensions = ExtrinsicExtensionsV5{
+		Era:   o.era(),
+		Nonce: o.Nonce,
+		Tip:   o.Tip,
+		Extra: signedExtensions.Extra,
+	}
+
+	return nil
 }
 
-// Sign adds a signature to the extrinsic
+// Sign adds a signature of the crypto scheme of the signer to the extrinsic
 func (e *Extrinsic) Sign(signer signature.KeyringPair, o SignatureOptions) error {
+	return e.SignWithSigner(NewKeyringPairSigner(signer), o)
+}
+
+// SignWithSigner is like Sign but signs with the given Signer, e.g. a remote signer.
+func (e *Extrinsic) SignWithSigner(signer Signer, o SignatureOptions) error {
 	if e.Type() != ExtrinsicVersion4 {
 		return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(), e.Type())
 	}
 
-	mb, err := codec.Encode(e.Method)
+	payload, err := newSigningPayloadV4(e.Method, o)
 	if err != nil {
 		return err
 	}
 
-	era := o.Era
-	if !o.Era.IsMortalEra {
-		era = ExtrinsicEra{IsImmortalEra: true}
+	sig, err := signer.Sign(payload.ToSign())
+	if err != nil {
+		return err
 	}
 
-	payload := ExtrinsicPayloadV4{
-		ExtrinsicPayloadV3: ExtrinsicPayloadV3{
-			Method:      mb,
-			Era:         era,
-			Nonce:       o.Nonce,
-			Tip:         o.Tip,
-			SpecVersion: o.SpecVersion,
-			GenesisHash: o.GenesisHash,
-			BlockHash:   o.BlockHash,
-		},
-		TransactionVersion: o.TransactionVersion,
+	*e = payload.extrinsic(MultiAddress{IsID: true, AsID: signer.AccountID()}, sig)
+
+	return nil
+}
+
+// newMultiSignature returns the MultiSignature of the given crypto scheme.
+func newMultiSignature(scheme signature.Scheme, sig []byte) (MultiSignature, error) {
+	switch scheme {
+	case signature.Sr25519:
+		return MultiSignature{IsSr25519: true, AsSr25519: NewSignature(sig)}, nil
+	case signature.Ed25519:
+		return MultiSignature{IsEd25519: true, AsEd25519: NewSignature(sig)}, nil
+	case signature.Ecdsa:
+		return MultiSignature{IsEcdsa: true, AsEcdsa: NewEcdsaSignature(sig)}, nil
+	default:
+		return MultiSignature{}, fmt.Errorf("%w: %v", signature.ErrUnknownScheme, scheme)
+	}
+}
+
+// SignWithMetadata adds a signature to the extrinsic, including the signed extensions declared by the metadata in
+// their declared order, see NewSignedExtensionPayload. The values of signed extensions can be set via values, all
+// other ones default to the values of the SignatureOptions. Metadata before V14 does not declare the types of signed
+// extensions, the extrinsic is signed via SignWithSigner instead. Key pairs can be used via NewKeyringPairSigner.
+//
+// The signer is attached in the format of the runtime, see Metadata.SignerFormat: as MultiAddress::Id by default, or as
+// the AccountId20 of its compressed ecdsa public key for Ethereum-compatible runtimes, which requires the signer to
+// return ecdsa signatures of the keccak-256 hash of the payload.
+func (e *Extrinsic) SignWithMetadata(
+	signer Signer,
+	meta *Metadata,
+	o SignatureOptions,
+	values SignedExtensionValues,
+) error {
+	if meta.Version < 14 {
+		return e.SignWithSigner(signer, o)
+	}
+
+	if meta.SignerFormat() == SignerFormatEthereum {
+		return e.signWithMetadataEthereum(signer, meta, o, values)
 	}
 
-	signerPubKey, err := NewMultiAddressFromAccountID(signer.PublicKey)
+	signerAddress := MultiAddress{IsID: true, AsID: signer.AccountID()}
+
+	return e.signWithMetadata(meta, o, values, signerAddress, signer.Sign)
+}
 
+// SignWithMetadataEcdsa is like SignWithMetadata but signs with an ecdsa key pair via NewEcdsaKeyringPairSigner. Key
+// pairs with the keccak-256 hasher sign for Ethereum-compatible chains like Moonbeam, which use the AccountId20 of the
+// key as signer and the bare signature, see ExtrinsicSignatureV4.Ethereum. For Ethereum-compatible runtimes, see
+// Metadata.SignerFormat, key pairs without the keccak-256 hasher fail with ErrSignerFormatMismatch, as the runtime
+// would reject their signatures. It requires metadata V14 or later.
+func (e *Extrinsic) SignWithMetadataEcdsa(
+	signer signature.KeyringPair,
+	meta *Metadata,
+	o SignatureOptions,
+	values SignedExtensionValues,
+) error {
+	if meta.Version < 14 {
+		return fmt.Errorf("signing with ecdsa keys is not supported for metadata V%d", meta.Version)
+	}
+
+	ecdsaSigner, err := NewEcdsaKeyringPairSigner(signer)
 	if err != nil {
 		return err
 	}
 
-	sig, err := payload.Sign(signer)
+	switch {
+	case signer.Hasher == signature.EcdsaHasherKeccak256:
+		return e.signWithMetadataEthereum(ecdsaSigner, meta, o, values)
+	case meta.SignerFormat() == SignerFormatEthereum:
+		return fmt.Errorf("%w: the runtime expects ecdsa signatures of the keccak-256 hash, got hasher %d",
+			ErrSignerFormatMismatch, signer.Hasher)
+	default:
+		return e.SignWithMetadata(ecdsaSigner, meta, o, values)
+	}
+}
+
+// signWithMetadataEthereum adds the ecdsa signature of the payload with the AccountId20 of the signer, see
+// ExtrinsicSignatureV4.Ethereum.
+func (e *Extrinsic) signWithMetadataEthereum(
+	signer Signer,
+	meta *Metadata,
+	o SignatureOptions,
+	values SignedExtensionValues,
+) error {
+	accountID20, err := signature.EcdsaAccountID20(signer.PublicKey())
+	if err != nil {
+		return fmt.Errorf("%w: the runtime expects AccountId20 signers: %v", ErrSignerFormatMismatch, err)
+	}
+
+	signerAddress, err := NewMultiAddressFromAccountID20(accountID20)
 	if err != nil {
 		return err
 	}
 
-	extSig := ExtrinsicSignatureV4{
-		Signer:    signerPubKey,
-		Signature: MultiSignature{IsSr25519: true, AsSr25519: sig},
-		Era:       era,
-		Nonce:     o.Nonce,
-		Tip:       o.Tip,
+	sign := func(payload []byte) (MultiSignature, error) {
+		sig, err := signer.Sign(payload)
+		if err != nil {
+			return MultiSignature{}, err
+		}
+
+		if !sig.IsEcdsa {
+			return MultiSignature{}, fmt.Errorf("%w: the runtime expects ecdsa signatures", ErrSignerFormatMismatch)
+		}
+
+		return sig, nil
 	}
 
-	e.Signature = extSig
+	if err := e.signWithMetadata(meta, o, values, signerAddress, sign); err != nil {
+		return err
+	}
 
-	// mark the extrinsic as signed
-	e.Version |= ExtrinsicBitSigned
+	e.Signature.Ethereum = true
+
+	return nil
+}
+
+// signWithMetadata adds the signature of the payload created from the signed extensions declared by the metadata.
+func (e *Extrinsic) signWithMetadata(
+	meta *Metadata,
+	o SignatureOptions,
+	values SignedExtensionValues,
+	signer MultiAddress,
+	sign func(payload []byte) (MultiSignature, error),
+) error {
+	if e.Type() != ExtrinsicVersion4 {
+		return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(), e.Type())
+	}
+
+	payload, err := NewSigningPayload(meta, e.Method, o, values)
+	if err != nil {
+		return err
+	}
+
+	sig, err := sign(payload.ToSign())
+	if err != nil {
+		return err
+	}
+
+	*e = payload.extrinsic(signer, sig)
 
 	return nil
 }
 
 func (e *Extrinsic) Decode(decoder scale.Decoder) error {
+	return e.decode(decoder, nil)
+}
+
+// DecodeWithMetadata decodes an extrinsic whose signature or transaction extensions are laid out as declared by the
+// metadata, see ExtrinsicSignatureV4.DecodeWithMetadata. Unlike Decode, it decodes the signatures and transaction
+// extensions of runtimes with other signed extensions than CheckMortality, CheckNonce and ChargeTransactionPayment,
+// e.g. CheckMetadataHash, and the signatures of Ethereum-compatible runtimes. The decoded extrinsic encodes to the same
+// bytes again.
+func (e *Extrinsic) DecodeWithMetadata(decoder scale.Decoder, meta *Metadata) error {
+	return e.decode(decoder, meta)
+}
+
+// decode decodes the extrinsic, the signature and transaction extensions are decoded with the metadata if it is set.
+func (e *Extrinsic) decode(decoder scale.Decoder, meta *Metadata) error {
 	// compact length encoding (1, 2, or 4 bytes) (may not be there for Extrinsics older than Jan 11 2019)
 	_, err := decoder.DecodeUintCompact()
 	if err != nil {
@@ -189,17 +385,22 @@ func (e *Extrinsic) Decode(decoder scale.Decoder) error {
 		return err
 	}
 
-	// signature
-	if e.IsSigned() {
-		if e.Type() != ExtrinsicVersion4 {
-			return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(),
-				e.Type())
-		}
+	if err := e.checkVersion(); err != nil {
+		return err
+	}
 
+	switch {
+	case e.IsSigned() && meta != nil:
+		err = e.Signature.DecodeWithMetadata(decoder, meta)
+	case e.IsSigned():
 		err = decoder.Decode(&e.Signature)
-		if err != nil {
-			return err
-		}
+	case e.IsGeneral() && meta != nil:
+		err = e.Extensions.DecodeWithMetadata(decoder, meta)
+	case e.IsGeneral():
+		err = decoder.Decode(&e.Extensions)
+	}
+	if err != nil {
+		return err
 	}
 
 	// call
@@ -212,11 +413,15 @@ func (e *Extrinsic) Decode(decoder scale.Decoder) error {
 }
 
 func (e Extrinsic) Encode(encoder scale.Encoder) error {
-	if e.Type() != ExtrinsicVersion4 {
+	if e.Type() != ExtrinsicVersion4 && e.Type() != ExtrinsicVersion5 {
 		return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(),
 			e.Type())
 	}
 
+	if err := e.checkVersion(); err != nil {
+		return err
+	}
+
 	// create a temporary buffer that will receive the plain encoded transaction (version, signature (optional),
 	// method/call)
 	var bb = bytes.Buffer{}
@@ -228,12 +433,15 @@ func (e Extrinsic) Encode(encoder scale.Encoder) error {
 		return err
 	}
 
-	// encode the signature if signed
-	if e.IsSigned() {
+	// encode the signature if signed, or the transaction extensions if general
+	switch {
+	case e.IsSigned():
 		err = tempEnc.Encode(e.Signature)
-		if err != nil {
-			return err
-		}
+	case e.IsGeneral():
+		err = tempEnc.Encode(e.Extensions)
+	}
+	if err != nil {
+		return err
 	}
 
 	// encode the method
@@ -17,9 +17,18 @@
 package types
 
 import (
+	"fmt"
+	"math"
+	"math/bits"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 )
 
+const (
+	minMortalEraPeriod = 4
+	maxMortalEraPeriod = 1 << 16
+)
+
 // ExtrinsicEra indicates either a mortal or immortal extrinsic
 type ExtrinsicEra struct {
 	IsImmortalEra bool
@@ -46,7 +55,14 @@ func (e *ExtrinsicEra) Decode(decoder scale.Decoder) error {
 		return err
 	}
 
-	e.AsMortalEra = MortalEra{first, second}
+	mortalEra := MortalEra{first, second}
+
+	// The runtime rejects eras that don't decode to a valid period and phase, so they are rejected here as well.
+	if period, phase := mortalEra.Period(), mortalEra.Phase(); period < minMortalEraPeriod || phase >= period {
+		return fmt.Errorf("invalid mortal era: period %d, phase %d", period, phase)
+	}
+
+	e.AsMortalEra = mortalEra
 
 	return nil
 }
@@ -69,8 +85,96 @@ func (e ExtrinsicEra) Encode(encoder scale.Encoder) error {
 	return nil
 }
 
+// NewMortalEra creates a mortal era that is valid for the given period starting at the current block, as done by
+// Era::mortal in sp_runtime. The period is rounded up to the next power of two between 4 and 65536, and the phase is
+// quantized for periods above 4096, so the era might start at a block before the current block. That block is
+// returned as birth block, its hash has to be signed as block hash of the CheckMortality signed extension.
+func NewMortalEra(currentBlockNumber uint64, period uint64) (ExtrinsicEra, uint64) {
+	period = nextPowerOfTwo(period)
+
+	switch {
+	case period < minMortalEraPeriod:
+		period = minMortalEraPeriod
+	case period > maxMortalEraPeriod:
+		period = maxMortalEraPeriod
+	}
+
+	quantizeFactor := mortalEraQuantizeFactor(period)
+	quantizedPhase := currentBlockNumber % period / quantizeFactor
+
+	// The lower 4 bits hold the period as a power of two minus one, the upper 12 bits the quantized phase.
+	encoded := uint16(bits.TrailingZeros64(period)-1) | uint16(quantizedPhase<<4)
+
+	era := ExtrinsicEra{
+		IsMortalEra: true,
+		AsMortalEra: MortalEra{First: byte(encoded), Second: byte(encoded >> 8)},
+	}
+
+	return era, era.Birth(currentBlockNumber)
+}
+
+// Birth returns the first block at which the extrinsic is valid, if it was created at the given block. It is 0 for
+// immortal eras.
+func (e ExtrinsicEra) Birth(currentBlockNumber uint64) uint64 {
+	if !e.IsMortalEra {
+		return 0
+	}
+
+	period, phase := e.AsMortalEra.Period(), e.AsMortalEra.Phase()
+
+	if currentBlockNumber < phase {
+		currentBlockNumber = phase
+	}
+
+	return (currentBlockNumber-phase)/period*period + phase
+}
+
+// Death returns the first block at which the extrinsic is no longer valid, if it was created at the given block. It
+// is math.MaxUint64 for immortal eras.
+func (e ExtrinsicEra) Death(currentBlockNumber uint64) uint64 {
+	if !e.IsMortalEra {
+		return math.MaxUint64
+	}
+
+	return e.Birth(currentBlockNumber) + e.AsMortalEra.Period()
+}
+
 // MortalEra for an extrinsic, indicating period and phase
 type MortalEra struct {
 	First  byte
 	Second byte
 }
+
+// Period returns the number of blocks the era is valid for
+func (m MortalEra) Period() uint64 {
+	return 2 << (m.encoded() % (1 << 4))
+}
+
+// Phase returns the offset of the birth block within the period
+func (m MortalEra) Phase() uint64 {
+	return (m.encoded() >> 4) * mortalEraQuantizeFactor(m.Period())
+}
+
+func (m MortalEra) encoded() uint64 {
+	return uint64(m.First) | uint64(m.Second)<<8
+}
+
+func mortalEraQuantizeFactor(period uint64) uint64 {
+	if factor := period >> 12; factor > 1 {
+		return factor
+	}
+
+	return 1
+}
+
+func nextPowerOfTwo(n uint64) uint64 {
+	if n <= 1 {
+		return 1
+	}
+
+	if n > 1<<63 {
+		return math.MaxUint64
+	}
+
+	return 1 << bits.Len64(n-1)
+}
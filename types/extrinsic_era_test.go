@@ -17,6 +17,7 @@
 package types_test
 
 import (
+	"math"
 	"testing"
 
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -52,8 +53,8 @@ var (
 
 			e.IsMortalEra = true
 			e.AsMortalEra = MortalEra{
-				First:  1,
-				Second: 2,
+				First:  0xa5,
+				Second: 0x02,
 			}
 		}),
 	}
@@ -67,3 +68,67 @@ func TestExtrinsicEra_EncodeDecode(t *testing.T) {
 
 	AssertRoundTripFuzz[ExtrinsicEra](t, 1000, extrinsicEraFuzzOpts...)
 }
+
+func TestNewMortalEra(t *testing.T) {
+	// The vectors are taken from the tests of sp_runtime's Era
+	for _, test := range []struct {
+		current, period uint64
+		encoded         string
+		expectedPeriod  uint64
+		expectedPhase   uint64
+	}{
+		{current: 42, period: 64, encoded: "0xa502", expectedPeriod: 64, expectedPhase: 42},
+		{current: 20_000, period: 32768, encoded: "0x4e9c", expectedPeriod: 32768, expectedPhase: 20_000},
+		{current: 513, period: 200, encoded: "0x1700", expectedPeriod: 256, expectedPhase: 1},
+		{current: 1, period: 2, encoded: "0x1100", expectedPeriod: 4, expectedPhase: 1},
+		{current: 5, period: 4, encoded: "0x1100", expectedPeriod: 4, expectedPhase: 1},
+		{current: 1_000_000, period: 1_000_000, encoded: "0x4f42", expectedPeriod: 65536, expectedPhase: 16960},
+		{current: 1_000_000, period: math.MaxUint64, encoded: "0x4f42", expectedPeriod: 65536, expectedPhase: 16960},
+		{current: 10, period: 0, encoded: "0x2100", expectedPeriod: 4, expectedPhase: 2},
+	} {
+		era, birth := NewMortalEra(test.current, test.period)
+
+		assert.True(t, era.IsMortalEra)
+		assert.Equal(t, test.expectedPeriod, era.AsMortalEra.Period(), "period of %+v", test)
+		assert.Equal(t, test.expectedPhase, era.AsMortalEra.Phase(), "phase of %+v", test)
+		encoded, err := EncodeToHex(era)
+		assert.NoError(t, err)
+		assert.Equal(t, test.encoded, encoded, "encoding of %+v", test)
+		assert.LessOrEqual(t, birth, test.current)
+		assert.Greater(t, era.Death(test.current), test.current)
+
+		var decoded ExtrinsicEra
+		assert.NoError(t, DecodeFromHex(test.encoded, &decoded))
+		assert.Equal(t, era, decoded)
+	}
+}
+
+func TestNewMortalEra_QuantizedBirth(t *testing.T) {
+	era, birth := NewMortalEra(1_000_007, 65536)
+
+	// The phase is quantized to multiples of 16, so the era starts before the current block.
+	assert.Equal(t, uint64(1_000_000), birth)
+	assert.Equal(t, uint64(1_000_000+65536), era.Death(1_000_007))
+}
+
+func TestExtrinsicEra_BirthDeath(t *testing.T) {
+	era, _ := NewMortalEra(42, 64)
+
+	for current, expectedBirth := range map[uint64]uint64{42: 42, 50: 42, 105: 42, 106: 106, 41: 42} {
+		assert.Equal(t, expectedBirth, era.Birth(current), "birth at %d", current)
+		assert.Equal(t, expectedBirth+64, era.Death(current), "death at %d", current)
+	}
+
+	immortal := ExtrinsicEra{IsImmortalEra: true}
+	assert.Equal(t, uint64(0), immortal.Birth(42))
+	assert.Equal(t, uint64(math.MaxUint64), immortal.Death(42))
+}
+
+func TestExtrinsicEra_DecodeInvalidMortalEra(t *testing.T) {
+	var e ExtrinsicEra
+
+	// A period of 2 is too short.
+	assert.Error(t, DecodeFromHex("0x1000", &e))
+	// The phase of 32 is not within the period of 4.
+	assert.Error(t, DecodeFromHex("0x0102", &e))
+}
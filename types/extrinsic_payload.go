@@ -46,7 +46,7 @@ func (e ExtrinsicPayloadV3) Sign(signer signature.KeyringPair) (Signature, error
 		return Signature{}, err
 	}
 
-	sig, err := signature.Sign(b, signer.URI)
+	sig, err := signer.Sign(b)
 	return NewSignature(sig), err
 }
 
@@ -99,6 +99,11 @@ func (e *ExtrinsicPayloadV3) Decode(decoder scale.Decoder) error {
 type ExtrinsicPayloadV4 struct {
 	ExtrinsicPayloadV3
 	TransactionVersion U32
+	// MetadataHashMode is only encoded if set, extra via CheckMetadataHash
+	MetadataHashMode Option[MetadataHashMode]
+	// MetadataHash is only encoded if the MetadataHashMode is set, as none if it is disabled. Additional via
+	// CheckMetadataHash
+	MetadataHash Hash
 }
 
 // Sign the extrinsic payload with the given derivation path
@@ -108,7 +113,7 @@ func (e ExtrinsicPayloadV4) Sign(signer signature.KeyringPair) (Signature, error
 		return Signature{}, err
 	}
 
-	sig, err := signature.Sign(b, signer.URI)
+	sig, err := signer.Sign(b)
 	return NewSignature(sig), err
 }
 
@@ -133,6 +138,14 @@ func (e ExtrinsicPayloadV4) Encode(encoder scale.Encoder) error {
 		return err
 	}
 
+	hasMode, mode := e.MetadataHashMode.Unwrap()
+	if hasMode {
+		err = encoder.Encode(mode)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = encoder.Encode(e.SpecVersion)
 	if err != nil {
 		return err
@@ -153,6 +166,18 @@ func (e ExtrinsicPayloadV4) Encode(encoder scale.Encoder) error {
 		return err
 	}
 
+	if hasMode {
+		metadataHash := NewEmptyOption[Hash]()
+		if mode == MetadataHashEnabled {
+			metadataHash = NewOption(e.MetadataHash)
+		}
+
+		err = encoder.Encode(metadataHash)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -109,3 +109,43 @@ func TestExtrinsicPayload_Sign(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, ok)
 }
+
+func TestExtrinsicPayloadV4_MetadataHash(t *testing.T) {
+	p := examplaryExtrinsicPayload
+	p.MetadataHashMode = NewOption(MetadataHashDisabled)
+	p.MetadataHash = NewHash(MustHexDecodeString("0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"))
+
+	enc, err := EncodeToHex(p)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "0x"+
+		"0600ffd7568e5f0a7eda67a82691ff379ac4bba4f9c9b859fe779b5d46363b61ad2db9e56c"+ // Method
+		"0703"+ // Era
+		"d148"+ // Nonce
+		"e2590100"+ // Tip
+		"00"+ // Metadata hash mode
+		"7b000000"+ // Spec version
+		"01000000"+ // Tx version
+		"dcd1346701ca8396496e52aa2785b1748deb6db09551b72159dcb3e08991025b"+ // Genesis Hash
+		"de8f69eeb5e065e18c6950ff708d7e551f68dc9bf59a07c52367c0280f805ec7"+ // BlockHash
+		"00", // Metadata hash
+		enc)
+
+	p.MetadataHashMode = NewOption(MetadataHashEnabled)
+
+	enc, err = EncodeToHex(p)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "0x"+
+		"0600ffd7568e5f0a7eda67a82691ff379ac4bba4f9c9b859fe779b5d46363b61ad2db9e56c"+ // Method
+		"0703"+ // Era
+		"d148"+ // Nonce
+		"e2590100"+ // Tip
+		"01"+ // Metadata hash mode
+		"7b000000"+ // Spec version
+		"01000000"+ // Tx version
+		"dcd1346701ca8396496e52aa2785b1748deb6db09551b72159dcb3e08991025b"+ // Genesis Hash
+		"de8f69eeb5e065e18c6950ff708d7e551f68dc9bf59a07c52367c0280f805ec7"+ // BlockHash
+		"010102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", // Metadata hash
+		enc)
+}
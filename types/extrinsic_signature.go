@@ -16,6 +16,12 @@
 
 package types
 
+import (
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
 type ExtrinsicSignatureV3 struct {
 	Signer    Address
 	Signature Signature
@@ -30,6 +36,220 @@ type ExtrinsicSignatureV4 struct {
 	Era       ExtrinsicEra // extra via system::CheckEra
 	Nonce     UCompact     // extra via system::CheckNonce (Compact<Index> where Index is u32))
 	Tip       UCompact     // extra via balances::TakeFees (Compact<Balance> where Balance is u128))
+	// MetadataHashMode is only encoded if set, extra via CheckMetadataHash. Whether the extension is present depends
+	// on the metadata, so it is only decoded by DecodeWithMetadata.
+	MetadataHashMode Option[MetadataHashMode]
+	// Extra, if set, is the encoded extra of all signed extensions in the order declared by the metadata, see
+	// Extrinsic.SignWithMetadata. It is encoded instead of Era, Nonce, Tip and MetadataHashMode. It is only decoded by
+	// DecodeWithMetadata.
+	Extra []byte
+	// Ethereum, if set, encodes the Signer as AccountId20 and the Signature as bare ecdsa signature, as used by
+	// Ethereum-compatible chains like Moonbeam. It is only decoded by DecodeWithMetadata, for runtimes with the
+	// Ethereum signer format, see Metadata.SignerFormat.
+	Ethereum bool
+}
+
+// Decode decodes a signature with a MultiAddress signer and the extra of the CheckMortality, CheckNonce and
+// ChargeTransactionPayment signed extensions only. Use DecodeWithMetadata for signatures of runtimes with other signed
+// extensions or signer formats.
+func (s *ExtrinsicSignatureV4) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&s.Signer); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&s.Signature); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&s.Era); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&s.Nonce); err != nil {
+		return err
+	}
+
+	return decoder.Decode(&s.Tip)
+}
+
+// DecodeWithMetadata decodes a signature as laid out by the runtime of the metadata: the signer and signature in the
+// signer format of the runtime, see Metadata.SignerFormat, followed by the extra of all signed extensions in the order
+// declared by the metadata. Extra holds the encoded extra, so that the signature encodes to the same bytes again, and
+// Era, Nonce, Tip and MetadataHashMode are set if the runtime declares their signed extensions. Metadata before V14
+// does not declare the types of signed extensions, the signature is decoded via Decode instead.
+func (s *ExtrinsicSignatureV4) DecodeWithMetadata(decoder scale.Decoder, meta *Metadata) error {
+	if meta.Version < 14 {
+		return s.Decode(decoder)
+	}
+
+	*s = ExtrinsicSignatureV4{}
+
+	if meta.SignerFormat() == SignerFormatEthereum {
+		if err := s.decodeEthereumSigner(decoder); err != nil {
+			return err
+		}
+	} else {
+		if err := decoder.Decode(&s.Signer); err != nil {
+			return err
+		}
+
+		if err := decoder.Decode(&s.Signature); err != nil {
+			return err
+		}
+	}
+
+	extra, err := decodeSignedExtensionsExtra(decoder, meta)
+	if err != nil {
+		return err
+	}
+
+	s.Era = extra.era
+	s.Nonce = extra.nonce
+	s.Tip = extra.tip
+	s.MetadataHashMode = extra.metadataHashMode
+	s.Extra = extra.raw
+
+	return nil
+}
+
+// decodeEthereumSigner decodes the AccountId20 signer and the bare ecdsa signature, see Ethereum.
+func (s *ExtrinsicSignatureV4) decodeEthereumSigner(decoder scale.Decoder) error {
+	s.Signer = MultiAddress{IsAddress20: true}
+	s.Signature = MultiSignature{IsEcdsa: true}
+	s.Ethereum = true
+
+	if err := decoder.Read(s.Signer.AsAddress20[:]); err != nil {
+		return err
+	}
+
+	return decoder.Read(s.Signature.AsEcdsa[:])
+}
+
+func (s ExtrinsicSignatureV4) Encode(encoder scale.Encoder) error {
+	if err := s.encodeSigner(encoder); err != nil {
+		return err
+	}
+
+	if s.Extra != nil {
+		return encoder.Write(s.Extra)
+	}
+
+	if err := encoder.Encode(s.Era); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(s.Nonce); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(s.Tip); err != nil {
+		return err
+	}
+
+	if ok, mode := s.MetadataHashMode.Unwrap(); ok {
+		return encoder.Encode(mode)
+	}
+
+	return nil
+}
+
+func (s ExtrinsicSignatureV4) encodeSigner(encoder scale.Encoder) error {
+	if s.Ethereum {
+		if !s.Signer.IsAddress20 || !s.Signature.IsEcdsa {
+			return errors.New("ethereum signatures require an AccountId20 signer and an ecdsa signature")
+		}
+
+		if err := encoder.Write(s.Signer.AsAddress20[:]); err != nil {
+			return err
+		}
+
+		return encoder.Write(s.Signature.AsEcdsa[:])
+	}
+
+	if err := encoder.Encode(s.Signer); err != nil {
+		return err
+	}
+
+	return encoder.Encode(s.Signature)
+}
+
+// ExtrinsicExtensionsV5 are the transaction extensions of a general transaction of extrinsic format version 5, see
+// NewGeneralExtrinsic.
+type ExtrinsicExtensionsV5 struct {
+	// ExtensionVersion is the version of the transaction extensions, 0 for the ones declared by the metadata
+	ExtensionVersion U8
+	Era              ExtrinsicEra // extra via system::CheckEra
+	Nonce            UCompact     // extra via system::CheckNonce (Compact<Index> where Index is u32))
+	Tip              UCompact     // extra via balances::TakeFees (Compact<Balance> where Balance is u128))
+	// Extra, if set, is the encoded extra of all transaction extensions in the order declared by the metadata, see
+	// Extrinsic.SetExtensionsWithMetadata. It is encoded instead of Era, Nonce and Tip. It is only decoded by
+	// DecodeWithMetadata.
+	Extra []byte
+}
+
+// Decode decodes the extensions version and the extra of the CheckMortality, CheckNonce and ChargeTransactionPayment
+// transaction extensions only. Use DecodeWithMetadata for runtimes with other transaction extensions.
+func (x *ExtrinsicExtensionsV5) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&x.ExtensionVersion); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&x.Era); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&x.Nonce); err != nil {
+		return err
+	}
+
+	return decoder.Decode(&x.Tip)
+}
+
+// DecodeWithMetadata decodes the extensions version and the extra of all transaction extensions in the order declared
+// by the metadata, like ExtrinsicSignatureV4.DecodeWithMetadata. Metadata before V14 does not declare the types of
+// transaction extensions, the extensions are decoded via Decode instead.
+func (x *ExtrinsicExtensionsV5) DecodeWithMetadata(decoder scale.Decoder, meta *Metadata) error {
+	if meta.Version < 14 {
+		return x.Decode(decoder)
+	}
+
+	*x = ExtrinsicExtensionsV5{}
+
+	if err := decoder.Decode(&x.ExtensionVersion); err != nil {
+		return err
+	}
+
+	extra, err := decodeSignedExtensionsExtra(decoder, meta)
+	if err != nil {
+		return err
+	}
+
+	x.Era = extra.era
+	x.Nonce = extra.nonce
+	x.Tip = extra.tip
+	x.Extra = extra.raw
+
+	return nil
+}
+
+func (x ExtrinsicExtensionsV5) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(x.ExtensionVersion); err != nil {
+		return err
+	}
+
+	if x.Extra != nil {
+		return encoder.Write(x.Extra)
+	}
+
+	if err := encoder.Encode(x.Era); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(x.Nonce); err != nil {
+		return err
+	}
+
+	return encoder.Encode(x.Tip)
 }
 
 type SignatureOptions struct {
@@ -40,4 +260,10 @@ type SignatureOptions struct {
 	GenesisHash        Hash         // additional via system::CheckGenesis
 	BlockHash          Hash         // additional via system::CheckEra
 	TransactionVersion U32          // additional via system::CheckTxVersion
+	// MetadataHashMode must be set for runtimes with the CheckMetadataHash signed extension, extra via
+	// CheckMetadataHash
+	MetadataHashMode Option[MetadataHashMode]
+	// MetadataHash is the RFC-0078 metadata hash, it is only signed if the MetadataHashMode is enabled. Additional via
+	// CheckMetadataHash
+	MetadataHash Hash
 }
@@ -24,6 +24,7 @@ import (
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	fuzz "github.com/google/gofuzz"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -56,6 +57,16 @@ var (
 		multiAddressFuzzOpts,
 		multiSignatureFuzzOpts,
 		extrinsicEraFuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(s *ExtrinsicSignatureV4, c fuzz.Continue) {
+				c.Fuzz(&s.Signer)
+				c.Fuzz(&s.Signature)
+				c.Fuzz(&s.Era)
+				c.Fuzz(&s.Nonce)
+				c.Fuzz(&s.Tip)
+				// Extra is never set when decoding.
+			}),
+		},
 	)
 )
 
@@ -99,3 +110,40 @@ func TestExtrinsicSignatureV4_EncodeDecodeWithOpts(t *testing.T) {
 
 	assert.Equal(t, sig, sigDec)
 }
+
+func TestExtrinsicSignatureV4_MetadataHashMode(t *testing.T) {
+	sig := ExtrinsicSignatureV4{
+		Signer:    MultiAddress{IsID: true, AsID: AccountID{1}},
+		Signature: MultiSignature{IsSr25519: true, AsSr25519: Signature{2}},
+		Era:       ExtrinsicEra{IsImmortalEra: true},
+		Nonce:     NewUCompactFromUInt(1),
+		Tip:       NewUCompactFromUInt(2),
+	}
+
+	enc, err := Encode(sig)
+	assert.NoError(t, err)
+
+	sig.MetadataHashMode = NewOption(MetadataHashEnabled)
+
+	encWithMode, err := Encode(sig)
+	assert.NoError(t, err)
+	assert.Equal(t, append(enc, 1), encWithMode)
+
+	// Whether the extension is present depends on the metadata, Decode does not decode the mode, see
+	// Extrinsic.DecodeWithMetadata.
+	var dec ExtrinsicSignatureV4
+	assert.NoError(t, Decode(encWithMode, &dec))
+	assert.True(t, dec.MetadataHashMode.IsNone())
+	assert.Equal(t, sig.Tip, dec.Tip)
+}
+
+func TestExtrinsicSignatureV4_Ethereum(t *testing.T) {
+	sig := ExtrinsicSignatureV4{
+		Signer:    MultiAddress{IsID: true, AsID: AccountID{1}},
+		Signature: MultiSignature{IsEcdsa: true, AsEcdsa: EcdsaSignature{2}},
+		Ethereum:  true,
+	}
+
+	_, err := Encode(sig)
+	assert.Error(t, err)
+}
@@ -18,7 +18,9 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
@@ -42,6 +44,72 @@ type ExtrinsicStatus struct {
 	AsUsurped         Hash
 	IsDropped         bool // 8:: Dropped
 	IsInvalid         bool // 9:: Invalid
+	IsUnknown         bool // Any variant that is not known yet
+	AsUnknown         UnknownExtrinsicStatus
+}
+
+// UnknownExtrinsicStatus is an extrinsic status that is not known to this library, e.g. because it was added in a
+// newer node version. Statuses are decoded into it instead of failing, so that watching an extrinsic does not end
+// when the node is upgraded.
+type UnknownExtrinsicStatus struct {
+	// Index is the variant index of a SCALE encoded status. JSON encoded statuses are identified by their name, the
+	// Index is 0 for them.
+	Index uint8
+	// Raw is the rest of the input after the variant index of a SCALE encoded status, or the JSON of a JSON encoded
+	// status.
+	Raw []byte
+}
+
+// Broadcast returns the peers the extrinsic was broadcast to, if the status is Broadcast.
+func (e ExtrinsicStatus) Broadcast() ([]Text, bool) {
+	return e.AsBroadcast, e.IsBroadcast
+}
+
+// InBlock returns the hash of the block the extrinsic was included in, if the status is InBlock.
+func (e ExtrinsicStatus) InBlock() (Hash, bool) {
+	return e.AsInBlock, e.IsInBlock
+}
+
+// Retracted returns the hash of the block the extrinsic was included in before it was retracted, if the status is
+// Retracted. The extrinsic is usually included in another block afterwards.
+func (e ExtrinsicStatus) Retracted() (Hash, bool) {
+	return e.AsRetracted, e.IsRetracted
+}
+
+// FinalityTimeout returns the hash of the block the extrinsic was included in, if the status is FinalityTimeout.
+func (e ExtrinsicStatus) FinalityTimeout() (Hash, bool) {
+	return e.AsFinalityTimeout, e.IsFinalityTimeout
+}
+
+// Finalized returns the hash of the finalized block the extrinsic was included in, if the status is Finalized.
+func (e ExtrinsicStatus) Finalized() (Hash, bool) {
+	return e.AsFinalized, e.IsFinalized
+}
+
+// Usurped returns the hash of the extrinsic that replaced the extrinsic in the pool, if the status is Usurped.
+func (e ExtrinsicStatus) Usurped() (Hash, bool) {
+	return e.AsUsurped, e.IsUsurped
+}
+
+// Unknown returns the unknown status, if the status is not known to this library.
+func (e ExtrinsicStatus) Unknown() (UnknownExtrinsicStatus, bool) {
+	return e.AsUnknown, e.IsUnknown
+}
+
+// BlockHash returns the hash of the block of InBlock, Retracted, FinalityTimeout and Finalized statuses.
+func (e ExtrinsicStatus) BlockHash() (Hash, bool) {
+	switch {
+	case e.IsInBlock:
+		return e.AsInBlock, true
+	case e.IsRetracted:
+		return e.AsRetracted, true
+	case e.IsFinalityTimeout:
+		return e.AsFinalityTimeout, true
+	case e.IsFinalized:
+		return e.AsFinalized, true
+	}
+
+	return Hash{}, false
 }
 
 func (e *ExtrinsicStatus) Decode(decoder scale.Decoder) error {
@@ -78,6 +146,11 @@ func (e *ExtrinsicStatus) Decode(decoder scale.Decoder) error {
 		e.IsDropped = true
 	case 9:
 		e.IsInvalid = true
+	default:
+		// The payload of unknown variants can't be told apart from the following data, the rest of the input is kept.
+		e.IsUnknown = true
+		e.AsUnknown.Index = b
+		e.AsUnknown.Raw, err = readRemaining(decoder)
 	}
 
 	if err != nil {
@@ -87,6 +160,27 @@ func (e *ExtrinsicStatus) Decode(decoder scale.Decoder) error {
 	return nil
 }
 
+// readRemaining reads the decoder until the end of its input.
+func readRemaining(decoder scale.Decoder) ([]byte, error) {
+	var remaining []byte
+
+	for {
+		b, err := decoder.ReadOneByte()
+		if errors.Is(err, io.EOF) {
+			return remaining, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := decoder.CheckBytesLength(uint64(len(remaining) + 1)); err != nil {
+			return nil, err
+		}
+
+		remaining = append(remaining, b)
+	}
+}
+
 func (e ExtrinsicStatus) Encode(encoder scale.Encoder) error {
 	var err1, err2 error
 	switch {
@@ -116,6 +210,9 @@ func (e ExtrinsicStatus) Encode(encoder scale.Encoder) error {
 		err1 = encoder.PushByte(8)
 	case e.IsInvalid:
 		err1 = encoder.PushByte(9)
+	case e.IsUnknown:
+		err1 = encoder.PushByte(e.AsUnknown.Index)
+		err2 = encoder.Write(e.AsUnknown.Raw)
 	}
 
 	if err1 != nil {
@@ -130,7 +227,8 @@ func (e ExtrinsicStatus) Encode(encoder scale.Encoder) error {
 
 func (e *ExtrinsicStatus) UnmarshalJSON(b []byte) error { //nolint:funlen
 	input := strings.TrimSpace(string(b))
-	if len(input) >= 2 && input[0] == '"' && input[len(input)-1] == '"' {
+	quoted := len(input) >= 2 && input[0] == '"' && input[len(input)-1] == '"'
+	if quoted {
 		input = input[1 : len(input)-1]
 	}
 
@@ -147,6 +245,9 @@ func (e *ExtrinsicStatus) UnmarshalJSON(b []byte) error { //nolint:funlen
 	case input == "invalid":
 		e.IsInvalid = true
 		return nil
+	case quoted:
+		e.setUnknownJSON(b)
+		return nil
 	}
 
 	// no simple case, decode into helper
@@ -187,11 +288,19 @@ func (e *ExtrinsicStatus) UnmarshalJSON(b []byte) error { //nolint:funlen
 		e.IsUsurped = true
 		e.AsUsurped = tmp.AsUsurped
 		return nil
+	case strings.HasPrefix(input, "{"):
+		e.setUnknownJSON(b)
+		return nil
 	}
 
 	return fmt.Errorf("unexpected JSON for ExtrinsicStatus, got %v", string(b))
 }
 
+func (e *ExtrinsicStatus) setUnknownJSON(b []byte) {
+	e.IsUnknown = true
+	e.AsUnknown = UnknownExtrinsicStatus{Raw: append([]byte{}, b...)}
+}
+
 func (e ExtrinsicStatus) MarshalJSON() ([]byte, error) {
 	switch {
 	case e.IsFuture:
@@ -238,6 +347,8 @@ func (e ExtrinsicStatus) MarshalJSON() ([]byte, error) {
 		}
 		tmp.AsUsurped = e.AsUsurped
 		return json.Marshal(tmp)
+	case e.IsUnknown && json.Valid(e.AsUnknown.Raw):
+		return e.AsUnknown.Raw, nil
 	}
 	return nil, fmt.Errorf("cannot marshal ExtrinsicStatus, got %#v", e)
 }
@@ -37,11 +37,15 @@ var testExtrinsicStatus6 = ExtrinsicStatus{IsFinalized: true, AsFinalized: NewHa
 var testExtrinsicStatus7 = ExtrinsicStatus{IsUsurped: true, AsUsurped: NewHash([]byte{0xee})}
 var testExtrinsicStatus8 = ExtrinsicStatus{IsDropped: true}
 var testExtrinsicStatus9 = ExtrinsicStatus{IsInvalid: true}
+var testExtrinsicStatus10 = ExtrinsicStatus{
+	IsUnknown: true,
+	AsUnknown: UnknownExtrinsicStatus{Index: 10, Raw: []byte{1, 2}},
+}
 
 var (
 	extrinsicStatusFuzzOpts = []FuzzOpt{
 		WithFuzzFuncs(func(e *ExtrinsicStatus, c fuzz.Continue) {
-			switch c.Intn(10) {
+			switch c.Intn(11) {
 			case 0:
 				e.IsFuture = true
 			case 1:
@@ -68,6 +72,10 @@ var (
 				e.IsDropped = true
 			case 9:
 				e.IsInvalid = true
+			case 10:
+				e.IsUnknown = true
+				e.AsUnknown.Index = uint8(10 + c.Intn(246))
+				e.AsUnknown.Raw = []byte{byte(c.Intn(256))}
 			}
 		}),
 	}
@@ -84,6 +92,7 @@ func TestExtrinsicStatus_EncodeDecode(t *testing.T) {
 	AssertRoundtrip(t, testExtrinsicStatus7)
 	AssertRoundtrip(t, testExtrinsicStatus8)
 	AssertRoundtrip(t, testExtrinsicStatus9)
+	AssertRoundtrip(t, testExtrinsicStatus10)
 	AssertRoundTripFuzz[ExtrinsicStatus](t, 1000, extrinsicStatusFuzzOpts...)
 	AssertDecodeNilData[ExtrinsicStatus](t)
 	AssertEncodeEmptyObj[ExtrinsicStatus](t, 0)
@@ -101,6 +110,7 @@ func TestExtrinsicStatus_Encode(t *testing.T) {
 		{testExtrinsicStatus7, MustHexDecodeString("0x07ee00000000000000000000000000000000000000000000000000000000000000")}, //nolint:lll
 		{testExtrinsicStatus8, []byte{0x08}},
 		{testExtrinsicStatus9, []byte{0x09}},
+		{testExtrinsicStatus10, []byte{0x0a, 0x01, 0x02}},
 	})
 }
 
@@ -116,6 +126,8 @@ func TestExtrinsicStatus_Decode(t *testing.T) {
 		{MustHexDecodeString("0x07ee00000000000000000000000000000000000000000000000000000000000000"), testExtrinsicStatus7}, //nolint:lll
 		{[]byte{0x08}, testExtrinsicStatus8},
 		{[]byte{0x09}, testExtrinsicStatus9},
+		{[]byte{0x0a, 0x01, 0x02}, testExtrinsicStatus10},
+		{[]byte{0xff}, ExtrinsicStatus{IsUnknown: true, AsUnknown: UnknownExtrinsicStatus{Index: 0xff}}},
 	})
 }
 
@@ -158,6 +170,12 @@ var testExtrinsicStatusTestCases = []struct {
 	}, {
 		[]byte("\"invalid\""),
 		ExtrinsicStatus{IsInvalid: true},
+	}, {
+		[]byte("\"unknown\""),
+		ExtrinsicStatus{IsUnknown: true, AsUnknown: UnknownExtrinsicStatus{Raw: []byte("\"unknown\"")}},
+	}, {
+		[]byte("{\"unknown\":[1,2]}"),
+		ExtrinsicStatus{IsUnknown: true, AsUnknown: UnknownExtrinsicStatus{Raw: []byte("{\"unknown\":[1,2]}")}},
 	},
 }
 
@@ -177,3 +195,53 @@ func TestExtrinsicStatus_MarshalJSON(t *testing.T) {
 		assert.Equal(t, test.encoded, actual)
 	}
 }
+
+func TestExtrinsicStatus_UnmarshalJSON_Error(t *testing.T) {
+	for _, input := range []string{"1", "[]", "{\"inBlock\":1}"} {
+		var actual ExtrinsicStatus
+		assert.Error(t, json.Unmarshal([]byte(input), &actual), input)
+	}
+}
+
+func TestExtrinsicStatus_Getters(t *testing.T) {
+	peers, ok := testExtrinsicStatus2.Broadcast()
+	assert.True(t, ok)
+	assert.Equal(t, []Text{"This", "is", "broadcast"}, peers)
+
+	for _, test := range []struct {
+		status ExtrinsicStatus
+		getter func(ExtrinsicStatus) (Hash, bool)
+	}{
+		{testExtrinsicStatus3, ExtrinsicStatus.InBlock},
+		{testExtrinsicStatus4, ExtrinsicStatus.Retracted},
+		{testExtrinsicStatus5, ExtrinsicStatus.FinalityTimeout},
+		{testExtrinsicStatus6, ExtrinsicStatus.Finalized},
+	} {
+		hash, ok := test.getter(test.status)
+		assert.True(t, ok)
+
+		blockHash, ok := test.status.BlockHash()
+		assert.True(t, ok)
+		assert.Equal(t, hash, blockHash)
+
+		_, ok = test.getter(testExtrinsicStatus1)
+		assert.False(t, ok)
+	}
+
+	hash, ok := testExtrinsicStatus7.Usurped()
+	assert.True(t, ok)
+	assert.Equal(t, NewHash([]byte{0xee}), hash)
+
+	_, ok = testExtrinsicStatus7.BlockHash()
+	assert.False(t, ok)
+
+	unknown, ok := testExtrinsicStatus10.Unknown()
+	assert.True(t, ok)
+	assert.Equal(t, uint8(10), unknown.Index)
+}
+
+func TestExtrinsicStatus_MarshalJSON_Unknown(t *testing.T) {
+	// The raw data of SCALE encoded statuses is not JSON.
+	_, err := json.Marshal(ExtrinsicStatus{IsUnknown: true, AsUnknown: UnknownExtrinsicStatus{Index: 10, Raw: []byte{1}}})
+	assert.Error(t, err)
+}
@@ -55,6 +55,115 @@ func TestExtrinsic_Unsigned_EncodeDecode(t *testing.T) {
 	assert.Equal(t, ext, extDec)
 }
 
+func TestExtrinsic_V5_EncodeDecode(t *testing.T) {
+	// Timestamp.set(1_700_000_000_000) as inherent, encoded in format version 5.
+	bare := NewExtrinsicV5(Call{
+		CallIndex: CallIndex{SectionIndex: 2, MethodIndex: 0},
+		Args:      MustHexDecodeString("0x0b0068e5cf8b01"),
+	})
+
+	// System.remark("hi") as general transaction with the default transaction extensions.
+	general := NewGeneralExtrinsic(
+		Call{CallIndex: CallIndex{SectionIndex: 0, MethodIndex: 0}, Args: MustHexDecodeString("0x086869")},
+		ExtrinsicExtensionsV5{Era: ExtrinsicEra{IsImmortalEra: true}, Nonce: NewUCompactFromUInt(1)},
+	)
+
+	assert.False(t, bare.IsSigned())
+	assert.False(t, bare.IsGeneral())
+	assert.Equal(t, uint8(ExtrinsicVersion5), bare.Type())
+	assert.False(t, general.IsSigned())
+	assert.True(t, general.IsGeneral())
+	assert.Equal(t, uint8(ExtrinsicVersion5), general.Type())
+
+	tests := []struct {
+		extrinsic Extrinsic
+		expected  string
+	}{
+		{
+			bare,
+			"0x" +
+				"28" + // length prefix, compact
+				"05" + // version 5, bare
+				"0200" + // call index
+				"0b0068e5cf8b01", // moment, compact
+		},
+		{
+			general,
+			"0x" +
+				"28" + // length prefix, compact
+				"45" + // version 5, general
+				"00" + // extension version
+				"00" + // era, immortal
+				"04" + // nonce, compact
+				"00" + // tip, compact
+				"0000" + // call index
+				"086869", // remark
+		},
+	}
+
+	for _, test := range tests {
+		enc, err := EncodeToHex(test.extrinsic)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, enc)
+
+		var dec Extrinsic
+		err = DecodeFromHex(enc, &dec)
+		assert.NoError(t, err)
+		assert.Equal(t, test.extrinsic, dec)
+	}
+}
+
+func TestExtrinsic_V5_UnsupportedVersions(t *testing.T) {
+	for _, version := range []byte{
+		ExtrinsicVersion5 | ExtrinsicBitSigned,
+		ExtrinsicVersion4 | ExtrinsicBitGeneral,
+		ExtrinsicVersion5 | ExtrinsicTypeMask,
+	} {
+		_, err := Encode(Extrinsic{Version: version})
+		assert.Error(t, err, "version %#x", version)
+
+		var dec Extrinsic
+		err = Decode([]byte{0x04, version, 0x00, 0x00}, &dec)
+		assert.Error(t, err, "version %#x", version)
+	}
+
+	// General transactions cannot be signed.
+	xt := NewGeneralExtrinsic(Call{}, ExtrinsicExtensionsV5{})
+	err := xt.Sign(signature.TestKeyringPairAlice, SignatureOptions{})
+	assert.Error(t, err)
+}
+
+func TestExtrinsic_SetExtensionsWithMetadata(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+	c := Call{CallIndex: CallIndex{SectionIndex: 0, MethodIndex: 0}, Args: MustHexDecodeString("0x086869")}
+
+	payload, err := NewSignedExtensionPayload(meta, testSignedExtensionOptions, nil)
+	assert.NoError(t, err)
+
+	xt := NewGeneralExtrinsic(c, ExtrinsicExtensionsV5{})
+
+	err = xt.SetExtensionsWithMetadata(meta, testSignedExtensionOptions, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, payload.Extra, xt.Extensions.Extra)
+
+	enc, err := Encode(xt)
+	assert.NoError(t, err)
+
+	body := append(append([]byte{ExtrinsicVersion5 | ExtrinsicBitGeneral, 0}, payload.Extra...), 0, 0, 8, 'h', 'i')
+	assert.Equal(t, append(MustHexDecodeString(mustEncodeCompactLength(t, len(body))), body...), enc)
+
+	v4 := NewExtrinsic(c)
+	err = v4.SetExtensionsWithMetadata(meta, testSignedExtensionOptions, nil)
+	assert.Error(t, err)
+}
+
+func mustEncodeCompactLength(t *testing.T, l int) string {
+	enc, err := EncodeToHex(NewUCompactFromUInt(uint64(l)))
+	assert.NoError(t, err)
+
+	return enc
+}
+
 func TestExtrinsic_Signed_EncodeDecode(t *testing.T) {
 	extEnc, err := EncodeToHex(ExamplaryExtrinsic)
 	assert.NoError(t, err)
@@ -145,6 +254,51 @@ func TestExtrinsic_Sign(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestExtrinsic_Sign_Ed25519(t *testing.T) {
+	ext := NewExtrinsic(Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}})
+
+	o := SignatureOptions{
+		BlockHash:          Hash{1},
+		GenesisHash:        Hash{1},
+		Nonce:              NewUCompactFromUInt(1),
+		SpecVersion:        123,
+		Tip:                NewUCompactFromUInt(2),
+		TransactionVersion: 1,
+	}
+
+	err := ext.Sign(signature.TestKeyringPairAliceEd25519, o)
+	assert.NoError(t, err)
+	assert.True(t, ext.Signature.Signature.IsEd25519)
+	assert.Equal(t, signature.TestKeyringPairAliceEd25519.PublicKey, ext.Signature.Signer.AsID[:])
+
+	mb, err := Encode(ext.Method)
+	assert.NoError(t, err)
+
+	b, err := Encode(ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: ExtrinsicPayloadV3{
+			Method:      mb,
+			Era:         ext.Signature.Era,
+			Nonce:       o.Nonce,
+			Tip:         o.Tip,
+			SpecVersion: o.SpecVersion,
+			GenesisHash: o.GenesisHash,
+			BlockHash:   o.BlockHash,
+		},
+		TransactionVersion: o.TransactionVersion,
+	})
+	assert.NoError(t, err)
+
+	ok, err := signature.TestKeyringPairAliceEd25519.Verify(b, ext.Signature.Signature.AsEd25519[:])
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	unknownScheme := signature.TestKeyringPairAliceEd25519
+	unknownScheme.Scheme = signature.Scheme(9)
+
+	err = ext.Sign(unknownScheme, o)
+	assert.ErrorIs(t, err, signature.ErrUnknownScheme)
+}
+
 func ExampleExtrinsic() {
 	bob, err := NewAddressFromHexAccountID("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
 	if err != nil {
@@ -0,0 +1,202 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+const (
+	fixedU128Decimals = 18
+	fixedI64Decimals  = 9
+)
+
+var (
+	ErrFixedPointDivisionByZero = errors.New("fixed point division by zero")
+	ErrFixedPointOverflow       = errors.New("fixed point overflow")
+
+	fixedU128Div = new(big.Int).Exp(big.NewInt(10), big.NewInt(fixedU128Decimals), nil)
+	fixedI64Div  = new(big.Int).Exp(big.NewInt(10), big.NewInt(fixedI64Decimals), nil)
+)
+
+// FixedU128 is an unsigned fixed point number with 18 decimals, like sp_arithmetic::FixedU128. It is encoded as the
+// u128 of its inner value, which is the number multiplied by 10^18.
+type FixedU128 struct {
+	Inner U128
+}
+
+// NewFixedU128FromInner creates a FixedU128 from its inner value, which is the number multiplied by 10^18.
+func NewFixedU128FromInner(inner big.Int) FixedU128 {
+	return FixedU128{Inner: NewU128(inner)}
+}
+
+// NewFixedU128FromFloat creates a FixedU128 from a float. Like FixedU128::from_float, the result is truncated and
+// saturates at zero and the max value.
+func NewFixedU128FromFloat(f float64) FixedU128 {
+	// Negative values and NaN result in zero.
+	if !(f > 0) {
+		return NewFixedU128FromInner(*new(big.Int))
+	}
+
+	if math.IsInf(f, 1) {
+		return NewFixedU128FromInner(*new(big.Int).Set(maxU128))
+	}
+
+	inner, _ := new(big.Float).Mul(big.NewFloat(f), new(big.Float).SetInt(fixedU128Div)).Int(nil)
+
+	return FixedU128{Inner: saturatingU128(inner)}
+}
+
+// NewFixedU128FromRational creates the FixedU128 of n / d, which is rounded down like FixedU128::checked_from_rational.
+func NewFixedU128FromRational(n, d *big.Int) (FixedU128, error) {
+	if d.Sign() == 0 {
+		return FixedU128{}, ErrFixedPointDivisionByZero
+	}
+
+	if n.Sign() < 0 || d.Sign() < 0 {
+		return FixedU128{}, ErrFixedPointOverflow
+	}
+
+	inner := mulDiv(n, fixedU128Div, d, roundingDown)
+
+	if inner.Cmp(maxU128) > 0 {
+		return FixedU128{}, ErrFixedPointOverflow
+	}
+
+	return NewFixedU128FromInner(*inner), nil
+}
+
+func (f *FixedU128) Decode(decoder scale.Decoder) error {
+	return decoder.Decode(&f.Inner)
+}
+
+func (f FixedU128) Encode(encoder scale.Encoder) error {
+	return encoder.Encode(f.Inner)
+}
+
+// Float64 returns the nearest float of the number.
+func (f FixedU128) Float64() float64 {
+	res, _ := new(big.Rat).SetFrac(u128Int(f.Inner), fixedU128Div).Float64()
+
+	return res
+}
+
+// Mul returns the product of the number and the balance, which is rounded down and saturates at the max U128 like
+// FixedU128::saturating_mul_int.
+func (f FixedU128) Mul(b U128) U128 {
+	return saturatingU128(mulDiv(u128Int(b), u128Int(f.Inner), fixedU128Div, roundingDown))
+}
+
+// DivInto returns the balance divided by the number, i.e. b / f, which is rounded down and saturates at the max U128.
+// ErrFixedPointDivisionByZero is returned if the number is zero.
+func (f FixedU128) DivInto(b U128) (U128, error) {
+	if u128Int(f.Inner).Sign() == 0 {
+		return U128{}, ErrFixedPointDivisionByZero
+	}
+
+	return saturatingU128(mulDiv(u128Int(b), fixedU128Div, u128Int(f.Inner), roundingDown)), nil
+}
+
+// String returns the number as a decimal, e.g. 1.5.
+func (f FixedU128) String() string {
+	return formatDecimal(u128Int(f.Inner), fixedU128Decimals)
+}
+
+// MarshalJSON returns the number as a decimal string, see String.
+func (f FixedU128) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// FixedI64 is a signed fixed point number with 9 decimals, like sp_arithmetic::FixedI64. It is encoded as the i64 of
+// its inner value, which is the number multiplied by 10^9.
+type FixedI64 int64
+
+// NewFixedI64FromFloat creates a FixedI64 from a float. Like FixedI64::from_float, the result is truncated and
+// saturates at the min and max value.
+func NewFixedI64FromFloat(f float64) FixedI64 {
+	inner := f * float64(fixedI64Div.Int64())
+
+	switch {
+	case math.IsNaN(inner):
+		return 0
+	case inner >= math.MaxInt64:
+		return math.MaxInt64
+	case inner <= math.MinInt64:
+		return math.MinInt64
+	default:
+		return FixedI64(inner)
+	}
+}
+
+// NewFixedI64FromRational creates the FixedI64 of n / d, which is rounded towards zero like
+// FixedI64::checked_from_rational.
+func NewFixedI64FromRational(n, d int64) (FixedI64, error) {
+	if d == 0 {
+		return 0, ErrFixedPointDivisionByZero
+	}
+
+	inner := new(big.Int).Quo(new(big.Int).Mul(big.NewInt(n), fixedI64Div), big.NewInt(d))
+
+	if !inner.IsInt64() {
+		return 0, ErrFixedPointOverflow
+	}
+
+	return FixedI64(inner.Int64()), nil
+}
+
+// Float64 returns the nearest float of the number.
+func (f FixedI64) Float64() float64 {
+	return float64(f) / float64(fixedI64Div.Int64())
+}
+
+// Mul returns the product of the number and the balance, which is rounded down and saturates at zero and the max
+// U128.
+func (f FixedI64) Mul(b U128) U128 {
+	if f <= 0 {
+		return NewU128(*new(big.Int))
+	}
+
+	return saturatingU128(mulDiv(u128Int(b), big.NewInt(int64(f)), fixedI64Div, roundingDown))
+}
+
+// DivInto returns the balance divided by the number, i.e. b / f, which is rounded down and saturates at zero and the
+// max U128. ErrFixedPointDivisionByZero is returned if the number is zero.
+func (f FixedI64) DivInto(b U128) (U128, error) {
+	switch {
+	case f == 0:
+		return U128{}, ErrFixedPointDivisionByZero
+	case f < 0:
+		return NewU128(*new(big.Int)), nil
+	default:
+		return saturatingU128(mulDiv(u128Int(b), fixedI64Div, big.NewInt(int64(f)), roundingDown)), nil
+	}
+}
+
+// String returns the number as a decimal, e.g. -0.25.
+func (f FixedI64) String() string {
+	return formatDecimal(big.NewInt(int64(f)), fixedI64Decimals)
+}
+
+// MarshalJSON returns the number as a decimal string, see String.
+func (f FixedI64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
@@ -0,0 +1,142 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedPoint_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[FixedU128](t, 100)
+	AssertRoundTripFuzz[FixedI64](t, 100)
+	AssertDecodeNilData[FixedU128](t)
+	AssertEncodeEmptyObj[FixedU128](t, 16)
+	AssertEncodeEmptyObj[FixedI64](t, 8)
+}
+
+func TestFixedPoint_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{
+			NewFixedU128FromInner(*big.NewInt(1_500_000_000_000_000_000)),
+			MustHexDecodeString("0x0000167b0d12d1140000000000000000"),
+		},
+		{NewFixedI64FromFloat(-0.25), MustHexDecodeString("0x804d19f1ffffffff")},
+	})
+}
+
+func TestFixedU128_New(t *testing.T) {
+	assert.Equal(t, "1.5", NewFixedU128FromFloat(1.5).String())
+	assert.Equal(t, "0", NewFixedU128FromFloat(-1).String())
+	assert.Equal(t, "0", NewFixedU128FromFloat(math.NaN()).String())
+	assert.Equal(t, "340282366920938463463.374607431768211455", NewFixedU128FromFloat(math.Inf(1)).String())
+
+	// Rationals are rounded down.
+	f, err := NewFixedU128FromRational(big.NewInt(2), big.NewInt(3))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.666666666666666666", f.String())
+
+	_, err = NewFixedU128FromRational(big.NewInt(1), big.NewInt(0))
+	assert.ErrorIs(t, err, ErrFixedPointDivisionByZero)
+
+	tooLarge, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	_, err = NewFixedU128FromRational(tooLarge, big.NewInt(1))
+	assert.ErrorIs(t, err, ErrFixedPointOverflow)
+}
+
+func TestFixedU128_MulDiv(t *testing.T) {
+	// The annual inflation applied to the total issuance.
+	inflation, err := NewFixedU128FromRational(big.NewInt(1075), big.NewInt(1000))
+	assert.NoError(t, err)
+
+	issuance, _ := new(big.Int).SetString("10000000000000000000", 10)
+
+	assert.Equal(t, "10750000000000000000", inflation.Mul(NewU128(*issuance)).String())
+
+	deflated, err := inflation.DivInto(NewU128(*issuance))
+	assert.NoError(t, err)
+	assert.Equal(t, "9302325581395348837", deflated.String())
+
+	// Results are rounded down.
+	third, err := NewFixedU128FromRational(big.NewInt(1), big.NewInt(3))
+	assert.NoError(t, err)
+	assert.Equal(t, "3", third.Mul(NewU128(*big.NewInt(10))).String())
+
+	maxU128, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	assert.Equal(t, maxU128.String(), NewFixedU128FromFloat(2).Mul(NewU128(*maxU128)).String())
+
+	_, err = NewFixedU128FromFloat(0).DivInto(NewU128(*big.NewInt(1)))
+	assert.ErrorIs(t, err, ErrFixedPointDivisionByZero)
+}
+
+func TestFixedI64_New(t *testing.T) {
+	assert.Equal(t, FixedI64(-250_000_000), NewFixedI64FromFloat(-0.25))
+	assert.Equal(t, FixedI64(math.MaxInt64), NewFixedI64FromFloat(1e20))
+	assert.Equal(t, FixedI64(math.MinInt64), NewFixedI64FromFloat(-1e20))
+
+	// Rationals are rounded towards zero.
+	f, err := NewFixedI64FromRational(-2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, FixedI64(-666_666_666), f)
+
+	_, err = NewFixedI64FromRational(1, 0)
+	assert.ErrorIs(t, err, ErrFixedPointDivisionByZero)
+
+	_, err = NewFixedI64FromRational(math.MaxInt64, 1)
+	assert.ErrorIs(t, err, ErrFixedPointOverflow)
+}
+
+func TestFixedI64_MulDiv(t *testing.T) {
+	balance := NewU128(*big.NewInt(1_000))
+
+	assert.Equal(t, "1500", NewFixedI64FromFloat(1.5).Mul(balance).String())
+	assert.Equal(t, "0", NewFixedI64FromFloat(-1.5).Mul(balance).String())
+
+	res, err := NewFixedI64FromFloat(0.25).DivInto(balance)
+	assert.NoError(t, err)
+	assert.Equal(t, "4000", res.String())
+
+	res, err = NewFixedI64FromFloat(-0.25).DivInto(balance)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", res.String())
+
+	_, err = FixedI64(0).DivInto(balance)
+	assert.ErrorIs(t, err, ErrFixedPointDivisionByZero)
+}
+
+func TestFixedPoint_String(t *testing.T) {
+	AssertString(t, []StringAssert{
+		{NewFixedU128FromInner(*big.NewInt(1)), "0.000000000000000001"},
+		{NewFixedU128FromFloat(2), "2"},
+		{NewFixedI64FromFloat(-0.25), "-0.25"},
+		{NewFixedI64FromFloat(0), "0"},
+	})
+
+	b, err := json.Marshal(NewFixedU128FromFloat(1.5))
+	assert.NoError(t, err)
+	assert.Equal(t, `"1.5"`, string(b))
+
+	assert.Equal(t, 1.5, NewFixedU128FromFloat(1.5).Float64())
+	assert.Equal(t, -0.25, NewFixedI64FromFloat(-0.25).Float64())
+}
@@ -1,28 +1,98 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package types
 
-import "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
 
+// Option is a structure that can store a value of type T or a missing value. It is encoded like Option<T> in Rust,
+// i.e. a 0x00 byte if the value is missing or a 0x01 byte followed by the value, except for Option[bool] and
+// Option[Bool], which are encoded in a single byte like OptionBool. Its JSON representation is null or the value.
+//
+// Option should be preferred over the type specific option types, e.g. OptionU32, for new types.
 type Option[T any] struct {
 	hasValue bool
 	value    T
 }
 
+// NewOption creates an Option with a value
 func NewOption[T any](t T) Option[T] {
 	return Option[T]{hasValue: true, value: t}
 }
 
+// NewEmptyOption creates an Option without a value
 func NewEmptyOption[T any]() Option[T] {
 	return Option[T]{hasValue: false}
 }
 
 func (o *Option[T]) Decode(decoder scale.Decoder) error {
+	switch value := any(&o.value).(type) {
+	case *Bool:
+		return decodeOptionBool(decoder, &o.hasValue, value)
+	case *bool:
+		return decodeOptionBool(decoder, &o.hasValue, (*Bool)(value))
+	}
+
 	return decoder.DecodeOption(&o.hasValue, &o.value)
 }
 
 func (o Option[T]) Encode(encoder scale.Encoder) error {
+	switch value := any(o.value).(type) {
+	case Bool:
+		return encodeOptionBool(encoder, o.hasValue, value)
+	case bool:
+		return encodeOptionBool(encoder, o.hasValue, Bool(value))
+	}
+
 	return encoder.EncodeOption(o.hasValue, o.value)
 }
 
+// MarshalJSON returns null if the value is missing, otherwise the JSON of the value
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if !o.hasValue {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON sets the option to none for null, otherwise the value is decoded from the JSON
+func (o *Option[T]) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		o.SetNone()
+		return nil
+	}
+
+	var value T
+
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+
+	o.SetSome(value)
+
+	return nil
+}
+
 // SetSome sets a value
 func (o *Option[T]) SetSome(value T) {
 	o.hasValue = true
@@ -43,6 +113,137 @@ func (o *Option[T]) Unwrap() (ok bool, value T) {
 	return o.hasValue, o.value
 }
 
+// HasValue returns true if a value is present
 func (o *Option[T]) HasValue() bool {
 	return o.hasValue
 }
+
+// IsSome returns true if a value is present
+func (o Option[T]) IsSome() bool {
+	return o.hasValue
+}
+
+// IsNone returns true if the value is missing
+func (o Option[T]) IsNone() bool {
+	return !o.hasValue
+}
+
+func encodeOptionBool(encoder scale.Encoder, hasValue bool, value Bool) error {
+	if !hasValue {
+		return NewOptionBoolEmpty().Encode(encoder)
+	}
+
+	return NewOptionBool(value).Encode(encoder)
+}
+
+func decodeOptionBool(decoder scale.Decoder, hasValue *bool, value *Bool) error {
+	var o OptionBool
+
+	if err := o.Decode(decoder); err != nil {
+		return err
+	}
+
+	*hasValue, *value = o.Unwrap()
+
+	return nil
+}
+
+// Result is either a value of type T on success or an error of type E. It is encoded like Result<T, E> in Rust, i.e.
+// a 0x00 byte followed by the value or a 0x01 byte followed by the error. Its JSON representation is {"ok": value} or
+// {"err": error}.
+type Result[T, E any] struct {
+	isErr bool
+	ok    T
+	err   E
+}
+
+// NewOkResult creates a successful Result with a value
+func NewOkResult[T, E any](value T) Result[T, E] {
+	return Result[T, E]{ok: value}
+}
+
+// NewErrResult creates a failed Result with an error
+func NewErrResult[T, E any](err E) Result[T, E] {
+	return Result[T, E]{isErr: true, err: err}
+}
+
+func (r *Result[T, E]) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		*r = Result[T, E]{}
+		return decoder.Decode(&r.ok)
+	case 1:
+		*r = Result[T, E]{isErr: true}
+		return decoder.Decode(&r.err)
+	default:
+		return fmt.Errorf("unknown byte prefix for encoded Result: %d", b)
+	}
+}
+
+func (r Result[T, E]) Encode(encoder scale.Encoder) error {
+	if r.isErr {
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(r.err)
+	}
+
+	if err := encoder.PushByte(0); err != nil {
+		return err
+	}
+
+	return encoder.Encode(r.ok)
+}
+
+// MarshalJSON returns {"ok": value} on success and {"err": error} on failure
+func (r Result[T, E]) MarshalJSON() ([]byte, error) {
+	if r.isErr {
+		return json.Marshal(map[string]E{"err": r.err})
+	}
+
+	return json.Marshal(map[string]T{"ok": r.ok})
+}
+
+// UnmarshalJSON decodes {"ok": value} or {"err": error}, the keys are case-insensitive like serde's Ok and Err
+func (r *Result[T, E]) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		Ok  json.RawMessage `json:"ok"`
+		Err json.RawMessage `json:"err"`
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	switch {
+	case tmp.Ok != nil && tmp.Err == nil:
+		*r = Result[T, E]{}
+		return json.Unmarshal(tmp.Ok, &r.ok)
+	case tmp.Err != nil && tmp.Ok == nil:
+		*r = Result[T, E]{isErr: true}
+		return json.Unmarshal(tmp.Err, &r.err)
+	default:
+		return fmt.Errorf("invalid Result, expected exactly one of ok and err: %s", b)
+	}
+}
+
+// IsOk returns true if the Result holds a value
+func (r Result[T, E]) IsOk() bool {
+	return !r.isErr
+}
+
+// IsErr returns true if the Result holds an error
+func (r Result[T, E]) IsErr() bool {
+	return r.isErr
+}
+
+// Unwrap returns the value, the error and a flag that indicates whether the Result holds a value
+func (r Result[T, E]) Unwrap() (ok bool, value T, err E) {
+	return !r.isErr, r.ok, r.err
+}
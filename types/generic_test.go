@@ -1,6 +1,7 @@
 package types_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -82,3 +83,101 @@ func testOptionMethods[T any](testVal T, t *testing.T) {
 	assert.False(t, hasValue)
 	assert.Equal(t, emptyVal, value)
 }
+
+func TestOption_Bool(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{NewEmptyOption[bool](), []byte{0}},
+		{NewOption(true), []byte{1}},
+		{NewOption(false), []byte{2}},
+		{NewOption(Bool(false)), []byte{2}},
+	})
+	AssertDecode(t, []DecodingAssert{
+		{[]byte{0}, NewEmptyOption[bool]()},
+		{[]byte{1}, NewOption(true)},
+		{[]byte{2}, NewOption(Bool(false))},
+	})
+
+	var o Option[bool]
+	assert.Error(t, Decode([]byte{3}, &o))
+}
+
+func TestOption_JSON(t *testing.T) {
+	b, err := json.Marshal(struct {
+		Some Option[U32]
+		None Option[U32]
+	}{NewOption[U32](7), NewEmptyOption[U32]()})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Some": 7, "None": null}`, string(b))
+
+	var o Option[U32]
+
+	assert.NoError(t, json.Unmarshal([]byte("7"), &o))
+	assert.Equal(t, NewOption[U32](7), o)
+	assert.True(t, o.IsSome())
+
+	assert.NoError(t, json.Unmarshal([]byte("null"), &o))
+	assert.Equal(t, NewEmptyOption[U32](), o)
+	assert.True(t, o.IsNone())
+
+	assert.Error(t, json.Unmarshal([]byte(`"a"`), &o))
+}
+
+func TestResult_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, NewOkResult[U32, Text](7))
+	AssertRoundtrip(t, NewErrResult[U32, Text]("failed"))
+	AssertRoundtrip(t, []Result[Option[U8], U8]{
+		NewOkResult[Option[U8], U8](NewOption[U8](1)),
+		NewOkResult[Option[U8], U8](NewEmptyOption[U8]()),
+		NewErrResult[Option[U8], U8](2),
+	})
+
+	AssertEncode(t, []EncodingAssert{
+		{NewOkResult[U32, U8](7), MustHexDecodeString("0x0007000000")},
+		{NewErrResult[U32, U8](2), MustHexDecodeString("0x0102")},
+	})
+
+	var r Result[U32, U8]
+	assert.Error(t, Decode([]byte{2}, &r))
+	assert.Error(t, Decode(nil, &r))
+}
+
+func TestResult_Methods(t *testing.T) {
+	r := NewOkResult[U32, Text](7)
+
+	ok, value, err := r.Unwrap()
+	assert.True(t, ok)
+	assert.True(t, r.IsOk())
+	assert.False(t, r.IsErr())
+	assert.Equal(t, U32(7), value)
+	assert.Equal(t, Text(""), err)
+
+	r = NewErrResult[U32, Text]("failed")
+
+	ok, value, err = r.Unwrap()
+	assert.False(t, ok)
+	assert.True(t, r.IsErr())
+	assert.Equal(t, U32(0), value)
+	assert.Equal(t, Text("failed"), err)
+}
+
+func TestResult_JSON(t *testing.T) {
+	b, err := json.Marshal([]Result[U32, string]{NewOkResult[U32, string](7), NewErrResult[U32, string]("failed")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"ok": 7}, {"err": "failed"}]`, string(b))
+
+	var r Result[U32, string]
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"Ok": 7}`), &r))
+	assert.Equal(t, NewOkResult[U32, string](7), r)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"err": "failed"}`), &r))
+	assert.Equal(t, NewErrResult[U32, string]("failed"), r)
+
+	var unit Result[*U32, string]
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"ok": null}`), &unit))
+	assert.True(t, unit.IsOk())
+
+	assert.Error(t, json.Unmarshal([]byte(`{}`), &r))
+	assert.Error(t, json.Unmarshal([]byte(`{"ok": 7, "err": "failed"}`), &r))
+}
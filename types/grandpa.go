@@ -0,0 +1,117 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// GrandpaRoundStates is the state of the GRANDPA voting rounds, as returned by grandpa_roundState
+type GrandpaRoundStates struct {
+	SetID U64 `json:"setId"`
+	// Best is the state of the best round
+	Best GrandpaRoundState `json:"best"`
+	// Background holds the states of the previous rounds that are still tracked
+	Background []GrandpaRoundState `json:"background"`
+}
+
+// GrandpaRoundState is the state of a single GRANDPA voting round
+type GrandpaRoundState struct {
+	Round           U32               `json:"round"`
+	TotalWeight     U32               `json:"totalWeight"`
+	ThresholdWeight U32               `json:"thresholdWeight"`
+	Prevotes        GrandpaRoundVotes `json:"prevotes"`
+	Precommits      GrandpaRoundVotes `json:"precommits"`
+}
+
+// GrandpaRoundVotes are the prevotes or precommits of a GRANDPA voting round
+type GrandpaRoundVotes struct {
+	CurrentWeight U32 `json:"currentWeight"`
+	// Missing holds the SS58 addresses of the authorities that did not vote yet
+	Missing []string `json:"missing"`
+}
+
+// GrandpaJustification is the justification of a block finalized by GRANDPA. It proves the finality of the commit
+// target with the signed precommits of more than 2/3 of the authorities.
+type GrandpaJustification struct {
+	Round  U64
+	Commit GrandpaCommit
+	// VotesAncestries holds the headers between the commit target and the targets of the precommits
+	VotesAncestries []Header
+}
+
+// GrandpaCommit is a GRANDPA commit message, it holds the signed precommits for the target block
+type GrandpaCommit struct {
+	TargetHash   Hash
+	TargetNumber U32
+	Precommits   []GrandpaSignedPrecommit
+}
+
+// GrandpaSignedPrecommit is a precommit signed by a GRANDPA authority
+type GrandpaSignedPrecommit struct {
+	Precommit GrandpaPrecommit
+	// Signature is the ed25519 signature of the authority over the precommit
+	Signature Signature
+	ID        AuthorityID
+}
+
+// GrandpaPrecommit is a GRANDPA vote for a block
+type GrandpaPrecommit struct {
+	TargetHash   Hash
+	TargetNumber U32
+}
+
+// GrandpaFinalityProof is the decoded finality proof returned by grandpa_proveFinality
+type GrandpaFinalityProof struct {
+	// Block is the hash of the block that is proven to be finalized
+	Block Hash
+	// Justification is the encoded GrandpaJustification of the block, see GrandpaEncodedJustification
+	Justification Bytes
+	// UnknownHeaders holds the headers between the requested block and Block
+	UnknownHeaders []Header
+}
+
+// GrandpaEncodedJustification is a SCALE encoded GrandpaJustification, as received via
+// grandpa_subscribeJustifications
+type GrandpaEncodedJustification Bytes
+
+// Decode decodes the justification
+func (j GrandpaEncodedJustification) Decode() (GrandpaJustification, error) {
+	var justification GrandpaJustification
+
+	err := codec.Decode(j, &justification)
+
+	return justification, err
+}
+
+// UnmarshalText deserializes a hex string into a GrandpaEncodedJustification.
+// Used for decoding JSON-RPC subscription messages (grandpa_subscribeJustifications)
+func (j *GrandpaEncodedJustification) UnmarshalText(text []byte) error {
+	bz, err := codec.HexDecodeString(string(text))
+	if err != nil {
+		return err
+	}
+
+	*j = bz
+
+	return nil
+}
+
+// MarshalText serializes the GrandpaEncodedJustification into a hex string
+func (j GrandpaEncodedJustification) MarshalText() ([]byte, error) {
+	return []byte(codec.HexEncodeToString(j)), nil
+}
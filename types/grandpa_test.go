@@ -0,0 +1,106 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var testGrandpaJustification = GrandpaJustification{
+	Round: 7,
+	Commit: GrandpaCommit{
+		TargetHash:   NewHash(MustHexDecodeString("0x0a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20212223242526272829")),
+		TargetNumber: 42,
+		Precommits: []GrandpaSignedPrecommit{
+			{
+				Precommit: GrandpaPrecommit{
+					TargetHash: NewHash(
+						MustHexDecodeString("0x0a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20212223242526272829"),
+					),
+					TargetNumber: 42,
+				},
+				Signature: NewSignature(sig1[:64]),
+				ID:        NewAuthorityID([32]byte{1, 2, 3}),
+			},
+		},
+	},
+	VotesAncestries: []Header{
+		{
+			ParentHash: NewHash(MustHexDecodeString("0x0101010101010101010101010101010101010101010101010101010101010101")),
+			Number:     41,
+		},
+	},
+}
+
+func TestGrandpaJustification_EncodeDecode(t *testing.T) {
+	bz, err := Encode(testGrandpaJustification)
+	assert.NoError(t, err)
+
+	AssertRoundtrip(t, testGrandpaJustification)
+	AssertRoundtrip(t, GrandpaFinalityProof{
+		Block:         testGrandpaJustification.Commit.TargetHash,
+		Justification: bz,
+	})
+}
+
+func TestGrandpaEncodedJustification_Decode(t *testing.T) {
+	hex, err := EncodeToHex(testGrandpaJustification)
+	assert.NoError(t, err)
+
+	var encoded GrandpaEncodedJustification
+
+	err = json.Unmarshal([]byte(`"`+hex+`"`), &encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, GrandpaEncodedJustification(MustHexDecodeString(hex)), encoded)
+
+	justification, err := encoded.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, testGrandpaJustification, justification)
+
+	_, err = GrandpaEncodedJustification{0x01}.Decode()
+	assert.Error(t, err)
+}
+
+func TestGrandpaRoundStates_UnmarshalJSON(t *testing.T) {
+	var states GrandpaRoundStates
+
+	err := json.Unmarshal([]byte(`{"setId":3,"best":{"round":12,"totalWeight":4,"thresholdWeight":3,`+
+		`"prevotes":{"currentWeight":3,"missing":["5GNJqTPyNqANBkUVMN1LPPrxXnFouWXoe2wNSmmEoLctxiZY"]},`+
+		`"precommits":{"currentWeight":0,"missing":[]}},"background":[]}`), &states)
+	assert.NoError(t, err)
+
+	assert.Equal(t, GrandpaRoundStates{
+		SetID: 3,
+		Best: GrandpaRoundState{
+			Round:           12,
+			TotalWeight:     4,
+			ThresholdWeight: 3,
+			Prevotes: GrandpaRoundVotes{
+				CurrentWeight: 3,
+				Missing:       []string{"5GNJqTPyNqANBkUVMN1LPPrxXnFouWXoe2wNSmmEoLctxiZY"},
+			},
+			Precommits: GrandpaRoundVotes{Missing: []string{}},
+		},
+		Background: []GrandpaRoundState{},
+	}, states)
+}
@@ -18,6 +18,8 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
 	"math/big"
 	"strconv"
 	"strings"
@@ -66,6 +68,9 @@ func (b *BlockNumber) Decode(decoder scale.Decoder) error {
 	if err != nil {
 		return err
 	}
+	if !u.IsUint64() || u.Uint64() > math.MaxUint32 {
+		return fmt.Errorf("compact-encoded block number %s overflows u32", u)
+	}
 	*b = BlockNumber(u.Uint64())
-	return err
+	return nil
 }
@@ -19,6 +19,8 @@ package types_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
@@ -43,6 +45,18 @@ func TestBlockNumber_EncodeDecode(t *testing.T) {
 	AssertEncodeEmptyObj[BlockNumber](t, 1)
 }
 
+func TestBlockNumber_Decode_Overflow(t *testing.T) {
+	var b BlockNumber
+
+	err := Decode(MustHexDecodeString("0x03ffffffff"), &b)
+	assert.NoError(t, err)
+	assert.Equal(t, BlockNumber(1<<32-1), b)
+
+	// 2^32 does not fit and must not wrap to 0.
+	err = Decode(MustHexDecodeString("0x070000000001"), &b)
+	assert.EqualError(t, err, "compact-encoded block number 4294967296 overflows u32")
+}
+
 func TestBlockNumber_JSONMarshalUnmarshal(t *testing.T) {
 	b := BlockNumber(1)
 	AssertJSONRoundTrip(t, &b)
@@ -0,0 +1,222 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	fuzz "github.com/google/gofuzz"
+)
+
+var (
+	testJunctionV3n1 = JunctionV3{
+		IsParachain: true,
+		ParachainID: NewUCompactFromUInt(1000),
+	}
+	testJunctionV3n2 = JunctionV3{
+		IsAccountID32:        true,
+		AccountID32NetworkID: NewEmptyOption[NetworkIDV3](),
+		AccountID:            AccountID{1, 2, 3},
+	}
+	testJunctionV3n3 = JunctionV3{
+		IsAccountKey20:        true,
+		AccountKey20NetworkID: NewOption(NetworkIDV3{IsEthereum: true, EthereumChainID: NewUCompactFromUInt(1)}),
+		AccountKey:            [20]U8{4, 5},
+	}
+	testJunctionV3n4 = JunctionV3{
+		IsGeneralKey:     true,
+		GeneralKeyLength: 2,
+		GeneralKey:       [32]U8{0xab, 0xcd},
+	}
+	testJunctionV3n5 = JunctionV3{
+		IsPlurality: true,
+		BodyID:      BodyIDV3{IsUnit: true},
+		BodyPart:    BodyPartV3{IsVoice: true},
+	}
+	testJunctionV3n6 = JunctionV3{
+		IsGlobalConsensus:        true,
+		GlobalConsensusNetworkID: NetworkIDV3{IsKusama: true},
+	}
+
+	junctionV3FuzzOpts = CombineFuzzOpts(
+		networkIDV3FuzzOpts,
+		bodyIDV3FuzzOpts,
+		bodyPartV3FuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(j *JunctionV3, c fuzz.Continue) {
+				switch c.Intn(10) {
+				case 0:
+					j.IsParachain = true
+
+					c.Fuzz(&j.ParachainID)
+				case 1:
+					j.IsAccountID32 = true
+
+					c.Fuzz(&j.AccountID32NetworkID)
+
+					c.Fuzz(&j.AccountID)
+				case 2:
+					j.IsAccountIndex64 = true
+
+					c.Fuzz(&j.AccountIndex64NetworkID)
+
+					c.Fuzz(&j.AccountIndex)
+				case 3:
+					j.IsAccountKey20 = true
+
+					c.Fuzz(&j.AccountKey20NetworkID)
+
+					c.Fuzz(&j.AccountKey)
+				case 4:
+					j.IsPalletInstance = true
+
+					c.Fuzz(&j.PalletIndex)
+				case 5:
+					j.IsGeneralIndex = true
+
+					c.Fuzz(&j.GeneralIndex)
+				case 6:
+					j.IsGeneralKey = true
+
+					c.Fuzz(&j.GeneralKeyLength)
+
+					c.Fuzz(&j.GeneralKey)
+				case 7:
+					j.IsOnlyChild = true
+				case 8:
+					j.IsPlurality = true
+
+					c.Fuzz(&j.BodyID)
+
+					c.Fuzz(&j.BodyPart)
+				case 9:
+					j.IsGlobalConsensus = true
+
+					c.Fuzz(&j.GlobalConsensusNetworkID)
+				}
+			}),
+		},
+	)
+)
+
+func TestJunctionV3_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[JunctionV3](t, 1000, junctionV3FuzzOpts...)
+	AssertDecodeNilData[JunctionV3](t)
+	AssertEncodeEmptyObj[JunctionV3](t, 0)
+}
+
+func TestJunctionV3_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testJunctionV3n1, MustHexDecodeString("0x00a10f")},
+		{testJunctionV3n2, MustHexDecodeString("0x01000102030000000000000000000000000000000000000000000000000000000000")},
+		{testJunctionV3n3, MustHexDecodeString("0x030107040405000000000000000000000000000000000000")},
+		{testJunctionV3n4, MustHexDecodeString("0x0602abcd000000000000000000000000000000000000000000000000000000000000")},
+		{testJunctionV3n5, MustHexDecodeString("0x080000")},
+		{testJunctionV3n6, MustHexDecodeString("0x0903")},
+	})
+}
+
+func TestJunctionV3_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x00a10f"), testJunctionV3n1},
+		{MustHexDecodeString("0x01000102030000000000000000000000000000000000000000000000000000000000"), testJunctionV3n2},
+		{MustHexDecodeString("0x030107040405000000000000000000000000000000000000"), testJunctionV3n3},
+		{MustHexDecodeString("0x0602abcd000000000000000000000000000000000000000000000000000000000000"), testJunctionV3n4},
+		{MustHexDecodeString("0x080000"), testJunctionV3n5},
+		{MustHexDecodeString("0x0903"), testJunctionV3n6},
+	})
+}
+
+var (
+	testJunctionsV3n1 = JunctionsV3{
+		IsHere: true,
+	}
+	testJunctionsV3n2 = JunctionsV3{
+		IsX2: true,
+		X2: [2]JunctionV3{
+			testJunctionV3n1,
+			{IsGeneralIndex: true, GeneralIndex: NewUCompactFromUInt(1984)},
+		},
+	}
+
+	junctionsV3FuzzOpts = CombineFuzzOpts(
+		junctionV3FuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(j *JunctionsV3, c fuzz.Continue) {
+				switch c.Intn(9) {
+				case 0:
+					j.IsHere = true
+				case 1:
+					j.IsX1 = true
+
+					c.Fuzz(&j.X1)
+				case 2:
+					j.IsX2 = true
+
+					c.Fuzz(&j.X2)
+				case 3:
+					j.IsX3 = true
+
+					c.Fuzz(&j.X3)
+				case 4:
+					j.IsX4 = true
+
+					c.Fuzz(&j.X4)
+				case 5:
+					j.IsX5 = true
+
+					c.Fuzz(&j.X5)
+				case 6:
+					j.IsX6 = true
+
+					c.Fuzz(&j.X6)
+				case 7:
+					j.IsX7 = true
+
+					c.Fuzz(&j.X7)
+				case 8:
+					j.IsX8 = true
+
+					c.Fuzz(&j.X8)
+				}
+			}),
+		},
+	)
+)
+
+func TestJunctionsV3_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[JunctionsV3](t, 1000, junctionsV3FuzzOpts...)
+	AssertDecodeNilData[JunctionsV3](t)
+	AssertEncodeEmptyObj[JunctionsV3](t, 0)
+}
+
+func TestJunctionsV3_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testJunctionsV3n1, MustHexDecodeString("0x00")},
+		{testJunctionsV3n2, MustHexDecodeString("0x0200a10f05011f")},
+	})
+}
+
+func TestJunctionsV3_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x00"), testJunctionsV3n1},
+		{MustHexDecodeString("0x0200a10f05011f"), testJunctionsV3n2},
+	})
+}
@@ -27,6 +27,9 @@ import (
 
 const MagicNumber uint32 = 0x6174656d
 
+// LatestMetadataVersion is the highest metadata version that can be decoded.
+const LatestMetadataVersion uint32 = 15
+
 // Modelled after https://github.com/paritytech/substrate/blob/v1.0.0rc2/srml/metadata/src/lib.rs
 
 type Metadata struct {
@@ -46,6 +49,7 @@ type Metadata struct {
 	AsMetadataV12 MetadataV12
 	AsMetadataV13 MetadataV13
 	AsMetadataV14 MetadataV14
+	AsMetadataV15 MetadataV15
 }
 
 type StorageEntryMetadata interface {
@@ -109,6 +113,14 @@ func NewMetadataV14() *Metadata {
 	}
 }
 
+func NewMetadataV15() *Metadata {
+	return &Metadata{
+		Version:       15,
+		AsMetadataV14: MetadataV14{Pallets: make([]PalletMetadataV14, 0)},
+		AsMetadataV15: MetadataV15{Pallets: make([]PalletMetadataV15, 0)},
+	}
+}
+
 func (m *Metadata) Decode(decoder scale.Decoder) error {
 	err := decoder.Decode(&m.MagicNumber)
 	if err != nil {
@@ -142,6 +154,10 @@ func (m *Metadata) Decode(decoder scale.Decoder) error {
 		err = decoder.Decode(&m.AsMetadataV13)
 	case 14:
 		err = decoder.Decode(&m.AsMetadataV14)
+	case 15:
+		err = decoder.Decode(&m.AsMetadataV15)
+		// V15 extends V14, the V14 view keeps code that relies on the V14 pallets and lookup working.
+		m.AsMetadataV14 = m.AsMetadataV15.toV14()
 	default:
 		return fmt.Errorf("unsupported metadata version %v", m.Version)
 	}
@@ -179,6 +195,8 @@ func (m Metadata) Encode(encoder scale.Encoder) error {
 		err = encoder.Encode(m.AsMetadataV13)
 	case 14:
 		err = encoder.Encode(m.AsMetadataV14)
+	case 15:
+		err = encoder.Encode(m.AsMetadataV15)
 	default:
 		return fmt.Errorf("unsupported metadata version %v", m.Version)
 	}
@@ -209,7 +227,7 @@ func NewMetadataError(variant Si1Variant) *MetadataError {
 }
 
 func (m *Metadata) FindError(moduleIndex U8, errorIndex [4]U8) (*MetadataError, error) {
-	if m.Version != 14 {
+	if m.Version != 14 && m.Version != 15 {
 		return nil, fmt.Errorf("invalid metadata version %d", m.Version)
 	}
 
@@ -237,7 +255,7 @@ func (m *Metadata) FindConstantValue(module string, constantName string) ([]byte
 		return m.AsMetadataV12.FindConstantValue(txtModule, txtConstantName)
 	case 13:
 		return m.AsMetadataV13.FindConstantValue(txtModule, txtConstantName)
-	case 14:
+	case 14, 15:
 		return m.AsMetadataV14.FindConstantValue(txtModule, txtConstantName)
 	default:
 		return nil, fmt.Errorf("unsupported metadata version")
@@ -262,7 +280,7 @@ func (m *Metadata) FindCallIndex(call string) (CallIndex, error) {
 		return m.AsMetadataV12.FindCallIndex(call)
 	case 13:
 		return m.AsMetadataV13.FindCallIndex(call)
-	case 14:
+	case 14, 15:
 		return m.AsMetadataV14.FindCallIndex(call)
 	default:
 		return CallIndex{}, fmt.Errorf("unsupported metadata version")
@@ -287,7 +305,7 @@ func (m *Metadata) FindEventNamesForEventID(eventID EventID) (Text, Text, error)
 		return m.AsMetadataV12.FindEventNamesForEventID(eventID)
 	case 13:
 		return m.AsMetadataV13.FindEventNamesForEventID(eventID)
-	case 14:
+	case 14, 15:
 		return m.AsMetadataV14.FindEventNamesForEventID(eventID)
 	default:
 		return "", "", fmt.Errorf("unsupported metadata version")
@@ -312,7 +330,7 @@ func (m *Metadata) FindStorageEntryMetadata(module string, fn string) (StorageEn
 		return m.AsMetadataV12.FindStorageEntryMetadata(module, fn)
 	case 13:
 		return m.AsMetadataV13.FindStorageEntryMetadata(module, fn)
-	case 14:
+	case 14, 15:
 		return m.AsMetadataV14.FindStorageEntryMetadata(module, fn)
 	default:
 		return nil, fmt.Errorf("unsupported metadata version")
@@ -337,13 +355,40 @@ func (m *Metadata) ExistsModuleMetadata(module string) bool {
 		return m.AsMetadataV12.ExistsModuleMetadata(module)
 	case 13:
 		return m.AsMetadataV13.ExistsModuleMetadata(module)
-	case 14:
+	case 14, 15:
 		return m.AsMetadataV14.ExistsModuleMetadata(module)
 	default:
 		return false
 	}
 }
 
+// HasSignedExtension returns true if the runtime uses the signed extension with the given name, e.g.
+// CheckMetadataHashExtension. Metadata before version 11 does not list the signed extensions.
+func (m *Metadata) HasSignedExtension(name string) bool {
+	var signedExtensions []string
+
+	switch m.Version {
+	case 11:
+		signedExtensions = m.AsMetadataV11.Extrinsic.SignedExtensions
+	case 12:
+		signedExtensions = m.AsMetadataV12.Extrinsic.SignedExtensions
+	case 13:
+		signedExtensions = m.AsMetadataV13.Extrinsic.SignedExtensions
+	case 14, 15:
+		for _, signedExtension := range m.AsMetadataV14.Extrinsic.SignedExtensions {
+			signedExtensions = append(signedExtensions, string(signedExtension.Identifier))
+		}
+	}
+
+	for _, signedExtension := range signedExtensions {
+		if signedExtension == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Default implementation of Hasher() for a Storage entry
 // It fails when called if entry is not a plain type.
 func DefaultPlainHasher(entry StorageEntryMetadata) (hash.Hash, error) {
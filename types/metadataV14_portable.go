@@ -118,6 +118,26 @@ type Si1Type struct {
 	Docs   []Text
 }
 
+// ErrSi1TypeNotFound is returned by ResolveSi1Type if a type is missing from the lookup.
+var ErrSi1TypeNotFound = errors.New("type not found")
+
+// ResolveSi1Type returns the type with the given ID from a lookup like MetadataV14.EfficientLookup, unwrapping
+// composites with a single field like BoundedVec.
+func ResolveSi1Type(lookup map[int64]*Si1Type, id Si1LookupTypeID) (*Si1Type, error) {
+	for {
+		t, ok := lookup[id.Int64()]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrSi1TypeNotFound, id.Int64())
+		}
+
+		if !t.Def.IsComposite || len(t.Def.Composite.Fields) != 1 {
+			return t, nil
+		}
+
+		id = t.Def.Composite.Fields[0].Type
+	}
+}
+
 type Si1TypeParameter struct {
 	Name    Text
 	HasType bool
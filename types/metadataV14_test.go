@@ -313,3 +313,43 @@ func TestMetadataV14_FindError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, metaErr)
 }
+
+func TestMetadata_HasSignedExtension(t *testing.T) {
+	var meta Metadata
+
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	assert.True(t, meta.HasSignedExtension("CheckNonce"))
+	assert.False(t, meta.HasSignedExtension(CheckMetadataHashExtension))
+
+	meta.AsMetadataV14.Extrinsic.SignedExtensions = append(
+		meta.AsMetadataV14.Extrinsic.SignedExtensions,
+		SignedExtensionMetadataV14{Identifier: CheckMetadataHashExtension},
+	)
+
+	assert.True(t, meta.HasSignedExtension(CheckMetadataHashExtension))
+}
+
+func TestResolveSi1Type(t *testing.T) {
+	u32 := &Si1Type{Def: Si1TypeDef{IsPrimitive: true, Primitive: Si1TypeDefPrimitive{Si0TypeDefPrimitive: IsU32}}}
+	bounded := &Si1Type{
+		Path: Si1Path{"BoundedVec"},
+		Def: Si1TypeDef{
+			IsComposite: true,
+			Composite:   Si1TypeDefComposite{Fields: []Si1Field{{Type: NewSi1LookupTypeIDFromUInt(0)}}},
+		},
+	}
+	lookup := map[int64]*Si1Type{0: u32, 1: bounded}
+
+	res, err := ResolveSi1Type(lookup, NewSi1LookupTypeIDFromUInt(1))
+	assert.NoError(t, err)
+	assert.Equal(t, u32, res)
+
+	res, err = ResolveSi1Type(lookup, NewSi1LookupTypeIDFromUInt(0))
+	assert.NoError(t, err)
+	assert.Equal(t, u32, res)
+
+	_, err = ResolveSi1Type(lookup, NewSi1LookupTypeIDFromUInt(2))
+	assert.ErrorIs(t, err, ErrSi1TypeNotFound)
+}
@@ -0,0 +1,215 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// nolint:lll
+// Based on https://github.com/paritytech/frame-metadata/blob/v16.0.0/frame-metadata/src/v15.rs
+//
+// state_getMetadata keeps returning V14, MetadataV15 can only be retrieved using the Metadata_metadata_at_version
+// runtime API.
+type MetadataV15 struct {
+	Lookup     PortableRegistryV14
+	Pallets    []PalletMetadataV15
+	Extrinsic  ExtrinsicV15
+	Type       Si1LookupTypeID
+	APIs       []RuntimeAPIMetadataV15
+	OuterEnums OuterEnumsV15
+	Custom     CustomMetadataV15
+
+	// Custom field to help us lookup a type from the registry
+	// more efficiently. This field is built while decoding and
+	// it is not to be encoded.
+	EfficientLookup map[int64]*Si1Type `scale:"-"`
+}
+
+// DecodeMetadataV15 decodes metadata V15 that is prefixed with the magic number and the version, as returned by the
+// Metadata_metadata_at_version runtime API.
+func DecodeMetadataV15(data []byte, meta *MetadataV15) error {
+	decoder := scale.NewDecoder(bytes.NewReader(data))
+
+	var magicNumber uint32
+	if err := decoder.Decode(&magicNumber); err != nil {
+		return err
+	}
+	if magicNumber != MagicNumber {
+		return fmt.Errorf("magic number mismatch: expected %#x, found %#x", MagicNumber, magicNumber)
+	}
+
+	var version uint8
+	if err := decoder.Decode(&version); err != nil {
+		return err
+	}
+	if version != 15 {
+		return fmt.Errorf("invalid metadata version %d", version)
+	}
+
+	return decoder.Decode(meta)
+}
+
+// Decode implementation for MetadataV15
+// Note: We opt for a custom impl build `EfficientLookup`
+// on the fly.
+func (m *MetadataV15) Decode(decoder scale.Decoder) error {
+	err := decoder.Decode(&m.Lookup)
+	if err != nil {
+		return err
+	}
+
+	m.EfficientLookup = m.Lookup.toMap()
+
+	err = decoder.Decode(&m.Pallets)
+	if err != nil {
+		return err
+	}
+
+	err = decoder.Decode(&m.Extrinsic)
+	if err != nil {
+		return err
+	}
+
+	err = decoder.Decode(&m.Type)
+	if err != nil {
+		return err
+	}
+
+	err = decoder.Decode(&m.APIs)
+	if err != nil {
+		return err
+	}
+
+	err = decoder.Decode(&m.OuterEnums)
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(&m.Custom)
+}
+
+// toV14 returns the V14 view of the metadata, which shares the lookup with V15. V15 does not provide the type of the
+// extrinsic, so the extrinsic type of the view is not set.
+func (m *MetadataV15) toV14() MetadataV14 {
+	pallets := make([]PalletMetadataV14, 0, len(m.Pallets))
+
+	for _, pallet := range m.Pallets {
+		pallets = append(pallets, pallet.PalletMetadataV14)
+	}
+
+	return MetadataV14{
+		Lookup:  m.Lookup,
+		Pallets: pallets,
+		Extrinsic: ExtrinsicV14{
+			Version:          m.Extrinsic.Version,
+			SignedExtensions: m.Extrinsic.SignedExtensions,
+		},
+		Type:            m.Type,
+		EfficientLookup: m.EfficientLookup,
+	}
+}
+
+// FindRuntimeAPIMethod returns the metadata of the method of the runtime API, e.g. AccountNonceApi and
+// account_nonce.
+func (m *MetadataV15) FindRuntimeAPIMethod(api, method string) (*RuntimeAPIMethodMetadataV15, error) {
+	for _, runtimeAPI := range m.APIs {
+		if string(runtimeAPI.Name) != api {
+			continue
+		}
+
+		for i, apiMethod := range runtimeAPI.Methods {
+			if string(apiMethod.Name) == method {
+				return &runtimeAPI.Methods[i], nil
+			}
+		}
+
+		return nil, fmt.Errorf("method %v not found within runtime API %v", method, api)
+	}
+
+	return nil, fmt.Errorf("runtime API %v not found in metadata", api)
+}
+
+type PalletMetadataV15 struct {
+	PalletMetadataV14
+	Docs []Text
+}
+
+func (m *PalletMetadataV15) Decode(decoder scale.Decoder) error {
+	err := m.PalletMetadataV14.Decode(decoder)
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(&m.Docs)
+}
+
+func (m PalletMetadataV15) Encode(encoder scale.Encoder) error {
+	err := m.PalletMetadataV14.Encode(encoder)
+	if err != nil {
+		return err
+	}
+
+	return encoder.Encode(m.Docs)
+}
+
+type ExtrinsicV15 struct {
+	Version          U8
+	AddressType      Si1LookupTypeID
+	CallType         Si1LookupTypeID
+	SignatureType    Si1LookupTypeID
+	ExtraType        Si1LookupTypeID
+	SignedExtensions []SignedExtensionMetadataV14
+}
+
+type RuntimeAPIMetadataV15 struct {
+	Name    Text
+	Methods []RuntimeAPIMethodMetadataV15
+	Docs    []Text
+}
+
+type RuntimeAPIMethodMetadataV15 struct {
+	Name   Text
+	Inputs []RuntimeAPIMethodParamMetadataV15
+	Output Si1LookupTypeID
+	Docs   []Text
+}
+
+type RuntimeAPIMethodParamMetadataV15 struct {
+	Name Text
+	Type Si1LookupTypeID
+}
+
+type OuterEnumsV15 struct {
+	CallEnumType  Si1LookupTypeID
+	EventEnumType Si1LookupTypeID
+	ErrorEnumType Si1LookupTypeID
+}
+
+// CustomMetadataV15 holds the custom metadata of the runtime, the map is encoded as a sorted list of its entries.
+type CustomMetadataV15 struct {
+	Map []CustomValueMetadataV15
+}
+
+type CustomValueMetadataV15 struct {
+	Name  Text
+	Type  Si1LookupTypeID
+	Value Bytes
+}
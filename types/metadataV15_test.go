@@ -0,0 +1,167 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMetadataV15() MetadataV15 {
+	primitive := func(p Si0TypeDefPrimitive) Si1TypeDef {
+		return Si1TypeDef{IsPrimitive: true, Primitive: Si1TypeDefPrimitive{Si0TypeDefPrimitive: p}}
+	}
+
+	return MetadataV15{
+		Lookup: PortableRegistryV14{
+			Types: []PortableTypeV14{
+				{ID: NewSi1LookupTypeIDFromUInt(0), Type: Si1Type{Def: primitive(IsU32)}},
+				{ID: NewSi1LookupTypeIDFromUInt(1), Type: Si1Type{Def: primitive(IsU8)}},
+				{
+					ID: NewSi1LookupTypeIDFromUInt(2),
+					Type: Si1Type{
+						Path: Si1Path{"sp_core", "crypto", "AccountId32"},
+						Def: Si1TypeDef{
+							IsArray: true,
+							Array:   Si1TypeDefArray{Len: 32, Type: NewSi1LookupTypeIDFromUInt(1)},
+						},
+					},
+				},
+			},
+		},
+		Pallets: []PalletMetadataV15{
+			{
+				PalletMetadataV14: PalletMetadataV14{Name: "System", Index: 0},
+				Docs:              []Text{"The System pallet"},
+			},
+		},
+		Extrinsic: ExtrinsicV15{
+			Version:     4,
+			AddressType: NewSi1LookupTypeIDFromUInt(2),
+			SignedExtensions: []SignedExtensionMetadataV14{
+				{Identifier: "CheckNonce", Type: NewSi1LookupTypeIDFromUInt(0)},
+			},
+		},
+		APIs: []RuntimeAPIMetadataV15{
+			{
+				Name: "AccountNonceApi",
+				Methods: []RuntimeAPIMethodMetadataV15{
+					{
+						Name: "account_nonce",
+						Inputs: []RuntimeAPIMethodParamMetadataV15{
+							{Name: "account", Type: NewSi1LookupTypeIDFromUInt(2)},
+						},
+						Output: NewSi1LookupTypeIDFromUInt(0),
+						Docs:   []Text{" Get current account nonce of given `AccountId`."},
+					},
+				},
+				Docs: []Text{" The API to query account nonce."},
+			},
+		},
+		OuterEnums: OuterEnumsV15{
+			CallEnumType:  NewSi1LookupTypeIDFromUInt(0),
+			EventEnumType: NewSi1LookupTypeIDFromUInt(0),
+			ErrorEnumType: NewSi1LookupTypeIDFromUInt(0),
+		},
+		Custom: CustomMetadataV15{
+			Map: []CustomValueMetadataV15{
+				{Name: "foo", Type: NewSi1LookupTypeIDFromUInt(0), Value: Bytes{1, 0, 0, 0}},
+			},
+		},
+	}
+}
+
+func TestMetadataV15EncodeDecodeRoundtrip(t *testing.T) {
+	meta := newTestMetadataV15()
+
+	encoded, err := Encode(meta)
+	assert.NoError(t, err)
+
+	var decoded MetadataV15
+	err = Decode(encoded, &decoded)
+	assert.NoError(t, err)
+
+	assert.Len(t, decoded.EfficientLookup, 3)
+	assert.Equal(t, &meta.Lookup.Types[2].Type, decoded.EfficientLookup[2])
+
+	decoded.EfficientLookup = nil
+	assert.Equal(t, meta, decoded)
+}
+
+func TestDecodeMetadataV15(t *testing.T) {
+	meta := newTestMetadataV15()
+
+	encoded, err := Encode(meta)
+	assert.NoError(t, err)
+
+	prefixed := append(MustHexDecodeString("0x6d6574610f"), encoded...)
+
+	var decoded MetadataV15
+	assert.NoError(t, DecodeMetadataV15(prefixed, &decoded))
+	assert.Equal(t, meta.APIs, decoded.APIs)
+
+	prefixed[4] = 14
+	assert.EqualError(t, DecodeMetadataV15(prefixed, &decoded), "invalid metadata version 14")
+
+	prefixed[0] = 0
+	assert.EqualError(
+		t,
+		DecodeMetadataV15(prefixed, &decoded),
+		"magic number mismatch: expected 0x6174656d, found 0x61746500",
+	)
+}
+
+func TestMetadataV15_FindRuntimeAPIMethod(t *testing.T) {
+	meta := newTestMetadataV15()
+
+	method, err := meta.FindRuntimeAPIMethod("AccountNonceApi", "account_nonce")
+	assert.NoError(t, err)
+	assert.Equal(t, &meta.APIs[0].Methods[0], method)
+
+	_, err = meta.FindRuntimeAPIMethod("AccountNonceApi", "unknown")
+	assert.EqualError(t, err, "method unknown not found within runtime API AccountNonceApi")
+
+	_, err = meta.FindRuntimeAPIMethod("UnknownApi", "account_nonce")
+	assert.EqualError(t, err, "runtime API UnknownApi not found in metadata")
+}
+
+func TestMetadataV15_Metadata(t *testing.T) {
+	metaV15 := newTestMetadataV15()
+
+	encoded, err := Encode(metaV15)
+	assert.NoError(t, err)
+
+	var meta Metadata
+	err = Decode(append(MustHexDecodeString("0x6d6574610f"), encoded...), &meta)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 15, meta.Version)
+	assert.Equal(t, metaV15.APIs, meta.AsMetadataV15.APIs)
+
+	// the V14 view shares the pallets and the lookup
+	assert.Equal(t, []PalletMetadataV14{metaV15.Pallets[0].PalletMetadataV14}, meta.AsMetadataV14.Pallets)
+	assert.Equal(t, meta.AsMetadataV15.EfficientLookup, meta.AsMetadataV14.EfficientLookup)
+	assert.Equal(t, metaV15.Extrinsic.SignedExtensions, meta.AsMetadataV14.Extrinsic.SignedExtensions)
+	assert.True(t, meta.ExistsModuleMetadata("System"))
+	assert.False(t, meta.ExistsModuleMetadata("Balances"))
+
+	reEncoded, err := Encode(meta)
+	assert.NoError(t, err)
+	assert.Equal(t, append(MustHexDecodeString("0x6d6574610f"), encoded...), reEncoded)
+}
@@ -0,0 +1,526 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Hash returns the blake2-256 hash of the SCALE encoded metadata. It changes with any change of the metadata,
+// including its docs and the order of its types, and is the same for the same metadata across environments, e.g. to
+// key caches by the metadata.
+func (m *Metadata) Hash() (Hash, error) {
+	var buf bytes.Buffer
+
+	if err := scale.NewEncoder(&buf).Encode(m); err != nil {
+		return Hash{}, err
+	}
+
+	return blake2b.Sum256(buf.Bytes()), nil
+}
+
+// Fingerprint returns the blake2-256 hash of the structure of the metadata. Unlike Hash, it does not change with the
+// docs, the IDs of the types in the lookup or the order of pallets, storage entries, constants and runtime APIs,
+// which do not affect the encoding of calls, events and storage. It only supports metadata v14 and later.
+func (m *Metadata) Fingerprint() (Hash, error) {
+	f, err := newMetadataFingerprint(m)
+	if err != nil {
+		return Hash{}, err
+	}
+
+	var b strings.Builder
+
+	b.WriteString(f.extrinsic)
+
+	for _, name := range sortedKeys(f.pallets) {
+		b.WriteString(f.pallets[name])
+	}
+
+	for _, name := range sortedKeys(f.apis) {
+		b.WriteString(f.apis[name])
+	}
+
+	for _, name := range sortedKeys(f.types) {
+		for _, description := range sortedKeys(f.types[name]) {
+			fmt.Fprintf(&b, "type %s %s\n", name, description)
+		}
+	}
+
+	return blake2b.Sum256([]byte(b.String())), nil
+}
+
+// MetadataDiff is a coarse summary of the differences between two metadata, see DiffMetadata.
+type MetadataDiff struct {
+	// AddedPallets and RemovedPallets are the names of the pallets that only exist in the new or the old metadata.
+	AddedPallets   []string
+	RemovedPallets []string
+	// ChangedPallets are the pallets of both metadata that differ.
+	ChangedPallets []PalletDiff
+	// ChangedTypes are the paths of the named types of both metadata whose structure differs, e.g.
+	// sp_runtime::multiaddress::MultiAddress<sp_core::crypto::AccountId32, ()>.
+	ChangedTypes []string
+}
+
+// IsEmpty returns true if the metadata do not differ structurally.
+func (d MetadataDiff) IsEmpty() bool {
+	return len(d.AddedPallets) == 0 && len(d.RemovedPallets) == 0 && len(d.ChangedPallets) == 0 &&
+		len(d.ChangedTypes) == 0
+}
+
+// PalletDiff holds the index and the number of calls, events, errors, storage entries and constants of a pallet in
+// the old and the new metadata. The numbers can be equal if e.g. the args of a call changed.
+type PalletDiff struct {
+	Name string
+
+	OldIndex, NewIndex         U8
+	OldCalls, NewCalls         int
+	OldEvents, NewEvents       int
+	OldErrors, NewErrors       int
+	OldStorage, NewStorage     int
+	OldConstants, NewConstants int
+}
+
+// DiffMetadata compares the structure of two metadata, like Metadata.Fingerprint, and reports the pallets that were
+// added, removed or changed and the named types whose structure changed. It only supports metadata v14 and later.
+func DiffMetadata(oldMeta, newMeta *Metadata) (MetadataDiff, error) {
+	var diff MetadataDiff
+
+	oldF, err := newMetadataFingerprint(oldMeta)
+	if err != nil {
+		return diff, err
+	}
+
+	newF, err := newMetadataFingerprint(newMeta)
+	if err != nil {
+		return diff, err
+	}
+
+	oldPallets := palletsByName(oldMeta)
+	newPallets := palletsByName(newMeta)
+
+	for _, name := range sortedKeys(newPallets) {
+		if _, ok := oldPallets[name]; !ok {
+			diff.AddedPallets = append(diff.AddedPallets, name)
+		}
+	}
+
+	for _, name := range sortedKeys(oldPallets) {
+		newPallet, ok := newPallets[name]
+		if !ok {
+			diff.RemovedPallets = append(diff.RemovedPallets, name)
+			continue
+		}
+
+		if oldF.pallets[name] == newF.pallets[name] {
+			continue
+		}
+
+		oldPallet := oldPallets[name]
+
+		diff.ChangedPallets = append(diff.ChangedPallets, PalletDiff{
+			Name:         name,
+			OldIndex:     oldPallet.Index,
+			NewIndex:     newPallet.Index,
+			OldCalls:     oldF.variantCount(oldPallet.HasCalls, oldPallet.Calls.Type),
+			NewCalls:     newF.variantCount(newPallet.HasCalls, newPallet.Calls.Type),
+			OldEvents:    oldF.variantCount(oldPallet.HasEvents, oldPallet.Events.Type),
+			NewEvents:    newF.variantCount(newPallet.HasEvents, newPallet.Events.Type),
+			OldErrors:    oldF.variantCount(oldPallet.HasErrors, oldPallet.Errors.Type),
+			NewErrors:    newF.variantCount(newPallet.HasErrors, newPallet.Errors.Type),
+			OldStorage:   len(oldPallet.Storage.Items),
+			NewStorage:   len(newPallet.Storage.Items),
+			OldConstants: len(oldPallet.Constants),
+			NewConstants: len(newPallet.Constants),
+		})
+	}
+
+	for _, name := range sortedKeys(oldF.types) {
+		newDescriptions, ok := newF.types[name]
+		if !ok {
+			continue
+		}
+
+		if strings.Join(sortedKeys(oldF.types[name]), "|") != strings.Join(sortedKeys(newDescriptions), "|") {
+			diff.ChangedTypes = append(diff.ChangedTypes, name)
+		}
+	}
+
+	return diff, nil
+}
+
+func palletsByName(meta *Metadata) map[string]PalletMetadataV14 {
+	pallets := make(map[string]PalletMetadataV14, len(meta.AsMetadataV14.Pallets))
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		pallets[string(pallet.Name)] = pallet
+	}
+
+	return pallets
+}
+
+// metadataFingerprint describes the pallets, runtime APIs and named types of the metadata without the IDs of the
+// types. Types are referred to by their path and type parameters if they have a path, or by their structure
+// otherwise. The structure of the named types is described once.
+type metadataFingerprint struct {
+	lookup map[int64]*Si1Type
+
+	// refs holds the references of the types, pending the types with a path whose structure is not described yet.
+	refs    map[int64]string
+	pending []int64
+
+	extrinsic string
+	pallets   map[string]string
+	apis      map[string]string
+
+	// types holds the descriptions of the named types, types with the same path and type parameters but a different
+	// structure have multiple descriptions.
+	types map[string]map[string]bool
+}
+
+func newMetadataFingerprint(meta *Metadata) (*metadataFingerprint, error) {
+	if meta.Version < 14 {
+		return nil, fmt.Errorf("metadata v%d is not supported, v14 or later is required", meta.Version)
+	}
+
+	f := &metadataFingerprint{
+		lookup:  meta.AsMetadataV14.EfficientLookup,
+		refs:    map[int64]string{},
+		pallets: map[string]string{},
+		apis:    map[string]string{},
+		types:   map[string]map[string]bool{},
+	}
+
+	if f.lookup == nil {
+		f.lookup = meta.AsMetadataV14.Lookup.toMap()
+	}
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		f.pallets[string(pallet.Name)] = f.describePallet(pallet)
+	}
+
+	if meta.Version >= 15 {
+		for _, api := range meta.AsMetadataV15.APIs {
+			f.apis[string(api.Name)] = f.describeAPI(api)
+		}
+	}
+
+	var extrinsic strings.Builder
+
+	fmt.Fprintf(&extrinsic, "extrinsic v%d\n", meta.AsMetadataV14.Extrinsic.Version)
+
+	// The order of the signed extensions is part of the encoding.
+	for _, ext := range meta.AsMetadataV14.Extrinsic.SignedExtensions {
+		fmt.Fprintf(&extrinsic, "extension %s %s %s\n",
+			ext.Identifier, f.typeRef(ext.Type), f.typeRef(ext.AdditionalSigned))
+	}
+
+	f.extrinsic = extrinsic.String()
+
+	f.describeNamedTypes()
+
+	return f, nil
+}
+
+func (f *metadataFingerprint) describePallet(pallet PalletMetadataV14) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "pallet %s %d\n", pallet.Name, pallet.Index)
+
+	// The variants of the calls, events and errors are part of the pallet, so that e.g. a call that is added changes
+	// the pallet.
+	for _, kind := range []struct {
+		name string
+		has  bool
+		id   Si1LookupTypeID
+	}{
+		{"calls", pallet.HasCalls, pallet.Calls.Type},
+		{"events", pallet.HasEvents, pallet.Events.Type},
+		{"errors", pallet.HasErrors, pallet.Errors.Type},
+	} {
+		if !kind.has {
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s %s", kind.name, f.typeRef(kind.id))
+
+		if t, ok := f.lookup[kind.id.Int64()]; ok {
+			fmt.Fprintf(&b, " %s", f.describeType(t))
+		}
+
+		b.WriteString("\n")
+	}
+
+	if pallet.HasStorage {
+		entries := map[string]string{}
+
+		for _, entry := range pallet.Storage.Items {
+			entries[string(entry.Name)] = f.describeStorageEntry(entry)
+		}
+
+		fmt.Fprintf(&b, "storage %s\n", pallet.Storage.Prefix)
+
+		for _, name := range sortedKeys(entries) {
+			b.WriteString(entries[name])
+		}
+	}
+
+	constants := map[string]string{}
+
+	for _, constant := range pallet.Constants {
+		constants[string(constant.Name)] = fmt.Sprintf("constant %s %s %#x\n",
+			constant.Name, f.typeRef(constant.Type), []byte(constant.Value))
+	}
+
+	for _, name := range sortedKeys(constants) {
+		b.WriteString(constants[name])
+	}
+
+	return b.String()
+}
+
+func (f *metadataFingerprint) describeStorageEntry(entry StorageEntryMetadataV14) string {
+	var modifier string
+
+	switch {
+	case entry.Modifier.IsOptional:
+		modifier = "optional"
+	case entry.Modifier.IsDefault:
+		modifier = "default"
+	case entry.Modifier.IsRequired:
+		modifier = "required"
+	}
+
+	if !entry.Type.IsMap {
+		return fmt.Sprintf("entry %s %s %s %#x\n",
+			entry.Name, modifier, f.typeRef(entry.Type.AsPlainType), []byte(entry.Fallback))
+	}
+
+	hashers := make([]string, 0, len(entry.Type.AsMap.Hashers))
+
+	for _, hasher := range entry.Type.AsMap.Hashers {
+		hashers = append(hashers, storageHasherName(hasher))
+	}
+
+	return fmt.Sprintf("entry %s %s map[%s]%s->%s %#x\n", entry.Name, modifier, strings.Join(hashers, ","),
+		f.typeRef(entry.Type.AsMap.Key), f.typeRef(entry.Type.AsMap.Value), []byte(entry.Fallback))
+}
+
+func (f *metadataFingerprint) describeAPI(api RuntimeAPIMetadataV15) string {
+	methods := map[string]string{}
+
+	for _, method := range api.Methods {
+		inputs := make([]string, 0, len(method.Inputs))
+
+		for _, input := range method.Inputs {
+			inputs = append(inputs, fmt.Sprintf("%s:%s", input.Name, f.typeRef(input.Type)))
+		}
+
+		methods[string(method.Name)] = fmt.Sprintf("method %s(%s)->%s\n",
+			method.Name, strings.Join(inputs, ","), f.typeRef(method.Output))
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "api %s\n", api.Name)
+
+	for _, name := range sortedKeys(methods) {
+		b.WriteString(methods[name])
+	}
+
+	return b.String()
+}
+
+func (f *metadataFingerprint) variantCount(has bool, id Si1LookupTypeID) int {
+	if !has {
+		return 0
+	}
+
+	t, ok := f.lookup[id.Int64()]
+	if !ok || !t.Def.IsVariant {
+		return 0
+	}
+
+	return len(t.Def.Variant.Variants)
+}
+
+// typeRef returns the reference of the type, i.e. its path and type parameters if it has a path, and its structure
+// otherwise. The structure of types with a path is described by describeNamedTypes, so that the references do not
+// depend on the order in which the types are visited.
+func (f *metadataFingerprint) typeRef(id Si1LookupTypeID) string {
+	if ref, ok := f.refs[id.Int64()]; ok {
+		return ref
+	}
+
+	t, ok := f.lookup[id.Int64()]
+	if !ok {
+		return fmt.Sprintf("missing(%d)", id.Int64())
+	}
+
+	if len(t.Path) == 0 {
+		ref := f.describeType(t)
+		f.refs[id.Int64()] = ref
+
+		return ref
+	}
+
+	segments := make([]string, 0, len(t.Path))
+
+	for _, segment := range t.Path {
+		segments = append(segments, string(segment))
+	}
+
+	ref := strings.Join(segments, "::")
+
+	// Guards against type parameters that refer to the type itself.
+	f.refs[id.Int64()] = ref
+
+	if len(t.Params) > 0 {
+		params := make([]string, 0, len(t.Params))
+
+		for _, param := range t.Params {
+			if param.HasType {
+				params = append(params, f.typeRef(param.Type))
+			} else {
+				params = append(params, "_")
+			}
+		}
+
+		ref = fmt.Sprintf("%s<%s>", ref, strings.Join(params, ", "))
+		f.refs[id.Int64()] = ref
+	}
+
+	f.pending = append(f.pending, id.Int64())
+
+	return ref
+}
+
+// describeNamedTypes describes the structure of the types with a path that were referenced, including the types they
+// refer to.
+func (f *metadataFingerprint) describeNamedTypes() {
+	for len(f.pending) > 0 {
+		id := f.pending[0]
+		f.pending = f.pending[1:]
+
+		ref := f.refs[id]
+
+		if f.types[ref] == nil {
+			f.types[ref] = map[string]bool{}
+		}
+
+		f.types[ref][f.describeType(f.lookup[id])] = true
+	}
+}
+
+func (f *metadataFingerprint) describeFields(fields []Si1Field) string {
+	described := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		if field.HasName {
+			described = append(described, fmt.Sprintf("%s:%s", field.Name, f.typeRef(field.Type)))
+		} else {
+			described = append(described, f.typeRef(field.Type))
+		}
+	}
+
+	return strings.Join(described, ", ")
+}
+
+func (f *metadataFingerprint) describeType(t *Si1Type) string {
+	def := t.Def
+
+	switch {
+	case def.IsComposite:
+		return fmt.Sprintf("{%s}", f.describeFields(def.Composite.Fields))
+	case def.IsVariant:
+		variants := append([]Si1Variant{}, def.Variant.Variants...)
+
+		sort.Slice(variants, func(i, j int) bool {
+			return variants[i].Index < variants[j].Index
+		})
+
+		described := make([]string, 0, len(variants))
+
+		for _, variant := range variants {
+			described = append(described, fmt.Sprintf("%d %s(%s)",
+				variant.Index, variant.Name, f.describeFields(variant.Fields)))
+		}
+
+		return fmt.Sprintf("enum{%s}", strings.Join(described, ", "))
+	case def.IsSequence:
+		return fmt.Sprintf("[%s]", f.typeRef(def.Sequence.Type))
+	case def.IsArray:
+		return fmt.Sprintf("[%s; %d]", f.typeRef(def.Array.Type), def.Array.Len)
+	case def.IsTuple:
+		items := make([]string, 0, len(def.Tuple))
+
+		for _, item := range def.Tuple {
+			items = append(items, f.typeRef(item))
+		}
+
+		return fmt.Sprintf("(%s)", strings.Join(items, ", "))
+	case def.IsPrimitive:
+		return fmt.Sprintf("primitive(%d)", def.Primitive.Si0TypeDefPrimitive)
+	case def.IsCompact:
+		return fmt.Sprintf("compact<%s>", f.typeRef(def.Compact.Type))
+	case def.IsBitSequence:
+		return fmt.Sprintf("bits<%s, %s>",
+			f.typeRef(def.BitSequence.BitStoreType), f.typeRef(def.BitSequence.BitOrderType))
+	case def.IsHistoricMetaCompat:
+		return fmt.Sprintf("historic(%s)", def.HistoricMetaCompat)
+	default:
+		return "unknown"
+	}
+}
+
+func storageHasherName(hasher StorageHasherV10) string {
+	switch {
+	case hasher.IsBlake2_128:
+		return "blake2_128"
+	case hasher.IsBlake2_256:
+		return "blake2_256"
+	case hasher.IsBlake2_128Concat:
+		return "blake2_128_concat"
+	case hasher.IsTwox128:
+		return "twox_128"
+	case hasher.IsTwox256:
+		return "twox_256"
+	case hasher.IsTwox64Concat:
+		return "twox_64_concat"
+	case hasher.IsIdentity:
+		return "identity"
+	default:
+		return "unknown"
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,199 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func decodeMetadataV14(t *testing.T) *Metadata {
+	var meta Metadata
+
+	require.NoError(t, DecodeFromHex(MetadataV14Data, &meta))
+
+	return &meta
+}
+
+func TestMetadata_Hash(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	hash, err := meta.Hash()
+	require.NoError(t, err)
+
+	data, err := HexDecodeString(MetadataV14Data)
+	require.NoError(t, err)
+
+	assert.Equal(t, Hash(blake2b.Sum256(data)), hash)
+	assert.Equal(t, "0xe4c9740b8e9ee7103ec35101b6878c4b1f156ceea5de62ef52f5a1b8d5bc8133", hash.Hex())
+}
+
+func TestMetadata_Fingerprint(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	fingerprint, err := meta.Fingerprint()
+	require.NoError(t, err)
+	assert.Equal(t, "0x28381e426882e943dcc49a3e08ee8f6634bef65e35099c1e9fe1a6851ab0852d", fingerprint.Hex())
+
+	// Docs and the order of pallets and storage entries do not change the fingerprint, but the hash.
+	reordered := decodeMetadataV14(t)
+	pallets := reordered.AsMetadataV14.Pallets
+
+	for i, j := 0, len(pallets)-1; i < j; i, j = i+1, j-1 {
+		pallets[i], pallets[j] = pallets[j], pallets[i]
+	}
+
+	for i := range pallets {
+		items := pallets[i].Storage.Items
+
+		for j, k := 0, len(items)-1; j < k; j, k = j+1, k-1 {
+			items[j], items[k] = items[k], items[j]
+		}
+	}
+
+	for i := range reordered.AsMetadataV14.Lookup.Types {
+		reordered.AsMetadataV14.Lookup.Types[i].Type.Docs = nil
+	}
+
+	reorderedFingerprint, err := reordered.Fingerprint()
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, reorderedFingerprint)
+
+	hash, err := meta.Hash()
+	require.NoError(t, err)
+
+	reorderedHash, err := reordered.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, reorderedHash)
+
+	// A call that is added changes the fingerprint.
+	addCall(t, reordered, "Balances", "new_call")
+
+	changedFingerprint, err := reordered.Fingerprint()
+	require.NoError(t, err)
+	assert.NotEqual(t, fingerprint, changedFingerprint)
+}
+
+func TestMetadata_Fingerprint_TypeIDs(t *testing.T) {
+	// The same types with other IDs have the same fingerprint.
+	newMeta := func(offset uint64) *Metadata {
+		u32, account := NewSi1LookupTypeIDFromUInt(offset), NewSi1LookupTypeIDFromUInt(offset+1)
+
+		meta := NewMetadataV14()
+		meta.AsMetadataV14.Lookup.Types = []PortableTypeV14{
+			{ID: u32, Type: Si1Type{Def: Si1TypeDef{
+				IsPrimitive: true,
+				Primitive:   Si1TypeDefPrimitive{Si0TypeDefPrimitive: IsU32},
+			}}},
+			{ID: account, Type: Si1Type{
+				Path: Si1Path{"Account"},
+				Def: Si1TypeDef{IsComposite: true, Composite: Si1TypeDefComposite{Fields: []Si1Field{
+					{HasName: true, Name: "nonce", Type: u32},
+				}}},
+			}},
+		}
+		meta.AsMetadataV14.Pallets = []PalletMetadataV14{{
+			Name:       "System",
+			HasStorage: true,
+			Storage: StorageMetadataV14{Prefix: "System", Items: []StorageEntryMetadataV14{{
+				Name: "Account",
+				Type: StorageEntryTypeV14{IsPlainType: true, AsPlainType: account},
+			}}},
+		}}
+
+		return meta
+	}
+
+	fingerprint, err := newMeta(0).Fingerprint()
+	require.NoError(t, err)
+
+	otherFingerprint, err := newMeta(10).Fingerprint()
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, otherFingerprint)
+}
+
+func TestDiffMetadata(t *testing.T) {
+	oldMeta := decodeMetadataV14(t)
+	newMeta := decodeMetadataV14(t)
+
+	diff, err := DiffMetadata(oldMeta, newMeta)
+	require.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+
+	pallets := newMeta.AsMetadataV14.Pallets
+
+	for i := range pallets {
+		if pallets[i].Name == "Timestamp" {
+			pallets[i].Name = "Clock"
+		}
+	}
+
+	addCall(t, newMeta, "Balances", "new_call")
+
+	for _, typ := range newMeta.AsMetadataV14.EfficientLookup {
+		if len(typ.Path) > 0 && typ.Path[len(typ.Path)-1] == "AccountInfo" {
+			typ.Def.Composite.Fields = typ.Def.Composite.Fields[1:]
+		}
+	}
+
+	diff, err = DiffMetadata(oldMeta, newMeta)
+	require.NoError(t, err)
+	assert.False(t, diff.IsEmpty())
+	assert.Equal(t, []string{"Clock"}, diff.AddedPallets)
+	assert.Equal(t, []string{"Timestamp"}, diff.RemovedPallets)
+
+	require.Len(t, diff.ChangedPallets, 1)
+
+	balances := diff.ChangedPallets[0]
+	assert.Equal(t, "Balances", balances.Name)
+	assert.Equal(t, balances.OldIndex, balances.NewIndex)
+	assert.Equal(t, balances.OldCalls+1, balances.NewCalls)
+	assert.Equal(t, balances.OldEvents, balances.NewEvents)
+
+	require.Len(t, diff.ChangedTypes, 2)
+	assert.True(t, strings.HasPrefix(diff.ChangedTypes[0], "frame_system::AccountInfo<"), diff.ChangedTypes[0])
+	assert.True(t, strings.HasPrefix(diff.ChangedTypes[1], "pallet_balances::pallet::Call<"), diff.ChangedTypes[1])
+
+	_, err = DiffMetadata(NewMetadataV13(), newMeta)
+	assert.Error(t, err)
+}
+
+func addCall(t *testing.T, meta *Metadata, palletName string, callName string) {
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) != palletName {
+			continue
+		}
+
+		callType := meta.AsMetadataV14.EfficientLookup[pallet.Calls.Type.Int64()]
+		variants := callType.Def.Variant.Variants
+
+		callType.Def.Variant.Variants = append(variants, Si1Variant{
+			Name:  Text(callName),
+			Index: variants[len(variants)-1].Index + 1,
+		})
+
+		return
+	}
+
+	t.Fatalf("pallet %s not found", palletName)
+}
@@ -0,0 +1,31 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// CheckMetadataHashExtension is the name of the signed extension that checks the metadata hash, see MetadataHashMode
+const CheckMetadataHashExtension = "CheckMetadataHash"
+
+// MetadataHashMode is the mode of the CheckMetadataHash signed extension. If it is enabled, the RFC-0078 hash of the
+// metadata is signed as additional data, so that offline signers like the Ledger generic app can verify the metadata
+// the extrinsic was built with. Runtimes with the extension reject extrinsics without the mode, so it must at least
+// be set to disabled for them, see Metadata.HasSignedExtension.
+type MetadataHashMode U8
+
+const (
+	MetadataHashDisabled MetadataHashMode = 0
+	MetadataHashEnabled  MetadataHashMode = 1
+)
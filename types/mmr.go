@@ -45,6 +45,65 @@ func (d *GenerateMMRProofResponse) UnmarshalJSON(bz []byte) error {
 
 type MMREncodableOpaqueLeaf Bytes
 
+// MMRLeavesProof is a MMR proof for a batch of leaves, as returned by mmr_generateProof and expected by
+// mmr_verifyProof and mmr_verifyProofStateless
+type MMRLeavesProof struct {
+	// BlockHash is the hash of the block the proof was generated at
+	BlockHash H256
+	// Leaves holds the SCALE encoded leaves in the order of Proof.LeafIndices, see DecodeLeaves
+	Leaves []MMREncodableOpaqueLeaf
+	Proof  MMRBatchProof
+}
+
+// DecodeLeaves decodes the leaves of the proof
+func (p MMRLeavesProof) DecodeLeaves() ([]MMRLeaf, error) {
+	leaves := make([]MMRLeaf, len(p.Leaves))
+
+	for i, leaf := range p.Leaves {
+		if err := codec.Decode(leaf, &leaves[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return leaves, nil
+}
+
+// UnmarshalJSON fills p with the JSON encoded byte array given by bz
+func (p *MMRLeavesProof) UnmarshalJSON(bz []byte) error {
+	var tmp struct {
+		BlockHash string `json:"blockHash"`
+		Leaves    string `json:"leaves"`
+		Proof     string `json:"proof"`
+	}
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+	if err := codec.DecodeFromHex(tmp.BlockHash, &p.BlockHash); err != nil {
+		return err
+	}
+	if err := codec.DecodeFromHex(tmp.Leaves, &p.Leaves); err != nil {
+		return err
+	}
+	return codec.DecodeFromHex(tmp.Proof, &p.Proof)
+}
+
+// MarshalJSON returns a JSON encoded byte array of p
+func (p MMRLeavesProof) MarshalJSON() ([]byte, error) {
+	leaves, err := codec.EncodeToHex(p.Leaves)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := codec.EncodeToHex(p.Proof)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		BlockHash string `json:"blockHash"`
+		Leaves    string `json:"leaves"`
+		Proof     string `json:"proof"`
+	}{p.BlockHash.Hex(), leaves, proof})
+}
+
 // MMRProof is a MMR proof
 type MMRProof struct {
 	// The index of the leaf the proof is for.
@@ -55,6 +114,16 @@ type MMRProof struct {
 	Items []H256
 }
 
+// MMRBatchProof is a MMR proof for a batch of leaves
+type MMRBatchProof struct {
+	// The indices of the leaves the proof is for.
+	LeafIndices []U64
+	// Number of leaves in MMR, when the proof was generated.
+	LeafCount U64
+	// Proof elements (hashes of siblings of inner nodes on the path to the leaves).
+	Items []H256
+}
+
 type MMRLeaf struct {
 	Version               MMRLeafVersion
 	ParentNumberAndHash   ParentNumberAndHash
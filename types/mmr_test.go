@@ -39,3 +39,28 @@ func TestGenerateMMRProofResponse_Unmarshal(t *testing.T) {
 
 	AssertEqual(t, unmarshalled, expected)
 }
+
+func TestMMRLeavesProof_JSON(t *testing.T) {
+	proof := MMRLeavesProof{
+		BlockHash: H256{0x52, 0xd9},
+		Leaves:    []MMREncodableOpaqueLeaf{{0x01, 0x02}, {0x03}},
+		Proof: MMRBatchProof{
+			LeafIndices: []U64{1, 3},
+			LeafCount:   5,
+			Items:       []H256{{0x0c}},
+		},
+	}
+
+	marshalled, err := json.Marshal(proof)
+	AssertEqual(t, err, nil)
+
+	var unmarshalled MMRLeavesProof
+	err = json.Unmarshal(marshalled, &unmarshalled)
+	AssertEqual(t, err, nil)
+	AssertEqual(t, unmarshalled, proof)
+
+	var tmp map[string]string
+	err = json.Unmarshal(marshalled, &tmp)
+	AssertEqual(t, err, nil)
+	AssertEqual(t, tmp["leaves"], "0x080801020403")
+}
@@ -17,10 +17,17 @@
 package types
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
 
+// MultiAddress is the sp_runtime::MultiAddress of a signer or a call argument. At most one of its variants is set, a
+// MultiAddress without a variant is encoded as no bytes. The index of the Index variant is compact-encoded.
 type MultiAddress struct {
 	IsID        bool
 	AsID        AccountID
@@ -47,6 +54,29 @@ func NewMultiAddressFromAccountID(b []byte) (MultiAddress, error) {
 	}, nil
 }
 
+// NewMultiAddressFromAccountID20 creates an Address20 address from the given 20 byte account, e.g. the AccountId20 of
+// Ethereum-compatible chains like Moonbeam.
+func NewMultiAddressFromAccountID20(b []byte) (MultiAddress, error) {
+	if len(b) != 20 {
+		return MultiAddress{}, fmt.Errorf("expected 20 bytes for an AccountId20, got %d", len(b))
+	}
+
+	address := MultiAddress{IsAddress20: true}
+	copy(address.AsAddress20[:], b)
+
+	return address, nil
+}
+
+// NewMultiAddressFromIndex creates an Index address from the given account index of the indices pallet.
+func NewMultiAddressFromIndex(index AccountIndex) MultiAddress {
+	return MultiAddress{IsIndex: true, AsIndex: index}
+}
+
+// NewMultiAddressFromRaw creates a Raw address from the given bytes, whose format is defined by the runtime.
+func NewMultiAddressFromRaw(b []byte) MultiAddress {
+	return MultiAddress{IsRaw: true, AsRaw: b}
+}
+
 // NewMultiAddressFromHexAccountID creates an Address from the given hex string that contains an AccountID (public key)
 func NewMultiAddressFromHexAccountID(str string) (MultiAddress, error) {
 	b, err := codec.HexDecodeString(str)
@@ -70,7 +100,7 @@ func (m MultiAddress) Encode(encoder scale.Encoder) error {
 			return err
 		}
 
-		return encoder.Encode(m.AsIndex)
+		return encoder.EncodeUintCompact(*new(big.Int).SetUint64(uint64(m.AsIndex)))
 	case m.IsRaw:
 		if err = encoder.PushByte(2); err != nil {
 			return err
@@ -108,7 +138,18 @@ func (m *MultiAddress) Decode(decoder scale.Decoder) error {
 	case 1:
 		m.IsIndex = true
 
-		return decoder.Decode(&m.AsIndex)
+		index, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return err
+		}
+
+		if !index.IsUint64() || index.Uint64() > math.MaxUint32 {
+			return fmt.Errorf("MultiAddress index %s overflows u32", index)
+		}
+
+		m.AsIndex = AccountIndex(index.Uint64())
+
+		return nil
 	case 2:
 		m.IsRaw = true
 
@@ -123,5 +164,92 @@ func (m *MultiAddress) Decode(decoder scale.Decoder) error {
 		return decoder.Decode(&m.AsAddress20)
 	}
 
+	return fmt.Errorf("unknown MultiAddress variant %d", b)
+}
+
+// multiAddressJSON is the JSON representation of a MultiAddress, like polkadot-js uses for enums. Byte variants are
+// hex encoded.
+type multiAddressJSON struct {
+	ID        *AccountID    `json:"id,omitempty"`
+	Index     *AccountIndex `json:"index,omitempty"`
+	Raw       *string       `json:"raw,omitempty"`
+	Address32 *string       `json:"address32,omitempty"`
+	Address20 *string       `json:"address20,omitempty"`
+}
+
+func (m MultiAddress) MarshalJSON() ([]byte, error) {
+	var tmp multiAddressJSON
+
+	switch {
+	case m.IsID:
+		tmp.ID = &m.AsID
+	case m.IsIndex:
+		tmp.Index = &m.AsIndex
+	case m.IsRaw:
+		raw := codec.HexEncodeToString(m.AsRaw)
+		tmp.Raw = &raw
+	case m.IsAddress32:
+		address32 := codec.HexEncodeToString(m.AsAddress32[:])
+		tmp.Address32 = &address32
+	case m.IsAddress20:
+		address20 := codec.HexEncodeToString(m.AsAddress20[:])
+		tmp.Address20 = &address20
+	}
+
+	return json.Marshal(tmp)
+}
+
+func (m *MultiAddress) UnmarshalJSON(b []byte) error {
+	var tmp multiAddressJSON
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var (
+		address MultiAddress
+		err     error
+	)
+
+	switch {
+	case tmp.ID != nil:
+		address = MultiAddress{IsID: true, AsID: *tmp.ID}
+	case tmp.Index != nil:
+		address = NewMultiAddressFromIndex(*tmp.Index)
+	case tmp.Raw != nil:
+		var raw []byte
+
+		raw, err = codec.HexDecodeString(*tmp.Raw)
+		address = NewMultiAddressFromRaw(raw)
+	case tmp.Address32 != nil:
+		address = MultiAddress{IsAddress32: true}
+		err = decodeFixedHex(*tmp.Address32, address.AsAddress32[:])
+	case tmp.Address20 != nil:
+		address = MultiAddress{IsAddress20: true}
+		err = decodeFixedHex(*tmp.Address20, address.AsAddress20[:])
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*m = address
+
+	return nil
+}
+
+// decodeFixedHex decodes the hex string into target, which has to have the length of the decoded bytes.
+func decodeFixedHex(s string, target []byte) error {
+	b, err := codec.HexDecodeString(s)
+	if err != nil {
+		return err
+	}
+
+	if len(b) != len(target) {
+		return fmt.Errorf("expected %d bytes, got %d", len(target), len(b))
+	}
+
+	copy(target, b)
+
 	return nil
 }
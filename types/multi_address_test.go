@@ -17,6 +17,7 @@
 package types_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -93,3 +94,73 @@ func TestNewMultiAddressFromAccountID(t *testing.T) {
 		AsAddress20: [20]byte{},
 	})
 }
+
+func TestMultiAddress_Constructors(t *testing.T) {
+	address20, err := NewMultiAddressFromAccountID20([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17,
+		18, 19, 20})
+	assert.NoError(t, err)
+	assert.Equal(t, MultiAddress{
+		IsAddress20: true,
+		AsAddress20: [20]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+	}, address20)
+
+	_, err = NewMultiAddressFromAccountID20(testAccountIDBytes)
+	assert.Error(t, err)
+
+	assert.Equal(t, MultiAddress{IsIndex: true, AsIndex: 100}, NewMultiAddressFromIndex(100))
+	assert.Equal(t, MultiAddress{IsRaw: true, AsRaw: []byte{1, 2, 3}}, NewMultiAddressFromRaw([]byte{1, 2, 3}))
+}
+
+func TestMultiAddress_Encode(t *testing.T) {
+	address20, err := NewMultiAddressFromAccountID20(MustHexDecodeString("0xf24ff3a9cf04c71dbc94d0b566f7a27b94566cac"))
+	assert.NoError(t, err)
+
+	AssertEncode(t, []EncodingAssert{
+		{newTestMultiAddress(), append([]byte{0}, testAccountIDBytes...)},
+		// The index is compact-encoded, like the #[codec(compact)] AccountIndex of sp_runtime::MultiAddress.
+		{NewMultiAddressFromIndex(1), MustHexDecodeString("0x0104")},
+		{NewMultiAddressFromIndex(100), MustHexDecodeString("0x019101")},
+		{NewMultiAddressFromIndex(1 << 31), MustHexDecodeString("0x010300000080")},
+		{NewMultiAddressFromRaw([]byte{1, 2, 3}), MustHexDecodeString("0x020c010203")},
+		{MultiAddress{IsAddress32: true, AsAddress32: [32]byte{1}}, append([]byte{3, 1}, make([]byte, 31)...)},
+		{address20, MustHexDecodeString("0x04f24ff3a9cf04c71dbc94d0b566f7a27b94566cac")},
+	})
+}
+
+func TestMultiAddress_Decode_Errors(t *testing.T) {
+	var address MultiAddress
+
+	err := Decode([]byte{5}, &address)
+	assert.EqualError(t, err, "unknown MultiAddress variant 5")
+
+	err = Decode(MustHexDecodeString("0x01070000000001"), &address)
+	assert.EqualError(t, err, "MultiAddress index 4294967296 overflows u32")
+}
+
+func TestMultiAddress_JSONMarshalUnmarshal(t *testing.T) {
+	f := fuzz.New().NilChance(0)
+	for _, opt := range multiAddressFuzzOpts {
+		opt(f)
+	}
+
+	for i := 0; i < 100; i++ {
+		var address MultiAddress
+
+		f.Fuzz(&address)
+
+		AssertJSONRoundTrip(t, &address)
+	}
+
+	b, err := json.Marshal(NewMultiAddressFromIndex(5))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"index":5}`, string(b))
+
+	b, err = json.Marshal(MultiAddress{IsAddress20: true, AsAddress20: [20]byte{0xab}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"address20":"0xab00000000000000000000000000000000000000"}`, string(b))
+
+	var address MultiAddress
+
+	err = json.Unmarshal([]byte(`{"address20":"0xabcd"}`), &address)
+	assert.EqualError(t, err, "expected 20 bytes, got 2")
+}
@@ -0,0 +1,94 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+
+// MultiLocationV3 is the MultiLocation of XCM v3, a location relative to the current one. The Location of XCM v4 has
+// the same encoding, see LocationV4.
+type MultiLocationV3 struct {
+	Parents  U8
+	Interior JunctionsV3
+}
+
+// LocationV4 is the Location of XCM v4.
+type LocationV4 = MultiLocationV3
+
+func (m *MultiLocationV3) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&m.Parents); err != nil {
+		return err
+	}
+
+	return decoder.Decode(&m.Interior)
+}
+
+func (m MultiLocationV3) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(m.Parents); err != nil {
+		return err
+	}
+
+	return encoder.Encode(m.Interior)
+}
+
+// VersionedLocation is the VersionedLocation of the XCM pallets of runtimes that support XCM v3 and v4. See
+// VersionedMultiLocation for older runtimes.
+type VersionedLocation struct {
+	IsV3 bool
+	V3   MultiLocationV3
+
+	IsV4 bool
+	V4   LocationV4
+}
+
+func (v *VersionedLocation) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 3:
+		v.IsV3 = true
+
+		return decoder.Decode(&v.V3)
+	case 4:
+		v.IsV4 = true
+
+		return decoder.Decode(&v.V4)
+	}
+
+	return nil
+}
+
+func (v VersionedLocation) Encode(encoder scale.Encoder) error {
+	switch {
+	case v.IsV3:
+		if err := encoder.PushByte(3); err != nil {
+			return err
+		}
+
+		return encoder.Encode(v.V3)
+	case v.IsV4:
+		if err := encoder.PushByte(4); err != nil {
+			return err
+		}
+
+		return encoder.Encode(v.V4)
+	}
+
+	return nil
+}
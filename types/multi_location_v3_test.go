@@ -0,0 +1,106 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	fuzz "github.com/google/gofuzz"
+)
+
+var (
+	testMultiLocationV3n1 = MultiLocationV3{
+		Parents: 1,
+		Interior: JunctionsV3{
+			IsX1: true,
+			X1:   testJunctionV3n1,
+		},
+	}
+
+	multiLocationV3FuzzOpts = junctionsV3FuzzOpts
+)
+
+func TestMultiLocationV3_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[MultiLocationV3](t, 100, multiLocationV3FuzzOpts...)
+	AssertDecodeNilData[MultiLocationV3](t)
+	AssertEncodeEmptyObj[MultiLocationV3](t, 1)
+}
+
+func TestMultiLocationV3_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testMultiLocationV3n1, MustHexDecodeString("0x010100a10f")},
+	})
+}
+
+func TestMultiLocationV3_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x010100a10f"), testMultiLocationV3n1},
+	})
+}
+
+var (
+	testVersionedLocation1 = VersionedLocation{
+		IsV3: true,
+		V3:   testMultiLocationV3n1,
+	}
+	testVersionedLocation2 = VersionedLocation{
+		IsV4: true,
+		V4:   testMultiLocationV3n1,
+	}
+
+	versionedLocationFuzzOpts = CombineFuzzOpts(
+		multiLocationV3FuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(v *VersionedLocation, c fuzz.Continue) {
+				if c.RandBool() {
+					v.IsV3 = true
+
+					c.Fuzz(&v.V3)
+
+					return
+				}
+
+				v.IsV4 = true
+
+				c.Fuzz(&v.V4)
+			}),
+		},
+	)
+)
+
+func TestVersionedLocation_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[VersionedLocation](t, 100, versionedLocationFuzzOpts...)
+	AssertDecodeNilData[VersionedLocation](t)
+	AssertEncodeEmptyObj[VersionedLocation](t, 0)
+}
+
+func TestVersionedLocation_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testVersionedLocation1, MustHexDecodeString("0x03010100a10f")},
+		{testVersionedLocation2, MustHexDecodeString("0x04010100a10f")},
+	})
+}
+
+func TestVersionedLocation_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x03010100a10f"), testVersionedLocation1},
+		{MustHexDecodeString("0x04010100a10f"), testVersionedLocation2},
+	})
+}
@@ -0,0 +1,137 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+
+// NetworkIDV3 is the NetworkId of XCM v3 and v4, which identifies a global consensus system.
+type NetworkIDV3 struct {
+	IsByGenesis bool
+	Genesis     Hash
+
+	IsByFork        bool
+	ForkBlockNumber U64
+	ForkBlockHash   Hash
+
+	IsPolkadot bool
+
+	IsKusama bool
+
+	IsWestend bool
+
+	IsRococo bool
+
+	IsWococo bool
+
+	IsEthereum      bool
+	EthereumChainID UCompact
+
+	IsBitcoinCore bool
+
+	IsBitcoinCash bool
+
+	IsPolkadotBulletin bool
+}
+
+func (n *NetworkIDV3) Decode(decoder scale.Decoder) error { //nolint:funlen
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		n.IsByGenesis = true
+
+		return decoder.Decode(&n.Genesis)
+	case 1:
+		n.IsByFork = true
+
+		if err := decoder.Decode(&n.ForkBlockNumber); err != nil {
+			return err
+		}
+
+		return decoder.Decode(&n.ForkBlockHash)
+	case 2:
+		n.IsPolkadot = true
+	case 3:
+		n.IsKusama = true
+	case 4:
+		n.IsWestend = true
+	case 5:
+		n.IsRococo = true
+	case 6:
+		n.IsWococo = true
+	case 7:
+		n.IsEthereum = true
+
+		return decoder.Decode(&n.EthereumChainID)
+	case 8:
+		n.IsBitcoinCore = true
+	case 9:
+		n.IsBitcoinCash = true
+	case 10:
+		n.IsPolkadotBulletin = true
+	}
+
+	return nil
+}
+
+func (n NetworkIDV3) Encode(encoder scale.Encoder) error { //nolint:funlen
+	switch {
+	case n.IsByGenesis:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(n.Genesis)
+	case n.IsByFork:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		if err := encoder.Encode(n.ForkBlockNumber); err != nil {
+			return err
+		}
+
+		return encoder.Encode(n.ForkBlockHash)
+	case n.IsPolkadot:
+		return encoder.PushByte(2)
+	case n.IsKusama:
+		return encoder.PushByte(3)
+	case n.IsWestend:
+		return encoder.PushByte(4)
+	case n.IsRococo:
+		return encoder.PushByte(5)
+	case n.IsWococo:
+		return encoder.PushByte(6)
+	case n.IsEthereum:
+		if err := encoder.PushByte(7); err != nil {
+			return err
+		}
+
+		return encoder.Encode(n.EthereumChainID)
+	case n.IsBitcoinCore:
+		return encoder.PushByte(8)
+	case n.IsBitcoinCash:
+		return encoder.PushByte(9)
+	case n.IsPolkadotBulletin:
+		return encoder.PushByte(10)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	fuzz "github.com/google/gofuzz"
+)
+
+var (
+	testNetworkIDV3n1 = NetworkIDV3{
+		IsByGenesis: true,
+		Genesis:     NewHash([]byte{1, 2, 3}),
+	}
+	testNetworkIDV3n2 = NetworkIDV3{
+		IsByFork:        true,
+		ForkBlockNumber: 5,
+		ForkBlockHash:   NewHash([]byte{4}),
+	}
+	testNetworkIDV3n3 = NetworkIDV3{
+		IsPolkadot: true,
+	}
+	testNetworkIDV3n4 = NetworkIDV3{
+		IsEthereum:      true,
+		EthereumChainID: NewUCompactFromUInt(1),
+	}
+	testNetworkIDV3n5 = NetworkIDV3{
+		IsPolkadotBulletin: true,
+	}
+
+	networkIDV3FuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(n *NetworkIDV3, c fuzz.Continue) {
+			switch c.Intn(11) {
+			case 0:
+				n.IsByGenesis = true
+
+				c.Fuzz(&n.Genesis)
+			case 1:
+				n.IsByFork = true
+
+				c.Fuzz(&n.ForkBlockNumber)
+
+				c.Fuzz(&n.ForkBlockHash)
+			case 2:
+				n.IsPolkadot = true
+			case 3:
+				n.IsKusama = true
+			case 4:
+				n.IsWestend = true
+			case 5:
+				n.IsRococo = true
+			case 6:
+				n.IsWococo = true
+			case 7:
+				n.IsEthereum = true
+
+				c.Fuzz(&n.EthereumChainID)
+			case 8:
+				n.IsBitcoinCore = true
+			case 9:
+				n.IsBitcoinCash = true
+			case 10:
+				n.IsPolkadotBulletin = true
+			}
+		}),
+		WithFuzzFuncs(func(o *Option[NetworkIDV3], c fuzz.Continue) {
+			if c.RandBool() {
+				*o = NewEmptyOption[NetworkIDV3]()
+				return
+			}
+
+			var n NetworkIDV3
+
+			c.Fuzz(&n)
+
+			*o = NewOption(n)
+		}),
+	}
+)
+
+func TestNetworkIDV3_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[NetworkIDV3](t, 100, networkIDV3FuzzOpts...)
+	AssertDecodeNilData[NetworkIDV3](t)
+	AssertEncodeEmptyObj[NetworkIDV3](t, 0)
+}
+
+func TestNetworkIDV3_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testNetworkIDV3n1, MustHexDecodeString("0x000102030000000000000000000000000000000000000000000000000000000000")},
+		{testNetworkIDV3n2, MustHexDecodeString("0x0105000000000000000400000000000000000000000000000000000000000000000000000000000000")}, //nolint:lll
+		{testNetworkIDV3n3, MustHexDecodeString("0x02")},
+		{testNetworkIDV3n4, MustHexDecodeString("0x0704")},
+		{testNetworkIDV3n5, MustHexDecodeString("0x0a")},
+	})
+}
+
+func TestNetworkIDV3_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x000102030000000000000000000000000000000000000000000000000000000000"), testNetworkIDV3n1},
+		{MustHexDecodeString("0x0105000000000000000400000000000000000000000000000000000000000000000000000000000000"), testNetworkIDV3n2}, //nolint:lll
+		{MustHexDecodeString("0x02"), testNetworkIDV3n3},
+		{MustHexDecodeString("0x0704"), testNetworkIDV3n4},
+		{MustHexDecodeString("0x0a"), testNetworkIDV3n5},
+	})
+}
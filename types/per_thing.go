@@ -0,0 +1,317 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+)
+
+// rounding is the rounding mode of an arithmetic operation, see sp_arithmetic::Rounding.
+type rounding uint8
+
+const (
+	roundingDown rounding = iota
+	roundingUp
+	// roundingNearestPrefDown rounds to the nearest value, halfway values are rounded down.
+	roundingNearestPrefDown
+	// roundingNearestPrefUp rounds to the nearest value, halfway values are rounded up.
+	roundingNearestPrefUp
+)
+
+const (
+	perbillAccuracy = 1_000_000_000
+	permillAccuracy = 1_000_000
+	percentAccuracy = 100
+)
+
+// Perbill is a fraction in parts per billion, like sp_arithmetic::Perbill. It is encoded as a u32.
+type Perbill uint32
+
+// NewPerbill creates a Perbill from parts per billion, which are capped at one.
+func NewPerbill(parts uint32) Perbill {
+	return Perbill(min(parts, perbillAccuracy))
+}
+
+// NewPerbillFromFloat creates a Perbill from a float between 0 and 1, which is truncated like Perbill::from_float.
+func NewPerbillFromFloat(f float64) Perbill {
+	return Perbill(perThingFromFloat(f, perbillAccuracy))
+}
+
+// NewPerbillFromRational creates the Perbill that is nearest to p / q, halfway values are rounded down like
+// Perbill::from_rational. Fractions above one and a q of zero result in one.
+func NewPerbillFromRational(p, q *big.Int) Perbill {
+	return Perbill(perThingFromRational(p, q, perbillAccuracy))
+}
+
+// Float64 returns the fraction as a float between 0 and 1.
+func (p Perbill) Float64() float64 {
+	return float64(p) / perbillAccuracy
+}
+
+// Mul returns the fraction of the balance, rounded to the nearest value like Perbill * N.
+func (p Perbill) Mul(b U128) U128 {
+	return perThingMul(uint64(p), perbillAccuracy, b, roundingNearestPrefDown)
+}
+
+// MulFloor returns the fraction of the balance, rounded down.
+func (p Perbill) MulFloor(b U128) U128 {
+	return perThingMul(uint64(p), perbillAccuracy, b, roundingDown)
+}
+
+// MulCeil returns the fraction of the balance, rounded up.
+func (p Perbill) MulCeil(b U128) U128 {
+	return perThingMul(uint64(p), perbillAccuracy, b, roundingUp)
+}
+
+// Div returns the balance of which the given balance is the fraction, like Perbill::saturating_reciprocal_mul.
+func (p Perbill) Div(b U128) U128 {
+	return perThingDiv(uint64(p), perbillAccuracy, b)
+}
+
+// String returns the fraction as a percentage, e.g. 12.5%.
+func (p Perbill) String() string {
+	return perThingString(uint64(p), perbillAccuracy)
+}
+
+// MarshalJSON returns the fraction as a percentage string, see String.
+func (p Perbill) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// Permill is a fraction in parts per million, like sp_arithmetic::Permill. It is encoded as a u32.
+type Permill uint32
+
+// NewPermill creates a Permill from parts per million, which are capped at one.
+func NewPermill(parts uint32) Permill {
+	return Permill(min(parts, permillAccuracy))
+}
+
+// NewPermillFromFloat creates a Permill from a float between 0 and 1, which is truncated like Permill::from_float.
+func NewPermillFromFloat(f float64) Permill {
+	return Permill(perThingFromFloat(f, permillAccuracy))
+}
+
+// NewPermillFromRational creates the Permill that is nearest to p / q, halfway values are rounded down like
+// Permill::from_rational. Fractions above one and a q of zero result in one.
+func NewPermillFromRational(p, q *big.Int) Permill {
+	return Permill(perThingFromRational(p, q, permillAccuracy))
+}
+
+// Float64 returns the fraction as a float between 0 and 1.
+func (p Permill) Float64() float64 {
+	return float64(p) / permillAccuracy
+}
+
+// Mul returns the fraction of the balance, rounded to the nearest value like Permill * N.
+func (p Permill) Mul(b U128) U128 {
+	return perThingMul(uint64(p), permillAccuracy, b, roundingNearestPrefDown)
+}
+
+// MulFloor returns the fraction of the balance, rounded down.
+func (p Permill) MulFloor(b U128) U128 {
+	return perThingMul(uint64(p), permillAccuracy, b, roundingDown)
+}
+
+// MulCeil returns the fraction of the balance, rounded up.
+func (p Permill) MulCeil(b U128) U128 {
+	return perThingMul(uint64(p), permillAccuracy, b, roundingUp)
+}
+
+// Div returns the balance of which the given balance is the fraction, like Permill::saturating_reciprocal_mul.
+func (p Permill) Div(b U128) U128 {
+	return perThingDiv(uint64(p), permillAccuracy, b)
+}
+
+// String returns the fraction as a percentage, e.g. 12.5%.
+func (p Permill) String() string {
+	return perThingString(uint64(p), permillAccuracy)
+}
+
+// MarshalJSON returns the fraction as a percentage string, see String.
+func (p Permill) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// Percent is a fraction in percent, like sp_arithmetic::Percent. It is encoded as a u8.
+type Percent uint8
+
+// NewPercent creates a Percent, which is capped at 100.
+func NewPercent(percent uint8) Percent {
+	return Percent(min(percent, percentAccuracy))
+}
+
+// NewPercentFromFloat creates a Percent from a float between 0 and 1, which is truncated like Percent::from_float.
+func NewPercentFromFloat(f float64) Percent {
+	return Percent(perThingFromFloat(f, percentAccuracy))
+}
+
+// NewPercentFromRational creates the Percent that is nearest to p / q, halfway values are rounded down like
+// Percent::from_rational. Fractions above one and a q of zero result in one.
+func NewPercentFromRational(p, q *big.Int) Percent {
+	return Percent(perThingFromRational(p, q, percentAccuracy))
+}
+
+// Float64 returns the fraction as a float between 0 and 1.
+func (p Percent) Float64() float64 {
+	return float64(p) / percentAccuracy
+}
+
+// Mul returns the fraction of the balance, rounded to the nearest value like Percent * N.
+func (p Percent) Mul(b U128) U128 {
+	return perThingMul(uint64(p), percentAccuracy, b, roundingNearestPrefDown)
+}
+
+// MulFloor returns the fraction of the balance, rounded down.
+func (p Percent) MulFloor(b U128) U128 {
+	return perThingMul(uint64(p), percentAccuracy, b, roundingDown)
+}
+
+// MulCeil returns the fraction of the balance, rounded up.
+func (p Percent) MulCeil(b U128) U128 {
+	return perThingMul(uint64(p), percentAccuracy, b, roundingUp)
+}
+
+// Div returns the balance of which the given balance is the fraction, like Percent::saturating_reciprocal_mul.
+func (p Percent) Div(b U128) U128 {
+	return perThingDiv(uint64(p), percentAccuracy, b)
+}
+
+// String returns the fraction as a percentage, e.g. 12%.
+func (p Percent) String() string {
+	return perThingString(uint64(p), percentAccuracy)
+}
+
+// MarshalJSON returns the fraction as a percentage string, see String.
+func (p Percent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func perThingFromFloat(f float64, accuracy uint64) uint64 {
+	// Negative values and NaN result in zero.
+	if !(f > 0) {
+		return 0
+	}
+
+	return uint64(min(f, 1) * float64(accuracy))
+}
+
+func perThingFromRational(p, q *big.Int, accuracy uint64) uint64 {
+	if p.Sign() <= 0 {
+		return 0
+	}
+
+	if q.Sign() <= 0 || p.Cmp(q) >= 0 {
+		return accuracy
+	}
+
+	return mulDiv(p, new(big.Int).SetUint64(accuracy), q, roundingNearestPrefDown).Uint64()
+}
+
+func perThingMul(parts, accuracy uint64, b U128, r rounding) U128 {
+	return NewU128(*mulDiv(u128Int(b), new(big.Int).SetUint64(parts), new(big.Int).SetUint64(accuracy), r))
+}
+
+func perThingDiv(parts, accuracy uint64, b U128) U128 {
+	if parts == 0 {
+		return NewU128(*new(big.Int).Set(maxU128))
+	}
+
+	res := mulDiv(u128Int(b), new(big.Int).SetUint64(accuracy), new(big.Int).SetUint64(parts), roundingNearestPrefUp)
+
+	return saturatingU128(res)
+}
+
+func perThingString(parts, accuracy uint64) string {
+	// The percentage has two decimals less than the accuracy.
+	decimals := len(new(big.Int).SetUint64(accuracy).String()) - 3
+
+	return formatDecimal(new(big.Int).SetUint64(parts), decimals) + "%"
+}
+
+// mulDiv returns x * n / d with the given rounding, x, n and d have to be non-negative.
+func mulDiv(x, n, d *big.Int, r rounding) *big.Int {
+	q, rem := new(big.Int).QuoRem(new(big.Int).Mul(x, n), d, new(big.Int))
+
+	if rem.Sign() == 0 {
+		return q
+	}
+
+	roundUp := false
+
+	switch r {
+	case roundingUp:
+		roundUp = true
+	case roundingNearestPrefDown:
+		roundUp = new(big.Int).Lsh(rem, 1).Cmp(d) > 0
+	case roundingNearestPrefUp:
+		roundUp = new(big.Int).Lsh(rem, 1).Cmp(d) >= 0
+	}
+
+	if roundUp {
+		q.Add(q, big.NewInt(1))
+	}
+
+	return q
+}
+
+func u128Int(b U128) *big.Int {
+	if b.Int == nil {
+		return new(big.Int)
+	}
+
+	return b.Int
+}
+
+// saturatingU128 returns the U128 of i, which is capped between zero and the max U128.
+func saturatingU128(i *big.Int) U128 {
+	switch {
+	case i.Sign() < 0:
+		return NewU128(*new(big.Int))
+	case i.Cmp(maxU128) > 0:
+		return NewU128(*new(big.Int).Set(maxU128))
+	default:
+		return NewU128(*i)
+	}
+}
+
+// formatDecimal formats i divided by 10^decimals, without trailing zeros.
+func formatDecimal(i *big.Int, decimals int) string {
+	sign := ""
+	if i.Sign() < 0 {
+		sign = "-"
+	}
+
+	digits := new(big.Int).Abs(i).String()
+
+	if decimals <= 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	integer, fraction := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+
+	if fraction == "" {
+		return sign + integer
+	}
+
+	return sign + integer + "." + fraction
+}
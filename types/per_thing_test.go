@@ -0,0 +1,115 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerThing_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[Perbill](t, 100)
+	AssertRoundTripFuzz[Permill](t, 100)
+	AssertRoundTripFuzz[Percent](t, 100)
+	AssertEncodeEmptyObj[Perbill](t, 4)
+	AssertEncodeEmptyObj[Permill](t, 4)
+	AssertEncodeEmptyObj[Percent](t, 1)
+}
+
+func TestPerThing_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{NewPerbill(500_000_000), MustHexDecodeString("0x0065cd1d")},
+		{NewPermill(1_000_000), MustHexDecodeString("0x40420f00")},
+		{NewPercent(5), MustHexDecodeString("0x05")},
+	})
+}
+
+func TestPerThing_New(t *testing.T) {
+	assert.Equal(t, Perbill(1_000_000_000), NewPerbill(2_000_000_000))
+	assert.Equal(t, Permill(1_000_000), NewPermill(2_000_000))
+	assert.Equal(t, Percent(100), NewPercent(101))
+
+	// Floats are truncated.
+	assert.Equal(t, Perbill(100_000_000), NewPerbillFromFloat(0.1))
+	assert.Equal(t, Percent(28), NewPercentFromFloat(0.29))
+	assert.Equal(t, Permill(1_000_000), NewPermillFromFloat(1.5))
+	assert.Equal(t, Permill(0), NewPermillFromFloat(-0.5))
+
+	// Rationals are rounded to the nearest value, halfway values down.
+	assert.Equal(t, Percent(33), NewPercentFromRational(big.NewInt(1), big.NewInt(3)))
+	assert.Equal(t, Percent(67), NewPercentFromRational(big.NewInt(2), big.NewInt(3)))
+	assert.Equal(t, Percent(0), NewPercentFromRational(big.NewInt(1), big.NewInt(200)))
+	assert.Equal(t, Percent(1), NewPercentFromRational(big.NewInt(3), big.NewInt(200)))
+	assert.Equal(t, Perbill(333_333_333), NewPerbillFromRational(big.NewInt(1), big.NewInt(3)))
+	assert.Equal(t, Perbill(1_000_000_000), NewPerbillFromRational(big.NewInt(4), big.NewInt(3)))
+	assert.Equal(t, Permill(1_000_000), NewPermillFromRational(big.NewInt(1), big.NewInt(0)))
+}
+
+func TestPerThing_Mul(t *testing.T) {
+	balance := NewU128(*big.NewInt(1_000))
+
+	// The commission of a validator.
+	commission := NewPerbill(15_600_000)
+	assert.Equal(t, "16", commission.Mul(balance).String())
+	assert.Equal(t, "15", commission.MulFloor(balance).String())
+	assert.Equal(t, "16", commission.MulCeil(balance).String())
+
+	// Halfway values are rounded down.
+	assert.Equal(t, "0", NewPercent(50).Mul(NewU128(*big.NewInt(1))).String())
+	assert.Equal(t, "2", NewPermill(500_000).Mul(NewU128(*big.NewInt(5))).String())
+
+	// Large balances do not overflow.
+	large, _ := new(big.Int).SetString("300000000000000000000000000000000000000", 10)
+	expected, _ := new(big.Int).SetString("150000000000000000000000000000000000000", 10)
+	assert.Equal(t, NewU128(*expected), NewPerbill(500_000_000).Mul(NewU128(*large)))
+}
+
+func TestPerThing_Div(t *testing.T) {
+	assert.Equal(t, "2000", NewPercent(50).Div(NewU128(*big.NewInt(1_000))).String())
+	assert.Equal(t, "3000003", NewPermill(333_333).Div(NewU128(*big.NewInt(1_000_000))).String())
+
+	maxU128, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	assert.Equal(t, NewU128(*maxU128), NewPerbill(0).Div(NewU128(*big.NewInt(1))))
+	assert.Equal(t, NewU128(*maxU128), NewPercent(50).Div(NewU128(*maxU128)))
+}
+
+func TestPerThing_String(t *testing.T) {
+	AssertString(t, []StringAssert{
+		{NewPerbill(123_456_789), "12.3456789%"},
+		{NewPerbill(1_000_000_000), "100%"},
+		{NewPerbill(1), "0.0000001%"},
+		{NewPermill(125_000), "12.5%"},
+		{NewPermill(0), "0%"},
+		{NewPercent(5), "5%"},
+	})
+
+	b, err := json.Marshal(NewPermill(125_000))
+	assert.NoError(t, err)
+	assert.Equal(t, `"12.5%"`, string(b))
+}
+
+func TestPerThing_Float64(t *testing.T) {
+	assert.Equal(t, 0.125, NewPerbill(125_000_000).Float64())
+	assert.Equal(t, 0.125, NewPermill(125_000).Float64())
+	assert.Equal(t, 0.05, NewPercent(5).Float64())
+}
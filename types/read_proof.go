@@ -0,0 +1,71 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// ReadProof contains the storage proof returned by state_getReadProof
+type ReadProof struct {
+	// At is the hash of the block the proof was created at
+	At Hash
+	// Proof holds the encoded trie nodes needed to prove the requested keys
+	Proof []Bytes
+}
+
+// UnmarshalJSON fills r with the JSON encoded byte array given by b
+func (r *ReadProof) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		At    Hash     `json:"at"`
+		Proof []string `json:"proof"`
+	}
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	r.At = tmp.At
+	r.Proof = make([]Bytes, len(tmp.Proof))
+
+	for i, node := range tmp.Proof {
+		bz, err := codec.HexDecodeString(node)
+		if err != nil {
+			return err
+		}
+		r.Proof[i] = bz
+	}
+
+	return nil
+}
+
+// MarshalJSON returns a JSON encoded byte array of r
+func (r ReadProof) MarshalJSON() ([]byte, error) {
+	proof := make([]string, len(r.Proof))
+	for i, node := range r.Proof {
+		proof[i] = codec.HexEncodeToString(node)
+	}
+
+	return json.Marshal(struct {
+		At    Hash     `json:"at"`
+		Proof []string `json:"proof"`
+	}{
+		At:    r.At,
+		Proof: proof,
+	})
+}
@@ -0,0 +1,165 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Well-known JSON-RPC error codes
+const (
+	RPCErrorCodeInvalidRequest = -32600
+	RPCErrorCodeMethodNotFound = -32601
+	RPCErrorCodeInvalidParams  = -32602
+	RPCErrorCodeInternal       = -32603
+
+	// RPCErrorCodeStateClient is returned by the state module if the client fails to handle the request, e.g. because
+	// the requested block is unknown.
+	RPCErrorCodeStateClient = 4003
+)
+
+// RPCError is an error that was returned by the node. It can be retrieved from the errors returned by the RPC
+// modules with errors.As.
+type RPCError struct {
+	Code    int
+	Message string
+	Data    interface{}
+}
+
+func (e RPCError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = fmt.Sprintf("json-rpc error %d", e.Code)
+	}
+
+	if e.Data == nil {
+		return msg
+	}
+
+	return fmt.Sprintf("%s: %v", msg, e.Data)
+}
+
+// ErrorCode returns the JSON-RPC error code
+func (e RPCError) ErrorCode() int {
+	return e.Code
+}
+
+// ErrorData returns the additional data of the error, if any
+func (e RPCError) ErrorData() interface{} {
+	return e.Data
+}
+
+// TransactionPoolError returns the kind of transaction pool error, if the error is one.
+func (e RPCError) TransactionPoolError() (TransactionPoolError, bool) {
+	kind := TransactionPoolError(e.Code)
+
+	if kind < TransactionPoolErrorInvalidTransaction || kind > TransactionPoolErrorImmediatelyDropped {
+		return 0, false
+	}
+
+	return kind, true
+}
+
+// TransactionPoolError is the kind of error that is returned by the transaction pool when submitting an extrinsic,
+// signaled by the custom error codes 1010 to 1016.
+type TransactionPoolError int
+
+const (
+	// TransactionPoolErrorInvalidTransaction signals that the extrinsic is invalid, e.g. because of a bad signature or
+	// an account that cannot pay the fees. Details are provided via RPCError.Data.
+	TransactionPoolErrorInvalidTransaction TransactionPoolError = 1010
+	// TransactionPoolErrorUnknownValidity signals that the validity of the extrinsic could not be determined.
+	TransactionPoolErrorUnknownValidity TransactionPoolError = 1011
+	// TransactionPoolErrorTemporarilyBanned signals that the extrinsic is temporarily banned.
+	TransactionPoolErrorTemporarilyBanned TransactionPoolError = 1012
+	// TransactionPoolErrorAlreadyImported signals that the extrinsic is already in the pool.
+	TransactionPoolErrorAlreadyImported TransactionPoolError = 1013
+	// TransactionPoolErrorTooLowPriority signals that the extrinsic cannot replace an existing one with the same tags.
+	TransactionPoolErrorTooLowPriority TransactionPoolError = 1014
+	// TransactionPoolErrorCycleDetected signals that the dependencies of the extrinsic form a cycle.
+	TransactionPoolErrorCycleDetected TransactionPoolError = 1015
+	// TransactionPoolErrorImmediatelyDropped signals that the extrinsic was dropped right away because the pool is
+	// full.
+	TransactionPoolErrorImmediatelyDropped TransactionPoolError = 1016
+)
+
+func (k TransactionPoolError) String() string {
+	switch k {
+	case TransactionPoolErrorInvalidTransaction:
+		return "InvalidTransaction"
+	case TransactionPoolErrorUnknownValidity:
+		return "UnknownValidity"
+	case TransactionPoolErrorTemporarilyBanned:
+		return "TemporarilyBanned"
+	case TransactionPoolErrorAlreadyImported:
+		return "AlreadyImported"
+	case TransactionPoolErrorTooLowPriority:
+		return "TooLowPriority"
+	case TransactionPoolErrorCycleDetected:
+		return "CycleDetected"
+	case TransactionPoolErrorImmediatelyDropped:
+		return "ImmediatelyDropped"
+	default:
+		return fmt.Sprintf("TransactionPoolError(%d)", int(k))
+	}
+}
+
+// AsRPCError returns the RPCError in the chain of err, if any.
+func AsRPCError(err error) (RPCError, bool) {
+	var rpcErr RPCError
+
+	ok := errors.As(err, &rpcErr)
+
+	return rpcErr, ok
+}
+
+// IsTransactionPoolError returns true if err was returned by the node because the transaction pool rejected an
+// extrinsic with the provided kind of error.
+func IsTransactionPoolError(err error, kind TransactionPoolError) bool {
+	rpcErr, ok := AsRPCError(err)
+
+	return ok && rpcErr.Code == int(kind)
+}
+
+// IsInvalidTransaction returns true if err was returned by the node because an extrinsic is invalid.
+func IsInvalidTransaction(err error) bool {
+	return IsTransactionPoolError(err, TransactionPoolErrorInvalidTransaction)
+}
+
+// IsMethodNotFound returns true if err was returned by the node because the RPC method is not available.
+func IsMethodNotFound(err error) bool {
+	rpcErr, ok := AsRPCError(err)
+
+	return ok && rpcErr.Code == RPCErrorCodeMethodNotFound
+}
+
+// IsBlockUnknown returns true if err was returned by the node because the requested block is unknown, or its state
+// was already pruned.
+func IsBlockUnknown(err error) bool {
+	rpcErr, ok := AsRPCError(err)
+	if !ok {
+		return false
+	}
+
+	msg := strings.ToLower(rpcErr.Error())
+
+	return strings.Contains(msg, "unknownblock") ||
+		strings.Contains(msg, "unknown block") ||
+		strings.Contains(msg, "state already discarded")
+}
@@ -0,0 +1,82 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCError_Error(t *testing.T) {
+	assert.Equal(t, "Method not found", RPCError{Code: -32601, Message: "Method not found"}.Error())
+	assert.Equal(t, "json-rpc error 1010", RPCError{Code: 1010}.Error())
+	assert.Equal(
+		t,
+		"Invalid Transaction: Transaction has a bad signature",
+		RPCError{Code: 1010, Message: "Invalid Transaction", Data: "Transaction has a bad signature"}.Error(),
+	)
+}
+
+func TestRPCError_TransactionPoolError(t *testing.T) {
+	kind, ok := RPCError{Code: 1013}.TransactionPoolError()
+	assert.True(t, ok)
+	assert.Equal(t, TransactionPoolErrorAlreadyImported, kind)
+	assert.Equal(t, "AlreadyImported", kind.String())
+
+	_, ok = RPCError{Code: 1017}.TransactionPoolError()
+	assert.False(t, ok)
+
+	_, ok = RPCError{Code: -32601}.TransactionPoolError()
+	assert.False(t, ok)
+
+	assert.Equal(t, "TransactionPoolError(1017)", TransactionPoolError(1017).String())
+}
+
+func TestRPCError_Helpers(t *testing.T) {
+	invalidTx := fmt.Errorf("submit: %w", RPCError{Code: 1010, Message: "Invalid Transaction"})
+
+	assert.True(t, IsInvalidTransaction(invalidTx))
+	assert.True(t, IsTransactionPoolError(invalidTx, TransactionPoolErrorInvalidTransaction))
+	assert.False(t, IsTransactionPoolError(invalidTx, TransactionPoolErrorTemporarilyBanned))
+	assert.False(t, IsMethodNotFound(invalidTx))
+	assert.False(t, IsBlockUnknown(invalidTx))
+
+	rpcErr, ok := AsRPCError(invalidTx)
+	assert.True(t, ok)
+	assert.Equal(t, 1010, rpcErr.ErrorCode())
+
+	assert.True(t, IsMethodNotFound(RPCError{Code: RPCErrorCodeMethodNotFound}))
+
+	assert.True(t, IsBlockUnknown(RPCError{
+		Code:    RPCErrorCodeStateClient,
+		Message: "Client error: UnknownBlock: State already discarded for 0x01",
+	}))
+	assert.True(t, IsBlockUnknown(RPCError{Code: RPCErrorCodeInternal, Data: "Unknown block"}))
+
+	plain := errors.New("Invalid Transaction")
+
+	assert.False(t, IsInvalidTransaction(plain))
+	assert.False(t, IsMethodNotFound(plain))
+	assert.False(t, IsBlockUnknown(plain))
+
+	_, ok = AsRPCError(plain)
+	assert.False(t, ok)
+}
@@ -0,0 +1,36 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCMethods_Has(t *testing.T) {
+	var methods RPCMethods
+
+	err := json.Unmarshal([]byte(`{"version":1,"methods":["chain_getBlock","rpc_methods"]}`), &methods)
+	assert.NoError(t, err)
+
+	assert.Equal(t, RPCMethods{Version: 1, Methods: []string{"chain_getBlock", "rpc_methods"}}, methods)
+	assert.True(t, methods.Has("chain_getBlock"))
+	assert.False(t, methods.Has("grandpa_roundState"))
+}
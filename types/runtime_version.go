@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"golang.org/x/crypto/blake2b"
 )
 
 type RuntimeVersion struct {
@@ -37,6 +38,23 @@ func NewRuntimeVersion() *RuntimeVersion {
 	return &RuntimeVersion{APIs: make([]RuntimeVersionAPI, 0)}
 }
 
+// APIVersion returns the version of the runtime API with the given name, e.g. "Core", if it is provided by the
+// runtime.
+func (r RuntimeVersion) APIVersion(name string) (U32, bool) {
+	id, err := NewRuntimeAPIID(name)
+	if err != nil {
+		return 0, false
+	}
+
+	for _, api := range r.APIs {
+		if api.APIID == id {
+			return api.Version, true
+		}
+	}
+
+	return 0, false
+}
+
 func (r *RuntimeVersion) Decode(decoder scale.Decoder) error {
 	err := decoder.Decode(&r.APIs)
 	if err != nil {
@@ -105,6 +123,21 @@ func (r RuntimeVersion) Encode(encoder scale.Encoder) error {
 	return nil
 }
 
+// NewRuntimeAPIID returns the ID that the runtime API with the given name is listed with in the runtime version,
+// which is the hex encoded blake2b-64 hash of the name.
+func NewRuntimeAPIID(name string) (string, error) {
+	h, err := blake2b.New(8, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := h.Write([]byte(name)); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%#x", h.Sum(nil)), nil
+}
+
 type RuntimeVersionAPI struct {
 	APIID   string
 	Version U32
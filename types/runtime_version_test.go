@@ -65,3 +65,22 @@ func TestRuntimeVersionAPI_JSONMarshalUnmarshal(t *testing.T) {
 	r := exampleRuntimeVersionAPI
 	AssertJSONRoundTrip(t, &r)
 }
+
+func TestNewRuntimeAPIID(t *testing.T) {
+	id, err := NewRuntimeAPIID("Core")
+	assert.NoError(t, err)
+	assert.Equal(t, "0xdf6acb689907609b", id)
+
+	id, err = NewRuntimeAPIID("Metadata")
+	assert.NoError(t, err)
+	assert.Equal(t, exampleRuntimeVersionAPI.APIID, id)
+}
+
+func TestRuntimeVersion_APIVersion(t *testing.T) {
+	version, ok := exampleRuntimeVersion.APIVersion("Metadata")
+	assert.True(t, ok)
+	assert.Equal(t, U32(23), version)
+
+	_, ok = exampleRuntimeVersion.APIVersion("Core")
+	assert.False(t, ok)
+}
@@ -16,6 +16,14 @@
 
 package types
 
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
 type SignedBlock struct {
 	Block         Block         `json:"block"`
 	Justification Justification `json:"justification"`
@@ -26,3 +34,94 @@ type Block struct {
 	Header     Header
 	Extrinsics []Extrinsic
 }
+
+// SignedBlockWithJustifications is a block together with the justifications of the consensus engines that finalized
+// it, as returned by chain_getBlock
+type SignedBlockWithJustifications struct {
+	Block Block `json:"block"`
+	// Justifications is nil if the block has no justifications, which is the case for most blocks since nodes only
+	// keep the justifications of blocks that end an authority set or that were explicitly requested.
+	Justifications Justifications `json:"justifications"`
+}
+
+// Justifications holds the justifications of a block by different consensus engines
+type Justifications []EngineJustification
+
+// Get returns the justification of the consensus engine
+func (j Justifications) Get(engineID ConsensusEngineID) (Justification, bool) {
+	for _, justification := range j {
+		if justification.ConsensusEngineID == engineID {
+			return justification.Justification, true
+		}
+	}
+
+	return nil, false
+}
+
+// GrandpaJustification returns the decoded GRANDPA justification, false is returned if there is none
+func (j Justifications) GrandpaJustification() (*GrandpaJustification, bool, error) {
+	justification, ok := j.Get(GrandpaEngineID)
+	if !ok {
+		return nil, false, nil
+	}
+
+	decoded, err := GrandpaEncodedJustification(justification).Decode()
+	if err != nil {
+		return nil, true, err
+	}
+
+	return &decoded, true, nil
+}
+
+// EngineJustification is the justification of a block by a consensus engine, e.g. the encoded GrandpaJustification
+// for GrandpaEngineID
+type EngineJustification struct {
+	ConsensusEngineID ConsensusEngineID
+	Justification     Justification
+}
+
+// UnmarshalJSON fills j with the JSON encoded [engine id, justification] pair given by b. Both are usually encoded as
+// arrays of bytes, hex strings are accepted as well.
+func (j *EngineJustification) UnmarshalJSON(b []byte) error {
+	var pair []json.RawMessage
+	if err := json.Unmarshal(b, &pair); err != nil {
+		return err
+	}
+
+	if len(pair) != 2 {
+		return fmt.Errorf("expected [engine id, justification] pair, got %d elements", len(pair))
+	}
+
+	engineID, err := unmarshalJSONBytes(pair[0])
+	if err != nil {
+		return fmt.Errorf("engine id: %w", err)
+	}
+
+	if len(engineID) != 4 {
+		return fmt.Errorf("engine id: expected 4 bytes, got %d", len(engineID))
+	}
+
+	justification, err := unmarshalJSONBytes(pair[1])
+	if err != nil {
+		return fmt.Errorf("justification: %w", err)
+	}
+
+	j.ConsensusEngineID = ConsensusEngineID(binary.LittleEndian.Uint32(engineID))
+	j.Justification = justification
+
+	return nil
+}
+
+// unmarshalJSONBytes decodes bytes that are either encoded as a JSON array of numbers or as a hex string.
+func unmarshalJSONBytes(b []byte) ([]byte, error) {
+	var hexString string
+	if err := json.Unmarshal(b, &hexString); err == nil {
+		return codec.HexDecodeString(hexString)
+	}
+
+	var res []byte
+
+	err := json.Unmarshal(b, &res)
+
+	return res, err
+}
@@ -0,0 +1,111 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedBlockWithJustifications_UnmarshalJSON(t *testing.T) {
+	justification, err := Encode(testGrandpaJustification)
+	require.NoError(t, err)
+
+	justificationBytes := make([]string, len(justification))
+	for i, b := range justification {
+		justificationBytes[i] = fmt.Sprint(b)
+	}
+
+	// Nodes encode the engine id and the justification as arrays of bytes.
+	block := fmt.Sprintf(`{
+		"block": {
+			"header": {
+				"parentHash": "0x0101010101010101010101010101010101010101010101010101010101010101",
+				"number": "0x2a",
+				"stateRoot": "0x0202020202020202020202020202020202020202020202020202020202020202",
+				"extrinsicsRoot": "0x0303030303030303030303030303030303030303030303030303030303030303",
+				"digest": {"logs": []}
+			},
+			"extrinsics": []
+		},
+		"justifications": [[[66, 69, 69, 70], "0x0102"], [[70, 82, 78, 75], [%s]]]
+	}`, strings.Join(justificationBytes, ","))
+
+	var res SignedBlockWithJustifications
+	require.NoError(t, json.Unmarshal([]byte(block), &res))
+
+	assert.Equal(t, BlockNumber(42), res.Block.Header.Number)
+	assert.Equal(t, Justifications{
+		{ConsensusEngineID: BeefyEngineID, Justification: Justification{1, 2}},
+		{ConsensusEngineID: GrandpaEngineID, Justification: justification},
+	}, res.Justifications)
+
+	beefy, ok := res.Justifications.Get(BeefyEngineID)
+	assert.True(t, ok)
+	assert.Equal(t, Justification{1, 2}, beefy)
+
+	grandpa, ok, err := res.Justifications.GrandpaJustification()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, &testGrandpaJustification, grandpa)
+}
+
+func TestSignedBlockWithJustifications_UnmarshalJSON_WithoutJustifications(t *testing.T) {
+	var res SignedBlockWithJustifications
+	require.NoError(t, json.Unmarshal([]byte(`{"block": {"extrinsics": []}, "justifications": null}`), &res))
+
+	assert.Nil(t, res.Justifications)
+
+	_, ok := res.Justifications.Get(GrandpaEngineID)
+	assert.False(t, ok)
+
+	grandpa, ok, err := res.Justifications.GrandpaJustification()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, grandpa)
+}
+
+func TestEngineJustification_UnmarshalJSON_Invalid(t *testing.T) {
+	for _, input := range []string{
+		`[[70, 82, 78, 75]]`,
+		`[[70, 82, 78], "0x01"]`,
+		`["0xzz", "0x01"]`,
+		`[[70, 82, 78, 75], {}]`,
+	} {
+		var j EngineJustification
+		assert.Error(t, json.Unmarshal([]byte(input), &j), input)
+	}
+}
+
+func TestEngineJustification_EncodeDecode(t *testing.T) {
+	j := EngineJustification{ConsensusEngineID: GrandpaEngineID, Justification: Justification{1, 2}}
+
+	b, err := Encode(j)
+	assert.NoError(t, err)
+	assert.Equal(t, MustHexDecodeString("0x46524e4b080102"), b)
+
+	var decoded EngineJustification
+	assert.NoError(t, Decode(b, &decoded))
+	assert.Equal(t, j, decoded)
+}
@@ -0,0 +1,452 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// Names of the signed extensions for which default values are derived from the SignatureOptions.
+const (
+	CheckNonZeroSenderExtension       = "CheckNonZeroSender"
+	CheckSpecVersionExtension         = "CheckSpecVersion"
+	CheckTxVersionExtension           = "CheckTxVersion"
+	CheckGenesisExtension             = "CheckGenesis"
+	CheckMortalityExtension           = "CheckMortality"
+	CheckEraExtension                 = "CheckEra"
+	CheckNonceExtension               = "CheckNonce"
+	CheckWeightExtension              = "CheckWeight"
+	ChargeTransactionPaymentExtension = "ChargeTransactionPayment"
+	ChargeAssetTxPaymentExtension     = "ChargeAssetTxPayment"
+)
+
+var (
+	ErrSignedExtensionValueMissing = errors.New("signed extension value missing")
+	ErrSignedExtensionTypeNotFound = errors.New("signed extension type not found")
+	ErrSignedExtensionTypeInvalid  = errors.New("signed extension type cannot be decoded")
+)
+
+// SignedExtensionValue holds the values of a signed extension. Extra is included in the extrinsic, AdditionalSigned
+// is only signed. Nil values are not encoded, which is the case for signed extensions with empty types.
+type SignedExtensionValue struct {
+	Extra            interface{}
+	AdditionalSigned interface{}
+}
+
+// SignedExtensionValues maps the names of signed extensions to their values.
+type SignedExtensionValues map[string]SignedExtensionValue
+
+// ChargeAssetTxPayment is the extra of the ChargeAssetTxPayment signed extension. The type of the asset ID depends on
+// the runtime, so it has to be encoded already. The fee is paid in the native token if no asset ID is set.
+type ChargeAssetTxPayment struct {
+	Tip     UCompact
+	AssetID Option[BytesBare]
+}
+
+// SignedExtensionPayload holds the encoded extra and additional signed data of all signed extensions of a runtime,
+// in the order declared by its metadata.
+type SignedExtensionPayload struct {
+	Extra            []byte
+	AdditionalSigned []byte
+}
+
+// NewSignedExtensionPayload encodes the signed extensions declared by the metadata. The values of the signed
+// extensions are taken from values if present, otherwise they default to the ones from the SignatureOptions for the
+// well-known signed extensions. Unknown signed extensions with empty types are skipped, for all other ones an error
+// naming the signed extension is returned. Only metadata V14 and later declares the types of signed extensions.
+func NewSignedExtensionPayload(
+	meta *Metadata,
+	o SignatureOptions,
+	values SignedExtensionValues,
+) (SignedExtensionPayload, error) {
+	if meta.Version < 14 {
+		return SignedExtensionPayload{}, fmt.Errorf("signed extension types are not available in metadata V%d",
+			meta.Version)
+	}
+
+	var payload SignedExtensionPayload
+
+	for _, signedExtension := range meta.AsMetadataV14.Extrinsic.SignedExtensions {
+		name := string(signedExtension.Identifier)
+
+		value, ok := values[name]
+		if !ok {
+			value, ok = defaultSignedExtensionValue(name, o)
+		}
+
+		if !ok {
+			empty, err := meta.AsMetadataV14.isEmptyType(signedExtension.Type)
+			if err != nil {
+				return SignedExtensionPayload{}, fmt.Errorf("signed extension %s: %w", name, err)
+			}
+
+			emptyAdditional, err := meta.AsMetadataV14.isEmptyType(signedExtension.AdditionalSigned)
+			if err != nil {
+				return SignedExtensionPayload{}, fmt.Errorf("signed extension %s: %w", name, err)
+			}
+
+			if !empty || !emptyAdditional {
+				return SignedExtensionPayload{}, fmt.Errorf("%w: %s", ErrSignedExtensionValueMissing, name)
+			}
+
+			continue
+		}
+
+		if value.Extra != nil {
+			b, err := codec.Encode(value.Extra)
+			if err != nil {
+				return SignedExtensionPayload{}, fmt.Errorf("encoding extra of signed extension %s: %w", name, err)
+			}
+
+			payload.Extra = append(payload.Extra, b...)
+		}
+
+		if value.AdditionalSigned != nil {
+			b, err := codec.Encode(value.AdditionalSigned)
+			if err != nil {
+				return SignedExtensionPayload{}, fmt.Errorf("encoding additional signed of signed extension %s: %w",
+					name, err)
+			}
+
+			payload.AdditionalSigned = append(payload.AdditionalSigned, b...)
+		}
+	}
+
+	return payload, nil
+}
+
+// defaultSignedExtensionValue returns the value of the well-known signed extension with the given name.
+func defaultSignedExtensionValue(name string, o SignatureOptions) (SignedExtensionValue, bool) {
+	switch name {
+	case CheckNonZeroSenderExtension, CheckWeightExtension:
+		return SignedExtensionValue{}, true
+	case CheckSpecVersionExtension:
+		return SignedExtensionValue{AdditionalSigned: o.SpecVersion}, true
+	case CheckTxVersionExtension:
+		return SignedExtensionValue{AdditionalSigned: o.TransactionVersion}, true
+	case CheckGenesisExtension:
+		return SignedExtensionValue{AdditionalSigned: o.GenesisHash}, true
+	case CheckMortalityExtension, CheckEraExtension:
+		return SignedExtensionValue{Extra: o.era(), AdditionalSigned: o.BlockHash}, true
+	case CheckNonceExtension:
+		return SignedExtensionValue{Extra: o.Nonce}, true
+	case ChargeTransactionPaymentExtension:
+		return SignedExtensionValue{Extra: o.Tip}, true
+	case ChargeAssetTxPaymentExtension:
+		return SignedExtensionValue{Extra: ChargeAssetTxPayment{Tip: o.Tip}}, true
+	case CheckMetadataHashExtension:
+		mode := o.metadataHashMode()
+
+		metadataHash := NewEmptyOption[Hash]()
+		if mode == MetadataHashEnabled {
+			metadataHash = NewOption(o.MetadataHash)
+		}
+
+		return SignedExtensionValue{Extra: mode, AdditionalSigned: metadataHash}, true
+	}
+
+	return SignedExtensionValue{}, false
+}
+
+// era returns the era of the options, which is immortal unless a mortal era is set.
+func (o SignatureOptions) era() ExtrinsicEra {
+	if !o.Era.IsMortalEra {
+		return ExtrinsicEra{IsImmortalEra: true}
+	}
+
+	return o.Era
+}
+
+// metadataHashMode returns the metadata hash mode of the options, which is disabled unless set.
+func (o SignatureOptions) metadataHashMode() MetadataHashMode {
+	if ok, mode := o.MetadataHashMode.Unwrap(); ok {
+		return mode
+	}
+
+	return MetadataHashDisabled
+}
+
+// isEmptyType returns true if the type with the given ID is encoded without any bytes, e.g. the empty tuple or a
+// struct without fields.
+func (m *MetadataV14) isEmptyType(id Si1LookupTypeID) (bool, error) {
+	typ, ok := m.EfficientLookup[id.Int64()]
+	if !ok {
+		return false, fmt.Errorf("%w: %d", ErrSignedExtensionTypeNotFound, id.Int64())
+	}
+
+	switch {
+	case typ.Def.IsComposite:
+		for _, field := range typ.Def.Composite.Fields {
+			empty, err := m.isEmptyType(field.Type)
+			if err != nil || !empty {
+				return false, err
+			}
+		}
+
+		return true, nil
+	case typ.Def.IsTuple:
+		for _, elem := range typ.Def.Tuple {
+			empty, err := m.isEmptyType(elem)
+			if err != nil || !empty {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// signedExtensionsExtra is the extra of the signed extensions of an extrinsic, decoded as declared by the metadata.
+type signedExtensionsExtra struct {
+	// raw is the encoded extra of all signed extensions
+	raw              []byte
+	era              ExtrinsicEra
+	nonce            UCompact
+	tip              UCompact
+	metadataHashMode Option[MetadataHashMode]
+}
+
+// decodeSignedExtensionsExtra decodes the extra of the signed extensions declared by the metadata in their declared
+// order. The values of the well-known signed extensions are decoded as well, the era is immortal unless the runtime
+// declares a mortality extension.
+func decodeSignedExtensionsExtra(decoder scale.Decoder, meta *Metadata) (signedExtensionsExtra, error) {
+	if meta.Version < 14 {
+		return signedExtensionsExtra{}, fmt.Errorf("signed extension types are not available in metadata V%d",
+			meta.Version)
+	}
+
+	extra := signedExtensionsExtra{
+		raw: []byte{},
+		era: ExtrinsicEra{IsImmortalEra: true},
+	}
+
+	for _, signedExtension := range meta.AsMetadataV14.Extrinsic.SignedExtensions {
+		name := string(signedExtension.Identifier)
+
+		b, err := meta.AsMetadataV14.readValue(decoder, signedExtension.Type, nil)
+		if err != nil {
+			return signedExtensionsExtra{}, fmt.Errorf("decoding extra of signed extension %s: %w", name, err)
+		}
+
+		if err := extra.set(name, b); err != nil {
+			return signedExtensionsExtra{}, fmt.Errorf("decoding extra of signed extension %s: %w", name, err)
+		}
+
+		extra.raw = append(extra.raw, b...)
+	}
+
+	return extra, nil
+}
+
+// set decodes the encoded extra of the well-known signed extension with the given name.
+func (x *signedExtensionsExtra) set(name string, b []byte) error {
+	switch name {
+	case CheckMortalityExtension, CheckEraExtension:
+		return codec.Decode(b, &x.era)
+	case CheckNonceExtension:
+		return codec.Decode(b, &x.nonce)
+	case ChargeTransactionPaymentExtension, ChargeAssetTxPaymentExtension:
+		// The tip of ChargeAssetTxPayment is followed by the asset ID.
+		return codec.Decode(b, &x.tip)
+	case CheckMetadataHashExtension:
+		var mode MetadataHashMode
+
+		if err := codec.Decode(b, &mode); err != nil {
+			return err
+		}
+
+		x.metadataHashMode = NewOption(mode)
+	}
+
+	return nil
+}
+
+// readValue reads the encoded value of the type with the given ID from the decoder and appends it to buf.
+func (m *MetadataV14) readValue(decoder scale.Decoder, id Si1LookupTypeID, buf []byte) ([]byte, error) {
+	typ, ok := m.EfficientLookup[id.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrSignedExtensionTypeNotFound, id.Int64())
+	}
+
+	def := typ.Def
+
+	switch {
+	case def.IsComposite:
+		return m.readFields(decoder, def.Composite.Fields, buf)
+	case def.IsTuple:
+		return m.readValues(decoder, def.Tuple, buf)
+	case def.IsVariant:
+		buf, err := readBytes(decoder, buf, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		index := buf[len(buf)-1]
+
+		for _, variant := range def.Variant.Variants {
+			if byte(variant.Index) == index {
+				return m.readFields(decoder, variant.Fields, buf)
+			}
+		}
+
+		return nil, fmt.Errorf("%w: type %d has no variant with index %d", ErrSignedExtensionTypeInvalid,
+			id.Int64(), index)
+	case def.IsSequence:
+		buf, n, err := readLength(decoder, buf)
+		if err != nil {
+			return nil, err
+		}
+
+		return m.readRepeated(decoder, def.Sequence.Type, n, buf)
+	case def.IsArray:
+		return m.readRepeated(decoder, def.Array.Type, uint64(def.Array.Len), buf)
+	case def.IsCompact:
+		buf, _, err := readCompact(decoder, buf)
+
+		return buf, err
+	case def.IsPrimitive:
+		return readPrimitive(decoder, def.Primitive.Si0TypeDefPrimitive, buf)
+	}
+
+	return nil, fmt.Errorf("%w: type %d", ErrSignedExtensionTypeInvalid, id.Int64())
+}
+
+func (m *MetadataV14) readFields(decoder scale.Decoder, fields []Si1Field, buf []byte) ([]byte, error) {
+	ids := make([]Si1LookupTypeID, 0, len(fields))
+
+	for _, field := range fields {
+		ids = append(ids, field.Type)
+	}
+
+	return m.readValues(decoder, ids, buf)
+}
+
+func (m *MetadataV14) readValues(decoder scale.Decoder, ids []Si1LookupTypeID, buf []byte) ([]byte, error) {
+	var err error
+
+	for _, id := range ids {
+		if buf, err = m.readValue(decoder, id, buf); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+func (m *MetadataV14) readRepeated(decoder scale.Decoder, id Si1LookupTypeID, n uint64, buf []byte) ([]byte, error) {
+	var err error
+
+	for i := uint64(0); i < n; i++ {
+		if buf, err = m.readValue(decoder, id, buf); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+// primitiveSizes are the sizes of the fixed-size primitives.
+var primitiveSizes = map[Si0TypeDefPrimitive]int{
+	IsBool: 1, IsChar: 4,
+	IsU8: 1, IsU16: 2, IsU32: 4, IsU64: 8, IsU128: 16, IsU256: 32,
+	IsI8: 1, IsI16: 2, IsI32: 4, IsI64: 8, IsI128: 16, IsI256: 32,
+}
+
+func readPrimitive(decoder scale.Decoder, primitive Si0TypeDefPrimitive, buf []byte) ([]byte, error) {
+	if primitive == IsStr {
+		buf, n, err := readLength(decoder, buf)
+		if err != nil {
+			return nil, err
+		}
+
+		return readBytes(decoder, buf, int(n))
+	}
+
+	size, ok := primitiveSizes[primitive]
+	if !ok {
+		return nil, fmt.Errorf("%w: primitive %d", ErrSignedExtensionTypeInvalid, primitive)
+	}
+
+	return readBytes(decoder, buf, size)
+}
+
+// readLength reads the compact encoded length of a sequence or string and appends it to buf.
+func readLength(decoder scale.Decoder, buf []byte) ([]byte, uint64, error) {
+	buf, n, err := readCompact(decoder, buf)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !n.IsUint64() {
+		return nil, 0, fmt.Errorf("%w: length %s", ErrSignedExtensionTypeInvalid, n)
+	}
+
+	if err := decoder.CheckBytesLength(n.Uint64()); err != nil {
+		return nil, 0, err
+	}
+
+	return buf, n.Uint64(), nil
+}
+
+// readCompact reads a compact encoded integer and appends it to buf.
+func readCompact(decoder scale.Decoder, buf []byte) ([]byte, *big.Int, error) {
+	start := len(buf)
+
+	buf, err := readBytes(decoder, buf, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The two lowest bits of the first byte determine the number of bytes that follow.
+	switch first := buf[start]; first & 0b11 {
+	case 0b01:
+		buf, err = readBytes(decoder, buf, 1)
+	case 0b10:
+		buf, err = readBytes(decoder, buf, 3)
+	case 0b11:
+		buf, err = readBytes(decoder, buf, int(first>>2)+4)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	n, err := scale.NewDecoder(bytes.NewReader(buf[start:])).DecodeUintCompact()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return buf, n, nil
+}
+
+func readBytes(decoder scale.Decoder, buf []byte, n int) ([]byte, error) {
+	b := make([]byte, n)
+
+	if err := decoder.Read(b); err != nil {
+		return nil, err
+	}
+
+	return append(buf, b...), nil
+}
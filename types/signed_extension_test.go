@@ -0,0 +1,413 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSignedExtensionOptions = SignatureOptions{
+	BlockHash:          Hash{1},
+	GenesisHash:        Hash{2},
+	Nonce:              NewUCompactFromUInt(3),
+	SpecVersion:        4,
+	Tip:                NewUCompactFromUInt(5),
+	TransactionVersion: 6,
+}
+
+func newTestSignedExtensionMetadata(t *testing.T) *Metadata {
+	var meta Metadata
+
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	require.NoError(t, err)
+
+	return &meta
+}
+
+// findSignedExtension returns the signed extension with the given name, to reuse its types for other extensions.
+func findSignedExtension(t *testing.T, meta *Metadata, name string) SignedExtensionMetadataV14 {
+	for _, signedExtension := range meta.AsMetadataV14.Extrinsic.SignedExtensions {
+		if string(signedExtension.Identifier) == name {
+			return signedExtension
+		}
+	}
+
+	t.Fatalf("signed extension %s not found", name)
+
+	return SignedExtensionMetadataV14{}
+}
+
+// findPrimitiveType returns the ID of a primitive type of the metadata, e.g. to declare signed extensions with it.
+func findPrimitiveType(t *testing.T, meta *Metadata, primitive Si0TypeDefPrimitive) Si1LookupTypeID {
+	for _, typ := range meta.AsMetadataV14.Lookup.Types {
+		if typ.Type.Def.IsPrimitive && typ.Type.Def.Primitive.Si0TypeDefPrimitive == primitive {
+			return typ.ID
+		}
+	}
+
+	t.Fatalf("primitive type %d not found", primitive)
+
+	return Si1LookupTypeID{}
+}
+
+// encodeDecodeWithMetadata encodes the extrinsic and decodes it again as laid out by the metadata.
+func encodeDecodeWithMetadata(t *testing.T, xt Extrinsic, meta *Metadata) ([]byte, Extrinsic) {
+	enc, err := Encode(xt)
+	require.NoError(t, err)
+
+	var dec Extrinsic
+
+	err = dec.DecodeWithMetadata(*scale.NewDecoder(bytes.NewReader(enc)), meta)
+	require.NoError(t, err)
+
+	return enc, dec
+}
+
+func TestNewSignedExtensionPayload(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+
+	payload, err := NewSignedExtensionPayload(meta, testSignedExtensionOptions, nil)
+	assert.NoError(t, err)
+
+	// CheckMortality (immortal), CheckNonce, ChargeAssetTxPayment (tip, no asset ID)
+	assert.Equal(t, []byte{0x00, 0x0c, 0x14, 0x00}, payload.Extra)
+
+	// CheckSpecVersion, CheckTxVersion, CheckGenesis, CheckMortality (block hash)
+	genesisHash, blockHash := testSignedExtensionOptions.GenesisHash, testSignedExtensionOptions.BlockHash
+
+	expected := []byte{4, 0, 0, 0, 6, 0, 0, 0}
+	expected = append(expected, genesisHash[:]...)
+	expected = append(expected, blockHash[:]...)
+
+	assert.Equal(t, expected, payload.AdditionalSigned)
+}
+
+func TestNewSignedExtensionPayload_Values(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+
+	payload, err := NewSignedExtensionPayload(meta, testSignedExtensionOptions, SignedExtensionValues{
+		ChargeAssetTxPaymentExtension: {
+			Extra: ChargeAssetTxPayment{
+				Tip:     NewUCompactFromUInt(0),
+				AssetID: NewOption(BytesBare{7, 0, 0, 0}),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0x0c, 0x00, 0x01, 7, 0, 0, 0}, payload.Extra)
+}
+
+func TestNewSignedExtensionPayload_UnknownExtension(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+
+	// Unknown signed extensions with empty types are skipped.
+	emptyType := findSignedExtension(t, meta, CheckWeightExtension)
+	emptyType.Identifier = "CheckEmpty"
+
+	// CheckNonce is a struct with a single field.
+	nonEmptyType := findSignedExtension(t, meta, CheckNonceExtension)
+	nonEmptyType.Identifier = "CheckAppId"
+
+	meta.AsMetadataV14.Extrinsic.SignedExtensions = append(
+		meta.AsMetadataV14.Extrinsic.SignedExtensions,
+		emptyType,
+		nonEmptyType,
+	)
+
+	_, err := NewSignedExtensionPayload(meta, testSignedExtensionOptions, nil)
+	assert.ErrorIs(t, err, ErrSignedExtensionValueMissing)
+	assert.ErrorContains(t, err, "CheckAppId")
+
+	payload, err := NewSignedExtensionPayload(meta, testSignedExtensionOptions, SignedExtensionValues{
+		"CheckAppId": {Extra: NewUCompactFromUInt(9)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0x0c, 0x14, 0x00, 0x24}, payload.Extra)
+}
+
+func TestNewSignedExtensionPayload_TypeNotFound(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+
+	meta.AsMetadataV14.Extrinsic.SignedExtensions = append(
+		meta.AsMetadataV14.Extrinsic.SignedExtensions,
+		SignedExtensionMetadataV14{Identifier: "CheckUnknown", Type: NewSi1LookupTypeIDFromUInt(1_000_000)},
+	)
+
+	_, err := NewSignedExtensionPayload(meta, testSignedExtensionOptions, nil)
+	assert.ErrorIs(t, err, ErrSignedExtensionTypeNotFound)
+}
+
+func TestNewSignedExtensionPayload_UnsupportedMetadata(t *testing.T) {
+	_, err := NewSignedExtensionPayload(ExamplaryMetadataV13, testSignedExtensionOptions, nil)
+	assert.Error(t, err)
+}
+
+func TestExtrinsic_SignWithMetadata(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+
+	c := Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	xt := NewExtrinsic(c)
+
+	err := xt.SignWithMetadata(NewKeyringPairSigner(signature.TestKeyringPairAlice), meta, testSignedExtensionOptions, nil)
+	assert.NoError(t, err)
+	assert.True(t, xt.IsSigned())
+	assert.Equal(t, []byte{0x00, 0x0c, 0x14, 0x00}, xt.Signature.Extra)
+
+	signedExtensions, err := NewSignedExtensionPayload(meta, testSignedExtensionOptions, nil)
+	require.NoError(t, err)
+
+	mb, err := Encode(c)
+	require.NoError(t, err)
+
+	payload := append(append(mb, signedExtensions.Extra...), signedExtensions.AdditionalSigned...)
+
+	ok, err := signature.Verify(payload, xt.Signature.Signature.AsSr25519[:], signature.TestKeyringPairAlice.URI)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// The extra of the signed extensions follows the signature.
+	sig, err := Encode(xt.Signature.Signature)
+	require.NoError(t, err)
+
+	enc, err := Encode(xt)
+	require.NoError(t, err)
+	assert.Contains(t, string(enc), string(append(sig, xt.Signature.Extra...)))
+}
+
+func TestExtrinsic_DecodeWithMetadata_MetadataHash(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+
+	meta.AsMetadataV14.Extrinsic.SignedExtensions = append(
+		meta.AsMetadataV14.Extrinsic.SignedExtensions,
+		SignedExtensionMetadataV14{
+			Identifier:       CheckMetadataHashExtension,
+			Type:             findPrimitiveType(t, meta, IsU8),
+			AdditionalSigned: findSignedExtension(t, meta, CheckWeightExtension).AdditionalSigned,
+		},
+	)
+
+	opts := testSignedExtensionOptions
+	opts.MetadataHashMode = NewOption(MetadataHashEnabled)
+	opts.MetadataHash = Hash{7}
+
+	xt := NewExtrinsic(Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}})
+
+	err := xt.SignWithMetadata(NewKeyringPairSigner(signature.TestKeyringPairAlice), meta, opts, nil)
+	require.NoError(t, err)
+
+	// CheckMortality (immortal), CheckNonce, ChargeAssetTxPayment (tip, no asset ID), CheckMetadataHash (enabled)
+	assert.Equal(t, []byte{0x00, 0x0c, 0x14, 0x00, 0x01}, xt.Signature.Extra)
+
+	enc, dec := encodeDecodeWithMetadata(t, xt, meta)
+	assert.Equal(t, xt, dec)
+
+	reenc, err := Encode(dec)
+	require.NoError(t, err)
+	assert.Equal(t, enc, reenc)
+
+	// Decode does not know about the extension and takes the mode for the call index.
+	var legacy Extrinsic
+
+	require.NoError(t, Decode(enc, &legacy))
+	assert.True(t, legacy.Signature.MetadataHashMode.IsNone())
+	assert.NotEqual(t, xt.Method, legacy.Method)
+}
+
+func TestExtrinsic_DecodeWithMetadata_Extra(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+
+	// CheckNonce is a struct with a single field.
+	appID := findSignedExtension(t, meta, CheckNonceExtension)
+	appID.Identifier = "CheckAppId"
+
+	meta.AsMetadataV14.Extrinsic.SignedExtensions = append(meta.AsMetadataV14.Extrinsic.SignedExtensions, appID)
+
+	xt := NewExtrinsic(Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}})
+
+	err := xt.SignWithMetadata(
+		NewKeyringPairSigner(signature.TestKeyringPairAlice),
+		meta,
+		testSignedExtensionOptions,
+		SignedExtensionValues{
+			ChargeAssetTxPaymentExtension: {
+				Extra: ChargeAssetTxPayment{
+					Tip:     NewUCompactFromUInt(8),
+					AssetID: NewOption(BytesBare{7, 0, 0, 0}),
+				},
+			},
+			"CheckAppId": {Extra: NewUCompactFromUInt(9)},
+		},
+	)
+	require.NoError(t, err)
+
+	enc, dec := encodeDecodeWithMetadata(t, xt, meta)
+	assert.Equal(t, xt.Signature.Extra, dec.Signature.Extra)
+	assert.Equal(t, xt.Signature.Signer, dec.Signature.Signer)
+	assert.Equal(t, xt.Signature.Signature, dec.Signature.Signature)
+	assert.Equal(t, xt.Method, dec.Method)
+
+	// The well-known signed extensions are decoded from the extra.
+	assert.Equal(t, ExtrinsicEra{IsImmortalEra: true}, dec.Signature.Era)
+	assert.Equal(t, testSignedExtensionOptions.Nonce, dec.Signature.Nonce)
+	assert.Equal(t, NewUCompactFromUInt(8), dec.Signature.Tip)
+
+	reenc, err := Encode(dec)
+	require.NoError(t, err)
+	assert.Equal(t, enc, reenc)
+}
+
+func TestExtrinsic_DecodeWithMetadata_General(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+
+	xt := NewGeneralExtrinsic(Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}},
+		ExtrinsicExtensionsV5{})
+
+	err := xt.SetExtensionsWithMetadata(meta, testSignedExtensionOptions, nil)
+	require.NoError(t, err)
+
+	enc, dec := encodeDecodeWithMetadata(t, xt, meta)
+	assert.Equal(t, xt, dec)
+
+	reenc, err := Encode(dec)
+	require.NoError(t, err)
+	assert.Equal(t, enc, reenc)
+}
+
+func TestExtrinsic_DecodeWithMetadata_UnsupportedMetadata(t *testing.T) {
+	enc, err := Encode(ExamplaryExtrinsic)
+	require.NoError(t, err)
+
+	// Metadata before V14 does not declare the signed extensions, the legacy layout is decoded.
+	var dec Extrinsic
+
+	err = dec.DecodeWithMetadata(*scale.NewDecoder(bytes.NewReader(enc)), ExamplaryMetadataV13)
+	require.NoError(t, err)
+	assert.Equal(t, ExamplaryExtrinsic, dec)
+}
+
+func TestExtrinsic_DecodeWithMetadata_TypeNotFound(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+
+	xt := NewExtrinsic(Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}})
+
+	err := xt.SignWithMetadata(
+		NewKeyringPairSigner(signature.TestKeyringPairAlice),
+		meta,
+		testSignedExtensionOptions,
+		nil,
+	)
+	require.NoError(t, err)
+
+	enc, err := Encode(xt)
+	require.NoError(t, err)
+
+	meta.AsMetadataV14.Extrinsic.SignedExtensions = append(
+		meta.AsMetadataV14.Extrinsic.SignedExtensions,
+		SignedExtensionMetadataV14{Identifier: "CheckUnknown", Type: NewSi1LookupTypeIDFromUInt(1_000_000)},
+	)
+
+	var dec Extrinsic
+
+	err = dec.DecodeWithMetadata(*scale.NewDecoder(bytes.NewReader(enc)), meta)
+	assert.ErrorIs(t, err, ErrSignedExtensionTypeNotFound)
+	assert.ErrorContains(t, err, "CheckUnknown")
+}
+
+func TestExtrinsic_SignWithMetadataEcdsa(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+
+	c := Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	signedExtensions, err := NewSignedExtensionPayload(meta, testSignedExtensionOptions, nil)
+	require.NoError(t, err)
+
+	mb, err := Encode(c)
+	require.NoError(t, err)
+
+	payload := append(append(mb, signedExtensions.Extra...), signedExtensions.AdditionalSigned...)
+
+	xt := NewExtrinsic(c)
+
+	err = xt.SignWithMetadataEcdsa(signature.TestEcdsaKeyringPairAlice, meta, testSignedExtensionOptions, nil)
+	assert.NoError(t, err)
+	assert.True(t, xt.Signature.Signature.IsEcdsa)
+	assert.Equal(t, signature.TestEcdsaKeyringPairAlice.AccountID(), xt.Signature.Signer.AsID[:])
+
+	ok, err := signature.TestEcdsaKeyringPairAlice.Verify(payload, xt.Signature.Signature.AsEcdsa[:])
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Ethereum-compatible chains use the AccountId20 and the bare signature.
+	ethereumSigner := signature.TestEcdsaKeyringPairAlice
+	ethereumSigner.Hasher = signature.EcdsaHasherKeccak256
+
+	accountID20, err := ethereumSigner.AccountID20()
+	require.NoError(t, err)
+
+	xt = NewExtrinsic(c)
+
+	err = xt.SignWithMetadataEcdsa(ethereumSigner, meta, testSignedExtensionOptions, nil)
+	assert.NoError(t, err)
+	assert.True(t, xt.Signature.Ethereum)
+	assert.Equal(t, accountID20, xt.Signature.Signer.AsAddress20[:])
+
+	ok, err = ethereumSigner.Verify(payload, xt.Signature.Signature.AsEcdsa[:])
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	enc, err := Encode(xt.Signature)
+	require.NoError(t, err)
+
+	expected := append(append(accountID20, xt.Signature.Signature.AsEcdsa[:]...), xt.Signature.Extra...)
+	assert.Equal(t, expected, enc)
+}
+
+func TestExtrinsic_SignWithMetadataEcdsa_UnsupportedMetadata(t *testing.T) {
+	xt := NewExtrinsic(Call{})
+
+	err := xt.SignWithMetadataEcdsa(signature.TestEcdsaKeyringPairAlice, ExamplaryMetadataV13,
+		testSignedExtensionOptions, nil)
+	assert.Error(t, err)
+}
+
+func TestExtrinsic_SignWithMetadataEcdsa_NotEcdsa(t *testing.T) {
+	xt := NewExtrinsic(Call{})
+
+	err := xt.SignWithMetadataEcdsa(signature.TestKeyringPairAlice, newTestSignedExtensionMetadata(t),
+		testSignedExtensionOptions, nil)
+	assert.ErrorIs(t, err, ErrNotEcdsaKeyringPair)
+}
+
+func TestExtrinsic_SignWithMetadata_Ed25519(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+
+	xt := NewExtrinsic(Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}})
+
+	err := xt.SignWithMetadata(NewKeyringPairSigner(signature.TestKeyringPairAliceEd25519), meta, testSignedExtensionOptions, nil)
+	assert.NoError(t, err)
+	assert.True(t, xt.Signature.Signature.IsEd25519)
+	assert.Equal(t, signature.TestKeyringPairAliceEd25519.PublicKey, xt.Signature.Signer.AsID[:])
+}
@@ -0,0 +1,128 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+)
+
+// ErrNotEcdsaKeyringPair is returned by NewEcdsaKeyringPairSigner for key pairs of other schemes than signature.Ecdsa.
+var ErrNotEcdsaKeyringPair = errors.New("key pair is not an ecdsa key pair")
+
+// Signer signs extrinsics, e.g. with a signature.KeyringPair via NewKeyringPairSigner or with keys that are kept in an
+// HSM or KMS via NewCallbackSigner.
+//
+// Payloads that are longer than 256 bytes are hashed with blake2-256 before they are passed to Sign, so signers must
+// sign the payload as is.
+type Signer interface {
+	// PublicKey returns the public key of the signer.
+	PublicKey() []byte
+	// AccountID returns the account that signs, which is used as signer of extrinsics.
+	AccountID() AccountID
+	// Sign signs the payload.
+	Sign(payload []byte) (MultiSignature, error)
+}
+
+// keyringPairSigner is the Signer of a signature.KeyringPair.
+type keyringPairSigner struct {
+	kp signature.KeyringPair
+}
+
+// NewKeyringPairSigner returns a Signer that signs with the key pair, according to its crypto scheme. Its account is
+// the AccountId32 of the key pair, see signature.KeyringPair.AccountID.
+func NewKeyringPairSigner(kp signature.KeyringPair) Signer {
+	return keyringPairSigner{kp: kp}
+}
+
+// NewEcdsaKeyringPairSigner is like NewKeyringPairSigner but only accepts ecdsa key pairs, which sign with their
+// hasher, see signature.EcdsaKeyringPairFromSecret.
+func NewEcdsaKeyringPairSigner(kp signature.KeyringPair) (Signer, error) {
+	if kp.Scheme != signature.Ecdsa {
+		return nil, fmt.Errorf("%w: %v", ErrNotEcdsaKeyringPair, kp.Scheme)
+	}
+
+	return keyringPairSigner{kp: kp}, nil
+}
+
+func (s keyringPairSigner) PublicKey() []byte {
+	return s.kp.PublicKey
+}
+
+func (s keyringPairSigner) AccountID() AccountID {
+	var accountID AccountID
+
+	copy(accountID[:], s.kp.AccountID())
+
+	return accountID
+}
+
+func (s keyringPairSigner) Sign(payload []byte) (MultiSignature, error) {
+	sig, err := s.kp.Sign(payload)
+	if err != nil {
+		return MultiSignature{}, err
+	}
+
+	return newMultiSignature(s.kp.Scheme, sig)
+}
+
+// callbackSigner is a Signer that delegates signing to a callback.
+type callbackSigner struct {
+	publicKey []byte
+	accountID AccountID
+	sign      func(payload []byte) (MultiSignature, error)
+}
+
+// NewCallbackSigner returns a Signer that delegates signing to the callback, e.g. a request to a remote signer. The
+// public key is used as account ID, so it has to be 32 bytes long. Other keys, e.g. 33 byte ecdsa public keys, need
+// an explicit account ID, see NewCallbackSignerWithAccountID.
+func NewCallbackSigner(publicKey []byte, sign func(payload []byte) (MultiSignature, error)) (Signer, error) {
+	accountID, err := NewAccountID(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCallbackSignerWithAccountID(publicKey, *accountID, sign), nil
+}
+
+// NewCallbackSignerWithAccountID is like NewCallbackSigner but signs as the given account, e.g. the AccountId32 of a
+// compressed ecdsa public key, see signature.EcdsaAccountID.
+func NewCallbackSignerWithAccountID(
+	publicKey []byte,
+	accountID AccountID,
+	sign func(payload []byte) (MultiSignature, error),
+) Signer {
+	return callbackSigner{
+		publicKey: publicKey,
+		accountID: accountID,
+		sign:      sign,
+	}
+}
+
+func (s callbackSigner) PublicKey() []byte {
+	return s.publicKey
+}
+
+func (s callbackSigner) AccountID() AccountID {
+	return s.accountID
+}
+
+func (s callbackSigner) Sign(payload []byte) (MultiSignature, error) {
+	return s.sign(payload)
+}
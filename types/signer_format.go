@@ -0,0 +1,114 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "errors"
+
+// ErrSignerFormatMismatch is returned when signing an extrinsic with a signer that the runtime does not accept, see
+// Metadata.SignerFormat.
+var ErrSignerFormatMismatch = errors.New("signer does not match the signer format of the runtime")
+
+// SignerFormat is the format of the signers and signatures of the extrinsics of a runtime, as declared by the Address
+// and Signature type parameters of its extrinsic type, see Metadata.SignerFormat.
+type SignerFormat uint8
+
+const (
+	// SignerFormatUnknown is the format of runtimes whose metadata does not declare the types of extrinsics, i.e.
+	// metadata before V14, or that declares other types.
+	SignerFormatUnknown SignerFormat = iota
+	// SignerFormatMultiAddress is the format of most Substrate chains: a MultiAddress signer and a MultiSignature.
+	SignerFormatMultiAddress
+	// SignerFormatEthereum is the format of Ethereum-compatible chains like Moonbeam: an AccountId20 signer and a bare
+	// ecdsa signature of the keccak-256 hash of the payload, see ExtrinsicSignatureV4.Ethereum.
+	SignerFormatEthereum
+)
+
+func (f SignerFormat) String() string {
+	switch f {
+	case SignerFormatMultiAddress:
+		return "MultiAddress"
+	case SignerFormatEthereum:
+		return "Ethereum"
+	default:
+		return "unknown"
+	}
+}
+
+// SignerFormat returns the format of the signers and signatures of the extrinsics of the runtime.
+func (m *Metadata) SignerFormat() SignerFormat {
+	if m.Version < 14 {
+		return SignerFormatUnknown
+	}
+
+	lookup := m.AsMetadataV14.EfficientLookup
+
+	extrinsicType, ok := lookup[m.AsMetadataV14.Extrinsic.Type.Int64()]
+	if !ok {
+		return SignerFormatUnknown
+	}
+
+	var addressType, signatureType *Si1Type
+
+	for _, param := range extrinsicType.Params {
+		if !param.HasType {
+			continue
+		}
+
+		switch param.Name {
+		case "Address":
+			addressType = lookup[param.Type.Int64()]
+		case "Signature":
+			signatureType = lookup[param.Type.Int64()]
+		}
+	}
+
+	if addressType == nil || signatureType == nil {
+		return SignerFormatUnknown
+	}
+
+	switch {
+	case hasPathSuffix(addressType, "MultiAddress") && hasPathSuffix(signatureType, "MultiSignature"):
+		return SignerFormatMultiAddress
+	case byteArrayLen(lookup, addressType) == 20 && byteArrayLen(lookup, signatureType) == 65:
+		return SignerFormatEthereum
+	default:
+		return SignerFormatUnknown
+	}
+}
+
+func hasPathSuffix(typ *Si1Type, name Text) bool {
+	return len(typ.Path) > 0 && typ.Path[len(typ.Path)-1] == name
+}
+
+// byteArrayLen returns the length of the byte array that the type wraps, e.g. 20 for AccountId20([u8; 20]), or 0 if
+// the type is not a byte array.
+func byteArrayLen(lookup map[int64]*Si1Type, typ *Si1Type) int {
+	for typ != nil && typ.Def.IsComposite && len(typ.Def.Composite.Fields) == 1 {
+		typ = lookup[typ.Def.Composite.Fields[0].Type.Int64()]
+	}
+
+	if typ == nil || !typ.Def.IsArray {
+		return 0
+	}
+
+	itemType, ok := lookup[typ.Def.Array.Type.Int64()]
+	if !ok || !itemType.Def.IsPrimitive || itemType.Def.Primitive.Si0TypeDefPrimitive != IsU8 {
+		return 0
+	}
+
+	return int(typ.Def.Array.Len)
+}
@@ -0,0 +1,118 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMoonbeamMetadata(t *testing.T) *Metadata {
+	var meta Metadata
+
+	err := DecodeFromHex(test.MoonbeamMetaHex, &meta)
+	require.NoError(t, err)
+
+	return &meta
+}
+
+func TestMetadata_SignerFormat(t *testing.T) {
+	var polkadot Metadata
+
+	err := DecodeFromHex(test.PolkadotMetadataHex, &polkadot)
+	require.NoError(t, err)
+
+	assert.Equal(t, SignerFormatUnknown, ExamplaryMetadataV13.SignerFormat())
+	assert.Equal(t, SignerFormatMultiAddress, newTestSignedExtensionMetadata(t).SignerFormat())
+	assert.Equal(t, SignerFormatMultiAddress, polkadot.SignerFormat())
+	assert.Equal(t, SignerFormatEthereum, newTestMoonbeamMetadata(t).SignerFormat())
+}
+
+func TestExtrinsic_SignWithMetadata_Ethereum(t *testing.T) {
+	meta := newTestMoonbeamMetadata(t)
+	c := Call{CallIndex: CallIndex{SectionIndex: 0, MethodIndex: 1}, Args: []byte{0}}
+
+	kp := signature.TestEcdsaKeyringPairAlice
+	kp.Hasher = signature.EcdsaHasherKeccak256
+
+	accountID20, err := kp.AccountID20()
+	require.NoError(t, err)
+
+	payload, err := NewSigningPayload(meta, c, testSignedExtensionOptions, nil)
+	require.NoError(t, err)
+
+	// Ecdsa key pairs and Signers of ecdsa keys are attached as AccountId20 signers.
+	xt := NewExtrinsic(c)
+
+	err = xt.SignWithMetadataEcdsa(kp, meta, testSignedExtensionOptions, nil)
+	require.NoError(t, err)
+	assert.True(t, xt.Signature.Ethereum)
+	assert.Equal(t, accountID20, xt.Signature.Signer.AsAddress20[:])
+	assert.NoError(t, payload.Verify(xt.Signature.Signer, xt.Signature.Signature))
+
+	// The AccountId20 signer and the bare signature are decoded as laid out by the metadata.
+	enc, dec := encodeDecodeWithMetadata(t, xt, meta)
+	assert.Equal(t, xt, dec)
+
+	reenc, err := Encode(dec)
+	require.NoError(t, err)
+	assert.Equal(t, enc, reenc)
+
+	// A remote signer of the ecdsa key, which signs as the AccountId32 of the key outside of Ethereum-compatible
+	// runtimes.
+	remoteSigner := NewCallbackSignerWithAccountID(
+		kp.PublicKey,
+		AccountID(kp.AccountID()),
+		func(payload []byte) (MultiSignature, error) {
+			sig, err := kp.Sign(payload)
+			if err != nil {
+				return MultiSignature{}, err
+			}
+
+			return MultiSignature{IsEcdsa: true, AsEcdsa: NewEcdsaSignature(sig)}, nil
+		},
+	)
+
+	viaSigner := NewExtrinsic(c)
+
+	err = viaSigner.SignWithMetadata(remoteSigner, meta, testSignedExtensionOptions, nil)
+	require.NoError(t, err)
+	assert.Equal(t, xt, viaSigner)
+
+	// Signers that the runtime would reject fail before signing.
+	kp.Hasher = signature.EcdsaHasherBlake2_256
+
+	rejected := NewExtrinsic(c)
+
+	err = rejected.SignWithMetadataEcdsa(kp, meta, testSignedExtensionOptions, nil)
+	assert.True(t, errors.Is(err, ErrSignerFormatMismatch))
+
+	err = rejected.SignWithMetadata(
+		NewKeyringPairSigner(signature.TestKeyringPairAlice),
+		meta,
+		testSignedExtensionOptions,
+		nil,
+	)
+	assert.True(t, errors.Is(err, ErrSignerFormatMismatch))
+}
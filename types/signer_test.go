@@ -0,0 +1,182 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestNewKeyringPairSigner(t *testing.T) {
+	signer := NewKeyringPairSigner(signature.TestKeyringPairAlice)
+
+	assert.Equal(t, signature.TestKeyringPairAlice.PublicKey, signer.PublicKey())
+
+	accountID := signer.AccountID()
+	assert.Equal(t, signature.TestKeyringPairAlice.PublicKey, accountID.ToBytes())
+
+	sig, err := signer.Sign([]byte("hello!"))
+	assert.NoError(t, err)
+	assert.True(t, sig.IsSr25519)
+
+	ok, err := signature.TestKeyringPairAlice.Verify([]byte("hello!"), sig.AsSr25519[:])
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	sig, err = NewKeyringPairSigner(signature.TestKeyringPairAliceEd25519).Sign([]byte("hello!"))
+	assert.NoError(t, err)
+	assert.True(t, sig.IsEd25519)
+}
+
+func TestNewEcdsaKeyringPairSigner(t *testing.T) {
+	kp := signature.TestEcdsaKeyringPairAlice
+
+	signer, err := NewEcdsaKeyringPairSigner(kp)
+	require.NoError(t, err)
+
+	assert.Equal(t, kp.PublicKey, signer.PublicKey())
+
+	accountID := signer.AccountID()
+	assert.Equal(t, signature.EcdsaAccountID(kp.PublicKey), accountID.ToBytes())
+
+	sig, err := signer.Sign([]byte("hello!"))
+	assert.NoError(t, err)
+	assert.True(t, sig.IsEcdsa)
+
+	ok, err := kp.Verify([]byte("hello!"), sig.AsEcdsa[:])
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = NewEcdsaKeyringPairSigner(signature.TestKeyringPairAlice)
+	assert.ErrorIs(t, err, ErrNotEcdsaKeyringPair)
+}
+
+func TestNewCallbackSigner(t *testing.T) {
+	_, err := NewCallbackSigner([]byte{1, 2, 3}, nil)
+	assert.ErrorIs(t, err, ErrInvalidAccountIDBytes)
+
+	var payloads [][]byte
+
+	signer, err := NewCallbackSigner(signature.TestKeyringPairAlice.PublicKey, func(payload []byte) (MultiSignature, error) {
+		payloads = append(payloads, payload)
+
+		return MultiSignature{IsEd25519: true, AsEd25519: Signature{1}}, nil
+	})
+	require.NoError(t, err)
+
+	// The call is long enough for the payload to be hashed before it is passed to the signer.
+	c := Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: make([]byte, 300)}
+
+	mb, err := Encode(c)
+	require.NoError(t, err)
+
+	xt := NewExtrinsic(c)
+
+	err = xt.SignWithSigner(signer, testSignedExtensionOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, signature.TestKeyringPairAlice.PublicKey, xt.Signature.Signer.AsID[:])
+	assert.Equal(t, MultiSignature{IsEd25519: true, AsEd25519: Signature{1}}, xt.Signature.Signature)
+
+	payload, err := Encode(ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: ExtrinsicPayloadV3{
+			Method:      mb,
+			Era:         ExtrinsicEra{IsImmortalEra: true},
+			Nonce:       testSignedExtensionOptions.Nonce,
+			Tip:         testSignedExtensionOptions.Tip,
+			SpecVersion: testSignedExtensionOptions.SpecVersion,
+			GenesisHash: testSignedExtensionOptions.GenesisHash,
+			BlockHash:   testSignedExtensionOptions.BlockHash,
+		},
+		TransactionVersion: testSignedExtensionOptions.TransactionVersion,
+	})
+	require.NoError(t, err)
+
+	hash := blake2b.Sum256(payload)
+	assert.Equal(t, [][]byte{hash[:]}, payloads)
+
+	meta := newTestSignedExtensionMetadata(t)
+
+	signedExtensions, err := NewSignedExtensionPayload(meta, testSignedExtensionOptions, nil)
+	require.NoError(t, err)
+
+	payload = append(append(mb, signedExtensions.Extra...), signedExtensions.AdditionalSigned...)
+	hash = blake2b.Sum256(payload)
+
+	err = xt.SignWithMetadata(signer, meta, testSignedExtensionOptions, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, hash[:], payloads[1])
+}
+
+func TestNewCallbackSignerWithAccountID(t *testing.T) {
+	kp := signature.TestEcdsaKeyringPairAlice
+	accountID := AccountID(kp.AccountID())
+
+	signer := NewCallbackSignerWithAccountID(kp.PublicKey, accountID, func(payload []byte) (MultiSignature, error) {
+		sig, err := kp.Sign(payload)
+		if err != nil {
+			return MultiSignature{}, err
+		}
+
+		return MultiSignature{IsEcdsa: true, AsEcdsa: NewEcdsaSignature(sig)}, nil
+	})
+
+	assert.Equal(t, kp.PublicKey, signer.PublicKey())
+	assert.Equal(t, accountID, signer.AccountID())
+
+	xt := NewExtrinsic(Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}})
+
+	err := xt.SignWithMetadata(signer, newTestSignedExtensionMetadata(t), testSignedExtensionOptions, nil)
+	assert.NoError(t, err)
+	assert.True(t, xt.Signature.Signature.IsEcdsa)
+	assert.Equal(t, accountID, xt.Signature.Signer.AsID)
+
+	// The 33 byte public key cannot be used as account ID.
+	_, err = NewCallbackSigner(kp.PublicKey, nil)
+	assert.ErrorIs(t, err, ErrInvalidAccountIDBytes)
+}
+
+func ExampleNewCallbackSigner() {
+	// The callback would usually send the payload to a remote signer, e.g. an HSM or a KMS.
+	signer, err := NewCallbackSigner(signature.TestKeyringPairAlice.PublicKey, func(payload []byte) (MultiSignature, error) {
+		sig, err := signature.Sign(payload, signature.TestKeyringPairAlice.URI)
+		if err != nil {
+			return MultiSignature{}, err
+		}
+
+		return MultiSignature{IsSr25519: true, AsSr25519: NewSignature(sig)}, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	xt := NewExtrinsic(Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}})
+
+	err = xt.SignWithSigner(signer, SignatureOptions{Nonce: NewUCompactFromUInt(1), Tip: NewUCompactFromUInt(0)})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(xt.IsSigned())
+	// Output: true
+}
@@ -0,0 +1,255 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	ErrSigningPayloadSignatureInvalid   = errors.New("signature does not verify against the signing payload")
+	ErrSigningPayloadSignerNotSupported = errors.New("signer address not supported")
+)
+
+// SigningPayload is the payload of an extrinsic that is signed outside of this process, e.g. by an air-gapped signer.
+// It is built online via NewSigningPayload, the bytes returned by ToSign are signed offline, and the signature is
+// attached via Attach, which creates the signed extrinsic that can be submitted later on.
+type SigningPayload struct {
+	// Call is the call of the extrinsic.
+	Call Call
+	// Options are the signature options the payload was created with.
+	Options SignatureOptions
+	// Payload is the encoded call followed by the extra and additional signed data of the signed extensions.
+	Payload []byte
+	// Extra is the encoded extra data of the signed extensions declared by the metadata, which is included in the
+	// extrinsic. It is nil for metadata before V14, the extra data is taken from the Options instead.
+	Extra []byte
+
+	metadataHashMode Option[MetadataHashMode]
+}
+
+// NewSigningPayload creates the signing payload of the call, including the signed extensions declared by the
+// metadata, exactly like SignWithMetadata. For metadata before V14 the payload is created like SignWithSigner does.
+func NewSigningPayload(
+	meta *Metadata,
+	call Call,
+	o SignatureOptions,
+	values SignedExtensionValues,
+) (SigningPayload, error) {
+	if meta.Version < 14 {
+		return newSigningPayloadV4(call, o)
+	}
+
+	mb, err := codec.Encode(call)
+	if err != nil {
+		return SigningPayload{}, err
+	}
+
+	signedExtensions, err := NewSignedExtensionPayload(meta, o, values)
+	if err != nil {
+		return SigningPayload{}, err
+	}
+
+	payload := make([]byte, 0, len(mb)+len(signedExtensions.Extra)+len(signedExtensions.AdditionalSigned))
+	payload = append(payload, mb...)
+	payload = append(payload, signedExtensions.Extra...)
+	payload = append(payload, signedExtensions.AdditionalSigned...)
+
+	metadataHashMode := NewEmptyOption[MetadataHashMode]()
+	if meta.HasSignedExtension(CheckMetadataHashExtension) {
+		metadataHashMode = NewOption(o.metadataHashMode())
+	}
+
+	return SigningPayload{
+		Call:    call,
+		Options: o,
+		Payload: payload,
+		// Extra is never nil, so that it is always encoded instead of the fields of the extrinsic signature.
+		Extra:            append([]byte{}, signedExtensions.Extra...),
+		metadataHashMode: metadataHashMode,
+	}, nil
+}
+
+// newSigningPayloadV4 creates the signing payload from the signed extensions of ExtrinsicPayloadV4.
+func newSigningPayloadV4(call Call, o SignatureOptions) (SigningPayload, error) {
+	mb, err := codec.Encode(call)
+	if err != nil {
+		return SigningPayload{}, err
+	}
+
+	payload := ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: ExtrinsicPayloadV3{
+			Method:      mb,
+			Era:         o.era(),
+			Nonce:       o.Nonce,
+			Tip:         o.Tip,
+			SpecVersion: o.SpecVersion,
+			GenesisHash: o.GenesisHash,
+			BlockHash:   o.BlockHash,
+		},
+		TransactionVersion: o.TransactionVersion,
+		MetadataHashMode:   o.MetadataHashMode,
+		MetadataHash:       o.MetadataHash,
+	}
+
+	b, err := codec.Encode(payload)
+	if err != nil {
+		return SigningPayload{}, err
+	}
+
+	return SigningPayload{
+		Call:             call,
+		Options:          o,
+		Payload:          b,
+		metadataHashMode: o.MetadataHashMode,
+	}, nil
+}
+
+// ToSign returns the bytes that have to be signed, which is the payload, or its blake2-256 hash if it is longer than
+// 256 bytes.
+func (p SigningPayload) ToSign() []byte {
+	if len(p.Payload) > 256 {
+		h := blake2b.Sum256(p.Payload)
+		return h[:]
+	}
+
+	return p.Payload
+}
+
+// String returns a human-readable breakdown of the signed values, so that they can be reviewed before signing.
+func (p SigningPayload) String() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "call: %d.%d (%d bytes of args)\n", p.Call.CallIndex.SectionIndex, p.Call.CallIndex.MethodIndex,
+		len(p.Call.Args))
+
+	if era := p.Options.era(); era.IsMortalEra {
+		fmt.Fprintf(&sb, "era: mortal, period %d, phase %d\n", era.AsMortalEra.Period(), era.AsMortalEra.Phase())
+	} else {
+		sb.WriteString("era: immortal\n")
+	}
+
+	nonce, tip := big.Int(p.Options.Nonce), big.Int(p.Options.Tip)
+
+	fmt.Fprintf(&sb, "nonce: %s\n", nonce.String())
+	fmt.Fprintf(&sb, "tip: %s\n", tip.String())
+	fmt.Fprintf(&sb, "spec version: %d\n", p.Options.SpecVersion)
+	fmt.Fprintf(&sb, "transaction version: %d\n", p.Options.TransactionVersion)
+	fmt.Fprintf(&sb, "genesis hash: %s\n", p.Options.GenesisHash.Hex())
+	fmt.Fprintf(&sb, "block hash: %s\n", p.Options.BlockHash.Hex())
+
+	if p.Options.metadataHashMode() == MetadataHashEnabled {
+		fmt.Fprintf(&sb, "metadata hash: %s\n", p.Options.MetadataHash.Hex())
+	} else {
+		sb.WriteString("metadata hash: disabled\n")
+	}
+
+	fmt.Fprintf(&sb, "payload: %#x", p.Payload)
+
+	return sb.String()
+}
+
+// Verify checks that the signature was created by the signer for this payload. Signers are supported as account ID,
+// which is the public key for sr25519 and ed25519 signatures and the blake2-256 hash of the public key for ecdsa
+// signatures, or as Ethereum-style AccountId20 for ecdsa signatures of the keccak-256 hash of the payload.
+func (p SigningPayload) Verify(signer MultiAddress, sig MultiSignature) error {
+	toSign := p.ToSign()
+
+	var (
+		ok  bool
+		err error
+	)
+
+	switch {
+	case signer.IsID && sig.IsSr25519:
+		ok, err = signature.VerifyWithPublicKey(toSign, sig.AsSr25519[:], signer.AsID[:], signature.Sr25519)
+	case signer.IsID && sig.IsEd25519:
+		ok, err = signature.VerifyWithPublicKey(toSign, sig.AsEd25519[:], signer.AsID[:], signature.Ed25519)
+	case signer.IsID && sig.IsEcdsa:
+		ok = verifyEcdsaSigner(toSign, sig.AsEcdsa, signature.EcdsaHasherBlake2_256, func(publicKey []byte) bool {
+			return bytes.Equal(signature.EcdsaAccountID(publicKey), signer.AsID[:])
+		})
+	case signer.IsAddress20 && sig.IsEcdsa:
+		ok = verifyEcdsaSigner(toSign, sig.AsEcdsa, signature.EcdsaHasherKeccak256, func(publicKey []byte) bool {
+			accountID20, err := signature.EcdsaAccountID20(publicKey)
+			return err == nil && bytes.Equal(accountID20, signer.AsAddress20[:])
+		})
+	default:
+		return ErrSigningPayloadSignerNotSupported
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSigningPayloadSignatureInvalid, err)
+	}
+
+	if !ok {
+		return ErrSigningPayloadSignatureInvalid
+	}
+
+	return nil
+}
+
+// verifyEcdsaSigner recovers the public key from the signature and checks whether it belongs to the signer.
+func verifyEcdsaSigner(
+	toSign []byte,
+	sig EcdsaSignature,
+	hasher signature.EcdsaHasher,
+	isSigner func(publicKey []byte) bool,
+) bool {
+	publicKey, err := signature.RecoverEcdsaPublicKey(toSign, sig[:], hasher)
+
+	return err == nil && isSigner(publicKey)
+}
+
+// Attach verifies the signature, see Verify, and returns the signed extrinsic. Ecdsa signatures of AccountId20 signers
+// are attached as Ethereum signatures, see ExtrinsicSignatureV4.Ethereum.
+func (p SigningPayload) Attach(signer MultiAddress, sig MultiSignature) (Extrinsic, error) {
+	if err := p.Verify(signer, sig); err != nil {
+		return Extrinsic{}, err
+	}
+
+	xt := p.extrinsic(signer, sig)
+	xt.Signature.Ethereum = signer.IsAddress20
+
+	return xt, nil
+}
+
+// extrinsic returns the extrinsic signed with the signature, without verifying it.
+func (p SigningPayload) extrinsic(signer MultiAddress, sig MultiSignature) Extrinsic {
+	return Extrinsic{
+		// mark the extrinsic as signed
+		Version: ExtrinsicVersion4 | ExtrinsicBitSigned,
+		Signature: ExtrinsicSignatureV4{
+			Signer:           signer,
+			Signature:        sig,
+			Era:              p.Options.era(),
+			Nonce:            p.Options.Nonce,
+			Tip:              p.Options.Tip,
+			MetadataHashMode: p.metadataHashMode,
+			Extra:            p.Extra,
+		},
+		Method: p.Call,
+	}
+}
@@ -0,0 +1,193 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signOffline signs the payload like an air-gapped signer would, with the keys of Alice.
+func signOffline(t *testing.T, toSign []byte, scheme signature.Scheme) MultiSignature {
+	kp := signature.TestKeyringPairAlice
+	if scheme == signature.Ed25519 {
+		kp = signature.TestKeyringPairAliceEd25519
+	}
+
+	sig, err := kp.Sign(toSign)
+	require.NoError(t, err)
+
+	if scheme == signature.Ed25519 {
+		return MultiSignature{IsEd25519: true, AsEd25519: NewSignature(sig)}
+	}
+
+	return MultiSignature{IsSr25519: true, AsSr25519: NewSignature(sig)}
+}
+
+func TestSigningPayload_Attach(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+
+	mortal := testSignedExtensionOptions
+	mortal.Era, _ = NewMortalEra(100, 64)
+
+	c := Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	tests := []struct {
+		name string
+		meta *Metadata
+		call Call
+		opts SignatureOptions
+	}{
+		{"v14", meta, c, testSignedExtensionOptions},
+		{"v14 mortal", meta, c, mortal},
+		// The payload is hashed before signing.
+		{"v14 long", meta, Call{CallIndex: c.CallIndex, Args: make([]byte, 300)}, mortal},
+		{"v13", ExamplaryMetadataV13, c, mortal},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Ed25519 signatures are deterministic, so the extrinsics are identical.
+			online := NewExtrinsic(test.call)
+
+			err := online.SignWithMetadata(NewKeyringPairSigner(signature.TestKeyringPairAliceEd25519), test.meta,
+				test.opts, nil)
+			require.NoError(t, err)
+
+			payload, err := NewSigningPayload(test.meta, test.call, test.opts, nil)
+			require.NoError(t, err)
+
+			signer, err := NewMultiAddressFromAccountID(signature.TestKeyringPairAliceEd25519.PublicKey)
+			require.NoError(t, err)
+
+			offline, err := payload.Attach(signer, signOffline(t, payload.ToSign(), signature.Ed25519))
+			require.NoError(t, err)
+
+			onlineEnc, err := Encode(online)
+			require.NoError(t, err)
+
+			offlineEnc, err := Encode(offline)
+			require.NoError(t, err)
+
+			assert.Equal(t, onlineEnc, offlineEnc)
+
+			// Sr25519 signatures are randomized, but verify all the same.
+			signer, err = NewMultiAddressFromAccountID(signature.TestKeyringPairAlice.PublicKey)
+			require.NoError(t, err)
+
+			_, err = payload.Attach(signer, signOffline(t, payload.ToSign(), signature.Sr25519))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSigningPayload_Attach_Ecdsa(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+	c := Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	payload, err := NewSigningPayload(meta, c, testSignedExtensionOptions, nil)
+	require.NoError(t, err)
+
+	for _, hasher := range []signature.EcdsaHasher{signature.EcdsaHasherBlake2_256, signature.EcdsaHasherKeccak256} {
+		kp := signature.TestEcdsaKeyringPairAlice
+		kp.Hasher = hasher
+
+		online := NewExtrinsic(c)
+
+		err := online.SignWithMetadataEcdsa(kp, meta, testSignedExtensionOptions, nil)
+		require.NoError(t, err)
+
+		sig, err := kp.Sign(payload.ToSign())
+		require.NoError(t, err)
+
+		offline, err := payload.Attach(
+			online.Signature.Signer,
+			MultiSignature{IsEcdsa: true, AsEcdsa: NewEcdsaSignature(sig)},
+		)
+		require.NoError(t, err)
+
+		onlineEnc, err := Encode(online)
+		require.NoError(t, err)
+
+		offlineEnc, err := Encode(offline)
+		require.NoError(t, err)
+
+		assert.Equal(t, onlineEnc, offlineEnc)
+	}
+}
+
+func TestSigningPayload_Verify_Invalid(t *testing.T) {
+	meta := newTestSignedExtensionMetadata(t)
+	c := Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}}
+
+	payload, err := NewSigningPayload(meta, c, testSignedExtensionOptions, nil)
+	require.NoError(t, err)
+
+	alice, err := NewMultiAddressFromAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	// The signature was created for other options.
+	other, err := NewSigningPayload(meta, c, SignatureOptions{Nonce: NewUCompactFromUInt(4)}, nil)
+	require.NoError(t, err)
+
+	_, err = payload.Attach(alice, signOffline(t, other.ToSign(), signature.Sr25519))
+	assert.ErrorIs(t, err, ErrSigningPayloadSignatureInvalid)
+
+	// The signature was created by another key.
+	bob, err := NewMultiAddressFromHexAccountID("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+	require.NoError(t, err)
+
+	err = payload.Verify(bob, signOffline(t, payload.ToSign(), signature.Sr25519))
+	assert.ErrorIs(t, err, ErrSigningPayloadSignatureInvalid)
+
+	err = payload.Verify(alice, MultiSignature{IsEcdsa: true})
+	assert.ErrorIs(t, err, ErrSigningPayloadSignatureInvalid)
+
+	err = payload.Verify(MultiAddress{IsIndex: true, AsIndex: 1}, signOffline(t, payload.ToSign(), signature.Sr25519))
+	assert.ErrorIs(t, err, ErrSigningPayloadSignerNotSupported)
+}
+
+func TestSigningPayload_String(t *testing.T) {
+	opts := testSignedExtensionOptions
+	opts.Era, _ = NewMortalEra(100, 64)
+
+	payload, err := NewSigningPayload(
+		newTestSignedExtensionMetadata(t),
+		Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0}, Args: []byte{1}},
+		opts,
+		nil,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "call: 5.0 (1 bytes of args)\n"+
+		"era: mortal, period 64, phase 36\n"+
+		"nonce: 3\n"+
+		"tip: 5\n"+
+		"spec version: 4\n"+
+		"transaction version: 6\n"+
+		"genesis hash: 0x0200000000000000000000000000000000000000000000000000000000000000\n"+
+		"block hash: 0x0100000000000000000000000000000000000000000000000000000000000000\n"+
+		"metadata hash: disabled\n"+
+		"payload: 0x05000145020c14000400000006000000"+
+		"0200000000000000000000000000000000000000000000000000000000000000"+
+		"0100000000000000000000000000000000000000000000000000000000000000", payload.String())
+}
@@ -0,0 +1,195 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Well-known SS58 network prefixes, see https://github.com/paritytech/ss58-registry for the complete registry. Any
+// other prefix can be passed as well.
+const (
+	PolkadotSS58Prefix  uint16 = 0
+	KusamaSS58Prefix    uint16 = 2
+	SubstrateSS58Prefix uint16 = 42
+)
+
+// maxSS58Prefix is the highest prefix that can be encoded in the two-byte format
+const maxSS58Prefix = 16383
+
+var (
+	ErrSS58InvalidBase58   = errors.New("invalid SS58 address: not base58 encoded")
+	ErrSS58InvalidLength   = errors.New("invalid SS58 address: bad length")
+	ErrSS58InvalidChecksum = errors.New("invalid SS58 address: bad checksum")
+	ErrSS58InvalidPrefix   = errors.New("invalid SS58 prefix")
+	ErrSS58PrefixMismatch  = errors.New("SS58 address has the wrong network prefix")
+)
+
+var ss58ChecksumPrefix = []byte("SS58PRE")
+
+// ss58ChecksumLen is the length of the checksum of SS58 encoded public keys, both for 32 byte keys and 33 byte
+// compressed ECDSA keys
+const ss58ChecksumLen = 2
+
+// SS58Encode encodes the payload, usually a 32 byte public key, with the network prefix as SS58 address. Prefixes
+// below 64 are encoded in a single byte, higher ones up to 16383 in two bytes.
+func SS58Encode(networkPrefix uint16, payload []byte) (string, error) {
+	if len(payload) != AccountIDLen && len(payload) != AccountIDLen+1 {
+		return "", fmt.Errorf("%w: payload of %d bytes", ErrSS58InvalidLength, len(payload))
+	}
+
+	prefix, err := encodeSS58Prefix(networkPrefix)
+	if err != nil {
+		return "", err
+	}
+
+	data := append(prefix, payload...)
+
+	return base58.Encode(append(data, ss58Checksum(data)...)), nil
+}
+
+// SS58Decode decodes an SS58 address and returns its network prefix and its payload, usually a 32 byte public key.
+func SS58Decode(address string) (uint16, []byte, error) {
+	data := base58.Decode(address)
+	if len(data) == 0 {
+		return 0, nil, ErrSS58InvalidBase58
+	}
+
+	networkPrefix, prefixLen, err := decodeSS58Prefix(data)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	payloadLen := len(data) - prefixLen - ss58ChecksumLen
+	if payloadLen != AccountIDLen && payloadLen != AccountIDLen+1 {
+		return 0, nil, fmt.Errorf("%w: %d bytes", ErrSS58InvalidLength, len(data))
+	}
+
+	body, checksum := data[:len(data)-ss58ChecksumLen], data[len(data)-ss58ChecksumLen:]
+
+	if !bytes.Equal(checksum, ss58Checksum(body)) {
+		return 0, nil, ErrSS58InvalidChecksum
+	}
+
+	return networkPrefix, body[prefixLen:], nil
+}
+
+// NewAccountIDFromSS58 creates an AccountID from the given SS58 address of any network
+func NewAccountIDFromSS58(address string) (*AccountID, error) {
+	_, payload, err := SS58Decode(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAccountID(payload)
+}
+
+// NewAccountIDFromSS58WithPrefix creates an AccountID from the given SS58 address, which must have the given network
+// prefix
+func NewAccountIDFromSS58WithPrefix(address string, networkPrefix uint16) (*AccountID, error) {
+	prefix, payload, err := SS58Decode(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if prefix != networkPrefix {
+		return nil, fmt.Errorf("%w: expected %d, got %d", ErrSS58PrefixMismatch, networkPrefix, prefix)
+	}
+
+	return NewAccountID(payload)
+}
+
+// NewAddressFromSS58 creates an Address from the given SS58 address of any network
+func NewAddressFromSS58(address string) (Address, error) {
+	accountID, err := NewAccountIDFromSS58(address)
+	if err != nil {
+		return Address{}, err
+	}
+
+	return NewAddressFromAccountID(accountID.ToBytes())
+}
+
+// NewMultiAddressFromSS58 creates a MultiAddress from the given SS58 address of any network
+func NewMultiAddressFromSS58(address string) (MultiAddress, error) {
+	accountID, err := NewAccountIDFromSS58(address)
+	if err != nil {
+		return MultiAddress{}, err
+	}
+
+	return NewMultiAddressFromAccountID(accountID.ToBytes())
+}
+
+// ToSS58 encodes the AccountID as SS58 address with the given network prefix, e.g. SubstrateSS58Prefix
+func (a *AccountID) ToSS58(networkPrefix uint16) (string, error) {
+	return SS58Encode(networkPrefix, a.ToBytes())
+}
+
+func encodeSS58Prefix(networkPrefix uint16) ([]byte, error) {
+	if err := validateSS58Prefix(networkPrefix); err != nil {
+		return nil, err
+	}
+
+	if networkPrefix < 64 {
+		return []byte{byte(networkPrefix)}, nil
+	}
+
+	// The lower 6 bits of the first byte hold bits 2-7 of the prefix, the second byte holds bits 0-1 in its upper two
+	// bits and bits 8-13 in its lower 6 bits.
+	return []byte{
+		byte((networkPrefix&0b0000_0000_1111_1100)>>2) | 0b0100_0000,
+		byte(networkPrefix>>8) | byte((networkPrefix&0b0000_0000_0000_0011)<<6),
+	}, nil
+}
+
+func decodeSS58Prefix(data []byte) (uint16, int, error) {
+	switch {
+	case data[0] < 64:
+		return uint16(data[0]), 1, validateSS58Prefix(uint16(data[0]))
+	case data[0] < 128:
+		if len(data) < 2 {
+			return 0, 0, fmt.Errorf("%w: %d bytes", ErrSS58InvalidLength, len(data))
+		}
+
+		lower := (data[0] << 2) | (data[1] >> 6)
+		upper := data[1] & 0b0011_1111
+		networkPrefix := uint16(lower) | uint16(upper)<<8
+
+		return networkPrefix, 2, validateSS58Prefix(networkPrefix)
+	default:
+		return 0, 0, fmt.Errorf("%w: first byte %d", ErrSS58InvalidPrefix, data[0])
+	}
+}
+
+// validateSS58Prefix rejects prefixes that cannot be encoded and the prefixes 46 and 47, which are reserved
+func validateSS58Prefix(networkPrefix uint16) error {
+	if networkPrefix > maxSS58Prefix || networkPrefix == 46 || networkPrefix == 47 {
+		return fmt.Errorf("%w: %d", ErrSS58InvalidPrefix, networkPrefix)
+	}
+
+	return nil
+}
+
+func ss58Checksum(data []byte) []byte {
+	h := blake2b.Sum512(append(append([]byte{}, ss58ChecksumPrefix...), data...))
+
+	return h[:ss58ChecksumLen]
+}
@@ -0,0 +1,109 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+var testAlice = AccountID(MustHexDecodeString("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"))
+
+func TestAccountID_ToSS58(t *testing.T) {
+	for prefix, expected := range map[uint16]string{
+		PolkadotSS58Prefix:  "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5",
+		KusamaSS58Prefix:    "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F",
+		SubstrateSS58Prefix: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		// Two-byte prefixes
+		64:    subkey.SS58Encode(testAlice[:], 64),
+		1284:  subkey.SS58Encode(testAlice[:], 1284),
+		16383: subkey.SS58Encode(testAlice[:], 16383),
+	} {
+		address, err := testAlice.ToSS58(prefix)
+		require.NoError(t, err)
+		assert.Equal(t, expected, address, "prefix %d", prefix)
+
+		decodedPrefix, payload, err := SS58Decode(address)
+		require.NoError(t, err)
+		assert.Equal(t, prefix, decodedPrefix)
+		assert.Equal(t, testAlice[:], payload)
+	}
+
+	for _, prefix := range []uint16{46, 47, 16384} {
+		_, err := testAlice.ToSS58(prefix)
+		assert.ErrorIs(t, err, ErrSS58InvalidPrefix)
+	}
+}
+
+func TestSS58Encode_InvalidLength(t *testing.T) {
+	_, err := SS58Encode(SubstrateSS58Prefix, []byte{1, 2, 3})
+	assert.ErrorIs(t, err, ErrSS58InvalidLength)
+}
+
+func TestSS58Decode_Errors(t *testing.T) {
+	_, _, err := SS58Decode("0OIl")
+	assert.ErrorIs(t, err, ErrSS58InvalidBase58)
+
+	_, _, err = SS58Decode("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ")
+	assert.ErrorIs(t, err, ErrSS58InvalidChecksum)
+
+	_, _, err = SS58Decode("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKut")
+	assert.ErrorIs(t, err, ErrSS58InvalidLength)
+
+	_, _, err = SS58Decode(subkey.SS58Encode(testAlice[:], 46))
+	assert.ErrorIs(t, err, ErrSS58InvalidPrefix)
+}
+
+func TestNewAccountIDFromSS58(t *testing.T) {
+	accountID, err := NewAccountIDFromSS58("15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5")
+	require.NoError(t, err)
+	assert.Equal(t, testAlice, *accountID)
+
+	accountID, err = NewAccountIDFromSS58WithPrefix(
+		"5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		SubstrateSS58Prefix,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, testAlice, *accountID)
+
+	_, err = NewAccountIDFromSS58WithPrefix("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", KusamaSS58Prefix)
+	assert.ErrorIs(t, err, ErrSS58PrefixMismatch)
+
+	_, err = NewAccountIDFromSS58(subkey.SS58Encode(append(testAlice[:], 2), SubstrateSS58Prefix))
+	assert.ErrorIs(t, err, ErrInvalidAccountIDBytes)
+}
+
+func TestNewAddressFromSS58(t *testing.T) {
+	address, err := NewAddressFromSS58("HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F")
+	require.NoError(t, err)
+	assert.Equal(t, Address{IsAccountID: true, AsAccountID: testAlice}, address)
+
+	multiAddress, err := NewMultiAddressFromSS58("HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F")
+	require.NoError(t, err)
+	assert.Equal(t, MultiAddress{IsID: true, AsID: testAlice}, multiAddress)
+
+	_, err = NewAddressFromSS58("invalid")
+	assert.Error(t, err)
+
+	_, err = NewMultiAddressFromSS58("invalid")
+	assert.Error(t, err)
+}
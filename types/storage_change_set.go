@@ -29,6 +29,8 @@ type StorageChangeSet struct {
 	Changes []KeyValueOption `json:"changes"`
 }
 
+// KeyValueOption holds a storage key and its value, if any. A key that was removed from storage is returned by the
+// node with a null value, in which case HasStorageData is false.
 type KeyValueOption struct {
 	StorageKey     StorageKey
 	HasStorageData bool
@@ -36,7 +38,7 @@ type KeyValueOption struct {
 }
 
 func (r *KeyValueOption) UnmarshalJSON(b []byte) error {
-	var tmp []string
+	var tmp []*string
 	if err := json.Unmarshal(b, &tmp); err != nil {
 		return err
 	}
@@ -44,15 +46,20 @@ func (r *KeyValueOption) UnmarshalJSON(b []byte) error {
 	case 0:
 		return fmt.Errorf("expected at least one entry for KeyValueOption")
 	case 2:
-		r.HasStorageData = true
-		data, err := codec.HexDecodeString(tmp[1])
-		if err != nil {
-			return err
+		if tmp[1] != nil {
+			r.HasStorageData = true
+			data, err := codec.HexDecodeString(*tmp[1])
+			if err != nil {
+				return err
+			}
+			r.StorageData = data
 		}
-		r.StorageData = data
 		fallthrough
 	case 1:
-		key, err := codec.HexDecodeString(tmp[0])
+		if tmp[0] == nil {
+			return fmt.Errorf("expected a storage key for KeyValueOption, got null")
+		}
+		key, err := codec.HexDecodeString(*tmp[0])
 		if err != nil {
 			return err
 		}
@@ -82,6 +82,20 @@ func TestKeyValueOption_UnmarshalJSON2(t *testing.T) {
 	}, kv)
 }
 
+func TestKeyValueOption_UnmarshalJSONNullValue(t *testing.T) {
+	s := []byte("[\"0xcc956bdb7605e3547539f321ac2bc95c\",null]")
+
+	var kv KeyValueOption
+
+	err := json.Unmarshal(s, &kv)
+	assert.NoError(t, err)
+
+	assert.Equal(t, KeyValueOption{
+		StorageKey:     MustHexDecodeString("0xcc956bdb7605e3547539f321ac2bc95c"),
+		HasStorageData: false,
+	}, kv)
+}
+
 func TestKeyValueOption_UnmarshalMarshalJSON(t *testing.T) {
 	s := []byte("[\"0xcc956bdb7605e3547539f321ac2bc95c\",\"0x0800000000000000000001000000000000\"]")
 
@@ -125,3 +139,12 @@ func TestKeyValueOption_UnmarshalErrorTooMany(t *testing.T) {
 	err := json.Unmarshal(s, &kv)
 	assert.Errorf(t, err, "expected 1 or 2 entries for KeyValueOption, got 3")
 }
+
+func TestKeyValueOption_UnmarshalErrorNullKey(t *testing.T) {
+	s := []byte("[null, \"0x12\"]")
+
+	var kv KeyValueOption
+
+	err := json.Unmarshal(s, &kv)
+	assert.Errorf(t, err, "expected a storage key for KeyValueOption, got null")
+}
@@ -0,0 +1,27 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// SyncState contains the block numbers of the sync of a node
+type SyncState struct {
+	// StartingBlock is the block the node started syncing from
+	StartingBlock U64
+	// CurrentBlock is the best block of the node
+	CurrentBlock U64
+	// HighestBlock is the highest block known to the node, zero if it is unknown
+	HighestBlock U64
+}
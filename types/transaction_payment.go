@@ -0,0 +1,282 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// RuntimeDispatchInfo is the dispatch information and the partial fee of an extrinsic, as returned by
+// TransactionPaymentApi_query_info and payment_queryInfo.
+type RuntimeDispatchInfo struct {
+	// Weight of the extrinsic
+	Weight Weight
+	// Class of the extrinsic
+	Class DispatchClass
+	// PartialFee is the inclusion fee of the extrinsic, without the tip
+	PartialFee U128
+}
+
+func (r *RuntimeDispatchInfo) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&r.Weight); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&r.Class); err != nil {
+		return err
+	}
+
+	return decoder.Decode(&r.PartialFee)
+}
+
+func (r RuntimeDispatchInfo) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(r.Weight); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(r.Class); err != nil {
+		return err
+	}
+
+	return encoder.Encode(r.PartialFee)
+}
+
+// UnmarshalJSON decodes the JSON returned by payment_queryInfo. The weight is either a V1 weight, which is a number,
+// or a V2 weight, which is an object with the ref time and the proof size.
+func (r *RuntimeDispatchInfo) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		Weight     json.RawMessage `json:"weight"`
+		Class      string          `json:"class"`
+		PartialFee json.RawMessage `json:"partialFee"`
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	weight, err := unmarshalWeightJSON(tmp.Weight)
+	if err != nil {
+		return err
+	}
+
+	class, err := newDispatchClassFromString(tmp.Class)
+	if err != nil {
+		return err
+	}
+
+	partialFee, err := unmarshalNumberOrHexJSON(tmp.PartialFee)
+	if err != nil {
+		return err
+	}
+
+	r.Weight = weight
+	r.Class = class
+	r.PartialFee = NewU128(*partialFee)
+
+	return nil
+}
+
+// RuntimeDispatchInfoV1 is the RuntimeDispatchInfo of runtimes that still used V1 weights, as returned by version 1
+// of TransactionPaymentApi_query_info.
+type RuntimeDispatchInfoV1 struct {
+	// Weight of the extrinsic
+	Weight WeightV1
+	// Class of the extrinsic
+	Class DispatchClass
+	// PartialFee is the inclusion fee of the extrinsic, without the tip
+	PartialFee U128
+}
+
+// ToRuntimeDispatchInfo converts the dispatch info to a RuntimeDispatchInfo with a V2 weight without a proof size
+func (r RuntimeDispatchInfoV1) ToRuntimeDispatchInfo() RuntimeDispatchInfo {
+	return RuntimeDispatchInfo{
+		Weight:     r.Weight.ToWeight(),
+		Class:      r.Class,
+		PartialFee: r.PartialFee,
+	}
+}
+
+// InclusionFee is the fee that has to be paid for an extrinsic to be included in a block.
+type InclusionFee struct {
+	// BaseFee is the minimum amount that has to be paid for any extrinsic
+	BaseFee U128
+	// LenFee is the fee for the length of the extrinsic
+	LenFee U128
+	// AdjustedWeightFee is the fee for the weight of the extrinsic, adjusted by the fee multiplier
+	AdjustedWeightFee U128
+}
+
+func (i *InclusionFee) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&i.BaseFee); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&i.LenFee); err != nil {
+		return err
+	}
+
+	return decoder.Decode(&i.AdjustedWeightFee)
+}
+
+func (i InclusionFee) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(i.BaseFee); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(i.LenFee); err != nil {
+		return err
+	}
+
+	return encoder.Encode(i.AdjustedWeightFee)
+}
+
+// UnmarshalJSON decodes the JSON returned by payment_queryFeeDetails, where the fees are numbers or hex strings.
+func (i *InclusionFee) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		BaseFee           json.RawMessage `json:"baseFee"`
+		LenFee            json.RawMessage `json:"lenFee"`
+		AdjustedWeightFee json.RawMessage `json:"adjustedWeightFee"`
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	fees := make([]*big.Int, 3)
+
+	for j, raw := range []json.RawMessage{tmp.BaseFee, tmp.LenFee, tmp.AdjustedWeightFee} {
+		fee, err := unmarshalNumberOrHexJSON(raw)
+		if err != nil {
+			return err
+		}
+
+		fees[j] = fee
+	}
+
+	i.BaseFee = NewU128(*fees[0])
+	i.LenFee = NewU128(*fees[1])
+	i.AdjustedWeightFee = NewU128(*fees[2])
+
+	return nil
+}
+
+// FeeDetails is the breakdown of the fee of an extrinsic, as returned by TransactionPaymentApi_query_fee_details and
+// payment_queryFeeDetails.
+type FeeDetails struct {
+	// InclusionFee is not set for extrinsics that don't pay fees, e.g. unsigned extrinsics
+	InclusionFee Option[InclusionFee]
+	// Tip of the extrinsic. It is not returned by payment_queryFeeDetails.
+	Tip U128
+}
+
+func (f *FeeDetails) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&f.InclusionFee); err != nil {
+		return err
+	}
+
+	return decoder.Decode(&f.Tip)
+}
+
+func (f FeeDetails) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(f.InclusionFee); err != nil {
+		return err
+	}
+
+	return encoder.Encode(f.Tip)
+}
+
+// UnmarshalJSON decodes the JSON returned by payment_queryFeeDetails.
+func (f *FeeDetails) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		InclusionFee *InclusionFee `json:"inclusionFee"`
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	f.InclusionFee = NewEmptyOption[InclusionFee]()
+
+	if tmp.InclusionFee != nil {
+		f.InclusionFee = NewOption(*tmp.InclusionFee)
+	}
+
+	f.Tip = NewU128(*big.NewInt(0))
+
+	return nil
+}
+
+func unmarshalWeightJSON(b []byte) (Weight, error) {
+	var refTime uint64
+
+	// V1 weights only consist of the ref time
+	if err := json.Unmarshal(b, &refTime); err == nil {
+		return NewWeightV1(refTime).ToWeight(), nil
+	}
+
+	var tmp map[string]uint64
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return Weight{}, fmt.Errorf("invalid weight: %w", err)
+	}
+
+	return NewWeight(
+		NewUCompactFromUInt(tmp["refTime"]+tmp["ref_time"]),
+		NewUCompactFromUInt(tmp["proofSize"]+tmp["proof_size"]),
+	), nil
+}
+
+// unmarshalNumberOrHexJSON decodes a number that is either a JSON number, a decimal string or a hex string.
+func unmarshalNumberOrHexJSON(b []byte) (*big.Int, error) {
+	var s string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		s = string(b)
+	}
+
+	i, ok := new(big.Int), false
+
+	if strings.HasPrefix(s, "0x") {
+		i, ok = i.SetString(s[2:], 16)
+	} else {
+		i, ok = i.SetString(s, 10)
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("invalid number: %s", b)
+	}
+
+	return i, nil
+}
+
+func newDispatchClassFromString(s string) (DispatchClass, error) {
+	switch strings.ToLower(s) {
+	case "normal":
+		return DispatchClass{IsNormal: true}, nil
+	case "operational":
+		return DispatchClass{IsOperational: true}, nil
+	case "mandatory":
+		return DispatchClass{IsMandatory: true}, nil
+	}
+
+	return DispatchClass{}, fmt.Errorf("invalid dispatch class: %s", s)
+}
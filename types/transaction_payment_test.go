@@ -0,0 +1,150 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testRuntimeDispatchInfo = RuntimeDispatchInfo{
+		Weight:     NewWeight(NewUCompactFromUInt(100), NewUCompactFromUInt(20)),
+		Class:      DispatchClass{IsOperational: true},
+		PartialFee: NewU128(*big.NewInt(1000)),
+	}
+
+	testFeeDetails = FeeDetails{
+		InclusionFee: NewOption(InclusionFee{
+			BaseFee:           NewU128(*big.NewInt(1)),
+			LenFee:            NewU128(*big.NewInt(2)),
+			AdjustedWeightFee: NewU128(*big.NewInt(3)),
+		}),
+		Tip: NewU128(*big.NewInt(4)),
+	}
+
+	testFeeDetailsNoInclusionFee = FeeDetails{
+		InclusionFee: NewEmptyOption[InclusionFee](),
+		Tip:          NewU128(*big.NewInt(0)),
+	}
+)
+
+func TestRuntimeDispatchInfo_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testRuntimeDispatchInfo, MustHexDecodeString("0x91015001e8030000000000000000000000000000")},
+	})
+}
+
+func TestRuntimeDispatchInfo_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x91015001e8030000000000000000000000000000"), testRuntimeDispatchInfo},
+	})
+}
+
+func TestRuntimeDispatchInfoV1_Decode(t *testing.T) {
+	var info RuntimeDispatchInfoV1
+
+	err := DecodeFromHex("0x640000000000000001e8030000000000000000000000000000", &info)
+	assert.NoError(t, err)
+
+	assert.Equal(t, RuntimeDispatchInfo{
+		Weight:     NewWeight(NewUCompactFromUInt(100), NewUCompactFromUInt(0)),
+		Class:      DispatchClass{IsOperational: true},
+		PartialFee: NewU128(*big.NewInt(1000)),
+	}, info.ToRuntimeDispatchInfo())
+}
+
+func TestRuntimeDispatchInfo_UnmarshalJSON(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		json string
+		exp  RuntimeDispatchInfo
+	}{
+		{
+			name: "weight v2",
+			json: `{"weight":{"ref_time":100,"proof_size":20},"class":"operational","partialFee":"1000"}`,
+			exp:  testRuntimeDispatchInfo,
+		},
+		{
+			name: "weight v2 camel case",
+			json: `{"weight":{"refTime":100,"proofSize":20},"class":"operational","partialFee":1000}`,
+			exp:  testRuntimeDispatchInfo,
+		},
+		{
+			name: "weight v1",
+			json: `{"weight":100,"class":"normal","partialFee":"0x3e8"}`,
+			exp: RuntimeDispatchInfo{
+				Weight:     NewWeight(NewUCompactFromUInt(100), NewUCompactFromUInt(0)),
+				Class:      DispatchClass{IsNormal: true},
+				PartialFee: NewU128(*big.NewInt(1000)),
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var info RuntimeDispatchInfo
+
+			assert.NoError(t, json.Unmarshal([]byte(test.json), &info))
+			assert.Equal(t, test.exp, info)
+		})
+	}
+}
+
+func TestRuntimeDispatchInfo_UnmarshalJSONErrors(t *testing.T) {
+	for _, j := range []string{
+		`{"weight":"a","class":"normal","partialFee":"1"}`,
+		`{"weight":1,"class":"unknown","partialFee":"1"}`,
+		`{"weight":1,"class":"normal","partialFee":"0xzz"}`,
+		`[]`,
+	} {
+		var info RuntimeDispatchInfo
+
+		assert.Error(t, json.Unmarshal([]byte(j), &info), j)
+	}
+}
+
+func TestFeeDetails_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, testFeeDetails)
+	AssertRoundtrip(t, testFeeDetailsNoInclusionFee)
+	AssertDecodeNilData[FeeDetails](t)
+}
+
+func TestFeeDetails_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testFeeDetailsNoInclusionFee, MustHexDecodeString("0x0000000000000000000000000000000000")},
+	})
+}
+
+func TestFeeDetails_UnmarshalJSON(t *testing.T) {
+	var details FeeDetails
+
+	err := json.Unmarshal([]byte(`{"inclusionFee":{"baseFee":1,"lenFee":"0x2","adjustedWeightFee":"0x03"}}`), &details)
+	assert.NoError(t, err)
+
+	exp := testFeeDetails
+	exp.Tip = NewU128(*big.NewInt(0))
+	assert.Equal(t, exp, details)
+
+	err = json.Unmarshal([]byte(`{"inclusionFee":null}`), &details)
+	assert.NoError(t, err)
+	assert.Equal(t, testFeeDetailsNoInclusionFee, details)
+}
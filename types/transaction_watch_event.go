@@ -0,0 +1,57 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// TransactionWatchEventType is the type of an event received via the transactionWatch_v1_submitAndWatch subscription
+type TransactionWatchEventType string
+
+const (
+	TransactionWatchValidated              TransactionWatchEventType = "validated"
+	TransactionWatchBestChainBlockIncluded TransactionWatchEventType = "bestChainBlockIncluded"
+	TransactionWatchFinalized              TransactionWatchEventType = "finalized"
+	TransactionWatchError                  TransactionWatchEventType = "error"
+	TransactionWatchInvalid                TransactionWatchEventType = "invalid"
+	TransactionWatchDropped                TransactionWatchEventType = "dropped"
+)
+
+// TransactionWatchEvent is an event received via the transactionWatch_v1_submitAndWatch subscription.
+//
+// Only the fields that belong to the Event type are set.
+type TransactionWatchEvent struct {
+	Event TransactionWatchEventType `json:"event"`
+	// Block is set for finalized events and for bestChainBlockIncluded events, unless the transaction
+	// is no longer included in the best chain
+	Block *TransactionBlock `json:"block,omitempty"`
+	// Error is set for error, invalid and dropped events
+	Error string `json:"error,omitempty"`
+}
+
+// IsFinal returns true if no more events are generated for the transaction after this event
+func (e TransactionWatchEvent) IsFinal() bool {
+	switch e.Event {
+	case TransactionWatchFinalized, TransactionWatchError, TransactionWatchInvalid, TransactionWatchDropped:
+		return true
+	default:
+		return false
+	}
+}
+
+// TransactionBlock identifies the block that includes a transaction and the index of the transaction in its body
+type TransactionBlock struct {
+	Hash  Hash   `json:"hash"`
+	Index uint32 `json:"index"`
+}
@@ -0,0 +1,83 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionWatchEvent_UnmarshalJSON(t *testing.T) {
+	blockHash := NewHash(MustHexDecodeString("0xa230d0b6dc75868237b08d71618f3d19526b8aa346d94c792a4fce0a945b1e3f"))
+
+	tests := []struct {
+		input    string
+		expected TransactionWatchEvent
+	}{
+		{
+			input:    `{"event":"validated"}`,
+			expected: TransactionWatchEvent{Event: TransactionWatchValidated},
+		},
+		{
+			input: `{"event":"bestChainBlockIncluded","block":{"hash":"` + blockHash.Hex() + `","index":2}}`,
+			expected: TransactionWatchEvent{
+				Event: TransactionWatchBestChainBlockIncluded,
+				Block: &TransactionBlock{Hash: blockHash, Index: 2},
+			},
+		},
+		{
+			input:    `{"event":"bestChainBlockIncluded","block":null}`,
+			expected: TransactionWatchEvent{Event: TransactionWatchBestChainBlockIncluded},
+		},
+		{
+			input: `{"event":"finalized","block":{"hash":"` + blockHash.Hex() + `","index":0}}`,
+			expected: TransactionWatchEvent{
+				Event: TransactionWatchFinalized,
+				Block: &TransactionBlock{Hash: blockHash},
+			},
+		},
+		{
+			input:    `{"event":"invalid","error":"bad signature"}`,
+			expected: TransactionWatchEvent{Event: TransactionWatchInvalid, Error: "bad signature"},
+		},
+		{
+			input:    `{"event":"dropped","error":"pool full"}`,
+			expected: TransactionWatchEvent{Event: TransactionWatchDropped, Error: "pool full"},
+		},
+	}
+
+	for _, test := range tests {
+		var event TransactionWatchEvent
+
+		err := json.Unmarshal([]byte(test.input), &event)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, event)
+	}
+}
+
+func TestTransactionWatchEvent_IsFinal(t *testing.T) {
+	assert.False(t, TransactionWatchEvent{Event: TransactionWatchValidated}.IsFinal())
+	assert.False(t, TransactionWatchEvent{Event: TransactionWatchBestChainBlockIncluded}.IsFinal())
+	assert.True(t, TransactionWatchEvent{Event: TransactionWatchFinalized}.IsFinal())
+	assert.True(t, TransactionWatchEvent{Event: TransactionWatchError}.IsFinal())
+	assert.True(t, TransactionWatchEvent{Event: TransactionWatchInvalid}.IsFinal())
+	assert.True(t, TransactionWatchEvent{Event: TransactionWatchDropped}.IsFinal())
+}
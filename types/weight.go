@@ -31,3 +31,17 @@ func NewWeight(refTime UCompact, proofSize UCompact) Weight {
 		ProofSize: proofSize,
 	}
 }
+
+// WeightV1 is the weight of runtimes before the proof size was introduced, it only consists of the ref time. It is
+// kept to decode historical data, e.g. via DispatchInfoV1.
+type WeightV1 U64
+
+// NewWeightV1 creates a new WeightV1 type
+func NewWeightV1(refTime uint64) WeightV1 {
+	return WeightV1(refTime)
+}
+
+// ToWeight converts the V1 weight to a Weight without a proof size
+func (w WeightV1) ToWeight() Weight {
+	return NewWeight(NewUCompactFromUInt(uint64(w)), NewUCompactFromUInt(0))
+}
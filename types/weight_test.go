@@ -22,6 +22,7 @@ import (
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
 )
 
 var (
@@ -67,3 +68,14 @@ func TestWeight_Eq(t *testing.T) {
 		{testWeight, NewBool(false), false},
 	})
 }
+
+func TestWeightV1_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[WeightV1](t, 100)
+	AssertEncode(t, []EncodingAssert{
+		{NewWeightV1(11), MustHexDecodeString("0x0b00000000000000")},
+	})
+}
+
+func TestWeightV1_ToWeight(t *testing.T) {
+	assert.Equal(t, NewWeight(NewUCompactFromUInt(11), NewUCompactFromUInt(0)), NewWeightV1(11).ToWeight())
+}
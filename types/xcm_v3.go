@@ -0,0 +1,317 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+
+// AssetIDV3 is the AssetId of XCM v3. The AssetId of XCM v4 is only the location of the asset, see AssetV4.
+type AssetIDV3 struct {
+	IsConcrete    bool
+	MultiLocation MultiLocationV3
+
+	IsAbstract  bool
+	AbstractKey [32]U8
+}
+
+func (a *AssetIDV3) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		a.IsConcrete = true
+
+		return decoder.Decode(&a.MultiLocation)
+	case 1:
+		a.IsAbstract = true
+
+		return decoder.Decode(&a.AbstractKey)
+	}
+
+	return nil
+}
+
+func (a AssetIDV3) Encode(encoder scale.Encoder) error {
+	switch {
+	case a.IsConcrete:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.MultiLocation)
+	case a.IsAbstract:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.AbstractKey)
+	}
+
+	return nil
+}
+
+// AssetInstanceV3 is the AssetInstance of XCM v3 and v4, which identifies a non-fungible asset.
+type AssetInstanceV3 struct {
+	IsUndefined bool
+
+	IsIndex bool
+	Index   UCompact
+
+	IsArray4 bool
+	Array4   [4]U8
+
+	IsArray8 bool
+	Array8   [8]U8
+
+	IsArray16 bool
+	Array16   [16]U8
+
+	IsArray32 bool
+	Array32   [32]U8
+}
+
+func (a *AssetInstanceV3) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		a.IsUndefined = true
+	case 1:
+		a.IsIndex = true
+
+		return decoder.Decode(&a.Index)
+	case 2:
+		a.IsArray4 = true
+
+		return decoder.Decode(&a.Array4)
+	case 3:
+		a.IsArray8 = true
+
+		return decoder.Decode(&a.Array8)
+	case 4:
+		a.IsArray16 = true
+
+		return decoder.Decode(&a.Array16)
+	case 5:
+		a.IsArray32 = true
+
+		return decoder.Decode(&a.Array32)
+	}
+
+	return nil
+}
+
+func (a AssetInstanceV3) Encode(encoder scale.Encoder) error {
+	switch {
+	case a.IsUndefined:
+		return encoder.PushByte(0)
+	case a.IsIndex:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.Index)
+	case a.IsArray4:
+		if err := encoder.PushByte(2); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.Array4)
+	case a.IsArray8:
+		if err := encoder.PushByte(3); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.Array8)
+	case a.IsArray16:
+		if err := encoder.PushByte(4); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.Array16)
+	case a.IsArray32:
+		if err := encoder.PushByte(5); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.Array32)
+	}
+
+	return nil
+}
+
+// FungibilityV3 is the Fungibility of XCM v3 and v4, the amount of a fungible asset or the instance of a non-fungible
+// one.
+type FungibilityV3 struct {
+	IsFungible bool
+	Amount     UCompact
+
+	IsNonFungible bool
+	AssetInstance AssetInstanceV3
+}
+
+func (f *FungibilityV3) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		f.IsFungible = true
+
+		return decoder.Decode(&f.Amount)
+	case 1:
+		f.IsNonFungible = true
+
+		return decoder.Decode(&f.AssetInstance)
+	}
+
+	return nil
+}
+
+func (f FungibilityV3) Encode(encoder scale.Encoder) error {
+	switch {
+	case f.IsFungible:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(f.Amount)
+	case f.IsNonFungible:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(f.AssetInstance)
+	}
+
+	return nil
+}
+
+// MultiAssetV3 is the MultiAsset of XCM v3.
+type MultiAssetV3 struct {
+	ID          AssetIDV3
+	Fungibility FungibilityV3
+}
+
+// MultiAssetsV3 are the MultiAssets of XCM v3, which have to be sorted and deduplicated.
+type MultiAssetsV3 []MultiAssetV3
+
+// AssetV4 is the Asset of XCM v4, which is identified by its location.
+type AssetV4 struct {
+	ID          LocationV4
+	Fungibility FungibilityV3
+}
+
+// AssetsV4 are the Assets of XCM v4, which have to be sorted and deduplicated.
+type AssetsV4 []AssetV4
+
+// VersionedAssets is the VersionedAssets of the XCM pallets of runtimes that support XCM v3 and v4. See
+// VersionedMultiAssets for older runtimes.
+type VersionedAssets struct {
+	IsV3 bool
+	V3   MultiAssetsV3
+
+	IsV4 bool
+	V4   AssetsV4
+}
+
+func (v *VersionedAssets) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 3:
+		v.IsV3 = true
+
+		return decoder.Decode(&v.V3)
+	case 4:
+		v.IsV4 = true
+
+		return decoder.Decode(&v.V4)
+	}
+
+	return nil
+}
+
+func (v VersionedAssets) Encode(encoder scale.Encoder) error {
+	switch {
+	case v.IsV3:
+		if err := encoder.PushByte(3); err != nil {
+			return err
+		}
+
+		return encoder.Encode(v.V3)
+	case v.IsV4:
+		if err := encoder.PushByte(4); err != nil {
+			return err
+		}
+
+		return encoder.Encode(v.V4)
+	}
+
+	return nil
+}
+
+// WeightLimitV3 is the WeightLimit of XCM v3 and v4. Unlike WeightLimit, the limit is a two-dimensional Weight.
+type WeightLimitV3 struct {
+	IsUnlimited bool
+
+	IsLimited bool
+	Limit     Weight
+}
+
+func (w *WeightLimitV3) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		w.IsUnlimited = true
+	case 1:
+		w.IsLimited = true
+
+		return decoder.Decode(&w.Limit)
+	}
+
+	return nil
+}
+
+func (w WeightLimitV3) Encode(encoder scale.Encoder) error {
+	switch {
+	case w.IsUnlimited:
+		return encoder.PushByte(0)
+	case w.IsLimited:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(w.Limit)
+	}
+
+	return nil
+}
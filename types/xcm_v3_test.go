@@ -0,0 +1,202 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	fuzz "github.com/google/gofuzz"
+)
+
+var (
+	testHereV3 = MultiLocationV3{Interior: JunctionsV3{IsHere: true}}
+
+	testFungibilityV3 = FungibilityV3{
+		IsFungible: true,
+		Amount:     NewUCompactFromUInt(10_000_000_000),
+	}
+
+	testVersionedAssets1 = VersionedAssets{
+		IsV3: true,
+		V3: MultiAssetsV3{
+			{
+				ID:          AssetIDV3{IsConcrete: true, MultiLocation: testHereV3},
+				Fungibility: testFungibilityV3,
+			},
+		},
+	}
+	testVersionedAssets2 = VersionedAssets{
+		IsV4: true,
+		V4: AssetsV4{
+			{
+				ID:          testHereV3,
+				Fungibility: testFungibilityV3,
+			},
+		},
+	}
+	testVersionedAssets3 = VersionedAssets{
+		IsV4: true,
+		V4: AssetsV4{
+			{
+				ID: testMultiLocationV3n1,
+				Fungibility: FungibilityV3{
+					IsNonFungible: true,
+					AssetInstance: AssetInstanceV3{IsIndex: true, Index: NewUCompactFromUInt(7)},
+				},
+			},
+		},
+	}
+
+	assetV3FuzzOpts = CombineFuzzOpts(
+		multiLocationV3FuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(a *AssetIDV3, c fuzz.Continue) {
+				if c.RandBool() {
+					a.IsConcrete = true
+
+					c.Fuzz(&a.MultiLocation)
+
+					return
+				}
+
+				a.IsAbstract = true
+
+				c.Fuzz(&a.AbstractKey)
+			}),
+			WithFuzzFuncs(func(a *AssetInstanceV3, c fuzz.Continue) {
+				switch c.Intn(6) {
+				case 0:
+					a.IsUndefined = true
+				case 1:
+					a.IsIndex = true
+
+					c.Fuzz(&a.Index)
+				case 2:
+					a.IsArray4 = true
+
+					c.Fuzz(&a.Array4)
+				case 3:
+					a.IsArray8 = true
+
+					c.Fuzz(&a.Array8)
+				case 4:
+					a.IsArray16 = true
+
+					c.Fuzz(&a.Array16)
+				case 5:
+					a.IsArray32 = true
+
+					c.Fuzz(&a.Array32)
+				}
+			}),
+			WithFuzzFuncs(func(f *FungibilityV3, c fuzz.Continue) {
+				if c.RandBool() {
+					f.IsFungible = true
+
+					c.Fuzz(&f.Amount)
+
+					return
+				}
+
+				f.IsNonFungible = true
+
+				c.Fuzz(&f.AssetInstance)
+			}),
+			WithFuzzFuncs(func(v *VersionedAssets, c fuzz.Continue) {
+				if c.RandBool() {
+					v.IsV3 = true
+
+					c.Fuzz(&v.V3)
+
+					return
+				}
+
+				v.IsV4 = true
+
+				c.Fuzz(&v.V4)
+			}),
+		},
+	)
+)
+
+func TestVersionedAssets_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[VersionedAssets](t, 100, assetV3FuzzOpts...)
+	AssertDecodeNilData[VersionedAssets](t)
+	AssertEncodeEmptyObj[VersionedAssets](t, 0)
+}
+
+func TestVersionedAssets_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testVersionedAssets1, MustHexDecodeString("0x0304000000000700e40b5402")},
+		{testVersionedAssets2, MustHexDecodeString("0x04040000000700e40b5402")},
+		{testVersionedAssets3, MustHexDecodeString("0x0404010100a10f01011c")},
+	})
+}
+
+func TestVersionedAssets_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x0304000000000700e40b5402"), testVersionedAssets1},
+		{MustHexDecodeString("0x04040000000700e40b5402"), testVersionedAssets2},
+		{MustHexDecodeString("0x0404010100a10f01011c"), testVersionedAssets3},
+	})
+}
+
+var (
+	testWeightLimitV3n1 = WeightLimitV3{
+		IsUnlimited: true,
+	}
+	testWeightLimitV3n2 = WeightLimitV3{
+		IsLimited: true,
+		Limit:     NewWeight(NewUCompactFromUInt(1000), NewUCompactFromUInt(10)),
+	}
+
+	weightLimitV3FuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(w *WeightLimitV3, c fuzz.Continue) {
+			if c.RandBool() {
+				w.IsUnlimited = true
+				return
+			}
+
+			w.IsLimited = true
+
+			c.Fuzz(&w.Limit)
+		}),
+	}
+)
+
+func TestWeightLimitV3_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[WeightLimitV3](t, 100, weightLimitV3FuzzOpts...)
+	AssertDecodeNilData[WeightLimitV3](t)
+	AssertEncodeEmptyObj[WeightLimitV3](t, 0)
+}
+
+func TestWeightLimitV3_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testWeightLimitV3n1, MustHexDecodeString("0x00")},
+		{testWeightLimitV3n2, MustHexDecodeString("0x01a10f28")},
+	})
+}
+
+func TestWeightLimitV3_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x00"), testWeightLimitV3n1},
+		{MustHexDecodeString("0x01a10f28"), testWeightLimitV3n2},
+	})
+}
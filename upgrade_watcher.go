@@ -0,0 +1,485 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsrpc
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/tracing"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	ErrUpgradeWatcherNotStarted = libErr.Error("upgrade watcher not started")
+	ErrUpgradeWatcherStale      = libErr.Error("upgrade watcher stale")
+	ErrUpgradeWatcherSubscribe  = libErr.Error("upgrade watcher runtime version subscription")
+	ErrUpgradeWatcherRefresh    = libErr.Error("upgrade watcher refresh")
+)
+
+// RuntimeRegistries are the registries of a runtime, built from its metadata.
+type RuntimeRegistries struct {
+	Call  registry.CallRegistry
+	Event registry.EventRegistry
+	Error registry.ErrorRegistry
+}
+
+// Upgrade is a runtime upgrade that was observed by an UpgradeWatcher.
+type Upgrade struct {
+	OldVersion types.RuntimeVersion
+	NewVersion types.RuntimeVersion
+
+	// Metadata and Registries belong to the new runtime.
+	Metadata   *types.Metadata
+	Registries *RuntimeRegistries
+
+	// BlockHash is the hash of the first block with the new runtime, i.e. the block the upgrade was enacted in. It is
+	// the latest block if the enactment could not be located, e.g. because the state of older blocks was pruned.
+	BlockHash types.Hash
+
+	// Missed is true if the upgrade happened while notifications were missed, e.g. while the client was
+	// disconnected, and was detected by comparing the runtime versions afterwards.
+	Missed bool
+}
+
+// UpgradeCallback is called with every runtime upgrade observed by an UpgradeWatcher. It must not block.
+type UpgradeCallback func(upgrade Upgrade)
+
+// UpgradeWatcherOptions configure an UpgradeWatcher. Zero values are replaced by the defaults.
+type UpgradeWatcherOptions struct {
+	// RetryInterval is the delay before a failed refresh is retried, it defaults to 5 seconds.
+	RetryInterval time.Duration
+
+	// OnError, if set, is called when a refresh failed. It must not block.
+	OnError func(err error)
+
+	// Logger, if set, logs runtime upgrades, failed refreshes and subscription gaps with the keys spec_version and
+	// block_hash. Nothing is logged by default.
+	Logger *slog.Logger
+
+	// Tracer, if set, traces the creation of the registries of new runtimes with the key spec_version. Nothing is
+	// traced by default.
+	Tracer tracing.Tracer
+}
+
+// RuntimeVersionSubscription is the subscription of runtime versions an UpgradeWatcher watches, e.g. a
+// *state.RuntimeVersionSubscription.
+type RuntimeVersionSubscription interface {
+	Chan() <-chan types.RuntimeVersion
+	Err() <-chan error
+	Gap() <-chan struct{}
+	Unsubscribe()
+}
+
+// watchedRuntime is the runtime the watcher knows about. It is never modified once it is stored.
+type watchedRuntime struct {
+	version     types.RuntimeVersion
+	blockNumber uint64
+	meta        *types.Metadata // nil if the last refresh failed
+	registries  *RuntimeRegistries
+}
+
+// UpgradeWatcher watches runtime version changes. Whenever the spec or the transaction version changes, it fetches
+// the metadata of the new runtime, rebuilds the registries and calls the registered callbacks, so that the
+// components that depend on the runtime, e.g. signing, event retrieval and storage helpers, stay consistent.
+//
+// The watcher implements submit.RuntimeCache, so submitters can sign with the metadata of the latest runtime.
+type UpgradeWatcher struct {
+	stateRPC        state.Provider
+	chainRPC        chain.Provider
+	registryFactory registry.Factory
+	opts            UpgradeWatcherOptions
+
+	mu        sync.Mutex
+	runtime   *watchedRuntime
+	callbacks map[uint64]UpgradeCallback
+	nextID    uint64
+}
+
+// NewUpgradeWatcher creates an UpgradeWatcher that reads runtime versions and metadata via the RPCs and builds the
+// registries via the registry factory. The watcher starts watching once Run or Watch is called.
+func NewUpgradeWatcher(
+	stateRPC state.Provider,
+	chainRPC chain.Provider,
+	registryFactory registry.Factory,
+	opts UpgradeWatcherOptions,
+) *UpgradeWatcher {
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = defaultMetadataCacheRetryInterval
+	}
+
+	return &UpgradeWatcher{
+		stateRPC:        stateRPC,
+		chainRPC:        chainRPC,
+		registryFactory: registryFactory,
+		opts:            opts,
+		callbacks:       make(map[uint64]UpgradeCallback),
+	}
+}
+
+// NewUpgradeWatcher creates an UpgradeWatcher that uses the RPCs of the API, see NewUpgradeWatcher.
+func (api *SubstrateAPI) NewUpgradeWatcher(
+	registryFactory registry.Factory,
+	opts UpgradeWatcherOptions,
+) *UpgradeWatcher {
+	return NewUpgradeWatcher(api.RPC.State, api.RPC.Chain, registryFactory, opts)
+}
+
+// Register registers the callback for runtime upgrades and returns the function that unregisters it. Callbacks are
+// called in the order they were registered.
+func (w *UpgradeWatcher) Register(callback UpgradeCallback) (unregister func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	id := w.nextID
+	w.nextID++
+	w.callbacks[id] = callback
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		delete(w.callbacks, id)
+	}
+}
+
+// GetRuntimeCached returns the runtime version and the metadata of the latest runtime the watcher knows about.
+func (w *UpgradeWatcher) GetRuntimeCached() (types.RuntimeVersion, *types.Metadata, error) {
+	runtime, err := w.current()
+	if err != nil {
+		return types.RuntimeVersion{}, nil, err
+	}
+
+	return runtime.version, runtime.meta, nil
+}
+
+// Registries returns the registries of the latest runtime the watcher knows about. They must not be modified.
+func (w *UpgradeWatcher) Registries() (*RuntimeRegistries, error) {
+	runtime, err := w.current()
+	if err != nil {
+		return nil, err
+	}
+
+	return runtime.registries, nil
+}
+
+// Run subscribes to runtime version changes and watches them until ctx is done or the subscription ended, see
+// Watch.
+func (w *UpgradeWatcher) Run(ctx context.Context) error {
+	sub, err := w.stateRPC.SubscribeRuntimeVersionContext(ctx)
+	if err != nil {
+		return ErrUpgradeWatcherSubscribe.Wrap(err)
+	}
+
+	return w.Watch(ctx, sub)
+}
+
+// Watch watches the runtime versions of the subscription until ctx is done or the subscription ended, and
+// unsubscribes afterwards. Watch can be called again with a new subscription, e.g. after reconnecting. Upgrades that
+// happened in between, or while the subscription signalled a gap, are detected by comparing the runtime versions and
+// reported as missed.
+func (w *UpgradeWatcher) Watch(ctx context.Context, sub RuntimeVersionSubscription) error {
+	defer sub.Unsubscribe()
+
+	var retry <-chan time.Time
+
+	// The first refresh detects the upgrades that happened since the previous subscription ended.
+	refresh, missed := true, true
+
+	for {
+		if refresh {
+			refresh, retry = false, nil
+
+			if !w.refresh(ctx, missed) {
+				retry = time.After(w.opts.RetryInterval)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case version, ok := <-sub.Chan():
+			if !ok {
+				return nil
+			}
+
+			runtime, err := w.current()
+			refresh = err != nil || !sameRuntimeVersion(runtime.version, version)
+			missed = false
+		case <-sub.Gap():
+			w.log(ctx, slog.LevelDebug, "Runtime version subscription gap, refreshing the runtime")
+
+			refresh, missed = true, true
+		case <-retry:
+			refresh = true
+		case err, ok := <-sub.Err():
+			if ok && err != nil {
+				return ErrUpgradeWatcherSubscribe.Wrap(err)
+			}
+
+			return nil
+		}
+	}
+}
+
+func (w *UpgradeWatcher) current() (*watchedRuntime, error) {
+	w.mu.Lock()
+	runtime := w.runtime
+	w.mu.Unlock()
+
+	if runtime == nil {
+		return nil, ErrUpgradeWatcherNotStarted
+	}
+
+	if runtime.meta == nil {
+		return nil, ErrUpgradeWatcherStale
+	}
+
+	return runtime, nil
+}
+
+// refresh compares the runtime of the latest block with the known one and handles the upgrade if it changed. If
+// that fails, the watcher is marked as stale until the next successful refresh.
+func (w *UpgradeWatcher) refresh(ctx context.Context, missed bool) bool {
+	w.mu.Lock()
+	old := w.runtime
+	w.mu.Unlock()
+
+	upgrade, err := w.fetch(ctx, old, missed)
+	if err != nil {
+		if old != nil {
+			w.store(&watchedRuntime{version: old.version, blockNumber: old.blockNumber})
+		}
+
+		w.log(
+			ctx,
+			slog.LevelWarn,
+			"Runtime refresh failed",
+			slog.Duration("retry_interval", w.opts.RetryInterval),
+			slog.String("error", err.Error()),
+		)
+
+		if w.opts.OnError != nil {
+			w.opts.OnError(ErrUpgradeWatcherRefresh.Wrap(err))
+		}
+
+		return false
+	}
+
+	if upgrade != nil && old != nil {
+		w.log(
+			ctx,
+			slog.LevelInfo,
+			"Runtime upgrade",
+			slog.Uint64("spec_version", uint64(upgrade.NewVersion.SpecVersion)),
+			slog.Uint64("transaction_version", uint64(upgrade.NewVersion.TransactionVersion)),
+			slog.Uint64("old_spec_version", uint64(upgrade.OldVersion.SpecVersion)),
+			slog.String("block_hash", upgrade.BlockHash.Hex()),
+			slog.Bool("missed", upgrade.Missed),
+		)
+
+		for _, callback := range w.sortedCallbacks() {
+			callback(*upgrade)
+		}
+	}
+
+	return true
+}
+
+// fetch stores the runtime of the latest block and returns the upgrade, or nil if the runtime did not change.
+func (w *UpgradeWatcher) fetch(ctx context.Context, old *watchedRuntime, missed bool) (*Upgrade, error) {
+	header, err := w.chainRPC.GetHeaderLatestContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockNumber := uint64(header.Number)
+
+	blockHash, err := w.chainRPC.GetBlockHashContext(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := w.stateRPC.GetRuntimeVersionContext(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if old != nil && old.meta != nil && sameRuntimeVersion(old.version, *version) {
+		w.store(&watchedRuntime{
+			version:     old.version,
+			blockNumber: blockNumber,
+			meta:        old.meta,
+			registries:  old.registries,
+		})
+
+		return nil, nil
+	}
+
+	meta, err := w.stateRPC.GetMetadataContext(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	registries, err := w.createRegistries(ctx, meta, version.SpecVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	w.store(&watchedRuntime{version: *version, blockNumber: blockNumber, meta: meta, registries: registries})
+
+	if old == nil || sameRuntimeVersion(old.version, *version) {
+		return nil, nil
+	}
+
+	return &Upgrade{
+		OldVersion: old.version,
+		NewVersion: *version,
+		Metadata:   meta,
+		Registries: registries,
+		BlockHash:  w.findEnactment(ctx, old.blockNumber+1, blockNumber, blockHash, *version),
+		Missed:     missed,
+	}, nil
+}
+
+// findEnactment returns the hash of the first block between start and end whose runtime is at least the given
+// version, assuming that versions only increase. The hash of the end block is returned if the search fails.
+func (w *UpgradeWatcher) findEnactment(
+	ctx context.Context,
+	start, end uint64,
+	endHash types.Hash,
+	version types.RuntimeVersion,
+) types.Hash {
+	for start < end {
+		mid := start + (end-start)/2
+
+		blockHash, err := w.chainRPC.GetBlockHashContext(ctx, mid)
+		if err != nil {
+			return endHash
+		}
+
+		midVersion, err := w.stateRPC.GetRuntimeVersionContext(ctx, blockHash)
+		if err != nil {
+			return endHash
+		}
+
+		if runtimeVersionReached(*midVersion, version) {
+			end, endHash = mid, blockHash
+		} else {
+			start = mid + 1
+		}
+	}
+
+	return endHash
+}
+
+// createRegistries creates the registries of the runtime, in a span if UpgradeWatcherOptions.Tracer is set.
+func (w *UpgradeWatcher) createRegistries(
+	ctx context.Context,
+	meta *types.Metadata,
+	specVersion types.U32,
+) (*RuntimeRegistries, error) {
+	if w.opts.Tracer == nil {
+		return w.createRegistriesUntraced(meta)
+	}
+
+	_, span := w.opts.Tracer.Start(
+		ctx,
+		"upgrade_watcher.CreateRegistries",
+		slog.Uint64("spec_version", uint64(specVersion)),
+	)
+
+	registries, err := w.createRegistriesUntraced(meta)
+
+	span.End(err)
+
+	return registries, err
+}
+
+func (w *UpgradeWatcher) createRegistriesUntraced(meta *types.Metadata) (*RuntimeRegistries, error) {
+	callRegistry, err := w.registryFactory.CreateCallRegistry(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	eventRegistry, err := w.registryFactory.CreateEventRegistry(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	errorRegistry, err := w.registryFactory.CreateErrorRegistry(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RuntimeRegistries{Call: callRegistry, Event: eventRegistry, Error: errorRegistry}, nil
+}
+
+// log logs the record via UpgradeWatcherOptions.Logger, if it is set.
+func (w *UpgradeWatcher) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if w.opts.Logger != nil {
+		w.opts.Logger.LogAttrs(ctx, level, msg, attrs...)
+	}
+}
+
+func (w *UpgradeWatcher) store(runtime *watchedRuntime) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.runtime = runtime
+}
+
+func (w *UpgradeWatcher) sortedCallbacks() []UpgradeCallback {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ids := make([]uint64, 0, len(w.callbacks))
+
+	for id := range w.callbacks {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	callbacks := make([]UpgradeCallback, 0, len(ids))
+
+	for _, id := range ids {
+		callbacks = append(callbacks, w.callbacks[id])
+	}
+
+	return callbacks
+}
+
+func sameRuntimeVersion(a, b types.RuntimeVersion) bool {
+	return a.SpecVersion == b.SpecVersion && a.TransactionVersion == b.TransactionVersion
+}
+
+// runtimeVersionReached returns true if the version is the target version or a later one.
+func runtimeVersionReached(version, target types.RuntimeVersion) bool {
+	if version.SpecVersion != target.SpecVersion {
+		return version.SpecVersion > target.SpecVersion
+	}
+
+	return version.TransactionVersion >= target.TransactionVersion
+}
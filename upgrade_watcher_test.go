@@ -0,0 +1,392 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsrpc_test
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/tracing"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type testVersionSubscription struct {
+	versions chan types.RuntimeVersion
+	gap      chan struct{}
+	errs     chan error
+}
+
+func newTestVersionSubscription() *testVersionSubscription {
+	return &testVersionSubscription{
+		versions: make(chan types.RuntimeVersion),
+		gap:      make(chan struct{}, 1),
+		errs:     make(chan error, 1),
+	}
+}
+
+func (s *testVersionSubscription) Chan() <-chan types.RuntimeVersion { return s.versions }
+func (s *testVersionSubscription) Err() <-chan error                 { return s.errs }
+func (s *testVersionSubscription) Gap() <-chan struct{}              { return s.gap }
+func (s *testVersionSubscription) Unsubscribe()                      {}
+
+// newTestUpgradeChain returns a client whose latest block is block 10 and block 20 afterwards. The runtime was
+// upgraded in block 15.
+func newTestUpgradeChain() *rpcmocksrv.MockClient {
+	cl := rpcmocksrv.NewMockClient().
+		Respond("chain_getHeader", types.Header{Number: 10}).
+		Respond("chain_getHeader", types.Header{Number: 20})
+
+	for n := 10; n <= 20; n++ {
+		blockHash := types.Hash{byte(n)}
+		version := testRuntimeVersion
+
+		if n >= 15 {
+			version = testUpgradedVersion
+		}
+
+		cl.Respond("chain_getBlockHash", blockHash.Hex(), n).
+			Respond("state_getRuntimeVersion", version, blockHash.Hex())
+	}
+
+	return cl
+}
+
+// watch starts watching the subscription and returns the channel that receives the result of Watch.
+func watch(w *gsrpc.UpgradeWatcher, sub gsrpc.RuntimeVersionSubscription) <-chan error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- w.Watch(context.Background(), sub)
+	}()
+
+	return done
+}
+
+func waitForUpgrade(t *testing.T, upgrades <-chan gsrpc.Upgrade) gsrpc.Upgrade {
+	select {
+	case upgrade := <-upgrades:
+		return upgrade
+	case <-time.After(5 * time.Second):
+		t.Fatal("no runtime upgrade observed")
+	}
+
+	return gsrpc.Upgrade{}
+}
+
+func TestUpgradeWatcher_Watch(t *testing.T) {
+	api := newTestSubstrateAPI(t, newTestUpgradeChain())
+	w := api.NewUpgradeWatcher(registry.NewFactory(), gsrpc.UpgradeWatcherOptions{})
+
+	_, _, err := w.GetRuntimeCached()
+	assert.ErrorIs(t, err, gsrpc.ErrUpgradeWatcherNotStarted)
+
+	upgrades := make(chan gsrpc.Upgrade, 2)
+
+	w.Register(func(upgrade gsrpc.Upgrade) { upgrades <- upgrade })
+	unregister := w.Register(func(upgrade gsrpc.Upgrade) { t.Error("unregistered callback called") })
+	unregister()
+
+	sub := newTestVersionSubscription()
+	done := watch(w, sub)
+
+	// Versions of the known runtime are ignored.
+	sub.versions <- testRuntimeVersion
+	sub.versions <- testUpgradedVersion
+
+	upgrade := waitForUpgrade(t, upgrades)
+	assert.Equal(t, testRuntimeVersion, upgrade.OldVersion)
+	assert.Equal(t, testUpgradedVersion, upgrade.NewVersion)
+	assert.Equal(t, types.Hash{15}, upgrade.BlockHash)
+	assert.False(t, upgrade.Missed)
+	assert.NotNil(t, upgrade.Metadata)
+	assert.NotEmpty(t, upgrade.Registries.Call)
+	assert.NotEmpty(t, upgrade.Registries.Event)
+
+	version, meta, err := w.GetRuntimeCached()
+	assert.NoError(t, err)
+	assert.Equal(t, testUpgradedVersion, version)
+	assert.Equal(t, upgrade.Metadata, meta)
+
+	registries, err := w.Registries()
+	assert.NoError(t, err)
+	assert.Equal(t, upgrade.Registries, registries)
+
+	close(sub.versions)
+	assert.NoError(t, <-done)
+}
+
+func TestUpgradeWatcher_Watch_Missed(t *testing.T) {
+	t.Run("gap", func(t *testing.T) {
+		api := newTestSubstrateAPI(t, newTestUpgradeChain())
+		w := api.NewUpgradeWatcher(registry.NewFactory(), gsrpc.UpgradeWatcherOptions{})
+
+		upgrades := make(chan gsrpc.Upgrade, 1)
+		w.Register(func(upgrade gsrpc.Upgrade) { upgrades <- upgrade })
+
+		sub := newTestVersionSubscription()
+		done := watch(w, sub)
+
+		sub.gap <- struct{}{}
+
+		upgrade := waitForUpgrade(t, upgrades)
+		assert.True(t, upgrade.Missed)
+		assert.Equal(t, types.Hash{15}, upgrade.BlockHash)
+
+		close(sub.versions)
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("resubscribe", func(t *testing.T) {
+		api := newTestSubstrateAPI(t, newTestUpgradeChain())
+		w := api.NewUpgradeWatcher(registry.NewFactory(), gsrpc.UpgradeWatcherOptions{})
+
+		upgrades := make(chan gsrpc.Upgrade, 1)
+		w.Register(func(upgrade gsrpc.Upgrade) { upgrades <- upgrade })
+
+		sub := newTestVersionSubscription()
+		done := watch(w, sub)
+
+		// The subscription ends with an error, the upgrade happens before watching a new subscription.
+		sub.errs <- testMetadataCacheErr.RPCError()
+		assert.ErrorIs(t, <-done, gsrpc.ErrUpgradeWatcherSubscribe)
+
+		sub = newTestVersionSubscription()
+		done = watch(w, sub)
+
+		upgrade := waitForUpgrade(t, upgrades)
+		assert.True(t, upgrade.Missed)
+		assert.Equal(t, testUpgradedVersion, upgrade.NewVersion)
+
+		close(sub.versions)
+		assert.NoError(t, <-done)
+	})
+}
+
+func TestUpgradeWatcher_Watch_Logger(t *testing.T) {
+	logs := &testLogHandler{}
+
+	api := newTestSubstrateAPI(t, newTestUpgradeChain())
+	w := api.NewUpgradeWatcher(registry.NewFactory(), gsrpc.UpgradeWatcherOptions{Logger: slog.New(logs)})
+
+	upgrades := make(chan gsrpc.Upgrade, 1)
+	w.Register(func(upgrade gsrpc.Upgrade) { upgrades <- upgrade })
+
+	sub := newTestVersionSubscription()
+	done := watch(w, sub)
+
+	sub.gap <- struct{}{}
+
+	waitForUpgrade(t, upgrades)
+
+	close(sub.versions)
+	assert.NoError(t, <-done)
+
+	assert.Equal(t, []string{
+		"Runtime version subscription gap, refreshing the runtime",
+		"Runtime upgrade",
+	}, logs.messages())
+
+	attrs := logs.attrs("Runtime upgrade")
+	assert.Equal(t, uint64(testUpgradedVersion.SpecVersion), attrs["spec_version"].Uint64())
+	assert.Equal(t, uint64(testRuntimeVersion.SpecVersion), attrs["old_spec_version"].Uint64())
+	assert.Equal(t, types.Hash{15}.Hex(), attrs["block_hash"].String())
+	assert.True(t, attrs["missed"].Bool())
+}
+
+func TestUpgradeWatcher_Watch_Tracer(t *testing.T) {
+	tracer := &testTracer{}
+
+	api := newTestSubstrateAPI(t, newTestUpgradeChain())
+	w := api.NewUpgradeWatcher(registry.NewFactory(), gsrpc.UpgradeWatcherOptions{Tracer: tracer})
+
+	upgrades := make(chan gsrpc.Upgrade, 1)
+	w.Register(func(upgrade gsrpc.Upgrade) { upgrades <- upgrade })
+
+	sub := newTestVersionSubscription()
+	done := watch(w, sub)
+
+	sub.gap <- struct{}{}
+
+	waitForUpgrade(t, upgrades)
+
+	close(sub.versions)
+	assert.NoError(t, <-done)
+
+	// The registries are created for the initial runtime and for the upgraded one.
+	assert.Equal(t, []uint64{
+		uint64(testRuntimeVersion.SpecVersion),
+		uint64(testUpgradedVersion.SpecVersion),
+	}, tracer.specVersions("upgrade_watcher.CreateRegistries"))
+}
+
+func TestUpgradeWatcher_Watch_RefreshError(t *testing.T) {
+	cl := rpcmocksrv.NewMockClient().
+		Respond("chain_getHeader", types.Header{Number: 10}).
+		Respond("chain_getBlockHash", testBlockHash.Hex()).
+		RespondError("state_getRuntimeVersion", testMetadataCacheErr)
+
+	errs := make(chan error, 1)
+	logs := &testLogHandler{}
+
+	w := newTestSubstrateAPI(t, cl).NewUpgradeWatcher(registry.NewFactory(), gsrpc.UpgradeWatcherOptions{
+		RetryInterval: time.Hour,
+		OnError:       func(err error) { errs <- err },
+		Logger:        slog.New(logs),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- w.Watch(ctx, newTestVersionSubscription())
+	}()
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, gsrpc.ErrUpgradeWatcherRefresh)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no refresh error reported")
+	}
+
+	assert.Equal(t, []string{"Runtime refresh failed"}, logs.messages())
+	assert.Equal(t, time.Hour, logs.attrs("Runtime refresh failed")["retry_interval"].Duration())
+
+	_, err := w.Registries()
+	assert.ErrorIs(t, err, gsrpc.ErrUpgradeWatcherNotStarted)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestUpgradeWatcher_Run_SubscribeError(t *testing.T) {
+	api := newTestSubstrateAPI(t, rpcmocksrv.NewMockClient())
+
+	err := api.NewUpgradeWatcher(registry.NewFactory(), gsrpc.UpgradeWatcherOptions{}).Run(context.Background())
+	assert.ErrorIs(t, err, gsrpc.ErrUpgradeWatcherSubscribe)
+}
+
+// testLogHandler records the logged records, it is safe for concurrent use.
+type testLogHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *testLogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *testLogHandler) Handle(_ context.Context, record slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.records = append(h.records, record.Clone())
+
+	return nil
+}
+
+func (h *testLogHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *testLogHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+func (h *testLogHandler) messages() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	messages := make([]string, 0, len(h.records))
+	for _, record := range h.records {
+		messages = append(messages, record.Message)
+	}
+
+	return messages
+}
+
+// attrs returns the attributes of the first record with the message.
+func (h *testLogHandler) attrs(msg string) map[string]slog.Value {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	attrs := make(map[string]slog.Value)
+
+	for _, record := range h.records {
+		if record.Message != msg {
+			continue
+		}
+
+		record.Attrs(func(attr slog.Attr) bool {
+			attrs[attr.Key] = attr.Value
+			return true
+		})
+
+		break
+	}
+
+	return attrs
+}
+
+// testTracer records the spec versions of the ended spans, it is safe for concurrent use.
+type testTracer struct {
+	mu    sync.Mutex
+	spans map[string][]uint64
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, tracing.Span) {
+	return ctx, &testSpan{tracer: t, name: name, attrs: attrs}
+}
+
+func (t *testTracer) specVersions(name string) []uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.spans[name]
+}
+
+type testSpan struct {
+	tracer *testTracer
+	name   string
+	attrs  []slog.Attr
+}
+
+func (s *testSpan) SetAttributes(attrs ...slog.Attr) {
+	s.attrs = append(s.attrs, attrs...)
+}
+
+func (s *testSpan) End(error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	if s.tracer.spans == nil {
+		s.tracer.spans = make(map[string][]uint64)
+	}
+
+	for _, attr := range s.attrs {
+		if attr.Key == "spec_version" {
+			s.tracer.spans[s.name] = append(s.tracer.spans[s.name], attr.Value.Uint64())
+		}
+	}
+}
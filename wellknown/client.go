@@ -0,0 +1,147 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wellknown
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+// RuntimeCode is the wasm code of a runtime.
+type RuntimeCode struct {
+	// Code is the wasm blob, which might be compressed.
+	Code []byte
+	// Hash is the blake2-256 hash of the code.
+	Hash types.Hash
+}
+
+// RuntimeAtBlock identifies the runtime of a block.
+type RuntimeAtBlock struct {
+	Block       types.Hash
+	CodeHash    types.Hash
+	SpecName    string
+	SpecVersion types.U32
+}
+
+// RuntimeComparison is the result of CompareRuntime.
+type RuntimeComparison struct {
+	From RuntimeAtBlock
+	To   RuntimeAtBlock
+	// CodeChanged is true if the code of the runtime differs between the blocks.
+	CodeChanged bool
+}
+
+// SpecVersionChanged returns true if the spec version differs between the blocks. The code can change without a new
+// spec version if it was set via System.set_code_without_checks.
+func (c *RuntimeComparison) SpecVersionChanged() bool {
+	return c.From.SpecVersion != c.To.SpecVersion
+}
+
+// Client reads the well-known keys of the runtime state.
+type Client struct {
+	stateRPC state.State
+}
+
+// NewClient creates a Client that reads the well-known keys via the state RPC.
+func NewClient(stateRPC state.State) *Client {
+	return &Client{stateRPC: stateRPC}
+}
+
+// GetRuntimeCode returns the wasm code of the runtime at the given block. The code is several megabytes large, use
+// GetRuntimeCodeHash to detect runtime upgrades.
+func (c *Client) GetRuntimeCode(ctx context.Context, blockHash types.Hash) (*RuntimeCode, error) {
+	raw, err := c.stateRPC.GetStorageRawContext(ctx, StorageKey(CodeKey), blockHash)
+	if err != nil {
+		return nil, ErrRuntimeCodeRetrieval.Wrap(err)
+	}
+
+	if len(*raw) == 0 {
+		return nil, ErrRuntimeCodeNotFound.WithMsg("block %s", blockHash.Hex())
+	}
+
+	return &RuntimeCode{
+		Code: *raw,
+		Hash: blake2b.Sum256(*raw),
+	}, nil
+}
+
+// GetRuntimeCodeHash returns the blake2-256 hash of the wasm code of the runtime at the given block, which is
+// computed by the node.
+func (c *Client) GetRuntimeCodeHash(ctx context.Context, blockHash types.Hash) (types.Hash, error) {
+	hash, err := c.stateRPC.GetStorageHashContext(ctx, StorageKey(CodeKey), blockHash)
+	if err != nil {
+		return types.Hash{}, ErrRuntimeCodeHashRetrieval.Wrap(err)
+	}
+
+	return hash, nil
+}
+
+// GetHeapPages returns the number of memory pages of the wasm runtime at the given block. ok is false if it is not
+// set, in which case the node uses its default.
+func (c *Client) GetHeapPages(ctx context.Context, blockHash types.Hash) (pages uint64, ok bool, err error) {
+	var res types.U64
+
+	ok, err = c.stateRPC.GetStorageContext(ctx, StorageKey(HeapPagesKey), &res, blockHash)
+	if err != nil {
+		return 0, false, ErrHeapPagesRetrieval.Wrap(err)
+	}
+
+	return uint64(res), ok, nil
+}
+
+// GetRuntime returns the code hash and version of the runtime at the given block.
+func (c *Client) GetRuntime(ctx context.Context, blockHash types.Hash) (RuntimeAtBlock, error) {
+	codeHash, err := c.GetRuntimeCodeHash(ctx, blockHash)
+	if err != nil {
+		return RuntimeAtBlock{}, err
+	}
+
+	version, err := c.stateRPC.GetRuntimeVersionContext(ctx, blockHash)
+	if err != nil {
+		return RuntimeAtBlock{}, ErrRuntimeVersionRetrieval.Wrap(err)
+	}
+
+	return RuntimeAtBlock{
+		Block:       blockHash,
+		CodeHash:    codeHash,
+		SpecName:    version.SpecName,
+		SpecVersion: version.SpecVersion,
+	}, nil
+}
+
+// CompareRuntime compares the runtimes of two blocks, e.g. the parent and the block of a governance referendum, to
+// detect runtime upgrades. Only the code hashes are retrieved, not the code itself.
+func (c *Client) CompareRuntime(ctx context.Context, from, to types.Hash) (*RuntimeComparison, error) {
+	fromRuntime, err := c.GetRuntime(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toRuntime, err := c.GetRuntime(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RuntimeComparison{
+		From:        fromRuntime,
+		To:          toRuntime,
+		CodeChanged: fromRuntime.CodeHash != toRuntime.CodeHash,
+	}, nil
+}
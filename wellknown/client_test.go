@@ -0,0 +1,123 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wellknown
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	testCode      = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	testCodeHash  = types.Hash(blake2b.Sum256(testCode))
+	testUpgrade   = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x01}
+	testBlockHash = types.Hash{1}
+	testNextHash  = types.Hash{2}
+	testLastHash  = types.Hash{3}
+)
+
+func testRuntimeVersion(specVersion types.U32) *types.RuntimeVersion {
+	return &types.RuntimeVersion{SpecName: "polkadot", SpecVersion: specVersion, APIs: []types.RuntimeVersionAPI{}}
+}
+
+func newTestClient() (*Client, *rpcmocksrv.MockClient) {
+	codeKey := StorageKey(CodeKey).Hex()
+	upgradeHash := types.Hash(blake2b.Sum256(testUpgrade))
+
+	cl := rpcmocksrv.NewMockClient().
+		Respond("state_getStorage", codec.HexEncodeToString(testCode), codeKey, testBlockHash.Hex()).
+		Respond("state_getStorage", codec.HexEncodeToString([]byte{0, 1, 0, 0, 0, 0, 0, 0}),
+			StorageKey(HeapPagesKey).Hex(), testBlockHash.Hex()).
+		Respond("state_getStorage", nil).
+		Respond("state_getStorageHash", testCodeHash.Hex(), codeKey, testBlockHash.Hex()).
+		Respond("state_getStorageHash", testCodeHash.Hex(), codeKey, testNextHash.Hex()).
+		Respond("state_getStorageHash", upgradeHash.Hex(), codeKey, testLastHash.Hex()).
+		Respond("state_getRuntimeVersion", testRuntimeVersion(1000), testBlockHash.Hex()).
+		Respond("state_getRuntimeVersion", testRuntimeVersion(1000), testNextHash.Hex()).
+		Respond("state_getRuntimeVersion", testRuntimeVersion(1001), testLastHash.Hex())
+
+	return NewClient(state.NewState(cl)), cl
+}
+
+func TestClient_GetRuntimeCode(t *testing.T) {
+	c, _ := newTestClient()
+
+	code, err := c.GetRuntimeCode(context.Background(), testBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, &RuntimeCode{Code: testCode, Hash: testCodeHash}, code)
+
+	_, err = c.GetRuntimeCode(context.Background(), testNextHash)
+	assert.ErrorIs(t, err, ErrRuntimeCodeNotFound)
+}
+
+func TestClient_GetHeapPages(t *testing.T) {
+	c, _ := newTestClient()
+
+	pages, ok, err := c.GetHeapPages(context.Background(), testBlockHash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(256), pages)
+
+	_, ok, err = c.GetHeapPages(context.Background(), testNextHash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestClient_CompareRuntime(t *testing.T) {
+	c, cl := newTestClient()
+
+	res, err := c.CompareRuntime(context.Background(), testBlockHash, testNextHash)
+	require.NoError(t, err)
+	assert.False(t, res.CodeChanged)
+	assert.False(t, res.SpecVersionChanged())
+	assert.Equal(t, RuntimeAtBlock{
+		Block:       testBlockHash,
+		CodeHash:    testCodeHash,
+		SpecName:    "polkadot",
+		SpecVersion: 1000,
+	}, res.From)
+
+	res, err = c.CompareRuntime(context.Background(), testNextHash, testLastHash)
+	require.NoError(t, err)
+	assert.True(t, res.CodeChanged)
+	assert.True(t, res.SpecVersionChanged())
+	assert.Equal(t, types.Hash(blake2b.Sum256(testUpgrade)), res.To.CodeHash)
+	assert.Equal(t, types.U32(1001), res.To.SpecVersion)
+
+	// The code itself is not retrieved.
+	cl.AssertNotCalled(t, "state_getStorage")
+}
+
+func TestClient_CompareRuntime_Errors(t *testing.T) {
+	c, _ := newTestClient()
+
+	_, err := c.CompareRuntime(context.Background(), types.Hash{9}, testLastHash)
+	assert.ErrorIs(t, err, ErrRuntimeCodeHashRetrieval)
+
+	cl := rpcmocksrv.NewMockClient().Respond("state_getStorageHash", testCodeHash.Hex())
+
+	_, err = NewClient(state.NewState(cl)).CompareRuntime(context.Background(), testBlockHash, testLastHash)
+	assert.ErrorIs(t, err, ErrRuntimeVersionRetrieval)
+}
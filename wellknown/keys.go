@@ -0,0 +1,48 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wellknown
+
+import "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+
+// The well-known storage keys of Substrate runtimes, see sp_storage::well_known_keys. Unlike the keys of pallet
+// storage, they are not hashed.
+const (
+	// CodeKey holds the wasm code of the runtime.
+	CodeKey = ":code"
+	// HeapPagesKey holds the number of memory pages of the wasm runtime as u64, if it differs from the default.
+	HeapPagesKey = ":heappages"
+	// ExtrinsicIndexKey holds the index of the extrinsic that is executed, as u32. It is only set while a block is
+	// executed.
+	ExtrinsicIndexKey = ":extrinsic_index"
+	// ChangesTrieKey holds the configuration of the changes trie, which was removed from Substrate.
+	ChangesTrieKey = ":changes_trie"
+	// ChildStorageKeyPrefix is the prefix of the keys that hold the roots of child tries.
+	ChildStorageKeyPrefix = ":child_storage:"
+	// DefaultChildStorageKeyPrefix is the prefix of the keys that hold the roots of default child tries.
+	DefaultChildStorageKeyPrefix = ChildStorageKeyPrefix + "default:"
+)
+
+// StorageKey returns the storage key of a well-known key, e.g. StorageKey(CodeKey).
+func StorageKey(key string) types.StorageKey {
+	return types.NewStorageKey([]byte(key))
+}
+
+// DefaultChildStorageKey returns the key of the default child trie with the given unprefixed storage key, as used by
+// the child storage methods of the state RPC.
+func DefaultChildStorageKey(storageKey []byte) types.StorageKey {
+	return append(StorageKey(DefaultChildStorageKeyPrefix), storageKey...)
+}
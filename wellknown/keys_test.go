@@ -0,0 +1,38 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wellknown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageKey(t *testing.T) {
+	assert.Equal(t, "0x3a636f6465", StorageKey(CodeKey).Hex())
+	assert.Equal(t, "0x3a686561707061676573", StorageKey(HeapPagesKey).Hex())
+	assert.Equal(t, "0x3a65787472696e7369635f696e646578", StorageKey(ExtrinsicIndexKey).Hex())
+}
+
+func TestDefaultChildStorageKey(t *testing.T) {
+	// The child trie key of the test fixtures of the state RPC.
+	assert.Equal(
+		t,
+		"0x3a6368696c645f73746f726167653a64656661756c743a05470000",
+		DefaultChildStorageKey([]byte{0x05, 0x47, 0x00, 0x00}).Hex(),
+	)
+}
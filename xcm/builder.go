@@ -0,0 +1,220 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xcm
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// palletNames are the names of the XCM pallet in relay chain and parachain runtimes.
+var palletNames = []string{"XcmPallet", "PolkadotXcm"}
+
+// Version is an XCM version that is supported by the Builder.
+type Version uint8
+
+const (
+	V3 Version = 3
+	V4 Version = 4
+)
+
+// Transfer holds the parameters of a transfer of assets to another chain.
+type Transfer struct {
+	// Dest is the destination chain, e.g. Parachain(1000).
+	Dest types.LocationV4
+	// Beneficiary is the account on the destination chain, e.g. AccountID32(accountID).
+	Beneficiary types.LocationV4
+	// Assets are the assets to transfer, as seen from the sending chain.
+	Assets types.AssetsV4
+	// FeeAssetItem is the index of the asset in Assets that pays the fees on the destination chain.
+	FeeAssetItem uint32
+	// WeightLimit limits the weight that is bought on the destination chain, it defaults to unlimited. It is ignored
+	// by the calls without a weight limit.
+	WeightLimit types.WeightLimitV3
+}
+
+// NativeTransfer returns the transfer of the amount of the native asset of the sending chain to the account on the
+// destination chain.
+func NativeTransfer(dest types.LocationV4, beneficiary types.AccountID, amount *big.Int) Transfer {
+	return Transfer{
+		Dest:        dest,
+		Beneficiary: AccountID32(beneficiary),
+		Assets:      types.AssetsV4{FungibleAsset(Here(), amount)},
+	}
+}
+
+// Builder creates the calls of the XCM pallet of a runtime, XcmPallet on relay chains and PolkadotXcm on parachains.
+type Builder struct {
+	meta    *types.Metadata
+	pallet  string
+	version Version
+}
+
+// NewBuilder creates a Builder for the XCM pallet of the runtime. The locations and assets are encoded in the latest
+// XCM version that is supported by the runtime, ErrXcmVersionNotSupported is returned if it supports neither XCM v3
+// nor v4.
+func NewBuilder(meta *types.Metadata) (*Builder, error) {
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if !pallet.HasCalls || !isXcmPallet(string(pallet.Name)) {
+			continue
+		}
+
+		version, err := latestVersion(meta, pallet.Calls.Type.Int64())
+		if err != nil {
+			return nil, err
+		}
+
+		return &Builder{meta: meta, pallet: string(pallet.Name), version: version}, nil
+	}
+
+	return nil, ErrXcmPalletNotFound
+}
+
+func isXcmPallet(name string) bool {
+	for _, palletName := range palletNames {
+		if name == palletName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// latestVersion returns the latest version of the VersionedLocation of the destination of teleport_assets.
+func latestVersion(meta *types.Metadata, callsType int64) (Version, error) {
+	calls, ok := meta.AsMetadataV14.EfficientLookup[callsType]
+	if !ok {
+		return 0, ErrXcmVersionNotSupported.WithMsg("calls type not found")
+	}
+
+	for _, call := range calls.Def.Variant.Variants {
+		if call.Name != "teleport_assets" || len(call.Fields) == 0 {
+			continue
+		}
+
+		location, ok := meta.AsMetadataV14.EfficientLookup[call.Fields[0].Type.Int64()]
+		if !ok {
+			break
+		}
+
+		var latest Version
+
+		for _, variant := range location.Def.Variant.Variants {
+			switch variant.Name {
+			case "V3":
+				latest = max(latest, V3)
+			case "V4":
+				latest = max(latest, V4)
+			}
+		}
+
+		if latest == 0 {
+			return 0, ErrXcmVersionNotSupported.WithMsg("runtime supports neither v3 nor v4")
+		}
+
+		return latest, nil
+	}
+
+	return 0, ErrXcmVersionNotSupported.WithMsg("versioned location type not found")
+}
+
+// Version returns the XCM version the calls are created with.
+func (b *Builder) Version() Version {
+	return b.version
+}
+
+// TeleportAssets creates the teleport_assets call, which teleports the assets to the destination chain.
+func (b *Builder) TeleportAssets(t Transfer) (types.Call, error) {
+	return b.newCall("teleport_assets", t, false)
+}
+
+// LimitedTeleportAssets creates the limited_teleport_assets call, see TeleportAssets, with the weight limit of the
+// transfer.
+func (b *Builder) LimitedTeleportAssets(t Transfer) (types.Call, error) {
+	return b.newCall("limited_teleport_assets", t, true)
+}
+
+// ReserveTransferAssets creates the reserve_transfer_assets call, which transfers the assets to the sovereign account
+// of the destination chain, which mints derivatives for the beneficiary.
+func (b *Builder) ReserveTransferAssets(t Transfer) (types.Call, error) {
+	return b.newCall("reserve_transfer_assets", t, false)
+}
+
+// LimitedReserveTransferAssets creates the limited_reserve_transfer_assets call, see ReserveTransferAssets, with the
+// weight limit of the transfer.
+func (b *Builder) LimitedReserveTransferAssets(t Transfer) (types.Call, error) {
+	return b.newCall("limited_reserve_transfer_assets", t, true)
+}
+
+// TransferAssets creates the transfer_assets call, which teleports or reserve transfers the assets depending on the
+// configuration of the runtime, with the weight limit of the transfer.
+func (b *Builder) TransferAssets(t Transfer) (types.Call, error) {
+	return b.newCall("transfer_assets", t, true)
+}
+
+func (b *Builder) newCall(name string, t Transfer, withWeightLimit bool) (types.Call, error) {
+	callName := b.pallet + "." + name
+
+	args := []interface{}{
+		b.versionedLocation(t.Dest),
+		b.versionedLocation(t.Beneficiary),
+		b.versionedAssets(t.Assets),
+		types.NewU32(t.FeeAssetItem),
+	}
+
+	if withWeightLimit {
+		weightLimit := t.WeightLimit
+		if !weightLimit.IsLimited {
+			weightLimit = types.WeightLimitV3{IsUnlimited: true}
+		}
+
+		args = append(args, weightLimit)
+	}
+
+	call, err := types.NewCall(b.meta, callName, args...)
+	if err != nil {
+		return types.Call{}, ErrCallCreation.WithMsg(callName).Wrap(err)
+	}
+
+	return call, nil
+}
+
+func (b *Builder) versionedLocation(location types.LocationV4) types.VersionedLocation {
+	if b.version == V3 {
+		return types.VersionedLocation{IsV3: true, V3: location}
+	}
+
+	return types.VersionedLocation{IsV4: true, V4: location}
+}
+
+func (b *Builder) versionedAssets(assets types.AssetsV4) types.VersionedAssets {
+	if b.version == V4 {
+		return types.VersionedAssets{IsV4: true, V4: assets}
+	}
+
+	multiAssets := make(types.MultiAssetsV3, 0, len(assets))
+
+	for _, asset := range assets {
+		multiAssets = append(multiAssets, types.MultiAssetV3{
+			ID:          types.AssetIDV3{IsConcrete: true, MultiLocation: asset.ID},
+			Fungibility: asset.Fungibility,
+		})
+	}
+
+	return types.VersionedAssets{IsV3: true, V3: multiAssets}
+}
@@ -0,0 +1,171 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xcm
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withXcmVersions returns a copy of the metadata whose VersionedMultiLocation has the given variants instead of V0
+// and V1, like the VersionedLocation of runtimes with newer XCM versions.
+func withXcmVersions(meta *types.Metadata, versions ...Version) *types.Metadata {
+	withVersions := *meta
+	withVersions.AsMetadataV14.EfficientLookup = make(map[int64]*types.Si1Type)
+
+	for id, typ := range meta.AsMetadataV14.EfficientLookup {
+		withVersions.AsMetadataV14.EfficientLookup[id] = typ
+
+		if len(typ.Path) == 0 || typ.Path[len(typ.Path)-1] != "VersionedMultiLocation" {
+			continue
+		}
+
+		versioned := *typ
+		versioned.Def.Variant.Variants = nil
+
+		for _, version := range versions {
+			versioned.Def.Variant.Variants = append(versioned.Def.Variant.Variants, types.Si1Variant{
+				Name:  types.Text(fmt.Sprintf("V%d", version)),
+				Index: types.U8(version),
+			})
+		}
+
+		withVersions.AsMetadataV14.EfficientLookup[id] = &versioned
+	}
+
+	return &withVersions
+}
+
+func TestNewBuilder(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+
+	// The runtime only supports XCM v0 and v1.
+	_, err := NewBuilder(meta)
+	assert.ErrorIs(t, err, ErrXcmVersionNotSupported)
+
+	b, err := NewBuilder(withXcmVersions(meta, V3))
+	require.NoError(t, err)
+	assert.Equal(t, V3, b.Version())
+
+	b, err = NewBuilder(withXcmVersions(meta, V3, V4))
+	require.NoError(t, err)
+	assert.Equal(t, V4, b.Version())
+
+	withoutXcm := *meta
+	withoutXcm.AsMetadataV14.Pallets = nil
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.Name != "XcmPallet" {
+			withoutXcm.AsMetadataV14.Pallets = append(withoutXcm.AsMetadataV14.Pallets, pallet)
+		}
+	}
+
+	_, err = NewBuilder(&withoutXcm)
+	assert.ErrorIs(t, err, ErrXcmPalletNotFound)
+}
+
+func TestBuilder_Calls(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+
+	accountID, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	transfer := NativeTransfer(Parachain(1000), *accountID, big.NewInt(10_000_000_000))
+
+	// 0x04 V4 ++ location ++ 0x04 V4 ++ location ++ 0x04 V4 ++ assets ++ fee asset item
+	encodedV4 := "0x04000100a10f" +
+		"0400010100d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d" +
+		"04040000000700e40b5402" +
+		"00000000"
+	// The asset ID of v3 assets is prefixed with 0x00 Concrete.
+	encodedV3 := "0x03000100a10f" +
+		"0300010100d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d" +
+		"0304000000000700e40b5402" +
+		"00000000"
+
+	v4, err := NewBuilder(withXcmVersions(meta, V3, V4))
+	require.NoError(t, err)
+
+	v3, err := NewBuilder(withXcmVersions(meta, V3))
+	require.NoError(t, err)
+
+	limited := transfer
+	limited.WeightLimit = types.WeightLimitV3{
+		IsLimited: true,
+		Limit:     types.NewWeight(types.NewUCompactFromUInt(1000), types.NewUCompactFromUInt(10)),
+	}
+
+	tests := []struct {
+		name         string
+		create       func() (types.Call, error)
+		methodIndex  uint8
+		expectedArgs string
+	}{
+		{
+			name:         "teleport_assets",
+			create:       func() (types.Call, error) { return v4.TeleportAssets(transfer) },
+			methodIndex:  1,
+			expectedArgs: encodedV4,
+		},
+		{
+			name:         "teleport_assets v3",
+			create:       func() (types.Call, error) { return v3.TeleportAssets(transfer) },
+			methodIndex:  1,
+			expectedArgs: encodedV3,
+		},
+		{
+			name:         "reserve_transfer_assets",
+			create:       func() (types.Call, error) { return v4.ReserveTransferAssets(transfer) },
+			methodIndex:  2,
+			expectedArgs: encodedV4,
+		},
+		{
+			name:        "limited_teleport_assets",
+			create:      func() (types.Call, error) { return v4.LimitedTeleportAssets(transfer) },
+			methodIndex: 9,
+			// Unlimited by default.
+			expectedArgs: encodedV4 + "00",
+		},
+		{
+			name:         "limited_reserve_transfer_assets",
+			create:       func() (types.Call, error) { return v4.LimitedReserveTransferAssets(limited) },
+			methodIndex:  8,
+			expectedArgs: encodedV4 + "01a10f28",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			call, err := test.create()
+			require.NoError(t, err)
+			assert.Equal(t, types.CallIndex{SectionIndex: 99, MethodIndex: test.methodIndex}, call.CallIndex)
+			assert.Equal(t, test.expectedArgs, codec.HexEncodeToString(call.Args))
+		})
+	}
+
+	// The runtime predates transfer_assets.
+	_, err = v4.TransferAssets(transfer)
+	assert.ErrorIs(t, err, ErrCallCreation)
+}
@@ -0,0 +1,211 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xcm
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	attemptedEvent = "Attempted"
+	sentEvent      = "Sent"
+
+	// sentFieldsWithMessageID is the number of fields of the Sent event of runtimes with XCM v3 and later.
+	sentFieldsWithMessageID = 4
+)
+
+// Outcome is the outcome of the execution of an XCM, with the names of its variants as defined by the runtime.
+type Outcome struct {
+	// Name is the name of the outcome, i.e. Complete, Incomplete or Error.
+	Name string
+	// Used is the weight used by the XCM, zero for the Error outcome.
+	Used types.Weight
+	// Error is the name of the XCM error, e.g. Barrier, if the XCM did not complete.
+	Error string
+}
+
+// IsComplete returns true if the XCM was executed completely.
+func (o Outcome) IsComplete() bool {
+	return o.Name == "Complete"
+}
+
+// AttemptedEvent holds the fields of the Attempted event, emitted when an XCM was executed locally.
+type AttemptedEvent struct {
+	Outcome Outcome
+}
+
+// SentEvent holds the fields of the Sent event, emitted when an XCM was sent to another chain. It is only decoded for
+// runtimes with XCM v3 and later.
+type SentEvent struct {
+	Origin      types.LocationV4
+	Destination types.LocationV4
+	// MessageID identifies the XCM on the destination chain, e.g. in its MessageQueue.Processed event.
+	MessageID types.Hash
+}
+
+// Events are the events of the XCM pallet emitted by an extrinsic.
+type Events struct {
+	Attempted []AttemptedEvent
+	Sent      []SentEvent
+}
+
+// DecodeEvents decodes the Attempted and Sent events of the XCM pallet, e.g. the events of submit.ExtrinsicResult.
+// The outcome of the Attempted event is decoded with the types of the metadata, so its variants and XCM errors are
+// named as in the runtime. Other events are ignored.
+func DecodeEvents(meta *types.Metadata, events []*parser.Event) (*Events, error) {
+	var res Events
+
+	for _, event := range events {
+		pallet, name, ok := strings.Cut(event.Name, ".")
+		if !ok || !isXcmPallet(pallet) {
+			continue
+		}
+
+		switch name {
+		case attemptedEvent:
+			outcome, err := decodeOutcome(meta, event)
+			if err != nil {
+				return nil, err
+			}
+
+			res.Attempted = append(res.Attempted, AttemptedEvent{Outcome: *outcome})
+		case sentEvent:
+			if len(event.Fields) != sentFieldsWithMessageID {
+				continue
+			}
+
+			var sent SentEvent
+
+			decoder := scale.NewDecoder(bytes.NewReader(event.Data))
+
+			if err := decoder.Decode(&sent.Origin); err != nil {
+				return nil, ErrEventDecoding.WithMsg(event.Name).Wrap(err)
+			}
+
+			if err := decoder.Decode(&sent.Destination); err != nil {
+				return nil, ErrEventDecoding.WithMsg(event.Name).Wrap(err)
+			}
+
+			// The message is not decoded, the message ID is the last field.
+			if len(event.Data) < len(sent.MessageID) {
+				return nil, ErrEventDecoding.WithMsg("%s message ID", event.Name)
+			}
+
+			copy(sent.MessageID[:], event.Data[len(event.Data)-len(sent.MessageID):])
+
+			res.Sent = append(res.Sent, sent)
+		}
+	}
+
+	return &res, nil
+}
+
+// decodeOutcome decodes the outcome of the Attempted event, whose weight and error types depend on the XCM version of
+// the runtime.
+func decodeOutcome(meta *types.Metadata, event *parser.Event) (*Outcome, error) {
+	outcomeType, err := attemptedOutcomeType(meta, event.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := scale.NewDecoder(bytes.NewReader(event.Data))
+
+	variant, err := decodeVariant(decoder, outcomeType)
+	if err != nil {
+		return nil, ErrEventDecoding.WithMsg(event.Name).Wrap(err)
+	}
+
+	outcome := &Outcome{Name: string(variant.Name)}
+
+	for _, field := range variant.Fields {
+		fieldType, ok := meta.AsMetadataV14.EfficientLookup[field.Type.Int64()]
+		if !ok {
+			return nil, ErrEventTypeNotFound.WithMsg("outcome field %d", field.Type.Int64())
+		}
+
+		switch {
+		case fieldType.Def.IsComposite:
+			err = decoder.Decode(&outcome.Used)
+		case fieldType.Def.IsPrimitive:
+			// XCM v2 weights are u64.
+			var used types.U64
+
+			if err = decoder.Decode(&used); err == nil {
+				outcome.Used.RefTime = types.NewUCompactFromUInt(uint64(used))
+			}
+		case fieldType.Def.IsVariant:
+			// The error is the last field, its fields, if any, are not decoded.
+			var xcmErr *types.Si1Variant
+
+			if xcmErr, err = decodeVariant(decoder, fieldType); err == nil {
+				outcome.Error = string(xcmErr.Name)
+			}
+		}
+
+		if err != nil {
+			return nil, ErrEventDecoding.WithMsg(event.Name).Wrap(err)
+		}
+	}
+
+	return outcome, nil
+}
+
+// attemptedOutcomeType returns the type of the outcome of the Attempted event with the given ID.
+func attemptedOutcomeType(meta *types.Metadata, eventID types.EventID) (*types.Si1Type, error) {
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if !pallet.HasEvents || uint8(pallet.Index) != eventID[0] {
+			continue
+		}
+
+		events, ok := meta.AsMetadataV14.EfficientLookup[pallet.Events.Type.Int64()]
+		if !ok {
+			break
+		}
+
+		for _, event := range events.Def.Variant.Variants {
+			if uint8(event.Index) != eventID[1] || len(event.Fields) != 1 {
+				continue
+			}
+
+			if outcomeType, ok := meta.AsMetadataV14.EfficientLookup[event.Fields[0].Type.Int64()]; ok {
+				return outcomeType, nil
+			}
+		}
+	}
+
+	return nil, ErrEventTypeNotFound.WithMsg("outcome of event %v", eventID)
+}
+
+func decodeVariant(decoder *scale.Decoder, t *types.Si1Type) (*types.Si1Variant, error) {
+	index, err := decoder.ReadOneByte()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, variant := range t.Def.Variant.Variants {
+		if uint8(variant.Index) == index {
+			return &t.Def.Variant.Variants[i], nil
+		}
+	}
+
+	return nil, ErrEventTypeNotFound.WithMsg("variant %d", index)
+}
@@ -0,0 +1,81 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xcm
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test/statetest"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	meta := statetest.PolkadotMetadata(t)
+
+	attemptedID := types.EventID{99, 0}
+
+	sentData, err := codec.Encode(struct {
+		Origin      types.LocationV4
+		Destination types.LocationV4
+		Message     []byte
+		MessageID   types.Hash
+	}{Here(), Parachain(1000), []byte{1, 2, 3}, types.NewHash([]byte{4, 5, 6})})
+	require.NoError(t, err)
+
+	res, err := DecodeEvents(meta, []*parser.Event{
+		{Name: "System.ExtrinsicSuccess", Data: []byte{1}},
+		// Complete(1000)
+		{Name: "XcmPallet.Attempted", EventID: attemptedID, Data: codec.MustHexDecodeString("0x00e803000000000000")},
+		// Incomplete(1000, Barrier)
+		{Name: "XcmPallet.Attempted", EventID: attemptedID, Data: codec.MustHexDecodeString("0x01e80300000000000018")},
+		// Error(Trap(42))
+		{Name: "XcmPallet.Attempted", EventID: attemptedID, Data: codec.MustHexDecodeString("0x02152a00000000000000")},
+		{Name: "PolkadotXcm.Sent", Fields: make(registry.DecodedFields, 4), Data: sentData},
+		// Runtimes before XCM v3 do not have the message ID.
+		{Name: "XcmPallet.Sent", Fields: make(registry.DecodedFields, 3), Data: []byte{1}},
+	})
+	require.NoError(t, err)
+
+	used := types.NewWeight(types.NewUCompactFromUInt(1000), types.UCompact{})
+
+	assert.Equal(t, []AttemptedEvent{
+		{Outcome: Outcome{Name: "Complete", Used: used}},
+		{Outcome: Outcome{Name: "Incomplete", Used: used, Error: "Barrier"}},
+		{Outcome: Outcome{Name: "Error", Error: "Trap"}},
+	}, res.Attempted)
+	assert.True(t, res.Attempted[0].Outcome.IsComplete())
+	assert.False(t, res.Attempted[1].Outcome.IsComplete())
+
+	assert.Equal(t, []SentEvent{
+		{Origin: Here(), Destination: Parachain(1000), MessageID: types.NewHash([]byte{4, 5, 6})},
+	}, res.Sent)
+
+	_, err = DecodeEvents(meta, []*parser.Event{
+		{Name: "XcmPallet.Attempted", EventID: attemptedID, Data: []byte{0}},
+	})
+	assert.ErrorIs(t, err, ErrEventDecoding)
+
+	_, err = DecodeEvents(meta, []*parser.Event{
+		{Name: "XcmPallet.Attempted", EventID: types.EventID{99, 42}, Data: []byte{0}},
+	})
+	assert.ErrorIs(t, err, ErrEventTypeNotFound)
+}
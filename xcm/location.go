@@ -0,0 +1,87 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xcm
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Here returns the location of the current chain, e.g. of its native asset.
+func Here() types.LocationV4 {
+	return types.LocationV4{Interior: types.JunctionsV4{IsHere: true}}
+}
+
+// RelayChain returns the location of the relay chain as seen from one of its parachains.
+func RelayChain() types.LocationV4 {
+	return types.LocationV4{Parents: 1, Interior: types.JunctionsV4{IsHere: true}}
+}
+
+// Parachain returns the location of the parachain as seen from its relay chain.
+func Parachain(paraID uint32) types.LocationV4 {
+	return types.LocationV4{Interior: x1(parachainJunction(paraID))}
+}
+
+// SiblingParachain returns the location of the parachain as seen from another parachain of the same relay chain.
+func SiblingParachain(paraID uint32) types.LocationV4 {
+	return types.LocationV4{Parents: 1, Interior: x1(parachainJunction(paraID))}
+}
+
+// AccountID32 returns the location of the account on the chain that interprets it, e.g. the beneficiary of a
+// transfer on the destination chain.
+func AccountID32(accountID types.AccountID) types.LocationV4 {
+	return types.LocationV4{Interior: x1(types.JunctionV4{
+		IsAccountID32:        true,
+		AccountID32NetworkID: types.NewEmptyOption[types.NetworkIDV3](),
+		AccountID:            accountID,
+	})}
+}
+
+// AccountKey20 returns the location of the Ethereum-style account on the chain that interprets it, see AccountID32.
+func AccountKey20(key [20]byte) types.LocationV4 {
+	j := types.JunctionV4{
+		IsAccountKey20:        true,
+		AccountKey20NetworkID: types.NewEmptyOption[types.NetworkIDV3](),
+	}
+
+	for i, b := range key {
+		j.AccountKey[i] = types.U8(b)
+	}
+
+	return types.LocationV4{Interior: x1(j)}
+}
+
+// FungibleAsset returns the amount of the fungible asset with the given location, e.g. Here for the native asset of
+// the sending chain.
+func FungibleAsset(id types.LocationV4, amount *big.Int) types.AssetV4 {
+	return types.AssetV4{
+		ID: id,
+		Fungibility: types.FungibilityV3{
+			IsFungible: true,
+			Amount:     types.NewUCompact(amount),
+		},
+	}
+}
+
+func parachainJunction(paraID uint32) types.JunctionV4 {
+	return types.JunctionV4{IsParachain: true, ParachainID: types.NewUCompactFromUInt(uint64(paraID))}
+}
+
+func x1(j types.JunctionV4) types.JunctionsV4 {
+	return types.JunctionsV4{IsX1: true, X1: j}
+}